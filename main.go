@@ -32,6 +32,7 @@ import (
 	"github.com/kyasbal/khi/pkg/lifecycle"
 	"github.com/kyasbal/khi/pkg/parameters"
 	"github.com/kyasbal/khi/pkg/server"
+	"github.com/kyasbal/khi/pkg/server/preset"
 	"github.com/kyasbal/khi/pkg/server/upload"
 
 	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
@@ -179,6 +180,7 @@ func run() int {
 			ResourceMonitor:  &server.ResourceMonitorImpl{},
 			ServerBasePath:   *parameters.Server.BasePath,
 			UploadFileStore:  upload.DefaultUploadFileStore,
+			PresetStore:      preset.NewPresetStore(*parameters.Common.PresetFolder),
 		}
 		engine, err := server.DefaultServerFactory.CreateInstance(serverMode)
 		if err != nil {
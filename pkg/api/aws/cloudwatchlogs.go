@@ -0,0 +1,212 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	cloudWatchLogsService     = "logs"
+	cloudWatchLogsTargetScope = "Logs_20140328"
+	amzJSONContentType        = "application/x-amz-json-1.1"
+)
+
+// CloudWatchLogsAPI is the subset of Amazon CloudWatch Logs API used in KHI.
+type CloudWatchLogsAPI interface {
+	// FilterLogEvents returns a page of the log events in the log group matching the input.
+	FilterLogEvents(ctx context.Context, input *FilterLogEventsInput) (*FilterLogEventsOutput, error)
+	// DescribeLogStreams returns a page of the log streams in the log group.
+	DescribeLogStreams(ctx context.Context, input *DescribeLogStreamsInput) (*DescribeLogStreamsOutput, error)
+}
+
+// FilterLogEventsInput is the request of the FilterLogEvents API.
+// See https://docs.aws.amazon.com/AmazonCloudWatchLogs/latest/APIReference/API_FilterLogEvents.html
+type FilterLogEventsInput struct {
+	LogGroupName string `json:"logGroupName"`
+	// LogStreamNames and LogStreamNamePrefix can't be specified at once.
+	LogStreamNames      []string `json:"logStreamNames,omitempty"`
+	LogStreamNamePrefix string   `json:"logStreamNamePrefix,omitempty"`
+	// StartTime and EndTime are the milliseconds since the epoch.
+	StartTime     int64  `json:"startTime,omitempty"`
+	EndTime       int64  `json:"endTime,omitempty"`
+	FilterPattern string `json:"filterPattern,omitempty"`
+	Limit         int32  `json:"limit,omitempty"`
+	NextToken     string `json:"nextToken,omitempty"`
+}
+
+// FilteredLogEvent is a log event returned from the FilterLogEvents API.
+type FilteredLogEvent struct {
+	EventID       string `json:"eventId"`
+	LogStreamName string `json:"logStreamName"`
+	Message       string `json:"message"`
+	// Timestamp and IngestionTime are the milliseconds since the epoch.
+	Timestamp     int64 `json:"timestamp"`
+	IngestionTime int64 `json:"ingestionTime"`
+}
+
+// FilterLogEventsOutput is the response of the FilterLogEvents API.
+type FilterLogEventsOutput struct {
+	Events []*FilteredLogEvent `json:"events"`
+	// NextToken is empty when there are no more events to read.
+	NextToken string `json:"nextToken,omitempty"`
+}
+
+// DescribeLogStreamsInput is the request of the DescribeLogStreams API.
+// See https://docs.aws.amazon.com/AmazonCloudWatchLogs/latest/APIReference/API_DescribeLogStreams.html
+type DescribeLogStreamsInput struct {
+	LogGroupName        string `json:"logGroupName"`
+	LogStreamNamePrefix string `json:"logStreamNamePrefix,omitempty"`
+	Limit               int32  `json:"limit,omitempty"`
+	NextToken           string `json:"nextToken,omitempty"`
+}
+
+// LogStream is a log stream returned from the DescribeLogStreams API.
+type LogStream struct {
+	LogStreamName string `json:"logStreamName"`
+	// FirstEventTimestamp and LastEventTimestamp are the milliseconds since the epoch.
+	FirstEventTimestamp int64 `json:"firstEventTimestamp"`
+	LastEventTimestamp  int64 `json:"lastEventTimestamp"`
+}
+
+// DescribeLogStreamsOutput is the response of the DescribeLogStreams API.
+type DescribeLogStreamsOutput struct {
+	LogStreams []*LogStream `json:"logStreams"`
+	NextToken  string       `json:"nextToken,omitempty"`
+}
+
+// APIError is the error returned from AWS APIs using the JSON protocol.
+type APIError struct {
+	StatusCode int
+	// Type is the error type like `ResourceNotFoundException`.
+	Type    string
+	Message string
+}
+
+// Error implements error.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("AWS API returned an error (status: %d, type: %s): %s", e.StatusCode, e.Type, e.Message)
+}
+
+// CloudWatchLogsClient calls Amazon CloudWatch Logs API with the given credentials.
+type CloudWatchLogsClient struct {
+	region      string
+	credentials Credentials
+	endpoint    string
+	httpClient  *http.Client
+}
+
+var _ CloudWatchLogsAPI = (*CloudWatchLogsClient)(nil)
+
+// NewCloudWatchLogsClient returns a CloudWatchLogsClient calling the regional endpoint of the given region.
+func NewCloudWatchLogsClient(region string, credentials Credentials, httpClient *http.Client) *CloudWatchLogsClient {
+	return NewCloudWatchLogsClientWithEndpoint(region, credentials, httpClient, fmt.Sprintf("https://logs.%s.amazonaws.com/", region))
+}
+
+// NewCloudWatchLogsClientWithEndpoint returns a CloudWatchLogsClient calling the given endpoint instead of the regional endpoint.
+func NewCloudWatchLogsClientWithEndpoint(region string, credentials Credentials, httpClient *http.Client, endpoint string) *CloudWatchLogsClient {
+	return &CloudWatchLogsClient{
+		region:      region,
+		credentials: credentials,
+		endpoint:    endpoint,
+		httpClient:  httpClient,
+	}
+}
+
+// FilterLogEvents implements CloudWatchLogsAPI.
+func (c *CloudWatchLogsClient) FilterLogEvents(ctx context.Context, input *FilterLogEventsInput) (*FilterLogEventsOutput, error) {
+	var output FilterLogEventsOutput
+	err := c.call(ctx, "FilterLogEvents", input, &output)
+	if err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// DescribeLogStreams implements CloudWatchLogsAPI.
+func (c *CloudWatchLogsClient) DescribeLogStreams(ctx context.Context, input *DescribeLogStreamsInput) (*DescribeLogStreamsOutput, error) {
+	var output DescribeLogStreamsOutput
+	err := c.call(ctx, "DescribeLogStreams", input, &output)
+	if err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// call sends a signed request for the operation and decodes the response to the output.
+func (c *CloudWatchLogsClient) call(ctx context.Context, operation string, input any, output any) error {
+	body, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("failed to encode the request of %s: %w", operation, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", amzJSONContentType)
+	req.Header.Set("X-Amz-Target", cloudWatchLogsTargetScope+"."+operation)
+	err = SignRequestV4(req, body, c.credentials, c.region, cloudWatchLogsService, time.Now())
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", operation, err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read the response of %s: %w", operation, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return parseAPIError(resp.StatusCode, respBody)
+	}
+	err = json.Unmarshal(respBody, output)
+	if err != nil {
+		return fmt.Errorf("failed to decode the response of %s: %w", operation, err)
+	}
+	return nil
+}
+
+// parseAPIError reads the error returned in the JSON protocol like `{"__type":"com.amazonaws.logs#ResourceNotFoundException","message":"..."}`.
+func parseAPIError(statusCode int, body []byte) error {
+	var errorBody struct {
+		Type         string `json:"__type"`
+		Message      string `json:"message"`
+		MessageUpper string `json:"Message"`
+	}
+	result := &APIError{StatusCode: statusCode}
+	if err := json.Unmarshal(body, &errorBody); err != nil {
+		result.Message = string(body)
+		return result
+	}
+	result.Type = errorBody.Type
+	if i := strings.LastIndex(result.Type, "#"); i != -1 {
+		result.Type = result.Type[i+1:]
+	}
+	result.Message = errorBody.Message
+	if result.Message == "" {
+		result.Message = errorBody.MessageUpper
+	}
+	return result
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCloudWatchLogsClient_FilterLogEvents(t *testing.T) {
+	var gotTarget, gotContentType, gotAuthorization string
+	var gotInput FilterLogEventsInput
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotTarget = r.Header.Get("X-Amz-Target")
+		gotContentType = r.Header.Get("Content-Type")
+		gotAuthorization = r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &gotInput)
+		w.Write([]byte(`{"events":[{"eventId":"event-1","logStreamName":"kube-apiserver-audit-abc","message":"{}","timestamp":1735689600000,"ingestionTime":1735689601000}],"nextToken":"token-2"}`))
+	}))
+	defer server.Close()
+
+	client := NewCloudWatchLogsClientWithEndpoint("us-west-2", Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"}, server.Client(), server.URL)
+	input := &FilterLogEventsInput{
+		LogGroupName:        "/aws/eks/my-cluster/cluster",
+		LogStreamNamePrefix: "kube-apiserver-audit-",
+		StartTime:           1735689600000,
+		EndTime:             1735693200000,
+		NextToken:           "token-1",
+	}
+	got, err := client.FilterLogEvents(context.Background(), input)
+	if err != nil {
+		t.Fatalf("FilterLogEvents() returned an unexpected error: %v", err)
+	}
+
+	want := &FilterLogEventsOutput{
+		Events: []*FilteredLogEvent{
+			{
+				EventID:       "event-1",
+				LogStreamName: "kube-apiserver-audit-abc",
+				Message:       "{}",
+				Timestamp:     1735689600000,
+				IngestionTime: 1735689601000,
+			},
+		},
+		NextToken: "token-2",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("FilterLogEvents() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(*input, gotInput); diff != "" {
+		t.Errorf("request body mismatch (-want +got):\n%s", diff)
+	}
+	if gotTarget != "Logs_20140328.FilterLogEvents" {
+		t.Errorf("X-Amz-Target = %q, want %q", gotTarget, "Logs_20140328.FilterLogEvents")
+	}
+	if gotContentType != "application/x-amz-json-1.1" {
+		t.Errorf("Content-Type = %q, want %q", gotContentType, "application/x-amz-json-1.1")
+	}
+	if !strings.HasPrefix(gotAuthorization, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") || !strings.Contains(gotAuthorization, "/us-west-2/logs/aws4_request") {
+		t.Errorf("Authorization header is not signed for the logs service: %q", gotAuthorization)
+	}
+}
+
+func TestCloudWatchLogsClient_DescribeLogStreams(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Amz-Target"); got != "Logs_20140328.DescribeLogStreams" {
+			t.Errorf("X-Amz-Target = %q, want %q", got, "Logs_20140328.DescribeLogStreams")
+		}
+		w.Write([]byte(`{"logStreams":[{"logStreamName":"kube-scheduler-abc","firstEventTimestamp":1,"lastEventTimestamp":2}]}`))
+	}))
+	defer server.Close()
+
+	client := NewCloudWatchLogsClientWithEndpoint("us-west-2", Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"}, server.Client(), server.URL)
+	got, err := client.DescribeLogStreams(context.Background(), &DescribeLogStreamsInput{LogGroupName: "/aws/eks/my-cluster/cluster"})
+	if err != nil {
+		t.Fatalf("DescribeLogStreams() returned an unexpected error: %v", err)
+	}
+	want := &DescribeLogStreamsOutput{
+		LogStreams: []*LogStream{
+			{LogStreamName: "kube-scheduler-abc", FirstEventTimestamp: 1, LastEventTimestamp: 2},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("DescribeLogStreams() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCloudWatchLogsClient_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"__type":"com.amazonaws.logs#ResourceNotFoundException","message":"The specified log group does not exist."}`))
+	}))
+	defer server.Close()
+
+	client := NewCloudWatchLogsClientWithEndpoint("us-west-2", Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"}, server.Client(), server.URL)
+	_, err := client.FilterLogEvents(context.Background(), &FilterLogEventsInput{LogGroupName: "/aws/eks/missing/cluster"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("FilterLogEvents() error = %v, want *APIError", err)
+	}
+	want := &APIError{
+		StatusCode: http.StatusBadRequest,
+		Type:       "ResourceNotFoundException",
+		Message:    "The specified log group does not exist.",
+	}
+	if diff := cmp.Diff(want, apiErr); diff != "" {
+		t.Errorf("APIError mismatch (-want +got):\n%s", diff)
+	}
+}
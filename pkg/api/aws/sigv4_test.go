@@ -0,0 +1,82 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestSignRequestV4(t *testing.T) {
+	// The example request from the AWS Signature Version 4 documentation.
+	req, err := http.NewRequest(http.MethodGet, "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	credentials := Credentials{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}
+
+	err = SignRequestV4(req, nil, credentials, "us-east-1", "iam", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("SignRequestV4() returned an unexpected error: %v", err)
+	}
+
+	want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, SignedHeaders=content-type;host;x-amz-date, Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Errorf("Authorization header mismatch\nwant: %s\ngot:  %s", want, got)
+	}
+	if got := req.Header.Get("X-Amz-Date"); got != "20150830T123600Z" {
+		t.Errorf("X-Amz-Date header = %q, want %q", got, "20150830T123600Z")
+	}
+}
+
+func TestSignRequestV4WithSessionToken(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "https://logs.us-west-2.amazonaws.com/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	credentials := Credentials{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+		SessionToken:    "session-token",
+	}
+
+	err = SignRequestV4(req, []byte("{}"), credentials, "us-west-2", "logs", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("SignRequestV4() returned an unexpected error: %v", err)
+	}
+	if got := req.Header.Get("X-Amz-Security-Token"); got != "session-token" {
+		t.Errorf("X-Amz-Security-Token header = %q, want %q", got, "session-token")
+	}
+	wantPrefix := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-west-2/logs/aws4_request, SignedHeaders=host;x-amz-date;x-amz-security-token, Signature="
+	if got := req.Header.Get("Authorization"); len(got) <= len(wantPrefix) || got[:len(wantPrefix)] != wantPrefix {
+		t.Errorf("Authorization header = %q, want prefix %q", got, wantPrefix)
+	}
+}
+
+func TestSignRequestV4WithoutCredentials(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "https://logs.us-west-2.amazonaws.com/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = SignRequestV4(req, nil, Credentials{}, "us-west-2", "logs", time.Now())
+	if err == nil {
+		t.Errorf("SignRequestV4() returned nil error for empty credentials")
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// LogAnalyticsScope is the OAuth 2.0 scope of tokens to call the Log Analytics query API.
+const LogAnalyticsScope = "https://api.loganalytics.io/.default"
+
+// LogAnalyticsAPI is the subset of Azure Monitor Log Analytics query API used in KHI.
+type LogAnalyticsAPI interface {
+	// Query runs the KQL query on the workspace and returns the result tables.
+	Query(ctx context.Context, input *QueryInput) (*QueryOutput, error)
+}
+
+// QueryInput is the request of the query API.
+// See https://learn.microsoft.com/en-us/rest/api/loganalytics/dataaccess/query/execute
+type QueryInput struct {
+	// WorkspaceID is the workspace ID (customer ID) of the Log Analytics workspace in the GUID form.
+	WorkspaceID string
+	// Query is the query in Kusto Query Language (KQL).
+	Query     string
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// QueryOutput is the response of the query API.
+type QueryOutput struct {
+	Tables []*Table `json:"tables"`
+	// Error is set when the query returned partial results, e.g. the result exceeded the limits of the query API.
+	Error *QueryError `json:"error,omitempty"`
+}
+
+// QueryError is the error returned with partial results of the query API.
+type QueryError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Column is a column of a result table.
+type Column struct {
+	Name string `json:"name"`
+	// Type is the KQL data type like `string`, `datetime` or `dynamic`.
+	Type string `json:"type"`
+}
+
+// Table is a result table of the query API.
+type Table struct {
+	Name    string   `json:"name"`
+	Columns []Column `json:"columns"`
+	Rows    [][]any  `json:"rows"`
+}
+
+// Records returns the rows as maps keyed by the column names.
+// Values of `dynamic` columns are returned as JSON encoded strings from the API and they are decoded when the value is a valid JSON.
+func (t *Table) Records() []map[string]any {
+	result := make([]map[string]any, 0, len(t.Rows))
+	for _, row := range t.Rows {
+		record := make(map[string]any, len(t.Columns))
+		for i, column := range t.Columns {
+			if i >= len(row) {
+				break
+			}
+			value := row[i]
+			if str, ok := value.(string); ok && column.Type == "dynamic" {
+				var decoded any
+				if err := json.Unmarshal([]byte(str), &decoded); err == nil {
+					value = decoded
+				}
+			}
+			record[column.Name] = value
+		}
+		result = append(result, record)
+	}
+	return result
+}
+
+// APIError is the error returned from Azure APIs.
+type APIError struct {
+	StatusCode int
+	// Code is the error code like `PathNotFoundError` or `invalid_client`.
+	Code    string
+	Message string
+}
+
+// Error implements error.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("Azure API returned an error (status: %d, code: %s): %s", e.StatusCode, e.Code, e.Message)
+}
+
+// LogAnalyticsClient calls Azure Monitor Log Analytics query API with the tokens from the TokenSource.
+type LogAnalyticsClient struct {
+	tokenSource TokenSource
+	endpoint    string
+	httpClient  *http.Client
+}
+
+var _ LogAnalyticsAPI = (*LogAnalyticsClient)(nil)
+
+// NewLogAnalyticsClient returns a LogAnalyticsClient calling the public endpoint of the query API.
+func NewLogAnalyticsClient(tokenSource TokenSource, httpClient *http.Client) *LogAnalyticsClient {
+	return NewLogAnalyticsClientWithEndpoint(tokenSource, httpClient, "https://api.loganalytics.io/")
+}
+
+// NewLogAnalyticsClientWithEndpoint returns a LogAnalyticsClient calling the given endpoint instead of the public endpoint.
+func NewLogAnalyticsClientWithEndpoint(tokenSource TokenSource, httpClient *http.Client, endpoint string) *LogAnalyticsClient {
+	return &LogAnalyticsClient{
+		tokenSource: tokenSource,
+		endpoint:    strings.TrimSuffix(endpoint, "/"),
+		httpClient:  httpClient,
+	}
+}
+
+// Query implements LogAnalyticsAPI.
+func (c *LogAnalyticsClient) Query(ctx context.Context, input *QueryInput) (*QueryOutput, error) {
+	body, err := json.Marshal(map[string]string{
+		"query":    input.Query,
+		"timespan": fmt.Sprintf("%s/%s", input.StartTime.UTC().Format(time.RFC3339Nano), input.EndTime.UTC().Format(time.RFC3339Nano)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the query request: %w", err)
+	}
+	token, err := c.tokenSource.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	queryURL := fmt.Sprintf("%s/v1/workspaces/%s/query", c.endpoint, url.PathEscape(input.WorkspaceID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, queryURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call the query API: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the response of the query API: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseAPIError(resp.StatusCode, respBody)
+	}
+	var output QueryOutput
+	err = json.Unmarshal(respBody, &output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the response of the query API: %w", err)
+	}
+	return &output, nil
+}
+
+// parseAPIError reads the error returned like `{"error":{"code":"PathNotFoundError","message":"..."}}`.
+func parseAPIError(statusCode int, body []byte) error {
+	var errorBody struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &errorBody); err != nil || errorBody.Error.Code == "" {
+		return &APIError{StatusCode: statusCode, Message: string(body)}
+	}
+	return &APIError{StatusCode: statusCode, Code: errorBody.Error.Code, Message: errorBody.Error.Message}
+}
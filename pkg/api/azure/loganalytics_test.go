@@ -0,0 +1,125 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type staticTokenSource string
+
+// Token implements TokenSource.
+func (s staticTokenSource) Token(ctx context.Context) (string, error) {
+	return string(s), nil
+}
+
+func TestLogAnalyticsClient_Query(t *testing.T) {
+	var gotPath, gotAuthorization string
+	var gotBody map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuthorization = r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &gotBody)
+		w.Write([]byte(`{"tables":[{"name":"PrimaryResult","columns":[{"name":"TimeGenerated","type":"datetime"},{"name":"Category","type":"string"}],"rows":[["2025-01-01T00:00:00Z","kube-scheduler"]]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewLogAnalyticsClientWithEndpoint(staticTokenSource("my-token"), server.Client(), server.URL)
+	got, err := client.Query(context.Background(), &QueryInput{
+		WorkspaceID: "00000000-0000-0000-0000-000000000000",
+		Query:       "AzureDiagnostics | take 1",
+		StartTime:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:     time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Query() returned an unexpected error: %v", err)
+	}
+
+	want := &QueryOutput{
+		Tables: []*Table{
+			{
+				Name:    "PrimaryResult",
+				Columns: []Column{{Name: "TimeGenerated", Type: "datetime"}, {Name: "Category", Type: "string"}},
+				Rows:    [][]any{{"2025-01-01T00:00:00Z", "kube-scheduler"}},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Query() mismatch (-want +got):\n%s", diff)
+	}
+	wantBody := map[string]string{
+		"query":    "AzureDiagnostics | take 1",
+		"timespan": "2025-01-01T00:00:00Z/2025-01-01T01:00:00Z",
+	}
+	if diff := cmp.Diff(wantBody, gotBody); diff != "" {
+		t.Errorf("request body mismatch (-want +got):\n%s", diff)
+	}
+	if gotPath != "/v1/workspaces/00000000-0000-0000-0000-000000000000/query" {
+		t.Errorf("path = %q, want %q", gotPath, "/v1/workspaces/00000000-0000-0000-0000-000000000000/query")
+	}
+	if gotAuthorization != "Bearer my-token" {
+		t.Errorf("Authorization = %q, want %q", gotAuthorization, "Bearer my-token")
+	}
+}
+
+func TestLogAnalyticsClient_QueryError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"message":"Failed to resolve table expression named 'ContainerLogV2'","code":"BadArgumentError"}}`))
+	}))
+	defer server.Close()
+
+	client := NewLogAnalyticsClientWithEndpoint(staticTokenSource("my-token"), server.Client(), server.URL)
+	_, err := client.Query(context.Background(), &QueryInput{WorkspaceID: "workspace", Query: "ContainerLogV2"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Query() error = %v, want *APIError", err)
+	}
+	want := &APIError{StatusCode: http.StatusBadRequest, Code: "BadArgumentError", Message: "Failed to resolve table expression named 'ContainerLogV2'"}
+	if diff := cmp.Diff(want, apiErr); diff != "" {
+		t.Errorf("Query() error mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestTable_Records(t *testing.T) {
+	table := &Table{
+		Columns: []Column{
+			{Name: "PodName", Type: "string"},
+			{Name: "LogMessage", Type: "dynamic"},
+			{Name: "Raw", Type: "string"},
+		},
+		Rows: [][]any{
+			{"pod-a", `{"msg":"hello"}`, `{"kept":"as string"}`},
+			{"pod-b", "plain text", nil},
+		},
+	}
+	want := []map[string]any{
+		{"PodName": "pod-a", "LogMessage": map[string]any{"msg": "hello"}, "Raw": `{"kept":"as string"}`},
+		{"PodName": "pod-b", "LogMessage": "plain text", "Raw": nil},
+	}
+	if diff := cmp.Diff(want, table.Records()); diff != "" {
+		t.Errorf("Records() mismatch (-want +got):\n%s", diff)
+	}
+}
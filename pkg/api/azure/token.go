@@ -0,0 +1,128 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenExpiryMargin is the duration before the expiry when a cached token is refreshed.
+const tokenExpiryMargin = time.Minute
+
+// TokenSource provides the bearer tokens to call Azure APIs.
+type TokenSource interface {
+	// Token returns a valid access token.
+	Token(ctx context.Context) (string, error)
+}
+
+// ClientCredentials is the credentials of a Microsoft Entra ID application (service principal) with a client secret.
+type ClientCredentials struct {
+	TenantID     string
+	ClientID     string
+	ClientSecret string
+}
+
+// ClientCredentialsTokenSource is a TokenSource obtaining tokens with the OAuth 2.0 client credentials flow.
+// Obtained tokens are cached until shortly before they expire.
+type ClientCredentialsTokenSource struct {
+	credentials   ClientCredentials
+	scope         string
+	authorityHost string
+	httpClient    *http.Client
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+var _ TokenSource = (*ClientCredentialsTokenSource)(nil)
+
+// NewClientCredentialsTokenSource returns a ClientCredentialsTokenSource obtaining tokens of the given scope from the Microsoft Entra ID global endpoint.
+func NewClientCredentialsTokenSource(credentials ClientCredentials, scope string, httpClient *http.Client) *ClientCredentialsTokenSource {
+	return NewClientCredentialsTokenSourceWithAuthorityHost(credentials, scope, httpClient, "https://login.microsoftonline.com/")
+}
+
+// NewClientCredentialsTokenSourceWithAuthorityHost returns a ClientCredentialsTokenSource obtaining tokens from the given authority host instead of the global endpoint.
+func NewClientCredentialsTokenSourceWithAuthorityHost(credentials ClientCredentials, scope string, httpClient *http.Client, authorityHost string) *ClientCredentialsTokenSource {
+	return &ClientCredentialsTokenSource{
+		credentials:   credentials,
+		scope:         scope,
+		authorityHost: strings.TrimSuffix(authorityHost, "/"),
+		httpClient:    httpClient,
+	}
+}
+
+// Token implements TokenSource.
+func (c *ClientCredentialsTokenSource) Token(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token != "" && time.Now().Add(tokenExpiryMargin).Before(c.expiresAt) {
+		return c.token, nil
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("client_id", c.credentials.ClientID)
+	form.Set("client_secret", c.credentials.ClientSecret)
+	form.Set("scope", c.scope)
+	tokenURL := fmt.Sprintf("%s/%s/oauth2/v2.0/token", c.authorityHost, url.PathEscape(c.credentials.TenantID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to obtain an access token: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var errorBody struct {
+			Error            string `json:"error"`
+			ErrorDescription string `json:"error_description"`
+		}
+		if err := json.Unmarshal(body, &errorBody); err != nil || errorBody.Error == "" {
+			return "", &APIError{StatusCode: resp.StatusCode, Message: string(body)}
+		}
+		return "", &APIError{StatusCode: resp.StatusCode, Code: errorBody.Error, Message: errorBody.ErrorDescription}
+	}
+	var tokenBody struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	err = json.Unmarshal(body, &tokenBody)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode the token response: %w", err)
+	}
+	if tokenBody.AccessToken == "" {
+		return "", fmt.Errorf("the token response contained no access token")
+	}
+	c.token = tokenBody.AccessToken
+	c.expiresAt = time.Now().Add(time.Duration(tokenBody.ExpiresIn) * time.Second)
+	return c.token, nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientCredentialsTokenSource(t *testing.T) {
+	requestCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		if r.URL.Path != "/my-tenant/oauth2/v2.0/token" {
+			t.Errorf("path = %q, want %q", r.URL.Path, "/my-tenant/oauth2/v2.0/token")
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		wantForm := map[string]string{
+			"grant_type":    "client_credentials",
+			"client_id":     "my-client",
+			"client_secret": "my-secret",
+			"scope":         LogAnalyticsScope,
+		}
+		for key, want := range wantForm {
+			if got := r.PostForm.Get(key); got != want {
+				t.Errorf("form value %s = %q, want %q", key, got, want)
+			}
+		}
+		w.Write([]byte(`{"token_type":"Bearer","expires_in":3599,"access_token":"token-1"}`))
+	}))
+	defer server.Close()
+
+	source := NewClientCredentialsTokenSourceWithAuthorityHost(ClientCredentials{TenantID: "my-tenant", ClientID: "my-client", ClientSecret: "my-secret"}, LogAnalyticsScope, server.Client(), server.URL)
+	for i := 0; i < 2; i++ {
+		token, err := source.Token(context.Background())
+		if err != nil {
+			t.Fatalf("Token() returned an unexpected error: %v", err)
+		}
+		if token != "token-1" {
+			t.Errorf("Token() = %q, want %q", token, "token-1")
+		}
+	}
+	if requestCount != 1 {
+		t.Errorf("token endpoint was called %d times, want 1 with the cached token", requestCount)
+	}
+}
+
+func TestClientCredentialsTokenSource_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":"invalid_client","error_description":"Invalid client secret provided."}`))
+	}))
+	defer server.Close()
+
+	source := NewClientCredentialsTokenSourceWithAuthorityHost(ClientCredentials{TenantID: "my-tenant", ClientID: "my-client", ClientSecret: "wrong"}, LogAnalyticsScope, server.Client(), server.URL)
+	_, err := source.Token(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Token() error = %v, want *APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusUnauthorized || apiErr.Code != "invalid_client" || apiErr.Message != "Invalid client secret provided." {
+		t.Errorf("Token() error = %+v, want the status 401 with the code invalid_client", apiErr)
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// SearchAPI is the subset of Elasticsearch and OpenSearch REST API used in KHI.
+// Documents are read with the scroll API supported by both of them.
+type SearchAPI interface {
+	// Search runs the search and returns the first page with the scroll ID to read the following pages.
+	Search(ctx context.Context, input *SearchInput) (*SearchOutput, error)
+	// Scroll returns the next page of the search.
+	Scroll(ctx context.Context, scrollID string, keepAlive time.Duration) (*SearchOutput, error)
+	// ClearScroll releases the search context of the scroll.
+	ClearScroll(ctx context.Context, scrollID string) error
+}
+
+// SearchInput is the request of the search API.
+// See https://www.elastic.co/guide/en/elasticsearch/reference/current/search-search.html
+type SearchInput struct {
+	// Index is the index name or the index pattern like `kube-audit-*`.
+	Index string
+	// Query is the query DSL given in the `query` field of the request body.
+	Query json.RawMessage
+	// Sort is given in the `sort` field of the request body.
+	Sort []map[string]string
+	// Size is the count of hits returned in a page.
+	Size int
+	// KeepAlive is the duration to keep the search context for the scroll.
+	KeepAlive time.Duration
+}
+
+// Hit is a document matched with the query.
+type Hit struct {
+	Index  string          `json:"_index"`
+	ID     string          `json:"_id"`
+	Source json.RawMessage `json:"_source"`
+}
+
+// SearchOutput is a page of the search result.
+type SearchOutput struct {
+	// ScrollID is the ID to read the next page.
+	ScrollID string
+	Hits     []*Hit
+	// TotalHits is the count of all the documents matched with the query.
+	TotalHits int64
+}
+
+// APIError is the error returned from Elasticsearch or OpenSearch.
+type APIError struct {
+	StatusCode int
+	// Type is the error type like `index_not_found_exception`.
+	Type   string
+	Reason string
+}
+
+// Error implements error.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("search API returned an error (status: %d, type: %s): %s", e.StatusCode, e.Type, e.Reason)
+}
+
+// Auth is the authentication used to call the REST API.
+// Basic authentication is used when Username is given. Otherwise Password is sent as an API key of Elasticsearch when it's given.
+type Auth struct {
+	Username string
+	Password string
+}
+
+// Client calls the REST API of Elasticsearch or OpenSearch.
+type Client struct {
+	endpoint   string
+	auth       Auth
+	httpClient *http.Client
+}
+
+var _ SearchAPI = (*Client)(nil)
+
+// NewClient returns a Client calling the REST API served at the given URL.
+func NewClient(endpoint string, auth Auth, httpClient *http.Client) *Client {
+	return &Client{
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		auth:       auth,
+		httpClient: httpClient,
+	}
+}
+
+// Search implements SearchAPI.
+func (c *Client) Search(ctx context.Context, input *SearchInput) (*SearchOutput, error) {
+	body := map[string]any{
+		"size": input.Size,
+	}
+	if len(input.Query) > 0 {
+		body["query"] = input.Query
+	}
+	if len(input.Sort) > 0 {
+		body["sort"] = input.Sort
+	}
+	path := fmt.Sprintf("/%s/_search?scroll=%s", url.PathEscape(input.Index), keepAliveParameter(input.KeepAlive))
+	return c.callSearch(ctx, http.MethodPost, path, body)
+}
+
+// Scroll implements SearchAPI.
+func (c *Client) Scroll(ctx context.Context, scrollID string, keepAlive time.Duration) (*SearchOutput, error) {
+	return c.callSearch(ctx, http.MethodPost, "/_search/scroll", map[string]any{
+		"scroll":    keepAliveParameter(keepAlive),
+		"scroll_id": scrollID,
+	})
+}
+
+// ClearScroll implements SearchAPI.
+func (c *Client) ClearScroll(ctx context.Context, scrollID string) error {
+	_, err := c.call(ctx, http.MethodDelete, "/_search/scroll", map[string]any{
+		"scroll_id": scrollID,
+	})
+	return err
+}
+
+// keepAliveParameter returns the duration in the time unit format of the REST API like `60s`.
+func keepAliveParameter(keepAlive time.Duration) string {
+	return fmt.Sprintf("%ds", int64(keepAlive.Seconds()))
+}
+
+// callSearch calls the search or the scroll API and decodes the page.
+func (c *Client) callSearch(ctx context.Context, method string, path string, body any) (*SearchOutput, error) {
+	respBody, err := c.call(ctx, method, path, body)
+	if err != nil {
+		return nil, err
+	}
+	var response struct {
+		ScrollID string `json:"_scroll_id"`
+		Hits     struct {
+			// Total is an object like `{"value": 10, "relation": "eq"}` in Elasticsearch 7 or later and OpenSearch, and a number in older versions.
+			Total json.RawMessage `json:"total"`
+			Hits  []*Hit          `json:"hits"`
+		} `json:"hits"`
+	}
+	err = json.Unmarshal(respBody, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the search response: %w", err)
+	}
+	output := &SearchOutput{
+		ScrollID: response.ScrollID,
+		Hits:     response.Hits.Hits,
+	}
+	var totalObject struct {
+		Value int64 `json:"value"`
+	}
+	if err := json.Unmarshal(response.Hits.Total, &totalObject); err == nil {
+		output.TotalHits = totalObject.Value
+	} else {
+		json.Unmarshal(response.Hits.Total, &output.TotalHits)
+	}
+	return output, nil
+}
+
+// call sends the request with the JSON body and returns the response body.
+func (c *Client) call(ctx context.Context, method string, path string, body any) ([]byte, error) {
+	reqBody, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	switch {
+	case c.auth.Username != "":
+		req.SetBasicAuth(c.auth.Username, c.auth.Password)
+	case c.auth.Password != "":
+		req.Header.Set("Authorization", "ApiKey "+c.auth.Password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the response of %s %s: %w", method, path, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseAPIError(resp.StatusCode, respBody)
+	}
+	return respBody, nil
+}
+
+// parseAPIError reads the error returned like `{"error":{"type":"index_not_found_exception","reason":"..."},"status":404}`.
+func parseAPIError(statusCode int, body []byte) error {
+	var errorBody struct {
+		Error struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &errorBody); err != nil || errorBody.Error.Type == "" {
+		return &APIError{StatusCode: statusCode, Reason: strings.TrimSpace(string(body))}
+	}
+	return &APIError{StatusCode: statusCode, Type: errorBody.Error.Type, Reason: errorBody.Error.Reason}
+}
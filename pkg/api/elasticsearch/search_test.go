@@ -0,0 +1,158 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_SearchAndScroll(t *testing.T) {
+	type request struct {
+		Method        string
+		Path          string
+		RawQuery      string
+		Body          map[string]any
+		Authorization string
+	}
+	var gotRequests []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var decoded map[string]any
+		json.Unmarshal(body, &decoded)
+		gotRequests = append(gotRequests, request{Method: r.Method, Path: r.URL.EscapedPath(), RawQuery: r.URL.RawQuery, Body: decoded, Authorization: r.Header.Get("Authorization")})
+		switch r.URL.Path {
+		case "/kube-audit-*/_search":
+			w.Write([]byte(`{"_scroll_id":"scroll-1","hits":{"total":{"value":2,"relation":"eq"},"hits":[{"_index":"kube-audit-2025.01.01","_id":"doc-1","_source":{"auditID":"audit-1"}}]}}`))
+		case "/_search/scroll":
+			if r.Method == http.MethodDelete {
+				w.Write([]byte(`{"succeeded":true,"num_freed":1}`))
+				return
+			}
+			w.Write([]byte(`{"_scroll_id":"scroll-2","hits":{"total":2,"hits":[{"_index":"kube-audit-2025.01.01","_id":"doc-2","_source":{"auditID":"audit-2"}}]}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/", Auth{Password: "api-key"}, server.Client())
+	first, err := client.Search(context.Background(), &SearchInput{
+		Index:     "kube-audit-*",
+		Query:     json.RawMessage(`{"match_all":{}}`),
+		Sort:      []map[string]string{{"@timestamp": "asc"}},
+		Size:      100,
+		KeepAlive: time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("Search() returned an unexpected error: %v", err)
+	}
+	second, err := client.Scroll(context.Background(), first.ScrollID, time.Minute)
+	if err != nil {
+		t.Fatalf("Scroll() returned an unexpected error: %v", err)
+	}
+	err = client.ClearScroll(context.Background(), second.ScrollID)
+	if err != nil {
+		t.Fatalf("ClearScroll() returned an unexpected error: %v", err)
+	}
+
+	wantFirst := &SearchOutput{
+		ScrollID:  "scroll-1",
+		Hits:      []*Hit{{Index: "kube-audit-2025.01.01", ID: "doc-1", Source: json.RawMessage(`{"auditID":"audit-1"}`)}},
+		TotalHits: 2,
+	}
+	if diff := cmp.Diff(wantFirst, first); diff != "" {
+		t.Errorf("Search() mismatch (-want +got):\n%s", diff)
+	}
+	wantSecond := &SearchOutput{
+		ScrollID:  "scroll-2",
+		Hits:      []*Hit{{Index: "kube-audit-2025.01.01", ID: "doc-2", Source: json.RawMessage(`{"auditID":"audit-2"}`)}},
+		TotalHits: 2,
+	}
+	if diff := cmp.Diff(wantSecond, second); diff != "" {
+		t.Errorf("Scroll() mismatch (-want +got):\n%s", diff)
+	}
+	wantRequests := []request{
+		{
+			Method:   http.MethodPost,
+			Path:     "/kube-audit-%2A/_search",
+			RawQuery: "scroll=60s",
+			Body: map[string]any{
+				"size":  float64(100),
+				"query": map[string]any{"match_all": map[string]any{}},
+				"sort":  []any{map[string]any{"@timestamp": "asc"}},
+			},
+			Authorization: "ApiKey api-key",
+		},
+		{
+			Method:        http.MethodPost,
+			Path:          "/_search/scroll",
+			Body:          map[string]any{"scroll": "60s", "scroll_id": "scroll-1"},
+			Authorization: "ApiKey api-key",
+		},
+		{
+			Method:        http.MethodDelete,
+			Path:          "/_search/scroll",
+			Body:          map[string]any{"scroll_id": "scroll-2"},
+			Authorization: "ApiKey api-key",
+		},
+	}
+	if diff := cmp.Diff(wantRequests, gotRequests); diff != "" {
+		t.Errorf("requests mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestClient_BasicAuth(t *testing.T) {
+	var gotUsername, gotPassword string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUsername, gotPassword, _ = r.BasicAuth()
+		w.Write([]byte(`{"_scroll_id":"scroll-1","hits":{"total":{"value":0},"hits":[]}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, Auth{Username: "elastic", Password: "changeme"}, server.Client())
+	_, err := client.Search(context.Background(), &SearchInput{Index: "logs", Size: 10, KeepAlive: time.Minute})
+	if err != nil {
+		t.Fatalf("Search() returned an unexpected error: %v", err)
+	}
+	if gotUsername != "elastic" || gotPassword != "changeme" {
+		t.Errorf("BasicAuth() = (%q, %q), want (elastic, changeme)", gotUsername, gotPassword)
+	}
+}
+
+func TestClient_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":{"root_cause":[],"type":"index_not_found_exception","reason":"no such index [kube-audit]"},"status":404}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, Auth{}, server.Client())
+	_, err := client.Search(context.Background(), &SearchInput{Index: "kube-audit", Size: 10, KeepAlive: time.Minute})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Search() error = %v, want *APIError", err)
+	}
+	want := &APIError{StatusCode: http.StatusNotFound, Type: "index_not_found_exception", Reason: "no such index [kube-audit]"}
+	if diff := cmp.Diff(want, apiErr); diff != "" {
+		t.Errorf("Search() error mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apirecord
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// RecordingUnaryClientInterceptor returns a grpc.UnaryClientInterceptor recording the responses of unary calls to the store.
+// Failed calls are also recorded with their status unless the context was canceled.
+func RecordingUnaryClientInterceptor(store *Store) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		callErr := invoker(ctx, method, req, reply, cc, opts...)
+		if ctx.Err() != nil {
+			return callErr
+		}
+		if err := recordGRPCInteraction(ctx, store, method, req, reply, callErr); err != nil {
+			slog.WarnContext(ctx, fmt.Sprintf("failed to record the response of %s: %v", method, err))
+		}
+		return callErr
+	}
+}
+
+// ReplayingUnaryClientInterceptor returns a grpc.UnaryClientInterceptor returning the responses recorded in the store without sending requests.
+func ReplayingUnaryClientInterceptor(store *Store) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		key, err := grpcInteractionKey(method, req)
+		if err != nil {
+			return err
+		}
+		interaction, err := store.Load(key)
+		if err != nil {
+			return fmt.Errorf("failed to replay %s: %w", method, err)
+		}
+		if codes.Code(interaction.GRPCCode) != codes.OK {
+			return status.Error(codes.Code(interaction.GRPCCode), interaction.ErrorMessage)
+		}
+		replyMessage, ok := reply.(proto.Message)
+		if !ok {
+			return fmt.Errorf("failed to replay %s: the reply %T is not a proto message", method, reply)
+		}
+		return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal([]byte(interaction.ResponseBody), replyMessage)
+	}
+}
+
+// ReplayingStreamClientInterceptor returns a grpc.StreamClientInterceptor rejecting streaming calls because they can't be replayed.
+func ReplayingStreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return nil, status.Errorf(codes.Unimplemented, "streaming call %s is not supported in the replay mode", method)
+	}
+}
+
+func recordGRPCInteraction(ctx context.Context, store *Store, method string, req, reply any, callErr error) error {
+	key, err := grpcInteractionKey(method, req)
+	if err != nil {
+		return err
+	}
+	requestBody, err := protojson.Marshal(req.(proto.Message))
+	if err != nil {
+		return err
+	}
+	interaction := &Interaction{
+		Request:     method,
+		RequestBody: string(sanitizeJSON(requestBody)),
+	}
+	if callErr != nil {
+		callStatus := status.Convert(callErr)
+		interaction.GRPCCode = int(callStatus.Code())
+		interaction.ErrorMessage = callStatus.Message()
+	} else {
+		replyMessage, ok := reply.(proto.Message)
+		if !ok {
+			return fmt.Errorf("the reply %T is not a proto message", reply)
+		}
+		responseBody, err := protojson.Marshal(replyMessage)
+		if err != nil {
+			return err
+		}
+		interaction.ResponseBody = string(sanitizeJSON(responseBody))
+	}
+	return store.Save(ctx, key, interaction)
+}
+
+// grpcInteractionKey returns the key identifying the gRPC request.
+// The request is serialized deterministically because the output of protojson is intentionally unstable.
+func grpcInteractionKey(method string, req any) (string, error) {
+	requestMessage, ok := req.(proto.Message)
+	if !ok {
+		return "", fmt.Errorf("the request %T is not a proto message", req)
+	}
+	requestBody, err := proto.MarshalOptions{Deterministic: true}.Marshal(requestMessage)
+	if err != nil {
+		return "", err
+	}
+	return interactionKey(method, requestBody), nil
+}
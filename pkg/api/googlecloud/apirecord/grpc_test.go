@@ -0,0 +1,101 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apirecord
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestRecordingAndReplayingUnaryClientInterceptor(t *testing.T) {
+	const method = "/google.logging.v2.LoggingServiceV2/ListLogEntries"
+	testCases := []struct {
+		name      string
+		request   *loggingpb.ListLogEntriesRequest
+		response  *loggingpb.ListLogEntriesResponse
+		invokeErr error
+	}{
+		{
+			name:     "successful response",
+			request:  &loggingpb.ListLogEntriesRequest{ResourceNames: []string{"projects/foo"}, Filter: `resource.type="k8s_cluster"`},
+			response: &loggingpb.ListLogEntriesResponse{Entries: []*loggingpb.LogEntry{{InsertId: "foo"}}, NextPageToken: "next"},
+		},
+		{
+			name:      "error response",
+			request:   &loggingpb.ListLogEntriesRequest{ResourceNames: []string{"projects/foo"}, Filter: "invalid"},
+			invokeErr: status.Error(codes.InvalidArgument, "invalid filter"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			store, err := NewStore(t.TempDir())
+			if err != nil {
+				t.Fatalf("NewStore() returned an unexpected error: %v", err)
+			}
+			invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				if tc.invokeErr != nil {
+					return tc.invokeErr
+				}
+				proto.Merge(reply.(proto.Message), tc.response)
+				return nil
+			}
+			recorded := &loggingpb.ListLogEntriesResponse{}
+			recordErr := RecordingUnaryClientInterceptor(store)(ctx, method, tc.request, recorded, nil, invoker)
+			if !errors.Is(recordErr, tc.invokeErr) {
+				t.Errorf("recording interceptor returned %v, want %v", recordErr, tc.invokeErr)
+			}
+
+			unexpectedInvoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				t.Errorf("the replaying interceptor invoked the call")
+				return nil
+			}
+			replayed := &loggingpb.ListLogEntriesResponse{}
+			replayErr := ReplayingUnaryClientInterceptor(store)(ctx, method, proto.Clone(tc.request), replayed, nil, unexpectedInvoker)
+			if tc.invokeErr != nil {
+				if status.Code(replayErr) != status.Code(tc.invokeErr) || status.Convert(replayErr).Message() != status.Convert(tc.invokeErr).Message() {
+					t.Errorf("replaying interceptor returned %v, want %v", replayErr, tc.invokeErr)
+				}
+				return
+			}
+			if replayErr != nil {
+				t.Fatalf("replaying interceptor returned an unexpected error: %v", replayErr)
+			}
+			if diff := cmp.Diff(tc.response, replayed, protocmp.Transform()); diff != "" {
+				t.Errorf("replayed response mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReplayingUnaryClientInterceptorWithoutRecord(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore() returned an unexpected error: %v", err)
+	}
+	err = ReplayingUnaryClientInterceptor(store)(context.Background(), "/foo", &loggingpb.ListLogEntriesRequest{}, &loggingpb.ListLogEntriesResponse{}, nil, nil)
+	if !errors.Is(err, ErrInteractionNotFound) {
+		t.Errorf("ReplayingUnaryClientInterceptor() returned %v, want ErrInteractionNotFound", err)
+	}
+}
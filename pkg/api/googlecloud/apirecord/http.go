@@ -0,0 +1,133 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apirecord
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// sensitiveQueryParameters is the set of query parameters removed from the recorded request URLs.
+var sensitiveQueryParameters = []string{"key", "access_token"}
+
+// recordingTransport is a http.RoundTripper writing the responses given from the base transport to the store.
+type recordingTransport struct {
+	base  http.RoundTripper
+	store *Store
+}
+
+// NewRecordingTransport returns a http.RoundTripper sending requests with the base transport and recording their responses to the store.
+func NewRecordingTransport(base http.RoundTripper, store *Store) http.RoundTripper {
+	return &recordingTransport{base: base, store: store}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	if requestBody != nil {
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(requestBody))
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	request := httpRequestDescription(req)
+	sanitizedRequestBody := sanitizeJSON(requestBody)
+	err = t.store.Save(req.Context(), interactionKey(request, sanitizedRequestBody), &Interaction{
+		Request:      request,
+		RequestBody:  string(sanitizedRequestBody),
+		StatusCode:   resp.StatusCode,
+		ContentType:  resp.Header.Get("Content-Type"),
+		ResponseBody: string(sanitizeJSON(responseBody)),
+	})
+	if err != nil {
+		slog.WarnContext(req.Context(), fmt.Sprintf("failed to record the response of %s: %v", request, err))
+	}
+	return resp, nil
+}
+
+// replayingTransport is a http.RoundTripper returning the responses recorded in the store without sending requests.
+type replayingTransport struct {
+	store *Store
+}
+
+// NewReplayingTransport returns a http.RoundTripper returning the responses recorded in the store.
+func NewReplayingTransport(store *Store) http.RoundTripper {
+	return &replayingTransport{store: store}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *replayingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	request := httpRequestDescription(req)
+	interaction, err := t.store.Load(interactionKey(request, sanitizeJSON(requestBody)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to replay %s: %w", request, err)
+	}
+	header := http.Header{}
+	if interaction.ContentType != "" {
+		header.Set("Content-Type", interaction.ContentType)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.StatusCode, http.StatusText(interaction.StatusCode)),
+		StatusCode:    interaction.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(interaction.ResponseBody)),
+		ContentLength: int64(len(interaction.ResponseBody)),
+		Request:       req,
+	}, nil
+}
+
+// readRequestBody reads and closes the body of the request. It returns nil when the request has no body.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	defer req.Body.Close()
+	return io.ReadAll(req.Body)
+}
+
+// httpRequestDescription returns the method and URL of the request without the sensitive query parameters.
+func httpRequestDescription(req *http.Request) string {
+	u := *req.URL
+	query := u.Query()
+	for _, parameter := range sensitiveQueryParameters {
+		query.Del(parameter)
+	}
+	u.RawQuery = query.Encode()
+	u.User = nil
+	return req.Method + " " + u.String()
+}
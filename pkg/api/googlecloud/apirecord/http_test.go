@@ -0,0 +1,107 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apirecord
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRecordingAndReplayingTransport(t *testing.T) {
+	requestCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		w.Write([]byte(`{"path":"` + r.URL.Path + `","body":` + string(body) + `,"accessToken":"secret"}`))
+	}))
+	defer server.Close()
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore() returned an unexpected error: %v", err)
+	}
+	recordingClient := &http.Client{Transport: NewRecordingTransport(http.DefaultTransport, store)}
+	replayingClient := &http.Client{Transport: NewReplayingTransport(store)}
+
+	testCases := []struct {
+		name           string
+		path           string
+		body           string
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:           "successful response",
+			path:           "/foo?key=api-key",
+			body:           `{"filter":"foo"}`,
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"accessToken":"REDACTED","body":{"filter":"foo"},"path":"/foo"}`,
+		},
+		{
+			name:           "error response",
+			path:           "/missing",
+			body:           `{}`,
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       `{"accessToken":"REDACTED","body":{},"path":"/missing"}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorded, err := recordingClient.Post(server.URL+tc.path, "application/json", strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatalf("recording request failed: %v", err)
+			}
+			recorded.Body.Close()
+			if recorded.StatusCode != tc.wantStatusCode {
+				t.Errorf("recorded status code = %d, want %d", recorded.StatusCode, tc.wantStatusCode)
+			}
+			countBeforeReplay := requestCount
+
+			// The replayed request differs only in the API key, that is removed from the recorded request.
+			replayedURL := strings.Replace(server.URL+tc.path, "api-key", "another-api-key", 1)
+			replayed, err := replayingClient.Post(replayedURL, "application/json", strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatalf("replaying request failed: %v", err)
+			}
+			defer replayed.Body.Close()
+			body, err := io.ReadAll(replayed.Body)
+			if err != nil {
+				t.Fatalf("failed to read the replayed body: %v", err)
+			}
+			if replayed.StatusCode != tc.wantStatusCode {
+				t.Errorf("replayed status code = %d, want %d", replayed.StatusCode, tc.wantStatusCode)
+			}
+			if string(body) != tc.wantBody {
+				t.Errorf("replayed body = %s, want %s", body, tc.wantBody)
+			}
+			if got := replayed.Header.Get("Content-Type"); got != "application/json" {
+				t.Errorf("replayed Content-Type = %q, want application/json", got)
+			}
+			if requestCount != countBeforeReplay {
+				t.Errorf("the replaying transport sent a request to the server")
+			}
+		})
+	}
+
+	if _, err := replayingClient.Post(server.URL+"/foo", "application/json", strings.NewReader(`{"filter":"bar"}`)); err == nil {
+		t.Errorf("replaying a request not recorded succeeded unexpectedly")
+	}
+}
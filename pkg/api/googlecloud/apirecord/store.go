@@ -0,0 +1,187 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package apirecord records the responses of Google Cloud APIs to files and replays them later.
+// The recorded responses let parsers and forms be developed offline and integration tests run without live projects.
+package apirecord
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// ErrInteractionNotFound is returned in the replay mode when no response was recorded for the request.
+var ErrInteractionNotFound = errors.New("no recorded interaction found for the request")
+
+// redactedValue replaces the values of the sensitive fields in the recorded requests and responses.
+const redactedValue = "REDACTED"
+
+// sensitiveFieldNames is the set of JSON field names whose values must not be written to the disk.
+var sensitiveFieldNames = map[string]struct{}{
+	"access_token":  {},
+	"accessToken":   {},
+	"refresh_token": {},
+	"refreshToken":  {},
+	"id_token":      {},
+	"idToken":       {},
+	"private_key":   {},
+	"privateKey":    {},
+	"client_secret": {},
+	"clientSecret":  {},
+}
+
+// Interaction is a pair of a request and its response stored in a file.
+type Interaction struct {
+	// Request is the HTTP method and URL of the request, or the full method name for gRPC requests.
+	Request string `json:"request"`
+	// RequestBody is the sanitized body of the request. It's only for humans reading the recorded file.
+	RequestBody string `json:"requestBody,omitempty"`
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int `json:"statusCode,omitempty"`
+	// ContentType is the Content-Type header of the HTTP response.
+	ContentType string `json:"contentType,omitempty"`
+	// GRPCCode is the status code of the gRPC response.
+	GRPCCode int `json:"grpcCode,omitempty"`
+	// ErrorMessage is the message of the gRPC status when the request failed.
+	ErrorMessage string `json:"errorMessage,omitempty"`
+	// ResponseBody is the sanitized body of the response.
+	ResponseBody string `json:"responseBody,omitempty"`
+}
+
+// Store reads and writes the recorded interactions in a folder. Each interaction is stored in a JSON file named with the hash of its request.
+type Store struct {
+	folder string
+	lock   sync.Mutex
+}
+
+// NewStore returns a Store using the given folder. The folder is created when it doesn't exist.
+// The folder and the recorded files are only accessible by the current user because they may contain private resource data.
+func NewStore(folder string) (*Store, error) {
+	if err := os.MkdirAll(folder, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create the folder for recorded API responses %s: %w", folder, err)
+	}
+	return &Store{folder: folder}, nil
+}
+
+// Save writes the interaction with the given key. A previously recorded interaction with the same key is overwritten.
+// The secret values given to the inspection run in the context are redacted from the interaction before it's written.
+func (s *Store) Save(ctx context.Context, key string, interaction *Interaction) error {
+	data, err := json.MarshalIndent(redactSecretValues(ctx, interaction), "", "  ")
+	if err != nil {
+		return err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return os.WriteFile(s.path(key), data, 0600)
+}
+
+// Load reads the interaction recorded with the given key. It returns an error wrapping ErrInteractionNotFound when nothing was recorded.
+func (s *Store) Load(key string) (*Interaction, error) {
+	s.lock.Lock()
+	data, err := os.ReadFile(s.path(key))
+	s.lock.Unlock()
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrInteractionNotFound
+		}
+		return nil, err
+	}
+	var interaction Interaction
+	if err := json.Unmarshal(data, &interaction); err != nil {
+		return nil, fmt.Errorf("failed to parse the recorded interaction %s: %w", s.path(key), err)
+	}
+	return &interaction, nil
+}
+
+func (s *Store) path(key string) string {
+	return filepath.Join(s.folder, key+".json")
+}
+
+// interactionKey returns the key identifying a request from its description and body.
+func interactionKey(request string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(request))
+	hash.Write([]byte{0})
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// redactSecretValues returns a copy of the interaction with the secret values of the inspection run replaced.
+// The interaction is returned as is when the context has no secret values.
+func redactSecretValues(ctx context.Context, interaction *Interaction) *Interaction {
+	secrets, err := khictx.GetValue(ctx, inspectioncore_contract.InspectionSecretValues)
+	if err != nil {
+		return interaction
+	}
+	redacted := *interaction
+	redacted.Request = secrets.Redact(interaction.Request)
+	redacted.RequestBody = secrets.Redact(interaction.RequestBody)
+	redacted.ErrorMessage = secrets.Redact(interaction.ErrorMessage)
+	redacted.ResponseBody = secrets.Redact(interaction.ResponseBody)
+	return &redacted
+}
+
+// sanitizeJSON replaces the values of the sensitive fields in the given JSON. The input is returned as is when it's not a JSON.
+func sanitizeJSON(data []byte) []byte {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return data
+	}
+	if !redactSensitiveFields(value) {
+		return data
+	}
+	sanitized, err := json.Marshal(value)
+	if err != nil {
+		return data
+	}
+	return sanitized
+}
+
+// redactSensitiveFields replaces the values of the sensitive fields in the decoded JSON value recursively. It returns true when any field was replaced.
+func redactSensitiveFields(value any) bool {
+	redacted := false
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if _, found := sensitiveFieldNames[key]; found {
+				v[key] = redactedValue
+				redacted = true
+				continue
+			}
+			if redactSensitiveFields(child) {
+				redacted = true
+			}
+		}
+	case []any:
+		for _, child := range v {
+			if redactSensitiveFields(child) {
+				redacted = true
+			}
+		}
+	}
+	return redacted
+}
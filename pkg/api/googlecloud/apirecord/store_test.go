@@ -0,0 +1,148 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apirecord
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+func TestStore(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore() returned an unexpected error: %v", err)
+	}
+	interaction := &Interaction{
+		Request:      "GET https://example.com/foo",
+		StatusCode:   200,
+		ContentType:  "application/json",
+		ResponseBody: `{"foo":"bar"}`,
+	}
+	if err := store.Save(context.Background(), "foo", interaction); err != nil {
+		t.Fatalf("Save() returned an unexpected error: %v", err)
+	}
+
+	got, err := store.Load("foo")
+	if err != nil {
+		t.Fatalf("Load() returned an unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(interaction, got); diff != "" {
+		t.Errorf("Load() returned an unexpected interaction (-want +got):\n%s", diff)
+	}
+	if _, err := store.Load("bar"); !errors.Is(err, ErrInteractionNotFound) {
+		t.Errorf("Load() returned %v for a key not recorded, want ErrInteractionNotFound", err)
+	}
+}
+
+func TestStoreFilePermissions(t *testing.T) {
+	folder := filepath.Join(t.TempDir(), "records")
+	store, err := NewStore(folder)
+	if err != nil {
+		t.Fatalf("NewStore() returned an unexpected error: %v", err)
+	}
+	if err := store.Save(context.Background(), "foo", &Interaction{Request: "GET https://example.com/foo"}); err != nil {
+		t.Fatalf("Save() returned an unexpected error: %v", err)
+	}
+
+	folderInfo, err := os.Stat(folder)
+	if err != nil {
+		t.Fatalf("failed to stat the folder: %v", err)
+	}
+	if got := folderInfo.Mode().Perm(); got != 0700 {
+		t.Errorf("folder permission = %o, want %o", got, 0700)
+	}
+	fileInfo, err := os.Stat(store.path("foo"))
+	if err != nil {
+		t.Fatalf("failed to stat the recorded file: %v", err)
+	}
+	if got := fileInfo.Mode().Perm(); got != 0600 {
+		t.Errorf("file permission = %o, want %o", got, 0600)
+	}
+}
+
+func TestStoreRedactsSecretValues(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore() returned an unexpected error: %v", err)
+	}
+	secrets := inspectioncore_contract.NewSecretValues()
+	secrets.Add("my-secret")
+	ctx := khictx.WithValue(context.Background(), inspectioncore_contract.InspectionSecretValues, secrets)
+	interaction := &Interaction{
+		Request:      "GET https://example.com/foo?key=my-secret",
+		RequestBody:  `{"password":"my-secret"}`,
+		ErrorMessage: "invalid key my-secret",
+		ResponseBody: `{"echo":"my-secret"}`,
+	}
+	if err := store.Save(ctx, "foo", interaction); err != nil {
+		t.Fatalf("Save() returned an unexpected error: %v", err)
+	}
+
+	got, err := store.Load("foo")
+	if err != nil {
+		t.Fatalf("Load() returned an unexpected error: %v", err)
+	}
+	want := &Interaction{
+		Request:      "GET https://example.com/foo?key=[REDACTED]",
+		RequestBody:  `{"password":"[REDACTED]"}`,
+		ErrorMessage: "invalid key [REDACTED]",
+		ResponseBody: `{"echo":"[REDACTED]"}`,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Load() returned an unexpected interaction (-want +got):\n%s", diff)
+	}
+	if interaction.RequestBody != `{"password":"my-secret"}` {
+		t.Errorf("Save() modified the given interaction: %q", interaction.RequestBody)
+	}
+}
+
+func TestSanitizeJSON(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "not a JSON",
+			input: "access_token=foo",
+			want:  "access_token=foo",
+		},
+		{
+			name:  "JSON without sensitive fields is kept as is",
+			input: `{"name": "foo", "size": 12345678901234567890}`,
+			want:  `{"name": "foo", "size": 12345678901234567890}`,
+		},
+		{
+			name:  "sensitive fields in nested objects and arrays",
+			input: `{"name":"foo","credentials":[{"privateKey":"secret","size":12345678901234567890}],"access_token":"token"}`,
+			want:  `{"access_token":"REDACTED","credentials":[{"privateKey":"REDACTED","size":12345678901234567890}],"name":"foo"}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := string(sanitizeJSON([]byte(tc.input)))
+			if got != tc.want {
+				t.Errorf("sanitizeJSON() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
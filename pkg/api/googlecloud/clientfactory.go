@@ -21,8 +21,14 @@ import (
 	container "cloud.google.com/go/container/apiv1"
 	logging "cloud.google.com/go/logging/apiv2"
 	monitoring "cloud.google.com/go/monitoring/apiv3/v2"
+	"golang.org/x/oauth2"
+	cloudresourcemanager "google.golang.org/api/cloudresourcemanager/v3"
 	"google.golang.org/api/composer/v1"
+	gkehub "google.golang.org/api/gkehub/v1"
 	"google.golang.org/api/option"
+	pubsub "google.golang.org/api/pubsub/v1"
+	storage "google.golang.org/api/storage/v1"
+	"google.golang.org/api/transport"
 )
 
 // ClientFactoryContextModifiers defines a function type for modifying the context
@@ -46,8 +52,13 @@ type ClientFactory struct {
 	ContainerClusterManagerClientOptions []ClientFactoryOptionsModifiers
 	LoggingClientOptions                 []ClientFactoryOptionsModifiers
 	RegionsClientOptions                 []ClientFactoryOptionsModifiers
+	ZonesClientOptions                   []ClientFactoryOptionsModifiers
+	ResourceManagerServiceOptions        []ClientFactoryOptionsModifiers
 	ComposerServiceOptions               []ClientFactoryOptionsModifiers
+	GKEHubServiceOptions                 []ClientFactoryOptionsModifiers
 	MonitoringMetricClientOptions        []ClientFactoryOptionsModifiers
+	StorageServiceOptions                []ClientFactoryOptionsModifiers
+	PubSubServiceOptions                 []ClientFactoryOptionsModifiers
 }
 
 // NewClientFactory creates a new ClientFactory with the given options.
@@ -132,6 +143,16 @@ func (s *ClientFactory) RegionsClient(ctx context.Context, c ResourceContainer,
 	return compute.NewRegionsRESTClient(ctx, opts...)
 }
 
+// ZonesClient returns the client for listing GCE zones. https://cloud.google.com/compute/docs/reference/rest/v1#rest-resource:-v1.zones
+func (s *ClientFactory) ZonesClient(ctx context.Context, c ResourceContainer, opts ...option.ClientOption) (*compute.ZonesClient, error) {
+	ctx, opts, err := s.prepareServiceInput(ctx, c, s.ZonesClientOptions, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return compute.NewZonesRESTClient(ctx, opts...)
+}
+
 // ComposerService returns the client for composer.googleapis.com from given context and the resource container.
 // Cloud Composer has no package defined by 'cloud.google.com/go', this method returns the low level API client from 'google.golang.org/api/composer/v1'
 func (s *ClientFactory) ComposerService(ctx context.Context, c ResourceContainer, opts ...option.ClientOption) (*composer.Service, error) {
@@ -143,6 +164,64 @@ func (s *ClientFactory) ComposerService(ctx context.Context, c ResourceContainer
 	return composer.NewService(ctx, opts...)
 }
 
+// GKEHubService returns the client for gkehub.googleapis.com from given context and the resource container.
+// This method returns the low level API client from 'google.golang.org/api/gkehub/v1'.
+func (s *ClientFactory) GKEHubService(ctx context.Context, c ResourceContainer, opts ...option.ClientOption) (*gkehub.Service, error) {
+	ctx, opts, err := s.prepareServiceInput(ctx, c, s.GKEHubServiceOptions, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return gkehub.NewService(ctx, opts...)
+}
+
+// StorageService returns the client for storage.googleapis.com from given context and the resource container.
+// This method returns the low level API client from 'google.golang.org/api/storage/v1'.
+func (s *ClientFactory) StorageService(ctx context.Context, c ResourceContainer, opts ...option.ClientOption) (*storage.Service, error) {
+	ctx, opts, err := s.prepareServiceInput(ctx, c, s.StorageServiceOptions, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return storage.NewService(ctx, opts...)
+}
+
+// PubSubService returns the client for pubsub.googleapis.com from given context and the resource container.
+// This method returns the low level API client from 'google.golang.org/api/pubsub/v1'.
+func (s *ClientFactory) PubSubService(ctx context.Context, c ResourceContainer, opts ...option.ClientOption) (*pubsub.Service, error) {
+	ctx, opts, err := s.prepareServiceInput(ctx, c, s.PubSubServiceOptions, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return pubsub.NewService(ctx, opts...)
+}
+
+// ResourceManagerService returns the client for cloudresourcemanager.googleapis.com from given context and the resource container.
+// This method returns the low level API client from 'google.golang.org/api/cloudresourcemanager/v3'.
+func (s *ClientFactory) ResourceManagerService(ctx context.Context, c ResourceContainer, opts ...option.ClientOption) (*cloudresourcemanager.Service, error) {
+	ctx, opts, err := s.prepareServiceInput(ctx, c, s.ResourceManagerServiceOptions, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return cloudresourcemanager.NewService(ctx, opts...)
+}
+
+// TokenSource returns the oauth2.TokenSource used by the clients for the given resource container.
+// The credentials are resolved from the client options in the same way as the clients, thus the tokens identify the principal calling the APIs.
+func (s *ClientFactory) TokenSource(ctx context.Context, c ResourceContainer, opts ...option.ClientOption) (oauth2.TokenSource, error) {
+	ctx, opts, err := s.prepareServiceInput(ctx, c, s.LoggingClientOptions, opts...)
+	if err != nil {
+		return nil, err
+	}
+	credentials, err := transport.Creds(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.TokenSource, nil
+}
+
 // MonitoringMetricClient returns the client for monitoring.googleapis.com from given context and the resource container.
 func (s *ClientFactory) MonitoringMetricClient(ctx context.Context, c ResourceContainer, opts ...option.ClientOption) (*monitoring.MetricClient, error) {
 	ctx, opts, err := s.prepareServiceInput(ctx, c, s.MonitoringMetricClientOptions, opts...)
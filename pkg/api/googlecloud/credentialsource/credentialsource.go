@@ -0,0 +1,280 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package credentialsource detects the sources of Google Cloud credentials available on the machine running KHI.
+package credentialsource
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/api/googlecloud/legacy"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// Source is the kind of a credential source.
+type Source string
+
+const (
+	// SourceADC is the Application Default Credentials file given with GOOGLE_APPLICATION_CREDENTIALS or generated by `gcloud auth application-default login`.
+	SourceADC Source = "adc"
+	// SourceGcloud is the account currently active on the gcloud CLI.
+	SourceGcloud Source = "gcloud"
+	// SourceMetadataServer is the service account attached to the Compute Engine instance or the GKE workload running KHI.
+	SourceMetadataServer Source = "metadata-server"
+	// SourceProvidedToken is the access token given with the `--access-token` flag.
+	SourceProvidedToken Source = "provided-token"
+)
+
+const cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
+// detectionCacheTTL is the duration to reuse the detected sources. Dry runs are requested on every form change, and the detection calls external commands and endpoints.
+const detectionCacheTTL = time.Minute
+
+// gcloudTokenLifetime is the duration to reuse the access token printed by gcloud. gcloud may return a cached token, so this is much shorter than the token lifetime.
+const gcloudTokenLifetime = 5 * time.Minute
+
+// Candidate is a credential source detected on the machine.
+type Candidate struct {
+	Source Source
+	// Principal is the email of the identity used with the credential source. This is empty when it couldn't be determined.
+	Principal   string
+	TokenSource oauth2.TokenSource
+}
+
+// Detector detects the available credential sources.
+type Detector struct {
+	accessToken       string
+	httpClient        *http.Client
+	metadataHost      string
+	tokenInfoEndpoint string
+	adcPath           func() string
+	runCommand        func(ctx context.Context, name string, args ...string) (string, error)
+
+	cacheLock  sync.Mutex
+	cached     []Candidate
+	cachedTime time.Time
+}
+
+// NewDetector returns a Detector. accessToken is the token given to KHI by the user and can be empty.
+func NewDetector(accessToken string) *Detector {
+	metadataHost := os.Getenv("GCE_METADATA_HOST")
+	if metadataHost == "" {
+		metadataHost = "metadata.google.internal"
+	}
+	return &Detector{
+		accessToken:       accessToken,
+		httpClient:        &http.Client{Timeout: 2 * time.Second},
+		metadataHost:      metadataHost,
+		tokenInfoEndpoint: defaultTokenInfoEndpoint,
+		adcPath:           defaultADCPath,
+		runCommand:        runCommand,
+	}
+}
+
+// Detect returns the credential sources available now. Sources failed to be detected are omitted from the result.
+func (d *Detector) Detect(ctx context.Context) []Candidate {
+	d.cacheLock.Lock()
+	defer d.cacheLock.Unlock()
+	if d.cached != nil && time.Since(d.cachedTime) < detectionCacheTTL {
+		return d.cached
+	}
+	result := []Candidate{}
+	detectors := []struct {
+		source Source
+		detect func(ctx context.Context) (*Candidate, error)
+	}{
+		{SourceADC, d.detectADC},
+		{SourceGcloud, d.detectGcloud},
+		{SourceMetadataServer, d.detectMetadataServer},
+		{SourceProvidedToken, d.detectProvidedToken},
+	}
+	for _, detector := range detectors {
+		candidate, err := detector.detect(ctx)
+		if err != nil {
+			slog.DebugContext(ctx, fmt.Sprintf("credential source %s is not available: %v", detector.source, err))
+			continue
+		}
+		result = append(result, *candidate)
+	}
+	d.cached = result
+	d.cachedTime = time.Now()
+	return result
+}
+
+func (d *Detector) detectADC(ctx context.Context) (*Candidate, error) {
+	path := d.adcPath()
+	credentialJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	credentials, err := google.CredentialsFromJSON(context.Background(), credentialJSON, cloudPlatformScope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the credentials file %s: %w", path, err)
+	}
+	var credentialFile struct {
+		ClientEmail string `json:"client_email"`
+	}
+	principal := ""
+	if err := json.Unmarshal(credentialJSON, &credentialFile); err == nil {
+		principal = credentialFile.ClientEmail
+	}
+	if principal == "" {
+		principal = d.principalFromTokenSource(ctx, credentials.TokenSource)
+	}
+	return &Candidate{Source: SourceADC, Principal: principal, TokenSource: credentials.TokenSource}, nil
+}
+
+func (d *Detector) detectGcloud(ctx context.Context) (*Candidate, error) {
+	account, err := d.runCommand(ctx, "gcloud", "config", "get-value", "account")
+	if err != nil {
+		return nil, err
+	}
+	account = strings.TrimSpace(account)
+	if account == "" || account == "(unset)" {
+		return nil, errors.New("no active account is set on gcloud")
+	}
+	tokenSource := oauth2.ReuseTokenSource(nil, &gcloudTokenSource{runCommand: d.runCommand})
+	return &Candidate{Source: SourceGcloud, Principal: account, TokenSource: tokenSource}, nil
+}
+
+func (d *Detector) detectMetadataServer(ctx context.Context) (*Candidate, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://%s/computeMetadata/v1/instance/service-accounts/default/email", d.metadataHost), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("metadata server returned status %d", resp.StatusCode)
+	}
+	email, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return &Candidate{Source: SourceMetadataServer, Principal: strings.TrimSpace(string(email)), TokenSource: google.ComputeTokenSource("", cloudPlatformScope)}, nil
+}
+
+func (d *Detector) detectProvidedToken(ctx context.Context) (*Candidate, error) {
+	if d.accessToken == "" {
+		return nil, errors.New("no access token is given")
+	}
+	tokenSource := legacy.NewRawTokenTokenSource(d.accessToken)
+	return &Candidate{Source: SourceProvidedToken, Principal: d.principalFromTokenSource(ctx, tokenSource), TokenSource: tokenSource}, nil
+}
+
+// defaultTokenInfoEndpoint is the endpoint returning the information of an access token.
+const defaultTokenInfoEndpoint = "https://oauth2.googleapis.com/tokeninfo"
+
+// PrincipalOf returns the email of the identity owning the access tokens returned from the token source. This returns an empty string when the email is not available.
+func PrincipalOf(ctx context.Context, tokenSource oauth2.TokenSource) string {
+	return principalFromTokenInfo(ctx, &http.Client{Timeout: 2 * time.Second}, defaultTokenInfoEndpoint, tokenSource)
+}
+
+// principalFromTokenSource returns the email associated with the access token from the tokeninfo endpoint. This returns an empty string when the email is not available.
+func (d *Detector) principalFromTokenSource(ctx context.Context, tokenSource oauth2.TokenSource) string {
+	return principalFromTokenInfo(ctx, d.httpClient, d.tokenInfoEndpoint, tokenSource)
+}
+
+// principalFromTokenInfo returns the email associated with the access token from the given tokeninfo endpoint.
+func principalFromTokenInfo(ctx context.Context, httpClient *http.Client, tokenInfoEndpoint string, tokenSource oauth2.TokenSource) string {
+	token, err := tokenSource.Token()
+	if err != nil {
+		slog.DebugContext(ctx, fmt.Sprintf("failed to get an access token to check the principal: %v", err))
+		return ""
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenInfoEndpoint+"?access_token="+url.QueryEscape(token.AccessToken), nil)
+	if err != nil {
+		return ""
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		slog.DebugContext(ctx, fmt.Sprintf("failed to call the tokeninfo endpoint: %v", err))
+		return ""
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return ""
+	}
+	var tokenInfo struct {
+		Email string `json:"email"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenInfo); err != nil {
+		return ""
+	}
+	return tokenInfo.Email
+}
+
+// gcloudTokenSource is an oauth2.TokenSource returning the access token of the account active on gcloud.
+type gcloudTokenSource struct {
+	runCommand func(ctx context.Context, name string, args ...string) (string, error)
+}
+
+// Token implements oauth2.TokenSource.
+func (g *gcloudTokenSource) Token() (*oauth2.Token, error) {
+	accessToken, err := g.runCommand(context.Background(), "gcloud", "auth", "print-access-token")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get an access token from gcloud: %w", err)
+	}
+	return &oauth2.Token{
+		AccessToken: strings.TrimSpace(accessToken),
+		Expiry:      time.Now().Add(gcloudTokenLifetime),
+	}, nil
+}
+
+var _ oauth2.TokenSource = (*gcloudTokenSource)(nil)
+
+// defaultADCPath returns the path of the Application Default Credentials file in the same order as the Google Cloud client libraries.
+func defaultADCPath() string {
+	if path := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"); path != "" {
+		return path
+	}
+	const f = "application_default_credentials.json"
+	if runtime.GOOS == "windows" {
+		return filepath.Join(os.Getenv("APPDATA"), "gcloud", f)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "gcloud", f)
+}
+
+func runCommand(ctx context.Context, name string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, name, args...).Output()
+	if err != nil {
+		return "", err
+	}
+	return string(output), nil
+}
@@ -0,0 +1,190 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credentialsource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func newTestDetector(t *testing.T, accessToken string, adcJSON string, commands map[string]string, metadataEmail string) *Detector {
+	t.Helper()
+	adcPath := filepath.Join(t.TempDir(), "application_default_credentials.json")
+	if adcJSON != "" {
+		if err := os.WriteFile(adcPath, []byte(adcJSON), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/computeMetadata/v1/instance/service-accounts/default/email" && metadataEmail != "":
+			if r.Header.Get("Metadata-Flavor") != "Google" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			fmt.Fprint(w, metadataEmail)
+		case r.URL.Path == "/tokeninfo" && r.URL.Query().Get("access_token") == "test-token":
+			fmt.Fprint(w, `{"email":"user@example.com"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return &Detector{
+		accessToken:       accessToken,
+		httpClient:        server.Client(),
+		metadataHost:      strings.TrimPrefix(server.URL, "http://"),
+		tokenInfoEndpoint: server.URL + "/tokeninfo",
+		adcPath:           func() string { return adcPath },
+		runCommand: func(ctx context.Context, name string, args ...string) (string, error) {
+			output, found := commands[strings.Join(append([]string{name}, args...), " ")]
+			if !found {
+				return "", errors.New("command not found")
+			}
+			return output, nil
+		},
+	}
+}
+
+type detectedSource struct {
+	Source    Source
+	Principal string
+}
+
+func TestDetect(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		accessToken   string
+		adcJSON       string
+		commands      map[string]string
+		metadataEmail string
+		want          []detectedSource
+	}{
+		{
+			desc: "no credential source",
+			want: []detectedSource{},
+		},
+		{
+			desc:    "service account key as ADC",
+			adcJSON: `{"type":"service_account","project_id":"test-project","client_email":"sa@test-project.iam.gserviceaccount.com","private_key":"dummy","token_uri":"https://oauth2.googleapis.com/token"}`,
+			want: []detectedSource{
+				{Source: SourceADC, Principal: "sa@test-project.iam.gserviceaccount.com"},
+			},
+		},
+		{
+			desc: "gcloud with an active account",
+			commands: map[string]string{
+				"gcloud config get-value account": "user@example.com\n",
+			},
+			want: []detectedSource{
+				{Source: SourceGcloud, Principal: "user@example.com"},
+			},
+		},
+		{
+			desc: "gcloud without an active account",
+			commands: map[string]string{
+				"gcloud config get-value account": "(unset)\n",
+			},
+			want: []detectedSource{},
+		},
+		{
+			desc:          "metadata server",
+			metadataEmail: "123-compute@developer.gserviceaccount.com",
+			want: []detectedSource{
+				{Source: SourceMetadataServer, Principal: "123-compute@developer.gserviceaccount.com"},
+			},
+		},
+		{
+			desc:        "provided token",
+			accessToken: "test-token",
+			want: []detectedSource{
+				{Source: SourceProvidedToken, Principal: "user@example.com"},
+			},
+		},
+		{
+			desc:        "provided token without email in the tokeninfo",
+			accessToken: "unknown-token",
+			want: []detectedSource{
+				{Source: SourceProvidedToken, Principal: ""},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			detector := newTestDetector(t, tc.accessToken, tc.adcJSON, tc.commands, tc.metadataEmail)
+
+			candidates := detector.Detect(t.Context())
+
+			got := []detectedSource{}
+			for _, candidate := range candidates {
+				if candidate.TokenSource == nil {
+					t.Errorf("TokenSource of %s is nil", candidate.Source)
+				}
+				got = append(got, detectedSource{Source: candidate.Source, Principal: candidate.Principal})
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Detect() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDetectCachesResult(t *testing.T) {
+	commands := map[string]string{
+		"gcloud config get-value account": "user@example.com",
+	}
+	detector := newTestDetector(t, "", "", commands, "")
+
+	first := detector.Detect(t.Context())
+	delete(commands, "gcloud config get-value account")
+	second := detector.Detect(t.Context())
+	if len(first) != 1 || len(second) != 1 {
+		t.Errorf("Detect() returned %d and %d candidates, want the cached result", len(first), len(second))
+	}
+
+	detector.cachedTime = time.Now().Add(-detectionCacheTTL)
+	third := detector.Detect(t.Context())
+	if len(third) != 0 {
+		t.Errorf("Detect() returned %d candidates after the cache expired, want 0", len(third))
+	}
+}
+
+func TestGcloudTokenSource(t *testing.T) {
+	source := &gcloudTokenSource{
+		runCommand: func(ctx context.Context, name string, args ...string) (string, error) {
+			return "ya29.test\n", nil
+		},
+	}
+	token, err := source.Token()
+	if err != nil {
+		t.Fatalf("Token() returned an unexpected error: %v", err)
+	}
+	if token.AccessToken != "ya29.test" {
+		t.Errorf("AccessToken = %q, want %q", token.AccessToken, "ya29.test")
+	}
+	if !token.Expiry.After(time.Now()) {
+		t.Errorf("Expiry = %v, want a time in the future", token.Expiry)
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// storedOAuthToken is the content of the file storing the refresh token obtained with InstalledAppFlow.
+type storedOAuthToken struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// InstalledAppFlow authenticates the user with the OAuth flow for installed applications.
+// It opens the authorization page in the browser and receives the authorization code with a redirect to a loopback address.
+// The refresh token is stored in the token file to reuse it after restarting KHI.
+type InstalledAppFlow struct {
+	// oauthConfig is the config of the OAuth client for desktop apps. RedirectURL is ignored and replaced with the loopback address.
+	oauthConfig   *oauth2.Config
+	tokenFilePath string
+	loginTimeout  time.Duration
+	openBrowser   func(url string) error
+
+	tokenSourceMutex   sync.Mutex
+	tokenSource        oauth2.TokenSource
+	storedRefreshToken string
+}
+
+var _ oauth2.TokenSource = (*InstalledAppFlow)(nil)
+
+// NewInstalledAppFlow creates a new InstalledAppFlow storing the refresh token in tokenFilePath.
+func NewInstalledAppFlow(oauthConfig *oauth2.Config, tokenFilePath string) *InstalledAppFlow {
+	return &InstalledAppFlow{
+		oauthConfig:   oauthConfig,
+		tokenFilePath: tokenFilePath,
+		loginTimeout:  5 * time.Minute,
+		openBrowser:   openBrowser,
+	}
+}
+
+// Token implements oauth2.TokenSource.
+// It uses the stored refresh token when it's available, otherwise it starts the login flow in the browser and blocks until the user finishes it.
+func (f *InstalledAppFlow) Token() (*oauth2.Token, error) {
+	f.tokenSourceMutex.Lock()
+	defer f.tokenSourceMutex.Unlock()
+	ctx := context.Background()
+	if f.tokenSource == nil {
+		refreshToken, err := f.loadRefreshToken()
+		if err != nil {
+			slog.Warn(fmt.Sprintf("failed to read the stored OAuth token. Login again with the browser: %v", err))
+		}
+		if refreshToken != "" {
+			f.storedRefreshToken = refreshToken
+			f.tokenSource = f.oauthConfig.TokenSource(ctx, &oauth2.Token{RefreshToken: refreshToken})
+		} else {
+			token, err := f.login(ctx)
+			if err != nil {
+				return nil, err
+			}
+			f.tokenSource = f.oauthConfig.TokenSource(ctx, token)
+		}
+	}
+
+	token, err := f.tokenSource.Token()
+	if err != nil {
+		// The refresh token is revoked or expired. Forget it to login again on the next request.
+		var retrieveErr *oauth2.RetrieveError
+		if errors.As(err, &retrieveErr) && retrieveErr.ErrorCode == "invalid_grant" {
+			f.tokenSource = nil
+			f.storedRefreshToken = ""
+			if err := os.Remove(f.tokenFilePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+				slog.Warn(fmt.Sprintf("failed to remove the invalid OAuth token file %s: %v", f.tokenFilePath, err))
+			}
+		}
+		return nil, err
+	}
+	if token.RefreshToken != "" && token.RefreshToken != f.storedRefreshToken {
+		if err := f.storeRefreshToken(token.RefreshToken); err != nil {
+			slog.Warn(fmt.Sprintf("failed to store the OAuth token. KHI will ask you to login again after restarting: %v", err))
+		} else {
+			f.storedRefreshToken = token.RefreshToken
+		}
+	}
+	return token, nil
+}
+
+// login opens the authorization page in the browser and exchanges the authorization code received on the loopback address with a token.
+func (f *InstalledAppFlow) login(ctx context.Context) (*oauth2.Token, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on a loopback address to receive the OAuth redirect: %w", err)
+	}
+	config := *f.oauthConfig
+	config.RedirectURL = fmt.Sprintf("http://%s/", listener.Addr().String())
+
+	state, err := generateInstalledAppStateCode()
+	if err != nil {
+		listener.Close()
+		return nil, err
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	codeChan := make(chan string, 1)
+	errChan := make(chan error, 1)
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			if errType := query.Get("error"); errType != "" {
+				http.Error(w, fmt.Sprintf("The authorization server redirected with an error: %s", errType), http.StatusBadRequest)
+				select {
+				case errChan <- fmt.Errorf("authentication failed with redirect error: %s", errType):
+				default:
+				}
+				return
+			}
+			if query.Get("state") != state {
+				http.Error(w, "invalid state code", http.StatusBadRequest)
+				return
+			}
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte(authenticationSuccessfulHTML))
+			select {
+			case codeChan <- query.Get("code"):
+			default:
+			}
+		}),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go server.Serve(listener)
+	defer server.Close()
+
+	authURL := config.AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.S256ChallengeOption(verifier))
+	slog.Info(fmt.Sprintf("Login with your Google account in the browser. Open the following URL when the browser didn't open automatically.\n%s", authURL))
+	if err := f.openBrowser(authURL); err != nil {
+		slog.Warn(fmt.Sprintf("failed to open the browser: %v", err))
+	}
+
+	select {
+	case code := <-codeChan:
+		return config.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	case err := <-errChan:
+		return nil, err
+	case <-time.After(f.loginTimeout):
+		return nil, fmt.Errorf("timed out waiting for the login in the browser")
+	}
+}
+
+// loadRefreshToken reads the refresh token from the token file. It returns an empty string without error when the file doesn't exist.
+func (f *InstalledAppFlow) loadRefreshToken() (string, error) {
+	content, err := os.ReadFile(f.tokenFilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	var stored storedOAuthToken
+	if err := json.Unmarshal(content, &stored); err != nil {
+		return "", err
+	}
+	return stored.RefreshToken, nil
+}
+
+// storeRefreshToken writes the refresh token to the token file readable only by the current user.
+func (f *InstalledAppFlow) storeRefreshToken(refreshToken string) error {
+	content, err := json.Marshal(storedOAuthToken{RefreshToken: refreshToken})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(f.tokenFilePath), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(f.tokenFilePath, content, 0600)
+}
+
+// generateInstalledAppStateCode generates a random state code to prevent CSRF attacks on the loopback address.
+func generateInstalledAppStateCode() (string, error) {
+	randomSeed := make([]byte, 32)
+	_, err := rand.Read(randomSeed)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", randomSeed), nil
+}
+
+// openBrowser opens the URL with the default browser of the platform.
+func openBrowser(url string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", url).Start()
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", url).Start()
+	default:
+		return exec.Command("xdg-open", url).Start()
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// newTestTokenEndpoint returns a token endpoint accepting the authorization code `valid-code` and the refresh token `valid-refresh-token`.
+func newTestTokenEndpoint(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("failed to parse the token request: %v", err)
+		}
+		switch {
+		case r.Form.Get("grant_type") == "authorization_code" && r.Form.Get("code") == "valid-code" && r.Form.Get("code_verifier") != "":
+		case r.Form.Get("grant_type") == "refresh_token" && r.Form.Get("refresh_token") == "valid-refresh-token":
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"test-access-token","token_type":"Bearer","refresh_token":"valid-refresh-token","expires_in":3600}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestInstalledAppFlow(t *testing.T, tokenFilePath string, openBrowser func(string) error) *InstalledAppFlow {
+	t.Helper()
+	tokenEndpoint := newTestTokenEndpoint(t)
+	flow := NewInstalledAppFlow(&oauth2.Config{
+		ClientID:     testClientID,
+		ClientSecret: testClientSecret,
+		Scopes:       []string{"test-scope"},
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  testAuthURL,
+			TokenURL: tokenEndpoint.URL,
+		},
+	}, tokenFilePath)
+	flow.openBrowser = openBrowser
+	flow.loginTimeout = 5 * time.Second
+	return flow
+}
+
+// redirectWithCode returns a fake browser redirecting to the loopback address with the given code.
+func redirectWithCode(t *testing.T, code string, overrideState string) func(string) error {
+	return func(authURL string) error {
+		parsed, err := url.Parse(authURL)
+		if err != nil {
+			return err
+		}
+		query := parsed.Query()
+		state := query.Get("state")
+		if overrideState != "" {
+			state = overrideState
+		}
+		redirect := fmt.Sprintf("%s?code=%s&state=%s", query.Get("redirect_uri"), code, state)
+		go func() {
+			resp, err := http.Get(redirect)
+			if err != nil {
+				t.Errorf("failed to request the redirect URL: %v", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+		return nil
+	}
+}
+
+func TestInstalledAppFlow_LoginAndStoreRefreshToken(t *testing.T) {
+	tokenFilePath := filepath.Join(t.TempDir(), "oauth", "token.json")
+	flow := newTestInstalledAppFlow(t, tokenFilePath, redirectWithCode(t, "valid-code", ""))
+
+	token, err := flow.Token()
+	if err != nil {
+		t.Fatalf("Token() returned an unexpected error: %v", err)
+	}
+	if token.AccessToken != "test-access-token" {
+		t.Errorf("Token() returned access token %q, want %q", token.AccessToken, "test-access-token")
+	}
+
+	content, err := os.ReadFile(tokenFilePath)
+	if err != nil {
+		t.Fatalf("failed to read the token file: %v", err)
+	}
+	var stored storedOAuthToken
+	if err := json.Unmarshal(content, &stored); err != nil {
+		t.Fatalf("failed to parse the token file: %v", err)
+	}
+	if stored.RefreshToken != "valid-refresh-token" {
+		t.Errorf("stored refresh token = %q, want %q", stored.RefreshToken, "valid-refresh-token")
+	}
+}
+
+func TestInstalledAppFlow_UseStoredRefreshToken(t *testing.T) {
+	tokenFilePath := filepath.Join(t.TempDir(), "token.json")
+	if err := os.WriteFile(tokenFilePath, []byte(`{"refresh_token":"valid-refresh-token"}`), 0600); err != nil {
+		t.Fatalf("failed to write the token file: %v", err)
+	}
+	flow := newTestInstalledAppFlow(t, tokenFilePath, func(string) error {
+		t.Errorf("browser must not be opened when the refresh token is stored")
+		return nil
+	})
+
+	token, err := flow.Token()
+	if err != nil {
+		t.Fatalf("Token() returned an unexpected error: %v", err)
+	}
+	if token.AccessToken != "test-access-token" {
+		t.Errorf("Token() returned access token %q, want %q", token.AccessToken, "test-access-token")
+	}
+}
+
+func TestInstalledAppFlow_RemoveRevokedRefreshToken(t *testing.T) {
+	tokenFilePath := filepath.Join(t.TempDir(), "token.json")
+	if err := os.WriteFile(tokenFilePath, []byte(`{"refresh_token":"revoked-refresh-token"}`), 0600); err != nil {
+		t.Fatalf("failed to write the token file: %v", err)
+	}
+	flow := newTestInstalledAppFlow(t, tokenFilePath, redirectWithCode(t, "valid-code", ""))
+
+	if _, err := flow.Token(); err == nil {
+		t.Fatalf("Token() returned no error for a revoked refresh token")
+	}
+	if _, err := os.Stat(tokenFilePath); !os.IsNotExist(err) {
+		t.Errorf("the token file was not removed after the refresh token was revoked: %v", err)
+	}
+
+	// The next request starts the login flow again.
+	if _, err := flow.Token(); err != nil {
+		t.Errorf("Token() returned an unexpected error after login again: %v", err)
+	}
+}
+
+func TestInstalledAppFlow_IgnoreRedirectWithInvalidState(t *testing.T) {
+	flow := newTestInstalledAppFlow(t, filepath.Join(t.TempDir(), "token.json"), redirectWithCode(t, "valid-code", "invalid-state"))
+	flow.loginTimeout = 500 * time.Millisecond
+
+	if _, err := flow.Token(); err == nil {
+		t.Errorf("Token() returned no error for a redirect with an invalid state")
+	}
+}
@@ -237,12 +237,15 @@ func handleErrorRedirect(ctx *gin.Context) bool {
 // statusOkWithCloseHTML sends an HTML response to the client that closes the current browser window/tab. This is typically used after a successful OAuth authentication to close the popup window.
 // It provides a user-friendly message indicating successful authentication.
 func statusOkWithCloseHTML(ctx *gin.Context) {
-	ctx.Writer.Write([]byte(`<html>
+	ctx.Writer.Write([]byte(authenticationSuccessfulHTML))
+	ctx.Status(http.StatusOK)
+}
+
+// authenticationSuccessfulHTML is the page shown after a successful authentication. It closes the tab when the browser allows it.
+const authenticationSuccessfulHTML = `<html>
 	<head>
 		<title>Authentication successful</title>
 		<script>window.close();</script>
 	</head>
 	<body>Authentication successful. You can close this tab.</body>
-</html>`))
-	ctx.Status(http.StatusOK)
-}
+</html>`
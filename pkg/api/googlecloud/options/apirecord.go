@@ -0,0 +1,97 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	"github.com/kyasbal/khi/pkg/api/googlecloud/apirecord"
+	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
+	"google.golang.org/grpc"
+)
+
+// RecordAPIResponses returns a googlecloud.ClientFactoryOption recording the responses of every API client to the given folder.
+// The responses are sanitized before written and they can be used later with ReplayAPIResponses.
+// The requests are authenticated with the credentials configured by the other options regardless of the order of options.
+func RecordAPIResponses(folder string) googlecloud.ClientFactoryOption {
+	return func(s *googlecloud.ClientFactory) error {
+		store, err := apirecord.NewStore(folder)
+		if err != nil {
+			return err
+		}
+		grpcModifier := func(opts []option.ClientOption, c googlecloud.ResourceContainer) ([]option.ClientOption, error) {
+			opts = append(opts, option.WithGRPCDialOption(grpc.WithChainUnaryInterceptor(apirecord.RecordingUnaryClientInterceptor(store))))
+			return opts, nil
+		}
+		restModifier := func(opts []option.ClientOption, c googlecloud.ResourceContainer) ([]option.ClientOption, error) {
+			// The client built with option.WithHTTPClient ignores the other options. The authenticated client is built here to wrap its transport.
+			client, endpoint, err := htransport.NewClient(context.Background(), append([]option.ClientOption{option.WithScopes("https://www.googleapis.com/auth/cloud-platform")}, opts...)...)
+			if err != nil {
+				return nil, err
+			}
+			recordingClient := &http.Client{Transport: apirecord.NewRecordingTransport(client.Transport, store)}
+			recordingOpts := []option.ClientOption{option.WithHTTPClient(recordingClient)}
+			if endpoint != "" {
+				recordingOpts = append(recordingOpts, option.WithEndpoint(endpoint))
+			}
+			return recordingOpts, nil
+		}
+		addAPIRecordModifiers(s, grpcModifier, restModifier)
+		return nil
+	}
+}
+
+// ReplayAPIResponses returns a googlecloud.ClientFactoryOption making every API client return the responses recorded with RecordAPIResponses in the given folder.
+// The clients don't send any request to Google Cloud and the options given before this option are ignored.
+func ReplayAPIResponses(folder string) googlecloud.ClientFactoryOption {
+	return func(s *googlecloud.ClientFactory) error {
+		store, err := apirecord.NewStore(folder)
+		if err != nil {
+			return err
+		}
+		grpcModifier := func(opts []option.ClientOption, c googlecloud.ResourceContainer) ([]option.ClientOption, error) {
+			return []option.ClientOption{
+				option.WithoutAuthentication(),
+				option.WithGRPCDialOption(grpc.WithChainUnaryInterceptor(apirecord.ReplayingUnaryClientInterceptor(store))),
+				option.WithGRPCDialOption(grpc.WithChainStreamInterceptor(apirecord.ReplayingStreamClientInterceptor())),
+			}, nil
+		}
+		restModifier := func(opts []option.ClientOption, c googlecloud.ResourceContainer) ([]option.ClientOption, error) {
+			return []option.ClientOption{
+				option.WithHTTPClient(&http.Client{Transport: apirecord.NewReplayingTransport(store)}),
+			}, nil
+		}
+		addAPIRecordModifiers(s, grpcModifier, restModifier)
+		return nil
+	}
+}
+
+// addAPIRecordModifiers adds the modifiers to the client specific options of the ClientFactory depending on the protocol used by each client.
+func addAPIRecordModifiers(s *googlecloud.ClientFactory, grpcModifier, restModifier googlecloud.ClientFactoryOptionsModifiers) {
+	s.ContainerClusterManagerClientOptions = append(s.ContainerClusterManagerClientOptions, grpcModifier)
+	s.LoggingClientOptions = append(s.LoggingClientOptions, grpcModifier)
+	s.MonitoringMetricClientOptions = append(s.MonitoringMetricClientOptions, grpcModifier)
+
+	s.RegionsClientOptions = append(s.RegionsClientOptions, restModifier)
+	s.ZonesClientOptions = append(s.ZonesClientOptions, restModifier)
+	s.ResourceManagerServiceOptions = append(s.ResourceManagerServiceOptions, restModifier)
+	s.ComposerServiceOptions = append(s.ComposerServiceOptions, restModifier)
+	s.GKEHubServiceOptions = append(s.GKEHubServiceOptions, restModifier)
+	s.StorageServiceOptions = append(s.StorageServiceOptions, restModifier)
+	s.PubSubServiceOptions = append(s.PubSubServiceOptions, restModifier)
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	"github.com/kyasbal/khi/pkg/api/googlecloud/apirecord"
+	"google.golang.org/api/gkehub/v1"
+	"google.golang.org/api/option"
+)
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestReplayAPIResponses(t *testing.T) {
+	ctx := context.Background()
+	folder := t.TempDir()
+	store, err := apirecord.NewStore(folder)
+	if err != nil {
+		t.Fatalf("NewStore() returned an unexpected error: %v", err)
+	}
+	fakeAPI := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"resources":[{"name":"projects/foo-project/locations/global/memberships/foo-cluster"}]}`)),
+			Request:    req,
+		}, nil
+	})
+	recordingService, err := gkehub.NewService(ctx, option.WithHTTPClient(&http.Client{Transport: apirecord.NewRecordingTransport(fakeAPI, store)}))
+	if err != nil {
+		t.Fatalf("failed to create the recording service: %v", err)
+	}
+	if _, err := recordingService.Projects.Locations.Memberships.List("projects/foo-project/locations/global").Do(); err != nil {
+		t.Fatalf("failed to record the response: %v", err)
+	}
+
+	// The token source given before the replay option must be ignored.
+	factory, err := googlecloud.NewClientFactory(TokenSource(&mockTokenSource{}), QuotaProject("quota-project"), ReplayAPIResponses(folder))
+	if err != nil {
+		t.Fatalf("NewClientFactory() returned an unexpected error: %v", err)
+	}
+	replayingService, err := factory.GKEHubService(ctx, googlecloud.Project("foo-project"))
+	if err != nil {
+		t.Fatalf("GKEHubService() returned an unexpected error: %v", err)
+	}
+	got, err := replayingService.Projects.Locations.Memberships.List("projects/foo-project/locations/global").Do()
+	if err != nil {
+		t.Fatalf("failed to replay the response: %v", err)
+	}
+	if len(got.Resources) != 1 || got.Resources[0].Name != "projects/foo-project/locations/global/memberships/foo-cluster" {
+		t.Errorf("unexpected replayed memberships: %+v", got.Resources)
+	}
+	if _, err := replayingService.Projects.Locations.Memberships.List("projects/bar-project/locations/global").Do(); err == nil {
+		t.Errorf("replaying a request not recorded succeeded unexpectedly")
+	}
+}
@@ -15,9 +15,13 @@
 package options
 
 import (
+	"context"
+	"sync"
+
 	"github.com/kyasbal/khi/pkg/api/googlecloud"
 	"github.com/kyasbal/khi/pkg/api/googlecloud/oauth"
 	"golang.org/x/oauth2"
+	"google.golang.org/api/impersonate"
 	"google.golang.org/api/option"
 )
 
@@ -81,3 +85,27 @@ func QuotaProject(projectID string) googlecloud.ClientFactoryOption {
 		return opts, nil
 	})
 }
+
+// ImpersonateServiceAccount returns a googlecloud.ClientFactoryOption that configures the client to use the credentials impersonating the given service account.
+// The credentials given with the options added before this option are used to impersonate the service account. Application Default Credentials are used when no credentials are given.
+func ImpersonateServiceAccount(serviceAccountEmail string) googlecloud.ClientFactoryOption {
+	var tokenSourceLock sync.Mutex
+	var tokenSource oauth2.TokenSource
+	return fromClientFactoryOptionsModifier(func(opts []option.ClientOption, c googlecloud.ResourceContainer) ([]option.ClientOption, error) {
+		tokenSourceLock.Lock()
+		defer tokenSourceLock.Unlock()
+		// The token source is reused among clients to avoid requesting a new token for every client.
+		if tokenSource == nil {
+			source, err := impersonate.CredentialsTokenSource(context.Background(), impersonate.CredentialsConfig{
+				TargetPrincipal: serviceAccountEmail,
+				Scopes:          []string{"https://www.googleapis.com/auth/cloud-platform"},
+			}, opts...)
+			if err != nil {
+				return nil, err
+			}
+			tokenSource = source
+		}
+		opts = append(opts, option.WithTokenSource(tokenSource))
+		return opts, nil
+	})
+}
@@ -234,3 +234,29 @@ func TestOAuth(t *testing.T) {
 		t.Errorf("Expected 1 option to be added, but got %d", len(opts))
 	}
 }
+
+func TestImpersonateServiceAccount(t *testing.T) {
+	optionFunc := ImpersonateServiceAccount("log-reader@foo-project.iam.gserviceaccount.com")
+	container := googlecloud.Project("any-project")
+
+	clientFactory := googlecloud.ClientFactory{}
+	err := optionFunc(&clientFactory)
+	if err != nil {
+		t.Errorf("optionFunc returned an unexpected error: %v", err)
+	}
+	clientOpts := clientFactory.ClientOptions
+	if len(clientOpts) != 1 {
+		t.Fatalf("Expected 1 option to be added, but got %d", len(clientOpts))
+	}
+
+	// The base credentials are given as a token source not to depend on the Application Default Credentials in the test environment.
+	for i := 0; i < 2; i++ {
+		opts, err := clientOpts[0]([]option.ClientOption{option.WithTokenSource(&mockTokenSource{})}, container)
+		if err != nil {
+			t.Errorf("client option returned an unexpected error: %v", err)
+		}
+		if len(opts) != 2 {
+			t.Errorf("Expected 2 options including the base credentials, but got %d", len(opts))
+		}
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	"google.golang.org/api/option"
+)
+
+var regionalEndpointLocationValidator = regexp.MustCompile(`^[a-z][a-z0-9-]*[a-z0-9]$`)
+
+// LoggingEndpoint returns a googlecloud.ClientFactoryOption that configures the Cloud Logging clients to send requests to the given endpoint(host:port).
+func LoggingEndpoint(endpoint string) googlecloud.ClientFactoryOption {
+	return func(s *googlecloud.ClientFactory) error {
+		s.LoggingClientOptions = append(s.LoggingClientOptions, func(opts []option.ClientOption, c googlecloud.ResourceContainer) ([]option.ClientOption, error) {
+			opts = append(opts, option.WithEndpoint(endpoint))
+			return opts, nil
+		})
+		return nil
+	}
+}
+
+// RegionalLoggingEndpoint returns a googlecloud.ClientFactoryOption that configures the Cloud Logging clients to use the regional endpoint of the given location.
+// Requests sent to a regional endpoint are processed in the location, that is required to read logs stored in log buckets under data residency requirements.
+func RegionalLoggingEndpoint(location string) googlecloud.ClientFactoryOption {
+	return func(s *googlecloud.ClientFactory) error {
+		if !regionalEndpointLocationValidator.MatchString(location) {
+			return fmt.Errorf("invalid location for the regional Cloud Logging endpoint: %q", location)
+		}
+		return LoggingEndpoint(RegionalLoggingEndpointAddress(location))(s)
+	}
+}
+
+// RegionalLoggingEndpointAddress returns the address(host:port) of the regional Cloud Logging endpoint of the given location.
+func RegionalLoggingEndpointAddress(location string) string {
+	return fmt.Sprintf("logging.%s.rep.googleapis.com:443", location)
+}
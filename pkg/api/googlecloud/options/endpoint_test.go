@@ -0,0 +1,79 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"testing"
+
+	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	"google.golang.org/api/option"
+)
+
+func TestLoggingEndpoint(t *testing.T) {
+	clientFactory := googlecloud.ClientFactory{}
+	err := LoggingEndpoint("logging.example.com:443")(&clientFactory)
+	if err != nil {
+		t.Fatalf("LoggingEndpoint() returned an unexpected error: %v", err)
+	}
+	if len(clientFactory.ClientOptions) != 0 {
+		t.Errorf("Expected no option to be added for all clients, but got %d", len(clientFactory.ClientOptions))
+	}
+	if len(clientFactory.LoggingClientOptions) != 1 {
+		t.Fatalf("Expected 1 option to be added for the logging clients, but got %d", len(clientFactory.LoggingClientOptions))
+	}
+	opts, err := clientFactory.LoggingClientOptions[0]([]option.ClientOption{}, googlecloud.Project("foo"))
+	if err != nil {
+		t.Errorf("client option returned an unexpected error: %v", err)
+	}
+	if len(opts) != 1 {
+		t.Errorf("Expected 1 option to be added, but got %d", len(opts))
+	}
+}
+
+func TestRegionalLoggingEndpoint(t *testing.T) {
+	testCases := []struct {
+		name     string
+		location string
+		wantErr  bool
+	}{
+		{name: "region", location: "europe-west1"},
+		{name: "multi region", location: "eu"},
+		{name: "empty", location: "", wantErr: true},
+		{name: "with a dot", location: "europe-west1.evil.example.com", wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clientFactory := googlecloud.ClientFactory{}
+			err := RegionalLoggingEndpoint(tc.location)(&clientFactory)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("RegionalLoggingEndpoint() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			wantOptionCount := 1
+			if tc.wantErr {
+				wantOptionCount = 0
+			}
+			if len(clientFactory.LoggingClientOptions) != wantOptionCount {
+				t.Errorf("Expected %d option to be added for the logging clients, but got %d", wantOptionCount, len(clientFactory.LoggingClientOptions))
+			}
+		})
+	}
+}
+
+func TestRegionalLoggingEndpointAddress(t *testing.T) {
+	want := "logging.europe-west1.rep.googleapis.com:443"
+	if got := RegionalLoggingEndpointAddress("europe-west1"); got != want {
+		t.Errorf("RegionalLoggingEndpointAddress() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	"golang.org/x/oauth2/google/externalaccount"
+	"google.golang.org/api/option"
+)
+
+// externalAccountCredentialConfig is the subset of the credential configuration file of Workload Identity Federation checked before using it.
+type externalAccountCredentialConfig struct {
+	Type                           string `json:"type"`
+	Audience                       string `json:"audience"`
+	TokenURL                       string `json:"token_url"`
+	ServiceAccountImpersonationURL string `json:"service_account_impersonation_url"`
+}
+
+// ExternalAccountCredentialFile returns a googlecloud.ClientFactoryOption to use the credential configuration file of Workload Identity Federation for any projects.
+// The file is generated with `gcloud iam workload-identity-pools create-cred-config` and it supports AWS, OIDC and executable credential sources.
+// The option returns an error when the file is not a valid external account credential configuration or it sends the credentials to endpoints other than Google APIs.
+func ExternalAccountCredentialFile(configPath string) googlecloud.ClientFactoryOption {
+	return func(s *googlecloud.ClientFactory) error {
+		configJSON, err := os.ReadFile(configPath)
+		if err != nil {
+			return fmt.Errorf("failed to read the external account credential file %s: %w", configPath, err)
+		}
+		if err := validateExternalAccountCredentialConfig(configJSON); err != nil {
+			return fmt.Errorf("invalid external account credential file %s: %w", configPath, err)
+		}
+		return fromClientFactoryOptionsModifier(func(opts []option.ClientOption, c googlecloud.ResourceContainer) ([]option.ClientOption, error) {
+			opts = append(opts, option.WithAuthCredentialsJSON(option.ExternalAccount, configJSON))
+			return opts, nil
+		})(s)
+	}
+}
+
+// ExternalAccount returns a googlecloud.ClientFactoryOption to use the credentials of Workload Identity Federation built from the given config for any projects.
+// This is useful to supply the subject tokens programmatically with SubjectTokenSupplier or AwsSecurityCredentialsSupplier in the config.
+func ExternalAccount(config externalaccount.Config) googlecloud.ClientFactoryOption {
+	return func(s *googlecloud.ClientFactory) error {
+		if len(config.Scopes) == 0 {
+			config.Scopes = []string{"https://www.googleapis.com/auth/cloud-platform"}
+		}
+		source, err := externalaccount.NewTokenSource(context.Background(), config)
+		if err != nil {
+			return err
+		}
+		return TokenSource(source)(s)
+	}
+}
+
+// validateExternalAccountCredentialConfig checks the credential configuration not to send the external credentials to unexpected endpoints.
+// ref: https://cloud.google.com/docs/authentication/external/externally-sourced-credentials
+func validateExternalAccountCredentialConfig(configJSON []byte) error {
+	var config externalAccountCredentialConfig
+	if err := json.Unmarshal(configJSON, &config); err != nil {
+		return err
+	}
+	if config.Type != "external_account" {
+		return fmt.Errorf("type must be `external_account` but got %q", config.Type)
+	}
+	if config.Audience == "" {
+		return fmt.Errorf("audience must not be empty")
+	}
+	if !isGoogleAPIEndpoint(config.TokenURL, "sts") {
+		return fmt.Errorf("token_url must be an endpoint of Security Token Service but got %q", config.TokenURL)
+	}
+	if config.ServiceAccountImpersonationURL != "" && !isGoogleAPIEndpoint(config.ServiceAccountImpersonationURL, "iamcredentials") {
+		return fmt.Errorf("service_account_impersonation_url must be an endpoint of IAM Service Account Credentials API but got %q", config.ServiceAccountImpersonationURL)
+	}
+	return nil
+}
+
+// isGoogleAPIEndpoint returns true when the URL is a HTTPS endpoint of the given Google API service including its regional endpoints.
+func isGoogleAPIEndpoint(endpoint string, service string) bool {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme != "https" {
+		return false
+	}
+	host := u.Hostname()
+	return strings.HasPrefix(host, service+".") && strings.HasSuffix(host, ".googleapis.com")
+}
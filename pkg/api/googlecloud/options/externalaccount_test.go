@@ -0,0 +1,165 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	"golang.org/x/oauth2/google/externalaccount"
+	"google.golang.org/api/option"
+)
+
+type mockSubjectTokenSupplier struct{}
+
+// SubjectToken implements externalaccount.SubjectTokenSupplier.
+func (m *mockSubjectTokenSupplier) SubjectToken(ctx context.Context, options externalaccount.SupplierOptions) (string, error) {
+	return "subject-token", nil
+}
+
+func TestExternalAccountCredentialFile(t *testing.T) {
+	testCases := []struct {
+		name    string
+		config  string
+		wantErr bool
+	}{
+		{
+			name: "valid AWS credential configuration",
+			config: `{
+				"type": "external_account",
+				"audience": "//iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/pool/providers/aws",
+				"subject_token_type": "urn:ietf:params:aws:token-type:aws4_request",
+				"token_url": "https://sts.googleapis.com/v1/token",
+				"service_account_impersonation_url": "https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/log-reader@foo-project.iam.gserviceaccount.com:generateAccessToken",
+				"credential_source": {
+					"environment_id": "aws1",
+					"region_url": "http://169.254.169.254/latest/meta-data/placement/availability-zone",
+					"url": "http://169.254.169.254/latest/meta-data/iam/security-credentials",
+					"regional_cred_verification_url": "https://sts.{region}.amazonaws.com?Action=GetCallerIdentity&Version=2011-06-15"
+				}
+			}`,
+		},
+		{
+			name: "valid OIDC credential configuration with a regional STS endpoint",
+			config: `{
+				"type": "external_account",
+				"audience": "//iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/pool/providers/oidc",
+				"subject_token_type": "urn:ietf:params:oauth:token-type:jwt",
+				"token_url": "https://sts.us-central1.rep.googleapis.com/v1/token",
+				"credential_source": {"file": "/var/run/secrets/token"}
+			}`,
+		},
+		{
+			name:    "service account key",
+			config:  `{"type": "service_account"}`,
+			wantErr: true,
+		},
+		{
+			name: "missing audience",
+			config: `{
+				"type": "external_account",
+				"token_url": "https://sts.googleapis.com/v1/token"
+			}`,
+			wantErr: true,
+		},
+		{
+			name: "token URL not in Google APIs",
+			config: `{
+				"type": "external_account",
+				"audience": "//iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/pool/providers/oidc",
+				"token_url": "https://sts.googleapis.com.example.com/v1/token"
+			}`,
+			wantErr: true,
+		},
+		{
+			name: "impersonation URL not in Google APIs",
+			config: `{
+				"type": "external_account",
+				"audience": "//iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/pool/providers/oidc",
+				"token_url": "https://sts.googleapis.com/v1/token",
+				"service_account_impersonation_url": "http://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/foo:generateAccessToken"
+			}`,
+			wantErr: true,
+		},
+		{
+			name:    "not a JSON",
+			config:  `foo`,
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "credential.json")
+			if err := os.WriteFile(configPath, []byte(tc.config), 0600); err != nil {
+				t.Fatalf("failed to write the config file: %v", err)
+			}
+
+			clientFactory := googlecloud.ClientFactory{}
+			err := ExternalAccountCredentialFile(configPath)(&clientFactory)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("optionFunc returned no error, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("optionFunc returned an unexpected error: %v", err)
+			}
+			if len(clientFactory.ClientOptions) != 1 {
+				t.Fatalf("Expected 1 option to be added, but got %d", len(clientFactory.ClientOptions))
+			}
+			opts, err := clientFactory.ClientOptions[0]([]option.ClientOption{}, googlecloud.Project("any-project"))
+			if err != nil {
+				t.Errorf("client option returned an unexpected error: %v", err)
+			}
+			if len(opts) != 1 {
+				t.Errorf("Expected 1 option to be added, but got %d", len(opts))
+			}
+		})
+	}
+}
+
+func TestExternalAccountCredentialFileWithMissingFile(t *testing.T) {
+	clientFactory := googlecloud.ClientFactory{}
+	err := ExternalAccountCredentialFile(filepath.Join(t.TempDir(), "not-found.json"))(&clientFactory)
+	if err == nil {
+		t.Errorf("optionFunc returned no error for a missing file")
+	}
+}
+
+func TestExternalAccount(t *testing.T) {
+	clientFactory := googlecloud.ClientFactory{}
+	err := ExternalAccount(externalaccount.Config{
+		Audience:             "//iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/pool/providers/oidc",
+		SubjectTokenType:     "urn:ietf:params:oauth:token-type:jwt",
+		SubjectTokenSupplier: &mockSubjectTokenSupplier{},
+	})(&clientFactory)
+	if err != nil {
+		t.Fatalf("optionFunc returned an unexpected error: %v", err)
+	}
+	if len(clientFactory.ClientOptions) != 1 {
+		t.Fatalf("Expected 1 option to be added, but got %d", len(clientFactory.ClientOptions))
+	}
+	opts, err := clientFactory.ClientOptions[0]([]option.ClientOption{}, googlecloud.Project("any-project"))
+	if err != nil {
+		t.Errorf("client option returned an unexpected error: %v", err)
+	}
+	if len(opts) != 1 {
+		t.Errorf("Expected 1 option to be added, but got %d", len(opts))
+	}
+}
@@ -33,6 +33,12 @@ const (
 	ResourceContainerInvalid ResourceContainerType = iota
 	// ResourceContainerProject represents a Google Cloud ResourceContainerProject resource container.
 	ResourceContainerProject ResourceContainerType = iota
+	// ResourceContainerOrganization represents a Google Cloud organization resource container.
+	ResourceContainerOrganization ResourceContainerType = iota
+	// ResourceContainerFolder represents a Google Cloud folder resource container.
+	ResourceContainerFolder ResourceContainerType = iota
+	// ResourceContainerBillingAccount represents a Google Cloud billing account resource container.
+	ResourceContainerBillingAccount ResourceContainerType = iota
 )
 
 // ProjectResourceContainer is an interface that represents a Google Cloud project resource container.
@@ -70,3 +76,50 @@ func (p *projectResourceContainerImpl) Identifier() string {
 }
 
 var _ ProjectResourceContainer = (*projectResourceContainerImpl)(nil)
+
+// parentResourceContainerImpl is an implementation of ResourceContainer for the resource containers above projects, e.g. organizations or folders.
+// These are used to query the logs stored in the log buckets of the containers.
+type parentResourceContainerImpl struct {
+	containerType ResourceContainerType
+	collection    string
+	id            string
+}
+
+// Organization creates a new ResourceContainer for a Google Cloud organization with the given organization ID.
+func Organization(organizationID string) ResourceContainer {
+	return &parentResourceContainerImpl{
+		containerType: ResourceContainerOrganization,
+		collection:    "organizations",
+		id:            organizationID,
+	}
+}
+
+// Folder creates a new ResourceContainer for a Google Cloud folder with the given folder ID.
+func Folder(folderID string) ResourceContainer {
+	return &parentResourceContainerImpl{
+		containerType: ResourceContainerFolder,
+		collection:    "folders",
+		id:            folderID,
+	}
+}
+
+// BillingAccount creates a new ResourceContainer for a Google Cloud billing account with the given billing account ID.
+func BillingAccount(billingAccountID string) ResourceContainer {
+	return &parentResourceContainerImpl{
+		containerType: ResourceContainerBillingAccount,
+		collection:    "billingAccounts",
+		id:            billingAccountID,
+	}
+}
+
+// GetType returns the ResourceContainerType of this container.
+func (p *parentResourceContainerImpl) GetType() ResourceContainerType {
+	return p.containerType
+}
+
+// Identifier returns the unique identifier for this resource container in the format "<collection>/<id>".
+func (p *parentResourceContainerImpl) Identifier() string {
+	return fmt.Sprintf("%s/%s", p.collection, p.id)
+}
+
+var _ ResourceContainer = (*parentResourceContainerImpl)(nil)
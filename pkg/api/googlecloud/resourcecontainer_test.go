@@ -33,3 +33,41 @@ func TestProjectResourceContainer(t *testing.T) {
 		t.Errorf("ProjectID() = %q, want %q", gotProjectID, projectID)
 	}
 }
+
+func TestParentResourceContainers(t *testing.T) {
+	testCases := []struct {
+		name           string
+		container      ResourceContainer
+		wantType       ResourceContainerType
+		wantIdentifier string
+	}{
+		{
+			name:           "organization",
+			container:      Organization("123"),
+			wantType:       ResourceContainerOrganization,
+			wantIdentifier: "organizations/123",
+		},
+		{
+			name:           "folder",
+			container:      Folder("456"),
+			wantType:       ResourceContainerFolder,
+			wantIdentifier: "folders/456",
+		},
+		{
+			name:           "billing account",
+			container:      BillingAccount("0123AB-4567CD-89EFAB"),
+			wantType:       ResourceContainerBillingAccount,
+			wantIdentifier: "billingAccounts/0123AB-4567CD-89EFAB",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if gotType := tc.container.GetType(); gotType != tc.wantType {
+				t.Errorf("GetType() = %v, want %v", gotType, tc.wantType)
+			}
+			if gotIdentifier := tc.container.Identifier(); gotIdentifier != tc.wantIdentifier {
+				t.Errorf("Identifier() = %q, want %q", gotIdentifier, tc.wantIdentifier)
+			}
+		})
+	}
+}
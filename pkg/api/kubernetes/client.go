@@ -0,0 +1,238 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// WatchAPI is the subset of the Kubernetes API used in KHI to capture changes of resources.
+type WatchAPI interface {
+	// Watch streams the changes of the resource after the resourceVersion and calls the handler for each change until the stream ends or ctx is cancelled.
+	// All existing objects are notified as ADDED events first when resourceVersion is empty.
+	Watch(ctx context.Context, resource Resource, resourceVersion string, handler func(event *WatchEvent) error) error
+}
+
+// Resource identifies a resource type served by the API server.
+type Resource struct {
+	// Group is empty for the core API group.
+	Group    string
+	Version  string
+	Resource string
+}
+
+// ParseResource parses the resource in `<version>/<resource>` for the core API group or `<group>/<version>/<resource>` like `apps/v1/deployments`.
+func ParseResource(value string) (Resource, error) {
+	parts := strings.Split(strings.TrimSpace(value), "/")
+	for _, part := range parts {
+		if part == "" {
+			return Resource{}, fmt.Errorf("invalid resource %q: the resource must be `<version>/<resource>` or `<group>/<version>/<resource>`", value)
+		}
+	}
+	switch len(parts) {
+	case 2:
+		return Resource{Version: parts[0], Resource: parts[1]}, nil
+	case 3:
+		return Resource{Group: parts[0], Version: parts[1], Resource: parts[2]}, nil
+	default:
+		return Resource{}, fmt.Errorf("invalid resource %q: the resource must be `<version>/<resource>` or `<group>/<version>/<resource>`", value)
+	}
+}
+
+// APIVersion returns the apiVersion of objects of the resource like `v1` or `apps/v1`.
+func (r Resource) APIVersion() string {
+	if r.Group == "" {
+		return r.Version
+	}
+	return r.Group + "/" + r.Version
+}
+
+// String returns the resource in the format accepted by ParseResource.
+func (r Resource) String() string {
+	return r.APIVersion() + "/" + r.Resource
+}
+
+func (r Resource) path() string {
+	if r.Group == "" {
+		return "/api/" + r.Version + "/" + r.Resource
+	}
+	return "/apis/" + r.Group + "/" + r.Version + "/" + r.Resource
+}
+
+// WatchEventType is the type of a change notified from the watch API.
+type WatchEventType string
+
+const (
+	WatchEventAdded    WatchEventType = "ADDED"
+	WatchEventModified WatchEventType = "MODIFIED"
+	WatchEventDeleted  WatchEventType = "DELETED"
+	WatchEventBookmark WatchEventType = "BOOKMARK"
+	WatchEventError    WatchEventType = "ERROR"
+)
+
+// WatchEvent is a change notified from the watch API.
+type WatchEvent struct {
+	Type WatchEventType `json:"type"`
+	// Object is the object after the change. It is a Status object for ERROR events.
+	Object json.RawMessage `json:"object"`
+}
+
+// APIError is the error returned from the API server.
+type APIError struct {
+	StatusCode int
+	Reason     string
+	Message    string
+}
+
+// Error implements error.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("Kubernetes API returned an error (status: %d, reason: %s): %s", e.StatusCode, e.Reason, e.Message)
+}
+
+// IsGone returns true when the error tells the requested resourceVersion is too old to watch.
+func IsGone(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusGone
+}
+
+// Client is the WatchAPI implementation calling the API server.
+type Client struct {
+	config      *RESTConfig
+	httpClient  *http.Client
+	tokenSource *execTokenSource
+}
+
+var _ WatchAPI = (*Client)(nil)
+
+// NewClient returns the client calling the API server with the endpoint and the credential in the config.
+func NewClient(config *RESTConfig) (*Client, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: config.Insecure,
+		ServerName:         config.TLSServerName,
+	}
+	if len(config.CAData) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(config.CAData) {
+			return nil, fmt.Errorf("failed to parse the certificate authority of the context %q", config.ContextName)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if len(config.CertData) > 0 || len(config.KeyData) > 0 {
+		certificate, err := tls.X509KeyPair(config.CertData, config.KeyData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the client certificate of the context %q: %w", config.ContextName, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	client := &Client{
+		config:     config,
+		httpClient: &http.Client{Transport: transport},
+	}
+	if config.BearerToken == "" && config.Exec != nil {
+		client.tokenSource = newExecTokenSource(config.Exec)
+	}
+	return client, nil
+}
+
+// Watch implements WatchAPI.
+func (c *Client) Watch(ctx context.Context, resource Resource, resourceVersion string, handler func(event *WatchEvent) error) error {
+	query := url.Values{}
+	query.Set("watch", "true")
+	query.Set("allowWatchBookmarks", "true")
+	if resourceVersion != "" {
+		query.Set("resourceVersion", resourceVersion)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.config.Host+resource.path()+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	err = c.authorize(ctx, req)
+	if err != nil {
+		return err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to watch %s: %w", resource, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return parseStatus(resp.StatusCode, body)
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var event WatchEvent
+		err := decoder.Decode(&event)
+		if err != nil {
+			if errors.Is(err, io.EOF) || ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to read the watch stream of %s: %w", resource, err)
+		}
+		if event.Type == WatchEventError {
+			return parseStatus(0, event.Object)
+		}
+		err = handler(&event)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (c *Client) authorize(ctx context.Context, req *http.Request) error {
+	switch {
+	case c.config.BearerToken != "":
+		req.Header.Set("Authorization", "Bearer "+c.config.BearerToken)
+	case c.tokenSource != nil:
+		token, err := c.tokenSource.Token(ctx)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	case c.config.Username != "":
+		req.SetBasicAuth(c.config.Username, c.config.Password)
+	}
+	return nil
+}
+
+// parseStatus returns the APIError from the Status object in the body.
+// The code in the Status is used when statusCode is 0.
+func parseStatus(statusCode int, body []byte) error {
+	var status struct {
+		Code    int    `json:"code"`
+		Reason  string `json:"reason"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &status); err != nil {
+		status.Message = strings.TrimSpace(string(body))
+	}
+	if statusCode == 0 {
+		statusCode = status.Code
+	}
+	return &APIError{StatusCode: statusCode, Reason: status.Reason, Message: status.Message}
+}
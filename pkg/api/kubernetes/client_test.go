@@ -0,0 +1,141 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseResource(t *testing.T) {
+	testCases := []struct {
+		value   string
+		want    Resource
+		wantErr bool
+	}{
+		{value: "v1/pods", want: Resource{Version: "v1", Resource: "pods"}},
+		{value: " apps/v1/deployments ", want: Resource{Group: "apps", Version: "v1", Resource: "deployments"}},
+		{value: "pods", wantErr: true},
+		{value: "apps//deployments", wantErr: true},
+		{value: "a/b/c/d", wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			got, err := ParseResource(tc.value)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("ParseResource(%q) returned no error, want an error", tc.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseResource(%q) returned an unexpected error: %v", tc.value, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ParseResource(%q) mismatch (-want +got):\n%s", tc.value, diff)
+			}
+		})
+	}
+}
+
+func TestClientWatch(t *testing.T) {
+	var gotPath, gotQuery, gotAuthorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotQuery = r.URL.RawQuery
+		gotAuthorization = r.Header.Get("Authorization")
+		fmt.Fprintln(w, `{"type":"ADDED","object":{"kind":"Deployment","metadata":{"name":"a","resourceVersion":"10"}}}`)
+		fmt.Fprintln(w, `{"type":"BOOKMARK","object":{"kind":"Deployment","metadata":{"resourceVersion":"11"}}}`)
+		fmt.Fprintln(w, `{"type":"DELETED","object":{"kind":"Deployment","metadata":{"name":"a","resourceVersion":"12"}}}`)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&RESTConfig{Host: server.URL, BearerToken: "token"})
+	if err != nil {
+		t.Fatalf("NewClient() returned an unexpected error: %v", err)
+	}
+	var gotTypes []WatchEventType
+	err = client.Watch(t.Context(), Resource{Group: "apps", Version: "v1", Resource: "deployments"}, "9", func(event *WatchEvent) error {
+		gotTypes = append(gotTypes, event.Type)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Watch() returned an unexpected error: %v", err)
+	}
+	if gotPath != "/apis/apps/v1/deployments" {
+		t.Errorf("path = %q, want %q", gotPath, "/apis/apps/v1/deployments")
+	}
+	if want := "allowWatchBookmarks=true&resourceVersion=9&watch=true"; gotQuery != want {
+		t.Errorf("query = %q, want %q", gotQuery, want)
+	}
+	if gotAuthorization != "Bearer token" {
+		t.Errorf("Authorization = %q, want %q", gotAuthorization, "Bearer token")
+	}
+	if diff := cmp.Diff([]WatchEventType{WatchEventAdded, WatchEventBookmark, WatchEventDeleted}, gotTypes); diff != "" {
+		t.Errorf("event types mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestClientWatchErrors(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		statusCode int
+		body       string
+		wantGone   bool
+		wantStatus int
+	}{
+		{
+			desc:       "error event of expired resource version",
+			statusCode: http.StatusOK,
+			body:       `{"type":"ERROR","object":{"kind":"Status","code":410,"reason":"Expired","message":"too old resource version"}}`,
+			wantGone:   true,
+			wantStatus: http.StatusGone,
+		},
+		{
+			desc:       "forbidden",
+			statusCode: http.StatusForbidden,
+			body:       `{"kind":"Status","code":403,"reason":"Forbidden","message":"pods is forbidden"}`,
+			wantStatus: http.StatusForbidden,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.statusCode)
+				fmt.Fprintln(w, tc.body)
+			}))
+			defer server.Close()
+			client, err := NewClient(&RESTConfig{Host: server.URL})
+			if err != nil {
+				t.Fatalf("NewClient() returned an unexpected error: %v", err)
+			}
+			err = client.Watch(t.Context(), Resource{Version: "v1", Resource: "pods"}, "", func(event *WatchEvent) error { return nil })
+			apiErr, ok := err.(*APIError)
+			if !ok {
+				t.Fatalf("Watch() returned %v, want an APIError", err)
+			}
+			if apiErr.StatusCode != tc.wantStatus {
+				t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, tc.wantStatus)
+			}
+			if got := IsGone(err); got != tc.wantGone {
+				t.Errorf("IsGone() = %v, want %v", got, tc.wantGone)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// execCredential is the subset of the ExecCredential printed by credential plugins.
+type execCredential struct {
+	Status struct {
+		Token               string    `json:"token"`
+		ExpirationTimestamp time.Time `json:"expirationTimestamp"`
+	} `json:"status"`
+}
+
+// execTokenSource runs the credential plugin and caches the token until it expires.
+type execTokenSource struct {
+	config *ExecConfig
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+func newExecTokenSource(config *ExecConfig) *execTokenSource {
+	return &execTokenSource{config: config}
+}
+
+// Token returns the cached token or the new token obtained from the credential plugin.
+func (e *execTokenSource) Token(ctx context.Context) (string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	// Tokens without the expiration are cached forever as client-go does.
+	if e.token != "" && (e.expiresAt.IsZero() || time.Now().Add(time.Minute).Before(e.expiresAt)) {
+		return e.token, nil
+	}
+	apiVersion := e.config.APIVersion
+	if apiVersion == "" {
+		apiVersion = "client.authentication.k8s.io/v1"
+	}
+	execInfo, err := json.Marshal(map[string]any{
+		"apiVersion": apiVersion,
+		"kind":       "ExecCredential",
+		"spec":       map[string]any{"interactive": false},
+	})
+	if err != nil {
+		return "", err
+	}
+	cmd := exec.CommandContext(ctx, e.config.Command, e.config.Args...)
+	cmd.Env = append(os.Environ(), "KUBERNETES_EXEC_INFO="+string(execInfo))
+	for _, env := range e.config.Env {
+		cmd.Env = append(cmd.Env, env.Name+"="+env.Value)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to run the credential plugin %s: %w: %s", e.config.Command, err, stderr.String())
+	}
+	var credential execCredential
+	err = json.Unmarshal(output, &credential)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse the output of the credential plugin %s: %w", e.config.Command, err)
+	}
+	if credential.Status.Token == "" {
+		return "", fmt.Errorf("the credential plugin %s returned no token. Credential plugins returning client certificates are not supported", e.config.Command)
+	}
+	e.token = credential.Status.Token
+	e.expiresAt = credential.Status.ExpirationTimestamp
+	return e.token, nil
+}
@@ -0,0 +1,200 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// kubeconfig is the subset of the kubeconfig file format used to connect to clusters.
+// See https://kubernetes.io/docs/reference/config-api/kubeconfig.v1/
+type kubeconfig struct {
+	CurrentContext string `yaml:"current-context"`
+	Clusters       []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			CertificateAuthority     string `yaml:"certificate-authority"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+			InsecureSkipTLSVerify    bool   `yaml:"insecure-skip-tls-verify"`
+			TLSServerName            string `yaml:"tls-server-name"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Users []struct {
+		Name string `yaml:"name"`
+		User struct {
+			Token                 string      `yaml:"token"`
+			TokenFile             string      `yaml:"tokenFile"`
+			ClientCertificate     string      `yaml:"client-certificate"`
+			ClientCertificateData string      `yaml:"client-certificate-data"`
+			ClientKey             string      `yaml:"client-key"`
+			ClientKeyData         string      `yaml:"client-key-data"`
+			Username              string      `yaml:"username"`
+			Password              string      `yaml:"password"`
+			Exec                  *ExecConfig `yaml:"exec"`
+		} `yaml:"user"`
+	} `yaml:"users"`
+	Contexts []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster string `yaml:"cluster"`
+			User    string `yaml:"user"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+}
+
+// ExecConfig is the command to run to obtain the credential with a client-go credential plugin.
+// See https://kubernetes.io/docs/reference/access-authn-authz/authentication/#client-go-credential-plugins
+type ExecConfig struct {
+	APIVersion string   `yaml:"apiVersion"`
+	Command    string   `yaml:"command"`
+	Args       []string `yaml:"args"`
+	Env        []struct {
+		Name  string `yaml:"name"`
+		Value string `yaml:"value"`
+	} `yaml:"env"`
+}
+
+// RESTConfig is the endpoint and the credential to call the Kubernetes API server resolved from a kubeconfig.
+type RESTConfig struct {
+	// Host is the URL of the API server like `https://10.0.0.1:6443`.
+	Host string
+	// ContextName is the name of the kubeconfig context this config was resolved from.
+	ContextName string
+
+	CAData        []byte
+	Insecure      bool
+	TLSServerName string
+
+	BearerToken string
+	CertData    []byte
+	KeyData     []byte
+	Username    string
+	Password    string
+	// Exec is the credential plugin used when the user has no static credential.
+	Exec *ExecConfig
+}
+
+// LoadKubeconfigFile reads the kubeconfig file and resolves the config of the context.
+// The current context is used when contextName is empty.
+func LoadKubeconfigFile(path string, contextName string) (*RESTConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the kubeconfig file %s: %w", path, err)
+	}
+	return LoadKubeconfig(data, filepath.Dir(path), contextName)
+}
+
+// LoadKubeconfig resolves the config of the context from the content of a kubeconfig.
+// Relative file paths in the kubeconfig are resolved from baseDir.
+func LoadKubeconfig(data []byte, baseDir string, contextName string) (*RESTConfig, error) {
+	var config kubeconfig
+	err := yaml.Unmarshal(data, &config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the kubeconfig: %w", err)
+	}
+	if contextName == "" {
+		contextName = config.CurrentContext
+	}
+	if contextName == "" {
+		return nil, fmt.Errorf("no context specified and the kubeconfig has no current-context")
+	}
+	var clusterName, userName string
+	contextFound := false
+	for _, context := range config.Contexts {
+		if context.Name == contextName {
+			clusterName, userName = context.Context.Cluster, context.Context.User
+			contextFound = true
+			break
+		}
+	}
+	if !contextFound {
+		return nil, fmt.Errorf("context %q not found in the kubeconfig", contextName)
+	}
+
+	result := &RESTConfig{ContextName: contextName}
+	clusterFound := false
+	for _, cluster := range config.Clusters {
+		if cluster.Name != clusterName {
+			continue
+		}
+		clusterFound = true
+		result.Host = strings.TrimSuffix(cluster.Cluster.Server, "/")
+		result.Insecure = cluster.Cluster.InsecureSkipTLSVerify
+		result.TLSServerName = cluster.Cluster.TLSServerName
+		result.CAData, err = readDataOrFile(cluster.Cluster.CertificateAuthorityData, cluster.Cluster.CertificateAuthority, baseDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the certificate authority of the cluster %q: %w", clusterName, err)
+		}
+		break
+	}
+	if !clusterFound {
+		return nil, fmt.Errorf("cluster %q used in the context %q not found in the kubeconfig", clusterName, contextName)
+	}
+	if result.Host == "" {
+		return nil, fmt.Errorf("cluster %q has no server", clusterName)
+	}
+
+	for _, user := range config.Users {
+		if user.Name != userName {
+			continue
+		}
+		result.BearerToken = user.User.Token
+		if result.BearerToken == "" && user.User.TokenFile != "" {
+			token, err := os.ReadFile(resolvePath(user.User.TokenFile, baseDir))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read the token file of the user %q: %w", userName, err)
+			}
+			result.BearerToken = strings.TrimSpace(string(token))
+		}
+		result.CertData, err = readDataOrFile(user.User.ClientCertificateData, user.User.ClientCertificate, baseDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the client certificate of the user %q: %w", userName, err)
+		}
+		result.KeyData, err = readDataOrFile(user.User.ClientKeyData, user.User.ClientKey, baseDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the client key of the user %q: %w", userName, err)
+		}
+		result.Username = user.User.Username
+		result.Password = user.User.Password
+		result.Exec = user.User.Exec
+		break
+	}
+	return result, nil
+}
+
+// readDataOrFile returns the base64 decoded data, or the content of the file when the data is empty.
+func readDataOrFile(base64Data string, path string, baseDir string) ([]byte, error) {
+	if base64Data != "" {
+		return base64.StdEncoding.DecodeString(base64Data)
+	}
+	if path == "" {
+		return nil, nil
+	}
+	return os.ReadFile(resolvePath(path, baseDir))
+}
+
+func resolvePath(path string, baseDir string) string {
+	if filepath.IsAbs(path) || baseDir == "" {
+		return path
+	}
+	return filepath.Join(baseDir, path)
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testKubeconfig = `
+apiVersion: v1
+kind: Config
+current-context: dev
+clusters:
+- name: dev-cluster
+  cluster:
+    server: https://10.0.0.1:6443/
+    certificate-authority-data: Y2EtZGF0YQ==
+- name: prod-cluster
+  cluster:
+    server: https://10.0.0.2:6443
+    insecure-skip-tls-verify: true
+users:
+- name: dev-user
+  user:
+    token: dev-token
+- name: prod-user
+  user:
+    tokenFile: token.txt
+- name: exec-user
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1beta1
+      command: gke-gcloud-auth-plugin
+contexts:
+- name: dev
+  context:
+    cluster: dev-cluster
+    user: dev-user
+- name: prod
+  context:
+    cluster: prod-cluster
+    user: prod-user
+- name: exec
+  context:
+    cluster: dev-cluster
+    user: exec-user
+- name: missing-cluster
+  context:
+    cluster: unknown
+    user: dev-user
+`
+
+func TestLoadKubeconfig(t *testing.T) {
+	baseDir := t.TempDir()
+	err := os.WriteFile(filepath.Join(baseDir, "token.txt"), []byte("prod-token\n"), 0600)
+	if err != nil {
+		t.Fatalf("failed to write the token file: %v", err)
+	}
+	testCases := []struct {
+		desc        string
+		contextName string
+		want        *RESTConfig
+		wantErr     bool
+	}{
+		{
+			desc: "current context",
+			want: &RESTConfig{
+				Host:        "https://10.0.0.1:6443",
+				ContextName: "dev",
+				CAData:      []byte("ca-data"),
+				BearerToken: "dev-token",
+			},
+		},
+		{
+			desc:        "token file relative to the kubeconfig",
+			contextName: "prod",
+			want: &RESTConfig{
+				Host:        "https://10.0.0.2:6443",
+				ContextName: "prod",
+				Insecure:    true,
+				BearerToken: "prod-token",
+			},
+		},
+		{
+			desc:        "credential plugin",
+			contextName: "exec",
+			want: &RESTConfig{
+				Host:        "https://10.0.0.1:6443",
+				ContextName: "exec",
+				CAData:      []byte("ca-data"),
+				Exec: &ExecConfig{
+					APIVersion: "client.authentication.k8s.io/v1beta1",
+					Command:    "gke-gcloud-auth-plugin",
+				},
+			},
+		},
+		{
+			desc:        "unknown context",
+			contextName: "staging",
+			wantErr:     true,
+		},
+		{
+			desc:        "unknown cluster",
+			contextName: "missing-cluster",
+			wantErr:     true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := LoadKubeconfig([]byte(testKubeconfig), baseDir, tc.contextName)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("LoadKubeconfig() returned no error, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadKubeconfig() returned an unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("LoadKubeconfig() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
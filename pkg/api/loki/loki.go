@@ -0,0 +1,223 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loki
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LokiAPI is the subset of Grafana Loki HTTP API used in KHI.
+type LokiAPI interface {
+	// QueryRange runs the LogQL log query over the time range and returns the matched streams.
+	QueryRange(ctx context.Context, input *QueryRangeInput) (*QueryRangeOutput, error)
+}
+
+// Direction is the order of the entries returned from the query_range API.
+type Direction string
+
+const (
+	DirectionForward  Direction = "forward"
+	DirectionBackward Direction = "backward"
+)
+
+// QueryRangeInput is the request of the query_range API.
+// See https://grafana.com/docs/loki/latest/reference/loki-http-api/#query-logs-within-a-range-of-time
+type QueryRangeInput struct {
+	// Query is the LogQL log query like `{namespace="default"}`. Metric queries are not supported.
+	Query string
+	// Start is inclusive and End is exclusive.
+	Start     time.Time
+	End       time.Time
+	Limit     int
+	Direction Direction
+}
+
+// Entry is a log line in a stream.
+type Entry struct {
+	Timestamp time.Time
+	Line      string
+	// StructuredMetadata is the metadata attached to the log line. It is nil when the line has no structured metadata.
+	StructuredMetadata map[string]string
+}
+
+// Stream is the log entries sharing the same label set.
+type Stream struct {
+	Labels  map[string]string
+	Entries []*Entry
+}
+
+// QueryRangeOutput is the response of the query_range API.
+type QueryRangeOutput struct {
+	Streams []*Stream
+}
+
+// EntryCount returns the count of entries in all the streams.
+func (q *QueryRangeOutput) EntryCount() int {
+	count := 0
+	for _, stream := range q.Streams {
+		count += len(stream.Entries)
+	}
+	return count
+}
+
+// APIError is the error returned from the Loki HTTP API.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+// Error implements error.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("Loki API returned an error (status: %d): %s", e.StatusCode, e.Message)
+}
+
+// Auth is the authentication used to call the Loki HTTP API.
+// Basic authentication is used when Username is given. Otherwise Password is sent as a bearer token when it's given.
+type Auth struct {
+	Username string
+	Password string
+	// TenantID is sent as the X-Scope-OrgID header for Loki running in the multi tenant mode.
+	TenantID string
+}
+
+// Client calls the Loki HTTP API.
+type Client struct {
+	endpoint   string
+	auth       Auth
+	httpClient *http.Client
+}
+
+var _ LokiAPI = (*Client)(nil)
+
+// NewClient returns a Client calling the Loki HTTP API served at the given URL.
+func NewClient(endpoint string, auth Auth, httpClient *http.Client) *Client {
+	return &Client{
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		auth:       auth,
+		httpClient: httpClient,
+	}
+}
+
+// QueryRange implements LokiAPI.
+func (c *Client) QueryRange(ctx context.Context, input *QueryRangeInput) (*QueryRangeOutput, error) {
+	params := url.Values{}
+	params.Set("query", input.Query)
+	params.Set("start", strconv.FormatInt(input.Start.UnixNano(), 10))
+	params.Set("end", strconv.FormatInt(input.End.UnixNano(), 10))
+	if input.Limit > 0 {
+		params.Set("limit", strconv.Itoa(input.Limit))
+	}
+	if input.Direction != "" {
+		params.Set("direction", string(input.Direction))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint+"/loki/api/v1/query_range?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case c.auth.Username != "":
+		req.SetBasicAuth(c.auth.Username, c.auth.Password)
+	case c.auth.Password != "":
+		req.Header.Set("Authorization", "Bearer "+c.auth.Password)
+	}
+	if c.auth.TenantID != "" {
+		req.Header.Set("X-Scope-OrgID", c.auth.TenantID)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call the query_range API: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the response of the query_range API: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &APIError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(body))}
+	}
+	return parseQueryRangeResponse(body)
+}
+
+// parseQueryRangeResponse decodes the response like `{"status":"success","data":{"resultType":"streams","result":[{"stream":{...},"values":[["<unix nano>","<line>"]]}]}}`.
+func parseQueryRangeResponse(body []byte) (*QueryRangeOutput, error) {
+	var response struct {
+		Status string `json:"status"`
+		Data   struct {
+			ResultType string `json:"resultType"`
+			Result     []struct {
+				Stream map[string]string   `json:"stream"`
+				Values [][]json.RawMessage `json:"values"`
+			} `json:"result"`
+		} `json:"data"`
+	}
+	err := json.Unmarshal(body, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the response of the query_range API: %w", err)
+	}
+	if response.Data.ResultType != "streams" {
+		return nil, fmt.Errorf("the query returned %q results. Use a log query returning streams instead of a metric query", response.Data.ResultType)
+	}
+
+	output := &QueryRangeOutput{}
+	for _, result := range response.Data.Result {
+		stream := &Stream{Labels: result.Stream}
+		for _, value := range result.Values {
+			entry, err := parseEntry(value)
+			if err != nil {
+				return nil, err
+			}
+			stream.Entries = append(stream.Entries, entry)
+		}
+		output.Streams = append(output.Streams, stream)
+	}
+	return output, nil
+}
+
+// parseEntry decodes an entry given as `["<unix nano>", "<line>"]` or `["<unix nano>", "<line>", {<structured metadata>}]`.
+func parseEntry(value []json.RawMessage) (*Entry, error) {
+	if len(value) < 2 {
+		return nil, fmt.Errorf("an entry must have a timestamp and a line but it had %d elements", len(value))
+	}
+	var timestampStr, line string
+	if err := json.Unmarshal(value[0], &timestampStr); err != nil {
+		return nil, fmt.Errorf("failed to decode the timestamp of an entry: %w", err)
+	}
+	timestamp, err := strconv.ParseInt(timestampStr, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the timestamp %q of an entry: %w", timestampStr, err)
+	}
+	if err := json.Unmarshal(value[1], &line); err != nil {
+		return nil, fmt.Errorf("failed to decode the line of an entry: %w", err)
+	}
+	entry := &Entry{
+		Timestamp: time.Unix(0, timestamp).UTC(),
+		Line:      line,
+	}
+	if len(value) > 2 {
+		if err := json.Unmarshal(value[2], &entry.StructuredMetadata); err != nil {
+			return nil, fmt.Errorf("failed to decode the structured metadata of an entry: %w", err)
+		}
+	}
+	return entry, nil
+}
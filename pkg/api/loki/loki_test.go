@@ -0,0 +1,170 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loki
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_QueryRange(t *testing.T) {
+	var gotRequest *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotRequest = r
+		w.Write([]byte(`{"status":"success","data":{"resultType":"streams","result":[{"stream":{"namespace":"default","pod":"nginx"},"values":[["1735689600000000001","line 1"],["1735689600000000002","line 2",{"trace_id":"abc"}]]}]}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/", Auth{Username: "user", Password: "pass", TenantID: "tenant-1"}, server.Client())
+	got, err := client.QueryRange(context.Background(), &QueryRangeInput{
+		Query:     `{namespace="default"}`,
+		Start:     time.Unix(0, 1735689600000000000),
+		End:       time.Unix(0, 1735693200000000000),
+		Limit:     100,
+		Direction: DirectionForward,
+	})
+	if err != nil {
+		t.Fatalf("QueryRange() returned an unexpected error: %v", err)
+	}
+
+	want := &QueryRangeOutput{
+		Streams: []*Stream{
+			{
+				Labels: map[string]string{"namespace": "default", "pod": "nginx"},
+				Entries: []*Entry{
+					{Timestamp: time.Unix(0, 1735689600000000001).UTC(), Line: "line 1"},
+					{Timestamp: time.Unix(0, 1735689600000000002).UTC(), Line: "line 2", StructuredMetadata: map[string]string{"trace_id": "abc"}},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("QueryRange() mismatch (-want +got):\n%s", diff)
+	}
+	if got.EntryCount() != 2 {
+		t.Errorf("EntryCount() = %d, want 2", got.EntryCount())
+	}
+	if gotRequest.URL.Path != "/loki/api/v1/query_range" {
+		t.Errorf("path = %q, want %q", gotRequest.URL.Path, "/loki/api/v1/query_range")
+	}
+	wantParams := map[string]string{
+		"query":     `{namespace="default"}`,
+		"start":     "1735689600000000000",
+		"end":       "1735693200000000000",
+		"limit":     "100",
+		"direction": "forward",
+	}
+	for key, want := range wantParams {
+		if got := gotRequest.URL.Query().Get(key); got != want {
+			t.Errorf("query parameter %s = %q, want %q", key, got, want)
+		}
+	}
+	if username, password, ok := gotRequest.BasicAuth(); !ok || username != "user" || password != "pass" {
+		t.Errorf("BasicAuth() = (%q, %q, %v), want (user, pass, true)", username, password, ok)
+	}
+	if got := gotRequest.Header.Get("X-Scope-OrgID"); got != "tenant-1" {
+		t.Errorf("X-Scope-OrgID = %q, want %q", got, "tenant-1")
+	}
+}
+
+func TestClient_QueryRangeAuth(t *testing.T) {
+	testCases := []struct {
+		desc              string
+		auth              Auth
+		wantAuthorization string
+	}{
+		{
+			desc:              "bearer token",
+			auth:              Auth{Password: "token"},
+			wantAuthorization: "Bearer token",
+		},
+		{
+			desc:              "no authentication",
+			auth:              Auth{},
+			wantAuthorization: "",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var gotAuthorization string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotAuthorization = r.Header.Get("Authorization")
+				w.Write([]byte(`{"status":"success","data":{"resultType":"streams","result":[]}}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, tc.auth, server.Client())
+			_, err := client.QueryRange(context.Background(), &QueryRangeInput{Query: `{job="audit"}`})
+			if err != nil {
+				t.Fatalf("QueryRange() returned an unexpected error: %v", err)
+			}
+			if gotAuthorization != tc.wantAuthorization {
+				t.Errorf("Authorization = %q, want %q", gotAuthorization, tc.wantAuthorization)
+			}
+		})
+	}
+}
+
+func TestClient_QueryRangeError(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		status  int
+		body    string
+		wantErr *APIError
+	}{
+		{
+			desc:    "api error",
+			status:  http.StatusBadRequest,
+			body:    "parse error at line 1, col 1: syntax error: unexpected IDENTIFIER\n",
+			wantErr: &APIError{StatusCode: http.StatusBadRequest, Message: "parse error at line 1, col 1: syntax error: unexpected IDENTIFIER"},
+		},
+		{
+			desc:   "metric query",
+			status: http.StatusOK,
+			body:   `{"status":"success","data":{"resultType":"matrix","result":[]}}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, Auth{}, server.Client())
+			_, err := client.QueryRange(context.Background(), &QueryRangeInput{Query: `{job="audit"}`})
+			if err == nil {
+				t.Fatal("QueryRange() returned no error")
+			}
+			if tc.wantErr == nil {
+				return
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("QueryRange() error = %v, want *APIError", err)
+			}
+			if diff := cmp.Diff(tc.wantErr, apiErr); diff != "" {
+				t.Errorf("QueryRange() error mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
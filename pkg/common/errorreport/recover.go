@@ -17,6 +17,7 @@ package errorreport
 import (
 	"context"
 	"fmt"
+	"runtime/debug"
 )
 
 // CheckAndReportPanic checks current function is not raising an error with panic and it reports the error when recover returns an error.
@@ -28,3 +29,29 @@ func CheckAndReportPanic() {
 		panic(r)
 	}
 }
+
+// PanicError is the error converted from a panic recovered in a worker goroutine.
+type PanicError struct {
+	// Value is the value given to the panic.
+	Value any
+	// Stack is the stack trace of the goroutine at the panic.
+	Stack string
+}
+
+// Error implements error.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic occurred: %v", e.Value)
+}
+
+// RecoverPanicAsError recovers a panic and stores it to the given error as a PanicError after reporting it.
+// This function must be called with defer in goroutines spawned from a task, because a panic in a goroutine other than the task goroutine can't be recovered by the task runner and crashes the whole process.
+func RecoverPanicAsError(err *error) {
+	if r := recover(); r != nil {
+		panicErr := &PanicError{
+			Value: r,
+			Stack: string(debug.Stack()),
+		}
+		DefaultErrorReporter.ReportSync(context.Background(), fmt.Errorf("%s\n%s", panicErr.Error(), panicErr.Stack))
+		*err = panicErr
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package markdown renders a small subset of Markdown to HTML safe to be embedded in the frontend.
+//
+// The supported syntax is paragraphs, unordered lists (`- ` or `* `), ordered lists (`1. `), inline code, bold (`**`), italic (`*`) and links.
+// Any raw HTML in the source is escaped, and links are only generated for http, https and mailto URLs.
+// Line breaks in a paragraph are kept as `<br>` because messages in KHI are often written with newlines.
+package markdown
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// allowedLinkSchemes is the set of URL schemes allowed in links. Links with any other scheme like `javascript:` are rendered as plain text.
+var allowedLinkSchemes = map[string]struct{}{
+	"http":   {},
+	"https":  {},
+	"mailto": {},
+}
+
+var unorderedListItemPattern = regexp.MustCompile(`^\s*[-*]\s+(.*)$`)
+var orderedListItemPattern = regexp.MustCompile(`^\s*\d+\.\s+(.*)$`)
+
+type blockType int
+
+const (
+	blockNone blockType = iota
+	blockParagraph
+	blockUnorderedList
+	blockOrderedList
+)
+
+// RenderSafeHTML renders the Markdown source to HTML. The result never contains HTML elements or attributes other than the ones generated by this renderer.
+func RenderSafeHTML(source string) string {
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	var sb strings.Builder
+	current := blockNone
+	lines := []string{}
+	flush := func() {
+		switch current {
+		case blockParagraph:
+			sb.WriteString("<p>")
+			for i, line := range lines {
+				if i > 0 {
+					sb.WriteString("<br>")
+				}
+				sb.WriteString(renderInline(line))
+			}
+			sb.WriteString("</p>")
+		case blockUnorderedList, blockOrderedList:
+			tag := "ul"
+			if current == blockOrderedList {
+				tag = "ol"
+			}
+			sb.WriteString("<" + tag + ">")
+			for _, line := range lines {
+				sb.WriteString("<li>" + renderInline(line) + "</li>")
+			}
+			sb.WriteString("</" + tag + ">")
+		}
+		current = blockNone
+		lines = lines[:0]
+	}
+	for _, line := range strings.Split(source, "\n") {
+		next := blockParagraph
+		content := strings.TrimSpace(line)
+		if content == "" {
+			flush()
+			continue
+		}
+		if match := unorderedListItemPattern.FindStringSubmatch(line); match != nil {
+			next, content = blockUnorderedList, match[1]
+		} else if match := orderedListItemPattern.FindStringSubmatch(line); match != nil {
+			next, content = blockOrderedList, match[1]
+		}
+		if next != current {
+			flush()
+			current = next
+		}
+		lines = append(lines, content)
+	}
+	flush()
+	return sb.String()
+}
+
+// renderInline renders the inline elements in a line of Markdown.
+func renderInline(text string) string {
+	var sb strings.Builder
+	for i := 0; i < len(text); {
+		switch {
+		case text[i] == '\\' && i+1 < len(text) && strings.IndexByte("\\`*[]()", text[i+1]) >= 0:
+			sb.WriteString(html.EscapeString(text[i+1 : i+2]))
+			i += 2
+			continue
+		case text[i] == '`':
+			if end := strings.IndexByte(text[i+1:], '`'); end >= 0 {
+				sb.WriteString("<code>" + html.EscapeString(text[i+1:i+1+end]) + "</code>")
+				i += end + 2
+				continue
+			}
+		case strings.HasPrefix(text[i:], "**"):
+			if end := strings.Index(text[i+2:], "**"); end > 0 {
+				sb.WriteString("<strong>" + renderInline(text[i+2:i+2+end]) + "</strong>")
+				i += end + 4
+				continue
+			}
+		case text[i] == '*':
+			if end := strings.IndexByte(text[i+1:], '*'); end > 0 && text[i+1] != ' ' {
+				sb.WriteString("<em>" + renderInline(text[i+1:i+1+end]) + "</em>")
+				i += end + 2
+				continue
+			}
+		case text[i] == '[':
+			if link, length, ok := renderLink(text[i:]); ok {
+				sb.WriteString(link)
+				i += length
+				continue
+			}
+		}
+		sb.WriteString(html.EscapeString(text[i : i+1]))
+		i++
+	}
+	return sb.String()
+}
+
+// renderLink renders the link at the beginning of the text in the form of `[label](url)`.
+// It returns the rendered HTML and the length of the source consumed, or false when the text doesn't start with a link with an allowed URL.
+func renderLink(text string) (string, int, bool) {
+	labelEnd := strings.Index(text, "](")
+	if labelEnd < 1 {
+		return "", 0, false
+	}
+	urlEnd := strings.IndexByte(text[labelEnd+2:], ')')
+	if urlEnd < 1 {
+		return "", 0, false
+	}
+	rawURL := strings.TrimSpace(text[labelEnd+2 : labelEnd+2+urlEnd])
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", 0, false
+	}
+	if _, allowed := allowedLinkSchemes[strings.ToLower(parsed.Scheme)]; !allowed {
+		return "", 0, false
+	}
+	label := renderInline(text[1:labelEnd])
+	return `<a href="` + html.EscapeString(parsed.String()) + `" target="_blank" rel="noopener noreferrer">` + label + `</a>`, labelEnd + 2 + urlEnd + 1, true
+}
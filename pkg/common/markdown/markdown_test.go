@@ -0,0 +1,104 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package markdown
+
+import "testing"
+
+func TestRenderSafeHTML(t *testing.T) {
+	testCases := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{
+			name:   "empty",
+			source: "",
+			want:   "",
+		},
+		{
+			name:   "paragraphs with line breaks",
+			source: "foo\nbar\n\nbaz",
+			want:   "<p>foo<br>bar</p><p>baz</p>",
+		},
+		{
+			name:   "unordered list",
+			source: "Warnings:\n- foo\n* bar",
+			want:   "<p>Warnings:</p><ul><li>foo</li><li>bar</li></ul>",
+		},
+		{
+			name:   "ordered list followed by paragraph",
+			source: "1. foo\n2. bar\nbaz",
+			want:   "<ol><li>foo</li><li>bar</li></ol><p>baz</p>",
+		},
+		{
+			name:   "inline elements",
+			source: "**bold** *italic* `code`",
+			want:   "<p><strong>bold</strong> <em>italic</em> <code>code</code></p>",
+		},
+		{
+			name:   "code escapes its content without rendering",
+			source: "`**<b>**`",
+			want:   "<p><code>**&lt;b&gt;**</code></p>",
+		},
+		{
+			name:   "link",
+			source: "See [the doc](https://example.com/a?b=c&d=e).",
+			want:   `<p>See <a href="https://example.com/a?b=c&amp;d=e" target="_blank" rel="noopener noreferrer">the doc</a>.</p>`,
+		},
+		{
+			name:   "link with disallowed scheme",
+			source: "[click](javascript:alert(1))",
+			want:   "<p>[click](javascript:alert(1))</p>",
+		},
+		{
+			name:   "link with relative URL",
+			source: "[foo](/bar)",
+			want:   "<p>[foo](/bar)</p>",
+		},
+		{
+			name:   "raw HTML is escaped",
+			source: `<script>alert("x")</script><img src=x onerror=alert(1)>`,
+			want:   "<p>&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt;&lt;img src=x onerror=alert(1)&gt;</p>",
+		},
+		{
+			name:   "HTML in link label is escaped",
+			source: `[<b onclick="x">foo</b>](https://example.com)`,
+			want:   `<p><a href="https://example.com" target="_blank" rel="noopener noreferrer">&lt;b onclick=&#34;x&#34;&gt;foo&lt;/b&gt;</a></p>`,
+		},
+		{
+			name:   "quotes in URL are escaped",
+			source: `[foo](https://example.com/"onmouseover="x)`,
+			want:   `<p><a href="https://example.com/%22onmouseover=%22x" target="_blank" rel="noopener noreferrer">foo</a></p>`,
+		},
+		{
+			name:   "escaped markers",
+			source: `\*not italic\*`,
+			want:   "<p>*not italic*</p>",
+		},
+		{
+			name:   "unclosed markers are kept",
+			source: "a * b ` c",
+			want:   "<p>a * b ` c</p>",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := RenderSafeHTML(tc.source)
+			if got != tc.want {
+				t.Errorf("RenderSafeHTML(%q) = %q, want %q", tc.source, got, tc.want)
+			}
+		})
+	}
+}
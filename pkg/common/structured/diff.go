@@ -0,0 +1,182 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package structured
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NodeDiffType is the kind of a change found by DiffNode.
+type NodeDiffType string
+
+const (
+	// NodeDiffAdded is the NodeDiffType for a leaf only found in the new node.
+	NodeDiffAdded NodeDiffType = "added"
+	// NodeDiffRemoved is the NodeDiffType for a leaf only found in the old node.
+	NodeDiffRemoved NodeDiffType = "removed"
+	// NodeDiffChanged is the NodeDiffType for a leaf found in both nodes with different values.
+	NodeDiffChanged NodeDiffType = "changed"
+)
+
+// NodeDiffEntry is a change of a leaf between 2 nodes.
+// A leaf is a scalar node, or an empty map or sequence node.
+type NodeDiffEntry struct {
+	Type NodeDiffType
+	// Before is the leaf in the old node. This is nil when the Type is NodeDiffAdded.
+	Before Node
+	// After is the leaf in the new node. This is nil when the Type is NodeDiffRemoved.
+	After Node
+}
+
+// DiffNode compares 2 nodes and returns the changed leaves keyed with their field paths.
+// Field paths are in the same format as the one accepted by NodeReader. Dots in map keys are escaped with `\` and elements of sequences are selected with their indices like `items[0].name`.
+// Sequences are compared element by element with their indices. A nil node is treated as a node having no leaf.
+func DiffNode(a, b Node) (map[string]*NodeDiffEntry, error) {
+	result := map[string]*NodeDiffEntry{}
+	err := diffNodeAt(nil, a, b, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func diffNodeAt(path []string, a, b Node, result map[string]*NodeDiffEntry) error {
+	if a == nil && b == nil {
+		return nil
+	}
+	if a == nil {
+		return collectLeaves(path, b, result, func(leaf Node) *NodeDiffEntry {
+			return &NodeDiffEntry{Type: NodeDiffAdded, After: leaf}
+		})
+	}
+	if b == nil {
+		return collectLeaves(path, a, result, func(leaf Node) *NodeDiffEntry {
+			return &NodeDiffEntry{Type: NodeDiffRemoved, Before: leaf}
+		})
+	}
+	if a.Type() == ScalarNodeType && b.Type() == ScalarNodeType {
+		aValue, err := a.NodeScalarValue()
+		if err != nil {
+			return err
+		}
+		bValue, err := b.NodeScalarValue()
+		if err != nil {
+			return err
+		}
+		if aValue != bValue {
+			result[toDiffFieldPath(path)] = &NodeDiffEntry{Type: NodeDiffChanged, Before: a, After: b}
+		}
+		return nil
+	}
+	if a.Type() != b.Type() {
+		if isLeafNode(a) && isLeafNode(b) {
+			result[toDiffFieldPath(path)] = &NodeDiffEntry{Type: NodeDiffChanged, Before: a, After: b}
+			return nil
+		}
+		// The structure is changed. Report the leaves under the path as removed and added.
+		err := diffNodeAt(path, a, nil, result)
+		if err != nil {
+			return err
+		}
+		return diffNodeAt(path, nil, b, result)
+	}
+	switch a.Type() {
+	case MapNodeType:
+		bChildren := map[string]Node{}
+		for key, child := range b.Children() {
+			bChildren[key.Key] = child
+		}
+		for key, aChild := range a.Children() {
+			err := diffNodeAt(append(path, EscapeFieldPathKey(key.Key)), aChild, bChildren[key.Key], result)
+			if err != nil {
+				return err
+			}
+			delete(bChildren, key.Key)
+		}
+		// Visit the children only in b with the original order.
+		for key, bChild := range b.Children() {
+			if _, found := bChildren[key.Key]; !found {
+				continue
+			}
+			err := diffNodeAt(append(path, EscapeFieldPathKey(key.Key)), nil, bChild, result)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case SequenceNodeType:
+		aChildren := make([]Node, 0, a.Len())
+		for _, child := range a.Children() {
+			aChildren = append(aChildren, child)
+		}
+		bChildren := make([]Node, 0, b.Len())
+		for _, child := range b.Children() {
+			bChildren = append(bChildren, child)
+		}
+		for i := 0; i < max(len(aChildren), len(bChildren)); i++ {
+			var aChild, bChild Node
+			if i < len(aChildren) {
+				aChild = aChildren[i]
+			}
+			if i < len(bChildren) {
+				bChild = bChildren[i]
+			}
+			err := diffNodeAt(append(path, fmt.Sprintf("[%d]", i)), aChild, bChild, result)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown node type: %v", a.Type())
+	}
+}
+
+// collectLeaves adds the entries generated from all the leaves under the node to the result.
+func collectLeaves(path []string, node Node, result map[string]*NodeDiffEntry, entryFactory func(leaf Node) *NodeDiffEntry) error {
+	if isLeafNode(node) {
+		result[toDiffFieldPath(path)] = entryFactory(node)
+		return nil
+	}
+	for key, child := range node.Children() {
+		childKey := EscapeFieldPathKey(key.Key)
+		if node.Type() == SequenceNodeType {
+			childKey = fmt.Sprintf("[%d]", key.Index)
+		}
+		err := collectLeaves(append(path, childKey), child, result, entryFactory)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isLeafNode returns true when the node has no child.
+func isLeafNode(node Node) bool {
+	return node.Type() == ScalarNodeType || node.Len() == 0
+}
+
+// toDiffFieldPath returns the field path in the format accepted by NodeReader from the escaped map keys and the index selectors.
+func toDiffFieldPath(path []string) string {
+	var result strings.Builder
+	for i, segment := range path {
+		if i > 0 && !strings.HasPrefix(segment, "[") {
+			result.WriteString(".")
+		}
+		result.WriteString(segment)
+	}
+	return result.String()
+}
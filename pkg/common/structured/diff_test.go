@@ -0,0 +1,140 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package structured
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// nodeDiffSummary is a comparable form of NodeDiffEntry used in tests.
+type nodeDiffSummary struct {
+	Type   NodeDiffType
+	Before any
+	After  any
+}
+
+func TestDiffNode(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		A        string
+		B        string
+		Expected map[string]nodeDiffSummary
+	}{
+		{
+			Name:     "identical nodes",
+			A:        "foo: bar\nqux: [1, 2]\n",
+			B:        "foo: bar\nqux: [1, 2]\n",
+			Expected: map[string]nodeDiffSummary{},
+		},
+		{
+			Name: "added, removed and changed fields",
+			A:    "metadata:\n  name: foo\n  labels:\n    app: a\nspec:\n  replicas: 1\n",
+			B:    "metadata:\n  name: foo\n  labels:\n    app: b\n    tier: web\nspec: {}\n",
+			Expected: map[string]nodeDiffSummary{
+				"metadata.labels.app":  {Type: NodeDiffChanged, Before: "a", After: "b"},
+				"metadata.labels.tier": {Type: NodeDiffAdded, After: "web"},
+				"spec.replicas":        {Type: NodeDiffRemoved, Before: 1},
+			},
+		},
+		{
+			Name: "sequence elements are compared with their indices",
+			A:    "items:\n- name: a\n- name: b\n",
+			B:    "items:\n- name: a\n- name: c\n- name: d\n",
+			Expected: map[string]nodeDiffSummary{
+				"items[1].name": {Type: NodeDiffChanged, Before: "b", After: "c"},
+				"items[2].name": {Type: NodeDiffAdded, After: "d"},
+			},
+		},
+		{
+			Name: "keys containing dots are escaped",
+			A:    "labels:\n  app.kubernetes.io/name: a\n",
+			B:    "labels:\n  app.kubernetes.io/name: b\n",
+			Expected: map[string]nodeDiffSummary{
+				`labels.app\.kubernetes\.io/name`: {Type: NodeDiffChanged, Before: "a", After: "b"},
+			},
+		},
+		{
+			Name: "structure change",
+			A:    "foo: bar\n",
+			B:    "foo:\n  baz: qux\n",
+			Expected: map[string]nodeDiffSummary{
+				"foo":     {Type: NodeDiffRemoved, Before: "bar"},
+				"foo.baz": {Type: NodeDiffAdded, After: "qux"},
+			},
+		},
+		{
+			Name: "nil node",
+			B:    "foo: bar\n",
+			Expected: map[string]nodeDiffSummary{
+				"foo": {Type: NodeDiffAdded, After: "bar"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var a, b Node
+			var err error
+			if tc.A != "" {
+				a, err = FromYAML(tc.A)
+				if err != nil {
+					t.Fatalf("failed to parse a node yaml %v", err)
+				}
+			}
+			if tc.B != "" {
+				b, err = FromYAML(tc.B)
+				if err != nil {
+					t.Fatalf("failed to parse b node yaml %v", err)
+				}
+			}
+			diff, err := DiffNode(a, b)
+			if err != nil {
+				t.Fatalf("DiffNode() returned an unexpected error: %v", err)
+			}
+			got := map[string]nodeDiffSummary{}
+			for path, entry := range diff {
+				summary := nodeDiffSummary{Type: entry.Type}
+				if entry.Before != nil {
+					summary.Before = mustScalarValue(t, entry.Before)
+				}
+				if entry.After != nil {
+					summary.After = mustScalarValue(t, entry.After)
+				}
+				got[path] = summary
+			}
+			if diff := cmp.Diff(tc.Expected, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+			for path, entry := range diff {
+				if entry.After == nil {
+					continue
+				}
+				if _, err := NewNodeReader(b).GetReader(path); err != nil {
+					t.Errorf("path %q is not readable from the new node: %v", path, err)
+				}
+			}
+		})
+	}
+}
+
+func mustScalarValue(t *testing.T, node Node) any {
+	t.Helper()
+	value, err := node.NodeScalarValue()
+	if err != nil {
+		t.Fatalf("failed to read the scalar value: %v", err)
+	}
+	return value
+}
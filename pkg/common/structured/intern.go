@@ -0,0 +1,79 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package structured
+
+import (
+	"runtime"
+	"sync"
+	"unique"
+	"weak"
+)
+
+// intern.go contains the pool to share scalar nodes with the same value.
+// Scalar nodes are never modified after their instantiation, thus a scalar node can be shared among any number of parents.
+// Manifests in resource histories repeat the same strings like namespaces or label values many times and sharing them reduces the heap usage.
+
+var (
+	nullScalarNode  = NewStandardScalarNode[any](nil)
+	trueScalarNode  = NewStandardScalarNode(true)
+	falseScalarNode = NewStandardScalarNode(false)
+)
+
+// stringScalarNodePool holds weak pointers of string scalar nodes keyed by their interned values.
+// An entry is removed after its node is garbage collected, then the pool doesn't retain strings no longer used.
+var stringScalarNodePool sync.Map // map[unique.Handle[string]]weak.Pointer[StandardScalarNode[string]]
+
+// newInternedScalarNode returns a scalar node of the given value. The returned node may be shared with other callers.
+// Only null, boolean and string values are shared. Nodes of the other types are created for each call.
+func newInternedScalarNode(value any) Node {
+	switch v := value.(type) {
+	case nil:
+		return nullScalarNode
+	case bool:
+		if v {
+			return trueScalarNode
+		}
+		return falseScalarNode
+	case string:
+		return newInternedStringScalarNode(v)
+	default:
+		return NewStandardScalarNode(value)
+	}
+}
+
+// newInternedStringScalarNode returns the shared scalar node of the given string.
+func newInternedStringScalarNode(value string) *StandardScalarNode[string] {
+	key := unique.Make(value)
+	if pointer, found := stringScalarNodePool.Load(key); found {
+		if node := pointer.(weak.Pointer[StandardScalarNode[string]]).Value(); node != nil {
+			return node
+		}
+	}
+	// The string from the handle is the canonical instance shared with other handles of the same value.
+	node := NewStandardScalarNode(key.Value())
+	pointer := weak.Make(node)
+	actual, loaded := stringScalarNodePool.LoadOrStore(key, pointer)
+	if loaded {
+		if existing := actual.(weak.Pointer[StandardScalarNode[string]]).Value(); existing != nil {
+			return existing
+		}
+		// The existing entry points a collected node. Replace it with the new node.
+		stringScalarNodePool.Store(key, pointer)
+	}
+	runtime.AddCleanup(node, func(key unique.Handle[string]) {
+		stringScalarNodePool.CompareAndDelete(key, pointer)
+	}, key)
+	return node
+}
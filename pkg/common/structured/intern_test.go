@@ -0,0 +1,142 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package structured
+
+import (
+	"runtime"
+	"testing"
+	"unique"
+)
+
+func TestNewInternedScalarNode(t *testing.T) {
+	for _, value := range []any{nil, true, false, "foo"} {
+		a := newInternedScalarNode(value)
+		b := newInternedScalarNode(value)
+		if a != b {
+			t.Errorf("scalar nodes of %#v are not shared", value)
+		}
+		got, err := a.NodeScalarValue()
+		if err != nil {
+			t.Fatalf("NodeScalarValue() returned an unexpected error: %v", err)
+		}
+		if got != value {
+			t.Errorf("NodeScalarValue() = %#v, want %#v", got, value)
+		}
+	}
+	if newInternedScalarNode(1) == newInternedScalarNode(1) {
+		t.Errorf("scalar nodes of int must not be shared")
+	}
+}
+
+func TestInternedStringScalarNodeIsRemovedAfterGC(t *testing.T) {
+	key := unique.Make("a string only used in TestInternedStringScalarNodeIsRemovedAfterGC")
+	node := newInternedStringScalarNode(key.Value())
+	if _, found := stringScalarNodePool.Load(key); !found {
+		t.Fatalf("the node is not stored in the pool")
+	}
+	runtime.KeepAlive(node)
+	node = nil
+	for i := 0; i < 10; i++ {
+		runtime.GC()
+		if _, found := stringScalarNodePool.Load(key); !found {
+			return
+		}
+	}
+	t.Errorf("the pool entry is not removed after the node is collected")
+}
+
+func TestFromYAMLSharesScalarNodes(t *testing.T) {
+	node, err := FromYAML(`items:
+- namespace: default
+  ready: true
+- namespace: default
+  ready: true
+`)
+	if err != nil {
+		t.Fatalf("failed to parse yaml: %v", err)
+	}
+	reader := NewNodeReader(node)
+	first, err := reader.GetReader("items[0]")
+	if err != nil {
+		t.Fatalf("failed to read the first item: %v", err)
+	}
+	second, err := reader.GetReader("items[1]")
+	if err != nil {
+		t.Fatalf("failed to read the second item: %v", err)
+	}
+	for _, fieldPath := range []string{"namespace", "ready"} {
+		a, _ := first.GetReader(fieldPath)
+		b, _ := second.GetReader(fieldPath)
+		if a.Node != b.Node {
+			t.Errorf("scalar nodes at %s are not shared", fieldPath)
+		}
+	}
+}
+
+func TestCloneStandardNodeSharesKeysWithoutAffectingSource(t *testing.T) {
+	source, err := FromYAML("a: 1\nb: 2\nc: 3\n")
+	if err != nil {
+		t.Fatalf("failed to parse yaml: %v", err)
+	}
+	writer, err := NewNodeWriter(source)
+	if err != nil {
+		t.Fatalf("NewNodeWriter() returned an unexpected error: %v", err)
+	}
+	if err := writer.Delete("a"); err != nil {
+		t.Fatalf("Delete() returned an unexpected error: %v", err)
+	}
+	if err := writer.SetInt("d", 4); err != nil {
+		t.Fatalf("SetInt() returned an unexpected error: %v", err)
+	}
+	sourceYAML, err := NewNodeReader(source).Serialize("", &YAMLNodeSerializer{})
+	if err != nil {
+		t.Fatalf("failed to serialize the source: %v", err)
+	}
+	if string(sourceYAML) != "a: 1\nb: 2\nc: 3\n" {
+		t.Errorf("the source node was modified:\n%s", sourceYAML)
+	}
+	gotYAML, err := writer.Reader().Serialize("", &YAMLNodeSerializer{})
+	if err != nil {
+		t.Fatalf("failed to serialize the clone: %v", err)
+	}
+	if string(gotYAML) != "b: 2\nc: 3\nd: 4\n" {
+		t.Errorf("unexpected clone:\n%s", gotYAML)
+	}
+}
+
+func BenchmarkCloneStandardNode(b *testing.B) {
+	node, err := FromYAML(`metadata:
+  name: foo
+  namespace: default
+  labels:
+    app: foo
+    tier: web
+spec:
+  containers:
+  - name: foo
+    image: nginx
+`)
+	if err != nil {
+		b.Fatal(err.Error())
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := cloneStandardNodeFromNode(node)
+		if err != nil {
+			b.Fatal(err.Error())
+		}
+	}
+}
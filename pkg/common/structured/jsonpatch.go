@@ -0,0 +1,396 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package structured
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unique"
+)
+
+// jsonPatchOperation is an operation in a JSON Patch document.
+type jsonPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  *string         `json:"path"`
+	From  *string         `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// ErrJSONPatchTestFailed is returned from ApplyJSONPatch when the value at the path of a `test` operation is not equal to the given value.
+var ErrJSONPatchTestFailed = errors.New("json patch test operation failed")
+
+// ApplyJSONPatch applies a JSON Patch https://datatracker.ietf.org/doc/html/rfc6902 to a previous node and generates a new Node.
+// The previous node is not modified. The operations are applied in the order and an error is returned when any of them failed including `test` operations.
+// A nil previous node is treated as a document not existing yet, thus only an operation replacing the whole document with the empty path can be applied first.
+func ApplyJSONPatch(prev Node, patch []byte) (Node, error) {
+	var operations []jsonPatchOperation
+	err := json.Unmarshal(patch, &operations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the json patch: %w", err)
+	}
+	var root Node
+	if prev != nil {
+		root, err = cloneStandardNodeFromNode(prev)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for i, operation := range operations {
+		root, err = applyJSONPatchOperation(root, &operation)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply the json patch operation at %d: %w", i, err)
+		}
+	}
+	return root, nil
+}
+
+// applyJSONPatchOperation applies an operation to the root node owned by ApplyJSONPatch and returns the new root.
+func applyJSONPatchOperation(root Node, operation *jsonPatchOperation) (Node, error) {
+	if operation.Path == nil {
+		return nil, fmt.Errorf("`path` is missing in the `%s` operation", operation.Op)
+	}
+	path, err := parseJSONPointer(*operation.Path)
+	if err != nil {
+		return nil, err
+	}
+	switch operation.Op {
+	case "add":
+		value, err := operation.valueNode()
+		if err != nil {
+			return nil, err
+		}
+		return jsonPatchAdd(root, path, value)
+	case "remove":
+		root, _, err := jsonPatchRemove(root, path)
+		return root, err
+	case "replace":
+		value, err := operation.valueNode()
+		if err != nil {
+			return nil, err
+		}
+		return jsonPatchReplace(root, path, value)
+	case "move":
+		from, err := operation.fromPath()
+		if err != nil {
+			return nil, err
+		}
+		if len(from) < len(path) && slices.Equal(from, path[:len(from)]) {
+			return nil, fmt.Errorf("a location can't be moved into one of its children: from %q to %q", *operation.From, *operation.Path)
+		}
+		root, value, err := jsonPatchRemove(root, from)
+		if err != nil {
+			return nil, err
+		}
+		return jsonPatchAdd(root, path, value)
+	case "copy":
+		from, err := operation.fromPath()
+		if err != nil {
+			return nil, err
+		}
+		value, err := jsonPatchGet(root, from)
+		if err != nil {
+			return nil, err
+		}
+		value, err = cloneStandardNodeFromNode(value)
+		if err != nil {
+			return nil, err
+		}
+		return jsonPatchAdd(root, path, value)
+	case "test":
+		value, err := operation.valueNode()
+		if err != nil {
+			return nil, err
+		}
+		current, err := jsonPatchGet(root, path)
+		if err != nil {
+			return nil, err
+		}
+		equal, err := jsonNodeEqual(current, value)
+		if err != nil {
+			return nil, err
+		}
+		if !equal {
+			return nil, fmt.Errorf("%w: the value at %q is not equal to the given value", ErrJSONPatchTestFailed, *operation.Path)
+		}
+		return root, nil
+	default:
+		return nil, fmt.Errorf("unsupported json patch operation `%s`", operation.Op)
+	}
+}
+
+// valueNode returns the `value` member of the operation as a Node.
+func (o *jsonPatchOperation) valueNode() (Node, error) {
+	if o.Value == nil {
+		return nil, fmt.Errorf("`value` is missing in the `%s` operation", o.Op)
+	}
+	// JSON is a subset of YAML 1.2.
+	return FromYAML(string(o.Value))
+}
+
+// fromPath returns the parsed `from` member of the operation.
+func (o *jsonPatchOperation) fromPath() ([]string, error) {
+	if o.From == nil {
+		return nil, fmt.Errorf("`from` is missing in the `%s` operation", o.Op)
+	}
+	return parseJSONPointer(*o.From)
+}
+
+// parseJSONPointer parses a JSON Pointer https://datatracker.ietf.org/doc/html/rfc6901 into the unescaped reference tokens.
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("json pointer %q must start with `/`", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// parseJSONPointerIndex parses a reference token pointing an element of a sequence with the given length.
+// `-` pointing the position after the last element is accepted only when allowEnd is true.
+func parseJSONPointerIndex(token string, length int, allowEnd bool) (int, error) {
+	if token == "-" && allowEnd {
+		return length, nil
+	}
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid sequence index %q", token)
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 {
+		return 0, fmt.Errorf("invalid sequence index %q", token)
+	}
+	maxIndex := length - 1
+	if allowEnd {
+		maxIndex = length
+	}
+	if index > maxIndex {
+		return 0, fmt.Errorf("sequence index %d is out of range", index)
+	}
+	return index, nil
+}
+
+// jsonPatchGet returns the node at the path.
+func jsonPatchGet(root Node, path []string) (Node, error) {
+	current := root
+	for i, token := range path {
+		if current == nil {
+			return nil, fmt.Errorf("path %q is not found", "/"+strings.Join(path[:i], "/"))
+		}
+		switch node := current.(type) {
+		case *StandardMapNode:
+			index := slices.Index(node.keys, unique.Make(token))
+			if index == -1 {
+				return nil, fmt.Errorf("key %q is not found at %q", token, "/"+strings.Join(path[:i], "/"))
+			}
+			current = node.values[index]
+		case *StandardSequenceNode:
+			index, err := parseJSONPointerIndex(token, len(node.value), false)
+			if err != nil {
+				return nil, err
+			}
+			current = node.value[index]
+		default:
+			return nil, fmt.Errorf("scalar at %q has no children", "/"+strings.Join(path[:i], "/"))
+		}
+	}
+	if current == nil {
+		return nil, errors.New("the document doesn't exist")
+	}
+	return current, nil
+}
+
+// jsonPatchAdd adds the value at the path and returns the new root. An existing value in a map is replaced.
+func jsonPatchAdd(root Node, path []string, value Node) (Node, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := jsonPatchGet(root, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	token := path[len(path)-1]
+	switch node := parent.(type) {
+	case *StandardMapNode:
+		key := unique.Make(token)
+		if index := slices.Index(node.keys, key); index != -1 {
+			node.values[index] = value
+		} else {
+			node.keys = append(node.keys, key)
+			node.values = append(node.values, value)
+		}
+	case *StandardSequenceNode:
+		index, err := parseJSONPointerIndex(token, len(node.value), true)
+		if err != nil {
+			return nil, err
+		}
+		node.value = slices.Insert(node.value, index, value)
+	default:
+		return nil, fmt.Errorf("can't add a child to the scalar at %q", "/"+strings.Join(path[:len(path)-1], "/"))
+	}
+	return root, nil
+}
+
+// jsonPatchReplace replaces the existing value at the path without changing its position and returns the new root.
+func jsonPatchReplace(root Node, path []string, value Node) (Node, error) {
+	if len(path) == 0 {
+		if root == nil {
+			return nil, errors.New("the document doesn't exist")
+		}
+		return value, nil
+	}
+	parent, err := jsonPatchGet(root, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	token := path[len(path)-1]
+	switch node := parent.(type) {
+	case *StandardMapNode:
+		index := slices.Index(node.keys, unique.Make(token))
+		if index == -1 {
+			return nil, fmt.Errorf("key %q is not found at %q", token, "/"+strings.Join(path[:len(path)-1], "/"))
+		}
+		node.values[index] = value
+	case *StandardSequenceNode:
+		index, err := parseJSONPointerIndex(token, len(node.value), false)
+		if err != nil {
+			return nil, err
+		}
+		node.value[index] = value
+	default:
+		return nil, fmt.Errorf("scalar at %q has no children", "/"+strings.Join(path[:len(path)-1], "/"))
+	}
+	return root, nil
+}
+
+// jsonPatchRemove removes the value at the path and returns the new root and the removed value.
+func jsonPatchRemove(root Node, path []string) (Node, Node, error) {
+	if len(path) == 0 {
+		if root == nil {
+			return nil, nil, errors.New("the document doesn't exist")
+		}
+		return nil, root, nil
+	}
+	parent, err := jsonPatchGet(root, path[:len(path)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+	token := path[len(path)-1]
+	var removed Node
+	switch node := parent.(type) {
+	case *StandardMapNode:
+		index := slices.Index(node.keys, unique.Make(token))
+		if index == -1 {
+			return nil, nil, fmt.Errorf("key %q is not found at %q", token, "/"+strings.Join(path[:len(path)-1], "/"))
+		}
+		removed = node.values[index]
+		node.keys = deleteMapKey(node.keys, index)
+		node.values = slices.Delete(node.values, index, index+1)
+	case *StandardSequenceNode:
+		index, err := parseJSONPointerIndex(token, len(node.value), false)
+		if err != nil {
+			return nil, nil, err
+		}
+		removed = node.value[index]
+		node.value = slices.Delete(node.value, index, index+1)
+	default:
+		return nil, nil, fmt.Errorf("scalar at %q has no children", "/"+strings.Join(path[:len(path)-1], "/"))
+	}
+	return root, removed, nil
+}
+
+// jsonNodeEqual returns true when the 2 nodes are equal in JSON. Order of map keys are ignored and numbers are compared by their values.
+func jsonNodeEqual(a, b Node) (bool, error) {
+	if a.Type() != b.Type() || a.Len() != b.Len() {
+		return false, nil
+	}
+	switch a.Type() {
+	case ScalarNodeType:
+		aValue, err := a.NodeScalarValue()
+		if err != nil {
+			return false, err
+		}
+		bValue, err := b.NodeScalarValue()
+		if err != nil {
+			return false, err
+		}
+		return jsonScalarEqual(aValue, bValue), nil
+	case SequenceNodeType:
+		bChildren := make([]Node, 0, b.Len())
+		for _, child := range b.Children() {
+			bChildren = append(bChildren, child)
+		}
+		for key, child := range a.Children() {
+			equal, err := jsonNodeEqual(child, bChildren[key.Index])
+			if err != nil || !equal {
+				return false, err
+			}
+		}
+		return true, nil
+	case MapNodeType:
+		bChildren := make(map[string]Node, b.Len())
+		for key, child := range b.Children() {
+			bChildren[key.Key] = child
+		}
+		for key, child := range a.Children() {
+			bChild, found := bChildren[key.Key]
+			if !found {
+				return false, nil
+			}
+			equal, err := jsonNodeEqual(child, bChild)
+			if err != nil || !equal {
+				return false, err
+			}
+		}
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown node type: %v", a.Type())
+	}
+}
+
+// jsonScalarEqual compares scalar values with treating ints and floats as numbers and timestamps as their string representations.
+func jsonScalarEqual(a, b any) bool {
+	aNumber, aIsNumber := jsonNumber(a)
+	bNumber, bIsNumber := jsonNumber(b)
+	if aIsNumber || bIsNumber {
+		return aIsNumber && bIsNumber && aNumber == bNumber
+	}
+	if aTime, ok := a.(time.Time); ok {
+		a = aTime.Format(time.RFC3339)
+	}
+	if bTime, ok := b.(time.Time); ok {
+		b = bTime.Format(time.RFC3339)
+	}
+	return a == b
+}
+
+func jsonNumber(value any) (float64, bool) {
+	switch number := value.(type) {
+	case int:
+		return float64(number), true
+	case float64:
+		return number, true
+	default:
+		return 0, false
+	}
+}
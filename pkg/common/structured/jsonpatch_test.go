@@ -0,0 +1,178 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package structured
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestApplyJSONPatch(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Prev     string
+		Patch    string
+		Expected string
+		WantErr  bool
+	}{
+		{
+			Name:     "add a field to a map",
+			Prev:     "foo: bar\n",
+			Patch:    `[{"op":"add","path":"/baz","value":{"qux":1}}]`,
+			Expected: "foo: bar\nbaz:\n  qux: 1\n",
+		},
+		{
+			Name:     "add replaces an existing field",
+			Prev:     "foo: bar\n",
+			Patch:    `[{"op":"add","path":"/foo","value":"baz"}]`,
+			Expected: "foo: baz\n",
+		},
+		{
+			Name:     "add an element in a sequence and append with -",
+			Prev:     "foo:\n  - a\n  - c\n",
+			Patch:    `[{"op":"add","path":"/foo/1","value":"b"},{"op":"add","path":"/foo/-","value":"d"}]`,
+			Expected: "foo:\n  - a\n  - b\n  - c\n  - d\n",
+		},
+		{
+			Name:     "remove a field and an element",
+			Prev:     "foo:\n  - a\n  - b\nbar: 1\n",
+			Patch:    `[{"op":"remove","path":"/bar"},{"op":"remove","path":"/foo/0"}]`,
+			Expected: "foo:\n  - b\n",
+		},
+		{
+			Name:     "replace a field with escaped key",
+			Prev:     "metadata:\n  labels:\n    app.kubernetes.io/name: foo\n",
+			Patch:    `[{"op":"replace","path":"/metadata/labels/app.kubernetes.io~1name","value":"bar"}]`,
+			Expected: "metadata:\n  labels:\n    app.kubernetes.io/name: bar\n",
+		},
+		{
+			Name:    "replace a missing field",
+			Prev:    "foo: bar\n",
+			Patch:   `[{"op":"replace","path":"/baz","value":"qux"}]`,
+			WantErr: true,
+		},
+		{
+			Name:     "move a field",
+			Prev:     "foo:\n  bar: 1\nqux: {}\n",
+			Patch:    `[{"op":"move","from":"/foo/bar","path":"/qux/bar"}]`,
+			Expected: "foo: {}\nqux:\n  bar: 1\n",
+		},
+		{
+			Name:    "move a field into its child",
+			Prev:    "foo:\n  bar: 1\n",
+			Patch:   `[{"op":"move","from":"/foo","path":"/foo/bar"}]`,
+			WantErr: true,
+		},
+		{
+			Name:     "copy a field",
+			Prev:     "foo:\n  bar: 1\n",
+			Patch:    `[{"op":"copy","from":"/foo","path":"/baz"}]`,
+			Expected: "foo:\n  bar: 1\nbaz:\n  bar: 1\n",
+		},
+		{
+			Name:     "test passes before a replace",
+			Prev:     "foo:\n  a: 1\n  b: [1, 2]\n",
+			Patch:    `[{"op":"test","path":"/foo","value":{"b":[1,2.0],"a":1}},{"op":"replace","path":"/foo/a","value":2}]`,
+			Expected: "foo:\n  a: 2\n  b:\n    - 1\n    - 2\n",
+		},
+		{
+			Name:    "test fails",
+			Prev:    "foo: bar\n",
+			Patch:   `[{"op":"test","path":"/foo","value":"baz"}]`,
+			WantErr: true,
+		},
+		{
+			Name:     "replace the whole document",
+			Prev:     "foo: bar\n",
+			Patch:    `[{"op":"replace","path":"","value":{"baz":"qux"}}]`,
+			Expected: "baz: qux\n",
+		},
+		{
+			Name:     "add the whole document to nil",
+			Patch:    `[{"op":"add","path":"","value":{"foo":"bar"}}]`,
+			Expected: "foo: bar\n",
+		},
+		{
+			Name:    "index with a leading zero",
+			Prev:    "foo:\n  - a\n  - b\n",
+			Patch:   `[{"op":"remove","path":"/foo/01"}]`,
+			WantErr: true,
+		},
+		{
+			Name:    "unknown operation",
+			Prev:    "foo: bar\n",
+			Patch:   `[{"op":"merge","path":"/foo","value":"baz"}]`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var prevNode Node
+			var err error
+			if tc.Prev != "" {
+				prevNode, err = FromYAML(tc.Prev)
+				if err != nil {
+					t.Fatalf("failed to parse prev node yaml %v", err)
+				}
+			}
+			var prevYAML []byte
+			if prevNode != nil {
+				prevYAML, err = NewNodeReader(prevNode).Serialize("", &YAMLNodeSerializer{})
+				if err != nil {
+					t.Fatalf("failed to serialize prev node %v", err)
+				}
+			}
+			got, err := ApplyJSONPatch(prevNode, []byte(tc.Patch))
+			if tc.WantErr {
+				if err == nil {
+					t.Fatalf("ApplyJSONPatch() returned no error, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ApplyJSONPatch() returned an unexpected error: %v", err)
+			}
+			gotYAML, err := NewNodeReader(got).Serialize("", &YAMLNodeSerializer{})
+			if err != nil {
+				t.Fatalf("failed to serialize result to yaml %v", err)
+			}
+			if diff := cmp.Diff(tc.Expected, string(gotYAML)); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+			if prevNode != nil {
+				afterYAML, err := NewNodeReader(prevNode).Serialize("", &YAMLNodeSerializer{})
+				if err != nil {
+					t.Fatalf("failed to serialize prev node %v", err)
+				}
+				if diff := cmp.Diff(string(prevYAML), string(afterYAML)); diff != "" {
+					t.Errorf("the previous node was modified (-before +after):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyJSONPatchTestFailedError(t *testing.T) {
+	prevNode, err := FromYAML("foo: bar\n")
+	if err != nil {
+		t.Fatalf("failed to parse prev node yaml %v", err)
+	}
+	_, err = ApplyJSONPatch(prevNode, []byte(`[{"op":"test","path":"/foo","value":"baz"}]`))
+	if !errors.Is(err, ErrJSONPatchTestFailed) {
+		t.Errorf("ApplyJSONPatch() returned %v, want ErrJSONPatchTestFailed", err)
+	}
+}
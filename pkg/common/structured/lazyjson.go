@@ -0,0 +1,217 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package structured
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// ErrInvalidJSON is returned when the bytes given to FromJSONLazy is not a valid JSON.
+var ErrInvalidJSON = errors.New("invalid JSON")
+
+// lazyJSONNode is a Node backed by the raw bytes of a JSON value.
+// Children of a map or a sequence are located on the first access to them and their values are parsed only when they are read.
+// Readers only reading a few fields from a large JSON don't need to decode the other fields.
+type lazyJSONNode struct {
+	// raw is the bytes of the JSON value without surrounding whitespaces.
+	raw []byte
+
+	childrenOnce sync.Once
+	keys         []string
+	children     []Node
+}
+
+// FromJSONLazy returns a Node backed by the given JSON bytes. The bytes must not be modified after calling this function.
+// The JSON is validated at first, but the values are parsed on demand.
+// Numbers without fractions or exponents are read as int and the others are read as float64, same as FromYAML.
+func FromJSONLazy(raw []byte) (Node, error) {
+	if !json.Valid(raw) {
+		return nil, ErrInvalidJSON
+	}
+	return &lazyJSONNode{raw: bytes.TrimSpace(raw)}, nil
+}
+
+// Type implements Node.
+func (n *lazyJSONNode) Type() NodeType {
+	switch n.raw[0] {
+	case '{':
+		return MapNodeType
+	case '[':
+		return SequenceNodeType
+	default:
+		return ScalarNodeType
+	}
+}
+
+// NodeScalarValue implements Node.
+func (n *lazyJSONNode) NodeScalarValue() (any, error) {
+	switch n.raw[0] {
+	case '{', '[':
+		return nil, ErrNonScalarNode
+	case 'n':
+		return nil, nil
+	case 't':
+		return true, nil
+	case 'f':
+		return false, nil
+	case '"':
+		return unquoteJSONString(n.raw)
+	default:
+		if bytes.ContainsAny(n.raw, ".eE") {
+			return strconv.ParseFloat(string(n.raw), 64)
+		}
+		value, err := strconv.Atoi(string(n.raw))
+		if err != nil {
+			// Integers overflowing int are read as float64 same as YAML.
+			return strconv.ParseFloat(string(n.raw), 64)
+		}
+		return value, nil
+	}
+}
+
+// Children implements Node.
+func (n *lazyJSONNode) Children() NodeChildrenIterator {
+	return func(yield func(key NodeChildrenKey, value Node) bool) {
+		n.locateChildren()
+		for i, child := range n.children {
+			key := NodeChildrenKey{Index: i}
+			if n.keys != nil {
+				key.Key = n.keys[i]
+			}
+			if !yield(key, child) {
+				return
+			}
+		}
+	}
+}
+
+// Len implements Node.
+func (n *lazyJSONNode) Len() int {
+	n.locateChildren()
+	return len(n.children)
+}
+
+// locateChildren finds the ranges of the children in the raw bytes. This runs only once for a node.
+func (n *lazyJSONNode) locateChildren() {
+	n.childrenOnce.Do(func() {
+		nodeType := n.Type()
+		if nodeType == ScalarNodeType {
+			return
+		}
+		if nodeType == MapNodeType {
+			n.keys = []string{}
+		}
+		// The bytes are validated in FromJSONLazy, thus the scanning below doesn't need to handle malformed JSON.
+		cursor := skipJSONWhitespace(n.raw, 1)
+		for n.raw[cursor] != '}' && n.raw[cursor] != ']' {
+			if nodeType == MapNodeType {
+				keyEnd := skipJSONValue(n.raw, cursor)
+				key, _ := unquoteJSONString(n.raw[cursor:keyEnd])
+				n.keys = append(n.keys, key)
+				cursor = skipJSONWhitespace(n.raw, keyEnd)
+				cursor = skipJSONWhitespace(n.raw, cursor+1) // skip ':'
+			}
+			valueEnd := skipJSONValue(n.raw, cursor)
+			n.children = append(n.children, &lazyJSONNode{raw: n.raw[cursor:valueEnd]})
+			cursor = skipJSONWhitespace(n.raw, valueEnd)
+			if n.raw[cursor] == ',' {
+				cursor = skipJSONWhitespace(n.raw, cursor+1)
+			}
+		}
+	})
+}
+
+// unquoteJSONString decodes a JSON string literal. It avoids the decoder when the string has no escape sequence.
+func unquoteJSONString(raw []byte) (string, error) {
+	if bytes.IndexByte(raw, '\\') == -1 {
+		return string(raw[1 : len(raw)-1]), nil
+	}
+	var result string
+	err := json.Unmarshal(raw, &result)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode a JSON string: %w", err)
+	}
+	return result, nil
+}
+
+func skipJSONWhitespace(raw []byte, cursor int) int {
+	for cursor < len(raw) {
+		switch raw[cursor] {
+		case ' ', '\t', '\n', '\r':
+			cursor++
+		default:
+			return cursor
+		}
+	}
+	return cursor
+}
+
+// skipJSONValue returns the index right after the JSON value starting at the cursor.
+func skipJSONValue(raw []byte, cursor int) int {
+	switch raw[cursor] {
+	case '"':
+		return skipJSONString(raw, cursor)
+	case '{', '[':
+		depth := 0
+		for cursor < len(raw) {
+			switch raw[cursor] {
+			case '"':
+				cursor = skipJSONString(raw, cursor)
+				continue
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+				if depth == 0 {
+					return cursor + 1
+				}
+			}
+			cursor++
+		}
+		return cursor
+	default:
+		for cursor < len(raw) {
+			switch raw[cursor] {
+			case ',', '}', ']', ' ', '\t', '\n', '\r':
+				return cursor
+			}
+			cursor++
+		}
+		return cursor
+	}
+}
+
+// skipJSONString returns the index right after the JSON string starting at the cursor.
+func skipJSONString(raw []byte, cursor int) int {
+	cursor++
+	for cursor < len(raw) {
+		switch raw[cursor] {
+		case '\\':
+			cursor += 2
+			continue
+		case '"':
+			return cursor + 1
+		}
+		cursor++
+	}
+	return cursor
+}
+
+var _ Node = (*lazyJSONNode)(nil)
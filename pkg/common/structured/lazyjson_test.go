@@ -0,0 +1,134 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package structured
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFromJSONLazy(t *testing.T) {
+	testCases := []struct {
+		Name  string
+		Input string
+	}{
+		{
+			Name:  "scalars",
+			Input: `{"null":null,"bool":true,"false":false,"int":-42,"float":3.5,"exp":1e3,"string":"foo","escaped":"a\"b\\cé","time":"2025-01-01T00:00:00Z"}`,
+		},
+		{
+			Name: "nested structures with whitespaces",
+			Input: ` {
+  "metadata": {"name": "foo", "labels": {"app.kubernetes.io/name": "bar"}},
+  "spec": {"containers": [ {"name": "a", "args": ["--x=}", "]"]}, {"name": "b"} ], "empty": {}, "emptyList": []},
+  "key with \"quote\"": 1
+} `,
+		},
+		{
+			Name:  "sequence root",
+			Input: `[1, "two", [3], {"four": 4}]`,
+		},
+		{
+			Name:  "scalar root",
+			Input: `"foo"`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			lazy, err := FromJSONLazy([]byte(tc.Input))
+			if err != nil {
+				t.Fatalf("FromJSONLazy() returned an unexpected error: %v", err)
+			}
+			standard, err := FromYAML(tc.Input)
+			if err != nil {
+				t.Fatalf("FromYAML() returned an unexpected error: %v", err)
+			}
+			gotYAML, err := NewNodeReader(lazy).Serialize("", &YAMLNodeSerializer{})
+			if err != nil {
+				t.Fatalf("failed to serialize the lazy node: %v", err)
+			}
+			wantYAML, err := NewNodeReader(standard).Serialize("", &YAMLNodeSerializer{})
+			if err != nil {
+				t.Fatalf("failed to serialize the standard node: %v", err)
+			}
+			if diff := cmp.Diff(string(wantYAML), string(gotYAML)); diff != "" {
+				t.Errorf("lazy node must be read same as FromYAML (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFromJSONLazyReader(t *testing.T) {
+	node, err := FromJSONLazy([]byte(`{"objectRef":{"resource":"pods","name":"foo"},"requestObject":{"spec":{"containers":[{"name":"a"},{"name":"b"}]}},"stageTimestamp":"2025-01-01T00:00:00.123456Z"}`))
+	if err != nil {
+		t.Fatalf("FromJSONLazy() returned an unexpected error: %v", err)
+	}
+	reader := NewNodeReader(node)
+	if got := reader.ReadStringOrDefault("objectRef.resource", ""); got != "pods" {
+		t.Errorf("objectRef.resource = %q, want pods", got)
+	}
+	if got := reader.ReadStringOrDefault("requestObject.spec.containers[1].name", ""); got != "b" {
+		t.Errorf("containers[1].name = %q, want b", got)
+	}
+	timestamp, err := reader.ReadTimestamp("stageTimestamp")
+	if err != nil || timestamp.Nanosecond() != 123456000 {
+		t.Errorf("ReadTimestamp() = %v, %v", timestamp, err)
+	}
+	if _, err := reader.ReadString("objectRef.missing"); err != ErrFieldNotFound {
+		t.Errorf("ReadString() for a missing field returned %v, want ErrFieldNotFound", err)
+	}
+}
+
+func TestFromJSONLazyInvalid(t *testing.T) {
+	for _, input := range []string{``, `{"foo":}`, `{"foo":"bar"`, `foo: bar`} {
+		if _, err := FromJSONLazy([]byte(input)); err != ErrInvalidJSON {
+			t.Errorf("FromJSONLazy(%q) returned %v, want ErrInvalidJSON", input, err)
+		}
+	}
+}
+
+func BenchmarkReadFieldFromLargeJSON(b *testing.B) {
+	items := make([]byte, 0, 1<<20)
+	items = append(items, `{"objectRef":{"resource":"pods"},"responseObject":{"items":[`...)
+	for i := 0; i < 2000; i++ {
+		if i > 0 {
+			items = append(items, ',')
+		}
+		items = append(items, `{"metadata":{"name":"foo","namespace":"default","labels":{"app":"foo","tier":"web"}},"spec":{"containers":[{"name":"a","image":"nginx"}]}}`...)
+	}
+	items = append(items, `]}}`...)
+	input := string(items)
+	b.Run("FromYAML", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			node, err := FromYAML(input)
+			if err != nil {
+				b.Fatal(err.Error())
+			}
+			NewNodeReader(node).ReadStringOrDefault("objectRef.resource", "")
+		}
+	})
+	b.Run("FromJSONLazy", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			node, err := FromJSONLazy(items)
+			if err != nil {
+				b.Fatal(err.Error())
+			}
+			NewNodeReader(node).ReadStringOrDefault("objectRef.resource", "")
+		}
+	})
+}
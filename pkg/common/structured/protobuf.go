@@ -0,0 +1,306 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package structured
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+	"unique"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Field numbers defined in node.proto.
+const (
+	protoNodeScalarField   protowire.Number = 1
+	protoNodeSequenceField protowire.Number = 2
+	protoNodeMapField      protowire.Number = 3
+
+	protoScalarNullField      protowire.Number = 1
+	protoScalarBoolField      protowire.Number = 2
+	protoScalarStringField    protowire.Number = 3
+	protoScalarIntField       protowire.Number = 4
+	protoScalarFloatField     protowire.Number = 5
+	protoScalarTimestampField protowire.Number = 6
+
+	protoTimestampSecondsField protowire.Number = 1
+	protoTimestampNanosField   protowire.Number = 2
+
+	protoSequenceElementsField protowire.Number = 1
+
+	protoMapEntriesField protowire.Number = 1
+
+	protoMapEntryKeyField   protowire.Number = 1
+	protoMapEntryValueField protowire.Number = 2
+)
+
+// ProtobufNodeSerializer serializes a Node into the `khi.structured.v1.Node` message defined in node.proto.
+type ProtobufNodeSerializer struct{}
+
+// Serialize implements NodeSerializer.
+func (p *ProtobufNodeSerializer) Serialize(node Node) ([]byte, error) {
+	return appendProtoNode(nil, node)
+}
+
+var _ NodeSerializer = (*ProtobufNodeSerializer)(nil)
+
+// FromProtobuf instanciates the Node interface from the `khi.structured.v1.Node` message serialized by ProtobufNodeSerializer.
+// Unknown fields written by newer versions are ignored.
+func FromProtobuf(data []byte) (Node, error) {
+	return consumeProtoNode(data)
+}
+
+func appendProtoNode(b []byte, node Node) ([]byte, error) {
+	switch node.Type() {
+	case ScalarNodeType:
+		value, err := node.NodeScalarValue()
+		if err != nil {
+			return nil, err
+		}
+		scalar, err := appendProtoScalar(nil, value)
+		if err != nil {
+			return nil, err
+		}
+		b = protowire.AppendTag(b, protoNodeScalarField, protowire.BytesType)
+		return protowire.AppendBytes(b, scalar), nil
+	case SequenceNodeType:
+		var sequence []byte
+		for _, child := range node.Children() {
+			element, err := appendProtoNode(nil, child)
+			if err != nil {
+				return nil, err
+			}
+			sequence = protowire.AppendTag(sequence, protoSequenceElementsField, protowire.BytesType)
+			sequence = protowire.AppendBytes(sequence, element)
+		}
+		b = protowire.AppendTag(b, protoNodeSequenceField, protowire.BytesType)
+		return protowire.AppendBytes(b, sequence), nil
+	case MapNodeType:
+		var mapMessage []byte
+		for key, child := range node.Children() {
+			value, err := appendProtoNode(nil, child)
+			if err != nil {
+				return nil, err
+			}
+			var entry []byte
+			entry = protowire.AppendTag(entry, protoMapEntryKeyField, protowire.BytesType)
+			entry = protowire.AppendString(entry, key.Key)
+			entry = protowire.AppendTag(entry, protoMapEntryValueField, protowire.BytesType)
+			entry = protowire.AppendBytes(entry, value)
+			mapMessage = protowire.AppendTag(mapMessage, protoMapEntriesField, protowire.BytesType)
+			mapMessage = protowire.AppendBytes(mapMessage, entry)
+		}
+		b = protowire.AppendTag(b, protoNodeMapField, protowire.BytesType)
+		return protowire.AppendBytes(b, mapMessage), nil
+	default:
+		return nil, fmt.Errorf("unknown node type: %v", node.Type())
+	}
+}
+
+func appendProtoScalar(b []byte, value any) ([]byte, error) {
+	switch v := value.(type) {
+	case nil:
+		b = protowire.AppendTag(b, protoScalarNullField, protowire.VarintType)
+		return protowire.AppendVarint(b, protowire.EncodeBool(true)), nil
+	case bool:
+		b = protowire.AppendTag(b, protoScalarBoolField, protowire.VarintType)
+		return protowire.AppendVarint(b, protowire.EncodeBool(v)), nil
+	case string:
+		b = protowire.AppendTag(b, protoScalarStringField, protowire.BytesType)
+		return protowire.AppendString(b, v), nil
+	case int:
+		b = protowire.AppendTag(b, protoScalarIntField, protowire.VarintType)
+		return protowire.AppendVarint(b, uint64(int64(v))), nil
+	case float64:
+		b = protowire.AppendTag(b, protoScalarFloatField, protowire.Fixed64Type)
+		return protowire.AppendFixed64(b, math.Float64bits(v)), nil
+	case time.Time:
+		var timestamp []byte
+		timestamp = protowire.AppendTag(timestamp, protoTimestampSecondsField, protowire.VarintType)
+		timestamp = protowire.AppendVarint(timestamp, uint64(v.Unix()))
+		timestamp = protowire.AppendTag(timestamp, protoTimestampNanosField, protowire.VarintType)
+		timestamp = protowire.AppendVarint(timestamp, uint64(int64(v.Nanosecond())))
+		b = protowire.AppendTag(b, protoScalarTimestampField, protowire.BytesType)
+		return protowire.AppendBytes(b, timestamp), nil
+	default:
+		return nil, fmt.Errorf("unsupported scalar type: %T", value)
+	}
+}
+
+// consumeProtoFields calls the handler for each field in the message. Values of length delimited fields are given as the bytes and the others are given as the varint or fixed64 value.
+func consumeProtoFields(data []byte, handler func(number protowire.Number, wireType protowire.Type, bytes []byte, value uint64) error) error {
+	for len(data) > 0 {
+		number, wireType, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+		var bytes []byte
+		var value uint64
+		switch wireType {
+		case protowire.BytesType:
+			bytes, n = protowire.ConsumeBytes(data)
+		case protowire.VarintType:
+			value, n = protowire.ConsumeVarint(data)
+		case protowire.Fixed64Type:
+			value, n = protowire.ConsumeFixed64(data)
+		default:
+			n = protowire.ConsumeFieldValue(number, wireType, data)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+		err := handler(number, wireType, bytes, value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func consumeProtoNode(data []byte) (Node, error) {
+	var result Node
+	err := consumeProtoFields(data, func(number protowire.Number, wireType protowire.Type, bytes []byte, value uint64) error {
+		if wireType != protowire.BytesType {
+			return nil
+		}
+		var err error
+		switch number {
+		case protoNodeScalarField:
+			result, err = consumeProtoScalar(bytes)
+		case protoNodeSequenceField:
+			result, err = consumeProtoSequence(bytes)
+		case protoNodeMapField:
+			result, err = consumeProtoMap(bytes)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, errors.New("node message has no value")
+	}
+	return result, nil
+}
+
+func consumeProtoScalar(data []byte) (Node, error) {
+	var result Node = newInternedScalarNode(nil)
+	err := consumeProtoFields(data, func(number protowire.Number, wireType protowire.Type, bytes []byte, value uint64) error {
+		switch {
+		case number == protoScalarNullField && wireType == protowire.VarintType:
+			result = newInternedScalarNode(nil)
+		case number == protoScalarBoolField && wireType == protowire.VarintType:
+			result = newInternedScalarNode(protowire.DecodeBool(value))
+		case number == protoScalarStringField && wireType == protowire.BytesType:
+			result = newInternedStringScalarNode(string(bytes))
+		case number == protoScalarIntField && wireType == protowire.VarintType:
+			result = NewStandardScalarNode(int(int64(value)))
+		case number == protoScalarFloatField && wireType == protowire.Fixed64Type:
+			result = NewStandardScalarNode(math.Float64frombits(value))
+		case number == protoScalarTimestampField && wireType == protowire.BytesType:
+			timestamp, err := consumeProtoTimestamp(bytes)
+			if err != nil {
+				return err
+			}
+			result = NewStandardScalarNode(timestamp)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func consumeProtoTimestamp(data []byte) (time.Time, error) {
+	var seconds, nanos int64
+	err := consumeProtoFields(data, func(number protowire.Number, wireType protowire.Type, bytes []byte, value uint64) error {
+		if wireType != protowire.VarintType {
+			return nil
+		}
+		switch number {
+		case protoTimestampSecondsField:
+			seconds = int64(value)
+		case protoTimestampNanosField:
+			nanos = int64(int32(value))
+		}
+		return nil
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(seconds, nanos).UTC(), nil
+}
+
+func consumeProtoSequence(data []byte) (Node, error) {
+	result := &StandardSequenceNode{value: []Node{}}
+	err := consumeProtoFields(data, func(number protowire.Number, wireType protowire.Type, bytes []byte, value uint64) error {
+		if number != protoSequenceElementsField || wireType != protowire.BytesType {
+			return nil
+		}
+		element, err := consumeProtoNode(bytes)
+		if err != nil {
+			return err
+		}
+		result.value = append(result.value, element)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func consumeProtoMap(data []byte) (Node, error) {
+	result := &StandardMapNode{keys: []unique.Handle[string]{}, values: []Node{}}
+	err := consumeProtoFields(data, func(number protowire.Number, wireType protowire.Type, bytes []byte, value uint64) error {
+		if number != protoMapEntriesField || wireType != protowire.BytesType {
+			return nil
+		}
+		var key string
+		var entryValue Node
+		err := consumeProtoFields(bytes, func(number protowire.Number, wireType protowire.Type, bytes []byte, value uint64) error {
+			if wireType != protowire.BytesType {
+				return nil
+			}
+			var err error
+			switch number {
+			case protoMapEntryKeyField:
+				key = string(bytes)
+			case protoMapEntryValueField:
+				entryValue, err = consumeProtoNode(bytes)
+			}
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if entryValue == nil {
+			// A missing message field is the default value. Treat it as null same as an empty Scalar.
+			entryValue = NewStandardScalarNode[any](nil)
+		}
+		result.keys = append(result.keys, unique.Make(key))
+		result.values = append(result.values, entryValue)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,208 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package structured
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// nodeProtoDescriptor is the descriptor equivalent to node.proto used to verify the wire format with the protobuf runtime.
+const nodeProtoDescriptor = `
+name: "node.proto"
+package: "khi.structured.v1"
+dependency: "google/protobuf/timestamp.proto"
+syntax: "proto3"
+message_type {
+  name: "Node"
+  field { name: "scalar" number: 1 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".khi.structured.v1.Scalar" oneof_index: 0 json_name: "scalar" }
+  field { name: "sequence" number: 2 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".khi.structured.v1.Sequence" oneof_index: 0 json_name: "sequence" }
+  field { name: "map" number: 3 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".khi.structured.v1.Map" oneof_index: 0 json_name: "map" }
+  oneof_decl { name: "node" }
+}
+message_type {
+  name: "Scalar"
+  field { name: "null_value" number: 1 label: LABEL_OPTIONAL type: TYPE_BOOL oneof_index: 0 json_name: "nullValue" }
+  field { name: "bool_value" number: 2 label: LABEL_OPTIONAL type: TYPE_BOOL oneof_index: 0 json_name: "boolValue" }
+  field { name: "string_value" number: 3 label: LABEL_OPTIONAL type: TYPE_STRING oneof_index: 0 json_name: "stringValue" }
+  field { name: "int_value" number: 4 label: LABEL_OPTIONAL type: TYPE_INT64 oneof_index: 0 json_name: "intValue" }
+  field { name: "float_value" number: 5 label: LABEL_OPTIONAL type: TYPE_DOUBLE oneof_index: 0 json_name: "floatValue" }
+  field { name: "timestamp_value" number: 6 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".google.protobuf.Timestamp" oneof_index: 0 json_name: "timestampValue" }
+  oneof_decl { name: "value" }
+}
+message_type {
+  name: "Sequence"
+  field { name: "elements" number: 1 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".khi.structured.v1.Node" json_name: "elements" }
+}
+message_type {
+  name: "Map"
+  field { name: "entries" number: 1 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".khi.structured.v1.MapEntry" json_name: "entries" }
+}
+message_type {
+  name: "MapEntry"
+  field { name: "key" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "key" }
+  field { name: "value" number: 2 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".khi.structured.v1.Node" json_name: "value" }
+}
+`
+
+func newNodeProtoMessageType(t *testing.T) protoreflect.MessageType {
+	t.Helper()
+	fileProto := &descriptorpb.FileDescriptorProto{}
+	err := prototext.Unmarshal([]byte(nodeProtoDescriptor), fileProto)
+	if err != nil {
+		t.Fatalf("failed to parse the descriptor: %v", err)
+	}
+	file, err := protodesc.NewFile(fileProto, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatalf("failed to build the descriptor: %v", err)
+	}
+	return dynamicpb.NewMessageType(file.Messages().ByName("Node"))
+}
+
+func TestProtobufNodeSerializer(t *testing.T) {
+	testCases := []struct {
+		Name  string
+		Input string
+	}{
+		{
+			Name: "scalar types",
+			Input: `nil: null
+bool: true
+int: -42
+float: 3.5
+string: foo
+time: 2025-01-01T12:00:00.123456789Z
+`,
+		},
+		{
+			Name: "nested structures with key order",
+			Input: `z: 1
+a:
+  - name: foo
+    ports: []
+  - name: bar
+    labels: {}
+m: [[1, 2], [3]]
+`,
+		},
+		{
+			Name:  "scalar root",
+			Input: `foo`,
+		},
+	}
+	messageType := newNodeProtoMessageType(t)
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			node, err := FromYAML(tc.Input)
+			if err != nil {
+				t.Fatalf("failed to parse yaml: %v", err)
+			}
+			serialized, err := (&ProtobufNodeSerializer{}).Serialize(node)
+			if err != nil {
+				t.Fatalf("Serialize() returned an unexpected error: %v", err)
+			}
+			got, err := FromProtobuf(serialized)
+			if err != nil {
+				t.Fatalf("FromProtobuf() returned an unexpected error: %v", err)
+			}
+			wantYAML, err := NewNodeReader(node).Serialize("", &YAMLNodeSerializer{})
+			if err != nil {
+				t.Fatalf("failed to serialize the original node: %v", err)
+			}
+			gotYAML, err := NewNodeReader(got).Serialize("", &YAMLNodeSerializer{})
+			if err != nil {
+				t.Fatalf("failed to serialize the deserialized node: %v", err)
+			}
+			if diff := cmp.Diff(string(wantYAML), string(gotYAML)); diff != "" {
+				t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+			}
+
+			// The serialized data must be readable as the message defined in node.proto, and the message re-serialized by the protobuf runtime must be readable with FromProtobuf.
+			message := messageType.New().Interface()
+			err = proto.UnmarshalOptions{DiscardUnknown: false}.Unmarshal(serialized, message)
+			if err != nil {
+				t.Fatalf("failed to unmarshal with the protobuf runtime: %v", err)
+			}
+			if unknown := message.ProtoReflect().GetUnknown(); len(unknown) > 0 {
+				t.Errorf("the protobuf runtime found unknown fields %v", unknown)
+			}
+			reserialized, err := proto.Marshal(message)
+			if err != nil {
+				t.Fatalf("failed to marshal with the protobuf runtime: %v", err)
+			}
+			got, err = FromProtobuf(reserialized)
+			if err != nil {
+				t.Fatalf("FromProtobuf() returned an unexpected error for the data from the protobuf runtime: %v", err)
+			}
+			gotYAML, err = NewNodeReader(got).Serialize("", &YAMLNodeSerializer{})
+			if err != nil {
+				t.Fatalf("failed to serialize the deserialized node: %v", err)
+			}
+			if diff := cmp.Diff(string(wantYAML), string(gotYAML)); diff != "" {
+				t.Errorf("round trip via the protobuf runtime mismatch (-want +got):\n%s\n%s", diff, protojson.Format(message))
+			}
+		})
+	}
+}
+
+func TestProtobufScalarTypes(t *testing.T) {
+	timestamp := time.Date(2025, 1, 1, 12, 0, 0, 123456789, time.UTC)
+	for _, value := range []any{nil, true, "foo", -42, 3.5, timestamp} {
+		serialized, err := (&ProtobufNodeSerializer{}).Serialize(NewStandardScalarNode(value))
+		if err != nil {
+			t.Fatalf("Serialize(%v) returned an unexpected error: %v", value, err)
+		}
+		node, err := FromProtobuf(serialized)
+		if err != nil {
+			t.Fatalf("FromProtobuf() returned an unexpected error: %v", err)
+		}
+		got, err := node.NodeScalarValue()
+		if err != nil {
+			t.Fatalf("NodeScalarValue() returned an unexpected error: %v", err)
+		}
+		if got != value {
+			t.Errorf("scalar value = %#v, want %#v", got, value)
+		}
+	}
+}
+
+func TestFromProtobufIgnoresUnknownFields(t *testing.T) {
+	serialized, err := (&ProtobufNodeSerializer{}).Serialize(NewStandardMap([]string{"foo"}, []Node{NewStandardScalarNode("bar")}))
+	if err != nil {
+		t.Fatalf("Serialize() returned an unexpected error: %v", err)
+	}
+	// Add a field a newer version may add to Node.
+	serialized = protowire.AppendTag(serialized, 100, protowire.BytesType)
+	serialized = protowire.AppendString(serialized, "unknown")
+	node, err := FromProtobuf(serialized)
+	if err != nil {
+		t.Fatalf("FromProtobuf() returned an unexpected error: %v", err)
+	}
+	if got := NewNodeReader(node).ReadStringOrDefault("foo", ""); got != "bar" {
+		t.Errorf("foo = %q, want bar", got)
+	}
+}
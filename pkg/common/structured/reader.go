@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -163,17 +164,122 @@ func (n *NodeReader) ReadBoolOrDefault(fieldPath string, defaultValue bool) bool
 	return getScalarValueOrDefaultAt(fieldPath, defaultValue, n)
 }
 
+// ReadStringSlice retrieves a sequence of string values from the specified field path.
+// Returns an error if the field doesn't exist, is not a sequence or any of its elements cannot be cast to a string.
+// A null or empty sequence is read as a nil slice.
+func (n *NodeReader) ReadStringSlice(fieldPath string) ([]string, error) {
+	return getSequenceValuesAt[string](fieldPath, n)
+}
+
+// ReadIntSlice retrieves a sequence of integer values from the specified field path.
+// Returns an error if the field doesn't exist, is not a sequence or any of its elements cannot be cast to an integer.
+// A null or empty sequence is read as a nil slice.
+func (n *NodeReader) ReadIntSlice(fieldPath string) ([]int, error) {
+	return getSequenceValuesAt[int](fieldPath, n)
+}
+
+// ReadStringMap retrieves a map of string values from the specified field path.
+// Returns an error if the field doesn't exist, is not a map or any of its values cannot be cast to a string.
+// A null or empty map is read as an empty map.
+func (n *NodeReader) ReadStringMap(fieldPath string) (map[string]string, error) {
+	node, err := n.getNode(fieldPath)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]string{}
+	if isNullNode(node) {
+		return result, nil
+	}
+	if node.Type() != MapNodeType {
+		return nil, fmt.Errorf("field %q is not a map", fieldPath)
+	}
+	for key, child := range node.Children() {
+		value, err := getScalarAs[string](child)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the value of key %q in %q: %w", key.Key, fieldPath, err)
+		}
+		result[key.Key] = value
+	}
+	return result, nil
+}
+
+// ReadStringSliceOrDefault retrieves a sequence of string values from the specified field path.
+// Returns the provided default value if the field doesn't exist or an error occurs.
+func (n *NodeReader) ReadStringSliceOrDefault(fieldPath string, defaultValue []string) []string {
+	value, err := n.ReadStringSlice(fieldPath)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// ReadIntSliceOrDefault retrieves a sequence of integer values from the specified field path.
+// Returns the provided default value if the field doesn't exist or an error occurs.
+func (n *NodeReader) ReadIntSliceOrDefault(fieldPath string, defaultValue []int) []int {
+	value, err := n.ReadIntSlice(fieldPath)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// ReadStringMapOrDefault retrieves a map of string values from the specified field path.
+// Returns the provided default value if the field doesn't exist or an error occurs.
+func (n *NodeReader) ReadStringMapOrDefault(fieldPath string, defaultValue map[string]string) map[string]string {
+	value, err := n.ReadStringMap(fieldPath)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getNode returns the node at the given field path.
+// A path segment can be followed by `[N]` to select the N-th element of a sequence or `[*]` to select all the elements.
+// When the path contains a wildcard, the returned node is a sequence of the all matched nodes. Elements not having the fields after the wildcard are ignored.
 func (n *NodeReader) getNode(fieldPath string) (Node, error) {
 	if fieldPath == "" {
 		return n.Node, nil
 	}
-	pathSegments := parseFieldPath(fieldPath)
-	currentNode := n.Node
-	for pathCursor := 0; pathCursor < len(pathSegments); pathCursor++ {
+	// Most of field paths don't contain any selector. Walk the keys directly not to pay the cost of handling selectors.
+	if !strings.Contains(fieldPath, "[") {
+		return getNodeByKeys(n.Node, fieldPath)
+	}
+	currentNodes := []Node{n.Node}
+	hasWildcard := false
+	for _, pathSegment := range parseFieldPath(fieldPath) {
+		key, selectors := parseFieldPathSegment(pathSegment)
+		var nextNodes []Node
+		for _, currentNode := range currentNodes {
+			nextNodes = append(nextNodes, selectNodes(currentNode, key, selectors)...)
+		}
+		if len(nextNodes) == 0 && !hasWildcard {
+			// currentNodes has only 1 node before any wildcard. The result can be empty only when the wildcard in this segment selected an empty sequence.
+			if !slices.Contains(selectors, fieldPathWildcard) {
+				return nil, ErrFieldNotFound
+			}
+			if _, found := childByKey(currentNodes[0], key); key != "" && !found {
+				return nil, ErrFieldNotFound
+			}
+		}
+		if slices.Contains(selectors, fieldPathWildcard) {
+			hasWildcard = true
+		}
+		currentNodes = nextNodes
+	}
+	if hasWildcard {
+		return &StandardSequenceNode{value: currentNodes}, nil
+	}
+	return currentNodes[0], nil
+}
+
+// getNodeByKeys returns the node at the field path consisting of only map keys.
+func getNodeByKeys(node Node, fieldPath string) (Node, error) {
+	currentNode := node
+	for _, key := range parseFieldPath(fieldPath) {
 		found := false
-		for key, value := range currentNode.Children() {
-			if key.Key == pathSegments[pathCursor] {
-				currentNode = value
+		for childKey, child := range currentNode.Children() {
+			if childKey.Key == key {
+				currentNode = child
 				found = true
 				break
 			}
@@ -185,6 +291,47 @@ func (n *NodeReader) getNode(fieldPath string) (Node, error) {
 	return currentNode, nil
 }
 
+// selectNodes returns the child with the key and then applies the index selectors to it.
+// It returns nil when no node matched.
+func selectNodes(node Node, key string, selectors []string) []Node {
+	current := []Node{node}
+	if key != "" || len(selectors) == 0 {
+		child, found := childByKey(node, key)
+		if !found {
+			return nil
+		}
+		current = []Node{child}
+	}
+	for _, selector := range selectors {
+		var next []Node
+		for _, node := range current {
+			if node.Type() != SequenceNodeType {
+				continue
+			}
+			index := -1
+			if selector != fieldPathWildcard {
+				index, _ = strconv.Atoi(selector)
+			}
+			for childKey, child := range node.Children() {
+				if index == -1 || childKey.Index == index {
+					next = append(next, child)
+				}
+			}
+		}
+		current = next
+	}
+	return current
+}
+
+func childByKey(node Node, key string) (Node, bool) {
+	for childKey, child := range node.Children() {
+		if childKey.Key == key {
+			return child, true
+		}
+	}
+	return nil, false
+}
+
 // ReadReflect unmarshal the strutured data into a given type after the gicen fieldPath.
 // TODO: ReadReflect currently marshals and unmarshals the strtucred data into the target.
 //
@@ -217,6 +364,36 @@ func ReadReflectK8sRuntimeObject[T runtime.Object](r *NodeReader, fieldPath stri
 	return nil
 }
 
+// EscapeFieldPathKey escapes the dots in a map key to use it as a segment of a field path.
+func EscapeFieldPathKey(key string) string {
+	return strings.ReplaceAll(key, ".", `\.`)
+}
+
+// fieldPathWildcard is the index selector in a field path matching all the elements of a sequence.
+const fieldPathWildcard = "*"
+
+// parseFieldPathSegment splits the trailing index selectors like `[0]` or `[*]` from a segment of a field path.
+// Brackets not containing a number or `*` are treated as a part of the key.
+func parseFieldPathSegment(segment string) (string, []string) {
+	var selectors []string
+	key := segment
+	for strings.HasSuffix(key, "]") {
+		open := strings.LastIndex(key, "[")
+		if open == -1 {
+			break
+		}
+		selector := key[open+1 : len(key)-1]
+		if selector != fieldPathWildcard {
+			if _, err := strconv.ParseUint(selector, 10, 64); err != nil {
+				break
+			}
+		}
+		selectors = append([]string{selector}, selectors...)
+		key = key[:open]
+	}
+	return key, selectors
+}
+
 // parseFieldPath splits a field path string according to specified rules.
 // It uses '.' as a delimiter, but '\.' is treated as an escaped literal dot.
 func parseFieldPath(s string) []string {
@@ -287,6 +464,37 @@ func getScalarAs[T any](scalarNode Node) (T, error) {
 	return *new(T), fmt.Errorf("failed to cast value %v to type %T", anyValue, *new(T))
 }
 
+func getSequenceValuesAt[T any](fieldPath string, nodeReader *NodeReader) ([]T, error) {
+	node, err := nodeReader.getNode(fieldPath)
+	if err != nil {
+		return nil, err
+	}
+	if isNullNode(node) {
+		return nil, nil
+	}
+	if node.Type() != SequenceNodeType {
+		return nil, fmt.Errorf("field %q is not a sequence", fieldPath)
+	}
+	var result []T
+	for key, child := range node.Children() {
+		value, err := getScalarAs[T](child)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the element at index %d in %q: %w", key.Index, fieldPath, err)
+		}
+		result = append(result, value)
+	}
+	return result, nil
+}
+
+// isNullNode returns true when the given node is a scalar node holding null.
+func isNullNode(node Node) bool {
+	if node.Type() != ScalarNodeType {
+		return false
+	}
+	value, err := node.NodeScalarValue()
+	return err == nil && value == nil
+}
+
 // getScalarAsString get the scalar node value as string.
 func getScalarAsString(scalarNode Node) (string, error) {
 	result, err := getScalarAs[string](scalarNode)
@@ -17,6 +17,8 @@ package structured
 import (
 	"testing"
 	"time"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestNodeReader(t *testing.T) {
@@ -272,6 +274,191 @@ complex:
 			t.Errorf("Expected %d objects, got %d", len(expectedObjects), objectCount)
 		}
 	})
+
+	t.Run("index selector", func(t *testing.T) {
+		value, err := reader.ReadString("complex.objects[1].name")
+		if err != nil {
+			t.Errorf("ReadString with an index failed: %v", err)
+		}
+		if value != "object2" {
+			t.Errorf("Expected 'object2', got %q", value)
+		}
+
+		value, err = reader.ReadString("array[0]")
+		if err != nil {
+			t.Errorf("ReadString with an index at the last segment failed: %v", err)
+		}
+		if value != "item1" {
+			t.Errorf("Expected 'item1', got %q", value)
+		}
+
+		_, err = reader.ReadString("complex.objects[2].name")
+		if err != ErrFieldNotFound {
+			t.Errorf("Expected ErrFieldNotFound for an out of range index, got %v", err)
+		}
+	})
+
+	t.Run("wildcard selector", func(t *testing.T) {
+		namesReader, err := reader.GetReader("complex.objects[*].name")
+		if err != nil {
+			t.Fatalf("GetReader with a wildcard failed: %v", err)
+		}
+		var names []string
+		for _, nameReader := range namesReader.Children() {
+			name, err := nameReader.ReadString("")
+			if err != nil {
+				t.Errorf("ReadString for name failed: %v", err)
+			}
+			names = append(names, name)
+		}
+		if len(names) != 2 || names[0] != "object1" || names[1] != "object2" {
+			t.Errorf("Expected [object1 object2], got %v", names)
+		}
+
+		missingReader, err := reader.GetReader("complex.objects[*].nonexistent")
+		if err != nil {
+			t.Fatalf("GetReader with a wildcard matching nothing failed: %v", err)
+		}
+		if missingReader.Len() != 0 {
+			t.Errorf("Expected no matched node, got %d", missingReader.Len())
+		}
+
+		_, err = reader.GetReader("nonexistent[*].name")
+		if err != ErrFieldNotFound {
+			t.Errorf("Expected ErrFieldNotFound for a missing field before the wildcard, got %v", err)
+		}
+	})
+}
+
+func TestNodeReaderTypedCollections(t *testing.T) {
+	yamlData := `
+metadata:
+  finalizers:
+    - foo
+    - bar
+  labels:
+    app: nginx
+    tier: frontend
+  annotations: null
+  emptyList: []
+spec:
+  ports:
+    - 80
+    - 443
+  mixed:
+    - foo
+    - 1
+  containers:
+    - name: nginx
+    - name: sidecar
+`
+	node, err := FromYAML(yamlData)
+	if err != nil {
+		t.Fatalf("Failed to parse YAML: %v", err)
+	}
+	reader := NewNodeReader(node)
+
+	t.Run("ReadStringSlice", func(t *testing.T) {
+		testCases := []struct {
+			fieldPath string
+			want      []string
+			wantErr   bool
+		}{
+			{fieldPath: "metadata.finalizers", want: []string{"foo", "bar"}},
+			{fieldPath: "spec.containers[*].name", want: []string{"nginx", "sidecar"}},
+			{fieldPath: "metadata.emptyList", want: nil},
+			{fieldPath: "metadata.annotations", want: nil},
+			{fieldPath: "spec.mixed", wantErr: true},
+			{fieldPath: "metadata.labels", wantErr: true},
+			{fieldPath: "nonexistent", wantErr: true},
+		}
+		for _, tc := range testCases {
+			got, err := reader.ReadStringSlice(tc.fieldPath)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ReadStringSlice(%q) error = %v, wantErr %v", tc.fieldPath, err, tc.wantErr)
+				continue
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ReadStringSlice(%q) mismatch (-want +got):\n%s", tc.fieldPath, diff)
+			}
+		}
+		if diff := cmp.Diff([]string{"default"}, reader.ReadStringSliceOrDefault("spec.mixed", []string{"default"})); diff != "" {
+			t.Errorf("ReadStringSliceOrDefault mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("ReadIntSlice", func(t *testing.T) {
+		got, err := reader.ReadIntSlice("spec.ports")
+		if err != nil {
+			t.Fatalf("ReadIntSlice failed: %v", err)
+		}
+		if diff := cmp.Diff([]int{80, 443}, got); diff != "" {
+			t.Errorf("ReadIntSlice mismatch (-want +got):\n%s", diff)
+		}
+		if _, err := reader.ReadIntSlice("metadata.finalizers"); err == nil {
+			t.Errorf("Expected an error for a sequence of strings")
+		}
+		if diff := cmp.Diff([]int{8080}, reader.ReadIntSliceOrDefault("nonexistent", []int{8080})); diff != "" {
+			t.Errorf("ReadIntSliceOrDefault mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("ReadStringMap", func(t *testing.T) {
+		got, err := reader.ReadStringMap("metadata.labels")
+		if err != nil {
+			t.Fatalf("ReadStringMap failed: %v", err)
+		}
+		if diff := cmp.Diff(map[string]string{"app": "nginx", "tier": "frontend"}, got); diff != "" {
+			t.Errorf("ReadStringMap mismatch (-want +got):\n%s", diff)
+		}
+		got, err = reader.ReadStringMap("metadata.annotations")
+		if err != nil {
+			t.Fatalf("ReadStringMap for null failed: %v", err)
+		}
+		if diff := cmp.Diff(map[string]string{}, got); diff != "" {
+			t.Errorf("ReadStringMap for null mismatch (-want +got):\n%s", diff)
+		}
+		if _, err := reader.ReadStringMap("metadata.finalizers"); err == nil {
+			t.Errorf("Expected an error for a sequence")
+		}
+		if _, err := reader.ReadStringMap("spec"); err == nil {
+			t.Errorf("Expected an error for a map with non string values")
+		}
+		if diff := cmp.Diff(map[string]string{"a": "b"}, reader.ReadStringMapOrDefault("nonexistent", map[string]string{"a": "b"})); diff != "" {
+			t.Errorf("ReadStringMapOrDefault mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestParseFieldPathSegment(t *testing.T) {
+	testCases := []struct {
+		input             string
+		expectedKey       string
+		expectedSelectors []string
+	}{
+		{input: "containers", expectedKey: "containers"},
+		{input: "containers[0]", expectedKey: "containers", expectedSelectors: []string{"0"}},
+		{input: "containers[*]", expectedKey: "containers", expectedSelectors: []string{"*"}},
+		{input: "matrix[1][*]", expectedKey: "matrix", expectedSelectors: []string{"1", "*"}},
+		{input: "[0]", expectedKey: "", expectedSelectors: []string{"0"}},
+		{input: "key[foo]", expectedKey: "key[foo]"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			key, selectors := parseFieldPathSegment(tc.input)
+			if key != tc.expectedKey {
+				t.Errorf("Expected key %q, got %q", tc.expectedKey, key)
+			}
+			if len(selectors) != len(tc.expectedSelectors) {
+				t.Fatalf("Expected selectors %v, got %v", tc.expectedSelectors, selectors)
+			}
+			for i := range selectors {
+				if selectors[i] != tc.expectedSelectors[i] {
+					t.Errorf("Expected selectors %v, got %v", tc.expectedSelectors, selectors)
+				}
+			}
+		})
+	}
 }
 
 func TestParseFieldPath(t *testing.T) {
@@ -339,3 +526,28 @@ func TestParseFieldPath(t *testing.T) {
 		})
 	}
 }
+
+func BenchmarkNodeReaderReadString(b *testing.B) {
+	node, err := FromYAML(`
+a:
+  b:
+    c: value
+items:
+  - name: foo
+  - name: bar
+`)
+	if err != nil {
+		b.Fatalf("Failed to parse YAML: %v", err)
+	}
+	reader := NewNodeReader(node)
+	for _, fieldPath := range []string{"a.b.c", "items[1].name"} {
+		b.Run(fieldPath, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := reader.ReadString(fieldPath); err != nil {
+					b.Fatalf("ReadString failed: %v", err)
+				}
+			}
+		})
+	}
+}
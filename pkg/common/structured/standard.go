@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 	"unique"
@@ -103,6 +104,13 @@ func (n *StandardScalarNode[T]) MarshalYAML() (interface{}, error) {
 	return yamlNode, nil
 }
 
+// standardScalarNode is implemented only by StandardScalarNode of any type parameter.
+type standardScalarNode interface {
+	isStandardScalarNode()
+}
+
+func (n *StandardScalarNode[T]) isStandardScalarNode() {}
+
 // NewStandardScalarNode instanciate the value of StandardScalarNode from the given value.
 func NewStandardScalarNode[T comparable](value T) *StandardScalarNode[T] {
 	return &StandardScalarNode[T]{
@@ -287,6 +295,12 @@ var _ Node = (*StandardMapNode)(nil)
 var _ yaml.Marshaler = (*StandardMapNode)(nil)
 var _ json.Marshaler = (*StandardMapNode)(nil)
 
+// deleteMapKey returns the keys of a map without the key at the index.
+// The keys of maps can be shared among cloned maps, thus the given slice must not be modified in place.
+func deleteMapKey(keys []unique.Handle[string], index int) []unique.Handle[string] {
+	return slices.Concat(keys[:index], keys[index+1:])
+}
+
 // NewEmptyMapNode returns an empty map node.
 func NewEmptyMapNode() Node {
 	return &StandardMapNode{
@@ -330,11 +344,15 @@ func getJSONMarshalerFromNode(node Node) (json.Marshaler, error) {
 func cloneStandardNodeFromNode(node Node) (Node, error) {
 	switch node.Type() {
 	case ScalarNodeType:
+		// Standard scalar nodes are immutable and can be shared without copying.
+		if _, ok := node.(standardScalarNode); ok {
+			return node, nil
+		}
 		scalarValue, err := node.NodeScalarValue()
 		if err != nil {
 			return nil, err
 		}
-		return NewStandardScalarNode(scalarValue), nil
+		return newInternedScalarNode(scalarValue), nil
 	case SequenceNodeType:
 		sequence := StandardSequenceNode{
 			value: make([]Node, 0, node.Len()),
@@ -349,11 +367,18 @@ func cloneStandardNodeFromNode(node Node) (Node, error) {
 		return &sequence, nil
 	case MapNodeType:
 		mapNode := StandardMapNode{
-			keys:   make([]unique.Handle[string], 0, node.Len()),
 			values: make([]Node, 0, node.Len()),
 		}
-		for key, child := range node.Children() {
-			mapNode.keys = append(mapNode.keys, unique.Make(key.Key))
+		if standardMap, ok := node.(*StandardMapNode); ok {
+			// Share the keys with the source. The capacity is limited to make appending keys to the clone reallocate the slice.
+			mapNode.keys = standardMap.keys[:len(standardMap.keys):len(standardMap.keys)]
+		} else {
+			mapNode.keys = make([]unique.Handle[string], 0, node.Len())
+			for key := range node.Children() {
+				mapNode.keys = append(mapNode.keys, unique.Make(key.Key))
+			}
+		}
+		for _, child := range node.Children() {
 			child, err := cloneStandardNodeFromNode(child)
 			if err != nil {
 				return nil, err
@@ -0,0 +1,277 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package structured
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+	"unique"
+)
+
+// ErrWildcardNotWritable is returned when a field path given to NodeWriter contains a wildcard selector.
+var ErrWildcardNotWritable = errors.New("wildcard can't be used in a field path to write")
+
+// NodeWriter builds or modifies a structured data with field paths.
+// The field paths are in the same format as the one accepted by NodeReader except wildcards.
+// Missing maps on the path are created, but elements of sequences must exist to be selected by their indices.
+type NodeWriter struct {
+	root Node
+}
+
+// NewNodeWriter returns a NodeWriter modifying a clone of the given node. A nil node starts from an empty map.
+func NewNodeWriter(base Node) (*NodeWriter, error) {
+	if base == nil {
+		return &NodeWriter{root: NewEmptyMapNode()}, nil
+	}
+	root, err := cloneStandardNodeFromNode(base)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeWriter{root: root}, nil
+}
+
+// Node returns the node built with this writer.
+func (w *NodeWriter) Node() Node {
+	return w.root
+}
+
+// Reader returns a NodeReader to read the node built with this writer.
+func (w *NodeWriter) Reader() *NodeReader {
+	return NewNodeReader(w.root)
+}
+
+// SetString sets a string value at the specified field path.
+func (w *NodeWriter) SetString(fieldPath string, value string) error {
+	return w.SetNode(fieldPath, NewStandardScalarNode(value))
+}
+
+// SetBool sets a boolean value at the specified field path.
+func (w *NodeWriter) SetBool(fieldPath string, value bool) error {
+	return w.SetNode(fieldPath, NewStandardScalarNode(value))
+}
+
+// SetInt sets an integer value at the specified field path.
+func (w *NodeWriter) SetInt(fieldPath string, value int) error {
+	return w.SetNode(fieldPath, NewStandardScalarNode(value))
+}
+
+// SetFloat sets a floating-point value at the specified field path.
+func (w *NodeWriter) SetFloat(fieldPath string, value float64) error {
+	return w.SetNode(fieldPath, NewStandardScalarNode(value))
+}
+
+// SetTimestamp sets a timestamp value at the specified field path.
+func (w *NodeWriter) SetTimestamp(fieldPath string, value time.Time) error {
+	return w.SetNode(fieldPath, NewStandardScalarNode(value))
+}
+
+// SetNode sets a clone of the given node at the specified field path. An empty field path replaces the whole node.
+// A new key is added at the end of the map.
+func (w *NodeWriter) SetNode(fieldPath string, node Node) error {
+	cloned, err := cloneStandardNodeFromNode(node)
+	if err != nil {
+		return err
+	}
+	if fieldPath == "" {
+		w.root = cloned
+		return nil
+	}
+	parent, last, err := w.parentOf(fieldPath, true)
+	if err != nil {
+		return err
+	}
+	return setChild(parent, last, cloned)
+}
+
+// Append appends a clone of the given node to the sequence at the specified field path.
+// A new sequence is created when the field doesn't exist.
+func (w *NodeWriter) Append(fieldPath string, node Node) error {
+	cloned, err := cloneStandardNodeFromNode(node)
+	if err != nil {
+		return err
+	}
+	target, err := w.getOrCreate(fieldPath, func() Node { return &StandardSequenceNode{value: []Node{}} })
+	if err != nil {
+		return err
+	}
+	sequence, ok := target.(*StandardSequenceNode)
+	if !ok {
+		return fmt.Errorf("field %q is not a sequence", fieldPath)
+	}
+	sequence.value = append(sequence.value, cloned)
+	return nil
+}
+
+// Delete removes the field at the specified field path. Elements after the removed element in a sequence are shifted.
+// Returns ErrFieldNotFound if the field doesn't exist.
+func (w *NodeWriter) Delete(fieldPath string) error {
+	if fieldPath == "" {
+		return errors.New("the root node can't be deleted")
+	}
+	parent, last, err := w.parentOf(fieldPath, false)
+	if err != nil {
+		return err
+	}
+	switch parentNode := parent.(type) {
+	case *StandardMapNode:
+		index := slices.Index(parentNode.keys, unique.Make(last.key))
+		if index == -1 {
+			return ErrFieldNotFound
+		}
+		parentNode.keys = deleteMapKey(parentNode.keys, index)
+		parentNode.values = slices.Delete(parentNode.values, index, index+1)
+	case *StandardSequenceNode:
+		if last.index >= len(parentNode.value) {
+			return ErrFieldNotFound
+		}
+		parentNode.value = slices.Delete(parentNode.value, last.index, last.index+1)
+	default:
+		return ErrFieldNotFound
+	}
+	return nil
+}
+
+// writerPathStep is a step in a field path to write. It selects a key of a map when isIndex is false, otherwise an element of a sequence.
+type writerPathStep struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// parseWriterFieldPath parses the field path into the steps.
+func parseWriterFieldPath(fieldPath string) ([]writerPathStep, error) {
+	var steps []writerPathStep
+	for _, pathSegment := range parseFieldPath(fieldPath) {
+		key, selectors := parseFieldPathSegment(pathSegment)
+		if key != "" || len(selectors) == 0 {
+			steps = append(steps, writerPathStep{key: key})
+		}
+		for _, selector := range selectors {
+			if selector == fieldPathWildcard {
+				return nil, ErrWildcardNotWritable
+			}
+			index, err := strconv.Atoi(selector)
+			if err != nil {
+				return nil, fmt.Errorf("invalid index %q in field path %q", selector, fieldPath)
+			}
+			steps = append(steps, writerPathStep{index: index, isIndex: true})
+		}
+	}
+	return steps, nil
+}
+
+// parentOf returns the parent node of the field and the last step of the field path.
+// Missing maps on the path are created when create is true.
+func (w *NodeWriter) parentOf(fieldPath string, create bool) (Node, writerPathStep, error) {
+	steps, err := parseWriterFieldPath(fieldPath)
+	if err != nil {
+		return nil, writerPathStep{}, err
+	}
+	current := w.root
+	for _, step := range steps[:len(steps)-1] {
+		current, err = childOf(current, step, create)
+		if err != nil {
+			return nil, writerPathStep{}, err
+		}
+	}
+	return current, steps[len(steps)-1], nil
+}
+
+// getOrCreate returns the node at the field path or sets the node generated with the factory when it doesn't exist.
+func (w *NodeWriter) getOrCreate(fieldPath string, factory func() Node) (Node, error) {
+	if fieldPath == "" {
+		return w.root, nil
+	}
+	parent, last, err := w.parentOf(fieldPath, true)
+	if err != nil {
+		return nil, err
+	}
+	child, err := childOf(parent, last, false)
+	if err == nil {
+		return child, nil
+	}
+	if !errors.Is(err, ErrFieldNotFound) || last.isIndex {
+		return nil, err
+	}
+	child = factory()
+	err = setChild(parent, last, child)
+	if err != nil {
+		return nil, err
+	}
+	return child, nil
+}
+
+// childOf returns the child selected with the step. A missing map key is filled with an empty map when create is true.
+func childOf(node Node, step writerPathStep, create bool) (Node, error) {
+	switch parent := node.(type) {
+	case *StandardMapNode:
+		if step.isIndex {
+			return nil, fmt.Errorf("index %d can't be used for a map", step.index)
+		}
+		index := slices.Index(parent.keys, unique.Make(step.key))
+		if index != -1 {
+			return parent.values[index], nil
+		}
+		if !create {
+			return nil, ErrFieldNotFound
+		}
+		child := NewEmptyMapNode()
+		parent.keys = append(parent.keys, unique.Make(step.key))
+		parent.values = append(parent.values, child)
+		return child, nil
+	case *StandardSequenceNode:
+		if !step.isIndex {
+			return nil, fmt.Errorf("key %q can't be used for a sequence", step.key)
+		}
+		if step.index >= len(parent.value) {
+			return nil, ErrFieldNotFound
+		}
+		return parent.value[step.index], nil
+	default:
+		return nil, fmt.Errorf("scalar node has no child to select with %+v", step)
+	}
+}
+
+// setChild sets the child selected with the step.
+func setChild(node Node, step writerPathStep, child Node) error {
+	switch parent := node.(type) {
+	case *StandardMapNode:
+		if step.isIndex {
+			return fmt.Errorf("index %d can't be used for a map", step.index)
+		}
+		key := unique.Make(step.key)
+		if index := slices.Index(parent.keys, key); index != -1 {
+			parent.values[index] = child
+			return nil
+		}
+		parent.keys = append(parent.keys, key)
+		parent.values = append(parent.values, child)
+		return nil
+	case *StandardSequenceNode:
+		if !step.isIndex {
+			return fmt.Errorf("key %q can't be used for a sequence", step.key)
+		}
+		if step.index >= len(parent.value) {
+			return fmt.Errorf("index %d is out of range of the sequence with %d elements", step.index, len(parent.value))
+		}
+		parent.value[step.index] = child
+		return nil
+	default:
+		return errors.New("scalar node can't have a child")
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package structured
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNodeWriter(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Base     string
+		Write    func(w *NodeWriter) error
+		Expected string
+	}{
+		{
+			Name: "set fields on an empty node",
+			Write: func(w *NodeWriter) error {
+				return errors.Join(
+					w.SetString("apiVersion", "v1"),
+					w.SetString("kind", "Pod"),
+					w.SetString("metadata.name", "foo"),
+					w.SetString(`metadata.labels.app\.kubernetes\.io/name`, "bar"),
+					w.SetInt("spec.priority", 1),
+					w.SetBool("spec.hostNetwork", true),
+				)
+			},
+			Expected: `apiVersion: v1
+kind: Pod
+metadata:
+  name: foo
+  labels:
+    app.kubernetes.io/name: bar
+spec:
+  priority: 1
+  hostNetwork: true
+`,
+		},
+		{
+			Name: "overwrite an existing field keeps the order of keys",
+			Base: "a: 1\nb: 2\nc: 3\n",
+			Write: func(w *NodeWriter) error {
+				return w.SetString("b", "two")
+			},
+			Expected: "a: 1\nb: two\nc: 3\n",
+		},
+		{
+			Name: "set a field in an element of a sequence",
+			Base: "containers:\n  - name: a\n  - name: b\n",
+			Write: func(w *NodeWriter) error {
+				return w.SetString("containers[1].image", "nginx")
+			},
+			Expected: "containers:\n  - name: a\n  - name: b\n    image: nginx\n",
+		},
+		{
+			Name: "append to an existing and a missing sequence",
+			Base: "items:\n  - 1\n",
+			Write: func(w *NodeWriter) error {
+				return errors.Join(
+					w.Append("items", NewStandardScalarNode(2)),
+					w.Append("others", NewStandardMap([]string{"name"}, []Node{NewStandardScalarNode("foo")})),
+				)
+			},
+			Expected: "items:\n  - 1\n  - 2\nothers:\n  - name: foo\n",
+		},
+		{
+			Name: "delete a field and an element",
+			Base: "a: 1\nb:\n  - x\n  - y\n",
+			Write: func(w *NodeWriter) error {
+				return errors.Join(
+					w.Delete("a"),
+					w.Delete("b[0]"),
+				)
+			},
+			Expected: "b:\n  - y\n",
+		},
+		{
+			Name: "set a node",
+			Base: "metadata:\n  name: foo\n",
+			Write: func(w *NodeWriter) error {
+				labels, err := FromYAML("app: bar\n")
+				if err != nil {
+					return err
+				}
+				return w.SetNode("metadata.labels", labels)
+			},
+			Expected: "metadata:\n  name: foo\n  labels:\n    app: bar\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var base Node
+			if tc.Base != "" {
+				var err error
+				base, err = FromYAML(tc.Base)
+				if err != nil {
+					t.Fatalf("failed to parse base node yaml %v", err)
+				}
+			}
+			writer, err := NewNodeWriter(base)
+			if err != nil {
+				t.Fatalf("NewNodeWriter() returned an unexpected error: %v", err)
+			}
+			err = tc.Write(writer)
+			if err != nil {
+				t.Fatalf("failed to write: %v", err)
+			}
+			got, err := writer.Reader().Serialize("", &YAMLNodeSerializer{})
+			if err != nil {
+				t.Fatalf("failed to serialize result to yaml %v", err)
+			}
+			if diff := cmp.Diff(tc.Expected, string(got)); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+			if base != nil {
+				baseYAML, err := NewNodeReader(base).Serialize("", &YAMLNodeSerializer{})
+				if err != nil {
+					t.Fatalf("failed to serialize base node %v", err)
+				}
+				if diff := cmp.Diff(tc.Base, string(baseYAML)); diff != "" {
+					t.Errorf("the base node was modified (-before +after):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestNodeWriterErrors(t *testing.T) {
+	base, err := FromYAML("a: 1\nb:\n  - x\n")
+	if err != nil {
+		t.Fatalf("failed to parse base node yaml %v", err)
+	}
+	writer, err := NewNodeWriter(base)
+	if err != nil {
+		t.Fatalf("NewNodeWriter() returned an unexpected error: %v", err)
+	}
+	if err := writer.SetString("b[*]", "y"); !errors.Is(err, ErrWildcardNotWritable) {
+		t.Errorf("SetString() with a wildcard returned %v, want ErrWildcardNotWritable", err)
+	}
+	if err := writer.SetString("b[1]", "y"); err == nil {
+		t.Errorf("SetString() with an out of range index returned no error")
+	}
+	if err := writer.SetString("a.c", "y"); err == nil {
+		t.Errorf("SetString() under a scalar returned no error")
+	}
+	if err := writer.Delete("c"); !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("Delete() for a missing field returned %v, want ErrFieldNotFound", err)
+	}
+	if err := writer.Append("a", NewStandardScalarNode(1)); err == nil {
+		t.Errorf("Append() to a scalar returned no error")
+	}
+}
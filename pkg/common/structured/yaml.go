@@ -104,15 +104,15 @@ func fromScalarYAMLNode(node *yaml.Node) (Node, error) {
 	// https://github.com/go-yaml/yaml/blob/944c86a7d29391925ed6ac33bee98a0516f1287a/resolve.go#L71-L80
 	switch node.Tag {
 	case yamlTagNull:
-		return NewStandardScalarNode[any](nil), nil
+		return newInternedScalarNode(nil), nil
 	case yamlTagBool:
 		boolValue, err := strconv.ParseBool(node.Value)
 		if err != nil {
 			return nil, err
 		}
-		return NewStandardScalarNode(boolValue), nil
+		return newInternedScalarNode(boolValue), nil
 	case yamlTagString:
-		return NewStandardScalarNode(node.Value), nil
+		return newInternedStringScalarNode(node.Value), nil
 	case yamlTagInt:
 		intValue, err := strconv.Atoi(node.Value)
 		if err != nil {
@@ -132,6 +132,6 @@ func fromScalarYAMLNode(node *yaml.Node) (Node, error) {
 		}
 		return NewStandardScalarNode(timestampValue), nil
 	default:
-		return NewStandardScalarNode(node.Value), nil
+		return newInternedStringScalarNode(node.Value), nil
 	}
 }
@@ -120,6 +120,25 @@ func (m *ReadonlyTypedMap) Keys() []string {
 	return m.source.Keys()
 }
 
+// Range calls f sequentially for each key and its untyped value in the map.
+// If f returns false, Range stops the iteration.
+// This is intended for debugging purpose like dumping all values. Use Get for type-safe access.
+func (m *TypedMap) Range(f func(key string, value any) bool) {
+	m.container.Range(func(key, value interface{}) bool {
+		strKey, ok := key.(string)
+		if !ok {
+			return true
+		}
+		return f(strKey, value)
+	})
+}
+
+// Range calls f sequentially for each key and its untyped value in the map.
+// If f returns false, Range stops the iteration.
+func (m *ReadonlyTypedMap) Range(f func(key string, value any) bool) {
+	m.source.Range(f)
+}
+
 // Get retrieves a value in a type-safe way.
 // Works with both TypedMap and ReadonlyTypedMap.
 func Get[T any](m ReadableTypedMap, key TypedKey[T]) (T, bool) {
@@ -399,6 +399,34 @@ func TestReadonlyTypedMapKeys(t *testing.T) {
 	})
 }
 
+func TestReadonlyTypedMapRange(t *testing.T) {
+	tm := NewTypedMap()
+	Set(tm, StringKey, "value1")
+	Set(tm, NewTypedKey[int]("key2"), 42)
+
+	got := map[string]any{}
+	tm.AsReadonly().Range(func(key string, value any) bool {
+		got[key] = value
+		return true
+	})
+	want := map[string]any{
+		StringKey.key: "value1",
+		"key2":        42,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Range() visited unexpected values (-want +got):\n%s", diff)
+	}
+
+	visited := 0
+	tm.AsReadonly().Range(func(key string, value any) bool {
+		visited++
+		return false
+	})
+	if visited != 1 {
+		t.Errorf("Range() visited %d entries after returning false, want 1", visited)
+	}
+}
+
 func TestGetOrSetFuncIsThreadSafe(t *testing.T) {
 	waitAttempts := sync.WaitGroup{}
 	for i := 0; i < 100; i++ {
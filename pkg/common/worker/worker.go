@@ -24,6 +24,8 @@ import (
 type Pool struct {
 	semaphore chan struct{}
 	waitGroup *sync.WaitGroup
+	errLock   sync.Mutex
+	err       error
 }
 
 func NewPool(maxParallelCount int) *Pool {
@@ -33,19 +35,36 @@ func NewPool(maxParallelCount int) *Pool {
 	}
 }
 
+// Run runs the given function in a goroutine once the number of running goroutines gets below the limit.
+// A panic raised in the function is recovered and returned from Wait.
 func (t *Pool) Run(f func()) {
 	t.waitGroup.Add(1)
 	t.semaphore <- struct{}{}
 	go func() {
-		defer errorreport.CheckAndReportPanic()
 		defer func() {
 			<-t.semaphore
 			t.waitGroup.Done()
 		}()
-		f()
+		if err := runRecoveringPanic(f); err != nil {
+			t.errLock.Lock()
+			defer t.errLock.Unlock()
+			if t.err == nil {
+				t.err = err
+			}
+		}
 	}()
 }
 
-func (t *Pool) Wait() {
+// Wait waits all the goroutines to finish and returns the first panic raised in them as an error.
+func (t *Pool) Wait() error {
 	t.waitGroup.Wait()
+	t.errLock.Lock()
+	defer t.errLock.Unlock()
+	return t.err
+}
+
+func runRecoveringPanic(f func()) (err error) {
+	defer errorreport.RecoverPanicAsError(&err)
+	f()
+	return nil
 }
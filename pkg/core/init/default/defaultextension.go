@@ -16,7 +16,10 @@ package defaultinit
 
 import (
 	"context"
+	"crypto/tls"
 	"log/slog"
+	"path/filepath"
+	"time"
 
 	"cloud.google.com/go/profiler"
 	"github.com/gin-contrib/cors"
@@ -29,6 +32,7 @@ import (
 	coreinit "github.com/kyasbal/khi/pkg/core/init"
 	coreinspection "github.com/kyasbal/khi/pkg/core/inspection"
 	"github.com/kyasbal/khi/pkg/core/inspection/logger"
+	"github.com/kyasbal/khi/pkg/core/inspection/taskcache"
 	"github.com/kyasbal/khi/pkg/core/inspection/tracing"
 	"github.com/kyasbal/khi/pkg/generated"
 	"github.com/kyasbal/khi/pkg/model/k8s"
@@ -36,6 +40,7 @@ import (
 	"github.com/kyasbal/khi/pkg/server"
 	"github.com/kyasbal/khi/pkg/server/option"
 	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
 
 	texporter "github.com/GoogleCloudPlatform/opentelemetry-operations-go/exporter/trace"
 	"go.opentelemetry.io/otel"
@@ -142,18 +147,63 @@ func (d *DefaultInitExtension) AfterParsingParameters() error {
 // ConfigureInspectionTaskServer implements coreinit.InitExtension.
 func (d *DefaultInitExtension) ConfigureInspectionTaskServer(taskServer *coreinspection.InspectionTaskServer) error {
 	d.taskServer = taskServer
+	taskServer.SetMaxTaskConcurrency(*parameters.Common.MaxConcurrentTasks)
+	if *parameters.Common.TaskMemoryLimitMB > 0 {
+		taskServer.SetTaskMemoryLimit(uint64(*parameters.Common.TaskMemoryLimitMB) * 1024 * 1024)
+	}
+	taskServer.SetCheckpointFolder(*parameters.Common.InspectionCheckpointFolder)
 	if !*parameters.Server.ViewerMode {
 		err := generated.RegisterAllInspectionTasks(taskServer)
 		if err != nil {
 			return err
 		}
 	}
-	if *parameters.Auth.QuotaProjectID != "" {
-		taskServer.AddRunContextOption(coreinspection.RunContextOptionArrayElementFromValue(googlecloudcommon_contract.APIClientFactoryOptionsContextKey, options.QuotaProject(*parameters.Auth.QuotaProjectID)))
+	if *parameters.Common.TaskCacheRedisAddress != "" {
+		var tlsConfig *tls.Config
+		if *parameters.Common.TaskCacheRedisTLS {
+			tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		backend := taskcache.NewRedisTaskCacheBackend(*parameters.Common.TaskCacheRedisAddress, *parameters.Common.TaskCacheRedisPassword, tlsConfig, time.Duration(*parameters.Common.TaskCacheTTLSeconds)*time.Second)
+		taskServer.SetTaskCacheBackend(backend)
+	} else if *parameters.Common.TaskCacheFolder != "" {
+		backend, err := inspectioncore_contract.NewFileSystemTaskCacheBackend(*parameters.Common.TaskCacheFolder)
+		if err != nil {
+			return err
+		}
+		taskServer.SetTaskCacheBackend(backend)
+	}
+	if *parameters.Common.QueryResultCacheFolder != "" {
+		backend, err := inspectioncore_contract.NewFileSystemTaskCacheBackend(*parameters.Common.QueryResultCacheFolder)
+		if err != nil {
+			return err
+		}
+		backend.SetEvictionPolicy(time.Duration(*parameters.Common.QueryResultCacheTTLSeconds)*time.Second, int64(*parameters.Common.QueryResultCacheMaxSizeMB)*1024*1024)
+		taskServer.AddRunContextOption(coreinspection.RunContextOptionFromValue[inspectioncore_contract.StreamingTaskCacheBackend](inspectioncore_contract.QueryResultCacheBackendContextKey, backend))
 	}
 	if *parameters.Auth.AccessToken != "" {
 		taskServer.AddRunContextOption(coreinspection.RunContextOptionArrayElementFromValue(googlecloudcommon_contract.APIClientFactoryOptionsContextKey, options.TokenSource(legacy.NewRawTokenTokenSource(*parameters.Auth.AccessToken))))
 	}
+	if parameters.Auth.OAuthBrowserLoginEnabled() {
+		flow := oauth.NewInstalledAppFlow(parameters.Auth.GetOAuthConfig(), filepath.Join(*parameters.Common.DataDestinationFolder, "oauth", "token.json"))
+		taskServer.AddRunContextOption(coreinspection.RunContextOptionArrayElementFromValue(googlecloudcommon_contract.APIClientFactoryOptionsContextKey, options.TokenSource(flow)))
+	}
+	if parameters.Auth.ExternalAccountCredentialFileEnabled() {
+		taskServer.AddRunContextOption(coreinspection.RunContextOptionArrayElementFromValue(googlecloudcommon_contract.APIClientFactoryOptionsContextKey, options.ExternalAccountCredentialFile(*parameters.Auth.ExternalAccountCredentialFile)))
+	}
+	if *parameters.Common.CloudLoggingEndpoint != "" {
+		taskServer.AddRunContextOption(coreinspection.RunContextOptionArrayElementFromValue(googlecloudcommon_contract.APIClientFactoryOptionsContextKey, options.LoggingEndpoint(*parameters.Common.CloudLoggingEndpoint)))
+	}
+	if *parameters.Common.CloudLoggingRegion != "" {
+		taskServer.AddRunContextOption(coreinspection.RunContextOptionArrayElementFromValue(googlecloudcommon_contract.APIClientFactoryOptionsContextKey, options.RegionalLoggingEndpoint(*parameters.Common.CloudLoggingRegion)))
+	}
+	if *parameters.Debug.GCPAPIRecordFolder != "" {
+		taskServer.AddRunContextOption(coreinspection.RunContextOptionArrayElementFromValue(googlecloudcommon_contract.APIClientFactoryOptionsContextKey, options.RecordAPIResponses(*parameters.Debug.GCPAPIRecordFolder)))
+		slog.Warn("Responses of Google Cloud APIs are recorded to " + *parameters.Debug.GCPAPIRecordFolder)
+	}
+	if *parameters.Debug.GCPAPIReplayFolder != "" {
+		taskServer.AddRunContextOption(coreinspection.RunContextOptionArrayElementFromValue(googlecloudcommon_contract.APIClientFactoryOptionsContextKey, options.ReplayAPIResponses(*parameters.Debug.GCPAPIReplayFolder)))
+		slog.Warn("Google Cloud APIs are not called. Responses recorded in " + *parameters.Debug.GCPAPIReplayFolder + " are used instead")
+	}
 	if *parameters.Debug.CloudTrace {
 		taskServer.AddInspectionInterceptor(tracing.NewInspectionTraceInterceptor(otel.Tracer("khi")))
 	}
@@ -0,0 +1,91 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coreinspection
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// checkpointRetention is the duration to keep the checkpoints of a run not completed.
+// Checkpoints of runs failed or abandoned in the middle are removed after this duration not to resume a run with logs fetched long time ago.
+const checkpointRetention = 24 * time.Hour
+
+// checkpointRunKey is the set of values identifying an inspection run to resume.
+type checkpointRunKey struct {
+	InspectionType string         `json:"inspectionType"`
+	Features       []string       `json:"features"`
+	Values         map[string]any `json:"values"`
+}
+
+// checkpointFolderForRun returns the folder to store checkpoints of the run.
+// Runs with the same inspection type, the same enabled features and the same request values share the folder.
+func checkpointFolderForRun(checkpointRoot string, inspectionType string, enabledFeatures map[string]bool, values map[string]any) (string, error) {
+	features := []string{}
+	for feature, enabled := range enabledFeatures {
+		if enabled {
+			features = append(features, feature)
+		}
+	}
+	slices.Sort(features)
+	// encoding/json sorts the map keys, thus the serialized key is stable for the same request.
+	serializedKey, err := json.Marshal(checkpointRunKey{
+		InspectionType: inspectionType,
+		Features:       features,
+		Values:         values,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize the checkpoint key: %w", err)
+	}
+	digest := sha256.Sum256(serializedKey)
+	return filepath.Join(checkpointRoot, hex.EncodeToString(digest[:])), nil
+}
+
+// removeExpiredCheckpoints removes the checkpoint folders under the checkpoint root not modified within the retention.
+func removeExpiredCheckpoints(checkpointRoot string, retention time.Duration, now time.Time) error {
+	entries, err := os.ReadDir(checkpointRoot)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	var errs []error
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if now.Sub(info.ModTime()) < retention {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(checkpointRoot, entry.Name())); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coreinspection
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCheckpointFolderForRun(t *testing.T) {
+	base, err := checkpointFolderForRun("/tmp/checkpoint", "gcp-gke", map[string]bool{"feature-a": true, "feature-b": true, "feature-c": false}, map[string]any{"project": "foo", "duration": "1h"})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if filepath.Dir(base) != "/tmp/checkpoint" {
+		t.Errorf("expected the folder under the checkpoint root, got %s", base)
+	}
+
+	testCases := []struct {
+		name            string
+		inspectionType  string
+		enabledFeatures map[string]bool
+		values          map[string]any
+		wantSame        bool
+	}{
+		{
+			name:            "same request",
+			inspectionType:  "gcp-gke",
+			enabledFeatures: map[string]bool{"feature-b": true, "feature-a": true},
+			values:          map[string]any{"duration": "1h", "project": "foo"},
+			wantSame:        true,
+		},
+		{
+			name:            "different inspection type",
+			inspectionType:  "gcp-composer",
+			enabledFeatures: map[string]bool{"feature-a": true, "feature-b": true},
+			values:          map[string]any{"project": "foo", "duration": "1h"},
+			wantSame:        false,
+		},
+		{
+			name:            "different features",
+			inspectionType:  "gcp-gke",
+			enabledFeatures: map[string]bool{"feature-a": true, "feature-c": true},
+			values:          map[string]any{"project": "foo", "duration": "1h"},
+			wantSame:        false,
+		},
+		{
+			name:            "different values",
+			inspectionType:  "gcp-gke",
+			enabledFeatures: map[string]bool{"feature-a": true, "feature-b": true},
+			values:          map[string]any{"project": "bar", "duration": "1h"},
+			wantSame:        false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := checkpointFolderForRun("/tmp/checkpoint", tc.inspectionType, tc.enabledFeatures, tc.values)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if (got == base) != tc.wantSame {
+				t.Errorf("checkpointFolderForRun() = %s, base = %s, wantSame = %v", got, base, tc.wantSame)
+			}
+		})
+	}
+}
+
+func TestRemoveExpiredCheckpoints(t *testing.T) {
+	root := t.TempDir()
+	now := time.Now()
+	for name, modTime := range map[string]time.Time{
+		"expired": now.Add(-2 * checkpointRetention),
+		"fresh":   now.Add(-time.Minute),
+	} {
+		folder := filepath.Join(root, name)
+		if err := os.MkdirAll(folder, 0755); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if err := os.Chtimes(folder, modTime, modTime); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	if err := removeExpiredCheckpoints(root, checkpointRetention, now); err != nil {
+		t.Fatalf("removeExpiredCheckpoints() returned an unexpected error %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "expired")); !os.IsNotExist(err) {
+		t.Errorf("expected the expired checkpoint folder to be removed, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "fresh")); err != nil {
+		t.Errorf("expected the fresh checkpoint folder to be kept, got %v", err)
+	}
+	if err := removeExpiredCheckpoints(filepath.Join(root, "missing"), checkpointRetention, now); err != nil {
+		t.Errorf("removeExpiredCheckpoints() must ignore the missing root, got %v", err)
+	}
+}
@@ -0,0 +1,293 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coreinspection
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// DefaultFollowInterval is the interval of re-running a followed inspection when no interval is specified.
+const DefaultFollowInterval = 30 * time.Second
+
+// MinimumFollowInterval is the shortest interval allowed to re-run a followed inspection.
+// Shorter intervals would mostly wait for the previous run and hit the Cloud Logging quota.
+const MinimumFollowInterval = 10 * time.Second
+
+// followEventBufferSize is the number of events buffered for each subscriber. Events are dropped for subscribers not reading them.
+const followEventBufferSize = 16
+
+// InspectionFollowEventType is the type of events published by InspectionFollower.
+type InspectionFollowEventType string
+
+const (
+	// InspectionFollowEventUpdated is published when a new inspection with the time range extended to the current time completed.
+	InspectionFollowEventUpdated InspectionFollowEventType = "updated"
+	// InspectionFollowEventError is published when a re-run failed. The follower keeps the last successful result and retries in the next interval.
+	InspectionFollowEventError InspectionFollowEventType = "error"
+	// InspectionFollowEventStopped is published when the follower is stopped. No event is published after this.
+	InspectionFollowEventStopped InspectionFollowEventType = "stopped"
+)
+
+// InspectionFollowEvent is an event published by InspectionFollower to notify the viewer of the followed inspection.
+type InspectionFollowEvent struct {
+	Type InspectionFollowEventType `json:"type"`
+	// Revision is the number of successful re-runs since the follower started.
+	Revision int `json:"revision"`
+	// InspectionID is the ID of the inspection holding the latest result. The viewer loads the data of this inspection.
+	InspectionID string `json:"inspectionId"`
+	// Message is the error message of the failed re-run. This is empty except for the error events.
+	Message string `json:"message,omitempty"`
+}
+
+// InspectionFollower re-runs a finished inspection periodically with its time ranges extended to end at the current time.
+// This allows KHI to be used during an ongoing incident. Each re-run is a new inspection of the same type, features and parameters,
+// and the previous result is kept available until the new one completes.
+// The runs share a map given with InspectionFollowSharedMap, and the log query tasks use it to query only the logs after the previous run
+// and append them to the logs accumulated in the previous runs.
+type InspectionFollower struct {
+	server   *InspectionTaskServer
+	sourceID string
+	interval time.Duration
+	// now returns the current time used as the end of the time ranges. This is replaced in tests.
+	now func() time.Time
+	// sharedMap is given to every run of the follower with InspectionFollowSharedMap.
+	sharedMap *typedmap.TypedMap
+
+	lock               sync.Mutex
+	latestInspectionID string
+	revision           int
+	subscribers        map[chan InspectionFollowEvent]struct{}
+	stopped            bool
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// FollowInspection starts re-running the inspection periodically with its time ranges extended to the current time.
+// The inspection must have been started. The first re-run happens after the given interval.
+func (s *InspectionTaskServer) FollowInspection(inspectionID string, interval time.Duration) (*InspectionFollower, error) {
+	source := s.GetInspection(inspectionID)
+	if source == nil {
+		return nil, fmt.Errorf("inspection %s was not found", inspectionID)
+	}
+	if !source.Started() {
+		return nil, fmt.Errorf("inspection %s is not yet started", inspectionID)
+	}
+	if interval < MinimumFollowInterval {
+		return nil, fmt.Errorf("follow interval %s is shorter than the minimum interval %s", interval, MinimumFollowInterval)
+	}
+	s.followersLock.Lock()
+	defer s.followersLock.Unlock()
+	if _, found := s.followers[inspectionID]; found {
+		return nil, fmt.Errorf("inspection %s is already followed", inspectionID)
+	}
+	follower := newInspectionFollower(s, inspectionID, interval, time.Now)
+	s.followers[inspectionID] = follower
+	follower.start()
+	return follower, nil
+}
+
+// GetFollower returns the follower of the inspection. It returns nil when the inspection is not followed.
+func (s *InspectionTaskServer) GetFollower(inspectionID string) *InspectionFollower {
+	s.followersLock.Lock()
+	defer s.followersLock.Unlock()
+	return s.followers[inspectionID]
+}
+
+// StopFollowing stops the follower of the inspection and waits for its ongoing re-run to be cancelled.
+func (s *InspectionTaskServer) StopFollowing(inspectionID string) error {
+	s.followersLock.Lock()
+	follower, found := s.followers[inspectionID]
+	delete(s.followers, inspectionID)
+	s.followersLock.Unlock()
+	if !found {
+		return fmt.Errorf("inspection %s is not followed", inspectionID)
+	}
+	follower.stop()
+	return nil
+}
+
+func newInspectionFollower(server *InspectionTaskServer, sourceID string, interval time.Duration, now func() time.Time) *InspectionFollower {
+	return &InspectionFollower{
+		server:             server,
+		sourceID:           sourceID,
+		interval:           interval,
+		now:                now,
+		sharedMap:          typedmap.NewTypedMap(),
+		latestInspectionID: sourceID,
+		subscribers:        map[chan InspectionFollowEvent]struct{}{},
+		done:               make(chan struct{}),
+	}
+}
+
+// Interval returns the interval of re-running the followed inspection.
+func (f *InspectionFollower) Interval() time.Duration {
+	return f.interval
+}
+
+// Latest returns the event describing the latest result of the follower.
+func (f *InspectionFollower) Latest() InspectionFollowEvent {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.latestEvent()
+}
+
+// Subscribe returns a channel receiving the events of the follower and a function to unsubscribe.
+// The channel receives the latest state first, and it is closed when the follower is stopped or unsubscribed.
+func (f *InspectionFollower) Subscribe() (<-chan InspectionFollowEvent, func()) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	ch := make(chan InspectionFollowEvent, followEventBufferSize)
+	if f.stopped {
+		ch <- InspectionFollowEvent{Type: InspectionFollowEventStopped, Revision: f.revision, InspectionID: f.latestInspectionID}
+		close(ch)
+		return ch, func() {}
+	}
+	if f.revision > 0 {
+		ch <- f.latestEvent()
+	}
+	f.subscribers[ch] = struct{}{}
+	return ch, func() {
+		f.lock.Lock()
+		defer f.lock.Unlock()
+		if _, found := f.subscribers[ch]; found {
+			delete(f.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+func (f *InspectionFollower) latestEvent() InspectionFollowEvent {
+	return InspectionFollowEvent{Type: InspectionFollowEventUpdated, Revision: f.revision, InspectionID: f.latestInspectionID}
+}
+
+func (f *InspectionFollower) start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	f.cancel = cancel
+	go func() {
+		defer close(f.done)
+		ticker := time.NewTicker(f.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				f.follow(ctx)
+			}
+		}
+	}()
+}
+
+func (f *InspectionFollower) stop() {
+	f.cancel()
+	<-f.done
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.stopped = true
+	event := InspectionFollowEvent{Type: InspectionFollowEventStopped, Revision: f.revision, InspectionID: f.latestInspectionID}
+	for ch := range f.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+		close(ch)
+	}
+	f.subscribers = map[chan InspectionFollowEvent]struct{}{}
+}
+
+// follow runs a new inspection with the time ranges of the followed inspection extended to the current time.
+// The previous result created by the follower is removed after the new run completes. The followed inspection itself is kept.
+func (f *InspectionFollower) follow(ctx context.Context) {
+	inspectionID, err := f.runLatest(ctx)
+	if ctx.Err() != nil {
+		if err == nil {
+			f.server.removeInspection(inspectionID)
+		}
+		return
+	}
+	if err != nil {
+		slog.WarnContext(ctx, fmt.Sprintf("failed to re-run the followed inspection %s\n%v", f.sourceID, err))
+		f.lock.Lock()
+		defer f.lock.Unlock()
+		f.publish(InspectionFollowEvent{Type: InspectionFollowEventError, Revision: f.revision, InspectionID: f.latestInspectionID, Message: err.Error()})
+		return
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.latestInspectionID != f.sourceID {
+		f.server.removeInspection(f.latestInspectionID)
+	}
+	f.latestInspectionID = inspectionID
+	f.revision++
+	f.publish(f.latestEvent())
+}
+
+// runLatest creates and runs a new inspection with the time range extended to now and returns its ID after it completes.
+// The new inspection is removed when the run failed.
+func (f *InspectionFollower) runLatest(ctx context.Context) (string, error) {
+	source := f.server.GetInspection(f.sourceID)
+	if source == nil {
+		return "", fmt.Errorf("inspection %s was not found", f.sourceID)
+	}
+	req, err := source.Request()
+	if err != nil {
+		return "", err
+	}
+	inspectionID, err := f.server.CreateInspection(source.InspectionType())
+	if err != nil {
+		return "", err
+	}
+	runner := f.server.GetInspection(inspectionID)
+	err = runner.SetFeatureList(source.EnabledFeatureIDs())
+	if err != nil {
+		f.server.removeInspection(inspectionID)
+		return "", err
+	}
+	runCtx := khictx.WithValue(ctx, inspectioncore_contract.InspectionFollowSharedMap, f.sharedMap)
+	err = runner.Run(runCtx, &inspectioncore_contract.InspectionRequest{
+		Values: formtask.ExtendTimeRangeParameterValues(req.Values, f.now()),
+	})
+	if err != nil {
+		f.server.removeInspection(inspectionID)
+		return "", err
+	}
+	<-runner.Wait()
+	if _, err := runner.Result(); err != nil {
+		f.server.removeInspection(inspectionID)
+		return "", err
+	}
+	return inspectionID, nil
+}
+
+// publish sends the event to the subscribers without blocking. The caller must hold the lock.
+func (f *InspectionFollower) publish(event InspectionFollowEvent) {
+	for ch := range f.subscribers {
+		select {
+		case ch <- event:
+		default:
+			slog.Warn(fmt.Sprintf("dropped a follow event of inspection %s for a slow subscriber", f.sourceID))
+		}
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coreinspection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	"github.com/kyasbal/khi/pkg/core/inspection/logger"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// followTestTask records the time range and the follow shared map given to each run and fails while failing is set.
+type followTestTask struct {
+	lock       sync.Mutex
+	timeRanges []string
+	sharedMaps []*typedmap.TypedMap
+	failing    bool
+}
+
+func (f *followTestTask) setFailing(failing bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.failing = failing
+}
+
+func (f *followTestTask) receivedTimeRanges() []string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return append([]string{}, f.timeRanges...)
+}
+
+func (f *followTestTask) receivedSharedMaps() []*typedmap.TypedMap {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return append([]*typedmap.TypedMap{}, f.sharedMaps...)
+}
+
+func newFollowTestServer(t *testing.T) (*InspectionTaskServer, *followTestTask) {
+	t.Helper()
+	logger.InitGlobalKHILogger()
+	server, err := NewServer(&inspectioncore_contract.IOConfig{
+		TemporaryFolder: t.TempDir(),
+		DataDestination: t.TempDir(),
+	})
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	if err := server.AddInspectionType(InspectionType{Id: "test-inspection", Name: "Test Inspection"}); err != nil {
+		t.Fatalf("AddInspectionType failed: %v", err)
+	}
+	recorder := &followTestTask{}
+	task := coretask.NewTask(
+		taskid.NewDefaultImplementationID[any]("follow-test-task"),
+		nil,
+		func(ctx context.Context) (any, error) {
+			input := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionTaskInput)
+			timeRange, _ := input["test-timerange"].(map[string]any)
+			recorder.lock.Lock()
+			defer recorder.lock.Unlock()
+			recorder.timeRanges = append(recorder.timeRanges, fmt.Sprintf("%s/%s", timeRange["endTime"], timeRange["duration"]))
+			sharedMap, _ := khictx.GetValue(ctx, inspectioncore_contract.InspectionFollowSharedMap)
+			recorder.sharedMaps = append(recorder.sharedMaps, sharedMap)
+			if recorder.failing {
+				return nil, errors.New("test error")
+			}
+			return nil, nil
+		},
+		coretask.WithLabelValue(inspectioncore_contract.LabelKeyInspectionTypes, []string{"test-inspection"}),
+		coretask.WithLabelValue(inspectioncore_contract.LabelKeyInspectionDefaultFeatureFlag, true),
+		coretask.WithLabelValue(inspectioncore_contract.LabelKeyInspectionFeatureFlag, true),
+		coretask.NewSubsequentTaskRefsTaskLabel(inspectioncore_contract.SerializerTaskID.Ref()),
+	)
+	if err := server.AddTask(task); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+	return server, recorder
+}
+
+func runFollowTestSource(t *testing.T, server *InspectionTaskServer) string {
+	t.Helper()
+	inspectionID, err := server.CreateInspection("test-inspection")
+	if err != nil {
+		t.Fatalf("CreateInspection failed: %v", err)
+	}
+	runner := server.GetInspection(inspectionID)
+	err = runner.Run(context.Background(), &inspectioncore_contract.InspectionRequest{
+		Values: map[string]any{
+			"test-timerange": map[string]any{"endTime": "2025-01-01T00:00:00Z", "duration": "1h"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	<-runner.Wait()
+	if _, err := runner.Result(); err != nil {
+		t.Fatalf("the followed inspection failed: %v", err)
+	}
+	return inspectionID
+}
+
+func receiveFollowEvent(t *testing.T, events <-chan InspectionFollowEvent) InspectionFollowEvent {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(10 * time.Second):
+		t.Fatalf("timed out waiting for a follow event")
+		return InspectionFollowEvent{}
+	}
+}
+
+func TestInspectionFollower_Follow(t *testing.T) {
+	server, recorder := newFollowTestServer(t)
+	sourceID := runFollowTestSource(t, server)
+	now := time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+	follower := newInspectionFollower(server, sourceID, MinimumFollowInterval, func() time.Time { return now })
+	events, unsubscribe := follower.Subscribe()
+	defer unsubscribe()
+
+	follower.follow(t.Context())
+	first := receiveFollowEvent(t, events)
+	if first.Type != InspectionFollowEventUpdated || first.Revision != 1 || first.InspectionID == sourceID {
+		t.Fatalf("unexpected first event %+v", first)
+	}
+
+	now = now.Add(time.Minute)
+	follower.follow(t.Context())
+	second := receiveFollowEvent(t, events)
+	if second.Type != InspectionFollowEventUpdated || second.Revision != 2 || second.InspectionID == first.InspectionID {
+		t.Fatalf("unexpected second event %+v", second)
+	}
+
+	if server.GetInspection(sourceID) == nil {
+		t.Errorf("the followed inspection must be kept")
+	}
+	if server.GetInspection(first.InspectionID) != nil {
+		t.Errorf("the previous result created by the follower must be removed")
+	}
+	if diff := cmp.Diff(second, follower.Latest()); diff != "" {
+		t.Errorf("Latest() mismatch (-want +got):\n%s", diff)
+	}
+	// The start time of the followed inspection is kept and the time range is extended to the current time.
+	wantTimeRanges := []string{"2025-01-01T00:00:00Z/1h", "2025-01-01T12:00:00Z/13h", "2025-01-01T12:01:00Z/13h1m"}
+	if diff := cmp.Diff(wantTimeRanges, recorder.receivedTimeRanges()); diff != "" {
+		t.Errorf("time ranges given to the runs mismatch (-want +got):\n%s", diff)
+	}
+	sharedMaps := recorder.receivedSharedMaps()
+	if sharedMaps[0] != nil {
+		t.Errorf("the follow shared map must not be given to the followed inspection")
+	}
+	if sharedMaps[1] == nil || sharedMaps[1] != sharedMaps[2] {
+		t.Errorf("the same follow shared map must be given to the runs of the follower")
+	}
+}
+
+func TestInspectionFollower_FollowFailure(t *testing.T) {
+	server, recorder := newFollowTestServer(t)
+	sourceID := runFollowTestSource(t, server)
+	follower := newInspectionFollower(server, sourceID, MinimumFollowInterval, time.Now)
+	events, unsubscribe := follower.Subscribe()
+	defer unsubscribe()
+
+	recorder.setFailing(true)
+	follower.follow(t.Context())
+
+	event := receiveFollowEvent(t, events)
+	if event.Type != InspectionFollowEventError || event.Revision != 0 || event.InspectionID != sourceID || event.Message == "" {
+		t.Errorf("unexpected event %+v", event)
+	}
+	if got := len(server.GetAllRunners()); got != 1 {
+		t.Errorf("the failed inspection must be removed, but the server has %d inspections", got)
+	}
+}
+
+func TestInspectionTaskServer_FollowInspection(t *testing.T) {
+	server, _ := newFollowTestServer(t)
+	sourceID := runFollowTestSource(t, server)
+	notStartedID, err := server.CreateInspection("test-inspection")
+	if err != nil {
+		t.Fatalf("CreateInspection failed: %v", err)
+	}
+
+	if _, err := server.FollowInspection("missing", DefaultFollowInterval); err == nil {
+		t.Errorf("FollowInspection() must fail for a missing inspection")
+	}
+	if _, err := server.FollowInspection(notStartedID, DefaultFollowInterval); err == nil {
+		t.Errorf("FollowInspection() must fail for an inspection not yet started")
+	}
+	if _, err := server.FollowInspection(sourceID, time.Second); err == nil {
+		t.Errorf("FollowInspection() must fail for an interval shorter than the minimum")
+	}
+
+	follower, err := server.FollowInspection(sourceID, DefaultFollowInterval)
+	if err != nil {
+		t.Fatalf("FollowInspection() returned an unexpected error: %v", err)
+	}
+	if server.GetFollower(sourceID) != follower {
+		t.Errorf("GetFollower() must return the started follower")
+	}
+	if _, err := server.FollowInspection(sourceID, DefaultFollowInterval); err == nil {
+		t.Errorf("FollowInspection() must fail for an inspection already followed")
+	}
+
+	events, unsubscribe := follower.Subscribe()
+	defer unsubscribe()
+	if err := server.StopFollowing(sourceID); err != nil {
+		t.Fatalf("StopFollowing() returned an unexpected error: %v", err)
+	}
+	if event := receiveFollowEvent(t, events); event.Type != InspectionFollowEventStopped {
+		t.Errorf("unexpected event %+v", event)
+	}
+	if _, open := <-events; open {
+		t.Errorf("the subscription must be closed after the follower stopped")
+	}
+	if server.GetFollower(sourceID) != nil {
+		t.Errorf("GetFollower() must return nil after the follower stopped")
+	}
+	if err := server.StopFollowing(sourceID); err == nil {
+		t.Errorf("StopFollowing() must fail for an inspection not followed")
+	}
+}
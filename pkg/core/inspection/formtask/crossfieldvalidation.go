@@ -0,0 +1,85 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package formtask
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	common_task "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// CrossFieldValidationError is a validation error found by a CrossFieldValidator.
+// Message is shown as the error hint of the form field specified with Field.
+type CrossFieldValidationError struct {
+	Field   taskid.UntypedTaskReference
+	Message string
+}
+
+// CrossFieldValidator is a function to check a combination of values given to multiple form fields.
+// The values are read with coretask.GetTaskResult from the form tasks. Returns an empty slice when it has no error.
+type CrossFieldValidator = func(ctx context.Context) ([]CrossFieldValidationError, error)
+
+// NewCrossFieldValidationTask returns a task to validate the resolved values of the given form fields at once.
+// In DryRun mode, the validation errors are attached to the form fields. In Run mode, the task returns an error instead.
+// This task is not included in a task graph by itself. Add NewSubsequentTaskRefsTaskLabel with the ID of this task on the form tasks to run it together with the forms.
+func NewCrossFieldValidationTask(id taskid.TaskImplementationID[struct{}], fields []taskid.UntypedTaskReference, validator CrossFieldValidator, labelOpts ...common_task.LabelOpt) common_task.Task[struct{}] {
+	return common_task.NewTask(id, fields, func(ctx context.Context) (struct{}, error) {
+		m := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
+		taskMode := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionTaskMode)
+
+		validationErrors, err := validator(ctx)
+		if err != nil {
+			return struct{}{}, fmt.Errorf("cross field validator for task `%s` returned an unrecoverable error\n%v", id, err)
+		}
+		if len(validationErrors) == 0 {
+			return struct{}{}, nil
+		}
+
+		fieldIDs := map[string]struct{}{}
+		for _, field := range fields {
+			fieldIDs[field.ReferenceIDString()] = struct{}{}
+		}
+		messages := []string{}
+		for _, validationError := range validationErrors {
+			fieldID := validationError.Field.ReferenceIDString()
+			if _, found := fieldIDs[fieldID]; !found {
+				return struct{}{}, fmt.Errorf("cross field validator for task `%s` returned an error for `%s` not included in its fields", id, fieldID)
+			}
+			messages = append(messages, fmt.Sprintf("%s: %s", fieldID, validationError.Message))
+		}
+		if taskMode == inspectioncore_contract.TaskModeRun {
+			return struct{}{}, fmt.Errorf("cross field validator for task `%s` returned validation errors. But this task was executed as a Run mode not in DryRun. All validations must be resolved before running.\n%s", id, strings.Join(messages, "\n"))
+		}
+
+		formFields, found := typedmap.Get(m, inspectionmetadata.FormFieldSetMetadataKey)
+		if !found {
+			return struct{}{}, fmt.Errorf("form field set was not found in the metadata set")
+		}
+		for _, validationError := range validationErrors {
+			err := formFields.SetFieldError(validationError.Field.ReferenceIDString(), validationError.Message)
+			if err != nil {
+				return struct{}{}, fmt.Errorf("failed to attach the validation error in task `%s`\n%v", id, err)
+			}
+		}
+		return struct{}{}, nil
+	}, labelOpts...)
+}
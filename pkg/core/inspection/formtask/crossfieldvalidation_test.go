@@ -0,0 +1,133 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package formtask
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+func TestCrossFieldValidationTask(t *testing.T) {
+	defaultTime := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	startTimeID := taskid.NewDefaultImplementationID[time.Time]("start-time")
+	endTimeID := taskid.NewDefaultImplementationID[time.Time]("end-time")
+	validationTaskID := taskid.NewDefaultImplementationID[struct{}]("time-range-validation")
+
+	testCases := []struct {
+		Name              string
+		Input             map[string]any
+		ExpectedHintType  inspectionmetadata.ParameterHintType
+		ExpectedHint      string
+		ExpectedRunError  bool
+		ExpectedOtherHint inspectionmetadata.ParameterHintType
+	}{
+		{
+			Name: "valid time range",
+			Input: map[string]any{
+				"start-time": "2025-01-01T00:00:00Z",
+				"end-time":   "2025-01-01T01:00:00Z",
+			},
+			ExpectedHintType:  inspectionmetadata.None,
+			ExpectedOtherHint: inspectionmetadata.None,
+		},
+		{
+			Name: "end time before start time",
+			Input: map[string]any{
+				"start-time": "2025-01-01T01:00:00Z",
+				"end-time":   "2025-01-01T00:00:00Z",
+			},
+			ExpectedHintType:  inspectionmetadata.Error,
+			ExpectedHint:      "end time must be after the start time",
+			ExpectedRunError:  true,
+			ExpectedOtherHint: inspectionmetadata.None,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			startTimeForm := NewDateTimeFormTaskBuilder(startTimeID, 1, "Start time").
+				WithDefaultValueFunc(func(ctx context.Context, previousValues []time.Time) (time.Time, error) {
+					return defaultTime, nil
+				}).Build(coretask.NewSubsequentTaskRefsTaskLabel(validationTaskID.Ref()))
+			endTimeForm := NewDateTimeFormTaskBuilder(endTimeID, 2, "End time").
+				WithDefaultValueFunc(func(ctx context.Context, previousValues []time.Time) (time.Time, error) {
+					return defaultTime, nil
+				}).Build(coretask.NewSubsequentTaskRefsTaskLabel(validationTaskID.Ref()))
+			validationTask := NewCrossFieldValidationTask(validationTaskID, []taskid.UntypedTaskReference{startTimeID.Ref(), endTimeID.Ref()}, func(ctx context.Context) ([]CrossFieldValidationError, error) {
+				startTime := coretask.GetTaskResult(ctx, startTimeID.Ref())
+				endTime := coretask.GetTaskResult(ctx, endTimeID.Ref())
+				if !endTime.After(startTime) {
+					return []CrossFieldValidationError{{Field: endTimeID.Ref(), Message: "end time must be after the start time"}}, nil
+				}
+				return nil, nil
+			})
+			dependencies := []coretask.UntypedTask{startTimeForm, validationTask}
+
+			// Execute task as DryRun mode
+			taskCtx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+			_, _, err := inspectiontest.RunInspectionTaskWithDependency(taskCtx, endTimeForm, dependencies, inspectioncore_contract.TaskModeDryRun, testCase.Input)
+			if err != nil {
+				t.Fatalf("task was ended with unexpected error\n%s", err)
+			}
+			metadata := khictx.MustGetValue(taskCtx, inspectioncore_contract.InspectionRunMetadata)
+			fields, found := typedmap.Get(metadata, inspectionmetadata.FormFieldSetMetadataKey)
+			if !found {
+				t.Fatal("FormFieldSet not found on metadata")
+			}
+			endTimeField := inspectionmetadata.GetParameterFormFieldBase(fields.DangerouslyGetField("end-time"))
+			if endTimeField.HintType != testCase.ExpectedHintType || endTimeField.Hint != testCase.ExpectedHint {
+				t.Errorf("the hint of end-time is not matching with the expected value. expected:(%s,%q), actual:(%s,%q)", testCase.ExpectedHintType, testCase.ExpectedHint, endTimeField.HintType, endTimeField.Hint)
+			}
+			startTimeField := inspectionmetadata.GetParameterFormFieldBase(fields.DangerouslyGetField("start-time"))
+			if startTimeField.HintType != testCase.ExpectedOtherHint {
+				t.Errorf("the hint type of start-time is not matching with the expected value. expected:%s, actual:%s", testCase.ExpectedOtherHint, startTimeField.HintType)
+			}
+
+			// Execute task as Run mode
+			taskCtx = inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+			_, _, err = inspectiontest.RunInspectionTaskWithDependency(taskCtx, endTimeForm, dependencies, inspectioncore_contract.TaskModeRun, testCase.Input)
+			if testCase.ExpectedRunError {
+				if err == nil {
+					t.Errorf("task was expected to be end with an error in Run mode. But the task finished without an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("task was ended with unexpected error\n%s", err)
+			}
+		})
+	}
+}
+
+func TestCrossFieldValidationTask_RejectsErrorForUnknownField(t *testing.T) {
+	fooID := taskid.NewDefaultImplementationID[time.Time]("foo")
+	barID := taskid.NewDefaultImplementationID[time.Time]("bar")
+	validationTask := NewCrossFieldValidationTask(taskid.NewDefaultImplementationID[struct{}]("validation"), []taskid.UntypedTaskReference{fooID.Ref()}, func(ctx context.Context) ([]CrossFieldValidationError, error) {
+		return []CrossFieldValidationError{{Field: barID.Ref(), Message: "error"}}, nil
+	})
+	taskCtx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+	_, _, err := inspectiontest.RunInspectionTask(taskCtx, validationTask, inspectioncore_contract.TaskModeDryRun, map[string]any{})
+	if err == nil {
+		t.Errorf("task was expected to be end with an error. But the task finished without an error")
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package formtask
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/common"
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	common_task "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// DateTimeFormInvalidFormatMessage is the validation error message shown when the given value can't be parsed as a time.
+const DateTimeFormInvalidFormatMessage = "invalid time format. Please specify in the format of `2006-01-02T15:04:05-07:00`(RFC3339)"
+
+// DateTimeFormValidator is a function to check if the given time is valid or not.
+// Returns "" as the result when it has no error, otherwise the returned value is used as an error message on frontend.
+type DateTimeFormValidator = func(ctx context.Context, value time.Time) (string, error)
+
+// DateTimeFormDefaultValueGenerator is a function type to generate the default value.
+type DateTimeFormDefaultValueGenerator = func(ctx context.Context, previousValues []time.Time) (time.Time, error)
+
+// DateTimeFormTimezoneProvider is a function type to return the timezone used to show the time on the form.
+type DateTimeFormTimezoneProvider = func(ctx context.Context) (*time.Location, error)
+
+// DateTimeFormHintGenerator is a function type to generate a hint string
+type DateTimeFormHintGenerator = func(ctx context.Context, value time.Time) (string, inspectionmetadata.ParameterHintType, error)
+
+// DateTimeFormTaskBuilder is an utility to construct an instance of task for date and time picker field.
+// The given value is parsed once in this task and the task returns the parsed time.Time.
+type DateTimeFormTaskBuilder struct {
+	FormTaskBuilderBase[time.Time]
+	defaultValue     DateTimeFormDefaultValueGenerator
+	validator        DateTimeFormValidator
+	timezoneProvider DateTimeFormTimezoneProvider
+	hintGenerator    DateTimeFormHintGenerator
+}
+
+// NewDateTimeFormTaskBuilder constructs an instance of DateTimeFormTaskBuilder.
+// The default value is initialized with the inspection creation time and the timezone is initialized with UTC.
+func NewDateTimeFormTaskBuilder(id taskid.TaskImplementationID[time.Time], priority int, fieldLabel string) *DateTimeFormTaskBuilder {
+	return &DateTimeFormTaskBuilder{
+		FormTaskBuilderBase: NewFormTaskBuilderBase(id, priority, fieldLabel),
+		defaultValue: func(ctx context.Context, previousValues []time.Time) (time.Time, error) {
+			return khictx.GetValue(ctx, inspectioncore_contract.InspectionCreationTime)
+		},
+		validator: func(ctx context.Context, value time.Time) (string, error) {
+			return "", nil
+		},
+		timezoneProvider: func(ctx context.Context) (*time.Location, error) {
+			return time.UTC, nil
+		},
+		hintGenerator: func(ctx context.Context, value time.Time) (string, inspectionmetadata.ParameterHintType, error) {
+			return "", inspectionmetadata.Info, nil
+		},
+	}
+}
+
+func (b *DateTimeFormTaskBuilder) WithDependencies(dependencies []taskid.UntypedTaskReference) *DateTimeFormTaskBuilder {
+	b.FormTaskBuilderBase.WithDependencies(dependencies)
+	return b
+}
+
+func (b *DateTimeFormTaskBuilder) WithDescription(description string) *DateTimeFormTaskBuilder {
+	b.FormTaskBuilderBase.WithDescription(description)
+	return b
+}
+
+// WithGroup places the form field in the group generated by the task built with GroupFormTaskBuilder.
+func (b *DateTimeFormTaskBuilder) WithGroup(group taskid.TaskReference[struct{}]) *DateTimeFormTaskBuilder {
+	b.FormTaskBuilderBase.WithGroup(group)
+	return b
+}
+
+// WithPosition places the form field in the section at the position relative to the other fields instead of the priority.
+func (b *DateTimeFormTaskBuilder) WithPosition(position inspectionmetadata.FormPosition) *DateTimeFormTaskBuilder {
+	b.FormTaskBuilderBase.WithPosition(position)
+	return b
+}
+
+// WithMarkdown marks the description and the hint of the form field as Markdown.
+func (b *DateTimeFormTaskBuilder) WithMarkdown() *DateTimeFormTaskBuilder {
+	b.FormTaskBuilderBase.WithMarkdown()
+	return b
+}
+
+// WithDocURL sets the URL of the document describing the syntax or the usage of the form field.
+func (b *DateTimeFormTaskBuilder) WithDocURL(url string) *DateTimeFormTaskBuilder {
+	b.FormTaskBuilderBase.WithDocURL(url)
+	return b
+}
+
+func (b *DateTimeFormTaskBuilder) WithValidator(validator DateTimeFormValidator) *DateTimeFormTaskBuilder {
+	b.validator = validator
+	return b
+}
+
+func (b *DateTimeFormTaskBuilder) WithDefaultValueFunc(defFunc DateTimeFormDefaultValueGenerator) *DateTimeFormTaskBuilder {
+	b.defaultValue = defFunc
+	return b
+}
+
+func (b *DateTimeFormTaskBuilder) WithTimezoneFunc(timezoneFunc DateTimeFormTimezoneProvider) *DateTimeFormTaskBuilder {
+	b.timezoneProvider = timezoneFunc
+	return b
+}
+
+func (b *DateTimeFormTaskBuilder) WithHintFunc(hintFunc DateTimeFormHintGenerator) *DateTimeFormTaskBuilder {
+	b.hintGenerator = hintFunc
+	return b
+}
+
+func (b *DateTimeFormTaskBuilder) Build(labelOpts ...common_task.LabelOpt) common_task.Task[time.Time] {
+	return common_task.NewTask(b.id, b.taskDependencies(), func(ctx context.Context) (time.Time, error) {
+		m := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
+		req := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionTaskInput)
+		taskMode := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionTaskMode)
+		globalSharedMap := khictx.MustGetValue(ctx, inspectioncore_contract.GlobalSharedMap)
+
+		previousValueStoreKey := typedmap.NewTypedKey[[]time.Time](fmt.Sprintf("datetime-form-pv-%s", b.id))
+		prevValue := typedmap.GetOrDefault(globalSharedMap, previousValueStoreKey, []time.Time{})
+
+		timezone, err := b.timezoneProvider(ctx)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("timezone provider for task `%s` returned an error\n%v", b.id, err)
+		}
+
+		field := inspectionmetadata.DateTimeParameterFormField{}
+		field.Suggestions = make([]string, len(prevValue))
+		for i, value := range prevValue {
+			field.Suggestions[i] = value.In(timezone).Format(time.RFC3339)
+		}
+
+		defaultValue, err := b.defaultValue(ctx, prevValue)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("default value generator for task `%s` returned an error\n%v", b.id, err)
+		}
+		field.Default = defaultValue.In(timezone).Format(time.RFC3339)
+
+		currentValue := defaultValue
+		validationErr := ""
+		if valueRaw, exist := req[b.id.ReferenceIDString()]; exist {
+			valueString, isString := valueRaw.(string)
+			if !isString {
+				return time.Time{}, fmt.Errorf("request parameter `%s` was not given in string in task %s", b.id, b.id)
+			}
+			parsed, err := common.ParseTime(valueString)
+			if err != nil {
+				validationErr = DateTimeFormInvalidFormatMessage
+			} else {
+				currentValue = parsed
+			}
+		}
+
+		field.Type = inspectionmetadata.DateTime
+		field.HintType = inspectionmetadata.Info
+
+		b.SetupBaseFormField(&field.ParameterFormFieldBase)
+
+		if validationErr == "" {
+			validationErr, err = b.validator(ctx, currentValue)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("validator for task `%s` returned an unrecoverable error\n%v", b.id, err)
+			}
+		}
+		if validationErr != "" {
+			// When the given time is invalid, it should be the default value.
+			currentValue = defaultValue
+		}
+		if validationErr != "" && taskMode == inspectioncore_contract.TaskModeRun {
+			return time.Time{}, fmt.Errorf("validator for task `%s` returned a validation error. But this task was executed as a Run mode not in DryRun. All validations must be resolved before running.\n%v", b.id, validationErr)
+		}
+
+		_, offsetSeconds := currentValue.In(timezone).Zone()
+		field.TimezoneShiftHours = float64(offsetSeconds) / 3600
+		field.ValueUTC = currentValue.UTC().Format(time.RFC3339)
+
+		if validationErr != "" {
+			field.HintType = inspectionmetadata.Error
+			field.Hint = validationErr
+		} else {
+			hint, hintType, err := b.hintGenerator(ctx, currentValue)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("failed to generate a hint for task %s\n%v", b.id, err)
+			}
+			if hint == "" {
+				hintType = inspectionmetadata.None
+			}
+			field.Hint = hint
+			field.HintType = hintType
+			if taskMode == inspectioncore_contract.TaskModeRun {
+				newValueHistory := append([]time.Time{currentValue}, prevValue...)
+				typedmap.Set(globalSharedMap, previousValueStoreKey, newValueHistory)
+			}
+		}
+
+		err = b.RecordParameterValue(m, currentValue.In(timezone).Format(time.RFC3339))
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to record the parameter value in task `%s`\n%v", b.id, err)
+		}
+		formFields, found := typedmap.Get(m, inspectionmetadata.FormFieldSetMetadataKey)
+		if !found {
+			return time.Time{}, fmt.Errorf("form field set was not found in the metadata set")
+		}
+		err = formFields.SetField(field)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to configure the form metadata in task `%s`\n%v", b.id, err)
+		}
+		return currentValue, nil
+	}, append(labelOpts, inspectioncore_contract.NewFormTaskLabelOpt(
+		b.label,
+		b.description,
+	))...)
+}
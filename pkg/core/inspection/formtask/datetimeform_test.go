@@ -0,0 +1,165 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package formtask
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+func TestDateTimeFormDefinitionBuilder(t *testing.T) {
+	defaultTime := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		Name              string
+		FormConfigurator  func(builder *DateTimeFormTaskBuilder)
+		RequestValue      any
+		ExpectedFormField inspectionmetadata.DateTimeParameterFormField
+		ExpectedValue     time.Time
+		ExpectedRunError  bool
+	}{
+		{
+			Name:          "A datetime form with given parameter",
+			RequestValue:  "2024-06-01T09:00:00+09:00",
+			ExpectedValue: time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC),
+			ExpectedFormField: inspectionmetadata.DateTimeParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					HintType: inspectionmetadata.None,
+				},
+				Default:     "2025-01-01T00:00:00Z",
+				ValueUTC:    "2024-06-01T00:00:00Z",
+				Suggestions: []string{},
+			},
+		},
+		{
+			Name: "A datetime form with timezone",
+			FormConfigurator: func(builder *DateTimeFormTaskBuilder) {
+				builder.WithTimezoneFunc(func(ctx context.Context) (*time.Location, error) {
+					return time.FixedZone("", -5*3600-1800), nil
+				})
+			},
+			ExpectedValue: defaultTime,
+			ExpectedFormField: inspectionmetadata.DateTimeParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					HintType: inspectionmetadata.None,
+				},
+				Default:            "2024-12-31T18:30:00-05:30",
+				ValueUTC:           "2025-01-01T00:00:00Z",
+				TimezoneShiftHours: -5.5,
+				Suggestions:        []string{},
+			},
+		},
+		{
+			Name:             "A datetime form with invalid format",
+			RequestValue:     "2024/06/01",
+			ExpectedValue:    defaultTime,
+			ExpectedRunError: true,
+			ExpectedFormField: inspectionmetadata.DateTimeParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					HintType: inspectionmetadata.Error,
+					Hint:     DateTimeFormInvalidFormatMessage,
+				},
+				Default:     "2025-01-01T00:00:00Z",
+				ValueUTC:    "2025-01-01T00:00:00Z",
+				Suggestions: []string{},
+			},
+		},
+		{
+			Name: "A datetime form with a custom validator",
+			FormConfigurator: func(builder *DateTimeFormTaskBuilder) {
+				builder.WithValidator(func(ctx context.Context, value time.Time) (string, error) {
+					if value.Year() < 2020 {
+						return "too old", nil
+					}
+					return "", nil
+				})
+			},
+			RequestValue:     "2019-01-01T00:00:00Z",
+			ExpectedValue:    defaultTime,
+			ExpectedRunError: true,
+			ExpectedFormField: inspectionmetadata.DateTimeParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					HintType: inspectionmetadata.Error,
+					Hint:     "too old",
+				},
+				Default:     "2025-01-01T00:00:00Z",
+				ValueUTC:    "2025-01-01T00:00:00Z",
+				Suggestions: []string{},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			builder := NewDateTimeFormTaskBuilder(taskid.NewDefaultImplementationID[time.Time]("foo-datetime"), 1, "foo label").
+				WithDefaultValueFunc(func(ctx context.Context, previousValues []time.Time) (time.Time, error) {
+					return defaultTime, nil
+				})
+			if testCase.FormConfigurator != nil {
+				testCase.FormConfigurator(builder)
+			}
+			taskDef := builder.Build()
+
+			inputMap := map[string]any{}
+			if testCase.RequestValue != nil {
+				inputMap["foo-datetime"] = testCase.RequestValue
+			}
+
+			// Execute task as DryRun mode
+			taskCtx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+			result, _, err := inspectiontest.RunInspectionTask(taskCtx, taskDef, inspectioncore_contract.TaskModeDryRun, inputMap)
+			if err != nil {
+				t.Fatalf("task was ended with unexpected error\n%s", err)
+			}
+			if !result.Equal(testCase.ExpectedValue) {
+				t.Errorf("the result is not matching with the expected value. expected:%s, actual:%s", testCase.ExpectedValue, result)
+			}
+			metadata := khictx.MustGetValue(taskCtx, inspectioncore_contract.InspectionRunMetadata)
+			fields, found := typedmap.Get(metadata, inspectionmetadata.FormFieldSetMetadataKey)
+			if !found {
+				t.Fatal("FormFieldSet not found on metadata")
+			}
+			field := fields.DangerouslyGetField("foo-datetime")
+			if diff := cmp.Diff(testCase.ExpectedFormField, field, cmpopts.IgnoreFields(inspectionmetadata.ParameterFormFieldBase{}, "ID", "Priority", "Type", "Label")); diff != "" {
+				t.Errorf("the generated form field is different from the expected\n%s", diff)
+			}
+
+			// Execute task as Run mode
+			taskCtx = inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+			result, _, err = inspectiontest.RunInspectionTask(taskCtx, taskDef, inspectioncore_contract.TaskModeRun, inputMap)
+			if testCase.ExpectedRunError {
+				if err == nil {
+					t.Errorf("task was expected to be end with an error in Run mode. But the task finished without an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("task was ended with unexpected error\n%s", err)
+			}
+			if !result.Equal(testCase.ExpectedValue) {
+				t.Errorf("the result is not matching with the expected value. expected:%s, actual:%s", testCase.ExpectedValue, result)
+			}
+		})
+	}
+}
@@ -53,8 +53,32 @@ func (b *FileFormTaskBuilder) WithDescription(description string) *FileFormTaskB
 	return b
 }
 
+// WithGroup places the form field in the group generated by the task built with GroupFormTaskBuilder.
+func (b *FileFormTaskBuilder) WithGroup(group taskid.TaskReference[struct{}]) *FileFormTaskBuilder {
+	b.FormTaskBuilderBase.WithGroup(group)
+	return b
+}
+
+// WithPosition places the form field in the section at the position relative to the other fields instead of the priority.
+func (b *FileFormTaskBuilder) WithPosition(position inspectionmetadata.FormPosition) *FileFormTaskBuilder {
+	b.FormTaskBuilderBase.WithPosition(position)
+	return b
+}
+
+// WithMarkdown marks the description and the hint of the form field as Markdown.
+func (b *FileFormTaskBuilder) WithMarkdown() *FileFormTaskBuilder {
+	b.FormTaskBuilderBase.WithMarkdown()
+	return b
+}
+
+// WithDocURL sets the URL of the document describing the syntax or the usage of the form field.
+func (b *FileFormTaskBuilder) WithDocURL(url string) *FileFormTaskBuilder {
+	b.FormTaskBuilderBase.WithDocURL(url)
+	return b
+}
+
 func (b *FileFormTaskBuilder) Build(labelOpts ...common_task.LabelOpt) common_task.Task[upload.UploadResult] {
-	return common_task.NewTask(b.FormTaskBuilderBase.id, b.FormTaskBuilderBase.dependencies, func(ctx context.Context) (upload.UploadResult, error) {
+	return common_task.NewTask(b.FormTaskBuilderBase.id, b.FormTaskBuilderBase.taskDependencies(), func(ctx context.Context) (upload.UploadResult, error) {
 		metadata := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
 
 		token := upload.DefaultUploadFileStore.GetUploadToken(GenerateUploadIDWithTaskContext(ctx, b.FormTaskBuilderBase.id.ReferenceIDString()), b.verifier)
@@ -70,6 +94,7 @@ func (b *FileFormTaskBuilder) Build(labelOpts ...common_task.LabelOpt) common_ta
 			},
 			Token:  token,
 			Status: uploadResult.Status,
+			Files:  []inspectionmetadata.FileParameterFormFieldFile{},
 		}
 		b.FormTaskBuilderBase.SetupBaseFormField(&field.ParameterFormFieldBase)
 
@@ -90,21 +115,27 @@ func (b *FileFormTaskBuilder) Build(labelOpts ...common_task.LabelOpt) common_ta
 // setFormHintsFromUploadResult sets the appropriate hint and hint type on a form field
 // based on the upload result status and any errors encountered during the upload process.
 func setFormHintsFromUploadResult(result upload.UploadResult, field inspectionmetadata.FileParameterFormField) inspectionmetadata.FileParameterFormField {
+	hint, hintType := hintFromUploadResult(result)
+	if hintType != inspectionmetadata.None {
+		field.Hint = hint
+		field.HintType = hintType
+	}
+	return field
+}
+
+// hintFromUploadResult returns the hint and its type for a file based on the upload result status and any errors encountered during the upload process.
+func hintFromUploadResult(result upload.UploadResult) (string, inspectionmetadata.ParameterHintType) {
 	switch {
 	case result.UploadError != nil:
-		field.Hint = result.UploadError.Error()
-		field.HintType = inspectionmetadata.Error
+		return result.UploadError.Error(), inspectionmetadata.Error
 	case result.VerificationError != nil:
-		field.Hint = result.VerificationError.Error()
-		field.HintType = inspectionmetadata.Error
+		return result.VerificationError.Error(), inspectionmetadata.Error
 	case result.Status == upload.UploadStatusWaiting:
-		field.Hint = "Waiting a file to be uploaded."
-		field.HintType = inspectionmetadata.Error
+		return "Waiting a file to be uploaded.", inspectionmetadata.Error
 	case result.Status != upload.UploadStatusCompleted:
-		field.Hint = "File is being processed. Please wait a moment."
-		field.HintType = inspectionmetadata.Error
+		return "File is being processed. Please wait a moment.", inspectionmetadata.Error
 	}
-	return field
+	return "", inspectionmetadata.None
 }
 
 // GenerateUploadIDWithTaskContext generates the upload ID from form ID and task ID.
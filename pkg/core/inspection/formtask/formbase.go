@@ -15,6 +15,10 @@
 package formtask
 
 import (
+	"fmt"
+	"slices"
+
+	"github.com/kyasbal/khi/pkg/common/typedmap"
 	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
 	"github.com/kyasbal/khi/pkg/core/task/taskid"
 )
@@ -26,6 +30,10 @@ type FormTaskBuilderBase[T any] struct {
 	priority     int
 	dependencies []taskid.UntypedTaskReference
 	description  string
+	group        taskid.TaskReference[struct{}]
+	position     *inspectionmetadata.FormPosition
+	markdown     bool
+	docURL       string
 }
 
 // NewFormTaskBuilderBase creates a new instance of the base builder
@@ -50,10 +58,60 @@ func (b *FormTaskBuilderBase[T]) WithDependencies(dependencies []taskid.UntypedT
 	return b
 }
 
+// WithGroup places the form field in the group generated by the task built with GroupFormTaskBuilder.
+// The group task is added to the dependencies to make the group available before the field.
+func (b *FormTaskBuilderBase[T]) WithGroup(group taskid.TaskReference[struct{}]) *FormTaskBuilderBase[T] {
+	b.group = group
+	return b
+}
+
+// WithPosition places the form field in the section at the position relative to the other fields instead of the priority.
+func (b *FormTaskBuilderBase[T]) WithPosition(position inspectionmetadata.FormPosition) *FormTaskBuilderBase[T] {
+	b.position = &position
+	return b
+}
+
+// WithMarkdown marks the description and the hint of the form field as Markdown. They are rendered to sanitized HTML before sent to the frontend.
+func (b *FormTaskBuilderBase[T]) WithMarkdown() *FormTaskBuilderBase[T] {
+	b.markdown = true
+	return b
+}
+
+// WithDocURL sets the URL of the document describing the syntax or the usage of the form field. The frontend shows a link to the document next to the label.
+func (b *FormTaskBuilderBase[T]) WithDocURL(url string) *FormTaskBuilderBase[T] {
+	b.docURL = url
+	return b
+}
+
+// taskDependencies returns the dependencies of the form task including the group task.
+func (b *FormTaskBuilderBase[T]) taskDependencies() []taskid.UntypedTaskReference {
+	if b.group == nil {
+		return b.dependencies
+	}
+	return append(slices.Clone(b.dependencies), b.group)
+}
+
 // SetupBaseFormField configures common form field properties
 func (b *FormTaskBuilderBase[T]) SetupBaseFormField(field *inspectionmetadata.ParameterFormFieldBase) {
 	field.ID = b.id.ReferenceIDString()
 	field.Label = b.label
 	field.Priority = b.priority
 	field.Description = b.description
+	if b.group != nil {
+		field.GroupID = b.group.ReferenceIDString()
+	}
+	field.Position = b.position
+	field.Markdown = b.markdown
+	field.DocURL = b.docURL
+}
+
+// RecordParameterValue records the resolved value of the form field in the metadata to export the parameters of the run.
+// The value must be in the same format as the value given in the inspection request.
+func (b *FormTaskBuilderBase[T]) RecordParameterValue(metadataSet *typedmap.ReadonlyTypedMap, value any) error {
+	parameterValues, found := typedmap.Get(metadataSet, inspectionmetadata.ParameterValueSetMetadataKey)
+	if !found {
+		return fmt.Errorf("parameter value set was not found in the metadata set")
+	}
+	parameterValues.SetValue(b.id.ReferenceIDString(), value)
+	return nil
 }
@@ -15,10 +15,16 @@
 package formtask
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
 	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
 	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
 )
 
 func TestNewFormTaskBuilderBase(t *testing.T) {
@@ -105,4 +111,96 @@ func TestFormTaskBuilderBase_SetupBaseFormField(t *testing.T) {
 	if field.Description != testDescription {
 		t.Errorf("Expected field Description to be %s, got %s", testDescription, field.Description)
 	}
+	if field.Markdown {
+		t.Errorf("Expected field Markdown to be false without WithMarkdown")
+	}
+
+	if field.DocURL != "" {
+		t.Errorf("Expected field DocURL to be empty without WithDocURL, got %s", field.DocURL)
+	}
+
+	builder.WithMarkdown()
+	builder.WithDocURL("https://example.com/doc")
+	builder.SetupBaseFormField(field)
+	if !field.Markdown {
+		t.Errorf("Expected field Markdown to be true after WithMarkdown")
+	}
+	if field.DocURL != "https://example.com/doc" {
+		t.Errorf("Expected field DocURL to be https://example.com/doc, got %s", field.DocURL)
+	}
+}
+
+func TestFormTaskBuilderBase_RecordParameterValue(t *testing.T) {
+	builder := NewFormTaskBuilderBase(taskid.NewDefaultImplementationID[string]("test-id"), 1, "Test Label")
+
+	metadataSet := typedmap.NewTypedMap()
+	if err := builder.RecordParameterValue(metadataSet.AsReadonly(), "foo"); err == nil {
+		t.Errorf("Expected an error when the parameter value set is missing, got nil")
+	}
+
+	parameterValues := inspectionmetadata.NewParameterValueSetMetadata()
+	typedmap.Set(metadataSet, inspectionmetadata.ParameterValueSetMetadataKey, parameterValues)
+	if err := builder.RecordParameterValue(metadataSet.AsReadonly(), "foo"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(map[string]any{"test-id": "foo"}, parameterValues.Values()); diff != "" {
+		t.Errorf("Unexpected recorded values (-want +got):\n%s", diff)
+	}
+}
+
+func TestFormTasksRecordResolvedParameterValues(t *testing.T) {
+	textTask := NewTextFormTaskBuilder(taskid.NewDefaultImplementationID[string]("text-form"), 1, "text").
+		WithDefaultValueConstant("text-default", false).
+		Build()
+	dateTimeTask := NewDateTimeFormTaskBuilder(taskid.NewDefaultImplementationID[time.Time]("datetime-form"), 2, "datetime").
+		WithDefaultValueFunc(func(ctx context.Context, previousValues []time.Time) (time.Time, error) {
+			return time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), nil
+		}).
+		Build()
+
+	testCases := []struct {
+		name  string
+		input map[string]any
+		want  map[string]any
+	}{
+		{
+			name:  "default values",
+			input: map[string]any{},
+			want: map[string]any{
+				"text-form":     "text-default",
+				"datetime-form": "2025-01-01T00:00:00Z",
+			},
+		},
+		{
+			name: "given values",
+			input: map[string]any{
+				"text-form":     "text-value",
+				"datetime-form": "2025-02-01T10:00:00Z",
+			},
+			want: map[string]any{
+				"text-form":     "text-value",
+				"datetime-form": "2025-02-01T10:00:00Z",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			taskCtx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+			_, metadata, err := inspectiontest.RunInspectionTask(taskCtx, textTask, inspectioncore_contract.TaskModeRun, tc.input)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			_, _, err = inspectiontest.RunInspectionTask(taskCtx, dateTimeTask, inspectioncore_contract.TaskModeRun, tc.input)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			parameterValues, found := typedmap.Get(metadata, inspectionmetadata.ParameterValueSetMetadataKey)
+			if !found {
+				t.Fatalf("parameter value set was not found in the metadata set")
+			}
+			if diff := cmp.Diff(tc.want, parameterValues.Values()); diff != "" {
+				t.Errorf("Unexpected recorded values (-want +got):\n%s", diff)
+			}
+		})
+	}
 }
@@ -0,0 +1,111 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package formtask
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	common_task "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// GroupFormTaskBuilder is an utility to construct an instance of task generating a group of form fields.
+// The group has no value by itself. Form fields are placed in the group by passing the reference of the group task to `WithGroup()` of their builders,
+// and they are sorted by their priorities in the group. Groups can be nested in another group in the same way.
+type GroupFormTaskBuilder struct {
+	FormTaskBuilderBase[struct{}]
+	collapsible        bool
+	collapsedByDefault bool
+}
+
+// NewGroupFormTaskBuilder constructs an instance of GroupFormTaskBuilder.
+func NewGroupFormTaskBuilder(id taskid.TaskImplementationID[struct{}], priority int, label string) *GroupFormTaskBuilder {
+	return &GroupFormTaskBuilder{
+		FormTaskBuilderBase: NewFormTaskBuilderBase(id, priority, label),
+	}
+}
+
+func (b *GroupFormTaskBuilder) WithDependencies(dependencies []taskid.UntypedTaskReference) *GroupFormTaskBuilder {
+	b.FormTaskBuilderBase.WithDependencies(dependencies)
+	return b
+}
+
+func (b *GroupFormTaskBuilder) WithDescription(description string) *GroupFormTaskBuilder {
+	b.FormTaskBuilderBase.WithDescription(description)
+	return b
+}
+
+// WithGroup nests this group in the parent group generated by the task built with GroupFormTaskBuilder.
+func (b *GroupFormTaskBuilder) WithGroup(group taskid.TaskReference[struct{}]) *GroupFormTaskBuilder {
+	b.FormTaskBuilderBase.WithGroup(group)
+	return b
+}
+
+// WithPosition places the form field in the section at the position relative to the other fields instead of the priority.
+func (b *GroupFormTaskBuilder) WithPosition(position inspectionmetadata.FormPosition) *GroupFormTaskBuilder {
+	b.FormTaskBuilderBase.WithPosition(position)
+	return b
+}
+
+// WithMarkdown marks the description and the hint of the form field as Markdown.
+func (b *GroupFormTaskBuilder) WithMarkdown() *GroupFormTaskBuilder {
+	b.FormTaskBuilderBase.WithMarkdown()
+	return b
+}
+
+// WithDocURL sets the URL of the document describing the syntax or the usage of the form field.
+func (b *GroupFormTaskBuilder) WithDocURL(url string) *GroupFormTaskBuilder {
+	b.FormTaskBuilderBase.WithDocURL(url)
+	return b
+}
+
+// WithCollapsible allows users to collapse the group. The group is collapsed at first when collapsedByDefault is true.
+func (b *GroupFormTaskBuilder) WithCollapsible(collapsedByDefault bool) *GroupFormTaskBuilder {
+	b.collapsible = true
+	b.collapsedByDefault = collapsedByDefault
+	return b
+}
+
+func (b *GroupFormTaskBuilder) Build(labelOpts ...common_task.LabelOpt) common_task.Task[struct{}] {
+	// The group must be set before its children. Run it as early as the form tasks unless the priority is given explicitly.
+	labelOpts = append([]common_task.LabelOpt{common_task.WithExecutionPriority(common_task.TaskExecutionPriorityHigh)}, labelOpts...)
+	return common_task.NewTask(b.id, b.taskDependencies(), func(ctx context.Context) (struct{}, error) {
+		m := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
+
+		field := inspectionmetadata.GroupParameterFormField{}
+		field.Type = inspectionmetadata.Group
+		field.HintType = inspectionmetadata.None
+		field.Children = []inspectionmetadata.ParameterFormField{}
+		field.Collapsible = b.collapsible
+		field.CollapsedByDefault = b.collapsedByDefault
+
+		b.SetupBaseFormField(&field.ParameterFormFieldBase)
+
+		formFields, found := typedmap.Get(m, inspectionmetadata.FormFieldSetMetadataKey)
+		if !found {
+			return struct{}{}, fmt.Errorf("form field set was not found in the metadata set")
+		}
+		err := formFields.SetField(field)
+		if err != nil {
+			return struct{}{}, fmt.Errorf("failed to configure the form metadata in task `%s`\n%v", b.id, err)
+		}
+		return struct{}{}, nil
+	}, labelOpts...)
+}
@@ -0,0 +1,138 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package formtask
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+func TestGroupFormTaskBuilder(t *testing.T) {
+	outerGroupID := taskid.NewDefaultImplementationID[struct{}]("outer-group")
+	innerGroupID := taskid.NewDefaultImplementationID[struct{}]("inner-group")
+	outerFieldID := taskid.NewDefaultImplementationID[string]("outer-field")
+	innerFieldID := taskid.NewDefaultImplementationID[string]("inner-field")
+	topLevelFieldID := taskid.NewDefaultImplementationID[string]("top-level-field")
+
+	outerGroup := NewGroupFormTaskBuilder(outerGroupID, 2, "Outer group").
+		WithDescription("outer group description").
+		Build()
+	innerGroup := NewGroupFormTaskBuilder(innerGroupID, 1, "Inner group").
+		WithGroup(outerGroupID.Ref()).
+		WithCollapsible(true).
+		Build()
+	outerField := NewTextFormTaskBuilder(outerFieldID, 2, "Outer field").WithGroup(outerGroupID.Ref()).Build()
+	innerField := NewTextFormTaskBuilder(innerFieldID, 1, "Inner field").WithGroup(innerGroupID.Ref()).Build()
+	topLevelField := NewTextFormTaskBuilder(topLevelFieldID, 1, "Top level field").Build()
+	mainTask := coretask.NewTask(taskid.NewDefaultImplementationID[struct{}]("main"), []taskid.UntypedTaskReference{outerFieldID.Ref(), innerFieldID.Ref(), topLevelFieldID.Ref()}, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, nil
+	})
+
+	ctx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+	_, metadata, err := inspectiontest.RunInspectionTaskWithDependency(ctx, mainTask, []coretask.UntypedTask{outerGroup, innerGroup, outerField, innerField, topLevelField}, inspectioncore_contract.TaskModeDryRun, map[string]any{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	formFields, found := typedmap.Get(metadata, inspectionmetadata.FormFieldSetMetadataKey)
+	if !found {
+		t.Fatalf("form field set was not found in the metadata set")
+	}
+
+	want := []inspectionmetadata.ParameterFormField{
+		inspectionmetadata.GroupParameterFormField{
+			ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+				ID:          "outer-group",
+				Type:        inspectionmetadata.Group,
+				Label:       "Outer group",
+				Description: "outer group description",
+				Priority:    2,
+				HintType:    inspectionmetadata.None,
+			},
+			Children: []inspectionmetadata.ParameterFormField{
+				inspectionmetadata.TextParameterFormField{
+					ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+						ID:       "outer-field",
+						Type:     inspectionmetadata.Text,
+						Label:    "Outer field",
+						Priority: 2,
+						HintType: inspectionmetadata.None,
+						GroupID:  "outer-group",
+					},
+				},
+				inspectionmetadata.GroupParameterFormField{
+					ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+						ID:       "inner-group",
+						Type:     inspectionmetadata.Group,
+						Label:    "Inner group",
+						Priority: 1,
+						HintType: inspectionmetadata.None,
+						GroupID:  "outer-group",
+					},
+					Collapsible:        true,
+					CollapsedByDefault: true,
+					Children: []inspectionmetadata.ParameterFormField{
+						inspectionmetadata.TextParameterFormField{
+							ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+								ID:       "inner-field",
+								Type:     inspectionmetadata.Text,
+								Label:    "Inner field",
+								Priority: 1,
+								HintType: inspectionmetadata.None,
+								GroupID:  "inner-group",
+							},
+						},
+					},
+				},
+			},
+		},
+		inspectionmetadata.TextParameterFormField{
+			ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+				ID:       "top-level-field",
+				Type:     inspectionmetadata.Text,
+				Label:    "Top level field",
+				Priority: 1,
+				HintType: inspectionmetadata.None,
+			},
+		},
+	}
+	if diff := cmp.Diff(want, formFields.ToSerializable(), cmpopts.IgnoreFields(inspectionmetadata.TextParameterFormField{}, "ValidationTiming")); diff != "" {
+		t.Errorf("unexpected form fields (-want +got):\n%s", diff)
+	}
+}
+
+func TestGroupFormTaskBuilderAddsGroupToDependencies(t *testing.T) {
+	groupID := taskid.NewDefaultImplementationID[struct{}]("group")
+	fieldTask := NewTextFormTaskBuilder(taskid.NewDefaultImplementationID[string]("field"), 1, "Field").
+		WithDependencies([]taskid.UntypedTaskReference{taskid.NewTaskReference[string]("dependency")}).
+		WithGroup(groupID.Ref()).
+		Build()
+
+	got := []string{}
+	for _, dependency := range fieldTask.Dependencies() {
+		got = append(got, dependency.ReferenceIDString())
+	}
+	if diff := cmp.Diff([]string{"dependency", "group"}, got); diff != "" {
+		t.Errorf("unexpected dependencies (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package formtask
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	common_task "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/server/upload"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// multiFileCountValueKey is the key of the file count in the value given to a file form field accepting multiple files.
+const multiFileCountValueKey = "fileCount"
+
+// MultiFileFormTaskBuilder is an utility to construct an instance of task for a file form field accepting multiple files.
+// The frontend gives the number of files as the value of the field like `{"fileCount": 3}`, and the task issues an upload token for each of them.
+// The task returns the upload results in the order of the files given by the user.
+type MultiFileFormTaskBuilder struct {
+	FormTaskBuilderBase[upload.UploadResultList]
+	verifier upload.UploadFileVerifier
+	maxFiles int
+}
+
+// NewMultiFileFormTaskBuilder constructs an instance of MultiFileFormTaskBuilder.
+// The verifier is used for each file independently.
+func NewMultiFileFormTaskBuilder(id taskid.TaskImplementationID[upload.UploadResultList], priority int, label string, verifier upload.UploadFileVerifier) *MultiFileFormTaskBuilder {
+	return &MultiFileFormTaskBuilder{
+		FormTaskBuilderBase: NewFormTaskBuilderBase(id, priority, label),
+		verifier:            verifier,
+	}
+}
+
+// WithDependencies sets the task dependencies
+func (b *MultiFileFormTaskBuilder) WithDependencies(dependencies []taskid.UntypedTaskReference) *MultiFileFormTaskBuilder {
+	b.FormTaskBuilderBase.WithDependencies(dependencies)
+	return b
+}
+
+// WithDescription sets the description for the form field
+func (b *MultiFileFormTaskBuilder) WithDescription(description string) *MultiFileFormTaskBuilder {
+	b.FormTaskBuilderBase.WithDescription(description)
+	return b
+}
+
+// WithGroup places the form field in the group generated by the task built with GroupFormTaskBuilder.
+func (b *MultiFileFormTaskBuilder) WithGroup(group taskid.TaskReference[struct{}]) *MultiFileFormTaskBuilder {
+	b.FormTaskBuilderBase.WithGroup(group)
+	return b
+}
+
+// WithPosition places the form field in the section at the position relative to the other fields instead of the priority.
+func (b *MultiFileFormTaskBuilder) WithPosition(position inspectionmetadata.FormPosition) *MultiFileFormTaskBuilder {
+	b.FormTaskBuilderBase.WithPosition(position)
+	return b
+}
+
+// WithMarkdown marks the description and the hint of the form field as Markdown.
+func (b *MultiFileFormTaskBuilder) WithMarkdown() *MultiFileFormTaskBuilder {
+	b.FormTaskBuilderBase.WithMarkdown()
+	return b
+}
+
+// WithDocURL sets the URL of the document describing the syntax or the usage of the form field.
+func (b *MultiFileFormTaskBuilder) WithDocURL(url string) *MultiFileFormTaskBuilder {
+	b.FormTaskBuilderBase.WithDocURL(url)
+	return b
+}
+
+// WithMaxFiles limits the number of files accepted in the field. 0 means no limit.
+func (b *MultiFileFormTaskBuilder) WithMaxFiles(maxFiles int) *MultiFileFormTaskBuilder {
+	b.maxFiles = maxFiles
+	return b
+}
+
+func (b *MultiFileFormTaskBuilder) Build(labelOpts ...common_task.LabelOpt) common_task.Task[upload.UploadResultList] {
+	return common_task.NewTask(b.id, b.taskDependencies(), func(ctx context.Context) (upload.UploadResultList, error) {
+		metadata := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
+		req := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionTaskInput)
+
+		field := inspectionmetadata.FileParameterFormField{
+			ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+				Type:     inspectionmetadata.File,
+				HintType: inspectionmetadata.None,
+			},
+			Multiple: true,
+			Files:    []inspectionmetadata.FileParameterFormFieldFile{},
+		}
+		b.SetupBaseFormField(&field.ParameterFormFieldBase)
+
+		fileCount, err := parseMultiFileCount(req[b.id.ReferenceIDString()])
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for the file form `%s`\n%v", b.id, err)
+		}
+		countHint := ""
+		if b.maxFiles > 0 && fileCount > b.maxFiles {
+			countHint = fmt.Sprintf("At most %d files can be uploaded.", b.maxFiles)
+			fileCount = b.maxFiles
+		}
+
+		results := make(upload.UploadResultList, 0, fileCount)
+		for i := 0; i < fileCount; i++ {
+			token := upload.DefaultUploadFileStore.GetUploadToken(GenerateUploadIDWithTaskContext(ctx, fmt.Sprintf("%s_%d", b.id.ReferenceIDString(), i)), b.verifier)
+			uploadResult, err := upload.DefaultUploadFileStore.GetResult(token)
+			if err != nil {
+				return nil, err
+			}
+			hint, hintType := hintFromUploadResult(uploadResult)
+			field.Files = append(field.Files, inspectionmetadata.FileParameterFormFieldFile{
+				Token:    token,
+				Status:   uploadResult.Status,
+				HintType: hintType,
+				Hint:     hint,
+			})
+			results = append(results, uploadResult)
+		}
+		field = setFormHintsFromMultipleFiles(field, countHint)
+
+		formFields, found := typedmap.Get(metadata, inspectionmetadata.FormFieldSetMetadataKey)
+		if !found {
+			return nil, fmt.Errorf("failed to get form fields from metadata")
+		}
+		err = formFields.SetField(field)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure the form metadata in task `%s`\n%v", b.id, err)
+		}
+		return results, nil
+	}, labelOpts...)
+}
+
+// parseMultiFileCount reads the number of files from the value given to a file form field accepting multiple files.
+// It returns 0 when no value was given.
+func parseMultiFileCount(value any) (int, error) {
+	if value == nil {
+		return 0, nil
+	}
+	valueMap, ok := value.(map[string]any)
+	if !ok {
+		return 0, fmt.Errorf("the value must be an object with `%s`, but got %T", multiFileCountValueKey, value)
+	}
+	countAny, found := valueMap[multiFileCountValueKey]
+	if !found {
+		return 0, nil
+	}
+	var count float64
+	switch c := countAny.(type) {
+	case float64:
+		count = c
+	case int:
+		count = float64(c)
+	default:
+		return 0, fmt.Errorf("`%s` must be a number, but got %T", multiFileCountValueKey, countAny)
+	}
+	if count < 0 || count != math.Trunc(count) {
+		return 0, fmt.Errorf("`%s` must be a non negative integer, but got %v", multiFileCountValueKey, count)
+	}
+	return int(count), nil
+}
+
+// setFormHintsFromMultipleFiles sets the status and the hint of the field accepting multiple files from the status of each file.
+// The status of the field is the least progressed status among the files, and the hint is the first one found among the files.
+func setFormHintsFromMultipleFiles(field inspectionmetadata.FileParameterFormField, countHint string) inspectionmetadata.FileParameterFormField {
+	if countHint != "" {
+		field.Hint = countHint
+		field.HintType = inspectionmetadata.Error
+	}
+	if len(field.Files) == 0 {
+		field.Status = upload.UploadStatusWaiting
+		if field.HintType == inspectionmetadata.None {
+			field.Hint = "Waiting files to be uploaded."
+			field.HintType = inspectionmetadata.Error
+		}
+		return field
+	}
+	field.Status = upload.UploadStatusCompleted
+	for i, file := range field.Files {
+		field.Status = min(field.Status, file.Status)
+		if field.HintType == inspectionmetadata.None && file.HintType != inspectionmetadata.None {
+			field.Hint = fmt.Sprintf("File %d: %s", i+1, file.Hint)
+			field.HintType = file.HintType
+		}
+	}
+	return field
+}
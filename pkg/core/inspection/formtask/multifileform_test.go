@@ -0,0 +1,220 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package formtask
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/server/upload"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+func TestParseMultiFileCount(t *testing.T) {
+	testCases := []struct {
+		name    string
+		value   any
+		want    int
+		wantErr bool
+	}{
+		{name: "no value", value: nil, want: 0},
+		{name: "without file count", value: map[string]any{"refreshedAt": "2025-01-01T00:00:00Z"}, want: 0},
+		{name: "file count decoded from JSON", value: map[string]any{"fileCount": float64(3)}, want: 3},
+		{name: "file count as int", value: map[string]any{"fileCount": 2}, want: 2},
+		{name: "non object value", value: "3", wantErr: true},
+		{name: "non number file count", value: map[string]any{"fileCount": "3"}, wantErr: true},
+		{name: "negative file count", value: map[string]any{"fileCount": float64(-1)}, wantErr: true},
+		{name: "fractional file count", value: map[string]any{"fileCount": 1.5}, wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseMultiFileCount(tc.value)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("parseMultiFileCount(%v) returned error %v, wantErr %v", tc.value, err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("parseMultiFileCount(%v) = %d, want %d", tc.value, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSetFormHintsFromMultipleFiles(t *testing.T) {
+	testCases := []struct {
+		name         string
+		files        []inspectionmetadata.FileParameterFormFieldFile
+		countHint    string
+		wantStatus   upload.UploadStatus
+		wantHint     string
+		wantHintType inspectionmetadata.ParameterHintType
+	}{
+		{
+			name:         "no files",
+			files:        []inspectionmetadata.FileParameterFormFieldFile{},
+			wantStatus:   upload.UploadStatusWaiting,
+			wantHint:     "Waiting files to be uploaded.",
+			wantHintType: inspectionmetadata.Error,
+		},
+		{
+			name: "all files completed",
+			files: []inspectionmetadata.FileParameterFormFieldFile{
+				{Status: upload.UploadStatusCompleted, HintType: inspectionmetadata.None},
+				{Status: upload.UploadStatusCompleted, HintType: inspectionmetadata.None},
+			},
+			wantStatus:   upload.UploadStatusCompleted,
+			wantHintType: inspectionmetadata.None,
+		},
+		{
+			name: "a file is being verified",
+			files: []inspectionmetadata.FileParameterFormFieldFile{
+				{Status: upload.UploadStatusCompleted, HintType: inspectionmetadata.None},
+				{Status: upload.UploadStatusVerifying, HintType: inspectionmetadata.Error, Hint: "File is being processed. Please wait a moment."},
+			},
+			wantStatus:   upload.UploadStatusVerifying,
+			wantHint:     "File 2: File is being processed. Please wait a moment.",
+			wantHintType: inspectionmetadata.Error,
+		},
+		{
+			name: "too many files",
+			files: []inspectionmetadata.FileParameterFormFieldFile{
+				{Status: upload.UploadStatusCompleted, HintType: inspectionmetadata.None},
+			},
+			countHint:    "At most 1 files can be uploaded.",
+			wantStatus:   upload.UploadStatusCompleted,
+			wantHint:     "At most 1 files can be uploaded.",
+			wantHintType: inspectionmetadata.Error,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			field := inspectionmetadata.FileParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					HintType: inspectionmetadata.None,
+				},
+				Multiple: true,
+				Files:    tc.files,
+			}
+			got := setFormHintsFromMultipleFiles(field, tc.countHint)
+			if got.Status != tc.wantStatus {
+				t.Errorf("status = %v, want %v", got.Status, tc.wantStatus)
+			}
+			if got.Hint != tc.wantHint || got.HintType != tc.wantHintType {
+				t.Errorf("hint = (%q, %v), want (%q, %v)", got.Hint, got.HintType, tc.wantHint, tc.wantHintType)
+			}
+		})
+	}
+}
+
+func TestMultiFileFormTaskBuilder(t *testing.T) {
+	originalStore := upload.DefaultUploadFileStore
+	defer func() { upload.DefaultUploadFileStore = originalStore }()
+	provider := upload.NewLocalUploadFileStoreProvider(t.TempDir())
+	upload.DefaultUploadFileStore = upload.NewUploadFileStore(provider)
+
+	formTaskID := taskid.NewDefaultImplementationID[upload.UploadResultList]("foo-files")
+	task := NewMultiFileFormTaskBuilder(formTaskID, 1, "Foo files", &upload.NopWaitUploadFileVerifier{}).
+		WithMaxFiles(2).
+		Build()
+	ctx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+	input := map[string]any{"foo-files": map[string]any{"fileCount": float64(3)}}
+
+	_, metadata, err := inspectiontest.RunInspectionTask(ctx, task, inspectioncore_contract.TaskModeDryRun, input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	field := getMultiFileFormField(t, metadata)
+	if len(field.Files) != 2 {
+		t.Fatalf("got %d files, want 2 files limited by WithMaxFiles", len(field.Files))
+	}
+	if field.Hint != "At most 2 files can be uploaded." {
+		t.Errorf("got hint %q, want the hint for too many files", field.Hint)
+	}
+
+	// Upload files in the reversed order to check the order of results follows the order of tokens.
+	contents := []string{"first", "second"}
+	for i := len(field.Files) - 1; i >= 0; i-- {
+		token := field.Files[i].Token
+		if err := upload.DefaultUploadFileStore.SetResultOnStartingUpload(token); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := provider.Write(token, strings.NewReader(contents[i])); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := upload.DefaultUploadFileStore.SetResultOnCompletedUpload(token, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	input = map[string]any{"foo-files": map[string]any{"fileCount": float64(2)}}
+	var results upload.UploadResultList
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		// The context is recreated not to reuse the form metadata generated in the previous run.
+		ctx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+		results, metadata, err = inspectiontest.RunInspectionTask(ctx, task, inspectioncore_contract.TaskModeRun, input)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if getMultiFileFormField(t, metadata).Status == upload.UploadStatusCompleted {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("the uploaded files were not verified in time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	field = getMultiFileFormField(t, metadata)
+	if field.HintType != inspectionmetadata.None {
+		t.Errorf("got hint %q, want no hint after all files were uploaded", field.Hint)
+	}
+
+	readers, err := results.GetReaders()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, reader := range readers {
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		reader.Close()
+		got = append(got, string(data))
+	}
+	if diff := cmp.Diff(contents, got); diff != "" {
+		t.Errorf("unexpected file contents (-want +got):\n%s", diff)
+	}
+}
+
+func getMultiFileFormField(t *testing.T, metadata *typedmap.ReadonlyTypedMap) inspectionmetadata.FileParameterFormField {
+	t.Helper()
+	formFields, found := typedmap.Get(metadata, inspectionmetadata.FormFieldSetMetadataKey)
+	if !found {
+		t.Fatalf("form field set was not found in the metadata set")
+	}
+	field, ok := formFields.DangerouslyGetField("foo-files").(inspectionmetadata.FileParameterFormField)
+	if !ok {
+		t.Fatalf("file form field was not found")
+	}
+	return field
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package formtask
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	common_task "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// SecretFormValidator is a function to check if the given secret is valid or not.
+// Returns "" as the result when it has no error, otherwise the returned value is used as an error message on frontend.
+// The secret value contained in the returned message is redacted.
+type SecretFormValidator = func(ctx context.Context, value string) (string, error)
+
+// SecretFormValueConverter is a function type to convert the given secret to another type stored in the variable set.
+type SecretFormValueConverter[T any] = func(ctx context.Context, value string) (T, error)
+
+// SecretFormTaskBuilder is an utility to construct an instance of task for secret form field like tokens or passwords.
+// Unlike the other form fields, the given value is never included in the metadata nor stored as the previous value,
+// and it is redacted from logs written in the inspection run.
+type SecretFormTaskBuilder[T any] struct {
+	FormTaskBuilderBase[T]
+	validator SecretFormValidator
+	converter SecretFormValueConverter[T]
+}
+
+// NewSecretFormTaskBuilder constructs an instance of SecretFormTaskBuilder.
+// The converter is initialized with a function to return the given value as a string.
+func NewSecretFormTaskBuilder[T any](id taskid.TaskImplementationID[T], priority int, fieldLabel string) *SecretFormTaskBuilder[T] {
+	return &SecretFormTaskBuilder[T]{
+		FormTaskBuilderBase: NewFormTaskBuilderBase(id, priority, fieldLabel),
+		validator: func(ctx context.Context, value string) (string, error) {
+			return "", nil
+		},
+		converter: func(ctx context.Context, value string) (T, error) {
+			var anyValue any = value
+			if converted, convertible := anyValue.(T); convertible {
+				return converted, nil
+			}
+			return *new(T), fmt.Errorf("value is not convertible to %T in the default converter. Did you forget to set the custom converter?", (*T)(nil))
+		},
+	}
+}
+
+func (b *SecretFormTaskBuilder[T]) WithDependencies(dependencies []taskid.UntypedTaskReference) *SecretFormTaskBuilder[T] {
+	b.FormTaskBuilderBase.WithDependencies(dependencies)
+	return b
+}
+
+func (b *SecretFormTaskBuilder[T]) WithDescription(description string) *SecretFormTaskBuilder[T] {
+	b.FormTaskBuilderBase.WithDescription(description)
+	return b
+}
+
+// WithGroup places the form field in the group generated by the task built with GroupFormTaskBuilder.
+func (b *SecretFormTaskBuilder[T]) WithGroup(group taskid.TaskReference[struct{}]) *SecretFormTaskBuilder[T] {
+	b.FormTaskBuilderBase.WithGroup(group)
+	return b
+}
+
+// WithPosition places the form field in the section at the position relative to the other fields instead of the priority.
+func (b *SecretFormTaskBuilder[T]) WithPosition(position inspectionmetadata.FormPosition) *SecretFormTaskBuilder[T] {
+	b.FormTaskBuilderBase.WithPosition(position)
+	return b
+}
+
+// WithMarkdown marks the description and the hint of the form field as Markdown.
+func (b *SecretFormTaskBuilder[T]) WithMarkdown() *SecretFormTaskBuilder[T] {
+	b.FormTaskBuilderBase.WithMarkdown()
+	return b
+}
+
+// WithDocURL sets the URL of the document describing the syntax or the usage of the form field.
+func (b *SecretFormTaskBuilder[T]) WithDocURL(url string) *SecretFormTaskBuilder[T] {
+	b.FormTaskBuilderBase.WithDocURL(url)
+	return b
+}
+
+func (b *SecretFormTaskBuilder[T]) WithValidator(validator SecretFormValidator) *SecretFormTaskBuilder[T] {
+	b.validator = validator
+	return b
+}
+
+func (b *SecretFormTaskBuilder[T]) WithConverter(converter SecretFormValueConverter[T]) *SecretFormTaskBuilder[T] {
+	b.converter = converter
+	return b
+}
+
+func (b *SecretFormTaskBuilder[T]) Build(labelOpts ...common_task.LabelOpt) common_task.Task[T] {
+	return common_task.NewTask(b.id, b.taskDependencies(), func(ctx context.Context) (T, error) {
+		m := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
+		req := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionTaskInput)
+		taskMode := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionTaskMode)
+
+		currentValue := ""
+		if valueRaw, exist := req[b.id.ReferenceIDString()]; exist {
+			valueString, isString := valueRaw.(string)
+			if !isString {
+				return *new(T), fmt.Errorf("request parameter `%s` was not given in string in task %s", b.id, b.id)
+			}
+			currentValue = valueString
+		}
+
+		secrets, err := khictx.GetValue(ctx, inspectioncore_contract.InspectionSecretValues)
+		if err != nil {
+			// The context for a run always contains the secret values. This is only for tasks run out of the inspection runner.
+			secrets = inspectioncore_contract.NewSecretValues()
+		}
+		secrets.Add(currentValue)
+
+		field := inspectionmetadata.SecretParameterFormField{}
+		field.Type = inspectionmetadata.Secret
+		field.HintType = inspectionmetadata.None
+		field.ValueGiven = currentValue != ""
+
+		b.SetupBaseFormField(&field.ParameterFormFieldBase)
+
+		validationErr, err := b.validator(ctx, currentValue)
+		if err != nil {
+			return *new(T), fmt.Errorf("validator for task `%s` returned an unrecoverable error\n%s", b.id, secrets.Redact(err.Error()))
+		}
+		validationErr = secrets.Redact(validationErr)
+		if validationErr != "" && taskMode == inspectioncore_contract.TaskModeRun {
+			return *new(T), fmt.Errorf("validator for task `%s` returned a validation error. But this task was executed as a Run mode not in DryRun. All validations must be resolved before running.\n%v", b.id, validationErr)
+		}
+
+		var convertedValue T
+		if validationErr != "" {
+			field.HintType = inspectionmetadata.Error
+			field.Hint = validationErr
+		} else {
+			convertedValue, err = b.converter(ctx, currentValue)
+			if err != nil {
+				return *new(T), fmt.Errorf("failed to convert the secret value to the dedicated value in task %s\n%s", b.id, secrets.Redact(err.Error()))
+			}
+		}
+
+		formFields, found := typedmap.Get(m, inspectionmetadata.FormFieldSetMetadataKey)
+		if !found {
+			return *new(T), fmt.Errorf("form field set was not found in the metadata set")
+		}
+		err = formFields.SetField(field)
+		if err != nil {
+			return *new(T), fmt.Errorf("failed to configure the form metadata in task `%s`\n%v", b.id, err)
+		}
+		return convertedValue, nil
+	}, append(labelOpts, inspectioncore_contract.NewFormTaskLabelOpt(
+		b.label,
+		b.description,
+	), common_task.WithLabelValue(inspectioncore_contract.TaskLabelKeyIsSecretFormTask, true))...)
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package formtask
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+func TestSecretFormDefinitionBuilder(t *testing.T) {
+	testCases := []struct {
+		Name              string
+		Validator         SecretFormValidator
+		RequestValue      any
+		ExpectedFormField inspectionmetadata.SecretParameterFormField
+		ExpectedValue     string
+		ExpectedRunError  bool
+	}{
+		{
+			Name:          "A secret form with given parameter",
+			RequestValue:  "s3cr3t-token",
+			ExpectedValue: "s3cr3t-token",
+			ExpectedFormField: inspectionmetadata.SecretParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					HintType: inspectionmetadata.None,
+				},
+				ValueGiven: true,
+			},
+		},
+		{
+			Name:          "A secret form without parameter",
+			ExpectedValue: "",
+			ExpectedFormField: inspectionmetadata.SecretParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					HintType: inspectionmetadata.None,
+				},
+				ValueGiven: false,
+			},
+		},
+		{
+			Name: "A secret form with validation error containing the secret",
+			Validator: func(ctx context.Context, value string) (string, error) {
+				return fmt.Sprintf("token `%s` is too short", value), nil
+			},
+			RequestValue:     "s3cr3t-token",
+			ExpectedValue:    "",
+			ExpectedRunError: true,
+			ExpectedFormField: inspectionmetadata.SecretParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					HintType: inspectionmetadata.Error,
+					Hint:     "token `[REDACTED]` is too short",
+				},
+				ValueGiven: true,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			builder := NewSecretFormTaskBuilder(taskid.NewDefaultImplementationID[string]("foo-secret"), 1, "foo label")
+			if testCase.Validator != nil {
+				builder.WithValidator(testCase.Validator)
+			}
+			taskDef := builder.Build()
+
+			inputMap := map[string]any{}
+			if testCase.RequestValue != nil {
+				inputMap["foo-secret"] = testCase.RequestValue
+			}
+
+			// Execute task as DryRun mode
+			secrets := inspectioncore_contract.NewSecretValues()
+			taskCtx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+			taskCtx = khictx.WithValue(taskCtx, inspectioncore_contract.InspectionSecretValues, secrets)
+			result, _, err := inspectiontest.RunInspectionTask(taskCtx, taskDef, inspectioncore_contract.TaskModeDryRun, inputMap)
+			if err != nil {
+				t.Fatalf("task was ended with unexpected error\n%s", err)
+			}
+			if result != testCase.ExpectedValue {
+				t.Errorf("the result is not matching with the expected value. expected:%s, actual:%s", testCase.ExpectedValue, result)
+			}
+			metadata := khictx.MustGetValue(taskCtx, inspectioncore_contract.InspectionRunMetadata)
+			fields, found := typedmap.Get(metadata, inspectionmetadata.FormFieldSetMetadataKey)
+			if !found {
+				t.Fatal("FormFieldSet not found on metadata")
+			}
+			field := fields.DangerouslyGetField("foo-secret")
+			if diff := cmp.Diff(testCase.ExpectedFormField, field, cmpopts.IgnoreFields(inspectionmetadata.ParameterFormFieldBase{}, "ID", "Priority", "Type", "Label")); diff != "" {
+				t.Errorf("the generated form field is different from the expected\n%s", diff)
+			}
+			serializedFields, err := json.Marshal(fields.ToSerializable())
+			if err != nil {
+				t.Fatalf("failed to serialize the form fields\n%s", err)
+			}
+			if value, isString := testCase.RequestValue.(string); isString {
+				if strings.Contains(string(serializedFields), value) {
+					t.Errorf("the serialized metadata contains the secret value\n%s", serializedFields)
+				}
+				if redacted := secrets.Redact(value); redacted != inspectioncore_contract.RedactedSecretValue {
+					t.Errorf("the secret value was not registered for redaction. got %s", redacted)
+				}
+			}
+
+			// Execute task as Run mode
+			taskCtx = inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+			result, _, err = inspectiontest.RunInspectionTask(taskCtx, taskDef, inspectioncore_contract.TaskModeRun, inputMap)
+			if testCase.ExpectedRunError {
+				if err == nil {
+					t.Fatalf("task was expected to be end with an error in Run mode. But the task finished without an error")
+				}
+				if value, isString := testCase.RequestValue.(string); isString && strings.Contains(err.Error(), value) {
+					t.Errorf("the error contains the secret value\n%s", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("task was ended with unexpected error\n%s", err)
+			}
+			if result != testCase.ExpectedValue {
+				t.Errorf("the result is not matching with the expected value. expected:%s, actual:%s", testCase.ExpectedValue, result)
+			}
+			globalSharedMap := khictx.MustGetValue(taskCtx, inspectioncore_contract.GlobalSharedMap)
+			for _, key := range globalSharedMap.Keys() {
+				if strings.Contains(key, "foo-secret") {
+					t.Errorf("the secret form must not store the previous value. found key %s", key)
+				}
+			}
+		})
+	}
+}
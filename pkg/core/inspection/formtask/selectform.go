@@ -0,0 +1,262 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package formtask
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	common_task "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// SelectFormOption is an option of the select form field.
+// Value is the value returned from the task when the option with the ID is selected.
+type SelectFormOption[T any] struct {
+	ID          string
+	Label       string
+	Description string
+	Value       T
+}
+
+// SelectFormValidator is a function to check if the given value is valid or not.
+// This is called only when the value is one of the options.
+// Returns "" as the result when it has no error, otherwise the returned value is used as an error message on frontend.
+type SelectFormValidator = func(ctx context.Context, value string) (string, error)
+
+// SelectFormDefaultValueGenerator is a function type to generate the ID of the option selected by default.
+type SelectFormDefaultValueGenerator = func(ctx context.Context, previousValues []string) (string, error)
+
+// SelectFormOptionsProvider is a function to return the list of options.
+type SelectFormOptionsProvider[T any] = func(ctx context.Context, previousValues []string) ([]SelectFormOption[T], error)
+
+// SelectFormHintGenerator is a function type to generate a hint string
+type SelectFormHintGenerator = func(ctx context.Context, value string, convertedValue any) (string, inspectionmetadata.ParameterHintType, error)
+
+// SelectFormTaskBuilder is an utility to construct an instance of task for select form field.
+// The task returns the Value of the selected option and rejects any value not included in the options.
+type SelectFormTaskBuilder[T any] struct {
+	FormTaskBuilderBase[T]
+	defaultValue    SelectFormDefaultValueGenerator
+	validator       SelectFormValidator
+	optionsProvider SelectFormOptionsProvider[T]
+	hintGenerator   SelectFormHintGenerator
+}
+
+// NewSelectFormTaskBuilder constructs an instance of SelectFormTaskBuilder.
+// The default value is initialized with a function returning an empty string, that means the first option is selected by default.
+func NewSelectFormTaskBuilder[T any](id taskid.TaskImplementationID[T], priority int, fieldLabel string) *SelectFormTaskBuilder[T] {
+	return &SelectFormTaskBuilder[T]{
+		FormTaskBuilderBase: NewFormTaskBuilderBase(id, priority, fieldLabel),
+		defaultValue: func(ctx context.Context, previousValues []string) (string, error) {
+			return "", nil
+		},
+		validator: func(ctx context.Context, value string) (string, error) {
+			return "", nil
+		},
+		optionsProvider: func(ctx context.Context, previousValues []string) ([]SelectFormOption[T], error) {
+			return []SelectFormOption[T]{}, nil
+		},
+		hintGenerator: func(ctx context.Context, value string, convertedValue any) (string, inspectionmetadata.ParameterHintType, error) {
+			return "", inspectionmetadata.Info, nil
+		},
+	}
+}
+
+func (b *SelectFormTaskBuilder[T]) WithDependencies(dependencies []taskid.UntypedTaskReference) *SelectFormTaskBuilder[T] {
+	b.FormTaskBuilderBase.WithDependencies(dependencies)
+	return b
+}
+
+func (b *SelectFormTaskBuilder[T]) WithDescription(description string) *SelectFormTaskBuilder[T] {
+	b.FormTaskBuilderBase.WithDescription(description)
+	return b
+}
+
+// WithGroup places the form field in the group generated by the task built with GroupFormTaskBuilder.
+func (b *SelectFormTaskBuilder[T]) WithGroup(group taskid.TaskReference[struct{}]) *SelectFormTaskBuilder[T] {
+	b.FormTaskBuilderBase.WithGroup(group)
+	return b
+}
+
+// WithPosition places the form field in the section at the position relative to the other fields instead of the priority.
+func (b *SelectFormTaskBuilder[T]) WithPosition(position inspectionmetadata.FormPosition) *SelectFormTaskBuilder[T] {
+	b.FormTaskBuilderBase.WithPosition(position)
+	return b
+}
+
+// WithMarkdown marks the description and the hint of the form field as Markdown.
+func (b *SelectFormTaskBuilder[T]) WithMarkdown() *SelectFormTaskBuilder[T] {
+	b.FormTaskBuilderBase.WithMarkdown()
+	return b
+}
+
+// WithDocURL sets the URL of the document describing the syntax or the usage of the form field.
+func (b *SelectFormTaskBuilder[T]) WithDocURL(url string) *SelectFormTaskBuilder[T] {
+	b.FormTaskBuilderBase.WithDocURL(url)
+	return b
+}
+
+func (b *SelectFormTaskBuilder[T]) WithValidator(validator SelectFormValidator) *SelectFormTaskBuilder[T] {
+	b.validator = validator
+	return b
+}
+
+func (b *SelectFormTaskBuilder[T]) WithDefaultValueFunc(defFunc SelectFormDefaultValueGenerator) *SelectFormTaskBuilder[T] {
+	b.defaultValue = defFunc
+	return b
+}
+
+func (b *SelectFormTaskBuilder[T]) WithDefaultValueConstant(defValue string, preferPrevValue bool) *SelectFormTaskBuilder[T] {
+	return b.WithDefaultValueFunc(func(ctx context.Context, previousValues []string) (string, error) {
+		if preferPrevValue {
+			if len(previousValues) > 0 {
+				return previousValues[0], nil
+			}
+		}
+		return defValue, nil
+	})
+}
+
+func (b *SelectFormTaskBuilder[T]) WithOptionsFunc(optionsFunc SelectFormOptionsProvider[T]) *SelectFormTaskBuilder[T] {
+	b.optionsProvider = optionsFunc
+	return b
+}
+
+func (b *SelectFormTaskBuilder[T]) WithOptionsConstant(options []SelectFormOption[T]) *SelectFormTaskBuilder[T] {
+	return b.WithOptionsFunc(func(ctx context.Context, previousValues []string) ([]SelectFormOption[T], error) {
+		return options, nil
+	})
+}
+
+func (b *SelectFormTaskBuilder[T]) WithHintFunc(hintFunc SelectFormHintGenerator) *SelectFormTaskBuilder[T] {
+	b.hintGenerator = hintFunc
+	return b
+}
+
+func (b *SelectFormTaskBuilder[T]) Build(labelOpts ...common_task.LabelOpt) common_task.Task[T] {
+	return common_task.NewTask(b.id, b.taskDependencies(), func(ctx context.Context) (T, error) {
+		m := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
+		req := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionTaskInput)
+		taskMode := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionTaskMode)
+		globalSharedMap := khictx.MustGetValue(ctx, inspectioncore_contract.GlobalSharedMap)
+
+		previousValueStoreKey := typedmap.NewTypedKey[[]string](fmt.Sprintf("select-form-pv-%s", b.id))
+		prevValue := typedmap.GetOrDefault(globalSharedMap, previousValueStoreKey, []string{})
+
+		options, err := b.optionsProvider(ctx, prevValue)
+		if err != nil {
+			return *new(T), fmt.Errorf("options provider for task `%s` returned an error\n%v", b.id, err)
+		}
+		if len(options) == 0 {
+			return *new(T), fmt.Errorf("options provider for task `%s` returned no option", b.id)
+		}
+		optionsByID := map[string]SelectFormOption[T]{}
+		field := inspectionmetadata.SelectParameterFormField{}
+		field.Options = make([]inspectionmetadata.SelectParameterFormFieldOptionItem, len(options))
+		for i, option := range options {
+			if _, found := optionsByID[option.ID]; found {
+				return *new(T), fmt.Errorf("options provider for task `%s` returned the duplicated option `%s`", b.id, option.ID)
+			}
+			optionsByID[option.ID] = option
+			field.Options[i] = inspectionmetadata.SelectParameterFormFieldOptionItem{
+				ID:          option.ID,
+				Label:       option.Label,
+				Description: option.Description,
+			}
+		}
+
+		// Compute the default value. The first option is used when the generated default value is not one of the options.
+		defaultValue, err := b.defaultValue(ctx, prevValue)
+		if err != nil {
+			return *new(T), fmt.Errorf("default value generator for task `%s` returned an error\n%v", b.id, err)
+		}
+		if _, found := optionsByID[defaultValue]; !found {
+			defaultValue = options[0].ID
+		}
+		field.Default = defaultValue
+		currentValue := defaultValue
+		if valueRaw, exist := req[b.id.ReferenceIDString()]; exist {
+			valueString, isString := valueRaw.(string)
+			if !isString {
+				return *new(T), fmt.Errorf("request parameter `%s` was not given in string in task %s", b.id, b.id)
+			}
+			currentValue = valueString
+		}
+
+		field.Type = inspectionmetadata.Select
+		field.HintType = inspectionmetadata.Info
+
+		b.SetupBaseFormField(&field.ParameterFormFieldBase)
+
+		validationErr := ""
+		if _, found := optionsByID[currentValue]; !found {
+			validationErr = fmt.Sprintf("`%s` is not one of the available options", currentValue)
+		} else {
+			validationErr, err = b.validator(ctx, currentValue)
+			if err != nil {
+				return *new(T), fmt.Errorf("validator for task `%s` returned an unrecoverable error\n%v", b.id, err)
+			}
+		}
+		if validationErr != "" {
+			// When invalid, fallback to default
+			currentValue = defaultValue
+		}
+		if validationErr != "" && taskMode == inspectioncore_contract.TaskModeRun {
+			return *new(T), fmt.Errorf("validator for task `%s` returned a validation error. But this task was executed as a Run mode not in DryRun. All validations must be resolved before running.\n%v", b.id, validationErr)
+		}
+
+		convertedValue := optionsByID[currentValue].Value
+		if validationErr != "" {
+			field.HintType = inspectionmetadata.Error
+			field.Hint = validationErr
+		} else {
+			hint, hintType, err := b.hintGenerator(ctx, currentValue, convertedValue)
+			if err != nil {
+				return *new(T), fmt.Errorf("failed to generate a hint for task %s\n%v", b.id, err)
+			}
+			if hint == "" {
+				hintType = inspectionmetadata.None
+			}
+			field.Hint = hint
+			field.HintType = hintType
+			if taskMode == inspectioncore_contract.TaskModeRun {
+				newValueHistory := append([]string{currentValue}, prevValue...)
+				typedmap.Set(globalSharedMap, previousValueStoreKey, newValueHistory)
+			}
+		}
+
+		err = b.RecordParameterValue(m, currentValue)
+		if err != nil {
+			return *new(T), fmt.Errorf("failed to record the parameter value in task `%s`\n%v", b.id, err)
+		}
+		formFields, found := typedmap.Get(m, inspectionmetadata.FormFieldSetMetadataKey)
+		if !found {
+			return *new(T), fmt.Errorf("form field set was not found in the metadata set")
+		}
+		err = formFields.SetField(field)
+		if err != nil {
+			return *new(T), fmt.Errorf("failed to configure the form metadata in task `%s`\n%v", b.id, err)
+		}
+		return convertedValue, nil
+	}, append(labelOpts, inspectioncore_contract.NewFormTaskLabelOpt(
+		b.label,
+		b.description,
+	))...)
+}
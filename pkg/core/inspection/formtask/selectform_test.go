@@ -0,0 +1,244 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package formtask
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+type selectFormConfigurator = func(builder *SelectFormTaskBuilder[int])
+
+var testVerbosityOptions = []SelectFormOption[int]{
+	{ID: "info", Label: "Info", Description: "Info and above", Value: 1},
+	{ID: "debug", Label: "Debug", Value: 2},
+}
+
+var testVerbosityOptionItems = []inspectionmetadata.SelectParameterFormFieldOptionItem{
+	{ID: "info", Label: "Info", Description: "Info and above"},
+	{ID: "debug", Label: "Debug"},
+}
+
+func TestSelectFormDefinitionBuilder(t *testing.T) {
+	testCases := []struct {
+		Name              string
+		FormConfigurator  selectFormConfigurator
+		RequestValue      any
+		ExpectedFormField inspectionmetadata.SelectParameterFormField
+		ExpectedValue     int
+		ExpectedRunError  bool
+	}{
+		{
+			Name: "A select form with given parameter",
+			FormConfigurator: func(builder *SelectFormTaskBuilder[int]) {
+				builder.WithOptionsConstant(testVerbosityOptions)
+			},
+			RequestValue:  "debug",
+			ExpectedValue: 2,
+			ExpectedFormField: inspectionmetadata.SelectParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					HintType: inspectionmetadata.None,
+				},
+				Options: testVerbosityOptionItems,
+				Default: "info",
+			},
+		},
+		{
+			Name: "A select form without parameter selects the first option",
+			FormConfigurator: func(builder *SelectFormTaskBuilder[int]) {
+				builder.WithOptionsConstant(testVerbosityOptions)
+			},
+			ExpectedValue: 1,
+			ExpectedFormField: inspectionmetadata.SelectParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					HintType: inspectionmetadata.None,
+				},
+				Options: testVerbosityOptionItems,
+				Default: "info",
+			},
+		},
+		{
+			Name: "A select form with default parameter",
+			FormConfigurator: func(builder *SelectFormTaskBuilder[int]) {
+				builder.WithOptionsConstant(testVerbosityOptions).WithDefaultValueConstant("debug", true)
+			},
+			ExpectedValue: 2,
+			ExpectedFormField: inspectionmetadata.SelectParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					HintType: inspectionmetadata.None,
+				},
+				Options: testVerbosityOptionItems,
+				Default: "debug",
+			},
+		},
+		{
+			Name: "A select form with a value not in the options",
+			FormConfigurator: func(builder *SelectFormTaskBuilder[int]) {
+				builder.WithOptionsConstant(testVerbosityOptions)
+			},
+			RequestValue:     "trace",
+			ExpectedValue:    1,
+			ExpectedRunError: true,
+			ExpectedFormField: inspectionmetadata.SelectParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					HintType: inspectionmetadata.Error,
+					Hint:     "`trace` is not one of the available options",
+				},
+				Options: testVerbosityOptionItems,
+				Default: "info",
+			},
+		},
+		{
+			Name: "A select form with a custom validator",
+			FormConfigurator: func(builder *SelectFormTaskBuilder[int]) {
+				builder.WithOptionsConstant(testVerbosityOptions).WithValidator(func(ctx context.Context, value string) (string, error) {
+					if value == "debug" {
+						return "debug is not available", nil
+					}
+					return "", nil
+				})
+			},
+			RequestValue:     "debug",
+			ExpectedValue:    1,
+			ExpectedRunError: true,
+			ExpectedFormField: inspectionmetadata.SelectParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					HintType: inspectionmetadata.Error,
+					Hint:     "debug is not available",
+				},
+				Options: testVerbosityOptionItems,
+				Default: "info",
+			},
+		},
+		{
+			Name: "A select form with hint",
+			FormConfigurator: func(builder *SelectFormTaskBuilder[int]) {
+				builder.WithOptionsConstant(testVerbosityOptions).WithHintFunc(func(ctx context.Context, value string, convertedValue any) (string, inspectionmetadata.ParameterHintType, error) {
+					if convertedValue.(int) > 1 {
+						return "debug logs can be large", inspectionmetadata.Warning, nil
+					}
+					return "", inspectionmetadata.Info, nil
+				})
+			},
+			RequestValue:  "debug",
+			ExpectedValue: 2,
+			ExpectedFormField: inspectionmetadata.SelectParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					HintType: inspectionmetadata.Warning,
+					Hint:     "debug logs can be large",
+				},
+				Options: testVerbosityOptionItems,
+				Default: "info",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			builder := NewSelectFormTaskBuilder(taskid.NewDefaultImplementationID[int]("foo-select"), 1, "foo label")
+			testCase.FormConfigurator(builder)
+			taskDef := builder.Build()
+
+			inputMap := map[string]any{}
+			if testCase.RequestValue != nil {
+				inputMap["foo-select"] = testCase.RequestValue
+			}
+
+			// Execute task as DryRun mode
+			taskCtx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+			result, _, err := inspectiontest.RunInspectionTask(taskCtx, taskDef, inspectioncore_contract.TaskModeDryRun, inputMap)
+			if err != nil {
+				t.Fatalf("task was ended with unexpected error\n%s", err)
+			}
+			if result != testCase.ExpectedValue {
+				t.Errorf("the result is not matching with the expected value. expected:%d, actual:%d", testCase.ExpectedValue, result)
+			}
+			metadata := khictx.MustGetValue(taskCtx, inspectioncore_contract.InspectionRunMetadata)
+			fields, found := typedmap.Get(metadata, inspectionmetadata.FormFieldSetMetadataKey)
+			if !found {
+				t.Fatal("FormFieldSet not found on metadata")
+			}
+			field := fields.DangerouslyGetField("foo-select")
+			if diff := cmp.Diff(testCase.ExpectedFormField, field, cmpopts.IgnoreFields(inspectionmetadata.ParameterFormFieldBase{}, "ID", "Priority", "Type", "Label")); diff != "" {
+				t.Errorf("the generated form field is different from the expected\n%s", diff)
+			}
+
+			// Execute task as Run mode
+			taskCtx = inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+			result, _, err = inspectiontest.RunInspectionTask(taskCtx, taskDef, inspectioncore_contract.TaskModeRun, inputMap)
+			if testCase.ExpectedRunError {
+				if err == nil {
+					t.Errorf("task was expected to be end with an error in Run mode. But the task finished without an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("task was ended with unexpected error\n%s", err)
+			}
+			if result != testCase.ExpectedValue {
+				t.Errorf("the result is not matching with the expected value. expected:%d, actual:%d", testCase.ExpectedValue, result)
+			}
+		})
+	}
+}
+
+func TestSelectFormDefinitionBuilder_InvalidOptions(t *testing.T) {
+	testCases := []struct {
+		Name    string
+		Options []SelectFormOption[int]
+	}{
+		{
+			Name:    "no options",
+			Options: []SelectFormOption[int]{},
+		},
+		{
+			Name: "duplicated options",
+			Options: []SelectFormOption[int]{
+				{ID: "info", Value: 1},
+				{ID: "info", Value: 2},
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			taskDef := NewSelectFormTaskBuilder(taskid.NewDefaultImplementationID[int]("foo-select"), 1, "foo label").WithOptionsConstant(testCase.Options).Build()
+			taskCtx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+			_, _, err := inspectiontest.RunInspectionTask(taskCtx, taskDef, inspectioncore_contract.TaskModeDryRun, map[string]any{})
+			if err == nil {
+				t.Errorf("task was expected to be end with an error. But the task finished without an error")
+			}
+		})
+	}
+}
+
+func TestSelectFormDefinitionBuilder_RejectsNonStringValue(t *testing.T) {
+	taskDef := NewSelectFormTaskBuilder(taskid.NewDefaultImplementationID[int]("foo-select"), 1, "foo label").WithOptionsConstant(testVerbosityOptions).Build()
+	taskCtx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+	_, _, err := inspectiontest.RunInspectionTask(taskCtx, taskDef, inspectioncore_contract.TaskModeDryRun, map[string]any{
+		"foo-select": []any{"info"},
+	})
+	if err == nil {
+		t.Errorf("task was expected to be end with an error. But the task finished without an error")
+	}
+}
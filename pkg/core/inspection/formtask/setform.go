@@ -45,6 +45,7 @@ type SetFormHintGenerator = func(ctx context.Context, value []string, convertedV
 type SetFormBoolProvider = func(ctx context.Context) (bool, error)
 
 // SetFormTaskBuilder is an utility to construct an instance of task for input form field.
+// The field works as a multi-select field. Values not included in the options are rejected unless custom values are allowed.
 type SetFormTaskBuilder[T any] struct {
 	FormTaskBuilderBase[T]
 	defaultValue     SetFormDefaultValueGenerator
@@ -96,6 +97,30 @@ func (b *SetFormTaskBuilder[T]) WithDescription(description string) *SetFormTask
 	return b
 }
 
+// WithGroup places the form field in the group generated by the task built with GroupFormTaskBuilder.
+func (b *SetFormTaskBuilder[T]) WithGroup(group taskid.TaskReference[struct{}]) *SetFormTaskBuilder[T] {
+	b.FormTaskBuilderBase.WithGroup(group)
+	return b
+}
+
+// WithPosition places the form field in the section at the position relative to the other fields instead of the priority.
+func (b *SetFormTaskBuilder[T]) WithPosition(position inspectionmetadata.FormPosition) *SetFormTaskBuilder[T] {
+	b.FormTaskBuilderBase.WithPosition(position)
+	return b
+}
+
+// WithMarkdown marks the description and the hint of the form field as Markdown.
+func (b *SetFormTaskBuilder[T]) WithMarkdown() *SetFormTaskBuilder[T] {
+	b.FormTaskBuilderBase.WithMarkdown()
+	return b
+}
+
+// WithDocURL sets the URL of the document describing the syntax or the usage of the form field.
+func (b *SetFormTaskBuilder[T]) WithDocURL(url string) *SetFormTaskBuilder[T] {
+	b.FormTaskBuilderBase.WithDocURL(url)
+	return b
+}
+
 func (b *SetFormTaskBuilder[T]) WithValidator(validator SetFormValidator) *SetFormTaskBuilder[T] {
 	b.validator = validator
 	return b
@@ -184,7 +209,7 @@ func (b *SetFormTaskBuilder[T]) WithConverter(converter SetFormValueConverter[T]
 }
 
 func (b *SetFormTaskBuilder[T]) Build(labelOpts ...common_task.LabelOpt) common_task.Task[T] {
-	return common_task.NewTask(b.id, b.dependencies, func(ctx context.Context) (T, error) {
+	return common_task.NewTask(b.id, b.taskDependencies(), func(ctx context.Context) (T, error) {
 		m := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
 		req := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionTaskInput)
 		taskMode := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionTaskMode)
@@ -254,9 +279,15 @@ func (b *SetFormTaskBuilder[T]) Build(labelOpts ...common_task.LabelOpt) common_
 		}
 		field.Options = options
 
-		validationErr, err := b.validator(ctx, currentValue)
-		if err != nil {
-			return *new(T), fmt.Errorf("validator for task `%s` returned an unrecoverable error\n%v", b.id, err)
+		validationErr := ""
+		if !allowCustomValue {
+			validationErr = validateSetFormValueInOptions(currentValue, options)
+		}
+		if validationErr == "" {
+			validationErr, err = b.validator(ctx, currentValue)
+			if err != nil {
+				return *new(T), fmt.Errorf("validator for task `%s` returned an unrecoverable error\n%v", b.id, err)
+			}
 		}
 		if validationErr != "" {
 			// When invalid, fallback to default
@@ -293,6 +324,10 @@ func (b *SetFormTaskBuilder[T]) Build(labelOpts ...common_task.LabelOpt) common_
 			}
 		}
 
+		err = b.RecordParameterValue(m, currentValue)
+		if err != nil {
+			return *new(T), fmt.Errorf("failed to record the parameter value in task `%s`\n%v", b.id, err)
+		}
 		formFields, found := typedmap.Get(m, inspectionmetadata.FormFieldSetMetadataKey)
 		if !found {
 			return *new(T), fmt.Errorf("form field set was not found in the metadata set")
@@ -307,3 +342,32 @@ func (b *SetFormTaskBuilder[T]) Build(labelOpts ...common_task.LabelOpt) common_
 		b.description,
 	))...)
 }
+
+// NewSetFormElementConverter returns a SetFormValueConverter converting each of the selected values with the given converter.
+func NewSetFormElementConverter[E any](converter func(ctx context.Context, value string) (E, error)) SetFormValueConverter[[]E] {
+	return func(ctx context.Context, value []string) ([]E, error) {
+		result := make([]E, len(value))
+		for i, element := range value {
+			converted, err := converter(ctx, element)
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert the value `%s` at index %d\n%v", element, i, err)
+			}
+			result[i] = converted
+		}
+		return result, nil
+	}
+}
+
+// validateSetFormValueInOptions returns a validation error message when any of the given values is not included in the options.
+func validateSetFormValueInOptions(value []string, options []inspectionmetadata.SetParameterFormFieldOptionItem) string {
+	optionIDs := map[string]struct{}{}
+	for _, option := range options {
+		optionIDs[option.ID] = struct{}{}
+	}
+	for _, element := range value {
+		if _, found := optionIDs[element]; !found {
+			return fmt.Sprintf("`%s` is not one of the available options", element)
+		}
+	}
+	return ""
+}
@@ -16,6 +16,7 @@ package formtask
 
 import (
 	"context"
+	"strconv"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -40,11 +41,13 @@ func TestSetFormDefinitionBuilder(t *testing.T) {
 		ExpectedError     string
 	}{
 		{
-			Name:             "A set form with given parameter",
-			FormConfigurator: func(builder *SetFormTaskBuilder[[]string]) {},
-			RequestValue:     []string{"bar"},
-			ExpectedValue:    []string{"bar"},
-			ExpectedError:    "",
+			Name: "A set form with given parameter",
+			FormConfigurator: func(builder *SetFormTaskBuilder[[]string]) {
+				builder.WithOptionsSimple([]string{"foo", "bar"})
+			},
+			RequestValue:  []string{"bar"},
+			ExpectedValue: []string{"bar"},
+			ExpectedError: "",
 			ExpectedFormField: inspectionmetadata.SetParameterFormField{
 				AllowCustomValue: false,
 				AllowAddAll:      true,
@@ -52,13 +55,16 @@ func TestSetFormDefinitionBuilder(t *testing.T) {
 				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
 					HintType: inspectionmetadata.None,
 				},
-				Options: []inspectionmetadata.SetParameterFormFieldOptionItem{},
+				Options: []inspectionmetadata.SetParameterFormFieldOptionItem{
+					{ID: "foo"},
+					{ID: "bar"},
+				},
 			},
 		},
 		{
 			Name: "A set form with default parameter",
 			FormConfigurator: func(builder *SetFormTaskBuilder[[]string]) {
-				builder.WithDefaultValueConstant([]string{"foo-default"}, true)
+				builder.WithDefaultValueConstant([]string{"foo-default"}, true).WithOptionsSimple([]string{"foo-default"})
 			},
 			RequestValue:  nil, // Simulate missing input
 			ExpectedValue: []string{"foo-default"},
@@ -71,7 +77,9 @@ func TestSetFormDefinitionBuilder(t *testing.T) {
 				AllowAddAll:      true,
 				AllowRemoveAll:   true,
 				Default:          []string{"foo-default"},
-				Options:          []inspectionmetadata.SetParameterFormFieldOptionItem{},
+				Options: []inspectionmetadata.SetParameterFormFieldOptionItem{
+					{ID: "foo-default"},
+				},
 			},
 		},
 		{
@@ -186,3 +194,63 @@ func TestSetFormDefinitionBuilder(t *testing.T) {
 		})
 	}
 }
+
+func TestSetFormDefinitionBuilder_RejectsValueNotInOptions(t *testing.T) {
+	taskDef := NewSetFormTaskBuilder(taskid.NewDefaultImplementationID[[]string]("foo-set"), 1, "foo label").
+		WithOptionsSimple([]string{"opt1", "opt2"}).
+		WithDefaultValueConstant([]string{"opt1"}, false).
+		Build()
+	inputMap := map[string]any{
+		"foo-set": []string{"opt1", "custom"},
+	}
+
+	taskCtx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+	result, _, err := inspectiontest.RunInspectionTask(taskCtx, taskDef, inspectioncore_contract.TaskModeDryRun, inputMap)
+	if err != nil {
+		t.Fatalf("task was ended with unexpected error\n%s", err)
+	}
+	if diff := cmp.Diff([]string{"opt1"}, result); diff != "" {
+		t.Errorf("the result is not matching with the expected value\n%s", diff)
+	}
+	metadata := khictx.MustGetValue(taskCtx, inspectioncore_contract.InspectionRunMetadata)
+	fields, found := typedmap.Get(metadata, inspectionmetadata.FormFieldSetMetadataKey)
+	if !found {
+		t.Fatal("FormFieldSet not found on metadata")
+	}
+	field := fields.DangerouslyGetField("foo-set").(inspectionmetadata.SetParameterFormField)
+	if field.HintType != inspectionmetadata.Error || field.Hint != "`custom` is not one of the available options" {
+		t.Errorf("the form field must have the validation error. hintType=%s, hint=%s", field.HintType, field.Hint)
+	}
+
+	taskCtx = inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+	_, _, err = inspectiontest.RunInspectionTask(taskCtx, taskDef, inspectioncore_contract.TaskModeRun, inputMap)
+	if err == nil {
+		t.Errorf("task was expected to be end with an error in Run mode. But the task finished without an error")
+	}
+}
+
+func TestNewSetFormElementConverter(t *testing.T) {
+	converter := NewSetFormElementConverter(func(ctx context.Context, value string) (int, error) {
+		return strconv.Atoi(value)
+	})
+	taskDef := NewSetFormTaskBuilder(taskid.NewDefaultImplementationID[[]int]("foo-set"), 1, "foo label").
+		WithOptionsSimple([]string{"1", "2", "3"}).
+		WithConverter(converter).
+		Build()
+
+	taskCtx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+	result, _, err := inspectiontest.RunInspectionTask(taskCtx, taskDef, inspectioncore_contract.TaskModeRun, map[string]any{
+		"foo-set": []any{"3", "1"},
+	})
+	if err != nil {
+		t.Fatalf("task was ended with unexpected error\n%s", err)
+	}
+	if diff := cmp.Diff([]int{3, 1}, result); diff != "" {
+		t.Errorf("the result is not matching with the expected value\n%s", diff)
+	}
+
+	_, err = converter(context.Background(), []string{"1", "foo"})
+	if err == nil {
+		t.Errorf("converter was expected to return an error for the non numeric value")
+	}
+}
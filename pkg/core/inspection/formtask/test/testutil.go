@@ -17,10 +17,12 @@ package form_task_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/kyasbal/khi/pkg/common/typedmap"
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
 	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
 	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
 	coretask "github.com/kyasbal/khi/pkg/core/task"
@@ -73,7 +75,140 @@ func TestTextForms[T any](t *testing.T, label string, formTask coretask.Task[T],
 			if textField.ParameterFormFieldBase.ID == "" {
 				t.Errorf("the generated form had the empty Id")
 			}
-			if diff := cmp.Diff(testCase.ExpectedFormField, field, cmpopts.IgnoreFields(inspectionmetadata.ParameterFormFieldBase{}, "Priority", "ID", "Type")); diff != "" {
+			if diff := cmp.Diff(testCase.ExpectedFormField, field, cmpopts.IgnoreFields(inspectionmetadata.ParameterFormFieldBase{}, "Priority", "Position", "ID", "Type")); diff != "" {
+				t.Errorf("the form task didn't generate the expected form field metadata\n%s", diff)
+			}
+		})
+	}
+}
+
+// DateTimeFormTestCase is the type to represent a test case of an inspection task to generate a date time field.
+type DateTimeFormTestCase struct {
+	Name              string
+	Input             string
+	ExpectedValue     time.Time
+	ExpectedFormField inspectionmetadata.DateTimeParameterFormField
+	Dependencies      []coretask.UntypedTask
+}
+
+// TestDateTimeForms tests an inspection task generating a DateTime form in the metadata.
+func TestDateTimeForms(t *testing.T, label string, formTask coretask.Task[time.Time], testCases []*DateTimeFormTestCase) {
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			ctx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+			result, metadata, err := inspectiontest.RunInspectionTaskWithDependency(ctx, formTask, testCase.Dependencies, inspectioncore_contract.TaskModeDryRun, map[string]any{
+				formTask.ID().ReferenceIDString(): testCase.Input,
+			})
+			if err != nil {
+				t.Errorf("form field task returned an error %v", err)
+			}
+			if !result.Equal(testCase.ExpectedValue) {
+				t.Errorf("the form task returned an unexpected value. expected:%s, actual:%s", testCase.ExpectedValue, result)
+			}
+
+			formFields, found := typedmap.Get(metadata, inspectionmetadata.FormFieldSetMetadataKey)
+			if !found {
+				t.Fatalf("form field metadata not found!")
+			}
+			field := formFields.DangerouslyGetField(formTask.UntypedID().GetUntypedReference().String())
+			dateTimeField, convertible := field.(inspectionmetadata.DateTimeParameterFormField)
+			if !convertible {
+				t.Fatal("the generated form is not a DateTimeParameterFormField")
+			}
+			if dateTimeField.ParameterFormFieldBase.Type != inspectionmetadata.DateTime {
+				t.Errorf("the generated form has type %s and it's not datetime", dateTimeField.ParameterFormFieldBase.Type)
+			}
+			if diff := cmp.Diff(testCase.ExpectedFormField, field, cmpopts.IgnoreFields(inspectionmetadata.ParameterFormFieldBase{}, "Priority", "Position", "ID", "Type")); diff != "" {
+				t.Errorf("the form task didn't generate the expected form field metadata\n%s", diff)
+			}
+		})
+	}
+}
+
+// TimeRangeFormTestCase is the type to represent a test case of an inspection task to generate a time range field.
+type TimeRangeFormTestCase struct {
+	Name              string
+	Input             map[string]any
+	ExpectedValue     formtask.TimeRange
+	ExpectedFormField inspectionmetadata.TimeRangeParameterFormField
+	Dependencies      []coretask.UntypedTask
+}
+
+// TestTimeRangeForms tests an inspection task generating a TimeRange form in the metadata. The input is not given to the task when Input is nil.
+func TestTimeRangeForms(t *testing.T, label string, formTask coretask.Task[formtask.TimeRange], testCases []*TimeRangeFormTestCase) {
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			input := map[string]any{}
+			if testCase.Input != nil {
+				input[formTask.ID().ReferenceIDString()] = testCase.Input
+			}
+			ctx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+			result, metadata, err := inspectiontest.RunInspectionTaskWithDependency(ctx, formTask, testCase.Dependencies, inspectioncore_contract.TaskModeDryRun, input)
+			if err != nil {
+				t.Errorf("form field task returned an error %v", err)
+			}
+			if !result.Start.Equal(testCase.ExpectedValue.Start) || !result.End.Equal(testCase.ExpectedValue.End) {
+				t.Errorf("the form task returned an unexpected value. expected:%v, actual:%v", testCase.ExpectedValue, result)
+			}
+
+			formFields, found := typedmap.Get(metadata, inspectionmetadata.FormFieldSetMetadataKey)
+			if !found {
+				t.Fatalf("form field metadata not found!")
+			}
+			field := formFields.DangerouslyGetField(formTask.UntypedID().GetUntypedReference().String())
+			timeRangeField, convertible := field.(inspectionmetadata.TimeRangeParameterFormField)
+			if !convertible {
+				t.Fatal("the generated form is not a TimeRangeParameterFormField")
+			}
+			if timeRangeField.ParameterFormFieldBase.Type != inspectionmetadata.TimeRange {
+				t.Errorf("the generated form has type %s and it's not timerange", timeRangeField.ParameterFormFieldBase.Type)
+			}
+			if diff := cmp.Diff(testCase.ExpectedFormField, field, cmpopts.IgnoreFields(inspectionmetadata.ParameterFormFieldBase{}, "Priority", "Position", "ID", "Type")); diff != "" {
+				t.Errorf("the form task didn't generate the expected form field metadata\n%s", diff)
+			}
+		})
+	}
+}
+
+// SetFormTestCase is the type to represent a test case of an inspection task to generate a set field.
+type SetFormTestCase struct {
+	Name              string
+	Input             []string
+	ExpectedFormField inspectionmetadata.SetParameterFormField
+	Dependencies      []coretask.UntypedTask
+}
+
+// TestSetForms tests an inspection task generating a Set form in the metadata.
+// The options of the field are not compared unless ExpectedFormField has options. The input is not given to the task when Input is nil.
+func TestSetForms[T any](t *testing.T, label string, formTask coretask.Task[T], testCases []*SetFormTestCase) {
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			input := map[string]any{}
+			if testCase.Input != nil {
+				input[formTask.ID().ReferenceIDString()] = testCase.Input
+			}
+			ctx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+			_, metadata, err := inspectiontest.RunInspectionTaskWithDependency(ctx, formTask, testCase.Dependencies, inspectioncore_contract.TaskModeDryRun, input)
+			if err != nil {
+				t.Errorf("form field task returned an error %v", err)
+			}
+
+			formFields, found := typedmap.Get(metadata, inspectionmetadata.FormFieldSetMetadataKey)
+			if !found {
+				t.Fatalf("form field metadata not found!")
+			}
+			field := formFields.DangerouslyGetField(formTask.UntypedID().GetUntypedReference().String())
+			setField, convertible := field.(inspectionmetadata.SetParameterFormField)
+			if !convertible {
+				t.Fatal("the generated form is not a SetParameterFormField")
+			}
+			if setField.ParameterFormFieldBase.Type != inspectionmetadata.Set {
+				t.Errorf("the generated form has type %s and it's not set", setField.ParameterFormFieldBase.Type)
+			}
+			if testCase.ExpectedFormField.Options == nil {
+				setField.Options = nil
+			}
+			if diff := cmp.Diff(testCase.ExpectedFormField, setField, cmpopts.IgnoreFields(inspectionmetadata.ParameterFormFieldBase{}, "Priority", "Position", "ID", "Type")); diff != "" {
 				t.Errorf("the form task didn't generate the expected form field metadata\n%s", diff)
 			}
 		})
@@ -17,6 +17,8 @@ package formtask
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"unicode/utf8"
 
 	"github.com/kyasbal/khi/pkg/common/khictx"
 	"github.com/kyasbal/khi/pkg/common/typedmap"
@@ -37,10 +39,21 @@ type TextFormDefaultValueGenerator = func(ctx context.Context, previousValues []
 // TextFormReadonlyProvider is a function type to compute if the field is allowed edit or not.
 type TextFormReadonlyProvider = func(ctx context.Context) (bool, error)
 
+// TextFormReadonlyReasonProvider is a function type to explain why the field is readonly. This is only called when the field is readonly.
+// Returns the reason and a human readable name of the source fixing the value like the name of the flag.
+type TextFormReadonlyReasonProvider = func(ctx context.Context) (inspectionmetadata.ReadonlyReasonType, string, error)
+
 // TextFormSuggestionsProvider is a function to return the list of strings shown in the autocomplete.
 // Return nil instead of emptry string array means the autocomplete is disabled for the field.
 type TextFormSuggestionsProvider = func(ctx context.Context, value string, previousValues []string) ([]string, error)
 
+// TextFormSuggestionItemsProvider is a function to return the list of suggestions with their details shown in the autocomplete.
+// Return nil instead of emptry array means the autocomplete is disabled for the field.
+type TextFormSuggestionItemsProvider = func(ctx context.Context, value string, previousValues []string) ([]inspectionmetadata.TextParameterFormFieldSuggestion, error)
+
+// TextFormSuggestionsLoadingProvider is a function type to compute if the suggestions are still being fetched in background.
+type TextFormSuggestionsLoadingProvider = func(ctx context.Context) (bool, error)
+
 // TextFormValueConverter is a function type to convert the given string value to another type stored in the variable set.
 type TextFormValueConverter[T any] = func(ctx context.Context, value string) (T, error)
 
@@ -54,10 +67,21 @@ type TextFormTaskBuilder[T any] struct {
 	defaultValue        TextFormDefaultValueGenerator
 	validator           TextFormValidator
 	readonlyProvider    TextFormReadonlyProvider
+	readonlyReason      TextFormReadonlyReasonProvider
 	suggestionsProvider TextFormSuggestionsProvider
-	hintGenerator       TextFormHintGenerator
-	converter           TextFormValueConverter[T]
-	validatingTiming    inspectionmetadata.TextFormValidationTimingType
+	// suggestionItemsProvider is used instead of suggestionsProvider when it's not nil.
+	suggestionItemsProvider TextFormSuggestionItemsProvider
+	suggestionsLoading      TextFormSuggestionsLoadingProvider
+	hintGenerator           TextFormHintGenerator
+	converter               TextFormValueConverter[T]
+	validatingTiming        inspectionmetadata.TextFormValidationTimingType
+	// pattern is the compiled regular expression of patternSource to fully match the value. nil when no pattern is given.
+	pattern             *regexp.Regexp
+	patternSource       string
+	patternErrorMessage string
+	// maxLength is the maximum number of characters of the value. 0 means no limit.
+	maxLength   int
+	placeholder string
 }
 
 // NewTextFormTaskBuilder constructs an instace of TextFormDefinitionBuilder.
@@ -67,7 +91,9 @@ type TextFormTaskBuilder[T any] struct {
 // defaultValue: Initialized with a function to return empty string.
 // validator: Initialized with a function to return empty string that indicates the validation is always passing.
 // allowEditProvider: Initialized with a function to return true.
+// readonlyReason: Initialized with a function to return ReadonlyReasonUnspecified without the source.
 // suggestionsProvider: Initialized with a function to return nil.
+// suggestionsLoading: Initialized with a function to return false.
 // converter: Initialized with a function to return the given value. This means no conversion applied and treated as a string.
 func NewTextFormTaskBuilder[T any](id taskid.TaskImplementationID[T], priority int, fieldLabel string) *TextFormTaskBuilder[T] {
 	return &TextFormTaskBuilder[T]{
@@ -81,9 +107,15 @@ func NewTextFormTaskBuilder[T any](id taskid.TaskImplementationID[T], priority i
 		readonlyProvider: func(ctx context.Context) (bool, error) {
 			return false, nil
 		},
+		readonlyReason: func(ctx context.Context) (inspectionmetadata.ReadonlyReasonType, string, error) {
+			return inspectionmetadata.ReadonlyReasonUnspecified, "", nil
+		},
 		suggestionsProvider: func(ctx context.Context, value string, previousValues []string) ([]string, error) {
 			return nil, nil
 		},
+		suggestionsLoading: func(ctx context.Context) (bool, error) {
+			return false, nil
+		},
 		converter: func(ctx context.Context, value string) (T, error) {
 			var anyValue any = value // This is needed for forcible cast from string to T.
 			return anyValue.(T), nil
@@ -105,11 +137,57 @@ func (b *TextFormTaskBuilder[T]) WithDescription(description string) *TextFormTa
 	return b
 }
 
+// WithGroup places the form field in the group generated by the task built with GroupFormTaskBuilder.
+func (b *TextFormTaskBuilder[T]) WithGroup(group taskid.TaskReference[struct{}]) *TextFormTaskBuilder[T] {
+	b.FormTaskBuilderBase.WithGroup(group)
+	return b
+}
+
+// WithPosition places the form field in the section at the position relative to the other fields instead of the priority.
+func (b *TextFormTaskBuilder[T]) WithPosition(position inspectionmetadata.FormPosition) *TextFormTaskBuilder[T] {
+	b.FormTaskBuilderBase.WithPosition(position)
+	return b
+}
+
+// WithMarkdown marks the description and the hint of the form field as Markdown.
+func (b *TextFormTaskBuilder[T]) WithMarkdown() *TextFormTaskBuilder[T] {
+	b.FormTaskBuilderBase.WithMarkdown()
+	return b
+}
+
+// WithDocURL sets the URL of the document describing the syntax or the usage of the form field.
+func (b *TextFormTaskBuilder[T]) WithDocURL(url string) *TextFormTaskBuilder[T] {
+	b.FormTaskBuilderBase.WithDocURL(url)
+	return b
+}
+
 func (b *TextFormTaskBuilder[T]) WithValidator(validator TextFormValidator) *TextFormTaskBuilder[T] {
 	b.validator = validator
 	return b
 }
 
+// WithRegexValidator requires the value to fully match the regular expression. The errorMessage is shown when the value doesn't match.
+// The pattern is also given to the frontend to validate the value in the client side, so it must be compatible with both Go and JavaScript regular expressions.
+// This panics when the pattern can't be compiled.
+func (b *TextFormTaskBuilder[T]) WithRegexValidator(pattern string, errorMessage string) *TextFormTaskBuilder[T] {
+	b.pattern = regexp.MustCompile(fmt.Sprintf("^(?:%s)$", pattern))
+	b.patternSource = pattern
+	b.patternErrorMessage = errorMessage
+	return b
+}
+
+// WithMaxLength limits the number of characters of the value. The limit is also given to the frontend to validate the value in the client side.
+func (b *TextFormTaskBuilder[T]) WithMaxLength(maxLength int) *TextFormTaskBuilder[T] {
+	b.maxLength = maxLength
+	return b
+}
+
+// WithPlaceholder sets the example input shown in the field while the value is empty. The placeholder is not used as the value unlike the default value.
+func (b *TextFormTaskBuilder[T]) WithPlaceholder(placeholder string) *TextFormTaskBuilder[T] {
+	b.placeholder = placeholder
+	return b
+}
+
 func (b *TextFormTaskBuilder[T]) WithDefaultValueFunc(defFunc TextFormDefaultValueGenerator) *TextFormTaskBuilder[T] {
 	b.defaultValue = defFunc
 	return b
@@ -131,6 +209,12 @@ func (b *TextFormTaskBuilder[T]) WithReadonlyFunc(readonlyFunc TextFormReadonlyP
 	return b
 }
 
+// WithReadonlyReasonFunc sets the function explaining why the field is readonly to show it on the form.
+func (b *TextFormTaskBuilder[T]) WithReadonlyReasonFunc(readonlyReasonFunc TextFormReadonlyReasonProvider) *TextFormTaskBuilder[T] {
+	b.readonlyReason = readonlyReasonFunc
+	return b
+}
+
 func (b *TextFormTaskBuilder[T]) WithSuggestionsFunc(suggestionsFunc TextFormSuggestionsProvider) *TextFormTaskBuilder[T] {
 	b.suggestionsProvider = suggestionsFunc
 	return b
@@ -142,6 +226,18 @@ func (b *TextFormTaskBuilder[T]) WithSuggestionsConstant(suggestions []string) *
 	})
 }
 
+// WithSuggestionItemsFunc sets the function returning the suggestions with their labels, descriptions and groups. This overrides the function given with WithSuggestionsFunc.
+func (b *TextFormTaskBuilder[T]) WithSuggestionItemsFunc(suggestionItemsFunc TextFormSuggestionItemsProvider) *TextFormTaskBuilder[T] {
+	b.suggestionItemsProvider = suggestionItemsFunc
+	return b
+}
+
+// WithSuggestionsLoadingFunc sets the function to report the suggestions are still being fetched, typically from the Loading field of an AutocompleteResult.
+func (b *TextFormTaskBuilder[T]) WithSuggestionsLoadingFunc(loadingFunc TextFormSuggestionsLoadingProvider) *TextFormTaskBuilder[T] {
+	b.suggestionsLoading = loadingFunc
+	return b
+}
+
 func (b *TextFormTaskBuilder[T]) WithHintFunc(hintFunc TextFormHintGenerator) *TextFormTaskBuilder[T] {
 	b.hintGenerator = hintFunc
 	return b
@@ -158,7 +254,7 @@ func (b *TextFormTaskBuilder[T]) WithValidatingTiming(timing inspectionmetadata.
 }
 
 func (b *TextFormTaskBuilder[T]) Build(labelOpts ...common_task.LabelOpt) common_task.Task[T] {
-	return common_task.NewTask(b.id, b.dependencies, func(ctx context.Context) (T, error) {
+	return common_task.NewTask(b.id, b.taskDependencies(), func(ctx context.Context) (T, error) {
 		m := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
 		req := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionTaskInput)
 		taskMode := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionTaskMode)
@@ -173,6 +269,14 @@ func (b *TextFormTaskBuilder[T]) Build(labelOpts ...common_task.LabelOpt) common
 		}
 		field := inspectionmetadata.TextParameterFormField{}
 		field.Readonly = readonly
+		if readonly {
+			reason, source, err := b.readonlyReason(ctx)
+			if err != nil {
+				return *new(T), fmt.Errorf("readonly reason provider for task `%s` returned an error\n%v", b.id, err)
+			}
+			field.ReadonlyReason = reason
+			field.ReadonlySource = source
+		}
 		field.ValidationTiming = b.validatingTiming
 
 		// Compute the default value of the form
@@ -196,15 +300,42 @@ func (b *TextFormTaskBuilder[T]) Build(labelOpts ...common_task.LabelOpt) common
 
 		b.SetupBaseFormField(&field.ParameterFormFieldBase)
 
-		suggestions, err := b.suggestionsProvider(ctx, currentValue, prevValue)
+		if b.suggestionItemsProvider != nil {
+			suggestionItems, err := b.suggestionItemsProvider(ctx, currentValue, prevValue)
+			if err != nil {
+				return *new(T), fmt.Errorf("suggesion items provider for task `%s` returned an error\n%v", b.id, err)
+			}
+			field.SuggestionItems = suggestionItems
+			if suggestionItems != nil {
+				field.Suggestions = make([]string, len(suggestionItems))
+				for i, item := range suggestionItems {
+					field.Suggestions[i] = item.Value
+				}
+			}
+		} else {
+			suggestions, err := b.suggestionsProvider(ctx, currentValue, prevValue)
+			if err != nil {
+				return *new(T), fmt.Errorf("suggesion provider for task `%s` returned an error\n%v", b.id, err)
+			}
+			field.Suggestions = suggestions
+		}
+		suggestionsLoading, err := b.suggestionsLoading(ctx)
 		if err != nil {
-			return *new(T), fmt.Errorf("suggesion provider for task `%s` returned an error\n%v", b.id, err)
+			return *new(T), fmt.Errorf("suggestions loading provider for task `%s` returned an error\n%v", b.id, err)
 		}
-		field.Suggestions = suggestions
+		field.SuggestionsLoading = suggestionsLoading
 
-		validationErr, err := b.validator(ctx, currentValue)
-		if err != nil {
-			return *new(T), fmt.Errorf("validator for task `%s` returned an unrecovable error\n%v", b.id, err)
+		field.Pattern = b.patternSource
+		field.PatternErrorMessage = b.patternErrorMessage
+		field.MaxLength = b.maxLength
+		field.Placeholder = b.placeholder
+
+		validationErr := b.validateConstraints(currentValue)
+		if validationErr == "" {
+			validationErr, err = b.validator(ctx, currentValue)
+			if err != nil {
+				return *new(T), fmt.Errorf("validator for task `%s` returned an unrecovable error\n%v", b.id, err)
+			}
 		}
 		if validationErr != "" {
 			// When the given string is invalid, it should be the default value.
@@ -239,6 +370,10 @@ func (b *TextFormTaskBuilder[T]) Build(labelOpts ...common_task.LabelOpt) common
 				typedmap.Set(globalSharedMap, previousValueStoreKey, newValueHistory)
 			}
 		}
+		err = b.RecordParameterValue(m, currentValue)
+		if err != nil {
+			return *new(T), fmt.Errorf("failed to record the parameter value in task `%s`\n%v", b.id, err)
+		}
 		formFields, found := typedmap.Get(m, inspectionmetadata.FormFieldSetMetadataKey)
 		if !found {
 			return *new(T), fmt.Errorf("form field set was not found in the metadata set")
@@ -253,3 +388,14 @@ func (b *TextFormTaskBuilder[T]) Build(labelOpts ...common_task.LabelOpt) common
 		b.description,
 	))...)
 }
+
+// validateConstraints returns the error message when the value violates the constraints given with WithMaxLength or WithRegexValidator.
+func (b *TextFormTaskBuilder[T]) validateConstraints(value string) string {
+	if b.maxLength > 0 && utf8.RuneCountInString(value) > b.maxLength {
+		return fmt.Sprintf("must be %d characters or less", b.maxLength)
+	}
+	if b.pattern != nil && !b.pattern.MatchString(value) {
+		return b.patternErrorMessage
+	}
+	return ""
+}
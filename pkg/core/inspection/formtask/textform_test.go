@@ -104,6 +104,7 @@ func TestTextFormDefinitionBuilder(t *testing.T) {
 					HintType: inspectionmetadata.None,
 				},
 				Readonly:         true,
+				ReadonlyReason:   inspectionmetadata.ReadonlyReasonUnspecified,
 				ValidationTiming: inspectionmetadata.Change,
 			},
 		},
@@ -122,10 +123,51 @@ func TestTextFormDefinitionBuilder(t *testing.T) {
 					HintType: inspectionmetadata.None,
 				},
 				Readonly:         true,
+				ReadonlyReason:   inspectionmetadata.ReadonlyReasonUnspecified,
 				Default:          "foo-from-default",
 				ValidationTiming: inspectionmetadata.Change,
 			},
 		},
+		{
+			Name: "A readonly text form with reason",
+			FormConfigurator: func(builder *TextFormTaskBuilder[string]) {
+				builder.WithReadonlyFunc(func(ctx context.Context) (bool, error) {
+					return true, nil
+				}).WithReadonlyReasonFunc(func(ctx context.Context) (inspectionmetadata.ReadonlyReasonType, string, error) {
+					return inspectionmetadata.ReadonlyReasonServerConfiguration, "--foo-flag", nil
+				})
+			},
+			RequestValue:  "",
+			ExpectedValue: "",
+			ExpectedError: "",
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					HintType: inspectionmetadata.None,
+				},
+				Readonly:         true,
+				ReadonlyReason:   inspectionmetadata.ReadonlyReasonServerConfiguration,
+				ReadonlySource:   "--foo-flag",
+				ValidationTiming: inspectionmetadata.Change,
+			},
+		},
+		{
+			Name: "A readonly reason is ignored for editable text form",
+			FormConfigurator: func(builder *TextFormTaskBuilder[string]) {
+				builder.WithReadonlyReasonFunc(func(ctx context.Context) (inspectionmetadata.ReadonlyReasonType, string, error) {
+					return inspectionmetadata.ReadonlyReasonServerConfiguration, "--foo-flag", nil
+				})
+			},
+			RequestValue:  "",
+			ExpectedValue: "",
+			ExpectedError: "",
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					HintType: inspectionmetadata.None,
+				},
+				Readonly:         false,
+				ValidationTiming: inspectionmetadata.Change,
+			},
+		},
 		{
 			Name: "A text form with hint",
 			FormConfigurator: func(builder *TextFormTaskBuilder[string]) {
@@ -160,6 +202,7 @@ func TestTextFormDefinitionBuilder(t *testing.T) {
 					HintType: inspectionmetadata.None,
 				},
 				Readonly:         true,
+				ReadonlyReason:   inspectionmetadata.ReadonlyReasonUnspecified,
 				Default:          "foo-from-default",
 				ValidationTiming: inspectionmetadata.Change,
 			},
@@ -189,6 +232,147 @@ func TestTextFormDefinitionBuilder(t *testing.T) {
 				ValidationTiming: inspectionmetadata.Change,
 			},
 		},
+		{
+			Name: "A text form with suggestions still loading",
+			FormConfigurator: func(builder *TextFormTaskBuilder[string]) {
+				builder.WithSuggestionsConstant([]string{
+					"foo-suggest1",
+				}).WithSuggestionsLoadingFunc(func(ctx context.Context) (bool, error) {
+					return true, nil
+				})
+			},
+			RequestValue:  "bar-from-request",
+			ExpectedValue: "bar-from-request",
+			ExpectedError: "",
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					HintType: inspectionmetadata.None,
+				},
+				Readonly: false,
+				Suggestions: []string{
+					"foo-suggest1",
+				},
+				SuggestionsLoading: true,
+				ValidationTiming:   inspectionmetadata.Change,
+			},
+		},
+		{
+			Name: "A text form with suggestion items",
+			FormConfigurator: func(builder *TextFormTaskBuilder[string]) {
+				builder.WithSuggestionsConstant([]string{
+					"ignored-suggest",
+				}).WithSuggestionItemsFunc(func(ctx context.Context, value string, previousValues []string) ([]inspectionmetadata.TextParameterFormFieldSuggestion, error) {
+					return []inspectionmetadata.TextParameterFormFieldSuggestion{
+						{Value: "foo-suggest1", Label: "Foo 1", Description: "the first foo", Group: "foo"},
+						{Value: "foo-suggest2"},
+					}, nil
+				})
+			},
+			RequestValue:  "bar-from-request",
+			ExpectedValue: "bar-from-request",
+			ExpectedError: "",
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					HintType: inspectionmetadata.None,
+				},
+				Readonly: false,
+				Suggestions: []string{
+					"foo-suggest1",
+					"foo-suggest2",
+				},
+				SuggestionItems: []inspectionmetadata.TextParameterFormFieldSuggestion{
+					{Value: "foo-suggest1", Label: "Foo 1", Description: "the first foo", Group: "foo"},
+					{Value: "foo-suggest2"},
+				},
+				ValidationTiming: inspectionmetadata.Change,
+			},
+		},
+		{
+			Name: "A text form with regex validator matching the value",
+			FormConfigurator: func(builder *TextFormTaskBuilder[string]) {
+				builder.WithRegexValidator("[a-z-]+", "must be lower case letters")
+			},
+			RequestValue:  "bar-from-request",
+			ExpectedValue: "bar-from-request",
+			ExpectedError: "",
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					HintType: inspectionmetadata.None,
+				},
+				ValidationTiming:    inspectionmetadata.Change,
+				Pattern:             "[a-z-]+",
+				PatternErrorMessage: "must be lower case letters",
+			},
+		},
+		{
+			Name: "A text form with regex validator not fully matching the value",
+			FormConfigurator: func(builder *TextFormTaskBuilder[string]) {
+				builder.WithRegexValidator("[a-z-]+", "must be lower case letters").WithValidator(func(ctx context.Context, value string) (string, error) {
+					return "custom validation error", nil
+				})
+			},
+			RequestValue:  "bar-FROM-request",
+			ExpectedValue: "",
+			ExpectedError: "",
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					HintType: inspectionmetadata.Error,
+					Hint:     "must be lower case letters",
+				},
+				ValidationTiming:    inspectionmetadata.Change,
+				Pattern:             "[a-z-]+",
+				PatternErrorMessage: "must be lower case letters",
+			},
+		},
+		{
+			Name: "A text form with max length exceeded",
+			FormConfigurator: func(builder *TextFormTaskBuilder[string]) {
+				builder.WithMaxLength(3)
+			},
+			RequestValue:  "ばーばー",
+			ExpectedValue: "",
+			ExpectedError: "",
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					HintType: inspectionmetadata.Error,
+					Hint:     "must be 3 characters or less",
+				},
+				ValidationTiming: inspectionmetadata.Change,
+				MaxLength:        3,
+			},
+		},
+		{
+			Name: "A text form with max length not exceeded",
+			FormConfigurator: func(builder *TextFormTaskBuilder[string]) {
+				builder.WithMaxLength(3)
+			},
+			RequestValue:  "ばーば",
+			ExpectedValue: "ばーば",
+			ExpectedError: "",
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					HintType: inspectionmetadata.None,
+				},
+				ValidationTiming: inspectionmetadata.Change,
+				MaxLength:        3,
+			},
+		},
+		{
+			Name: "A text form with placeholder doesn't use it as the value",
+			FormConfigurator: func(builder *TextFormTaskBuilder[string]) {
+				builder.WithPlaceholder("e.g. foo-bar")
+			},
+			RequestValue:  "",
+			ExpectedValue: "",
+			ExpectedError: "",
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					HintType: inspectionmetadata.None,
+				},
+				ValidationTiming: inspectionmetadata.Change,
+				Placeholder:      "e.g. foo-bar",
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -268,3 +452,33 @@ func TestTextFormDefinitionBuilder(t *testing.T) {
 		})
 	}
 }
+
+func TestTextFormConstraintsFailRun(t *testing.T) {
+	testCases := []struct {
+		name    string
+		builder *TextFormTaskBuilder[string]
+		value   string
+	}{
+		{
+			name:    "regex",
+			builder: NewTextFormTaskBuilder(taskid.NewDefaultImplementationID[string]("foo"), 1, "foo label").WithRegexValidator("[0-9]+", "must be a number"),
+			value:   "12a",
+		},
+		{
+			name:    "max length",
+			builder: NewTextFormTaskBuilder(taskid.NewDefaultImplementationID[string]("foo"), 1, "foo label").WithMaxLength(2),
+			value:   "123",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			taskCtx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+			_, _, err := inspectiontest.RunInspectionTask(taskCtx, tc.builder.Build(), inspectioncore_contract.TaskModeRun, map[string]any{
+				"foo": tc.value,
+			})
+			if err == nil {
+				t.Errorf("task was expected to be end with an error for the value violating the constraint. But the task finished without an error")
+			}
+		})
+	}
+}
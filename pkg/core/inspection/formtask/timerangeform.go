@@ -0,0 +1,367 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package formtask
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/common"
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	common_task "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// TimeRangeFormNonPositiveDurationMessage is the validation error message shown when the given duration is zero or negative.
+const TimeRangeFormNonPositiveDurationMessage = "duration must be positive"
+
+// TimeRange is a range of time given from the field generated by TimeRangeFormTaskBuilder.
+type TimeRange struct {
+	// Start is the beginning of the range.
+	Start time.Time
+	// End is the end of the range.
+	End time.Time
+}
+
+// Duration returns the length of the time range.
+func (r TimeRange) Duration() time.Duration {
+	return r.End.Sub(r.Start)
+}
+
+// TimeRangeFormValidator is a function to check if the given time range is valid or not.
+// Returns "" as the result when it has no error, otherwise the returned value is used as an error message on frontend.
+type TimeRangeFormValidator = func(ctx context.Context, value TimeRange) (string, error)
+
+// TimeRangeFormDefaultValueGenerator is a function type to generate the default value.
+type TimeRangeFormDefaultValueGenerator = func(ctx context.Context, previousValues []TimeRange) (TimeRange, error)
+
+// TimeRangeFormHintGenerator is a function type to generate a hint string
+type TimeRangeFormHintGenerator = func(ctx context.Context, value TimeRange) (string, inspectionmetadata.ParameterHintType, error)
+
+// TimeRangeFormTaskBuilder is an utility to construct an instance of task for the field to input a time range with the end time and the duration.
+// The given value is parsed once in this task and the task returns the parsed TimeRange.
+type TimeRangeFormTaskBuilder struct {
+	FormTaskBuilderBase[TimeRange]
+	defaultValue     TimeRangeFormDefaultValueGenerator
+	validator        TimeRangeFormValidator
+	timezoneProvider DateTimeFormTimezoneProvider
+	hintGenerator    TimeRangeFormHintGenerator
+	presets          []time.Duration
+	// lookbackLimit is how long ago the time range can start from the inspection creation time without lookbackWarning. 0 means no limit.
+	lookbackLimit   time.Duration
+	lookbackWarning string
+}
+
+// NewTimeRangeFormTaskBuilder constructs an instance of TimeRangeFormTaskBuilder.
+// The default value is initialized with the previous value or the last 1 hour from the inspection creation time, and the timezone is initialized with UTC.
+func NewTimeRangeFormTaskBuilder(id taskid.TaskImplementationID[TimeRange], priority int, fieldLabel string) *TimeRangeFormTaskBuilder {
+	return &TimeRangeFormTaskBuilder{
+		FormTaskBuilderBase: NewFormTaskBuilderBase(id, priority, fieldLabel),
+		defaultValue: func(ctx context.Context, previousValues []TimeRange) (TimeRange, error) {
+			if len(previousValues) > 0 {
+				return previousValues[0], nil
+			}
+			creationTime, err := khictx.GetValue(ctx, inspectioncore_contract.InspectionCreationTime)
+			if err != nil {
+				return TimeRange{}, err
+			}
+			end := creationTime.Truncate(time.Second)
+			return TimeRange{Start: end.Add(-time.Hour), End: end}, nil
+		},
+		validator: func(ctx context.Context, value TimeRange) (string, error) {
+			return "", nil
+		},
+		timezoneProvider: func(ctx context.Context) (*time.Location, error) {
+			return time.UTC, nil
+		},
+		hintGenerator: func(ctx context.Context, value TimeRange) (string, inspectionmetadata.ParameterHintType, error) {
+			return "", inspectionmetadata.Info, nil
+		},
+	}
+}
+
+func (b *TimeRangeFormTaskBuilder) WithDependencies(dependencies []taskid.UntypedTaskReference) *TimeRangeFormTaskBuilder {
+	b.FormTaskBuilderBase.WithDependencies(dependencies)
+	return b
+}
+
+func (b *TimeRangeFormTaskBuilder) WithDescription(description string) *TimeRangeFormTaskBuilder {
+	b.FormTaskBuilderBase.WithDescription(description)
+	return b
+}
+
+// WithGroup places the form field in the group generated by the task built with GroupFormTaskBuilder.
+func (b *TimeRangeFormTaskBuilder) WithGroup(group taskid.TaskReference[struct{}]) *TimeRangeFormTaskBuilder {
+	b.FormTaskBuilderBase.WithGroup(group)
+	return b
+}
+
+// WithPosition places the form field in the section at the position relative to the other fields instead of the priority.
+func (b *TimeRangeFormTaskBuilder) WithPosition(position inspectionmetadata.FormPosition) *TimeRangeFormTaskBuilder {
+	b.FormTaskBuilderBase.WithPosition(position)
+	return b
+}
+
+// WithMarkdown marks the description and the hint of the form field as Markdown.
+func (b *TimeRangeFormTaskBuilder) WithMarkdown() *TimeRangeFormTaskBuilder {
+	b.FormTaskBuilderBase.WithMarkdown()
+	return b
+}
+
+// WithDocURL sets the URL of the document describing the syntax or the usage of the form field.
+func (b *TimeRangeFormTaskBuilder) WithDocURL(url string) *TimeRangeFormTaskBuilder {
+	b.FormTaskBuilderBase.WithDocURL(url)
+	return b
+}
+
+func (b *TimeRangeFormTaskBuilder) WithValidator(validator TimeRangeFormValidator) *TimeRangeFormTaskBuilder {
+	b.validator = validator
+	return b
+}
+
+func (b *TimeRangeFormTaskBuilder) WithDefaultValueFunc(defFunc TimeRangeFormDefaultValueGenerator) *TimeRangeFormTaskBuilder {
+	b.defaultValue = defFunc
+	return b
+}
+
+func (b *TimeRangeFormTaskBuilder) WithTimezoneFunc(timezoneFunc DateTimeFormTimezoneProvider) *TimeRangeFormTaskBuilder {
+	b.timezoneProvider = timezoneFunc
+	return b
+}
+
+func (b *TimeRangeFormTaskBuilder) WithHintFunc(hintFunc TimeRangeFormHintGenerator) *TimeRangeFormTaskBuilder {
+	b.hintGenerator = hintFunc
+	return b
+}
+
+// WithPresets adds the buttons to select the time range of the given duration ending at the inspection creation time. (e.g `Last 1h`)
+func (b *TimeRangeFormTaskBuilder) WithPresets(durations ...time.Duration) *TimeRangeFormTaskBuilder {
+	b.presets = durations
+	return b
+}
+
+// WithLookbackLimit shows the warning as the hint when the time range starts before the limit from the inspection creation time.
+// The warning is shown in addition to the hint given with WithHintFunc.
+func (b *TimeRangeFormTaskBuilder) WithLookbackLimit(limit time.Duration, warning string) *TimeRangeFormTaskBuilder {
+	b.lookbackLimit = limit
+	b.lookbackWarning = warning
+	return b
+}
+
+func (b *TimeRangeFormTaskBuilder) Build(labelOpts ...common_task.LabelOpt) common_task.Task[TimeRange] {
+	return common_task.NewTask(b.id, b.taskDependencies(), func(ctx context.Context) (TimeRange, error) {
+		m := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
+		req := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionTaskInput)
+		taskMode := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionTaskMode)
+		globalSharedMap := khictx.MustGetValue(ctx, inspectioncore_contract.GlobalSharedMap)
+		creationTime := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionCreationTime)
+
+		previousValueStoreKey := typedmap.NewTypedKey[[]TimeRange](fmt.Sprintf("timerange-form-pv-%s", b.id))
+		prevValue := typedmap.GetOrDefault(globalSharedMap, previousValueStoreKey, []TimeRange{})
+
+		timezone, err := b.timezoneProvider(ctx)
+		if err != nil {
+			return TimeRange{}, fmt.Errorf("timezone provider for task `%s` returned an error\n%v", b.id, err)
+		}
+
+		field := inspectionmetadata.TimeRangeParameterFormField{}
+		defaultValue, err := b.defaultValue(ctx, prevValue)
+		if err != nil {
+			return TimeRange{}, fmt.Errorf("default value generator for task `%s` returned an error\n%v", b.id, err)
+		}
+		field.Default = toTimeRangeParameterValue(defaultValue, timezone)
+
+		field.Presets = make([]inspectionmetadata.TimeRangeParameterFormFieldPreset, len(b.presets))
+		presetEnd := creationTime.Truncate(time.Second)
+		for i, duration := range b.presets {
+			field.Presets[i] = inspectionmetadata.TimeRangeParameterFormFieldPreset{
+				Label: fmt.Sprintf("Last %s", formatTimeRangeDuration(duration)),
+				Value: toTimeRangeParameterValue(TimeRange{Start: presetEnd.Add(-duration), End: presetEnd}, timezone),
+			}
+		}
+
+		currentValue := defaultValue
+		validationErr := ""
+		if valueRaw, exist := req[b.id.ReferenceIDString()]; exist {
+			currentValue, validationErr, err = parseTimeRangeParameterValue(valueRaw, defaultValue)
+			if err != nil {
+				return TimeRange{}, fmt.Errorf("request parameter `%s` was invalid in task %s\n%v", b.id, b.id, err)
+			}
+		}
+
+		field.Type = inspectionmetadata.TimeRange
+		field.HintType = inspectionmetadata.Info
+
+		b.SetupBaseFormField(&field.ParameterFormFieldBase)
+
+		if validationErr == "" && currentValue.Duration() <= 0 {
+			validationErr = TimeRangeFormNonPositiveDurationMessage
+		}
+		if validationErr == "" {
+			validationErr, err = b.validator(ctx, currentValue)
+			if err != nil {
+				return TimeRange{}, fmt.Errorf("validator for task `%s` returned an unrecoverable error\n%v", b.id, err)
+			}
+		}
+		if validationErr != "" {
+			// When the given time range is invalid, it should be the default value.
+			currentValue = defaultValue
+		}
+		if validationErr != "" && taskMode == inspectioncore_contract.TaskModeRun {
+			return TimeRange{}, fmt.Errorf("validator for task `%s` returned a validation error. But this task was executed as a Run mode not in DryRun. All validations must be resolved before running.\n%v", b.id, validationErr)
+		}
+
+		_, offsetSeconds := currentValue.End.In(timezone).Zone()
+		field.TimezoneShiftHours = float64(offsetSeconds) / 3600
+		field.StartTimeUTC = currentValue.Start.UTC().Format(time.RFC3339)
+		field.EndTimeUTC = currentValue.End.UTC().Format(time.RFC3339)
+
+		if validationErr != "" {
+			field.HintType = inspectionmetadata.Error
+			field.Hint = validationErr
+		} else {
+			hint, hintType, err := b.hintGenerator(ctx, currentValue)
+			if err != nil {
+				return TimeRange{}, fmt.Errorf("failed to generate a hint for task %s\n%v", b.id, err)
+			}
+			if b.lookbackLimit > 0 && creationTime.Sub(currentValue.Start) > b.lookbackLimit {
+				hint = strings.TrimSpace(b.lookbackWarning + "\n\n" + hint)
+				if hintType != inspectionmetadata.Error {
+					hintType = inspectionmetadata.Warning
+				}
+			}
+			if hint == "" {
+				hintType = inspectionmetadata.None
+			}
+			field.Hint = hint
+			field.HintType = hintType
+			if taskMode == inspectioncore_contract.TaskModeRun {
+				newValueHistory := append([]TimeRange{currentValue}, prevValue...)
+				typedmap.Set(globalSharedMap, previousValueStoreKey, newValueHistory)
+			}
+		}
+
+		err = b.RecordParameterValue(m, toTimeRangeParameterValue(currentValue, timezone))
+		if err != nil {
+			return TimeRange{}, fmt.Errorf("failed to record the parameter value in task `%s`\n%v", b.id, err)
+		}
+		formFields, found := typedmap.Get(m, inspectionmetadata.FormFieldSetMetadataKey)
+		if !found {
+			return TimeRange{}, fmt.Errorf("form field set was not found in the metadata set")
+		}
+		err = formFields.SetField(field)
+		if err != nil {
+			return TimeRange{}, fmt.Errorf("failed to configure the form metadata in task `%s`\n%v", b.id, err)
+		}
+		return currentValue, nil
+	}, append(labelOpts, inspectioncore_contract.NewFormTaskLabelOpt(
+		b.label,
+		b.description,
+	))...)
+}
+
+// parseTimeRangeParameterValue parses the request value in the form of TimeRangeParameterValue.
+// The end time or the duration missing in the value is filled with the one of the default value.
+// It returns a validation error message when the end time or the duration can't be parsed, and returns an error only when the value is not an object.
+func parseTimeRangeParameterValue(valueRaw any, defaultValue TimeRange) (TimeRange, string, error) {
+	valueMap, isMap := valueRaw.(map[string]any)
+	if !isMap {
+		return TimeRange{}, "", fmt.Errorf("the value was not given in object")
+	}
+	end := defaultValue.End
+	if endRaw, exist := valueMap["endTime"]; exist {
+		endString, isString := endRaw.(string)
+		if !isString {
+			return TimeRange{}, "", fmt.Errorf("endTime was not given in string")
+		}
+		parsed, err := common.ParseTime(endString)
+		if err != nil {
+			return TimeRange{}, DateTimeFormInvalidFormatMessage, nil
+		}
+		end = parsed
+	}
+	duration := defaultValue.Duration()
+	if durationRaw, exist := valueMap["duration"]; exist {
+		durationString, isString := durationRaw.(string)
+		if !isString {
+			return TimeRange{}, "", fmt.Errorf("duration was not given in string")
+		}
+		parsed, err := time.ParseDuration(durationString)
+		if err != nil {
+			return TimeRange{}, err.Error(), nil
+		}
+		duration = parsed
+	}
+	return TimeRange{Start: end.Add(-duration), End: end}, "", nil
+}
+
+// ExtendTimeRangeParameterValues returns a copy of the request values with the end time of every time range value extended to the given time.
+// The start times are kept, so the time ranges grow to end at the given time. The other values and the values failed to be parsed are copied as they are.
+func ExtendTimeRangeParameterValues(values map[string]any, end time.Time) map[string]any {
+	result := make(map[string]any, len(values))
+	for key, value := range values {
+		result[key] = value
+		valueMap, isMap := value.(map[string]any)
+		if !isMap {
+			continue
+		}
+		endString, hasEndTime := valueMap["endTime"].(string)
+		durationString, hasDuration := valueMap["duration"].(string)
+		if !hasEndTime || !hasDuration {
+			continue
+		}
+		currentEnd, err := common.ParseTime(endString)
+		if err != nil {
+			continue
+		}
+		duration, err := time.ParseDuration(durationString)
+		if err != nil || !end.After(currentEnd) {
+			continue
+		}
+		extended := make(map[string]any, len(valueMap))
+		for k, v := range valueMap {
+			extended[k] = v
+		}
+		extended["endTime"] = end.Format(time.RFC3339)
+		extended["duration"] = formatTimeRangeDuration(duration + end.Sub(currentEnd))
+		result[key] = extended
+	}
+	return result
+}
+
+// toTimeRangeParameterValue converts the time range to the value of the form field with the end time in the given timezone.
+func toTimeRangeParameterValue(value TimeRange, timezone *time.Location) inspectionmetadata.TimeRangeParameterValue {
+	return inspectionmetadata.TimeRangeParameterValue{
+		EndTime:  value.End.In(timezone).Format(time.RFC3339),
+		Duration: formatTimeRangeDuration(value.Duration()),
+	}
+}
+
+// formatTimeRangeDuration formats the duration without the trailing zero units. (e.g `1h` instead of `1h0m0s`)
+func formatTimeRangeDuration(duration time.Duration) string {
+	result := duration.String()
+	if strings.HasSuffix(result, "m0s") {
+		result = strings.TrimSuffix(result, "0s")
+	}
+	if strings.HasSuffix(result, "h0m") {
+		result = strings.TrimSuffix(result, "0m")
+	}
+	return result
+}
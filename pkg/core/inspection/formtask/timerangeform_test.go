@@ -0,0 +1,320 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package formtask
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+func TestTimeRangeFormDefinitionBuilder(t *testing.T) {
+	defaultEnd := time.Date(2025, time.January, 1, 1, 1, 1, 0, time.UTC)
+	defaultRange := TimeRange{Start: defaultEnd.Add(-time.Hour), End: defaultEnd}
+	defaultValue := inspectionmetadata.TimeRangeParameterValue{EndTime: "2025-01-01T01:01:01Z", Duration: "1h"}
+	testCases := []struct {
+		Name              string
+		FormConfigurator  func(builder *TimeRangeFormTaskBuilder)
+		RequestValue      any
+		ExpectedFormField inspectionmetadata.TimeRangeParameterFormField
+		ExpectedValue     TimeRange
+		ExpectedRunError  bool
+	}{
+		{
+			Name:          "A time range form without the parameter",
+			ExpectedValue: defaultRange,
+			ExpectedFormField: inspectionmetadata.TimeRangeParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					HintType: inspectionmetadata.None,
+				},
+				Default:      defaultValue,
+				StartTimeUTC: "2025-01-01T00:01:01Z",
+				EndTimeUTC:   "2025-01-01T01:01:01Z",
+				Presets:      []inspectionmetadata.TimeRangeParameterFormFieldPreset{},
+			},
+		},
+		{
+			Name:          "A time range form with given parameter",
+			RequestValue:  map[string]any{"endTime": "2024-06-01T09:00:00+09:00", "duration": "1h30m"},
+			ExpectedValue: TimeRange{Start: time.Date(2024, time.May, 31, 22, 30, 0, 0, time.UTC), End: time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)},
+			ExpectedFormField: inspectionmetadata.TimeRangeParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					HintType: inspectionmetadata.None,
+				},
+				Default:      defaultValue,
+				StartTimeUTC: "2024-05-31T22:30:00Z",
+				EndTimeUTC:   "2024-06-01T00:00:00Z",
+				Presets:      []inspectionmetadata.TimeRangeParameterFormFieldPreset{},
+			},
+		},
+		{
+			Name:          "A time range form with only the duration",
+			RequestValue:  map[string]any{"duration": "10m"},
+			ExpectedValue: TimeRange{Start: defaultEnd.Add(-10 * time.Minute), End: defaultEnd},
+			ExpectedFormField: inspectionmetadata.TimeRangeParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					HintType: inspectionmetadata.None,
+				},
+				Default:      defaultValue,
+				StartTimeUTC: "2025-01-01T00:51:01Z",
+				EndTimeUTC:   "2025-01-01T01:01:01Z",
+				Presets:      []inspectionmetadata.TimeRangeParameterFormFieldPreset{},
+			},
+		},
+		{
+			Name: "A time range form with timezone and presets",
+			FormConfigurator: func(builder *TimeRangeFormTaskBuilder) {
+				builder.WithTimezoneFunc(func(ctx context.Context) (*time.Location, error) {
+					return time.FixedZone("", 9*3600), nil
+				}).WithPresets(time.Hour, 24*time.Hour)
+			},
+			ExpectedValue: defaultRange,
+			ExpectedFormField: inspectionmetadata.TimeRangeParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					HintType: inspectionmetadata.None,
+				},
+				Default:            inspectionmetadata.TimeRangeParameterValue{EndTime: "2025-01-01T10:01:01+09:00", Duration: "1h"},
+				StartTimeUTC:       "2025-01-01T00:01:01Z",
+				EndTimeUTC:         "2025-01-01T01:01:01Z",
+				TimezoneShiftHours: 9,
+				Presets: []inspectionmetadata.TimeRangeParameterFormFieldPreset{
+					{Label: "Last 1h", Value: inspectionmetadata.TimeRangeParameterValue{EndTime: "2025-01-01T10:01:01+09:00", Duration: "1h"}},
+					{Label: "Last 24h", Value: inspectionmetadata.TimeRangeParameterValue{EndTime: "2025-01-01T10:01:01+09:00", Duration: "24h"}},
+				},
+			},
+		},
+		{
+			Name:             "A time range form with invalid end time",
+			RequestValue:     map[string]any{"endTime": "2024/06/01", "duration": "1h"},
+			ExpectedValue:    defaultRange,
+			ExpectedRunError: true,
+			ExpectedFormField: inspectionmetadata.TimeRangeParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					HintType: inspectionmetadata.Error,
+					Hint:     DateTimeFormInvalidFormatMessage,
+				},
+				Default:      defaultValue,
+				StartTimeUTC: "2025-01-01T00:01:01Z",
+				EndTimeUTC:   "2025-01-01T01:01:01Z",
+				Presets:      []inspectionmetadata.TimeRangeParameterFormFieldPreset{},
+			},
+		},
+		{
+			Name:             "A time range form with invalid duration",
+			RequestValue:     map[string]any{"duration": "foo"},
+			ExpectedValue:    defaultRange,
+			ExpectedRunError: true,
+			ExpectedFormField: inspectionmetadata.TimeRangeParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					HintType: inspectionmetadata.Error,
+					Hint:     "time: invalid duration \"foo\"",
+				},
+				Default:      defaultValue,
+				StartTimeUTC: "2025-01-01T00:01:01Z",
+				EndTimeUTC:   "2025-01-01T01:01:01Z",
+				Presets:      []inspectionmetadata.TimeRangeParameterFormFieldPreset{},
+			},
+		},
+		{
+			Name:             "A time range form with negative duration",
+			RequestValue:     map[string]any{"duration": "-10m"},
+			ExpectedValue:    defaultRange,
+			ExpectedRunError: true,
+			ExpectedFormField: inspectionmetadata.TimeRangeParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					HintType: inspectionmetadata.Error,
+					Hint:     TimeRangeFormNonPositiveDurationMessage,
+				},
+				Default:      defaultValue,
+				StartTimeUTC: "2025-01-01T00:01:01Z",
+				EndTimeUTC:   "2025-01-01T01:01:01Z",
+				Presets:      []inspectionmetadata.TimeRangeParameterFormFieldPreset{},
+			},
+		},
+		{
+			Name: "A time range form starting before the lookback limit",
+			FormConfigurator: func(builder *TimeRangeFormTaskBuilder) {
+				builder.WithLookbackLimit(24*time.Hour, "too old").
+					WithHintFunc(func(ctx context.Context, value TimeRange) (string, inspectionmetadata.ParameterHintType, error) {
+						return "foo hint", inspectionmetadata.Info, nil
+					})
+			},
+			RequestValue:  map[string]any{"duration": "25h"},
+			ExpectedValue: TimeRange{Start: defaultEnd.Add(-25 * time.Hour), End: defaultEnd},
+			ExpectedFormField: inspectionmetadata.TimeRangeParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					HintType: inspectionmetadata.Warning,
+					Hint:     "too old\n\nfoo hint",
+				},
+				Default:      defaultValue,
+				StartTimeUTC: "2024-12-31T00:01:01Z",
+				EndTimeUTC:   "2025-01-01T01:01:01Z",
+				Presets:      []inspectionmetadata.TimeRangeParameterFormFieldPreset{},
+			},
+		},
+		{
+			Name: "A time range form starting after the lookback limit",
+			FormConfigurator: func(builder *TimeRangeFormTaskBuilder) {
+				builder.WithLookbackLimit(24*time.Hour, "too old")
+			},
+			RequestValue:  map[string]any{"duration": "23h"},
+			ExpectedValue: TimeRange{Start: defaultEnd.Add(-23 * time.Hour), End: defaultEnd},
+			ExpectedFormField: inspectionmetadata.TimeRangeParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					HintType: inspectionmetadata.None,
+				},
+				Default:      defaultValue,
+				StartTimeUTC: "2024-12-31T02:01:01Z",
+				EndTimeUTC:   "2025-01-01T01:01:01Z",
+				Presets:      []inspectionmetadata.TimeRangeParameterFormFieldPreset{},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			builder := NewTimeRangeFormTaskBuilder(taskid.NewDefaultImplementationID[TimeRange]("foo-timerange"), 1, "foo label")
+			if testCase.FormConfigurator != nil {
+				testCase.FormConfigurator(builder)
+			}
+			taskDef := builder.Build()
+
+			inputMap := map[string]any{}
+			if testCase.RequestValue != nil {
+				inputMap["foo-timerange"] = testCase.RequestValue
+			}
+
+			// Execute task as DryRun mode
+			taskCtx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+			result, _, err := inspectiontest.RunInspectionTask(taskCtx, taskDef, inspectioncore_contract.TaskModeDryRun, inputMap)
+			if err != nil {
+				t.Fatalf("task was ended with unexpected error\n%s", err)
+			}
+			if !result.Start.Equal(testCase.ExpectedValue.Start) || !result.End.Equal(testCase.ExpectedValue.End) {
+				t.Errorf("the result is not matching with the expected value. expected:%v, actual:%v", testCase.ExpectedValue, result)
+			}
+			metadata := khictx.MustGetValue(taskCtx, inspectioncore_contract.InspectionRunMetadata)
+			fields, found := typedmap.Get(metadata, inspectionmetadata.FormFieldSetMetadataKey)
+			if !found {
+				t.Fatal("FormFieldSet not found on metadata")
+			}
+			field := fields.DangerouslyGetField("foo-timerange")
+			if diff := cmp.Diff(testCase.ExpectedFormField, field, cmpopts.IgnoreFields(inspectionmetadata.ParameterFormFieldBase{}, "ID", "Priority", "Type", "Label")); diff != "" {
+				t.Errorf("the generated form field is different from the expected\n%s", diff)
+			}
+
+			// Execute task as Run mode
+			taskCtx = inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+			result, _, err = inspectiontest.RunInspectionTask(taskCtx, taskDef, inspectioncore_contract.TaskModeRun, inputMap)
+			if testCase.ExpectedRunError {
+				if err == nil {
+					t.Errorf("task was expected to be end with an error in Run mode. But the task finished without an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("task was ended with unexpected error\n%s", err)
+			}
+			if !result.Start.Equal(testCase.ExpectedValue.Start) || !result.End.Equal(testCase.ExpectedValue.End) {
+				t.Errorf("the result is not matching with the expected value. expected:%v, actual:%v", testCase.ExpectedValue, result)
+			}
+		})
+	}
+}
+
+func TestTimeRangeFormRejectsNonObjectValue(t *testing.T) {
+	taskDef := NewTimeRangeFormTaskBuilder(taskid.NewDefaultImplementationID[TimeRange]("foo-timerange"), 1, "foo label").Build()
+	taskCtx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+	_, _, err := inspectiontest.RunInspectionTask(taskCtx, taskDef, inspectioncore_contract.TaskModeDryRun, map[string]any{
+		"foo-timerange": "1h",
+	})
+	if err == nil {
+		t.Errorf("task was expected to be end with an error for a non object value")
+	}
+}
+
+func TestFormatTimeRangeDuration(t *testing.T) {
+	testCases := []struct {
+		duration time.Duration
+		want     string
+	}{
+		{duration: time.Hour, want: "1h"},
+		{duration: 10 * time.Minute, want: "10m"},
+		{duration: 90 * time.Minute, want: "1h30m"},
+		{duration: time.Hour + time.Second, want: "1h0m1s"},
+		{duration: 30 * time.Second, want: "30s"},
+	}
+	for _, tc := range testCases {
+		if got := formatTimeRangeDuration(tc.duration); got != tc.want {
+			t.Errorf("formatTimeRangeDuration(%s) = %q, want %q", tc.duration, got, tc.want)
+		}
+	}
+}
+
+func TestExtendTimeRangeParameterValues(t *testing.T) {
+	end := time.Date(2025, time.January, 1, 2, 30, 0, 0, time.UTC)
+	values := map[string]any{
+		"foo-timerange": map[string]any{
+			"endTime":  "2025-01-01T00:00:00Z",
+			"duration": "1h",
+			"timezone": "UTC",
+		},
+		"foo-future-timerange": map[string]any{
+			"endTime":  "2025-01-02T00:00:00Z",
+			"duration": "1h",
+		},
+		"foo-invalid-timerange": map[string]any{
+			"endTime":  "2025-01-01T00:00:00Z",
+			"duration": "foo",
+		},
+		"foo-text":   "bar",
+		"foo-object": map[string]any{"endTime": "2025-01-01T00:00:00Z"},
+	}
+	want := map[string]any{
+		"foo-timerange": map[string]any{
+			"endTime":  "2025-01-01T02:30:00Z",
+			"duration": "3h30m",
+			"timezone": "UTC",
+		},
+		"foo-future-timerange": map[string]any{
+			"endTime":  "2025-01-02T00:00:00Z",
+			"duration": "1h",
+		},
+		"foo-invalid-timerange": map[string]any{
+			"endTime":  "2025-01-01T00:00:00Z",
+			"duration": "foo",
+		},
+		"foo-text":   "bar",
+		"foo-object": map[string]any{"endTime": "2025-01-01T00:00:00Z"},
+	}
+
+	got := ExtendTimeRangeParameterValues(values, end)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ExtendTimeRangeParameterValues() mismatch (-want +got):\n%s", diff)
+	}
+	if values["foo-timerange"].(map[string]any)["endTime"] != "2025-01-01T00:00:00Z" {
+		t.Errorf("ExtendTimeRangeParameterValues() modified the given values")
+	}
+}
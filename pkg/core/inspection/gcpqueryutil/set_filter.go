@@ -59,8 +59,14 @@ func (r *SetFilterParseResult) SubtractivesWithQuotes() []string {
 	return result
 }
 
+// ParseSetFilter parses the whitespace separated set filter string.
 func ParseSetFilter(filter string, aliases SetFilterAliasToItemsMap, allowAny bool, allowSubtract bool, convertToLowerCase bool) (*SetFilterParseResult, error) {
-	filterElements := strings.Split(filter, " ")
+	return ParseSetFilterItems(strings.Split(filter, " "), aliases, allowAny, allowSubtract, convertToLowerCase)
+}
+
+// ParseSetFilterItems parses the set filter given as the list of elements, like values given from a set form field.
+func ParseSetFilterItems(items []string, aliases SetFilterAliasToItemsMap, allowAny bool, allowSubtract bool, convertToLowerCase bool) (*SetFilterParseResult, error) {
+	filterElements := slices.Clone(items)
 	for i := 0; i < len(filterElements); i++ {
 		filterElements[i] = strings.TrimSpace(filterElements[i])
 		if filterElements[i] != "" && !validElementRegex.Match([]byte(filterElements[i])) {
@@ -227,3 +227,50 @@ func TestParseSetFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestParseSetFilterItems(t *testing.T) {
+	var testAliasMap SetFilterAliasToItemsMap = map[string][]string{
+		"foobar": {"foo", "bar"},
+	}
+	testCases := []struct {
+		Name           string
+		Items          []string
+		ExpectedResult *SetFilterParseResult
+	}{
+		{
+			Name:  "empty",
+			Items: []string{},
+			ExpectedResult: &SetFilterParseResult{
+				Additives:    []string{},
+				Subtractives: []string{},
+			},
+		},
+		{
+			Name:  "items with alias and subtraction",
+			Items: []string{"@foobar", "-foo", "qux"},
+			ExpectedResult: &SetFilterParseResult{
+				Additives:    []string{"bar", "qux"},
+				Subtractives: []string{},
+			},
+		},
+		{
+			Name:  "item containing whitespace",
+			Items: []string{"foo bar"},
+			ExpectedResult: &SetFilterParseResult{
+				ValidationError: "filter value must be whitespace splitted series of [a-zA-Z0-9\\-_]+",
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			result, err := ParseSetFilterItems(testCase.Items, testAliasMap, true, true, true)
+			if err != nil {
+				t.Errorf("unexpected error\n%s", err)
+			}
+
+			if diff := cmp.Diff(testCase.ExpectedResult, result); diff != "" {
+				t.Errorf("ParseSetFilterItems result is not matching with the expected result\n%s", diff)
+			}
+		})
+	}
+}
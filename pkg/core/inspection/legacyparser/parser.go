@@ -144,9 +144,10 @@ func NewParserTaskFromParser(taskId taskid.TaskImplementationID[struct{}], parse
 				limitChannel <- struct{}{}
 				groupedLogs := groups[groupNames[currentGroup]]
 				threadCount += 1
-				wg.Go(func() error { // TODO: replace this with pkg/common/worker/pool
-					defer errorreport.CheckAndReportPanic()
-					err = builder.ParseLogsByGroups(ctx, groupedLogs, func(logIndex int, l *log.Log) *history.ChangeSet {
+				wg.Go(func() (err error) { // TODO: replace this with pkg/common/worker/pool
+					defer errorreport.RecoverPanicAsError(&err)
+					defer func() { <-limitChannel }()
+					return builder.ParseLogsByGroups(ctx, groupedLogs, func(logIndex int, l *log.Log) *history.ChangeSet {
 						cs := history.NewChangeSet(l)
 						err := parser.Parse(ctx, l, cs, builder)
 						logCounterChannel <- struct{}{}
@@ -164,8 +165,6 @@ func NewParserTaskFromParser(taskId taskid.TaskImplementationID[struct{}], parse
 						}
 						return cs
 					})
-					<-limitChannel
-					return err
 				})
 				currentGroup += 1
 				doneThreadCount.Add(1)
@@ -0,0 +1,89 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// RedactFilter is a slog.Handler that replaces the secret values given to the current inspection run in log records.
+// The secret values are read from the context given to Handle. Attributes given with WithAttrs are not redacted.
+type RedactFilter struct {
+	childLogHandler slog.Handler
+}
+
+// NewRedactFilter creates a new RedactFilter wrapping the child handler.
+func NewRedactFilter(childHandler slog.Handler) *RedactFilter {
+	return &RedactFilter{childHandler}
+}
+
+// Enabled implements slog.Handler.
+func (f *RedactFilter) Enabled(ctx context.Context, level slog.Level) bool {
+	return f.childLogHandler.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler.
+func (f *RedactFilter) Handle(ctx context.Context, r slog.Record) error {
+	secrets, err := khictx.GetValue(ctx, inspectioncore_contract.InspectionSecretValues)
+	if err != nil {
+		return f.childLogHandler.Handle(ctx, r)
+	}
+	redacted := slog.NewRecord(r.Time, r.Level, secrets.Redact(r.Message), r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		redacted.AddAttrs(redactAttr(secrets, a))
+		return true
+	})
+	return f.childLogHandler.Handle(ctx, redacted)
+}
+
+// WithAttrs implements slog.Handler.
+func (f *RedactFilter) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &RedactFilter{f.childLogHandler.WithAttrs(attrs)}
+}
+
+// WithGroup implements slog.Handler.
+func (f *RedactFilter) WithGroup(name string) slog.Handler {
+	return &RedactFilter{f.childLogHandler.WithGroup(name)}
+}
+
+// redactAttr returns the attribute with the secret values replaced in its value.
+func redactAttr(secrets *inspectioncore_contract.SecretValues, a slog.Attr) slog.Attr {
+	value := a.Value.Resolve()
+	switch value.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, secrets.Redact(value.String()))
+	case slog.KindGroup:
+		group := value.Group()
+		redactedGroup := make([]any, len(group))
+		for i, child := range group {
+			redactedGroup[i] = redactAttr(secrets, child)
+		}
+		return slog.Group(a.Key, redactedGroup...)
+	case slog.KindAny:
+		original := value.String()
+		if redacted := secrets.Redact(original); redacted != original {
+			return slog.String(a.Key, redacted)
+		}
+		return a
+	default:
+		return a
+	}
+}
+
+var _ slog.Handler = (*RedactFilter)(nil)
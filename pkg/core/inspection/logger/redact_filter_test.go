@@ -0,0 +1,85 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+func TestRedactFilter(t *testing.T) {
+	secrets := inspectioncore_contract.NewSecretValues()
+	secrets.Add("s3cr3t-token")
+	ctxWithSecrets := khictx.WithValue(context.Background(), inspectioncore_contract.InspectionSecretValues, secrets)
+
+	testCases := []struct {
+		name     string
+		ctx      context.Context
+		log      func(logger *slog.Logger, ctx context.Context)
+		contains []string
+		excludes []string
+	}{
+		{
+			name: "message",
+			ctx:  ctxWithSecrets,
+			log: func(logger *slog.Logger, ctx context.Context) {
+				logger.InfoContext(ctx, "token=s3cr3t-token")
+			},
+			contains: []string{"token=[REDACTED]"},
+			excludes: []string{"s3cr3t-token"},
+		},
+		{
+			name: "attributes",
+			ctx:  ctxWithSecrets,
+			log: func(logger *slog.Logger, ctx context.Context) {
+				logger.InfoContext(ctx, "request failed", "header", "Bearer s3cr3t-token", "error", errors.New("invalid token s3cr3t-token"), slog.Group("auth", "token", "s3cr3t-token"), "count", 3)
+			},
+			contains: []string{"header=\"Bearer [REDACTED]\"", "error=\"invalid token [REDACTED]\"", "auth.token=[REDACTED]", "count=3"},
+			excludes: []string{"s3cr3t-token"},
+		},
+		{
+			name: "without secret values in the context",
+			ctx:  context.Background(),
+			log: func(logger *slog.Logger, ctx context.Context) {
+				logger.InfoContext(ctx, "token=s3cr3t-token")
+			},
+			contains: []string{"token=s3cr3t-token"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			buf, childHandler := newTestHandler()
+			logger := slog.New(NewRedactFilter(childHandler))
+			tc.log(logger, tc.ctx)
+			output := buf.String()
+			for _, expected := range tc.contains {
+				if !strings.Contains(output, expected) {
+					t.Errorf("log output %q doesn't contain %q", output, expected)
+				}
+			}
+			for _, unexpected := range tc.excludes {
+				if strings.Contains(output, unexpected) {
+					t.Errorf("log output %q contains %q", output, unexpected)
+				}
+			}
+		})
+	}
+}
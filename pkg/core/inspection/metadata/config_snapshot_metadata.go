@@ -0,0 +1,72 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inspectionmetadata
+
+import (
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+)
+
+// ConfigSnapshot is a configuration of a resource captured at the time of the inspection.
+// It lets users interpret timelines without looking up the configuration with other tools.
+type ConfigSnapshot struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Snapshot any    `json:"snapshot"`
+}
+
+// ConfigSnapshotSetMetadata is a metadata type containing the configuration snapshots captured in the inspection.
+type ConfigSnapshotSetMetadata struct {
+	Snapshots []*ConfigSnapshot `json:"snapshots"`
+	lock      sync.Mutex
+}
+
+var _ Metadata = (*ConfigSnapshotSetMetadata)(nil)
+
+// Labels implements Metadata.
+func (*ConfigSnapshotSetMetadata) Labels() *typedmap.ReadonlyTypedMap {
+	return NewLabelSet(IncludeInRunResult(), IncludeInResultBinary())
+}
+
+// ToSerializable implements Metadata.
+func (c *ConfigSnapshotSetMetadata) ToSerializable() interface{} {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	snapshots := slices.Clone(c.Snapshots)
+	slices.SortFunc(snapshots, func(a, b *ConfigSnapshot) int { return strings.Compare(a.ID, b.ID) })
+	return &ConfigSnapshotSetMetadata{Snapshots: snapshots}
+}
+
+// AddSnapshot records a configuration snapshot. The snapshot is overwritten when the snapshot with the same ID was already recorded.
+func (c *ConfigSnapshotSetMetadata) AddSnapshot(snapshot *ConfigSnapshot) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for i, s := range c.Snapshots {
+		if s.ID == snapshot.ID {
+			c.Snapshots[i] = snapshot
+			return
+		}
+	}
+	c.Snapshots = append(c.Snapshots, snapshot)
+}
+
+func NewConfigSnapshotSetMetadata() *ConfigSnapshotSetMetadata {
+	return &ConfigSnapshotSetMetadata{
+		Snapshots: []*ConfigSnapshot{},
+	}
+}
@@ -77,3 +77,32 @@ func TestProgressConformance(t *testing.T) {
 func TestPlanMetadataConformance(t *testing.T) {
 	ConformanceMetadataTypeTest(t, &InspectionPlanMetadata{})
 }
+
+func TestFailedFeatureSetMetadataConformance(t *testing.T) {
+	failedFeatures := NewFailedFeatureSetMetadata()
+	failedFeatures.AddFailedFeature(&FailedFeature{ID: "foo", Title: "Foo", Error: "foo error"})
+	ConformanceMetadataTypeTest(t, failedFeatures)
+}
+
+func TestConfigSnapshotSetMetadataConformance(t *testing.T) {
+	snapshots := NewConfigSnapshotSetMetadata()
+	snapshots.AddSnapshot(&ConfigSnapshot{ID: "foo", Title: "Foo", Snapshot: map[string]string{"version": "1.30"}})
+	ConformanceMetadataTypeTest(t, snapshots)
+}
+
+func TestTaskStatsMetadataConformance(t *testing.T) {
+	taskStats := NewTaskStatsMetadata()
+	taskStats.SetTaskStat(&TaskStat{ID: "foo", DurationSeconds: 1.5, OutputBytes: 100, LogCount: 2})
+	ConformanceMetadataTypeTest(t, taskStats)
+}
+
+func TestParameterValueSetMetadataConformance(t *testing.T) {
+	parameters := NewParameterValueSetMetadata()
+	parameters.SetValue("foo", "foo-value")
+	parameters.SetValue("bar", []string{"bar-value"})
+	ConformanceMetadataTypeTest(t, parameters)
+}
+
+func TestFormLayoutMetadataConformance(t *testing.T) {
+	ConformanceMetadataTypeTest(t, NewFormLayoutMetadata(NewFormFieldSetMetadata()))
+}
@@ -15,6 +15,8 @@
 package inspectionmetadata
 
 import (
+	"fmt"
+
 	"github.com/kyasbal/khi/pkg/common/typedmap"
 )
 
@@ -42,9 +44,10 @@ func (e *ErrorMessageSetMetadata) ToSerializable() interface{} {
 var _ Metadata = (*ErrorMessageSetMetadata)(nil)
 
 // AddErrorMessage stores a new ErrorMessage. Duplicated error message will be ignored.
+// Error messages with the same ErrorId are regarded as duplicated except task panics, which are kept for each distinct message.
 func (e *ErrorMessageSetMetadata) AddErrorMessage(newError *ErrorMessage) {
 	for _, msg := range e.ErrorMessages {
-		if msg.ErrorId == newError.ErrorId {
+		if msg.ErrorId == newError.ErrorId && (!isErrorIdDistinguishedByMessage(newError.ErrorId) || msg.Message == newError.Message) {
 			return // Skip adding duplicated error
 		}
 	}
@@ -58,8 +61,46 @@ func NewUnauthorizedErrorMessage() *ErrorMessage {
 	}
 }
 
+// taskPanicErrorId is the ErrorId of the error messages generated for task panics.
+const taskPanicErrorId = 3
+
+// NewTaskPanicErrorMessage returns an ErrorMessage for a task raised a panic with its stack trace.
+func NewTaskPanicErrorMessage(taskID string, value any, stack string) *ErrorMessage {
+	return &ErrorMessage{
+		ErrorId: taskPanicErrorId,
+		Message: fmt.Sprintf("Task %s panicked: %v\n%s", taskID, value, stack),
+	}
+}
+
+// circuitOpenErrorId is the ErrorId of the error messages generated for API requests stopped by an open circuit breaker.
+const circuitOpenErrorId = 4
+
+// NewCircuitOpenErrorMessage returns an ErrorMessage consolidating the errors of the requests to the API stopped after repeated failures.
+func NewCircuitOpenErrorMessage(apiName string, cause string) *ErrorMessage {
+	return &ErrorMessage{
+		ErrorId: circuitOpenErrorId,
+		Message: fmt.Sprintf("The remaining requests to %s were stopped because it failed repeatedly. The queries not completed are skipped: %s", apiName, cause),
+	}
+}
+
 func NewErrorMessageSetMetadata() *ErrorMessageSetMetadata {
 	return &ErrorMessageSetMetadata{
 		ErrorMessages: []*ErrorMessage{},
 	}
 }
+
+// featureFailedErrorId is the ErrorId of the error messages generated for features failed without aborting the inspection.
+const featureFailedErrorId = 5
+
+// NewFeatureFailedErrorMessage returns an ErrorMessage for a feature failed without aborting the inspection.
+func NewFeatureFailedErrorMessage(featureTitle string, cause string) *ErrorMessage {
+	return &ErrorMessage{
+		ErrorId: featureFailedErrorId,
+		Message: fmt.Sprintf("Feature %q failed and its logs are missing in the result: %s", featureTitle, cause),
+	}
+}
+
+// isErrorIdDistinguishedByMessage returns true when error messages with the given ErrorId are deduplicated by their messages instead of their ErrorId.
+func isErrorIdDistinguishedByMessage(errorId int) bool {
+	return errorId == taskPanicErrorId || errorId == featureFailedErrorId
+}
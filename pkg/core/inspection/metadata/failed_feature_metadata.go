@@ -0,0 +1,74 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inspectionmetadata
+
+import (
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	"github.com/kyasbal/khi/pkg/model/enum"
+)
+
+// FailedFeature describes a feature task failed without aborting the inspection.
+// Timelines generated from the log type of the feature can be missing or incomplete in the inspection result.
+type FailedFeature struct {
+	ID      string       `json:"id"`
+	Title   string       `json:"title"`
+	LogType enum.LogType `json:"logType"`
+	Error   string       `json:"error"`
+}
+
+// FailedFeatureSetMetadata is a metadata type containing the feature tasks failed in the inspection.
+type FailedFeatureSetMetadata struct {
+	FailedFeatures []*FailedFeature `json:"failedFeatures"`
+	lock           sync.Mutex
+}
+
+var _ Metadata = (*FailedFeatureSetMetadata)(nil)
+
+// Labels implements Metadata.
+func (*FailedFeatureSetMetadata) Labels() *typedmap.ReadonlyTypedMap {
+	return NewLabelSet(IncludeInRunResult(), IncludeInTaskList(), IncludeInResultBinary())
+}
+
+// ToSerializable implements Metadata.
+func (f *FailedFeatureSetMetadata) ToSerializable() interface{} {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	failedFeatures := slices.Clone(f.FailedFeatures)
+	slices.SortFunc(failedFeatures, func(a, b *FailedFeature) int { return strings.Compare(a.ID, b.ID) })
+	return &FailedFeatureSetMetadata{FailedFeatures: failedFeatures}
+}
+
+// AddFailedFeature records a failed feature. The error is overwritten when the feature with the same ID was already recorded.
+func (f *FailedFeatureSetMetadata) AddFailedFeature(failedFeature *FailedFeature) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for i, feature := range f.FailedFeatures {
+		if feature.ID == failedFeature.ID {
+			f.FailedFeatures[i] = failedFeature
+			return
+		}
+	}
+	f.FailedFeatures = append(f.FailedFeatures, failedFeature)
+}
+
+func NewFailedFeatureSetMetadata() *FailedFeatureSetMetadata {
+	return &FailedFeatureSetMetadata{
+		FailedFeatures: []*FailedFeature{},
+	}
+}
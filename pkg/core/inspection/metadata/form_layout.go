@@ -0,0 +1,233 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inspectionmetadata
+
+import (
+	"slices"
+
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+)
+
+// FormSection is a section of the form containing related fields like the fields identifying the resource.
+// Sections are placed relatively to each other with After instead of numeric priorities.
+type FormSection struct {
+	// ID is the unique identifier of the section.
+	ID string
+	// Label is the human readable title of the section. The frontend shows the fields without a title when this is empty.
+	Label string
+	// After is the section placed before this section. When the section is not used in the form, the section declared in its After is used instead.
+	// The section is placed at the beginning when After is nil.
+	After *FormSection
+}
+
+// FormPosition declares the section of a form field and its position relative to the other fields in the section.
+type FormPosition struct {
+	// Section is the section containing the field.
+	Section *FormSection
+	// After is the list of field IDs placed before this field. IDs of fields not in the same section are ignored.
+	After []string
+	// Before is the list of field IDs placed after this field. IDs of fields not in the same section are ignored.
+	Before []string
+}
+
+// FormLayoutSection is the serializable layout of a section with the IDs of its fields in the order shown in the form.
+type FormLayoutSection struct {
+	// ID is the ID of the section. This is empty for the fields without any section.
+	ID string `json:"id"`
+	// Label is the human readable title of the section.
+	Label string `json:"label"`
+	// FieldIDs is the list of IDs of the top level fields in the section.
+	FieldIDs []string `json:"fieldIds"`
+}
+
+// FormLayoutMetadata is a metadata type used in frontend to place the top level form fields in sections.
+type FormLayoutMetadata struct {
+	formFields *FormFieldSetMetadata
+}
+
+var _ Metadata = (*FormLayoutMetadata)(nil)
+
+// NewFormLayoutMetadata returns a FormLayoutMetadata generating the layout of the given form field set.
+func NewFormLayoutMetadata(formFields *FormFieldSetMetadata) *FormLayoutMetadata {
+	return &FormLayoutMetadata{
+		formFields: formFields,
+	}
+}
+
+// Labels implements Metadata.
+func (*FormLayoutMetadata) Labels() *typedmap.ReadonlyTypedMap {
+	return NewLabelSet(IncludeInDryRunResult())
+}
+
+// ToSerializable implements Metadata.
+func (f *FormLayoutMetadata) ToSerializable() interface{} {
+	return f.formFields.Layout()
+}
+
+// layoutFormFields sorts the fields by their sections and the relative positions in the sections.
+// Fields without a section are placed after all the sections. Fields are sorted in descending order of the priority unless their positions require another order.
+// The result doesn't depend on the order of the given fields, thus the form is stable regardless of the order of tasks generating fields.
+func layoutFormFields(fields []ParameterFormField) {
+	sortFormFields(fields)
+
+	sections := map[string]*FormSection{}
+	fieldsInSection := map[string][]ParameterFormField{}
+	unpositionedFields := []ParameterFormField{}
+	for _, field := range fields {
+		section := formFieldSection(field)
+		if section == nil {
+			unpositionedFields = append(unpositionedFields, field)
+			continue
+		}
+		if _, found := sections[section.ID]; !found {
+			sections[section.ID] = section
+		}
+		fieldsInSection[section.ID] = append(fieldsInSection[section.ID], field)
+	}
+
+	result := make([]ParameterFormField, 0, len(fields))
+	for _, sectionID := range orderFormSections(sections) {
+		result = append(result, orderFieldsInSection(fieldsInSection[sectionID])...)
+	}
+	result = append(result, orderFieldsInSection(unpositionedFields)...)
+	copy(fields, result)
+}
+
+// orderFormSections returns the IDs of the given sections in the order to be shown.
+func orderFormSections(sections map[string]*FormSection) []string {
+	ids := make([]string, 0, len(sections))
+	for id := range sections {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	indices := map[string]int{}
+	for i, id := range ids {
+		indices[id] = i
+	}
+	edges := [][2]int{}
+	for i, id := range ids {
+		// Find the nearest preceding section used in the form.
+		for after := sections[id].After; after != nil; after = after.After {
+			if afterIndex, found := indices[after.ID]; found {
+				edges = append(edges, [2]int{afterIndex, i})
+				break
+			}
+		}
+	}
+	result := make([]string, 0, len(ids))
+	for _, index := range stableTopologicalOrder(len(ids), edges) {
+		result = append(result, ids[index])
+	}
+	return result
+}
+
+// orderFieldsInSection returns the fields in the order satisfying their relative positions.
+// The given order is kept as much as possible for fields without constraints between them.
+func orderFieldsInSection(fields []ParameterFormField) []ParameterFormField {
+	indices := map[string]int{}
+	for i, field := range fields {
+		indices[GetParameterFormFieldBase(field).ID] = i
+	}
+	edges := [][2]int{}
+	for i, field := range fields {
+		position := GetParameterFormFieldBase(field).Position
+		if position == nil {
+			continue
+		}
+		for _, after := range position.After {
+			if afterIndex, found := indices[after]; found {
+				edges = append(edges, [2]int{afterIndex, i})
+			}
+		}
+		for _, before := range position.Before {
+			if beforeIndex, found := indices[before]; found {
+				edges = append(edges, [2]int{i, beforeIndex})
+			}
+		}
+	}
+	result := make([]ParameterFormField, 0, len(fields))
+	for _, index := range stableTopologicalOrder(len(fields), edges) {
+		result = append(result, fields[index])
+	}
+	return result
+}
+
+// stableTopologicalOrder returns the indices of n nodes sorted to satisfy the given edges from the former to the latter node.
+// The node with the smallest index is picked first among the nodes ready to be placed.
+// Nodes in a cycle are appended at the end in the order of their indices.
+func stableTopologicalOrder(n int, edges [][2]int) []int {
+	inDegrees := make([]int, n)
+	successors := make([][]int, n)
+	for _, edge := range edges {
+		successors[edge[0]] = append(successors[edge[0]], edge[1])
+		inDegrees[edge[1]]++
+	}
+	placed := make([]bool, n)
+	result := make([]int, 0, n)
+	for len(result) < n {
+		next := -1
+		for i := 0; i < n; i++ {
+			if !placed[i] && inDegrees[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next == -1 {
+			break
+		}
+		placed[next] = true
+		result = append(result, next)
+		for _, successor := range successors[next] {
+			inDegrees[successor]--
+		}
+	}
+	for i := 0; i < n; i++ {
+		if !placed[i] {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+// formFieldSection returns the section of the field. Returns nil when the field has no section.
+func formFieldSection(field ParameterFormField) *FormSection {
+	position := GetParameterFormFieldBase(field).Position
+	if position == nil {
+		return nil
+	}
+	return position.Section
+}
+
+// layoutSections returns the sections of the given fields already sorted with layoutFormFields.
+func layoutSections(fields []ParameterFormField) []FormLayoutSection {
+	result := []FormLayoutSection{}
+	for _, field := range fields {
+		id, label := "", ""
+		if section := formFieldSection(field); section != nil {
+			id, label = section.ID, section.Label
+		}
+		fieldID := GetParameterFormFieldBase(field).ID
+		if len(result) > 0 && result[len(result)-1].ID == id {
+			result[len(result)-1].FieldIDs = append(result[len(result)-1].FieldIDs, fieldID)
+			continue
+		}
+		result = append(result, FormLayoutSection{
+			ID:       id,
+			Label:    label,
+			FieldIDs: []string{fieldID},
+		})
+	}
+	return result
+}
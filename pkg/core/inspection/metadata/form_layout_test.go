@@ -0,0 +1,167 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inspectionmetadata
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var (
+	testSectionFirst  = &FormSection{ID: "first", Label: "First"}
+	testSectionSecond = &FormSection{ID: "second", Label: "Second", After: testSectionFirst}
+	testSectionThird  = &FormSection{ID: "third", Label: "Third", After: testSectionSecond}
+)
+
+func positionedFieldForTest(id string, priority int, position *FormPosition) TextParameterFormField {
+	field := fieldWithIdAndPriorityForTest(id, priority)
+	field.Position = position
+	return field
+}
+
+func TestFormFieldSetLayout(t *testing.T) {
+	testCases := []struct {
+		name   string
+		fields []ParameterFormField
+		want   []FormLayoutSection
+	}{
+		{
+			name: "sections ordered with After",
+			fields: []ParameterFormField{
+				positionedFieldForTest("third-field", 0, &FormPosition{Section: testSectionThird}),
+				positionedFieldForTest("first-field", 0, &FormPosition{Section: testSectionFirst}),
+				positionedFieldForTest("second-field", 0, &FormPosition{Section: testSectionSecond}),
+			},
+			want: []FormLayoutSection{
+				{ID: "first", Label: "First", FieldIDs: []string{"first-field"}},
+				{ID: "second", Label: "Second", FieldIDs: []string{"second-field"}},
+				{ID: "third", Label: "Third", FieldIDs: []string{"third-field"}},
+			},
+		},
+		{
+			name: "section placed after the nearest used section when the intermediate section is missing",
+			fields: []ParameterFormField{
+				positionedFieldForTest("third-field", 0, &FormPosition{Section: testSectionThird}),
+				positionedFieldForTest("first-field", 0, &FormPosition{Section: testSectionFirst}),
+			},
+			want: []FormLayoutSection{
+				{ID: "first", Label: "First", FieldIDs: []string{"first-field"}},
+				{ID: "third", Label: "Third", FieldIDs: []string{"third-field"}},
+			},
+		},
+		{
+			name: "fields ordered with After and Before",
+			fields: []ParameterFormField{
+				positionedFieldForTest("c", 0, &FormPosition{Section: testSectionFirst, After: []string{"b"}}),
+				positionedFieldForTest("a", 0, &FormPosition{Section: testSectionFirst, Before: []string{"b"}}),
+				positionedFieldForTest("b", 0, &FormPosition{Section: testSectionFirst}),
+			},
+			want: []FormLayoutSection{
+				{ID: "first", Label: "First", FieldIDs: []string{"a", "b", "c"}},
+			},
+		},
+		{
+			name: "missing fields in After and Before are ignored",
+			fields: []ParameterFormField{
+				positionedFieldForTest("b", 0, &FormPosition{Section: testSectionFirst, After: []string{"missing"}}),
+				positionedFieldForTest("a", 0, &FormPosition{Section: testSectionFirst, Before: []string{"missing"}}),
+			},
+			want: []FormLayoutSection{
+				{ID: "first", Label: "First", FieldIDs: []string{"a", "b"}},
+			},
+		},
+		{
+			name: "fields in a cycle are ordered by the priority",
+			fields: []ParameterFormField{
+				positionedFieldForTest("a", 1, &FormPosition{Section: testSectionFirst, After: []string{"b"}}),
+				positionedFieldForTest("b", 2, &FormPosition{Section: testSectionFirst, After: []string{"a"}}),
+				positionedFieldForTest("c", 0, &FormPosition{Section: testSectionFirst}),
+			},
+			want: []FormLayoutSection{
+				{ID: "first", Label: "First", FieldIDs: []string{"c", "b", "a"}},
+			},
+		},
+		{
+			name: "fields without position placed at the end in the order of priority",
+			fields: []ParameterFormField{
+				fieldWithIdAndPriorityForTest("low", 1),
+				positionedFieldForTest("first-field", 0, &FormPosition{Section: testSectionFirst}),
+				fieldWithIdAndPriorityForTest("high", 2),
+			},
+			want: []FormLayoutSection{
+				{ID: "first", Label: "First", FieldIDs: []string{"first-field"}},
+				{ID: "", Label: "", FieldIDs: []string{"high", "low"}},
+			},
+		},
+		{
+			name:   "no fields",
+			fields: []ParameterFormField{},
+			want:   []FormLayoutSection{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fs := NewFormFieldSetMetadata()
+			for _, field := range tc.fields {
+				if err := fs.SetField(field); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			if diff := cmp.Diff(tc.want, fs.Layout()); diff != "" {
+				t.Errorf("Layout() returned unexpected layout (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFormFieldSetLayoutIsStableRegardlessOfFieldOrder(t *testing.T) {
+	fields := []ParameterFormField{
+		positionedFieldForTest("a", 0, &FormPosition{Section: testSectionSecond}),
+		positionedFieldForTest("b", 0, &FormPosition{Section: testSectionSecond, After: []string{"a"}}),
+		positionedFieldForTest("c", 0, &FormPosition{Section: testSectionFirst}),
+		fieldWithIdAndPriorityForTest("d", 0),
+	}
+	want := []FormLayoutSection{
+		{ID: "first", Label: "First", FieldIDs: []string{"c"}},
+		{ID: "second", Label: "Second", FieldIDs: []string{"a", "b"}},
+		{ID: "", Label: "", FieldIDs: []string{"d"}},
+	}
+	for _, order := range [][]int{{0, 1, 2, 3}, {3, 2, 1, 0}, {1, 3, 0, 2}} {
+		fs := NewFormFieldSetMetadata()
+		for _, i := range order {
+			if err := fs.SetField(fields[i]); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		if diff := cmp.Diff(want, fs.Layout()); diff != "" {
+			t.Errorf("Layout() with the field order %v returned unexpected layout (-want +got):\n%s", order, diff)
+		}
+	}
+}
+
+func TestFormLayoutMetadata(t *testing.T) {
+	fs := NewFormFieldSetMetadata()
+	layout := NewFormLayoutMetadata(fs)
+	if err := fs.SetField(positionedFieldForTest("foo", 0, &FormPosition{Section: testSectionFirst})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []FormLayoutSection{
+		{ID: "first", Label: "First", FieldIDs: []string{"foo"}},
+	}
+	if diff := cmp.Diff(want, layout.ToSerializable()); diff != "" {
+		t.Errorf("ToSerializable() returned unexpected layout (-want +got):\n%s", diff)
+	}
+}
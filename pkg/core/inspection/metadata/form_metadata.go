@@ -20,6 +20,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/kyasbal/khi/pkg/common/markdown"
 	"github.com/kyasbal/khi/pkg/common/typedmap"
 	"github.com/kyasbal/khi/pkg/server/upload"
 )
@@ -36,6 +37,14 @@ const (
 	File ParameterInputType = "file"
 	// Set is a type of ParameterInputType. This represents the set type input field.
 	Set ParameterInputType = "set"
+	// Select is a type of ParameterInputType. This represents the drop down type input field to choose a value from the options.
+	Select ParameterInputType = "select"
+	// DateTime is a type of ParameterInputType. This represents the date and time picker field.
+	DateTime ParameterInputType = "datetime"
+	// TimeRange is a type of ParameterInputType. This represents the field to input a time range with the end time and the duration.
+	TimeRange ParameterInputType = "timerange"
+	// Secret is a type of ParameterInputType. This represents the masked input field for secrets like credentials.
+	Secret ParameterInputType = "secret"
 )
 
 // ParameterHintType represents the types of hint message shown at the bottom of parameter forms.
@@ -62,6 +71,16 @@ const (
 	Blur TextFormValidationTimingType = "blur"
 )
 
+// ReadonlyReasonType is a machine readable reason why a form field is readonly.
+type ReadonlyReasonType string
+
+const (
+	// ReadonlyReasonUnspecified is a type of ReadonlyReasonType used for readonly fields without a specific reason.
+	ReadonlyReasonUnspecified ReadonlyReasonType = "unspecified"
+	// ReadonlyReasonServerConfiguration is a type of ReadonlyReasonType. The value is fixed by the server configuration like command line flags or environment variables.
+	ReadonlyReasonServerConfiguration ReadonlyReasonType = "server-configuration"
+)
+
 // ParameterFormField is an interface that all specific form field types must implement.
 // It serves as a marker interface for different parameter input types.
 type ParameterFormField interface{}
@@ -82,6 +101,15 @@ type ParameterFormFieldBase struct {
 	HintType ParameterHintType `json:"hintType"`
 	// Hint is the message shown under the form field. Assign HintType as well when you assign a value to this field.
 	Hint string `json:"hint"`
+	// Markdown is true when Description and Hint are written in Markdown.
+	// They are rendered to sanitized HTML on serialization, and the frontend shows them as HTML instead of plain text.
+	Markdown bool `json:"markdown"`
+	// DocURL is the URL of the document describing the syntax or the usage of this field. Empty when the field has no document.
+	DocURL string `json:"docUrl"`
+	// GroupID is the ID of the Group type field containing this field. The field is placed at the top level when this is empty.
+	GroupID string `json:"-"`
+	// Position is the section and the relative position of this field. The field is placed by Priority when this is nil.
+	Position *FormPosition `json:"-"`
 }
 
 // GroupParameterFormField represents Group type parameter specific data.
@@ -102,12 +130,41 @@ type TextParameterFormField struct {
 	ParameterFormFieldBase
 	// Readonly limits users to modify the field.
 	Readonly bool `json:"readonly"`
+	// ReadonlyReason is the reason why the field is readonly. Empty when the field is not readonly.
+	ReadonlyReason ReadonlyReasonType `json:"readonlyReason"`
+	// ReadonlySource is a human readable name of the source fixing the value like the name of the flag. Empty when the source is unknown.
+	ReadonlySource string `json:"readonlySource"`
 	// Default is the default value of this field.
 	Default string `json:"default"`
 	// Suggestion is the auto complete drop down values.
 	Suggestions []string `json:"suggestions"`
+	// SuggestionItems is the auto complete drop down values with the details. Each Value is also contained in Suggestions in the same order.
+	// This is nil when the suggestions are given only with their values.
+	SuggestionItems []TextParameterFormFieldSuggestion `json:"suggestionItems"`
+	// SuggestionsLoading is true when the suggestions are still being fetched. Suggestions may contain stale values in that case.
+	SuggestionsLoading bool `json:"suggestionsLoading"`
 	// ValidationTiming specifies when the validation for this text field should be triggered.
 	ValidationTiming TextFormValidationTimingType `json:"validationTiming"`
+	// Pattern is the regular expression the value must fully match. Empty when the value has no pattern constraint.
+	Pattern string `json:"pattern"`
+	// PatternErrorMessage is the error message shown when the value doesn't match Pattern.
+	PatternErrorMessage string `json:"patternErrorMessage"`
+	// MaxLength is the maximum number of characters of the value. 0 means no limit.
+	MaxLength int `json:"maxLength"`
+	// Placeholder is the example input shown in the field while the value is empty. Unlike Default, this is never used as the value.
+	Placeholder string `json:"placeholder"`
+}
+
+// TextParameterFormFieldSuggestion represents a suggestion item of TextParameterFormField with the details shown in the auto complete drop down.
+type TextParameterFormFieldSuggestion struct {
+	// Value is the value set to the field when the suggestion is selected.
+	Value string `json:"value"`
+	// Label is a human readable name of the suggestion. The frontend shows Value when this is empty.
+	Label string `json:"label"`
+	// Description is a human readable supplemental information of the suggestion like the location of the resource.
+	Description string `json:"description"`
+	// Group is the name of the group the suggestion is listed in. Suggestions without Group are listed without grouping.
+	Group string `json:"group"`
 }
 
 // SetParameterFormFieldOptionItem represents an option item in SetParameterFormField.
@@ -133,6 +190,77 @@ type SetParameterFormField struct {
 	Default []string `json:"default"`
 }
 
+// SelectParameterFormFieldOptionItem represents an option item in SelectParameterFormField.
+type SelectParameterFormFieldOptionItem struct {
+	// ID is the unique identifier of the option. This is the value sent from frontend when the option is selected.
+	ID string `json:"id"`
+	// Label is a short human readable name of the option shown in the drop down.
+	Label string `json:"label"`
+	// Description is a human readable description of the option.
+	Description string `json:"description"`
+}
+
+// SelectParameterFormField represents Select type parameter specific data.
+type SelectParameterFormField struct {
+	ParameterFormFieldBase
+	// Options is the list of available options.
+	Options []SelectParameterFormFieldOptionItem `json:"options"`
+	// Default is the ID of the option selected by default.
+	Default string `json:"default"`
+}
+
+// DateTimeParameterFormField represents DateTime type parameter specific data.
+type DateTimeParameterFormField struct {
+	ParameterFormFieldBase
+	// Default is the default value of this field in RFC3339 format in the timezone shifted with TimezoneShiftHours.
+	Default string `json:"default"`
+	// ValueUTC is the current value of this field normalized in UTC in RFC3339 format.
+	ValueUTC string `json:"valueUTC"`
+	// TimezoneShiftHours is the offset from UTC in hours used to show the time on the picker.
+	TimezoneShiftHours float64 `json:"timezoneShiftHours"`
+	// Suggestions is the list of values used previously in RFC3339 format.
+	Suggestions []string `json:"suggestions"`
+}
+
+// TimeRangeParameterValue is the value of TimeRange type parameter.
+type TimeRangeParameterValue struct {
+	// EndTime is the end of the time range in RFC3339 format.
+	EndTime string `json:"endTime"`
+	// Duration is the length of the time range in the format of Go time.Duration. (e.g `1h30m`)
+	Duration string `json:"duration"`
+}
+
+// TimeRangeParameterFormFieldPreset is a time range selectable with a click on TimeRangeParameterFormField.
+type TimeRangeParameterFormFieldPreset struct {
+	// Label is a short human readable name of the preset. (e.g `Last 1h`)
+	Label string `json:"label"`
+	// Value is the value set to the field when the preset is selected.
+	Value TimeRangeParameterValue `json:"value"`
+}
+
+// TimeRangeParameterFormField represents TimeRange type parameter specific data.
+type TimeRangeParameterFormField struct {
+	ParameterFormFieldBase
+	// Default is the default value of this field. The end time is in the timezone shifted with TimezoneShiftHours.
+	Default TimeRangeParameterValue `json:"default"`
+	// StartTimeUTC is the start of the current time range normalized in UTC in RFC3339 format.
+	StartTimeUTC string `json:"startTimeUTC"`
+	// EndTimeUTC is the end of the current time range normalized in UTC in RFC3339 format.
+	EndTimeUTC string `json:"endTimeUTC"`
+	// TimezoneShiftHours is the offset from UTC in hours used to show the end time on the picker.
+	TimezoneShiftHours float64 `json:"timezoneShiftHours"`
+	// Presets is the list of time ranges selectable with a click.
+	Presets []TimeRangeParameterFormFieldPreset `json:"presets"`
+}
+
+// SecretParameterFormField represents Secret type parameter specific data.
+// The value given to the field is never included in the metadata.
+type SecretParameterFormField struct {
+	ParameterFormFieldBase
+	// ValueGiven is true when a non empty value was given to this field.
+	ValueGiven bool `json:"valueGiven"`
+}
+
 // FileParameterFormField represents File type parameter specific data.
 type FileParameterFormField struct {
 	ParameterFormFieldBase
@@ -140,6 +268,22 @@ type FileParameterFormField struct {
 	Token upload.UploadToken `json:"token"`
 	// Status is the current status of the file.
 	Status upload.UploadStatus `json:"status"`
+	// Multiple is true when the field accepts multiple files. Files are uploaded with the tokens in Files instead of Token when this is true.
+	Multiple bool `json:"multiple"`
+	// Files is the list of files in the order given by the user. This is always empty when Multiple is false.
+	Files []FileParameterFormFieldFile `json:"files"`
+}
+
+// FileParameterFormFieldFile represents the status of a file given to a File type parameter accepting multiple files.
+type FileParameterFormFieldFile struct {
+	// Token is the token used for uploading this file.
+	Token upload.UploadToken `json:"token"`
+	// Status is the current status of the file.
+	Status upload.UploadStatus `json:"status"`
+	// HintType is the type of Hint.
+	HintType ParameterHintType `json:"hintType"`
+	// Hint is the message shown for the file.
+	Hint string `json:"hint"`
 }
 
 // FormFieldSetMetadata is a metadata type used in frontend to generate the form fields.
@@ -155,10 +299,16 @@ func (*FormFieldSetMetadata) Labels() *typedmap.ReadonlyTypedMap {
 	return NewLabelSet(IncludeInDryRunResult())
 }
 
+// ToSerializable implements Metadata.
+// Descriptions and hints of the fields written in Markdown are rendered to HTML in the result.
 func (f *FormFieldSetMetadata) ToSerializable() interface{} {
-	return f.fields
+	f.fieldsLock.RLock()
+	defer f.fieldsLock.RUnlock()
+	return renderMarkdownFields(f.fields)
 }
 
+// SetField adds the field to the form. The field is added as a child of the group when GroupID of the field is not empty.
+// Fields are sorted by their positions in each group.
 func (f *FormFieldSetMetadata) SetField(newField ParameterFormField) error {
 	f.fieldsLock.Lock()
 	defer f.fieldsLock.Unlock()
@@ -166,35 +316,97 @@ func (f *FormFieldSetMetadata) SetField(newField ParameterFormField) error {
 	if newFieldBase.ID == "" {
 		return fmt.Errorf("id must not be empty")
 	}
-	for _, field := range f.fields {
-		fieldBase := GetParameterFormFieldBase(field)
-		if fieldBase.ID == newFieldBase.ID {
-			return fmt.Errorf("id %s is already used", newFieldBase.ID)
-		}
+	if findField(f.fields, newFieldBase.ID) != nil {
+		return fmt.Errorf("id %s is already used", newFieldBase.ID)
 	}
-	f.fields = append(f.fields, newField)
-	slices.SortFunc(f.fields, func(a, b ParameterFormField) int {
-		paramBBase := GetParameterFormFieldBase(b)
-		paramABase := GetParameterFormFieldBase(a)
-		priorityDiff := paramBBase.Priority - paramABase.Priority
-		if priorityDiff != 0 {
-			return priorityDiff
-		} else {
-			return strings.Compare(paramABase.ID, paramBBase.ID)
+	if newFieldBase.GroupID == "" {
+		f.fields = append(f.fields, newField)
+		layoutFormFields(f.fields)
+		return nil
+	}
+	if !addFieldToGroup(f.fields, newFieldBase.GroupID, newField) {
+		return fmt.Errorf("group %s for field %s was not found", newFieldBase.GroupID, newFieldBase.ID)
+	}
+	return nil
+}
+
+// Layout returns the sections of the top level fields in the order shown in the form.
+func (f *FormFieldSetMetadata) Layout() []FormLayoutSection {
+	f.fieldsLock.RLock()
+	defer f.fieldsLock.RUnlock()
+	return layoutSections(f.fields)
+}
+
+// SetFieldError attaches the given validation error message to the field already set with the ID.
+// The message is appended when the field already has an error hint.
+func (f *FormFieldSetMetadata) SetFieldError(id string, message string) error {
+	f.fieldsLock.Lock()
+	defer f.fieldsLock.Unlock()
+	field := findField(f.fields, id)
+	if field == nil {
+		return fmt.Errorf("field %s was not found", id)
+	}
+	fieldBase := GetParameterFormFieldBase(*field)
+	if fieldBase.HintType == Error && fieldBase.Hint != "" {
+		message = fieldBase.Hint + "\n" + message
+	}
+	fieldBase.HintType = Error
+	fieldBase.Hint = message
+	updated, err := withParameterFormFieldBase(*field, fieldBase)
+	if err != nil {
+		return err
+	}
+	*field = updated
+	return nil
+}
+
+// AddFieldHint attaches the given hint message to the field already set with the ID.
+// The message is appended when the field already has a hint, and the hint type becomes the more severe one of the current and the given type.
+func (f *FormFieldSetMetadata) AddFieldHint(id string, hintType ParameterHintType, message string) error {
+	f.fieldsLock.Lock()
+	defer f.fieldsLock.Unlock()
+	field := findField(f.fields, id)
+	if field == nil {
+		return fmt.Errorf("field %s was not found", id)
+	}
+	fieldBase := GetParameterFormFieldBase(*field)
+	if fieldBase.HintType != None && fieldBase.Hint != "" {
+		message = fieldBase.Hint + "\n" + message
+		if hintTypeSeverity(fieldBase.HintType) > hintTypeSeverity(hintType) {
+			hintType = fieldBase.HintType
 		}
-	})
+	}
+	fieldBase.HintType = hintType
+	fieldBase.Hint = message
+	updated, err := withParameterFormFieldBase(*field, fieldBase)
+	if err != nil {
+		return err
+	}
+	*field = updated
 	return nil
 }
 
+// hintTypeSeverity returns the order of the hint types to decide which type is shown when a field has multiple hints.
+func hintTypeSeverity(hintType ParameterHintType) int {
+	switch hintType {
+	case Error:
+		return 3
+	case Warning:
+		return 2
+	case Info:
+		return 1
+	default:
+		return 0
+	}
+}
+
 // DangerouslyGetField shouldn't be used in non testing code. Because a field shouldn't depend on the other field
 // This is only for testing purpose.
 func (f *FormFieldSetMetadata) DangerouslyGetField(id string) ParameterFormField {
 	f.fieldsLock.RLock()
 	defer f.fieldsLock.RUnlock()
-	for _, field := range f.fields {
-		if GetParameterFormFieldBase(field).ID == id {
-			return field
-		}
+	if field := findField(f.fields, id); field != nil {
+		return *field
 	}
 	return ParameterFormFieldBase{}
 }
@@ -208,6 +420,14 @@ func GetParameterFormFieldBase(parameter ParameterFormField) ParameterFormFieldB
 		return v.ParameterFormFieldBase
 	case SetParameterFormField:
 		return v.ParameterFormFieldBase
+	case SelectParameterFormField:
+		return v.ParameterFormFieldBase
+	case DateTimeParameterFormField:
+		return v.ParameterFormFieldBase
+	case TimeRangeParameterFormField:
+		return v.ParameterFormFieldBase
+	case SecretParameterFormField:
+		return v.ParameterFormFieldBase
 	case FileParameterFormField:
 		return v.ParameterFormFieldBase
 	default:
@@ -215,6 +435,123 @@ func GetParameterFormFieldBase(parameter ParameterFormField) ParameterFormFieldB
 	}
 }
 
+// SecretFieldIDs returns the IDs of the Secret type fields in the given fields including the children of groups.
+func SecretFieldIDs(fields []ParameterFormField) []string {
+	result := []string{}
+	for _, field := range fields {
+		switch v := field.(type) {
+		case GroupParameterFormField:
+			result = append(result, SecretFieldIDs(v.Children)...)
+		case SecretParameterFormField:
+			result = append(result, v.ID)
+		}
+	}
+	return result
+}
+
+// findField returns the pointer to the element holding the field with the ID in the fields including the children of groups.
+// Returns nil when the field was not found.
+func findField(fields []ParameterFormField, id string) *ParameterFormField {
+	for i, field := range fields {
+		if GetParameterFormFieldBase(field).ID == id {
+			return &fields[i]
+		}
+		if group, ok := field.(GroupParameterFormField); ok {
+			if found := findField(group.Children, id); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
+
+// addFieldToGroup adds the field to the children of the group with the ID found in the fields. Returns false when the group was not found.
+func addFieldToGroup(fields []ParameterFormField, groupID string, newField ParameterFormField) bool {
+	for i, field := range fields {
+		group, ok := field.(GroupParameterFormField)
+		if !ok {
+			continue
+		}
+		if group.ID == groupID {
+			group.Children = append(group.Children, newField)
+			layoutFormFields(group.Children)
+			fields[i] = group
+			return true
+		}
+		if addFieldToGroup(group.Children, groupID, newField) {
+			return true
+		}
+	}
+	return false
+}
+
+// renderMarkdownFields returns a copy of the fields with the descriptions and hints written in Markdown rendered to HTML.
+func renderMarkdownFields(fields []ParameterFormField) []ParameterFormField {
+	result := make([]ParameterFormField, 0, len(fields))
+	for _, field := range fields {
+		if group, ok := field.(GroupParameterFormField); ok {
+			group.Children = renderMarkdownFields(group.Children)
+			field = group
+		}
+		base := GetParameterFormFieldBase(field)
+		if base.Markdown {
+			base.Description = markdown.RenderSafeHTML(base.Description)
+			base.Hint = markdown.RenderSafeHTML(base.Hint)
+			if rendered, err := withParameterFormFieldBase(field, base); err == nil {
+				field = rendered
+			}
+		}
+		result = append(result, field)
+	}
+	return result
+}
+
+// sortFormFields sorts the fields in descending order of the priority. Fields with the same priority are sorted by ID.
+func sortFormFields(fields []ParameterFormField) {
+	slices.SortFunc(fields, func(a, b ParameterFormField) int {
+		paramBBase := GetParameterFormFieldBase(b)
+		paramABase := GetParameterFormFieldBase(a)
+		priorityDiff := paramBBase.Priority - paramABase.Priority
+		if priorityDiff != 0 {
+			return priorityDiff
+		} else {
+			return strings.Compare(paramABase.ID, paramBBase.ID)
+		}
+	})
+}
+
+// withParameterFormFieldBase returns a copy of the given ParameterFormField with its ParameterFormFieldBase replaced.
+func withParameterFormFieldBase(parameter ParameterFormField, base ParameterFormFieldBase) (ParameterFormField, error) {
+	switch v := parameter.(type) {
+	case GroupParameterFormField:
+		v.ParameterFormFieldBase = base
+		return v, nil
+	case TextParameterFormField:
+		v.ParameterFormFieldBase = base
+		return v, nil
+	case SetParameterFormField:
+		v.ParameterFormFieldBase = base
+		return v, nil
+	case SelectParameterFormField:
+		v.ParameterFormFieldBase = base
+		return v, nil
+	case DateTimeParameterFormField:
+		v.ParameterFormFieldBase = base
+		return v, nil
+	case TimeRangeParameterFormField:
+		v.ParameterFormFieldBase = base
+		return v, nil
+	case SecretParameterFormField:
+		v.ParameterFormFieldBase = base
+		return v, nil
+	case FileParameterFormField:
+		v.ParameterFormFieldBase = base
+		return v, nil
+	default:
+		return nil, fmt.Errorf("unsupported form field type %T", parameter)
+	}
+}
+
 // NewFormFieldSetMetadata creates and returns a new empty FormFieldSetMetadata instance.
 func NewFormFieldSetMetadata() *FormFieldSetMetadata {
 	return &FormFieldSetMetadata{
@@ -50,3 +50,262 @@ func TestFormFieldSetShouldSortOnAddingNewField(t *testing.T) {
 		t.Errorf("FieldSet has fields in unexpected shape\n%v", diff)
 	}
 }
+
+func groupWithIdAndPriorityForTest(id string, priority int, groupID string) GroupParameterFormField {
+	return GroupParameterFormField{
+		ParameterFormFieldBase: ParameterFormFieldBase{
+			ID:       id,
+			Priority: priority,
+			GroupID:  groupID,
+		},
+		Children: []ParameterFormField{},
+	}
+}
+
+func fieldInGroupForTest(id string, priority int, groupID string) TextParameterFormField {
+	field := fieldWithIdAndPriorityForTest(id, priority)
+	field.GroupID = groupID
+	return field
+}
+
+func TestFormFieldSetShouldNestFieldsInGroups(t *testing.T) {
+	fsActual := NewFormFieldSetMetadata()
+	fields := []ParameterFormField{
+		fieldWithIdAndPriorityForTest("foo", 1),
+		groupWithIdAndPriorityForTest("group", 2, ""),
+		fieldInGroupForTest("group-foo", 1, "group"),
+		fieldInGroupForTest("group-bar", 3, "group"),
+		groupWithIdAndPriorityForTest("nested-group", 2, "group"),
+		fieldInGroupForTest("nested-foo", 1, "nested-group"),
+	}
+	for _, field := range fields {
+		if err := fsActual.SetField(field); err != nil {
+			t.Fatalf("SetField() returned an unexpected error: %v", err)
+		}
+	}
+
+	nestedGroup := groupWithIdAndPriorityForTest("nested-group", 2, "group")
+	nestedGroup.Children = []ParameterFormField{
+		fieldInGroupForTest("nested-foo", 1, "nested-group"),
+	}
+	group := groupWithIdAndPriorityForTest("group", 2, "")
+	group.Children = []ParameterFormField{
+		fieldInGroupForTest("group-bar", 3, "group"),
+		nestedGroup,
+		fieldInGroupForTest("group-foo", 1, "group"),
+	}
+	fsExpected := &FormFieldSetMetadata{
+		fields: []ParameterFormField{
+			group,
+			fieldWithIdAndPriorityForTest("foo", 1),
+		},
+	}
+	if diff := cmp.Diff(fsActual, fsExpected, cmp.AllowUnexported(FormFieldSetMetadata{}), cmpopts.IgnoreFields(FormFieldSetMetadata{}, "fieldsLock")); diff != "" {
+		t.Errorf("FieldSet has fields in unexpected shape\n%v", diff)
+	}
+
+	if err := fsActual.SetFieldError("nested-foo", "error message"); err != nil {
+		t.Fatalf("SetFieldError() returned an unexpected error for a nested field: %v", err)
+	}
+	wantField := fieldInGroupForTest("nested-foo", 1, "nested-group")
+	wantField.HintType = Error
+	wantField.Hint = "error message"
+	if diff := cmp.Diff(wantField, fsActual.DangerouslyGetField("nested-foo")); diff != "" {
+		t.Errorf("field mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFormFieldSetSetFieldInGroupErrors(t *testing.T) {
+	fs := NewFormFieldSetMetadata()
+	if err := fs.SetField(groupWithIdAndPriorityForTest("group", 1, "")); err != nil {
+		t.Fatalf("SetField() returned an unexpected error: %v", err)
+	}
+	if err := fs.SetField(fieldInGroupForTest("foo", 1, "group")); err != nil {
+		t.Fatalf("SetField() returned an unexpected error: %v", err)
+	}
+	if err := fs.SetField(fieldWithIdAndPriorityForTest("foo", 1)); err == nil {
+		t.Errorf("SetField() returned no error for the ID already used in a group")
+	}
+	if err := fs.SetField(fieldInGroupForTest("bar", 1, "unknown-group")); err == nil {
+		t.Errorf("SetField() returned no error for an unknown group")
+	}
+	if err := fs.SetField(fieldInGroupForTest("qux", 1, "foo")); err == nil {
+		t.Errorf("SetField() returned no error for a group ID of a non group field")
+	}
+}
+
+func TestFormFieldSetSetFieldError(t *testing.T) {
+	testCases := []struct {
+		name      string
+		field     ParameterFormField
+		messages  []string
+		wantField ParameterFormField
+	}{
+		{
+			name: "field without hint",
+			field: SetParameterFormField{
+				ParameterFormFieldBase: ParameterFormFieldBase{ID: "foo", HintType: None},
+				Default:                []string{"a"},
+			},
+			messages: []string{"error message"},
+			wantField: SetParameterFormField{
+				ParameterFormFieldBase: ParameterFormFieldBase{ID: "foo", HintType: Error, Hint: "error message"},
+				Default:                []string{"a"},
+			},
+		},
+		{
+			name: "field with a warning hint",
+			field: TextParameterFormField{
+				ParameterFormFieldBase: ParameterFormFieldBase{ID: "foo", HintType: Warning, Hint: "warning message"},
+			},
+			messages: []string{"error message"},
+			wantField: TextParameterFormField{
+				ParameterFormFieldBase: ParameterFormFieldBase{ID: "foo", HintType: Error, Hint: "error message"},
+			},
+		},
+		{
+			name: "multiple errors",
+			field: DateTimeParameterFormField{
+				ParameterFormFieldBase: ParameterFormFieldBase{ID: "foo", HintType: None},
+			},
+			messages: []string{"error message 1", "error message 2"},
+			wantField: DateTimeParameterFormField{
+				ParameterFormFieldBase: ParameterFormFieldBase{ID: "foo", HintType: Error, Hint: "error message 1\nerror message 2"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fs := NewFormFieldSetMetadata()
+			if err := fs.SetField(tc.field); err != nil {
+				t.Fatalf("SetField() returned an unexpected error: %v", err)
+			}
+			for _, message := range tc.messages {
+				if err := fs.SetFieldError("foo", message); err != nil {
+					t.Fatalf("SetFieldError() returned an unexpected error: %v", err)
+				}
+			}
+			if diff := cmp.Diff(tc.wantField, fs.DangerouslyGetField("foo")); diff != "" {
+				t.Errorf("field mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFormFieldSetSetFieldErrorWithUnknownID(t *testing.T) {
+	fs := NewFormFieldSetMetadata()
+	fs.SetField(fieldWithIdAndPriorityForTest("foo", 1))
+	if err := fs.SetFieldError("bar", "error message"); err == nil {
+		t.Errorf("SetFieldError() returned no error for an unknown field")
+	}
+}
+
+func TestFormFieldSetAddFieldHint(t *testing.T) {
+	type hint struct {
+		hintType ParameterHintType
+		message  string
+	}
+	testCases := []struct {
+		name      string
+		field     ParameterFormField
+		hints     []hint
+		wantField ParameterFormField
+	}{
+		{
+			name: "field without hint",
+			field: TimeRangeParameterFormField{
+				ParameterFormFieldBase: ParameterFormFieldBase{ID: "foo", HintType: None},
+			},
+			hints: []hint{{Info, "info message"}},
+			wantField: TimeRangeParameterFormField{
+				ParameterFormFieldBase: ParameterFormFieldBase{ID: "foo", HintType: Info, Hint: "info message"},
+			},
+		},
+		{
+			name: "more severe hint type is used",
+			field: TextParameterFormField{
+				ParameterFormFieldBase: ParameterFormFieldBase{ID: "foo", HintType: Info, Hint: "info message"},
+			},
+			hints: []hint{{Warning, "warning message"}, {Info, "another info message"}},
+			wantField: TextParameterFormField{
+				ParameterFormFieldBase: ParameterFormFieldBase{ID: "foo", HintType: Warning, Hint: "info message\nwarning message\nanother info message"},
+			},
+		},
+		{
+			name: "error hint is kept",
+			field: TextParameterFormField{
+				ParameterFormFieldBase: ParameterFormFieldBase{ID: "foo", HintType: Error, Hint: "error message"},
+			},
+			hints: []hint{{Info, "info message"}},
+			wantField: TextParameterFormField{
+				ParameterFormFieldBase: ParameterFormFieldBase{ID: "foo", HintType: Error, Hint: "error message\ninfo message"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fs := NewFormFieldSetMetadata()
+			if err := fs.SetField(tc.field); err != nil {
+				t.Fatalf("SetField() returned an unexpected error: %v", err)
+			}
+			for _, h := range tc.hints {
+				if err := fs.AddFieldHint("foo", h.hintType, h.message); err != nil {
+					t.Fatalf("AddFieldHint() returned an unexpected error: %v", err)
+				}
+			}
+			if diff := cmp.Diff(tc.wantField, fs.DangerouslyGetField("foo")); diff != "" {
+				t.Errorf("field mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFormFieldSetToSerializableRendersMarkdown(t *testing.T) {
+	fs := NewFormFieldSetMetadata()
+	markdownField := fieldWithIdAndPriorityForTest("markdown", 2)
+	markdownField.Markdown = true
+	markdownField.Description = "Use `foo`"
+	markdownField.Hint = "- <b>bar</b>"
+	plainField := fieldInGroupForTest("plain", 1, "group")
+	plainField.Description = "Use `foo`"
+	fs.SetField(markdownField)
+	fs.SetField(groupWithIdAndPriorityForTest("group", 1, ""))
+	fs.SetField(plainField)
+	nestedMarkdownField := fieldInGroupForTest("nested-markdown", 2, "group")
+	nestedMarkdownField.Markdown = true
+	nestedMarkdownField.Description = "**baz**"
+	fs.SetField(nestedMarkdownField)
+
+	serialized := fs.ToSerializable().([]ParameterFormField)
+	rendered := serialized[0].(TextParameterFormField)
+	if rendered.Description != "<p>Use <code>foo</code></p>" || rendered.Hint != "<ul><li>&lt;b&gt;bar&lt;/b&gt;</li></ul>" {
+		t.Errorf("ToSerializable() returned unexpected rendered field: description %q, hint %q", rendered.Description, rendered.Hint)
+	}
+	group := serialized[1].(GroupParameterFormField)
+	if got := group.Children[0].(TextParameterFormField).Description; got != "<p><strong>baz</strong></p>" {
+		t.Errorf("ToSerializable() returned unexpected description %q for the Markdown field in the group", got)
+	}
+	if got := group.Children[1].(TextParameterFormField).Description; got != "Use `foo`" {
+		t.Errorf("ToSerializable() returned unexpected description %q for the plain text field", got)
+	}
+	if got := fs.DangerouslyGetField("markdown").(TextParameterFormField).Description; got != "Use `foo`" {
+		t.Errorf("ToSerializable() modified the stored description to %q", got)
+	}
+}
+
+func TestSecretFieldIDs(t *testing.T) {
+	fields := []ParameterFormField{
+		TextParameterFormField{ParameterFormFieldBase: ParameterFormFieldBase{ID: "text"}},
+		SecretParameterFormField{ParameterFormFieldBase: ParameterFormFieldBase{ID: "secret1"}},
+		GroupParameterFormField{
+			ParameterFormFieldBase: ParameterFormFieldBase{ID: "group"},
+			Children: []ParameterFormField{
+				SecretParameterFormField{ParameterFormFieldBase: ParameterFormFieldBase{ID: "secret2"}},
+				TextParameterFormField{ParameterFormFieldBase: ParameterFormFieldBase{ID: "text2"}},
+			},
+		},
+	}
+	if diff := cmp.Diff([]string{"secret1", "secret2"}, SecretFieldIDs(fields)); diff != "" {
+		t.Errorf("SecretFieldIDs() returned unexpected IDs (-want +got):\n%s", diff)
+	}
+}
@@ -16,8 +16,20 @@ package inspectionmetadata
 
 var HeaderMetadataKey = NewMetadataKey[*HeaderMetadata]("header")
 var FormFieldSetMetadataKey = NewMetadataKey[*FormFieldSetMetadata]("form")
+
+// FormLayoutMetadataKey is a key to get FormLayoutMetadata from the metadata set.
+var FormLayoutMetadataKey = NewMetadataKey[*FormLayoutMetadata]("formLayout")
 var ErrorMessageSetMetadataKey = NewMetadataKey[*ErrorMessageSetMetadata]("error")
 
+// ParameterValueSetMetadataKey is a key to get ParameterValueSetMetadata from the metadata set.
+var ParameterValueSetMetadataKey = NewMetadataKey[*ParameterValueSetMetadata]("parameters")
+
+// FailedFeatureSetMetadataKey is a key to get FailedFeatureSetMetadata from the metadata set.
+var FailedFeatureSetMetadataKey = NewMetadataKey[*FailedFeatureSetMetadata]("failedFeatures")
+
+// ConfigSnapshotSetMetadataKey is a key to get ConfigSnapshotSetMetadata from the metadata set.
+var ConfigSnapshotSetMetadataKey = NewMetadataKey[*ConfigSnapshotSetMetadata]("configSnapshots")
+
 // LogMetadataKey is a key to get LogMetadata from the metadata set.
 var LogMetadataKey = NewMetadataKey[*LogMetadata]("log")
 var InspectionPlanMetadataKey = NewMetadataKey[*InspectionPlanMetadata]("plan")
@@ -26,3 +38,6 @@ var InspectionPlanMetadataKey = NewMetadataKey[*InspectionPlanMetadata]("plan")
 // from a context or metadata map.
 var ProgressMetadataKey = NewMetadataKey[*Progress]("progress")
 var QueryMetadataKey = NewMetadataKey[*QueryMetadata]("query")
+
+// TaskStatsMetadataKey is a key to get TaskStatsMetadata from the metadata set.
+var TaskStatsMetadataKey = NewMetadataKey[*TaskStatsMetadata]("taskStats")
@@ -0,0 +1,62 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inspectionmetadata
+
+import (
+	"maps"
+	"sync"
+
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+)
+
+// ParameterValueSetMetadata is a metadata type containing the form parameter values resolved by form tasks in a run.
+// The values are the raw form values in the same format as the inspection request, so they can be given to another inspection to reproduce the run.
+type ParameterValueSetMetadata struct {
+	values map[string]any
+	lock   sync.RWMutex
+}
+
+var _ Metadata = (*ParameterValueSetMetadata)(nil)
+
+// NewParameterValueSetMetadata returns an empty ParameterValueSetMetadata.
+func NewParameterValueSetMetadata() *ParameterValueSetMetadata {
+	return &ParameterValueSetMetadata{
+		values: map[string]any{},
+	}
+}
+
+// Labels implements Metadata.
+func (*ParameterValueSetMetadata) Labels() *typedmap.ReadonlyTypedMap {
+	return NewLabelSet(IncludeInRunResult())
+}
+
+// ToSerializable implements Metadata.
+func (p *ParameterValueSetMetadata) ToSerializable() interface{} {
+	return p.Values()
+}
+
+// SetValue records the resolved value of the form field with the ID.
+func (p *ParameterValueSetMetadata) SetValue(id string, value any) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.values[id] = value
+}
+
+// Values returns a copy of the recorded values keyed by form field IDs.
+func (p *ParameterValueSetMetadata) Values() map[string]any {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return maps.Clone(p.values)
+}
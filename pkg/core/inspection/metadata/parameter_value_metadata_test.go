@@ -0,0 +1,43 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inspectionmetadata
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParameterValueSetMetadataValues(t *testing.T) {
+	parameters := NewParameterValueSetMetadata()
+	parameters.SetValue("foo", "foo-value")
+	parameters.SetValue("bar", []string{"bar-value"})
+	parameters.SetValue("foo", "foo-value-2")
+
+	values := parameters.Values()
+	want := map[string]any{
+		"foo": "foo-value-2",
+		"bar": []string{"bar-value"},
+	}
+	if diff := cmp.Diff(want, values); diff != "" {
+		t.Errorf("Values() returned unexpected values (-want +got):\n%s", diff)
+	}
+
+	// The returned map must not affect the values in the metadata.
+	values["baz"] = "baz-value"
+	if _, found := parameters.Values()["baz"]; found {
+		t.Errorf("Values() returned the map shared with the metadata")
+	}
+}
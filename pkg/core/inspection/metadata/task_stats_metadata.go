@@ -0,0 +1,77 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inspectionmetadata
+
+import (
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+)
+
+// TaskStat is the performance record of a task executed in an inspection run.
+type TaskStat struct {
+	// ID is the task implementation ID.
+	ID string `json:"id"`
+	// DurationSeconds is the wall time spent to run the task including its retries.
+	DurationSeconds float64 `json:"durationSeconds"`
+	// OutputBytes is the byte size of the serialized task output estimated from sampled logs. It is 0 when the size of the output type is not measurable.
+	OutputBytes int64 `json:"outputBytes"`
+	// LogCount is the count of log entries in the task output. It is 0 when the task doesn't output logs.
+	LogCount int `json:"logCount"`
+}
+
+// TaskStatsMetadata is a metadata type containing performance records of the tasks in an inspection run.
+// This is serialized in the inspection result to analyze performance regressions on real inspections.
+type TaskStatsMetadata struct {
+	TaskStats []*TaskStat `json:"taskStats"`
+	lock      sync.Mutex
+}
+
+var _ Metadata = (*TaskStatsMetadata)(nil)
+
+// Labels implements Metadata.
+func (*TaskStatsMetadata) Labels() *typedmap.ReadonlyTypedMap {
+	return NewLabelSet(IncludeInRunResult(), IncludeInResultBinary())
+}
+
+// ToSerializable implements Metadata.
+func (t *TaskStatsMetadata) ToSerializable() interface{} {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	taskStats := slices.Clone(t.TaskStats)
+	slices.SortFunc(taskStats, func(a, b *TaskStat) int { return strings.Compare(a.ID, b.ID) })
+	return &TaskStatsMetadata{TaskStats: taskStats}
+}
+
+// SetTaskStat records the stat of a task. The existing stat of the task with the same ID is overwritten.
+func (t *TaskStatsMetadata) SetTaskStat(stat *TaskStat) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for i, taskStat := range t.TaskStats {
+		if taskStat.ID == stat.ID {
+			t.TaskStats[i] = stat
+			return
+		}
+	}
+	t.TaskStats = append(t.TaskStats, stat)
+}
+
+func NewTaskStatsMetadata() *TaskStatsMetadata {
+	return &TaskStatsMetadata{
+		TaskStats: []*TaskStat{},
+	}
+}
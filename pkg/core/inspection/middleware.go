@@ -0,0 +1,53 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coreinspection
+
+import (
+	"context"
+
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// TaskMiddleware is a pair of hooks called around every task execution in inspection runs.
+// Cross-cutting concerns like logging, metrics or redaction of parameters can be implemented once as a middleware instead of in each task.
+type TaskMiddleware struct {
+	// Before is called before the task runs. The returned context is passed to the task and the After hook.
+	// Returning an error fails the task without running it. Before can be nil.
+	Before func(ctx context.Context, taskID taskid.UntypedTaskImplementationID, mode inspectioncore_contract.InspectionTaskModeType) (context.Context, error)
+	// After is called after the task finished with its result and error. The returned error replaces the error of the task.
+	// After can be nil.
+	After func(ctx context.Context, taskID taskid.UntypedTaskImplementationID, mode inspectioncore_contract.InspectionTaskModeType, result any, err error) error
+}
+
+// interceptor returns a coretask.Interceptor calling the hooks of the middleware for tasks run in the given mode.
+func (m TaskMiddleware) interceptor(mode inspectioncore_contract.InspectionTaskModeType) coretask.Interceptor {
+	return func(ctx context.Context, task coretask.UntypedTask, next func(context.Context) (any, error)) (any, error) {
+		taskID := task.UntypedID()
+		if m.Before != nil {
+			var err error
+			ctx, err = m.Before(ctx, taskID, mode)
+			if err != nil {
+				return nil, err
+			}
+		}
+		result, err := next(ctx)
+		if m.After != nil {
+			err = m.After(ctx, taskID, mode, result, err)
+		}
+		return result, err
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coreinspection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+type middlewareTestContextKey struct{}
+
+// recordingMiddleware returns a TaskMiddleware appending the hook calls to the given slice.
+func recordingMiddleware(name string, calls *[]string) TaskMiddleware {
+	return TaskMiddleware{
+		Before: func(ctx context.Context, taskID taskid.UntypedTaskImplementationID, mode inspectioncore_contract.InspectionTaskModeType) (context.Context, error) {
+			*calls = append(*calls, fmt.Sprintf("%s-before %s %s", name, taskID, inspectioncore_contract.TaskModeToString(mode)))
+			return context.WithValue(ctx, middlewareTestContextKey{}, name), nil
+		},
+		After: func(ctx context.Context, taskID taskid.UntypedTaskImplementationID, mode inspectioncore_contract.InspectionTaskModeType, result any, err error) error {
+			*calls = append(*calls, fmt.Sprintf("%s-after %s %v %v", name, taskID, result, ctx.Value(middlewareTestContextKey{})))
+			return err
+		},
+	}
+}
+
+func runWithMiddlewares(t *testing.T, task coretask.UntypedTask, middlewares ...TaskMiddleware) error {
+	t.Helper()
+	taskSet, err := coretask.NewTaskSet([]coretask.UntypedTask{task})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	runnableTaskSet, err := taskSet.ToRunnableTaskSet()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	inspectionRunner := &InspectionTaskRunner{inspectionServer: &InspectionTaskServer{taskMiddlewares: middlewares}}
+	runner, err := inspectionRunner.newLocalRunner(runnableTaskSet, inspectioncore_contract.TaskModeRun)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := runner.Run(context.Background()); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	<-runner.Wait()
+	_, err = runner.Result()
+	return err
+}
+
+func TestTaskMiddleware_CallsHooksAroundTask(t *testing.T) {
+	calls := []string{}
+	task := coretask.NewTask(taskid.NewDefaultImplementationID[string]("foo"), nil, func(ctx context.Context) (string, error) {
+		calls = append(calls, fmt.Sprintf("run %v", ctx.Value(middlewareTestContextKey{})))
+		return "result", nil
+	})
+
+	err := runWithMiddlewares(t, task, recordingMiddleware("first", &calls), recordingMiddleware("second", &calls))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	want := []string{
+		"first-before foo#default run",
+		"second-before foo#default run",
+		"run second",
+		"second-after foo#default result second",
+		"first-after foo#default result first",
+	}
+	if diff := cmp.Diff(want, calls); diff != "" {
+		t.Errorf("hook calls mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestTaskMiddleware_BeforeError(t *testing.T) {
+	executed := false
+	task := coretask.NewTask(taskid.NewDefaultImplementationID[string]("foo"), nil, func(ctx context.Context) (string, error) {
+		executed = true
+		return "", nil
+	})
+
+	err := runWithMiddlewares(t, task, TaskMiddleware{
+		Before: func(ctx context.Context, taskID taskid.UntypedTaskImplementationID, mode inspectioncore_contract.InspectionTaskModeType) (context.Context, error) {
+			return nil, errors.New("before error")
+		},
+	})
+	if err == nil || !strings.Contains(err.Error(), "before error") {
+		t.Errorf("expected the error from the Before hook, got %v", err)
+	}
+	if executed {
+		t.Error("task must not run when the Before hook returned an error")
+	}
+}
+
+func TestTaskMiddleware_AfterReplacesError(t *testing.T) {
+	task := coretask.NewTask(taskid.NewDefaultImplementationID[string]("foo"), nil, func(ctx context.Context) (string, error) {
+		return "", errors.New("secret-token in task error")
+	})
+
+	err := runWithMiddlewares(t, task, TaskMiddleware{
+		After: func(ctx context.Context, taskID taskid.UntypedTaskImplementationID, mode inspectioncore_contract.InspectionTaskModeType, result any, err error) error {
+			if err != nil {
+				return errors.New(strings.ReplaceAll(err.Error(), "secret-token", "<redacted>"))
+			}
+			return nil
+		},
+	})
+	if err == nil || !strings.Contains(err.Error(), "<redacted> in task error") || strings.Contains(err.Error(), "secret-token") {
+		t.Errorf("expected the error replaced by the After hook, got %v", err)
+	}
+}
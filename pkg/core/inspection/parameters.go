@@ -0,0 +1,72 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coreinspection
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	"gopkg.in/yaml.v3"
+)
+
+// InspectionParameters is the set of form parameter values used in a run.
+// It is exported to a file to reproduce the inspection later or to attach the exact parameters to bug reports.
+type InspectionParameters struct {
+	// InspectionType is the ID of the inspection type the parameters were used for.
+	InspectionType string `json:"inspectionType" yaml:"inspectionType"`
+	// Values is the map of form field IDs and their values. Values of secret and file fields are not included.
+	Values map[string]any `json:"values" yaml:"values"`
+}
+
+// ExportParameters returns the form parameter values resolved by the form tasks in the run.
+// The values include the default values of fields not given in the request.
+func (i *InspectionTaskRunner) ExportParameters() (*InspectionParameters, error) {
+	i.runnerLock.Lock()
+	defer i.runnerLock.Unlock()
+	if i.runner == nil {
+		return nil, fmt.Errorf("this inspection is not yet started")
+	}
+	parameterValues, found := typedmap.Get(i.metadata, inspectionmetadata.ParameterValueSetMetadataKey)
+	if !found {
+		return nil, fmt.Errorf("parameter value set was not found in the metadata set")
+	}
+	return &InspectionParameters{
+		InspectionType: i.currentInspectionType,
+		Values:         parameterValues.Values(),
+	}, nil
+}
+
+// ParseInspectionParameters parses InspectionParameters from the exported file content in YAML or JSON.
+func ParseInspectionParameters(data []byte) (*InspectionParameters, error) {
+	var parameters InspectionParameters
+	if err := yaml.Unmarshal(data, &parameters); err != nil {
+		return nil, fmt.Errorf("failed to parse the inspection parameters\n%w", err)
+	}
+	if parameters.InspectionType == "" {
+		return nil, fmt.Errorf("inspectionType is missing in the inspection parameters")
+	}
+	if parameters.Values == nil {
+		parameters.Values = map[string]any{}
+	}
+	for key, value := range parameters.Values {
+		// Timestamps without quotes are decoded as time.Time, but form tasks receive time values as strings.
+		if t, ok := value.(time.Time); ok {
+			parameters.Values[key] = t.Format(time.RFC3339)
+		}
+	}
+	return &parameters, nil
+}
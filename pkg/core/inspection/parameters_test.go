@@ -0,0 +1,189 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coreinspection_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	coreinspection "github.com/kyasbal/khi/pkg/core/inspection"
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	"github.com/kyasbal/khi/pkg/core/inspection/logger"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	"gopkg.in/yaml.v3"
+)
+
+func TestInspectionTaskRunner_ExportParameters(t *testing.T) {
+	logger.InitGlobalKHILogger()
+	server, err := coreinspection.NewServer(&inspectioncore_contract.IOConfig{
+		TemporaryFolder: t.TempDir(),
+		DataDestination: t.TempDir(),
+	})
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	inspectionType := coreinspection.InspectionType{
+		Id:   "test-inspection",
+		Name: "Test Inspection",
+	}
+	if err := server.AddInspectionType(inspectionType); err != nil {
+		t.Fatalf("AddInspectionType failed: %v", err)
+	}
+	fooFormTaskID := taskid.NewDefaultImplementationID[string]("foo-form")
+	barFormTaskID := taskid.NewDefaultImplementationID[string]("bar-form")
+	tasks := []coretask.UntypedTask{
+		formtask.NewTextFormTaskBuilder(fooFormTaskID, 1, "foo").Build(inspectioncore_contract.InspectionTypeLabel(inspectionType.Id)),
+		formtask.NewTextFormTaskBuilder(barFormTaskID, 2, "bar").WithDefaultValueConstant("bar-default", false).Build(inspectioncore_contract.InspectionTypeLabel(inspectionType.Id)),
+		coretask.NewTask(
+			taskid.NewDefaultImplementationID[any]("feature-task"),
+			[]taskid.UntypedTaskReference{fooFormTaskID.Ref(), barFormTaskID.Ref()},
+			func(ctx context.Context) (any, error) {
+				return nil, nil
+			},
+			coretask.WithLabelValue(inspectioncore_contract.LabelKeyInspectionTypes, []string{inspectionType.Id}),
+			coretask.WithLabelValue(inspectioncore_contract.LabelKeyInspectionDefaultFeatureFlag, true),
+			coretask.WithLabelValue(inspectioncore_contract.LabelKeyInspectionFeatureFlag, true),
+			coretask.NewSubsequentTaskRefsTaskLabel(inspectioncore_contract.SerializerTaskID.Ref()),
+		),
+	}
+	for _, task := range tasks {
+		if err := server.AddTask(task); err != nil {
+			t.Fatalf("AddTask failed: %v", err)
+		}
+	}
+	inspectionID, err := server.CreateInspection(inspectionType.Id)
+	if err != nil {
+		t.Fatalf("CreateInspection failed: %v", err)
+	}
+	runner := server.GetInspection(inspectionID)
+
+	if _, err := runner.ExportParameters(); err == nil {
+		t.Errorf("ExportParameters() returned nil error before running the inspection")
+	}
+
+	err = runner.Run(context.Background(), &inspectioncore_contract.InspectionRequest{
+		Values: map[string]any{"foo-form": "foo-value"},
+	})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	<-runner.Wait()
+
+	got, err := runner.ExportParameters()
+	if err != nil {
+		t.Fatalf("ExportParameters() returned an unexpected error: %v", err)
+	}
+	want := &coreinspection.InspectionParameters{
+		InspectionType: "test-inspection",
+		Values: map[string]any{
+			"foo-form": "foo-value",
+			"bar-form": "bar-default",
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ExportParameters() returned unexpected parameters (-want +got):\n%s", diff)
+	}
+
+	// The exported parameters must be parsed back to the same parameters.
+	exported, err := yaml.Marshal(got)
+	if err != nil {
+		t.Fatalf("failed to marshal the parameters: %v", err)
+	}
+	parsed, err := coreinspection.ParseInspectionParameters(exported)
+	if err != nil {
+		t.Fatalf("ParseInspectionParameters() returned an unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(want, parsed); diff != "" {
+		t.Errorf("ParseInspectionParameters() returned unexpected parameters (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseInspectionParameters(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   string
+		want    *coreinspection.InspectionParameters
+		wantErr bool
+	}{
+		{
+			name: "yaml",
+			input: `inspectionType: foo
+values:
+  text: text-value
+  set:
+    - a
+    - b
+  time: 2025-01-01T00:00:00Z
+`,
+			want: &coreinspection.InspectionParameters{
+				InspectionType: "foo",
+				Values: map[string]any{
+					"text": "text-value",
+					"set":  []any{"a", "b"},
+					"time": "2025-01-01T00:00:00Z",
+				},
+			},
+		},
+		{
+			name:  "json",
+			input: `{"inspectionType":"foo","values":{"text":"text-value","time":"2025-01-01T00:00:00Z"}}`,
+			want: &coreinspection.InspectionParameters{
+				InspectionType: "foo",
+				Values: map[string]any{
+					"text": "text-value",
+					"time": "2025-01-01T00:00:00Z",
+				},
+			},
+		},
+		{
+			name:  "without values",
+			input: `inspectionType: foo`,
+			want: &coreinspection.InspectionParameters{
+				InspectionType: "foo",
+				Values:         map[string]any{},
+			},
+		},
+		{
+			name:    "without inspection type",
+			input:   `values: {"text": "text-value"}`,
+			wantErr: true,
+		},
+		{
+			name:    "malformed",
+			input:   `{`,
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := coreinspection.ParseInspectionParameters([]byte(tc.input))
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("ParseInspectionParameters() returned nil error, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseInspectionParameters() returned an unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ParseInspectionParameters() returned unexpected parameters (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -32,7 +32,9 @@ import (
 	"github.com/kyasbal/khi/pkg/core/inspection/logger"
 	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
 	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
 	"github.com/kyasbal/khi/pkg/lifecycle"
+	"github.com/kyasbal/khi/pkg/model/enum"
 	"github.com/kyasbal/khi/pkg/model/history"
 	"github.com/kyasbal/khi/pkg/parameters"
 	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
@@ -66,6 +68,8 @@ type InspectionTaskRunner struct {
 	inspectionCreationTime time.Time
 	interceptors           []InspectionInterceptor
 	runComplete            chan (struct{})
+	// request is the request given to the run. It is nil until the inspection is started.
+	request *inspectioncore_contract.InspectionRequest
 }
 
 // NewInspectionRunner creates a new InspectionTaskRunner.
@@ -146,6 +150,11 @@ func (i *InspectionTaskRunner) SetInspectionType(inspectionType string) error {
 	return i.SetFeatureList(defaultFeatureIds)
 }
 
+// InspectionType returns the ID of the inspection type set to this runner.
+func (i *InspectionTaskRunner) InspectionType() string {
+	return i.currentInspectionType
+}
+
 // FeatureList returns the list of available features for the current inspection type.
 func (i *InspectionTaskRunner) FeatureList() ([]FeatureListItem, error) {
 	if i.availableTasks == nil {
@@ -222,12 +231,13 @@ func (i *InspectionTaskRunner) UpdateFeatureMap(featureMap map[string]bool) erro
 // withRunContextValues returns a context with the value specific to a single run of task.
 func (i *InspectionTaskRunner) withRunContextValues(ctx context.Context, runner coretask.TaskRunner, runMode inspectioncore_contract.InspectionTaskModeType, taskInput map[string]any) (context.Context, error) {
 
-	opts := make([]RunContextOption, 0, len(i.runContextOptions)+2)
+	opts := make([]RunContextOption, 0, len(i.runContextOptions)+4)
 	opts = append(opts, i.runContextOptions...)
 	// Add option values determined for this run call.
 	opts = append(opts, RunContextOptionFromValue(inspectioncore_contract.TaskRunner, runner))
-	opts = append(opts, RunContextOptionFromValue(inspectioncore_contract.InspectionTaskInput, taskInput))
+	opts = append(opts, RunContextOptionFromValue(inspectioncore_contract.InspectionTaskInput, canonicalizeTaskInputKeys(taskInput)))
 	opts = append(opts, RunContextOptionFromValue(inspectioncore_contract.InspectionTaskMode, runMode))
+	opts = append(opts, RunContextOptionFromValue(inspectioncore_contract.InspectionSecretValues, inspectioncore_contract.NewSecretValues()))
 
 	var err error
 	for _, opt := range opts {
@@ -238,6 +248,20 @@ func (i *InspectionTaskRunner) withRunContextValues(ctx context.Context, runner
 	return ctx, nil
 }
 
+// canonicalizeTaskInputKeys returns a copy of the task input with the keys given in aliased task reference IDs replaced to their canonical IDs.
+// This keeps parameters saved before renaming a form task usable. The value given with the canonical ID takes precedence over the aliased one.
+func canonicalizeTaskInputKeys(taskInput map[string]any) map[string]any {
+	result := make(map[string]any, len(taskInput))
+	for key, value := range taskInput {
+		canonical := taskid.CanonicalReferenceID(key)
+		if _, found := result[canonical]; found && canonical != key {
+			continue
+		}
+		result[canonical] = value
+	}
+	return result
+}
+
 // Run executes the inspection. It resolves the task graph, sets up the context
 // and metadata, and starts the task runner asynchronously.
 func (i *InspectionTaskRunner) Run(ctx context.Context, req *inspectioncore_contract.InspectionRequest) error {
@@ -252,17 +276,34 @@ func (i *InspectionTaskRunner) Run(ctx context.Context, req *inspectioncore_cont
 		return err
 	}
 
-	runner, err := coretask.NewLocalRunner(runnableTaskGraph)
+	runner, err := i.newLocalRunner(runnableTaskGraph, inspectioncore_contract.TaskModeRun)
 	if err != nil {
 		return err
 	}
 	i.runner = runner
+	i.request = req
 
 	runCtx, err := i.withRunContextValues(ctx, i.runner, inspectioncore_contract.TaskModeRun, req.Values)
 	if err != nil {
 		return err
 	}
 
+	checkpointFolder := ""
+	if i.inspectionServer.checkpointFolder != "" {
+		if err := removeExpiredCheckpoints(i.inspectionServer.checkpointFolder, checkpointRetention, time.Now()); err != nil {
+			slog.WarnContext(runCtx, fmt.Sprintf("failed to remove expired checkpoints\n%v", err))
+		}
+		checkpointFolder, err = checkpointFolderForRun(i.inspectionServer.checkpointFolder, i.currentInspectionType, i.enabledFeatures, req.Values)
+		if err != nil {
+			return err
+		}
+		checkpointBackend, err := inspectioncore_contract.NewFileSystemTaskCacheBackend(checkpointFolder)
+		if err != nil {
+			return fmt.Errorf("failed to prepare the checkpoint folder %s: %w", checkpointFolder, err)
+		}
+		runCtx = khictx.WithValue[inspectioncore_contract.StreamingTaskCacheBackend](runCtx, inspectioncore_contract.InspectionCheckpointBackendContextKey, checkpointBackend)
+	}
+
 	runMetadata := i.generateMetadataForRun(runCtx, &inspectionmetadata.HeaderMetadata{
 		InspectionName:         currentInspectionType.Name,
 		InspectTimeUnixSeconds: time.Now().Unix(),
@@ -311,6 +352,8 @@ func (i *InspectionTaskRunner) Run(ctx context.Context, req *inspectioncore_cont
 			if errors.Is(cancelableCtx.Err(), context.Canceled) {
 				progress.MarkCancelled()
 				status = "cancel"
+				// A cancelled run is abandoned by the user and never resumed.
+				removeCheckpointFolder(runCtx, checkpointFolder)
 			} else {
 				progress.MarkError()
 				status = "error"
@@ -320,6 +363,9 @@ func (i *InspectionTaskRunner) Run(ctx context.Context, req *inspectioncore_cont
 			progress.MarkDone()
 			status = "done"
 
+			// Checkpoints are only needed to resume an incomplete run.
+			removeCheckpointFolder(runCtx, checkpointFolder)
+
 			history, found := typedmap.Get(result, typedmap.NewTypedKey[inspectioncore_contract.Store](inspectioncore_contract.SerializerTaskID.ReferenceIDString()))
 			if !found {
 				slog.ErrorContext(runCtx, fmt.Sprintf("Failed to get generated history after the completion\n%s", err))
@@ -338,6 +384,16 @@ func (i *InspectionTaskRunner) Run(ctx context.Context, req *inspectioncore_cont
 	return nil
 }
 
+// removeCheckpointFolder removes the checkpoints of the run. It does nothing when checkpointing is disabled.
+func removeCheckpointFolder(ctx context.Context, checkpointFolder string) {
+	if checkpointFolder == "" {
+		return
+	}
+	if err := os.RemoveAll(checkpointFolder); err != nil {
+		slog.WarnContext(ctx, fmt.Sprintf("failed to remove the checkpoint folder %s\n%v", checkpointFolder, err))
+	}
+}
+
 // Result returns the final result of a completed inspection.
 // It extracts the inspection data store and serializable metadata from the task runner's result.
 func (i *InspectionTaskRunner) Result() (*InspectionRunResult, error) {
@@ -386,7 +442,7 @@ func (i *InspectionTaskRunner) DryRun(ctx context.Context, req *inspectioncore_c
 		return nil, err
 	}
 
-	runner, err := coretask.NewLocalRunner(runnableTaskGraph)
+	runner, err := i.newLocalRunner(runnableTaskGraph, inspectioncore_contract.TaskModeDryRun)
 	if err != nil {
 		return nil, err
 	}
@@ -451,11 +507,70 @@ func (i *InspectionTaskRunner) Cancel() error {
 	return nil
 }
 
+// Request returns the request given to the run of this inspection.
+// It returns an error when the inspection hasn't started yet.
+func (i *InspectionTaskRunner) Request() (*inspectioncore_contract.InspectionRequest, error) {
+	i.runnerLock.Lock()
+	defer i.runnerLock.Unlock()
+	if i.request == nil {
+		return nil, fmt.Errorf("this inspection is not yet started")
+	}
+	return i.request, nil
+}
+
+// EnabledFeatureIDs returns the IDs of the feature tasks enabled in this inspection.
+func (i *InspectionTaskRunner) EnabledFeatureIDs() []string {
+	featureIDs := []string{}
+	for featureID, enabled := range i.enabledFeatures {
+		if enabled {
+			featureIDs = append(featureIDs, featureID)
+		}
+	}
+	slices.Sort(featureIDs)
+	return featureIDs
+}
+
 // Wait returns a channel that is closed when the inspection finishes.
 func (i *InspectionTaskRunner) Wait() <-chan struct{} {
 	return i.runComplete
 }
 
+// SecretFormFieldIDs returns the IDs of the secret form fields available in the inspection type of this inspection.
+// The IDs are determined from the labels of the form tasks without running any task.
+func (i *InspectionTaskRunner) SecretFormFieldIDs() ([]string, error) {
+	if i.availableTasks == nil {
+		return nil, fmt.Errorf("inspection type is not set to this inspection")
+	}
+	secretFormTasks := coretask.Subset(i.availableTasks, filter.NewEnabledFilter(inspectioncore_contract.TaskLabelKeyIsSecretFormTask, false))
+	result := []string{}
+	for _, task := range secretFormTasks.GetAll() {
+		result = append(result, task.UntypedID().ReferenceIDString())
+	}
+	return result, nil
+}
+
+// TaskGraph returns the runnable task graph resolved from the current inspection type and enabled features.
+// This is mainly used for debugging why a task was or wasn't scheduled.
+func (i *InspectionTaskRunner) TaskGraph() (*coretask.TaskSet, error) {
+	return i.resolveTaskGraph()
+}
+
+// TaskExecutionStates returns the execution state of each task in the current run.
+// It returns an error when the inspection hasn't started yet.
+func (i *InspectionTaskRunner) TaskExecutionStates() (*coretask.TaskExecutionDescription, error) {
+	i.runnerLock.Lock()
+	runner := i.runner
+	i.runnerLock.Unlock()
+	if runner == nil {
+		return nil, fmt.Errorf("this inspection is not yet started")
+	}
+	localRunner, ok := runner.(*coretask.LocalRunner)
+	if !ok {
+		return nil, fmt.Errorf("the task runner of this inspection doesn't support describing task execution states")
+	}
+	return localRunner.DescribeTaskExecution(), nil
+}
+
 func (i *InspectionTaskRunner) resolveTaskGraph() (*coretask.TaskSet, error) {
 	if i.featureTasks == nil || i.availableTasks == nil {
 		return nil, fmt.Errorf("this runner is not ready for resolving graph")
@@ -472,6 +587,71 @@ func (i *InspectionTaskRunner) resolveTaskGraph() (*coretask.TaskSet, error) {
 	return initialTaskSet.ToRunnableTaskSet()
 }
 
+// newLocalRunner instantiates a LocalRunner for the given task graph with the concurrency and memory limits and the task middlewares configured on the server.
+func (i *InspectionTaskRunner) newLocalRunner(taskGraph *coretask.TaskSet, mode inspectioncore_contract.InspectionTaskModeType) (*coretask.LocalRunner, error) {
+	runner, err := coretask.NewLocalRunner(taskGraph)
+	if err != nil {
+		return nil, err
+	}
+	if i.inspectionServer != nil {
+		runner.WithMaxConcurrency(i.inspectionServer.maxTaskConcurrency)
+		runner.WithMemoryLimit(i.inspectionServer.taskMemoryLimitBytes)
+		for _, middleware := range i.inspectionServer.taskMiddlewares {
+			runner.AddInterceptor(middleware.interceptor(mode))
+		}
+	}
+	runner.AddIsolatedFailureHandler(recordFailedFeature)
+	runner.AddInterceptor(recordTaskPanic)
+	if parameters.Debug.TaskStats != nil && *parameters.Debug.TaskStats {
+		runner.AddInterceptor(recordTaskStat)
+	}
+	return runner, nil
+}
+
+// recordTaskPanic is a coretask.Interceptor recording panics converted to errors by the task runner in the ErrorMessageSetMetadata with their stack traces.
+func recordTaskPanic(ctx context.Context, task coretask.UntypedTask, next func(context.Context) (any, error)) (any, error) {
+	result, err := next(ctx)
+	var panicErr *coretask.TaskPanicError
+	if !errors.As(err, &panicErr) {
+		return result, err
+	}
+	metadataSet, metadataErr := khictx.GetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
+	if metadataErr != nil {
+		return result, err
+	}
+	if errorMessageSet, found := typedmap.Get(metadataSet, inspectionmetadata.ErrorMessageSetMetadataKey); found {
+		errorMessageSet.AddErrorMessage(inspectionmetadata.NewTaskPanicErrorMessage(panicErr.TaskID, panicErr.Value, panicErr.Stack))
+	}
+	return result, err
+}
+
+// recordFailedFeature records a feature task failed without aborting the inspection in the FailedFeatureSetMetadata and the ErrorMessageSetMetadata.
+func recordFailedFeature(ctx context.Context, task coretask.UntypedTask, taskErr error) {
+	if !typedmap.GetOrDefault(task.Labels(), inspectioncore_contract.LabelKeyInspectionFeatureFlag, false) {
+		return
+	}
+	metadataSet, err := khictx.GetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
+	if err != nil {
+		slog.WarnContext(ctx, fmt.Sprintf("failed to get the metadata set to record the failed feature %s: %v", task.UntypedID(), err))
+		return
+	}
+	title := typedmap.GetOrDefault(task.Labels(), inspectioncore_contract.LabelKeyFeatureTaskTitle, "")
+	if errorMessageSet, found := typedmap.Get(metadataSet, inspectionmetadata.ErrorMessageSetMetadataKey); found {
+		errorMessageSet.AddErrorMessage(inspectionmetadata.NewFeatureFailedErrorMessage(title, taskErr.Error()))
+	}
+	failedFeatures, found := typedmap.Get(metadataSet, inspectionmetadata.FailedFeatureSetMetadataKey)
+	if !found {
+		slog.WarnContext(ctx, fmt.Sprintf("failed feature set metadata was not found to record the failed feature %s", task.UntypedID()))
+		return
+	}
+	failedFeatures.AddFailedFeature(&inspectionmetadata.FailedFeature{
+		ID:      task.UntypedID().String(),
+		Title:   title,
+		LogType: typedmap.GetOrDefault(task.Labels(), inspectioncore_contract.LabelKeyFeatureTaskTargetLogType, enum.LogTypeUnknown),
+		Error:   taskErr.Error(),
+	})
+}
+
 func (i *InspectionTaskRunner) generateMetadataForDryRun(ctx context.Context, initHeader *inspectionmetadata.HeaderMetadata, taskGraph *coretask.TaskSet) *typedmap.ReadonlyTypedMap {
 	writableMetadata := typedmap.NewTypedMap()
 	i.addCommonMetadata(ctx, writableMetadata, initHeader, taskGraph)
@@ -487,7 +667,13 @@ func (i *InspectionTaskRunner) generateMetadataForRun(ctx context.Context, initH
 func (i *InspectionTaskRunner) addCommonMetadata(ctx context.Context, writableMetadata *typedmap.TypedMap, initHeader *inspectionmetadata.HeaderMetadata, taskGraph *coretask.TaskSet) {
 	typedmap.Set(writableMetadata, inspectionmetadata.HeaderMetadataKey, initHeader)
 	typedmap.Set(writableMetadata, inspectionmetadata.ErrorMessageSetMetadataKey, inspectionmetadata.NewErrorMessageSetMetadata())
-	typedmap.Set(writableMetadata, inspectionmetadata.FormFieldSetMetadataKey, inspectionmetadata.NewFormFieldSetMetadata())
+	typedmap.Set(writableMetadata, inspectionmetadata.FailedFeatureSetMetadataKey, inspectionmetadata.NewFailedFeatureSetMetadata())
+	typedmap.Set(writableMetadata, inspectionmetadata.ConfigSnapshotSetMetadataKey, inspectionmetadata.NewConfigSnapshotSetMetadata())
+	typedmap.Set(writableMetadata, inspectionmetadata.TaskStatsMetadataKey, inspectionmetadata.NewTaskStatsMetadata())
+	formFields := inspectionmetadata.NewFormFieldSetMetadata()
+	typedmap.Set(writableMetadata, inspectionmetadata.FormFieldSetMetadataKey, formFields)
+	typedmap.Set(writableMetadata, inspectionmetadata.FormLayoutMetadataKey, inspectionmetadata.NewFormLayoutMetadata(formFields))
+	typedmap.Set(writableMetadata, inspectionmetadata.ParameterValueSetMetadataKey, inspectionmetadata.NewParameterValueSetMetadata())
 	typedmap.Set(writableMetadata, inspectionmetadata.QueryMetadataKey, inspectionmetadata.NewQueryMetadata())
 	typedmap.Set(writableMetadata, inspectionmetadata.LogMetadataKey, inspectionmetadata.NewLogMetadata())
 
@@ -533,8 +719,8 @@ func InspectionTaskLogger(logLevelForRun slog.Level, logLevelForDryRun slog.Leve
 func makeLogger(minLevel slog.Level, logBuffer *bytes.Buffer, withColor bool) slog.Handler {
 	logThrottleCount := 10 // Similar logs over logThrottleCount will be discarded
 
-	return logger.NewTeeHandler(
+	return logger.NewRedactFilter(logger.NewTeeHandler(
 		logger.NewThrottleFilter(logThrottleCount, logger.NewSeverityFilter(minLevel, logger.NewKHIFormatLogger(os.Stdout, withColor))),
 		logger.NewThrottleFilter(logThrottleCount, logger.NewSeverityFilter(minLevel, logger.NewKHIFormatLogger(logBuffer, false))),
-	)
+	))
 }
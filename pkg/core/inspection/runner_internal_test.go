@@ -0,0 +1,86 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coreinspection
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+func TestCanonicalizeTaskInputKeys(t *testing.T) {
+	err := taskid.RegisterReferenceAlias(taskid.NewTaskReference[string]("test.input.old-form"), taskid.NewTaskReference[string]("test.input.new-form"))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	err = taskid.RegisterReferenceAlias(taskid.NewTaskReference[string]("test.input.old-form2"), taskid.NewTaskReference[string]("test.input.new-form2"))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	got := canonicalizeTaskInputKeys(map[string]any{
+		"test.input.old-form":  "saved",
+		"test.input.old-form2": "saved",
+		"test.input.new-form2": "current",
+		"test.input.other":     "other",
+	})
+	want := map[string]any{
+		"test.input.new-form":  "saved",
+		"test.input.new-form2": "current",
+		"test.input.other":     "other",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("canonicalizeTaskInputKeys() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRecordTaskPanic(t *testing.T) {
+	metadataSet := typedmap.NewTypedMap()
+	errorMessageSet := inspectionmetadata.NewErrorMessageSetMetadata()
+	typedmap.Set(metadataSet, inspectionmetadata.ErrorMessageSetMetadataKey, errorMessageSet)
+	ctx := khictx.WithValue(context.Background(), inspectioncore_contract.InspectionRunMetadata, metadataSet.AsReadonly())
+	task := coretask.NewTask(taskid.NewDefaultImplementationID[any]("foo"), nil, func(ctx context.Context) (any, error) {
+		return nil, nil
+	})
+
+	for _, taskErr := range []error{
+		&coretask.TaskPanicError{TaskID: "foo#default", Value: "boom", Stack: "stack-foo"},
+		&coretask.TaskPanicError{TaskID: "bar#default", Value: "boom", Stack: "stack-bar"},
+		errors.New("not a panic"),
+	} {
+		_, err := recordTaskPanic(ctx, task, func(ctx context.Context) (any, error) {
+			return nil, taskErr
+		})
+		if err != taskErr {
+			t.Errorf("recordTaskPanic() must return the original error, got %v", err)
+		}
+	}
+
+	want := []*inspectionmetadata.ErrorMessage{
+		inspectionmetadata.NewTaskPanicErrorMessage("foo#default", "boom", "stack-foo"),
+		inspectionmetadata.NewTaskPanicErrorMessage("bar#default", "boom", "stack-bar"),
+	}
+	if diff := cmp.Diff(want, errorMessageSet.ErrorMessages); diff != "" {
+		t.Errorf("error messages mismatch (-want +got):\n%s", diff)
+	}
+}
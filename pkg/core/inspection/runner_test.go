@@ -16,13 +16,17 @@ package coreinspection_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
 	coreinspection "github.com/kyasbal/khi/pkg/core/inspection"
 	"github.com/kyasbal/khi/pkg/core/inspection/logger"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
 	coretask "github.com/kyasbal/khi/pkg/core/task"
 	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
 	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
 )
 
@@ -33,6 +37,7 @@ func TestInspectionTaskRunner_Interceptor(t *testing.T) {
 	// Setup minimal server
 	ioConfig := &inspectioncore_contract.IOConfig{
 		TemporaryFolder: t.TempDir(),
+		DataDestination: t.TempDir(),
 	}
 	server, err := coreinspection.NewServer(ioConfig)
 	if err != nil {
@@ -109,3 +114,134 @@ func TestInspectionTaskRunner_Interceptor(t *testing.T) {
 		t.Errorf("Execution order mismatch (-want +got):\n%s", diff)
 	}
 }
+
+func TestInspectionTaskRunner_FeatureFailure(t *testing.T) {
+	logger.InitGlobalKHILogger()
+
+	testCases := []struct {
+		name                 string
+		notIsolated          bool
+		wantErrorMessages    int
+		wantFailedFeatureIDs []string
+		wantResult           bool
+		// wantTaskStates is the expected states of tasks by their IDs. Tasks not in this map are not checked.
+		wantTaskStates map[string]string
+	}{
+		{
+			name:                 "feature failure is isolated by default and other features complete",
+			notIsolated:          false,
+			wantErrorMessages:    1,
+			wantFailedFeatureIDs: []string{"failing-feature#default"},
+			wantResult:           true,
+			wantTaskStates: map[string]string{
+				"failing-feature#default":                         coretask.TaskExecutionStateFailed,
+				"other-feature#default":                           coretask.TaskExecutionStateDone,
+				inspectioncore_contract.SerializerTaskID.String(): coretask.TaskExecutionStateDone,
+			},
+		},
+		{
+			name:              "feature opted out from the isolation aborts the inspection",
+			notIsolated:       true,
+			wantErrorMessages: 0,
+			wantResult:        false,
+			wantTaskStates: map[string]string{
+				"failing-feature#default": coretask.TaskExecutionStateFailed,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server, err := coreinspection.NewServer(&inspectioncore_contract.IOConfig{
+				TemporaryFolder: t.TempDir(),
+				DataDestination: t.TempDir(),
+			})
+			if err != nil {
+				t.Fatalf("NewServer failed: %v", err)
+			}
+			inspectionType := coreinspection.InspectionType{
+				Id:   "test-inspection",
+				Name: "Test Inspection",
+			}
+			if err := server.AddInspectionType(inspectionType); err != nil {
+				t.Fatalf("AddInspectionType failed: %v", err)
+			}
+
+			failingFeatureLabel := inspectioncore_contract.FeatureTaskLabel("Failing feature", "", enum.LogTypeAudit, 1, true, inspectionType.Id)
+			if tc.notIsolated {
+				failingFeatureLabel = failingFeatureLabel.WithoutFailureIsolation()
+			}
+			failingFeature := coretask.NewTask(
+				taskid.NewDefaultImplementationID[any]("failing-feature"),
+				nil,
+				func(ctx context.Context) (any, error) {
+					return nil, errors.New("test error")
+				},
+				failingFeatureLabel,
+				coretask.NewSubsequentTaskRefsTaskLabel(inspectioncore_contract.SerializerTaskID.Ref()),
+			)
+			otherFeature := coretask.NewTask(
+				taskid.NewDefaultImplementationID[any]("other-feature"),
+				nil,
+				func(ctx context.Context) (any, error) {
+					return nil, nil
+				},
+				inspectioncore_contract.FeatureTaskLabel("Other feature", "", enum.LogTypeEvent, 2, true, inspectionType.Id),
+				coretask.NewSubsequentTaskRefsTaskLabel(inspectioncore_contract.SerializerTaskID.Ref()),
+			)
+			for _, task := range []coretask.UntypedTask{failingFeature, otherFeature} {
+				if err := server.AddTask(task); err != nil {
+					t.Fatalf("AddTask failed: %v", err)
+				}
+			}
+
+			inspectionID, err := server.CreateInspection(inspectionType.Id)
+			if err != nil {
+				t.Fatalf("CreateInspection failed: %v", err)
+			}
+			runner := server.GetInspection(inspectionID)
+			if err := runner.Run(context.Background(), &inspectioncore_contract.InspectionRequest{Values: map[string]any{}}); err != nil {
+				t.Fatalf("Run failed: %v", err)
+			}
+			<-runner.Wait()
+
+			metadata, err := runner.GetCurrentMetadata()
+			if err != nil {
+				t.Fatalf("GetCurrentMetadata failed: %v", err)
+			}
+			errorMessages, _ := typedmap.Get(metadata, inspectionmetadata.ErrorMessageSetMetadataKey)
+			if len(errorMessages.ErrorMessages) != tc.wantErrorMessages {
+				t.Errorf("Expected %d error messages, got %v", tc.wantErrorMessages, errorMessages.ErrorMessages)
+			}
+			failedFeatures, _ := typedmap.Get(metadata, inspectionmetadata.FailedFeatureSetMetadataKey)
+			var gotFailedFeatureIDs []string
+			for _, feature := range failedFeatures.FailedFeatures {
+				gotFailedFeatureIDs = append(gotFailedFeatureIDs, feature.ID)
+			}
+			if diff := cmp.Diff(tc.wantFailedFeatureIDs, gotFailedFeatureIDs); diff != "" {
+				t.Errorf("Failed features mismatch (-want +got):\n%s", diff)
+			}
+
+			_, err = runner.Result()
+			if tc.wantResult && err != nil {
+				t.Errorf("Expected the inspection result to be available, got error %v", err)
+			}
+			if !tc.wantResult && err == nil {
+				t.Errorf("Expected the inspection to be aborted without a result")
+			}
+
+			states, err := runner.TaskExecutionStates()
+			if err != nil {
+				t.Fatalf("TaskExecutionStates failed: %v", err)
+			}
+			gotTaskStates := map[string]string{}
+			for _, task := range states.Tasks {
+				if _, found := tc.wantTaskStates[task.ID]; found {
+					gotTaskStates[task.ID] = task.State
+				}
+			}
+			if diff := cmp.Diff(tc.wantTaskStates, gotTaskStates); diff != "" {
+				t.Errorf("Task states mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
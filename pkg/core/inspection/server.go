@@ -15,11 +15,16 @@
 package coreinspection
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/kyasbal/khi/pkg/common/idgenerator"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
 	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
 	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
 	inspectioncore_impl "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/impl"
 	"golang.org/x/exp/slices"
@@ -64,12 +69,26 @@ type InspectionTaskServer struct {
 	inspectionTypes []*InspectionType
 	// inspections are generated inspection task runers
 	inspections           map[string]*InspectionTaskRunner
+	inspectionsLock       sync.RWMutex
 	inspectionIDGenerator idgenerator.IDGenerator
+	// followers are the followers re-running inspections with the latest time range. The keys are the IDs of the followed inspections.
+	followers     map[string]*InspectionFollower
+	followersLock sync.Mutex
 
 	ioConfig *inspectioncore_contract.IOConfig
 
 	runContextOptions      []RunContextOption
 	inspectionIntercepters []InspectionInterceptor
+	// taskMiddlewares are called around every task execution in inspections. The first middleware is the outer-most one.
+	taskMiddlewares []TaskMiddleware
+	// maxTaskConcurrency is the maximum number of tasks running at the same time in an inspection. 0 means unlimited.
+	maxTaskConcurrency int
+	// taskMemoryLimitBytes is the heap usage over which memory heavy tasks are delayed in an inspection. 0 means unlimited.
+	taskMemoryLimitBytes uint64
+	// checkpointFolder is the folder to store checkpoints of inspection runs. Checkpointing is disabled when this is empty.
+	checkpointFolder string
+	// taskCacheBackend is the backend persisting results of cached tasks. It is nil when the results are kept only in memory.
+	taskCacheBackend inspectioncore_contract.TaskCacheBackend
 }
 
 func NewServer(ioConfig *inspectioncore_contract.IOConfig) (*InspectionTaskServer, error) {
@@ -81,6 +100,7 @@ func NewServer(ioConfig *inspectioncore_contract.IOConfig) (*InspectionTaskServe
 		RootTaskSet:           ns,
 		inspectionTypes:       make([]*InspectionType, 0),
 		inspections:           map[string]*InspectionTaskRunner{},
+		followers:             map[string]*InspectionFollower{},
 		inspectionIDGenerator: idgenerator.NewPrefixIDGenerator("inspection-"),
 		ioConfig:              ioConfig,
 	}
@@ -122,6 +142,68 @@ func (s *InspectionTaskServer) AddInspectionInterceptor(interceptor InspectionIn
 	s.inspectionIntercepters = append(s.inspectionIntercepters, interceptor)
 }
 
+// AddTaskMiddleware adds a middleware called around every task execution of all new inspection runs.
+// Middlewares are called in the order they are added. The first middleware is the outer-most one.
+func (s *InspectionTaskServer) AddTaskMiddleware(middleware TaskMiddleware) {
+	s.taskMiddlewares = append(s.taskMiddlewares, middleware)
+}
+
+// SetMaxTaskConcurrency sets the maximum number of tasks running at the same time in each inspection run.
+// A value less than or equal to 0 means unlimited.
+func (s *InspectionTaskServer) SetMaxTaskConcurrency(maxConcurrency int) {
+	s.maxTaskConcurrency = maxConcurrency
+}
+
+// SetTaskMemoryLimit sets the heap usage in bytes over which memory heavy tasks wait for other heavy tasks to finish before starting.
+// A value of 0 means unlimited.
+func (s *InspectionTaskServer) SetTaskMemoryLimit(limitBytes uint64) {
+	s.taskMemoryLimitBytes = limitBytes
+}
+
+// SetCheckpointFolder sets the folder to store checkpoints of the logs queried in each inspection run.
+// An inspection run with the same request as the crashed run reuses the queried logs, but the later stages like parsing run again.
+// Checkpoints of incomplete runs are removed after checkpointRetention. An empty string disables checkpointing.
+func (s *InspectionTaskServer) SetCheckpointFolder(folder string) {
+	s.checkpointFolder = folder
+}
+
+// SetTaskCacheBackend sets the backend to persist results of cached tasks in inspection runs.
+func (s *InspectionTaskServer) SetTaskCacheBackend(backend inspectioncore_contract.TaskCacheBackend) {
+	s.taskCacheBackend = backend
+	s.AddRunContextOption(RunContextOptionFromValue(inspectioncore_contract.TaskCacheBackendContextKey, backend))
+}
+
+// ErrInvalidTaskCacheTarget is returned from InvalidateTaskCache when any of the given IDs is malformed.
+var ErrInvalidTaskCacheTarget = errors.New("invalid task cache invalidation target")
+
+// InvalidateTaskCache evicts the results reused by cached tasks without restarting the server.
+// Each of referenceIDs evicts results of all registered implementations of the task reference, and each of implementationIDs given as "referenceID#implementationHash" evicts results of the implementation.
+// The results are removed from the task cache backend as well not to be reused after the server restarted.
+// It returns an error wrapping ErrInvalidTaskCacheTarget without invalidating anything when any of the given IDs is malformed.
+func (s *InspectionTaskServer) InvalidateTaskCache(ctx context.Context, referenceIDs []string, implementationIDs []string) error {
+	references := map[string]struct{}{}
+	for _, referenceID := range referenceIDs {
+		if referenceID == "" || strings.Contains(referenceID, "#") {
+			return fmt.Errorf("%w: task reference id %q must be a non empty string without '#'", ErrInvalidTaskCacheTarget, referenceID)
+		}
+		references[referenceID] = struct{}{}
+	}
+	implementations := make([]taskid.UntypedTaskImplementationID, 0, len(implementationIDs))
+	for _, implementationID := range implementationIDs {
+		referenceID, implementationHash, found := strings.Cut(implementationID, "#")
+		if !found || referenceID == "" || implementationHash == "" || strings.Contains(implementationHash, "#") {
+			return fmt.Errorf("%w: task implementation id %q must be in the form of referenceID#implementationHash", ErrInvalidTaskCacheTarget, implementationID)
+		}
+		implementations = append(implementations, taskid.NewImplementationID(taskid.NewTaskReference[any](referenceID), implementationHash))
+	}
+	for _, task := range s.GetAllRegisteredTasks() {
+		if _, found := references[task.UntypedID().ReferenceIDString()]; found {
+			implementations = append(implementations, task.UntypedID())
+		}
+	}
+	return inspectiontaskbase.EvictCachedTaskResults(ctx, inspectionRunnerGlobalSharedMap, s.taskCacheBackend, implementations)
+}
+
 // CreateInspection generates an inspection and returns inspection ID
 func (s *InspectionTaskServer) CreateInspection(inspectionType string) (string, error) {
 	id := s.inspectionIDGenerator.Generate()
@@ -131,15 +213,26 @@ func (s *InspectionTaskServer) CreateInspection(inspectionType string) (string,
 	if err != nil {
 		return "", err
 	}
+	s.inspectionsLock.Lock()
+	defer s.inspectionsLock.Unlock()
 	s.inspections[inspectionRunner.ID] = inspectionRunner
 	return inspectionRunner.ID, nil
 }
 
 // Inspection returns an instance of an Inspection queried with given inspection ID.
 func (s *InspectionTaskServer) GetInspection(inspectionID string) *InspectionTaskRunner {
+	s.inspectionsLock.RLock()
+	defer s.inspectionsLock.RUnlock()
 	return s.inspections[inspectionID]
 }
 
+// removeInspection removes the inspection from the server. The inspection must not be running.
+func (s *InspectionTaskServer) removeInspection(inspectionID string) {
+	s.inspectionsLock.Lock()
+	defer s.inspectionsLock.Unlock()
+	delete(s.inspections, inspectionID)
+}
+
 func (s *InspectionTaskServer) GetAllInspectionTypes() []*InspectionType {
 	return append([]*InspectionType{}, s.inspectionTypes...)
 }
@@ -154,6 +247,8 @@ func (s *InspectionTaskServer) GetInspectionType(inspectionTypeId string) *Inspe
 }
 
 func (s *InspectionTaskServer) GetAllRunners() []*InspectionTaskRunner {
+	s.inspectionsLock.RLock()
+	defer s.inspectionsLock.RUnlock()
 	inspections := []*InspectionTaskRunner{}
 	for _, value := range s.inspections {
 		inspections = append(inspections, value)
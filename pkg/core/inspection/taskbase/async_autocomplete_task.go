@@ -0,0 +1,133 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inspectiontaskbase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// AsyncAutocompleteDigestFunc returns a string representation of the inputs used by AsyncAutocompleteFetcher.
+type AsyncAutocompleteDigestFunc = func(ctx context.Context) (string, error)
+
+// AsyncAutocompleteFetcher fetches the autocomplete candidates. The given context is cancelled when the inputs are changed or the fetch timeout is reached.
+type AsyncAutocompleteFetcher[T any] = func(ctx context.Context) (*inspectioncore_contract.AutocompleteResult[T], error)
+
+// asyncAutocompleteState is the state of an async autocomplete task shared among runs.
+type asyncAutocompleteState[T any] struct {
+	mu sync.Mutex
+	// fetchingDigest is the digest of the inputs used in the latest fetch.
+	fetchingDigest string
+	// fetchDone is closed when the latest fetch finished.
+	fetchDone chan struct{}
+	// cancelFetch cancels the latest fetch.
+	cancelFetch context.CancelFunc
+	// resultDigest is the digest of the inputs used to fetch the result.
+	resultDigest string
+	result       *inspectioncore_contract.AutocompleteResult[T]
+}
+
+// NewAsyncAutocompleteTask generates a task returning autocomplete candidates without blocking the dry run on slow APIs.
+// The fetcher is called in background with fetchTimeout when the digest of its inputs is changed, and the task waits for it at most waitTimeout.
+// When the fetch isn't finished in time, the task returns the candidates fetched last time with Loading set to true. The fetched result is returned from the later runs with the same digest.
+func NewAsyncAutocompleteTask[T any](taskID taskid.TaskImplementationID[*inspectioncore_contract.AutocompleteResult[T]], dependencies []taskid.UntypedTaskReference, waitTimeout time.Duration, fetchTimeout time.Duration, digestFunc AsyncAutocompleteDigestFunc, fetcher AsyncAutocompleteFetcher[T], labelOpt ...coretask.LabelOpt) coretask.Task[*inspectioncore_contract.AutocompleteResult[T]] {
+	return coretask.NewTask(taskID, dependencies, func(ctx context.Context) (*inspectioncore_contract.AutocompleteResult[T], error) {
+		globalSharedMap := khictx.MustGetValue(ctx, inspectioncore_contract.GlobalSharedMap)
+		stateKey := typedmap.NewTypedKey[*asyncAutocompleteState[T]](asyncAutocompleteStateKeyForTask(taskID))
+		state := typedmap.GetOrSetFunc(globalSharedMap, stateKey, func() *asyncAutocompleteState[T] {
+			return &asyncAutocompleteState[T]{}
+		})
+
+		digest, err := digestFunc(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute the digest for task `%s`\n%v", taskID, err)
+		}
+
+		fetchDone := state.startFetchIfNeeded(ctx, digest, fetchTimeout, fetcher)
+		timer := time.NewTimer(waitTimeout)
+		defer timer.Stop()
+		select {
+		case <-fetchDone:
+		case <-timer.C:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		return state.resultFor(digest), nil
+	}, labelOpt...)
+}
+
+// startFetchIfNeeded starts fetching the candidates for the digest when no fetch was started for the digest.
+// The running fetch for the other digest is cancelled. Returns the channel closed when the fetch for the digest finished.
+func (s *asyncAutocompleteState[T]) startFetchIfNeeded(ctx context.Context, digest string, fetchTimeout time.Duration, fetcher AsyncAutocompleteFetcher[T]) <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fetchDone != nil && s.fetchingDigest == digest {
+		return s.fetchDone
+	}
+	if s.cancelFetch != nil {
+		s.cancelFetch()
+	}
+	// The fetch must outlive the current task run, so only the values are inherited from the task context.
+	fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), fetchTimeout)
+	fetchDone := make(chan struct{})
+	s.fetchingDigest = digest
+	s.fetchDone = fetchDone
+	s.cancelFetch = cancel
+	go func() {
+		defer close(fetchDone)
+		defer cancel()
+		result, err := fetcher(fetchCtx)
+		if err != nil {
+			result = &inspectioncore_contract.AutocompleteResult[T]{
+				Values: []T{},
+				Error:  err.Error(),
+			}
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.fetchingDigest != digest {
+			return
+		}
+		s.resultDigest = digest
+		s.result = result
+	}()
+	return fetchDone
+}
+
+// resultFor returns the fetched result for the digest, or the result fetched last time marked as loading when the fetch for the digest isn't finished.
+func (s *asyncAutocompleteState[T]) resultFor(digest string) *inspectioncore_contract.AutocompleteResult[T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.result != nil && s.resultDigest == digest {
+		result := *s.result
+		return &result
+	}
+	result := &inspectioncore_contract.AutocompleteResult[T]{
+		Values:  []T{},
+		Loading: true,
+	}
+	if s.result != nil {
+		result.Values = s.result.Values
+	}
+	return result
+}
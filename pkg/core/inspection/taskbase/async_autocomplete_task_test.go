@@ -0,0 +1,163 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inspectiontaskbase
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+func runAsyncAutocompleteTaskForTest(t *testing.T, ctx context.Context, task coretask.Task[*inspectioncore_contract.AutocompleteResult[string]]) *inspectioncore_contract.AutocompleteResult[string] {
+	t.Helper()
+	result, _, err := inspectiontest.RunInspectionTask(ctx, task, inspectioncore_contract.TaskModeDryRun, map[string]any{})
+	if err != nil {
+		t.Fatalf("unexpected task error result %v", err)
+	}
+	return result
+}
+
+// waitAsyncAutocompleteResult runs the task until it returns a result not loading.
+func waitAsyncAutocompleteResult(t *testing.T, ctx context.Context, task coretask.Task[*inspectioncore_contract.AutocompleteResult[string]]) *inspectioncore_contract.AutocompleteResult[string] {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		result := runAsyncAutocompleteTaskForTest(t, ctx, task)
+		if !result.Loading {
+			return result
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("the task kept returning the loading result")
+	return nil
+}
+
+func TestAsyncAutocompleteTask_ReturnsResultFetchedInTime(t *testing.T) {
+	testTaskID := taskid.NewDefaultImplementationID[*inspectioncore_contract.AutocompleteResult[string]]("foo")
+	task := NewAsyncAutocompleteTask(testTaskID, []taskid.UntypedTaskReference{}, 5*time.Second, 5*time.Second, func(ctx context.Context) (string, error) {
+		return "digest", nil
+	}, func(ctx context.Context) (*inspectioncore_contract.AutocompleteResult[string], error) {
+		return &inspectioncore_contract.AutocompleteResult[string]{Values: []string{"foo", "bar"}}, nil
+	})
+
+	ctx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+	result := runAsyncAutocompleteTaskForTest(t, ctx, task)
+	if diff := cmp.Diff(&inspectioncore_contract.AutocompleteResult[string]{Values: []string{"foo", "bar"}}, result); diff != "" {
+		t.Errorf("unexpected result (-want +got):\n%s", diff)
+	}
+}
+
+func TestAsyncAutocompleteTask_ReturnsLoadingResultWhenFetchIsSlow(t *testing.T) {
+	testTaskID := taskid.NewDefaultImplementationID[*inspectioncore_contract.AutocompleteResult[string]]("foo")
+	var digest atomic.Value
+	digest.Store("first")
+	var fetchCount atomic.Int32
+	releaseFetch := make(chan struct{})
+	task := NewAsyncAutocompleteTask(testTaskID, []taskid.UntypedTaskReference{}, time.Millisecond, 5*time.Second, func(ctx context.Context) (string, error) {
+		return digest.Load().(string), nil
+	}, func(ctx context.Context) (*inspectioncore_contract.AutocompleteResult[string], error) {
+		fetchCount.Add(1)
+		<-releaseFetch
+		return &inspectioncore_contract.AutocompleteResult[string]{Values: []string{digest.Load().(string)}}, nil
+	})
+
+	ctx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+	result := runAsyncAutocompleteTaskForTest(t, ctx, task)
+	if diff := cmp.Diff(&inspectioncore_contract.AutocompleteResult[string]{Values: []string{}, Loading: true}, result); diff != "" {
+		t.Errorf("unexpected result before fetching (-want +got):\n%s", diff)
+	}
+	runAsyncAutocompleteTaskForTest(t, ctx, task)
+	if count := fetchCount.Load(); count != 1 {
+		t.Errorf("fetcher was called %d times for the same digest, want 1", count)
+	}
+
+	close(releaseFetch)
+	result = waitAsyncAutocompleteResult(t, ctx, task)
+	if diff := cmp.Diff(&inspectioncore_contract.AutocompleteResult[string]{Values: []string{"first"}}, result); diff != "" {
+		t.Errorf("unexpected result after fetching (-want +got):\n%s", diff)
+	}
+
+	// The values fetched last time are returned while fetching for the new digest.
+	digest.Store("second")
+	result = runAsyncAutocompleteTaskForTest(t, ctx, task)
+	if result.Loading {
+		if diff := cmp.Diff(&inspectioncore_contract.AutocompleteResult[string]{Values: []string{"first"}, Loading: true}, result); diff != "" {
+			t.Errorf("unexpected result while fetching (-want +got):\n%s", diff)
+		}
+	}
+	result = waitAsyncAutocompleteResult(t, ctx, task)
+	if diff := cmp.Diff(&inspectioncore_contract.AutocompleteResult[string]{Values: []string{"second"}}, result); diff != "" {
+		t.Errorf("unexpected result after fetching for the new digest (-want +got):\n%s", diff)
+	}
+}
+
+func TestAsyncAutocompleteTask_CancelsStaleFetch(t *testing.T) {
+	testTaskID := taskid.NewDefaultImplementationID[*inspectioncore_contract.AutocompleteResult[string]]("foo")
+	var digest atomic.Value
+	digest.Store("first")
+	var fetchCount atomic.Int32
+	firstFetchErr := make(chan error, 1)
+	task := NewAsyncAutocompleteTask(testTaskID, []taskid.UntypedTaskReference{}, time.Millisecond, 5*time.Second, func(ctx context.Context) (string, error) {
+		return digest.Load().(string), nil
+	}, func(ctx context.Context) (*inspectioncore_contract.AutocompleteResult[string], error) {
+		if fetchCount.Add(1) == 1 {
+			<-ctx.Done()
+			firstFetchErr <- ctx.Err()
+			return nil, ctx.Err()
+		}
+		return &inspectioncore_contract.AutocompleteResult[string]{Values: []string{"second"}}, nil
+	})
+
+	ctx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+	runAsyncAutocompleteTaskForTest(t, ctx, task)
+	digest.Store("second")
+	result := waitAsyncAutocompleteResult(t, ctx, task)
+
+	select {
+	case err := <-firstFetchErr:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("the stale fetch ended with %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the stale fetch was not cancelled")
+	}
+	if diff := cmp.Diff(&inspectioncore_contract.AutocompleteResult[string]{Values: []string{"second"}}, result); diff != "" {
+		t.Errorf("unexpected result (-want +got):\n%s", diff)
+	}
+}
+
+func TestAsyncAutocompleteTask_ReturnsFetchErrorInResult(t *testing.T) {
+	testTaskID := taskid.NewDefaultImplementationID[*inspectioncore_contract.AutocompleteResult[string]]("foo")
+	task := NewAsyncAutocompleteTask(testTaskID, []taskid.UntypedTaskReference{}, time.Millisecond, time.Millisecond, func(ctx context.Context) (string, error) {
+		return "digest", nil
+	}, func(ctx context.Context) (*inspectioncore_contract.AutocompleteResult[string], error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	ctx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+	result := waitAsyncAutocompleteResult(t, ctx, task)
+	if diff := cmp.Diff(&inspectioncore_contract.AutocompleteResult[string]{Values: []string{}, Error: context.DeadlineExceeded.Error()}, result); diff != "" {
+		t.Errorf("unexpected result (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inspectiontaskbase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// EvictCachedTaskResults removes the results of the given cached task implementations from the shared map and the backend.
+// The next run of these tasks receives an empty previous value and computes its value again. The persisted results are removed from the backend, thus the eviction is kept after the server restarted.
+// The backend can be nil when the cached results are not persisted.
+func EvictCachedTaskResults(ctx context.Context, sharedMap *typedmap.TypedMap, backend inspectioncore_contract.TaskCacheBackend, ids []taskid.UntypedTaskImplementationID) error {
+	errs := []error{}
+	for _, id := range ids {
+		key := cacheKeyForTask(id)
+		typedmap.Delete(sharedMap, typedmap.NewTypedKey[any](key))
+		typedmap.Delete(sharedMap, typedmap.NewTypedKey[any](asyncAutocompleteStateKeyForTask(id)))
+		if backend == nil {
+			continue
+		}
+		if err := backend.Delete(ctx, key); err != nil {
+			errs = append(errs, fmt.Errorf("failed to evict the persisted cache of task %s: %w", id.String(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// cacheKeyForTask returns the key to store the cached result of the given task.
+func cacheKeyForTask(id taskid.UntypedTaskImplementationID) string {
+	return fmt.Sprintf("cached_result-%s", id.String())
+}
+
+// asyncAutocompleteStateKeyForTask returns the key to store the state of the given async autocomplete task.
+func asyncAutocompleteStateKeyForTask(id taskid.UntypedTaskImplementationID) string {
+	return fmt.Sprintf("async-%s", cacheKeyForTask(id))
+}
@@ -16,13 +16,17 @@ package inspectiontaskbase
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"log/slog"
 
 	"github.com/kyasbal/khi/pkg/common/khictx"
 	"github.com/kyasbal/khi/pkg/common/typedmap"
 	coretask "github.com/kyasbal/khi/pkg/core/task"
 	"github.com/kyasbal/khi/pkg/core/task/taskid"
 	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // CacheableTaskResult is the combination of the cached value and a digest of its dependency.
@@ -36,20 +40,85 @@ type CacheableTaskResult[T any] struct {
 
 // NewCachedTask generates a task which can reuse the value last time.
 func NewCachedTask[T any](taskID taskid.TaskImplementationID[T], depdendencies []taskid.UntypedTaskReference, f func(ctx context.Context, prevValue CacheableTaskResult[T]) (CacheableTaskResult[T], error), labelOpt ...coretask.LabelOpt) coretask.Task[T] {
+	return newCachedTask(taskID, depdendencies, f, false, labelOpt...)
+}
+
+// NewPersistentCachedTask generates a task which can reuse the value last time even after the server restarted.
+// The cached value is also stored in the TaskCacheBackend provided in the context when it's available.
+// The value type must be serializable as JSON.
+func NewPersistentCachedTask[T any](taskID taskid.TaskImplementationID[T], depdendencies []taskid.UntypedTaskReference, f func(ctx context.Context, prevValue CacheableTaskResult[T]) (CacheableTaskResult[T], error), labelOpt ...coretask.LabelOpt) coretask.Task[T] {
+	return newCachedTask(taskID, depdendencies, f, true, labelOpt...)
+}
+
+func newCachedTask[T any](taskID taskid.TaskImplementationID[T], depdendencies []taskid.UntypedTaskReference, f func(ctx context.Context, prevValue CacheableTaskResult[T]) (CacheableTaskResult[T], error), persistent bool, labelOpt ...coretask.LabelOpt) coretask.Task[T] {
 	return coretask.NewTask(taskID, depdendencies, func(ctx context.Context) (T, error) {
 		inspectionSharedMap := khictx.MustGetValue(ctx, inspectioncore_contract.GlobalSharedMap)
-		cacheKey := typedmap.NewTypedKey[CacheableTaskResult[T]](fmt.Sprintf("cached_result-%s", taskID.String()))
-		cachedResult := typedmap.GetOrDefault(inspectionSharedMap, cacheKey, CacheableTaskResult[T]{
-			Value:            *new(T),
-			DependencyDigest: "",
-		})
+		cacheKeyStr := cacheKeyForTask(taskID)
+		cacheKey := typedmap.NewTypedKey[CacheableTaskResult[T]](cacheKeyStr)
+		cachedResult, found := typedmap.Get(inspectionSharedMap, cacheKey)
+		if !found {
+			cachedResult = CacheableTaskResult[T]{
+				Value:            *new(T),
+				DependencyDigest: "",
+			}
+		}
+
+		var backend inspectioncore_contract.TaskCacheBackend
+		if persistent {
+			backend, _ = khictx.GetValue(ctx, inspectioncore_contract.TaskCacheBackendContextKey)
+		}
+		if backend != nil && !found {
+			if persisted, found := loadPersistedCacheableTaskResult[T](ctx, backend, cacheKeyStr); found {
+				cachedResult = persisted
+			}
+		}
 
 		nextCache, err := f(ctx, cachedResult)
 		if err != nil {
 			return *new(T), err
 		}
 
+		cacheHit := cachedResult.DependencyDigest != "" && nextCache.DependencyDigest == cachedResult.DependencyDigest
+		coretask.RecordCacheHit(ctx, cacheHit)
+		trace.SpanFromContext(ctx).SetAttributes(
+			attribute.Bool("cache_hit", cacheHit),
+		)
+
 		typedmap.Set(inspectionSharedMap, cacheKey, nextCache)
+		if backend != nil && nextCache.DependencyDigest != cachedResult.DependencyDigest {
+			storePersistedCacheableTaskResult(ctx, backend, cacheKeyStr, nextCache)
+		}
 		return nextCache.Value, nil
 	}, labelOpt...)
 }
+
+// loadPersistedCacheableTaskResult reads the cached result from the backend.
+// Failures on reading the cache are only logged because the task can still compute the value without the cache.
+func loadPersistedCacheableTaskResult[T any](ctx context.Context, backend inspectioncore_contract.TaskCacheBackend, key string) (CacheableTaskResult[T], bool) {
+	serialized, found, err := backend.Get(ctx, key)
+	if err != nil {
+		slog.WarnContext(ctx, fmt.Sprintf("failed to read the persisted cache %s\n%v", key, err))
+		return CacheableTaskResult[T]{}, false
+	}
+	if !found {
+		return CacheableTaskResult[T]{}, false
+	}
+	var result CacheableTaskResult[T]
+	if err := json.Unmarshal(serialized, &result); err != nil {
+		slog.WarnContext(ctx, fmt.Sprintf("failed to deserialize the persisted cache %s\n%v", key, err))
+		return CacheableTaskResult[T]{}, false
+	}
+	return result, true
+}
+
+// storePersistedCacheableTaskResult writes the cached result to the backend.
+func storePersistedCacheableTaskResult[T any](ctx context.Context, backend inspectioncore_contract.TaskCacheBackend, key string, result CacheableTaskResult[T]) {
+	serialized, err := json.Marshal(result)
+	if err != nil {
+		slog.WarnContext(ctx, fmt.Sprintf("failed to serialize the cache %s\n%v", key, err))
+		return
+	}
+	if err := backend.Set(ctx, key, serialized); err != nil {
+		slog.WarnContext(ctx, fmt.Sprintf("failed to persist the cache %s\n%v", key, err))
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/common/khictx"
 	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
 	"github.com/kyasbal/khi/pkg/core/task/taskid"
 	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
@@ -58,3 +59,117 @@ func TestCachedTask(t *testing.T) {
 		t.Errorf("unexpected prevValues (-want +got):\n%s", diff)
 	}
 }
+
+func TestPersistentCachedTask(t *testing.T) {
+	backend, err := inspectioncore_contract.NewFileSystemTaskCacheBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create the cache backend: %v", err)
+	}
+	prevValues := []CacheableTaskResult[[]string]{}
+	testTaskID := taskid.NewDefaultImplementationID[[]string]("foo")
+	task := NewPersistentCachedTask(testTaskID, []taskid.UntypedTaskReference{}, func(ctx context.Context, prevValue CacheableTaskResult[[]string]) (CacheableTaskResult[[]string], error) {
+		prevValues = append(prevValues, prevValue)
+		return CacheableTaskResult[[]string]{
+			Value:            []string{"foo", "bar"},
+			DependencyDigest: "foo",
+		}, nil
+	})
+
+	for i := 0; i < 2; i++ {
+		// Each context has its own GlobalSharedMap to simulate server restarts.
+		ctx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+		ctx = khictx.WithValue(ctx, inspectioncore_contract.TaskCacheBackendContextKey, inspectioncore_contract.TaskCacheBackend(backend))
+		_, _, err := inspectiontest.RunInspectionTask(ctx, task, inspectioncore_contract.TaskModeRun, map[string]any{})
+		if err != nil {
+			t.Errorf("unexpected task error result %v", err)
+		}
+	}
+
+	if diff := cmp.Diff(prevValues, []CacheableTaskResult[[]string]{
+		{
+			Value:            nil,
+			DependencyDigest: "",
+		},
+		{
+			Value:            []string{"foo", "bar"},
+			DependencyDigest: "foo",
+		},
+	}); diff != "" {
+		t.Errorf("unexpected prevValues (-want +got):\n%s", diff)
+	}
+}
+
+func TestPersistentCachedTaskWithoutBackend(t *testing.T) {
+	testTaskID := taskid.NewDefaultImplementationID[string]("foo")
+	runCount := 0
+	task := NewPersistentCachedTask(testTaskID, []taskid.UntypedTaskReference{}, func(ctx context.Context, prevValue CacheableTaskResult[string]) (CacheableTaskResult[string], error) {
+		runCount++
+		if prevValue.DependencyDigest != "" {
+			t.Errorf("unexpected cached value %v", prevValue)
+		}
+		return CacheableTaskResult[string]{
+			Value:            "foo",
+			DependencyDigest: "foo",
+		}, nil
+	})
+	for i := 0; i < 2; i++ {
+		ctx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+		_, _, err := inspectiontest.RunInspectionTask(ctx, task, inspectioncore_contract.TaskModeRun, map[string]any{})
+		if err != nil {
+			t.Errorf("unexpected task error result %v", err)
+		}
+	}
+	if runCount != 2 {
+		t.Errorf("got %d runs, want 2", runCount)
+	}
+}
+
+func TestEvictCachedTaskResults(t *testing.T) {
+	backend, err := inspectioncore_contract.NewFileSystemTaskCacheBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create the cache backend: %v", err)
+	}
+	testTaskID := taskid.NewImplementationID(taskid.NewTaskReference[string]("cache-invalidation-test"), "foo")
+	prevDigests := []string{}
+	task := NewPersistentCachedTask(testTaskID, []taskid.UntypedTaskReference{}, func(ctx context.Context, prevValue CacheableTaskResult[string]) (CacheableTaskResult[string], error) {
+		prevDigests = append(prevDigests, prevValue.DependencyDigest)
+		return CacheableTaskResult[string]{
+			Value:            "foo",
+			DependencyDigest: "foo",
+		}, nil
+	})
+
+	newContext := func() context.Context {
+		// Each context has its own GlobalSharedMap to simulate server restarts.
+		ctx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+		return khictx.WithValue(ctx, inspectioncore_contract.TaskCacheBackendContextKey, inspectioncore_contract.TaskCacheBackend(backend))
+	}
+	evict := func(ctx context.Context) {
+		sharedMap := khictx.MustGetValue(ctx, inspectioncore_contract.GlobalSharedMap)
+		if err := EvictCachedTaskResults(ctx, sharedMap, backend, []taskid.UntypedTaskImplementationID{testTaskID}); err != nil {
+			t.Fatalf("EvictCachedTaskResults() returned an unexpected error: %v", err)
+		}
+	}
+	ctx := newContext()
+	steps := []func(){
+		func() {},
+		func() {},
+		func() { evict(ctx) },
+		func() { ctx = newContext() },
+		func() {
+			evict(ctx)
+			ctx = newContext()
+		},
+	}
+	for _, step := range steps {
+		step()
+		_, _, err := inspectiontest.RunInspectionTask(ctx, task, inspectioncore_contract.TaskModeRun, map[string]any{})
+		if err != nil {
+			t.Errorf("unexpected task error result %v", err)
+		}
+	}
+
+	if diff := cmp.Diff([]string{"", "foo", "", "foo", ""}, prevDigests); diff != "" {
+		t.Errorf("unexpected previous digests (-want +got):\n%s", diff)
+	}
+}
@@ -72,8 +72,11 @@ func NewFieldSetReadTask(taskId taskid.TaskImplementationID[[]*log.Log], logTask
 			})
 		}
 
-		pool.Wait()
+		err := pool.Wait()
 		progressUpdator.Done()
+		if err != nil {
+			return nil, err
+		}
 
 		tracingActive, _ := khictx.GetValue(ctx, inspectioncore_contract.TracingActive)
 		if tracingActive {
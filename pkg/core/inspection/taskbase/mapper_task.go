@@ -117,8 +117,11 @@ func NewLogToTimelineMapperTask[T any](tid taskid.TaskImplementationID[struct{}]
 				processedLogCount.Add(uint32(len(group.Logs)))
 			})
 		}
-		pool.Wait()
+		err := pool.Wait()
 		updator.Done()
+		if err != nil {
+			return struct{}{}, err
+		}
 
 		tracingActive, _ := khictx.GetValue(ctx, inspectioncore_contract.TracingActive)
 		if tracingActive {
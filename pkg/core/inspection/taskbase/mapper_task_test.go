@@ -68,6 +68,9 @@ func (m *mockLogToTimelineMapper) ProcessLogByGroup(ctx context.Context, l *log.
 	default:
 		cs.SetLogSeverity(enum.SeverityFatal)
 	}
+	if l.ReadBoolOrDefault("panic", false) {
+		panic("test panic")
+	}
 	shouldErr := l.ReadBoolOrDefault("error", false)
 	if shouldErr {
 		return mockLogToTimelineMapperGroupData{
@@ -252,6 +255,31 @@ func TestLogToTimelineMapperTask(t *testing.T) {
 			},
 			wantError: false, // The task itself should not fail
 		},
+		{
+			desc:     "Execution with a panic in one of the logs",
+			taskMode: inspectioncore_contract.TaskModeRun,
+			prevLogGroupMap: LogGroupMap{
+				"group1": {
+					Group: "group1",
+					Logs: []*log.Log{
+						mustNewLogFromYAML(t, `{"apiVersion": "v1", "kind": "Pod", "namespace": "default", "name": "pod-1", "panic": true}`),
+					},
+				},
+				"group2": {
+					Group: "group2",
+					Logs: []*log.Log{
+						mustNewLogFromYAML(t, `{"apiVersion": "v1", "kind": "Pod", "namespace": "default", "name": "pod-2"}`),
+					},
+				},
+			},
+			verifyHistory: func(t *testing.T, historyBuilder *history.Builder) {
+				pod2Events := historyBuilder.GetTimelineBuilder("core/v1#Pod#default#pod-2").GetClonedEvents()
+				if len(pod2Events) != 1 {
+					t.Errorf("expected 1 event for pod-2, but got %d", len(pod2Events))
+				}
+			},
+			wantError: true, // The panic must be returned as the task error instead of crashing the process
+		},
 		{
 			desc:            "Empty log group map",
 			taskMode:        inspectioncore_contract.TaskModeRun,
@@ -0,0 +1,230 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package taskcache provides TaskCacheBackend implementations sharing cached task results among multiple KHI server replicas.
+package taskcache
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// maxRESPBulkLength is the maximum length of bulk strings accepted in replies. This is same as the default proto-max-bulk-len of redis servers.
+// Replies declaring a longer bulk string are rejected before allocating the buffer for it.
+const maxRESPBulkLength = 512 * 1024 * 1024
+
+// RedisTaskCacheBackend is an implementation of TaskCacheBackend storing entries in a Redis server.
+// It speaks the RESP protocol over a single connection and reconnects when the connection is broken.
+type RedisTaskCacheBackend struct {
+	address   string
+	password  string
+	tlsConfig *tls.Config
+	keyPrefix string
+	ttl       time.Duration
+	timeout   time.Duration
+
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+var _ inspectioncore_contract.TaskCacheBackend = (*RedisTaskCacheBackend)(nil)
+
+// NewRedisTaskCacheBackend returns a RedisTaskCacheBackend connecting to the given address.
+// The connection is established over TLS with the given config when tlsConfig is not nil. The server name is taken from the address when the config doesn't specify it.
+// The password is sent with the AUTH command when it's not empty. Entries expire after the ttl when ttl is larger than 0.
+func NewRedisTaskCacheBackend(address string, password string, tlsConfig *tls.Config, ttl time.Duration) *RedisTaskCacheBackend {
+	return &RedisTaskCacheBackend{
+		address:   address,
+		password:  password,
+		tlsConfig: tlsConfig,
+		keyPrefix: "khi-task-cache/",
+		ttl:       ttl,
+		timeout:   5 * time.Second,
+	}
+}
+
+// Get implements inspectioncore_contract.TaskCacheBackend.
+func (r *RedisTaskCacheBackend) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := r.do(ctx, "GET", r.keyPrefix+key)
+	if err != nil {
+		return nil, false, err
+	}
+	if reply == nil {
+		return nil, false, nil
+	}
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, false, fmt.Errorf("unexpected reply type %T for GET", reply)
+	}
+	return value, true, nil
+}
+
+// Set implements inspectioncore_contract.TaskCacheBackend.
+func (r *RedisTaskCacheBackend) Set(ctx context.Context, key string, value []byte) error {
+	args := []string{"SET", r.keyPrefix + key, string(value)}
+	if r.ttl > 0 {
+		args = append(args, "PX", strconv.FormatInt(r.ttl.Milliseconds(), 10))
+	}
+	_, err := r.do(ctx, args...)
+	return err
+}
+
+// Delete implements inspectioncore_contract.TaskCacheBackend.
+func (r *RedisTaskCacheBackend) Delete(ctx context.Context, key string) error {
+	_, err := r.do(ctx, "DEL", r.keyPrefix+key)
+	return err
+}
+
+// Close closes the underlying connection.
+func (r *RedisTaskCacheBackend) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.closeConnection()
+}
+
+// do sends a command and returns its reply. The connection is discarded on any I/O error not to reuse a connection in an unknown state.
+func (r *RedisTaskCacheBackend) do(ctx context.Context, args ...string) (any, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.ensureConnection(ctx); err != nil {
+		return nil, err
+	}
+	reply, err := r.roundTrip(ctx, args...)
+	if err != nil {
+		var redisErr redisError
+		if !errors.As(err, &redisErr) {
+			r.closeConnection()
+		}
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (r *RedisTaskCacheBackend) ensureConnection(ctx context.Context) error {
+	if r.conn != nil {
+		return nil
+	}
+	netDialer := &net.Dialer{Timeout: r.timeout}
+	var conn net.Conn
+	var err error
+	if r.tlsConfig != nil {
+		conn, err = (&tls.Dialer{NetDialer: netDialer, Config: r.tlsConfig}).DialContext(ctx, "tcp", r.address)
+	} else {
+		conn, err = netDialer.DialContext(ctx, "tcp", r.address)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to redis server %s: %w", r.address, err)
+	}
+	r.conn = conn
+	r.reader = bufio.NewReader(conn)
+	if r.password != "" {
+		if _, err := r.roundTrip(ctx, "AUTH", r.password); err != nil {
+			r.closeConnection()
+			return fmt.Errorf("failed to authenticate to redis server %s: %w", r.address, err)
+		}
+	}
+	return nil
+}
+
+func (r *RedisTaskCacheBackend) closeConnection() error {
+	if r.conn == nil {
+		return nil
+	}
+	err := r.conn.Close()
+	r.conn = nil
+	r.reader = nil
+	return err
+}
+
+func (r *RedisTaskCacheBackend) roundTrip(ctx context.Context, args ...string) (any, error) {
+	deadline := time.Now().Add(r.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := r.conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+	if _, err := r.conn.Write(encodeRESPCommand(args)); err != nil {
+		return nil, err
+	}
+	return readRESPReply(r.reader, maxRESPBulkLength)
+}
+
+// redisError is an error reply returned from the redis server.
+type redisError string
+
+func (e redisError) Error() string {
+	return "redis: " + string(e)
+}
+
+// encodeRESPCommand encodes the command as an array of bulk strings.
+func encodeRESPCommand(args []string) []byte {
+	result := []byte(fmt.Sprintf("*%d\r\n", len(args)))
+	for _, arg := range args {
+		result = append(result, fmt.Sprintf("$%d\r\n", len(arg))...)
+		result = append(result, arg...)
+		result = append(result, "\r\n"...)
+	}
+	return result
+}
+
+// readRESPReply reads a single reply. Simple strings and integers are returned as string and int64, bulk strings are returned as []byte and nil bulk strings are returned as nil.
+// Bulk strings longer than maxBulkLength are rejected.
+func readRESPReply(reader *bufio.Reader, maxBulkLength int) (any, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("malformed redis reply %q", line)
+	}
+	payload := line[1 : len(line)-2]
+	switch line[0] {
+	case '+':
+		return payload, nil
+	case '-':
+		return nil, redisError(payload)
+	case ':':
+		return strconv.ParseInt(payload, 10, 64)
+	case '$':
+		length, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, fmt.Errorf("malformed redis bulk string length %q", payload)
+		}
+		if length < 0 {
+			return nil, nil
+		}
+		if length > maxBulkLength {
+			return nil, fmt.Errorf("redis bulk string length %d exceeds the limit %d", length, maxBulkLength)
+		}
+		value := make([]byte, length+2)
+		if _, err := io.ReadFull(reader, value); err != nil {
+			return nil, err
+		}
+		return value[:length], nil
+	default:
+		return nil, fmt.Errorf("unsupported redis reply type %q", line[0])
+	}
+}
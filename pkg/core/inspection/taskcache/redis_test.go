@@ -0,0 +1,307 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package taskcache
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// fakeRedisServer is a minimal redis server only supporting AUTH, GET and SET used in tests.
+// It accepts connections over TLS when it's created with a TLS config.
+type fakeRedisServer struct {
+	listener net.Listener
+	password string
+	mu       sync.Mutex
+	values   map[string]string
+	commands [][]string
+}
+
+func newFakeRedisServer(t *testing.T, password string, tlsConfig *tls.Config) *fakeRedisServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	if tlsConfig != nil {
+		listener = tls.NewListener(listener, tlsConfig)
+	}
+	server := &fakeRedisServer{
+		listener: listener,
+		password: password,
+		values:   map[string]string{},
+	}
+	go server.serve()
+	t.Cleanup(func() { listener.Close() })
+	return server
+}
+
+func (s *fakeRedisServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeRedisServer) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	authenticated := s.password == ""
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.commands = append(s.commands, args)
+		var reply string
+		switch {
+		case strings.ToUpper(args[0]) == "AUTH":
+			if args[1] == s.password {
+				authenticated = true
+				reply = "+OK\r\n"
+			} else {
+				reply = "-WRONGPASS invalid password\r\n"
+			}
+		case !authenticated:
+			reply = "-NOAUTH Authentication required.\r\n"
+		case strings.ToUpper(args[0]) == "GET":
+			if value, found := s.values[args[1]]; found {
+				reply = fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+			} else {
+				reply = "$-1\r\n"
+			}
+		case strings.ToUpper(args[0]) == "SET":
+			s.values[args[1]] = args[2]
+			reply = "+OK\r\n"
+		case strings.ToUpper(args[0]) == "DEL":
+			if _, found := s.values[args[1]]; found {
+				delete(s.values, args[1])
+				reply = ":1\r\n"
+			} else {
+				reply = ":0\r\n"
+			}
+		default:
+			reply = "-ERR unknown command\r\n"
+		}
+		s.mu.Unlock()
+		if _, err := conn.Write([]byte(reply)); err != nil {
+			return
+		}
+	}
+}
+
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		length, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, length+2)
+		if _, err := io.ReadFull(reader, buf); err != nil {
+			return nil, err
+		}
+		args = append(args, string(buf[:length]))
+	}
+	return args, nil
+}
+
+func TestRedisTaskCacheBackend(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeRedisServer(t, "secret", nil)
+	backend := NewRedisTaskCacheBackend(server.listener.Addr().String(), "secret", nil, time.Hour)
+	defer backend.Close()
+
+	_, found, err := backend.Get(ctx, "foo")
+	if err != nil {
+		t.Fatalf("Get() returned an unexpected error: %v", err)
+	}
+	if found {
+		t.Errorf("Get() returned found=true for a missing key")
+	}
+
+	value := "value with\r\nline breaks"
+	if err := backend.Set(ctx, "foo", []byte(value)); err != nil {
+		t.Fatalf("Set() returned an unexpected error: %v", err)
+	}
+
+	// Another backend instance must share the value like another server replica.
+	another := NewRedisTaskCacheBackend(server.listener.Addr().String(), "secret", nil, time.Hour)
+	defer another.Close()
+	got, found, err := another.Get(ctx, "foo")
+	if err != nil {
+		t.Fatalf("Get() returned an unexpected error: %v", err)
+	}
+	if !found {
+		t.Fatalf("Get() returned found=false for a stored key")
+	}
+	if string(got) != value {
+		t.Errorf("Get() = %q, want %q", string(got), value)
+	}
+
+	if err := another.Delete(ctx, "foo"); err != nil {
+		t.Fatalf("Delete() returned an unexpected error: %v", err)
+	}
+	if _, found, err := backend.Get(ctx, "foo"); err != nil || found {
+		t.Errorf("Get() after Delete() = (found=%v, err=%v), want (found=false, err=nil)", found, err)
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	var setCommand []string
+	for _, command := range server.commands {
+		if command[0] == "SET" {
+			setCommand = command
+		}
+	}
+	wantSetCommand := []string{"SET", "khi-task-cache/foo", value, "PX", "3600000"}
+	if strings.Join(setCommand, " ") != strings.Join(wantSetCommand, " ") {
+		t.Errorf("SET command = %q, want %q", setCommand, wantSetCommand)
+	}
+}
+
+func TestRedisTaskCacheBackendWithWrongPassword(t *testing.T) {
+	server := newFakeRedisServer(t, "secret", nil)
+	backend := NewRedisTaskCacheBackend(server.listener.Addr().String(), "wrong", nil, 0)
+	defer backend.Close()
+
+	_, _, err := backend.Get(context.Background(), "foo")
+	if err == nil {
+		t.Errorf("Get() returned nil error, want an authentication error")
+	}
+}
+
+func TestRedisTaskCacheBackendReconnects(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeRedisServer(t, "", nil)
+	backend := NewRedisTaskCacheBackend(server.listener.Addr().String(), "", nil, 0)
+	defer backend.Close()
+
+	if err := backend.Set(ctx, "foo", []byte("bar")); err != nil {
+		t.Fatalf("Set() returned an unexpected error: %v", err)
+	}
+	// Break the current connection from the client side.
+	backend.mu.Lock()
+	backend.conn.Close()
+	backend.mu.Unlock()
+
+	if _, _, err := backend.Get(ctx, "foo"); err == nil {
+		t.Fatalf("Get() on a broken connection returned nil error")
+	}
+	got, found, err := backend.Get(ctx, "foo")
+	if err != nil {
+		t.Fatalf("Get() after reconnecting returned an unexpected error: %v", err)
+	}
+	if !found || string(got) != "bar" {
+		t.Errorf("Get() = %q(found=%v), want %q", string(got), found, "bar")
+	}
+}
+
+func TestRedisTaskCacheBackendWithTLS(t *testing.T) {
+	// Borrow the self signed certificate for 127.0.0.1 and the client config trusting it from httptest.
+	httpServer := httptest.NewTLSServer(http.NotFoundHandler())
+	serverCertificate := httpServer.TLS.Certificates[0]
+	rootCAs := httpServer.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs
+	httpServer.Close()
+
+	ctx := context.Background()
+	server := newFakeRedisServer(t, "secret", &tls.Config{Certificates: []tls.Certificate{serverCertificate}})
+
+	backend := NewRedisTaskCacheBackend(server.listener.Addr().String(), "secret", &tls.Config{RootCAs: rootCAs}, 0)
+	defer backend.Close()
+	if err := backend.Set(ctx, "foo", []byte("bar")); err != nil {
+		t.Fatalf("Set() over TLS returned an unexpected error: %v", err)
+	}
+	got, found, err := backend.Get(ctx, "foo")
+	if err != nil {
+		t.Fatalf("Get() over TLS returned an unexpected error: %v", err)
+	}
+	if !found || string(got) != "bar" {
+		t.Errorf("Get() = %q(found=%v), want %q", string(got), found, "bar")
+	}
+
+	// The server certificate must be verified.
+	untrusted := NewRedisTaskCacheBackend(server.listener.Addr().String(), "secret", &tls.Config{}, 0)
+	defer untrusted.Close()
+	if _, _, err := untrusted.Get(ctx, "foo"); err == nil {
+		t.Errorf("Get() with an untrusted server certificate returned nil error")
+	}
+}
+
+func TestReadRESPReply(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		reply   string
+		want    any
+		wantErr bool
+	}{
+		{desc: "simple string", reply: "+OK\r\n", want: "OK"},
+		{desc: "integer", reply: ":42\r\n", want: int64(42)},
+		{desc: "bulk string", reply: "$3\r\nbar\r\n", want: []byte("bar")},
+		{desc: "bulk string at the limit", reply: "$8\r\n12345678\r\n", want: []byte("12345678")},
+		{desc: "nil bulk string", reply: "$-1\r\n", want: nil},
+		{desc: "error", reply: "-ERR unknown command\r\n", wantErr: true},
+		{desc: "bulk string longer than the limit", reply: "$9\r\n123456789\r\n", wantErr: true},
+		{desc: "huge bulk string length", reply: "$9223372036854775807\r\n", wantErr: true},
+		{desc: "truncated bulk string", reply: "$5\r\nbar", wantErr: true},
+		{desc: "malformed line", reply: "+OK\n", wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := readRESPReply(bufio.NewReader(strings.NewReader(tc.reply)), 8)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("readRESPReply(%q) returned nil error, want an error", tc.reply)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readRESPReply(%q) returned an unexpected error: %v", tc.reply, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("readRESPReply(%q) mismatch (-want +got):\n%s", tc.reply, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coreinspection
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/structured"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/model/log"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// recordTaskStat is a coretask.Interceptor recording the wall time and the output size of each task completed successfully in the TaskStatsMetadata.
+// Stats are recorded only in the run mode because the dry run doesn't generate the inspection result. It is added to the runner only when --task-stats is set.
+func recordTaskStat(ctx context.Context, task coretask.UntypedTask, next func(context.Context) (any, error)) (any, error) {
+	startTime := time.Now()
+	result, err := next(ctx)
+	duration := time.Since(startTime)
+	if err != nil {
+		return result, err
+	}
+	mode, modeErr := khictx.GetValue(ctx, inspectioncore_contract.InspectionTaskMode)
+	if modeErr != nil || mode != inspectioncore_contract.TaskModeRun {
+		return result, err
+	}
+	metadataSet, metadataErr := khictx.GetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
+	if metadataErr != nil {
+		return result, err
+	}
+	taskStats, found := typedmap.Get(metadataSet, inspectionmetadata.TaskStatsMetadataKey)
+	if !found {
+		return result, err
+	}
+	outputBytes, logCount := measureTaskOutput(ctx, result)
+	taskStats.SetTaskStat(&inspectionmetadata.TaskStat{
+		ID:              task.UntypedID().String(),
+		DurationSeconds: duration.Seconds(),
+		OutputBytes:     outputBytes,
+		LogCount:        logCount,
+	})
+	return result, err
+}
+
+// taskOutputSampledLogCount is the maximum count of logs serialized to estimate the byte size of a task output.
+const taskOutputSampledLogCount = 100
+
+// measureTaskOutput returns the byte size and the count of log entries of the given task output.
+// The byte size of logs is estimated from the size of the JSON representation of logs sampled at even intervals not to serialize all of them.
+// The size is 0 for the other types not to serialize arbitrary large values.
+func measureTaskOutput(ctx context.Context, result any) (int64, int) {
+	switch output := result.(type) {
+	case []*log.Log:
+		if len(output) == 0 {
+			return 0, 0
+		}
+		stride := (len(output) + taskOutputSampledLogCount - 1) / taskOutputSampledLogCount
+		var sampledBytes int64
+		sampledCount := 0
+		for i := 0; i < len(output); i += stride {
+			serialized, err := output[i].Serialize("", &structured.JSONNodeSerializer{})
+			if err != nil {
+				slog.DebugContext(ctx, fmt.Sprintf("failed to measure the size of a log %s: %v", output[i].ID, err))
+				continue
+			}
+			sampledBytes += int64(len(serialized))
+			sampledCount++
+		}
+		if sampledCount == 0 {
+			return 0, len(output)
+		}
+		return sampledBytes * int64(len(output)) / int64(sampledCount), len(output)
+	case string:
+		return int64(len(output)), 0
+	case []byte:
+		return int64(len(output)), 0
+	default:
+		return 0, 0
+	}
+}
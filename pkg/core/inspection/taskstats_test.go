@@ -0,0 +1,125 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coreinspection
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/log"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+func mustNewLogFromYAMLString(t *testing.T, yaml string) *log.Log {
+	t.Helper()
+	l, err := log.NewLogFromYAMLString(yaml)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	return l
+}
+
+func repeatedLogs(t *testing.T, yaml string, count int) []*log.Log {
+	t.Helper()
+	result := make([]*log.Log, 0, count)
+	for i := 0; i < count; i++ {
+		result = append(result, mustNewLogFromYAMLString(t, yaml))
+	}
+	return result
+}
+
+func TestMeasureTaskOutput(t *testing.T) {
+	testCases := []struct {
+		name         string
+		result       any
+		wantBytes    int64
+		wantLogCount int
+	}{
+		{
+			name:         "logs",
+			result:       []*log.Log{mustNewLogFromYAMLString(t, "foo: bar"), mustNewLogFromYAMLString(t, "a: 1")},
+			wantBytes:    int64(len(`{"foo":"bar"}`) + len(`{"a":1}`)),
+			wantLogCount: 2,
+		},
+		{
+			name:         "logs more than the sampled count",
+			result:       repeatedLogs(t, "foo: bar", 3*taskOutputSampledLogCount+1),
+			wantBytes:    int64(len(`{"foo":"bar"}`) * (3*taskOutputSampledLogCount + 1)),
+			wantLogCount: 3*taskOutputSampledLogCount + 1,
+		},
+		{
+			name:   "empty logs",
+			result: []*log.Log{},
+		},
+		{
+			name:      "string",
+			result:    "foo",
+			wantBytes: 3,
+		},
+		{
+			name:   "unmeasurable type",
+			result: map[string]string{"foo": "bar"},
+		},
+		{
+			name:   "nil",
+			result: nil,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotBytes, gotLogCount := measureTaskOutput(context.Background(), tc.result)
+			if gotBytes != tc.wantBytes || gotLogCount != tc.wantLogCount {
+				t.Errorf("measureTaskOutput() = (%d, %d), want (%d, %d)", gotBytes, gotLogCount, tc.wantBytes, tc.wantLogCount)
+			}
+		})
+	}
+}
+
+func TestRecordTaskStat(t *testing.T) {
+	metadataSet := typedmap.NewTypedMap()
+	taskStats := inspectionmetadata.NewTaskStatsMetadata()
+	typedmap.Set(metadataSet, inspectionmetadata.TaskStatsMetadataKey, taskStats)
+	ctx := khictx.WithValue(context.Background(), inspectioncore_contract.InspectionRunMetadata, metadataSet.AsReadonly())
+	newTask := func(id string) coretask.UntypedTask {
+		return coretask.NewTask(taskid.NewDefaultImplementationID[any](id), nil, func(ctx context.Context) (any, error) {
+			return nil, nil
+		})
+	}
+
+	runCtx := khictx.WithValue(ctx, inspectioncore_contract.InspectionTaskMode, inspectioncore_contract.TaskModeRun)
+	dryRunCtx := khictx.WithValue(ctx, inspectioncore_contract.InspectionTaskMode, inspectioncore_contract.TaskModeDryRun)
+	recordTaskStat(runCtx, newTask("foo"), func(ctx context.Context) (any, error) { return "foo-result", nil })
+	recordTaskStat(runCtx, newTask("failed"), func(ctx context.Context) (any, error) { return nil, errors.New("error") })
+	recordTaskStat(dryRunCtx, newTask("dryrun"), func(ctx context.Context) (any, error) { return "", nil })
+
+	if len(taskStats.TaskStats) != 1 {
+		t.Fatalf("expected only the stat of the successful task in run mode, got %d stats", len(taskStats.TaskStats))
+	}
+	got := taskStats.TaskStats[0]
+	if got.DurationSeconds < 0 {
+		t.Errorf("duration must not be negative, got %f", got.DurationSeconds)
+	}
+	want := &inspectionmetadata.TaskStat{ID: "foo#default", OutputBytes: 10, DurationSeconds: got.DurationSeconds}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("task stat mismatch (-want +got):\n%s", diff)
+	}
+}
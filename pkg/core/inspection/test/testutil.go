@@ -96,7 +96,11 @@ func generateTestMetadata() *typedmap.ReadonlyTypedMap {
 	writableMetadata := typedmap.NewTypedMap()
 	typedmap.Set(writableMetadata, inspectionmetadata.HeaderMetadataKey, &inspectionmetadata.HeaderMetadata{})
 	typedmap.Set(writableMetadata, inspectionmetadata.ErrorMessageSetMetadataKey, inspectionmetadata.NewErrorMessageSetMetadata())
-	typedmap.Set(writableMetadata, inspectionmetadata.FormFieldSetMetadataKey, inspectionmetadata.NewFormFieldSetMetadata())
+	typedmap.Set(writableMetadata, inspectionmetadata.ConfigSnapshotSetMetadataKey, inspectionmetadata.NewConfigSnapshotSetMetadata())
+	formFields := inspectionmetadata.NewFormFieldSetMetadata()
+	typedmap.Set(writableMetadata, inspectionmetadata.FormFieldSetMetadataKey, formFields)
+	typedmap.Set(writableMetadata, inspectionmetadata.FormLayoutMetadataKey, inspectionmetadata.NewFormLayoutMetadata(formFields))
+	typedmap.Set(writableMetadata, inspectionmetadata.ParameterValueSetMetadataKey, inspectionmetadata.NewParameterValueSetMetadata())
 	typedmap.Set(writableMetadata, inspectionmetadata.QueryMetadataKey, inspectionmetadata.NewQueryMetadata())
 	typedmap.Set(writableMetadata, inspectionmetadata.ProgressMetadataKey, inspectionmetadata.NewProgress())
 	return writableMetadata.AsReadonly()
@@ -27,6 +27,8 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
+// NewInspectionTraceInterceptor returns an InspectionInterceptor emitting a trace for each inspection run.
+// The inspection is recorded as the root span and each task execution is recorded as its child span with the task ID, the mode and the cache-hit attribute.
 func NewInspectionTraceInterceptor(tracer trace.Tracer) coreinspection.InspectionInterceptor {
 	return func(ctx context.Context, req *inspectioncore_contract.InspectionRequest, next func(context.Context) error) error {
 		inspectionID := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionTaskInspectionID)
@@ -45,6 +47,9 @@ func NewInspectionTraceInterceptor(tracer trace.Tracer) coreinspection.Inspectio
 		runner.AddInterceptor(func(ctx context.Context, task coretask.UntypedTask, next func(context.Context) (any, error)) (any, error) {
 			ctx, span := tracer.Start(ctx, task.UntypedID().String(), trace.WithAttributes(
 				attribute.String("task_id", task.UntypedID().String()),
+				attribute.String("mode", inspectioncore_contract.TaskModeToString(mode)),
+				// Tasks reusing the previous result overwrite this attribute on the span obtained from its context.
+				attribute.Bool("cache_hit", false),
 			))
 			defer span.End()
 
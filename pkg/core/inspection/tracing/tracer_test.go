@@ -23,8 +23,10 @@ import (
 	"github.com/kyasbal/khi/pkg/core/task/taskid"
 	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 func TestNewInspectionTraceInterceptor(t *testing.T) {
@@ -80,6 +82,7 @@ func TestNewInspectionTraceInterceptor(t *testing.T) {
 	}
 
 	_, err = taskInterceptor(ctx, mockTask, func(ctx context.Context) (any, error) {
+		oteltrace.SpanFromContext(ctx).SetAttributes(attribute.Bool("cache_hit", true))
 		return "result", nil
 	})
 	if err != nil {
@@ -95,6 +98,20 @@ func TestNewInspectionTraceInterceptor(t *testing.T) {
 		if taskSpan.Name != "test-task#default" {
 			t.Errorf("Expected task span name 'test-task#default', got '%s'", taskSpan.Name)
 		}
+		wantAttributes := map[attribute.Key]attribute.Value{
+			"task_id":   attribute.StringValue("test-task#default"),
+			"mode":      attribute.StringValue(inspectioncore_contract.TaskModeToString(inspectioncore_contract.TaskModeRun)),
+			"cache_hit": attribute.BoolValue(true),
+		}
+		gotAttributes := map[attribute.Key]attribute.Value{}
+		for _, attr := range taskSpan.Attributes {
+			gotAttributes[attr.Key] = attr.Value
+		}
+		for key, want := range wantAttributes {
+			if got, found := gotAttributes[key]; !found || got != want {
+				t.Errorf("Expected task span attribute %s=%v, got %v", key, want.Emit(), got.Emit())
+			}
+		}
 	}
 }
 
@@ -106,7 +123,7 @@ func (m *mockTaskRunner) Run(ctx context.Context) error {
 	return nil
 }
 
-func (m *mockTaskRunner) Wait() <-chan interface{} {
+func (m *mockTaskRunner) Wait() <-chan coretask.TaskRunnerTerminalState {
 	return nil
 }
 
@@ -0,0 +1,118 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coretask
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+)
+
+// maxSuggestedTaskReferences is the maximum count of task references suggested for a missing task reference.
+const maxSuggestedTaskReferences = 3
+
+// describeMissingTaskReference returns a human readable diagnostic of a missing task reference.
+// It includes the tasks requiring the reference and the nearest task references found in the candidates to help fixing typos or missing registrations.
+func describeMissingTaskReference(missing taskid.UntypedTaskReference, requiredBy []UntypedTask, candidates []UntypedTask) string {
+	result := &strings.Builder{}
+	result.WriteString(fmt.Sprintf("* %s\n", missing.ReferenceIDString()))
+	if len(requiredBy) > 0 {
+		requiredByIDs := make([]string, 0, len(requiredBy))
+		for _, task := range requiredBy {
+			requiredByIDs = append(requiredByIDs, task.UntypedID().String())
+		}
+		slices.Sort(requiredByIDs)
+		result.WriteString(fmt.Sprintf("    required by: %s\n", strings.Join(requiredByIDs, ", ")))
+	}
+	nearest := nearestTaskReferenceIDs(missing.ReferenceIDString(), candidates, maxSuggestedTaskReferences)
+	if len(nearest) > 0 {
+		result.WriteString(fmt.Sprintf("    did you mean: %s\n", strings.Join(nearest, ", ")))
+	}
+	return result.String()
+}
+
+// tasksDependingOn returns the tasks depending on the given task reference.
+func tasksDependingOn(ref taskid.UntypedTaskReference, tasks []UntypedTask) []UntypedTask {
+	result := []UntypedTask{}
+	for _, task := range tasks {
+		for _, dependency := range task.Dependencies() {
+			if dependency.ReferenceIDString() == ref.ReferenceIDString() {
+				result = append(result, task)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// nearestTaskReferenceIDs returns at most limit reference IDs of the candidate tasks ordered by the edit distance from the target.
+// Candidates too far from the target to be a typo of it are excluded.
+func nearestTaskReferenceIDs(target string, candidates []UntypedTask, limit int) []string {
+	type scoredReference struct {
+		referenceID string
+		distance    int
+	}
+	// Allow edits up to the half of the target length to exclude unrelated tasks.
+	maxDistance := len(target) / 2
+	seen := map[string]struct{}{}
+	scored := []scoredReference{}
+	for _, candidate := range candidates {
+		referenceID := candidate.UntypedID().ReferenceIDString()
+		if _, found := seen[referenceID]; found {
+			continue
+		}
+		seen[referenceID] = struct{}{}
+		distance := levenshteinDistance(target, referenceID)
+		if distance > maxDistance {
+			continue
+		}
+		scored = append(scored, scoredReference{referenceID: referenceID, distance: distance})
+	}
+	slices.SortFunc(scored, func(a, b scoredReference) int {
+		if a.distance != b.distance {
+			return a.distance - b.distance
+		}
+		return strings.Compare(a.referenceID, b.referenceID)
+	})
+	result := []string{}
+	for i := 0; i < len(scored) && i < limit; i++ {
+		result = append(result, scored[i].referenceID)
+	}
+	return result
+}
+
+// levenshteinDistance returns the count of single character edits needed to change a to b.
+func levenshteinDistance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	current := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		current[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			current[j] = min(prev[j]+1, current[j-1]+1, prev[j-1]+cost)
+		}
+		prev, current = current, prev
+	}
+	return prev[len(br)]
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coretask
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLevenshteinDistance(t *testing.T) {
+	testCases := []struct {
+		a    string
+		b    string
+		want int
+	}{
+		{a: "", b: "", want: 0},
+		{a: "foo", b: "", want: 3},
+		{a: "", b: "foo", want: 3},
+		{a: "foo", b: "foo", want: 0},
+		{a: "kitten", b: "sitting", want: 3},
+		{a: "node-logs", b: "node-log", want: 1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.a+"-"+tc.b, func(t *testing.T) {
+			if got := levenshteinDistance(tc.a, tc.b); got != tc.want {
+				t.Errorf("levenshteinDistance(%q, %q) = %d, want %d", tc.a, tc.b, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNearestTaskReferenceIDs(t *testing.T) {
+	candidates := []UntypedTask{
+		newDebugTask("example.com/query/node-logs", []string{}),
+		newDebugTask("example.com/query/node-log", []string{}),
+		newDebugTask("example.com/query/pod-logs", []string{}),
+		newDebugTask("example.com/form/cluster-name", []string{}),
+		newDebugTask("unrelated", []string{}),
+	}
+
+	got := nearestTaskReferenceIDs("example.com/query/node-logz", candidates, 2)
+	want := []string{"example.com/query/node-log", "example.com/query/node-logs"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("nearestTaskReferenceIDs() mismatch (-want +got):\n%s", diff)
+	}
+
+	if got := nearestTaskReferenceIDs("foo", candidates, 3); len(got) != 0 {
+		t.Errorf("expected no suggestion for a reference far from any candidates, got %v", got)
+	}
+}
+
+func TestToRunnableTaskSetReportsMissingDependencyDetails(t *testing.T) {
+	taskSet, err := NewTaskSet([]UntypedTask{
+		newDebugTask("example.com/foo", []string{"example.com/bar-task"}),
+		newDebugTask("example.com/qux", []string{"example.com/bar-task"}),
+		newDebugTask("example.com/bar-tusk", []string{}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	_, err = taskSet.ToRunnableTaskSet()
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+	for _, want := range []string{
+		"* example.com/bar-task",
+		"required by: example.com/foo#default, example.com/qux#default",
+		"did you mean: example.com/bar-tusk",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected the error to contain %q, got\n%s", want, err.Error())
+		}
+	}
+}
@@ -18,7 +18,6 @@ import (
 	"context"
 	"fmt"
 	"slices"
-	"strings"
 
 	"github.com/kyasbal/khi/pkg/common/typedmap"
 	"github.com/kyasbal/khi/pkg/core/task/taskid"
@@ -161,7 +160,7 @@ func (d *TaskDependencyGraphResolverRule) Resolve(currentGraphTasks []UntypedTas
 		for _, ref := range missingReferences {
 			highest, err := findHighestPriorityUntypedTaskForTaskReference(ref, availableTasks)
 			if err != nil {
-				return GraphResolverRuleResult{}, err
+				return GraphResolverRuleResult{}, fmt.Errorf("%w\n%s", err, describeMissingTaskReference(ref, tasksDependingOn(ref, currentGraphTasks), availableTasks))
 			}
 			result.Tasks = append(result.Tasks, highest)
 		}
@@ -220,6 +219,11 @@ func (d *dependencyOverridenUntypedTask) UntypedRun(ctx context.Context) (any, e
 	return d.Parent.UntypedRun(ctx)
 }
 
+// zeroResult implements zeroResultProvider by delegating to the parent task.
+func (d *dependencyOverridenUntypedTask) zeroResult() any {
+	return zeroResultOf(d.Parent)
+}
+
 var _ UntypedTask = (*dependencyOverridenUntypedTask)(nil)
 
 // SubsequentTaskRefsGraphResolverRule is a resolver rule that adds tasks to the graph
@@ -324,11 +328,7 @@ func findHighestPriorityUntypedTaskForTaskReference(ref taskid.UntypedTaskRefere
 	}
 
 	if len(matched) == 0 {
-		var availableTaskIDs []string
-		for _, task := range availableTasks {
-			availableTaskIDs = append(availableTaskIDs, "*"+task.UntypedID().String())
-		}
-		return nil, fmt.Errorf("failed to resolve task dependency. No available task can be referenced as '%s'.\nAvailable tasks:\n%s", ref.ReferenceIDString(), strings.Join(availableTaskIDs, "\n"))
+		return nil, fmt.Errorf("failed to resolve task dependency. No available task can be referenced as '%s'", ref.ReferenceIDString())
 	}
 
 	// pick one of matched task with the highest priority
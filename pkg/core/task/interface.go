@@ -20,10 +20,23 @@ import (
 	"github.com/kyasbal/khi/pkg/common/typedmap"
 )
 
+// TaskRunnerTerminalState is the final state of a task runner after all of its tasks finished.
+type TaskRunnerTerminalState string
+
+const (
+	// TaskRunnerStateCompleted indicates that all tasks completed without a failure aborting the graph.
+	TaskRunnerStateCompleted TaskRunnerTerminalState = "completed"
+	// TaskRunnerStateFailed indicates that a task failure aborted the graph.
+	TaskRunnerStateFailed TaskRunnerTerminalState = "failed"
+	// TaskRunnerStateCancelled indicates that the context given to Run was cancelled before the graph finished.
+	TaskRunnerStateCancelled TaskRunnerTerminalState = "cancelled"
+)
+
 // TaskRunner receives the runnable TaskSet and run tasks with topological sorted order.
 type TaskRunner interface {
 	Run(ctx context.Context) error
-	Wait() <-chan interface{}
+	// Wait returns a channel receiving the terminal state of the runner once after all tasks finished.
+	Wait() <-chan TaskRunnerTerminalState
 	Result() (*typedmap.ReadonlyTypedMap, error)
 	Tasks() []UntypedTask
 	AddInterceptor(interceptor Interceptor)
@@ -0,0 +1,72 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coretask
+
+import (
+	"context"
+
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+)
+
+// LabelKeyTaskFailureIsolated marks the task as a boundary of failures.
+// When the task or any task in the subtree only reachable to the rest of the graph through such boundaries fails, the runner records the failure
+// instead of aborting the whole graph. Tasks depending on the failed boundary task receive the zero value of its result type.
+var LabelKeyTaskFailureIsolated = NewTaskLabelKey[bool](KHISystemPrefix + "task-failure-isolated")
+
+// WithFailureIsolation returns a LabelOpt to mark the task as a boundary of failures.
+func WithFailureIsolation() LabelOpt {
+	return WithLabelValue(LabelKeyTaskFailureIsolated, true)
+}
+
+// IsolatedFailureHandler is called when a task labeled with LabelKeyTaskFailureIsolated failed or one of its dependencies failed.
+// The error is the error returned from the task or the error describing the failed dependency.
+type IsolatedFailureHandler func(ctx context.Context, task UntypedTask, err error)
+
+// failureContainableTasks returns the flags for each task in the given topologically sorted tasks telling if a failure of the task can be isolated.
+// A failure is isolatable when the task is a failure boundary itself or all of the tasks depending on it are isolatable.
+func failureContainableTasks(tasks []UntypedTask) []bool {
+	dependents := map[string][]int{}
+	for i, task := range tasks {
+		for _, dependency := range task.Dependencies() {
+			dependents[dependency.ReferenceIDString()] = append(dependents[dependency.ReferenceIDString()], i)
+		}
+	}
+	result := make([]bool, len(tasks))
+	// Dependents always come after their dependencies in the topologically sorted tasks.
+	for i := len(tasks) - 1; i >= 0; i-- {
+		if isFailureBoundary(tasks[i]) {
+			result[i] = true
+			continue
+		}
+		taskDependents := dependents[tasks[i].UntypedID().ReferenceIDString()]
+		if len(taskDependents) == 0 {
+			continue
+		}
+		containable := true
+		for _, dependent := range taskDependents {
+			if !result[dependent] {
+				containable = false
+				break
+			}
+		}
+		result[i] = containable
+	}
+	return result
+}
+
+// isFailureBoundary returns true when the task is labeled with LabelKeyTaskFailureIsolated.
+func isFailureBoundary(task UntypedTask) bool {
+	return typedmap.GetOrDefault(task.Labels(), LabelKeyTaskFailureIsolated, false)
+}
@@ -15,6 +15,8 @@
 package coretask
 
 import (
+	"time"
+
 	"github.com/kyasbal/khi/pkg/common/typedmap"
 	"github.com/kyasbal/khi/pkg/core/task/taskid"
 )
@@ -55,6 +57,11 @@ func WithSelectionPriority(priority int) LabelOpt {
 	}
 }
 
+// WithTimeout returns a LabelOpt to cancel the task when its execution takes longer than the given duration.
+func WithTimeout(timeout time.Duration) LabelOpt {
+	return WithLabelValue(LabelKeyTaskTimeout, timeout)
+}
+
 // labelValueOpt stores a label value associating to a label key.
 type labelValueOpt[T any] struct {
 	labelKey TaskLabelKey[T]
@@ -0,0 +1,109 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coretask
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/metrics"
+	"sync"
+	"time"
+)
+
+// LabelKeyTaskMemoryHeavy marks the task consumes large amount of memory like tasks querying or parsing logs.
+// The task runner delays starting these tasks while the heap usage is over its memory limit.
+var LabelKeyTaskMemoryHeavy = NewTaskLabelKey[bool](KHISystemPrefix + "task-memory-heavy")
+
+// WithMemoryHeavy returns a LabelOpt to mark the task as a memory heavy task.
+func WithMemoryHeavy() LabelOpt {
+	return WithLabelValue(LabelKeyTaskMemoryHeavy, true)
+}
+
+// heapObjectsMetricName is the runtime metric name of the memory occupied by live and unswept objects in the heap.
+const heapObjectsMetricName = "/memory/classes/heap/objects:bytes"
+
+// defaultMemoryBudgetPollInterval is the interval to sample the heap usage while heavy tasks are waiting for the memory.
+const defaultMemoryBudgetPollInterval = 500 * time.Millisecond
+
+// readHeapObjectsBytes returns the current heap usage of this process.
+func readHeapObjectsBytes() uint64 {
+	sample := []metrics.Sample{{Name: heapObjectsMetricName}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return sample[0].Value.Uint64()
+}
+
+// memoryBudget delays starting memory heavy tasks while the heap usage is over the limit.
+// At least one heavy task is always allowed to run to keep the task graph progressing even when the memory is occupied by other reasons.
+type memoryBudget struct {
+	limitBytes   uint64
+	pollInterval time.Duration
+	// readHeapBytes returns the current heap usage. This is replaceable for testing.
+	readHeapBytes func() uint64
+
+	mu           sync.Mutex
+	runningHeavy int
+	// heavyTaskDone is closed and replaced every time a heavy task finished to wake up waiting tasks.
+	heavyTaskDone chan struct{}
+}
+
+func newMemoryBudget(limitBytes uint64) *memoryBudget {
+	return &memoryBudget{
+		limitBytes:    limitBytes,
+		pollInterval:  defaultMemoryBudgetPollInterval,
+		readHeapBytes: readHeapObjectsBytes,
+		heavyTaskDone: make(chan struct{}),
+	}
+}
+
+// acquire blocks until a heavy task can start under the memory limit.
+// It returns a function to be called when the heavy task finished. The wait is interrupted when the context is cancelled.
+func (b *memoryBudget) acquire(ctx context.Context) (func(), error) {
+	logged := false
+	for {
+		b.mu.Lock()
+		heapBytes := b.readHeapBytes()
+		if b.runningHeavy == 0 || heapBytes < b.limitBytes {
+			b.runningHeavy++
+			b.mu.Unlock()
+			return b.release, nil
+		}
+		heavyTaskDone := b.heavyTaskDone
+		b.mu.Unlock()
+
+		if !logged {
+			slog.DebugContext(ctx, fmt.Sprintf("delaying a memory heavy task because the heap usage %d bytes is over the limit %d bytes", heapBytes, b.limitBytes))
+			logged = true
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-heavyTaskDone:
+		case <-time.After(b.pollInterval):
+		}
+	}
+}
+
+// release marks a heavy task finished and wakes up the waiting heavy tasks.
+func (b *memoryBudget) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.runningHeavy--
+	close(b.heavyTaskDone)
+	b.heavyTaskDone = make(chan struct{})
+}
@@ -0,0 +1,159 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coretask
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+)
+
+func TestMemoryBudget_AllowsHeavyTaskUnderLimit(t *testing.T) {
+	budget := newMemoryBudget(100)
+	budget.readHeapBytes = func() uint64 { return 50 }
+
+	release1, err := budget.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	release2, err := budget.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	release1()
+	release2()
+	if budget.runningHeavy != 0 {
+		t.Errorf("expected no running heavy task, got %d", budget.runningHeavy)
+	}
+}
+
+func TestMemoryBudget_AllowsFirstHeavyTaskOverLimit(t *testing.T) {
+	budget := newMemoryBudget(100)
+	budget.readHeapBytes = func() uint64 { return 200 }
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	release, err := budget.acquire(ctx)
+	if err != nil {
+		t.Fatalf("the first heavy task must not wait even when the heap usage is over the limit, got %v", err)
+	}
+	release()
+}
+
+func TestMemoryBudget_DelaysHeavyTaskOverLimit(t *testing.T) {
+	var heapBytes atomic.Uint64
+	heapBytes.Store(200)
+	budget := newMemoryBudget(100)
+	budget.pollInterval = time.Hour
+	budget.readHeapBytes = heapBytes.Load
+
+	release1, err := budget.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	acquired := make(chan struct{})
+	go func() {
+		release2, err := budget.acquire(context.Background())
+		if err != nil {
+			t.Errorf("unexpected error %v", err)
+			return
+		}
+		close(acquired)
+		release2()
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("the second heavy task must wait while the heap usage is over the limit")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	heapBytes.Store(50)
+	release1()
+
+	select {
+	case <-acquired:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the second heavy task didn't start after the first heavy task finished")
+	}
+}
+
+func TestMemoryBudget_CancelledWhileWaiting(t *testing.T) {
+	budget := newMemoryBudget(100)
+	budget.pollInterval = time.Hour
+	budget.readHeapBytes = func() uint64 { return 200 }
+
+	release, err := budget.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = budget.acquire(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestLocalRunner_WithMemoryLimit(t *testing.T) {
+	var running atomic.Int32
+	var maxRunning atomic.Int32
+	heavyTask := func(id string) UntypedTask {
+		return NewTask(taskid.NewDefaultImplementationID[any](id), nil, func(ctx context.Context) (any, error) {
+			current := running.Add(1)
+			if current > maxRunning.Load() {
+				maxRunning.Store(current)
+			}
+			time.Sleep(10 * time.Millisecond)
+			running.Add(-1)
+			return nil, nil
+		}, WithMemoryHeavy())
+	}
+
+	taskSet, err := NewTaskSet([]UntypedTask{heavyTask("task1"), heavyTask("task2"), heavyTask("task3")})
+	if err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+	sortResult := taskSet.sortTaskGraph()
+	runnableSet := &TaskSet{tasks: sortResult.TopologicalSortedTasks, runnable: true}
+
+	runner, err := NewLocalRunner(runnableSet)
+	if err != nil {
+		t.Fatalf("Failed to create runner: %v", err)
+	}
+	runner.WithMemoryLimit(100)
+	runner.memoryBudget.pollInterval = time.Hour
+	runner.memoryBudget.readHeapBytes = func() uint64 { return 200 }
+
+	err = runner.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to run task: %v", err)
+	}
+	<-runner.Wait()
+
+	if _, err := runner.Result(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if maxRunning.Load() != 1 {
+		t.Errorf("Expected heavy tasks to run one by one over the memory limit, got max running count %d", maxRunning.Load())
+	}
+}
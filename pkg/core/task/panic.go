@@ -0,0 +1,56 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coretask
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/kyasbal/khi/pkg/common/errorreport"
+)
+
+// TaskPanicError is the error returned as the result of a task raised a panic.
+// The task runner converts panics in tasks to this error not to crash the whole process with a bug in a single task.
+type TaskPanicError struct {
+	// TaskID is the task implementation ID of the task raised the panic.
+	TaskID string
+	// Value is the value given to the panic.
+	Value any
+	// Stack is the stack trace of the goroutine at the panic.
+	Stack string
+}
+
+// Error implements error.
+func (e *TaskPanicError) Error() string {
+	return fmt.Sprintf("task %s panicked: %v", e.TaskID, e.Value)
+}
+
+// runRecoveringPanic calls the given function and converts a panic raised in it to a TaskPanicError.
+// The panic is also reported with the default error reporter.
+func runRecoveringPanic(ctx context.Context, task UntypedTask, f func(ctx context.Context) (any, error)) (result any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicErr := &TaskPanicError{
+				TaskID: task.UntypedID().String(),
+				Value:  r,
+				Stack:  string(debug.Stack()),
+			}
+			errorreport.DefaultErrorReporter.ReportSync(ctx, fmt.Errorf("%s\n%s", panicErr.Error(), panicErr.Stack))
+			result, err = nil, panicErr
+		}
+	}()
+	return f(ctx)
+}
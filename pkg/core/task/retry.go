@@ -0,0 +1,137 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coretask
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	core_contract "github.com/kyasbal/khi/pkg/task/core/contract"
+)
+
+// LabelKeyTaskRetryPolicy is the RetryPolicy used by the task runner when the task returned an error.
+var LabelKeyTaskRetryPolicy = NewTaskLabelKey[*RetryPolicy](KHISystemPrefix + "task-retry-policy")
+
+// RetryPolicy defines how many times and how long the runner waits before retrying a failed task.
+// The backoff between attempts grows exponentially from InitialBackoff by Multiplier until it reaches MaxBackoff.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum count of task executions including the first attempt.
+	MaxAttempts int
+	// InitialBackoff is the wait duration before the second attempt.
+	InitialBackoff time.Duration
+	// MaxBackoff is the upper limit of the wait duration between attempts.
+	MaxBackoff time.Duration
+	// Multiplier is the factor applied to the backoff after each failed attempt.
+	Multiplier float64
+	// Jitter is the ratio of randomization applied to each backoff. 0.2 randomizes the backoff within ±20%.
+	Jitter float64
+	// Retryable decides if the task is retried after it returned the error. Any error is retried when it is nil.
+	Retryable func(err error) bool
+}
+
+// NewExponentialBackoffRetryPolicy returns a RetryPolicy doubling its backoff from 1 second up to 30 seconds with 20% jitter.
+func NewExponentialBackoffRetryPolicy(maxAttempts int) *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    maxAttempts,
+		InitialBackoff: time.Second,
+		MaxBackoff:     30 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// WithRetry returns a LabelOpt to retry the task with the given policy when it failed.
+func WithRetry(policy *RetryPolicy) LabelOpt {
+	return WithLabelValue(LabelKeyTaskRetryPolicy, policy)
+}
+
+// backoff returns the wait duration after the given count of failed attempts.
+func (p *RetryPolicy) backoff(failedAttempts int) time.Duration {
+	backoff := float64(p.InitialBackoff)
+	for i := 1; i < failedAttempts; i++ {
+		backoff *= p.Multiplier
+		if p.MaxBackoff > 0 && backoff >= float64(p.MaxBackoff) {
+			backoff = float64(p.MaxBackoff)
+			break
+		}
+	}
+	if p.Jitter > 0 {
+		backoff *= 1 + p.Jitter*(rand.Float64()*2-1)
+	}
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+	return time.Duration(backoff)
+}
+
+// IsFinalAttempt returns true when the error returned from the current attempt of the task won't be retried.
+// Tasks use it to report the error only once after the last attempt. It always returns true when the task isn't retried.
+func IsFinalAttempt(ctx context.Context, err error) bool {
+	attempt, getErr := khictx.GetValue(ctx, core_contract.TaskAttemptContextKey)
+	if getErr != nil {
+		return true
+	}
+	return attempt.IsFinal(err)
+}
+
+// AttemptState returns the map shared among the attempts of the current task run.
+// Tasks store their progress in it to resume the work from where the failed attempt stopped. It returns an empty map when the task isn't retried.
+func AttemptState(ctx context.Context) *typedmap.TypedMap {
+	attempt, err := khictx.GetValue(ctx, core_contract.TaskAttemptContextKey)
+	if err != nil {
+		return typedmap.NewTypedMap()
+	}
+	return attempt.State
+}
+
+// runWithRetry calls the given function until it succeeds or the count of attempts reaches the limit of the policy.
+// It stops retrying when the context is cancelled and returns the last error.
+func runWithRetry(ctx context.Context, policy *RetryPolicy, f func(ctx context.Context) (any, error)) (any, error) {
+	if policy == nil || policy.MaxAttempts <= 1 {
+		return f(ctx)
+	}
+	var lastErr error
+	state := typedmap.NewTypedMap()
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		isFinal := func(err error) bool {
+			return attempt == policy.MaxAttempts || ctx.Err() != nil || (policy.Retryable != nil && !policy.Retryable(err))
+		}
+		attemptCtx := khictx.WithValue(ctx, core_contract.TaskAttemptContextKey, &core_contract.TaskAttempt{
+			IsFinal: isFinal,
+			State:   state,
+		})
+		result, err := f(attemptCtx)
+		if err == nil {
+			return result, nil
+		}
+		lastErr = err
+		if isFinal(err) {
+			break
+		}
+		backoff := policy.backoff(attempt)
+		slog.WarnContext(ctx, fmt.Sprintf("task failed at attempt %d/%d. retrying after %s\n%v", attempt, policy.MaxAttempts, backoff, err))
+		select {
+		case <-ctx.Done():
+			return nil, lastErr
+		case <-time.After(backoff):
+		}
+	}
+	return nil, lastErr
+}
@@ -0,0 +1,222 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coretask
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := &RetryPolicy{
+		MaxAttempts:    10,
+		InitialBackoff: time.Second,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+	}
+	testCases := []struct {
+		failedAttempts int
+		want           time.Duration
+	}{
+		{failedAttempts: 1, want: time.Second},
+		{failedAttempts: 2, want: 2 * time.Second},
+		{failedAttempts: 3, want: 4 * time.Second},
+		{failedAttempts: 4, want: 5 * time.Second},
+		{failedAttempts: 8, want: 5 * time.Second},
+	}
+	for _, tc := range testCases {
+		got := policy.backoff(tc.failedAttempts)
+		if got != tc.want {
+			t.Errorf("backoff(%d) = %s, want %s", tc.failedAttempts, got, tc.want)
+		}
+	}
+}
+
+func TestRetryPolicyBackoffWithJitter(t *testing.T) {
+	policy := &RetryPolicy{
+		MaxAttempts:    10,
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Minute,
+		Multiplier:     2,
+		Jitter:         0.5,
+	}
+	for i := 0; i < 100; i++ {
+		got := policy.backoff(2)
+		if got < time.Second || got > 3*time.Second {
+			t.Fatalf("backoff(2) = %s, want within [1s, 3s]", got)
+		}
+	}
+}
+
+func TestRunWithRetry(t *testing.T) {
+	errTransient := errors.New("transient error")
+	testCases := []struct {
+		name         string
+		policy       *RetryPolicy
+		failCount    int
+		wantErr      bool
+		wantAttempts int
+	}{
+		{
+			name:         "without policy",
+			policy:       nil,
+			failCount:    1,
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+		{
+			name:         "succeeds after retries",
+			policy:       &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, Multiplier: 2},
+			failCount:    2,
+			wantErr:      false,
+			wantAttempts: 3,
+		},
+		{
+			name:         "fails after reaching max attempts",
+			policy:       &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, Multiplier: 2},
+			failCount:    5,
+			wantErr:      true,
+			wantAttempts: 3,
+		},
+		{
+			name:         "retries retryable errors",
+			policy:       &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, Multiplier: 2, Retryable: func(err error) bool { return errors.Is(err, errTransient) }},
+			failCount:    2,
+			wantErr:      false,
+			wantAttempts: 3,
+		},
+		{
+			name:         "doesn't retry non retryable errors",
+			policy:       &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, Multiplier: 2, Retryable: func(err error) bool { return false }},
+			failCount:    2,
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			attempts := 0
+			result, err := runWithRetry(context.Background(), tc.policy, func(ctx context.Context) (any, error) {
+				attempts++
+				if attempts <= tc.failCount {
+					return nil, errTransient
+				}
+				return "ok", nil
+			})
+			if tc.wantErr {
+				if !errors.Is(err, errTransient) {
+					t.Errorf("got error %v, want %v", err, errTransient)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("got error %v, want nil", err)
+				}
+				if result != "ok" {
+					t.Errorf("got result %v, want ok", result)
+				}
+			}
+			if attempts != tc.wantAttempts {
+				t.Errorf("got %d attempts, want %d", attempts, tc.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestRunWithRetryStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	_, err := runWithRetry(ctx, &RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Hour}, func(ctx context.Context) (any, error) {
+		attempts++
+		cancel()
+		return nil, errors.New("failed")
+	})
+	if err == nil {
+		t.Error("got nil error, want an error")
+	}
+	if attempts != 1 {
+		t.Errorf("got %d attempts, want 1", attempts)
+	}
+}
+
+func TestRunWithRetryAttempt(t *testing.T) {
+	stateKey := typedmap.NewTypedKey[int]("attempts")
+	var finals []bool
+	_, err := runWithRetry(context.Background(), &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, Multiplier: 2}, func(ctx context.Context) (any, error) {
+		state := AttemptState(ctx)
+		attempts := typedmap.GetOrDefault(state, stateKey, 0) + 1
+		typedmap.Set(state, stateKey, attempts)
+		err := fmt.Errorf("failed at attempt %d", attempts)
+		finals = append(finals, IsFinalAttempt(ctx, err))
+		return nil, err
+	})
+	if err == nil || err.Error() != "failed at attempt 3" {
+		t.Errorf("got error %v, want the error of the third attempt sharing the state", err)
+	}
+	if diff := cmp.Diff([]bool{false, false, true}, finals); diff != "" {
+		t.Errorf("IsFinalAttempt mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestIsFinalAttemptWithoutRetry(t *testing.T) {
+	if !IsFinalAttempt(context.Background(), errors.New("failed")) {
+		t.Error("IsFinalAttempt() = false, want true without retry")
+	}
+	if AttemptState(context.Background()) == nil {
+		t.Error("AttemptState() = nil, want an empty map without retry")
+	}
+}
+
+func TestLocalRunner_TaskWithRetry(t *testing.T) {
+	attempts := 0
+	task := NewTask(taskid.NewDefaultImplementationID[any]("task1"), nil, func(ctx context.Context) (any, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, errors.New("transient error")
+		}
+		return "result", nil
+	}, WithRetry(&RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, Multiplier: 2}))
+
+	taskSet, err := NewTaskSet([]UntypedTask{task})
+	if err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+	runnableSet, err := taskSet.ToRunnableTaskSet()
+	if err != nil {
+		t.Fatalf("Failed to sort task set: %v", err)
+	}
+	runner, err := NewLocalRunner(runnableSet)
+	if err != nil {
+		t.Fatalf("Failed to create runner: %v", err)
+	}
+	if err := runner.Run(context.Background()); err != nil {
+		t.Fatalf("Failed to run task: %v", err)
+	}
+	<-runner.Wait()
+
+	if _, err := runner.Result(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	val, found := GetTaskResultFromLocalRunner(runner, taskid.NewTaskReference[string]("task1"))
+	if !found || val != "result" {
+		t.Errorf("Expected task result 'result', got '%v'(found=%v)", val, found)
+	}
+}
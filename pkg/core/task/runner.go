@@ -38,13 +38,25 @@ type Interceptor func(ctx context.Context, task UntypedTask, next func(context.C
 type LocalRunner struct {
 	resolvedTaskSet *TaskSet
 	resultVariable  *typedmap.TypedMap
+	skippedTasks    *typedmap.TypedMap
+	failedTasks     *typedmap.TypedMap
 	resultError     error
+	terminalState   TaskRunnerTerminalState
 	started         bool
 	stopped         bool
 	taskWaiters     *typedmap.ReadonlyTypedMap
-	waiter          chan interface{}
+	waiter          chan struct{}
 	taskStatuses    []*LocalRunnerTaskStat
-	interceptors    []Interceptor
+	// taskStatusLock guards taskStatuses. They are written by the goroutines running tasks and can be read while the graph is running.
+	taskStatusLock sync.RWMutex
+	interceptors   []Interceptor
+	// executionSlots limits the count of tasks running at the same time. It is nil when the concurrency is unlimited.
+	executionSlots *executionSlotQueue
+	// memoryBudget delays memory heavy tasks while the heap usage is over the limit. It is nil when the memory limit is not set.
+	memoryBudget *memoryBudget
+	// failureContainable tells if a failure of the task at the same index can be isolated without aborting the graph.
+	failureContainable      []bool
+	isolatedFailureHandlers []IsolatedFailureHandler
 }
 
 // LocalRunner implements task_interface.TaskRunner
@@ -57,6 +69,13 @@ type LocalRunnerTaskStat struct {
 	Error     error
 	StartTime time.Time
 	EndTime   time.Time
+	// Skipped is true when the task was not executed because its SkipPredicate returned true.
+	Skipped bool
+	// HeapDeltaBytes is the difference of the process heap usage between the start and the end of the task.
+	// It is sampled only when the runner has a memory limit. The value also includes allocations of other tasks running concurrently.
+	HeapDeltaBytes int64
+	// CacheHit is true when the task reported it reused its cached result with RecordCacheHit.
+	CacheHit bool
 }
 
 const (
@@ -88,14 +107,15 @@ func NewLocalRunner(taskSet *TaskSet) (*LocalRunner, error) {
 		typedmap.Set(taskWaiters, waiterKeyForTask(taskSet.tasks[i].UntypedID().GetUntypedReference()), &waiter)
 	}
 	return &LocalRunner{
-		resolvedTaskSet: taskSet,
-		started:         false,
-		resultVariable:  nil,
-		resultError:     nil,
-		stopped:         false,
-		taskWaiters:     taskWaiters.AsReadonly(),
-		waiter:          make(chan interface{}),
-		taskStatuses:    taskStatuses,
+		resolvedTaskSet:    taskSet,
+		started:            false,
+		resultVariable:     nil,
+		resultError:        nil,
+		stopped:            false,
+		taskWaiters:        taskWaiters.AsReadonly(),
+		waiter:             make(chan struct{}),
+		taskStatuses:       taskStatuses,
+		failureContainable: failureContainableTasks(taskSet.tasks),
 	}, nil
 }
 
@@ -106,12 +126,42 @@ func GetTaskResultFromLocalRunner[TaskResult any](runner *LocalRunner, taskRef t
 	return typedmap.Get(runner.resultVariable, typedmap.NewTypedKey[TaskResult](taskRef.String()))
 }
 
+// WithMaxConcurrency limits the number of tasks running concurrently to maxConcurrency.
+// Tasks ready to run over the limit wait until another running task finishes, and start in the order of LabelKeyTaskExecutionPriority.
+// A value less than or equal to 0 removes the limit. This must be called before Run.
+func (r *LocalRunner) WithMaxConcurrency(maxConcurrency int) *LocalRunner {
+	if maxConcurrency <= 0 {
+		r.executionSlots = nil
+		return r
+	}
+	r.executionSlots = newExecutionSlotQueue(maxConcurrency)
+	return r
+}
+
+// WithMemoryLimit delays starting tasks labeled with LabelKeyTaskMemoryHeavy while the heap usage of the process is over limitBytes.
+// It also enables sampling heap usage of each task in LocalRunnerTaskStat.
+// A value of 0 removes the limit. This must be called before Run.
+func (r *LocalRunner) WithMemoryLimit(limitBytes uint64) *LocalRunner {
+	if limitBytes == 0 {
+		r.memoryBudget = nil
+		return r
+	}
+	r.memoryBudget = newMemoryBudget(limitBytes)
+	return r
+}
+
 // AddInterceptor adds an interceptor to the runner.
 // Interceptors are executed in the order they are added.
 func (r *LocalRunner) AddInterceptor(interceptor Interceptor) {
 	r.interceptors = append(r.interceptors, interceptor)
 }
 
+// AddIsolatedFailureHandler adds a handler called when a task labeled with LabelKeyTaskFailureIsolated failed without aborting the graph.
+// Handlers are called before tasks depending on the failed task start. This must be called before Run.
+func (r *LocalRunner) AddIsolatedFailureHandler(handler IsolatedFailureHandler) {
+	r.isolatedFailureHandlers = append(r.isolatedFailureHandlers, handler)
+}
+
 // Run starts the execution of the task graph in a non-blocking manner.
 // It launches a goroutine to manage the entire execution process.
 // It returns an error if the runner has already been started.
@@ -119,12 +169,16 @@ func (r *LocalRunner) Run(ctx context.Context) error {
 	if r.started {
 		return fmt.Errorf("this task is already started before")
 	}
+	r.started = true
 	go func() {
 		defer r.finalizeExecution()
 
 		// Setting up graph context
 		r.resultVariable = typedmap.NewTypedMap()
+		r.skippedTasks = typedmap.NewTypedMap()
+		r.failedTasks = typedmap.NewTypedMap()
 		ctx = khictx.WithValue(ctx, core_contract.TaskResultMapContextKey, r.resultVariable)
+		ctx = khictx.WithValue(ctx, core_contract.SkippedTaskSetContextKey, r.skippedTasks)
 
 		tasks := r.resolvedTaskSet.GetAll()
 		cancelableCtx, cancel := context.WithCancel(ctx)
@@ -146,20 +200,34 @@ func (r *LocalRunner) Run(ctx context.Context) error {
 			r.resultError = err
 		}
 		cancel()
+		switch {
+		case ctx.Err() != nil:
+			r.terminalState = TaskRunnerStateCancelled
+		case r.resultError != nil:
+			r.terminalState = TaskRunnerStateFailed
+		default:
+			r.terminalState = TaskRunnerStateCompleted
+		}
 	}()
 	return nil
 }
 
-// Wait returns a channel that is closed when the runner finishes executing all tasks
-// in the graph. This is the primary mechanism for waiting for the completion of the
-// entire task set.
-func (r *LocalRunner) Wait() <-chan interface{} {
-	return r.waiter
+// Wait returns a channel receiving the terminal state of the runner after it finished executing all tasks
+// in the graph. The channel is closed after sending the state. This is the primary mechanism for waiting for the completion of the
+// entire task set. Each call returns a new channel, thus multiple callers can wait for the same runner.
+func (r *LocalRunner) Wait() <-chan TaskRunnerTerminalState {
+	result := make(chan TaskRunnerTerminalState, 1)
+	go func() {
+		<-r.waiter
+		result <- r.terminalState
+		close(result)
+	}()
+	return result
 }
 
 // Result returns the final results of the task graph execution.
 // It returns a map of task results if the execution was successful, or an error
-// if any task failed or the runner has not yet completed.
+// if any task failed without being isolated or the runner has not yet completed.
 // This method should only be called after the channel from Wait() has been closed.
 func (r *LocalRunner) Result() (*typedmap.ReadonlyTypedMap, error) {
 	if !r.stopped {
@@ -174,8 +242,23 @@ func (r *LocalRunner) Result() (*typedmap.ReadonlyTypedMap, error) {
 // TaskStatuses returns a slice of LocalRunnerTaskStat, providing the status
 // and execution details for each task in the runner's task set.
 // The order of statuses corresponds to the order of tasks in the resolved TaskSet.
+// The returned values are snapshots taken at the call and they are safe to read while the runner is running.
 func (r *LocalRunner) TaskStatuses() []*LocalRunnerTaskStat {
-	return r.taskStatuses
+	r.taskStatusLock.RLock()
+	defer r.taskStatusLock.RUnlock()
+	result := make([]*LocalRunnerTaskStat, len(r.taskStatuses))
+	for i, status := range r.taskStatuses {
+		statusCopy := *status
+		result[i] = &statusCopy
+	}
+	return result
+}
+
+// updateTaskStatus modifies the status of the task at the given index with holding the lock for the task statuses.
+func (r *LocalRunner) updateTaskStatus(taskDefIndex int, update func(status *LocalRunnerTaskStat)) {
+	r.taskStatusLock.Lock()
+	defer r.taskStatusLock.Unlock()
+	update(r.taskStatuses[taskDefIndex])
 }
 
 // runTask manages the entire lifecycle of a single task within the graph.
@@ -183,8 +266,12 @@ func (r *LocalRunner) TaskStatuses() []*LocalRunnerTaskStat {
 // records its status, and stores its result or handles any errors.
 func (r *LocalRunner) runTask(graphCtx context.Context, taskDefIndex int) error {
 	task := r.resolvedTaskSet.GetAll()[taskDefIndex]
-	taskStatus := r.taskStatuses[taskDefIndex]
 	taskCtx := khictx.WithValue(graphCtx, core_contract.TaskImplementationIDContextKey, task.UntypedID())
+	taskCtx = khictx.WithValue(taskCtx, core_contract.TaskCacheHitRecorderContextKey, func(hit bool) {
+		r.updateTaskStatus(taskDefIndex, func(status *LocalRunnerTaskStat) {
+			status.CacheHit = hit
+		})
+	})
 
 	// Wait for completions of all dependencies.
 	for _, dependency := range task.Dependencies() {
@@ -194,13 +281,51 @@ func (r *LocalRunner) runTask(graphCtx context.Context, taskDefIndex int) error
 		}
 	}
 
-	taskStatus.StartTime = time.Now()
-	taskStatus.Phase = LocalRunnerTaskStatPhaseRunning
+	if r.failureContainable[taskDefIndex] {
+		if failedDependency, found := r.findFailedDependency(task); found {
+			return r.failTask(taskCtx, taskDefIndex, fmt.Errorf("dependency %s failed", failedDependency.ReferenceIDString()))
+		}
+	}
+
+	skipped, err := r.skipTaskIfNeeded(taskCtx, task, taskDefIndex)
+	if err != nil {
+		return r.failTask(taskCtx, taskDefIndex, err)
+	}
+	if skipped {
+		return nil
+	}
+
+	releaseMemory, err := r.acquireMemoryBudget(taskCtx, task)
+	if err != nil {
+		return err
+	}
+	releaseSlot, err := r.acquireExecutionSlot(taskCtx, task, taskDefIndex)
+	if err != nil {
+		releaseMemory()
+		return err
+	}
+	// The slot can be released by a task that stopped due to the cancellation. Don't start the task in that case.
+	if err := taskCtx.Err(); err != nil {
+		releaseSlot()
+		releaseMemory()
+		return err
+	}
+
+	var heapBytesAtStart uint64
+	if r.memoryBudget != nil {
+		heapBytesAtStart = r.memoryBudget.readHeapBytes()
+	}
+	startTime := time.Now()
+	r.updateTaskStatus(taskDefIndex, func(status *LocalRunnerTaskStat) {
+		status.StartTime = startTime
+		status.Phase = LocalRunnerTaskStatPhaseRunning
+	})
 	slog.DebugContext(taskCtx, fmt.Sprintf("task %s started", task.UntypedID()))
 
 	// Run the task with interceptors
+	retryPolicy := typedmap.GetOrDefault[*RetryPolicy](task.Labels(), LabelKeyTaskRetryPolicy, nil)
 	runFunc := func(ctx context.Context) (any, error) {
-		return task.UntypedRun(ctx)
+		return runWithRetry(ctx, retryPolicy, task.UntypedRun)
 	}
 
 	// Chain interceptors in reverse order so the first interceptor is the outer-most wrapper
@@ -212,20 +337,41 @@ func (r *LocalRunner) runTask(graphCtx context.Context, taskDefIndex int) error
 		}
 	}
 
-	result, err := runFunc(taskCtx)
+	runCtx := taskCtx
+	timeout := typedmap.GetOrDefault(task.Labels(), LabelKeyTaskTimeout, 0)
+	if timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		runCtx, cancelTimeout = context.WithTimeout(taskCtx, timeout)
+		defer cancelTimeout()
+	}
 
-	taskStatus.Phase = LocalRunnerTaskStatPhaseStopped
-	taskStatus.EndTime = time.Now()
-	slog.DebugContext(taskCtx, fmt.Sprintf("task %s stopped after %f sec", task.UntypedID(), taskStatus.EndTime.Sub(taskStatus.StartTime).Seconds()))
-	taskStatus.Error = err
+	// Recover panics outside of the interceptors to convert panics raised in interceptors to the task error as well.
+	result, err := runRecoveringPanic(runCtx, task, runFunc)
+	releaseSlot()
+	releaseMemory()
+	var heapDeltaBytes int64
+	if r.memoryBudget != nil {
+		heapDeltaBytes = int64(r.memoryBudget.readHeapBytes()) - int64(heapBytesAtStart)
+	}
+	endTime := time.Now()
+	slog.DebugContext(taskCtx, fmt.Sprintf("task %s stopped after %f sec", task.UntypedID(), endTime.Sub(startTime).Seconds()))
+	if taskCtx.Err() != context.Canceled && runCtx.Err() == context.DeadlineExceeded {
+		if err == nil {
+			err = context.DeadlineExceeded
+		}
+		err = fmt.Errorf("task timed out after %s: %w", timeout, err)
+	}
+	r.updateTaskStatus(taskDefIndex, func(status *LocalRunnerTaskStat) {
+		status.HeapDeltaBytes = heapDeltaBytes
+		status.Phase = LocalRunnerTaskStatPhaseStopped
+		status.EndTime = endTime
+		status.Error = err
+	})
 	if taskCtx.Err() == context.Canceled {
 		return context.Canceled
 	}
 	if err != nil {
-		detailedErr := r.wrapWithTaskError(err, task)
-		r.resultError = detailedErr
-		slog.ErrorContext(taskCtx, err.Error())
-		return detailedErr
+		return r.failTask(taskCtx, taskDefIndex, err)
 	}
 
 	// store the task result to result map
@@ -236,6 +382,95 @@ func (r *LocalRunner) runTask(graphCtx context.Context, taskDefIndex int) error
 	return nil
 }
 
+// TaskExecutionDescription is a serializable snapshot of the execution state of tasks in a LocalRunner.
+type TaskExecutionDescription struct {
+	// Tasks is the list of tasks in the graph in topological order.
+	Tasks []*TaskExecutionNodeDescription `json:"tasks"`
+}
+
+// TaskExecutionNodeDescription describes the execution state of a task in a TaskExecutionDescription.
+type TaskExecutionNodeDescription struct {
+	// ID is the task implementation ID.
+	ID string `json:"id"`
+	// Dependencies is the list of task implementation IDs resolved for the dependencies of this task.
+	Dependencies []string `json:"dependencies"`
+	// State is one of TaskExecutionState* values.
+	State string `json:"state"`
+	// StartTime is the time when the task started. It is nil when the task hasn't started yet.
+	StartTime *time.Time `json:"startTime,omitempty"`
+	// EndTime is the time when the task finished. It is nil when the task hasn't finished yet.
+	EndTime *time.Time `json:"endTime,omitempty"`
+	// DurationSeconds is the elapsed time of the task. The duration until now is used for running tasks.
+	DurationSeconds float64 `json:"durationSeconds"`
+	// CacheHit is true when the task reused its cached result.
+	CacheHit bool `json:"cacheHit"`
+	// Error is the error message returned from the task. It is empty when the task hasn't failed.
+	Error string `json:"error,omitempty"`
+}
+
+const (
+	// TaskExecutionStateWaiting indicates that the task is waiting for its dependencies or resources to start.
+	TaskExecutionStateWaiting = "waiting"
+	// TaskExecutionStateRunning indicates that the task is currently running.
+	TaskExecutionStateRunning = "running"
+	// TaskExecutionStateDone indicates that the task completed successfully.
+	TaskExecutionStateDone = "done"
+	// TaskExecutionStateFailed indicates that the task finished with an error.
+	TaskExecutionStateFailed = "failed"
+	// TaskExecutionStateSkipped indicates that the task was skipped by its SkipPredicate.
+	TaskExecutionStateSkipped = "skipped"
+)
+
+// DescribeTaskExecution returns the current execution state of each task in the graph.
+// This can be called while the runner is running to diagnose stuck tasks.
+func (r *LocalRunner) DescribeTaskExecution() *TaskExecutionDescription {
+	tasks := r.resolvedTaskSet.GetAll()
+	result := &TaskExecutionDescription{
+		Tasks: make([]*TaskExecutionNodeDescription, 0, len(tasks)),
+	}
+	now := time.Now()
+	statuses := r.TaskStatuses()
+	sourceRelation := map[string]UntypedTask{}
+	for i, task := range tasks {
+		status := statuses[i]
+		dependencies := make([]string, 0, len(task.Dependencies()))
+		for _, source := range task.Dependencies() {
+			dependencies = append(dependencies, sourceRelation[source.ReferenceIDString()].UntypedID().String())
+		}
+		node := &TaskExecutionNodeDescription{
+			ID:           task.UntypedID().String(),
+			Dependencies: dependencies,
+			CacheHit:     status.CacheHit,
+		}
+		switch {
+		case status.Phase == LocalRunnerTaskStatPhaseWaiting:
+			node.State = TaskExecutionStateWaiting
+		case status.Phase == LocalRunnerTaskStatPhaseRunning:
+			node.State = TaskExecutionStateRunning
+		case status.Skipped:
+			node.State = TaskExecutionStateSkipped
+		case status.Error != nil:
+			node.State = TaskExecutionStateFailed
+			node.Error = status.Error.Error()
+		default:
+			node.State = TaskExecutionStateDone
+		}
+		if !status.StartTime.IsZero() {
+			startTime := status.StartTime
+			node.StartTime = &startTime
+			endTime := now
+			if !status.EndTime.IsZero() {
+				endTime = status.EndTime
+				node.EndTime = &endTime
+			}
+			node.DurationSeconds = endTime.Sub(startTime).Seconds()
+		}
+		result.Tasks = append(result.Tasks, node)
+		sourceRelation[task.UntypedID().ReferenceIDString()] = task
+	}
+	return result
+}
+
 func (r *LocalRunner) Tasks() []UntypedTask {
 	return r.resolvedTaskSet.GetAll()
 }
@@ -271,6 +506,93 @@ func (r *LocalRunner) waitForDependency(ctx context.Context, task taskid.Untyped
 	}
 }
 
+// failTask records the error of the task. When the failure can be isolated, the zero value of the task result type is stored as its result
+// and the task is completed without aborting the graph. Otherwise it returns the detailed error to abort the graph.
+func (r *LocalRunner) failTask(ctx context.Context, taskDefIndex int, err error) error {
+	task := r.resolvedTaskSet.GetAll()[taskDefIndex]
+	r.updateTaskStatus(taskDefIndex, func(status *LocalRunnerTaskStat) {
+		status.Phase = LocalRunnerTaskStatPhaseStopped
+		status.Error = err
+	})
+	if !r.failureContainable[taskDefIndex] {
+		detailedErr := r.wrapWithTaskError(err, task)
+		slog.ErrorContext(ctx, err.Error())
+		return detailedErr
+	}
+	slog.WarnContext(ctx, fmt.Sprintf("task %s failed but the failure is isolated from other tasks\n%v", task.UntypedID(), err))
+
+	ref := task.UntypedID().GetUntypedReference()
+	typedmap.Set(r.failedTasks, failedKeyForTask(ref), err)
+	typedmap.Set(r.resultVariable, typedmap.NewTypedKey[any](ref.ReferenceIDString()), zeroResultOf(task))
+	if isFailureBoundary(task) {
+		for _, handler := range r.isolatedFailureHandlers {
+			handler(ctx, task, err)
+		}
+	}
+	r.releaseTaskWaiter(task.UntypedID())
+	return nil
+}
+
+// findFailedDependency returns the first dependency of the task failed with an isolated failure.
+func (r *LocalRunner) findFailedDependency(task UntypedTask) (taskid.UntypedTaskReference, bool) {
+	for _, dependency := range task.Dependencies() {
+		if _, found := typedmap.Get(r.failedTasks, failedKeyForTask(dependency)); found {
+			return dependency, true
+		}
+	}
+	return nil, false
+}
+
+// skipTaskIfNeeded evaluates the SkipPredicate of the task and completes the task without running it when the predicate returned true.
+// The zero value of the task result type is stored as the result of the skipped task and the task is recorded in the skipped task set.
+func (r *LocalRunner) skipTaskIfNeeded(ctx context.Context, task UntypedTask, taskDefIndex int) (bool, error) {
+	predicate := typedmap.GetOrDefault[SkipPredicate](task.Labels(), LabelKeyTaskSkipPredicate, nil)
+	if predicate == nil {
+		return false, nil
+	}
+	skip, err := predicate(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate the skip predicate: %w", err)
+	}
+	if !skip {
+		return false, nil
+	}
+	now := time.Now()
+	r.updateTaskStatus(taskDefIndex, func(status *LocalRunnerTaskStat) {
+		status.StartTime = now
+		status.EndTime = now
+		status.Skipped = true
+		status.Phase = LocalRunnerTaskStatPhaseStopped
+	})
+	slog.DebugContext(ctx, fmt.Sprintf("task %s skipped", task.UntypedID()))
+
+	ref := task.UntypedID().GetUntypedReference()
+	typedmap.Set(r.skippedTasks, skippedKeyForTask(ref), true)
+	typedmap.Set(r.resultVariable, typedmap.NewTypedKey[any](ref.ReferenceIDString()), zeroResultOf(task))
+	r.releaseTaskWaiter(task.UntypedID())
+	return true, nil
+}
+
+// acquireExecutionSlot blocks until the task can start without exceeding the concurrency limit.
+// Tasks with the same priority are started in the topological order to keep the scheduling deterministic.
+// It returns a function to release the acquired slot. The wait is interrupted when the context is cancelled.
+func (r *LocalRunner) acquireExecutionSlot(ctx context.Context, task UntypedTask, taskDefIndex int) (func(), error) {
+	if r.executionSlots == nil {
+		return func() {}, nil
+	}
+	priority := typedmap.GetOrDefault(task.Labels(), LabelKeyTaskExecutionPriority, TaskExecutionPriorityDefault)
+	return r.executionSlots.acquire(ctx, priority, taskDefIndex)
+}
+
+// acquireMemoryBudget blocks until the task can start under the memory limit when the task is labeled as a memory heavy task.
+// It returns a function to be called when the task finished. The wait is interrupted when the context is cancelled.
+func (r *LocalRunner) acquireMemoryBudget(ctx context.Context, task UntypedTask) (func(), error) {
+	if r.memoryBudget == nil || !typedmap.GetOrDefault(task.Labels(), LabelKeyTaskMemoryHeavy, false) {
+		return func() {}, nil
+	}
+	return r.memoryBudget.acquire(ctx)
+}
+
 // releaseTaskWaiter releases a waiter for a single task as complete by unlocking its corresponding
 // RWMutex. This allows any tasks that depend on it to proceed.
 func (r *LocalRunner) releaseTaskWaiter(task taskid.UntypedTaskImplementationID) error {
@@ -288,11 +610,18 @@ func (r *LocalRunner) releaseTaskWaiter(task taskid.UntypedTaskImplementationID)
 // It marks the runner as stopped, closes the main waiter channel to signal completion,
 // and ensures all task waiter locks are released.
 func (r *LocalRunner) finalizeExecution() {
-	r.stopped = true
-	close(r.waiter)
+	// Release the waiters first to let goroutines waiting for dependencies of cancelled tasks exit before notifying the completion.
 	for _, task := range r.resolvedTaskSet.tasks {
 		r.releaseTaskWaiter(task.UntypedID())
 	}
+	r.stopped = true
+	close(r.waiter)
+}
+
+// failedKeyForTask is a helper function that creates a type-safe
+// key for accessing the error of a failed task in the failed task set.
+func failedKeyForTask(taskID taskid.UntypedTaskReference) typedmap.TypedKey[error] {
+	return typedmap.NewTypedKey[error](taskID.ReferenceIDString())
 }
 
 // waiterKeyForTask is a helper function that creates a type-safe
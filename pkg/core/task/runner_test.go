@@ -17,6 +17,8 @@ package coretask
 import (
 	"context"
 	"errors"
+	"fmt"
+	"runtime"
 	"strings"
 	"sync"
 	"testing"
@@ -239,6 +241,127 @@ func TestLocalRunner_ContextCancellation(t *testing.T) {
 	}
 }
 
+func TestLocalRunner_CancellationStopsPendingTasks(t *testing.T) {
+	goroutinesBefore := runtime.NumGoroutine()
+	taskStarted := make(chan struct{})
+	blocking := createMockTask("blocking", nil, func(ctx context.Context) (any, error) {
+		close(taskStarted)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	dependentExecuted := false
+	dependent := createMockTask("dependent", []string{"blocking"}, func(ctx context.Context) (any, error) {
+		dependentExecuted = true
+		return nil, nil
+	})
+	// waitingForSlot can't start after the blocking task started because the blocking task occupies the only execution slot.
+	// It may run before the blocking task depending on the order of arrivals.
+	waitingForSlotExecuted := false
+	waitingForSlot := createMockTask("waiting-for-slot", nil, func(ctx context.Context) (any, error) {
+		select {
+		case <-taskStarted:
+			waitingForSlotExecuted = true
+		default:
+		}
+		return nil, nil
+	})
+
+	taskSet, err := NewTaskSet([]UntypedTask{blocking, dependent, waitingForSlot})
+	if err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+
+	sortResult := taskSet.sortTaskGraph()
+	runnableSet := &TaskSet{tasks: sortResult.TopologicalSortedTasks, runnable: true}
+
+	runner, err := NewLocalRunner(runnableSet)
+	if err != nil {
+		t.Fatalf("Failed to create runner: %v", err)
+	}
+	runner.WithMaxConcurrency(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err = runner.Run(ctx)
+	if err != nil {
+		t.Fatalf("Failed to run task: %v", err)
+	}
+	<-taskStarted
+	cancel()
+
+	select {
+	case state := <-runner.Wait():
+		if state != TaskRunnerStateCancelled {
+			t.Errorf("Expected the terminal state %q, got %q", TaskRunnerStateCancelled, state)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("runner didn't stop promptly after the cancellation")
+	}
+	if dependentExecuted || waitingForSlotExecuted {
+		t.Errorf("pending tasks must not run after the cancellation. dependent=%v, waiting-for-slot=%v", dependentExecuted, waitingForSlotExecuted)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > goroutinesBefore && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if goroutines := runtime.NumGoroutine(); goroutines > goroutinesBefore {
+		t.Errorf("goroutines were left after the cancellation. before=%d, after=%d", goroutinesBefore, goroutines)
+	}
+}
+
+func TestLocalRunner_WaitReturnsTerminalState(t *testing.T) {
+	testCases := []struct {
+		name    string
+		taskErr error
+		want    TaskRunnerTerminalState
+	}{
+		{
+			name: "completed",
+			want: TaskRunnerStateCompleted,
+		},
+		{
+			name:    "failed",
+			taskErr: errors.New("task error"),
+			want:    TaskRunnerStateFailed,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			task := createMockTask("task1", nil, func(ctx context.Context) (any, error) {
+				return nil, tc.taskErr
+			})
+			taskSet, err := NewTaskSet([]UntypedTask{task})
+			if err != nil {
+				t.Fatalf("Failed to create task set: %v", err)
+			}
+
+			sortResult := taskSet.sortTaskGraph()
+			runnableSet := &TaskSet{tasks: sortResult.TopologicalSortedTasks, runnable: true}
+
+			runner, err := NewLocalRunner(runnableSet)
+			if err != nil {
+				t.Fatalf("Failed to create runner: %v", err)
+			}
+			err = runner.Run(context.Background())
+			if err != nil {
+				t.Fatalf("Failed to run task: %v", err)
+			}
+
+			// Every channel returned from Wait receives the state.
+			first, second := runner.Wait(), runner.Wait()
+			if got := <-first; got != tc.want {
+				t.Errorf("Expected the terminal state %q, got %q", tc.want, got)
+			}
+			if got := <-second; got != tc.want {
+				t.Errorf("Expected the terminal state %q from the second waiter, got %q", tc.want, got)
+			}
+			if err := runner.Run(context.Background()); err == nil {
+				t.Error("Expected an error when the runner was started twice")
+			}
+		})
+	}
+}
+
 func TestLocalRunner_AddInterceptor(t *testing.T) {
 	executionOrder := []string{}
 
@@ -296,3 +419,575 @@ func TestLocalRunner_AddInterceptor(t *testing.T) {
 		t.Errorf("Execution order mismatch (-want +got):\n%s", diff)
 	}
 }
+
+func TestLocalRunner_WithMaxConcurrency(t *testing.T) {
+	testCases := []struct {
+		name           string
+		maxConcurrency int
+		taskCount      int
+		wantMaxRunning int
+	}{
+		{
+			name:           "limited to 1",
+			maxConcurrency: 1,
+			taskCount:      5,
+			wantMaxRunning: 1,
+		},
+		{
+			name:           "limited to 2",
+			maxConcurrency: 2,
+			taskCount:      5,
+			wantMaxRunning: 2,
+		},
+		{
+			name:           "unlimited",
+			maxConcurrency: 0,
+			taskCount:      5,
+			wantMaxRunning: 5,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var mu sync.Mutex
+			running := 0
+			maxRunning := 0
+			// All tasks must be started before any task finishes when the concurrency is unlimited.
+			allStarted := make(chan struct{})
+			startedCount := 0
+
+			tasks := []UntypedTask{}
+			for i := 0; i < tc.taskCount; i++ {
+				tasks = append(tasks, createMockTask(fmt.Sprintf("task%d", i), nil, func(ctx context.Context) (any, error) {
+					mu.Lock()
+					running++
+					startedCount++
+					if running > maxRunning {
+						maxRunning = running
+					}
+					if startedCount == tc.taskCount {
+						close(allStarted)
+					}
+					mu.Unlock()
+
+					select {
+					case <-allStarted:
+					case <-time.After(50 * time.Millisecond):
+					}
+
+					mu.Lock()
+					running--
+					mu.Unlock()
+					return nil, nil
+				}))
+			}
+
+			taskSet, err := NewTaskSet(tasks)
+			if err != nil {
+				t.Fatalf("Failed to create task set: %v", err)
+			}
+
+			sortResult := taskSet.sortTaskGraph()
+			runnableSet := &TaskSet{tasks: sortResult.TopologicalSortedTasks, runnable: true}
+
+			runner, err := NewLocalRunner(runnableSet)
+			if err != nil {
+				t.Fatalf("Failed to create runner: %v", err)
+			}
+			runner.WithMaxConcurrency(tc.maxConcurrency)
+
+			err = runner.Run(context.Background())
+			if err != nil {
+				t.Fatalf("Failed to run task: %v", err)
+			}
+
+			<-runner.Wait()
+
+			if _, err := runner.Result(); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if maxRunning != tc.wantMaxRunning {
+				t.Errorf("Expected max running task count %d, got %d", tc.wantMaxRunning, maxRunning)
+			}
+		})
+	}
+}
+
+func TestLocalRunner_TaskTimeout(t *testing.T) {
+	task1 := NewTask(taskid.NewDefaultImplementationID[any]("task1"), nil, func(ctx context.Context) (any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}, WithTimeout(10*time.Millisecond))
+
+	task2Executed := false
+	task2 := createMockTask("task2", []string{"task1"}, func(ctx context.Context) (any, error) {
+		task2Executed = true
+		return "result2", nil
+	})
+
+	taskSet, err := NewTaskSet([]UntypedTask{task1, task2})
+	if err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+
+	sortResult := taskSet.sortTaskGraph()
+	runnableSet := &TaskSet{tasks: sortResult.TopologicalSortedTasks, runnable: true}
+
+	runner, err := NewLocalRunner(runnableSet)
+	if err != nil {
+		t.Fatalf("Failed to create runner: %v", err)
+	}
+
+	err = runner.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to run task: %v", err)
+	}
+
+	select {
+	case <-runner.Wait():
+	case <-time.After(5 * time.Second):
+		t.Fatal("runner didn't finish after the task timeout")
+	}
+
+	_, err = runner.Result()
+	if err == nil {
+		t.Fatal("Expected an error, got nil")
+	}
+	if !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("Expected error containing 'timed out', got '%s'", err.Error())
+	}
+	if task2Executed {
+		t.Error("Dependent task should not be executed when a dependency timed out")
+	}
+	if !errors.Is(runner.TaskStatuses()[0].Error, context.DeadlineExceeded) {
+		t.Errorf("Expected task status error to be context.DeadlineExceeded, got %v", runner.TaskStatuses()[0].Error)
+	}
+}
+
+func TestLocalRunner_SkipPredicate(t *testing.T) {
+	task1ID := taskid.NewDefaultImplementationID[string]("task1")
+	task2ID := taskid.NewDefaultImplementationID[[]string]("task2")
+	task1 := NewTask(task1ID, nil, func(ctx context.Context) (string, error) {
+		return "", nil
+	})
+	task2Executed := false
+	task2 := NewTask(task2ID, []taskid.UntypedTaskReference{task1ID.Ref()}, func(ctx context.Context) ([]string, error) {
+		task2Executed = true
+		return []string{"foo"}, nil
+	}, WithSkipPredicate(func(ctx context.Context) (bool, error) {
+		return GetTaskResult(ctx, task1ID.Ref()) == "", nil
+	}))
+	task3Executed := false
+	task3 := NewTask(taskid.NewDefaultImplementationID[any]("task3"), []taskid.UntypedTaskReference{task2ID.Ref()}, func(ctx context.Context) (any, error) {
+		task3Executed = true
+		return nil, nil
+	}, WithSkipPredicate(SkipWhenAnyTaskSkipped(task2ID.Ref())))
+	var task4GotSkipped bool
+	var task4GotResult []string
+	task4 := NewTask(taskid.NewDefaultImplementationID[any]("task4"), []taskid.UntypedTaskReference{task2ID.Ref()}, func(ctx context.Context) (any, error) {
+		task4GotSkipped = IsTaskSkipped(ctx, task2ID.Ref())
+		task4GotResult = GetTaskResult(ctx, task2ID.Ref())
+		return nil, nil
+	})
+
+	taskSet, err := NewTaskSet([]UntypedTask{task1, task2, task3, task4})
+	if err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+
+	sortResult := taskSet.sortTaskGraph()
+	runnableSet := &TaskSet{tasks: sortResult.TopologicalSortedTasks, runnable: true}
+
+	runner, err := NewLocalRunner(runnableSet)
+	if err != nil {
+		t.Fatalf("Failed to create runner: %v", err)
+	}
+
+	err = runner.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to run task: %v", err)
+	}
+
+	<-runner.Wait()
+
+	if _, err := runner.Result(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if task2Executed {
+		t.Error("task2 should be skipped")
+	}
+	if task3Executed {
+		t.Error("task3 should be skipped because task2 was skipped")
+	}
+	if !task4GotSkipped {
+		t.Error("task4 should see task2 as skipped")
+	}
+	if task4GotResult != nil {
+		t.Errorf("Expected the zero value as the result of skipped task2, got %v", task4GotResult)
+	}
+
+	gotSkipped := map[string]bool{}
+	for i, task := range runner.Tasks() {
+		gotSkipped[task.UntypedID().ReferenceIDString()] = runner.TaskStatuses()[i].Skipped
+	}
+	wantSkipped := map[string]bool{
+		"task1": false,
+		"task2": true,
+		"task3": true,
+		"task4": false,
+	}
+	if diff := cmp.Diff(wantSkipped, gotSkipped); diff != "" {
+		t.Errorf("Skipped status mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLocalRunner_SkipPredicateError(t *testing.T) {
+	taskExecuted := false
+	task := NewTask(taskid.NewDefaultImplementationID[any]("task1"), nil, func(ctx context.Context) (any, error) {
+		taskExecuted = true
+		return nil, nil
+	}, WithSkipPredicate(func(ctx context.Context) (bool, error) {
+		return false, errors.New("predicate error")
+	}))
+
+	taskSet, err := NewTaskSet([]UntypedTask{task})
+	if err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+
+	sortResult := taskSet.sortTaskGraph()
+	runnableSet := &TaskSet{tasks: sortResult.TopologicalSortedTasks, runnable: true}
+
+	runner, err := NewLocalRunner(runnableSet)
+	if err != nil {
+		t.Fatalf("Failed to create runner: %v", err)
+	}
+
+	err = runner.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to run task: %v", err)
+	}
+
+	<-runner.Wait()
+
+	_, err = runner.Result()
+	if err == nil {
+		t.Fatal("Expected an error, got nil")
+	}
+	if !strings.Contains(err.Error(), "predicate error") {
+		t.Errorf("Expected error containing 'predicate error', got '%s'", err.Error())
+	}
+	if taskExecuted {
+		t.Error("Task should not be executed when its skip predicate failed")
+	}
+}
+
+func TestLocalRunner_IsolatedFailure(t *testing.T) {
+	queryAID := taskid.NewDefaultImplementationID[[]string]("query-a")
+	featureAID := taskid.NewDefaultImplementationID[string]("feature-a")
+	featureBID := taskid.NewDefaultImplementationID[string]("feature-b")
+	queryA := NewTask(queryAID, nil, func(ctx context.Context) ([]string, error) {
+		return nil, errors.New("query error")
+	})
+	featureAExecuted := false
+	featureA := NewTask(featureAID, []taskid.UntypedTaskReference{queryAID.Ref()}, func(ctx context.Context) (string, error) {
+		featureAExecuted = true
+		return "a", nil
+	}, WithFailureIsolation())
+	featureB := NewTask(featureBID, nil, func(ctx context.Context) (string, error) {
+		return "b", nil
+	}, WithFailureIsolation())
+	var gotResults []string
+	serializer := NewTask(taskid.NewDefaultImplementationID[any]("serializer"), []taskid.UntypedTaskReference{featureAID.Ref(), featureBID.Ref()}, func(ctx context.Context) (any, error) {
+		gotResults = []string{GetTaskResult(ctx, featureAID.Ref()), GetTaskResult(ctx, featureBID.Ref())}
+		return nil, nil
+	})
+
+	taskSet, err := NewTaskSet([]UntypedTask{queryA, featureA, featureB, serializer})
+	if err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+
+	sortResult := taskSet.sortTaskGraph()
+	runnableSet := &TaskSet{tasks: sortResult.TopologicalSortedTasks, runnable: true}
+
+	runner, err := NewLocalRunner(runnableSet)
+	if err != nil {
+		t.Fatalf("Failed to create runner: %v", err)
+	}
+	failedTasks := map[string]string{}
+	runner.AddIsolatedFailureHandler(func(ctx context.Context, task UntypedTask, err error) {
+		failedTasks[task.UntypedID().String()] = err.Error()
+	})
+
+	err = runner.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to run task: %v", err)
+	}
+
+	<-runner.Wait()
+
+	if _, err := runner.Result(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if featureAExecuted {
+		t.Error("feature-a should not be executed because its dependency failed")
+	}
+	if diff := cmp.Diff([]string{"", "b"}, gotResults); diff != "" {
+		t.Errorf("serializer got unexpected results (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(map[string]string{"feature-a#default": "dependency query-a failed"}, failedTasks); diff != "" {
+		t.Errorf("isolated failures mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLocalRunner_FailureNotIsolated(t *testing.T) {
+	sharedID := taskid.NewDefaultImplementationID[string]("shared")
+	shared := NewTask(sharedID, nil, func(ctx context.Context) (string, error) {
+		return "", errors.New("shared error")
+	})
+	feature := NewTask(taskid.NewDefaultImplementationID[string]("feature"), []taskid.UntypedTaskReference{sharedID.Ref()}, func(ctx context.Context) (string, error) {
+		return "", nil
+	}, WithFailureIsolation())
+	// serializer depends on the shared task directly, thus the failure of the shared task can't be isolated in the feature.
+	serializer := NewTask(taskid.NewDefaultImplementationID[any]("serializer"), []taskid.UntypedTaskReference{sharedID.Ref()}, func(ctx context.Context) (any, error) {
+		return nil, nil
+	})
+
+	taskSet, err := NewTaskSet([]UntypedTask{shared, feature, serializer})
+	if err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+
+	sortResult := taskSet.sortTaskGraph()
+	runnableSet := &TaskSet{tasks: sortResult.TopologicalSortedTasks, runnable: true}
+
+	runner, err := NewLocalRunner(runnableSet)
+	if err != nil {
+		t.Fatalf("Failed to create runner: %v", err)
+	}
+
+	err = runner.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to run task: %v", err)
+	}
+
+	<-runner.Wait()
+
+	_, err = runner.Result()
+	if err == nil {
+		t.Fatal("Expected an error, got nil")
+	}
+	if !strings.Contains(err.Error(), "shared error") {
+		t.Errorf("Expected error containing 'shared error', got '%s'", err.Error())
+	}
+}
+
+func TestLocalRunner_TaskPanic(t *testing.T) {
+	task := NewTask(taskid.NewDefaultImplementationID[any]("task1"), nil, func(ctx context.Context) (any, error) {
+		var m map[string]string
+		m["foo"] = "bar"
+		return nil, nil
+	})
+
+	taskSet, err := NewTaskSet([]UntypedTask{task})
+	if err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+
+	sortResult := taskSet.sortTaskGraph()
+	runnableSet := &TaskSet{tasks: sortResult.TopologicalSortedTasks, runnable: true}
+
+	runner, err := NewLocalRunner(runnableSet)
+	if err != nil {
+		t.Fatalf("Failed to create runner: %v", err)
+	}
+
+	err = runner.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to run task: %v", err)
+	}
+
+	<-runner.Wait()
+
+	_, err = runner.Result()
+	if err == nil {
+		t.Fatal("Expected an error, got nil")
+	}
+	if !strings.Contains(err.Error(), "task task1#default panicked: assignment to entry in nil map") {
+		t.Errorf("Expected error describing the panic, got '%s'", err.Error())
+	}
+	var panicErr *TaskPanicError
+	if !errors.As(runner.TaskStatuses()[0].Error, &panicErr) {
+		t.Fatalf("Expected TaskPanicError in the task status, got %v", runner.TaskStatuses()[0].Error)
+	}
+	if !strings.Contains(panicErr.Stack, "TestLocalRunner_TaskPanic") {
+		t.Errorf("Expected the stack trace including the panicked function, got %s", panicErr.Stack)
+	}
+}
+
+func TestLocalRunner_InterceptorPanic(t *testing.T) {
+	task := NewTask(taskid.NewDefaultImplementationID[any]("task1"), nil, func(ctx context.Context) (any, error) {
+		return nil, nil
+	})
+
+	taskSet, err := NewTaskSet([]UntypedTask{task})
+	if err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+
+	sortResult := taskSet.sortTaskGraph()
+	runnableSet := &TaskSet{tasks: sortResult.TopologicalSortedTasks, runnable: true}
+
+	runner, err := NewLocalRunner(runnableSet)
+	if err != nil {
+		t.Fatalf("Failed to create runner: %v", err)
+	}
+	runner.AddInterceptor(func(ctx context.Context, task UntypedTask, next func(context.Context) (any, error)) (any, error) {
+		panic("interceptor panic")
+	})
+
+	err = runner.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to run task: %v", err)
+	}
+
+	<-runner.Wait()
+
+	_, err = runner.Result()
+	if err == nil {
+		t.Fatal("Expected an error, got nil")
+	}
+	var panicErr *TaskPanicError
+	if !errors.As(runner.TaskStatuses()[0].Error, &panicErr) {
+		t.Fatalf("Expected TaskPanicError in the task status, got %v", runner.TaskStatuses()[0].Error)
+	}
+	if panicErr.Value != "interceptor panic" {
+		t.Errorf("Expected the panic value 'interceptor panic', got %v", panicErr.Value)
+	}
+}
+
+func TestLocalRunner_DescribeTaskExecution(t *testing.T) {
+	task1 := createMockTask("task1", nil, func(ctx context.Context) (any, error) {
+		RecordCacheHit(ctx, true)
+		return "result1", nil
+	})
+	task2 := createMockTask("task2", []string{"task1"}, func(ctx context.Context) (any, error) {
+		return nil, errors.New("task2 error")
+	})
+	task3 := createMockTask("task3", []string{"task2"}, func(ctx context.Context) (any, error) {
+		return nil, nil
+	})
+
+	taskSet, err := NewTaskSet([]UntypedTask{task1, task2, task3})
+	if err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+
+	sortResult := taskSet.sortTaskGraph()
+	runnableSet := &TaskSet{tasks: sortResult.TopologicalSortedTasks, runnable: true}
+
+	runner, err := NewLocalRunner(runnableSet)
+	if err != nil {
+		t.Fatalf("Failed to create runner: %v", err)
+	}
+
+	before := runner.DescribeTaskExecution()
+	for _, task := range before.Tasks {
+		if task.State != TaskExecutionStateWaiting {
+			t.Errorf("Expected task %s to be waiting before running, got %s", task.ID, task.State)
+		}
+	}
+
+	err = runner.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to run task: %v", err)
+	}
+	<-runner.Wait()
+
+	description := runner.DescribeTaskExecution()
+	type nodeSummary struct {
+		ID           string
+		Dependencies []string
+		State        string
+		CacheHit     bool
+		Error        string
+		Started      bool
+	}
+	got := []nodeSummary{}
+	for _, task := range description.Tasks {
+		got = append(got, nodeSummary{
+			ID:           task.ID,
+			Dependencies: task.Dependencies,
+			State:        task.State,
+			CacheHit:     task.CacheHit,
+			Error:        task.Error,
+			Started:      task.StartTime != nil,
+		})
+	}
+	want := []nodeSummary{
+		{ID: "task1#default", Dependencies: []string{}, State: TaskExecutionStateDone, CacheHit: true, Started: true},
+		{ID: "task2#default", Dependencies: []string{"task1#default"}, State: TaskExecutionStateFailed, Error: "task2 error", Started: true},
+		{ID: "task3#default", Dependencies: []string{"task2#default"}, State: TaskExecutionStateWaiting},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Task execution description mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// TestLocalRunner_DescribeTaskExecutionWhileRunning polls the execution state while tasks are running.
+// This is mainly for detecting data races with `go test -race`.
+func TestLocalRunner_DescribeTaskExecutionWhileRunning(t *testing.T) {
+	tasks := []UntypedTask{}
+	for i := 0; i < 10; i++ {
+		var dependencies []string
+		if i > 0 {
+			dependencies = []string{fmt.Sprintf("task%d", i-1)}
+		}
+		tasks = append(tasks, createMockTask(fmt.Sprintf("task%d", i), dependencies, func(ctx context.Context) (any, error) {
+			RecordCacheHit(ctx, i%2 == 0)
+			time.Sleep(time.Millisecond)
+			if i == 9 {
+				return nil, errors.New("last task error")
+			}
+			return i, nil
+		}))
+	}
+	taskSet, err := NewTaskSet(tasks)
+	if err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+	sortResult := taskSet.sortTaskGraph()
+	runner, err := NewLocalRunner(&TaskSet{tasks: sortResult.TopologicalSortedTasks, runnable: true})
+	if err != nil {
+		t.Fatalf("Failed to create runner: %v", err)
+	}
+
+	if err := runner.Run(context.Background()); err != nil {
+		t.Fatalf("Failed to run task: %v", err)
+	}
+	wait := runner.Wait()
+	polled := 0
+	for done := false; !done; {
+		select {
+		case <-wait:
+			done = true
+		default:
+			description := runner.DescribeTaskExecution()
+			if len(description.Tasks) != len(tasks) {
+				t.Fatalf("Expected %d tasks in the description, got %d", len(tasks), len(description.Tasks))
+			}
+			for _, status := range runner.TaskStatuses() {
+				_ = status.Phase
+			}
+			polled++
+		}
+	}
+	if polled == 0 {
+		t.Errorf("Expected the execution state to be polled while running")
+	}
+	description := runner.DescribeTaskExecution()
+	if got := description.Tasks[len(tasks)-1].State; got != TaskExecutionStateFailed {
+		t.Errorf("Expected the last task to be failed, got %s", got)
+	}
+}
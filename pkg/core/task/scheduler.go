@@ -0,0 +1,149 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coretask
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+)
+
+// LabelKeyTaskExecutionPriority is the priority used by the task runner to decide which task to start first when more tasks are ready than its concurrency limit allows.
+// Tasks with the higher value start earlier. Tasks without this label have TaskExecutionPriorityDefault.
+var LabelKeyTaskExecutionPriority = NewTaskLabelKey[int](KHISystemPrefix + "task-execution-priority")
+
+const (
+	// TaskExecutionPriorityHigh is the execution priority for user facing or cheap tasks like form tasks.
+	TaskExecutionPriorityHigh = 100
+	// TaskExecutionPriorityDefault is the execution priority used for tasks without the priority label.
+	TaskExecutionPriorityDefault = 0
+	// TaskExecutionPriorityLow is the execution priority for heavy tasks like log queries.
+	TaskExecutionPriorityLow = -100
+)
+
+// WithExecutionPriority returns a LabelOpt to set the priority used when the runner chooses the next task to start.
+func WithExecutionPriority(priority int) LabelOpt {
+	return WithLabelValue(LabelKeyTaskExecutionPriority, priority)
+}
+
+// executionSlotRequest is a pending request of a task waiting for an execution slot.
+type executionSlotRequest struct {
+	priority int
+	// order breaks ties between requests with the same priority. The smaller one is granted first.
+	order   int
+	granted chan struct{}
+	// index is the position of this request in the heap. It is -1 after the request is removed from the heap.
+	index int
+}
+
+// executionSlotRequestHeap implements heap.Interface to pop the request with the highest priority first.
+type executionSlotRequestHeap []*executionSlotRequest
+
+func (h executionSlotRequestHeap) Len() int { return len(h) }
+
+func (h executionSlotRequestHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority > h[j].priority
+	}
+	return h[i].order < h[j].order
+}
+
+func (h executionSlotRequestHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *executionSlotRequestHeap) Push(x any) {
+	request := x.(*executionSlotRequest)
+	request.index = len(*h)
+	*h = append(*h, request)
+}
+
+func (h *executionSlotRequestHeap) Pop() any {
+	old := *h
+	n := len(old)
+	request := old[n-1]
+	old[n-1] = nil
+	request.index = -1
+	*h = old[:n-1]
+	return request
+}
+
+// executionSlotQueue limits the count of tasks running at the same time.
+// When no slot is available, the waiting tasks are granted slots in the order of their priorities instead of the order of their arrivals.
+type executionSlotQueue struct {
+	mu       sync.Mutex
+	capacity int
+	running  int
+	waiting  executionSlotRequestHeap
+}
+
+func newExecutionSlotQueue(capacity int) *executionSlotQueue {
+	return &executionSlotQueue{
+		capacity: capacity,
+	}
+}
+
+// acquire blocks until an execution slot is granted for the request with the given priority and order.
+// It returns a function to release the acquired slot. The wait is interrupted when the context is cancelled.
+func (q *executionSlotQueue) acquire(ctx context.Context, priority int, order int) (func(), error) {
+	q.mu.Lock()
+	if q.running < q.capacity && q.waiting.Len() == 0 {
+		q.running++
+		q.mu.Unlock()
+		return q.release, nil
+	}
+	request := &executionSlotRequest{
+		priority: priority,
+		order:    order,
+		granted:  make(chan struct{}),
+	}
+	heap.Push(&q.waiting, request)
+	q.mu.Unlock()
+
+	select {
+	case <-request.granted:
+		return q.release, nil
+	case <-ctx.Done():
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		if request.index < 0 {
+			// The slot was granted at the same time of the cancellation. Pass it to the next request.
+			q.running--
+			q.grantLocked()
+		} else {
+			heap.Remove(&q.waiting, request.index)
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// release returns the slot and grants it to the waiting request with the highest priority.
+func (q *executionSlotQueue) release() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.running--
+	q.grantLocked()
+}
+
+// grantLocked grants available slots to waiting requests. The caller must hold the lock.
+func (q *executionSlotQueue) grantLocked() {
+	for q.running < q.capacity && q.waiting.Len() > 0 {
+		request := heap.Pop(&q.waiting).(*executionSlotRequest)
+		q.running++
+		close(request.granted)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coretask
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// waitForWaitingRequests blocks until the given count of requests are waiting in the queue.
+func waitForWaitingRequests(t *testing.T, q *executionSlotQueue, count int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		q.mu.Lock()
+		waiting := q.waiting.Len()
+		q.mu.Unlock()
+		if waiting == count {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("requests didn't reach the expected waiting count %d", count)
+}
+
+func TestExecutionSlotQueue_GrantsInPriorityOrder(t *testing.T) {
+	type request struct {
+		name     string
+		priority int
+		order    int
+	}
+	requests := []request{
+		{name: "low", priority: TaskExecutionPriorityLow, order: 1},
+		{name: "default-later", priority: TaskExecutionPriorityDefault, order: 3},
+		{name: "high", priority: TaskExecutionPriorityHigh, order: 4},
+		{name: "default-earlier", priority: TaskExecutionPriorityDefault, order: 2},
+	}
+	q := newExecutionSlotQueue(1)
+	releaseFirst, err := q.acquire(context.Background(), 0, 0)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	var mu sync.Mutex
+	grantedOrder := []string{}
+	var wg sync.WaitGroup
+	for i, r := range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := q.acquire(context.Background(), r.priority, r.order)
+			if err != nil {
+				t.Errorf("unexpected error %v", err)
+				return
+			}
+			mu.Lock()
+			grantedOrder = append(grantedOrder, r.name)
+			mu.Unlock()
+			release()
+		}()
+		waitForWaitingRequests(t, q, i+1)
+	}
+
+	releaseFirst()
+	wg.Wait()
+
+	want := []string{"high", "default-earlier", "default-later", "low"}
+	if diff := cmp.Diff(want, grantedOrder); diff != "" {
+		t.Errorf("granted order mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestExecutionSlotQueue_CancelledRequest(t *testing.T) {
+	q := newExecutionSlotQueue(1)
+	releaseFirst, err := q.acquire(context.Background(), 0, 0)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error)
+	go func() {
+		_, err := q.acquire(ctx, TaskExecutionPriorityHigh, 1)
+		errCh <- err
+	}()
+	waitForWaitingRequests(t, q, 1)
+	cancel()
+	if err := <-errCh; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	waitForWaitingRequests(t, q, 0)
+
+	releaseFirst()
+	release, err := q.acquire(context.Background(), 0, 2)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	release()
+	if q.running != 0 {
+		t.Errorf("expected no running slot, got %d", q.running)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coretask
+
+import (
+	"context"
+
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	core_contract "github.com/kyasbal/khi/pkg/task/core/contract"
+)
+
+// SkipPredicate decides whether the task runner skips the task at runtime.
+// It is called after all dependencies of the task completed, thus it can read their results with GetTaskResult.
+type SkipPredicate func(ctx context.Context) (bool, error)
+
+// LabelKeyTaskSkipPredicate is the SkipPredicate evaluated by the task runner before running the task.
+// When the predicate returns true, the task is not executed and the zero value of its result type is stored as its result.
+var LabelKeyTaskSkipPredicate = NewTaskLabelKey[SkipPredicate](KHISystemPrefix + "task-skip-predicate")
+
+// WithSkipPredicate returns a LabelOpt to skip the task when the given predicate returns true.
+func WithSkipPredicate(predicate SkipPredicate) LabelOpt {
+	return WithLabelValue(LabelKeyTaskSkipPredicate, predicate)
+}
+
+// SkipWhenAnyTaskSkipped returns a SkipPredicate skipping the task when any of the given tasks were skipped.
+// Downstream tasks consuming results of skippable tasks can use this to propagate the skip.
+func SkipWhenAnyTaskSkipped(refs ...taskid.UntypedTaskReference) SkipPredicate {
+	return func(ctx context.Context) (bool, error) {
+		for _, ref := range refs {
+			if IsTaskSkipped(ctx, ref) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+}
+
+// IsTaskSkipped returns true when the referenced task was skipped by its SkipPredicate in the current task graph.
+func IsTaskSkipped(ctx context.Context, reference taskid.UntypedTaskReference) bool {
+	skippedTasks, err := khictx.GetValue(ctx, core_contract.SkippedTaskSetContextKey)
+	if err != nil {
+		return false
+	}
+	return typedmap.GetOrDefault(skippedTasks, skippedKeyForTask(reference), false)
+}
+
+// zeroResultProvider is implemented by tasks able to return the zero value of its result type.
+// The runner stores this value as the result of skipped tasks to keep the result map type safe.
+type zeroResultProvider interface {
+	zeroResult() any
+}
+
+// zeroResultOf returns the zero value of the result type of the given task.
+func zeroResultOf(task UntypedTask) any {
+	if provider, ok := task.(zeroResultProvider); ok {
+		return provider.zeroResult()
+	}
+	return nil
+}
+
+// skippedKeyForTask is a helper function that creates a type-safe
+// key for accessing the skipped flag in the skipped task set.
+func skippedKeyForTask(taskID taskid.UntypedTaskReference) typedmap.TypedKey[bool] {
+	return typedmap.NewTypedKey[bool](taskID.ReferenceIDString())
+}
@@ -17,6 +17,7 @@ package coretask
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/kyasbal/khi/pkg/common/typedmap"
 	"github.com/kyasbal/khi/pkg/core/task/taskid"
@@ -42,6 +43,9 @@ var LabelKeyRequiredTask = NewTaskLabelKey[bool](KHISystemPrefix + "required-tas
 // LabelKeySubsequentTaskRefs is the list of task references. These tasks are included in the task graph later and the included task reference this task.
 var LabelKeySubsequentTaskRefs = NewTaskLabelKey[[]taskid.UntypedTaskReference](KHISystemPrefix + "subsquent-task-refs")
 
+// LabelKeyTaskTimeout is the maximum duration of a single task execution. The context given to the task is cancelled when the task runs over this duration and the task fails with a timeout error.
+var LabelKeyTaskTimeout = NewTaskLabelKey[time.Duration](KHISystemPrefix + "task-timeout")
+
 type UntypedTask interface {
 	UntypedID() taskid.UntypedTaskImplementationID
 	// Labels returns KHITaskLabelSet assigned to this task unit.
@@ -101,6 +105,11 @@ func (c *TaskImpl[TaskResult]) UntypedRun(ctx context.Context) (any, error) {
 	return c.Run(ctx)
 }
 
+// zeroResult implements zeroResultProvider.
+func (c *TaskImpl[TaskResult]) zeroResult() any {
+	return *new(TaskResult)
+}
+
 var _ Task[any] = (*TaskImpl[any])(nil)
 
 func NewTask[TaskResult any](taskId taskid.TaskImplementationID[TaskResult], dependencies []taskid.UntypedTaskReference, runFunc func(ctx context.Context) (TaskResult, error), labelOpts ...LabelOpt) *TaskImpl[TaskResult] {
@@ -0,0 +1,106 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package taskid
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// referenceAliases holds the aliases of reference IDs registered with RegisterReferenceAlias.
+// Task references with an aliased reference ID are treated as references to the canonical reference ID.
+var referenceAliases = &referenceAliasRegistry{
+	canonicalByAlias: map[string]string{},
+}
+
+// referenceAliasRegistry maps old reference IDs to the canonical reference IDs they were renamed to.
+type referenceAliasRegistry struct {
+	mu               sync.RWMutex
+	canonicalByAlias map[string]string
+}
+
+// RegisterReferenceAlias registers the reference ID of oldRef as an alias of the reference ID of newRef.
+// This is used to rename a task without breaking dependencies or saved parameters referring the task with its old reference ID.
+// Registering the same alias again is allowed, but it returns an error when the alias is already registered for another reference ID,
+// when the new reference ID is itself an alias, or when the old reference ID is already used as the canonical ID of other aliases.
+func RegisterReferenceAlias[TaskResult any](oldRef TaskReference[TaskResult], newRef TaskReference[TaskResult]) error {
+	return referenceAliases.register(rawReferenceID(oldRef), rawReferenceID(newRef))
+}
+
+// CanonicalReferenceID returns the reference ID the given reference ID is an alias of.
+// It returns the given reference ID as is when it is not an alias.
+func CanonicalReferenceID(referenceID string) string {
+	return referenceAliases.canonical(referenceID)
+}
+
+// ReferenceIDAliases returns the sorted list of aliases registered for the given canonical reference ID.
+func ReferenceIDAliases(canonicalReferenceID string) []string {
+	return referenceAliases.aliases(canonicalReferenceID)
+}
+
+func (r *referenceAliasRegistry) register(oldID string, newID string) error {
+	if oldID == newID {
+		return fmt.Errorf("reference id %s can't be an alias of itself", oldID)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if current, found := r.canonicalByAlias[oldID]; found {
+		if current == newID {
+			return nil
+		}
+		return fmt.Errorf("reference id %s is already registered as an alias of %s. It can't be an alias of %s", oldID, current, newID)
+	}
+	if canonical, found := r.canonicalByAlias[newID]; found {
+		return fmt.Errorf("reference id %s is an alias of %s. Register the alias %s to %s instead", newID, canonical, oldID, canonical)
+	}
+	for alias, canonical := range r.canonicalByAlias {
+		if canonical == oldID {
+			return fmt.Errorf("reference id %s is the canonical id of the alias %s. It can't be an alias of %s", oldID, alias, newID)
+		}
+	}
+	r.canonicalByAlias[oldID] = newID
+	return nil
+}
+
+func (r *referenceAliasRegistry) canonical(referenceID string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if canonical, found := r.canonicalByAlias[referenceID]; found {
+		return canonical
+	}
+	return referenceID
+}
+
+func (r *referenceAliasRegistry) aliases(canonicalReferenceID string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := []string{}
+	for alias, canonical := range r.canonicalByAlias {
+		if canonical == canonicalReferenceID {
+			result = append(result, alias)
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+
+// rawReferenceID returns the reference ID given at the construction of the reference without resolving aliases.
+func rawReferenceID(ref UntypedTaskReference) string {
+	if impl, ok := ref.(interface{ rawID() string }); ok {
+		return impl.rawID()
+	}
+	return ref.ReferenceIDString()
+}
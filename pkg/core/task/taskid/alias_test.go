@@ -0,0 +1,79 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package taskid
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRegisterReferenceAlias_ResolvesToCanonicalID(t *testing.T) {
+	oldRef := NewTaskReference[string]("test.alias.resolve.old")
+	newRef := NewTaskReference[string]("test.alias.resolve.new")
+	oldImplementationID := NewDefaultImplementationID[string]("test.alias.resolve.old")
+	if err := RegisterReferenceAlias(oldRef, newRef); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if got := oldRef.ReferenceIDString(); got != "test.alias.resolve.new" {
+		t.Errorf("ReferenceIDString() = %q, want %q", got, "test.alias.resolve.new")
+	}
+	if got := oldImplementationID.String(); got != "test.alias.resolve.new#default" {
+		t.Errorf("String() = %q, want %q", got, "test.alias.resolve.new#default")
+	}
+	if got := NewImplementationID(oldRef, "foo").String(); got != "test.alias.resolve.new#foo" {
+		t.Errorf("String() = %q, want %q", got, "test.alias.resolve.new#foo")
+	}
+	if got := CanonicalReferenceID("test.alias.resolve.unrelated"); got != "test.alias.resolve.unrelated" {
+		t.Errorf("CanonicalReferenceID() = %q, want the given id as is", got)
+	}
+	if diff := cmp.Diff([]string{"test.alias.resolve.old"}, ReferenceIDAliases("test.alias.resolve.new")); diff != "" {
+		t.Errorf("ReferenceIDAliases() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRegisterReferenceAlias_Reregistration(t *testing.T) {
+	a := NewTaskReference[string]("test.alias.reregister.a")
+	b := NewTaskReference[string]("test.alias.reregister.b")
+	c := NewTaskReference[string]("test.alias.reregister.c")
+	if err := RegisterReferenceAlias(a, b); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	testCases := []struct {
+		name    string
+		oldRef  TaskReference[string]
+		newRef  TaskReference[string]
+		wantErr bool
+	}{
+		{name: "same alias again", oldRef: a, newRef: b, wantErr: false},
+		{name: "alias to another reference", oldRef: a, newRef: c, wantErr: true},
+		{name: "alias to an alias", oldRef: c, newRef: a, wantErr: true},
+		{name: "canonical id to be an alias", oldRef: b, newRef: c, wantErr: true},
+		{name: "alias of itself", oldRef: c, newRef: c, wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := RegisterReferenceAlias(tc.oldRef, tc.newRef)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("RegisterReferenceAlias() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got := CanonicalReferenceID("test.alias.reregister.a"); got != "test.alias.reregister.b" {
+				t.Errorf("CanonicalReferenceID() = %q, want the alias to be unchanged", got)
+			}
+		})
+	}
+}
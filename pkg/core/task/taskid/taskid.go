@@ -82,7 +82,13 @@ type taskReferenceImpl[TaskResult any] struct {
 }
 
 // String returns the string representation of the reference ID.
+// When the reference ID is registered as an alias, this returns the canonical reference ID.
 func (t taskReferenceImpl[TaskResult]) String() string {
+	return CanonicalReferenceID(t.id)
+}
+
+// rawID returns the reference ID given at the construction without resolving aliases.
+func (t taskReferenceImpl[TaskResult]) rawID() string {
 	return t.id
 }
 
@@ -99,8 +105,9 @@ type taskImplementationIDImpl[TaskResult any] struct {
 }
 
 // String returns the full string representation of the implementation ID in the format "referenceId#implementationHash".
+// The reference ID part is resolved to the canonical reference ID when it is registered as an alias.
 func (t taskImplementationIDImpl[TaskResult]) String() string {
-	return t.referenceId + "#" + t.implementationHash
+	return t.ReferenceIDString() + "#" + t.implementationHash
 }
 
 // Ref returns a TaskReference associated with this implementation ID.
@@ -120,8 +127,9 @@ func (t taskReferenceImpl[TaskResult]) isTaskReference() bool {
 }
 
 // ReferenceIDString returns only the reference ID portion of the implementation ID, without the hash.
+// When the reference ID is registered as an alias, this returns the canonical reference ID.
 func (t taskImplementationIDImpl[TaskResult]) ReferenceIDString() string {
-	return t.referenceId
+	return CanonicalReferenceID(t.referenceId)
 }
 
 // GetTaskImplementationHash returns the implementation-specific hash part of the ID.
@@ -167,7 +175,7 @@ func NewImplementationID[TaskResult any](baseReference TaskReference[TaskResult]
 	if strings.Contains(implementationHash, "#") {
 		panic(fmt.Sprintf("implementation hash %s is invalid. It cannot contain '#' in NewImplementationID.\nThis is likely a bug in the KHI task implementation or an incorrect ID was provided in the taskid definition.\nPlease report a bug at https://github.com/kyasbal/khi/issues", implementationHash))
 	}
-	return taskImplementationIDImpl[TaskResult]{referenceId: baseReference.String(), implementationHash: implementationHash}
+	return taskImplementationIDImpl[TaskResult]{referenceId: rawReferenceID(baseReference), implementationHash: implementationHash}
 }
 
 // ReinterpretTaskReference casts UntypedTaskReference to TaskReference[T]. Use this with caution.
@@ -217,9 +217,9 @@ func (s *TaskSet) ToRunnableTaskSet() (*TaskSet, error) {
 			})
 			missingDependenciesStr := &strings.Builder{}
 			for _, missingRef := range sortResult.MissingDependencies {
-				missingDependenciesStr.WriteString(fmt.Sprintf("* %s\n", missingRef.ReferenceIDString()))
+				missingDependenciesStr.WriteString(describeMissingTaskReference(missingRef, tasksDependingOn(missingRef, s.tasks), s.tasks))
 			}
-			return nil, fmt.Errorf("missing dependency found int he given task set.\n Missing %s", missingDependenciesStr.String())
+			return nil, fmt.Errorf("missing dependency found in the given task set.\n Missing:\n%s", missingDependenciesStr.String())
 		}
 		return nil, fmt.Errorf("failed to sort as a runnable task graph. unreachable")
 	}
@@ -262,6 +262,52 @@ func (s *TaskSet) DumpGraphviz() (string, error) {
 	return result, nil
 }
 
+// TaskGraphDescription is a serializable description of a runnable task graph for debugging purpose.
+type TaskGraphDescription struct {
+	// Tasks is the list of tasks in the graph in topological order.
+	Tasks []*TaskNodeDescription `json:"tasks"`
+}
+
+// TaskNodeDescription describes a task in a TaskGraphDescription.
+type TaskNodeDescription struct {
+	// ID is the task implementation ID.
+	ID string `json:"id"`
+	// Dependencies is the list of task implementation IDs resolved for the dependencies of this task.
+	Dependencies []string `json:"dependencies"`
+	// Labels is the map of label keys and their values formatted as string.
+	Labels map[string]string `json:"labels"`
+}
+
+// DumpDescription returns the task graph with task IDs, resolved dependencies and labels for debugging purpose.
+// The result has the same nodes and edges as the graph returned from DumpGraphviz.
+func (s *TaskSet) DumpDescription() (*TaskGraphDescription, error) {
+	if !s.runnable {
+		return nil, fmt.Errorf("can't describe a graph for non runnable graph")
+	}
+	result := &TaskGraphDescription{
+		Tasks: make([]*TaskNodeDescription, 0, len(s.tasks)),
+	}
+	sourceRelation := map[string]UntypedTask{}
+	for _, task := range s.tasks {
+		dependencies := make([]string, 0, len(task.Dependencies()))
+		for _, source := range task.Dependencies() {
+			dependencies = append(dependencies, sourceRelation[source.ReferenceIDString()].UntypedID().String())
+		}
+		labels := map[string]string{}
+		task.Labels().Range(func(key string, value any) bool {
+			labels[key] = fmt.Sprintf("%v", value)
+			return true
+		})
+		result.Tasks = append(result.Tasks, &TaskNodeDescription{
+			ID:           task.UntypedID().String(),
+			Dependencies: dependencies,
+			Labels:       labels,
+		})
+		sourceRelation[task.UntypedID().ReferenceIDString()] = task
+	}
+	return result, nil
+}
+
 func sortedMapKeys[T any](inputMap map[string]T) []string {
 	result := []string{}
 	for key := range inputMap {
@@ -381,4 +427,9 @@ func (w *wrapGraphFirstTask) UntypedID() taskid.UntypedTaskImplementationID {
 	return w.task.UntypedID()
 }
 
+// zeroResult implements zeroResultProvider by delegating to the wrapped task.
+func (w *wrapGraphFirstTask) zeroResult() any {
+	return zeroResultOf(w.task)
+}
+
 var _ Task[any] = (*wrapGraphFirstTask)(nil)
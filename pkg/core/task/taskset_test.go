@@ -18,6 +18,7 @@ import (
 	"context"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/kyasbal/khi/pkg/common/typedmap"
@@ -224,6 +225,47 @@ bar_default -> foo_default
 	}
 }
 
+func TestDumpDescription(t *testing.T) {
+	inputTasks := []UntypedTask{
+		newDebugTask("foo", []string{"bar"}, WithTimeout(time.Minute)),
+		newDebugTask("bar", []string{"qux"}),
+		newDebugTask("qux", []string{}),
+	}
+	ts, err := NewTaskSet(inputTasks)
+	if err != nil {
+		t.Fatalf("unexpected err:%s", err.Error())
+	}
+	resolvedTaskSet, err := ts.ToRunnableTaskSet()
+	if err != nil {
+		t.Fatalf("unexpected err:%s", err.Error())
+	}
+
+	want := &TaskGraphDescription{
+		Tasks: []*TaskNodeDescription{
+			{ID: "qux#default", Dependencies: []string{}, Labels: map[string]string{}},
+			{ID: "bar#default", Dependencies: []string{"qux#default"}, Labels: map[string]string{}},
+			{ID: "foo#default", Dependencies: []string{"bar#default"}, Labels: map[string]string{LabelKeyTaskTimeout.Key(): "1m0s"}},
+		},
+	}
+	got, err := resolvedTaskSet.DumpDescription()
+	if err != nil {
+		t.Fatalf("unexpected err:%s", err.Error())
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("DumpDescription() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
+
+func TestDumpDescriptionWithNonRunnableTaskSet(t *testing.T) {
+	ts, err := NewTaskSet([]UntypedTask{newDebugTask("foo", []string{"bar"})})
+	if err != nil {
+		t.Fatalf("unexpected err:%s", err.Error())
+	}
+	if _, err := ts.DumpDescription(); err == nil {
+		t.Errorf("DumpDescription() returned nil error for a non runnable task set")
+	}
+}
+
 func TestDumpGraphvizReturnsStableResult(t *testing.T) {
 	COUNT := 100
 	for i := 0; i < COUNT; i++ {
@@ -319,3 +361,16 @@ func TestNewSetWithDuplicatedID(t *testing.T) {
 		t.Errorf("expected error, but returned no error")
 	}
 }
+
+func TestSortTaskGraphWithAliasedDependency(t *testing.T) {
+	err := taskid.RegisterReferenceAlias(taskid.NewTaskReference[any]("test.sort.aliased.old"), taskid.NewTaskReference[any]("test.sort.aliased.new"))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	tasks := []UntypedTask{
+		newDebugTask("foo", []string{"test.sort.aliased.old"}),
+		newDebugTask("test.sort.aliased.new", []string{}),
+	}
+
+	assertSortTaskGraph(t, tasks, []string{"test.sort.aliased.new", "foo"}, []string{}, true, "")
+}
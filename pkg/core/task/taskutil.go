@@ -46,6 +46,16 @@ func GetTaskResultOptional[T any](ctx context.Context, reference taskid.TaskRefe
 	return typedmap.Get(taskResults, typedmap.NewTypedKey[T](reference.ReferenceIDString()))
 }
 
+// RecordCacheHit records whether the current task reused its cached result instead of computing it.
+// The recorded value is shown in the task execution state. It does nothing when the task runner doesn't support recording it.
+func RecordCacheHit(ctx context.Context, hit bool) {
+	recorder, err := khictx.GetValue(ctx, core_contract.TaskCacheHitRecorderContextKey)
+	if err != nil {
+		return
+	}
+	recorder(hit)
+}
+
 // WrapErrorWithTaskInformation annotate given error with the current task information.
 func WrapErrorWithTaskInformation(ctx context.Context, err error) error {
 	taskID := khictx.MustGetValue(ctx, core_contract.TaskImplementationIDContextKey)
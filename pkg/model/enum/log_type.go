@@ -33,6 +33,8 @@ const (
 	LogTypeSerialPort            LogType = 13
 
 	LogTypeCSMAccessLog LogType = 14 // Added since 0.49
+	LogTypeNetworkFlow  LogType = 15
+	LogTypeLoadBalancer LogType = 16
 
 	logTypeUnusedEnd
 )
@@ -124,4 +126,14 @@ var LogTypes = map[LogType]LogTypeFrontendMetadata{
 		Label:                "csm_access_log",
 		LabelBackgroundColor: mustHexToHDRColor4("#FF8500"),
 	},
+	LogTypeNetworkFlow: {
+		EnumKeyName:          "LogTypeNetworkFlow",
+		Label:                "network_flow",
+		LabelBackgroundColor: mustHexToHDRColor4("#00897B"),
+	},
+	LogTypeLoadBalancer: {
+		EnumKeyName:          "LogTypeLoadBalancer",
+		Label:                "load_balancer",
+		LabelBackgroundColor: mustHexToHDRColor4("#5E35B1"),
+	},
 }
@@ -32,7 +32,8 @@ const (
 	RelationshipAirflowTaskInstance   ParentRelationship = 12
 	RelationshipCSMAccessLog          ParentRelationship = 13 // Added since 0.49
 	RelationshipPodPhase              ParentRelationship = 14 // Added since 0.50
-	relationshipUnusedEnd                                     // Add items above. This field is used for counting items in this enum to test.
+	RelationshipNetworkFlow           ParentRelationship = 15
+	relationshipUnusedEnd                                // Add items above. This field is used for counting items in this enum to test.
 )
 
 // EnumParentRelationshipLength is the count of ParentRelationship enum elements.
@@ -144,6 +145,10 @@ var ParentRelationships = map[ParentRelationship]ParentRelationshipFrontendMetad
 				SourceLogType: LogTypeAutoscaler,
 				Description:   "A log related to the Pod which triggered or prevented autoscaler",
 			},
+			{
+				SourceLogType: LogTypeLoadBalancer,
+				Description:   "A request failed on the load balancer routing to the Service or its NEG",
+			},
 		},
 	},
 	RelationshipResourceCondition: {
@@ -172,6 +177,16 @@ var ParentRelationships = map[ParentRelationship]ParentRelationshipFrontendMetad
 				SourceLogType: LogTypeAudit,
 				Description:   "The condition state is `Unknown`",
 			},
+			{
+				State:         RevisionStateConditionTrue,
+				SourceLogType: LogTypeLoadBalancer,
+				Description:   "The load balancer health check reported the Pod as healthy",
+			},
+			{
+				State:         RevisionStateConditionFalse,
+				SourceLogType: LogTypeLoadBalancer,
+				Description:   "The load balancer health check reported the Pod as unhealthy",
+			},
 		},
 	},
 	RelationshipOperation: {
@@ -539,6 +554,26 @@ var ParentRelationships = map[ParentRelationship]ParentRelationshipFrontendMetad
 			},
 		},
 	},
+	RelationshipNetworkFlow: {
+		Visible:              true,
+		EnumKeyName:          "RelationshipNetworkFlow",
+		Label:                "flow",
+		LongName:             "Network flow",
+		LabelColor:           mustHexToHDRColor4("#FFFFFF"),
+		LabelBackgroundColor: mustHexToHDRColor4("#00897B"),
+		Hint:                 "VPC flow logs and network policy denials of the Pod IP",
+		SortPriority:         5002, // just under CSM access logs
+		GeneratableEvents: []GeneratableEventInfo{
+			{
+				SourceLogType: LogTypeNetworkFlow,
+				Description:   "A connection reported in VPC flow logs",
+			},
+			{
+				SourceLogType: LogTypeNetworkFlow,
+				Description:   "A connection denied by a network policy",
+			},
+		},
+	},
 	RelationshipPodPhase: {
 		Visible:              true,
 		EnumKeyName:          "RelationshipPodPhase",
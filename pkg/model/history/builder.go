@@ -39,6 +39,9 @@ import (
 
 type BuilderLogWalker = func(logIndex int, l *log.Log) *ChangeSet
 
+// ClusterScopeResolver returns the name of the cluster the given log came from. It returns an empty string for logs not associated with a cluster.
+type ClusterScopeResolver = func(l *log.Log) string
+
 // Builder builds History from ChangeSet obtained from parsers.
 type Builder struct {
 	history                *History
@@ -50,6 +53,8 @@ type Builder struct {
 	logIdToSerializableLog *common.ShardingMap[*SerializableLog]
 	historyResourceCache   *common.ShardingMap[*Resource]
 	sorter                 *ResourceSorter
+	clusterScopeLock       sync.RWMutex
+	clusterScopeResolver   ClusterScopeResolver
 }
 
 func NewBuilder(tmpFolder string) *Builder {
@@ -319,6 +324,25 @@ func (builder *Builder) SerializeLogs(ctx context.Context, entireLogs []*log.Log
 	return errGrp.Wait()
 }
 
+// SetClusterScopeResolver sets the resolver of the clusters of logs. The resource paths written from a log are scoped to its cluster with resourcepath.ClusterScopedPath.
+// This must be set before parsers write the logs of multiple clusters to the history.
+func (builder *Builder) SetClusterScopeResolver(resolver ClusterScopeResolver) {
+	builder.clusterScopeLock.Lock()
+	defer builder.clusterScopeLock.Unlock()
+	builder.clusterScopeResolver = resolver
+}
+
+// ClusterScopeOf returns the cluster name the resource paths written from the given log are scoped to.
+// It returns an empty string when the resource paths are not scoped to clusters.
+func (builder *Builder) ClusterScopeOf(l *log.Log) string {
+	builder.clusterScopeLock.RLock()
+	defer builder.clusterScopeLock.RUnlock()
+	if builder.clusterScopeResolver == nil {
+		return ""
+	}
+	return builder.clusterScopeResolver(l)
+}
+
 // ParseLogsByGroups parse group of logs with given function and write them into history and sort them everytime.
 // Deprecated:
 // Use ChangeSet.FlushToHistory and sort timelines manually from callers.
@@ -163,10 +163,49 @@ func (cs *ChangeSet) addResourceRelationship(resourcePath resourcepath.ResourceP
 	return nil
 }
 
+// scopeToCluster rewrites every resource path in this ChangeSet with resourcepath.ClusterScopedPath.
+func (cs *ChangeSet) scopeToCluster(cluster string) {
+	if cluster == "" {
+		return
+	}
+	scope := func(path string) string {
+		return resourcepath.ClusterScopedPath(path, cluster)
+	}
+	revisionsMap := make(map[string][]*StagingResourceRevision, len(cs.RevisionsMap))
+	for path, revisions := range cs.RevisionsMap {
+		revisionsMap[scope(path)] = append(revisionsMap[scope(path)], revisions...)
+	}
+	cs.RevisionsMap = revisionsMap
+	eventsMap := make(map[string][]*ResourceEvent, len(cs.EventsMap))
+	for path, events := range cs.EventsMap {
+		eventsMap[scope(path)] = append(eventsMap[scope(path)], events...)
+	}
+	cs.EventsMap = eventsMap
+	relationships := make(map[string]enum.ParentRelationship, len(cs.ResourceRelationshipRewrites))
+	for path, relationship := range cs.ResourceRelationshipRewrites {
+		relationships[scope(path)] = relationship
+	}
+	cs.ResourceRelationshipRewrites = relationships
+	aliases := make(map[string][]string, len(cs.Aliases))
+	for source, destinations := range cs.Aliases {
+		for _, destination := range destinations {
+			aliases[scope(source)] = append(aliases[scope(source)], scope(destination))
+		}
+	}
+	cs.Aliases = aliases
+	for _, annotation := range cs.Annotations {
+		if reference, ok := annotation.(*ResourceReferenceAnnotation); ok {
+			reference.Path = scope(reference.Path)
+		}
+	}
+}
+
 // FlushToHistory writes the recorded changeset to the history and returns resource paths where the resource modified.
 // This method applies all staged revisions, events, log properties, aliases, and resource relationships
 // to the provided Builder. It returns a list of resource paths that were modified and any error encountered.
+// The resource paths are scoped to the cluster of the log when the builder has a ClusterScopeResolver.
 func (cs *ChangeSet) FlushToHistory(builder *Builder) ([]string, error) {
+	cs.scopeToCluster(builder.ClusterScopeOf(cs.Log))
 	changedPaths := []string{}
 	// Write revisions in this ChangeSet
 	for resourcePath, revisions := range cs.RevisionsMap {
@@ -255,3 +255,44 @@ func TestChangesetFlushIsThreadSafe(t *testing.T) {
 		}
 	}
 }
+
+func TestChangesetFlushScopesResourcePathsToCluster(t *testing.T) {
+	builder := NewBuilder(t.TempDir())
+	builder.SetClusterScopeResolver(func(l *log.Log) string {
+		return l.ReadStringOrDefault("resource.labels.cluster_name", "")
+	})
+	logs := []*log.Log{}
+	for _, cluster := range []string{"cluster-a", "cluster-b"} {
+		logs = append(logs, testlog.New(testlog.YAML(fmt.Sprintf("insertId: %s\ntimestamp: 2024-01-01T00:00:00Z\nresource:\n  labels:\n    cluster_name: %s", cluster, cluster))).MustBuildLogEntity(&testCommonFieldSetReader{}))
+	}
+	if err := builder.SerializeLogs(t.Context(), logs, func() {}); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	gotPaths := []string{}
+	for _, l := range logs {
+		cs := NewChangeSet(l)
+		pod := resourcepath.Pod("default", "foo")
+		cs.AddEvent(pod)
+		cs.AddResourceAlias(pod, resourcepath.Node("node-a"))
+		paths, err := cs.FlushToHistory(builder)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		gotPaths = append(gotPaths, paths...)
+		if diff := cmp.Diff(paths, cs.GetAllResourcePaths()); diff != "" {
+			t.Errorf("resource paths in the ChangeSet mismatch (-want +got):\n%s", diff)
+		}
+		wantAnnotation := &ResourceReferenceAnnotation{Path: paths[0]}
+		if diff := cmp.Diff([]LogAnnotation{wantAnnotation}, cs.Annotations); diff != "" {
+			t.Errorf("annotations mismatch (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff([]string{resourcepath.ClusterScopedPath(resourcepath.Node("node-a").Path, l.ReadStringOrDefault("resource.labels.cluster_name", ""))}, cs.GetAliases(resourcepath.ResourcePath{Path: paths[0]})); diff != "" {
+			t.Errorf("aliases mismatch (-want +got):\n%s", diff)
+		}
+	}
+	wantPaths := []string{"core/v1#pod#cluster-a/default#foo", "core/v1#pod#cluster-b/default#foo"}
+	if diff := cmp.Diff(wantPaths, gotPaths); diff != "" {
+		t.Errorf("changed paths mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -24,3 +24,25 @@ func NetworkEndpointGroup(negNamespace string, negName string) ResourcePath {
 	}
 	return NameLayerGeneralItem("networking.gke.io/v1beta1", "servicenetworkendpointgroup", negNamespace, negName)
 }
+
+// GCEInstanceGroupManager returns the ResourcePath of timeline for a managed instance group of Compute Engine.
+func GCEInstanceGroupManager(location string, name string) ResourcePath {
+	if location == "" {
+		location = nonSpecifiedPlaceholder
+	}
+	if name == "" {
+		name = nonSpecifiedPlaceholder
+	}
+	return NameLayerGeneralItem("compute.googleapis.com", "instancegroupmanager", location, name)
+}
+
+// GCEInstanceGroupAutoscaler returns the ResourcePath of timeline for the autoscaler of a managed instance group.
+func GCEInstanceGroupAutoscaler(location string, instanceGroupName string) ResourcePath {
+	if location == "" {
+		location = nonSpecifiedPlaceholder
+	}
+	if instanceGroupName == "" {
+		instanceGroupName = nonSpecifiedPlaceholder
+	}
+	return SubresourceLayerGeneralItem("compute.googleapis.com", "instancegroupmanager", location, instanceGroupName, "autoscaler")
+}
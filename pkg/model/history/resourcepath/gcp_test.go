@@ -45,3 +45,36 @@ func TestNetworkEndpointGroup(t *testing.T) {
 		})
 	}
 }
+
+func TestGCEInstanceGroupManager(t *testing.T) {
+	testCases := []struct {
+		name     string
+		location string
+		igmName  string
+		expected string
+	}{
+		{"all specified", "us-central1-a", "my-mig", "compute.googleapis.com#instancegroupmanager#us-central1-a#my-mig"},
+		{"empty location", "", "my-mig", "compute.googleapis.com#instancegroupmanager#unknown#my-mig"},
+		{"empty name", "us-central1", "", "compute.googleapis.com#instancegroupmanager#us-central1#unknown"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := GCEInstanceGroupManager(tc.location, tc.igmName)
+			if result.Path != tc.expected {
+				t.Errorf("GCEInstanceGroupManager(%s,%s).Path=%q, want %q", tc.location, tc.igmName, result.Path, tc.expected)
+			}
+		})
+	}
+}
+
+func TestGCEInstanceGroupAutoscaler(t *testing.T) {
+	result := GCEInstanceGroupAutoscaler("us-central1-a", "my-mig")
+	expected := "compute.googleapis.com#instancegroupmanager#us-central1-a#my-mig#autoscaler"
+	if result.Path != expected {
+		t.Errorf("GCEInstanceGroupAutoscaler().Path=%q, want %q", result.Path, expected)
+	}
+	if result.ParentRelationship != enum.RelationshipChild {
+		t.Errorf("GCEInstanceGroupAutoscaler().ParentRelationship=%q, want %q", result.ParentRelationship, enum.RelationshipChild)
+	}
+}
@@ -67,6 +67,22 @@ func NameLayerGeneralItem(apiVersion, kind, namespace, name string) ResourcePath
 	}
 }
 
+// ClusterScopedPath returns the resource path with the cluster name prefixed to its namespace layer.
+// e.g. `core/v1#pod#cluster-a/default#foo` for `core/v1#pod#default#foo` in the cluster `cluster-a`.
+// This separates the timelines of the resources with the same name in different clusters when an inspection gathers logs from multiple clusters.
+// The path is returned as is when the cluster is empty, the path has no namespace layer or the namespace layer is already scoped to the cluster.
+func ClusterScopedPath(path string, cluster string) string {
+	if cluster == "" {
+		return path
+	}
+	layers := strings.SplitN(path, "#", 4)
+	if len(layers) < 3 || strings.HasPrefix(layers[2], cluster+"/") {
+		return path
+	}
+	layers[2] = fmt.Sprintf("%s/%s", cluster, layers[2])
+	return strings.Join(layers, "#")
+}
+
 func SubresourceLayerGeneralItem(apiVersion, kind, namespace, name, subresource string) ResourcePath {
 	if subresource == "" {
 		subresource = PlaceholderForEmptyField
@@ -284,3 +284,50 @@ func TestSubresourceLayerGeneralItem(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterScopedPath(t *testing.T) {
+	tests := []struct {
+		tname   string
+		path    string
+		cluster string
+		want    string
+	}{
+		{
+			tname:   "name layer",
+			path:    "core/v1#pod#default#my-pod",
+			cluster: "cluster-a",
+			want:    "core/v1#pod#cluster-a/default#my-pod",
+		},
+		{
+			tname:   "subresource layer",
+			path:    "core/v1#pod#default#my-pod#binding",
+			cluster: "cluster-a",
+			want:    "core/v1#pod#cluster-a/default#my-pod#binding",
+		},
+		{
+			tname:   "empty cluster",
+			path:    "core/v1#pod#default#my-pod",
+			cluster: "",
+			want:    "core/v1#pod#default#my-pod",
+		},
+		{
+			tname:   "kind layer",
+			path:    "core/v1#pod",
+			cluster: "cluster-a",
+			want:    "core/v1#pod",
+		},
+		{
+			tname:   "already scoped",
+			path:    "core/v1#pod#cluster-a/default#my-pod",
+			cluster: "cluster-a",
+			want:    "core/v1#pod#cluster-a/default#my-pod",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tname, func(t *testing.T) {
+			if got := ClusterScopedPath(tt.path, tt.cluster); got != tt.want {
+				t.Errorf("ClusterScopedPath(%q, %q) = %q, want %q", tt.path, tt.cluster, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcepath
+
+import (
+	"fmt"
+
+	"github.com/kyasbal/khi/pkg/model/enum"
+)
+
+// PodNetworkFlow returns the path of the timeline for the network flows sent or received by the Pod.
+func PodNetworkFlow(podNamespace string, podName string) ResourcePath {
+	pod := Pod(podNamespace, podName)
+	return ResourcePath{
+		Path:               fmt.Sprintf("%s#network-flow", pod.Path),
+		ParentRelationship: enum.RelationshipNetworkFlow,
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcepath
+
+import (
+	"testing"
+
+	"github.com/kyasbal/khi/pkg/model/enum"
+)
+
+func TestPodNetworkFlow(t *testing.T) {
+	result := PodNetworkFlow("default", "nginx")
+	want := "core/v1#pod#default#nginx#network-flow"
+	if result.Path != want {
+		t.Errorf("PodNetworkFlow().Path = %v, want %v", result.Path, want)
+	}
+	if result.ParentRelationship != enum.RelationshipNetworkFlow {
+		t.Errorf("PodNetworkFlow().ParentRelationship = %v, want %v", result.ParentRelationship, enum.RelationshipNetworkFlow)
+	}
+}
@@ -34,6 +34,14 @@ func Autoscaler(clusterName string) ResourcePath {
 	return cluster
 }
 
+// AutopilotWarden returns the path of GKE Warden, the admission controller enforcing the constraints of Autopilot clusters.
+func AutopilotWarden(clusterName string) ResourcePath {
+	cluster := Cluster(clusterName)
+	cluster.Path = fmt.Sprintf("%s#warden", cluster.Path)
+	cluster.ParentRelationship = enum.RelationshipControlPlaneComponent
+	return cluster
+}
+
 func Nodepool(clusterName string, nodepoolName string) ResourcePath {
 	if clusterName == "" {
 		clusterName = nonSpecifiedPlaceholder
@@ -189,3 +197,22 @@ func PodPhase(nodeName string, podNamespace, podName, uid string) ResourcePath {
 		ParentRelationship: enum.RelationshipPodPhase,
 	}
 }
+
+// PermissionDenial returns the path of the timeline recording the requests from the principal denied by the authorizer against the resource.
+// Denials are grouped by the principal at the kind layer to make authorization regressions of a component visible at a glance.
+func PermissionDenial(principal string, namespace string, pluralKind string, name string) ResourcePath {
+	if principal == "" {
+		principal = nonSpecifiedPlaceholder
+	}
+	resource := pluralKind
+	if name != "" {
+		resource = fmt.Sprintf("%s/%s", pluralKind, name)
+	}
+	return NameLayerGeneralItem("@Authorization", principal, namespace, resource)
+}
+
+// VeleroOperation returns the path of the pseudo operation timeline under a Velero Backup or Restore showing the window it was processed by the Velero server.
+// kind must be the singular lower case kind name, `backup` or `restore`.
+func VeleroOperation(kind string, namespace string, name string) ResourcePath {
+	return Operation(NameLayerGeneralItem("velero.io/v1", kind, namespace, name), kind, name)
+}
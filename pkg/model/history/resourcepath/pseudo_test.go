@@ -68,6 +68,30 @@ func TestAutoscaler(t *testing.T) {
 	}
 }
 
+func TestAutopilotWarden(t *testing.T) {
+	expectedParentRelationship := enum.RelationshipControlPlaneComponent
+	testCases := []struct {
+		name        string
+		clusterName string
+		expected    string
+	}{
+		{"Cluster name specified", "my-cluster", "@Cluster#controlplane#cluster-scope#my-cluster#warden"},
+		{"Empty cluster name", "", "@Cluster#controlplane#cluster-scope#unknown#warden"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := AutopilotWarden(tc.clusterName)
+			if result.Path != tc.expected {
+				t.Errorf("AutopilotWarden(%v).Path = %v, want %v", tc.clusterName, result.Path, tc.expected)
+			}
+			if result.ParentRelationship != expectedParentRelationship {
+				t.Errorf("AutopilotWarden(%v).ParentRelationship = %v, want %v", tc.clusterName, result.ParentRelationship, expectedParentRelationship)
+			}
+		})
+	}
+}
+
 func TestNodepool(t *testing.T) {
 	expectedParentRelationship := enum.RelationshipChild
 	testCases := []struct {
@@ -381,3 +405,57 @@ func TestOwnerSubresource(t *testing.T) {
 		})
 	}
 }
+
+func TestPermissionDenial(t *testing.T) {
+	expectedParentRelationship := enum.RelationshipChild
+	testCases := []struct {
+		name       string
+		principal  string
+		namespace  string
+		pluralKind string
+		resource   string
+		expected   string
+	}{
+		{"Named resource", "system:serviceaccount:default:foo", "default", "pods", "nginx", "@Authorization#system:serviceaccount:default:foo#default#pods/nginx"},
+		{"Collection request", "user@example.com", "cluster-scope", "nodes", "", "@Authorization#user@example.com#cluster-scope#nodes"},
+		{"Empty principal", "", "default", "pods", "nginx", "@Authorization#unknown#default#pods/nginx"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := PermissionDenial(tc.principal, tc.namespace, tc.pluralKind, tc.resource)
+			if result.Path != tc.expected {
+				t.Errorf("PermissionDenial(%v, %v, %v, %v).Path = %v, want %v", tc.principal, tc.namespace, tc.pluralKind, tc.resource, result.Path, tc.expected)
+			}
+			if result.ParentRelationship != expectedParentRelationship {
+				t.Errorf("PermissionDenial(%v, %v, %v, %v).ParentRelationship = %v, want %v", tc.principal, tc.namespace, tc.pluralKind, tc.resource, result.ParentRelationship, expectedParentRelationship)
+			}
+		})
+	}
+}
+
+func TestVeleroOperation(t *testing.T) {
+	expectedParentRelationship := enum.RelationshipOperation
+	testCases := []struct {
+		name      string
+		kind      string
+		namespace string
+		resource  string
+		expected  string
+	}{
+		{"Backup", "backup", "velero", "daily-20250101", "velero.io/v1#backup#velero#daily-20250101#backup-daily-20250101"},
+		{"Restore", "restore", "velero", "daily-20250101-restore", "velero.io/v1#restore#velero#daily-20250101-restore#restore-daily-20250101-restore"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := VeleroOperation(tc.kind, tc.namespace, tc.resource)
+			if result.Path != tc.expected {
+				t.Errorf("VeleroOperation(%v, %v, %v).Path = %v, want %v", tc.kind, tc.namespace, tc.resource, result.Path, tc.expected)
+			}
+			if result.ParentRelationship != expectedParentRelationship {
+				t.Errorf("VeleroOperation(%v, %v, %v).ParentRelationship = %v, want %v", tc.kind, tc.namespace, tc.resource, result.ParentRelationship, expectedParentRelationship)
+			}
+		})
+	}
+}
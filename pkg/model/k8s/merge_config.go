@@ -15,10 +15,13 @@
 package k8s
 
 import (
+	"encoding/json"
 	"fmt"
 	"log/slog"
+	"strings"
 
 	"github.com/kyasbal/khi/pkg/common/structured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 // K8sManifestMergeConfigRegistry holds merge configurations for Kubernetes manifests.
@@ -48,3 +51,37 @@ func (r *K8sManifestMergeConfigRegistry) Get(apiVersion string, kind string) *st
 		return r.defaultResolver
 	}
 }
+
+// RegisterSchemaSource registers the merge configurations read from an OpenAPI v2/v3 document or CustomResourceDefinition manifests.
+// Configurations registered before for the same apiVersion and kind are replaced, because the schema given from the cluster is more accurate than the built-in one.
+func (r *K8sManifestMergeConfigRegistry) RegisterSchemaSource(source []byte) (int, error) {
+	var resolvers map[schema.GroupVersionKind]*structured.MergeConfigResolver
+	var err error
+	if isOpenAPIDocument(source) {
+		resolvers, err = FromOpenAPIDocument(source)
+	} else {
+		resolvers, err = FromCustomResourceDefinition(source)
+	}
+	if err != nil {
+		return 0, err
+	}
+	for gvk, resolver := range resolvers {
+		apiVersion := gvk.GroupVersion().Identifier()
+		if gvk.Group == "" {
+			apiVersion = "core/" + gvk.Version
+		}
+		r.mergeConfigResolvers[fmt.Sprintf("%s-%s", apiVersion, strings.ToLower(gvk.Kind))] = resolver
+	}
+	return len(resolvers), nil
+}
+
+// isOpenAPIDocument returns true when the source is an OpenAPI document having the `swagger` or `openapi` version field instead of Kubernetes manifests.
+func isOpenAPIDocument(source []byte) bool {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(source, &fields); err != nil {
+		return false
+	}
+	_, isV2 := fields["swagger"]
+	_, isV3 := fields["openapi"]
+	return isV2 || isV3
+}
@@ -0,0 +1,208 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/common/structured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// openAPISchema is the subset of a schema object in OpenAPI v2/v3 documents or CRDs used to resolve the merge strategies of lists.
+type openAPISchema struct {
+	Ref               string                    `json:"$ref"`
+	AllOf             []*openAPISchema          `json:"allOf"`
+	Properties        map[string]*openAPISchema `json:"properties"`
+	Items             *openAPISchema            `json:"items"`
+	PatchStrategy     string                    `json:"x-kubernetes-patch-strategy"`
+	PatchMergeKey     string                    `json:"x-kubernetes-patch-merge-key"`
+	ListType          string                    `json:"x-kubernetes-list-type"`
+	ListMapKeys       []string                  `json:"x-kubernetes-list-map-keys"`
+	GroupVersionKinds []schema.GroupVersionKind `json:"x-kubernetes-group-version-kind"`
+}
+
+// openAPIDocument is the subset of an OpenAPI v2 or v3 document.
+type openAPIDocument struct {
+	// Definitions holds the schemas in OpenAPI v2.
+	Definitions map[string]*openAPISchema `json:"definitions"`
+	Components  struct {
+		// Schemas holds the schemas in OpenAPI v3.
+		Schemas map[string]*openAPISchema `json:"schemas"`
+	} `json:"components"`
+}
+
+// customResourceDefinition is the subset of apiextensions.k8s.io/v1 CustomResourceDefinition.
+type customResourceDefinition struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		Group string `json:"group"`
+		Names struct {
+			Kind     string `json:"kind"`
+			ListKind string `json:"listKind"`
+		} `json:"names"`
+		Versions []struct {
+			Name   string `json:"name"`
+			Schema struct {
+				OpenAPIV3Schema *openAPISchema `json:"openAPIV3Schema"`
+			} `json:"schema"`
+		} `json:"versions"`
+	} `json:"spec"`
+	// Items is used when the manifest is a list of CRDs.
+	Items []*customResourceDefinition `json:"items"`
+}
+
+// FromOpenAPIDocument returns the merge configurations of all the kinds defined in an OpenAPI v2 or v3 document served from the Kubernetes API server at `/openapi/v2` or `/openapi/v3/...`.
+// The strategy of a list is resolved from `x-kubernetes-patch-strategy` and `x-kubernetes-patch-merge-key`, or `x-kubernetes-list-type` and `x-kubernetes-list-map-keys` when the patch strategy is not given.
+func FromOpenAPIDocument(document []byte) (map[schema.GroupVersionKind]*structured.MergeConfigResolver, error) {
+	var doc openAPIDocument
+	err := json.Unmarshal(document, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the OpenAPI document: %w", err)
+	}
+	definitions := doc.Definitions
+	if len(definitions) == 0 {
+		definitions = doc.Components.Schemas
+	}
+	if len(definitions) == 0 {
+		return nil, errors.New("no schema definition found in the OpenAPI document")
+	}
+	result := map[schema.GroupVersionKind]*structured.MergeConfigResolver{}
+	for name, definition := range definitions {
+		for _, gvk := range definition.GroupVersionKinds {
+			resolver := newEmptyMergeConfigResolver()
+			err := resolveSchemaRecursive("", definition, definitions, []string{name}, resolver)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve the schema %s: %w", name, err)
+			}
+			result[gvk] = resolver
+		}
+	}
+	return result, nil
+}
+
+// FromCustomResourceDefinition returns the merge configurations of the custom resource defined in a CustomResourceDefinition manifest or a list of them in YAML or JSON.
+// CRDs usually don't have patch strategies, the list type markers like `// +listType=map` and `// +listMapKey=name` generated as `x-kubernetes-list-type` and `x-kubernetes-list-map-keys` are used instead.
+func FromCustomResourceDefinition(manifest []byte) (map[schema.GroupVersionKind]*structured.MergeConfigResolver, error) {
+	node, err := structured.FromYAML(string(manifest))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the CustomResourceDefinition manifest: %w", err)
+	}
+	var crd customResourceDefinition
+	err = structured.ReadReflect(structured.NewNodeReader(node), "", &crd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the CustomResourceDefinition manifest: %w", err)
+	}
+	crds := crd.Items
+	if crd.Kind == "CustomResourceDefinition" {
+		crds = []*customResourceDefinition{&crd}
+	}
+	if len(crds) == 0 {
+		return nil, errors.New("no CustomResourceDefinition found in the manifest")
+	}
+	result := map[schema.GroupVersionKind]*structured.MergeConfigResolver{}
+	for _, crd := range crds {
+		for _, version := range crd.Spec.Versions {
+			if version.Schema.OpenAPIV3Schema == nil {
+				continue
+			}
+			resolver := newEmptyMergeConfigResolver()
+			err := resolveSchemaRecursive("", version.Schema.OpenAPIV3Schema, nil, nil, resolver)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve the schema of %s/%s %s: %w", crd.Spec.Group, version.Name, crd.Spec.Names.Kind, err)
+			}
+			result[schema.GroupVersionKind{Group: crd.Spec.Group, Version: version.Name, Kind: crd.Spec.Names.Kind}] = resolver
+		}
+	}
+	return result, nil
+}
+
+func newEmptyMergeConfigResolver() *structured.MergeConfigResolver {
+	return &structured.MergeConfigResolver{
+		MergeStrategies: make(map[string]structured.MergeArrayStrategy),
+		MergeKeys:       map[string]string{},
+	}
+}
+
+// resolveSchemaRecursive walks the schema and stores the strategies of lists in the resolver with the same field paths as FromResourceTypeReflection.
+// refStack holds the names of definitions being resolved to stop walking recursive definitions like JSONSchemaProps.
+func resolveSchemaRecursive(path string, current *openAPISchema, definitions map[string]*openAPISchema, refStack []string, resolver *structured.MergeConfigResolver) error {
+	if strings.Count(path, ".") > MAXIMUM_STRUCTURE_DEPTH {
+		return fmt.Errorf("maximum structure depth reached. is this a recursive structure?")
+	}
+	if current.Ref != "" {
+		name := current.Ref[strings.LastIndex(current.Ref, "/")+1:]
+		if slices.Contains(refStack, name) {
+			return nil
+		}
+		definition, found := definitions[name]
+		if !found {
+			return fmt.Errorf("reference %s is not found", current.Ref)
+		}
+		return resolveSchemaRecursive(path, definition, definitions, append(refStack, name), resolver)
+	}
+	for _, subSchema := range current.AllOf {
+		err := resolveSchemaRecursive(path, subSchema, definitions, refStack, resolver)
+		if err != nil {
+			return err
+		}
+	}
+	for name, property := range current.Properties {
+		propertyPath := fmt.Sprintf("%s.%s", path, name)
+		if path == "" {
+			propertyPath = name
+		}
+		if property.Items != nil {
+			strategy, mergeKey := listMergeStrategy(property)
+			resolver.MergeStrategies[propertyPath] = strategy
+			if strategy == structured.MergeStrategyMerge {
+				resolver.MergeKeys[propertyPath] = mergeKey
+			}
+			err := resolveSchemaRecursive(propertyPath+".[]", property.Items, definitions, refStack, resolver)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		err := resolveSchemaRecursive(propertyPath, property, definitions, refStack, resolver)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listMergeStrategy returns the merge strategy and the merge key of a list property.
+func listMergeStrategy(property *openAPISchema) (structured.MergeArrayStrategy, string) {
+	if slices.Contains(strings.Split(property.PatchStrategy, ","), "merge") {
+		return structured.MergeStrategyMerge, property.PatchMergeKey
+	}
+	switch property.ListType {
+	case "map":
+		// The merger supports only a single merge key. The first key is usually the most identifying one, e.g. `containerPort` of `containerPort` and `protocol`.
+		if len(property.ListMapKeys) > 0 {
+			return structured.MergeStrategyMerge, property.ListMapKeys[0]
+		}
+		return structured.MergeStrategyReplace, ""
+	case "set":
+		return structured.MergeStrategyMerge, ""
+	default:
+		return structured.MergeStrategyReplace, ""
+	}
+}
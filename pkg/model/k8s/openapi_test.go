@@ -0,0 +1,253 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/common/structured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const testOpenAPIV2Document = `{
+  "swagger": "2.0",
+  "definitions": {
+    "io.k8s.api.apps.v1.Deployment": {
+      "properties": {
+        "metadata": {"$ref": "#/definitions/io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta"},
+        "spec": {"$ref": "#/definitions/io.k8s.api.apps.v1.DeploymentSpec"}
+      },
+      "x-kubernetes-group-version-kind": [{"group": "apps", "kind": "Deployment", "version": "v1"}]
+    },
+    "io.k8s.api.apps.v1.DeploymentSpec": {
+      "properties": {
+        "template": {
+          "properties": {
+            "spec": {
+              "properties": {
+                "containers": {
+                  "items": {"$ref": "#/definitions/io.k8s.api.core.v1.Container"},
+                  "type": "array",
+                  "x-kubernetes-patch-merge-key": "name",
+                  "x-kubernetes-patch-strategy": "merge"
+                }
+              }
+            }
+          }
+        }
+      }
+    },
+    "io.k8s.api.core.v1.Container": {
+      "properties": {
+        "args": {"items": {"type": "string"}, "type": "array", "x-kubernetes-list-type": "atomic"},
+        "ports": {
+          "items": {"type": "object"},
+          "type": "array",
+          "x-kubernetes-list-map-keys": ["containerPort", "protocol"],
+          "x-kubernetes-list-type": "map",
+          "x-kubernetes-patch-merge-key": "containerPort",
+          "x-kubernetes-patch-strategy": "merge"
+        }
+      }
+    },
+    "io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta": {
+      "properties": {
+        "finalizers": {
+          "items": {"type": "string"},
+          "type": "array",
+          "x-kubernetes-list-type": "set",
+          "x-kubernetes-patch-strategy": "merge"
+        },
+        "ownerReferences": {
+          "items": {"type": "object"},
+          "type": "array",
+          "x-kubernetes-patch-merge-key": "uid",
+          "x-kubernetes-patch-strategy": "merge"
+        }
+      }
+    }
+  }
+}`
+
+const testOpenAPIV3Document = `{
+  "openapi": "3.0.0",
+  "components": {
+    "schemas": {
+      "io.k8s.api.core.v1.Pod": {
+        "properties": {
+          "spec": {"allOf": [{"$ref": "#/components/schemas/io.k8s.api.core.v1.PodSpec"}]}
+        },
+        "x-kubernetes-group-version-kind": [{"group": "", "kind": "Pod", "version": "v1"}]
+      },
+      "io.k8s.api.core.v1.PodSpec": {
+        "properties": {
+          "volumes": {
+            "items": {"allOf": [{"$ref": "#/components/schemas/io.k8s.api.core.v1.Volume"}]},
+            "type": "array",
+            "x-kubernetes-patch-merge-key": "name",
+            "x-kubernetes-patch-strategy": "merge,retainKeys"
+          }
+        }
+      },
+      "io.k8s.api.core.v1.Volume": {
+        "properties": {
+          "nested": {"allOf": [{"$ref": "#/components/schemas/io.k8s.api.core.v1.Volume"}]}
+        }
+      }
+    }
+  }
+}`
+
+const testCustomResourceDefinition = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+spec:
+  group: example.com
+  names:
+    kind: Widget
+    listKind: WidgetList
+    plural: widgets
+  versions:
+  - name: v1
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            properties:
+              parts:
+                type: array
+                x-kubernetes-list-type: map
+                x-kubernetes-list-map-keys:
+                - id
+                items:
+                  type: object
+                  properties:
+                    tags:
+                      type: array
+                      x-kubernetes-list-type: set
+                      items:
+                        type: string
+              notes:
+                type: array
+                items:
+                  type: string
+`
+
+func TestFromOpenAPIDocument(t *testing.T) {
+	testCases := []struct {
+		name     string
+		document string
+		want     map[schema.GroupVersionKind]*structured.MergeConfigResolver
+	}{
+		{
+			name:     "OpenAPI v2",
+			document: testOpenAPIV2Document,
+			want: map[schema.GroupVersionKind]*structured.MergeConfigResolver{
+				{Group: "apps", Version: "v1", Kind: "Deployment"}: {
+					MergeStrategies: map[string]structured.MergeArrayStrategy{
+						"metadata.finalizers":                    structured.MergeStrategyMerge,
+						"metadata.ownerReferences":               structured.MergeStrategyMerge,
+						"spec.template.spec.containers":          structured.MergeStrategyMerge,
+						"spec.template.spec.containers.[].args":  structured.MergeStrategyReplace,
+						"spec.template.spec.containers.[].ports": structured.MergeStrategyMerge,
+					},
+					MergeKeys: map[string]string{
+						"metadata.finalizers":                    "",
+						"metadata.ownerReferences":               "uid",
+						"spec.template.spec.containers":          "name",
+						"spec.template.spec.containers.[].ports": "containerPort",
+					},
+				},
+			},
+		},
+		{
+			name:     "OpenAPI v3 with allOf and recursive references",
+			document: testOpenAPIV3Document,
+			want: map[schema.GroupVersionKind]*structured.MergeConfigResolver{
+				{Group: "", Version: "v1", Kind: "Pod"}: {
+					MergeStrategies: map[string]structured.MergeArrayStrategy{
+						"spec.volumes": structured.MergeStrategyMerge,
+					},
+					MergeKeys: map[string]string{
+						"spec.volumes": "name",
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := FromOpenAPIDocument([]byte(tc.document))
+			if err != nil {
+				t.Fatalf("FromOpenAPIDocument() returned an unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFromCustomResourceDefinition(t *testing.T) {
+	got, err := FromCustomResourceDefinition([]byte(testCustomResourceDefinition))
+	if err != nil {
+		t.Fatalf("FromCustomResourceDefinition() returned an unexpected error: %v", err)
+	}
+	want := map[schema.GroupVersionKind]*structured.MergeConfigResolver{
+		{Group: "example.com", Version: "v1", Kind: "Widget"}: {
+			MergeStrategies: map[string]structured.MergeArrayStrategy{
+				"spec.parts":         structured.MergeStrategyMerge,
+				"spec.parts.[].tags": structured.MergeStrategyMerge,
+				"spec.notes":         structured.MergeStrategyReplace,
+			},
+			MergeKeys: map[string]string{
+				"spec.parts":         "id",
+				"spec.parts.[].tags": "",
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
+
+func TestRegisterSchemaSource(t *testing.T) {
+	registry, err := GenerateDefaultMergeConfig()
+	if err != nil {
+		t.Fatalf("GenerateDefaultMergeConfig() returned an unexpected error: %v", err)
+	}
+	for _, source := range []string{testOpenAPIV2Document, testCustomResourceDefinition} {
+		_, err := registry.RegisterSchemaSource([]byte(source))
+		if err != nil {
+			t.Fatalf("RegisterSchemaSource() returned an unexpected error: %v", err)
+		}
+	}
+
+	widgetResolver := registry.Get("example.com/v1", "widget")
+	if key, err := widgetResolver.GetMergeKey("spec.parts"); err != nil || key != "id" {
+		t.Errorf("merge key of spec.parts = %q, %v, want \"id\"", key, err)
+	}
+	deploymentResolver := registry.Get("apps/v1", "deployment")
+	if strategy := deploymentResolver.GetMergeArrayStrategy("spec.template.spec.containers.[].args"); strategy != structured.MergeStrategyReplace {
+		t.Errorf("strategy of containers args = %s, want replace", strategy)
+	}
+	if _, err := deploymentResolver.GetMergeKey("spec.template.spec.volumes"); err == nil {
+		t.Errorf("the built-in configuration of Deployment must be replaced with the one from the schema source")
+	}
+}
@@ -70,7 +70,8 @@ func resolveTypeRecursive(path string, current reflect.Type, resolver *structure
 				}
 				if fieldKind == reflect.Slice || fieldKind == reflect.Array {
 					patchStrategy, ok := field.Tag.Lookup("patchStrategy")
-					if !ok || patchStrategy != "merge" {
+					// The strategy can be combined with other strategies like `merge,retainKeys`.
+					if !ok || !slices.Contains(strings.Split(patchStrategy, ","), "merge") {
 						resolver.MergeStrategies[jsonFieldPath] = structured.MergeStrategyReplace
 					} else {
 						resolver.MergeStrategies[jsonFieldPath] = structured.MergeStrategyMerge
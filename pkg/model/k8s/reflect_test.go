@@ -54,6 +54,7 @@ func TestFromResourceTypeReflection(t *testing.T) {
 		ReplaceReplace []testStructSecondLayerReplace `json:"replacereplace,omitempty"`
 		Inline         InlineFields                   `json:",inline"`
 		PointerType    *testStructSecondLayer         `json:"pointerType"`
+		MergeRetain    []TestCaseMapField             `json:"mergeretain,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
 	}
 	type recursiveStruct struct {
 		Name      string            `json:"name"`
@@ -86,6 +87,10 @@ func TestFromResourceTypeReflection(t *testing.T) {
 					path:     "mergename",
 					strategy: structured.MergeStrategyMerge,
 					mergeKey: "name",
+				}, {
+					path:     "mergeretain",
+					strategy: structured.MergeStrategyMerge,
+					mergeKey: "name",
 				}, {
 					path:     "replacearray",
 					strategy: structured.MergeStrategyReplace,
@@ -69,6 +69,16 @@ func NewLogFromYAMLString(yaml string) (*Log, error) {
 	return NewLog(structured.NewNodeReader(node)), nil
 }
 
+// NewLogFromJSON instanciate a new Log from the given JSON bytes.
+// Fields are parsed on demand, this is preferred over NewLogFromYAMLString for large JSON logs only partially read.
+func NewLogFromJSON(raw []byte) (*Log, error) {
+	node, err := structured.FromJSONLazy(raw)
+	if err != nil {
+		return nil, err
+	}
+	return NewLog(structured.NewNodeReader(node)), nil
+}
+
 // NewLogWithFieldSetsForTest generate an empty Log with given FieldSet. This is for testing purpose to instanciate a log already parsed.
 func NewLogWithFieldSetsForTest(fieldSets ...FieldSet) *Log {
 	log := NewLog(&structured.NodeReader{})
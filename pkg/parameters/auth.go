@@ -29,15 +29,25 @@ type AuthParameters struct {
 	// FixedProjectID is a GCP project ID prefilled in the form. User won't be able to edit it from the form.
 	FixedProjectID *string
 
-	// QuotaProjectID is a GCP project ID used as the quota project. This is useful when user wants to use KHI against a project with another project with larger logging read quota.
+	// QuotaProjectID is a GCP project ID used as the quota project. This is useful when user wants to use KHI against a project with another project with larger logging read quota. This is the default value of the form field and users can change it from the form.
 	QuotaProjectID *string
 
+	// ImpersonateServiceAccount is the email of the service account impersonated for GCP related requests. This is the default value of the form field and users can change it from the form.
+	ImpersonateServiceAccount *string
+
+	// ExternalAccountCredentialFile is the path to the credential configuration file of Workload Identity Federation used for GCP related requests.
+	ExternalAccountCredentialFile *string
+
 	// OAuthClientID is the client ID used for getting access tokens via OAuth.
 	OAuthClientID *string
 
 	// OAuthClientSecret is the client secret used for getting access tokens via OAuth.
 	OAuthClientSecret *string
 
+	// OAuthBrowserLogin enables the OAuth flow for installed applications to login with the browser on the machine running KHI.
+	// OAuthClientID and OAuthClientSecret must be the ones of an OAuth client for desktop apps.
+	OAuthBrowserLogin *bool
+
 	// OAuthRedirectURI is the callback URL for OAuth. This must be provided as full qualified URL.
 	OAuthRedirectURI *string
 
@@ -61,12 +71,26 @@ func (a *AuthParameters) PostProcess() error {
 	if *a.OAuthClientID == "" && *a.OAuthClientSecret != "" {
 		return fmt.Errorf("--oauth-client-id must be set when --oauth-client-secret is set")
 	}
-	if *a.OAuthClientID != "" && *a.OAuthRedirectURI == "" {
+	if a.OAuthBrowserLoginEnabled() {
+		if *a.OAuthClientID == "" {
+			return fmt.Errorf("--oauth-client-id and --oauth-client-secret must be set when --oauth-browser-login is set")
+		}
+		if *a.OAuthRedirectURI != "" {
+			return fmt.Errorf("cannot use --oauth-browser-login and --oauth-redirect-uri at the same time")
+		}
+		if *a.AccessToken != "" || a.ExternalAccountCredentialFileEnabled() {
+			return fmt.Errorf("cannot use --oauth-browser-login with --access-token or --external-account-credential-file at the same time")
+		}
+	}
+	if *a.OAuthClientID != "" && *a.OAuthRedirectURI == "" && !a.OAuthBrowserLoginEnabled() {
 		return fmt.Errorf("--oauth-redirect-uri must be set when --oauth-client-id is set")
 	}
 	if *a.AccessToken != "" && a.OAuthEnabled() {
 		return fmt.Errorf("cannot use --access-token and OAuth parameters at the same time")
 	}
+	if a.ExternalAccountCredentialFileEnabled() && (*a.AccessToken != "" || a.OAuthEnabled()) {
+		return fmt.Errorf("cannot use --external-account-credential-file with --access-token or OAuth parameters at the same time")
+	}
 	if *a.AccessToken != "" {
 		slog.Warn("--access-token parameter is deprecated and not recommended after KHI supporting authentication via Application Default Credentials(ADC)")
 	}
@@ -77,9 +101,12 @@ func (a *AuthParameters) PostProcess() error {
 func (a *AuthParameters) Prepare() error {
 	a.AccessToken = flag.String("access-token", "", "(Deprecated) The token used for GCP related requests. This parameter is deprecated, please consider authenticating with Application Default Credentials(ADC) instead.", "GCP_ACCESS_TOKEN")
 	a.FixedProjectID = flag.String("fixed-project-id", "", "A GCP project ID prefilled in the form. User won't be able to edit it from the form.", "KHI_FIXED_PROJECT_ID")
-	a.QuotaProjectID = flag.String("quota-project-id", "", "A GCP project ID used as the quota project. This is useful when user wants to use KHI against a project with another project with larger logging read quota.", "")
+	a.QuotaProjectID = flag.String("quota-project-id", "", "A GCP project ID used as the quota project. This is useful when user wants to use KHI against a project with another project with larger logging read quota. This value is used as the default value of the form field.", "")
+	a.ImpersonateServiceAccount = flag.String("impersonate-service-account", "", "The email of the service account impersonated for GCP related requests. This is useful when only a dedicated service account has the permission to read logs. The caller needs `roles/iam.serviceAccountTokenCreator` on the service account. This value is used as the default value of the form field.", "KHI_IMPERSONATE_SERVICE_ACCOUNT")
+	a.ExternalAccountCredentialFile = flag.String("external-account-credential-file", "", "The path to the credential configuration file of Workload Identity Federation used for GCP related requests. This is useful when KHI is running outside of Google Cloud (e.g. AWS or an environment with an OIDC provider) without a service account key. The file can be generated with `gcloud iam workload-identity-pools create-cred-config`.", "KHI_EXTERNAL_ACCOUNT_CREDENTIAL_FILE")
 	a.OAuthClientID = flag.String("oauth-client-id", "", "The client ID used for getting access tokens via OAuth.", "KHI_OAUTH_CLIENT_ID")
 	a.OAuthClientSecret = flag.String("oauth-client-secret", "", "The client secret used for getting access tokens via OAuth.", "KHI_OAUTH_CLIENT_SECRET")
+	a.OAuthBrowserLogin = flag.Bool("oauth-browser-login", false, "Login with a Google account in the browser on the machine running KHI instead of using Application Default Credentials. This is useful for desktop users without gcloud CLI. `--oauth-client-id` and `--oauth-client-secret` must be the ones of an OAuth client for desktop apps. The refresh token is stored in the `oauth` folder under `--data-destination-folder`.", "KHI_OAUTH_BROWSER_LOGIN")
 	a.OAuthRedirectURI = flag.String("oauth-redirect-uri", "", "The callback URI for OAuth. This must be provided as full qualified URL.", "")
 	a.OAuthRedirectTargetServingPath = flag.String("oauth-redirect-target-serving-path", "/oauth/callback", "The path to serve the callback target.", "")
 	a.OAuthStateSuffix = flag.String("oauth-state-suffix", "", "The suffix added to the state parameter in OAuth. The state will be generated in the format of `<random-string><suffix>`.", "")
@@ -94,6 +121,16 @@ func (a *AuthParameters) OAuthEnabled() bool {
 	return *a.OAuthClientID != "" && *a.OAuthClientSecret != "" && *a.OAuthRedirectURI != "" && *a.OAuthRedirectTargetServingPath != ""
 }
 
+// ExternalAccountCredentialFileEnabled returns if the credential configuration file of Workload Identity Federation is given or not.
+func (a *AuthParameters) ExternalAccountCredentialFileEnabled() bool {
+	return a.ExternalAccountCredentialFile != nil && *a.ExternalAccountCredentialFile != ""
+}
+
+// OAuthBrowserLoginEnabled returns if the OAuth flow for installed applications is enabled or not.
+func (a *AuthParameters) OAuthBrowserLoginEnabled() bool {
+	return a.OAuthBrowserLogin != nil && *a.OAuthBrowserLogin
+}
+
 // GetOAuthConfig returns the *oauth2.Config constructed from the given parameter.
 func (a *AuthParameters) GetOAuthConfig() *oauth2.Config {
 	return &oauth2.Config{
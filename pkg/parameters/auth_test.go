@@ -44,6 +44,9 @@ func TestAuthParameters(t *testing.T) {
 				AccessToken:                    testutil.P(""),
 				FixedProjectID:                 testutil.P(""),
 				QuotaProjectID:                 testutil.P(""),
+				ImpersonateServiceAccount:      testutil.P(""),
+				ExternalAccountCredentialFile:  testutil.P(""),
+				OAuthBrowserLogin:              testutil.P(false),
 				OAuthClientID:                  testutil.P(""),
 				OAuthClientSecret:              testutil.P(""),
 				OAuthRedirectURI:               testutil.P(""),
@@ -245,6 +248,84 @@ func TestAuthParameters_PostProcess(t *testing.T) {
 			},
 			expectErr: true,
 		},
+		{
+			name: "valid: external account credential file only",
+			params: &AuthParameters{
+				OAuthClientID:                 testutil.P(""),
+				OAuthClientSecret:             testutil.P(""),
+				OAuthRedirectURI:              testutil.P(""),
+				AccessToken:                   testutil.P(""),
+				ExternalAccountCredentialFile: testutil.P("/path/to/credential.json"),
+			},
+			expectErr: false,
+		},
+		{
+			name: "invalid: external account credential file and access token",
+			params: &AuthParameters{
+				OAuthClientID:                 testutil.P(""),
+				OAuthClientSecret:             testutil.P(""),
+				OAuthRedirectURI:              testutil.P(""),
+				AccessToken:                   testutil.P("some-token"),
+				ExternalAccountCredentialFile: testutil.P("/path/to/credential.json"),
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid: external account credential file and oauth params",
+			params: &AuthParameters{
+				OAuthClientID:                  testutil.P("id"),
+				OAuthClientSecret:              testutil.P("secret"),
+				OAuthRedirectURI:               testutil.P("uri"),
+				OAuthRedirectTargetServingPath: testutil.P("/oauth/callback"),
+				AccessToken:                    testutil.P(""),
+				ExternalAccountCredentialFile:  testutil.P("/path/to/credential.json"),
+			},
+			expectErr: true,
+		},
+		{
+			name: "valid: browser login",
+			params: &AuthParameters{
+				OAuthClientID:     testutil.P("id"),
+				OAuthClientSecret: testutil.P("secret"),
+				OAuthRedirectURI:  testutil.P(""),
+				AccessToken:       testutil.P(""),
+				OAuthBrowserLogin: testutil.P(true),
+			},
+			expectErr: false,
+		},
+		{
+			name: "invalid: browser login without client id",
+			params: &AuthParameters{
+				OAuthClientID:     testutil.P(""),
+				OAuthClientSecret: testutil.P(""),
+				OAuthRedirectURI:  testutil.P(""),
+				AccessToken:       testutil.P(""),
+				OAuthBrowserLogin: testutil.P(true),
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid: browser login with redirect uri",
+			params: &AuthParameters{
+				OAuthClientID:     testutil.P("id"),
+				OAuthClientSecret: testutil.P("secret"),
+				OAuthRedirectURI:  testutil.P("uri"),
+				AccessToken:       testutil.P(""),
+				OAuthBrowserLogin: testutil.P(true),
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid: browser login and access token",
+			params: &AuthParameters{
+				OAuthClientID:     testutil.P("id"),
+				OAuthClientSecret: testutil.P("secret"),
+				OAuthRedirectURI:  testutil.P(""),
+				AccessToken:       testutil.P("some-token"),
+				OAuthBrowserLogin: testutil.P(true),
+			},
+			expectErr: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"strings"
 
 	"github.com/kyasbal/khi/pkg/common/constants"
 	"github.com/kyasbal/khi/pkg/common/flag"
@@ -32,8 +33,46 @@ type CommonParameters struct {
 	TemporaryFolder *string
 	// UploadFileStoreFolder is the folder path to store the uploaded log files.
 	UploadFileStoreFolder *string
+	// PresetFolder is the folder path to store the named presets of form parameter values.
+	PresetFolder *string
 	// Version is the flag to show the version name and exit.
 	Version *bool
+	// MaxConcurrentTasks is the maximum number of tasks running at the same time in an inspection. 0 means unlimited.
+	MaxConcurrentTasks *int
+	// TaskMemoryLimitMB is the heap usage in megabytes over which memory heavy tasks are delayed in an inspection. 0 means unlimited.
+	TaskMemoryLimitMB *int
+	// InspectionCheckpointFolder is the folder path to store the logs queried in inspection runs to resume them after a server crash. Checkpointing is disabled when this is empty.
+	InspectionCheckpointFolder *string
+	// TaskCacheFolder is the folder path to persist cached task results across server restarts. The persistent cache is disabled when this is empty.
+	TaskCacheFolder *string
+	// QueryResultCacheFolder is the folder path to cache raw log entries returned from Cloud Logging queries. The query result cache is disabled when this is empty.
+	QueryResultCacheFolder *string
+	// QueryResultCacheTTLSeconds is the duration in seconds to keep a cached query result not reused. 0 means no expiration.
+	QueryResultCacheTTLSeconds *int
+	// QueryResultCacheMaxSizeMB is the total size of cached query results in megabytes over which least recently used results are removed. 0 means unlimited.
+	QueryResultCacheMaxSizeMB *int
+	// TaskCacheRedisAddress is the address of the Redis server shared among KHI server replicas to cache task results. This is preferred over TaskCacheFolder when both of them are specified.
+	TaskCacheRedisAddress *string
+	// TaskCacheRedisPassword is the password used to authenticate to the Redis server specified with TaskCacheRedisAddress.
+	TaskCacheRedisPassword *string
+	// TaskCacheRedisTLS connects to the Redis server specified with TaskCacheRedisAddress over TLS when true.
+	TaskCacheRedisTLS *bool
+	// TaskCacheTTLSeconds is the lifetime of each entry stored in the Redis server in seconds. 0 means no expiration.
+	TaskCacheTTLSeconds *int
+	// CloudLoggingMaxRequestsPerMinute is the maximum number of Cloud Logging list requests sent per minute in an inspection. 0 means unlimited.
+	CloudLoggingMaxRequestsPerMinute *int
+	// CloudLoggingMaxConcurrentReads is the maximum number of Cloud Logging list requests in flight at the same time in an inspection. 0 means unlimited.
+	CloudLoggingMaxConcurrentReads *int
+	// CloudLoggingEndpoint is the address(host:port) of the Cloud Logging API endpoint used instead of the global endpoint.
+	CloudLoggingEndpoint *string
+	// CloudLoggingRegion is the location of the regional Cloud Logging API endpoint used instead of the global endpoint.
+	CloudLoggingRegion *string
+	// CloudLoggingDataBoundary is the data boundary the Cloud Logging requests must stay in. The only supported value is "eu".
+	CloudLoggingDataBoundary *string
+	// ParserPluginFolder is the folder path containing manifests of external parser plugins loaded at startup. No plugin is loaded when this is empty.
+	ParserPluginFolder *string
+	// K8sSchemaSources is the comma separated list of file paths or URLs of OpenAPI documents or CustomResourceDefinition manifests used to resolve merge strategies of patch requests. Only the built-in schema is used when this is empty.
+	K8sSchemaSources *string
 }
 
 // PostProcess implements ParameterStore.
@@ -45,7 +84,42 @@ func (c *CommonParameters) PostProcess() error {
 	if *c.UploadFileStoreFolder == "" {
 		*c.UploadFileStoreFolder = *c.DataDestinationFolder + "/upload"
 	}
-	return nil
+	if *c.PresetFolder == "" {
+		*c.PresetFolder = *c.DataDestinationFolder + "/presets"
+	}
+	return c.validateCloudLoggingEndpoint()
+}
+
+// validateCloudLoggingEndpoint checks the combination of the parameters configuring the Cloud Logging API endpoint.
+func (c *CommonParameters) validateCloudLoggingEndpoint() error {
+	endpoint := valueOrEmpty(c.CloudLoggingEndpoint)
+	region := valueOrEmpty(c.CloudLoggingRegion)
+	dataBoundary := valueOrEmpty(c.CloudLoggingDataBoundary)
+	if endpoint != "" && region != "" {
+		return fmt.Errorf("--cloud-logging-endpoint and --cloud-logging-region can't be used together")
+	}
+	switch dataBoundary {
+	case "":
+		return nil
+	case "eu":
+		if region == "" {
+			return fmt.Errorf("--cloud-logging-data-boundary=eu requires --cloud-logging-region to use the regional endpoint in EU")
+		}
+		if region != "eu" && !strings.HasPrefix(region, "europe-") {
+			return fmt.Errorf("--cloud-logging-region=%s is not a location in EU required by --cloud-logging-data-boundary=eu", region)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported --cloud-logging-data-boundary=%s. The only supported value is `eu`", dataBoundary)
+	}
+}
+
+// valueOrEmpty returns the string pointed by the given pointer or empty string when it is nil.
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
 }
 
 // Prepare implements ParameterStore.
@@ -53,7 +127,26 @@ func (c *CommonParameters) Prepare() error {
 	c.DataDestinationFolder = flag.String("data-destination-folder", "./data", "The folder path where the final khi file to be stored for serving.", "")
 	c.TemporaryFolder = flag.String("temporary-folder", "/tmp", "The folder path where be used as a working directory to generate the final khi file.", "")
 	c.UploadFileStoreFolder = flag.String("upload-file-store-folder", "", "The folder path to store the uploaded log files. Use the concatinated path of `--data-destination-folder` and `/upload` when this value is not specified.", "")
+	c.PresetFolder = flag.String("preset-folder", "", "The folder path to store the named presets of form parameter values. Use the concatinated path of `--data-destination-folder` and `/presets` when this value is not specified.", "KHI_PRESET_FOLDER")
 	c.Version = flag.Bool("version", false, "Show the version.", "")
+	c.TaskCacheFolder = flag.String("task-cache-folder", "", "The folder path to persist cached task results like autocomplete suggestions across server restarts. The persistent cache is disabled when this value is not specified.", "KHI_TASK_CACHE_FOLDER")
+	c.TaskCacheRedisAddress = flag.String("task-cache-redis-address", "", "The address(host:port) of the Redis server to share cached task results among multiple KHI server replicas. This is preferred over `--task-cache-folder` when both of them are specified.", "KHI_TASK_CACHE_REDIS_ADDRESS")
+	c.TaskCacheRedisPassword = flag.String("task-cache-redis-password", "", "The password used to authenticate to the Redis server specified with `--task-cache-redis-address`.", "KHI_TASK_CACHE_REDIS_PASSWORD")
+	c.TaskCacheRedisTLS = flag.Bool("task-cache-redis-tls", false, "Connect to the Redis server specified with `--task-cache-redis-address` over TLS. The server certificate is verified with the system root CAs.", "KHI_TASK_CACHE_REDIS_TLS")
+	c.TaskCacheTTLSeconds = flag.Int("task-cache-ttl-seconds", 24*60*60, "The lifetime of each cached task result stored in the Redis server in seconds. 0 means no expiration.", "")
+	c.MaxConcurrentTasks = flag.Int("max-concurrent-tasks", 0, "The maximum number of tasks running at the same time in an inspection. Set a small value to avoid exhausting memory or API quota on a large inspection. 0 means unlimited.", "KHI_MAX_CONCURRENT_TASKS")
+	c.TaskMemoryLimitMB = flag.Int("task-memory-limit-mb", 0, "The heap usage in megabytes over which memory heavy tasks like log queries wait for other heavy tasks to finish before starting. Set a value smaller than the memory available for KHI to avoid running out of memory on a large inspection. 0 means unlimited.", "KHI_TASK_MEMORY_LIMIT_MB")
+	c.InspectionCheckpointFolder = flag.String("inspection-checkpoint-folder", "", "The folder path to store logs queried in an inspection run. When the server crashed in the middle of a run, running the same inspection again with the same credentials reuses these logs instead of querying them again. Parsing the logs runs again. Checkpoints of incomplete runs are removed after 24 hours. Checkpointing is disabled when this value is not specified.", "KHI_INSPECTION_CHECKPOINT_FOLDER")
+	c.QueryResultCacheFolder = flag.String("query-result-cache-folder", "", "The folder path to cache raw log entries returned from Cloud Logging queries. Inspections querying the same logs with the same filter, time range and credentials reuse the cached entries instead of downloading them again. Cached entries are removed with `--query-result-cache-ttl-seconds` and `--query-result-cache-max-size-mb`. The query result cache is disabled when this value is not specified.", "KHI_QUERY_RESULT_CACHE_FOLDER")
+	c.QueryResultCacheTTLSeconds = flag.Int("query-result-cache-ttl-seconds", 7*24*60*60, "The duration in seconds to keep a cached query result not reused in the folder specified with `--query-result-cache-folder`. 0 means no expiration.", "")
+	c.QueryResultCacheMaxSizeMB = flag.Int("query-result-cache-max-size-mb", 10*1024, "The total size in megabytes of cached query results in the folder specified with `--query-result-cache-folder`. Least recently used results are removed when the size exceeds this value. 0 means unlimited.", "")
+	c.CloudLoggingMaxRequestsPerMinute = flag.Int("cloud-logging-max-requests-per-minute", 0, "The maximum number of Cloud Logging list requests sent per minute from all log queries in an inspection. Set a value below the read quota of the project (60 requests per minute by default) to avoid the queries failing with quota errors on a large inspection. 0 means unlimited.", "KHI_CLOUD_LOGGING_MAX_REQUESTS_PER_MINUTE")
+	c.CloudLoggingMaxConcurrentReads = flag.Int("cloud-logging-max-concurrent-reads", 0, "The maximum number of Cloud Logging list requests in flight at the same time from all log queries in an inspection. 0 means unlimited.", "KHI_CLOUD_LOGGING_MAX_CONCURRENT_READS")
+	c.CloudLoggingEndpoint = flag.String("cloud-logging-endpoint", "", "The address(host:port) of the Cloud Logging API endpoint used instead of the global endpoint. Use `--cloud-logging-region` instead to use a regional endpoint.", "KHI_CLOUD_LOGGING_ENDPOINT")
+	c.CloudLoggingRegion = flag.String("cloud-logging-region", "", "The location of the regional Cloud Logging API endpoint(`logging.<location>.rep.googleapis.com`) used instead of the global endpoint. Use this to read logs stored in regionalized log buckets under data residency requirements.", "KHI_CLOUD_LOGGING_REGION")
+	c.CloudLoggingDataBoundary = flag.String("cloud-logging-data-boundary", "", "The data boundary the Cloud Logging requests must stay in. The only supported value is `eu`, that requires `--cloud-logging-region` to be a location in EU.", "KHI_CLOUD_LOGGING_DATA_BOUNDARY")
+	c.ParserPluginFolder = flag.String("parser-plugin-folder", "", "The folder path containing YAML manifests of external parser plugins. Each plugin is registered as a feature parsing logs with the command or the declarative parser definition in the manifest. No plugin is loaded when this value is not specified.", "KHI_PARSER_PLUGIN_FOLDER")
+	c.K8sSchemaSources = flag.String("k8s-schema-sources", "", "The comma separated list of file paths or URLs of OpenAPI documents(e.g. the response of `kubectl get --raw /openapi/v2`) or CustomResourceDefinition manifests. The list merge keys and strategies in these schemas are used to reconstruct resources from patch requests in audit logs, in addition to the schema of the built-in resources.", "KHI_K8S_SCHEMA_SOURCES")
 	return nil
 }
 
@@ -32,10 +32,29 @@ func TestCommonParameters(t *testing.T) {
 		{
 			name: "default",
 			want: &CommonParameters{
-				DataDestinationFolder: testutil.P("./data"),
-				TemporaryFolder:       testutil.P("/tmp"),
-				Version:               testutil.P(false),
-				UploadFileStoreFolder: testutil.P("./data/upload"),
+				DataDestinationFolder:            testutil.P("./data"),
+				TemporaryFolder:                  testutil.P("/tmp"),
+				Version:                          testutil.P(false),
+				UploadFileStoreFolder:            testutil.P("./data/upload"),
+				PresetFolder:                     testutil.P("./data/presets"),
+				MaxConcurrentTasks:               testutil.P(0),
+				TaskMemoryLimitMB:                testutil.P(0),
+				InspectionCheckpointFolder:       testutil.P(""),
+				TaskCacheFolder:                  testutil.P(""),
+				QueryResultCacheFolder:           testutil.P(""),
+				QueryResultCacheTTLSeconds:       testutil.P(7 * 24 * 60 * 60),
+				QueryResultCacheMaxSizeMB:        testutil.P(10 * 1024),
+				TaskCacheRedisAddress:            testutil.P(""),
+				TaskCacheRedisPassword:           testutil.P(""),
+				TaskCacheRedisTLS:                testutil.P(false),
+				TaskCacheTTLSeconds:              testutil.P(24 * 60 * 60),
+				CloudLoggingMaxRequestsPerMinute: testutil.P(0),
+				CloudLoggingMaxConcurrentReads:   testutil.P(0),
+				CloudLoggingEndpoint:             testutil.P(""),
+				CloudLoggingRegion:               testutil.P(""),
+				CloudLoggingDataBoundary:         testutil.P(""),
+				ParserPluginFolder:               testutil.P(""),
+				K8sSchemaSources:                 testutil.P(""),
 			},
 			before: func() {
 				os.Args = []string{os.Args[0]}
@@ -75,12 +94,14 @@ func TestCommonParametersPostProcess(t *testing.T) {
 				TemporaryFolder:       testutil.P("/tmp"),
 				Version:               testutil.P(false),
 				UploadFileStoreFolder: testutil.P(""),
+				PresetFolder:          testutil.P(""),
 			},
 			want: CommonParameters{
 				DataDestinationFolder: testutil.P("./data"),
 				TemporaryFolder:       testutil.P("/tmp"),
 				Version:               testutil.P(false),
 				UploadFileStoreFolder: testutil.P("./data/upload"),
+				PresetFolder:          testutil.P("./data/presets"),
 			},
 		},
 		{
@@ -90,12 +111,14 @@ func TestCommonParametersPostProcess(t *testing.T) {
 				TemporaryFolder:       testutil.P("/tmp"),
 				Version:               testutil.P(false),
 				UploadFileStoreFolder: testutil.P("/foo/bar"),
+				PresetFolder:          testutil.P("/foo/presets"),
 			},
 			want: CommonParameters{
 				DataDestinationFolder: testutil.P("./data"),
 				TemporaryFolder:       testutil.P("/tmp"),
 				Version:               testutil.P(false),
 				UploadFileStoreFolder: testutil.P("/foo/bar"),
+				PresetFolder:          testutil.P("/foo/presets"),
 			},
 		},
 	}
@@ -113,3 +136,42 @@ func TestCommonParametersPostProcess(t *testing.T) {
 		})
 	}
 }
+
+func TestCommonParametersPostProcessWithCloudLoggingEndpoint(t *testing.T) {
+	testCases := []struct {
+		name         string
+		endpoint     string
+		region       string
+		dataBoundary string
+		wantErr      bool
+	}{
+		{name: "no endpoint"},
+		{name: "custom endpoint", endpoint: "logging.example.com:443"},
+		{name: "region", region: "us-central1"},
+		{name: "custom endpoint and region", endpoint: "logging.example.com:443", region: "us-central1", wantErr: true},
+		{name: "eu data boundary with a region in EU", region: "europe-west1", dataBoundary: "eu"},
+		{name: "eu data boundary with the EU multi region", region: "eu", dataBoundary: "eu"},
+		{name: "eu data boundary without region", dataBoundary: "eu", wantErr: true},
+		{name: "eu data boundary with a region outside of EU", region: "us-central1", dataBoundary: "eu", wantErr: true},
+		{name: "unsupported data boundary", region: "us-central1", dataBoundary: "us", wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			prepareFlagParsingTest(t)
+			params := CommonParameters{
+				DataDestinationFolder:    testutil.P("./data"),
+				TemporaryFolder:          testutil.P("/tmp"),
+				Version:                  testutil.P(false),
+				UploadFileStoreFolder:    testutil.P(""),
+				PresetFolder:             testutil.P(""),
+				CloudLoggingEndpoint:     testutil.P(tc.endpoint),
+				CloudLoggingRegion:       testutil.P(tc.region),
+				CloudLoggingDataBoundary: testutil.P(tc.dataBoundary),
+			}
+			err := params.PostProcess()
+			if (err != nil) != tc.wantErr {
+				t.Errorf("PostProcess() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
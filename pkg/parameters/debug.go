@@ -45,6 +45,17 @@ type DebugParameters struct {
 	// CloudTraceProject
 	// The GCP project ID where the trace sends the data to.
 	CloudTraceProject *string
+
+	// GCPAPIRecordFolder
+	// The folder where KHI records the sanitized responses of Google Cloud APIs.
+	GCPAPIRecordFolder *string
+	// GCPAPIReplayFolder
+	// The folder where KHI reads the recorded responses of Google Cloud APIs from instead of sending requests.
+	GCPAPIReplayFolder *string
+
+	// TaskStats
+	// If this flag is set, KHI records the wall time and the output size of each task in the inspection result.
+	TaskStats *bool
 }
 
 // PostProcess implements ParameterStore.
@@ -55,6 +66,9 @@ func (d *DebugParameters) PostProcess() error {
 	if *d.CloudTrace && (d.CloudTraceProject == nil || *d.CloudTraceProject == "") {
 		return errors.New("--cloud-trace-project-id is required when --cloud-trace is set")
 	}
+	if *d.GCPAPIRecordFolder != "" && *d.GCPAPIReplayFolder != "" {
+		return errors.New("--gcp-api-record-folder and --gcp-api-replay-folder can't be set at the same time")
+	}
 	return nil
 }
 
@@ -67,6 +81,9 @@ func (d *DebugParameters) Prepare() error {
 	d.NoColor = flag.Bool("no-color", false, "If this flag is set, KHI prints logs without color.", "")
 	d.CloudTrace = flag.Bool("cloud-trace", false, "If this flag is set, KHI sends traces to Cloud Trace.", "")
 	d.CloudTraceProject = flag.String("cloud-trace-project-id", "", "The GCP project ID where the trace sends the data to.", "")
+	d.GCPAPIRecordFolder = flag.String("gcp-api-record-folder", "", "The folder where KHI records the sanitized responses of Google Cloud APIs. The recorded responses can be replayed with --gcp-api-replay-folder.", "")
+	d.TaskStats = flag.Bool("task-stats", false, "If this flag is set, KHI records the wall time and the output size of each task in the inspection result to analyze performance regressions.", "")
+	d.GCPAPIReplayFolder = flag.String("gcp-api-replay-folder", "", "The folder where KHI reads the responses recorded with --gcp-api-record-folder from. Google Cloud APIs are not called when this flag is set.", "")
 	return nil
 }
 
@@ -36,13 +36,16 @@ func TestDebugParameters(t *testing.T) {
 			},
 			name: "default",
 			want: &DebugParameters{
-				Profiler:          testutil.P(false),
-				ProfilerService:   testutil.P("khi"),
-				ProfilerProject:   testutil.P(""),
-				Verbose:           testutil.P(false),
-				NoColor:           testutil.P(false),
-				CloudTrace:        testutil.P(false),
-				CloudTraceProject: testutil.P(""),
+				Profiler:           testutil.P(false),
+				ProfilerService:    testutil.P("khi"),
+				ProfilerProject:    testutil.P(""),
+				Verbose:            testutil.P(false),
+				NoColor:            testutil.P(false),
+				CloudTrace:         testutil.P(false),
+				CloudTraceProject:  testutil.P(""),
+				GCPAPIRecordFolder: testutil.P(""),
+				GCPAPIReplayFolder: testutil.P(""),
+				TaskStats:          testutil.P(false),
 			},
 		},
 		{
@@ -52,13 +55,16 @@ func TestDebugParameters(t *testing.T) {
 			},
 			name: "cloud trace enabled",
 			want: &DebugParameters{
-				Profiler:          testutil.P(false),
-				ProfilerService:   testutil.P("khi"),
-				ProfilerProject:   testutil.P(""),
-				Verbose:           testutil.P(false),
-				NoColor:           testutil.P(false),
-				CloudTrace:        testutil.P(true),
-				CloudTraceProject: testutil.P("my-project"),
+				Profiler:           testutil.P(false),
+				ProfilerService:    testutil.P("khi"),
+				ProfilerProject:    testutil.P(""),
+				Verbose:            testutil.P(false),
+				NoColor:            testutil.P(false),
+				CloudTrace:         testutil.P(true),
+				CloudTraceProject:  testutil.P("my-project"),
+				GCPAPIRecordFolder: testutil.P(""),
+				GCPAPIReplayFolder: testutil.P(""),
+				TaskStats:          testutil.P(false),
 			},
 		},
 	}
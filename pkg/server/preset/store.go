@@ -0,0 +1,189 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preset
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxPresetNameLength is the maximum length of a preset name.
+const maxPresetNameLength = 100
+
+// ErrPresetNotFound is returned when the preset with the given name doesn't exist.
+var ErrPresetNotFound = errors.New("preset not found")
+
+// Preset is a named set of form parameter values saved for an inspection type.
+type Preset struct {
+	// Name is the unique name of the preset in the inspection type.
+	Name string `json:"name"`
+	// InspectionType is the ID of the inspection type the preset was saved for.
+	InspectionType string `json:"inspectionType"`
+	// Values is the map of form field IDs and their values.
+	Values map[string]any `json:"values"`
+	// UpdatedAt is the time when the preset was saved last time.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// PresetStore saves presets on the local file system.
+// Presets of an inspection type are stored in a JSON file named with the digest of the inspection type ID.
+type PresetStore struct {
+	folder string
+	lock   sync.Mutex
+}
+
+// NewPresetStore returns a PresetStore storing presets in the given folder.
+// The folder is created when a preset is saved first time.
+func NewPresetStore(folder string) *PresetStore {
+	return &PresetStore{
+		folder: folder,
+	}
+}
+
+// List returns the presets saved for the inspection type sorted by name.
+func (s *PresetStore) List(inspectionType string) ([]*Preset, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	presets, err := s.read(inspectionType)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*Preset, 0, len(presets))
+	for _, preset := range presets {
+		result = append(result, preset)
+	}
+	slices.SortFunc(result, func(a, b *Preset) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return result, nil
+}
+
+// Get returns the preset with the name saved for the inspection type. It returns ErrPresetNotFound when the preset doesn't exist.
+func (s *PresetStore) Get(inspectionType string, name string) (*Preset, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	presets, err := s.read(inspectionType)
+	if err != nil {
+		return nil, err
+	}
+	preset, found := presets[name]
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrPresetNotFound, name)
+	}
+	return preset, nil
+}
+
+// Save stores the values as a preset with the name for the inspection type. The existing preset with the same name is overwritten.
+func (s *PresetStore) Save(inspectionType string, name string, values map[string]any) (*Preset, error) {
+	if err := ValidatePresetName(name); err != nil {
+		return nil, err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	presets, err := s.read(inspectionType)
+	if err != nil {
+		return nil, err
+	}
+	preset := &Preset{
+		Name:           name,
+		InspectionType: inspectionType,
+		Values:         values,
+		UpdatedAt:      time.Now(),
+	}
+	presets[name] = preset
+	if err := s.write(inspectionType, presets); err != nil {
+		return nil, err
+	}
+	return preset, nil
+}
+
+// Delete removes the preset with the name saved for the inspection type. It returns ErrPresetNotFound when the preset doesn't exist.
+func (s *PresetStore) Delete(inspectionType string, name string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	presets, err := s.read(inspectionType)
+	if err != nil {
+		return err
+	}
+	if _, found := presets[name]; !found {
+		return fmt.Errorf("%w: %s", ErrPresetNotFound, name)
+	}
+	delete(presets, name)
+	return s.write(inspectionType, presets)
+}
+
+// ValidatePresetName returns an error when the name can't be used as a preset name.
+func ValidatePresetName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("preset name must not be empty")
+	}
+	if len(name) > maxPresetNameLength {
+		return fmt.Errorf("preset name must be shorter than %d characters", maxPresetNameLength)
+	}
+	return nil
+}
+
+func (s *PresetStore) read(inspectionType string) (map[string]*Preset, error) {
+	serialized, err := os.ReadFile(s.presetFilePath(inspectionType))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return map[string]*Preset{}, nil
+		}
+		return nil, err
+	}
+	presets := map[string]*Preset{}
+	if err := json.Unmarshal(serialized, &presets); err != nil {
+		return nil, fmt.Errorf("failed to parse the preset file for inspection type %s\n%w", inspectionType, err)
+	}
+	return presets, nil
+}
+
+func (s *PresetStore) write(inspectionType string, presets map[string]*Preset) error {
+	if err := os.MkdirAll(s.folder, 0755); err != nil {
+		return err
+	}
+	serialized, err := json.Marshal(presets)
+	if err != nil {
+		return err
+	}
+	// Write to a temporary file first not to leave a broken preset file when the process is terminated during writing it.
+	tmpFile, err := os.CreateTemp(s.folder, "tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, err := tmpFile.Write(serialized); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile.Name(), s.presetFilePath(inspectionType))
+}
+
+func (s *PresetStore) presetFilePath(inspectionType string) string {
+	digest := sha256.Sum256([]byte(inspectionType))
+	return filepath.Join(s.folder, hex.EncodeToString(digest[:])+".json")
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preset
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestPresetStore(t *testing.T) {
+	folder := t.TempDir()
+	store := NewPresetStore(folder)
+
+	presets, err := store.List("foo")
+	if err != nil {
+		t.Fatalf("List() returned an unexpected error: %v", err)
+	}
+	if len(presets) != 0 {
+		t.Errorf("List() returned %d presets before saving any preset, want 0", len(presets))
+	}
+
+	if _, err := store.Save("foo", "prod", map[string]any{"project": "prod-project"}); err != nil {
+		t.Fatalf("Save() returned an unexpected error: %v", err)
+	}
+	if _, err := store.Save("foo", "dev", map[string]any{"project": "dev-project"}); err != nil {
+		t.Fatalf("Save() returned an unexpected error: %v", err)
+	}
+	if _, err := store.Save("foo", "prod", map[string]any{"project": "prod-project-2"}); err != nil {
+		t.Fatalf("Save() returned an unexpected error: %v", err)
+	}
+	if _, err := store.Save("bar", "prod", map[string]any{"project": "bar-project"}); err != nil {
+		t.Fatalf("Save() returned an unexpected error: %v", err)
+	}
+
+	// Presets must be read from the file system with another instance.
+	store = NewPresetStore(folder)
+	presets, err = store.List("foo")
+	if err != nil {
+		t.Fatalf("List() returned an unexpected error: %v", err)
+	}
+	want := []*Preset{
+		{Name: "dev", InspectionType: "foo", Values: map[string]any{"project": "dev-project"}},
+		{Name: "prod", InspectionType: "foo", Values: map[string]any{"project": "prod-project-2"}},
+	}
+	if diff := cmp.Diff(want, presets, cmpopts.IgnoreFields(Preset{}, "UpdatedAt")); diff != "" {
+		t.Errorf("List() returned unexpected presets (-want +got):\n%s", diff)
+	}
+
+	preset, err := store.Get("bar", "prod")
+	if err != nil {
+		t.Fatalf("Get() returned an unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(map[string]any{"project": "bar-project"}, preset.Values); diff != "" {
+		t.Errorf("Get() returned unexpected values (-want +got):\n%s", diff)
+	}
+
+	if err := store.Delete("foo", "prod"); err != nil {
+		t.Fatalf("Delete() returned an unexpected error: %v", err)
+	}
+	if _, err := store.Get("foo", "prod"); !errors.Is(err, ErrPresetNotFound) {
+		t.Errorf("Get() returned %v for a deleted preset, want %v", err, ErrPresetNotFound)
+	}
+	if err := store.Delete("foo", "prod"); !errors.Is(err, ErrPresetNotFound) {
+		t.Errorf("Delete() returned %v for a deleted preset, want %v", err, ErrPresetNotFound)
+	}
+}
+
+func TestValidatePresetName(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "valid name", input: "prod cluster"},
+		{name: "empty name", input: "", wantErr: true},
+		{name: "whitespace only", input: "  ", wantErr: true},
+		{name: "too long name", input: strings.Repeat("a", maxPresetNameLength+1), wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidatePresetName(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ValidatePresetName(%q) returned %v, wantErr %v", tc.input, err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -16,8 +16,10 @@ package server
 
 import (
 	"embed"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"math"
 	"net/http"
@@ -25,6 +27,7 @@ import (
 	"path"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/kyasbal/khi/pkg/common/filter"
 	"github.com/kyasbal/khi/pkg/common/typedmap"
@@ -33,11 +36,13 @@ import (
 	"github.com/kyasbal/khi/pkg/parameters"
 	"github.com/kyasbal/khi/pkg/server/config"
 	"github.com/kyasbal/khi/pkg/server/popup"
+	"github.com/kyasbal/khi/pkg/server/preset"
 	"github.com/kyasbal/khi/pkg/server/upload"
 	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
 
 	"github.com/gin-contrib/static"
 	"github.com/gin-gonic/gin"
+	"gopkg.in/yaml.v3"
 )
 
 const embeddedStaticFolderPath = "dist/browser"
@@ -51,6 +56,8 @@ type ServerConfig struct {
 	ResourceMonitor  ResourceMonitor
 	ServerBasePath   string
 	UploadFileStore  *upload.UploadFileStore
+	// PresetStore stores the named presets of form parameter values. The preset endpoints are disabled when this is nil.
+	PresetStore *preset.PresetStore
 }
 
 func redirectMiddleware(exactPath string, redirectTo string) gin.HandlerFunc {
@@ -300,6 +307,68 @@ func CreateKHIServer(engine *gin.Engine, inspectionServer *coreinspection.Inspec
 			ctx.String(http.StatusOK, "ok")
 		})
 
+		// POST /api/v3/inspection/<inspection-id>/follow
+		// Starts re-running the inspection periodically with its time ranges extended to the current time.
+		router.POST("/api/v3/inspection/:inspectionID/follow", func(ctx *gin.Context) {
+			inspectionID := ctx.Param("inspectionID")
+			if inspectionServer.GetInspection(inspectionID) == nil {
+				ctx.String(http.StatusNotFound, fmt.Sprintf("inspection %s was not found", inspectionID))
+				return
+			}
+			var reqBody PostInspectionFollowRequest
+			if err := ctx.ShouldBindJSON(&reqBody); err != nil {
+				ctx.String(http.StatusBadRequest, err.Error())
+				return
+			}
+			interval := coreinspection.DefaultFollowInterval
+			if reqBody.IntervalSeconds != 0 {
+				interval = time.Duration(reqBody.IntervalSeconds) * time.Second
+			}
+			follower, err := inspectionServer.FollowInspection(inspectionID, interval)
+			if err != nil {
+				ctx.String(http.StatusBadRequest, err.Error())
+				return
+			}
+			ctx.JSON(http.StatusAccepted, follower.Latest())
+		})
+
+		// DELETE /api/v3/inspection/<inspection-id>/follow
+		// Stops re-running the followed inspection.
+		router.DELETE("/api/v3/inspection/:inspectionID/follow", func(ctx *gin.Context) {
+			inspectionID := ctx.Param("inspectionID")
+			err := inspectionServer.StopFollowing(inspectionID)
+			if err != nil {
+				ctx.String(http.StatusNotFound, err.Error())
+				return
+			}
+			ctx.String(http.StatusOK, "ok")
+		})
+
+		// GET /api/v3/inspection/<inspection-id>/follow/events
+		// Streams the events of the followed inspection as server-sent events until the follower is stopped.
+		router.GET("/api/v3/inspection/:inspectionID/follow/events", func(ctx *gin.Context) {
+			inspectionID := ctx.Param("inspectionID")
+			follower := inspectionServer.GetFollower(inspectionID)
+			if follower == nil {
+				ctx.String(http.StatusNotFound, fmt.Sprintf("inspection %s is not followed", inspectionID))
+				return
+			}
+			events, unsubscribe := follower.Subscribe()
+			defer unsubscribe()
+			ctx.Stream(func(w io.Writer) bool {
+				select {
+				case event, open := <-events:
+					if !open {
+						return false
+					}
+					ctx.SSEvent(string(event.Type), event)
+					return event.Type != coreinspection.InspectionFollowEventStopped
+				case <-ctx.Request.Context().Done():
+					return false
+				}
+			})
+		})
+
 		router.GET("/api/v3/inspection/:inspectionID/metadata", func(ctx *gin.Context) {
 			inspectionID := ctx.Param("inspectionID")
 			currentTask := inspectionServer.GetInspection(inspectionID)
@@ -315,6 +384,234 @@ func CreateKHIServer(engine *gin.Engine, inspectionServer *coreinspection.Inspec
 			ctx.JSON(http.StatusOK, result)
 		})
 
+		// GET /api/v3/inspection/<inspection-id>/debug/taskgraph?format=<json|dot>
+		router.GET("/api/v3/inspection/:inspectionID/debug/taskgraph", func(ctx *gin.Context) {
+			inspectionID := ctx.Param("inspectionID")
+			currentTask := inspectionServer.GetInspection(inspectionID)
+			if currentTask == nil {
+				ctx.String(http.StatusNotFound, fmt.Sprintf("inspecton %s was not found", inspectionID))
+				return
+			}
+			taskGraph, err := currentTask.TaskGraph()
+			if err != nil {
+				ctx.String(http.StatusBadRequest, err.Error())
+				return
+			}
+			switch format := ctx.DefaultQuery("format", "json"); format {
+			case "json":
+				description, err := taskGraph.DumpDescription()
+				if err != nil {
+					ctx.String(http.StatusInternalServerError, err.Error())
+					return
+				}
+				ctx.JSON(http.StatusOK, description)
+			case "dot":
+				graph, err := taskGraph.DumpGraphviz()
+				if err != nil {
+					ctx.String(http.StatusInternalServerError, err.Error())
+					return
+				}
+				ctx.Data(http.StatusOK, "text/vnd.graphviz; charset=utf-8", []byte(graph))
+			default:
+				ctx.String(http.StatusBadRequest, fmt.Sprintf("unsupported task graph format %s", format))
+			}
+		})
+
+		// GET /api/v3/inspection/<inspection-id>/taskstates
+		router.GET("/api/v3/inspection/:inspectionID/taskstates", func(ctx *gin.Context) {
+			inspectionID := ctx.Param("inspectionID")
+			currentTask := inspectionServer.GetInspection(inspectionID)
+			if currentTask == nil {
+				ctx.String(http.StatusNotFound, fmt.Sprintf("inspecton %s was not found", inspectionID))
+				return
+			}
+			states, err := currentTask.TaskExecutionStates()
+			if err != nil {
+				ctx.String(http.StatusBadRequest, err.Error())
+				return
+			}
+			ctx.JSON(http.StatusOK, states)
+		})
+
+		// GET /api/v3/inspection/<inspection-id>/parameters
+		// Returns the form parameter values resolved in the run as a file to reproduce the inspection later.
+		// The file is in YAML by default, and in JSON when the `format` query parameter is `json`.
+		router.GET("/api/v3/inspection/:inspectionID/parameters", func(ctx *gin.Context) {
+			inspectionID := ctx.Param("inspectionID")
+			currentTask := inspectionServer.GetInspection(inspectionID)
+			if currentTask == nil {
+				ctx.String(http.StatusNotFound, fmt.Sprintf("inspection %s was not found", inspectionID))
+				return
+			}
+			exportedParameters, err := currentTask.ExportParameters()
+			if err != nil {
+				ctx.String(http.StatusBadRequest, err.Error())
+				return
+			}
+			format := ctx.DefaultQuery("format", "yaml")
+			var serialized []byte
+			var contentType string
+			switch format {
+			case "yaml":
+				serialized, err = yaml.Marshal(exportedParameters)
+				contentType = "application/yaml; charset=utf-8"
+			case "json":
+				serialized, err = json.MarshalIndent(exportedParameters, "", "  ")
+				contentType = "application/json; charset=utf-8"
+			default:
+				ctx.String(http.StatusBadRequest, fmt.Sprintf("unsupported format %s", format))
+				return
+			}
+			if err != nil {
+				ctx.String(http.StatusInternalServerError, err.Error())
+				return
+			}
+			ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"khi-parameters-%s.%s\"", inspectionID, format))
+			ctx.Data(http.StatusOK, contentType, serialized)
+		})
+
+		// POST /api/v3/inspection/<inspection-id>/parameters/import
+		// Parses the exported parameter file in the request body and returns the values to pre-fill the form of the inspection.
+		router.POST("/api/v3/inspection/:inspectionID/parameters/import", func(ctx *gin.Context) {
+			inspectionID := ctx.Param("inspectionID")
+			currentTask := inspectionServer.GetInspection(inspectionID)
+			if currentTask == nil {
+				ctx.String(http.StatusNotFound, fmt.Sprintf("inspection %s was not found", inspectionID))
+				return
+			}
+			body, err := ctx.GetRawData()
+			if err != nil {
+				ctx.String(http.StatusBadRequest, err.Error())
+				return
+			}
+			importedParameters, err := coreinspection.ParseInspectionParameters(body)
+			if err != nil {
+				ctx.String(http.StatusBadRequest, err.Error())
+				return
+			}
+			if importedParameters.InspectionType != currentTask.InspectionType() {
+				ctx.String(http.StatusBadRequest, fmt.Sprintf("the parameters are for inspection type %s, but the inspection is %s", importedParameters.InspectionType, currentTask.InspectionType()))
+				return
+			}
+			ctx.JSON(http.StatusOK, &PostImportParametersResponse{
+				Values: importedParameters.Values,
+			})
+		})
+
+		// POST /api/v3/cache/invalidate
+		router.POST("/api/v3/cache/invalidate", func(ctx *gin.Context) {
+			var reqBody PostTaskCacheInvalidationRequest
+			if err := ctx.ShouldBindJSON(&reqBody); err != nil {
+				ctx.String(http.StatusBadRequest, err.Error())
+				return
+			}
+			err := inspectionServer.InvalidateTaskCache(ctx.Request.Context(), reqBody.TaskReferences, reqBody.ImplementationIDs)
+			if err != nil {
+				if errors.Is(err, coreinspection.ErrInvalidTaskCacheTarget) {
+					ctx.String(http.StatusBadRequest, err.Error())
+					return
+				}
+				ctx.String(http.StatusInternalServerError, err.Error())
+				return
+			}
+			ctx.String(http.StatusAccepted, "ok")
+		})
+
+		if serverConfig.PresetStore != nil {
+			// GET /api/v3/inspection/types/<type-id>/presets
+			// Returns the presets saved for the inspection type.
+			router.GET("/api/v3/inspection/types/:typeID/presets", func(ctx *gin.Context) {
+				typeID := ctx.Param("typeID")
+				presets, err := serverConfig.PresetStore.List(typeID)
+				if err != nil {
+					ctx.String(http.StatusInternalServerError, err.Error())
+					return
+				}
+				ctx.JSON(http.StatusOK, &GetPresetsResponse{
+					Presets: presets,
+				})
+			})
+
+			// DELETE /api/v3/inspection/types/<type-id>/presets/<preset-name>
+			router.DELETE("/api/v3/inspection/types/:typeID/presets/:presetName", func(ctx *gin.Context) {
+				typeID := ctx.Param("typeID")
+				presetName := ctx.Param("presetName")
+				err := serverConfig.PresetStore.Delete(typeID, presetName)
+				if err != nil {
+					if errors.Is(err, preset.ErrPresetNotFound) {
+						ctx.String(http.StatusNotFound, err.Error())
+						return
+					}
+					ctx.String(http.StatusInternalServerError, err.Error())
+					return
+				}
+				ctx.String(http.StatusOK, "ok")
+			})
+
+			// PUT /api/v3/inspection/<inspection-id>/presets/<preset-name>
+			// Saves the given values as a preset of the inspection type of the inspection. Values of secret fields are never saved.
+			router.PUT("/api/v3/inspection/:inspectionID/presets/:presetName", func(ctx *gin.Context) {
+				inspectionID := ctx.Param("inspectionID")
+				presetName := ctx.Param("presetName")
+				currentTask := inspectionServer.GetInspection(inspectionID)
+				if currentTask == nil {
+					ctx.String(http.StatusNotFound, fmt.Sprintf("inspection %s was not found", inspectionID))
+					return
+				}
+				var reqBody PutPresetRequest
+				if err := ctx.ShouldBindJSON(&reqBody); err != nil {
+					ctx.String(http.StatusBadRequest, err.Error())
+					return
+				}
+				if err := preset.ValidatePresetName(presetName); err != nil {
+					ctx.String(http.StatusBadRequest, err.Error())
+					return
+				}
+				secretFieldIDs, err := currentTask.SecretFormFieldIDs()
+				if err != nil {
+					ctx.String(http.StatusInternalServerError, fmt.Sprintf("failed to determine secret fields: %v", err))
+					return
+				}
+				values := map[string]any{}
+				for key, value := range reqBody.Values {
+					values[key] = value
+				}
+				for _, secretFieldID := range secretFieldIDs {
+					delete(values, secretFieldID)
+				}
+				savedPreset, err := serverConfig.PresetStore.Save(currentTask.InspectionType(), presetName, values)
+				if err != nil {
+					ctx.String(http.StatusInternalServerError, err.Error())
+					return
+				}
+				ctx.JSON(http.StatusOK, savedPreset)
+			})
+
+			// POST /api/v3/inspection/<inspection-id>/presets/<preset-name>/apply
+			// Returns the values of the preset saved for the inspection type of the inspection.
+			router.POST("/api/v3/inspection/:inspectionID/presets/:presetName/apply", func(ctx *gin.Context) {
+				inspectionID := ctx.Param("inspectionID")
+				presetName := ctx.Param("presetName")
+				currentTask := inspectionServer.GetInspection(inspectionID)
+				if currentTask == nil {
+					ctx.String(http.StatusNotFound, fmt.Sprintf("inspection %s was not found", inspectionID))
+					return
+				}
+				savedPreset, err := serverConfig.PresetStore.Get(currentTask.InspectionType(), presetName)
+				if err != nil {
+					if errors.Is(err, preset.ErrPresetNotFound) {
+						ctx.String(http.StatusNotFound, err.Error())
+						return
+					}
+					ctx.String(http.StatusInternalServerError, err.Error())
+					return
+				}
+				ctx.JSON(http.StatusOK, &PostApplyPresetResponse{
+					Values: savedPreset.Values,
+				})
+			})
+		}
+
 		router.GET("/api/v3/inspection/:inspectionID/data", func(ctx *gin.Context) {
 			inspectionID := ctx.Param("inspectionID")
 			currentTask := inspectionServer.GetInspection(inspectionID)
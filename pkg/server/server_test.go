@@ -41,6 +41,7 @@ import (
 	"github.com/kyasbal/khi/pkg/core/task/taskid"
 	"github.com/kyasbal/khi/pkg/server/config"
 	"github.com/kyasbal/khi/pkg/server/popup"
+	"github.com/kyasbal/khi/pkg/server/preset"
 	"github.com/kyasbal/khi/pkg/server/upload"
 	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
 	"github.com/kyasbal/khi/pkg/testutil"
@@ -119,6 +120,7 @@ func createTestInspectionServer() (*coreinspection.InspectionTaskServer, error)
 			return "", nil
 		}).Build(inspectioncore_contract.InspectionTypeLabel("foo")),
 		formtask.NewTextFormTaskBuilder(taskid.NewDefaultImplementationID[string]("bar-input"), 1, "A input field for bar").Build(inspectioncore_contract.InspectionTypeLabel("bar")),
+		formtask.NewSecretFormTaskBuilder(taskid.NewDefaultImplementationID[string]("foo-secret"), 2, "A secret field for foo").Build(inspectioncore_contract.InspectionTypeLabel("foo")),
 		inspectiontaskbase.NewProgressReportableInspectionTask(debugTaskImplID("feature-foo1"), []taskid.UntypedTaskReference{debugRef("foo-input")}, func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, tp *inspectionmetadata.TaskProgressMetadata) (any, error) {
 			return "feature-foo1-value", nil
 		}, inspectioncore_contract.FeatureTaskLabel("foo feature1", "test-feature", enum.LogTypeAudit, 10, false, "foo"), coretask.NewSubsequentTaskRefsTaskLabel(inspectioncore_contract.SerializerTaskID.Ref())),
@@ -324,7 +326,7 @@ func TestApiResponses(t *testing.T) {
 			RequestGenerator: func(t *testing.T, stat map[string]string) any {
 				return map[string]any{}
 			},
-			BodyValidator: metadataIgnoredBodyCompare(`{"metadata":{"form":[{"default":"","description":"","hint":"","hintType":"none","id":"foo-input","label":"A input field for foo","readonly":false,"suggestions":null,"type":"text","validationTiming":"change"}],"query":[]}}`, "plan"),
+			BodyValidator: metadataIgnoredBodyCompare(`{"metadata":{"form":[{"default":"","description":"","docUrl":"","hint":"","hintType":"none","id":"foo-input","label":"A input field for foo","markdown":false,"maxLength":0,"pattern":"","patternErrorMessage":"","placeholder":"","readonly":false,"readonlyReason":"","readonlySource":"","suggestionItems":null,"suggestions":null,"suggestionsLoading":false,"type":"text","validationTiming":"change"}],"formLayout":[{"fieldIds":["foo-input"],"id":"","label":""}],"query":[]}}`, "plan"),
 		},
 		{
 			// 008
@@ -337,7 +339,7 @@ func TestApiResponses(t *testing.T) {
 					"foo-input": "foo-input-value",
 				}
 			},
-			BodyValidator: metadataIgnoredBodyCompare(`{"metadata":{"form":[{"default":"","description":"","hint":"","hintType":"none","id":"foo-input","label":"A input field for foo","readonly":false,"suggestions":null,"type":"text","validationTiming":"change"}],"query":[]}}`, "plan"),
+			BodyValidator: metadataIgnoredBodyCompare(`{"metadata":{"form":[{"default":"","description":"","docUrl":"","hint":"","hintType":"none","id":"foo-input","label":"A input field for foo","markdown":false,"maxLength":0,"pattern":"","patternErrorMessage":"","placeholder":"","readonly":false,"readonlyReason":"","readonlySource":"","suggestionItems":null,"suggestions":null,"suggestionsLoading":false,"type":"text","validationTiming":"change"}],"formLayout":[{"fieldIds":["foo-input"],"id":"","label":""}],"query":[]}}`, "plan"),
 		},
 		{
 			// 009
@@ -350,7 +352,7 @@ func TestApiResponses(t *testing.T) {
 					"foo-input": "foo-input-invalid-value",
 				}
 			},
-			BodyValidator: metadataIgnoredBodyCompare(`{"metadata":{"form":[{"default":"","description":"","hint":"invalid value","hintType":"error","id":"foo-input","label":"A input field for foo","readonly":false,"suggestions":null,"type":"text","validationTiming":"change"}],"query":[]}}`, "plan"),
+			BodyValidator: metadataIgnoredBodyCompare(`{"metadata":{"form":[{"default":"","description":"","docUrl":"","hint":"invalid value","hintType":"error","id":"foo-input","label":"A input field for foo","markdown":false,"maxLength":0,"pattern":"","patternErrorMessage":"","placeholder":"","readonly":false,"readonlyReason":"","readonlySource":"","suggestionItems":null,"suggestions":null,"suggestionsLoading":false,"type":"text","validationTiming":"change"}],"formLayout":[{"fieldIds":["foo-input"],"id":"","label":""}],"query":[]}}`, "plan"),
 		},
 		{
 			// 010
@@ -419,7 +421,7 @@ func TestApiResponses(t *testing.T) {
 			ExpectedCode:  200,
 			RequestMethod: "GET",
 			RequestPath:   "/foo/api/v3/inspection",
-			BodyValidator: taskCompare("task-1", `{"error":{"errorMessages":[]},"progress":{"phase":"DONE","progresses":[],"totalProgress":{"id":"Total","indeterminate":false,"label":"Total","message":"2 of 2 tasks complete","percentage":1}}}`, "header"),
+			BodyValidator: taskCompare("task-1", `{"error":{"errorMessages":[]},"failedFeatures":{"failedFeatures":[]},"progress":{"phase":"DONE","progresses":[],"totalProgress":{"id":"Total","indeterminate":false,"label":"Total","message":"2 of 2 tasks complete","percentage":1}}}`, "header"),
 		},
 		{
 			// 017
@@ -510,7 +512,7 @@ func TestApiResponses(t *testing.T) {
 			ExpectedCode:  200,
 			RequestMethod: "GET",
 			RequestPath:   "/foo/api/v3/inspection",
-			BodyValidator: taskCompare("task-2", `{"error":{"errorMessages":[]},"progress":{"phase":"RUNNING","progresses":[{"id":"neverend#default","indeterminate":false,"label":"neverend#default","message":"test","percentage":0.5}],"totalProgress":{"id":"Total","indeterminate":false,"label":"Total","message":"0 of 3 tasks complete","percentage":0}}}`, "header"),
+			BodyValidator: taskCompare("task-2", `{"error":{"errorMessages":[]},"failedFeatures":{"failedFeatures":[]},"progress":{"phase":"RUNNING","progresses":[{"id":"neverend#default","indeterminate":false,"label":"neverend#default","message":"test","percentage":0.5}],"totalProgress":{"id":"Total","indeterminate":false,"label":"Total","message":"0 of 3 tasks complete","percentage":0}}}`, "header"),
 		},
 		{
 			// 026
@@ -537,7 +539,7 @@ func TestApiResponses(t *testing.T) {
 			ExpectedCode:  200,
 			RequestMethod: "GET",
 			RequestPath:   "/foo/api/v3/inspection",
-			BodyValidator: taskCompare("task-2", `{"error":{"errorMessages":[]},"progress":{"phase":"CANCELLED","progresses":[],"totalProgress":{"id":"Total","indeterminate":false,"label":"Total","message":"1 of 3 tasks complete","percentage":0.33333334}}}`, "header"),
+			BodyValidator: taskCompare("task-2", `{"error":{"errorMessages":[]},"failedFeatures":{"failedFeatures":[]},"progress":{"phase":"CANCELLED","progresses":[],"totalProgress":{"id":"Total","indeterminate":false,"label":"Total","message":"1 of 3 tasks complete","percentage":0.33333334}}}`, "header"),
 		},
 		{
 			// 030
@@ -583,7 +585,7 @@ func TestApiResponses(t *testing.T) {
 			ExpectedCode:  200,
 			RequestMethod: "GET",
 			RequestPath:   "/foo/api/v3/inspection",
-			BodyValidator: taskCompare("task-3", `{"error":{"errorMessages":[]},"progress":{"phase":"ERROR","progresses":[],"totalProgress":{"id":"Total","indeterminate":false,"label":"Total","message":"1 of 3 tasks complete","percentage":0.33333334}}}`, "header"),
+			BodyValidator: taskCompare("task-3", `{"error":{"errorMessages":[{"errorId":5,"link":"","message":"Feature \"qux feature1\" failed and its logs are missing in the result: dependency errorend failed"}]},"failedFeatures":{"failedFeatures":[{"error":"dependency errorend failed","id":"feature-qux#default","logType":2,"title":"qux feature1"}]},"progress":{"phase":"DONE","progresses":[],"totalProgress":{"id":"Total","indeterminate":false,"label":"Total","message":"3 of 3 tasks complete","percentage":1}}}`, "header"),
 		},
 		{
 			// 034
@@ -748,6 +750,520 @@ func TestApiResponses(t *testing.T) {
 	}
 }
 
+func TestDebugTaskGraphEndpoint(t *testing.T) {
+	testCases := []struct {
+		name             string
+		query            string
+		unknownID        bool
+		wantCode         int
+		wantBodyContains []string
+	}{
+		{
+			name:             "json format by default",
+			wantCode:         200,
+			wantBodyContains: []string{`"id":"feature-foo2#default"`, `"dependencies":["foo-input#default"]`},
+		},
+		{
+			name:             "dot format",
+			query:            "?format=dot",
+			wantCode:         200,
+			wantBodyContains: []string{"digraph G {", "foo_input_default -> feature_foo2_default"},
+		},
+		{
+			name:     "unsupported format",
+			query:    "?format=yaml",
+			wantCode: 400,
+		},
+		{
+			name:      "unknown inspection",
+			unknownID: true,
+			wantCode:  404,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger.InitGlobalKHILogger()
+			inspectionServer, err := createTestInspectionServer()
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			inspectionID, err := inspectionServer.CreateInspection("foo")
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			err = inspectionServer.GetInspection(inspectionID).SetFeatureList([]string{"feature-foo2#default"})
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			if tc.unknownID {
+				inspectionID = "not-existing-inspection"
+			}
+			engine := gin.New()
+			engine = CreateKHIServer(engine, inspectionServer, &ServerConfig{
+				StaticFolderPath: "dist",
+				ResourceMonitor:  &ResourceMonitorMock{UsedMemory: 1000},
+			})
+			recorder := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v3/inspection/%s/debug/taskgraph%s", inspectionID, tc.query), nil)
+			engine.ServeHTTP(recorder, req)
+			if recorder.Code != tc.wantCode {
+				t.Errorf("got response code %d, want %d\n%s", recorder.Code, tc.wantCode, recorder.Body)
+			}
+			for _, want := range tc.wantBodyContains {
+				if !strings.Contains(recorder.Body.String(), want) {
+					t.Errorf("response body doesn't contain %q\n%s", want, recorder.Body)
+				}
+			}
+		})
+	}
+}
+
+func TestTaskStatesEndpoint(t *testing.T) {
+	testCases := []struct {
+		name             string
+		run              bool
+		unknownID        bool
+		wantCode         int
+		wantBodyContains []string
+	}{
+		{
+			name:             "completed inspection",
+			run:              true,
+			wantCode:         200,
+			wantBodyContains: []string{`"id":"feature-foo2#default"`, `"dependencies":["foo-input#default"]`, `"state":"done"`, `"cacheHit":false`},
+		},
+		{
+			name:     "not started inspection",
+			wantCode: 400,
+		},
+		{
+			name:      "unknown inspection",
+			unknownID: true,
+			wantCode:  404,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger.InitGlobalKHILogger()
+			inspectionServer, err := createTestInspectionServer()
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			inspectionID, err := inspectionServer.CreateInspection("foo")
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			inspection := inspectionServer.GetInspection(inspectionID)
+			err = inspection.SetFeatureList([]string{"feature-foo2#default"})
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			if tc.run {
+				err = inspection.Run(context.Background(), &inspectioncore_contract.InspectionRequest{
+					Values: map[string]any{"foo-input": "foo-input-value"},
+				})
+				if err != nil {
+					t.Fatalf("unexpected error %s", err)
+				}
+				<-inspection.Wait()
+			}
+			if tc.unknownID {
+				inspectionID = "not-existing-inspection"
+			}
+			engine := gin.New()
+			engine = CreateKHIServer(engine, inspectionServer, &ServerConfig{
+				StaticFolderPath: "dist",
+				ResourceMonitor:  &ResourceMonitorMock{UsedMemory: 1000},
+			})
+			recorder := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v3/inspection/%s/taskstates", inspectionID), nil)
+			engine.ServeHTTP(recorder, req)
+			if recorder.Code != tc.wantCode {
+				t.Errorf("got response code %d, want %d\n%s", recorder.Code, tc.wantCode, recorder.Body)
+			}
+			for _, want := range tc.wantBodyContains {
+				if !strings.Contains(recorder.Body.String(), want) {
+					t.Errorf("response body doesn't contain %q\n%s", want, recorder.Body)
+				}
+			}
+		})
+	}
+}
+
+func TestCacheInvalidationEndpoint(t *testing.T) {
+	testCases := []struct {
+		name     string
+		body     string
+		wantCode int
+	}{
+		{
+			name:     "valid references and implementation IDs",
+			body:     `{"taskReferences":["cache-invalidation-endpoint-test"],"implementationIDs":["cache-invalidation-endpoint-test#default"]}`,
+			wantCode: 202,
+		},
+		{
+			name:     "reference ID with implementation hash",
+			body:     `{"taskReferences":["cache-invalidation-endpoint-test#default"]}`,
+			wantCode: 400,
+		},
+		{
+			name:     "implementation ID without implementation hash",
+			body:     `{"implementationIDs":["cache-invalidation-endpoint-test"]}`,
+			wantCode: 400,
+		},
+		{
+			name:     "malformed body",
+			body:     `{`,
+			wantCode: 400,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			inspectionServer, err := createTestInspectionServer()
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			engine := gin.New()
+			engine = CreateKHIServer(engine, inspectionServer, &ServerConfig{
+				StaticFolderPath: "dist",
+				ResourceMonitor:  &ResourceMonitorMock{UsedMemory: 1000},
+			})
+			recorder := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/v3/cache/invalidate", strings.NewReader(tc.body))
+			engine.ServeHTTP(recorder, req)
+			if recorder.Code != tc.wantCode {
+				t.Errorf("got response code %d, want %d\n%s", recorder.Code, tc.wantCode, recorder.Body)
+			}
+		})
+	}
+}
+
+func TestParametersEndpoints(t *testing.T) {
+	logger.InitGlobalKHILogger()
+	inspectionServer, err := createTestInspectionServer()
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	inspectionID, err := inspectionServer.CreateInspection("foo")
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	notStartedInspectionID, err := inspectionServer.CreateInspection("foo")
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	inspection := inspectionServer.GetInspection(inspectionID)
+	err = inspection.SetFeatureList([]string{"feature-foo2#default"})
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	err = inspection.Run(context.Background(), &inspectioncore_contract.InspectionRequest{
+		Values: map[string]any{"foo-input": "foo-input-value"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	<-inspection.Wait()
+	engine := gin.New()
+	engine = CreateKHIServer(engine, inspectionServer, &ServerConfig{
+		StaticFolderPath: "dist",
+		ResourceMonitor:  &ResourceMonitorMock{UsedMemory: 1000},
+	})
+
+	testCases := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:     "export in yaml",
+			method:   "GET",
+			path:     fmt.Sprintf("/api/v3/inspection/%s/parameters", inspectionID),
+			wantCode: 200,
+			wantBody: "inspectionType: foo\nvalues:\n    foo-input: foo-input-value\n",
+		},
+		{
+			name:     "export in json",
+			method:   "GET",
+			path:     fmt.Sprintf("/api/v3/inspection/%s/parameters?format=json", inspectionID),
+			wantCode: 200,
+			wantBody: "{\n  \"inspectionType\": \"foo\",\n  \"values\": {\n    \"foo-input\": \"foo-input-value\"\n  }\n}",
+		},
+		{
+			name:     "export in unsupported format",
+			method:   "GET",
+			path:     fmt.Sprintf("/api/v3/inspection/%s/parameters?format=xml", inspectionID),
+			wantCode: 400,
+		},
+		{
+			name:     "export from not started inspection",
+			method:   "GET",
+			path:     fmt.Sprintf("/api/v3/inspection/%s/parameters", notStartedInspectionID),
+			wantCode: 400,
+		},
+		{
+			name:     "import",
+			method:   "POST",
+			path:     fmt.Sprintf("/api/v3/inspection/%s/parameters/import", notStartedInspectionID),
+			body:     "inspectionType: foo\nvalues:\n    foo-input: foo-input-value\n",
+			wantCode: 200,
+			wantBody: `{"values":{"foo-input":"foo-input-value"}}`,
+		},
+		{
+			name:     "import parameters of another inspection type",
+			method:   "POST",
+			path:     fmt.Sprintf("/api/v3/inspection/%s/parameters/import", notStartedInspectionID),
+			body:     "inspectionType: bar\nvalues:\n    bar-input: bar-input-value\n",
+			wantCode: 400,
+		},
+		{
+			name:     "import malformed parameters",
+			method:   "POST",
+			path:     fmt.Sprintf("/api/v3/inspection/%s/parameters/import", notStartedInspectionID),
+			body:     "{",
+			wantCode: 400,
+		},
+		{
+			name:     "import to unknown inspection",
+			method:   "POST",
+			path:     "/api/v3/inspection/not-existing-inspection/parameters/import",
+			body:     "inspectionType: foo\n",
+			wantCode: 404,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			req, _ := http.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			engine.ServeHTTP(recorder, req)
+			if recorder.Code != tc.wantCode {
+				t.Errorf("got response code %d, want %d\n%s", recorder.Code, tc.wantCode, recorder.Body)
+			}
+			if tc.wantBody != "" {
+				if diff := cmp.Diff(tc.wantBody, recorder.Body.String()); diff != "" {
+					t.Errorf("unexpected response body (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestFollowEndpoints(t *testing.T) {
+	logger.InitGlobalKHILogger()
+	inspectionServer, err := createTestInspectionServer()
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	inspectionID, err := inspectionServer.CreateInspection("foo")
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	notStartedInspectionID, err := inspectionServer.CreateInspection("foo")
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	inspection := inspectionServer.GetInspection(inspectionID)
+	err = inspection.SetFeatureList([]string{"feature-foo2#default"})
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	err = inspection.Run(context.Background(), &inspectioncore_contract.InspectionRequest{
+		Values: map[string]any{"foo-input": "foo-input-value"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	<-inspection.Wait()
+	engine := gin.New()
+	engine = CreateKHIServer(engine, inspectionServer, &ServerConfig{
+		StaticFolderPath: "dist",
+		ResourceMonitor:  &ResourceMonitorMock{UsedMemory: 1000},
+	})
+
+	// The test cases are executed in order because following an inspection changes the server state.
+	testCases := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:     "events of not followed inspection",
+			method:   "GET",
+			path:     fmt.Sprintf("/api/v3/inspection/%s/follow/events", inspectionID),
+			wantCode: 404,
+		},
+		{
+			name:     "follow unknown inspection",
+			method:   "POST",
+			path:     "/api/v3/inspection/not-existing-inspection/follow",
+			body:     `{}`,
+			wantCode: 404,
+		},
+		{
+			name:     "follow not started inspection",
+			method:   "POST",
+			path:     fmt.Sprintf("/api/v3/inspection/%s/follow", notStartedInspectionID),
+			body:     `{}`,
+			wantCode: 400,
+		},
+		{
+			name:     "follow with too short interval",
+			method:   "POST",
+			path:     fmt.Sprintf("/api/v3/inspection/%s/follow", inspectionID),
+			body:     `{"intervalSeconds":1}`,
+			wantCode: 400,
+		},
+		{
+			name:     "follow",
+			method:   "POST",
+			path:     fmt.Sprintf("/api/v3/inspection/%s/follow", inspectionID),
+			body:     `{"intervalSeconds":3600}`,
+			wantCode: 202,
+			wantBody: fmt.Sprintf(`{"type":"updated","revision":0,"inspectionId":"%s"}`, inspectionID),
+		},
+		{
+			name:     "follow already followed inspection",
+			method:   "POST",
+			path:     fmt.Sprintf("/api/v3/inspection/%s/follow", inspectionID),
+			body:     `{}`,
+			wantCode: 400,
+		},
+		{
+			name:     "stop following",
+			method:   "DELETE",
+			path:     fmt.Sprintf("/api/v3/inspection/%s/follow", inspectionID),
+			wantCode: 200,
+		},
+		{
+			name:     "stop following not followed inspection",
+			method:   "DELETE",
+			path:     fmt.Sprintf("/api/v3/inspection/%s/follow", inspectionID),
+			wantCode: 404,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			req, _ := http.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			engine.ServeHTTP(recorder, req)
+			if recorder.Code != tc.wantCode {
+				t.Errorf("got response code %d, want %d\n%s", recorder.Code, tc.wantCode, recorder.Body)
+			}
+			if tc.wantBody != "" {
+				if diff := cmp.Diff(tc.wantBody, recorder.Body.String()); diff != "" {
+					t.Errorf("unexpected response body (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestPresetEndpoints(t *testing.T) {
+	logger.InitGlobalKHILogger()
+	inspectionServer, err := createTestInspectionServer()
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	inspectionID, err := inspectionServer.CreateInspection("foo")
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	engine := gin.New()
+	engine = CreateKHIServer(engine, inspectionServer, &ServerConfig{
+		StaticFolderPath: "dist",
+		ResourceMonitor:  &ResourceMonitorMock{UsedMemory: 1000},
+		PresetStore:      preset.NewPresetStore(t.TempDir()),
+	})
+	steps := []struct {
+		method           string
+		path             string
+		body             string
+		wantCode         int
+		wantBodyContains []string
+		wantBodyExcludes []string
+	}{
+		{
+			method:           "GET",
+			path:             "/api/v3/inspection/types/foo/presets",
+			wantCode:         200,
+			wantBodyContains: []string{`{"presets":[]}`},
+		},
+		{
+			method:           "PUT",
+			path:             fmt.Sprintf("/api/v3/inspection/%s/presets/prod", inspectionID),
+			body:             `{"values":{"foo-input":"foo-input-value","foo-secret":"secret-value"}}`,
+			wantCode:         200,
+			wantBodyContains: []string{`"name":"prod"`, `"inspectionType":"foo"`},
+			wantBodyExcludes: []string{"secret-value"},
+		},
+		{
+			method:   "PUT",
+			path:     fmt.Sprintf("/api/v3/inspection/%s/presets/prod", "not-existing-inspection"),
+			body:     `{"values":{"foo-input":"foo-input-value"}}`,
+			wantCode: 404,
+		},
+		{
+			method:           "GET",
+			path:             "/api/v3/inspection/types/foo/presets",
+			wantCode:         200,
+			wantBodyContains: []string{`"name":"prod"`, `"values":{"foo-input":"foo-input-value"}`},
+			wantBodyExcludes: []string{"foo-secret", "secret-value"},
+		},
+		{
+			method:           "POST",
+			path:             fmt.Sprintf("/api/v3/inspection/%s/presets/prod/apply", inspectionID),
+			wantCode:         200,
+			wantBodyContains: []string{`{"values":{"foo-input":"foo-input-value"}}`},
+			wantBodyExcludes: []string{"secret-value"},
+		},
+		{
+			method:   "POST",
+			path:     fmt.Sprintf("/api/v3/inspection/%s/presets/not-existing-preset/apply", inspectionID),
+			wantCode: 404,
+		},
+		{
+			method:   "DELETE",
+			path:     "/api/v3/inspection/types/foo/presets/prod",
+			wantCode: 200,
+		},
+		{
+			method:   "DELETE",
+			path:     "/api/v3/inspection/types/foo/presets/prod",
+			wantCode: 404,
+		},
+		{
+			method:           "GET",
+			path:             "/api/v3/inspection/types/foo/presets",
+			wantCode:         200,
+			wantBodyContains: []string{`{"presets":[]}`},
+		},
+	}
+	for i, step := range steps {
+		recorder := httptest.NewRecorder()
+		req, _ := http.NewRequest(step.method, step.path, strings.NewReader(step.body))
+		engine.ServeHTTP(recorder, req)
+		if recorder.Code != step.wantCode {
+			t.Errorf("step %d: %s %s got response code %d, want %d\n%s", i, step.method, step.path, recorder.Code, step.wantCode, recorder.Body)
+		}
+		for _, want := range step.wantBodyContains {
+			if !strings.Contains(recorder.Body.String(), want) {
+				t.Errorf("step %d: response body doesn't contain %q\n%s", i, want, recorder.Body)
+			}
+		}
+		for _, unwanted := range step.wantBodyExcludes {
+			if strings.Contains(recorder.Body.String(), unwanted) {
+				t.Errorf("step %d: response body contains %q\n%s", i, unwanted, recorder.Body)
+			}
+		}
+	}
+}
+
 func TestKHIServer_EndpointExistsWithConfigs(t *testing.T) {
 	testCases := []struct {
 		name           string
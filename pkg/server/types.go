@@ -14,7 +14,10 @@
 
 package server
 
-import coreinspection "github.com/kyasbal/khi/pkg/core/inspection"
+import (
+	coreinspection "github.com/kyasbal/khi/pkg/core/inspection"
+	"github.com/kyasbal/khi/pkg/server/preset"
+)
 
 type SerializedMetadata = map[string]any
 
@@ -52,8 +55,45 @@ type PatchInspectionFeatureRequest struct {
 type PutInspectionFeatureResponse struct {
 }
 
+// PostTaskCacheInvalidationRequest is the request body of the endpoint invalidating results of cached tasks.
+type PostTaskCacheInvalidationRequest struct {
+	// TaskReferences are the task reference IDs to invalidate cached results of all their implementations.
+	TaskReferences []string `json:"taskReferences"`
+	// ImplementationIDs are the task implementation IDs to invalidate their cached results.
+	ImplementationIDs []string `json:"implementationIDs"`
+}
+
 type GetInspectionFeatureResponse struct {
 	Features []coreinspection.FeatureListItem `json:"features"`
 }
 
 type PostInspectionDryRunRequest = map[string]any
+
+// PostInspectionFollowRequest is the request body of the endpoint starting to follow an inspection.
+type PostInspectionFollowRequest struct {
+	// IntervalSeconds is the interval of re-running the inspection in seconds. The default interval is used when this is 0.
+	IntervalSeconds int `json:"intervalSeconds"`
+}
+
+// PostImportParametersResponse is the type of the response for /api/v3/inspection/<inspection-id>/parameters/import
+type PostImportParametersResponse struct {
+	// Values is the map of form field IDs and their values to set on the form.
+	Values map[string]any `json:"values"`
+}
+
+// GetPresetsResponse is the type of the response for /api/v3/inspection/types/<type-id>/presets
+type GetPresetsResponse struct {
+	Presets []*preset.Preset `json:"presets"`
+}
+
+// PutPresetRequest is the request body of the endpoint saving the form parameter values of an inspection as a preset.
+type PutPresetRequest struct {
+	// Values is the map of form field IDs and their values.
+	Values map[string]any `json:"values"`
+}
+
+// PostApplyPresetResponse is the type of the response for /api/v3/inspection/<inspection-id>/presets/<preset-name>/apply
+type PostApplyPresetResponse struct {
+	// Values is the map of form field IDs and their values to set on the form.
+	Values map[string]any `json:"values"`
+}
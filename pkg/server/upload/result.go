@@ -47,3 +47,23 @@ func (r *UploadResult) GetReader() (io.ReadCloser, error) {
 	}
 	return r.StoreProvider.Read(r.Token)
 }
+
+// UploadResultList is the list of UploadResult for a form field accepting multiple files, in the order given by the user.
+type UploadResultList []UploadResult
+
+// GetReaders returns the readers of the uploaded files in the order of the list.
+// Callers must close all the returned readers. No reader is left open when this returns an error.
+func (l UploadResultList) GetReaders() ([]io.ReadCloser, error) {
+	readers := make([]io.ReadCloser, 0, len(l))
+	for i := range l {
+		reader, err := l[i].GetReader()
+		if err != nil {
+			for _, opened := range readers {
+				opened.Close()
+			}
+			return nil, fmt.Errorf("failed to read the file at index %d: %w", i, err)
+		}
+		readers = append(readers, reader)
+	}
+	return readers, nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upload
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestUploadResultListGetReaders(t *testing.T) {
+	provider := NewLocalUploadFileStoreProvider(t.TempDir())
+	contents := []string{"first", "second"}
+	results := UploadResultList{}
+	for i, content := range contents {
+		token := &DirectUploadToken{ID: []string{"file-a", "file-b"}[i]}
+		if err := provider.Write(token, strings.NewReader(content)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		results = append(results, UploadResult{Token: token, StoreProvider: provider, Status: UploadStatusCompleted})
+	}
+
+	readers, err := results.GetReaders()
+	if err != nil {
+		t.Fatalf("GetReaders() returned an unexpected error: %v", err)
+	}
+	for i, reader := range readers {
+		data, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(data) != contents[i] {
+			t.Errorf("reader at index %d returned %q, want %q", i, string(data), contents[i])
+		}
+	}
+
+	results = append(results, UploadResult{Token: &DirectUploadToken{ID: "file-c"}, StoreProvider: provider, Status: UploadStatusWaiting})
+	_, err = results.GetReaders()
+	if err == nil {
+		t.Fatal("GetReaders() returned no error with a file not uploaded")
+	}
+	if !strings.Contains(err.Error(), "index 2") {
+		t.Errorf("GetReaders() returned %v, want an error mentioning the index of the file", err)
+	}
+}
@@ -136,8 +136,12 @@ func (s *UploadFileStore) SetResultOnCompletedUpload(token UploadToken, uploadEr
 		}
 	}
 	if uploadError == nil {
+		// Read the verifier before starting the goroutine because the verifier map can be updated by GetUploadToken concurrently.
+		s.verifierLock.RLock()
+		verifier := s.verifiers[token.GetID()]
+		s.verifierLock.RUnlock()
 		go func() {
-			err := s.verifiers[token.GetID()].Verify(s.StoreProvider, token)
+			err := verifier.Verify(s.StoreProvider, token)
 			s.resultLock.Lock()
 			defer s.resultLock.Unlock()
 			current, ok := s.results[token.GetID()]
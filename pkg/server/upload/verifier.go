@@ -15,11 +15,18 @@
 package upload
 
 import (
+	"archive/tar"
+	"archive/zip"
 	"bufio"
 	"bytes"
+	"compress/gzip"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 // UploadFileVerifier verifies uploaded files (e.g., file type checks).
@@ -46,6 +53,9 @@ var _ UploadFileVerifier = &NopWaitUploadFileVerifier{}
 
 type JSONLineUploadFileVerifier struct {
 	MaxLineSizeInBytes int
+	// AcceptNonJSONLine accepts the lines not in JSON when it returns true.
+	// This lets the files mixing JSON lines with lines in other formats the reader supports pass the verification.
+	AcceptNonJSONLine func(line []byte) bool
 }
 
 // Verify implements UploadFileVerifier.
@@ -69,6 +79,9 @@ func (j *JSONLineUploadFileVerifier) Verify(storeProvider UploadFileStoreProvide
 		}
 
 		if !json.Valid(line) {
+			if j.AcceptNonJSONLine != nil && j.AcceptNonJSONLine(line) {
+				continue
+			}
 			// json.Valid only returns valid or not, gets the error message with deserializing it.
 			var data interface{}
 			if err := json.Unmarshal(line, &data); err != nil {
@@ -88,3 +101,169 @@ func (j *JSONLineUploadFileVerifier) Verify(storeProvider UploadFileStoreProvide
 }
 
 var _ UploadFileVerifier = &JSONLineUploadFileVerifier{}
+
+// YAMLUploadFileVerifier verifies the uploaded file is a stream of YAML documents.
+// JSON files are also accepted because JSON is a subset of YAML.
+type YAMLUploadFileVerifier struct{}
+
+// Verify implements UploadFileVerifier.
+func (y *YAMLUploadFileVerifier) Verify(storeProvider UploadFileStoreProvider, token UploadToken) error {
+	reader, err := storeProvider.Read(token)
+	if err != nil {
+		return fmt.Errorf("failed to read the uploded file")
+	}
+	defer reader.Close()
+
+	decoder := yaml.NewDecoder(reader)
+	for documentIndex := 1; ; documentIndex++ {
+		var document yaml.Node
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid YAML or JSON in the document %d: %w", documentIndex, err)
+		}
+	}
+}
+
+var _ UploadFileVerifier = &YAMLUploadFileVerifier{}
+
+// gzipMagic is the leading bytes of gzip compressed data.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// NewTarReader returns the tar.Reader reading the tar archive from the reader. The archive is decompressed when it's compressed with gzip.
+func NewTarReader(reader io.Reader) (*tar.Reader, error) {
+	buffered := bufio.NewReader(reader)
+	head, err := buffered.Peek(len(gzipMagic))
+	if err == nil && bytes.Equal(head, gzipMagic) {
+		gzipReader, err := gzip.NewReader(buffered)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress the archive: %w", err)
+		}
+		return tar.NewReader(gzipReader), nil
+	}
+	return tar.NewReader(buffered), nil
+}
+
+// TarArchiveUploadFileVerifier verifies the uploaded file is a tar archive optionally compressed with gzip.
+type TarArchiveUploadFileVerifier struct{}
+
+// Verify implements UploadFileVerifier.
+func (t *TarArchiveUploadFileVerifier) Verify(storeProvider UploadFileStoreProvider, token UploadToken) error {
+	reader, err := storeProvider.Read(token)
+	if err != nil {
+		return fmt.Errorf("failed to read the uploded file")
+	}
+	defer reader.Close()
+
+	fileCount := 0
+	err = walkTarArchive(reader, func(file *ArchiveFile) error {
+		fileCount++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if fileCount == 0 {
+		return fmt.Errorf("the archive contains no file")
+	}
+	return nil
+}
+
+var _ UploadFileVerifier = &TarArchiveUploadFileVerifier{}
+
+// zipMagic is the leading bytes of zip archives.
+var zipMagic = []byte("PK\x03\x04")
+
+// ArchiveFile is a regular file in an archive given to the function passed to WalkArchive.
+type ArchiveFile struct {
+	Name    string
+	ModTime time.Time
+	Content io.Reader
+}
+
+// WalkArchive calls the walker function for each regular file in the zip or tar archive read from the reader. Tar archives can be compressed with gzip.
+// Zip archives are read into memory at once because their central directory is placed at the end.
+func WalkArchive(reader io.Reader, walker func(file *ArchiveFile) error) error {
+	buffered := bufio.NewReader(reader)
+	head, err := buffered.Peek(len(zipMagic))
+	if err != nil || !bytes.Equal(head, zipMagic) {
+		return walkTarArchive(buffered, walker)
+	}
+	data, err := io.ReadAll(buffered)
+	if err != nil {
+		return err
+	}
+	zipReader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return fmt.Errorf("invalid zip archive: %w", err)
+	}
+	for _, file := range zipReader.File {
+		if !file.Mode().IsRegular() {
+			continue
+		}
+		content, err := file.Open()
+		if err != nil {
+			return fmt.Errorf("failed to read %s in the zip archive: %w", file.Name, err)
+		}
+		err = walker(&ArchiveFile{Name: file.Name, ModTime: file.Modified, Content: content})
+		content.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkTarArchive(reader io.Reader, walker func(file *ArchiveFile) error) error {
+	tarReader, err := NewTarReader(reader)
+	if err != nil {
+		return err
+	}
+	fileCount := 0
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid tar archive after %d files: %w", fileCount, err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		fileCount++
+		err = walker(&ArchiveFile{Name: header.Name, ModTime: header.ModTime, Content: tarReader})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// ArchiveUploadFileVerifier verifies the uploaded file is a zip archive or a tar archive optionally compressed with gzip.
+type ArchiveUploadFileVerifier struct{}
+
+// Verify implements UploadFileVerifier.
+func (a *ArchiveUploadFileVerifier) Verify(storeProvider UploadFileStoreProvider, token UploadToken) error {
+	reader, err := storeProvider.Read(token)
+	if err != nil {
+		return fmt.Errorf("failed to read the uploded file")
+	}
+	defer reader.Close()
+
+	fileCount := 0
+	err = WalkArchive(reader, func(file *ArchiveFile) error {
+		fileCount++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if fileCount == 0 {
+		return fmt.Errorf("the archive contains no file")
+	}
+	return nil
+}
+
+var _ UploadFileVerifier = &ArchiveUploadFileVerifier{}
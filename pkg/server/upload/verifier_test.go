@@ -15,6 +15,10 @@
 package upload
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
 	"strings"
 	"testing"
 )
@@ -23,6 +27,7 @@ func TestJSONLineUploadFileVerifier(t *testing.T) {
 	tests := []struct {
 		name        string
 		data        string
+		acceptLine  func(line []byte) bool
 		expectedErr string
 	}{
 		{
@@ -56,11 +61,85 @@ func TestJSONLineUploadFileVerifier(t *testing.T) {
 {"name": "Hank", "age": 60}
    `, expectedErr: "",
 		},
+		{
+			name: "Non JSON lines accepted by AcceptNonJSONLine",
+			data: `{"name": "Ivy", "age": 65}
+<30>Jan  2 15:04:05 node-1 kubelet[123]: started`,
+			acceptLine:  func(line []byte) bool { return bytes.HasPrefix(line, []byte("<")) },
+			expectedErr: "",
+		},
+		{
+			name: "Non JSON lines rejected by AcceptNonJSONLine",
+			data: `{"name": "Jack", "age": 70}
+plain text`,
+			acceptLine:  func(line []byte) bool { return bytes.HasPrefix(line, []byte("<")) },
+			expectedErr: "invalid JSON on line 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := &JSONLineUploadFileVerifier{MaxLineSizeInBytes: 1024 * 1024, AcceptNonJSONLine: tt.acceptLine}
+			provider := &MockLocalUploadFileStoreProvider{Data: tt.data}
+			err := verifier.Verify(provider, &DirectUploadToken{ID: "test"})
+
+			if tt.expectedErr == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+			} else {
+				if err == nil {
+					t.Errorf("Expected error, but got nil")
+				} else if !strings.Contains(err.Error(), tt.expectedErr) {
+					t.Errorf("Expected error to contain: %q, but got: %v", tt.expectedErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestYAMLUploadFileVerifier(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		expectedErr string
+	}{
+		{
+			name: "Valid YAML",
+			data: `apiVersion: v1
+kind: List
+items: []`,
+			expectedErr: "",
+		},
+		{
+			name: "Multiple YAML documents",
+			data: `kind: Pod
+---
+kind: Service`,
+			expectedErr: "",
+		},
+		{
+			name:        "Valid JSON",
+			data:        `{"apiVersion": "v1", "kind": "List", "items": []}`,
+			expectedErr: "",
+		},
+		{
+			name:        "Empty File",
+			data:        "",
+			expectedErr: "",
+		},
+		{
+			name: "Invalid YAML",
+			data: `kind: Pod
+---
+kind: [Service`,
+			expectedErr: "invalid YAML or JSON in the document 2",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			verifier := &JSONLineUploadFileVerifier{MaxLineSizeInBytes: 1024 * 1024}
+			verifier := &YAMLUploadFileVerifier{}
 			provider := &MockLocalUploadFileStoreProvider{Data: tt.data}
 			err := verifier.Verify(provider, &DirectUploadToken{ID: "test"})
 
@@ -78,3 +157,145 @@ func TestJSONLineUploadFileVerifier(t *testing.T) {
 		})
 	}
 }
+
+func TestTarArchiveUploadFileVerifier(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        func(t *testing.T) string
+		expectedErr string
+	}{
+		{
+			name: "tar archive",
+			data: func(t *testing.T) string {
+				return string(newTestTarArchive(t, map[string]string{"must-gather/version": "1"}))
+			},
+		},
+		{
+			name: "gzip compressed tar archive",
+			data: func(t *testing.T) string {
+				var compressed bytes.Buffer
+				writer := gzip.NewWriter(&compressed)
+				writer.Write(newTestTarArchive(t, map[string]string{"must-gather/version": "1"}))
+				writer.Close()
+				return compressed.String()
+			},
+		},
+		{
+			name: "archive without files",
+			data: func(t *testing.T) string {
+				return string(newTestTarArchive(t, map[string]string{}))
+			},
+			expectedErr: "the archive contains no file",
+		},
+		{
+			name: "not an archive",
+			data: func(t *testing.T) string {
+				return strings.Repeat("not a tar archive\n", 100)
+			},
+			expectedErr: "invalid tar archive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := &TarArchiveUploadFileVerifier{}
+			provider := &MockLocalUploadFileStoreProvider{Data: tt.data(t)}
+			err := verifier.Verify(provider, &DirectUploadToken{ID: "test"})
+
+			if tt.expectedErr == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+			} else {
+				if err == nil {
+					t.Errorf("Expected error, but got nil")
+				} else if !strings.Contains(err.Error(), tt.expectedErr) {
+					t.Errorf("Expected error to contain: %q, but got: %v", tt.expectedErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestArchiveUploadFileVerifier(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        func(t *testing.T) string
+		expectedErr string
+	}{
+		{
+			name: "zip archive",
+			data: func(t *testing.T) string {
+				var archive bytes.Buffer
+				writer := zip.NewWriter(&archive)
+				file, err := writer.Create("kind/kind-version.txt")
+				if err != nil {
+					t.Fatalf("failed to create a file in the zip archive: %v", err)
+				}
+				file.Write([]byte("kind v0.27.0"))
+				writer.Close()
+				return archive.String()
+			},
+		},
+		{
+			name: "tar archive",
+			data: func(t *testing.T) string {
+				return string(newTestTarArchive(t, map[string]string{"kind/kind-version.txt": "kind v0.27.0"}))
+			},
+		},
+		{
+			name: "broken zip archive",
+			data: func(t *testing.T) string {
+				return "PK\x03\x04" + strings.Repeat("broken", 10)
+			},
+			expectedErr: "invalid zip archive",
+		},
+		{
+			name: "not an archive",
+			data: func(t *testing.T) string {
+				return strings.Repeat("not an archive\n", 100)
+			},
+			expectedErr: "invalid tar archive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := &ArchiveUploadFileVerifier{}
+			provider := &MockLocalUploadFileStoreProvider{Data: tt.data(t)}
+			err := verifier.Verify(provider, &DirectUploadToken{ID: "test"})
+
+			if tt.expectedErr == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+			} else {
+				if err == nil {
+					t.Errorf("Expected error, but got nil")
+				} else if !strings.Contains(err.Error(), tt.expectedErr) {
+					t.Errorf("Expected error to contain: %q, but got: %v", tt.expectedErr, err)
+				}
+			}
+		})
+	}
+}
+
+func newTestTarArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var archive bytes.Buffer
+	writer := tar.NewWriter(&archive)
+	for name, content := range files {
+		err := writer.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(content)), Typeflag: tar.TypeReg})
+		if err != nil {
+			t.Fatalf("failed to write a tar header: %v", err)
+		}
+		_, err = writer.Write([]byte(content))
+		if err != nil {
+			t.Fatalf("failed to write a file in the archive: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to close the tar writer: %v", err)
+	}
+	return archive.Bytes()
+}
@@ -24,3 +24,20 @@ var TaskResultMapContextKey = typedmap.NewTypedKey[*typedmap.TypedMap]("khi.goog
 
 // TaskImplementationIDContextKey is the key to get the current task implementation ID.
 var TaskImplementationIDContextKey = typedmap.NewTypedKey[taskid.UntypedTaskImplementationID]("khi.google.com/task-implementation-id")
+
+// SkippedTaskSetContextKey is the key to get the set of task references skipped by their skip predicates in the current task graph.
+var SkippedTaskSetContextKey = typedmap.NewTypedKey[*typedmap.TypedMap]("khi.google.com/skipped-task-set")
+
+// TaskCacheHitRecorderContextKey is the key to get the function recording whether the current task reused its cached result.
+var TaskCacheHitRecorderContextKey = typedmap.NewTypedKey[func(hit bool)]("khi.google.com/task-cache-hit-recorder")
+
+// TaskAttemptContextKey is the key to get the current attempt of the task retried with its retry policy.
+var TaskAttemptContextKey = typedmap.NewTypedKey[*TaskAttempt]("khi.google.com/task-attempt")
+
+// TaskAttempt holds the information about the current attempt of a task retried with its retry policy.
+type TaskAttempt struct {
+	// IsFinal returns true when the error returned from the current attempt won't be retried.
+	IsFinal func(err error) bool
+	// State is the map shared among the attempts of a task run. Tasks store their progress in it to resume the work in the next attempt.
+	State *typedmap.TypedMap
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsclustereks_contract
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/api/aws"
+	"github.com/kyasbal/khi/pkg/common/structured"
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+// cloudWatchLogEventField is the field added on each log to hold the metadata of the CloudWatch log event.
+const cloudWatchLogEventField = "cloudwatch"
+
+// ControlPlaneLogGroupName returns the name of the log group receiving the control plane logs of the EKS cluster.
+func ControlPlaneLogGroupName(clusterName string) string {
+	return fmt.Sprintf("/aws/eks/%s/cluster", clusterName)
+}
+
+// DataplaneLogGroupName returns the name of the log group receiving the node logs forwarded by Fluent Bit with Container Insights.
+func DataplaneLogGroupName(clusterName string) string {
+	return fmt.Sprintf("/aws/containerinsights/%s/dataplane", clusterName)
+}
+
+// NewLogFromCloudWatchEvent converts a CloudWatch log event to a log.
+// A message given in a JSON object (e.g. audit logs or Fluent Bit records) is used as the log body. Other messages are stored in the `message` field.
+// The metadata of the event is stored in the `cloudwatch` field.
+func NewLogFromCloudWatchEvent(logGroupName string, event *aws.FilteredLogEvent) (*log.Log, error) {
+	body := map[string]any{}
+	if err := json.Unmarshal([]byte(event.Message), &body); err != nil {
+		body = map[string]any{
+			"message": event.Message,
+		}
+	}
+	body[cloudWatchLogEventField] = map[string]any{
+		"eventId":       event.EventID,
+		"logGroupName":  logGroupName,
+		"logStreamName": event.LogStreamName,
+		"timestamp":     time.UnixMilli(event.Timestamp).UTC().Format(time.RFC3339Nano),
+	}
+	serialized, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize the log event %s: %w", event.EventID, err)
+	}
+	return log.NewLogFromYAMLString(string(serialized))
+}
+
+// FilterAllLogEvents calls FilterLogEvents API until all the pages are read.
+// onPage is called with the count of events read so far after reading each page.
+func FilterAllLogEvents(ctx context.Context, api aws.CloudWatchLogsAPI, input *aws.FilterLogEventsInput, onPage func(readCount int)) ([]*aws.FilteredLogEvent, error) {
+	pageInput := *input
+	var result []*aws.FilteredLogEvent
+	for {
+		output, err := api.FilterLogEvents(ctx, &pageInput)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, output.Events...)
+		if onPage != nil {
+			onPage(len(result))
+		}
+		if output.NextToken == "" || output.NextToken == pageInput.NextToken {
+			return result, nil
+		}
+		pageInput.NextToken = output.NextToken
+	}
+}
+
+// DescribeAllLogStreams calls DescribeLogStreams API until all the pages are read.
+func DescribeAllLogStreams(ctx context.Context, api aws.CloudWatchLogsAPI, input *aws.DescribeLogStreamsInput) ([]*aws.LogStream, error) {
+	pageInput := *input
+	var result []*aws.LogStream
+	for {
+		output, err := api.DescribeLogStreams(ctx, &pageInput)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, output.LogStreams...)
+		if output.NextToken == "" || output.NextToken == pageInput.NextToken {
+			return result, nil
+		}
+		pageInput.NextToken = output.NextToken
+	}
+}
+
+// CloudWatchLogEventCommonFieldSetReader implements log.FieldSetReader for log.CommonFieldSet{} from the metadata of the CloudWatch log event.
+type CloudWatchLogEventCommonFieldSetReader struct{}
+
+// FieldSetKind implements log.FieldSetReader.
+func (c *CloudWatchLogEventCommonFieldSetReader) FieldSetKind() string {
+	return (&log.CommonFieldSet{}).Kind()
+}
+
+// Read implements log.FieldSetReader.
+func (c *CloudWatchLogEventCommonFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	result := &log.CommonFieldSet{}
+	result.DisplayID = reader.ReadStringOrDefault(cloudWatchLogEventField+".eventId", "unknown")
+	timestamp, err := reader.ReadTimestamp(cloudWatchLogEventField + ".timestamp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the timestamp of the CloudWatch log event: %w", err)
+	}
+	result.Timestamp = timestamp
+	return result, nil
+}
+
+var _ log.FieldSetReader = (*CloudWatchLogEventCommonFieldSetReader)(nil)
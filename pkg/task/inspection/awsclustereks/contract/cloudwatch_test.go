@@ -0,0 +1,170 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsclustereks_contract
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/api/aws"
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+type fakeCloudWatchLogsAPI struct {
+	filterLogEventsPages    map[string]*aws.FilterLogEventsOutput
+	describeLogStreamsPages map[string]*aws.DescribeLogStreamsOutput
+	err                     error
+}
+
+// FilterLogEvents implements aws.CloudWatchLogsAPI.
+func (f *fakeCloudWatchLogsAPI) FilterLogEvents(ctx context.Context, input *aws.FilterLogEventsInput) (*aws.FilterLogEventsOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.filterLogEventsPages[input.NextToken], nil
+}
+
+// DescribeLogStreams implements aws.CloudWatchLogsAPI.
+func (f *fakeCloudWatchLogsAPI) DescribeLogStreams(ctx context.Context, input *aws.DescribeLogStreamsInput) (*aws.DescribeLogStreamsOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.describeLogStreamsPages[input.NextToken], nil
+}
+
+var _ aws.CloudWatchLogsAPI = (*fakeCloudWatchLogsAPI)(nil)
+
+func TestNewLogFromCloudWatchEvent(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		message       string
+		wantFields    map[string]string
+		wantTimestamp time.Time
+	}{
+		{
+			desc:    "json message",
+			message: `{"kind":"Event","stage":"ResponseComplete"}`,
+			wantFields: map[string]string{
+				"kind":                     "Event",
+				"stage":                    "ResponseComplete",
+				"cloudwatch.eventId":       "event-1",
+				"cloudwatch.logGroupName":  "/aws/eks/my-cluster/cluster",
+				"cloudwatch.logStreamName": "kube-apiserver-audit-abc",
+			},
+			wantTimestamp: time.Date(2025, 1, 1, 0, 0, 0, 123000000, time.UTC),
+		},
+		{
+			desc:    "text message",
+			message: `I0101 00:00:00.123456      10 leaderelection.go:268] successfully acquired lease`,
+			wantFields: map[string]string{
+				"message":            `I0101 00:00:00.123456      10 leaderelection.go:268] successfully acquired lease`,
+				"cloudwatch.eventId": "event-1",
+			},
+			wantTimestamp: time.Date(2025, 1, 1, 0, 0, 0, 123000000, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l, err := NewLogFromCloudWatchEvent("/aws/eks/my-cluster/cluster", &aws.FilteredLogEvent{
+				EventID:       "event-1",
+				LogStreamName: "kube-apiserver-audit-abc",
+				Message:       tc.message,
+				Timestamp:     time.Date(2025, 1, 1, 0, 0, 0, 123000000, time.UTC).UnixMilli(),
+			})
+			if err != nil {
+				t.Fatalf("NewLogFromCloudWatchEvent() returned an unexpected error: %v", err)
+			}
+			for fieldPath, want := range tc.wantFields {
+				if got := l.ReadStringOrDefault(fieldPath, ""); got != want {
+					t.Errorf("field %s = %q, want %q", fieldPath, got, want)
+				}
+			}
+
+			err = l.SetFieldSetReader(&CloudWatchLogEventCommonFieldSetReader{})
+			if err != nil {
+				t.Fatalf("SetFieldSetReader() returned an unexpected error: %v", err)
+			}
+			commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+			if !commonFieldSet.Timestamp.Equal(tc.wantTimestamp) {
+				t.Errorf("Timestamp = %v, want %v", commonFieldSet.Timestamp, tc.wantTimestamp)
+			}
+			if commonFieldSet.DisplayID != "event-1" {
+				t.Errorf("DisplayID = %q, want %q", commonFieldSet.DisplayID, "event-1")
+			}
+		})
+	}
+}
+
+func TestFilterAllLogEvents(t *testing.T) {
+	api := &fakeCloudWatchLogsAPI{
+		filterLogEventsPages: map[string]*aws.FilterLogEventsOutput{
+			"": {
+				Events:    []*aws.FilteredLogEvent{{EventID: "event-1"}, {EventID: "event-2"}},
+				NextToken: "page-2",
+			},
+			"page-2": {
+				Events: []*aws.FilteredLogEvent{{EventID: "event-3"}},
+			},
+		},
+	}
+	var readCounts []int
+	got, err := FilterAllLogEvents(context.Background(), api, &aws.FilterLogEventsInput{LogGroupName: "group"}, func(readCount int) {
+		readCounts = append(readCounts, readCount)
+	})
+	if err != nil {
+		t.Fatalf("FilterAllLogEvents() returned an unexpected error: %v", err)
+	}
+	want := []*aws.FilteredLogEvent{{EventID: "event-1"}, {EventID: "event-2"}, {EventID: "event-3"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("FilterAllLogEvents() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]int{2, 3}, readCounts); diff != "" {
+		t.Errorf("read counts mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFilterAllLogEventsWithError(t *testing.T) {
+	wantErr := errors.New("access denied")
+	_, err := FilterAllLogEvents(context.Background(), &fakeCloudWatchLogsAPI{err: wantErr}, &aws.FilterLogEventsInput{LogGroupName: "group"}, nil)
+	if !errors.Is(err, wantErr) {
+		t.Errorf("FilterAllLogEvents() error = %v, want %v", err, wantErr)
+	}
+}
+
+func TestDescribeAllLogStreams(t *testing.T) {
+	api := &fakeCloudWatchLogsAPI{
+		describeLogStreamsPages: map[string]*aws.DescribeLogStreamsOutput{
+			"": {
+				LogStreams: []*aws.LogStream{{LogStreamName: "kube-scheduler-abc"}},
+				NextToken:  "page-2",
+			},
+			"page-2": {
+				LogStreams: []*aws.LogStream{{LogStreamName: "kube-apiserver-abc"}},
+			},
+		},
+	}
+	got, err := DescribeAllLogStreams(context.Background(), api, &aws.DescribeLogStreamsInput{LogGroupName: "group"})
+	if err != nil {
+		t.Fatalf("DescribeAllLogStreams() returned an unexpected error: %v", err)
+	}
+	want := []*aws.LogStream{{LogStreamName: "kube-scheduler-abc"}, {LogStreamName: "kube-apiserver-abc"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("DescribeAllLogStreams() mismatch (-want +got):\n%s", diff)
+	}
+}
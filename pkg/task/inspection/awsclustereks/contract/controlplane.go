@@ -0,0 +1,107 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsclustereks_contract
+
+import (
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/common/structured"
+	"github.com/kyasbal/khi/pkg/core/inspection/logutil"
+	"github.com/kyasbal/khi/pkg/model/history/resourcepath"
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+// AuditLogStreamNamePrefix is the prefix of the log streams of kube-apiserver audit logs in the control plane log group.
+const AuditLogStreamNamePrefix = "kube-apiserver-audit-"
+
+// controlPlaneComponentLogStreamNamePrefixes maps the prefixes of the log streams in the control plane log group to the component names.
+// Each log stream is named with the component name followed by a hash (e.g. `kube-scheduler-0123abcd`).
+var controlPlaneComponentLogStreamNamePrefixes = []struct {
+	prefix    string
+	component string
+}{
+	{prefix: "kube-apiserver-", component: "kube-apiserver"},
+	{prefix: "authenticator-", component: "authenticator"},
+	{prefix: "kube-controller-manager-", component: "kube-controller-manager"},
+	{prefix: "cloud-controller-manager-", component: "cloud-controller-manager"},
+	{prefix: "kube-scheduler-", component: "kube-scheduler"},
+}
+
+// ControlPlaneComponentFromLogStreamName returns the control plane component name writing the log stream.
+// It returns an empty string for audit log streams and unknown log streams.
+func ControlPlaneComponentFromLogStreamName(logStreamName string) string {
+	if strings.HasPrefix(logStreamName, AuditLogStreamNamePrefix) {
+		return ""
+	}
+	for _, p := range controlPlaneComponentLogStreamNamePrefixes {
+		if strings.HasPrefix(logStreamName, p.prefix) {
+			return p.component
+		}
+	}
+	return ""
+}
+
+// clusterNameFromControlPlaneLogGroupName reads the cluster name from the log group name like `/aws/eks/<cluster name>/cluster`.
+func clusterNameFromControlPlaneLogGroupName(logGroupName string) string {
+	name := strings.TrimPrefix(logGroupName, "/aws/eks/")
+	name = strings.TrimSuffix(name, "/cluster")
+	if name == logGroupName || name == "" {
+		return "unknown"
+	}
+	return name
+}
+
+// EKSControlPlaneComponentFieldSet is the fieldset of control plane component logs other than audit logs.
+type EKSControlPlaneComponentFieldSet struct {
+	ClusterName   string
+	ComponentName string
+	Message       *logutil.ParseStructuredLogResult
+}
+
+// Kind implements log.FieldSet.
+func (e *EKSControlPlaneComponentFieldSet) Kind() string {
+	return "eks_controlplane_component"
+}
+
+// ResourcePath returns the path of the timeline for the component.
+func (e *EKSControlPlaneComponentFieldSet) ResourcePath() resourcepath.ResourcePath {
+	return resourcepath.ControlplaneComponent(e.ClusterName, e.ComponentName)
+}
+
+var _ log.FieldSet = (*EKSControlPlaneComponentFieldSet)(nil)
+
+// EKSControlPlaneComponentFieldSetReader implements log.FieldSetReader for EKSControlPlaneComponentFieldSet.
+type EKSControlPlaneComponentFieldSetReader struct {
+	StructuredLogParser logutil.StructuredLogParser
+}
+
+// FieldSetKind implements log.FieldSetReader.
+func (e *EKSControlPlaneComponentFieldSetReader) FieldSetKind() string {
+	return (&EKSControlPlaneComponentFieldSet{}).Kind()
+}
+
+// Read implements log.FieldSetReader.
+func (e *EKSControlPlaneComponentFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	var result EKSControlPlaneComponentFieldSet
+	result.ClusterName = clusterNameFromControlPlaneLogGroupName(reader.ReadStringOrDefault(cloudWatchLogEventField+".logGroupName", ""))
+	result.ComponentName = ControlPlaneComponentFromLogStreamName(reader.ReadStringOrDefault(cloudWatchLogEventField+".logStreamName", ""))
+	if result.ComponentName == "" {
+		result.ComponentName = "unknown"
+	}
+	result.Message = e.StructuredLogParser.TryParse(reader.ReadStringOrDefault("message", ""))
+	return &result, nil
+}
+
+var _ log.FieldSetReader = (*EKSControlPlaneComponentFieldSetReader)(nil)
@@ -0,0 +1,74 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsclustereks_contract
+
+import (
+	"testing"
+
+	"github.com/kyasbal/khi/pkg/api/aws"
+	"github.com/kyasbal/khi/pkg/core/inspection/logutil"
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+func TestControlPlaneComponentFromLogStreamName(t *testing.T) {
+	testCases := []struct {
+		logStreamName string
+		want          string
+	}{
+		{logStreamName: "kube-apiserver-0123abcd", want: "kube-apiserver"},
+		{logStreamName: "kube-apiserver-audit-0123abcd", want: ""},
+		{logStreamName: "authenticator-0123abcd", want: "authenticator"},
+		{logStreamName: "kube-controller-manager-0123abcd", want: "kube-controller-manager"},
+		{logStreamName: "cloud-controller-manager-0123abcd", want: "cloud-controller-manager"},
+		{logStreamName: "kube-scheduler-0123abcd", want: "kube-scheduler"},
+		{logStreamName: "unknown-stream", want: ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.logStreamName, func(t *testing.T) {
+			if got := ControlPlaneComponentFromLogStreamName(tc.logStreamName); got != tc.want {
+				t.Errorf("ControlPlaneComponentFromLogStreamName(%q) = %q, want %q", tc.logStreamName, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEKSControlPlaneComponentFieldSetReader(t *testing.T) {
+	l, err := NewLogFromCloudWatchEvent(ControlPlaneLogGroupName("my-cluster"), &aws.FilteredLogEvent{
+		EventID:       "event-1",
+		LogStreamName: "kube-scheduler-0123abcd",
+		Message:       `E0101 00:00:00.000000      10 schedule_one.go:100] "Error scheduling pod" pod="default/nginx"`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = l.SetFieldSetReader(&EKSControlPlaneComponentFieldSetReader{StructuredLogParser: logutil.NewKLogTextParser(true)})
+	if err != nil {
+		t.Fatalf("SetFieldSetReader() returned an unexpected error: %v", err)
+	}
+
+	got := log.MustGetFieldSet(l, &EKSControlPlaneComponentFieldSet{})
+	if got.ClusterName != "my-cluster" {
+		t.Errorf("ClusterName = %q, want %q", got.ClusterName, "my-cluster")
+	}
+	if got.ComponentName != "kube-scheduler" {
+		t.Errorf("ComponentName = %q, want %q", got.ComponentName, "kube-scheduler")
+	}
+	if got.ResourcePath().Path != "@Cluster#controlplane#cluster-scope#my-cluster#kube-scheduler" {
+		t.Errorf("ResourcePath() = %q", got.ResourcePath().Path)
+	}
+	if msg, _ := got.Message.MainMessage(); msg != "Error scheduling pod" {
+		t.Errorf("Message.MainMessage() = %q, want %q", msg, "Error scheduling pod")
+	}
+}
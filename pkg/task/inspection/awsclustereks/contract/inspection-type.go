@@ -0,0 +1,31 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsclustereks_contract
+
+import (
+	"math"
+
+	coreinspection "github.com/kyasbal/khi/pkg/core/inspection"
+)
+
+const InspectionTypeID = "aws-eks-cloudwatch-logs"
+
+var EKSCloudWatchLogsInspectionType = coreinspection.InspectionType{
+	Id:          InspectionTypeID,
+	Name:        "Amazon EKS (CloudWatch Logs)",
+	Description: "Visualize logs of Amazon EKS clusters gathered from Amazon CloudWatch Logs",
+	Icon:        "assets/icons/k8s.png",
+	Priority:    math.MaxInt - 2000,
+}
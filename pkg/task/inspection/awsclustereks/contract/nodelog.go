@@ -0,0 +1,60 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsclustereks_contract
+
+import (
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/common/structured"
+	"github.com/kyasbal/khi/pkg/core/inspection/logutil"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudlogk8snode_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8snode/contract"
+)
+
+// EKSFluentBitNodeLogFieldSetReader implements log.FieldSetReader for googlecloudlogk8snode_contract.K8sNodeLogCommonFieldSet{} from the systemd logs forwarded by Fluent Bit.
+// The Fluent Bit configuration of Container Insights renames `MESSAGE`, `_SYSTEMD_UNIT` and `_HOSTNAME` to `message`, `systemd_unit` and `hostname`.
+// The original journald field names are read when the record was forwarded without the renaming.
+type EKSFluentBitNodeLogFieldSetReader struct {
+	StructuredLogParser logutil.StructuredLogParser
+}
+
+// FieldSetKind implements log.FieldSetReader.
+func (e *EKSFluentBitNodeLogFieldSetReader) FieldSetKind() string {
+	return (&googlecloudlogk8snode_contract.K8sNodeLogCommonFieldSet{}).Kind()
+}
+
+// Read implements log.FieldSetReader.
+func (e *EKSFluentBitNodeLogFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	var result googlecloudlogk8snode_contract.K8sNodeLogCommonFieldSet
+	result.Message = e.StructuredLogParser.TryParse(readFirstStringOrDefault(reader, "", "message", "MESSAGE", "log"))
+	result.Component = readFirstStringOrDefault(reader, "", "SYSLOG_IDENTIFIER")
+	if result.Component == "" {
+		result.Component = strings.TrimSuffix(readFirstStringOrDefault(reader, "", "systemd_unit", "_SYSTEMD_UNIT"), ".service")
+	}
+	result.NodeName = readFirstStringOrDefault(reader, "", "hostname", "_HOSTNAME")
+	return &result, nil
+}
+
+var _ log.FieldSetReader = (*EKSFluentBitNodeLogFieldSetReader)(nil)
+
+// readFirstStringOrDefault returns the first non empty string field found in the given field paths.
+func readFirstStringOrDefault(reader *structured.NodeReader, defaultValue string, fieldPaths ...string) string {
+	for _, fieldPath := range fieldPaths {
+		if value := reader.ReadStringOrDefault(fieldPath, ""); value != "" {
+			return value
+		}
+	}
+	return defaultValue
+}
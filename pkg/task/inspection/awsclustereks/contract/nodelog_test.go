@@ -0,0 +1,76 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsclustereks_contract
+
+import (
+	"testing"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/logutil"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudlogk8snode_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8snode/contract"
+)
+
+func TestEKSFluentBitNodeLogFieldSetReader(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		input         string
+		wantComponent string
+		wantNodeName  string
+		wantMessage   string
+	}{
+		{
+			desc:          "record renamed by the Container Insights configuration",
+			input:         `{"hostname":"ip-10-0-0-1.ec2.internal","systemd_unit":"kubelet.service","message":"I0101 00:00:00.000000    1234 kubelet.go:100] \"Started kubelet\""}`,
+			wantComponent: "kubelet",
+			wantNodeName:  "ip-10-0-0-1.ec2.internal",
+			wantMessage:   "Started kubelet",
+		},
+		{
+			desc:          "record with journald field names",
+			input:         `{"_HOSTNAME":"ip-10-0-0-1.ec2.internal","_SYSTEMD_UNIT":"containerd.service","SYSLOG_IDENTIFIER":"containerd","MESSAGE":"time=\"2025-01-01T00:00:00Z\" level=info msg=\"starting containerd\""}`,
+			wantComponent: "containerd",
+			wantNodeName:  "ip-10-0-0-1.ec2.internal",
+			wantMessage:   "starting containerd",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l, err := log.NewLogFromYAMLString(tc.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = l.SetFieldSetReader(&EKSFluentBitNodeLogFieldSetReader{
+				StructuredLogParser: logutil.NewMultiTextLogParser(
+					logutil.NewKLogTextParser(true),
+					logutil.NewLogfmtTextParser(),
+					&logutil.FallbackRawTextLogParser{},
+				),
+			})
+			if err != nil {
+				t.Fatalf("SetFieldSetReader() returned an unexpected error: %v", err)
+			}
+			got := log.MustGetFieldSet(l, &googlecloudlogk8snode_contract.K8sNodeLogCommonFieldSet{})
+			if got.Component != tc.wantComponent {
+				t.Errorf("Component = %q, want %q", got.Component, tc.wantComponent)
+			}
+			if got.NodeName != tc.wantNodeName {
+				t.Errorf("NodeName = %q, want %q", got.NodeName, tc.wantNodeName)
+			}
+			if msg, _ := got.Message.MainMessage(); msg != tc.wantMessage {
+				t.Errorf("Message.MainMessage() = %q, want %q", msg, tc.wantMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsclustereks_contract
+
+import (
+	"github.com/kyasbal/khi/pkg/api/aws"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/kyasbal/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	googlecloudlogk8snode_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8snode/contract"
+	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// EKSTaskPrefix is the prefixes of IDs used in Amazon EKS related tasks.
+const EKSTaskPrefix = "khi.google.com/aws-eks/"
+
+// InputRegionTaskID is the task ID for the form to input the AWS region of the cluster.
+var InputRegionTaskID = taskid.NewDefaultImplementationID[string](EKSTaskPrefix + "form/region")
+
+// InputClusterNameTaskID is the task ID for the form to input the name of the EKS cluster.
+var InputClusterNameTaskID = taskid.NewDefaultImplementationID[string](EKSTaskPrefix + "form/cluster-name")
+
+// InputAccessKeyIDTaskID is the task ID for the form to input the AWS access key ID.
+var InputAccessKeyIDTaskID = taskid.NewDefaultImplementationID[string](EKSTaskPrefix + "form/access-key-id")
+
+// InputSecretAccessKeyTaskID is the task ID for the secret form to input the AWS secret access key.
+var InputSecretAccessKeyTaskID = taskid.NewDefaultImplementationID[string](EKSTaskPrefix + "form/secret-access-key")
+
+// InputSessionTokenTaskID is the task ID for the secret form to input the AWS session token of temporary credentials.
+var InputSessionTokenTaskID = taskid.NewDefaultImplementationID[string](EKSTaskPrefix + "form/session-token")
+
+// CredentialsTaskID is the task ID to resolve the AWS credentials from the forms or the environment variables.
+var CredentialsTaskID = taskid.NewDefaultImplementationID[aws.Credentials](EKSTaskPrefix + "credentials")
+
+// CloudWatchLogsClientTaskID is the task ID to provide the client of CloudWatch Logs API.
+var CloudWatchLogsClientTaskID = taskid.NewDefaultImplementationID[aws.CloudWatchLogsAPI](EKSTaskPrefix + "cloudwatch-logs-client")
+
+// AuditLogQueryTaskID is the task ID to query kube-apiserver audit logs from CloudWatch Logs in place of reading uploaded audit log files.
+var AuditLogQueryTaskID = taskid.NewImplementationID(ossclusterk8s_contract.AuditLogFileReaderTaskID.Ref(), "eks")
+
+// EKSK8sAuditLogProviderTaskID is the task ID to provide the audit logs to the common k8s audit log parsers.
+var EKSK8sAuditLogProviderTaskID = taskid.NewImplementationID(commonlogk8sauditv2_contract.K8sAuditLogProviderRef, "eks")
+
+// EKSK8sAuditLogParserTailTaskID is the task ID of the feature task to parse audit logs.
+var EKSK8sAuditLogParserTailTaskID = taskid.NewImplementationID(commonlogk8sauditv2_contract.K8sAuditLogParserTailRef, "eks")
+
+// EKSK8sAuditPermissionDeniedParserTailTaskID is the task ID of the feature task to parse audit logs of denied requests.
+var EKSK8sAuditPermissionDeniedParserTailTaskID = taskid.NewImplementationID(commonlogk8sauditv2_contract.K8sAuditPermissionDeniedParserTailRef, "eks")
+
+// ControlPlaneComponentLogQueryTaskID is the task ID to query the control plane component logs other than audit logs.
+var ControlPlaneComponentLogQueryTaskID = taskid.NewDefaultImplementationID[[]*log.Log](EKSTaskPrefix + "control-plane-component-log-query")
+
+// ControlPlaneComponentLogFieldSetReaderTaskID is the task ID to read the fieldset of control plane component logs.
+var ControlPlaneComponentLogFieldSetReaderTaskID = taskid.NewDefaultImplementationID[[]*log.Log](EKSTaskPrefix + "control-plane-component-log-fieldset-reader")
+
+// ControlPlaneComponentLogIngesterTaskID is the task ID to finalize the control plane component logs to be included in the final output.
+var ControlPlaneComponentLogIngesterTaskID = taskid.NewDefaultImplementationID[[]*log.Log](EKSTaskPrefix + "control-plane-component-log-ingester")
+
+// ControlPlaneComponentLogGrouperTaskID is the task ID to group control plane component logs by the component.
+var ControlPlaneComponentLogGrouperTaskID = taskid.NewDefaultImplementationID[inspectiontaskbase.LogGroupMap](EKSTaskPrefix + "control-plane-component-log-grouper")
+
+// ControlPlaneComponentLogToTimelineMapperTaskID is the task ID to add events of control plane component logs on the timelines.
+var ControlPlaneComponentLogToTimelineMapperTaskID = taskid.NewDefaultImplementationID[struct{}](EKSTaskPrefix + "control-plane-component-log-timeline-mapper")
+
+// ControlPlaneComponentLogTailTaskID is the task ID of the feature task to parse control plane component logs.
+var ControlPlaneComponentLogTailTaskID = taskid.NewDefaultImplementationID[struct{}](EKSTaskPrefix + "control-plane-component-log-tail")
+
+// NodeLogQueryTaskID is the task ID to query the node logs forwarded by Fluent Bit as the source of the node log parsers.
+var NodeLogQueryTaskID = taskid.NewImplementationID(googlecloudlogk8snode_contract.ListLogEntriesTaskID.Ref(), "eks")
+
+// NodeLogCommonFieldSetReaderTaskID is the task ID to read the fieldset used by the node log parsers from the Fluent Bit records.
+var NodeLogCommonFieldSetReaderTaskID = taskid.NewImplementationID(googlecloudlogk8snode_contract.CommonFieldsetReaderTaskID.Ref(), "eks")
+
+// NodeLogParserTailTaskID is the task ID of the feature task to parse node logs.
+var NodeLogParserTailTaskID = taskid.NewDefaultImplementationID[struct{}](EKSTaskPrefix + "node-log-parser-tail")
@@ -0,0 +1,101 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsclustereks_impl
+
+import (
+	"context"
+
+	"github.com/kyasbal/khi/pkg/api/aws"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	awsclustereks_contract "github.com/kyasbal/khi/pkg/task/inspection/awsclustereks/contract"
+	commonlogk8sauditv2_contract "github.com/kyasbal/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	commontimerange_contract "github.com/kyasbal/khi/pkg/task/inspection/commontimerange/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// AuditLogQueryTask reads kube-apiserver audit logs from CloudWatch Logs in place of the uploaded audit log files.
+// The audit logs of EKS are the same JSON objects written by kube-apiserver, thus the filters and the parsers for OSS clusters process them.
+var AuditLogQueryTask = inspectiontaskbase.NewProgressReportableInspectionTask(
+	awsclustereks_contract.AuditLogQueryTaskID,
+	[]taskid.UntypedTaskReference{
+		awsclustereks_contract.CloudWatchLogsClientTaskID.Ref(),
+		awsclustereks_contract.InputClusterNameTaskID.Ref(),
+		commontimerange_contract.InputStartTimeTaskID.Ref(),
+		commontimerange_contract.InputEndTimeTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, tp *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, error) {
+		if taskMode == inspectioncore_contract.TaskModeDryRun {
+			return []*log.Log{}, nil
+		}
+		client := coretask.GetTaskResult(ctx, awsclustereks_contract.CloudWatchLogsClientTaskID.Ref())
+		clusterName := coretask.GetTaskResult(ctx, awsclustereks_contract.InputClusterNameTaskID.Ref())
+		startTime := coretask.GetTaskResult(ctx, commontimerange_contract.InputStartTimeTaskID.Ref())
+		endTime := coretask.GetTaskResult(ctx, commontimerange_contract.InputEndTimeTaskID.Ref())
+
+		logs, err := queryCloudWatchLogs(ctx, client, &aws.FilterLogEventsInput{
+			LogGroupName:        awsclustereks_contract.ControlPlaneLogGroupName(clusterName),
+			LogStreamNamePrefix: awsclustereks_contract.AuditLogStreamNamePrefix,
+			StartTime:           startTime.UnixMilli(),
+			EndTime:             endTime.UnixMilli(),
+		}, "Enable the audit log type of the control plane logging of the cluster", tp, &ossclusterk8s_contract.OSSK8sAuditLogCommonFieldSetReader{}, enum.LogTypeAudit)
+		if err != nil {
+			return nil, err
+		}
+
+		var result []*log.Log
+		for _, l := range logs {
+			if !commonlogk8sauditv2_contract.IsParsableAuditLogStage(l) {
+				continue
+			}
+			result = append(result, l)
+		}
+		return result, nil
+	},
+	coretask.WithSelectionPriority(1000),
+	inspectioncore_contract.InspectionTypeLabel(awsclustereks_contract.InspectionTypeID),
+)
+
+var EKSK8sAuditLogFieldExtractorTask = inspectiontaskbase.NewFieldSetReadTask(
+	awsclustereks_contract.EKSK8sAuditLogProviderTaskID,
+	ossclusterk8s_contract.NonEventAuditLogFilterTaskID.Ref(),
+	[]log.FieldSetReader{(&ossclusterk8s_contract.OSSK8sAuditLogFieldSetReader{})},
+	inspectioncore_contract.InspectionTypeLabel(awsclustereks_contract.InspectionTypeID),
+)
+
+var EKSK8sAuditLogParserTailTask = inspectiontaskbase.NewInspectionTask(
+	awsclustereks_contract.EKSK8sAuditLogParserTailTaskID,
+	commonlogk8sauditv2_contract.ParserTailTaskDependencies(),
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (struct{}, error) {
+		return struct{}{}, nil
+	},
+	inspectioncore_contract.FeatureTaskLabel("Kubernetes Audit Log(v3)", `Gather kube-apiserver audit logs sent to CloudWatch Logs by the control plane logging and visualize resource modifications.`, enum.LogTypeAudit, 1001, true, awsclustereks_contract.InspectionTypeID), coretask.NewSubsequentTaskRefsTaskLabel(inspectioncore_contract.SerializerTaskID.Ref()),
+)
+
+var EKSK8sAuditPermissionDeniedParserTailTask = inspectiontaskbase.NewInspectionTask(
+	awsclustereks_contract.EKSK8sAuditPermissionDeniedParserTailTaskID,
+	[]taskid.UntypedTaskReference{
+		commonlogk8sauditv2_contract.PermissionDeniedLogToTimelineMapperTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (struct{}, error) {
+		return struct{}{}, nil
+	},
+	inspectioncore_contract.FeatureTaskLabel("Kubernetes Permission Denials", `Gather kube-apiserver audit logs of requests denied by the authorizer and show them on timelines grouped by the principal and the resource.`, enum.LogTypeAudit, 1002, false, awsclustereks_contract.InspectionTypeID), coretask.NewSubsequentTaskRefsTaskLabel(inspectioncore_contract.SerializerTaskID.Ref()),
+)
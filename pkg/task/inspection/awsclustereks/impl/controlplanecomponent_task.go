@@ -0,0 +1,169 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsclustereks_impl
+
+import (
+	"context"
+	"slices"
+
+	"github.com/kyasbal/khi/pkg/api/aws"
+	"github.com/kyasbal/khi/pkg/core/inspection/logutil"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/history"
+	"github.com/kyasbal/khi/pkg/model/log"
+	awsclustereks_contract "github.com/kyasbal/khi/pkg/task/inspection/awsclustereks/contract"
+	commontimerange_contract "github.com/kyasbal/khi/pkg/task/inspection/commontimerange/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// maxLogStreamNamesPerRequest is the maximum count of log stream names specified in a FilterLogEvents request.
+const maxLogStreamNamesPerRequest = 100
+
+// ControlPlaneComponentLogQueryTask reads the control plane component logs other than audit logs.
+// The log streams are listed at first because the prefix of kube-apiserver log streams also matches the audit log streams.
+var ControlPlaneComponentLogQueryTask = inspectiontaskbase.NewProgressReportableInspectionTask(
+	awsclustereks_contract.ControlPlaneComponentLogQueryTaskID,
+	[]taskid.UntypedTaskReference{
+		awsclustereks_contract.CloudWatchLogsClientTaskID.Ref(),
+		awsclustereks_contract.InputClusterNameTaskID.Ref(),
+		commontimerange_contract.InputStartTimeTaskID.Ref(),
+		commontimerange_contract.InputEndTimeTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, tp *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, error) {
+		if taskMode == inspectioncore_contract.TaskModeDryRun {
+			return []*log.Log{}, nil
+		}
+		client := coretask.GetTaskResult(ctx, awsclustereks_contract.CloudWatchLogsClientTaskID.Ref())
+		clusterName := coretask.GetTaskResult(ctx, awsclustereks_contract.InputClusterNameTaskID.Ref())
+		startTime := coretask.GetTaskResult(ctx, commontimerange_contract.InputStartTimeTaskID.Ref())
+		endTime := coretask.GetTaskResult(ctx, commontimerange_contract.InputEndTimeTaskID.Ref())
+		logGroupName := awsclustereks_contract.ControlPlaneLogGroupName(clusterName)
+		logGroupHint := "Enable the control plane logging of the cluster"
+
+		streams, err := awsclustereks_contract.DescribeAllLogStreams(ctx, client, &aws.DescribeLogStreamsInput{
+			LogGroupName: logGroupName,
+		})
+		if err != nil {
+			return nil, err
+		}
+		var streamNames []string
+		for _, stream := range streams {
+			if awsclustereks_contract.ControlPlaneComponentFromLogStreamName(stream.LogStreamName) != "" {
+				streamNames = append(streamNames, stream.LogStreamName)
+			}
+		}
+
+		var result []*log.Log
+		for chunk := range slices.Chunk(streamNames, maxLogStreamNamesPerRequest) {
+			logs, err := queryCloudWatchLogs(ctx, client, &aws.FilterLogEventsInput{
+				LogGroupName:   logGroupName,
+				LogStreamNames: chunk,
+				StartTime:      startTime.UnixMilli(),
+				EndTime:        endTime.UnixMilli(),
+			}, logGroupHint, tp, &awsclustereks_contract.CloudWatchLogEventCommonFieldSetReader{}, enum.LogTypeControlPlaneComponent)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, logs...)
+		}
+		slices.SortStableFunc(result, func(a, b *log.Log) int {
+			return log.MustGetFieldSet(a, &log.CommonFieldSet{}).Timestamp.Compare(log.MustGetFieldSet(b, &log.CommonFieldSet{}).Timestamp)
+		})
+		return result, nil
+	},
+)
+
+var ControlPlaneComponentLogFieldSetReaderTask = inspectiontaskbase.NewFieldSetReadTask(
+	awsclustereks_contract.ControlPlaneComponentLogFieldSetReaderTaskID,
+	awsclustereks_contract.ControlPlaneComponentLogQueryTaskID.Ref(),
+	[]log.FieldSetReader{
+		&awsclustereks_contract.EKSControlPlaneComponentFieldSetReader{
+			StructuredLogParser: logutil.NewMultiTextLogParser(
+				logutil.NewKLogTextParser(true),
+				&logutil.FallbackRawTextLogParser{},
+			),
+		},
+	},
+)
+
+// ControlPlaneComponentLogIngesterTask serializes logs to history. No control plane component logs are discarded.
+var ControlPlaneComponentLogIngesterTask = inspectiontaskbase.NewLogIngesterTask(awsclustereks_contract.ControlPlaneComponentLogIngesterTaskID, awsclustereks_contract.ControlPlaneComponentLogFieldSetReaderTaskID.Ref())
+
+var ControlPlaneComponentLogGrouperTask = inspectiontaskbase.NewLogGrouperTask(
+	awsclustereks_contract.ControlPlaneComponentLogGrouperTaskID,
+	awsclustereks_contract.ControlPlaneComponentLogFieldSetReaderTaskID.Ref(),
+	func(ctx context.Context, l *log.Log) string {
+		componentFieldSet, err := log.GetFieldSet(l, &awsclustereks_contract.EKSControlPlaneComponentFieldSet{})
+		if err != nil {
+			return ""
+		}
+		return componentFieldSet.ComponentName
+	},
+)
+
+var ControlPlaneComponentLogToTimelineMapperTask = inspectiontaskbase.NewLogToTimelineMapperTask[struct{}](awsclustereks_contract.ControlPlaneComponentLogToTimelineMapperTaskID, &controlPlaneComponentLogToTimelineMapperTaskSetting{})
+
+type controlPlaneComponentLogToTimelineMapperTaskSetting struct {
+}
+
+// Dependencies implements inspectiontaskbase.LogToTimelineMapper.
+func (c *controlPlaneComponentLogToTimelineMapperTaskSetting) Dependencies() []taskid.UntypedTaskReference {
+	return []taskid.UntypedTaskReference{}
+}
+
+// GroupedLogTask implements inspectiontaskbase.LogToTimelineMapper.
+func (c *controlPlaneComponentLogToTimelineMapperTaskSetting) GroupedLogTask() taskid.TaskReference[inspectiontaskbase.LogGroupMap] {
+	return awsclustereks_contract.ControlPlaneComponentLogGrouperTaskID.Ref()
+}
+
+// LogIngesterTask implements inspectiontaskbase.LogToTimelineMapper.
+func (c *controlPlaneComponentLogToTimelineMapperTaskSetting) LogIngesterTask() taskid.TaskReference[[]*log.Log] {
+	return awsclustereks_contract.ControlPlaneComponentLogIngesterTaskID.Ref()
+}
+
+// ProcessLogByGroup implements inspectiontaskbase.LogToTimelineMapper.
+func (c *controlPlaneComponentLogToTimelineMapperTaskSetting) ProcessLogByGroup(ctx context.Context, l *log.Log, cs *history.ChangeSet, builder *history.Builder, prevGroupData struct{}) (struct{}, error) {
+	componentFieldSet, err := log.GetFieldSet(l, &awsclustereks_contract.EKSControlPlaneComponentFieldSet{})
+	if err != nil {
+		return struct{}{}, err
+	}
+	summary, err := componentFieldSet.Message.MainMessage()
+	if err != nil || summary == "" {
+		summary = componentFieldSet.Message.Raw()
+	}
+	if severity, err := componentFieldSet.Message.Severity(); err == nil {
+		cs.SetLogSeverity(severity)
+	}
+	cs.SetLogSummary(summary)
+	cs.AddEvent(componentFieldSet.ResourcePath())
+	return struct{}{}, nil
+}
+
+var _ inspectiontaskbase.LogToTimelineMapper[struct{}] = (*controlPlaneComponentLogToTimelineMapperTaskSetting)(nil)
+
+var ControlPlaneComponentLogTailTask = inspectiontaskbase.NewInspectionTask(
+	awsclustereks_contract.ControlPlaneComponentLogTailTaskID,
+	[]taskid.UntypedTaskReference{
+		awsclustereks_contract.ControlPlaneComponentLogToTimelineMapperTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (struct{}, error) {
+		return struct{}{}, nil
+	},
+	inspectioncore_contract.FeatureTaskLabel("Kubernetes Control plane component logs", `Gather control plane component logs (kube-apiserver, authenticator, kube-controller-manager, cloud-controller-manager and kube-scheduler) sent to CloudWatch Logs by the control plane logging.`, enum.LogTypeControlPlaneComponent, 9000, false, awsclustereks_contract.InspectionTypeID),
+)
@@ -0,0 +1,138 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsclustereks_impl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/api/aws"
+	"github.com/kyasbal/khi/pkg/core/inspection/logutil"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/history"
+	"github.com/kyasbal/khi/pkg/model/log"
+	awsclustereks_contract "github.com/kyasbal/khi/pkg/task/inspection/awsclustereks/contract"
+	commontimerange_contract "github.com/kyasbal/khi/pkg/task/inspection/commontimerange/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	"github.com/kyasbal/khi/pkg/testutil/testchangeset"
+)
+
+type fakeCloudWatchLogsAPI struct {
+	logStreams          []*aws.LogStream
+	events              map[string][]*aws.FilteredLogEvent
+	filterLogEventsCall []*aws.FilterLogEventsInput
+}
+
+// FilterLogEvents implements aws.CloudWatchLogsAPI.
+func (f *fakeCloudWatchLogsAPI) FilterLogEvents(ctx context.Context, input *aws.FilterLogEventsInput) (*aws.FilterLogEventsOutput, error) {
+	f.filterLogEventsCall = append(f.filterLogEventsCall, input)
+	output := &aws.FilterLogEventsOutput{}
+	for _, name := range input.LogStreamNames {
+		output.Events = append(output.Events, f.events[name]...)
+	}
+	return output, nil
+}
+
+// DescribeLogStreams implements aws.CloudWatchLogsAPI.
+func (f *fakeCloudWatchLogsAPI) DescribeLogStreams(ctx context.Context, input *aws.DescribeLogStreamsInput) (*aws.DescribeLogStreamsOutput, error) {
+	return &aws.DescribeLogStreamsOutput{LogStreams: f.logStreams}, nil
+}
+
+var _ aws.CloudWatchLogsAPI = (*fakeCloudWatchLogsAPI)(nil)
+
+func TestControlPlaneComponentLogQueryTask(t *testing.T) {
+	startTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	endTime := startTime.Add(time.Hour)
+	api := &fakeCloudWatchLogsAPI{
+		logStreams: []*aws.LogStream{
+			{LogStreamName: "kube-apiserver-audit-abc"},
+			{LogStreamName: "kube-scheduler-abc"},
+			{LogStreamName: "kube-apiserver-abc"},
+		},
+		events: map[string][]*aws.FilteredLogEvent{
+			"kube-scheduler-abc": {
+				{EventID: "event-2", LogStreamName: "kube-scheduler-abc", Message: "I0101 00:00:02.000000      10 scheduler.go:10] scheduled", Timestamp: startTime.Add(2 * time.Second).UnixMilli()},
+			},
+			"kube-apiserver-abc": {
+				{EventID: "event-1", LogStreamName: "kube-apiserver-abc", Message: "I0101 00:00:01.000000      10 apiserver.go:10] started", Timestamp: startTime.Add(time.Second).UnixMilli()},
+			},
+		},
+	}
+
+	ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+	logs, _, err := inspectiontest.RunInspectionTask(ctx, ControlPlaneComponentLogQueryTask, inspectioncore_contract.TaskModeRun, map[string]any{},
+		tasktest.NewTaskDependencyValuePair[aws.CloudWatchLogsAPI](awsclustereks_contract.CloudWatchLogsClientTaskID.Ref(), api),
+		tasktest.NewTaskDependencyValuePair(awsclustereks_contract.InputClusterNameTaskID.Ref(), "my-cluster"),
+		tasktest.NewTaskDependencyValuePair(commontimerange_contract.InputStartTimeTaskID.Ref(), startTime),
+		tasktest.NewTaskDependencyValuePair(commontimerange_contract.InputEndTimeTaskID.Ref(), endTime),
+	)
+	if err != nil {
+		t.Fatalf("ControlPlaneComponentLogQueryTask returned an unexpected error: %v", err)
+	}
+
+	wantCalls := []*aws.FilterLogEventsInput{
+		{
+			LogGroupName:   "/aws/eks/my-cluster/cluster",
+			LogStreamNames: []string{"kube-scheduler-abc", "kube-apiserver-abc"},
+			StartTime:      startTime.UnixMilli(),
+			EndTime:        endTime.UnixMilli(),
+		},
+	}
+	if diff := cmp.Diff(wantCalls, api.filterLogEventsCall); diff != "" {
+		t.Errorf("FilterLogEvents calls mismatch (-want +got):\n%s", diff)
+	}
+	var gotIDs []string
+	for _, l := range logs {
+		if l.LogType != enum.LogTypeControlPlaneComponent {
+			t.Errorf("LogType = %v, want %v", l.LogType, enum.LogTypeControlPlaneComponent)
+		}
+		gotIDs = append(gotIDs, log.MustGetFieldSet(l, &log.CommonFieldSet{}).DisplayID)
+	}
+	if diff := cmp.Diff([]string{"event-1", "event-2"}, gotIDs); diff != "" {
+		t.Errorf("logs are not sorted by the timestamp (-want +got):\n%s", diff)
+	}
+}
+
+func TestControlPlaneComponentLogToTimelineMapper(t *testing.T) {
+	l, err := awsclustereks_contract.NewLogFromCloudWatchEvent(awsclustereks_contract.ControlPlaneLogGroupName("my-cluster"), &aws.FilteredLogEvent{
+		EventID:       "event-1",
+		LogStreamName: "kube-controller-manager-abc",
+		Message:       `E0101 00:00:00.000000      10 controller.go:100] "Failed to sync" err="conflict"`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = l.SetFieldSetReader(&awsclustereks_contract.EKSControlPlaneComponentFieldSetReader{StructuredLogParser: logutil.NewKLogTextParser(true)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cs := history.NewChangeSet(l)
+	_, err = (&controlPlaneComponentLogToTimelineMapperTaskSetting{}).ProcessLogByGroup(context.Background(), l, cs, nil, struct{}{})
+	if err != nil {
+		t.Fatalf("ProcessLogByGroup() returned an unexpected error: %v", err)
+	}
+	asserters := []testchangeset.ChangeSetAsserter{
+		&testchangeset.HasEvent{ResourcePath: "@Cluster#controlplane#cluster-scope#my-cluster#kube-controller-manager"},
+		&testchangeset.HasLogSummary{WantLogSummary: "Failed to sync"},
+	}
+	for _, asserter := range asserters {
+		asserter.Assert(t, cs)
+	}
+}
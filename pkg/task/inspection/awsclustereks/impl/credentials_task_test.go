@@ -0,0 +1,94 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsclustereks_impl
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/api/aws"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	awsclustereks_contract "github.com/kyasbal/khi/pkg/task/inspection/awsclustereks/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+func TestCredentialsTask(t *testing.T) {
+	testCases := []struct {
+		desc            string
+		mode            inspectioncore_contract.InspectionTaskModeType
+		accessKeyID     string
+		secretAccessKey string
+		sessionToken    string
+		env             map[string]string
+		want            aws.Credentials
+		wantErr         bool
+	}{
+		{
+			desc:            "credentials from the forms",
+			mode:            inspectioncore_contract.TaskModeRun,
+			accessKeyID:     "AKIDFORM",
+			secretAccessKey: "form-secret",
+			sessionToken:    "form-token",
+			env: map[string]string{
+				"AWS_ACCESS_KEY_ID":     "AKIDENV",
+				"AWS_SECRET_ACCESS_KEY": "env-secret",
+			},
+			want: aws.Credentials{AccessKeyID: "AKIDFORM", SecretAccessKey: "form-secret", SessionToken: "form-token"},
+		},
+		{
+			desc: "credentials from the environment variables",
+			mode: inspectioncore_contract.TaskModeRun,
+			env: map[string]string{
+				"AWS_ACCESS_KEY_ID":     "AKIDENV",
+				"AWS_SECRET_ACCESS_KEY": "env-secret",
+				"AWS_SESSION_TOKEN":     "env-token",
+			},
+			want: aws.Credentials{AccessKeyID: "AKIDENV", SecretAccessKey: "env-secret", SessionToken: "env-token"},
+		},
+		{
+			desc:    "missing credentials in run mode",
+			mode:    inspectioncore_contract.TaskModeRun,
+			wantErr: true,
+		},
+		{
+			desc: "missing credentials in dry run mode",
+			mode: inspectioncore_contract.TaskModeDryRun,
+			want: aws.Credentials{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			for _, name := range []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_SESSION_TOKEN"} {
+				t.Setenv(name, tc.env[name])
+			}
+			ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+			got, _, err := inspectiontest.RunInspectionTask(ctx, CredentialsTask, tc.mode, map[string]any{},
+				tasktest.NewTaskDependencyValuePair(awsclustereks_contract.InputAccessKeyIDTaskID.Ref(), tc.accessKeyID),
+				tasktest.NewTaskDependencyValuePair(awsclustereks_contract.InputSecretAccessKeyTaskID.Ref(), tc.secretAccessKey),
+				tasktest.NewTaskDependencyValuePair(awsclustereks_contract.InputSessionTokenTaskID.Ref(), tc.sessionToken),
+			)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("CredentialsTask error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("CredentialsTask mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsclustereks_impl
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	awsclustereks_contract "github.com/kyasbal/khi/pkg/task/inspection/awsclustereks/contract"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+)
+
+var regionValidator = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-\d+$`)
+
+var clusterNameValidator = regexp.MustCompile(`^[0-9A-Za-z][A-Za-z0-9\-_]*$`)
+
+// InputRegionTask defines a form task to input the AWS region of the EKS cluster.
+var InputRegionTask = formtask.NewTextFormTaskBuilder(awsclustereks_contract.InputRegionTaskID, 0, "Region").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionResourceIdentifier}).
+	WithPlaceholder("e.g. us-west-2").
+	WithDescription("The AWS region of the EKS cluster.").
+	WithDefaultValueFunc(func(ctx context.Context, previousValues []string) (string, error) {
+		if len(previousValues) > 0 {
+			return previousValues[0], nil
+		}
+		return "", nil
+	}).
+	WithValidator(func(ctx context.Context, value string) (string, error) {
+		if !regionValidator.MatchString(strings.TrimSpace(value)) {
+			return "Region must be a valid AWS region like `us-west-2`", nil
+		}
+		return "", nil
+	}).
+	WithConverter(func(ctx context.Context, value string) (string, error) {
+		return strings.TrimSpace(value), nil
+	}).
+	Build()
+
+// InputClusterNameTask defines a form task to input the name of the EKS cluster.
+var InputClusterNameTask = formtask.NewTextFormTaskBuilder(awsclustereks_contract.InputClusterNameTaskID, 0, "Cluster name").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionResourceIdentifier, After: []string{awsclustereks_contract.InputRegionTaskID.ReferenceIDString()}}).
+	WithPlaceholder("e.g. my-cluster").
+	WithDescription("The name of the EKS cluster. Control plane logs are read from the log group `/aws/eks/<cluster name>/cluster` and node logs are read from the log group `/aws/containerinsights/<cluster name>/dataplane`.").
+	WithMarkdown().
+	WithDefaultValueFunc(func(ctx context.Context, previousValues []string) (string, error) {
+		if len(previousValues) > 0 {
+			return previousValues[0], nil
+		}
+		return "", nil
+	}).
+	WithValidator(func(ctx context.Context, value string) (string, error) {
+		value = strings.TrimSpace(value)
+		if len(value) > 100 || !clusterNameValidator.MatchString(value) {
+			return "Cluster name must match `^[0-9A-Za-z][A-Za-z0-9\\-_]*$` and must be 100 characters or less", nil
+		}
+		return "", nil
+	}).
+	WithConverter(func(ctx context.Context, value string) (string, error) {
+		return strings.TrimSpace(value), nil
+	}).
+	Build()
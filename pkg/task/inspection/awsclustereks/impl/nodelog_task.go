@@ -0,0 +1,95 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsclustereks_impl
+
+import (
+	"context"
+
+	"github.com/kyasbal/khi/pkg/api/aws"
+	"github.com/kyasbal/khi/pkg/core/inspection/logutil"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	awsclustereks_contract "github.com/kyasbal/khi/pkg/task/inspection/awsclustereks/contract"
+	commontimerange_contract "github.com/kyasbal/khi/pkg/task/inspection/commontimerange/contract"
+	googlecloudlogk8snode_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8snode/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// NodeLogQueryTask reads the systemd logs of nodes forwarded by Fluent Bit of Container Insights in place of the node logs queried from Cloud Logging.
+// The node log parsers for GKE process them to generate the node scoped timelines.
+var NodeLogQueryTask = inspectiontaskbase.NewProgressReportableInspectionTask(
+	awsclustereks_contract.NodeLogQueryTaskID,
+	[]taskid.UntypedTaskReference{
+		awsclustereks_contract.CloudWatchLogsClientTaskID.Ref(),
+		awsclustereks_contract.InputClusterNameTaskID.Ref(),
+		commontimerange_contract.InputStartTimeTaskID.Ref(),
+		commontimerange_contract.InputEndTimeTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, tp *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, error) {
+		if taskMode == inspectioncore_contract.TaskModeDryRun {
+			return []*log.Log{}, nil
+		}
+		client := coretask.GetTaskResult(ctx, awsclustereks_contract.CloudWatchLogsClientTaskID.Ref())
+		clusterName := coretask.GetTaskResult(ctx, awsclustereks_contract.InputClusterNameTaskID.Ref())
+		startTime := coretask.GetTaskResult(ctx, commontimerange_contract.InputStartTimeTaskID.Ref())
+		endTime := coretask.GetTaskResult(ctx, commontimerange_contract.InputEndTimeTaskID.Ref())
+
+		return queryCloudWatchLogs(ctx, client, &aws.FilterLogEventsInput{
+			LogGroupName: awsclustereks_contract.DataplaneLogGroupName(clusterName),
+			StartTime:    startTime.UnixMilli(),
+			EndTime:      endTime.UnixMilli(),
+		}, "Install Fluent Bit with Container Insights on the cluster to send node logs", tp, &awsclustereks_contract.CloudWatchLogEventCommonFieldSetReader{}, enum.LogTypeNode)
+	},
+	coretask.WithSelectionPriority(1000),
+	inspectioncore_contract.InspectionTypeLabel(awsclustereks_contract.InspectionTypeID),
+)
+
+// NodeLogCommonFieldSetReaderTask reads the fieldset used by the node log parsers from the Fluent Bit records instead of the logs from Cloud Logging.
+var NodeLogCommonFieldSetReaderTask = inspectiontaskbase.NewFieldSetReadTask(
+	awsclustereks_contract.NodeLogCommonFieldSetReaderTaskID,
+	googlecloudlogk8snode_contract.ListLogEntriesTaskID.Ref(),
+	[]log.FieldSetReader{
+		&awsclustereks_contract.EKSFluentBitNodeLogFieldSetReader{
+			StructuredLogParser: logutil.NewMultiTextLogParser(
+				logutil.NewJsonlTextParser(),
+				logutil.NewKLogTextParser(true),
+				logutil.NewLogfmtTextParser(),
+				&logutil.FallbackRawTextLogParser{},
+			),
+		},
+	},
+	coretask.WithSelectionPriority(1000),
+	inspectioncore_contract.InspectionTypeLabel(awsclustereks_contract.InspectionTypeID),
+)
+
+// NodeLogParserTailTask is the feature task to generate node scoped timelines from the node logs in CloudWatch Logs.
+var NodeLogParserTailTask = inspectiontaskbase.NewInspectionTask(
+	awsclustereks_contract.NodeLogParserTailTaskID,
+	[]taskid.UntypedTaskReference{
+		googlecloudlogk8snode_contract.ContainerdLogLogToTimelineMapperTaskID.Ref(),
+		googlecloudlogk8snode_contract.KubeletLogLogToTimelineMapperTaskID.Ref(),
+		googlecloudlogk8snode_contract.OtherLogLogToTimelineMapperTaskID.Ref(),
+
+		googlecloudlogk8snode_contract.ContainerIDDiscoveryTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (struct{}, error) {
+		return struct{}{}, nil
+	},
+	inspectioncore_contract.FeatureTaskLabel("Kubernetes Node Logs", `Gather kubelet and container runtime logs sent to CloudWatch Logs by Fluent Bit of Container Insights.`, enum.LogTypeNode, 1003, false, awsclustereks_contract.InspectionTypeID), coretask.NewSubsequentTaskRefsTaskLabel(inspectioncore_contract.SerializerTaskID.Ref()),
+)
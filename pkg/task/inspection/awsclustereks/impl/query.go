@@ -0,0 +1,62 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsclustereks_impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/kyasbal/khi/pkg/api/aws"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	awsclustereks_contract "github.com/kyasbal/khi/pkg/task/inspection/awsclustereks/contract"
+)
+
+// queryCloudWatchLogs reads all the log events matching the input and converts them to logs sorted by their timestamps.
+// logGroupHint is shown in the error when the log group doesn't exist to tell how to enable sending the logs.
+func queryCloudWatchLogs(ctx context.Context, api aws.CloudWatchLogsAPI, input *aws.FilterLogEventsInput, logGroupHint string, tp *inspectionmetadata.TaskProgressMetadata, commonFieldSetReader log.FieldSetReader, logType enum.LogType) ([]*log.Log, error) {
+	tp.MarkIndeterminate()
+	events, err := awsclustereks_contract.FilterAllLogEvents(ctx, api, input, func(readCount int) {
+		tp.Message = fmt.Sprintf("%d logs fetched", readCount)
+	})
+	if err != nil {
+		var apiErr *aws.APIError
+		if errors.As(err, &apiErr) && apiErr.Type == "ResourceNotFoundException" {
+			return nil, fmt.Errorf("log group %s was not found. %s: %w", input.LogGroupName, logGroupHint, err)
+		}
+		return nil, fmt.Errorf("failed to read logs from log group %s: %w", input.LogGroupName, err)
+	}
+
+	logs := make([]*log.Log, 0, len(events))
+	for _, event := range events {
+		l, err := awsclustereks_contract.NewLogFromCloudWatchEvent(input.LogGroupName, event)
+		if err != nil {
+			return nil, err
+		}
+		err = l.SetFieldSetReader(commonFieldSetReader)
+		if err != nil {
+			return nil, err
+		}
+		l.LogType = logType
+		logs = append(logs, l)
+	}
+	slices.SortFunc(logs, func(a, b *log.Log) int {
+		return log.MustGetFieldSet(a, &log.CommonFieldSet{}).Timestamp.Compare(log.MustGetFieldSet(b, &log.CommonFieldSet{}).Timestamp)
+	})
+	return logs, nil
+}
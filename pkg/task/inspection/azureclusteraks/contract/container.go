@@ -0,0 +1,74 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureclusteraks_contract
+
+import (
+	"encoding/json"
+
+	"github.com/kyasbal/khi/pkg/common/structured"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudlogk8scontainer_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8scontainer/contract"
+)
+
+// structuredContainerLogMessageFieldNames are the fields read as the message from container logs written in JSON.
+var structuredContainerLogMessageFieldNames = []string{
+	"message",
+	"msg",
+	"MESSAGE",
+	"log",
+}
+
+// AKSContainerLogFieldSetReader implements log.FieldSetReader for googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet{} from the ContainerLogV2 records.
+type AKSContainerLogFieldSetReader struct{}
+
+// FieldSetKind implements log.FieldSetReader.
+func (a *AKSContainerLogFieldSetReader) FieldSetKind() string {
+	return (&googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet{}).Kind()
+}
+
+// Read implements log.FieldSetReader.
+func (a *AKSContainerLogFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	var result googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet
+	result.Namespace = reader.ReadStringOrDefault(logAnalyticsRecordField+".PodNamespace", "unknown")
+	result.PodName = reader.ReadStringOrDefault(logAnalyticsRecordField+".PodName", "unknown")
+	result.ContainerName = reader.ReadStringOrDefault(logAnalyticsRecordField+".ContainerName", "unknown")
+	for _, fieldName := range structuredContainerLogMessageFieldNames {
+		message, err := reader.ReadString(fieldName)
+		if err == nil {
+			result.Message = message
+			return &result, nil
+		}
+	}
+	// The message is a JSON object without any known message field. Show the whole object except the record columns.
+	body := map[string]json.RawMessage{}
+	for key, child := range reader.Children() {
+		if key.Key == logAnalyticsRecordField {
+			continue
+		}
+		serializedChild, err := child.Serialize("", &structured.JSONNodeSerializer{})
+		if err != nil {
+			return nil, err
+		}
+		body[key.Key] = serializedChild
+	}
+	serialized, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	result.Message = string(serialized)
+	return &result, nil
+}
+
+var _ log.FieldSetReader = (*AKSContainerLogFieldSetReader)(nil)
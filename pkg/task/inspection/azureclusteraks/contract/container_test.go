@@ -0,0 +1,77 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureclusteraks_contract
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudlogk8scontainer_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8scontainer/contract"
+)
+
+func TestAKSContainerLogFieldSetReader(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		logMessage any
+		want       string
+	}{
+		{
+			desc:       "text message",
+			logMessage: "listening on :8080",
+			want:       "listening on :8080",
+		},
+		{
+			desc:       "json message with a known message field",
+			logMessage: map[string]any{"level": "info", "msg": "listening on :8080"},
+			want:       "listening on :8080",
+		},
+		{
+			desc:       "json message without a known message field",
+			logMessage: map[string]any{"level": "info", "port": 8080},
+			want:       `{"level":"info","port":8080}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l, err := NewLogFromRecord(map[string]any{
+				"TimeGenerated": "2025-01-01T00:00:00Z",
+				"_ItemId":       "item-1",
+				"PodNamespace":  "default",
+				"PodName":       "nginx",
+				"ContainerName": "server",
+				"LogMessage":    tc.logMessage,
+			}, "LogMessage")
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = l.SetFieldSetReader(&AKSContainerLogFieldSetReader{})
+			if err != nil {
+				t.Fatalf("SetFieldSetReader() returned an unexpected error: %v", err)
+			}
+
+			want := &googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet{
+				Namespace:     "default",
+				PodName:       "nginx",
+				ContainerName: "server",
+				Message:       tc.want,
+			}
+			got := log.MustGetFieldSet(l, &googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet{})
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("K8sContainerLogFieldSet mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
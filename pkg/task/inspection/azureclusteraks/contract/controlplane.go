@@ -0,0 +1,73 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureclusteraks_contract
+
+import (
+	"github.com/kyasbal/khi/pkg/common/structured"
+	"github.com/kyasbal/khi/pkg/core/inspection/logutil"
+	"github.com/kyasbal/khi/pkg/model/history/resourcepath"
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+// ControlPlaneComponentCategories are the categories of the AzureDiagnostics records containing control plane component logs other than audit logs.
+// Each category is used as the component name on the timeline.
+var ControlPlaneComponentCategories = []string{
+	"kube-apiserver",
+	"kube-controller-manager",
+	"kube-scheduler",
+	"cluster-autoscaler",
+	"cloud-controller-manager",
+	"guard",
+}
+
+// AKSControlPlaneComponentFieldSet is the fieldset of control plane component logs other than audit logs.
+type AKSControlPlaneComponentFieldSet struct {
+	ClusterName   string
+	ComponentName string
+	Message       *logutil.ParseStructuredLogResult
+}
+
+// Kind implements log.FieldSet.
+func (a *AKSControlPlaneComponentFieldSet) Kind() string {
+	return "aks_controlplane_component"
+}
+
+// ResourcePath returns the path of the timeline for the component.
+func (a *AKSControlPlaneComponentFieldSet) ResourcePath() resourcepath.ResourcePath {
+	return resourcepath.ControlplaneComponent(a.ClusterName, a.ComponentName)
+}
+
+var _ log.FieldSet = (*AKSControlPlaneComponentFieldSet)(nil)
+
+// AKSControlPlaneComponentFieldSetReader implements log.FieldSetReader for AKSControlPlaneComponentFieldSet.
+type AKSControlPlaneComponentFieldSetReader struct {
+	StructuredLogParser logutil.StructuredLogParser
+}
+
+// FieldSetKind implements log.FieldSetReader.
+func (a *AKSControlPlaneComponentFieldSetReader) FieldSetKind() string {
+	return (&AKSControlPlaneComponentFieldSet{}).Kind()
+}
+
+// Read implements log.FieldSetReader.
+func (a *AKSControlPlaneComponentFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	var result AKSControlPlaneComponentFieldSet
+	result.ClusterName = reader.ReadStringOrDefault(logAnalyticsRecordField+".ClusterName", "unknown")
+	result.ComponentName = reader.ReadStringOrDefault(logAnalyticsRecordField+".Category", "unknown")
+	result.Message = a.StructuredLogParser.TryParse(reader.ReadStringOrDefault("message", ""))
+	return &result, nil
+}
+
+var _ log.FieldSetReader = (*AKSControlPlaneComponentFieldSetReader)(nil)
@@ -0,0 +1,53 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureclusteraks_contract
+
+import (
+	"testing"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/logutil"
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+func TestAKSControlPlaneComponentFieldSetReader(t *testing.T) {
+	l, err := NewLogFromRecord(map[string]any{
+		"TimeGenerated": "2025-01-01T00:00:00Z",
+		"_ItemId":       "item-1",
+		"Category":      "kube-scheduler",
+		"ClusterName":   "my-cluster",
+		"log_s":         `E0101 00:00:00.000000      10 schedule_one.go:100] "Error scheduling pod" pod="default/nginx"`,
+	}, "log_s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = l.SetFieldSetReader(&AKSControlPlaneComponentFieldSetReader{StructuredLogParser: logutil.NewKLogTextParser(true)})
+	if err != nil {
+		t.Fatalf("SetFieldSetReader() returned an unexpected error: %v", err)
+	}
+
+	got := log.MustGetFieldSet(l, &AKSControlPlaneComponentFieldSet{})
+	if got.ClusterName != "my-cluster" {
+		t.Errorf("ClusterName = %q, want %q", got.ClusterName, "my-cluster")
+	}
+	if got.ComponentName != "kube-scheduler" {
+		t.Errorf("ComponentName = %q, want %q", got.ComponentName, "kube-scheduler")
+	}
+	if got.ResourcePath().Path != "@Cluster#controlplane#cluster-scope#my-cluster#kube-scheduler" {
+		t.Errorf("ResourcePath() = %q", got.ResourcePath().Path)
+	}
+	if msg, _ := got.Message.MainMessage(); msg != "Error scheduling pod" {
+		t.Errorf("Message.MainMessage() = %q, want %q", msg, "Error scheduling pod")
+	}
+}
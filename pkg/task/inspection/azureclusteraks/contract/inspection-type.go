@@ -0,0 +1,31 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureclusteraks_contract
+
+import (
+	"math"
+
+	coreinspection "github.com/kyasbal/khi/pkg/core/inspection"
+)
+
+const InspectionTypeID = "azure-aks-log-analytics"
+
+var AKSLogAnalyticsInspectionType = coreinspection.InspectionType{
+	Id:          InspectionTypeID,
+	Name:        "Azure AKS (Azure Monitor Logs)",
+	Description: "Visualize logs of Azure Kubernetes Service clusters gathered from a Log Analytics workspace",
+	Icon:        "assets/icons/k8s.png",
+	Priority:    math.MaxInt - 2001,
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureclusteraks_contract
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/common/structured"
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+// logAnalyticsRecordField is the field added on each log to hold the columns of the Log Analytics record other than the message.
+const logAnalyticsRecordField = "azure"
+
+// AuditLogCategories are the categories of the AzureDiagnostics records containing kube-apiserver audit logs.
+// `kube-audit-admin` is the subset of `kube-audit` without get and list requests, thus the same audit event can be found in both categories.
+var AuditLogCategories = []string{"kube-audit", "kube-audit-admin"}
+
+// ClusterResourceID returns the Azure resource ID of the AKS cluster.
+func ClusterResourceID(subscriptionID, resourceGroup, clusterName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.ContainerService/managedClusters/%s", subscriptionID, resourceGroup, clusterName)
+}
+
+// AuditLogQuery returns the KQL query to read kube-apiserver audit logs of the cluster sent to the AzureDiagnostics table by the diagnostic settings.
+func AuditLogQuery(clusterResourceID string) string {
+	return fmt.Sprintf(`AzureDiagnostics
+| where _ResourceId =~ %s
+| where Category in (%s)
+| project TimeGenerated, _ItemId, Category, log_s`, kqlString(clusterResourceID), kqlStringList(AuditLogCategories))
+}
+
+// ControlPlaneComponentLogQuery returns the KQL query to read control plane component logs other than audit logs sent to the AzureDiagnostics table by the diagnostic settings.
+// The cluster name is added as the ClusterName column because the Resource column of AzureDiagnostics is upper cased.
+func ControlPlaneComponentLogQuery(clusterResourceID string, clusterName string) string {
+	return fmt.Sprintf(`AzureDiagnostics
+| where _ResourceId =~ %s
+| where Category in (%s)
+| project TimeGenerated, _ItemId, Category, log_s, ClusterName = %s`, kqlString(clusterResourceID), kqlStringList(ControlPlaneComponentCategories), kqlString(clusterName))
+}
+
+// ContainerLogQuery returns the KQL query to read container stdout/stderr logs of the cluster collected by Container insights into the ContainerLogV2 table.
+func ContainerLogQuery(clusterResourceID string) string {
+	return fmt.Sprintf(`ContainerLogV2
+| where _ResourceId =~ %s
+| project TimeGenerated, _ItemId, Computer, ContainerId, PodNamespace, PodName, ContainerName, LogSource, LogMessage`, kqlString(clusterResourceID))
+}
+
+// kqlString returns the given string as a KQL string literal.
+func kqlString(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+// kqlStringList returns the given strings as comma separated KQL string literals.
+func kqlStringList(values []string) string {
+	literals := make([]string, 0, len(values))
+	for _, value := range values {
+		literals = append(literals, kqlString(value))
+	}
+	return strings.Join(literals, ", ")
+}
+
+// NewLogFromRecord converts a record returned from the Log Analytics query API to a log.
+// A message given in a JSON object (e.g. audit logs or structured container logs) is used as the log body. Other messages are stored in the `message` field.
+// The other columns of the record are stored in the `azure` field.
+func NewLogFromRecord(record map[string]any, messageColumn string) (*log.Log, error) {
+	body := map[string]any{}
+	switch message := record[messageColumn].(type) {
+	case map[string]any:
+		body = message
+	case string:
+		if err := json.Unmarshal([]byte(message), &body); err != nil {
+			body = map[string]any{
+				"message": message,
+			}
+		}
+	case nil:
+		body["message"] = ""
+	default:
+		body["message"] = message
+	}
+	columns := map[string]any{}
+	for name, value := range record {
+		if name == messageColumn {
+			continue
+		}
+		columns[name] = value
+	}
+	body[logAnalyticsRecordField] = columns
+	serialized, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize the record %v: %w", record["_ItemId"], err)
+	}
+	return log.NewLogFromYAMLString(string(serialized))
+}
+
+// LogAnalyticsRecordCommonFieldSetReader implements log.FieldSetReader for log.CommonFieldSet{} from the columns of the Log Analytics record.
+type LogAnalyticsRecordCommonFieldSetReader struct{}
+
+// FieldSetKind implements log.FieldSetReader.
+func (l *LogAnalyticsRecordCommonFieldSetReader) FieldSetKind() string {
+	return (&log.CommonFieldSet{}).Kind()
+}
+
+// Read implements log.FieldSetReader.
+func (l *LogAnalyticsRecordCommonFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	result := &log.CommonFieldSet{}
+	result.DisplayID = reader.ReadStringOrDefault(logAnalyticsRecordField+"._ItemId", "unknown")
+	timestamp, err := reader.ReadTimestamp(logAnalyticsRecordField + ".TimeGenerated")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the timestamp of the Log Analytics record: %w", err)
+	}
+	result.Timestamp = timestamp
+	return result, nil
+}
+
+var _ log.FieldSetReader = (*LogAnalyticsRecordCommonFieldSetReader)(nil)
@@ -0,0 +1,142 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureclusteraks_contract
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+func TestClusterResourceID(t *testing.T) {
+	got := ClusterResourceID("00000000-0000-0000-0000-000000000000", "my-rg", "my-cluster")
+	want := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/my-rg/providers/Microsoft.ContainerService/managedClusters/my-cluster"
+	if got != want {
+		t.Errorf("ClusterResourceID() = %q, want %q", got, want)
+	}
+}
+
+func TestAuditLogQuery(t *testing.T) {
+	got := AuditLogQuery(`/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ContainerService/managedClusters/my-cluster`)
+	want := `AzureDiagnostics
+| where _ResourceId =~ "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ContainerService/managedClusters/my-cluster"
+| where Category in ("kube-audit", "kube-audit-admin")
+| project TimeGenerated, _ItemId, Category, log_s`
+	if got != want {
+		t.Errorf("AuditLogQuery() = %q, want %q", got, want)
+	}
+}
+
+func TestKQLString(t *testing.T) {
+	testCases := []struct {
+		value string
+		want  string
+	}{
+		{value: "my-cluster", want: `"my-cluster"`},
+		{value: `a"b`, want: `"a\"b"`},
+		{value: `a\b`, want: `"a\\b"`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			if got := kqlString(tc.value); got != tc.want {
+				t.Errorf("kqlString(%q) = %q, want %q", tc.value, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewLogFromRecord(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		record        map[string]any
+		messageColumn string
+		wantFields    map[string]string
+	}{
+		{
+			desc: "json message in a string column",
+			record: map[string]any{
+				"TimeGenerated": "2025-01-01T00:00:00.1234567Z",
+				"_ItemId":       "item-1",
+				"Category":      "kube-audit",
+				"log_s":         `{"kind":"Event","stage":"ResponseComplete"}`,
+			},
+			messageColumn: "log_s",
+			wantFields: map[string]string{
+				"kind":           "Event",
+				"stage":          "ResponseComplete",
+				"azure._ItemId":  "item-1",
+				"azure.Category": "kube-audit",
+			},
+		},
+		{
+			desc: "text message",
+			record: map[string]any{
+				"TimeGenerated": "2025-01-01T00:00:00.1234567Z",
+				"_ItemId":       "item-1",
+				"Category":      "kube-scheduler",
+				"log_s":         "I0101 00:00:00.123456      10 leaderelection.go:268] successfully acquired lease",
+			},
+			messageColumn: "log_s",
+			wantFields: map[string]string{
+				"message":        "I0101 00:00:00.123456      10 leaderelection.go:268] successfully acquired lease",
+				"azure.Category": "kube-scheduler",
+			},
+		},
+		{
+			desc: "decoded dynamic message",
+			record: map[string]any{
+				"TimeGenerated": "2025-01-01T00:00:00.1234567Z",
+				"_ItemId":       "item-1",
+				"PodName":       "nginx",
+				"LogMessage":    map[string]any{"msg": "hello"},
+			},
+			messageColumn: "LogMessage",
+			wantFields: map[string]string{
+				"msg":           "hello",
+				"azure.PodName": "nginx",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l, err := NewLogFromRecord(tc.record, tc.messageColumn)
+			if err != nil {
+				t.Fatalf("NewLogFromRecord() returned an unexpected error: %v", err)
+			}
+			for fieldPath, want := range tc.wantFields {
+				if got := l.ReadStringOrDefault(fieldPath, ""); got != want {
+					t.Errorf("field %s = %q, want %q", fieldPath, got, want)
+				}
+			}
+			if l.Has("azure." + tc.messageColumn) {
+				t.Errorf("the message column %s must not be kept in the azure field", tc.messageColumn)
+			}
+
+			err = l.SetFieldSetReader(&LogAnalyticsRecordCommonFieldSetReader{})
+			if err != nil {
+				t.Fatalf("SetFieldSetReader() returned an unexpected error: %v", err)
+			}
+			commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+			if commonFieldSet.DisplayID != "item-1" {
+				t.Errorf("DisplayID = %q, want %q", commonFieldSet.DisplayID, "item-1")
+			}
+			wantTimestamp := time.Date(2025, 1, 1, 0, 0, 0, 123456700, time.UTC)
+			if !commonFieldSet.Timestamp.Equal(wantTimestamp) {
+				t.Errorf("Timestamp = %v, want %v", commonFieldSet.Timestamp, wantTimestamp)
+			}
+		})
+	}
+}
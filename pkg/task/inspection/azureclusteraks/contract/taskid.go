@@ -0,0 +1,102 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureclusteraks_contract
+
+import (
+	"github.com/kyasbal/khi/pkg/api/azure"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/kyasbal/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// AKSTaskPrefix is the prefixes of IDs used in Azure Kubernetes Service related tasks.
+const AKSTaskPrefix = "khi.google.com/azure-aks/"
+
+// InputSubscriptionIDTaskID is the task ID for the form to input the Azure subscription ID of the cluster.
+var InputSubscriptionIDTaskID = taskid.NewDefaultImplementationID[string](AKSTaskPrefix + "form/subscription-id")
+
+// InputResourceGroupTaskID is the task ID for the form to input the resource group of the cluster.
+var InputResourceGroupTaskID = taskid.NewDefaultImplementationID[string](AKSTaskPrefix + "form/resource-group")
+
+// InputClusterNameTaskID is the task ID for the form to input the name of the AKS cluster.
+var InputClusterNameTaskID = taskid.NewDefaultImplementationID[string](AKSTaskPrefix + "form/cluster-name")
+
+// InputWorkspaceIDTaskID is the task ID for the form to input the workspace ID of the Log Analytics workspace receiving the logs of the cluster.
+var InputWorkspaceIDTaskID = taskid.NewDefaultImplementationID[string](AKSTaskPrefix + "form/workspace-id")
+
+// InputTenantIDTaskID is the task ID for the form to input the Microsoft Entra ID tenant ID.
+var InputTenantIDTaskID = taskid.NewDefaultImplementationID[string](AKSTaskPrefix + "form/tenant-id")
+
+// InputClientIDTaskID is the task ID for the form to input the client ID of the service principal.
+var InputClientIDTaskID = taskid.NewDefaultImplementationID[string](AKSTaskPrefix + "form/client-id")
+
+// InputClientSecretTaskID is the task ID for the secret form to input the client secret of the service principal.
+var InputClientSecretTaskID = taskid.NewDefaultImplementationID[string](AKSTaskPrefix + "form/client-secret")
+
+// ClusterResourceIDTaskID is the task ID to build the Azure resource ID of the cluster from the forms.
+var ClusterResourceIDTaskID = taskid.NewDefaultImplementationID[string](AKSTaskPrefix + "cluster-resource-id")
+
+// CredentialsTaskID is the task ID to resolve the service principal credentials from the forms or the environment variables.
+var CredentialsTaskID = taskid.NewDefaultImplementationID[azure.ClientCredentials](AKSTaskPrefix + "credentials")
+
+// LogAnalyticsClientTaskID is the task ID to provide the client of Log Analytics query API.
+var LogAnalyticsClientTaskID = taskid.NewDefaultImplementationID[azure.LogAnalyticsAPI](AKSTaskPrefix + "log-analytics-client")
+
+// AuditLogQueryTaskID is the task ID to query kube-apiserver audit logs from the AzureDiagnostics table in place of reading uploaded audit log files.
+var AuditLogQueryTaskID = taskid.NewImplementationID(ossclusterk8s_contract.AuditLogFileReaderTaskID.Ref(), "aks")
+
+// AKSK8sAuditLogProviderTaskID is the task ID to provide the audit logs to the common k8s audit log parsers.
+var AKSK8sAuditLogProviderTaskID = taskid.NewImplementationID(commonlogk8sauditv2_contract.K8sAuditLogProviderRef, "aks")
+
+// AKSK8sAuditLogParserTailTaskID is the task ID of the feature task to parse audit logs.
+var AKSK8sAuditLogParserTailTaskID = taskid.NewImplementationID(commonlogk8sauditv2_contract.K8sAuditLogParserTailRef, "aks")
+
+// AKSK8sAuditPermissionDeniedParserTailTaskID is the task ID of the feature task to parse audit logs of denied requests.
+var AKSK8sAuditPermissionDeniedParserTailTaskID = taskid.NewImplementationID(commonlogk8sauditv2_contract.K8sAuditPermissionDeniedParserTailRef, "aks")
+
+// ControlPlaneComponentLogQueryTaskID is the task ID to query the control plane component logs other than audit logs.
+var ControlPlaneComponentLogQueryTaskID = taskid.NewDefaultImplementationID[[]*log.Log](AKSTaskPrefix + "control-plane-component-log-query")
+
+// ControlPlaneComponentLogFieldSetReaderTaskID is the task ID to read the fieldset of control plane component logs.
+var ControlPlaneComponentLogFieldSetReaderTaskID = taskid.NewDefaultImplementationID[[]*log.Log](AKSTaskPrefix + "control-plane-component-log-fieldset-reader")
+
+// ControlPlaneComponentLogIngesterTaskID is the task ID to finalize the control plane component logs to be included in the final output.
+var ControlPlaneComponentLogIngesterTaskID = taskid.NewDefaultImplementationID[[]*log.Log](AKSTaskPrefix + "control-plane-component-log-ingester")
+
+// ControlPlaneComponentLogGrouperTaskID is the task ID to group control plane component logs by the component.
+var ControlPlaneComponentLogGrouperTaskID = taskid.NewDefaultImplementationID[inspectiontaskbase.LogGroupMap](AKSTaskPrefix + "control-plane-component-log-grouper")
+
+// ControlPlaneComponentLogToTimelineMapperTaskID is the task ID to add events of control plane component logs on the timelines.
+var ControlPlaneComponentLogToTimelineMapperTaskID = taskid.NewDefaultImplementationID[struct{}](AKSTaskPrefix + "control-plane-component-log-timeline-mapper")
+
+// ControlPlaneComponentLogTailTaskID is the task ID of the feature task to parse control plane component logs.
+var ControlPlaneComponentLogTailTaskID = taskid.NewDefaultImplementationID[struct{}](AKSTaskPrefix + "control-plane-component-log-tail")
+
+// ContainerLogQueryTaskID is the task ID to query container stdout/stderr logs from the ContainerLogV2 table.
+var ContainerLogQueryTaskID = taskid.NewDefaultImplementationID[[]*log.Log](AKSTaskPrefix + "container-log-query")
+
+// ContainerLogFieldSetReaderTaskID is the task ID to read the fieldset of container logs.
+var ContainerLogFieldSetReaderTaskID = taskid.NewDefaultImplementationID[[]*log.Log](AKSTaskPrefix + "container-log-fieldset-reader")
+
+// ContainerLogIngesterTaskID is the task ID to finalize the container logs to be included in the final output.
+var ContainerLogIngesterTaskID = taskid.NewDefaultImplementationID[[]*log.Log](AKSTaskPrefix + "container-log-ingester")
+
+// ContainerLogGrouperTaskID is the task ID to group container logs by the container.
+var ContainerLogGrouperTaskID = taskid.NewDefaultImplementationID[inspectiontaskbase.LogGroupMap](AKSTaskPrefix + "container-log-grouper")
+
+// ContainerLogToTimelineMapperTaskID is the task ID to add events of container logs on the container timelines.
+var ContainerLogToTimelineMapperTaskID = taskid.NewDefaultImplementationID[struct{}](AKSTaskPrefix + "container-log-timeline-mapper")
@@ -0,0 +1,105 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureclusteraks_impl
+
+import (
+	"context"
+
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	azureclusteraks_contract "github.com/kyasbal/khi/pkg/task/inspection/azureclusteraks/contract"
+	commonlogk8sauditv2_contract "github.com/kyasbal/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	commontimerange_contract "github.com/kyasbal/khi/pkg/task/inspection/commontimerange/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// AuditLogQueryTask reads kube-apiserver audit logs from the AzureDiagnostics table in place of the uploaded audit log files.
+// The audit logs of AKS are the same JSON objects written by kube-apiserver, thus the filters and the parsers for OSS clusters process them.
+var AuditLogQueryTask = inspectiontaskbase.NewProgressReportableInspectionTask(
+	azureclusteraks_contract.AuditLogQueryTaskID,
+	[]taskid.UntypedTaskReference{
+		azureclusteraks_contract.LogAnalyticsClientTaskID.Ref(),
+		azureclusteraks_contract.InputWorkspaceIDTaskID.Ref(),
+		azureclusteraks_contract.ClusterResourceIDTaskID.Ref(),
+		commontimerange_contract.InputStartTimeTaskID.Ref(),
+		commontimerange_contract.InputEndTimeTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, tp *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, error) {
+		if taskMode == inspectioncore_contract.TaskModeDryRun {
+			return []*log.Log{}, nil
+		}
+		client := coretask.GetTaskResult(ctx, azureclusteraks_contract.LogAnalyticsClientTaskID.Ref())
+		workspaceID := coretask.GetTaskResult(ctx, azureclusteraks_contract.InputWorkspaceIDTaskID.Ref())
+		clusterResourceID := coretask.GetTaskResult(ctx, azureclusteraks_contract.ClusterResourceIDTaskID.Ref())
+		startTime := coretask.GetTaskResult(ctx, commontimerange_contract.InputStartTimeTaskID.Ref())
+		endTime := coretask.GetTaskResult(ctx, commontimerange_contract.InputEndTimeTaskID.Ref())
+
+		logs, err := queryLogAnalytics(ctx, client, workspaceID, azureclusteraks_contract.AuditLogQuery(clusterResourceID), "log_s", startTime, endTime,
+			"Send the kube-audit category of the cluster to the workspace in the Azure diagnostics mode of the diagnostic settings", tp, &ossclusterk8s_contract.OSSK8sAuditLogCommonFieldSetReader{}, enum.LogTypeAudit)
+		if err != nil {
+			return nil, err
+		}
+
+		var result []*log.Log
+		seenAuditIDs := map[string]struct{}{}
+		for _, l := range logs {
+			if !commonlogk8sauditv2_contract.IsParsableAuditLogStage(l) {
+				continue
+			}
+			// The same audit event is found in both kube-audit and kube-audit-admin categories when both of them are enabled.
+			auditID := l.ReadStringOrDefault("auditID", "")
+			if _, found := seenAuditIDs[auditID]; found && auditID != "" {
+				continue
+			}
+			seenAuditIDs[auditID] = struct{}{}
+			result = append(result, l)
+		}
+		return result, nil
+	},
+	coretask.WithSelectionPriority(1000),
+	inspectioncore_contract.InspectionTypeLabel(azureclusteraks_contract.InspectionTypeID),
+)
+
+var AKSK8sAuditLogFieldExtractorTask = inspectiontaskbase.NewFieldSetReadTask(
+	azureclusteraks_contract.AKSK8sAuditLogProviderTaskID,
+	ossclusterk8s_contract.NonEventAuditLogFilterTaskID.Ref(),
+	[]log.FieldSetReader{(&ossclusterk8s_contract.OSSK8sAuditLogFieldSetReader{})},
+	inspectioncore_contract.InspectionTypeLabel(azureclusteraks_contract.InspectionTypeID),
+)
+
+var AKSK8sAuditLogParserTailTask = inspectiontaskbase.NewInspectionTask(
+	azureclusteraks_contract.AKSK8sAuditLogParserTailTaskID,
+	commonlogk8sauditv2_contract.ParserTailTaskDependencies(),
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (struct{}, error) {
+		return struct{}{}, nil
+	},
+	inspectioncore_contract.FeatureTaskLabel("Kubernetes Audit Log(v3)", `Gather kube-apiserver audit logs sent to the Log Analytics workspace by the diagnostic settings and visualize resource modifications.`, enum.LogTypeAudit, 1001, true, azureclusteraks_contract.InspectionTypeID), coretask.NewSubsequentTaskRefsTaskLabel(inspectioncore_contract.SerializerTaskID.Ref()),
+)
+
+var AKSK8sAuditPermissionDeniedParserTailTask = inspectiontaskbase.NewInspectionTask(
+	azureclusteraks_contract.AKSK8sAuditPermissionDeniedParserTailTaskID,
+	[]taskid.UntypedTaskReference{
+		commonlogk8sauditv2_contract.PermissionDeniedLogToTimelineMapperTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (struct{}, error) {
+		return struct{}{}, nil
+	},
+	inspectioncore_contract.FeatureTaskLabel("Kubernetes Permission Denials", `Gather kube-apiserver audit logs of requests denied by the authorizer and show them on timelines grouped by the principal and the resource.`, enum.LogTypeAudit, 1002, false, azureclusteraks_contract.InspectionTypeID), coretask.NewSubsequentTaskRefsTaskLabel(inspectioncore_contract.SerializerTaskID.Ref()),
+)
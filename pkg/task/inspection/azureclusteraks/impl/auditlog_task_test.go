@@ -0,0 +1,105 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureclusteraks_impl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/api/azure"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	"github.com/kyasbal/khi/pkg/model/log"
+	azureclusteraks_contract "github.com/kyasbal/khi/pkg/task/inspection/azureclusteraks/contract"
+	commontimerange_contract "github.com/kyasbal/khi/pkg/task/inspection/commontimerange/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// fakeLogAnalyticsAPI returns the rows whose TimeGenerated is in the queried time range.
+type fakeLogAnalyticsAPI struct {
+	columns []azure.Column
+	rows    [][]any
+	queries []*azure.QueryInput
+}
+
+// Query implements azure.LogAnalyticsAPI.
+func (f *fakeLogAnalyticsAPI) Query(ctx context.Context, input *azure.QueryInput) (*azure.QueryOutput, error) {
+	f.queries = append(f.queries, input)
+	table := &azure.Table{Name: "PrimaryResult", Columns: f.columns}
+	for _, row := range f.rows {
+		timeGenerated, err := time.Parse(time.RFC3339, row[0].(string))
+		if err != nil {
+			return nil, err
+		}
+		if !timeGenerated.Before(input.StartTime) && timeGenerated.Before(input.EndTime) {
+			table.Rows = append(table.Rows, row)
+		}
+	}
+	return &azure.QueryOutput{Tables: []*azure.Table{table}}, nil
+}
+
+var _ azure.LogAnalyticsAPI = (*fakeLogAnalyticsAPI)(nil)
+
+func TestAuditLogQueryTask(t *testing.T) {
+	startTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	endTime := startTime.Add(90 * time.Minute)
+	api := &fakeLogAnalyticsAPI{
+		columns: []azure.Column{
+			{Name: "TimeGenerated", Type: "datetime"},
+			{Name: "_ItemId", Type: "string"},
+			{Name: "Category", Type: "string"},
+			{Name: "log_s", Type: "string"},
+		},
+		rows: [][]any{
+			{"2025-01-01T01:10:00Z", "item-3", "kube-audit", `{"auditID":"audit-2","stage":"ResponseComplete","stageTimestamp":"2025-01-01T01:10:00Z"}`},
+			{"2025-01-01T00:10:00Z", "item-1", "kube-audit", `{"auditID":"audit-1","stage":"ResponseComplete","stageTimestamp":"2025-01-01T00:10:00Z"}`},
+			{"2025-01-01T00:10:00Z", "item-2", "kube-audit-admin", `{"auditID":"audit-1","stage":"ResponseComplete","stageTimestamp":"2025-01-01T00:10:00Z"}`},
+			{"2025-01-01T00:20:00Z", "item-4", "kube-audit", `{"auditID":"audit-3","stage":"RequestReceived","stageTimestamp":"2025-01-01T00:20:00Z"}`},
+		},
+	}
+
+	ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+	logs, _, err := inspectiontest.RunInspectionTask(ctx, AuditLogQueryTask, inspectioncore_contract.TaskModeRun, map[string]any{},
+		tasktest.NewTaskDependencyValuePair[azure.LogAnalyticsAPI](azureclusteraks_contract.LogAnalyticsClientTaskID.Ref(), api),
+		tasktest.NewTaskDependencyValuePair(azureclusteraks_contract.InputWorkspaceIDTaskID.Ref(), "workspace"),
+		tasktest.NewTaskDependencyValuePair(azureclusteraks_contract.ClusterResourceIDTaskID.Ref(), "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ContainerService/managedClusters/my-cluster"),
+		tasktest.NewTaskDependencyValuePair(commontimerange_contract.InputStartTimeTaskID.Ref(), startTime),
+		tasktest.NewTaskDependencyValuePair(commontimerange_contract.InputEndTimeTaskID.Ref(), endTime),
+	)
+	if err != nil {
+		t.Fatalf("AuditLogQueryTask returned an unexpected error: %v", err)
+	}
+
+	var gotWindows [][2]time.Time
+	for _, query := range api.queries {
+		gotWindows = append(gotWindows, [2]time.Time{query.StartTime, query.EndTime})
+	}
+	wantWindows := [][2]time.Time{
+		{startTime, startTime.Add(time.Hour)},
+		{startTime.Add(time.Hour), endTime},
+	}
+	if diff := cmp.Diff(wantWindows, gotWindows); diff != "" {
+		t.Errorf("queried time windows mismatch (-want +got):\n%s", diff)
+	}
+	var gotAuditIDs []string
+	for _, l := range logs {
+		gotAuditIDs = append(gotAuditIDs, log.MustGetFieldSet(l, &log.CommonFieldSet{}).DisplayID)
+	}
+	if diff := cmp.Diff([]string{"audit-1", "audit-2"}, gotAuditIDs); diff != "" {
+		t.Errorf("audit logs mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureclusteraks_impl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyasbal/khi/pkg/model/history"
+	azureclusteraks_contract "github.com/kyasbal/khi/pkg/task/inspection/azureclusteraks/contract"
+	"github.com/kyasbal/khi/pkg/testutil/testchangeset"
+)
+
+func TestContainerLogToTimelineMapper(t *testing.T) {
+	l, err := azureclusteraks_contract.NewLogFromRecord(map[string]any{
+		"TimeGenerated": "2025-01-01T00:00:00Z",
+		"_ItemId":       "item-1",
+		"PodNamespace":  "default",
+		"PodName":       "nginx",
+		"ContainerName": "server",
+		"LogMessage":    "listening on :8080",
+	}, "LogMessage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = l.SetFieldSetReader(&azureclusteraks_contract.AKSContainerLogFieldSetReader{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cs := history.NewChangeSet(l)
+	_, err = (&containerLogToTimelineMapperTaskSetting{}).ProcessLogByGroup(context.Background(), l, cs, nil, struct{}{})
+	if err != nil {
+		t.Fatalf("ProcessLogByGroup() returned an unexpected error: %v", err)
+	}
+	asserters := []testchangeset.ChangeSetAsserter{
+		&testchangeset.HasEvent{ResourcePath: "core/v1#pod#default#nginx#server"},
+		&testchangeset.HasLogSummary{WantLogSummary: "listening on :8080"},
+	}
+	for _, asserter := range asserters {
+		asserter.Assert(t, cs)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureclusteraks_impl
+
+import (
+	"context"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/logutil"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/history"
+	"github.com/kyasbal/khi/pkg/model/log"
+	azureclusteraks_contract "github.com/kyasbal/khi/pkg/task/inspection/azureclusteraks/contract"
+	commontimerange_contract "github.com/kyasbal/khi/pkg/task/inspection/commontimerange/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// ControlPlaneComponentLogQueryTask reads the control plane component logs other than audit logs from the AzureDiagnostics table.
+var ControlPlaneComponentLogQueryTask = inspectiontaskbase.NewProgressReportableInspectionTask(
+	azureclusteraks_contract.ControlPlaneComponentLogQueryTaskID,
+	[]taskid.UntypedTaskReference{
+		azureclusteraks_contract.LogAnalyticsClientTaskID.Ref(),
+		azureclusteraks_contract.InputWorkspaceIDTaskID.Ref(),
+		azureclusteraks_contract.InputClusterNameTaskID.Ref(),
+		azureclusteraks_contract.ClusterResourceIDTaskID.Ref(),
+		commontimerange_contract.InputStartTimeTaskID.Ref(),
+		commontimerange_contract.InputEndTimeTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, tp *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, error) {
+		if taskMode == inspectioncore_contract.TaskModeDryRun {
+			return []*log.Log{}, nil
+		}
+		client := coretask.GetTaskResult(ctx, azureclusteraks_contract.LogAnalyticsClientTaskID.Ref())
+		workspaceID := coretask.GetTaskResult(ctx, azureclusteraks_contract.InputWorkspaceIDTaskID.Ref())
+		clusterName := coretask.GetTaskResult(ctx, azureclusteraks_contract.InputClusterNameTaskID.Ref())
+		clusterResourceID := coretask.GetTaskResult(ctx, azureclusteraks_contract.ClusterResourceIDTaskID.Ref())
+		startTime := coretask.GetTaskResult(ctx, commontimerange_contract.InputStartTimeTaskID.Ref())
+		endTime := coretask.GetTaskResult(ctx, commontimerange_contract.InputEndTimeTaskID.Ref())
+
+		return queryLogAnalytics(ctx, client, workspaceID, azureclusteraks_contract.ControlPlaneComponentLogQuery(clusterResourceID, clusterName), "log_s", startTime, endTime,
+			"Send the control plane component categories of the cluster to the workspace in the Azure diagnostics mode of the diagnostic settings", tp, &azureclusteraks_contract.LogAnalyticsRecordCommonFieldSetReader{}, enum.LogTypeControlPlaneComponent)
+	},
+)
+
+var ControlPlaneComponentLogFieldSetReaderTask = inspectiontaskbase.NewFieldSetReadTask(
+	azureclusteraks_contract.ControlPlaneComponentLogFieldSetReaderTaskID,
+	azureclusteraks_contract.ControlPlaneComponentLogQueryTaskID.Ref(),
+	[]log.FieldSetReader{
+		&azureclusteraks_contract.AKSControlPlaneComponentFieldSetReader{
+			StructuredLogParser: logutil.NewMultiTextLogParser(
+				logutil.NewKLogTextParser(true),
+				logutil.NewJsonlTextParser(),
+				&logutil.FallbackRawTextLogParser{},
+			),
+		},
+	},
+)
+
+// ControlPlaneComponentLogIngesterTask serializes logs to history. No control plane component logs are discarded.
+var ControlPlaneComponentLogIngesterTask = inspectiontaskbase.NewLogIngesterTask(azureclusteraks_contract.ControlPlaneComponentLogIngesterTaskID, azureclusteraks_contract.ControlPlaneComponentLogFieldSetReaderTaskID.Ref())
+
+var ControlPlaneComponentLogGrouperTask = inspectiontaskbase.NewLogGrouperTask(
+	azureclusteraks_contract.ControlPlaneComponentLogGrouperTaskID,
+	azureclusteraks_contract.ControlPlaneComponentLogFieldSetReaderTaskID.Ref(),
+	func(ctx context.Context, l *log.Log) string {
+		componentFieldSet, err := log.GetFieldSet(l, &azureclusteraks_contract.AKSControlPlaneComponentFieldSet{})
+		if err != nil {
+			return ""
+		}
+		return componentFieldSet.ComponentName
+	},
+)
+
+var ControlPlaneComponentLogToTimelineMapperTask = inspectiontaskbase.NewLogToTimelineMapperTask[struct{}](azureclusteraks_contract.ControlPlaneComponentLogToTimelineMapperTaskID, &controlPlaneComponentLogToTimelineMapperTaskSetting{})
+
+type controlPlaneComponentLogToTimelineMapperTaskSetting struct {
+}
+
+// Dependencies implements inspectiontaskbase.LogToTimelineMapper.
+func (c *controlPlaneComponentLogToTimelineMapperTaskSetting) Dependencies() []taskid.UntypedTaskReference {
+	return []taskid.UntypedTaskReference{}
+}
+
+// GroupedLogTask implements inspectiontaskbase.LogToTimelineMapper.
+func (c *controlPlaneComponentLogToTimelineMapperTaskSetting) GroupedLogTask() taskid.TaskReference[inspectiontaskbase.LogGroupMap] {
+	return azureclusteraks_contract.ControlPlaneComponentLogGrouperTaskID.Ref()
+}
+
+// LogIngesterTask implements inspectiontaskbase.LogToTimelineMapper.
+func (c *controlPlaneComponentLogToTimelineMapperTaskSetting) LogIngesterTask() taskid.TaskReference[[]*log.Log] {
+	return azureclusteraks_contract.ControlPlaneComponentLogIngesterTaskID.Ref()
+}
+
+// ProcessLogByGroup implements inspectiontaskbase.LogToTimelineMapper.
+func (c *controlPlaneComponentLogToTimelineMapperTaskSetting) ProcessLogByGroup(ctx context.Context, l *log.Log, cs *history.ChangeSet, builder *history.Builder, prevGroupData struct{}) (struct{}, error) {
+	componentFieldSet, err := log.GetFieldSet(l, &azureclusteraks_contract.AKSControlPlaneComponentFieldSet{})
+	if err != nil {
+		return struct{}{}, err
+	}
+	summary, err := componentFieldSet.Message.MainMessage()
+	if err != nil || summary == "" {
+		summary = componentFieldSet.Message.Raw()
+	}
+	if severity, err := componentFieldSet.Message.Severity(); err == nil {
+		cs.SetLogSeverity(severity)
+	}
+	cs.SetLogSummary(summary)
+	cs.AddEvent(componentFieldSet.ResourcePath())
+	return struct{}{}, nil
+}
+
+var _ inspectiontaskbase.LogToTimelineMapper[struct{}] = (*controlPlaneComponentLogToTimelineMapperTaskSetting)(nil)
+
+var ControlPlaneComponentLogTailTask = inspectiontaskbase.NewInspectionTask(
+	azureclusteraks_contract.ControlPlaneComponentLogTailTaskID,
+	[]taskid.UntypedTaskReference{
+		azureclusteraks_contract.ControlPlaneComponentLogToTimelineMapperTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (struct{}, error) {
+		return struct{}{}, nil
+	},
+	inspectioncore_contract.FeatureTaskLabel("Kubernetes Control plane component logs", `Gather control plane component logs (kube-apiserver, kube-controller-manager, kube-scheduler, cluster-autoscaler, cloud-controller-manager and guard) sent to the Log Analytics workspace by the diagnostic settings.`, enum.LogTypeControlPlaneComponent, 9000, false, azureclusteraks_contract.InspectionTypeID),
+)
@@ -0,0 +1,90 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureclusteraks_impl
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/kyasbal/khi/pkg/api/azure"
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	azureclusteraks_contract "github.com/kyasbal/khi/pkg/task/inspection/azureclusteraks/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// InputTenantIDTask defines a form task to input the Microsoft Entra ID tenant ID of the service principal.
+var InputTenantIDTask = formtask.NewTextFormTaskBuilder(azureclusteraks_contract.InputTenantIDTaskID, 0, "Tenant ID").
+	WithPosition(inspectionmetadata.FormPosition{Section: azureclusteraks_contract.FormSectionAzureCredentials}).
+	WithPlaceholder("e.g. 00000000-0000-0000-0000-000000000000").
+	WithDescription("The Microsoft Entra ID tenant of the service principal. The service principal needs the `Log Analytics Reader` role on the workspace. Leave the credential fields empty to use `AZURE_TENANT_ID`, `AZURE_CLIENT_ID` and `AZURE_CLIENT_SECRET` environment variables of the KHI server.").
+	WithMarkdown().
+	WithDefaultValueFunc(previousValueOrEmpty).
+	WithConverter(trimSpace).
+	Build()
+
+// InputClientIDTask defines a form task to input the client ID of the service principal.
+var InputClientIDTask = formtask.NewTextFormTaskBuilder(azureclusteraks_contract.InputClientIDTaskID, 0, "Client ID").
+	WithPosition(inspectionmetadata.FormPosition{Section: azureclusteraks_contract.FormSectionAzureCredentials, After: []string{azureclusteraks_contract.InputTenantIDTaskID.ReferenceIDString()}}).
+	WithPlaceholder("e.g. 00000000-0000-0000-0000-000000000000").
+	WithDescription("The application (client) ID of the service principal.").
+	WithDefaultValueFunc(previousValueOrEmpty).
+	WithConverter(trimSpace).
+	Build()
+
+// InputClientSecretTask defines a secret form task to input the client secret of the service principal.
+var InputClientSecretTask = formtask.NewSecretFormTaskBuilder(azureclusteraks_contract.InputClientSecretTaskID, 0, "Client secret").
+	WithPosition(inspectionmetadata.FormPosition{Section: azureclusteraks_contract.FormSectionAzureCredentials, After: []string{azureclusteraks_contract.InputClientIDTaskID.ReferenceIDString()}}).
+	WithDescription("The client secret of the service principal.").
+	WithConverter(trimSpace).
+	Build()
+
+// CredentialsTask resolves the service principal credentials from the credential forms. The environment variables are used when the client ID is not given.
+var CredentialsTask = inspectiontaskbase.NewInspectionTask(azureclusteraks_contract.CredentialsTaskID, []taskid.UntypedTaskReference{
+	azureclusteraks_contract.InputTenantIDTaskID.Ref(),
+	azureclusteraks_contract.InputClientIDTaskID.Ref(),
+	azureclusteraks_contract.InputClientSecretTaskID.Ref(),
+}, func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (azure.ClientCredentials, error) {
+	credentials := azure.ClientCredentials{
+		TenantID:     coretask.GetTaskResult(ctx, azureclusteraks_contract.InputTenantIDTaskID.Ref()),
+		ClientID:     coretask.GetTaskResult(ctx, azureclusteraks_contract.InputClientIDTaskID.Ref()),
+		ClientSecret: coretask.GetTaskResult(ctx, azureclusteraks_contract.InputClientSecretTaskID.Ref()),
+	}
+	if credentials.ClientID == "" {
+		credentials = azure.ClientCredentials{
+			TenantID:     os.Getenv("AZURE_TENANT_ID"),
+			ClientID:     os.Getenv("AZURE_CLIENT_ID"),
+			ClientSecret: os.Getenv("AZURE_CLIENT_SECRET"),
+		}
+	}
+	if taskMode == inspectioncore_contract.TaskModeRun && (credentials.TenantID == "" || credentials.ClientID == "" || credentials.ClientSecret == "") {
+		return azure.ClientCredentials{}, fmt.Errorf("Azure credentials are not given. Fill the tenant ID, the client ID and the client secret, or set AZURE_TENANT_ID, AZURE_CLIENT_ID and AZURE_CLIENT_SECRET environment variables")
+	}
+	return credentials, nil
+})
+
+// LogAnalyticsClientTask provides the client of Log Analytics query API authenticated as the service principal.
+var LogAnalyticsClientTask = inspectiontaskbase.NewInspectionTask(azureclusteraks_contract.LogAnalyticsClientTaskID, []taskid.UntypedTaskReference{
+	azureclusteraks_contract.CredentialsTaskID.Ref(),
+}, func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (azure.LogAnalyticsAPI, error) {
+	credentials := coretask.GetTaskResult(ctx, azureclusteraks_contract.CredentialsTaskID.Ref())
+	tokenSource := azure.NewClientCredentialsTokenSource(credentials, azure.LogAnalyticsScope, http.DefaultClient)
+	return azure.NewLogAnalyticsClient(tokenSource, http.DefaultClient), nil
+})
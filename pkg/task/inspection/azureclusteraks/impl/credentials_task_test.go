@@ -0,0 +1,97 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureclusteraks_impl
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/api/azure"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	azureclusteraks_contract "github.com/kyasbal/khi/pkg/task/inspection/azureclusteraks/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+func TestCredentialsTask(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		mode         inspectioncore_contract.InspectionTaskModeType
+		tenantID     string
+		clientID     string
+		clientSecret string
+		env          map[string]string
+		want         azure.ClientCredentials
+		wantErr      bool
+	}{
+		{
+			desc:         "credentials from the forms",
+			mode:         inspectioncore_contract.TaskModeRun,
+			tenantID:     "form-tenant",
+			clientID:     "form-client",
+			clientSecret: "form-secret",
+			env: map[string]string{
+				"AZURE_TENANT_ID":     "env-tenant",
+				"AZURE_CLIENT_ID":     "env-client",
+				"AZURE_CLIENT_SECRET": "env-secret",
+			},
+			want: azure.ClientCredentials{TenantID: "form-tenant", ClientID: "form-client", ClientSecret: "form-secret"},
+		},
+		{
+			desc: "credentials from the environment variables",
+			mode: inspectioncore_contract.TaskModeRun,
+			env: map[string]string{
+				"AZURE_TENANT_ID":     "env-tenant",
+				"AZURE_CLIENT_ID":     "env-client",
+				"AZURE_CLIENT_SECRET": "env-secret",
+			},
+			want: azure.ClientCredentials{TenantID: "env-tenant", ClientID: "env-client", ClientSecret: "env-secret"},
+		},
+		{
+			desc:     "missing client secret in run mode",
+			mode:     inspectioncore_contract.TaskModeRun,
+			tenantID: "form-tenant",
+			clientID: "form-client",
+			wantErr:  true,
+		},
+		{
+			desc: "missing credentials in dry run mode",
+			mode: inspectioncore_contract.TaskModeDryRun,
+			want: azure.ClientCredentials{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			for _, name := range []string{"AZURE_TENANT_ID", "AZURE_CLIENT_ID", "AZURE_CLIENT_SECRET"} {
+				t.Setenv(name, tc.env[name])
+			}
+			ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+			got, _, err := inspectiontest.RunInspectionTask(ctx, CredentialsTask, tc.mode, map[string]any{},
+				tasktest.NewTaskDependencyValuePair(azureclusteraks_contract.InputTenantIDTaskID.Ref(), tc.tenantID),
+				tasktest.NewTaskDependencyValuePair(azureclusteraks_contract.InputClientIDTaskID.Ref(), tc.clientID),
+				tasktest.NewTaskDependencyValuePair(azureclusteraks_contract.InputClientSecretTaskID.Ref(), tc.clientSecret),
+			)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("CredentialsTask error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("CredentialsTask mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureclusteraks_impl
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	azureclusteraks_contract "github.com/kyasbal/khi/pkg/task/inspection/azureclusteraks/contract"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+var guidValidator = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+var resourceGroupValidator = regexp.MustCompile(`^[-\w.()]{1,90}$`)
+
+var clusterNameValidator = regexp.MustCompile(`^[0-9A-Za-z][0-9A-Za-z_-]{0,62}$`)
+
+// previousValueOrEmpty is the default value function using the last value given to the form.
+func previousValueOrEmpty(ctx context.Context, previousValues []string) (string, error) {
+	if len(previousValues) > 0 {
+		return previousValues[0], nil
+	}
+	return "", nil
+}
+
+// trimSpace is the converter to remove spaces around the given value.
+func trimSpace(ctx context.Context, value string) (string, error) {
+	return strings.TrimSpace(value), nil
+}
+
+// InputSubscriptionIDTask defines a form task to input the Azure subscription ID of the AKS cluster.
+var InputSubscriptionIDTask = formtask.NewTextFormTaskBuilder(azureclusteraks_contract.InputSubscriptionIDTaskID, 0, "Subscription ID").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionResourceIdentifier}).
+	WithPlaceholder("e.g. 00000000-0000-0000-0000-000000000000").
+	WithDescription("The ID of the Azure subscription containing the AKS cluster.").
+	WithDefaultValueFunc(previousValueOrEmpty).
+	WithValidator(func(ctx context.Context, value string) (string, error) {
+		if !guidValidator.MatchString(strings.TrimSpace(value)) {
+			return "Subscription ID must be a GUID like `00000000-0000-0000-0000-000000000000`", nil
+		}
+		return "", nil
+	}).
+	WithConverter(trimSpace).
+	Build()
+
+// InputResourceGroupTask defines a form task to input the resource group of the AKS cluster.
+var InputResourceGroupTask = formtask.NewTextFormTaskBuilder(azureclusteraks_contract.InputResourceGroupTaskID, 0, "Resource group").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionResourceIdentifier, After: []string{azureclusteraks_contract.InputSubscriptionIDTaskID.ReferenceIDString()}}).
+	WithPlaceholder("e.g. my-resource-group").
+	WithDescription("The name of the resource group containing the AKS cluster.").
+	WithDefaultValueFunc(previousValueOrEmpty).
+	WithValidator(func(ctx context.Context, value string) (string, error) {
+		value = strings.TrimSpace(value)
+		if !resourceGroupValidator.MatchString(value) || strings.HasSuffix(value, ".") {
+			return "Resource group must consist of alphanumerics, underscores, parentheses, hyphens and periods, must not end with a period and must be 90 characters or less", nil
+		}
+		return "", nil
+	}).
+	WithConverter(trimSpace).
+	Build()
+
+// InputClusterNameTask defines a form task to input the name of the AKS cluster.
+var InputClusterNameTask = formtask.NewTextFormTaskBuilder(azureclusteraks_contract.InputClusterNameTaskID, 0, "Cluster name").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionResourceIdentifier, After: []string{azureclusteraks_contract.InputResourceGroupTaskID.ReferenceIDString()}}).
+	WithPlaceholder("e.g. my-cluster").
+	WithDescription("The name of the AKS cluster.").
+	WithDefaultValueFunc(previousValueOrEmpty).
+	WithValidator(func(ctx context.Context, value string) (string, error) {
+		if !clusterNameValidator.MatchString(strings.TrimSpace(value)) {
+			return "Cluster name must match `^[0-9A-Za-z][0-9A-Za-z_-]{0,62}$`", nil
+		}
+		return "", nil
+	}).
+	WithConverter(trimSpace).
+	Build()
+
+// InputWorkspaceIDTask defines a form task to input the workspace ID of the Log Analytics workspace receiving the logs of the cluster.
+var InputWorkspaceIDTask = formtask.NewTextFormTaskBuilder(azureclusteraks_contract.InputWorkspaceIDTaskID, 0, "Log Analytics workspace ID").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionResourceIdentifier, After: []string{azureclusteraks_contract.InputClusterNameTaskID.ReferenceIDString()}}).
+	WithPlaceholder("e.g. 00000000-0000-0000-0000-000000000000").
+	WithDescription("The workspace ID of the Log Analytics workspace receiving the logs of the cluster. Control plane logs are read from the `AzureDiagnostics` table written by the diagnostic settings of the cluster and container logs are read from the `ContainerLogV2` table written by Container insights.").
+	WithMarkdown().
+	WithDefaultValueFunc(previousValueOrEmpty).
+	WithValidator(func(ctx context.Context, value string) (string, error) {
+		if !guidValidator.MatchString(strings.TrimSpace(value)) {
+			return "Workspace ID must be a GUID shown as `Workspace ID` on the overview page of the Log Analytics workspace", nil
+		}
+		return "", nil
+	}).
+	WithConverter(trimSpace).
+	Build()
+
+// ClusterResourceIDTask builds the Azure resource ID of the cluster used to filter the records in the workspace.
+var ClusterResourceIDTask = inspectiontaskbase.NewInspectionTask(azureclusteraks_contract.ClusterResourceIDTaskID, []taskid.UntypedTaskReference{
+	azureclusteraks_contract.InputSubscriptionIDTaskID.Ref(),
+	azureclusteraks_contract.InputResourceGroupTaskID.Ref(),
+	azureclusteraks_contract.InputClusterNameTaskID.Ref(),
+}, func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (string, error) {
+	subscriptionID := coretask.GetTaskResult(ctx, azureclusteraks_contract.InputSubscriptionIDTaskID.Ref())
+	resourceGroup := coretask.GetTaskResult(ctx, azureclusteraks_contract.InputResourceGroupTaskID.Ref())
+	clusterName := coretask.GetTaskResult(ctx, azureclusteraks_contract.InputClusterNameTaskID.Ref())
+	return azureclusteraks_contract.ClusterResourceID(subscriptionID, resourceGroup, clusterName), nil
+})
@@ -0,0 +1,82 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureclusteraks_impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/api/azure"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	azureclusteraks_contract "github.com/kyasbal/khi/pkg/task/inspection/azureclusteraks/contract"
+)
+
+// queryWindow is the duration of the time range queried at once.
+// The query API returns at most 500,000 records for a query, thus a long time range is split into multiple queries.
+const queryWindow = time.Hour
+
+// queryLogAnalytics runs the query for each window in the time range and converts the records to logs sorted by their timestamps.
+// tableHint is shown in the error when the table doesn't exist in the workspace to tell how to enable sending the logs.
+func queryLogAnalytics(ctx context.Context, api azure.LogAnalyticsAPI, workspaceID string, query string, messageColumn string, startTime, endTime time.Time, tableHint string, tp *inspectionmetadata.TaskProgressMetadata, commonFieldSetReader log.FieldSetReader, logType enum.LogType) ([]*log.Log, error) {
+	var logs []*log.Log
+	windowCount := int((endTime.Sub(startTime) + queryWindow - 1) / queryWindow)
+	for i, windowStart := 0, startTime; windowStart.Before(endTime); i, windowStart = i+1, windowStart.Add(queryWindow) {
+		windowEnd := windowStart.Add(queryWindow)
+		if windowEnd.After(endTime) {
+			windowEnd = endTime
+		}
+		tp.Update(float32(i)/float32(windowCount), fmt.Sprintf("%d logs fetched", len(logs)))
+		output, err := api.Query(ctx, &azure.QueryInput{
+			WorkspaceID: workspaceID,
+			Query:       query,
+			StartTime:   windowStart,
+			EndTime:     windowEnd,
+		})
+		if err != nil {
+			var apiErr *azure.APIError
+			if errors.As(err, &apiErr) && apiErr.Code == "BadArgumentError" {
+				return nil, fmt.Errorf("failed to query the workspace %s. %s: %w", workspaceID, tableHint, err)
+			}
+			return nil, fmt.Errorf("failed to query the workspace %s: %w", workspaceID, err)
+		}
+		if output.Error != nil {
+			slog.WarnContext(ctx, "the query returned partial results. Some logs may be missing", "workspace", workspaceID, "start", windowStart, "end", windowEnd, "code", output.Error.Code, "message", output.Error.Message)
+		}
+		for _, table := range output.Tables {
+			for _, record := range table.Records() {
+				l, err := azureclusteraks_contract.NewLogFromRecord(record, messageColumn)
+				if err != nil {
+					return nil, err
+				}
+				err = l.SetFieldSetReader(commonFieldSetReader)
+				if err != nil {
+					return nil, err
+				}
+				l.LogType = logType
+				logs = append(logs, l)
+			}
+		}
+	}
+	slices.SortStableFunc(logs, func(a, b *log.Log) int {
+		return log.MustGetFieldSet(a, &log.CommonFieldSet{}).Timestamp.Compare(log.MustGetFieldSet(b, &log.CommonFieldSet{}).Timestamp)
+	})
+	return logs, nil
+}
@@ -41,6 +41,8 @@ type K8sAuditLogFieldSet struct {
 	StatusMessage string
 	// IsError is true if the response is an error.
 	IsError bool
+	// IsPermissionDenied is true if the request is denied by the authorizer.
+	IsPermissionDenied bool
 	// Request is the request body.
 	Request *structured.NodeReader
 	// Response is the response body.
@@ -65,4 +67,7 @@ func (k *K8sAuditLogFieldSet) VerbString() string {
 	return enum.RevisionVerbs[k.K8sOperation.Verb].Label
 }
 
+// AuthorizationDecisionForbid is the value of `authorization.k8s.io/decision` audit annotation when the request is denied by the authorizer.
+const AuthorizationDecisionForbid = "forbid"
+
 var _ log.FieldSet = (*K8sAuditLogFieldSet)(nil)
@@ -0,0 +1,49 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commonlogk8sauditv2_contract
+
+import (
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+// ParserTailTaskDependencies returns the references of the mapper and discovery tasks a parser tail task of an audit log source depends on.
+// Each source defines its own tail task with its feature label and lists these tasks as its dependencies to run the whole audit log parsers.
+func ParserTailTaskDependencies() []taskid.UntypedTaskReference {
+	return []taskid.UntypedTaskReference{
+		LogSummaryLogToTimelineMapperTaskID.Ref(),
+		NonSuccessLogLogToTimelineMapperTaskID.Ref(),
+		NamespaceRequestLogToTimelineMapperTaskID.Ref(),
+		ResourceRevisionLogToTimelineMapperTaskID.Ref(),
+		ConditionLogToTimelineMapperTaskID.Ref(),
+		VeleroOperationLogToTimelineMapperTaskID.Ref(),
+		ResourceOwnerReferenceTimelineMapperTaskID.Ref(),
+		PodPhaseLogToTimelineMapperTaskID.Ref(),
+		EndpointResourceLogToTimelineMapperTaskID.Ref(),
+		ContainerLogToTimelineMapperTaskID.Ref(),
+
+		NodeNameDiscoveryTaskID.Ref(),
+		ResourceUIDDiscoveryTaskID.Ref(),
+		ContainerIDDiscoveryTaskID.Ref(),
+		IPLeaseHistoryDiscoveryTaskID.Ref(),
+	}
+}
+
+// IsParsableAuditLogStage returns true when the given audit log is at the stage the audit log parsers process.
+// Sources reading raw kube-apiserver audit events use this to drop the other stages before passing logs to the parsers.
+func IsParsableAuditLogStage(l *log.Log) bool {
+	// TODO: we may need to consider processing logs not with ResponseComplete stage. All logs not on the ResponseComplete stage will be ignored for now.
+	return l.ReadStringOrDefault("stage", "") == "ResponseComplete"
+}
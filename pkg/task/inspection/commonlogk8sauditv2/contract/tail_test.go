@@ -0,0 +1,56 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commonlogk8sauditv2_contract
+
+import (
+	"testing"
+
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+func TestIsParsableAuditLogStage(t *testing.T) {
+	testCases := []struct {
+		name string
+		yaml string
+		want bool
+	}{
+		{
+			name: "ResponseComplete",
+			yaml: `stage: ResponseComplete`,
+			want: true,
+		},
+		{
+			name: "RequestReceived",
+			yaml: `stage: RequestReceived`,
+			want: false,
+		},
+		{
+			name: "without stage",
+			yaml: `verb: get`,
+			want: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			l, err := log.NewLogFromYAMLString(tc.yaml)
+			if err != nil {
+				t.Fatalf("failed to parse the test log: %v", err)
+			}
+			if got := IsParsableAuditLogStage(l); got != tc.want {
+				t.Errorf("IsParsableAuditLogStage() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -31,6 +31,9 @@ var K8sAuditLogProviderRef = taskid.NewTaskReference[[]*log.Log](TaskIDPrefix +
 // K8sAuditLogParserTailRef is the task reference for the task to depend all enabled k8s audit log parsing sub tasks.
 var K8sAuditLogParserTailRef = taskid.NewTaskReference[struct{}](TaskIDPrefix + "k8s-auditlog-parser-tail")
 
+// K8sAuditPermissionDeniedParserTailRef is the reference to the last task of the feature visualizing requests denied by the authorizer. Each log source provides its implementation.
+var K8sAuditPermissionDeniedParserTailRef = taskid.NewTaskReference[struct{}](TaskIDPrefix + "k8s-auditlog-permission-denied-parser-tail")
+
 // K8sAuditLogIngesterTaskID is the task ID for the task to serialize the k8s audit log.
 var K8sAuditLogIngesterTaskID = taskid.NewDefaultImplementationID[[]*log.Log](TaskIDPrefix + "k8s-auditlog-ingester")
 
@@ -40,6 +43,9 @@ var SuccessLogFilterTaskID = taskid.NewDefaultImplementationID[[]*log.Log](TaskI
 // NonSuccessLogFilterTaskID is the task ID for the task to filter non-success logs.
 var NonSuccessLogFilterTaskID = taskid.NewDefaultImplementationID[[]*log.Log](TaskIDPrefix + "non-success-log-filter")
 
+// PermissionDeniedLogFilterTaskID is the task ID for the task to filter logs of requests denied by the authorizer.
+var PermissionDeniedLogFilterTaskID = taskid.NewDefaultImplementationID[[]*log.Log](TaskIDPrefix + "permission-denied-log-filter")
+
 // LogSorterTaskID is the task ID for the task to sort logs by time.
 var LogSorterTaskID = taskid.NewDefaultImplementationID[[]*log.Log](TaskIDPrefix + "log-sorter")
 
@@ -49,6 +55,9 @@ var LogSummaryGrouperTaskID = taskid.NewDefaultImplementationID[inspectiontaskba
 // NonSuccessLogGrouperTaskID is the task ID for the task to group non-success logs.
 var NonSuccessLogGrouperTaskID = taskid.NewDefaultImplementationID[inspectiontaskbase.LogGroupMap](TaskIDPrefix + "non-success-log-grouper")
 
+// PermissionDeniedLogGrouperTaskID is the task ID for the task to group logs of denied requests by the principal and the resource.
+var PermissionDeniedLogGrouperTaskID = taskid.NewDefaultImplementationID[inspectiontaskbase.LogGroupMap](TaskIDPrefix + "permission-denied-log-grouper")
+
 // ChangeTargetGrouperTaskID is the task ID for the task to group logs by the target resource.
 var ChangeTargetGrouperTaskID = taskid.NewDefaultImplementationID[ResourceLogGroupMap](TaskIDPrefix + "change-target-grouper")
 
@@ -67,6 +76,9 @@ var LogSummaryLogToTimelineMapperTaskID = taskid.NewDefaultImplementationID[stru
 // NonSuccessLogLogToTimelineMapperTaskID is the task ID for the task to generate history from non-success logs.
 var NonSuccessLogLogToTimelineMapperTaskID = taskid.NewDefaultImplementationID[struct{}](TaskIDPrefix + "non-success-timeline-mapper")
 
+// PermissionDeniedLogToTimelineMapperTaskID is the task ID for the task to map logs of denied requests into the timelines of the principals.
+var PermissionDeniedLogToTimelineMapperTaskID = taskid.NewDefaultImplementationID[struct{}](TaskIDPrefix + "permission-denied-timeline-mapper")
+
 // ResourceRevisionLogToTimelineMapperTaskID is the task ID for the task to map logs into resource revision history.
 var ResourceRevisionLogToTimelineMapperTaskID = taskid.NewDefaultImplementationID[struct{}](TaskIDPrefix + "resource-revision-timeline-mapper")
 
@@ -85,6 +97,9 @@ var ContainerLogToTimelineMapperTaskID = taskid.NewDefaultImplementationID[struc
 // ConditionLogToTimelineMapperTaskID is the task ID for the task to generate condition history.
 var ConditionLogToTimelineMapperTaskID = taskid.NewDefaultImplementationID[struct{}](TaskIDPrefix + "condition-timeline-mapper")
 
+// VeleroOperationLogToTimelineMapperTaskID is the task ID for the task to map Velero Backups and Restores into the windows they were processed.
+var VeleroOperationLogToTimelineMapperTaskID = taskid.NewDefaultImplementationID[struct{}](TaskIDPrefix + "velero-operation-timeline-mapper")
+
 // NodeNameDiscoveryTaskID is the task ID for extracting node names from audit logs.
 var NodeNameDiscoveryTaskID = taskid.NewDefaultImplementationID[[]string](TaskIDPrefix + "node-name-discovery")
 
@@ -42,12 +42,14 @@ type ResourceIdentity struct {
 	Name            string
 	Namespace       string
 	SubresourceName string
+	// Cluster is the name of the cluster the resource belongs to. This is empty unless the inspection gathers logs from multiple clusters.
+	Cluster string
 }
 
 // Equals implements resourcelease.LeaseHolder.
 func (r *ResourceIdentity) Equals(holder resourcelease.LeaseHolder) bool {
 	if castedHolder, ok := holder.(*ResourceIdentity); ok {
-		return r.APIVersion == castedHolder.APIVersion && r.Kind == castedHolder.Kind && r.Name == castedHolder.Name && r.Namespace == castedHolder.Namespace && r.SubresourceName == castedHolder.SubresourceName
+		return r.APIVersion == castedHolder.APIVersion && r.Kind == castedHolder.Kind && r.Name == castedHolder.Name && r.Namespace == castedHolder.Namespace && r.SubresourceName == castedHolder.SubresourceName && r.Cluster == castedHolder.Cluster
 	}
 	return false
 }
@@ -74,15 +76,15 @@ func (r *ResourceIdentity) Type() ResourceIdentityType {
 	}
 }
 
-// ResourcePathString returns the resource path string.
+// ResourcePathString returns the resource path string. The path is scoped to the cluster when the identity has its cluster.
 func (r *ResourceIdentity) ResourcePathString() string {
 	switch r.Type() {
 	case Namespace:
-		return resourcepath.NameLayerGeneralItem(r.APIVersion, r.Kind, r.Namespace, "@namespace").Path
+		return resourcepath.ClusterScopedPath(resourcepath.NameLayerGeneralItem(r.APIVersion, r.Kind, r.Namespace, "@namespace").Path, r.Cluster)
 	case Resource:
-		return resourcepath.NameLayerGeneralItem(r.APIVersion, r.Kind, r.Namespace, r.Name).Path
+		return resourcepath.ClusterScopedPath(resourcepath.NameLayerGeneralItem(r.APIVersion, r.Kind, r.Namespace, r.Name).Path, r.Cluster)
 	case Subresource:
-		return resourcepath.SubresourceLayerGeneralItem(r.APIVersion, r.Kind, r.Namespace, r.Name, r.SubresourceName).Path
+		return resourcepath.ClusterScopedPath(resourcepath.SubresourceLayerGeneralItem(r.APIVersion, r.Kind, r.Namespace, r.Name, r.SubresourceName).Path, r.Cluster)
 	default:
 		panic(fmt.Sprintf("unknown resource identity type: %d", r.Type()))
 	}
@@ -96,6 +98,7 @@ func (r *ResourceIdentity) SubresourceIdentity(subresourceName string) *Resource
 		Name:            r.Name,
 		Namespace:       r.Namespace,
 		SubresourceName: subresourceName,
+		Cluster:         r.Cluster,
 	}
 }
 
@@ -109,6 +112,7 @@ func (r *ResourceIdentity) ParentIdentity() *ResourceIdentity {
 			APIVersion: r.APIVersion,
 			Kind:       r.Kind,
 			Namespace:  r.Namespace,
+			Cluster:    r.Cluster,
 		}
 	case Subresource:
 		return &ResourceIdentity{
@@ -116,6 +120,7 @@ func (r *ResourceIdentity) ParentIdentity() *ResourceIdentity {
 			Kind:       r.Kind,
 			Namespace:  r.Namespace,
 			Name:       r.Name,
+			Cluster:    r.Cluster,
 		}
 	default:
 		panic(fmt.Sprintf("unknown resource identity type: %d", r.Type()))
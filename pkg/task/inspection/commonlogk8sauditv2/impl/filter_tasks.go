@@ -39,3 +39,12 @@ var NonSuccessLogFilterTask = inspectiontaskbase.NewLogFilterTask(
 		return log.MustGetFieldSet(l, &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{}).IsError
 	},
 )
+
+// PermissionDeniedLogFilterTask filters logs of requests denied by the authorizer.
+var PermissionDeniedLogFilterTask = inspectiontaskbase.NewLogFilterTask(
+	commonlogk8sauditv2_contract.PermissionDeniedLogFilterTaskID,
+	commonlogk8sauditv2_contract.K8sAuditLogProviderRef,
+	func(ctx context.Context, l *log.Log) bool {
+		return log.MustGetFieldSet(l, &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{}).IsPermissionDenied
+	},
+)
@@ -67,3 +67,27 @@ func TestNonSuccessLogFilterTask(t *testing.T) {
 		},
 	})
 }
+
+func TestPermissionDeniedLogFilterTask(t *testing.T) {
+	inspectiontaskbasetest.AssertFilterTask(t, PermissionDeniedLogFilterTask, commonlogk8sauditv2_contract.K8sAuditLogProviderRef, []inspectiontaskbasetest.FilterTaskTestCase{
+		{
+			Description: "non-success log not denied by the authorizer",
+			LogFields: []log.FieldSet{
+				&commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
+					IsError: true,
+				},
+			},
+			WantIncluded: false,
+		},
+		{
+			Description: "permission denied log",
+			LogFields: []log.FieldSet{
+				&commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
+					IsError:            true,
+					IsPermissionDenied: true,
+				},
+			},
+			WantIncluded: true,
+		},
+	})
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/kyasbal/khi/pkg/common/khictx"
 	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
 	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
 	coretask "github.com/kyasbal/khi/pkg/core/task"
@@ -42,6 +43,7 @@ var NonSuccessLogGrouperTask = inspectiontaskbase.NewLogGrouperTask(
 )
 
 // ChangeTargetGrouperTask groups logs by resource that is modified by the operation in the log.
+// Logs of the resources with the same name in different clusters are grouped separately when the history builder scopes resource paths to clusters.
 // This task determines the group, specifically handling the following cases:
 // 1. When multiple resources are modified by the operation, the log entry is duplicated and assigned to each group.
 // 2. When a subresource is modified by the operation and its result contains its parent manifest, it uses the parent resource as the group key.
@@ -55,6 +57,7 @@ var ChangeTargetGrouperTask = inspectiontaskbase.NewProgressReportableInspection
 
 		progress.MarkIndeterminate()
 
+		builder := khictx.MustGetValue(ctx, inspectioncore_contract.CurrentHistoryBuilder)
 		logs := coretask.GetTaskResult(ctx, commonlogk8sauditv2_contract.LogSorterTaskID.Ref())
 		result := commonlogk8sauditv2_contract.ResourceLogGroupMap{}
 		scanner := targetResourceScanner{
@@ -66,6 +69,7 @@ var ChangeTargetGrouperTask = inspectiontaskbase.NewProgressReportableInspection
 			ops := scanner.scanTargetResource(l)
 			for _, op := range ops {
 				resource := commonlogk8sauditv2_contract.ResourceIdentityFromKubernetesOperation(op)
+				resource.Cluster = builder.ClusterScopeOf(l)
 				path := resource.ResourcePathString()
 				if result[path] == nil {
 					result[path] = &commonlogk8sauditv2_contract.ResourceLogGroup{
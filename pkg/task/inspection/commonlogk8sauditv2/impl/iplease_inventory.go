@@ -74,10 +74,10 @@ func processPodResource(group *commonlogk8sauditv2_contract.ResourceManifestLogG
 			continue
 		}
 		ips := map[string]struct{}{}
-		podIPsReader, err := l.ResourceBodyReader.GetReader("status.podIPs")
+		podIPsReader, err := l.ResourceBodyReader.GetReader("status.podIPs[*].ip")
 		if err == nil {
 			for _, podIPReader := range podIPsReader.Children() {
-				ip, err := podIPReader.ReadString("ip")
+				ip, err := podIPReader.ReadString("")
 				if err != nil {
 					continue
 				}
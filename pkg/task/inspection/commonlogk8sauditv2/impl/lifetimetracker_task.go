@@ -229,8 +229,11 @@ var ResourceLifetimeTrackerTask = inspectiontaskbase.NewProgressReportableInspec
 				processedLogCount.Add(uint32(len(group.Logs)))
 			})
 		}
-		pool.Wait()
+		err := pool.Wait()
 		updator.Done()
+		if err != nil {
+			return nil, err
+		}
 
 		return groupedLogs, nil
 	},
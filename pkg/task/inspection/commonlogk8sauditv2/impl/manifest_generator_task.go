@@ -16,6 +16,7 @@ package commonlogk8sauditv2_impl
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"runtime"
@@ -26,6 +27,7 @@ import (
 
 	"github.com/kyasbal/khi/pkg/core/inspection/progressutil"
 
+	"github.com/kyasbal/khi/pkg/common/errorreport"
 	"github.com/kyasbal/khi/pkg/common/structured"
 	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
 	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
@@ -76,7 +78,8 @@ var ManifestGeneratorTask = inspectiontaskbase.NewProgressReportableInspectionTa
 	for path, group := range logGroups {
 		path := path
 		group := group
-		grp.Go(func() error {
+		grp.Go(func() (err error) {
+			defer errorreport.RecoverPanicAsError(&err)
 			defer doneGroupCount.Add(1)
 			resourceLogs := []*commonlogk8sauditv2_contract.ResourceManifestLog{}
 			generator := groupManifestGenerator{
@@ -166,27 +169,28 @@ func (g *groupManifestGenerator) Process(ctx context.Context, l *log.Log) (*comm
 			if name == g.resourceName {
 				found = true
 				// XXList omits apiVersion and kind in its item. Generate a reader with the field.
-				rawYAML, err := item.Serialize("", &structured.YAMLNodeSerializer{})
-				if err != nil {
-					slog.WarnContext(ctx, fmt.Sprintf("failed to serialize resource body to yaml\n%s", err.Error()))
-				}
 				var prevAPIVersion, prevKind string
 				if g.prevRevisionReader != nil {
 					prevAPIVersion = g.prevRevisionReader.ReadStringOrDefault("apiVersion", "")
 					prevKind = g.prevRevisionReader.ReadStringOrDefault("kind", "")
 				}
-				fullYAMLStr := string(rawYAML)
-				if prevAPIVersion != "" && prevKind != "" {
-					fullYAMLStr = fmt.Sprintf(`apiVersion: %s
-kind: %s
-%s`, prevAPIVersion, prevKind, fullYAMLStr)
+				if prevAPIVersion == "" || prevKind == "" {
+					currentBodyReader = &item
+					break
+				}
+				writer, err := structured.NewNodeWriter(structured.NewStandardMap(
+					[]string{"apiVersion", "kind"},
+					[]structured.Node{structured.NewStandardScalarNode(prevAPIVersion), structured.NewStandardScalarNode(prevKind)},
+				))
+				if err == nil {
+					for key, child := range item.Children() {
+						err = errors.Join(err, writer.SetNode(structured.EscapeFieldPathKey(key.Key), child.Node))
+					}
 				}
-
-				rootNode, err := structured.FromYAML(fullYAMLStr)
 				if err != nil {
-					slog.WarnContext(ctx, fmt.Sprintf("failed to serialize resource body to yaml after constructing full yaml\n%s", err.Error()))
+					slog.WarnContext(ctx, fmt.Sprintf("failed to construct the resource body with apiVersion and kind\n%s", err.Error()))
 				} else {
-					currentBodyReader = structured.NewNodeReader(rootNode)
+					currentBodyReader = writer.Reader()
 				}
 				break
 			}
@@ -208,12 +212,27 @@ kind: %s
 	currentRevisionBody = removeAtType(currentRevisionBody)
 
 	if fieldSet.K8sOperation.Verb == enum.RevisionVerbPatch && partial {
-		op := fieldSet.K8sOperation
-		mergeConfigResolver := g.mergeConfigRegistry.Get(op.APIVersion, op.GetSingularKindName())
-		mergedNode, err := structured.MergeNode(g.prevRevisionReader.Node, currentBodyReader.Node, structured.MergeConfiguration{
-			MergeMapOrderStrategy:    &structured.DefaultMergeMapOrderStrategy{},
-			ArrayMergeConfigResolver: mergeConfigResolver,
-		})
+		var mergedNode structured.Node
+		var err error
+		if isJSONPatch(currentBodyReader) {
+			// Some clients send RFC 6902 JSON Patch instead of strategic merge patch. The request body is the list of operations then.
+			var rawPatch []byte
+			rawPatch, err = currentBodyReader.Serialize("", &structured.JSONNodeSerializer{})
+			if err == nil {
+				var prevNode structured.Node
+				if g.prevRevisionReader != nil {
+					prevNode = g.prevRevisionReader.Node
+				}
+				mergedNode, err = structured.ApplyJSONPatch(prevNode, rawPatch)
+			}
+		} else {
+			op := fieldSet.K8sOperation
+			mergeConfigResolver := g.mergeConfigRegistry.Get(op.APIVersion, op.GetSingularKindName())
+			mergedNode, err = structured.MergeNode(g.prevRevisionReader.Node, currentBodyReader.Node, structured.MergeConfiguration{
+				MergeMapOrderStrategy:    &structured.DefaultMergeMapOrderStrategy{},
+				ArrayMergeConfigResolver: mergeConfigResolver,
+			})
+		}
 		var mergedNodeReader *structured.NodeReader
 		var mergedYAML string
 		if err != nil {
@@ -259,6 +278,19 @@ kind: %s
 }
 
 // removeAtType removes @type in response or request payload.
+// isJSONPatch returns true when the given patch request body is a JSON Patch document, a sequence of operations with `op` fields.
+func isJSONPatch(body *structured.NodeReader) bool {
+	if body.Node.Type() != structured.SequenceNodeType || body.Len() == 0 {
+		return false
+	}
+	for _, operation := range body.Children() {
+		if _, err := operation.ReadString("op"); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
 func removeAtType(yamlString string) string {
 	lines := strings.Split(yamlString, "\n")
 	var result []string
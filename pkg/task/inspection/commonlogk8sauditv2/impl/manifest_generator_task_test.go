@@ -113,6 +113,47 @@ metadata:
   labels:
     foo: bar
     qux: quux
+`,
+			},
+		},
+		{
+			desc: "json patch request",
+			inputs: []*testGroupManifestGeneratorInput{
+				{
+					op: &model.KubernetesObjectOperation{
+						Verb: enum.RevisionVerbUpdate,
+					},
+					responseYAML: `apiVersion: v1
+kind: Pod
+metadata:
+  labels:
+    foo: bar`,
+				},
+				{
+					op: &model.KubernetesObjectOperation{
+						Verb: enum.RevisionVerbPatch,
+					},
+					requestYAML: `- op: replace
+  path: /metadata/labels/foo
+  value: baz
+- op: add
+  path: /metadata/labels/qux
+  value: quux`,
+				},
+			},
+			wantBodies: []string{
+				`apiVersion: v1
+kind: Pod
+metadata:
+  labels:
+    foo: bar
+`,
+				`apiVersion: v1
+kind: Pod
+metadata:
+  labels:
+    foo: baz
+    qux: quux
 `,
 			},
 		},
@@ -0,0 +1,77 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commonlogk8sauditv2_impl
+
+import (
+	"context"
+	"fmt"
+
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/history"
+	"github.com/kyasbal/khi/pkg/model/history/resourcepath"
+	"github.com/kyasbal/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/kyasbal/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+)
+
+// PermissionDeniedLogGrouperTask groups logs of denied requests by the principal and the resource.
+var PermissionDeniedLogGrouperTask = inspectiontaskbase.NewLogGrouperTask(
+	commonlogk8sauditv2_contract.PermissionDeniedLogGrouperTaskID,
+	commonlogk8sauditv2_contract.PermissionDeniedLogFilterTaskID.Ref(),
+	func(ctx context.Context, l *log.Log) string {
+		fieldSet := log.MustGetFieldSet(l, &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{})
+		return permissionDenialResourcePath(fieldSet).Path
+	},
+)
+
+// PermissionDeniedLogToTimelineMapperTask is the task to record requests denied by the authorizer on the timelines grouped by the principal.
+// Denials shown alongside resource changes help finding authorization regressions like RBAC rules broken after an upgrade.
+var PermissionDeniedLogToTimelineMapperTask = inspectiontaskbase.NewLogToTimelineMapperTask[struct{}](commonlogk8sauditv2_contract.PermissionDeniedLogToTimelineMapperTaskID, &permissionDeniedLogToTimelineMapperTaskSetting{})
+
+type permissionDeniedLogToTimelineMapperTaskSetting struct{}
+
+// Dependencies implements inspectiontaskbase.LogToTimelineMapper.
+func (p *permissionDeniedLogToTimelineMapperTaskSetting) Dependencies() []taskid.UntypedTaskReference {
+	return []taskid.UntypedTaskReference{}
+}
+
+// GroupedLogTask implements inspectiontaskbase.LogToTimelineMapper.
+func (p *permissionDeniedLogToTimelineMapperTaskSetting) GroupedLogTask() taskid.TaskReference[inspectiontaskbase.LogGroupMap] {
+	return commonlogk8sauditv2_contract.PermissionDeniedLogGrouperTaskID.Ref()
+}
+
+// LogIngesterTask implements inspectiontaskbase.LogToTimelineMapper.
+func (p *permissionDeniedLogToTimelineMapperTaskSetting) LogIngesterTask() taskid.TaskReference[[]*log.Log] {
+	return commonlogk8sauditv2_contract.K8sAuditLogIngesterTaskID.Ref()
+}
+
+// ProcessLogByGroup implements inspectiontaskbase.LogToTimelineMapper.
+func (p *permissionDeniedLogToTimelineMapperTaskSetting) ProcessLogByGroup(ctx context.Context, l *log.Log, cs *history.ChangeSet, builder *history.Builder, prevGroupData struct{}) (struct{}, error) {
+	fieldSet := log.MustGetFieldSet(l, &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{})
+	cs.AddEvent(permissionDenialResourcePath(fieldSet))
+	return struct{}{}, nil
+}
+
+var _ inspectiontaskbase.LogToTimelineMapper[struct{}] = (*permissionDeniedLogToTimelineMapperTaskSetting)(nil)
+
+// permissionDenialResourcePath returns the path of the timeline for the principal and the resource of the denied request.
+func permissionDenialResourcePath(fieldSet *commonlogk8sauditv2_contract.K8sAuditLogFieldSet) resourcepath.ResourcePath {
+	op := fieldSet.K8sOperation
+	name := op.Name
+	if op.SubResourceName != "" {
+		name = fmt.Sprintf("%s/%s", name, op.SubResourceName)
+	}
+	return resourcepath.PermissionDenial(fieldSet.Principal, op.Namespace, op.PluralKind, name)
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commonlogk8sauditv2_impl
+
+import (
+	"testing"
+
+	"github.com/kyasbal/khi/pkg/model"
+	"github.com/kyasbal/khi/pkg/model/history"
+	"github.com/kyasbal/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/kyasbal/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	"github.com/kyasbal/khi/pkg/testutil/testchangeset"
+)
+
+func TestPermissionDeniedLogToTimelineMapperTaskSetting_ProcessLogByGroup(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		input    *commonlogk8sauditv2_contract.K8sAuditLogFieldSet
+		wantPath string
+	}{
+		{
+			desc: "denied request against a named resource",
+			input: &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
+				Principal: "system:serviceaccount:kube-system:foo-controller",
+				K8sOperation: &model.KubernetesObjectOperation{
+					APIVersion: "apps/v1",
+					PluralKind: "deployments",
+					Namespace:  "default",
+					Name:       "nginx",
+				},
+			},
+			wantPath: "@Authorization#system:serviceaccount:kube-system:foo-controller#default#deployments/nginx",
+		},
+		{
+			desc: "denied request against a subresource",
+			input: &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
+				Principal: "user@example.com",
+				K8sOperation: &model.KubernetesObjectOperation{
+					APIVersion:      "core/v1",
+					PluralKind:      "pods",
+					Namespace:       "default",
+					Name:            "nginx",
+					SubResourceName: "exec",
+				},
+			},
+			wantPath: "@Authorization#user@example.com#default#pods/nginx/exec",
+		},
+		{
+			desc: "denied request against a collection",
+			input: &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
+				Principal: "user@example.com",
+				K8sOperation: &model.KubernetesObjectOperation{
+					APIVersion: "core/v1",
+					PluralKind: "nodes",
+					Namespace:  "cluster-scope",
+				},
+			},
+			wantPath: "@Authorization#user@example.com#cluster-scope#nodes",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l := log.NewLogWithFieldSetsForTest(tc.input)
+			cs := history.NewChangeSet(l)
+
+			setting := &permissionDeniedLogToTimelineMapperTaskSetting{}
+			_, err := setting.ProcessLogByGroup(t.Context(), l, cs, nil, struct{}{})
+			if err != nil {
+				t.Fatalf("ProcessLogByGroup() returned an unexpected error: %v", err)
+			}
+			asserter := testchangeset.HasEvent{
+				ResourcePath: tc.wantPath,
+			}
+			asserter.Assert(t, cs)
+		})
+	}
+}
@@ -34,7 +34,11 @@ func Register(registry coreinspection.InspectionTaskRegistry) error {
 		ResourceRevisionLogToTimelineMapperTask,
 		NonSuccessLogGrouperTask,
 		NonSuccessLogLogToTimelineMapperTask,
+		PermissionDeniedLogFilterTask,
+		PermissionDeniedLogGrouperTask,
+		PermissionDeniedLogToTimelineMapperTask,
 		ConditionLogToTimelineMapperTask,
+		VeleroOperationLogToTimelineMapperTask,
 		ResourceOwnerReferenceTimelineMapperTask,
 		PodPhaseLogToTimelineMapperTask,
 		EndpointResourceLogToTimelineMapperTask,
@@ -59,24 +59,13 @@ func GetFinalizers(reader *structured.NodeReader) ([]string, bool) {
 	}
 
 	readFinalizers := func(path string) ([]string, bool) {
-		r, err := reader.GetReader(path)
-		if err != nil {
+		if !reader.Has(path) {
 			return nil, false
 		}
-		// Assuming finalizers is a list of strings
-		// We need to iterate or read as string list.
-		// structured.NodeReader doesn't have ReadStringList directly visible in the previous context,
-		// but let's check how it was used: `finalizers.Len() > 0`.
-		// We can try to read it as a list.
-		var result []string
-		// Iterate over the list
-		for _, v := range r.Children() {
-			v, err := v.NodeScalarValue()
-			if err != nil {
-				slog.Warn("an error occurred while reading finalizer elements", "err", err)
-				continue
-			}
-			result = append(result, v.(string))
+		result, err := reader.ReadStringSlice(path)
+		if err != nil {
+			slog.Warn("an error occurred while reading finalizers", "path", path, "err", err)
+			return nil, false
 		}
 		return result, true
 	}
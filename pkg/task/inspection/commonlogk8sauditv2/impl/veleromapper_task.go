@@ -0,0 +1,153 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commonlogk8sauditv2_impl
+
+import (
+	"context"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/common/structured"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/history"
+	"github.com/kyasbal/khi/pkg/model/history/resourcepath"
+	"github.com/kyasbal/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/kyasbal/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+)
+
+// veleroTerminalPhases is the set of phases Backups and Restores end with.
+// Validation failures end the operation without setting status.completionTimestamp.
+var veleroTerminalPhases = map[string]struct{}{
+	"Completed":        {},
+	"PartiallyFailed":  {},
+	"Failed":           {},
+	"FailedValidation": {},
+}
+
+type veleroOperationTaskState struct {
+	// started is true after the revision for the beginning of the operation was recorded.
+	started bool
+	// finished is true after the revision for the end of the operation was recorded.
+	finished bool
+}
+
+type veleroOperationLogToTimelineMapperTaskSetting struct {
+}
+
+// Process implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (v *veleroOperationLogToTimelineMapperTaskSetting) Process(ctx context.Context, passIndex int, event commonlogk8sauditv2_contract.ResourceChangeEvent, cs *history.ChangeSet, builder *history.Builder, state *veleroOperationTaskState) (*veleroOperationTaskState, error) {
+	if state == nil || event.EventType == commonlogk8sauditv2_contract.ChangeEventTypeTargetCreation {
+		state = &veleroOperationTaskState{}
+	}
+	if event.EventTargetBodyReader == nil {
+		return state, nil
+	}
+	commonFieldSet := log.MustGetFieldSet(event.Log, &log.CommonFieldSet{})
+	k8sFieldSet := log.MustGetFieldSet(event.Log, &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{})
+	operationPath := resourcepath.VeleroOperation(event.EventTargetResource.Kind, event.EventTargetResource.Namespace, event.EventTargetResource.Name)
+
+	if !state.started {
+		if startTime, found := readVeleroTimestamp(event.EventTargetBodyReader, "status.startTimestamp"); found {
+			cs.AddRevision(operationPath, &history.StagingResourceRevision{
+				Verb:       k8sFieldSet.K8sOperation.Verb,
+				Body:       event.EventTargetBodyYAML,
+				Partial:    false,
+				Requestor:  k8sFieldSet.Principal,
+				ChangeTime: startTime,
+				State:      enum.RevisionStateOperationStarted,
+			})
+			state.started = true
+		}
+	}
+	if !state.finished {
+		completionTime, found := readVeleroTimestamp(event.EventTargetBodyReader, "status.completionTimestamp")
+		if !found {
+			phase := event.EventTargetBodyReader.ReadStringOrDefault("status.phase", "")
+			if _, terminal := veleroTerminalPhases[phase]; terminal {
+				completionTime, found = commonFieldSet.Timestamp, true
+			}
+		}
+		if found {
+			cs.AddRevision(operationPath, &history.StagingResourceRevision{
+				Verb:       k8sFieldSet.K8sOperation.Verb,
+				Body:       event.EventTargetBodyYAML,
+				Partial:    false,
+				Requestor:  k8sFieldSet.Principal,
+				ChangeTime: completionTime,
+				State:      enum.RevisionStateOperationFinished,
+			})
+			state.finished = true
+		}
+	}
+	return state, nil
+}
+
+// readVeleroTimestamp reads the timestamp at the given field path of a Velero resource.
+func readVeleroTimestamp(reader *structured.NodeReader, fieldPath string) (time.Time, bool) {
+	val, err := reader.ReadString(fieldPath)
+	if err != nil {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// Dependencies implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (v *veleroOperationLogToTimelineMapperTaskSetting) Dependencies() []taskid.UntypedTaskReference {
+	return []taskid.UntypedTaskReference{}
+}
+
+// PassCount implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (v *veleroOperationLogToTimelineMapperTaskSetting) PassCount() int {
+	return 1
+}
+
+// GroupedLogTask implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (v *veleroOperationLogToTimelineMapperTaskSetting) GroupedLogTask() taskid.TaskReference[commonlogk8sauditv2_contract.ResourceManifestLogGroupMap] {
+	return commonlogk8sauditv2_contract.ResourceLifetimeTrackerTaskID.Ref()
+}
+
+// LogIngesterTask implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (v *veleroOperationLogToTimelineMapperTaskSetting) LogIngesterTask() taskid.TaskReference[[]*log.Log] {
+	return commonlogk8sauditv2_contract.K8sAuditLogIngesterTaskID.Ref()
+}
+
+// TaskID implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (v *veleroOperationLogToTimelineMapperTaskSetting) TaskID() taskid.TaskImplementationID[struct{}] {
+	return commonlogk8sauditv2_contract.VeleroOperationLogToTimelineMapperTaskID
+}
+
+// ResourcePairs implements commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting.
+func (v *veleroOperationLogToTimelineMapperTaskSetting) ResourcePairs(ctx context.Context, groupedLogs commonlogk8sauditv2_contract.ResourceManifestLogGroupMap) ([]commonlogk8sauditv2_contract.ResourcePair, error) {
+	result := []commonlogk8sauditv2_contract.ResourcePair{}
+	for _, group := range groupedLogs {
+		// velero.io/v1#backup#namespace#name or velero.io/v1#restore#namespace#name
+		if group.Resource.Type() != commonlogk8sauditv2_contract.Resource || group.Resource.APIVersion != "velero.io/v1" || (group.Resource.Kind != "backup" && group.Resource.Kind != "restore") {
+			continue
+		}
+		result = append(result, commonlogk8sauditv2_contract.ResourcePair{
+			TargetGroup: group.Resource,
+		})
+	}
+	return result, nil
+}
+
+var _ commonlogk8sauditv2_contract.ManifestLogToTimelineMapperTaskSetting[*veleroOperationTaskState] = (*veleroOperationLogToTimelineMapperTaskSetting)(nil)
+
+// VeleroOperationLogToTimelineMapperTask is the task to generate the windows Velero Backups and Restores were processed from their status.
+var VeleroOperationLogToTimelineMapperTask = commonlogk8sauditv2_contract.NewManifestLogToTimelineMapper[*veleroOperationTaskState](&veleroOperationLogToTimelineMapperTaskSetting{})
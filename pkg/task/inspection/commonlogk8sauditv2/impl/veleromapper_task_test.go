@@ -0,0 +1,191 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commonlogk8sauditv2_impl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/common/structured"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/history"
+	"github.com/kyasbal/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/kyasbal/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	"github.com/kyasbal/khi/pkg/testutil/testchangeset"
+)
+
+func TestVeleroOperationTask_Process(t *testing.T) {
+	testTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	operationPath := "velero.io/v1#restore#velero#test#restore-test"
+
+	type step struct {
+		resourceBodyYAML string
+		eventType        commonlogk8sauditv2_contract.ChangeEventType
+	}
+
+	testCases := []struct {
+		name      string
+		steps     []step
+		wantState *veleroOperationTaskState
+		asserters []testchangeset.ChangeSetAsserter
+	}{
+		{
+			name: "restore completed",
+			steps: []step{
+				{
+					resourceBodyYAML: `status:
+  phase: New`,
+					eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetCreation,
+				},
+				{
+					resourceBodyYAML: `status:
+  phase: InProgress
+  startTimestamp: "2025-01-01T00:00:01Z"`,
+					eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+				},
+				{
+					resourceBodyYAML: `status:
+  phase: PartiallyFailed
+  errors: 2
+  startTimestamp: "2025-01-01T00:00:01Z"
+  completionTimestamp: "2025-01-01T00:00:10Z"`,
+					eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+				},
+			},
+			wantState: &veleroOperationTaskState{started: true, finished: true},
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchRevisionCount{ResourcePath: operationPath, WantCount: 2},
+				&testchangeset.HasRevision{
+					ResourcePath: operationPath,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbPatch,
+						State:      enum.RevisionStateOperationStarted,
+						ChangeTime: testTime.Add(1 * time.Second),
+						Body: `status:
+  phase: InProgress
+  startTimestamp: "2025-01-01T00:00:01Z"`,
+					},
+				},
+				&testchangeset.HasRevision{
+					ResourcePath: operationPath,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbPatch,
+						State:      enum.RevisionStateOperationFinished,
+						ChangeTime: testTime.Add(10 * time.Second),
+						Body: `status:
+  phase: PartiallyFailed
+  errors: 2
+  startTimestamp: "2025-01-01T00:00:01Z"
+  completionTimestamp: "2025-01-01T00:00:10Z"`,
+					},
+				},
+			},
+		},
+		{
+			name: "restore failed validation",
+			steps: []step{
+				{
+					resourceBodyYAML: `status:
+  phase: New`,
+					eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetCreation,
+				},
+				{
+					resourceBodyYAML: `status:
+  phase: FailedValidation`,
+					eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetModification,
+				},
+			},
+			wantState: &veleroOperationTaskState{started: false, finished: true},
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchRevisionCount{ResourcePath: operationPath, WantCount: 1},
+				&testchangeset.HasRevision{
+					ResourcePath: operationPath,
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbPatch,
+						State:      enum.RevisionStateOperationFinished,
+						ChangeTime: testTime.Add(1 * time.Second),
+						Body: `status:
+  phase: FailedValidation`,
+					},
+				},
+			},
+		},
+		{
+			name: "restore recreated with the same name",
+			steps: []step{
+				{
+					resourceBodyYAML: `status:
+  phase: Completed
+  startTimestamp: "2025-01-01T00:00:00Z"
+  completionTimestamp: "2025-01-01T00:00:00Z"`,
+					eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetCreation,
+				},
+				{
+					resourceBodyYAML: `status:
+  phase: InProgress
+  startTimestamp: "2025-01-01T00:00:01Z"`,
+					eventType: commonlogk8sauditv2_contract.ChangeEventTypeTargetCreation,
+				},
+			},
+			wantState: &veleroOperationTaskState{started: true, finished: false},
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchRevisionCount{ResourcePath: operationPath, WantCount: 3},
+			},
+		},
+	}
+
+	taskSetting := &veleroOperationLogToTimelineMapperTaskSetting{}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fs := newTestK8sAuditLogFieldSet(enum.RevisionVerbPatch, "velero.io/v1", "restores")
+			logObj := log.NewLogWithFieldSetsForTest(fs, &log.CommonFieldSet{Timestamp: testTime})
+			cs := history.NewChangeSet(logObj)
+
+			var state *veleroOperationTaskState
+			for i, s := range tc.steps {
+				logObj := log.NewLogWithFieldSetsForTest(fs, &log.CommonFieldSet{Timestamp: testTime.Add(time.Duration(i) * time.Second)})
+				node, err := structured.FromYAML(s.resourceBodyYAML)
+				if err != nil {
+					t.Fatalf("failed to parse resource body: %v", err)
+				}
+				event := commonlogk8sauditv2_contract.ResourceChangeEvent{
+					Log:                   logObj,
+					EventType:             s.eventType,
+					EventTargetBodyReader: structured.NewNodeReader(node),
+					EventTargetBodyYAML:   s.resourceBodyYAML,
+					EventTargetResource: &commonlogk8sauditv2_contract.ResourceIdentity{
+						APIVersion: "velero.io/v1",
+						Kind:       "restore",
+						Namespace:  "velero",
+						Name:       "test",
+					},
+				}
+				state, err = taskSetting.Process(context.Background(), 0, event, cs, nil, state)
+				if err != nil {
+					t.Fatalf("Process failed: %v", err)
+				}
+			}
+
+			if diff := cmp.Diff(tc.wantState, state, cmp.AllowUnexported(veleroOperationTaskState{})); diff != "" {
+				t.Errorf("state mismatch (-want +got):\n%s", diff)
+			}
+			for _, asserter := range tc.asserters {
+				asserter.Assert(t, cs)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commontimerange_contract
+
+import (
+	"time"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+)
+
+// TaskIDPrefix is the prefix for the task IDs of the time range inputs shared by log sources.
+var TaskIDPrefix = "khi.google.com/common-time-range/"
+
+// InputTimeRangeTaskID is the task ID for the time range of the log query.
+// This is the time range input for log sources not depending on a specific log backend. Sources querying Cloud Logging use the time range of googlecloudcommon instead.
+var InputTimeRangeTaskID = taskid.NewDefaultImplementationID[formtask.TimeRange](TaskIDPrefix + "input-time-range")
+
+// InputEndTimeTaskID is the task ID for the end time of the log query. This is computed from InputTimeRangeTask.
+var InputEndTimeTaskID = taskid.NewDefaultImplementationID[time.Time](TaskIDPrefix + "input-end-time")
+
+// InputStartTimeTaskID is the task ID for the start time of the log query. This is computed from InputTimeRangeTask.
+var InputStartTimeTaskID = taskid.NewDefaultImplementationID[time.Time](TaskIDPrefix + "input-start-time")
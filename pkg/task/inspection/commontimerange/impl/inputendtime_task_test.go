@@ -0,0 +1,43 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commontimerange_impl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	commontimerange_contract "github.com/kyasbal/khi/pkg/task/inspection/commontimerange/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+func TestInputEndTime(t *testing.T) {
+	endTime := time.Date(2023, time.January, 2, 15, 45, 0, 0, time.UTC)
+	timeRange := formtask.TimeRange{Start: endTime.Add(-time.Hour), End: endTime}
+
+	ctx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+	result, _, err := inspectiontest.RunInspectionTask(ctx, InputEndTimeTask, inspectioncore_contract.TaskModeDryRun, map[string]any{},
+		tasktest.NewTaskDependencyValuePair(commontimerange_contract.InputTimeRangeTaskID.Ref(), timeRange),
+	)
+	if err != nil {
+		t.Errorf("unexpected error\n%v", err)
+	}
+	if !result.Equal(endTime) {
+		t.Errorf("returned time is not matching with the expected value\n%s", result)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commontimerange_impl
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	commontimerange_contract "github.com/kyasbal/khi/pkg/task/inspection/commontimerange/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// InputStartTimeTask returns the start time of the time range given from InputTimeRangeTask.
+// This also records the time range on the header metadata.
+var InputStartTimeTask = inspectiontaskbase.NewInspectionTask(commontimerange_contract.InputStartTimeTaskID, []taskid.UntypedTaskReference{
+	commontimerange_contract.InputTimeRangeTaskID.Ref(),
+}, func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (time.Time, error) {
+	timeRange := coretask.GetTaskResult(ctx, commontimerange_contract.InputTimeRangeTaskID.Ref())
+	metadataSet := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
+
+	header, found := typedmap.Get(metadataSet, inspectionmetadata.HeaderMetadataKey)
+	if !found {
+		return time.Time{}, fmt.Errorf("header metadata not found")
+	}
+
+	header.StartTimeUnixSeconds = timeRange.Start.Unix()
+	header.EndTimeUnixSeconds = timeRange.End.Unix()
+	return timeRange.Start, nil
+})
@@ -0,0 +1,55 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commontimerange_impl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	commontimerange_contract "github.com/kyasbal/khi/pkg/task/inspection/commontimerange/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+func TestInputStartTime(t *testing.T) {
+	endTime := time.Date(2023, time.January, 2, 15, 45, 0, 0, time.UTC)
+	timeRange := formtask.TimeRange{Start: endTime.Add(-90 * time.Minute), End: endTime}
+
+	ctx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+	startTime, _, err := inspectiontest.RunInspectionTask(ctx, InputStartTimeTask, inspectioncore_contract.TaskModeDryRun, map[string]any{},
+		tasktest.NewTaskDependencyValuePair(commontimerange_contract.InputTimeRangeTaskID.Ref(), timeRange),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error\n%v", err)
+	}
+	if !startTime.Equal(timeRange.Start) {
+		t.Errorf("returned time is not matching with the expected value\n%s", startTime)
+	}
+
+	metadataSet := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
+	header, found := typedmap.Get(metadataSet, inspectionmetadata.HeaderMetadataKey)
+	if !found {
+		t.Fatalf("header metadata not found")
+	}
+	if header.StartTimeUnixSeconds != timeRange.Start.Unix() || header.EndTimeUnixSeconds != timeRange.End.Unix() {
+		t.Errorf("header time range = %d ~ %d, want %d ~ %d", header.StartTimeUnixSeconds, header.EndTimeUnixSeconds, timeRange.Start.Unix(), timeRange.End.Unix())
+	}
+}
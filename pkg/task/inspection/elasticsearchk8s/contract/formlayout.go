@@ -0,0 +1,34 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchk8s_contract
+
+import (
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+)
+
+// FormSectionElasticsearchAuthentication is the form section for the credentials used to call the REST API.
+var FormSectionElasticsearchAuthentication = &inspectionmetadata.FormSection{
+	ID:    ElasticsearchTaskPrefix + "form-section/authentication",
+	Label: "Elasticsearch/OpenSearch authentication",
+	After: googlecloudcommon_contract.FormSectionResourceIdentifier,
+}
+
+// FormSectionElasticsearchQuery is the form section for the options of the search query.
+var FormSectionElasticsearchQuery = &inspectionmetadata.FormSection{
+	ID:    ElasticsearchTaskPrefix + "form-section/query",
+	Label: "Search query",
+	After: FormSectionElasticsearchAuthentication,
+}
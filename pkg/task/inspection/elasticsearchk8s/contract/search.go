@@ -0,0 +1,150 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchk8s_contract
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/api/elasticsearch"
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+// searchHitField is the field added on each log to hold the index and the ID of the document.
+const searchHitField = "elasticsearch"
+
+// SearchPageSize is the count of documents requested in a page of the scroll.
+const SearchPageSize = 1000
+
+// SearchKeepAlive is the duration to keep the search context between the pages of the scroll.
+const SearchKeepAlive = time.Minute
+
+// DefaultQueryTemplate is the query DSL template selecting the documents in the time range.
+const DefaultQueryTemplate = `{"bool":{"filter":[{"range":{"{{.TimeField}}":{"gte":"{{.StartTime}}","lt":"{{.EndTime}}","format":"strict_date_optional_time"}}}]}}`
+
+// QueryTemplateParameters is the parameters available in the query DSL template.
+type QueryTemplateParameters struct {
+	// TimeField is the field holding the timestamp of documents.
+	TimeField string
+	// StartTime and EndTime are the time range formatted in RFC3339.
+	StartTime string
+	EndTime   string
+}
+
+// NewQueryTemplateParameters returns the QueryTemplateParameters for the time range.
+func NewQueryTemplateParameters(timeField string, startTime, endTime time.Time) *QueryTemplateParameters {
+	return &QueryTemplateParameters{
+		TimeField: timeField,
+		StartTime: startTime.UTC().Format(time.RFC3339Nano),
+		EndTime:   endTime.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+// RenderQueryTemplate renders the query DSL template written in the Go text/template syntax and verifies the result is a JSON object.
+func RenderQueryTemplate(queryTemplate string, parameters *QueryTemplateParameters) (json.RawMessage, error) {
+	tmpl, err := template.New("query").Option("missingkey=error").Parse(queryTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the query template: %w", err)
+	}
+	var rendered bytes.Buffer
+	err = tmpl.Execute(&rendered, parameters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render the query template: %w", err)
+	}
+	var query map[string]any
+	err = json.Unmarshal(rendered.Bytes(), &query)
+	if err != nil {
+		return nil, fmt.Errorf("the rendered query is not a JSON object: %w", err)
+	}
+	return json.RawMessage(rendered.Bytes()), nil
+}
+
+// SearchAllHits reads all the pages of the search with the scroll API and releases the search context at the end.
+// onPage is called with the count of hits read so far and the total count of hits after reading each page.
+func SearchAllHits(ctx context.Context, api elasticsearch.SearchAPI, input *elasticsearch.SearchInput, onPage func(readCount int, totalCount int64)) ([]*elasticsearch.Hit, error) {
+	output, err := api.Search(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	var result []*elasticsearch.Hit
+	scrollID := output.ScrollID
+	defer func() {
+		if scrollID == "" {
+			return
+		}
+		if err := api.ClearScroll(context.WithoutCancel(ctx), scrollID); err != nil {
+			slog.WarnContext(ctx, "failed to clear the scroll", "error", err)
+		}
+	}()
+	for {
+		result = append(result, output.Hits...)
+		if onPage != nil {
+			onPage(len(result), output.TotalHits)
+		}
+		if len(output.Hits) == 0 || output.ScrollID == "" {
+			return result, nil
+		}
+		output, err = api.Scroll(ctx, output.ScrollID, input.KeepAlive)
+		if err != nil {
+			return nil, err
+		}
+		if output.ScrollID != "" {
+			scrollID = output.ScrollID
+		}
+	}
+}
+
+// rawLineFieldNames are the fields holding the raw line when a log shipper stores the audit log file without parsing the lines.
+var rawLineFieldNames = []string{"message", "log"}
+
+// NewLogFromHit converts a document to a log.
+// The source of the document is used as the log body. When the source has no `auditID` field and the raw line field (`message` or `log`) holds a JSON object,
+// the decoded line is used as the body instead because log shippers store the lines of the audit log file without parsing them by default.
+// The index and the ID of the document are stored in the `elasticsearch` field.
+func NewLogFromHit(hit *elasticsearch.Hit) (*log.Log, error) {
+	body := map[string]any{}
+	err := json.Unmarshal(hit.Source, &body)
+	if err != nil {
+		return nil, fmt.Errorf("the source of the document %s/%s is not a JSON object: %w", hit.Index, hit.ID, err)
+	}
+	if _, found := body["auditID"]; !found {
+		for _, fieldName := range rawLineFieldNames {
+			line, ok := body[fieldName].(string)
+			if !ok || !strings.HasPrefix(strings.TrimSpace(line), "{") {
+				continue
+			}
+			decoded := map[string]any{}
+			if err := json.Unmarshal([]byte(line), &decoded); err == nil {
+				body = decoded
+				break
+			}
+		}
+	}
+	body[searchHitField] = map[string]any{
+		"index": hit.Index,
+		"id":    hit.ID,
+	}
+	serialized, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize the document %s/%s: %w", hit.Index, hit.ID, err)
+	}
+	return log.NewLogFromYAMLString(string(serialized))
+}
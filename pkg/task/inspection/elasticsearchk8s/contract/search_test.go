@@ -0,0 +1,99 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchk8s_contract
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/api/elasticsearch"
+)
+
+func TestRenderQueryTemplate(t *testing.T) {
+	startTime := time.Date(2025, 1, 1, 9, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	got, err := RenderQueryTemplate(DefaultQueryTemplate, NewQueryTemplateParameters("@timestamp", startTime, startTime.Add(time.Hour)))
+	if err != nil {
+		t.Fatalf("RenderQueryTemplate returned an unexpected error: %v", err)
+	}
+	want := `{"bool":{"filter":[{"range":{"@timestamp":{"gte":"2025-01-01T00:00:00Z","lt":"2025-01-01T01:00:00Z","format":"strict_date_optional_time"}}}]}}`
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("rendered query mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewLogFromHit(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		source       string
+		wantFields   map[string]string
+		wantNoFields []string
+	}{
+		{
+			desc:   "source is the audit log",
+			source: `{"auditID":"a","stage":"ResponseComplete","message":"{\"foo\":\"bar\"}"}`,
+			wantFields: map[string]string{
+				"auditID":             "a",
+				"stage":               "ResponseComplete",
+				"message":             `{"foo":"bar"}`,
+				"elasticsearch.index": "kube-audit",
+				"elasticsearch.id":    "doc",
+			},
+		},
+		{
+			desc:   "audit log stored as the raw line",
+			source: `{"@timestamp":"2025-01-01T00:00:00Z","log":"{\"auditID\":\"a\"}"}`,
+			wantFields: map[string]string{
+				"auditID":          "a",
+				"elasticsearch.id": "doc",
+			},
+			wantNoFields: []string{"@timestamp", "log"},
+		},
+		{
+			desc:   "raw line not in JSON",
+			source: `{"message":"plain text"}`,
+			wantFields: map[string]string{
+				"message":          "plain text",
+				"elasticsearch.id": "doc",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l, err := NewLogFromHit(&elasticsearch.Hit{Index: "kube-audit", ID: "doc", Source: json.RawMessage(tc.source)})
+			if err != nil {
+				t.Fatalf("NewLogFromHit() returned an unexpected error: %v", err)
+			}
+			for fieldPath, want := range tc.wantFields {
+				if got := l.ReadStringOrDefault(fieldPath, ""); got != want {
+					t.Errorf("field %s = %q, want %q", fieldPath, got, want)
+				}
+			}
+			for _, fieldPath := range tc.wantNoFields {
+				if got := l.ReadStringOrDefault(fieldPath, ""); got != "" {
+					t.Errorf("field %s = %q, want no value", fieldPath, got)
+				}
+			}
+		})
+	}
+}
+
+func TestNewLogFromHitWithInvalidSource(t *testing.T) {
+	_, err := NewLogFromHit(&elasticsearch.Hit{Index: "kube-audit", ID: "doc", Source: json.RawMessage(`"text"`)})
+	if err == nil {
+		t.Errorf("NewLogFromHit() returned no error for the source not being a JSON object")
+	}
+}
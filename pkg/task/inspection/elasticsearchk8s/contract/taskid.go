@@ -0,0 +1,63 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchk8s_contract
+
+import (
+	"encoding/json"
+
+	"github.com/kyasbal/khi/pkg/api/elasticsearch"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	commonlogk8sauditv2_contract "github.com/kyasbal/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// ElasticsearchTaskPrefix is the prefixes of IDs used in Elasticsearch/OpenSearch related tasks.
+const ElasticsearchTaskPrefix = "khi.google.com/elasticsearch/"
+
+// InputURLTaskID is the task ID for the form to input the URL of the REST API.
+var InputURLTaskID = taskid.NewDefaultImplementationID[string](ElasticsearchTaskPrefix + "form/url")
+
+// InputIndexPatternTaskID is the task ID for the form to input the index pattern to search.
+var InputIndexPatternTaskID = taskid.NewDefaultImplementationID[string](ElasticsearchTaskPrefix + "form/index-pattern")
+
+// InputUsernameTaskID is the task ID for the form to input the username of the basic authentication.
+var InputUsernameTaskID = taskid.NewDefaultImplementationID[string](ElasticsearchTaskPrefix + "form/username")
+
+// InputPasswordTaskID is the task ID for the secret form to input the password of the basic authentication or the API key.
+var InputPasswordTaskID = taskid.NewDefaultImplementationID[string](ElasticsearchTaskPrefix + "form/password")
+
+// InputTimeFieldTaskID is the task ID for the form to input the field holding the timestamp of documents.
+var InputTimeFieldTaskID = taskid.NewDefaultImplementationID[string](ElasticsearchTaskPrefix + "form/time-field")
+
+// InputQueryTemplateTaskID is the task ID for the form to input the template of the query DSL.
+var InputQueryTemplateTaskID = taskid.NewDefaultImplementationID[string](ElasticsearchTaskPrefix + "form/query-template")
+
+// SearchQueryTaskID is the task ID to render the query DSL from the template with the time range.
+var SearchQueryTaskID = taskid.NewDefaultImplementationID[json.RawMessage](ElasticsearchTaskPrefix + "search-query")
+
+// SearchClientTaskID is the task ID to provide the client of the REST API.
+var SearchClientTaskID = taskid.NewDefaultImplementationID[elasticsearch.SearchAPI](ElasticsearchTaskPrefix + "search-client")
+
+// AuditLogQueryTaskID is the task ID to search kube-apiserver audit logs in place of reading uploaded audit log files.
+var AuditLogQueryTaskID = taskid.NewImplementationID(ossclusterk8s_contract.AuditLogFileReaderTaskID.Ref(), "elasticsearch")
+
+// ElasticsearchK8sAuditLogProviderTaskID is the task ID to provide the audit logs to the common k8s audit log parsers.
+var ElasticsearchK8sAuditLogProviderTaskID = taskid.NewImplementationID(commonlogk8sauditv2_contract.K8sAuditLogProviderRef, "elasticsearch")
+
+// ElasticsearchK8sAuditLogParserTailTaskID is the task ID of the feature task to parse audit logs.
+var ElasticsearchK8sAuditLogParserTailTaskID = taskid.NewImplementationID(commonlogk8sauditv2_contract.K8sAuditLogParserTailRef, "elasticsearch")
+
+// ElasticsearchK8sAuditPermissionDeniedParserTailTaskID is the task ID of the feature task to parse audit logs of denied requests.
+var ElasticsearchK8sAuditPermissionDeniedParserTailTaskID = taskid.NewImplementationID(commonlogk8sauditv2_contract.K8sAuditPermissionDeniedParserTailRef, "elasticsearch")
@@ -0,0 +1,92 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchk8s_impl
+
+import (
+	"context"
+
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/kyasbal/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	elasticsearchk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/elasticsearchk8s/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// AuditLogQueryTask searches kube-apiserver audit logs stored in Elasticsearch/OpenSearch in place of the uploaded audit log files.
+// Each document holds the JSON object written by kube-apiserver, thus the filters and the parsers for OSS clusters process them.
+var AuditLogQueryTask = inspectiontaskbase.NewProgressReportableInspectionTask(
+	elasticsearchk8s_contract.AuditLogQueryTaskID,
+	[]taskid.UntypedTaskReference{
+		elasticsearchk8s_contract.SearchClientTaskID.Ref(),
+		elasticsearchk8s_contract.InputIndexPatternTaskID.Ref(),
+		elasticsearchk8s_contract.SearchQueryTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, tp *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, error) {
+		if taskMode == inspectioncore_contract.TaskModeDryRun {
+			return []*log.Log{}, nil
+		}
+		client := coretask.GetTaskResult(ctx, elasticsearchk8s_contract.SearchClientTaskID.Ref())
+		index := coretask.GetTaskResult(ctx, elasticsearchk8s_contract.InputIndexPatternTaskID.Ref())
+		query := coretask.GetTaskResult(ctx, elasticsearchk8s_contract.SearchQueryTaskID.Ref())
+
+		logs, err := searchDocuments(ctx, client, index, query, tp, &ossclusterk8s_contract.OSSK8sAuditLogCommonFieldSetReader{}, enum.LogTypeAudit)
+		if err != nil {
+			return nil, err
+		}
+
+		var result []*log.Log
+		for _, l := range logs {
+			if !commonlogk8sauditv2_contract.IsParsableAuditLogStage(l) {
+				continue
+			}
+			result = append(result, l)
+		}
+		return result, nil
+	},
+	coretask.WithSelectionPriority(1000),
+	inspectioncore_contract.InspectionTypeLabel(elasticsearchk8s_contract.InspectionTypeID),
+)
+
+var ElasticsearchK8sAuditLogFieldExtractorTask = inspectiontaskbase.NewFieldSetReadTask(
+	elasticsearchk8s_contract.ElasticsearchK8sAuditLogProviderTaskID,
+	ossclusterk8s_contract.NonEventAuditLogFilterTaskID.Ref(),
+	[]log.FieldSetReader{(&ossclusterk8s_contract.OSSK8sAuditLogFieldSetReader{})},
+	inspectioncore_contract.InspectionTypeLabel(elasticsearchk8s_contract.InspectionTypeID),
+)
+
+var ElasticsearchK8sAuditLogParserTailTask = inspectiontaskbase.NewInspectionTask(
+	elasticsearchk8s_contract.ElasticsearchK8sAuditLogParserTailTaskID,
+	commonlogk8sauditv2_contract.ParserTailTaskDependencies(),
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (struct{}, error) {
+		return struct{}{}, nil
+	},
+	inspectioncore_contract.FeatureTaskLabel("Kubernetes Audit Log(v3)", `Gather kube-apiserver audit logs stored in Elasticsearch/OpenSearch and visualize resource modifications.`, enum.LogTypeAudit, 1001, true, elasticsearchk8s_contract.InspectionTypeID), coretask.NewSubsequentTaskRefsTaskLabel(inspectioncore_contract.SerializerTaskID.Ref()),
+)
+
+var ElasticsearchK8sAuditPermissionDeniedParserTailTask = inspectiontaskbase.NewInspectionTask(
+	elasticsearchk8s_contract.ElasticsearchK8sAuditPermissionDeniedParserTailTaskID,
+	[]taskid.UntypedTaskReference{
+		commonlogk8sauditv2_contract.PermissionDeniedLogToTimelineMapperTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (struct{}, error) {
+		return struct{}{}, nil
+	},
+	inspectioncore_contract.FeatureTaskLabel("Kubernetes Permission Denials", `Gather kube-apiserver audit logs of requests denied by the authorizer and show them on timelines grouped by the principal and the resource.`, enum.LogTypeAudit, 1002, false, elasticsearchk8s_contract.InspectionTypeID), coretask.NewSubsequentTaskRefsTaskLabel(inspectioncore_contract.SerializerTaskID.Ref()),
+)
@@ -0,0 +1,140 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchk8s_impl
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/api/elasticsearch"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	"github.com/kyasbal/khi/pkg/model/log"
+	elasticsearchk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/elasticsearchk8s/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+type fakeSearchAPI struct {
+	pages         [][]*elasticsearch.Hit
+	searchInputs  []*elasticsearch.SearchInput
+	clearedScroll []string
+}
+
+// Search implements elasticsearch.SearchAPI.
+func (f *fakeSearchAPI) Search(ctx context.Context, input *elasticsearch.SearchInput) (*elasticsearch.SearchOutput, error) {
+	f.searchInputs = append(f.searchInputs, input)
+	return f.page(0), nil
+}
+
+// Scroll implements elasticsearch.SearchAPI.
+func (f *fakeSearchAPI) Scroll(ctx context.Context, scrollID string, keepAlive time.Duration) (*elasticsearch.SearchOutput, error) {
+	var page int
+	json.Unmarshal([]byte(scrollID), &page)
+	return f.page(page), nil
+}
+
+// ClearScroll implements elasticsearch.SearchAPI.
+func (f *fakeSearchAPI) ClearScroll(ctx context.Context, scrollID string) error {
+	f.clearedScroll = append(f.clearedScroll, scrollID)
+	return nil
+}
+
+func (f *fakeSearchAPI) page(index int) *elasticsearch.SearchOutput {
+	output := &elasticsearch.SearchOutput{ScrollID: "scroll", TotalHits: 3}
+	if index < len(f.pages) {
+		output.Hits = f.pages[index]
+	}
+	nextID, _ := json.Marshal(index + 1)
+	output.ScrollID = string(nextID)
+	return output
+}
+
+var _ elasticsearch.SearchAPI = (*fakeSearchAPI)(nil)
+
+func TestAuditLogQueryTask(t *testing.T) {
+	api := &fakeSearchAPI{
+		pages: [][]*elasticsearch.Hit{
+			{
+				{Index: "kube-audit-2025.01.01", ID: "doc-2", Source: json.RawMessage(`{"auditID":"audit-2","stage":"ResponseComplete","stageTimestamp":"2025-01-01T00:00:02Z"}`)},
+				{Index: "kube-audit-2025.01.01", ID: "doc-3", Source: json.RawMessage(`{"auditID":"audit-3","stage":"RequestReceived","stageTimestamp":"2025-01-01T00:00:03Z"}`)},
+			},
+			{
+				{Index: "kube-audit-2025.01.01", ID: "doc-1", Source: json.RawMessage(`{"@timestamp":"2025-01-01T00:00:01Z","message":"{\"auditID\":\"audit-1\",\"stage\":\"ResponseComplete\",\"stageTimestamp\":\"2025-01-01T00:00:01Z\"}"}`)},
+			},
+		},
+	}
+	query := json.RawMessage(`{"match_all":{}}`)
+
+	ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+	logs, _, err := inspectiontest.RunInspectionTask(ctx, AuditLogQueryTask, inspectioncore_contract.TaskModeRun, map[string]any{},
+		tasktest.NewTaskDependencyValuePair[elasticsearch.SearchAPI](elasticsearchk8s_contract.SearchClientTaskID.Ref(), api),
+		tasktest.NewTaskDependencyValuePair(elasticsearchk8s_contract.InputIndexPatternTaskID.Ref(), "kube-audit-*"),
+		tasktest.NewTaskDependencyValuePair(elasticsearchk8s_contract.SearchQueryTaskID.Ref(), query),
+	)
+	if err != nil {
+		t.Fatalf("AuditLogQueryTask returned an unexpected error: %v", err)
+	}
+
+	wantInputs := []*elasticsearch.SearchInput{
+		{
+			Index:     "kube-audit-*",
+			Query:     query,
+			Sort:      []map[string]string{{"_doc": "asc"}},
+			Size:      elasticsearchk8s_contract.SearchPageSize,
+			KeepAlive: elasticsearchk8s_contract.SearchKeepAlive,
+		},
+	}
+	if diff := cmp.Diff(wantInputs, api.searchInputs); diff != "" {
+		t.Errorf("search inputs mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"3"}, api.clearedScroll); diff != "" {
+		t.Errorf("cleared scrolls mismatch (-want +got):\n%s", diff)
+	}
+	var gotAuditIDs []string
+	for _, l := range logs {
+		gotAuditIDs = append(gotAuditIDs, log.MustGetFieldSet(l, &log.CommonFieldSet{}).DisplayID)
+	}
+	if diff := cmp.Diff([]string{"audit-1", "audit-2"}, gotAuditIDs); diff != "" {
+		t.Errorf("audit logs mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestValidateQueryTemplate(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		value   string
+		wantErr bool
+	}{
+		{desc: "default template", value: elasticsearchk8s_contract.DefaultQueryTemplate},
+		{desc: "template without parameters", value: `{"match_all":{}}`},
+		{desc: "broken template syntax", value: `{"range":{"{{.TimeField":{}}}`, wantErr: true},
+		{desc: "unknown parameter", value: `{"term":{"verb":"{{.Verb}}"}}`, wantErr: true},
+		{desc: "not a JSON object", value: `[{"match_all":{}}]`, wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			message, err := validateQueryTemplate(t.Context(), tc.value)
+			if err != nil {
+				t.Fatalf("validateQueryTemplate returned an unexpected error: %v", err)
+			}
+			if gotErr := message != ""; gotErr != tc.wantErr {
+				t.Errorf("validateQueryTemplate(%q) = %q, want error %v", tc.value, message, tc.wantErr)
+			}
+		})
+	}
+}
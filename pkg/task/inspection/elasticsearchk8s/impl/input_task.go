@@ -0,0 +1,162 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchk8s_impl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/api/elasticsearch"
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	commontimerange_contract "github.com/kyasbal/khi/pkg/task/inspection/commontimerange/contract"
+	elasticsearchk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/elasticsearchk8s/contract"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// previousValueOr returns the default value function using the last value given to the form or the given default value.
+func previousValueOr(defaultValue string) func(ctx context.Context, previousValues []string) (string, error) {
+	return func(ctx context.Context, previousValues []string) (string, error) {
+		if len(previousValues) > 0 {
+			return previousValues[0], nil
+		}
+		return defaultValue, nil
+	}
+}
+
+// trimSpace is the converter to remove spaces around the given value.
+func trimSpace(ctx context.Context, value string) (string, error) {
+	return strings.TrimSpace(value), nil
+}
+
+// validateQueryTemplate returns the validation message when the query template can't be rendered to a JSON object.
+func validateQueryTemplate(ctx context.Context, value string) (string, error) {
+	sampleTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, err := elasticsearchk8s_contract.RenderQueryTemplate(value, elasticsearchk8s_contract.NewQueryTemplateParameters("@timestamp", sampleTime, sampleTime.Add(time.Hour)))
+	if err != nil {
+		return fmt.Sprintf("The query template is invalid: %s", err.Error()), nil
+	}
+	return "", nil
+}
+
+// InputURLTask defines a form task to input the URL of the Elasticsearch/OpenSearch REST API.
+var InputURLTask = formtask.NewTextFormTaskBuilder(elasticsearchk8s_contract.InputURLTaskID, 0, "Elasticsearch/OpenSearch URL").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionResourceIdentifier}).
+	WithPlaceholder("e.g. https://elasticsearch.logging.svc:9200").
+	WithDescription("The base URL of the Elasticsearch or OpenSearch REST API. Documents are read with `_search` and the scroll API under this URL.").
+	WithMarkdown().
+	WithDefaultValueFunc(previousValueOr("")).
+	WithValidator(func(ctx context.Context, value string) (string, error) {
+		parsed, err := url.Parse(strings.TrimSpace(value))
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return "Elasticsearch/OpenSearch URL must be an absolute URL beginning with `http://` or `https://`", nil
+		}
+		return "", nil
+	}).
+	WithConverter(trimSpace).
+	Build()
+
+// InputIndexPatternTask defines a form task to input the index pattern to search.
+var InputIndexPatternTask = formtask.NewTextFormTaskBuilder(elasticsearchk8s_contract.InputIndexPatternTaskID, 0, "Index pattern").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionResourceIdentifier, After: []string{elasticsearchk8s_contract.InputURLTaskID.ReferenceIDString()}}).
+	WithPlaceholder("e.g. kube-audit-*").
+	WithDescription("The index, data stream or alias to search. Wildcards and comma separated lists are accepted.").
+	WithDefaultValueFunc(previousValueOr("kube-audit-*")).
+	WithValidator(func(ctx context.Context, value string) (string, error) {
+		if strings.TrimSpace(value) == "" {
+			return "Index pattern must not be empty", nil
+		}
+		return "", nil
+	}).
+	WithConverter(trimSpace).
+	Build()
+
+// InputUsernameTask defines a form task to input the username of the basic authentication.
+var InputUsernameTask = formtask.NewTextFormTaskBuilder(elasticsearchk8s_contract.InputUsernameTaskID, 0, "Username").
+	WithPosition(inspectionmetadata.FormPosition{Section: elasticsearchk8s_contract.FormSectionElasticsearchAuthentication}).
+	WithDescription("The username of the basic authentication. Leave this empty to send the password as an API key, or to call the REST API without authentication.").
+	WithDefaultValueFunc(previousValueOr("")).
+	WithConverter(trimSpace).
+	Build()
+
+// InputPasswordTask defines a secret form task to input the password of the basic authentication or the API key.
+var InputPasswordTask = formtask.NewSecretFormTaskBuilder(elasticsearchk8s_contract.InputPasswordTaskID, 0, "Password or API key").
+	WithPosition(inspectionmetadata.FormPosition{Section: elasticsearchk8s_contract.FormSectionElasticsearchAuthentication, After: []string{elasticsearchk8s_contract.InputUsernameTaskID.ReferenceIDString()}}).
+	WithDescription("The password of the basic authentication, or the encoded API key when the username is empty.").
+	WithConverter(trimSpace).
+	Build()
+
+// InputTimeFieldTask defines a form task to input the field holding the timestamp of documents.
+var InputTimeFieldTask = formtask.NewTextFormTaskBuilder(elasticsearchk8s_contract.InputTimeFieldTaskID, 0, "Time field").
+	WithPosition(inspectionmetadata.FormPosition{Section: elasticsearchk8s_contract.FormSectionElasticsearchQuery}).
+	WithPlaceholder("e.g. @timestamp").
+	WithDescription("The date field used to select the documents in the time range. It is given to the query template as `{{.TimeField}}`.").
+	WithMarkdown().
+	WithDefaultValueFunc(previousValueOr("@timestamp")).
+	WithValidator(func(ctx context.Context, value string) (string, error) {
+		if strings.TrimSpace(value) == "" {
+			return "Time field must not be empty", nil
+		}
+		return "", nil
+	}).
+	WithConverter(trimSpace).
+	Build()
+
+// InputQueryTemplateTask defines a form task to input the template of the query DSL.
+var InputQueryTemplateTask = formtask.NewTextFormTaskBuilder(elasticsearchk8s_contract.InputQueryTemplateTaskID, 0, "Query template").
+	WithPosition(inspectionmetadata.FormPosition{Section: elasticsearchk8s_contract.FormSectionElasticsearchQuery, After: []string{elasticsearchk8s_contract.InputTimeFieldTaskID.ReferenceIDString()}}).
+	WithDescription("The `query` of the search request written in the query DSL. The value is a Go template receiving `{{.TimeField}}`, `{{.StartTime}}` and `{{.EndTime}}` (RFC3339). Add filters to the default query to narrow down the documents to the kube-apiserver audit logs when the index holds other logs.").
+	WithMarkdown().
+	WithDefaultValueFunc(previousValueOr(elasticsearchk8s_contract.DefaultQueryTemplate)).
+	WithValidator(validateQueryTemplate).
+	WithConverter(trimSpace).
+	Build()
+
+// SearchQueryTask renders the query DSL from the query template with the time range.
+var SearchQueryTask = inspectiontaskbase.NewInspectionTask(elasticsearchk8s_contract.SearchQueryTaskID, []taskid.UntypedTaskReference{
+	elasticsearchk8s_contract.InputTimeFieldTaskID.Ref(),
+	elasticsearchk8s_contract.InputQueryTemplateTaskID.Ref(),
+	commontimerange_contract.InputStartTimeTaskID.Ref(),
+	commontimerange_contract.InputEndTimeTaskID.Ref(),
+}, func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (json.RawMessage, error) {
+	timeField := coretask.GetTaskResult(ctx, elasticsearchk8s_contract.InputTimeFieldTaskID.Ref())
+	queryTemplate := coretask.GetTaskResult(ctx, elasticsearchk8s_contract.InputQueryTemplateTaskID.Ref())
+	startTime := coretask.GetTaskResult(ctx, commontimerange_contract.InputStartTimeTaskID.Ref())
+	endTime := coretask.GetTaskResult(ctx, commontimerange_contract.InputEndTimeTaskID.Ref())
+	return elasticsearchk8s_contract.RenderQueryTemplate(queryTemplate, elasticsearchk8s_contract.NewQueryTemplateParameters(timeField, startTime, endTime))
+})
+
+// SearchClientTask provides the client of the Elasticsearch/OpenSearch REST API.
+var SearchClientTask = inspectiontaskbase.NewInspectionTask(elasticsearchk8s_contract.SearchClientTaskID, []taskid.UntypedTaskReference{
+	elasticsearchk8s_contract.InputURLTaskID.Ref(),
+	elasticsearchk8s_contract.InputUsernameTaskID.Ref(),
+	elasticsearchk8s_contract.InputPasswordTaskID.Ref(),
+}, func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (elasticsearch.SearchAPI, error) {
+	endpoint := coretask.GetTaskResult(ctx, elasticsearchk8s_contract.InputURLTaskID.Ref())
+	auth := elasticsearch.Auth{
+		Username: coretask.GetTaskResult(ctx, elasticsearchk8s_contract.InputUsernameTaskID.Ref()),
+		Password: coretask.GetTaskResult(ctx, elasticsearchk8s_contract.InputPasswordTaskID.Ref()),
+	}
+	return elasticsearch.NewClient(endpoint, auth, http.DefaultClient), nil
+})
@@ -0,0 +1,66 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchk8s_impl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/kyasbal/khi/pkg/api/elasticsearch"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	elasticsearchk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/elasticsearchk8s/contract"
+)
+
+// searchDocuments reads all the documents matching the query from the index and converts them to logs sorted by the timestamp.
+func searchDocuments(ctx context.Context, api elasticsearch.SearchAPI, index string, query json.RawMessage, tp *inspectionmetadata.TaskProgressMetadata, commonFieldSetReader log.FieldSetReader, logType enum.LogType) ([]*log.Log, error) {
+	tp.MarkIndeterminate()
+	hits, err := elasticsearchk8s_contract.SearchAllHits(ctx, api, &elasticsearch.SearchInput{
+		Index: index,
+		Query: query,
+		// Documents are sorted after converting them to logs. Sorting with _doc is the cheapest order for the scroll API.
+		Sort:      []map[string]string{{"_doc": "asc"}},
+		Size:      elasticsearchk8s_contract.SearchPageSize,
+		KeepAlive: elasticsearchk8s_contract.SearchKeepAlive,
+	}, func(readCount int, totalCount int64) {
+		if totalCount > 0 {
+			tp.Update(float32(readCount)/float32(totalCount), fmt.Sprintf("%d/%d documents fetched", readCount, totalCount))
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search documents in %s: %w", index, err)
+	}
+
+	logs := make([]*log.Log, 0, len(hits))
+	for _, hit := range hits {
+		l, err := elasticsearchk8s_contract.NewLogFromHit(hit)
+		if err != nil {
+			return nil, err
+		}
+		err = l.SetFieldSetReader(commonFieldSetReader)
+		if err != nil {
+			return nil, err
+		}
+		l.LogType = logType
+		logs = append(logs, l)
+	}
+	slices.SortStableFunc(logs, func(a, b *log.Log) int {
+		return log.MustGetFieldSet(a, &log.CommonFieldSet{}).Timestamp.Compare(log.MustGetFieldSet(b, &log.CommonFieldSet{}).Timestamp)
+	})
+	return logs, nil
+}
@@ -0,0 +1,310 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardk8s_contract
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ugorji/go/codec"
+)
+
+// eventTimeExtType is the msgpack extension type of EventTime carrying the timestamp in nanosecond precision.
+const eventTimeExtType = 0
+
+// maxDecompressedEntriesSize is the maximum size of the entries decompressed from a CompressedPackedForward message.
+const maxDecompressedEntriesSize = 64 * 1024 * 1024
+
+// ForwardEntry is a log record received with the forward protocol.
+type ForwardEntry struct {
+	Tag    string         `json:"tag"`
+	Time   time.Time      `json:"time"`
+	Record map[string]any `json:"record"`
+}
+
+// newMsgpackHandle returns the msgpack handle decoding maps with string keys to make the records serializable in JSON.
+func newMsgpackHandle() *codec.MsgpackHandle {
+	handle := &codec.MsgpackHandle{}
+	handle.RawToString = true
+	handle.WriteExt = true
+	handle.MapType = reflect.TypeOf(map[string]any(nil))
+	return handle
+}
+
+// ReadForwardMessages decodes the messages sent in the Message, Forward, PackedForward or CompressedPackedForward mode of the forward protocol from the reader until it reaches EOF.
+// onEntries is called with the entries of each message, and the acknowledgement is written to the writer after it when the message requires it with the `chunk` option.
+// Authentication with the shared key (the handshake phase) is not supported.
+func ReadForwardMessages(reader io.Reader, writer io.Writer, onEntries func(entries []*ForwardEntry) error) error {
+	handle := newMsgpackHandle()
+	decoder := codec.NewDecoder(reader, handle)
+	encoder := codec.NewEncoder(writer, handle)
+	for {
+		var message []any
+		err := decoder.Decode(&message)
+		if err != nil {
+			if isEOF(err) {
+				return nil
+			}
+			return fmt.Errorf("failed to decode a forward message: %w", err)
+		}
+		entries, option, err := parseForwardMessage(message)
+		if err != nil {
+			return err
+		}
+		err = onEntries(entries)
+		if err != nil {
+			return err
+		}
+		if chunk, ok := option["chunk"].(string); ok && chunk != "" {
+			err = encoder.Encode(map[string]any{"ack": chunk})
+			if err != nil {
+				return fmt.Errorf("failed to send the acknowledgement: %w", err)
+			}
+		}
+	}
+}
+
+// parseForwardMessage reads the entries and the option from a decoded message in any mode of the forward protocol.
+func parseForwardMessage(message []any) ([]*ForwardEntry, map[string]any, error) {
+	if len(message) < 2 {
+		return nil, nil, fmt.Errorf("a forward message must have at least 2 elements but %d elements were given", len(message))
+	}
+	tag, ok := message[0].(string)
+	if !ok {
+		return nil, nil, fmt.Errorf("the tag of a forward message must be a string but %T was given", message[0])
+	}
+	switch payload := message[1].(type) {
+	case []any:
+		// Forward mode: [tag, [[time, record], ...], option]
+		option := messageOption(message, 2)
+		entries := make([]*ForwardEntry, 0, len(payload))
+		for _, element := range payload {
+			pair, ok := element.([]any)
+			if !ok {
+				return nil, nil, fmt.Errorf("an entry of a forward message must be an array but %T was given", element)
+			}
+			entry, err := newForwardEntry(tag, pair)
+			if err != nil {
+				return nil, nil, err
+			}
+			entries = append(entries, entry)
+		}
+		return entries, option, nil
+	case string:
+		return parsePackedEntries(tag, []byte(payload), messageOption(message, 2))
+	case []byte:
+		return parsePackedEntries(tag, payload, messageOption(message, 2))
+	default:
+		// Message mode: [tag, time, record, option]
+		if len(message) < 3 {
+			return nil, nil, fmt.Errorf("a forward message in the message mode must have at least 3 elements but %d elements were given", len(message))
+		}
+		entry, err := newForwardEntry(tag, message[1:3])
+		if err != nil {
+			return nil, nil, err
+		}
+		return []*ForwardEntry{entry}, messageOption(message, 3), nil
+	}
+}
+
+// parsePackedEntries reads the entries concatenated in a PackedForward or CompressedPackedForward message.
+func parsePackedEntries(tag string, packed []byte, option map[string]any) ([]*ForwardEntry, map[string]any, error) {
+	if compressed, _ := option["compressed"].(string); compressed == "gzip" {
+		decompressed, err := decompressPackedEntries(packed, maxDecompressedEntriesSize)
+		if err != nil {
+			return nil, nil, err
+		}
+		packed = decompressed
+	}
+	decoder := codec.NewDecoderBytes(packed, newMsgpackHandle())
+	var entries []*ForwardEntry
+	for {
+		var pair []any
+		err := decoder.Decode(&pair)
+		if err != nil {
+			if isEOF(err) {
+				return entries, option, nil
+			}
+			return nil, nil, fmt.Errorf("failed to decode the packed entries: %w", err)
+		}
+		entry, err := newForwardEntry(tag, pair)
+		if err != nil {
+			return nil, nil, err
+		}
+		entries = append(entries, entry)
+	}
+}
+
+// decompressPackedEntries decompresses the gzip compressed entries of a CompressedPackedForward message.
+// It returns an error instead of reading further when the decompressed size exceeds the limit, because a small message can expand to a huge payload.
+func decompressPackedEntries(compressed []byte, limit int64) ([]byte, error) {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the compressed entries: %w", err)
+	}
+	defer gzipReader.Close()
+	decompressed, err := io.ReadAll(io.LimitReader(gzipReader, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the compressed entries: %w", err)
+	}
+	if int64(len(decompressed)) > limit {
+		return nil, fmt.Errorf("the decompressed entries exceed the maximum size of %d bytes", limit)
+	}
+	return decompressed, nil
+}
+
+// newForwardEntry reads a pair of the time and the record.
+func newForwardEntry(tag string, pair []any) (*ForwardEntry, error) {
+	if len(pair) < 2 {
+		return nil, fmt.Errorf("an entry must be a pair of the time and the record but %d elements were given", len(pair))
+	}
+	timestamp, err := parseEventTime(pair[0])
+	if err != nil {
+		return nil, err
+	}
+	record, ok := pair[1].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("the record of an entry must be a map but %T was given", pair[1])
+	}
+	return &ForwardEntry{Tag: tag, Time: timestamp, Record: record}, nil
+}
+
+// parseEventTime reads the time of an entry given as the seconds since the epoch or the EventTime extension.
+func parseEventTime(value any) (time.Time, error) {
+	switch typed := value.(type) {
+	case int64:
+		return time.Unix(typed, 0), nil
+	case uint64:
+		return time.Unix(int64(typed), 0), nil
+	case float64:
+		return time.UnixMicro(int64(typed * 1e6)), nil
+	case codec.RawExt:
+		return parseEventTimeExt(&typed)
+	case *codec.RawExt:
+		return parseEventTimeExt(typed)
+	default:
+		return time.Time{}, fmt.Errorf("unsupported time type %T in an entry", value)
+	}
+}
+
+// parseEventTimeExt reads the EventTime extension containing the seconds and the nanoseconds in 32bit big-endian integers.
+func parseEventTimeExt(ext *codec.RawExt) (time.Time, error) {
+	if ext.Tag != eventTimeExtType || len(ext.Data) != 8 {
+		return time.Time{}, fmt.Errorf("unsupported extension type %d with %d bytes in the time of an entry", ext.Tag, len(ext.Data))
+	}
+	seconds := binary.BigEndian.Uint32(ext.Data[:4])
+	nanoseconds := binary.BigEndian.Uint32(ext.Data[4:])
+	return time.Unix(int64(seconds), int64(nanoseconds)), nil
+}
+
+// messageOption returns the option map at the index of the message or an empty map when it's not given.
+func messageOption(message []any, index int) map[string]any {
+	if len(message) > index {
+		if option, ok := message[index].(map[string]any); ok {
+			return option
+		}
+	}
+	return map[string]any{}
+}
+
+// isEOF returns true when the error reports the end of the stream.
+func isEOF(err error) bool {
+	return errors.Is(err, io.EOF) || strings.HasSuffix(err.Error(), io.EOF.Error())
+}
+
+// CaptureForwardedEntries accepts connections on the listener until the duration elapses or ctx is cancelled and passes the entries received from them to onEntries.
+// onEntries is never called concurrently. The listener and the remaining connections are closed when the capture ends.
+// A connection sending malformed messages is closed with a warning, and an error is returned only when onEntries failed.
+func CaptureForwardedEntries(ctx context.Context, listener net.Listener, duration time.Duration, onEntries func(entries []*ForwardEntry) error) error {
+	captureCtx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+
+	var mu sync.Mutex
+	var sinkErr error
+	connections := map[net.Conn]struct{}{}
+	var wg sync.WaitGroup
+
+	go func() {
+		<-captureCtx.Done()
+		listener.Close()
+		mu.Lock()
+		defer mu.Unlock()
+		for conn := range connections {
+			conn.Close()
+		}
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if captureCtx.Err() != nil {
+				break
+			}
+			cancel()
+			wg.Wait()
+			return fmt.Errorf("failed to accept a connection: %w", err)
+		}
+		mu.Lock()
+		if captureCtx.Err() != nil {
+			mu.Unlock()
+			conn.Close()
+			break
+		}
+		connections[conn] = struct{}{}
+		mu.Unlock()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				mu.Lock()
+				defer mu.Unlock()
+				delete(connections, conn)
+				conn.Close()
+			}()
+			err := ReadForwardMessages(conn, conn, func(entries []*ForwardEntry) error {
+				mu.Lock()
+				defer mu.Unlock()
+				if sinkErr != nil {
+					return sinkErr
+				}
+				sinkErr = onEntries(entries)
+				if sinkErr != nil {
+					cancel()
+				}
+				return sinkErr
+			})
+			if err != nil && captureCtx.Err() == nil {
+				slog.WarnContext(ctx, fmt.Sprintf("closed the forward connection from %s: %s", conn.RemoteAddr(), err))
+			}
+		}()
+	}
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	return sinkErr
+}
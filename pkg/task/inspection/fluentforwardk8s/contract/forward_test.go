@@ -0,0 +1,226 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardk8s_contract
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ugorji/go/codec"
+)
+
+// encodeMsgpack returns the concatenated msgpack encoding of the given values.
+func encodeMsgpack(t *testing.T, values ...any) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	encoder := codec.NewEncoder(&buf, newMsgpackHandle())
+	for _, value := range values {
+		if err := encoder.Encode(value); err != nil {
+			t.Fatalf("failed to encode %v: %v", value, err)
+		}
+	}
+	return buf.Bytes()
+}
+
+// eventTime returns the EventTime extension of the given time.
+func eventTime(timestamp time.Time) codec.RawExt {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint32(data[:4], uint32(timestamp.Unix()))
+	binary.BigEndian.PutUint32(data[4:], uint32(timestamp.Nanosecond()))
+	return codec.RawExt{Tag: eventTimeExtType, Data: data}
+}
+
+func TestReadForwardMessages(t *testing.T) {
+	timestamp := time.Date(2025, 1, 1, 0, 0, 0, 123456789, time.UTC)
+	record := map[string]any{"log": "hello"}
+	packed := encodeMsgpack(t, []any{eventTime(timestamp), record}, []any{timestamp.Unix(), record})
+	var compressed bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressed)
+	gzipWriter.Write(packed)
+	gzipWriter.Close()
+
+	testCases := []struct {
+		desc      string
+		input     []byte
+		wantTimes []time.Time
+		wantAck   []any
+	}{
+		{
+			desc:      "message mode with the time in seconds",
+			input:     encodeMsgpack(t, []any{"kube.var.log", timestamp.Unix(), record}),
+			wantTimes: []time.Time{timestamp.Truncate(time.Second)},
+		},
+		{
+			desc:      "message mode with EventTime and chunk option",
+			input:     encodeMsgpack(t, []any{"kube.var.log", eventTime(timestamp), record, map[string]any{"chunk": "abc"}}),
+			wantTimes: []time.Time{timestamp},
+			wantAck:   []any{map[string]any{"ack": "abc"}},
+		},
+		{
+			desc:      "forward mode",
+			input:     encodeMsgpack(t, []any{"kube.var.log", []any{[]any{eventTime(timestamp), record}, []any{timestamp.Unix(), record}}}),
+			wantTimes: []time.Time{timestamp, timestamp.Truncate(time.Second)},
+		},
+		{
+			desc:      "packed forward mode",
+			input:     encodeMsgpack(t, []any{"kube.var.log", packed, map[string]any{"size": 2}}),
+			wantTimes: []time.Time{timestamp, timestamp.Truncate(time.Second)},
+		},
+		{
+			desc:      "compressed packed forward mode",
+			input:     encodeMsgpack(t, []any{"kube.var.log", compressed.Bytes(), map[string]any{"compressed": "gzip", "chunk": "def"}}),
+			wantTimes: []time.Time{timestamp, timestamp.Truncate(time.Second)},
+			wantAck:   []any{map[string]any{"ack": "def"}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var gotTimes []time.Time
+			var ack bytes.Buffer
+			err := ReadForwardMessages(bytes.NewReader(tc.input), &ack, func(entries []*ForwardEntry) error {
+				for _, entry := range entries {
+					if entry.Tag != "kube.var.log" {
+						t.Errorf("Tag = %q, want %q", entry.Tag, "kube.var.log")
+					}
+					if diff := cmp.Diff(record, entry.Record); diff != "" {
+						t.Errorf("Record mismatch (-want +got):\n%s", diff)
+					}
+					gotTimes = append(gotTimes, entry.Time.UTC())
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("ReadForwardMessages() returned an unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantTimes, gotTimes); diff != "" {
+				t.Errorf("entry times mismatch (-want +got):\n%s", diff)
+			}
+
+			var gotAck []any
+			decoder := codec.NewDecoder(&ack, newMsgpackHandle())
+			for ack.Len() > 0 {
+				var value any
+				if err := decoder.Decode(&value); err != nil {
+					t.Fatalf("failed to decode the acknowledgement: %v", err)
+				}
+				gotAck = append(gotAck, value)
+			}
+			if diff := cmp.Diff(tc.wantAck, gotAck); diff != "" {
+				t.Errorf("acknowledgements mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReadForwardMessagesWithMalformedMessage(t *testing.T) {
+	input := encodeMsgpack(t, []any{"kube.var.log", "not-a-time", "not-a-record"})
+	err := ReadForwardMessages(bytes.NewReader(input), &bytes.Buffer{}, func(entries []*ForwardEntry) error {
+		return nil
+	})
+	if err == nil {
+		t.Errorf("ReadForwardMessages() returned no error for a malformed message")
+	}
+}
+
+func TestDecompressPackedEntries(t *testing.T) {
+	payload := bytes.Repeat([]byte("a"), 1024)
+	var compressed bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressed)
+	gzipWriter.Write(payload)
+	gzipWriter.Close()
+
+	testCases := []struct {
+		desc    string
+		input   []byte
+		limit   int64
+		wantErr bool
+	}{
+		{
+			desc:  "within the limit",
+			input: compressed.Bytes(),
+			limit: 1024,
+		},
+		{
+			desc:    "exceeding the limit",
+			input:   compressed.Bytes(),
+			limit:   1023,
+			wantErr: true,
+		},
+		{
+			desc:    "not gzip compressed",
+			input:   payload,
+			limit:   1024,
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := decompressPackedEntries(tc.input, tc.limit)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("decompressPackedEntries() returned no error, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decompressPackedEntries() returned an unexpected error: %v", err)
+			}
+			if !bytes.Equal(got, payload) {
+				t.Errorf("decompressPackedEntries() returned %d bytes, want the original %d bytes", len(got), len(payload))
+			}
+		})
+	}
+}
+
+func TestCaptureForwardedEntries(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	timestamp := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	go func() {
+		for i := 0; i < 2; i++ {
+			conn, err := net.Dial("tcp", listener.Addr().String())
+			if err != nil {
+				t.Errorf("failed to connect: %v", err)
+				return
+			}
+			conn.Write(encodeMsgpack(t, []any{"kube.var.log", timestamp.Unix(), map[string]any{"log": "hello"}}))
+			conn.Close()
+		}
+	}()
+
+	var got []*ForwardEntry
+	err = CaptureForwardedEntries(context.Background(), listener, 500*time.Millisecond, func(entries []*ForwardEntry) error {
+		got = append(got, entries...)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("CaptureForwardedEntries() returned an unexpected error: %v", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("CaptureForwardedEntries() received %d entries, want 2", len(got))
+	}
+	if _, err := net.Dial("tcp", listener.Addr().String()); err == nil {
+		t.Errorf("the listener was not closed after the capture")
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardk8s_contract
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/common/structured"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/kyasbal/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	googlecloudlogk8scontainer_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8scontainer/contract"
+	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// forwardField is the field added to the captured logs to keep the tag, the time and the ID of the forwarded entry.
+const forwardField = "forward"
+
+// maxCapturedLineSize is the maximum size of a line of the stored entries.
+const maxCapturedLineSize = 16 * 1024 * 1024
+
+// messageFields are the fields holding the raw log line in the order of the priority.
+// Fluent Bit writes the line read with the tail input in `log`, and Fluentd writes it in `message` with the most of parsers.
+var messageFields = []string{"log", "message"}
+
+// CapturedLogs is the logs read from the captured entries grouped by the parsers processing them.
+type CapturedLogs struct {
+	AuditLogs     []*log.Log
+	NodeLogs      []*log.Log
+	ContainerLogs []*log.Log
+	// SkippedEntries is the count of the entries not recognized as any of the logs.
+	SkippedEntries int
+}
+
+// ReadCapturedLogs reads the entries stored in JSON lines and classifies them into the audit, node and container logs.
+//   - Entries containing a Kubernetes audit event either in the record itself or in the raw log line are read as audit logs. Only the events at the ResponseComplete stage are used as the OSS audit log reader does.
+//   - Entries with the fields of journald (`MESSAGE` with `_HOSTNAME`, `SYSLOG_IDENTIFIER` or `_SYSTEMD_UNIT`) or a raw syslog line are read as node logs.
+//   - Entries with the `kubernetes` metadata added by the Kubernetes filter of Fluent Bit or Fluentd are read as container logs.
+func ReadCapturedLogs(reader io.Reader) (*CapturedLogs, error) {
+	result := &CapturedLogs{}
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxCapturedLineSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(strings.TrimSpace(string(line))) == 0 {
+			continue
+		}
+		var entry ForwardEntry
+		err := json.Unmarshal(line, &entry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read a captured entry: %w", err)
+		}
+		id := entryID(line)
+		l, logType, err := newLogFromForwardEntry(&entry, id)
+		if err != nil {
+			return nil, err
+		}
+		switch logType {
+		case enum.LogTypeAudit:
+			if !commonlogk8sauditv2_contract.IsParsableAuditLogStage(l) {
+				continue
+			}
+			err = l.SetFieldSetReader(&ossclusterk8s_contract.OSSK8sAuditLogCommonFieldSetReader{})
+			if err != nil {
+				return nil, err
+			}
+			result.AuditLogs = append(result.AuditLogs, l)
+		case enum.LogTypeNode:
+			err = l.SetFieldSetReader(&ossclusterk8s_contract.OSSJournaldCommonFieldSetReader{})
+			if err != nil {
+				return nil, err
+			}
+			result.NodeLogs = append(result.NodeLogs, l)
+		case enum.LogTypeContainer:
+			err = l.SetFieldSetReader(&ForwardContainerLogCommonFieldSetReader{})
+			if err != nil {
+				return nil, err
+			}
+			result.ContainerLogs = append(result.ContainerLogs, l)
+		default:
+			result.SkippedEntries++
+			continue
+		}
+		l.LogType = logType
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read the captured entries: %w", err)
+	}
+	return result, nil
+}
+
+// newLogFromForwardEntry converts the entry to the log in the shape expected by the parser of the returned log type.
+// It returns a nil log with enum.LogTypeUnknown when the entry isn't recognized.
+func newLogFromForwardEntry(entry *ForwardEntry, id string) (*log.Log, enum.LogType, error) {
+	if event, ok := auditEventFromRecord(entry.Record); ok {
+		l, err := newLogFromMap(event)
+		return l, enum.LogTypeAudit, err
+	}
+	if isJournaldRecord(entry.Record) {
+		body := make(map[string]any, len(entry.Record)+2)
+		for key, value := range entry.Record {
+			body[key] = value
+		}
+		if _, found := body["__REALTIME_TIMESTAMP"]; !found {
+			body["__REALTIME_TIMESTAMP"] = strconv.FormatInt(entry.Time.UnixMicro(), 10)
+		}
+		if _, found := body["__CURSOR"]; !found {
+			body["__CURSOR"] = id
+		}
+		l, err := newLogFromMap(body)
+		return l, enum.LogTypeNode, err
+	}
+	message, hasMessage := recordMessage(entry.Record)
+	if kubernetes, ok := entry.Record["kubernetes"].(map[string]any); ok && kubernetes["pod_name"] != nil {
+		body := make(map[string]any, len(entry.Record)+1)
+		for key, value := range entry.Record {
+			body[key] = value
+		}
+		body[forwardField] = map[string]any{
+			"tag":     entry.Tag,
+			"time":    entry.Time.Format(time.RFC3339Nano),
+			"id":      id,
+			"message": strings.TrimRight(message, "\r\n"),
+		}
+		l, err := newLogFromMap(body)
+		return l, enum.LogTypeContainer, err
+	}
+	if hasMessage && ossclusterk8s_contract.IsSyslogLine([]byte(message)) {
+		l, err := ossclusterk8s_contract.NewLogFromSyslogLine(message, entry.Time)
+		return l, enum.LogTypeNode, err
+	}
+	return nil, enum.LogTypeUnknown, nil
+}
+
+// auditEventFromRecord returns the Kubernetes audit event in the record or in the raw log line of the record.
+func auditEventFromRecord(record map[string]any) (map[string]any, bool) {
+	if isAuditEvent(record) {
+		return record, true
+	}
+	message, ok := recordMessage(record)
+	if !ok || !strings.HasPrefix(strings.TrimSpace(message), "{") {
+		return nil, false
+	}
+	var event map[string]any
+	if err := json.Unmarshal([]byte(message), &event); err != nil || !isAuditEvent(event) {
+		return nil, false
+	}
+	return event, true
+}
+
+// isAuditEvent returns true when the map is a Kubernetes audit event.
+func isAuditEvent(body map[string]any) bool {
+	apiVersion, _ := body["apiVersion"].(string)
+	kind, _ := body["kind"].(string)
+	return strings.HasPrefix(apiVersion, "audit.k8s.io/") && kind == "Event"
+}
+
+// isJournaldRecord returns true when the record has the fields of a journald log read with the systemd input.
+func isJournaldRecord(record map[string]any) bool {
+	if _, ok := record["MESSAGE"]; !ok {
+		return false
+	}
+	for _, field := range []string{"_HOSTNAME", "SYSLOG_IDENTIFIER", "_SYSTEMD_UNIT"} {
+		if _, ok := record[field]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// recordMessage returns the raw log line in the record.
+func recordMessage(record map[string]any) (string, bool) {
+	for _, field := range messageFields {
+		if message, ok := record[field].(string); ok {
+			return message, true
+		}
+	}
+	return "", false
+}
+
+// entryID returns the ID of the captured entry computed from the stored line.
+func entryID(line []byte) string {
+	hash := fnv.New64a()
+	hash.Write(line)
+	return fmt.Sprintf("%016x", hash.Sum64())
+}
+
+func newLogFromMap(body map[string]any) (*log.Log, error) {
+	serialized, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize a captured entry: %w", err)
+	}
+	return log.NewLogFromYAMLString(string(serialized))
+}
+
+// ForwardContainerLogCommonFieldSetReader implements log.FieldSetReader for log.CommonFieldSet{} from the captured container logs.
+type ForwardContainerLogCommonFieldSetReader struct{}
+
+// FieldSetKind implements log.FieldSetReader.
+func (f *ForwardContainerLogCommonFieldSetReader) FieldSetKind() string {
+	return (&log.CommonFieldSet{}).Kind()
+}
+
+// Read implements log.FieldSetReader.
+func (f *ForwardContainerLogCommonFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	result := &log.CommonFieldSet{}
+	result.DisplayID = reader.ReadStringOrDefault(forwardField+".id", "unknown")
+	timestamp, err := reader.ReadTimestamp(forwardField + ".time")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the timestamp of the captured container log: %w", err)
+	}
+	result.Timestamp = timestamp
+	result.Severity = enum.SeverityUnknown
+	return result, nil
+}
+
+var _ log.FieldSetReader = (*ForwardContainerLogCommonFieldSetReader)(nil)
+
+// ForwardContainerLogFieldSetReader implements log.FieldSetReader for googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet{} from the captured container logs.
+type ForwardContainerLogFieldSetReader struct{}
+
+// FieldSetKind implements log.FieldSetReader.
+func (f *ForwardContainerLogFieldSetReader) FieldSetKind() string {
+	return (&googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet{}).Kind()
+}
+
+// Read implements log.FieldSetReader.
+func (f *ForwardContainerLogFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	return &googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet{
+		Namespace:     reader.ReadStringOrDefault("kubernetes.namespace_name", "unknown"),
+		PodName:       reader.ReadStringOrDefault("kubernetes.pod_name", "unknown"),
+		ContainerName: reader.ReadStringOrDefault("kubernetes.container_name", "unknown"),
+		Message:       reader.ReadStringOrDefault(forwardField+".message", ""),
+	}, nil
+}
+
+var _ log.FieldSetReader = (*ForwardContainerLogFieldSetReader)(nil)
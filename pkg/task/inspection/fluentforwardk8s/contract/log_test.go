@@ -0,0 +1,104 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardk8s_contract
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudlogk8scontainer_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8scontainer/contract"
+)
+
+func TestReadCapturedLogs(t *testing.T) {
+	timestamp := time.Date(2025, 1, 1, 0, 0, 0, 123000000, time.UTC)
+	auditEvent := map[string]any{
+		"apiVersion":     "audit.k8s.io/v1",
+		"kind":           "Event",
+		"stage":          "ResponseComplete",
+		"auditID":        "audit-1",
+		"verb":           "create",
+		"stageTimestamp": "2025-01-01T00:00:00.000000Z",
+		"objectRef":      map[string]any{"resource": "pods", "namespace": "default", "name": "nginx"},
+	}
+	serializedAuditEvent, err := json.Marshal(auditEvent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := []*ForwardEntry{
+		{Tag: "audit", Time: timestamp, Record: auditEvent},
+		{Tag: "audit", Time: timestamp, Record: map[string]any{"log": string(serializedAuditEvent)}},
+		{Tag: "audit", Time: timestamp, Record: map[string]any{"apiVersion": "audit.k8s.io/v1", "kind": "Event", "stage": "RequestReceived"}},
+		{Tag: "systemd", Time: timestamp, Record: map[string]any{"_HOSTNAME": "node-1", "SYSLOG_IDENTIFIER": "kubelet", "MESSAGE": "started", "PRIORITY": "6"}},
+		{Tag: "syslog", Time: timestamp, Record: map[string]any{"message": "<30>Jan  1 00:00:00 node-2 containerd[123]: started"}},
+		{Tag: "kube.var.log", Time: timestamp, Record: map[string]any{
+			"log":        "hello\n",
+			"stream":     "stdout",
+			"kubernetes": map[string]any{"namespace_name": "default", "pod_name": "nginx", "container_name": "nginx"},
+		}},
+		{Tag: "other", Time: timestamp, Record: map[string]any{"foo": "bar"}},
+	}
+	var lines []string
+	for _, entry := range entries {
+		serialized, err := json.Marshal(entry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines = append(lines, string(serialized))
+	}
+
+	got, err := ReadCapturedLogs(strings.NewReader(strings.Join(lines, "\n")))
+	if err != nil {
+		t.Fatalf("ReadCapturedLogs() returned an unexpected error: %v", err)
+	}
+	if len(got.AuditLogs) != 2 || len(got.NodeLogs) != 2 || len(got.ContainerLogs) != 1 || got.SkippedEntries != 1 {
+		t.Fatalf("ReadCapturedLogs() returned %d audit logs, %d node logs, %d container logs and %d skipped entries, want 2, 2, 1 and 1", len(got.AuditLogs), len(got.NodeLogs), len(got.ContainerLogs), got.SkippedEntries)
+	}
+	for _, l := range got.AuditLogs {
+		if got := l.ReadStringOrDefault("auditID", ""); got != "audit-1" {
+			t.Errorf("auditID = %q, want %q", got, "audit-1")
+		}
+	}
+
+	nodeLog := log.MustGetFieldSet(got.NodeLogs[0], &log.CommonFieldSet{})
+	if !nodeLog.Timestamp.Equal(timestamp) {
+		t.Errorf("node log timestamp = %v, want %v", nodeLog.Timestamp, timestamp)
+	}
+	if got := got.NodeLogs[1].ReadStringOrDefault("_HOSTNAME", ""); got != "node-2" {
+		t.Errorf("_HOSTNAME of the syslog line = %q, want %q", got, "node-2")
+	}
+
+	containerLog := got.ContainerLogs[0]
+	commonFieldSet := log.MustGetFieldSet(containerLog, &log.CommonFieldSet{})
+	if !commonFieldSet.Timestamp.Equal(timestamp) {
+		t.Errorf("container log timestamp = %v, want %v", commonFieldSet.Timestamp, timestamp)
+	}
+	if len(commonFieldSet.DisplayID) != 16 {
+		t.Errorf("DisplayID = %q, want a 16 characters hash", commonFieldSet.DisplayID)
+	}
+	err = containerLog.SetFieldSetReader(&ForwardContainerLogFieldSetReader{})
+	if err != nil {
+		t.Fatalf("SetFieldSetReader() returned an unexpected error: %v", err)
+	}
+	containerFieldSet := log.MustGetFieldSet(containerLog, &googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet{})
+	if got, want := containerFieldSet.ResourcePath().Path, "core/v1#pod#default#nginx#nginx"; got != want {
+		t.Errorf("ResourcePath() = %q, want %q", got, want)
+	}
+	if containerFieldSet.Message != "hello" {
+		t.Errorf("Message = %q, want %q", containerFieldSet.Message, "hello")
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardk8s_contract
+
+import (
+	"time"
+
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/kyasbal/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	googlecloudlogk8snode_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8snode/contract"
+	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// FluentForwardTaskPrefix is the prefixes of IDs used in forward capture related tasks.
+const FluentForwardTaskPrefix = "khi.google.com/fluent-forward/"
+
+// InputListenAddressTaskID is the task ID for the form to input the address to listen for the forward protocol.
+var InputListenAddressTaskID = taskid.NewDefaultImplementationID[string](FluentForwardTaskPrefix + "form/listen-address")
+
+// InputCaptureDurationTaskID is the task ID for the form to input the duration to receive logs.
+var InputCaptureDurationTaskID = taskid.NewDefaultImplementationID[time.Duration](FluentForwardTaskPrefix + "form/duration")
+
+// CaptureTaskID is the task ID to receive the forwarded logs for the capture duration.
+var CaptureTaskID = taskid.NewDefaultImplementationID[*CapturedLogs](FluentForwardTaskPrefix + "capture")
+
+// AuditLogReaderTaskID is the task ID to provide the captured audit logs in place of reading uploaded audit log files.
+var AuditLogReaderTaskID = taskid.NewImplementationID(ossclusterk8s_contract.AuditLogFileReaderTaskID.Ref(), "fluent-forward")
+
+// AuditLogProviderTaskID is the task ID to provide the captured audit logs to the common k8s audit log parsers.
+var AuditLogProviderTaskID = taskid.NewImplementationID(commonlogk8sauditv2_contract.K8sAuditLogProviderRef, "fluent-forward")
+
+// AuditLogParserTailTaskID is the task ID of the feature task to parse the captured audit logs.
+var AuditLogParserTailTaskID = taskid.NewImplementationID(commonlogk8sauditv2_contract.K8sAuditLogParserTailRef, "fluent-forward")
+
+// NodeLogReaderTaskID is the task ID to provide the captured node logs as the source of the node log parsers.
+var NodeLogReaderTaskID = taskid.NewImplementationID(googlecloudlogk8snode_contract.ListLogEntriesTaskID.Ref(), "fluent-forward")
+
+// NodeLogCommonFieldSetReaderTaskID is the task ID to read the fieldset used by the node log parsers from the captured node logs.
+var NodeLogCommonFieldSetReaderTaskID = taskid.NewImplementationID(googlecloudlogk8snode_contract.CommonFieldsetReaderTaskID.Ref(), "fluent-forward")
+
+// NodeLogParserTailTaskID is the task ID of the feature task to parse the captured node logs.
+var NodeLogParserTailTaskID = taskid.NewDefaultImplementationID[struct{}](FluentForwardTaskPrefix + "node-log-parser-tail")
+
+// ContainerLogReaderTaskID is the task ID to provide the captured container logs.
+var ContainerLogReaderTaskID = taskid.NewDefaultImplementationID[[]*log.Log](FluentForwardTaskPrefix + "container-log-reader")
+
+// ContainerLogFieldSetReaderTaskID is the task ID to read the container log fieldset from the captured container logs.
+var ContainerLogFieldSetReaderTaskID = taskid.NewDefaultImplementationID[[]*log.Log](FluentForwardTaskPrefix + "container-log-fieldset-reader")
+
+// ContainerLogIngesterTaskID is the task ID to ingest the captured container logs to the history.
+var ContainerLogIngesterTaskID = taskid.NewDefaultImplementationID[[]*log.Log](FluentForwardTaskPrefix + "container-log-ingester")
+
+// ContainerLogGrouperTaskID is the task ID to group the captured container logs by the container.
+var ContainerLogGrouperTaskID = taskid.NewDefaultImplementationID[inspectiontaskbase.LogGroupMap](FluentForwardTaskPrefix + "container-log-grouper")
+
+// ContainerLogToTimelineMapperTaskID is the task ID of the feature task to map the captured container logs to the timelines of the containers.
+var ContainerLogToTimelineMapperTaskID = taskid.NewDefaultImplementationID[struct{}](FluentForwardTaskPrefix + "container-log-mapper")
@@ -0,0 +1,63 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardk8s_impl
+
+import (
+	"context"
+
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/kyasbal/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	fluentforwardk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/fluentforwardk8s/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// AuditLogReaderTask provides the captured audit logs in place of the uploaded audit log files.
+var AuditLogReaderTask = inspectiontaskbase.NewInspectionTask(
+	fluentforwardk8s_contract.AuditLogReaderTaskID,
+	[]taskid.UntypedTaskReference{
+		fluentforwardk8s_contract.CaptureTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) ([]*log.Log, error) {
+		if taskMode == inspectioncore_contract.TaskModeDryRun {
+			return []*log.Log{}, nil
+		}
+		capturedLogs := coretask.GetTaskResult(ctx, fluentforwardk8s_contract.CaptureTaskID.Ref())
+		sortLogsByTimestamp(capturedLogs.AuditLogs)
+		return capturedLogs.AuditLogs, nil
+	},
+	coretask.WithSelectionPriority(1000),
+	inspectioncore_contract.InspectionTypeLabel(fluentforwardk8s_contract.InspectionTypeID),
+)
+
+var AuditLogFieldExtractorTask = inspectiontaskbase.NewFieldSetReadTask(
+	fluentforwardk8s_contract.AuditLogProviderTaskID,
+	ossclusterk8s_contract.NonEventAuditLogFilterTaskID.Ref(),
+	[]log.FieldSetReader{(&ossclusterk8s_contract.OSSK8sAuditLogFieldSetReader{})},
+	inspectioncore_contract.InspectionTypeLabel(fluentforwardk8s_contract.InspectionTypeID),
+)
+
+var AuditLogParserTailTask = inspectiontaskbase.NewInspectionTask(
+	fluentforwardk8s_contract.AuditLogParserTailTaskID,
+	commonlogk8sauditv2_contract.ParserTailTaskDependencies(),
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (struct{}, error) {
+		return struct{}{}, nil
+	},
+	inspectioncore_contract.FeatureTaskLabel("Kubernetes Audit Log", `Gather kubernetes audit logs forwarded during the capture and visualize resource modifications.`, enum.LogTypeAudit, 1001, true, fluentforwardk8s_contract.InspectionTypeID), coretask.NewSubsequentTaskRefsTaskLabel(inspectioncore_contract.SerializerTaskID.Ref()),
+)
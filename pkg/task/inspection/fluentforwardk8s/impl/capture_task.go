@@ -0,0 +1,148 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardk8s_impl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/server/upload"
+	fluentforwardk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/fluentforwardk8s/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// progressUpdateInterval is the interval to update the progress while receiving logs.
+const progressUpdateInterval = time.Second
+
+// CaptureTask listens for the forward protocol for the capture duration and stores the received entries in JSON lines with the upload store provider.
+// The stored entries are read back and classified into the audit, node and container logs after the capture. The time range of the inspection is the capture window.
+var CaptureTask = inspectiontaskbase.NewProgressReportableInspectionTask(
+	fluentforwardk8s_contract.CaptureTaskID,
+	[]taskid.UntypedTaskReference{
+		fluentforwardk8s_contract.InputListenAddressTaskID.Ref(),
+		fluentforwardk8s_contract.InputCaptureDurationTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, tp *inspectionmetadata.TaskProgressMetadata) (*fluentforwardk8s_contract.CapturedLogs, error) {
+		if taskMode == inspectioncore_contract.TaskModeDryRun {
+			return &fluentforwardk8s_contract.CapturedLogs{}, nil
+		}
+		address := coretask.GetTaskResult(ctx, fluentforwardk8s_contract.InputListenAddressTaskID.Ref())
+		duration := coretask.GetTaskResult(ctx, fluentforwardk8s_contract.InputCaptureDurationTaskID.Ref())
+
+		store := upload.DefaultUploadFileStore
+		if store == nil {
+			return nil, errors.New("upload file store is not initialized")
+		}
+		writableProvider, ok := store.StoreProvider.(upload.DirectWritableUploadFileStoreProvider)
+		if !ok {
+			return nil, errors.New("upload file store provider doesn't support writing the captured logs")
+		}
+		token := store.GetUploadToken(formtask.GenerateUploadIDWithTaskContext(ctx, "forwarded-entries"), &upload.NopWaitUploadFileVerifier{})
+
+		listener, err := net.Listen("tcp", address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to listen on %s: %w", address, err)
+		}
+
+		startTime := time.Now()
+		var receivedCount atomic.Int64
+		progressCtx, cancelProgress := context.WithCancel(ctx)
+		go func() {
+			ticker := time.NewTicker(progressUpdateInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-progressCtx.Done():
+					return
+				case <-ticker.C:
+					elapsed := time.Since(startTime)
+					tp.Update(float32(elapsed)/float32(duration), fmt.Sprintf("%d entries received on %s (%s/%s)", receivedCount.Load(), address, elapsed.Truncate(time.Second), duration))
+				}
+			}
+		}()
+		err = storeForwardedEntries(writableProvider, token, func(encoder *json.Encoder) error {
+			return fluentforwardk8s_contract.CaptureForwardedEntries(ctx, listener, duration, func(entries []*fluentforwardk8s_contract.ForwardEntry) error {
+				for _, entry := range entries {
+					if err := encoder.Encode(entry); err != nil {
+						return err
+					}
+				}
+				receivedCount.Add(int64(len(entries)))
+				return nil
+			})
+		})
+		cancelProgress()
+		if err != nil {
+			return nil, err
+		}
+		setHeaderTimeRange(ctx, startTime, time.Now())
+
+		tp.MarkIndeterminate()
+		reader, err := store.StoreProvider.Read(token)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the captured logs: %w", err)
+		}
+		defer reader.Close()
+		logs, err := fluentforwardk8s_contract.ReadCapturedLogs(reader)
+		if err != nil {
+			return nil, err
+		}
+		slog.InfoContext(ctx, fmt.Sprintf("received %d entries: %d audit logs, %d node logs, %d container logs, %d entries skipped", receivedCount.Load(), len(logs.AuditLogs), len(logs.NodeLogs), len(logs.ContainerLogs), logs.SkippedEntries))
+		return logs, nil
+	},
+)
+
+// storeForwardedEntries writes the entries encoded in the capture function to the store provider while receiving them.
+func storeForwardedEntries(provider upload.DirectWritableUploadFileStoreProvider, token upload.UploadToken, capture func(encoder *json.Encoder) error) error {
+	pipeReader, pipeWriter := io.Pipe()
+	writeErr := make(chan error, 1)
+	go func() {
+		err := provider.Write(token, pipeReader)
+		pipeReader.CloseWithError(err)
+		writeErr <- err
+	}()
+	captureErr := capture(json.NewEncoder(pipeWriter))
+	pipeWriter.CloseWithError(captureErr)
+	err := <-writeErr
+	if captureErr != nil {
+		return captureErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to store the captured logs: %w", err)
+	}
+	return nil
+}
+
+// setHeaderTimeRange sets the time range in the header metadata to the capture window.
+func setHeaderTimeRange(ctx context.Context, startTime, endTime time.Time) {
+	metadataSet := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
+	header := typedmap.GetOrDefault(metadataSet, inspectionmetadata.HeaderMetadataKey, &inspectionmetadata.HeaderMetadata{})
+	header.StartTimeUnixSeconds = startTime.Unix()
+	header.EndTimeUnixSeconds = endTime.Unix()
+}
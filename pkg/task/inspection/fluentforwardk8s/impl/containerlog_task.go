@@ -0,0 +1,110 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardk8s_impl
+
+import (
+	"context"
+
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/history"
+	"github.com/kyasbal/khi/pkg/model/log"
+	fluentforwardk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/fluentforwardk8s/contract"
+	googlecloudlogk8scontainer_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8scontainer/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// ContainerLogReaderTask provides the captured logs with the metadata added by the Kubernetes filter of Fluent Bit or Fluentd.
+var ContainerLogReaderTask = inspectiontaskbase.NewInspectionTask(
+	fluentforwardk8s_contract.ContainerLogReaderTaskID,
+	[]taskid.UntypedTaskReference{
+		fluentforwardk8s_contract.CaptureTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) ([]*log.Log, error) {
+		if taskMode == inspectioncore_contract.TaskModeDryRun {
+			return []*log.Log{}, nil
+		}
+		capturedLogs := coretask.GetTaskResult(ctx, fluentforwardk8s_contract.CaptureTaskID.Ref())
+		sortLogsByTimestamp(capturedLogs.ContainerLogs)
+		return capturedLogs.ContainerLogs, nil
+	},
+)
+
+var ContainerLogFieldSetReaderTask = inspectiontaskbase.NewFieldSetReadTask(
+	fluentforwardk8s_contract.ContainerLogFieldSetReaderTaskID,
+	fluentforwardk8s_contract.ContainerLogReaderTaskID.Ref(),
+	[]log.FieldSetReader{
+		&fluentforwardk8s_contract.ForwardContainerLogFieldSetReader{},
+	},
+)
+
+var ContainerLogIngesterTask = inspectiontaskbase.NewLogIngesterTask(fluentforwardk8s_contract.ContainerLogIngesterTaskID, fluentforwardk8s_contract.ContainerLogReaderTaskID.Ref())
+
+var ContainerLogGrouperTask = inspectiontaskbase.NewLogGrouperTask(
+	fluentforwardk8s_contract.ContainerLogGrouperTaskID,
+	fluentforwardk8s_contract.ContainerLogFieldSetReaderTaskID.Ref(),
+	func(ctx context.Context, l *log.Log) string {
+		// container log parser is stateless and it doesn't require grouping to work, but grouping them by the container for better performance to process them in parallel.
+		containerFields, err := log.GetFieldSet(l, &googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet{})
+		if err != nil {
+			return "unknown"
+		}
+		return containerFields.ResourcePath().Path
+	},
+)
+
+var ContainerLogToTimelineMapperTask = inspectiontaskbase.NewLogToTimelineMapperTask[struct{}](fluentforwardk8s_contract.ContainerLogToTimelineMapperTaskID, &containerLogToTimelineMapperTaskSetting{},
+	inspectioncore_contract.FeatureTaskLabel(`Kubernetes container logs`,
+		`Gather stdout/stderr logs of containers forwarded during the capture to visualize them on the timeline under an associated Pod.`,
+		enum.LogTypeContainer,
+		4000,
+		true,
+		fluentforwardk8s_contract.InspectionTypeID),
+)
+
+type containerLogToTimelineMapperTaskSetting struct {
+}
+
+// Dependencies implements inspectiontaskbase.LogToTimelineMapper.
+func (c *containerLogToTimelineMapperTaskSetting) Dependencies() []taskid.UntypedTaskReference {
+	return []taskid.UntypedTaskReference{}
+}
+
+// GroupedLogTask implements inspectiontaskbase.LogToTimelineMapper.
+func (c *containerLogToTimelineMapperTaskSetting) GroupedLogTask() taskid.TaskReference[inspectiontaskbase.LogGroupMap] {
+	return fluentforwardk8s_contract.ContainerLogGrouperTaskID.Ref()
+}
+
+// LogIngesterTask implements inspectiontaskbase.LogToTimelineMapper.
+func (c *containerLogToTimelineMapperTaskSetting) LogIngesterTask() taskid.TaskReference[[]*log.Log] {
+	return fluentforwardk8s_contract.ContainerLogIngesterTaskID.Ref()
+}
+
+// ProcessLogByGroup implements inspectiontaskbase.LogToTimelineMapper.
+func (c *containerLogToTimelineMapperTaskSetting) ProcessLogByGroup(ctx context.Context, l *log.Log, cs *history.ChangeSet, builder *history.Builder, prevGroupData struct{}) (struct{}, error) {
+	containerFields, err := log.GetFieldSet(l, &googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet{})
+	if err != nil {
+		return struct{}{}, nil
+	}
+
+	cs.AddEvent(containerFields.ResourcePath())
+	cs.SetLogSummary(containerFields.Message)
+	googlecloudlogk8scontainer_contract.AddVeleroLogEvents(cs, containerFields)
+	return struct{}{}, nil
+}
+
+var _ inspectiontaskbase.LogToTimelineMapper[struct{}] = (*containerLogToTimelineMapperTaskSetting)(nil)
@@ -0,0 +1,128 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardk8s_impl
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	fluentforwardk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/fluentforwardk8s/contract"
+)
+
+// defaultListenAddress is the address to listen by default. 24224 is the port used by the forward input of Fluentd and Fluent Bit.
+// Only the loopback interface is used by default because the forward protocol is received without authentication. Users give another host to receive logs from other machines.
+const defaultListenAddress = "127.0.0.1:24224"
+
+// minCaptureDuration and maxCaptureDuration are the bounds of the duration to receive logs.
+const (
+	minCaptureDuration = 10 * time.Second
+	maxCaptureDuration = time.Hour
+)
+
+// previousValueOr returns the default value function using the last value given to the form or the given default value.
+func previousValueOr(defaultValue string) func(ctx context.Context, previousValues []string) (string, error) {
+	return func(ctx context.Context, previousValues []string) (string, error) {
+		if len(previousValues) > 0 {
+			return previousValues[0], nil
+		}
+		return defaultValue, nil
+	}
+}
+
+// validateListenAddress checks the address is in the `host:port` form with a valid port number.
+func validateListenAddress(value string) error {
+	_, port, err := net.SplitHostPort(strings.TrimSpace(value))
+	if err != nil {
+		return fmt.Errorf("listen address must be in the `host:port` form like `127.0.0.1:24224`")
+	}
+	portNumber, err := strconv.Atoi(port)
+	if err != nil || portNumber < 1 || portNumber > 65535 {
+		return fmt.Errorf("port must be a number between 1 and 65535")
+	}
+	return nil
+}
+
+// isLoopbackListenAddress returns true when the address only accepts connections from the same machine.
+// An empty host listens on all the interfaces, thus it's not a loopback address.
+func isLoopbackListenAddress(value string) bool {
+	host, _, err := net.SplitHostPort(strings.TrimSpace(value))
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// parseCaptureDuration parses the duration in the Go duration format like `5m` and checks it's in the allowed range.
+func parseCaptureDuration(value string) (time.Duration, error) {
+	duration, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("capture duration must be a duration like `5m` or `30s`")
+	}
+	if duration < minCaptureDuration || duration > maxCaptureDuration {
+		return 0, fmt.Errorf("capture duration must be between %s and %s", minCaptureDuration, maxCaptureDuration)
+	}
+	return duration, nil
+}
+
+// InputListenAddressTask defines a form task to input the address to listen for the forward protocol.
+var InputListenAddressTask = formtask.NewTextFormTaskBuilder(fluentforwardk8s_contract.InputListenAddressTaskID, 0, "Listen address").
+	WithPosition(inspectionmetadata.FormPosition{Section: fluentforwardk8s_contract.FormSectionCapture}).
+	WithPlaceholder("e.g. 127.0.0.1:24224").
+	WithDescription("The address on the machine running KHI to receive logs with the forward protocol. Point the `forward` output of Fluentd or Fluent Bit to this address. Only the connections from the same machine are accepted by default. Specify the host like `0.0.0.0:24224` to receive logs from other machines. TLS and the shared key authentication are not supported, thus listen on other interfaces only on a trusted network.").
+	WithMarkdown().
+	WithDefaultValueFunc(previousValueOr(defaultListenAddress)).
+	WithValidator(func(ctx context.Context, value string) (string, error) {
+		if err := validateListenAddress(value); err != nil {
+			return err.Error(), nil
+		}
+		return "", nil
+	}).
+	WithHintFunc(func(ctx context.Context, value string, convertedValue any) (string, inspectionmetadata.ParameterHintType, error) {
+		if isLoopbackListenAddress(value) {
+			return "", inspectionmetadata.Info, nil
+		}
+		return "KHI accepts logs from any machine reaching this address without authentication. Make sure the network is trusted.", inspectionmetadata.Warning, nil
+	}).
+	WithConverter(func(ctx context.Context, value string) (string, error) {
+		return strings.TrimSpace(value), nil
+	}).
+	Build()
+
+// InputCaptureDurationTask defines a form task to input the duration to receive logs.
+var InputCaptureDurationTask = formtask.NewTextFormTaskBuilder(fluentforwardk8s_contract.InputCaptureDurationTaskID, 0, "Capture duration").
+	WithPosition(inspectionmetadata.FormPosition{Section: fluentforwardk8s_contract.FormSectionCapture, After: []string{fluentforwardk8s_contract.InputListenAddressTaskID.ReferenceIDString()}}).
+	WithPlaceholder("e.g. 5m").
+	WithDescription(fmt.Sprintf("The duration to receive logs after starting the inspection, between %s and %s. The inspection finishes after the duration.", minCaptureDuration, maxCaptureDuration)).
+	WithDefaultValueFunc(previousValueOr("5m")).
+	WithValidator(func(ctx context.Context, value string) (string, error) {
+		if _, err := parseCaptureDuration(value); err != nil {
+			return err.Error(), nil
+		}
+		return "", nil
+	}).
+	WithConverter(func(ctx context.Context, value string) (time.Duration, error) {
+		return parseCaptureDuration(value)
+	}).
+	Build()
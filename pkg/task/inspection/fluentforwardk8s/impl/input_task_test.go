@@ -0,0 +1,66 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardk8s_impl
+
+import "testing"
+
+func TestValidateListenAddress(t *testing.T) {
+	testCases := []struct {
+		value   string
+		wantErr bool
+	}{
+		{value: "127.0.0.1:24224"},
+		{value: ":24224"},
+		{value: " 0.0.0.0:24224 "},
+		{value: "[::1]:24224"},
+		{value: "24224", wantErr: true},
+		{value: ":0", wantErr: true},
+		{value: ":forward", wantErr: true},
+		{value: ":65536", wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			err := validateListenAddress(tc.value)
+			if tc.wantErr && err == nil {
+				t.Errorf("validateListenAddress(%q) returned no error, want an error", tc.value)
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("validateListenAddress(%q) returned an unexpected error: %v", tc.value, err)
+			}
+		})
+	}
+}
+
+func TestIsLoopbackListenAddress(t *testing.T) {
+	testCases := []struct {
+		value string
+		want  bool
+	}{
+		{value: "127.0.0.1:24224", want: true},
+		{value: " localhost:24224 ", want: true},
+		{value: "[::1]:24224", want: true},
+		{value: ":24224", want: false},
+		{value: "0.0.0.0:24224", want: false},
+		{value: "192.168.0.10:24224", want: false},
+		{value: "24224", want: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			if got := isLoopbackListenAddress(tc.value); got != tc.want {
+				t.Errorf("isLoopbackListenAddress(%q) = %v, want %v", tc.value, got, tc.want)
+			}
+		})
+	}
+}
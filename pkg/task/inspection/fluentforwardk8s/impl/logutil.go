@@ -0,0 +1,28 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardk8s_impl
+
+import (
+	"slices"
+
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+// sortLogsByTimestamp sorts the logs by the timestamp in the common fieldset.
+func sortLogsByTimestamp(logs []*log.Log) {
+	slices.SortStableFunc(logs, func(a, b *log.Log) int {
+		return log.MustGetFieldSet(a, &log.CommonFieldSet{}).Timestamp.Compare(log.MustGetFieldSet(b, &log.CommonFieldSet{}).Timestamp)
+	})
+}
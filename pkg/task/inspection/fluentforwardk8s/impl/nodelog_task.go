@@ -0,0 +1,82 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardk8s_impl
+
+import (
+	"context"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/logutil"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	fluentforwardk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/fluentforwardk8s/contract"
+	googlecloudlogk8snode_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8snode/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// NodeLogReaderTask provides the captured journald and syslog logs in place of the node logs queried from Cloud Logging.
+var NodeLogReaderTask = inspectiontaskbase.NewInspectionTask(
+	fluentforwardk8s_contract.NodeLogReaderTaskID,
+	[]taskid.UntypedTaskReference{
+		fluentforwardk8s_contract.CaptureTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) ([]*log.Log, error) {
+		if taskMode == inspectioncore_contract.TaskModeDryRun {
+			return []*log.Log{}, nil
+		}
+		capturedLogs := coretask.GetTaskResult(ctx, fluentforwardk8s_contract.CaptureTaskID.Ref())
+		sortLogsByTimestamp(capturedLogs.NodeLogs)
+		return capturedLogs.NodeLogs, nil
+	},
+	coretask.WithSelectionPriority(1000),
+	inspectioncore_contract.InspectionTypeLabel(fluentforwardk8s_contract.InspectionTypeID),
+)
+
+// NodeLogCommonFieldSetReaderTask reads the fieldset used by the node log parsers from the captured node logs instead of the logs from Cloud Logging.
+var NodeLogCommonFieldSetReaderTask = inspectiontaskbase.NewFieldSetReadTask(
+	fluentforwardk8s_contract.NodeLogCommonFieldSetReaderTaskID,
+	googlecloudlogk8snode_contract.ListLogEntriesTaskID.Ref(),
+	[]log.FieldSetReader{
+		&ossclusterk8s_contract.OSSJournaldNodeLogFieldSetReader{
+			StructuredLogParser: logutil.NewMultiTextLogParser(
+				logutil.NewJsonlTextParser(),
+				logutil.NewKLogTextParser(true),
+				logutil.NewLogfmtTextParser(),
+				&logutil.FallbackRawTextLogParser{},
+			),
+		},
+	},
+	coretask.WithSelectionPriority(1000),
+	inspectioncore_contract.InspectionTypeLabel(fluentforwardk8s_contract.InspectionTypeID),
+)
+
+// NodeLogParserTailTask is the feature task to generate node scoped timelines from the captured node logs.
+var NodeLogParserTailTask = inspectiontaskbase.NewInspectionTask(
+	fluentforwardk8s_contract.NodeLogParserTailTaskID,
+	[]taskid.UntypedTaskReference{
+		googlecloudlogk8snode_contract.ContainerdLogLogToTimelineMapperTaskID.Ref(),
+		googlecloudlogk8snode_contract.KubeletLogLogToTimelineMapperTaskID.Ref(),
+		googlecloudlogk8snode_contract.OtherLogLogToTimelineMapperTaskID.Ref(),
+
+		googlecloudlogk8snode_contract.ContainerIDDiscoveryTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (struct{}, error) {
+		return struct{}{}, nil
+	},
+	inspectioncore_contract.FeatureTaskLabel("Kubernetes Node Logs", `Gather kubelet and container runtime logs forwarded from the systemd or syslog inputs during the capture.`, enum.LogTypeNode, 1003, true, fluentforwardk8s_contract.InspectionTypeID), coretask.NewSubsequentTaskRefsTaskLabel(inspectioncore_contract.SerializerTaskID.Ref()),
+)
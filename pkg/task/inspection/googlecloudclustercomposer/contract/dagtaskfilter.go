@@ -0,0 +1,72 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudclustercomposer_contract
+
+import "strings"
+
+// DagTaskFilterAny is the filter value to select task instances of any DAG.
+const DagTaskFilterAny = "@any"
+
+// DagTaskFilterSeparator separates the DAG ID and the task ID in a filter value.
+// Slash is used because it can't be a part of DAG IDs or task IDs while dots can be.
+const DagTaskFilterSeparator = "/"
+
+// ComposerDagTaskFilter selects Airflow task instances by the DAG ID and the task ID.
+type ComposerDagTaskFilter struct {
+	matchAll bool
+	dags     map[string]struct{}
+	tasks    map[string]struct{}
+}
+
+// NewComposerDagTaskFilter returns a ComposerDagTaskFilter from the filter values.
+// Each value is either a DAG ID selecting all tasks in the DAG or "<DAG ID>/<task ID>" selecting a single task.
+// The filter matches any task instance when the values are empty or contain DagTaskFilterAny.
+func NewComposerDagTaskFilter(values []string) *ComposerDagTaskFilter {
+	filter := &ComposerDagTaskFilter{
+		matchAll: len(values) == 0,
+		dags:     map[string]struct{}{},
+		tasks:    map[string]struct{}{},
+	}
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == DagTaskFilterAny {
+			filter.matchAll = true
+			continue
+		}
+		if strings.Contains(value, DagTaskFilterSeparator) {
+			filter.tasks[value] = struct{}{}
+		} else {
+			filter.dags[value] = struct{}{}
+		}
+	}
+	return filter
+}
+
+// MatchAll returns true when the filter selects any task instance.
+func (f *ComposerDagTaskFilter) MatchAll() bool {
+	return f.matchAll
+}
+
+// Match returns true when the task instance with the given DAG ID and task ID is selected by the filter.
+func (f *ComposerDagTaskFilter) Match(dagID string, taskID string) bool {
+	if f.matchAll {
+		return true
+	}
+	if _, found := f.dags[dagID]; found {
+		return true
+	}
+	_, found := f.tasks[dagID+DagTaskFilterSeparator+taskID]
+	return found
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudclustercomposer_contract
+
+import "testing"
+
+func TestComposerDagTaskFilter(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		values []string
+		dagID  string
+		taskID string
+		want   bool
+	}{
+		{
+			desc:   "empty filter matches any task",
+			values: []string{},
+			dagID:  "my_dag",
+			taskID: "my_task",
+			want:   true,
+		},
+		{
+			desc:   "@any matches any task",
+			values: []string{"other_dag", DagTaskFilterAny},
+			dagID:  "my_dag",
+			taskID: "my_task",
+			want:   true,
+		},
+		{
+			desc:   "DAG ID matches all tasks in the DAG",
+			values: []string{"my_dag"},
+			dagID:  "my_dag",
+			taskID: "my_task",
+			want:   true,
+		},
+		{
+			desc:   "DAG ID doesn't match other DAGs",
+			values: []string{"my_dag"},
+			dagID:  "other_dag",
+			taskID: "my_task",
+			want:   false,
+		},
+		{
+			desc:   "task ID with dots in task group",
+			values: []string{"my_dag/group.my_task"},
+			dagID:  "my_dag",
+			taskID: "group.my_task",
+			want:   true,
+		},
+		{
+			desc:   "task doesn't match other tasks in the same DAG",
+			values: []string{"my_dag/my_task"},
+			dagID:  "my_dag",
+			taskID: "other_task",
+			want:   false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := NewComposerDagTaskFilter(tc.values).Match(tc.dagID, tc.taskID)
+			if got != tc.want {
+				t.Errorf("Match(%q, %q) = %v, want %v", tc.dagID, tc.taskID, got, tc.want)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ package googlecloudclustercomposer_contract
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/kyasbal/khi/pkg/common/structured"
@@ -46,7 +47,6 @@ var (
 
 	// TODO Add log types
 	// * Trying to enqueue tasks: [<TaskInstance: airflow_monitoring.echo scheduled__2025-04-10T04:00:00+00:00 [scheduled]>] for executor: CeleryExecutor(parallelism=0) (ONLY appliucable from 2.10.x)
-	// * Sending TaskInstanceKey(dag_id='airflow_monitoring', task_id='echo', run_id='scheduled__2025-04-10T04:00:00+00:00', try_number=1, map_index=-1) to CeleryExecutor with priority 2147483647 and queue default
 	// * Adding to queue: ['airflow', 'tasks', 'run', 'airflow_monitoring', 'echo', 'scheduled__2025-04-10T04:00:00+00:00', '--local', '--subdir', 'DAGS_FOLDER/airflow_monitoring.py']
 
 	// Received executor event with state queued for task instance TaskInstanceKey(dag_id='khi_dag', task_id='add_one', run_id='scheduled__2023-11-30T05:00:00+00:00', try_number=1, map_index=0)
@@ -366,3 +366,96 @@ func (c *ComposerWorkerTaskInstanceFieldSetReader) tryReadFromLabels(reader *str
 }
 
 var _ log.FieldSetReader = &ComposerWorkerTaskInstanceFieldSetReader{}
+
+// ComposerTaskAttemptFieldSet is the fieldset for logs recording an attempt of an Airflow task instance.
+type ComposerTaskAttemptFieldSet struct {
+	TaskInstance *AirflowTaskInstance
+	TryNumber    int
+	// MaxTries is the maximum count of the attempts. This is 0 when it is not available in the log.
+	MaxTries int
+}
+
+func (c *ComposerTaskAttemptFieldSet) Kind() string {
+	return "ComposerTaskAttempt"
+}
+
+var _ log.FieldSet = &ComposerTaskAttemptFieldSet{}
+
+type ComposerTaskAttemptFieldSetReader struct{}
+
+func (c *ComposerTaskAttemptFieldSetReader) FieldSetKind() string {
+	return (&ComposerTaskAttemptFieldSet{}).Kind()
+}
+
+var (
+	// Sending TaskInstanceKey(dag_id='DAG_ID', task_id='TASK_ID', run_id='RUN_ID', try_number=TRY_NUMBER, map_index=MAP_INDEX) to CeleryExecutor with priority 2147483647 and queue default
+	// ref: https://github.com/apache/airflow/blob/2.7.3/airflow/jobs/scheduler_job_runner.py
+	airflowSchedulerSendingToExecutorTemplate = regexp.MustCompile(`Sending TaskInstanceKey\(dag_id='(?P<dagid>[^']+)', task_id='(?P<taskid>[^']+)', run_id='(?P<runid>[^']+)', try_number=(?P<tryNumber>\d+), map_index=(?P<mapIndex>-?\d+)\) to (?P<executor>\S+)`)
+
+	// Starting attempt TRY_NUMBER of MAX_TRIES
+	// ref: https://github.com/apache/airflow/blob/2.7.3/airflow/models/taskinstance.py
+	airflowWorkerStartingAttemptTemplate = regexp.MustCompile(`Starting attempt (?P<tryNumber>\d+) of (?P<maxTries>\d+)`)
+)
+
+func (c *ComposerTaskAttemptFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	textPayload, err := reader.ReadString("textPayload")
+	if err != nil {
+		return nil, fmt.Errorf("textPayload not found")
+	}
+
+	if matches := airflowSchedulerSendingToExecutorTemplate.FindStringSubmatch(textPayload); matches != nil {
+		tryNumber, err := strconv.Atoi(matches[airflowSchedulerSendingToExecutorTemplate.SubexpIndex("tryNumber")])
+		if err != nil {
+			return nil, err
+		}
+		return &ComposerTaskAttemptFieldSet{
+			TaskInstance: NewAirflowTaskInstance(
+				matches[airflowSchedulerSendingToExecutorTemplate.SubexpIndex("dagid")],
+				matches[airflowSchedulerSendingToExecutorTemplate.SubexpIndex("taskid")],
+				matches[airflowSchedulerSendingToExecutorTemplate.SubexpIndex("runid")],
+				matches[airflowSchedulerSendingToExecutorTemplate.SubexpIndex("mapIndex")],
+				"",
+				TASKINSTANCE_QUEUED,
+			),
+			TryNumber: tryNumber,
+		}, nil
+	}
+
+	if matches := airflowWorkerStartingAttemptTemplate.FindStringSubmatch(textPayload); matches != nil {
+		// The worker log only contains the attempt count in the message. The task instance is identified from the labels.
+		workerId, err := reader.ReadString("labels.worker_id")
+		if err != nil {
+			return nil, fmt.Errorf("worker_id not found")
+		}
+		dagid, err := reader.ReadString("labels.workflow")
+		if err != nil {
+			return nil, fmt.Errorf("workflow not found")
+		}
+		taskid, err := reader.ReadString("labels.task-id")
+		if err != nil {
+			return nil, fmt.Errorf("task-id not found")
+		}
+		runid, err := reader.ReadString("labels.run-id")
+		if err != nil {
+			return nil, fmt.Errorf("run-id not found")
+		}
+		mapIndex := reader.ReadStringOrDefault("labels.map-index", "-1")
+		tryNumber, err := strconv.Atoi(matches[airflowWorkerStartingAttemptTemplate.SubexpIndex("tryNumber")])
+		if err != nil {
+			return nil, err
+		}
+		maxTries, err := strconv.Atoi(matches[airflowWorkerStartingAttemptTemplate.SubexpIndex("maxTries")])
+		if err != nil {
+			return nil, err
+		}
+		return &ComposerTaskAttemptFieldSet{
+			TaskInstance: NewAirflowTaskInstance(dagid, taskid, runid, mapIndex, workerId, TASKINSTANCE_RUNNING),
+			TryNumber:    tryNumber,
+			MaxTries:     maxTries,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("not an Airflow task attempt log")
+}
+
+var _ log.FieldSetReader = &ComposerTaskAttemptFieldSetReader{}
@@ -416,3 +416,83 @@ labels:`
 		})
 	}
 }
+
+func TestComposerTaskAttemptFieldSetReader_Read(t *testing.T) {
+	reader := &ComposerTaskAttemptFieldSetReader{}
+
+	tests := []struct {
+		name    string
+		yaml    string
+		want    *ComposerTaskAttemptFieldSet
+		wantErr bool
+	}{
+		{
+			name: "scheduler sending task instance to executor",
+			yaml: `textPayload: "Sending TaskInstanceKey(dag_id='airflow_monitoring', task_id='echo', run_id='scheduled__2025-04-10T04:00:00+00:00', try_number=2, map_index=-1) to CeleryExecutor with priority 2147483647 and queue default"`,
+			want: &ComposerTaskAttemptFieldSet{
+				TaskInstance: NewAirflowTaskInstance("airflow_monitoring", "echo", "scheduled__2025-04-10T04:00:00+00:00", "-1", "", TASKINSTANCE_QUEUED),
+				TryNumber:    2,
+			},
+		},
+		{
+			name: "worker starting attempt",
+			yaml: `textPayload: "Starting attempt 1 of 3"
+labels:
+  worker_id: airflow-worker-abc
+  workflow: airflow_monitoring
+  task-id: echo
+  run-id: scheduled__2025-04-10T04:00:00+00:00
+  map-index: "2"`,
+			want: &ComposerTaskAttemptFieldSet{
+				TaskInstance: NewAirflowTaskInstance("airflow_monitoring", "echo", "scheduled__2025-04-10T04:00:00+00:00", "2", "airflow-worker-abc", TASKINSTANCE_RUNNING),
+				TryNumber:    1,
+				MaxTries:     3,
+			},
+		},
+		{
+			name: "worker starting attempt without map index",
+			yaml: `textPayload: "Starting attempt 2 of 2"
+labels:
+  worker_id: airflow-worker-abc
+  workflow: airflow_monitoring
+  task-id: echo
+  run-id: scheduled__2025-04-10T04:00:00+00:00`,
+			want: &ComposerTaskAttemptFieldSet{
+				TaskInstance: NewAirflowTaskInstance("airflow_monitoring", "echo", "scheduled__2025-04-10T04:00:00+00:00", "-1", "airflow-worker-abc", TASKINSTANCE_RUNNING),
+				TryNumber:    2,
+				MaxTries:     2,
+			},
+		},
+		{
+			name: "worker starting attempt without task labels",
+			yaml: `textPayload: "Starting attempt 1 of 3"
+labels:
+  worker_id: airflow-worker-abc`,
+			wantErr: true,
+		},
+		{
+			name:    "unrelated log",
+			yaml:    `textPayload: "Executing command in Celery"`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yamlNode, err := structured.FromYAML(tt.yaml)
+			if err != nil {
+				t.Fatalf("failed to parse yaml: %v", err)
+			}
+			got, err := reader.Read(structured.NewNodeReader(yamlNode))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Read() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(AirflowTaskInstance{})); diff != "" {
+				t.Errorf("Read() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -50,6 +50,12 @@ var AutocompleteComposerComponentsTaskID = taskid.NewDefaultImplementationID[*in
 // InputComposerComponentsTaskID is the task id for selecting target Composer components.
 var InputComposerComponentsTaskID taskid.TaskImplementationID[[]string] = taskid.NewDefaultImplementationID[[]string](GoogleCloudComposerTaskIDPrefix + "input/composer/components")
 
+// AutocompleteComposerDagIDsTaskID is the task id for autocompleting DAG IDs in the Composer environment from Cloud Monitoring.
+var AutocompleteComposerDagIDsTaskID = taskid.NewDefaultImplementationID[*inspectioncore_contract.AutocompleteResult[string]](GoogleCloudComposerTaskIDPrefix + "autocomplete/composer-dag-ids")
+
+// InputComposerDagTaskFilterTaskID is the task id for selecting target DAGs and tasks.
+var InputComposerDagTaskFilterTaskID taskid.TaskImplementationID[*ComposerDagTaskFilter] = taskid.NewDefaultImplementationID[*ComposerDagTaskFilter](GoogleCloudComposerTaskIDPrefix + "input/composer/dag-task-filter")
+
 // ComposerLogsQueryTaskID is the task id for the task that queries Logs from Cloud Logging.
 var ComposerLogsQueryTaskID taskid.TaskImplementationID[[]*log.Log] = taskid.NewDefaultImplementationID[[]*log.Log](GoogleCloudComposerTaskIDPrefix + "query-composer-logs")
 
@@ -62,6 +68,12 @@ var AirflowWorkerLogFilterTaskID taskid.TaskImplementationID[[]*log.Log] = taski
 // AirflowSchedulerLogFilterTaskID is the task id for filtering Airflow scheduler logs.
 var AirflowSchedulerLogFilterTaskID taskid.TaskImplementationID[[]*log.Log] = taskid.NewDefaultImplementationID[[]*log.Log](GoogleCloudComposerTaskIDPrefix + "filter-scheduler")
 
+// AirflowSchedulerDagTaskFilterTaskID is the task id for filtering Airflow scheduler logs by the DAG and task filter.
+var AirflowSchedulerDagTaskFilterTaskID taskid.TaskImplementationID[[]*log.Log] = taskid.NewDefaultImplementationID[[]*log.Log](GoogleCloudComposerTaskIDPrefix + "filter-scheduler-dag-task")
+
+// AirflowWorkerDagTaskFilterTaskID is the task id for filtering Airflow worker logs by the DAG and task filter.
+var AirflowWorkerDagTaskFilterTaskID taskid.TaskImplementationID[[]*log.Log] = taskid.NewDefaultImplementationID[[]*log.Log](GoogleCloudComposerTaskIDPrefix + "filter-worker-dag-task")
+
 // AirflowDagProcessorManagerLogFilterTaskID is the task id for filtering Airflow DAG processor manager logs.
 var AirflowDagProcessorManagerLogFilterTaskID taskid.TaskImplementationID[[]*log.Log] = taskid.NewDefaultImplementationID[[]*log.Log](GoogleCloudComposerTaskIDPrefix + "filter-dag-processor-manager")
 
@@ -15,7 +15,11 @@
 package googlecloudclustercomposer_impl
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/history"
 	googlecloudclustercomposer_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudclustercomposer/contract"
 )
 
@@ -50,3 +54,26 @@ func tiStatusToVerb(ti *googlecloudclustercomposer_contract.AirflowTaskInstance)
 		return enum.RevisionVerbComposerTaskInstanceUnimplemented, enum.RevisionStateConditionUnknown
 	}
 }
+
+// recordTaskAttempt records an attempt of the task instance on its timeline.
+// The attempt is also recorded on the worker timeline when the worker running the attempt is known.
+func recordTaskAttempt(cs *history.ChangeSet, attempt *googlecloudclustercomposer_contract.ComposerTaskAttemptFieldSet, requestor string, changeTime time.Time) {
+	ti := attempt.TaskInstance
+	verb, state := tiStatusToVerb(ti)
+	cs.AddRevision(ti.ResourcePath(), &history.StagingResourceRevision{
+		Verb:       verb,
+		State:      state,
+		Requestor:  requestor,
+		ChangeTime: changeTime,
+		Partial:    false,
+		Body:       ti.ToYaml(),
+	})
+	if ti.Host() != "" {
+		cs.AddEvent(googlecloudclustercomposer_contract.NewAirflowWorker(ti.Host()).ResourcePath())
+	}
+	if attempt.MaxTries > 0 {
+		cs.SetLogSummary(fmt.Sprintf("Attempt %d of %d: %s.%s (%s)", attempt.TryNumber, attempt.MaxTries, ti.DagId(), ti.TaskId(), ti.RunId()))
+	} else {
+		cs.SetLogSummary(fmt.Sprintf("Attempt %d: %s.%s (%s)", attempt.TryNumber, ti.DagId(), ti.TaskId(), ti.RunId()))
+	}
+}
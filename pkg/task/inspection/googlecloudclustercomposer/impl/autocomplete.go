@@ -258,3 +258,69 @@ var AutocompleteComposerComponentsTask = inspectiontaskbase.NewCachedTask(google
 		},
 	}, nil
 })
+
+// AutocompleteComposerDagIDsTask is the task that autocompletes the DAG IDs that ran in the Composer environment within the time range.
+var AutocompleteComposerDagIDsTask = inspectiontaskbase.NewCachedTask(googlecloudclustercomposer_contract.AutocompleteComposerDagIDsTaskID, []taskid.UntypedTaskReference{
+	googlecloudclustercomposer_contract.ClusterIdentityTaskID.Ref(),
+	googlecloudcommon_contract.InputStartTimeTaskID.Ref(),
+	googlecloudcommon_contract.InputEndTimeTaskID.Ref(),
+	googlecloudclustercomposer_contract.InputComposerEnvironmentNameTaskID.Ref(),
+	googlecloudcommon_contract.APIClientFactoryTaskID.Ref(),
+	googlecloudcommon_contract.APIClientCallOptionsInjectorTaskID.Ref(),
+}, func(ctx context.Context, prevValue inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[string]]) (inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[string]], error) {
+	clusterIdentity := coretask.GetTaskResult(ctx, googlecloudclustercomposer_contract.ClusterIdentityTaskID.Ref())
+	projectID := clusterIdentity.ProjectID
+	location := clusterIdentity.Location
+
+	startTime := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputStartTimeTaskID.Ref())
+	endTime := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputEndTimeTaskID.Ref())
+	environmentName := coretask.GetTaskResult(ctx, googlecloudclustercomposer_contract.InputComposerEnvironmentNameTaskID.Ref())
+	cf := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientFactoryTaskID.Ref())
+	optionInjector := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientCallOptionsInjectorTaskID.Ref())
+
+	currentDigest := fmt.Sprintf("%s-%s-%s-%s-%d-%d", projectID, location, environmentName, "composer.googleapis.com/workflow/run_count", startTime.Unix(), endTime.Unix())
+	if currentDigest == prevValue.DependencyDigest {
+		return prevValue, nil
+	}
+
+	if projectID == "" || environmentName == "" || location == "" {
+		return inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[string]]{
+			Value: &inspectioncore_contract.AutocompleteResult[string]{
+				Values: []string{},
+				Hint:   "DAG IDs are suggested after the project ID, location, and environment name are provided.",
+			},
+			DependencyDigest: currentDigest,
+		}, nil
+	}
+
+	client, err := cf.MonitoringMetricClient(ctx, googlecloud.Project(projectID))
+	if err != nil {
+		return prevValue, fmt.Errorf("failed to create monitoring metric client: %w", err)
+	}
+	defer client.Close()
+
+	ctx = optionInjector.InjectToCallContext(ctx, googlecloud.Project(projectID))
+
+	filter := fmt.Sprintf(`resource.type = "cloud_composer_workflow" AND metric.type = "composer.googleapis.com/workflow/run_count" AND resource.labels.environment_name = "%s" AND resource.labels.location = "%s"`, environmentName, location)
+
+	errorString := ""
+	hintString := ""
+	dagIDs, err := googlecloud.QueryDistinctStringLabelValuesFromMetrics(ctx, client, projectID, filter, startTime, endTime, "resource.label.workflow_name", "workflow_name")
+	if err != nil {
+		errorString = err.Error()
+	}
+	sort.Strings(dagIDs)
+
+	if errorString == "" && len(dagIDs) == 0 {
+		hintString = "No DAG runs found for the specified environment and time range."
+	}
+
+	return inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[string]]{
+		DependencyDigest: currentDigest,
+		Value: &inspectioncore_contract.AutocompleteResult[string]{
+			Values: dagIDs,
+			Error:  errorString,
+			Hint:   hintString,
+		},
+	}, nil
+})
@@ -30,5 +30,6 @@ var ComposerLogsFieldSetReadTask = inspectiontaskbase.NewFieldSetReadTask(
 		&googlecloudclustercomposer_contract.ComposerFieldSetReader{},
 		&googlecloudclustercomposer_contract.ComposerTaskInstanceFieldSetReader{},
 		&googlecloudclustercomposer_contract.ComposerWorkerTaskInstanceFieldSetReader{},
+		&googlecloudclustercomposer_contract.ComposerTaskAttemptFieldSetReader{},
 	},
 )
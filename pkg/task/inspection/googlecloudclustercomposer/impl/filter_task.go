@@ -22,6 +22,7 @@ import (
 	"github.com/kyasbal/khi/pkg/core/task/taskid"
 	"github.com/kyasbal/khi/pkg/model/log"
 	googlecloudclustercomposer_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudclustercomposer/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
 )
 
 func componentFilterTask(taskID taskid.TaskImplementationID[[]*log.Log], source taskid.TaskReference[[]*log.Log], componentName string) coretask.Task[[]*log.Log] {
@@ -54,3 +55,46 @@ var AirflowOtherLogFilterTask = inspectiontaskbase.NewLogFilterTask(
 		return fs.Component != "airflow-worker" && fs.Component != "airflow-scheduler" && fs.Component != "dag-processor-manager"
 	},
 )
+
+// dagTaskFilterTask returns a task to drop the logs about Airflow task instances not selected by the DAG and task filter.
+// Logs not related to any task instance are kept to show the component timelines.
+func dagTaskFilterTask(taskID taskid.TaskImplementationID[[]*log.Log], source taskid.TaskReference[[]*log.Log]) coretask.Task[[]*log.Log] {
+	return inspectiontaskbase.NewInspectionTask(taskID, []taskid.UntypedTaskReference{
+		source,
+		googlecloudclustercomposer_contract.InputComposerDagTaskFilterTaskID.Ref(),
+	}, func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) ([]*log.Log, error) {
+		if taskMode != inspectioncore_contract.TaskModeRun {
+			return []*log.Log{}, nil
+		}
+		logs := coretask.GetTaskResult(ctx, source)
+		filter := coretask.GetTaskResult(ctx, googlecloudclustercomposer_contract.InputComposerDagTaskFilterTaskID.Ref())
+		if filter.MatchAll() {
+			return logs, nil
+		}
+		filteredLogs := []*log.Log{}
+		for _, l := range logs {
+			ti := taskInstanceFromLog(l)
+			if ti == nil || filter.Match(ti.DagId(), ti.TaskId()) {
+				filteredLogs = append(filteredLogs, l)
+			}
+		}
+		return filteredLogs, nil
+	})
+}
+
+// taskInstanceFromLog returns the Airflow task instance the log is about. It returns nil when the log is not related to any task instance.
+func taskInstanceFromLog(l *log.Log) *googlecloudclustercomposer_contract.AirflowTaskInstance {
+	if fs, err := log.GetFieldSet(l, &googlecloudclustercomposer_contract.ComposerTaskInstanceFieldSet{}); err == nil {
+		return fs.TaskInstance
+	}
+	if fs, err := log.GetFieldSet(l, &googlecloudclustercomposer_contract.ComposerWorkerTaskInstanceFieldSet{}); err == nil {
+		return fs.TaskInstance
+	}
+	if fs, err := log.GetFieldSet(l, &googlecloudclustercomposer_contract.ComposerTaskAttemptFieldSet{}); err == nil {
+		return fs.TaskInstance
+	}
+	return nil
+}
+
+var AirflowWorkerDagTaskFilterTask = dagTaskFilterTask(googlecloudclustercomposer_contract.AirflowWorkerDagTaskFilterTaskID, googlecloudclustercomposer_contract.AirflowWorkerLogFilterTaskID.Ref())
+var AirflowSchedulerDagTaskFilterTask = dagTaskFilterTask(googlecloudclustercomposer_contract.AirflowSchedulerDagTaskFilterTaskID, googlecloudclustercomposer_contract.AirflowSchedulerLogFilterTaskID.Ref())
@@ -0,0 +1,90 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudclustercomposer_impl
+
+import (
+	"testing"
+
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudclustercomposer_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudclustercomposer/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+func TestAirflowWorkerDagTaskFilterTask(t *testing.T) {
+	componentLog := log.NewLogWithFieldSetsForTest(
+		&log.MainMessageFieldSet{MainMessage: "Worker Heartbeat"},
+	)
+	myDagLog := log.NewLogWithFieldSetsForTest(
+		&googlecloudclustercomposer_contract.ComposerWorkerTaskInstanceFieldSet{
+			TaskInstance: googlecloudclustercomposer_contract.NewAirflowTaskInstance("my_dag", "my_task", "run1", "-1", "airflow-worker-abc", googlecloudclustercomposer_contract.TASKINSTANCE_RUNNING),
+		},
+	)
+	otherTaskLog := log.NewLogWithFieldSetsForTest(
+		&googlecloudclustercomposer_contract.ComposerTaskAttemptFieldSet{
+			TaskInstance: googlecloudclustercomposer_contract.NewAirflowTaskInstance("my_dag", "other_task", "run1", "-1", "airflow-worker-abc", googlecloudclustercomposer_contract.TASKINSTANCE_RUNNING),
+			TryNumber:    1,
+		},
+	)
+	otherDagLog := log.NewLogWithFieldSetsForTest(
+		&googlecloudclustercomposer_contract.ComposerTaskInstanceFieldSet{
+			TaskInstance: googlecloudclustercomposer_contract.NewAirflowTaskInstance("other_dag", "my_task", "run1", "-1", "", googlecloudclustercomposer_contract.TASKINSTANCE_SUCCESS),
+		},
+	)
+	logs := []*log.Log{componentLog, myDagLog, otherTaskLog, otherDagLog}
+
+	testCases := []struct {
+		desc   string
+		filter []string
+		want   []*log.Log
+	}{
+		{
+			desc:   "@any keeps all logs",
+			filter: []string{googlecloudclustercomposer_contract.DagTaskFilterAny},
+			want:   logs,
+		},
+		{
+			desc:   "DAG filter",
+			filter: []string{"my_dag"},
+			want:   []*log.Log{componentLog, myDagLog, otherTaskLog},
+		},
+		{
+			desc:   "task filter",
+			filter: []string{"my_dag/my_task"},
+			want:   []*log.Log{componentLog, myDagLog},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+			got, _, err := inspectiontest.RunInspectionTask(ctx, AirflowWorkerDagTaskFilterTask, inspectioncore_contract.TaskModeRun, map[string]any{},
+				tasktest.NewTaskDependencyValuePair(googlecloudclustercomposer_contract.AirflowWorkerLogFilterTaskID.Ref(), logs),
+				tasktest.NewTaskDependencyValuePair(googlecloudclustercomposer_contract.InputComposerDagTaskFilterTaskID.Ref(), googlecloudclustercomposer_contract.NewComposerDagTaskFilter(tc.filter)),
+			)
+			if err != nil {
+				t.Fatalf("RunInspectionTask() returned an unexpected error: %v", err)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("AirflowWorkerDagTaskFilterTask returned %d logs, want %d", len(got), len(tc.want))
+			}
+			for i := range tc.want {
+				if got[i] != tc.want[i] {
+					t.Errorf("AirflowWorkerDagTaskFilterTask returned an unexpected log at index %d", i)
+				}
+			}
+		})
+	}
+}
@@ -25,7 +25,8 @@ import (
 	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
 )
 
-var InputComposerComponentsTask = formtask.NewSetFormTaskBuilder(googlecloudclustercomposer_contract.InputComposerComponentsTaskID, googlecloudcommon_contract.FormBasePriority+3000, "Composer Components").
+var InputComposerComponentsTask = formtask.NewSetFormTaskBuilder(googlecloudclustercomposer_contract.InputComposerComponentsTaskID, 0, "Composer Components").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionComposerLogFilter}).
 	WithDependencies([]taskid.UntypedTaskReference{googlecloudclustercomposer_contract.AutocompleteComposerComponentsTaskID.Ref()}).
 	WithDefaultValueConstant([]string{"@any"}, true).
 	WithAllowAddAll(false).
@@ -0,0 +1,70 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudclustercomposer_impl
+
+import (
+	"context"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	googlecloudclustercomposer_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudclustercomposer/contract"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+)
+
+// InputComposerDagTaskFilterTask is the form task to select the DAGs and tasks shown in the task instance timelines.
+var InputComposerDagTaskFilterTask = formtask.NewSetFormTaskBuilder(googlecloudclustercomposer_contract.InputComposerDagTaskFilterTaskID, 0, "DAGs / Tasks").
+	WithPosition(inspectionmetadata.FormPosition{
+		Section: googlecloudcommon_contract.FormSectionComposerLogFilter,
+		After:   []string{googlecloudclustercomposer_contract.InputComposerComponentsTaskID.ReferenceIDString()},
+	}).
+	WithDependencies([]taskid.UntypedTaskReference{googlecloudclustercomposer_contract.AutocompleteComposerDagIDsTaskID.Ref()}).
+	WithDefaultValueConstant([]string{googlecloudclustercomposer_contract.DagTaskFilterAny}, true).
+	WithAllowAddAll(false).
+	WithAllowRemoveAll(false).
+	WithAllowCustomValue(true).
+	WithDescription("The DAGs to show the task instances of. Specify `DAG_ID/TASK_ID` to select a specific task in a DAG. Specify `@any` to show the task instances of every DAG.").
+	WithOptionsFunc(func(ctx context.Context, previousValues []string) ([]inspectionmetadata.SetParameterFormFieldOptionItem, error) {
+		autocompleteResult := coretask.GetTaskResult(ctx, googlecloudclustercomposer_contract.AutocompleteComposerDagIDsTaskID.Ref())
+
+		options := []inspectionmetadata.SetParameterFormFieldOptionItem{
+			{ID: googlecloudclustercomposer_contract.DagTaskFilterAny},
+		}
+		if autocompleteResult != nil {
+			for _, dagID := range autocompleteResult.Values {
+				options = append(options, inspectionmetadata.SetParameterFormFieldOptionItem{
+					ID: dagID,
+				})
+			}
+		}
+		return options, nil
+	}).
+	WithHintFunc(func(ctx context.Context, value []string, convertedValue any) (string, inspectionmetadata.ParameterHintType, error) {
+		autocompleteResult := coretask.GetTaskResult(ctx, googlecloudclustercomposer_contract.AutocompleteComposerDagIDsTaskID.Ref())
+		if autocompleteResult != nil {
+			if autocompleteResult.Error != "" {
+				return autocompleteResult.Error, inspectionmetadata.Warning, nil
+			}
+			if autocompleteResult.Hint != "" {
+				return autocompleteResult.Hint, inspectionmetadata.Info, nil
+			}
+		}
+		return "", inspectionmetadata.None, nil
+	}).
+	WithConverter(func(ctx context.Context, value []string) (*googlecloudclustercomposer_contract.ComposerDagTaskFilter, error) {
+		return googlecloudclustercomposer_contract.NewComposerDagTaskFilter(value), nil
+	}).
+	Build()
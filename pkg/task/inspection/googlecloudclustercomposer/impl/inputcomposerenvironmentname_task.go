@@ -19,14 +19,21 @@ import (
 
 	"github.com/kyasbal/khi/pkg/common"
 	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
 	coretask "github.com/kyasbal/khi/pkg/core/task"
 	"github.com/kyasbal/khi/pkg/core/task/taskid"
 	googlecloudclustercomposer_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudclustercomposer/contract"
 	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
 )
 
 // InputComposerEnvironmentNameTask is the task that inputs composer environment name.
-var InputComposerEnvironmentNameTask = formtask.NewTextFormTaskBuilder(googlecloudclustercomposer_contract.InputComposerEnvironmentNameTaskID, googlecloudcommon_contract.PriorityForResourceIdentifierGroup+4400, "Composer Environment Name").WithDependencies(
+var InputComposerEnvironmentNameTask = formtask.NewTextFormTaskBuilder(googlecloudclustercomposer_contract.InputComposerEnvironmentNameTaskID, 0, "Composer Environment Name").
+	WithPosition(inspectionmetadata.FormPosition{
+		Section: googlecloudcommon_contract.FormSectionResourceIdentifier,
+		After:   []string{googlecloudcommon_contract.InputProjectIdTaskID.ReferenceIDString()},
+		Before:  []string{googlecloudk8scommon_contract.InputClusterNameTaskID.ReferenceIDString()},
+	}).WithDependencies(
 	[]taskid.UntypedTaskReference{googlecloudclustercomposer_contract.AutocompleteComposerEnvironmentIdentityTaskID.Ref()},
 ).WithSuggestionsFunc(func(ctx context.Context, value string, previousValues []string) ([]string, error) {
 	environments := coretask.GetTaskResult(ctx, googlecloudclustercomposer_contract.AutocompleteComposerEnvironmentIdentityTaskID.Ref())
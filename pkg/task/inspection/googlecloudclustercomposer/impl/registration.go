@@ -42,14 +42,19 @@ func Register(registry coreinspection.InspectionTaskRegistry) error {
 		AutocompleteComposerComponentsTask,
 		InputComposerComponentsTask,
 
+		AutocompleteComposerDagIDsTask,
+		InputComposerDagTaskFilterTask,
+
 		ComposerLogsQueryTask,
 
 		AirflowSchedulerLogFilterTask,
+		AirflowSchedulerDagTaskFilterTask,
 		AirflowSchedulerLogGrouperTask,
 		AirflowSchedulerLogIngesterTask,
 		AirflowSchedulerLogToTimelineMapperTask,
 
 		AirflowWorkerLogFilterTask,
+		AirflowWorkerDagTaskFilterTask,
 		AirflowWorkerLogGrouperTask,
 		AirflowWorkerLogIngesterTask,
 		AirflowWorkerLogToTimelineMapperTask,
@@ -28,7 +28,7 @@ import (
 
 var AirflowSchedulerLogGrouperTask = inspectiontaskbase.NewLogGrouperTask(
 	googlecloudclustercomposer_contract.AirflowSchedulerLogGrouperTaskID,
-	googlecloudclustercomposer_contract.AirflowSchedulerLogFilterTaskID.Ref(),
+	googlecloudclustercomposer_contract.AirflowSchedulerDagTaskFilterTaskID.Ref(),
 	func(ctx context.Context, l *log.Log) string {
 		return ""
 	},
@@ -36,7 +36,7 @@ var AirflowSchedulerLogGrouperTask = inspectiontaskbase.NewLogGrouperTask(
 
 var AirflowSchedulerLogIngesterTask = inspectiontaskbase.NewLogIngesterTask(
 	googlecloudclustercomposer_contract.AirflowSchedulerLogIngesterTaskID,
-	googlecloudclustercomposer_contract.AirflowSchedulerLogFilterTaskID.Ref(),
+	googlecloudclustercomposer_contract.AirflowSchedulerDagTaskFilterTaskID.Ref(),
 )
 
 var AirflowSchedulerLogToTimelineMapperTask = inspectiontaskbase.NewLogToTimelineMapperTask[struct{}](
@@ -76,6 +76,11 @@ func (c *airflowSchedulerLogToTimelineMapperSetting) ProcessLogByGroup(ctx conte
 	}
 
 	commonField, _ := log.GetFieldSet(l, &log.CommonFieldSet{})
+	if attemptField, err := log.GetFieldSet(l, &googlecloudclustercomposer_contract.ComposerTaskAttemptFieldSet{}); err == nil {
+		recordTaskAttempt(cs, attemptField, "airflow-scheduler", commonField.Timestamp)
+		return struct{}{}, nil
+	}
+
 	tiField, err := log.GetFieldSet(l, &googlecloudclustercomposer_contract.ComposerTaskInstanceFieldSet{})
 	if err != nil {
 		return struct{}{}, nil // Not an Airflow TaskInstance log
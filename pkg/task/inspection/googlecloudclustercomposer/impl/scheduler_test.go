@@ -130,6 +130,42 @@ func TestAirflowSchedulerMapperTask_ProcessLogByGroup(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Scheduler sending an attempt to the executor",
+			logs: []*log.Log{
+				log.NewLogWithFieldSetsForTest(
+					&log.CommonFieldSet{Timestamp: timestamp},
+					&log.MainMessageFieldSet{MainMessage: "Sending TaskInstanceKey(...) to CeleryExecutor"},
+					&googlecloudclustercomposer_contract.ComposerFieldSet{
+						SchedulerID: "airflow-scheduler-7b5f",
+					},
+					&googlecloudclustercomposer_contract.ComposerTaskAttemptFieldSet{
+						TaskInstance: googlecloudclustercomposer_contract.NewAirflowTaskInstance(
+							"my_dag", "task_id_1", "2023-01-01T00:00:00Z", "-1", "", googlecloudclustercomposer_contract.TASKINSTANCE_QUEUED,
+						),
+						TryNumber: 2,
+					},
+				),
+			},
+			asserters: [][]testchangeset.ChangeSetAsserter{
+				{
+					&testchangeset.HasEvent{
+						ResourcePath: resourcepath.SubresourceLayerGeneralItem("Apache Airflow", "AirflowScheduler", "cluster-scope", "airflow-scheduler-7b5f", "airflow-scheduler").Path,
+					},
+					&testchangeset.HasRevision{
+						ResourcePath: googlecloudclustercomposer_contract.NewAirflowTaskInstance("my_dag", "task_id_1", "2023-01-01T00:00:00Z", "-1", "", googlecloudclustercomposer_contract.TASKINSTANCE_QUEUED).ResourcePath().Path,
+						WantRevision: history.StagingResourceRevision{
+							Verb:       enum.RevisionVerbComposerTaskInstanceQueued,
+							State:      enum.RevisionStateComposerTiQueued,
+							ChangeTime: timestamp,
+							Requestor:  "airflow-scheduler",
+							Body:       googlecloudclustercomposer_contract.NewAirflowTaskInstance("my_dag", "task_id_1", "2023-01-01T00:00:00Z", "-1", "", googlecloudclustercomposer_contract.TASKINSTANCE_QUEUED).ToYaml(),
+						},
+					},
+					&testchangeset.HasLogSummary{WantLogSummary: "Attempt 2: my_dag.task_id_1 (2023-01-01T00:00:00Z)"},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
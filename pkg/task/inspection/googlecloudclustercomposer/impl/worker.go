@@ -27,7 +27,7 @@ import (
 
 var AirflowWorkerLogGrouperTask = inspectiontaskbase.NewLogGrouperTask(
 	googlecloudclustercomposer_contract.AirflowWorkerLogGrouperTaskID,
-	googlecloudclustercomposer_contract.AirflowWorkerLogFilterTaskID.Ref(),
+	googlecloudclustercomposer_contract.AirflowWorkerDagTaskFilterTaskID.Ref(),
 	func(ctx context.Context, l *log.Log) string {
 		return ""
 	},
@@ -35,7 +35,7 @@ var AirflowWorkerLogGrouperTask = inspectiontaskbase.NewLogGrouperTask(
 
 var AirflowWorkerLogIngesterTask = inspectiontaskbase.NewLogIngesterTask(
 	googlecloudclustercomposer_contract.AirflowWorkerLogIngesterTaskID,
-	googlecloudclustercomposer_contract.AirflowWorkerLogFilterTaskID.Ref(),
+	googlecloudclustercomposer_contract.AirflowWorkerDagTaskFilterTaskID.Ref(),
 )
 
 var AirflowWorkerLogToTimelineMapperTask = inspectiontaskbase.NewLogToTimelineMapperTask[struct{}](
@@ -75,13 +75,22 @@ func (c *airflowWorkerLogToTimelineMapperSetting) ProcessLogByGroup(ctx context.
 		cs.SetLogSummary(mainMessage.MainMessage)
 	}
 
+	commonField, _ := log.GetFieldSet(l, &log.CommonFieldSet{})
+	if attemptField, err := log.GetFieldSet(l, &googlecloudclustercomposer_contract.ComposerTaskAttemptFieldSet{}); err == nil {
+		recordTaskAttempt(cs, attemptField, "airflow-worker", commonField.Timestamp)
+		return struct{}{}, nil
+	}
+
 	workerTiField, err := log.GetFieldSet(l, &googlecloudclustercomposer_contract.ComposerWorkerTaskInstanceFieldSet{})
 	if err != nil {
 		return struct{}{}, nil
 	}
 	ti := workerTiField.TaskInstance
 
-	commonField, _ := log.GetFieldSet(l, &log.CommonFieldSet{})
+	// Map the task instance to the worker pod running it when the worker is only known from the message.
+	if ti.Host() != "" && (workerField == nil || ti.Host() != workerField.WorkerID) {
+		cs.AddEvent(googlecloudclustercomposer_contract.NewAirflowWorker(ti.Host()).ResourcePath())
+	}
 
 	r := ti.ResourcePath()
 	if ti.Status() == googlecloudclustercomposer_contract.TASKINSTANCE_NONE {
@@ -95,6 +95,65 @@ func TestAirflowWorkerMapperTask_ProcessLogByGroup(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Worker starting an attempt",
+			logs: []*log.Log{
+				log.NewLogWithFieldSetsForTest(
+					&log.CommonFieldSet{Timestamp: timestamp},
+					&log.MainMessageFieldSet{MainMessage: "Starting attempt 1 of 3"},
+					&googlecloudclustercomposer_contract.ComposerFieldSet{
+						WorkerID: "airflow-worker-abc",
+					},
+					&googlecloudclustercomposer_contract.ComposerTaskAttemptFieldSet{
+						TaskInstance: googlecloudclustercomposer_contract.NewAirflowTaskInstance(
+							"my_dag", "task_id_1", "2023-01-01T00:00:00Z", "-1", "airflow-worker-abc", googlecloudclustercomposer_contract.TASKINSTANCE_RUNNING,
+						),
+						TryNumber: 1,
+						MaxTries:  3,
+					},
+				),
+			},
+			asserters: [][]testchangeset.ChangeSetAsserter{
+				{
+					&testchangeset.HasEvent{
+						ResourcePath: googlecloudclustercomposer_contract.NewAirflowWorker("airflow-worker-abc").ResourcePath().Path,
+					},
+					&testchangeset.HasRevision{
+						ResourcePath: googlecloudclustercomposer_contract.NewAirflowTaskInstance("my_dag", "task_id_1", "2023-01-01T00:00:00Z", "-1", "airflow-worker-abc", googlecloudclustercomposer_contract.TASKINSTANCE_RUNNING).ResourcePath().Path,
+						WantRevision: history.StagingResourceRevision{
+							Verb:       enum.RevisionVerbComposerTaskInstanceRunning,
+							State:      enum.RevisionStateComposerTiRunning,
+							ChangeTime: timestamp,
+							Requestor:  "airflow-worker",
+							Body:       googlecloudclustercomposer_contract.NewAirflowTaskInstance("my_dag", "task_id_1", "2023-01-01T00:00:00Z", "-1", "airflow-worker-abc", googlecloudclustercomposer_contract.TASKINSTANCE_RUNNING).ToYaml(),
+						},
+					},
+					&testchangeset.HasLogSummary{WantLogSummary: "Attempt 1 of 3: my_dag.task_id_1 (2023-01-01T00:00:00Z)"},
+				},
+			},
+		},
+		{
+			name: "Worker running a task instance on another host",
+			logs: []*log.Log{
+				log.NewLogWithFieldSetsForTest(
+					&log.CommonFieldSet{Timestamp: timestamp},
+					&log.MainMessageFieldSet{MainMessage: "Running <TaskInstance: my_dag.task_id_1 2023-01-01T00:00:00Z [running]> on host airflow-worker-def"},
+					&googlecloudclustercomposer_contract.ComposerFieldSet{},
+					&googlecloudclustercomposer_contract.ComposerWorkerTaskInstanceFieldSet{
+						TaskInstance: googlecloudclustercomposer_contract.NewAirflowTaskInstance(
+							"my_dag", "task_id_1", "2023-01-01T00:00:00Z", "-1", "airflow-worker-def", googlecloudclustercomposer_contract.TASKINSTANCE_RUNNING,
+						),
+					},
+				),
+			},
+			asserters: [][]testchangeset.ChangeSetAsserter{
+				{
+					&testchangeset.HasEvent{
+						ResourcePath: googlecloudclustercomposer_contract.NewAirflowWorker("airflow-worker-def").ResourcePath().Path,
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
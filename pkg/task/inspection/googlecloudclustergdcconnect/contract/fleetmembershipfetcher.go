@@ -0,0 +1,107 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudclustergdcconnect_contract
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	gkehub "google.golang.org/api/gkehub/v1"
+)
+
+// FleetMembershipListFetcher fetches the on-prem clusters registered as fleet memberships in the project.
+type FleetMembershipListFetcher interface {
+	GetOnPremClusterIdentities(ctx context.Context, projectID string) ([]googlecloudk8scommon_contract.GoogleCloudClusterIdentity, error)
+}
+
+type FleetMembershipListFetcherImpl struct{}
+
+// GetOnPremClusterIdentities implements FleetMembershipListFetcher.
+func (f *FleetMembershipListFetcherImpl) GetOnPremClusterIdentities(ctx context.Context, projectID string) ([]googlecloudk8scommon_contract.GoogleCloudClusterIdentity, error) {
+	cf := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientFactoryTaskID.Ref())
+	injector := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientCallOptionsInjectorTaskID.Ref())
+
+	hubClient, err := cf.GKEHubService(ctx, googlecloud.Project(projectID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the gkehub api client:%v", err)
+	}
+
+	result := []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{}
+	var nextPageToken string
+	for {
+		req := hubClient.Projects.Locations.Memberships.List(fmt.Sprintf("projects/%s/locations/-", projectID)).PageToken(nextPageToken)
+		injector.InjectToCall(req, googlecloud.Project(projectID))
+		resp, err := req.Do()
+		if err != nil {
+			return nil, err
+		}
+		for _, membership := range resp.Resources {
+			if identity, ok := membershipToClusterIdentity(projectID, membership); ok {
+				result = append(result, identity)
+			}
+		}
+		nextPageToken = resp.NextPageToken
+		if nextPageToken == "" {
+			break
+		}
+	}
+	return result, nil
+}
+
+var _ FleetMembershipListFetcher = (*FleetMembershipListFetcherImpl)(nil)
+
+// membershipToClusterIdentity converts the fleet membership of an on-prem cluster to the cluster identity.
+// It returns false when the membership is not for an on-prem cluster.
+func membershipToClusterIdentity(projectID string, membership *gkehub.Membership) (googlecloudk8scommon_contract.GoogleCloudClusterIdentity, bool) {
+	if membership.Endpoint == nil || membership.Endpoint.OnPremCluster == nil {
+		return googlecloudk8scommon_contract.GoogleCloudClusterIdentity{}, false
+	}
+	// The name is in the form "projects/{projectId}/locations/{location}/memberships/{membershipId}"
+	clusterName := lastPathSegment(membership.Name)
+	location := pathSegmentAfter(membership.Name, "locations")
+	// The resource link is in the form "//gkeonprem.googleapis.com/projects/{projectId}/locations/{location}/{vmwareClusters|bareMetalClusters}/{clusterName}".
+	// The cluster name and the location in logs are the ones of the gkeonprem resource rather than the membership.
+	if resourceLink := membership.Endpoint.OnPremCluster.ResourceLink; resourceLink != "" {
+		clusterName = lastPathSegment(resourceLink)
+		if linkLocation := pathSegmentAfter(resourceLink, "locations"); linkLocation != "" {
+			location = linkLocation
+		}
+	}
+	return googlecloudk8scommon_contract.GoogleCloudClusterIdentity{
+		ProjectID:   projectID,
+		ClusterName: clusterName,
+		Location:    location,
+	}, true
+}
+
+func lastPathSegment(path string) string {
+	return path[strings.LastIndex(path, "/")+1:]
+}
+
+// pathSegmentAfter returns the path segment next to the given collection name. It returns an empty string when the collection is not found.
+func pathSegmentAfter(path string, collection string) string {
+	segments := strings.Split(path, "/")
+	for i := 0; i < len(segments)-1; i++ {
+		if segments[i] == collection {
+			return segments[i+1]
+		}
+	}
+	return ""
+}
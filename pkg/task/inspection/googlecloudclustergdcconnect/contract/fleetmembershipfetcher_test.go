@@ -0,0 +1,98 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudclustergdcconnect_contract
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	gkehub "google.golang.org/api/gkehub/v1"
+)
+
+func TestMembershipToClusterIdentity(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		membership *gkehub.Membership
+		want       googlecloudk8scommon_contract.GoogleCloudClusterIdentity
+		wantOk     bool
+	}{
+		{
+			desc: "vmware user cluster",
+			membership: &gkehub.Membership{
+				Name: "projects/foo-project/locations/global/memberships/user-cluster-membership",
+				Endpoint: &gkehub.MembershipEndpoint{
+					OnPremCluster: &gkehub.OnPremCluster{
+						ClusterType:  "USER",
+						ResourceLink: "//gkeonprem.googleapis.com/projects/foo-project/locations/us-west1/vmwareClusters/user-cluster",
+					},
+				},
+			},
+			want: googlecloudk8scommon_contract.GoogleCloudClusterIdentity{
+				ProjectID:   "foo-project",
+				ClusterName: "user-cluster",
+				Location:    "us-west1",
+			},
+			wantOk: true,
+		},
+		{
+			desc: "on-prem cluster without resource link",
+			membership: &gkehub.Membership{
+				Name: "projects/foo-project/locations/us-central1/memberships/baremetal-cluster",
+				Endpoint: &gkehub.MembershipEndpoint{
+					OnPremCluster: &gkehub.OnPremCluster{
+						ClusterType: "HYBRID",
+					},
+				},
+			},
+			want: googlecloudk8scommon_contract.GoogleCloudClusterIdentity{
+				ProjectID:   "foo-project",
+				ClusterName: "baremetal-cluster",
+				Location:    "us-central1",
+			},
+			wantOk: true,
+		},
+		{
+			desc: "gke cluster",
+			membership: &gkehub.Membership{
+				Name: "projects/foo-project/locations/us-central1/memberships/gke-cluster",
+				Endpoint: &gkehub.MembershipEndpoint{
+					GkeCluster: &gkehub.GkeCluster{
+						ResourceLink: "//container.googleapis.com/projects/foo-project/locations/us-central1/clusters/gke-cluster",
+					},
+				},
+			},
+			wantOk: false,
+		},
+		{
+			desc: "membership without endpoint",
+			membership: &gkehub.Membership{
+				Name: "projects/foo-project/locations/global/memberships/unknown",
+			},
+			wantOk: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, ok := membershipToClusterIdentity("foo-project", tc.membership)
+			if ok != tc.wantOk {
+				t.Fatalf("membershipToClusterIdentity() ok = %v, want %v", ok, tc.wantOk)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("membershipToClusterIdentity() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudclustergdcconnect_contract
+
+import (
+	"math"
+
+	coreinspection "github.com/kyasbal/khi/pkg/core/inspection"
+)
+
+// InspectionTypeId is the unique identifier for the GDC clusters connected through Connect gateway.
+var InspectionTypeId = "gcp-gdc-connect-gateway"
+
+// GDCConnectGatewayInspectionType defines the inspection type for GDC clusters connected through Connect gateway.
+var GDCConnectGatewayInspectionType = coreinspection.InspectionType{
+	Id:   InspectionTypeId,
+	Name: "GDC clusters via Connect gateway(Anthos on VMWare/Baremetal)",
+	Description: `Visualize logs generated from GDCV for VMWare or GDCV for Baremetal clusters registered to a fleet and connected through Connect gateway.
+Cluster names are suggested from the fleet memberships of the project.
+Supporting K8s audit log, k8s event log,k8s node log, k8s container log and OnPream API audit log.`,
+	Icon:     "assets/icons/anthos.png",
+	Priority: math.MaxInt - 5,
+}
@@ -0,0 +1,32 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudclustergdcconnect_contract
+
+import (
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+)
+
+// ClusterGDCConnectCommonTaskPrefix is the common task id prefix for GDC clusters connected through Connect gateway.
+var ClusterGDCConnectCommonTaskPrefix = googlecloudk8scommon_contract.GoogleCloudCommonK8STaskIDPrefix + "gdc-connect/"
+
+// ClusterNamePrefixTaskID is the task ID for the cluster name prefix of GDC clusters connected through Connect gateway.
+var ClusterNamePrefixTaskID = taskid.NewImplementationID(googlecloudk8scommon_contract.ClusterNamePrefixTaskRef, "gdc-connect")
+
+// AutocompleteClusterIdentityTaskID is the task ID for autocompleting the cluster identities from the fleet memberships.
+var AutocompleteClusterIdentityTaskID = taskid.NewImplementationID(googlecloudk8scommon_contract.AutocompleteClusterIdentityTaskID.Ref(), "gdc-connect")
+
+// FleetMembershipListFetcherTaskID is the task id for injecting FleetMembershipListFetcher instance.
+var FleetMembershipListFetcherTaskID = taskid.NewDefaultImplementationID[FleetMembershipListFetcher](ClusterGDCConnectCommonTaskPrefix + "fleet-membership-list-fetcher")
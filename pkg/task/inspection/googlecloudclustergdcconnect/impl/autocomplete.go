@@ -0,0 +1,75 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudclustergdcconnect_impl
+
+import (
+	"context"
+
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	googlecloudclustergdcconnect_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudclustergdcconnect/contract"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// AutocompleteClusterIdentityTask is an implementation for googlecloudk8scommon_contract.AutocompleteClusterIdentityTaskID
+// the task returns the on-prem clusters registered as the fleet memberships of the project.
+var AutocompleteClusterIdentityTask = inspectiontaskbase.NewPersistentCachedTask(googlecloudclustergdcconnect_contract.AutocompleteClusterIdentityTaskID, []taskid.UntypedTaskReference{
+	googlecloudclustergdcconnect_contract.FleetMembershipListFetcherTaskID.Ref(),
+	googlecloudcommon_contract.InputProjectIdTaskID.Ref(),
+}, func(ctx context.Context, prevValue inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]]) (inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]], error) {
+	projectID := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputProjectIdTaskID.Ref())
+
+	if projectID == prevValue.DependencyDigest && prevValue.Value != nil {
+		return prevValue, nil
+	}
+	if projectID == "" {
+		return inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]]{
+			DependencyDigest: projectID,
+			Value: &inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]{
+				Values: []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{},
+				Hint:   "Cluster names are suggested after the project ID is provided.",
+			},
+		}, nil
+	}
+
+	fetcher := coretask.GetTaskResult(ctx, googlecloudclustergdcconnect_contract.FleetMembershipListFetcherTaskID.Ref())
+	identities, err := fetcher.GetOnPremClusterIdentities(ctx, projectID)
+	if err != nil {
+		return inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]]{
+			DependencyDigest: projectID,
+			Value: &inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]{
+				Values: []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{},
+				Error:  "Failed to fetch the list of fleet memberships. Please confirm if the Project ID is correct and the GKE Hub API is enabled, or retry later",
+			},
+		}, nil
+	}
+
+	hint := ""
+	if len(identities) == 0 {
+		hint = "No on-prem clusters are registered to the fleet of this project. Please proceed by manually entering the cluster name."
+	}
+	return inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]]{
+		DependencyDigest: projectID,
+		Value: &inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]{
+			Values: identities,
+			Hint:   hint,
+		},
+	}, nil
+}, inspectioncore_contract.InspectionTypeLabel(googlecloudclustergdcconnect_contract.InspectionTypeId),
+	coretask.WithSelectionPriority(1000), // Override the default autocomplete using Cloud Monitoring because fleet memberships list the clusters even when the metrics are not exported.
+)
@@ -0,0 +1,107 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudclustergdcconnect_impl
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	googlecloudclustergdcconnect_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudclustergdcconnect/contract"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+type mockFleetMembershipListFetcher struct {
+	identities map[string][]googlecloudk8scommon_contract.GoogleCloudClusterIdentity
+	wantError  bool
+}
+
+// GetOnPremClusterIdentities implements googlecloudclustergdcconnect_contract.FleetMembershipListFetcher.
+func (m *mockFleetMembershipListFetcher) GetOnPremClusterIdentities(ctx context.Context, projectID string) ([]googlecloudk8scommon_contract.GoogleCloudClusterIdentity, error) {
+	if m.wantError {
+		return nil, fmt.Errorf("test error")
+	}
+	return m.identities[projectID], nil
+}
+
+var _ googlecloudclustergdcconnect_contract.FleetMembershipListFetcher = (*mockFleetMembershipListFetcher)(nil)
+
+func TestAutocompleteClusterIdentityTask(t *testing.T) {
+	fooClusters := []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{
+		{ProjectID: "foo-project", ClusterName: "user-cluster", Location: "us-west1"},
+		{ProjectID: "foo-project", ClusterName: "admin-cluster", Location: "us-west1"},
+	}
+	testCases := []struct {
+		desc        string
+		projectID   string
+		fetcherFail bool
+		want        *inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]
+	}{
+		{
+			desc:      "project id is empty",
+			projectID: "",
+			want: &inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]{
+				Values: []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{},
+				Hint:   "Cluster names are suggested after the project ID is provided.",
+			},
+		},
+		{
+			desc:      "clusters found",
+			projectID: "foo-project",
+			want: &inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]{
+				Values: fooClusters,
+			},
+		},
+		{
+			desc:      "no clusters found",
+			projectID: "bar-project",
+			want: &inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]{
+				Hint: "No on-prem clusters are registered to the fleet of this project. Please proceed by manually entering the cluster name.",
+			},
+		},
+		{
+			desc:        "with error",
+			projectID:   "foo-project",
+			fetcherFail: true,
+			want: &inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]{
+				Values: []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{},
+				Error:  "Failed to fetch the list of fleet memberships. Please confirm if the Project ID is correct and the GKE Hub API is enabled, or retry later",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+			result, _, err := inspectiontest.RunInspectionTask(ctx, AutocompleteClusterIdentityTask, inspectioncore_contract.TaskModeDryRun, map[string]any{},
+				tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.InputProjectIdTaskID.Ref(), tc.projectID),
+				tasktest.NewTaskDependencyValuePair[googlecloudclustergdcconnect_contract.FleetMembershipListFetcher](googlecloudclustergdcconnect_contract.FleetMembershipListFetcherTaskID.Ref(), &mockFleetMembershipListFetcher{
+					identities: map[string][]googlecloudk8scommon_contract.GoogleCloudClusterIdentity{"foo-project": fooClusters},
+					wantError:  tc.fetcherFail,
+				}),
+			)
+			if err != nil {
+				t.Fatalf("failed to run inspection task: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, result); diff != "" {
+				t.Errorf("result of AutocompleteClusterIdentityTask mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudclustergke_contract
+
+import (
+	"context"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+)
+
+// ClusterConfigFetcher fetches the configuration of GKE clusters from the container API.
+type ClusterConfigFetcher interface {
+	GetClusterConfig(ctx context.Context, cluster googlecloudk8scommon_contract.GoogleCloudClusterIdentity) (*ClusterConfigSnapshot, error)
+}
+
+type ClusterConfigFetcherImpl struct{}
+
+// GetClusterConfig implements ClusterConfigFetcher.
+func (c *ClusterConfigFetcherImpl) GetClusterConfig(ctx context.Context, cluster googlecloudk8scommon_contract.GoogleCloudClusterIdentity) (*ClusterConfigSnapshot, error) {
+	resp, err := getCluster(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	return NewClusterConfigSnapshot(resp), nil
+}
+
+var _ ClusterConfigFetcher = (*ClusterConfigFetcherImpl)(nil)
+
+// ClusterConfigSnapshot is the configuration of a GKE cluster captured at the time of the inspection.
+// It only contains the fields useful to interpret the timelines, e.g. versions and autoscaling settings.
+type ClusterConfigSnapshot struct {
+	Name                 string                    `json:"name"`
+	Location             string                    `json:"location"`
+	Status               string                    `json:"status"`
+	CurrentMasterVersion string                    `json:"currentMasterVersion"`
+	CurrentNodeVersion   string                    `json:"currentNodeVersion"`
+	ReleaseChannel       string                    `json:"releaseChannel"`
+	Autopilot            bool                      `json:"autopilot"`
+	NodeAutoProvisioning bool                      `json:"nodeAutoProvisioning"`
+	AutoscalingProfile   string                    `json:"autoscalingProfile"`
+	NodePools            []*NodePoolConfigSnapshot `json:"nodePools"`
+}
+
+// NodePoolConfigSnapshot is the configuration of a node pool in a GKE cluster captured at the time of the inspection.
+type NodePoolConfigSnapshot struct {
+	Name             string                       `json:"name"`
+	Status           string                       `json:"status"`
+	Version          string                       `json:"version"`
+	MachineType      string                       `json:"machineType"`
+	InitialNodeCount int32                        `json:"initialNodeCount"`
+	Locations        []string                     `json:"locations"`
+	Autoscaling      *NodePoolAutoscalingSnapshot `json:"autoscaling,omitempty"`
+}
+
+// NodePoolAutoscalingSnapshot is the cluster autoscaler setting of a node pool. It's nil in NodePoolConfigSnapshot when the autoscaling is disabled.
+type NodePoolAutoscalingSnapshot struct {
+	MinNodeCount      int32  `json:"minNodeCount"`
+	MaxNodeCount      int32  `json:"maxNodeCount"`
+	TotalMinNodeCount int32  `json:"totalMinNodeCount"`
+	TotalMaxNodeCount int32  `json:"totalMaxNodeCount"`
+	LocationPolicy    string `json:"locationPolicy"`
+	Autoprovisioned   bool   `json:"autoprovisioned"`
+}
+
+// NewClusterConfigSnapshot converts the cluster returned from the container API to ClusterConfigSnapshot.
+func NewClusterConfigSnapshot(cluster *containerpb.Cluster) *ClusterConfigSnapshot {
+	nodePools := []*NodePoolConfigSnapshot{}
+	for _, nodePool := range cluster.GetNodePools() {
+		nodePools = append(nodePools, newNodePoolConfigSnapshot(nodePool))
+	}
+	return &ClusterConfigSnapshot{
+		Name:                 cluster.GetName(),
+		Location:             cluster.GetLocation(),
+		Status:               cluster.GetStatus().String(),
+		CurrentMasterVersion: cluster.GetCurrentMasterVersion(),
+		CurrentNodeVersion:   cluster.GetCurrentNodeVersion(),
+		ReleaseChannel:       cluster.GetReleaseChannel().GetChannel().String(),
+		Autopilot:            cluster.GetAutopilot().GetEnabled(),
+		NodeAutoProvisioning: cluster.GetAutoscaling().GetEnableNodeAutoprovisioning(),
+		AutoscalingProfile:   cluster.GetAutoscaling().GetAutoscalingProfile().String(),
+		NodePools:            nodePools,
+	}
+}
+
+func newNodePoolConfigSnapshot(nodePool *containerpb.NodePool) *NodePoolConfigSnapshot {
+	snapshot := &NodePoolConfigSnapshot{
+		Name:             nodePool.GetName(),
+		Status:           nodePool.GetStatus().String(),
+		Version:          nodePool.GetVersion(),
+		MachineType:      nodePool.GetConfig().GetMachineType(),
+		InitialNodeCount: nodePool.GetInitialNodeCount(),
+		Locations:        nodePool.GetLocations(),
+	}
+	if autoscaling := nodePool.GetAutoscaling(); autoscaling.GetEnabled() {
+		snapshot.Autoscaling = &NodePoolAutoscalingSnapshot{
+			MinNodeCount:      autoscaling.GetMinNodeCount(),
+			MaxNodeCount:      autoscaling.GetMaxNodeCount(),
+			TotalMinNodeCount: autoscaling.GetTotalMinNodeCount(),
+			TotalMaxNodeCount: autoscaling.GetTotalMaxNodeCount(),
+			LocationPolicy:    autoscaling.GetLocationPolicy().String(),
+			Autoprovisioned:   autoscaling.GetAutoprovisioned(),
+		}
+	}
+	return snapshot
+}
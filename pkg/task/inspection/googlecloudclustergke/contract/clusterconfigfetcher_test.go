@@ -0,0 +1,136 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudclustergke_contract
+
+import (
+	"testing"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewClusterConfigSnapshot(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		cluster *containerpb.Cluster
+		want    *ClusterConfigSnapshot
+	}{
+		{
+			desc: "standard cluster with node pools",
+			cluster: &containerpb.Cluster{
+				Name:                 "foo-cluster",
+				Location:             "us-central1",
+				Status:               containerpb.Cluster_RUNNING,
+				CurrentMasterVersion: "1.30.5-gke.1014001",
+				CurrentNodeVersion:   "1.30.4-gke.1348000",
+				ReleaseChannel:       &containerpb.ReleaseChannel{Channel: containerpb.ReleaseChannel_REGULAR},
+				Autoscaling: &containerpb.ClusterAutoscaling{
+					EnableNodeAutoprovisioning: true,
+					AutoscalingProfile:         containerpb.ClusterAutoscaling_OPTIMIZE_UTILIZATION,
+				},
+				NodePools: []*containerpb.NodePool{
+					{
+						Name:             "default-pool",
+						Status:           containerpb.NodePool_RUNNING,
+						Version:          "1.30.4-gke.1348000",
+						Config:           &containerpb.NodeConfig{MachineType: "e2-medium"},
+						InitialNodeCount: 3,
+						Locations:        []string{"us-central1-a", "us-central1-b"},
+					},
+					{
+						Name:             "autoscaled-pool",
+						Status:           containerpb.NodePool_RECONCILING,
+						Version:          "1.30.5-gke.1014001",
+						Config:           &containerpb.NodeConfig{MachineType: "n2-standard-4"},
+						InitialNodeCount: 1,
+						Locations:        []string{"us-central1-a"},
+						Autoscaling: &containerpb.NodePoolAutoscaling{
+							Enabled:        true,
+							MinNodeCount:   1,
+							MaxNodeCount:   10,
+							LocationPolicy: containerpb.NodePoolAutoscaling_ANY,
+						},
+					},
+					{
+						Name:        "autoscaling-disabled-pool",
+						Autoscaling: &containerpb.NodePoolAutoscaling{Enabled: false, MaxNodeCount: 5},
+					},
+				},
+			},
+			want: &ClusterConfigSnapshot{
+				Name:                 "foo-cluster",
+				Location:             "us-central1",
+				Status:               "RUNNING",
+				CurrentMasterVersion: "1.30.5-gke.1014001",
+				CurrentNodeVersion:   "1.30.4-gke.1348000",
+				ReleaseChannel:       "REGULAR",
+				NodeAutoProvisioning: true,
+				AutoscalingProfile:   "OPTIMIZE_UTILIZATION",
+				NodePools: []*NodePoolConfigSnapshot{
+					{
+						Name:             "default-pool",
+						Status:           "RUNNING",
+						Version:          "1.30.4-gke.1348000",
+						MachineType:      "e2-medium",
+						InitialNodeCount: 3,
+						Locations:        []string{"us-central1-a", "us-central1-b"},
+					},
+					{
+						Name:             "autoscaled-pool",
+						Status:           "RECONCILING",
+						Version:          "1.30.5-gke.1014001",
+						MachineType:      "n2-standard-4",
+						InitialNodeCount: 1,
+						Locations:        []string{"us-central1-a"},
+						Autoscaling: &NodePoolAutoscalingSnapshot{
+							MinNodeCount:   1,
+							MaxNodeCount:   10,
+							LocationPolicy: "ANY",
+						},
+					},
+					{
+						Name:   "autoscaling-disabled-pool",
+						Status: "STATUS_UNSPECIFIED",
+					},
+				},
+			},
+		},
+		{
+			desc: "autopilot cluster without release channel",
+			cluster: &containerpb.Cluster{
+				Name:      "bar-cluster",
+				Location:  "asia-northeast1",
+				Autopilot: &containerpb.Autopilot{Enabled: true},
+			},
+			want: &ClusterConfigSnapshot{
+				Name:               "bar-cluster",
+				Location:           "asia-northeast1",
+				Status:             "STATUS_UNSPECIFIED",
+				ReleaseChannel:     "UNSPECIFIED",
+				Autopilot:          true,
+				AutoscalingProfile: "PROFILE_UNSPECIFIED",
+				NodePools:          []*NodePoolConfigSnapshot{},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := NewClusterConfigSnapshot(tc.cluster)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("NewClusterConfigSnapshot() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudclustergke_contract
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+var clusterLabelKeyValidator = regexp.MustCompile(`^[a-z][0-9a-z_\-]{0,62}$`)
+
+var clusterLabelValueValidator = regexp.MustCompile(`^[0-9a-z_\-]{0,63}$`)
+
+// ClusterLabelSelector is the set of resource labels a cluster must have to be discovered.
+// A cluster matches the selector when it has all the labels with the same values.
+type ClusterLabelSelector map[string]string
+
+// ParseClusterLabelSelector parses `key=value` pairs separated by spaces or commas. e.g. `team=payments env=prod`
+func ParseClusterLabelSelector(value string) (ClusterLabelSelector, error) {
+	selector := ClusterLabelSelector{}
+	pairs := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	for _, pair := range pairs {
+		key, labelValue, found := strings.Cut(pair, "=")
+		if !found {
+			return nil, fmt.Errorf("label `%s` must be in the form of `key=value`", pair)
+		}
+		if !clusterLabelKeyValidator.MatchString(key) {
+			return nil, fmt.Errorf("label key `%s` must match `^[a-z][0-9a-z_\\-]{0,62}$`", key)
+		}
+		if !clusterLabelValueValidator.MatchString(labelValue) {
+			return nil, fmt.Errorf("label value `%s` must match `^[0-9a-z_\\-]{0,63}$`", labelValue)
+		}
+		if current, found := selector[key]; found && current != labelValue {
+			return nil, fmt.Errorf("label key `%s` is specified more than once with different values", key)
+		}
+		selector[key] = labelValue
+	}
+	return selector, nil
+}
+
+// Matches returns true when the given labels contain all the labels of the selector.
+func (s ClusterLabelSelector) Matches(labels map[string]string) bool {
+	for key, value := range s {
+		labelValue, found := labels[key]
+		if !found || labelValue != value {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the selector in the form of `key=value` pairs sorted by their keys.
+func (s ClusterLabelSelector) String() string {
+	pairs := make([]string, 0, len(s))
+	for key, value := range s {
+		pairs = append(pairs, key+"="+value)
+	}
+	slices.Sort(pairs)
+	return strings.Join(pairs, " ")
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudclustergke_contract
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseClusterLabelSelector(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		value   string
+		want    ClusterLabelSelector
+		wantErr bool
+	}{
+		{
+			desc:  "empty",
+			value: "",
+			want:  ClusterLabelSelector{},
+		},
+		{
+			desc:  "space and comma separated labels",
+			value: " team=payments,env=prod  tier=",
+			want:  ClusterLabelSelector{"team": "payments", "env": "prod", "tier": ""},
+		},
+		{
+			desc:  "duplicated label with the same value",
+			value: "team=payments team=payments",
+			want:  ClusterLabelSelector{"team": "payments"},
+		},
+		{
+			desc:    "label without value",
+			value:   "team",
+			wantErr: true,
+		},
+		{
+			desc:    "invalid label key",
+			value:   "Team=payments",
+			wantErr: true,
+		},
+		{
+			desc:    "invalid label value",
+			value:   "team=Payments",
+			wantErr: true,
+		},
+		{
+			desc:    "duplicated label with different values",
+			value:   "team=payments team=billing",
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := ParseClusterLabelSelector(tc.value)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("ParseClusterLabelSelector(%q) returned no error", tc.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseClusterLabelSelector(%q) returned an unexpected error: %v", tc.value, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ParseClusterLabelSelector(%q) mismatch (-want +got):\n%s", tc.value, diff)
+			}
+		})
+	}
+}
+
+func TestClusterLabelSelectorMatches(t *testing.T) {
+	selector := ClusterLabelSelector{"team": "payments", "env": "prod"}
+	testCases := []struct {
+		desc   string
+		labels map[string]string
+		want   bool
+	}{
+		{
+			desc:   "all labels match",
+			labels: map[string]string{"team": "payments", "env": "prod", "owner": "foo"},
+			want:   true,
+		},
+		{
+			desc:   "a label value differs",
+			labels: map[string]string{"team": "payments", "env": "dev"},
+			want:   false,
+		},
+		{
+			desc:   "a label is missing",
+			labels: map[string]string{"team": "payments"},
+			want:   false,
+		},
+		{
+			desc:   "nil labels",
+			labels: nil,
+			want:   false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := selector.Matches(tc.labels); got != tc.want {
+				t.Errorf("Matches(%v) = %v, want %v", tc.labels, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestClusterLabelSelectorString(t *testing.T) {
+	selector := ClusterLabelSelector{"team": "payments", "env": "prod"}
+	want := "env=prod team=payments"
+	if got := selector.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
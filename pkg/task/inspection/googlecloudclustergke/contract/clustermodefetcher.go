@@ -0,0 +1,61 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudclustergke_contract
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+)
+
+// ClusterModeFetcher fetches the mode of operation of GKE clusters from the container API.
+type ClusterModeFetcher interface {
+	IsAutopilotCluster(ctx context.Context, cluster googlecloudk8scommon_contract.GoogleCloudClusterIdentity) (bool, error)
+}
+
+type ClusterModeFetcherImpl struct{}
+
+// IsAutopilotCluster implements ClusterModeFetcher.
+func (c *ClusterModeFetcherImpl) IsAutopilotCluster(ctx context.Context, cluster googlecloudk8scommon_contract.GoogleCloudClusterIdentity) (bool, error) {
+	resp, err := getCluster(ctx, cluster)
+	if err != nil {
+		return false, err
+	}
+	return resp.GetAutopilot().GetEnabled(), nil
+}
+
+// getCluster gets the cluster from the container API with the API client factory and the call options injector given from the task dependencies.
+func getCluster(ctx context.Context, cluster googlecloudk8scommon_contract.GoogleCloudClusterIdentity) (*containerpb.Cluster, error) {
+	cf := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientFactoryTaskID.Ref())
+	injector := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientCallOptionsInjectorTaskID.Ref())
+
+	client, err := cf.ContainerClusterManagerClient(ctx, googlecloud.Project(cluster.ProjectID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cluster manager client: %w", err)
+	}
+	defer client.Close()
+
+	ctx = injector.InjectToCallContext(ctx, googlecloud.Project(cluster.ProjectID))
+	return client.GetCluster(ctx, &containerpb.GetClusterRequest{
+		Name: fmt.Sprintf("projects/%s/locations/%s/clusters/%s", cluster.ProjectID, cluster.Location, cluster.ClusterName),
+	})
+}
+
+var _ ClusterModeFetcher = (*ClusterModeFetcherImpl)(nil)
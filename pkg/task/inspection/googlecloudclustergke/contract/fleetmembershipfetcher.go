@@ -0,0 +1,111 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudclustergke_contract
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	gkehub "google.golang.org/api/gkehub/v1"
+)
+
+// FleetMembershipFetcher fetches the GKE clusters registered as fleet memberships in the fleet host project.
+type FleetMembershipFetcher interface {
+	GetGKEFleetMemberships(ctx context.Context, fleetProjectID string) ([]googlecloudk8scommon_contract.GoogleCloudFleetMembership, error)
+}
+
+type FleetMembershipFetcherImpl struct{}
+
+// GetGKEFleetMemberships implements FleetMembershipFetcher.
+func (f *FleetMembershipFetcherImpl) GetGKEFleetMemberships(ctx context.Context, fleetProjectID string) ([]googlecloudk8scommon_contract.GoogleCloudFleetMembership, error) {
+	cf := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientFactoryTaskID.Ref())
+	injector := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientCallOptionsInjectorTaskID.Ref())
+
+	hubClient, err := cf.GKEHubService(ctx, googlecloud.Project(fleetProjectID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the gkehub api client:%v", err)
+	}
+
+	result := []googlecloudk8scommon_contract.GoogleCloudFleetMembership{}
+	var nextPageToken string
+	for {
+		req := hubClient.Projects.Locations.Memberships.List(fmt.Sprintf("projects/%s/locations/-", fleetProjectID)).PageToken(nextPageToken)
+		injector.InjectToCall(req, googlecloud.Project(fleetProjectID))
+		resp, err := req.Do()
+		if err != nil {
+			return nil, err
+		}
+		for _, membership := range resp.Resources {
+			if fleetMembership, ok := membershipToGKEFleetMembership(fleetProjectID, membership); ok {
+				result = append(result, fleetMembership)
+			}
+		}
+		nextPageToken = resp.NextPageToken
+		if nextPageToken == "" {
+			break
+		}
+	}
+	return result, nil
+}
+
+var _ FleetMembershipFetcher = (*FleetMembershipFetcherImpl)(nil)
+
+// membershipToGKEFleetMembership converts the fleet membership of a GKE cluster to GoogleCloudFleetMembership.
+// It returns false when the membership is not for a GKE cluster.
+func membershipToGKEFleetMembership(fleetProjectID string, membership *gkehub.Membership) (googlecloudk8scommon_contract.GoogleCloudFleetMembership, bool) {
+	if membership.Endpoint == nil || membership.Endpoint.GkeCluster == nil {
+		return googlecloudk8scommon_contract.GoogleCloudFleetMembership{}, false
+	}
+	// The resource link is in the form "//container.googleapis.com/projects/{projectId}/locations/{location}/clusters/{clusterName}".
+	// The project can be different from the fleet host project when the cluster is registered to the fleet of another project.
+	resourceLink := membership.Endpoint.GkeCluster.ResourceLink
+	projectID := pathSegmentAfter(resourceLink, "projects")
+	location := pathSegmentAfter(resourceLink, "locations")
+	clusterName := pathSegmentAfter(resourceLink, "clusters")
+	if projectID == "" || location == "" || clusterName == "" {
+		return googlecloudk8scommon_contract.GoogleCloudFleetMembership{}, false
+	}
+	state := ""
+	if membership.State != nil {
+		state = membership.State.Code
+	}
+	return googlecloudk8scommon_contract.GoogleCloudFleetMembership{
+		MembershipName: membership.Name,
+		FleetProjectID: fleetProjectID,
+		Cluster: googlecloudk8scommon_contract.GoogleCloudClusterIdentity{
+			ProjectID:   projectID,
+			ClusterName: clusterName,
+			Location:    location,
+		},
+		Labels: membership.Labels,
+		State:  state,
+	}, true
+}
+
+// pathSegmentAfter returns the path segment next to the given collection name. It returns an empty string when the collection is not found.
+func pathSegmentAfter(path string, collection string) string {
+	segments := strings.Split(path, "/")
+	for i := 0; i < len(segments)-1; i++ {
+		if segments[i] == collection {
+			return segments[i+1]
+		}
+	}
+	return ""
+}
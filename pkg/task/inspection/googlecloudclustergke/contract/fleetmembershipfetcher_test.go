@@ -0,0 +1,121 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudclustergke_contract
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	gkehub "google.golang.org/api/gkehub/v1"
+)
+
+func TestMembershipToGKEFleetMembership(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		membership *gkehub.Membership
+		want       googlecloudk8scommon_contract.GoogleCloudFleetMembership
+		wantOk     bool
+	}{
+		{
+			desc: "gke cluster in the fleet host project",
+			membership: &gkehub.Membership{
+				Name: "projects/fleet-project/locations/us-central1/memberships/gke-cluster",
+				Endpoint: &gkehub.MembershipEndpoint{
+					GkeCluster: &gkehub.GkeCluster{
+						ResourceLink: "//container.googleapis.com/projects/fleet-project/locations/us-central1/clusters/gke-cluster",
+					},
+				},
+				Labels: map[string]string{"env": "prod"},
+				State:  &gkehub.MembershipState{Code: "READY"},
+			},
+			want: googlecloudk8scommon_contract.GoogleCloudFleetMembership{
+				MembershipName: "projects/fleet-project/locations/us-central1/memberships/gke-cluster",
+				FleetProjectID: "fleet-project",
+				Cluster: googlecloudk8scommon_contract.GoogleCloudClusterIdentity{
+					ProjectID:   "fleet-project",
+					ClusterName: "gke-cluster",
+					Location:    "us-central1",
+				},
+				Labels: map[string]string{"env": "prod"},
+				State:  "READY",
+			},
+			wantOk: true,
+		},
+		{
+			desc: "zonal gke cluster in another project",
+			membership: &gkehub.Membership{
+				Name: "projects/fleet-project/locations/global/memberships/other-cluster",
+				Endpoint: &gkehub.MembershipEndpoint{
+					GkeCluster: &gkehub.GkeCluster{
+						ResourceLink: "//container.googleapis.com/projects/other-project/zones/us-west1-a/clusters/other-cluster",
+					},
+				},
+			},
+			wantOk: false,
+		},
+		{
+			desc: "gke cluster in another project",
+			membership: &gkehub.Membership{
+				Name: "projects/fleet-project/locations/global/memberships/other-cluster",
+				Endpoint: &gkehub.MembershipEndpoint{
+					GkeCluster: &gkehub.GkeCluster{
+						ResourceLink: "//container.googleapis.com/projects/other-project/locations/asia-northeast1/clusters/other-cluster",
+					},
+				},
+			},
+			want: googlecloudk8scommon_contract.GoogleCloudFleetMembership{
+				MembershipName: "projects/fleet-project/locations/global/memberships/other-cluster",
+				FleetProjectID: "fleet-project",
+				Cluster: googlecloudk8scommon_contract.GoogleCloudClusterIdentity{
+					ProjectID:   "other-project",
+					ClusterName: "other-cluster",
+					Location:    "asia-northeast1",
+				},
+			},
+			wantOk: true,
+		},
+		{
+			desc: "on-prem cluster",
+			membership: &gkehub.Membership{
+				Name: "projects/fleet-project/locations/global/memberships/user-cluster",
+				Endpoint: &gkehub.MembershipEndpoint{
+					OnPremCluster: &gkehub.OnPremCluster{
+						ResourceLink: "//gkeonprem.googleapis.com/projects/fleet-project/locations/us-west1/vmwareClusters/user-cluster",
+					},
+				},
+			},
+			wantOk: false,
+		},
+		{
+			desc: "membership without endpoint",
+			membership: &gkehub.Membership{
+				Name: "projects/fleet-project/locations/global/memberships/unknown",
+			},
+			wantOk: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, ok := membershipToGKEFleetMembership("fleet-project", tc.membership)
+			if ok != tc.wantOk {
+				t.Fatalf("membershipToGKEFleetMembership() ok = %v, want %v", ok, tc.wantOk)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("membershipToGKEFleetMembership() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudclustergke_contract
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+)
+
+// LabeledClusterFetcher lists the GKE clusters having the resource labels matching the selector.
+type LabeledClusterFetcher interface {
+	ListClustersWithLabels(ctx context.Context, projectID string, selector ClusterLabelSelector) ([]googlecloudk8scommon_contract.GoogleCloudClusterIdentity, error)
+}
+
+type LabeledClusterFetcherImpl struct{}
+
+// ListClustersWithLabels implements LabeledClusterFetcher.
+func (l *LabeledClusterFetcherImpl) ListClustersWithLabels(ctx context.Context, projectID string, selector ClusterLabelSelector) ([]googlecloudk8scommon_contract.GoogleCloudClusterIdentity, error) {
+	cf := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientFactoryTaskID.Ref())
+	injector := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientCallOptionsInjectorTaskID.Ref())
+
+	client, err := cf.ContainerClusterManagerClient(ctx, googlecloud.Project(projectID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cluster manager client: %w", err)
+	}
+	defer client.Close()
+
+	ctx = injector.InjectToCallContext(ctx, googlecloud.Project(projectID))
+	resp, err := client.ListClusters(ctx, &containerpb.ListClustersRequest{
+		Parent: fmt.Sprintf("projects/%s/locations/-", projectID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return clustersMatchingLabels(projectID, resp.GetClusters(), selector), nil
+}
+
+var _ LabeledClusterFetcher = (*LabeledClusterFetcherImpl)(nil)
+
+// clustersMatchingLabels returns the identities of the clusters having the resource labels matching the selector.
+func clustersMatchingLabels(projectID string, clusters []*containerpb.Cluster, selector ClusterLabelSelector) []googlecloudk8scommon_contract.GoogleCloudClusterIdentity {
+	result := []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{}
+	for _, cluster := range clusters {
+		if !selector.Matches(cluster.GetResourceLabels()) {
+			continue
+		}
+		result = append(result, googlecloudk8scommon_contract.GoogleCloudClusterIdentity{
+			ProjectID:   projectID,
+			ClusterName: cluster.GetName(),
+			Location:    cluster.GetLocation(),
+		})
+	}
+	return result
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudclustergke_contract
+
+import (
+	"testing"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/google/go-cmp/cmp"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+)
+
+func TestClustersMatchingLabels(t *testing.T) {
+	clusters := []*containerpb.Cluster{
+		{Name: "payments-prod", Location: "us-central1", ResourceLabels: map[string]string{"team": "payments", "env": "prod"}},
+		{Name: "payments-dev", Location: "us-central1-a", ResourceLabels: map[string]string{"team": "payments", "env": "dev"}},
+		{Name: "search-prod", Location: "asia-northeast1", ResourceLabels: map[string]string{"team": "search", "env": "prod"}},
+		{Name: "unlabeled", Location: "us-west1"},
+	}
+	testCases := []struct {
+		desc     string
+		selector ClusterLabelSelector
+		want     []googlecloudk8scommon_contract.GoogleCloudClusterIdentity
+	}{
+		{
+			desc:     "single label",
+			selector: ClusterLabelSelector{"team": "payments"},
+			want: []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{
+				{ProjectID: "foo-project", ClusterName: "payments-prod", Location: "us-central1"},
+				{ProjectID: "foo-project", ClusterName: "payments-dev", Location: "us-central1-a"},
+			},
+		},
+		{
+			desc:     "multiple labels",
+			selector: ClusterLabelSelector{"team": "payments", "env": "prod"},
+			want: []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{
+				{ProjectID: "foo-project", ClusterName: "payments-prod", Location: "us-central1"},
+			},
+		},
+		{
+			desc:     "no cluster matches",
+			selector: ClusterLabelSelector{"team": "billing"},
+			want:     []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := clustersMatchingLabels("foo-project", clusters, tc.selector)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("clustersMatchingLabels() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
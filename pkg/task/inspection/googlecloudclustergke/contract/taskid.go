@@ -31,3 +31,30 @@ var AutocompleteMetricsK8sContainerTaskIDForGKE = taskid.NewImplementationID(goo
 
 // AutocompleteMetricsK8sNodeTaskIDForGKE is the task ID for the metrics type used for autocomplete cluster names in GKE.
 var AutocompleteMetricsK8sNodeTaskIDForGKE = taskid.NewImplementationID(googlecloudk8scommon_contract.AutocompleteMetricsK8sNodeTaskID.Ref(), "gke")
+
+// AutocompleteFleetMembershipsTaskIDForGKE is the task ID for listing the GKE clusters registered to the fleet of the project.
+var AutocompleteFleetMembershipsTaskIDForGKE = taskid.NewImplementationID(googlecloudk8scommon_contract.AutocompleteFleetMembershipsTaskID.Ref(), "gke")
+
+// FleetMembershipFetcherTaskID is the task id for injecting FleetMembershipFetcher instance.
+var FleetMembershipFetcherTaskID = taskid.NewDefaultImplementationID[FleetMembershipFetcher](ClusterGKETaskCommonPrefix + "fleet-membership-fetcher")
+
+// AutopilotClusterTaskIDForGKE is the task ID for checking if any of the selected GKE clusters is an Autopilot cluster.
+var AutopilotClusterTaskIDForGKE = taskid.NewImplementationID(googlecloudk8scommon_contract.AutopilotClusterTaskID.Ref(), "gke")
+
+// ClusterModeFetcherTaskID is the task id for injecting ClusterModeFetcher instance.
+var ClusterModeFetcherTaskID = taskid.NewDefaultImplementationID[ClusterModeFetcher](ClusterGKETaskCommonPrefix + "cluster-mode-fetcher")
+
+// InputClusterLabelsTaskID is the task ID for the resource labels used to discover the clusters across the selected projects.
+var InputClusterLabelsTaskID = taskid.NewDefaultImplementationID[ClusterLabelSelector](ClusterGKETaskCommonPrefix + "input-cluster-labels")
+
+// LabeledClusterFetcherTaskID is the task id for injecting LabeledClusterFetcher instance.
+var LabeledClusterFetcherTaskID = taskid.NewDefaultImplementationID[LabeledClusterFetcher](ClusterGKETaskCommonPrefix + "labeled-cluster-fetcher")
+
+// AutocompleteLabeledClustersTaskIDForGKE is the task ID for listing the GKE clusters matching the resource labels in the selected projects.
+var AutocompleteLabeledClustersTaskIDForGKE = taskid.NewImplementationID(googlecloudk8scommon_contract.AutocompleteLabeledClustersTaskID.Ref(), "gke")
+
+// ClusterConfigFetcherTaskID is the task id for injecting ClusterConfigFetcher instance.
+var ClusterConfigFetcherTaskID = taskid.NewDefaultImplementationID[ClusterConfigFetcher](ClusterGKETaskCommonPrefix + "cluster-config-fetcher")
+
+// ClusterConfigSnapshotTaskID is the task ID for recording the configuration of the selected GKE clusters in the inspection metadata.
+var ClusterConfigSnapshotTaskID = taskid.NewDefaultImplementationID[struct{}](ClusterGKETaskCommonPrefix + "cluster-config-snapshot")
@@ -16,11 +16,16 @@ package googlecloudclustergke_impl
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
 	coretask "github.com/kyasbal/khi/pkg/core/task"
 	"github.com/kyasbal/khi/pkg/core/task/taskid"
 	googlecloudclustergke_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudclustergke/contract"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
 	googlecloudinspectiontypegroup_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudinspectiontypegroup/contract"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
 	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
 )
 
@@ -35,3 +40,101 @@ var AutocompleteMetricsK8sContainerTask = coretask.NewTask(googlecloudclustergke
 var AutocompleteMetricsK8sNodeTask = coretask.NewTask(googlecloudclustergke_contract.AutocompleteMetricsK8sNodeTaskIDForGKE, []taskid.UntypedTaskReference{}, func(ctx context.Context) (string, error) {
 	return "kubernetes.io/node/cpu/total_cores", nil
 }, coretask.WithSelectionPriority(1000), inspectioncore_contract.InspectionTypeLabel(googlecloudinspectiontypegroup_contract.GKEBasedClusterInspectionTypes...))
+
+// AutocompleteFleetMembershipsTask returns the GKE clusters registered to the fleet of the project.
+// The clusters can belong to other projects, so users inspecting a fleet can pick clusters across projects from the fleet host project.
+var AutocompleteFleetMembershipsTask = inspectiontaskbase.NewPersistentCachedTask(googlecloudclustergke_contract.AutocompleteFleetMembershipsTaskIDForGKE, []taskid.UntypedTaskReference{
+	googlecloudclustergke_contract.FleetMembershipFetcherTaskID.Ref(),
+	googlecloudcommon_contract.InputProjectIdTaskID.Ref(),
+}, func(ctx context.Context, prevValue inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudFleetMembership]]) (inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudFleetMembership]], error) {
+	projectID := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputProjectIdTaskID.Ref())
+
+	if projectID == prevValue.DependencyDigest && prevValue.Value != nil {
+		return prevValue, nil
+	}
+	if projectID == "" {
+		return inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudFleetMembership]]{
+			DependencyDigest: projectID,
+			Value: &inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudFleetMembership]{
+				Values: []googlecloudk8scommon_contract.GoogleCloudFleetMembership{},
+			},
+		}, nil
+	}
+
+	fetcher := coretask.GetTaskResult(ctx, googlecloudclustergke_contract.FleetMembershipFetcherTaskID.Ref())
+	memberships, err := fetcher.GetGKEFleetMemberships(ctx, projectID)
+	if err != nil {
+		// Projects without fleets or without the GKE Hub API enabled are common. The cluster names are still suggested from metrics.
+		return inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudFleetMembership]]{
+			DependencyDigest: projectID,
+			Value: &inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudFleetMembership]{
+				Values: []googlecloudk8scommon_contract.GoogleCloudFleetMembership{},
+				Error:  "Failed to fetch the list of fleet memberships. Please confirm if the GKE Hub API is enabled in the project, or retry later",
+			},
+		}, nil
+	}
+	return inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudFleetMembership]]{
+		DependencyDigest: projectID,
+		Value: &inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudFleetMembership]{
+			Values: memberships,
+		},
+	}, nil
+}, coretask.WithSelectionPriority(1000), inspectioncore_contract.InspectionTypeLabel(googlecloudclustergke_contract.InspectionTypeId))
+
+// AutocompleteLabeledClustersTask lists the GKE clusters having the resource labels given in the form across the project and the additional projects.
+var AutocompleteLabeledClustersTask = inspectiontaskbase.NewPersistentCachedTask(googlecloudclustergke_contract.AutocompleteLabeledClustersTaskIDForGKE, []taskid.UntypedTaskReference{
+	googlecloudclustergke_contract.LabeledClusterFetcherTaskID.Ref(),
+	googlecloudclustergke_contract.InputClusterLabelsTaskID.Ref(),
+	googlecloudcommon_contract.InputProjectIdTaskID.Ref(),
+	googlecloudcommon_contract.InputAdditionalProjectIDsTaskID.Ref(),
+}, func(ctx context.Context, prevValue inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]]) (inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]], error) {
+	selector := coretask.GetTaskResult(ctx, googlecloudclustergke_contract.InputClusterLabelsTaskID.Ref())
+	projectID := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputProjectIdTaskID.Ref())
+	additionalProjectIDs := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputAdditionalProjectIDsTaskID.Ref())
+
+	projectIDs := []string{}
+	if projectID != "" {
+		projectIDs = append(projectIDs, projectID)
+	}
+	for _, additionalProjectID := range additionalProjectIDs {
+		if additionalProjectID != projectID {
+			projectIDs = append(projectIDs, additionalProjectID)
+		}
+	}
+
+	currentDigest := fmt.Sprintf("%s-%s", selector.String(), strings.Join(projectIDs, ","))
+	if currentDigest == prevValue.DependencyDigest && prevValue.Value != nil {
+		return prevValue, nil
+	}
+	if len(selector) == 0 || len(projectIDs) == 0 {
+		return inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]]{
+			DependencyDigest: currentDigest,
+			Value: &inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]{
+				Values: []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{},
+			},
+		}, nil
+	}
+
+	fetcher := coretask.GetTaskResult(ctx, googlecloudclustergke_contract.LabeledClusterFetcherTaskID.Ref())
+	clusters := []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{}
+	failedProjectIDs := []string{}
+	for _, projectID := range projectIDs {
+		projectClusters, err := fetcher.ListClustersWithLabels(ctx, projectID, selector)
+		if err != nil {
+			failedProjectIDs = append(failedProjectIDs, projectID)
+			continue
+		}
+		clusters = append(clusters, projectClusters...)
+	}
+	errorString := ""
+	if len(failedProjectIDs) > 0 {
+		errorString = fmt.Sprintf("Failed to list the clusters with the labels in %s. Please confirm if the Kubernetes Engine API is enabled in the projects and you have the permission to list the clusters.", strings.Join(failedProjectIDs, ", "))
+	}
+	return inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]]{
+		DependencyDigest: currentDigest,
+		Value: &inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]{
+			Values: clusters,
+			Error:  errorString,
+		},
+	}, nil
+}, coretask.WithSelectionPriority(1000), inspectioncore_contract.InspectionTypeLabel(googlecloudclustergke_contract.InspectionTypeId))
@@ -0,0 +1,188 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudclustergke_impl
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	googlecloudclustergke_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudclustergke/contract"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+type mockFleetMembershipFetcher struct {
+	memberships map[string][]googlecloudk8scommon_contract.GoogleCloudFleetMembership
+	wantError   bool
+}
+
+// GetGKEFleetMemberships implements googlecloudclustergke_contract.FleetMembershipFetcher.
+func (m *mockFleetMembershipFetcher) GetGKEFleetMemberships(ctx context.Context, fleetProjectID string) ([]googlecloudk8scommon_contract.GoogleCloudFleetMembership, error) {
+	if m.wantError {
+		return nil, fmt.Errorf("test error")
+	}
+	return m.memberships[fleetProjectID], nil
+}
+
+var _ googlecloudclustergke_contract.FleetMembershipFetcher = (*mockFleetMembershipFetcher)(nil)
+
+func TestAutocompleteFleetMembershipsTask(t *testing.T) {
+	fleetMemberships := []googlecloudk8scommon_contract.GoogleCloudFleetMembership{
+		{
+			MembershipName: "projects/fleet-project/locations/us-central1/memberships/foo-cluster",
+			FleetProjectID: "fleet-project",
+			Cluster:        googlecloudk8scommon_contract.GoogleCloudClusterIdentity{ProjectID: "other-project", ClusterName: "foo-cluster", Location: "us-central1"},
+			Labels:         map[string]string{"env": "prod"},
+			State:          "READY",
+		},
+	}
+	testCases := []struct {
+		desc        string
+		projectID   string
+		fetcherFail bool
+		want        *inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudFleetMembership]
+	}{
+		{
+			desc:      "project id is empty",
+			projectID: "",
+			want: &inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudFleetMembership]{
+				Values: []googlecloudk8scommon_contract.GoogleCloudFleetMembership{},
+			},
+		},
+		{
+			desc:      "memberships found",
+			projectID: "fleet-project",
+			want: &inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudFleetMembership]{
+				Values: fleetMemberships,
+			},
+		},
+		{
+			desc:        "with error",
+			projectID:   "fleet-project",
+			fetcherFail: true,
+			want: &inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudFleetMembership]{
+				Values: []googlecloudk8scommon_contract.GoogleCloudFleetMembership{},
+				Error:  "Failed to fetch the list of fleet memberships. Please confirm if the GKE Hub API is enabled in the project, or retry later",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+			result, _, err := inspectiontest.RunInspectionTask(ctx, AutocompleteFleetMembershipsTask, inspectioncore_contract.TaskModeDryRun, map[string]any{},
+				tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.InputProjectIdTaskID.Ref(), tc.projectID),
+				tasktest.NewTaskDependencyValuePair[googlecloudclustergke_contract.FleetMembershipFetcher](googlecloudclustergke_contract.FleetMembershipFetcherTaskID.Ref(), &mockFleetMembershipFetcher{
+					memberships: map[string][]googlecloudk8scommon_contract.GoogleCloudFleetMembership{"fleet-project": fleetMemberships},
+					wantError:   tc.fetcherFail,
+				}),
+			)
+			if err != nil {
+				t.Fatalf("failed to run inspection task: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, result); diff != "" {
+				t.Errorf("result of AutocompleteFleetMembershipsTask mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+type mockLabeledClusterFetcher struct {
+	clusters       map[string][]googlecloudk8scommon_contract.GoogleCloudClusterIdentity
+	failedProjects []string
+}
+
+// ListClustersWithLabels implements googlecloudclustergke_contract.LabeledClusterFetcher.
+func (m *mockLabeledClusterFetcher) ListClustersWithLabels(ctx context.Context, projectID string, selector googlecloudclustergke_contract.ClusterLabelSelector) ([]googlecloudk8scommon_contract.GoogleCloudClusterIdentity, error) {
+	if slices.Contains(m.failedProjects, projectID) {
+		return nil, fmt.Errorf("test error")
+	}
+	return m.clusters[projectID], nil
+}
+
+var _ googlecloudclustergke_contract.LabeledClusterFetcher = (*mockLabeledClusterFetcher)(nil)
+
+func TestAutocompleteLabeledClustersTask(t *testing.T) {
+	fetcher := &mockLabeledClusterFetcher{
+		clusters: map[string][]googlecloudk8scommon_contract.GoogleCloudClusterIdentity{
+			"foo-project": {{ProjectID: "foo-project", ClusterName: "payments-prod", Location: "us-central1"}},
+			"bar-project": {{ProjectID: "bar-project", ClusterName: "payments-dev", Location: "asia-northeast1"}},
+		},
+		failedProjects: []string{"forbidden-project"},
+	}
+	testCases := []struct {
+		desc                 string
+		selector             googlecloudclustergke_contract.ClusterLabelSelector
+		projectID            string
+		additionalProjectIDs []string
+		want                 *inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]
+	}{
+		{
+			desc:                 "labels are empty",
+			selector:             googlecloudclustergke_contract.ClusterLabelSelector{},
+			projectID:            "foo-project",
+			additionalProjectIDs: []string{},
+			want: &inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]{
+				Values: []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{},
+			},
+		},
+		{
+			desc:                 "clusters across the projects",
+			selector:             googlecloudclustergke_contract.ClusterLabelSelector{"team": "payments"},
+			projectID:            "foo-project",
+			additionalProjectIDs: []string{"bar-project", "foo-project"},
+			want: &inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]{
+				Values: []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{
+					{ProjectID: "foo-project", ClusterName: "payments-prod", Location: "us-central1"},
+					{ProjectID: "bar-project", ClusterName: "payments-dev", Location: "asia-northeast1"},
+				},
+			},
+		},
+		{
+			desc:                 "clusters in the other projects are returned when a project failed",
+			selector:             googlecloudclustergke_contract.ClusterLabelSelector{"team": "payments"},
+			projectID:            "forbidden-project",
+			additionalProjectIDs: []string{"bar-project"},
+			want: &inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]{
+				Values: []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{
+					{ProjectID: "bar-project", ClusterName: "payments-dev", Location: "asia-northeast1"},
+				},
+				Error: "Failed to list the clusters with the labels in forbidden-project. Please confirm if the Kubernetes Engine API is enabled in the projects and you have the permission to list the clusters.",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+			result, _, err := inspectiontest.RunInspectionTask(ctx, AutocompleteLabeledClustersTask, inspectioncore_contract.TaskModeDryRun, map[string]any{},
+				tasktest.NewTaskDependencyValuePair(googlecloudclustergke_contract.InputClusterLabelsTaskID.Ref(), tc.selector),
+				tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.InputProjectIdTaskID.Ref(), tc.projectID),
+				tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.InputAdditionalProjectIDsTaskID.Ref(), tc.additionalProjectIDs),
+				tasktest.NewTaskDependencyValuePair[googlecloudclustergke_contract.LabeledClusterFetcher](googlecloudclustergke_contract.LabeledClusterFetcherTaskID.Ref(), fetcher),
+			)
+			if err != nil {
+				t.Fatalf("failed to run inspection task: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, result); diff != "" {
+				t.Errorf("result of AutocompleteLabeledClustersTask mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
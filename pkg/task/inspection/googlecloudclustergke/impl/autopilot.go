@@ -0,0 +1,69 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudclustergke_impl
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	googlecloudclustergke_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudclustergke/contract"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// AutopilotClusterTask checks if any of the selected GKE clusters is running in Autopilot mode with the container API.
+// The result is cached until the selected clusters change, because this task also runs in dry run mode to show form hints.
+var AutopilotClusterTask = inspectiontaskbase.NewPersistentCachedTask(googlecloudclustergke_contract.AutopilotClusterTaskIDForGKE, []taskid.UntypedTaskReference{
+	googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref(),
+	googlecloudclustergke_contract.ClusterModeFetcherTaskID.Ref(),
+}, func(ctx context.Context, prevValue inspectiontaskbase.CacheableTaskResult[bool]) (inspectiontaskbase.CacheableTaskResult[bool], error) {
+	clusters := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref())
+	fetcher := coretask.GetTaskResult(ctx, googlecloudclustergke_contract.ClusterModeFetcherTaskID.Ref())
+
+	digests := []string{}
+	for _, cluster := range clusters {
+		digests = append(digests, cluster.UniqueDigest())
+	}
+	currentDigest := strings.Join(digests, ",")
+	if currentDigest == prevValue.DependencyDigest {
+		return prevValue, nil
+	}
+
+	for _, cluster := range clusters {
+		if cluster.ProjectID == "" || cluster.ClusterName == "" || cluster.Location == "" {
+			continue
+		}
+		isAutopilot, err := fetcher.IsAutopilotCluster(ctx, cluster)
+		if err != nil {
+			// The cluster can be already deleted or the user may not have the permission to get it. Parsers for Standard clusters are still usable for Autopilot clusters.
+			slog.WarnContext(ctx, "failed to get the mode of the cluster. Assuming it's not an Autopilot cluster", "cluster", cluster.ClusterName, "error", err)
+			continue
+		}
+		if isAutopilot {
+			return inspectiontaskbase.CacheableTaskResult[bool]{
+				DependencyDigest: currentDigest,
+				Value:            true,
+			}, nil
+		}
+	}
+	return inspectiontaskbase.CacheableTaskResult[bool]{
+		DependencyDigest: currentDigest,
+		Value:            false,
+	}, nil
+}, coretask.WithSelectionPriority(1000), inspectioncore_contract.InspectionTypeLabel(googlecloudclustergke_contract.InspectionTypeId))
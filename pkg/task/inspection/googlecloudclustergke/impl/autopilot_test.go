@@ -0,0 +1,99 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudclustergke_impl
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	googlecloudclustergke_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudclustergke/contract"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+type mockClusterModeFetcher struct {
+	autopilotClusters map[string]bool
+	failingClusters   map[string]bool
+}
+
+// IsAutopilotCluster implements googlecloudclustergke_contract.ClusterModeFetcher.
+func (m *mockClusterModeFetcher) IsAutopilotCluster(ctx context.Context, cluster googlecloudk8scommon_contract.GoogleCloudClusterIdentity) (bool, error) {
+	if m.failingClusters[cluster.ClusterName] {
+		return false, fmt.Errorf("test error")
+	}
+	return m.autopilotClusters[cluster.ClusterName], nil
+}
+
+var _ googlecloudclustergke_contract.ClusterModeFetcher = (*mockClusterModeFetcher)(nil)
+
+func TestAutopilotClusterTask(t *testing.T) {
+	fetcher := &mockClusterModeFetcher{
+		autopilotClusters: map[string]bool{"autopilot-cluster": true},
+		failingClusters:   map[string]bool{"deleted-cluster": true},
+	}
+	testCases := []struct {
+		desc     string
+		clusters []string
+		want     bool
+	}{
+		{
+			desc:     "no cluster",
+			clusters: []string{},
+			want:     false,
+		},
+		{
+			desc:     "standard cluster",
+			clusters: []string{"standard-cluster"},
+			want:     false,
+		},
+		{
+			desc:     "autopilot cluster",
+			clusters: []string{"autopilot-cluster"},
+			want:     true,
+		},
+		{
+			desc:     "autopilot cluster selected with standard clusters",
+			clusters: []string{"standard-cluster", "autopilot-cluster"},
+			want:     true,
+		},
+		{
+			desc:     "failed to get the cluster",
+			clusters: []string{"deleted-cluster"},
+			want:     false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			clusters := []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{}
+			for _, name := range tc.clusters {
+				clusters = append(clusters, googlecloudk8scommon_contract.GoogleCloudClusterIdentity{ProjectID: "foo-project", ClusterName: name, Location: "us-central1"})
+			}
+			ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+			result, _, err := inspectiontest.RunInspectionTask(ctx, AutopilotClusterTask, inspectioncore_contract.TaskModeDryRun, map[string]any{},
+				tasktest.NewTaskDependencyValuePair(googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref(), clusters),
+				tasktest.NewTaskDependencyValuePair[googlecloudclustergke_contract.ClusterModeFetcher](googlecloudclustergke_contract.ClusterModeFetcherTaskID.Ref(), fetcher),
+			)
+			if err != nil {
+				t.Fatalf("failed to run inspection task: %v", err)
+			}
+			if result != tc.want {
+				t.Errorf("AutopilotClusterTask returned %v, want %v", result, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudclustergke_impl
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	googlecloudclustergke_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudclustergke/contract"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// ClusterConfigSnapshotTask records the cluster and node pool configuration of the selected GKE clusters in the inspection metadata.
+// Users can interpret the timelines with the versions and autoscaling settings at the time of the inspection without looking them up separately.
+var ClusterConfigSnapshotTask = inspectiontaskbase.NewInspectionTask(googlecloudclustergke_contract.ClusterConfigSnapshotTaskID, []taskid.UntypedTaskReference{
+	googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref(),
+	googlecloudclustergke_contract.ClusterConfigFetcherTaskID.Ref(),
+}, func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (struct{}, error) {
+	if taskMode == inspectioncore_contract.TaskModeDryRun {
+		return struct{}{}, nil
+	}
+	clusters := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref())
+	fetcher := coretask.GetTaskResult(ctx, googlecloudclustergke_contract.ClusterConfigFetcherTaskID.Ref())
+	metadataSet := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
+	snapshots, found := typedmap.Get(metadataSet, inspectionmetadata.ConfigSnapshotSetMetadataKey)
+	if !found {
+		return struct{}{}, fmt.Errorf("config snapshot metadata was not found")
+	}
+
+	for _, cluster := range clusters {
+		if cluster.ProjectID == "" || cluster.ClusterName == "" || cluster.Location == "" {
+			continue
+		}
+		config, err := fetcher.GetClusterConfig(ctx, cluster)
+		if err != nil {
+			// The cluster can be already deleted or the user may not have the permission to get it. Logs are still usable without the snapshot.
+			slog.WarnContext(ctx, "failed to get the configuration of the cluster. The snapshot is not included in the result", "cluster", cluster.ClusterName, "error", err)
+			continue
+		}
+		snapshots.AddSnapshot(&inspectionmetadata.ConfigSnapshot{
+			ID:       clusterResourceName(cluster),
+			Title:    fmt.Sprintf("GKE cluster %s", cluster.ClusterName),
+			Snapshot: config,
+		})
+	}
+	return struct{}{}, nil
+}, coretask.NewRequiredTaskLabel(), inspectioncore_contract.InspectionTypeLabel(googlecloudclustergke_contract.InspectionTypeId))
+
+func clusterResourceName(cluster googlecloudk8scommon_contract.GoogleCloudClusterIdentity) string {
+	return fmt.Sprintf("projects/%s/locations/%s/clusters/%s", cluster.ProjectID, cluster.Location, cluster.ClusterName)
+}
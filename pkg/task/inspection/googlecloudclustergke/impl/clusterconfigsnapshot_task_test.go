@@ -0,0 +1,122 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudclustergke_impl
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	googlecloudclustergke_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudclustergke/contract"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+type mockClusterConfigFetcher struct {
+	failingClusters map[string]bool
+	calledClusters  []string
+}
+
+// GetClusterConfig implements googlecloudclustergke_contract.ClusterConfigFetcher.
+func (m *mockClusterConfigFetcher) GetClusterConfig(ctx context.Context, cluster googlecloudk8scommon_contract.GoogleCloudClusterIdentity) (*googlecloudclustergke_contract.ClusterConfigSnapshot, error) {
+	m.calledClusters = append(m.calledClusters, cluster.ClusterName)
+	if m.failingClusters[cluster.ClusterName] {
+		return nil, fmt.Errorf("test error")
+	}
+	return &googlecloudclustergke_contract.ClusterConfigSnapshot{Name: cluster.ClusterName, Location: cluster.Location, CurrentMasterVersion: "1.30.5-gke.1014001"}, nil
+}
+
+var _ googlecloudclustergke_contract.ClusterConfigFetcher = (*mockClusterConfigFetcher)(nil)
+
+func TestClusterConfigSnapshotTask(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		mode       inspectioncore_contract.InspectionTaskModeType
+		clusters   []string
+		want       []*inspectionmetadata.ConfigSnapshot
+		wantCalled []string
+	}{
+		{
+			desc:       "records the snapshots of the clusters",
+			mode:       inspectioncore_contract.TaskModeRun,
+			clusters:   []string{"foo-cluster", "bar-cluster"},
+			wantCalled: []string{"foo-cluster", "bar-cluster"},
+			want: []*inspectionmetadata.ConfigSnapshot{
+				{
+					ID:       "projects/foo-project/locations/us-central1/clusters/bar-cluster",
+					Title:    "GKE cluster bar-cluster",
+					Snapshot: &googlecloudclustergke_contract.ClusterConfigSnapshot{Name: "bar-cluster", Location: "us-central1", CurrentMasterVersion: "1.30.5-gke.1014001"},
+				},
+				{
+					ID:       "projects/foo-project/locations/us-central1/clusters/foo-cluster",
+					Title:    "GKE cluster foo-cluster",
+					Snapshot: &googlecloudclustergke_contract.ClusterConfigSnapshot{Name: "foo-cluster", Location: "us-central1", CurrentMasterVersion: "1.30.5-gke.1014001"},
+				},
+			},
+		},
+		{
+			desc:       "skips the clusters failed to get",
+			mode:       inspectioncore_contract.TaskModeRun,
+			clusters:   []string{"deleted-cluster", "foo-cluster"},
+			wantCalled: []string{"deleted-cluster", "foo-cluster"},
+			want: []*inspectionmetadata.ConfigSnapshot{
+				{
+					ID:       "projects/foo-project/locations/us-central1/clusters/foo-cluster",
+					Title:    "GKE cluster foo-cluster",
+					Snapshot: &googlecloudclustergke_contract.ClusterConfigSnapshot{Name: "foo-cluster", Location: "us-central1", CurrentMasterVersion: "1.30.5-gke.1014001"},
+				},
+			},
+		},
+		{
+			desc:     "does not call the API in dry run mode",
+			mode:     inspectioncore_contract.TaskModeDryRun,
+			clusters: []string{"foo-cluster"},
+			want:     []*inspectionmetadata.ConfigSnapshot{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			fetcher := &mockClusterConfigFetcher{failingClusters: map[string]bool{"deleted-cluster": true}}
+			clusters := []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{}
+			for _, name := range tc.clusters {
+				clusters = append(clusters, googlecloudk8scommon_contract.GoogleCloudClusterIdentity{ProjectID: "foo-project", ClusterName: name, Location: "us-central1"})
+			}
+			ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+			_, metadata, err := inspectiontest.RunInspectionTask(ctx, ClusterConfigSnapshotTask, tc.mode, map[string]any{},
+				tasktest.NewTaskDependencyValuePair(googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref(), clusters),
+				tasktest.NewTaskDependencyValuePair[googlecloudclustergke_contract.ClusterConfigFetcher](googlecloudclustergke_contract.ClusterConfigFetcherTaskID.Ref(), fetcher),
+			)
+			if err != nil {
+				t.Fatalf("failed to run inspection task: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantCalled, fetcher.calledClusters); diff != "" {
+				t.Errorf("GetClusterConfig was called with unexpected clusters (-want +got):\n%s", diff)
+			}
+			snapshots, found := typedmap.Get(metadata, inspectionmetadata.ConfigSnapshotSetMetadataKey)
+			if !found {
+				t.Fatalf("config snapshot metadata was not found")
+			}
+			got := snapshots.ToSerializable().(*inspectionmetadata.ConfigSnapshotSetMetadata).Snapshots
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ClusterConfigSnapshotTask recorded unexpected snapshots (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
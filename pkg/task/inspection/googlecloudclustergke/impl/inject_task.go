@@ -0,0 +1,71 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudclustergke_impl
+
+import (
+	"context"
+
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	googlecloudclustergke_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudclustergke/contract"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+)
+
+// FleetMembershipFetcherTask injects FleetMembershipFetcher implementation.
+var FleetMembershipFetcherTask = coretask.NewTask(
+	googlecloudclustergke_contract.FleetMembershipFetcherTaskID,
+	[]taskid.UntypedTaskReference{
+		googlecloudcommon_contract.APIClientFactoryTaskID.Ref(),
+	},
+	func(ctx context.Context) (googlecloudclustergke_contract.FleetMembershipFetcher, error) {
+		return &googlecloudclustergke_contract.FleetMembershipFetcherImpl{}, nil
+	},
+)
+
+// ClusterModeFetcherTask injects ClusterModeFetcher implementation.
+var ClusterModeFetcherTask = coretask.NewTask(
+	googlecloudclustergke_contract.ClusterModeFetcherTaskID,
+	[]taskid.UntypedTaskReference{
+		googlecloudcommon_contract.APIClientFactoryTaskID.Ref(),
+		googlecloudcommon_contract.APIClientCallOptionsInjectorTaskID.Ref(),
+	},
+	func(ctx context.Context) (googlecloudclustergke_contract.ClusterModeFetcher, error) {
+		return &googlecloudclustergke_contract.ClusterModeFetcherImpl{}, nil
+	},
+)
+
+// LabeledClusterFetcherTask injects LabeledClusterFetcher implementation.
+var LabeledClusterFetcherTask = coretask.NewTask(
+	googlecloudclustergke_contract.LabeledClusterFetcherTaskID,
+	[]taskid.UntypedTaskReference{
+		googlecloudcommon_contract.APIClientFactoryTaskID.Ref(),
+		googlecloudcommon_contract.APIClientCallOptionsInjectorTaskID.Ref(),
+	},
+	func(ctx context.Context) (googlecloudclustergke_contract.LabeledClusterFetcher, error) {
+		return &googlecloudclustergke_contract.LabeledClusterFetcherImpl{}, nil
+	},
+)
+
+// ClusterConfigFetcherTask injects ClusterConfigFetcher implementation.
+var ClusterConfigFetcherTask = coretask.NewTask(
+	googlecloudclustergke_contract.ClusterConfigFetcherTaskID,
+	[]taskid.UntypedTaskReference{
+		googlecloudcommon_contract.APIClientFactoryTaskID.Ref(),
+		googlecloudcommon_contract.APIClientCallOptionsInjectorTaskID.Ref(),
+	},
+	func(ctx context.Context) (googlecloudclustergke_contract.ClusterConfigFetcher, error) {
+		return &googlecloudclustergke_contract.ClusterConfigFetcherImpl{}, nil
+	},
+)
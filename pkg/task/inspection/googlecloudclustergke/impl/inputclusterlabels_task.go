@@ -0,0 +1,49 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudclustergke_impl
+
+import (
+	"context"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	googlecloudclustergke_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudclustergke/contract"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+)
+
+// InputClusterLabelsTask is a form task receiving the resource labels to discover the GKE clusters across the project and the additional projects.
+// The discovered clusters are suggested in the cluster name input. This is useful when the user doesn't remember the cluster names.
+var InputClusterLabelsTask = formtask.NewTextFormTaskBuilder(googlecloudclustergke_contract.InputClusterLabelsTaskID, 0, "Cluster labels").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionResourceIdentifier, After: []string{googlecloudcommon_contract.InputProjectIdTaskID.ReferenceIDString(), googlecloudcommon_contract.InputAdditionalProjectIDsTaskID.ReferenceIDString()}, Before: []string{googlecloudk8scommon_contract.InputClusterNameTaskID.ReferenceIDString()}}).
+	WithPlaceholder("e.g. team=payments env=prod").
+	WithDescription("Space or comma separated resource labels in the form of `key=value`. The clusters having all these labels in the selected projects are suggested in the cluster name input. Leave this empty when you know the cluster name.").
+	WithValidatingTiming(inspectionmetadata.Blur).
+	WithDefaultValueFunc(func(ctx context.Context, previousValues []string) (string, error) {
+		if len(previousValues) > 0 {
+			return previousValues[0], nil
+		}
+		return "", nil
+	}).
+	WithValidator(func(ctx context.Context, value string) (string, error) {
+		if _, err := googlecloudclustergke_contract.ParseClusterLabelSelector(value); err != nil {
+			return err.Error(), nil
+		}
+		return "", nil
+	}).
+	WithConverter(func(ctx context.Context, value string) (googlecloudclustergke_contract.ClusterLabelSelector, error) {
+		return googlecloudclustergke_contract.ParseClusterLabelSelector(value)
+	}).
+	Build()
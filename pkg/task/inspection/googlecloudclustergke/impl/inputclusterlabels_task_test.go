@@ -0,0 +1,79 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudclustergke_impl
+
+import (
+	"testing"
+
+	form_task_test "github.com/kyasbal/khi/pkg/core/inspection/formtask/test"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	googlecloudclustergke_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudclustergke/contract"
+)
+
+func TestInputClusterLabelsTask(t *testing.T) {
+	wantDescription := "Space or comma separated resource labels in the form of `key=value`. The clusters having all these labels in the selected projects are suggested in the cluster name input. Leave this empty when you know the cluster name."
+	wantPlaceholder := "e.g. team=payments env=prod"
+	form_task_test.TestTextForms(t, "cluster-labels", InputClusterLabelsTask, []*form_task_test.TextFormTestCase{
+		{
+			Name:          "empty value",
+			Input:         "",
+			ExpectedValue: googlecloudclustergke_contract.ClusterLabelSelector{},
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					ID:          googlecloudclustergke_contract.InputClusterLabelsTaskID.ReferenceIDString(),
+					Type:        inspectionmetadata.Text,
+					Label:       "Cluster labels",
+					Description: wantDescription,
+					HintType:    inspectionmetadata.None,
+				},
+				ValidationTiming: inspectionmetadata.Blur,
+				Placeholder:      wantPlaceholder,
+			},
+		},
+		{
+			Name:          "space and comma separated labels",
+			Input:         "team=payments, env=prod",
+			ExpectedValue: googlecloudclustergke_contract.ClusterLabelSelector{"team": "payments", "env": "prod"},
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					ID:          googlecloudclustergke_contract.InputClusterLabelsTaskID.ReferenceIDString(),
+					Type:        inspectionmetadata.Text,
+					Label:       "Cluster labels",
+					Description: wantDescription,
+					HintType:    inspectionmetadata.None,
+				},
+				ValidationTiming: inspectionmetadata.Blur,
+				Placeholder:      wantPlaceholder,
+			},
+		},
+		{
+			Name:          "label without value",
+			Input:         "team",
+			ExpectedValue: googlecloudclustergke_contract.ClusterLabelSelector{},
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					ID:          googlecloudclustergke_contract.InputClusterLabelsTaskID.ReferenceIDString(),
+					Type:        inspectionmetadata.Text,
+					Label:       "Cluster labels",
+					Description: wantDescription,
+					HintType:    inspectionmetadata.Error,
+					Hint:        "label `team` must be in the form of `key=value`",
+				},
+				ValidationTiming: inspectionmetadata.Blur,
+				Placeholder:      wantPlaceholder,
+			},
+		},
+	})
+}
@@ -30,5 +30,14 @@ func Register(registry coreinspection.InspectionTaskRegistry) error {
 		GKEClusterNamePrefixTask,
 		AutocompleteMetricsK8sContainerTask,
 		AutocompleteMetricsK8sNodeTask,
+		AutocompleteFleetMembershipsTask,
+		FleetMembershipFetcherTask,
+		AutopilotClusterTask,
+		ClusterModeFetcherTask,
+		InputClusterLabelsTask,
+		AutocompleteLabeledClustersTask,
+		LabeledClusterFetcherTask,
+		ClusterConfigFetcherTask,
+		ClusterConfigSnapshotTask,
 	)
 }
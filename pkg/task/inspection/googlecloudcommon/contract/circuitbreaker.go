@@ -0,0 +1,104 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_contract
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrLoggingCircuitOpen is the error returned without sending requests after Cloud Logging failed repeatedly for a resource container in an inspection run.
+var ErrLoggingCircuitOpen = errors.New("cloud logging requests are stopped after repeated failures")
+
+// LoggingCircuitBreaker stops the Cloud Logging API requests sent to a resource container from all log queries in an inspection run after the API failed repeatedly for the container.
+// Each resource container has its own circuit, because a failing project must not stop the queries against other healthy projects.
+// Only server errors are counted as failures. Permission errors are not counted because they are specific to each query and retrying them is already prevented.
+// Once a circuit is opened, it stays open until the end of the inspection run. The nil value never opens the circuit.
+type LoggingCircuitBreaker struct {
+	failureThreshold int
+	lock             sync.Mutex
+	// circuits are the circuits of resource containers keyed by the container identifier.
+	circuits map[string]*loggingCircuit
+}
+
+// loggingCircuit is the state of the circuit for a resource container.
+type loggingCircuit struct {
+	consecutiveFailures int
+	// openCause is the error opened the circuit. The circuit is closed while it's nil.
+	openCause error
+}
+
+// NewLoggingCircuitBreaker returns a LoggingCircuitBreaker opening the circuit of a resource container after failureThreshold failures in series.
+// A non positive value disables the circuit breaker.
+func NewLoggingCircuitBreaker(failureThreshold int) *LoggingCircuitBreaker {
+	return &LoggingCircuitBreaker{
+		failureThreshold: failureThreshold,
+		circuits:         map[string]*loggingCircuit{},
+	}
+}
+
+// Allow returns an error wrapping ErrLoggingCircuitOpen and the error opened the circuit when requests to the container must not be sent.
+func (c *LoggingCircuitBreaker) Allow(container googlecloud.ResourceContainer) error {
+	if c == nil {
+		return nil
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	circuit, found := c.circuits[container.Identifier()]
+	if found && circuit.openCause != nil {
+		return fmt.Errorf("%w for %s: %w", ErrLoggingCircuitOpen, container.Identifier(), circuit.openCause)
+	}
+	return nil
+}
+
+// Record records the result of a request to the container. A successful request resets the count of failures, and errors not caused by the server are ignored.
+func (c *LoggingCircuitBreaker) Record(container googlecloud.ResourceContainer, err error) {
+	if c == nil || c.failureThreshold <= 0 {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	circuit, found := c.circuits[container.Identifier()]
+	if !found {
+		circuit = &loggingCircuit{}
+		c.circuits[container.Identifier()] = circuit
+	}
+	if err == nil {
+		circuit.consecutiveFailures = 0
+		return
+	}
+	if !isCircuitBreakingError(err) {
+		return
+	}
+	circuit.consecutiveFailures++
+	if circuit.consecutiveFailures >= c.failureThreshold && circuit.openCause == nil {
+		circuit.openCause = err
+	}
+}
+
+// isCircuitBreakingError returns true when the error is a server error of Cloud Logging.
+func isCircuitBreakingError(err error) bool {
+	switch status.Code(err) {
+	case codes.Internal, codes.Unavailable, codes.Unknown, codes.DataLoss:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_contract
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLoggingCircuitBreaker(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "service unavailable")
+	internal := status.Error(codes.Internal, "internal error")
+	permissionDenied := status.Error(codes.PermissionDenied, "permission denied")
+	unauthenticated := status.Error(codes.Unauthenticated, "unauthenticated")
+	invalidArgument := status.Error(codes.InvalidArgument, "invalid filter")
+	testCases := []struct {
+		name             string
+		failureThreshold int
+		results          []error
+		wantOpenCause    error
+	}{
+		{
+			name:             "opens after the failures in series",
+			failureThreshold: 3,
+			results:          []error{unavailable, internal, unavailable},
+			wantOpenCause:    unavailable,
+		},
+		{
+			name:             "a success resets the failure count",
+			failureThreshold: 3,
+			results:          []error{unavailable, internal, nil, unavailable, unavailable},
+		},
+		{
+			name:             "errors unrelated to the server are ignored",
+			failureThreshold: 2,
+			results:          []error{internal, invalidArgument, internal},
+			wantOpenCause:    internal,
+		},
+		{
+			name:             "permission errors never open",
+			failureThreshold: 1,
+			results:          []error{permissionDenied, unauthenticated, permissionDenied},
+		},
+		{
+			name:             "keeps the first cause after opened",
+			failureThreshold: 1,
+			results:          []error{internal, unavailable, nil},
+			wantOpenCause:    internal,
+		},
+		{
+			name:             "non positive threshold never opens",
+			failureThreshold: 0,
+			results:          []error{unavailable, unavailable, unavailable},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			container := googlecloud.Project("foo")
+			breaker := NewLoggingCircuitBreaker(tc.failureThreshold)
+			for _, result := range tc.results {
+				breaker.Record(container, result)
+			}
+			err := breaker.Allow(container)
+			if tc.wantOpenCause == nil {
+				if err != nil {
+					t.Errorf("Allow() returned an unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrLoggingCircuitOpen) || !errors.Is(err, tc.wantOpenCause) {
+				t.Errorf("Allow() returned %v, want an error wrapping ErrLoggingCircuitOpen and %v", err, tc.wantOpenCause)
+			}
+		})
+	}
+}
+
+func TestLoggingCircuitBreaker_PerResourceContainer(t *testing.T) {
+	failing := googlecloud.Project("failing")
+	healthy := googlecloud.Project("healthy")
+	breaker := NewLoggingCircuitBreaker(2)
+	breaker.Record(failing, status.Error(codes.Unavailable, "service unavailable"))
+	breaker.Record(healthy, nil)
+	breaker.Record(failing, status.Error(codes.Unavailable, "service unavailable"))
+
+	if err := breaker.Allow(failing); !errors.Is(err, ErrLoggingCircuitOpen) {
+		t.Errorf("Allow() for the failing container returned %v, want an error wrapping ErrLoggingCircuitOpen", err)
+	}
+	if err := breaker.Allow(healthy); err != nil {
+		t.Errorf("Allow() for the healthy container returned an unexpected error: %v", err)
+	}
+}
+
+func TestLoggingCircuitBreaker_Nil(t *testing.T) {
+	var breaker *LoggingCircuitBreaker
+	container := googlecloud.Project("foo")
+	breaker.Record(container, status.Error(codes.Unavailable, "service unavailable"))
+	if err := breaker.Allow(container); err != nil {
+		t.Errorf("Allow() of the nil circuit breaker returned an unexpected error: %v", err)
+	}
+}
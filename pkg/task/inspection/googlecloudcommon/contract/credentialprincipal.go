@@ -0,0 +1,64 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_contract
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log/slog"
+
+	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	"github.com/kyasbal/khi/pkg/api/googlecloud/credentialsource"
+)
+
+// CredentialPrincipalResolver is implemented by LogFetchers able to tell the identity calling Cloud Logging.
+// Logs stored beyond a single query are keyed with the identity not to return logs fetched by an identity to another identity.
+type CredentialPrincipalResolver interface {
+	// CredentialPrincipal returns the string identifying the principal calling Cloud Logging.
+	CredentialPrincipal(ctx context.Context) (string, error)
+}
+
+// credentialPrincipalOf returns the principal calling Cloud Logging with the fetcher.
+// It returns an empty string when the fetcher can't tell the principal. Logs must not be stored beyond the query in that case.
+func credentialPrincipalOf(ctx context.Context, fetcher LogFetcher) string {
+	resolver, ok := fetcher.(CredentialPrincipalResolver)
+	if !ok {
+		return ""
+	}
+	principal, err := resolver.CredentialPrincipal(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, fmt.Sprintf("failed to determine the principal calling Cloud Logging. Logs are not stored for reuse\n%v", err))
+		return ""
+	}
+	return principal
+}
+
+// resolveCredentialPrincipal returns the email of the principal used by the clients generated from the factory.
+// The digest of the access token is used instead when the email is not available. The token is bound to a principal, but it changes when the token is refreshed.
+func resolveCredentialPrincipal(ctx context.Context, factory *googlecloud.ClientFactory) (string, error) {
+	tokenSource, err := factory.TokenSource(ctx, googlecloud.Project(""))
+	if err != nil {
+		return "", err
+	}
+	if email := credentialsource.PrincipalOf(ctx, tokenSource); email != "" {
+		return email, nil
+	}
+	token, err := tokenSource.Token()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("token-sha256:%x", sha256.Sum256([]byte(token.AccessToken))), nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_contract
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// principalResolvingLogFetcher is a LogFetcher implementing CredentialPrincipalResolver for testing.
+type principalResolvingLogFetcher struct {
+	mockLogFetcher
+	principal string
+	err       error
+}
+
+// CredentialPrincipal implements CredentialPrincipalResolver.
+func (f *principalResolvingLogFetcher) CredentialPrincipal(ctx context.Context) (string, error) {
+	return f.principal, f.err
+}
+
+var _ CredentialPrincipalResolver = (*principalResolvingLogFetcher)(nil)
+
+func TestCredentialPrincipalOf(t *testing.T) {
+	testCases := []struct {
+		name    string
+		fetcher LogFetcher
+		want    string
+	}{
+		{
+			name:    "fetcher not resolving the principal",
+			fetcher: &mockLogFetcher{},
+			want:    "",
+		},
+		{
+			name:    "fetcher failed to resolve the principal",
+			fetcher: &principalResolvingLogFetcher{err: errors.New("test error")},
+			want:    "",
+		},
+		{
+			name:    "fetcher resolving the principal",
+			fetcher: &principalResolvingLogFetcher{principal: "foo@example.com"},
+			want:    "foo@example.com",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := credentialPrincipalOf(context.Background(), tc.fetcher); got != tc.want {
+				t.Errorf("credentialPrincipalOf() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -14,13 +14,15 @@
 
 package googlecloudcommon_contract
 
-const (
-	// FormBasePriority is the base priority for Google Cloud common forms.
-	FormBasePriority = 100000
-	// PriorityForQueryTimeGroup is the priority for the query time group.
-	PriorityForQueryTimeGroup = FormBasePriority + 50000
-	// PriorityForResourceIdentifierGroup is the priority for the resource identifier group.
-	PriorityForResourceIdentifierGroup = FormBasePriority + 40000
-	// PriorityForK8sResourceFilterGroup is the priority for the k8s resource filter group.
-	PriorityForK8sResourceFilterGroup = FormBasePriority + 30000
+import (
+	"context"
+
+	"github.com/kyasbal/khi/pkg/api/googlecloud/credentialsource"
 )
+
+// CredentialSourceDetector detects the credential sources available for the Google Cloud API calls.
+type CredentialSourceDetector interface {
+	Detect(ctx context.Context) []credentialsource.Candidate
+}
+
+var _ CredentialSourceDetector = (*credentialsource.Detector)(nil)
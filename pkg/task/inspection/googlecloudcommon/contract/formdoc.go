@@ -0,0 +1,24 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_contract
+
+// formReferenceDocumentURL is the URL of the reference document of form fields generated from the task graph.
+const formReferenceDocumentURL = "https://github.com/kyasbal/khi/blob/main/docs/en/reference/forms.md"
+
+// FormReferenceDocURL returns the URL of the section in the form reference document.
+// The anchor is the one generated from the heading of the section, that is the label of the field in lower case without symbols and spaces replaced with `-`.
+func FormReferenceDocURL(anchor string) string {
+	return formReferenceDocumentURL + "#" + anchor
+}
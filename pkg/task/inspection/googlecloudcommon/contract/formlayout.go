@@ -0,0 +1,77 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_contract
+
+import (
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	commontimerange_contract "github.com/kyasbal/khi/pkg/task/inspection/commontimerange/contract"
+)
+
+// FormSectionQueryTime is the form section for the time range of the queries.
+// This is placed after the source neutral query time section, thus the sections placed after this section follow the source neutral one in forms of sources not using Cloud Logging.
+var FormSectionQueryTime = &inspectionmetadata.FormSection{
+	ID:    GoogleCloudCommonTaskIDPrefix + "form-section/query-time",
+	Label: "Query time range",
+	After: commontimerange_contract.FormSectionQueryTime,
+}
+
+// FormSectionResourceIdentifier is the form section for the fields identifying the resource like the project ID or the cluster name.
+var FormSectionResourceIdentifier = &inspectionmetadata.FormSection{
+	ID:    GoogleCloudCommonTaskIDPrefix + "form-section/resource-identifier",
+	Label: "Resource",
+	After: FormSectionQueryTime,
+}
+
+// FormSectionK8sResourceFilter is the form section for the filters of Kubernetes resources used in multiple log types.
+var FormSectionK8sResourceFilter = &inspectionmetadata.FormSection{
+	ID:    GoogleCloudCommonTaskIDPrefix + "form-section/k8s-resource-filter",
+	Label: "Kubernetes resource filter",
+	After: FormSectionResourceIdentifier,
+}
+
+// FormSectionControlPlaneLogFilter is the form section for the filters only used for control plane component logs.
+var FormSectionControlPlaneLogFilter = &inspectionmetadata.FormSection{
+	ID:    GoogleCloudCommonTaskIDPrefix + "form-section/control-plane-log-filter",
+	Label: "Control plane logs",
+	After: FormSectionK8sResourceFilter,
+}
+
+// FormSectionContainerLogFilter is the form section for the filters only used for container logs.
+var FormSectionContainerLogFilter = &inspectionmetadata.FormSection{
+	ID:    GoogleCloudCommonTaskIDPrefix + "form-section/container-log-filter",
+	Label: "Container logs",
+	After: FormSectionControlPlaneLogFilter,
+}
+
+// FormSectionCSMLogFilter is the form section for the filters only used for Cloud Service Mesh access logs.
+var FormSectionCSMLogFilter = &inspectionmetadata.FormSection{
+	ID:    GoogleCloudCommonTaskIDPrefix + "form-section/csm-log-filter",
+	Label: "Cloud Service Mesh logs",
+	After: FormSectionContainerLogFilter,
+}
+
+// FormSectionComposerLogFilter is the form section for the filters only used for Cloud Composer logs.
+var FormSectionComposerLogFilter = &inspectionmetadata.FormSection{
+	ID:    GoogleCloudCommonTaskIDPrefix + "form-section/composer-log-filter",
+	Label: "Composer logs",
+	After: FormSectionCSMLogFilter,
+}
+
+// FormSectionAdvancedQuery is the form section for the advanced options applied to every query.
+var FormSectionAdvancedQuery = &inspectionmetadata.FormSection{
+	ID:    GoogleCloudCommonTaskIDPrefix + "form-section/advanced-query",
+	Label: "Advanced query options",
+	After: FormSectionComposerLogFilter,
+}
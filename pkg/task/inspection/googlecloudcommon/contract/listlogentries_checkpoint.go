@@ -0,0 +1,30 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_contract
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// listLogEntriesCheckpointKey returns the key of the checkpoint storing logs fetched with a log filter in a ListLogEntries task.
+// The key is built from the resolved query and the principal calling Cloud Logging, because the request values of the run can resolve to a different time range
+// (e.g. a time range relative to now) or a different principal when the run is resumed.
+func listLogEntriesCheckpointKey(taskID string, principal string, filter string, resourceNames []string, startTime, endTime time.Time) string {
+	digest := sha256.Sum256([]byte(fmt.Sprintf("%s\n%s\n%s\n%d\n%d", principal, strings.Join(resourceNames, ","), filter, startTime.UnixNano(), endTime.UnixNano())))
+	return fmt.Sprintf("list-log-entries-%s-%x", taskID, digest)
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_contract
+
+import (
+	"testing"
+	"time"
+)
+
+func TestListLogEntriesCheckpointKey(t *testing.T) {
+	startTime := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	endTime := startTime.Add(time.Hour)
+	base := listLogEntriesCheckpointKey("foo-task", "foo@example.com", "filter", []string{"projects/foo"}, startTime, endTime)
+
+	testCases := []struct {
+		name          string
+		principal     string
+		filter        string
+		resourceNames []string
+		startTime     time.Time
+		endTime       time.Time
+		wantSame      bool
+	}{
+		{
+			name:          "same query",
+			principal:     "foo@example.com",
+			filter:        "filter",
+			resourceNames: []string{"projects/foo"},
+			startTime:     startTime,
+			endTime:       endTime,
+			wantSame:      true,
+		},
+		{
+			name:          "different principal",
+			principal:     "bar@example.com",
+			filter:        "filter",
+			resourceNames: []string{"projects/foo"},
+			startTime:     startTime,
+			endTime:       endTime,
+		},
+		{
+			name:          "different filter",
+			principal:     "foo@example.com",
+			filter:        "another filter",
+			resourceNames: []string{"projects/foo"},
+			startTime:     startTime,
+			endTime:       endTime,
+		},
+		{
+			name:          "different resource names",
+			principal:     "foo@example.com",
+			filter:        "filter",
+			resourceNames: []string{"projects/bar"},
+			startTime:     startTime,
+			endTime:       endTime,
+		},
+		{
+			name:          "time range resolved to a later time",
+			principal:     "foo@example.com",
+			filter:        "filter",
+			resourceNames: []string{"projects/foo"},
+			startTime:     startTime.Add(time.Minute),
+			endTime:       endTime.Add(time.Minute),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := listLogEntriesCheckpointKey("foo-task", tc.principal, tc.filter, tc.resourceNames, tc.startTime, tc.endTime)
+			if (got == base) != tc.wantSame {
+				t.Errorf("listLogEntriesCheckpointKey() = %s, base = %s, wantSame = %v", got, base, tc.wantSame)
+			}
+		})
+	}
+}
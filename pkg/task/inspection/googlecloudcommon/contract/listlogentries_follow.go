@@ -0,0 +1,97 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_contract
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+)
+
+// followQueryOverlap is the duration before the end of the previous run queried again in the next run of a followed inspection.
+// Cloud Logging can ingest logs a while after their timestamps, and these logs would be missed without querying the overlap again.
+const followQueryOverlap = 2 * time.Minute
+
+// followedLogEntries holds the log entries queried in the previous runs of a followed inspection for a query.
+type followedLogEntries struct {
+	mu sync.Mutex
+	// startTime is the start of the time range the entries were queried with.
+	startTime time.Time
+	// fetchedUntil is the end of the time range queried in the last run.
+	fetchedUntil time.Time
+	entries      []*loggingpb.LogEntry
+	seen         map[string]struct{}
+}
+
+// followedLogEntriesFor returns the followedLogEntries of the query stored in the map shared among the runs of a followed inspection.
+func followedLogEntriesFor(sharedMap *typedmap.TypedMap, taskID string, filter string, resourceNames []string) *followedLogEntries {
+	digest := sha256.Sum256([]byte(fmt.Sprintf("%s\n%s", strings.Join(resourceNames, ","), filter)))
+	key := typedmap.NewTypedKey[*followedLogEntries](fmt.Sprintf("follow-log-entries-%s-%x", taskID, digest))
+	return typedmap.GetOrSetFunc(sharedMap, key, func() *followedLogEntries {
+		return &followedLogEntries{seen: map[string]struct{}{}}
+	})
+}
+
+// fetchStartTime returns the start time of the query to fetch the entries not queried in the previous runs.
+// The whole time range is queried when the time range doesn't continue from the previous run.
+func (f *followedLogEntries) fetchStartTime(startTime, endTime time.Time) time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.continuesFrom(startTime, endTime) {
+		return startTime
+	}
+	fetchStartTime := f.fetchedUntil.Add(-followQueryOverlap)
+	if fetchStartTime.Before(startTime) {
+		return startTime
+	}
+	return fetchStartTime
+}
+
+// merge appends the newly fetched entries not seen in the previous runs and returns all the entries queried with the time range.
+func (f *followedLogEntries) merge(startTime, endTime time.Time, fetched []*loggingpb.LogEntry) []*loggingpb.LogEntry {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.continuesFrom(startTime, endTime) {
+		f.entries = nil
+		f.seen = map[string]struct{}{}
+	}
+	for _, entry := range fetched {
+		key := followedLogEntryKey(entry)
+		if _, found := f.seen[key]; found {
+			continue
+		}
+		f.seen[key] = struct{}{}
+		f.entries = append(f.entries, entry)
+	}
+	f.startTime = startTime
+	f.fetchedUntil = endTime
+	return slices.Clone(f.entries)
+}
+
+// continuesFrom returns true when the time range extends the time range queried in the previous run.
+func (f *followedLogEntries) continuesFrom(startTime, endTime time.Time) bool {
+	return !f.fetchedUntil.IsZero() && f.startTime.Equal(startTime) && !f.fetchedUntil.After(endTime)
+}
+
+// followedLogEntryKey returns the key identifying a log entry queried again in the overlap of the time ranges.
+func followedLogEntryKey(entry *loggingpb.LogEntry) string {
+	return fmt.Sprintf("%s\n%s\n%d", entry.GetLogName(), entry.GetInsertId(), entry.GetTimestamp().AsTime().UnixNano())
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_contract
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newFollowedTestEntry(insertID string, timestamp time.Time) *loggingpb.LogEntry {
+	return &loggingpb.LogEntry{
+		LogName:   "projects/foo/logs/bar",
+		InsertId:  insertID,
+		Timestamp: timestamppb.New(timestamp),
+	}
+}
+
+func insertIDsOf(entries []*loggingpb.LogEntry) []string {
+	result := []string{}
+	for _, entry := range entries {
+		result = append(result, entry.GetInsertId())
+	}
+	return result
+}
+
+func TestFollowedLogEntries(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	firstEnd := start.Add(time.Hour)
+	secondEnd := firstEnd.Add(30 * time.Minute)
+	followed := followedLogEntriesFor(typedmap.NewTypedMap(), "foo-task", "foo-filter", []string{"projects/foo"})
+
+	if got := followed.fetchStartTime(start, firstEnd); !got.Equal(start) {
+		t.Errorf("fetchStartTime() of the first run = %v, want %v", got, start)
+	}
+	got := followed.merge(start, firstEnd, []*loggingpb.LogEntry{
+		newFollowedTestEntry("a", start.Add(time.Minute)),
+		newFollowedTestEntry("b", firstEnd.Add(-time.Minute)),
+	})
+	if diff := cmp.Diff([]string{"a", "b"}, insertIDsOf(got)); diff != "" {
+		t.Errorf("merge() of the first run mismatch (-want +got):\n%s", diff)
+	}
+
+	wantFetchStart := firstEnd.Add(-followQueryOverlap)
+	if got := followed.fetchStartTime(start, secondEnd); !got.Equal(wantFetchStart) {
+		t.Errorf("fetchStartTime() of the second run = %v, want %v", got, wantFetchStart)
+	}
+	// The entry "b" is queried again in the overlap and must not be duplicated.
+	got = followed.merge(start, secondEnd, []*loggingpb.LogEntry{
+		newFollowedTestEntry("b", firstEnd.Add(-time.Minute)),
+		newFollowedTestEntry("c", firstEnd.Add(time.Minute)),
+	})
+	if diff := cmp.Diff([]string{"a", "b", "c"}, insertIDsOf(got)); diff != "" {
+		t.Errorf("merge() of the second run mismatch (-want +got):\n%s", diff)
+	}
+
+	movedStart := start.Add(time.Minute)
+	if got := followed.fetchStartTime(movedStart, secondEnd); !got.Equal(movedStart) {
+		t.Errorf("fetchStartTime() with a different start time = %v, want %v", got, movedStart)
+	}
+	got = followed.merge(movedStart, secondEnd, []*loggingpb.LogEntry{
+		newFollowedTestEntry("c", firstEnd.Add(time.Minute)),
+	})
+	if diff := cmp.Diff([]string{"c"}, insertIDsOf(got)); diff != "" {
+		t.Errorf("merge() with a different start time mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFollowedLogEntriesFor(t *testing.T) {
+	sharedMap := typedmap.NewTypedMap()
+	foo := followedLogEntriesFor(sharedMap, "foo-task", "foo-filter", []string{"projects/foo"})
+	if followedLogEntriesFor(sharedMap, "foo-task", "foo-filter", []string{"projects/foo"}) != foo {
+		t.Error("expected the same followedLogEntries for the same query")
+	}
+	if followedLogEntriesFor(sharedMap, "foo-task", "foo-filter", []string{"projects/bar"}) == foo {
+		t.Error("expected a different followedLogEntries for different resource names")
+	}
+	if followedLogEntriesFor(sharedMap, "bar-task", "foo-filter", []string{"projects/foo"}) == foo {
+		t.Error("expected a different followedLogEntries for a different task")
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_contract
+
+import (
+	"time"
+
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+// listLogEntriesResumeStateKey is the key of the listLogEntriesResumeState in the map shared among the attempts of a ListLogEntries task.
+var listLogEntriesResumeStateKey = typedmap.NewTypedKey[*listLogEntriesResumeState]("list-log-entries-resume-state")
+
+// listLogEntriesResumeState holds the progress of a ListLogEntries task kept among its attempts.
+// A retried attempt skips the filters and groups completed in the previous attempts and resumes the interrupted query from the timestamp of the last received entry.
+// Filters and groups are queried in order, and the entries of a query are received in the ascending order of their timestamps.
+type listLogEntriesResumeState struct {
+	// logs are the logs converted in the previous attempts.
+	logs []*log.Log
+	// filterIndex and groupIndex point to the query interrupted in the previous attempt.
+	filterIndex int
+	groupIndex  int
+	// checkpointWriter and resultCacheWriter are the writers of the interrupted query kept open to continue writing in the next attempt.
+	checkpointWriter  *logEntryStoreWriter
+	resultCacheWriter *logEntryStoreWriter
+	// fetchedEntries are the entries of the interrupted query received in the previous attempts of a followed inspection.
+	fetchedEntries []*loggingpb.LogEntry
+	// resumeFrom is the timestamp of the last received entry truncated to seconds, the precision of the timestamps in Cloud Logging filters.
+	resumeFrom time.Time
+	// seen holds the keys of the received entries with timestamps within the second of resumeFrom. They are received again from the resumed query.
+	seen map[string]struct{}
+}
+
+// fetchStartTime returns the start time of the query resuming the interrupted query.
+func (s *listLogEntriesResumeState) fetchStartTime(startTime time.Time) time.Time {
+	if s.resumeFrom.After(startTime) {
+		return s.resumeFrom
+	}
+	return startTime
+}
+
+// accept records the received entry and returns false when it was already received before the query was interrupted.
+func (s *listLogEntriesResumeState) accept(entry *loggingpb.LogEntry) bool {
+	second := entry.GetTimestamp().AsTime().Truncate(time.Second)
+	key := followedLogEntryKey(entry)
+	switch {
+	case second.After(s.resumeFrom):
+		s.resumeFrom = second
+		s.seen = map[string]struct{}{key: {}}
+		return true
+	case second.Equal(s.resumeFrom):
+		if _, found := s.seen[key]; found {
+			return false
+		}
+		s.seen[key] = struct{}{}
+		return true
+	default:
+		return true
+	}
+}
+
+// completeGroup moves the progress to the next group of the filter.
+func (s *listLogEntriesResumeState) completeGroup() {
+	s.groupIndex++
+	s.resultCacheWriter = nil
+	s.fetchedEntries = nil
+	s.resumeFrom = time.Time{}
+	s.seen = nil
+}
+
+// completeFilter moves the progress to the first group of the next filter.
+func (s *listLogEntriesResumeState) completeFilter() {
+	s.completeGroup()
+	s.filterIndex++
+	s.groupIndex = 0
+	s.checkpointWriter = nil
+}
+
+// abort discards the entries written to the writers of the interrupted query when it won't be resumed.
+func (s *listLogEntriesResumeState) abort() {
+	s.resultCacheWriter.Abort()
+	s.checkpointWriter.Abort()
+	s.resultCacheWriter = nil
+	s.checkpointWriter = nil
+}
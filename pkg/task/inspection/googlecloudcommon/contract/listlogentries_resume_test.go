@@ -0,0 +1,74 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_contract
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestListLogEntriesResumeState(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	state := &listLogEntriesResumeState{}
+
+	if got := state.fetchStartTime(start); !got.Equal(start) {
+		t.Errorf("fetchStartTime() before receiving entries = %v, want %v", got, start)
+	}
+	var accepted []*loggingpb.LogEntry
+	for _, entry := range []*loggingpb.LogEntry{
+		newFollowedTestEntry("a", start.Add(time.Minute)),
+		newFollowedTestEntry("b", start.Add(2*time.Minute+100*time.Millisecond)),
+		newFollowedTestEntry("c", start.Add(2*time.Minute+200*time.Millisecond)),
+	} {
+		if state.accept(entry) {
+			accepted = append(accepted, entry)
+		}
+	}
+
+	// The interrupted query resumes from the second of the last received entry.
+	wantFetchStart := start.Add(2 * time.Minute)
+	if got := state.fetchStartTime(start); !got.Equal(wantFetchStart) {
+		t.Errorf("fetchStartTime() after receiving entries = %v, want %v", got, wantFetchStart)
+	}
+	// The entries within the second are received again and must not be duplicated.
+	for _, entry := range []*loggingpb.LogEntry{
+		newFollowedTestEntry("b", start.Add(2*time.Minute+100*time.Millisecond)),
+		newFollowedTestEntry("c", start.Add(2*time.Minute+200*time.Millisecond)),
+		newFollowedTestEntry("d", start.Add(2*time.Minute+300*time.Millisecond)),
+		newFollowedTestEntry("e", start.Add(3*time.Minute)),
+	} {
+		if state.accept(entry) {
+			accepted = append(accepted, entry)
+		}
+	}
+	if diff := cmp.Diff([]string{"a", "b", "c", "d", "e"}, insertIDsOf(accepted)); diff != "" {
+		t.Errorf("accepted entries mismatch (-want +got):\n%s", diff)
+	}
+
+	state.completeGroup()
+	if got := state.fetchStartTime(start); !got.Equal(start) {
+		t.Errorf("fetchStartTime() of the next group = %v, want %v", got, start)
+	}
+	if state.groupIndex != 1 {
+		t.Errorf("groupIndex = %d, want 1", state.groupIndex)
+	}
+	state.completeFilter()
+	if state.filterIndex != 1 || state.groupIndex != 0 {
+		t.Errorf("filterIndex, groupIndex = %d, %d, want 1, 0", state.filterIndex, state.groupIndex)
+	}
+}
@@ -16,6 +16,7 @@ package googlecloudcommon_contract
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"maps"
@@ -42,6 +43,8 @@ import (
 	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // maxResourceNameCountPerRequest is the maximum allowed count of resource names per single entries.list. The default quota is 100.
@@ -108,7 +111,9 @@ func monitorProgress(ctx context.Context, wg *sync.WaitGroup, source <-chan LogF
 	}()
 }
 
-func convertLogsArray(ctx context.Context, wg *sync.WaitGroup, source <-chan *loggingpb.LogEntry, dest *[]*log.Log, logType enum.LogType) {
+// convertLogsArray converts the log entries received from the source and appends them to dest unless it is nil.
+// The raw entries are passed to accept unless it is nil, and the entries it returned false for are dropped.
+func convertLogsArray(ctx context.Context, wg *sync.WaitGroup, source <-chan *loggingpb.LogEntry, dest *[]*log.Log, accept func(entry *loggingpb.LogEntry) bool, logType enum.LogType) {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
@@ -120,30 +125,44 @@ func convertLogsArray(ctx context.Context, wg *sync.WaitGroup, source <-chan *lo
 				if !ok {
 					return
 				}
-				node, err := logconvert.LogEntryToNode(l)
-				if err != nil {
-					slog.WarnContext(ctx, fmt.Sprintf("failed to convert loggingpb.LogEntry (insertId: %s, timestamp: %v) to structured.Node %v", l.InsertId, l.Timestamp, err))
+				if accept != nil && !accept(l) {
+					continue
+				}
+				if dest == nil {
 					continue
 				}
-				khiLog := log.NewLog(structured.NewNodeReader(node))
-				khiLog.LogType = logType
-				*dest = append(*dest, khiLog)
+				if khiLog, ok := convertLogEntry(ctx, l, logType); ok {
+					*dest = append(*dest, khiLog)
+				}
 			}
 		}
 	}()
 }
 
+// convertLogEntry converts a loggingpb.LogEntry to a log.Log with the given log type. It returns false when the entry can't be converted.
+func convertLogEntry(ctx context.Context, l *loggingpb.LogEntry, logType enum.LogType) (*log.Log, bool) {
+	node, err := logconvert.LogEntryToNode(l)
+	if err != nil {
+		slog.WarnContext(ctx, fmt.Sprintf("failed to convert loggingpb.LogEntry (insertId: %s, timestamp: %v) to structured.Node %v", l.InsertId, l.Timestamp, err))
+		return nil, false
+	}
+	khiLog := log.NewLog(structured.NewNodeReader(node))
+	khiLog.LogType = logType
+	return khiLog, true
+}
+
 // NewListLogEntriesTask creates a new task that lists log entries from Cloud Logging based on the provided settings.
-func NewListLogEntriesTask(taskSetting ListLogEntriesTaskSetting) coretask.Task[[]*log.Log] {
+// labelOpts are added to the labels of the task, e.g. coretask.WithSkipPredicate to skip the query when it can't match any log.
+func NewListLogEntriesTask(taskSetting ListLogEntriesTaskSetting, labelOpts ...coretask.LabelOpt) coretask.Task[[]*log.Log] {
 	taskID := taskSetting.TaskID()
 	dependencies := taskSetting.Dependencies()
-	dependencies = append(dependencies, InputStartTimeTaskID.Ref(), InputEndTimeTaskID.Ref(), InputLoggingFilterResourceNameTaskID.Ref(), LoggingFetcherTaskID.Ref())
+	dependencies = append(dependencies, InputStartTimeTaskID.Ref(), InputEndTimeTaskID.Ref(), InputLoggingFilterResourceNameTaskID.Ref(), InputAdditionalProjectIDsTaskID.Ref(), InputLogViewResourceNamesTaskID.Ref(), InputAdditionalLogFilterTaskID.Ref(), LoggingFetcherTaskID.Ref())
 	description := taskSetting.Description()
 
 	return inspectiontaskbase.NewProgressReportableInspectionTask(
 		taskID,
 		dependencies,
-		func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, progress *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, error) {
+		func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, progress *inspectionmetadata.TaskProgressMetadata) (_ []*log.Log, err error) {
 			startTime := coretask.GetTaskResult(ctx, InputStartTimeTaskID.Ref())
 			endTime := coretask.GetTaskResult(ctx, InputEndTimeTaskID.Ref())
 			resourceNames, err := handleResourceNames(ctx, taskID, taskSetting)
@@ -159,6 +178,7 @@ func NewListLogEntriesTask(taskSetting ListLogEntriesTaskSetting) coretask.Task[
 				slog.DebugContext(ctx, "LogFilters returned an emptry list. Skipping fetching logs for this task")
 				return []*log.Log{}, nil
 			}
+			filters = appendAdditionalLogFilter(filters, coretask.GetTaskResult(ctx, InputAdditionalLogFilterTaskID.Ref()))
 			timePartitionCount, err := taskSetting.TimePartitionCount(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("TimePartitionCount returned an error: %w", err)
@@ -167,17 +187,71 @@ func NewListLogEntriesTask(taskSetting ListLogEntriesTaskSetting) coretask.Task[
 				return nil, fmt.Errorf("TimePartitionCount returned an invalid value %d, it must be bigger than 0", timePartitionCount)
 			}
 
-			allLogs := make([]*log.Log, 0)
 			for filterIndex, filter := range filters {
 				err := setQueryInfo(ctx, taskID.String(), filter, filterIndex, len(filters), startTime, endTime, description)
 				if err != nil {
 					return nil, err
 				}
+			}
+			if taskMode == inspectioncore_contract.TaskModeDryRun {
+				showLogVolumeEstimate(ctx, taskID.String(), filters, resourceNames, startTime, endTime, description)
+			}
+
+			var checkpointBackend, queryResultCache inspectioncore_contract.StreamingTaskCacheBackend
+			principal := ""
+			if taskMode == inspectioncore_contract.TaskModeRun {
+				checkpointBackend, _ = khictx.GetValue(ctx, inspectioncore_contract.InspectionCheckpointBackendContextKey)
+				queryResultCache, _ = khictx.GetValue(ctx, inspectioncore_contract.QueryResultCacheBackendContextKey)
+				if !isQueryResultCacheable(endTime, time.Now()) {
+					queryResultCache = nil
+				}
+				// A followed inspection queries only the logs after the previous run and merges them into the logs accumulated in the shared map.
+				if followSharedMap, _ := khictx.GetValue(ctx, inspectioncore_contract.InspectionFollowSharedMap); followSharedMap != nil {
+					queryResultCache = nil
+				}
+				if checkpointBackend != nil || queryResultCache != nil {
+					principal = credentialPrincipalOf(ctx, coretask.GetTaskResult(ctx, LoggingFetcherTaskID.Ref()))
+				}
+				// Checkpoints and query results are keyed with the principal not to reuse logs fetched by another principal.
+				if principal == "" {
+					checkpointBackend = nil
+					queryResultCache = nil
+				}
+			}
 
+			// A retried attempt resumes from where the previous attempt was interrupted instead of querying the logs again.
+			resume := typedmap.GetOrSetFunc(coretask.AttemptState(ctx), listLogEntriesResumeStateKey, func() *listLogEntriesResumeState {
+				return &listLogEntriesResumeState{logs: make([]*log.Log, 0)}
+			})
+			defer func() {
+				if err != nil && coretask.IsFinalAttempt(ctx, err) {
+					resume.abort()
+				}
+			}()
+
+			for filterIndex, filter := range filters {
 				// Don't run logging filter except the run mode
 				if taskMode != inspectioncore_contract.TaskModeRun {
 					continue
 				}
+				if filterIndex < resume.filterIndex {
+					continue
+				}
+
+				checkpointKey := listLogEntriesCheckpointKey(taskID.String(), principal, filter, resourceNames, startTime, endTime)
+				if checkpointBackend != nil && resume.groupIndex == 0 {
+					if logs, found := loadLogsFromLogEntryStore(ctx, checkpointBackend, checkpointKey, description.DefaultLogType, nil); found {
+						slog.InfoContext(ctx, fmt.Sprintf("restored %d logs from the checkpoint instead of querying them again", len(logs)))
+						resume.logs = append(resume.logs, logs...)
+						resume.completeFilter()
+						continue
+					}
+				}
+				// The checkpoint is committed only after all the groups were fetched not to resume with logs of a part of the groups.
+				if resume.checkpointWriter == nil {
+					resume.checkpointWriter = openLogEntryStoreWriter(ctx, checkpointBackend, checkpointKey)
+				}
+				checkpointWriter := resume.checkpointWriter
 
 				groups, err := groupResourceNamesByContainer(resourceNames)
 				if err != nil {
@@ -187,25 +261,84 @@ func NewListLogEntriesTask(taskSetting ListLogEntriesTaskSetting) coretask.Task[
 
 				logFetcher := coretask.GetTaskResult(ctx, LoggingFetcherTaskID.Ref())
 				progressReportableLogFetcher := NewTimePartitioningProgressReportableLogFetcher(logFetcher, 500*time.Millisecond, timePartitionCount, runtime.GOMAXPROCS(0))
+				followSharedMap, _ := khictx.GetValue(ctx, inspectioncore_contract.InspectionFollowSharedMap)
 
 				for groupIndex, group := range groups {
+					if groupIndex < resume.groupIndex {
+						continue
+					}
+					resultCacheKey := queryResultCacheKey(principal, filter, group.container, group.resourceNames, startTime, endTime)
+					if queryResultCache != nil && resume.resultCacheWriter == nil {
+						if logs, found := loadLogsFromLogEntryStore(ctx, queryResultCache, resultCacheKey, description.DefaultLogType, checkpointWriter); found {
+							slog.InfoContext(ctx, fmt.Sprintf("reused %d log entries from the query result cache instead of querying them again", len(logs)))
+							resume.logs = append(resume.logs, logs...)
+							resume.completeGroup()
+							continue
+						}
+					}
+
 					var wg sync.WaitGroup
 					var logChan = make(chan *loggingpb.LogEntry)
 					var progressChan = make(chan LogFetchProgress)
 					listCallIndex := filterIndex*len(groups) + groupIndex
 					allListCalls := len(filters) * len(groups)
+					convertDest := &resume.logs
+					fetchStartTime := startTime
+					// Raw entries are streamed to the stores while they are fetched instead of holding a copy of them in memory.
+					if resume.resultCacheWriter == nil {
+						resume.resultCacheWriter = openLogEntryStoreWriter(ctx, queryResultCache, resultCacheKey)
+					}
+					resultCacheWriter := resume.resultCacheWriter
+					rawSink := func(entry *loggingpb.LogEntry) {
+						resultCacheWriter.Write(entry)
+						checkpointWriter.Write(entry)
+					}
+					var followed *followedLogEntries
+					if followSharedMap != nil {
+						followed = followedLogEntriesFor(followSharedMap, taskID.String(), filter, group.resourceNames)
+						fetchStartTime = followed.fetchStartTime(startTime, endTime)
+						rawSink = func(entry *loggingpb.LogEntry) {
+							resume.fetchedEntries = append(resume.fetchedEntries, entry)
+						}
+						convertDest = nil
+					}
+					accept := func(entry *loggingpb.LogEntry) bool {
+						if !resume.accept(entry) {
+							return false
+						}
+						rawSink(entry)
+						return true
+					}
 					monitorProgress(ctx, &wg, progressChan, progress, listCallIndex, allListCalls)
-					convertLogsArray(ctx, &wg, logChan, &allLogs, description.DefaultLogType)
-					err = progressReportableLogFetcher.FetchLogsWithProgress(logChan, progressChan, ctx, startTime, endTime, filter, group.container, group.resourceNames)
+					convertLogsArray(ctx, &wg, logChan, convertDest, accept, description.DefaultLogType)
+					err = progressReportableLogFetcher.FetchLogsWithProgress(logChan, progressChan, ctx, resume.fetchStartTime(fetchStartTime), endTime, filter, group.container, group.resourceNames)
 					wg.Wait()
 
 					if err != nil {
-						err := setErrorMetadataForFetchLogError(ctx, err)
+						// The error is recorded only once when the query won't be resumed in the next attempt.
+						if coretask.IsFinalAttempt(ctx, err) {
+							return nil, setErrorMetadataForFetchLogError(ctx, err)
+						}
 						return nil, err
 					}
+					if followed != nil {
+						for _, entry := range followed.merge(startTime, endTime, resume.fetchedEntries) {
+							checkpointWriter.Write(entry)
+							if khiLog, ok := convertLogEntry(ctx, entry, description.DefaultLogType); ok {
+								resume.logs = append(resume.logs, khiLog)
+							}
+						}
+						resume.completeGroup()
+						continue
+					}
+					resultCacheWriter.Commit(ctx)
+					resume.completeGroup()
 				}
+				checkpointWriter.Commit(ctx)
+				resume.completeFilter()
 			}
 
+			allLogs := resume.logs
 			// GCPCommonFieldSet is always required for any logs retrieved from Cloud Logging.
 			for _, l := range allLogs {
 				l.SetFieldSetReader(&gcpqueryutil.GCPCommonFieldSetReader{})
@@ -219,11 +352,79 @@ func NewListLogEntriesTask(taskSetting ListLogEntriesTaskSetting) coretask.Task[
 			}
 
 			return allLogs, nil
-		}, inspectioncore_contract.NewQueryTaskLabelOpt(description.DefaultLogType, description.ExampleQuery),
-		coretask.WithLabelValue(RequestOptionalInputResourceNameTaskLabel, taskID.ReferenceIDString()),
+		}, append([]coretask.LabelOpt{
+			inspectioncore_contract.NewQueryTaskLabelOpt(description.DefaultLogType, description.ExampleQuery),
+			coretask.WithLabelValue(RequestOptionalInputResourceNameTaskLabel, taskID.ReferenceIDString()),
+			coretask.WithRetry(newListLogEntriesRetryPolicy()),
+		}, labelOpts...)...,
 	)
 }
 
+// newListLogEntriesRetryPolicy returns the RetryPolicy of ListLogEntries tasks retrying only transient errors of Cloud Logging.
+func newListLogEntriesRetryPolicy() *coretask.RetryPolicy {
+	policy := coretask.NewExponentialBackoffRetryPolicy(3)
+	policy.Retryable = isTransientLoggingError
+	return policy
+}
+
+// isTransientLoggingError returns true when the error returned from Cloud Logging may be resolved by retrying the query later.
+// Errors after the circuit breaker opened are not retried because the circuit stays open in the inspection run.
+func isTransientLoggingError(err error) bool {
+	if errors.Is(err, ErrLoggingCircuitOpen) {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.Unavailable:
+		return true
+	default:
+		return false
+	}
+}
+
+// showLogVolumeEstimate estimates the volume of logs queried by the filters and shows it as a hint of the time range form field.
+// The estimate is skipped when the LogFetcher doesn't implement LogVolumeEstimator. Errors are only logged not to block the dry run.
+func showLogVolumeEstimate(ctx context.Context, taskID string, filters []string, resourceNames []string, startTime, endTime time.Time, description *ListLogEntriesTaskDescription) {
+	estimator, ok := coretask.GetTaskResult(ctx, LoggingFetcherTaskID.Ref()).(LogVolumeEstimator)
+	if !ok {
+		return
+	}
+	groups, err := groupResourceNamesByContainer(resourceNames)
+	if err != nil {
+		slog.DebugContext(ctx, fmt.Sprintf("skipping the log volume estimation because of the invalid resource names: %v", err))
+		return
+	}
+	if len(groups) == 0 {
+		return
+	}
+	groups = divideGroupByMaximumResourceName(groups, maxResourceNameCountPerRequest)
+
+	estimate := LogVolumeEstimate{Exact: true}
+	for _, filter := range filters {
+		for _, group := range groups {
+			groupEstimate, err := estimateLogVolumeWithCache(ctx, estimator, taskID, filter, group.container, group.resourceNames, startTime, endTime)
+			if err != nil {
+				slog.WarnContext(ctx, fmt.Sprintf("failed to estimate the log volume of %s: %v", description.QueryName, err))
+				return
+			}
+			estimate = estimate.Add(groupEstimate)
+		}
+	}
+
+	metadata := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
+	formFields, found := typedmap.Get(metadata, inspectionmetadata.FormFieldSetMetadataKey)
+	if !found {
+		return
+	}
+	hintType := inspectionmetadata.Info
+	if estimate.MemoryBytes > largeLogVolumeWarningBytes {
+		hintType = inspectionmetadata.Warning
+	}
+	err = formFields.AddFieldHint(InputTimeRangeTaskID.Ref().String(), hintType, estimate.HintMessage(description.QueryName))
+	if err != nil {
+		slog.DebugContext(ctx, fmt.Sprintf("skipping to show the log volume estimate: %v", err))
+	}
+}
+
 // handleResourceNames retrieves and validates resource names for a given task, updating default values if necessary.
 func handleResourceNames(ctx context.Context, taskID taskid.TaskImplementationID[[]*log.Log], taskSetting ListLogEntriesTaskSetting) ([]string, error) {
 	resourceNamesInput := coretask.GetTaskResult(ctx, InputLoggingFilterResourceNameTaskID.Ref())
@@ -233,12 +434,50 @@ func handleResourceNames(ctx context.Context, taskID taskid.TaskImplementationID
 	if err != nil {
 		return nil, fmt.Errorf("ResourceNames returned an error: %w", err)
 	}
+	additionalProjectIDs := coretask.GetTaskResult(ctx, InputAdditionalProjectIDsTaskID.Ref())
+	defaultResourceNames = appendAdditionalProjectResourceNames(defaultResourceNames, additionalProjectIDs)
+	logViewResourceNames := coretask.GetTaskResult(ctx, InputLogViewResourceNamesTaskID.Ref())
+	defaultResourceNames = appendMissingResourceNames(defaultResourceNames, logViewResourceNames)
 
 	resourceNamesInput.UpdateDefaultResourceNamesForQuery(taskID.ReferenceIDString(), defaultResourceNames)
 
 	return queryResourceNamePair.CurrentResourceNames, nil
 }
 
+// appendAdditionalProjectResourceNames appends the project resource names of the given project IDs to the resource names when they are not included yet.
+func appendAdditionalProjectResourceNames(resourceNames []string, projectIDs []string) []string {
+	projectResourceNames := make([]string, 0, len(projectIDs))
+	for _, projectID := range projectIDs {
+		projectResourceNames = append(projectResourceNames, fmt.Sprintf("projects/%s", projectID))
+	}
+	return appendMissingResourceNames(resourceNames, projectResourceNames)
+}
+
+// appendMissingResourceNames appends the additional resource names to the resource names when they are not included yet.
+func appendMissingResourceNames(resourceNames []string, additionalResourceNames []string) []string {
+	result := append([]string{}, resourceNames...)
+	for _, resourceName := range additionalResourceNames {
+		if slices.Contains(result, resourceName) {
+			continue
+		}
+		result = append(result, resourceName)
+	}
+	return result
+}
+
+// appendAdditionalLogFilter appends the user supplied filter fragment to each of the given filters.
+// The fragment is surrounded with parentheses not to change the precedence of the operators in it.
+func appendAdditionalLogFilter(filters []string, additionalFilter string) []string {
+	if strings.TrimSpace(additionalFilter) == "" {
+		return filters
+	}
+	result := make([]string, 0, len(filters))
+	for _, filter := range filters {
+		result = append(result, fmt.Sprintf("%s\n(%s)", filter, additionalFilter))
+	}
+	return result
+}
+
 // setQueryInfo records the generated Cloud Logging query details into the inspection run metadata.
 func setQueryInfo(ctx context.Context, taskID, baseLogFilter string, logFilterIndex, totalLogFilterCount int, startTime, endTime time.Time, description *ListLogEntriesTaskDescription) error {
 	metadata := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
@@ -261,12 +500,17 @@ func setQueryInfo(ctx context.Context, taskID, baseLogFilter string, logFilterIn
 }
 
 // setErrorMetadataForFetchLogError extracts error information from a log fetching operation and adds it to the inspection run's error message set metadata.
+// The errors of queries stopped by the open circuit are consolidated into a single error message.
 func setErrorMetadataForFetchLogError(ctx context.Context, err error) error {
 	metadata := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
 	errorMessageSet, found := typedmap.Get(metadata, inspectionmetadata.ErrorMessageSetMetadataKey)
 	if !found {
 		return fmt.Errorf("error message set metadata was not found. originalError=%w", err)
 	}
+	if errors.Is(err, ErrLoggingCircuitOpen) {
+		errorMessageSet.AddErrorMessage(inspectionmetadata.NewCircuitOpenErrorMessage("Cloud Logging", err.Error()))
+		return err
+	}
 	errorMessageSet.AddErrorMessage(&inspectionmetadata.ErrorMessage{
 		ErrorId: 0,
 		Message: err.Error(),
@@ -287,21 +531,9 @@ func groupResourceNamesByContainer(resourceNames []string) ([]*resourceContainer
 	groups := make(map[string]*resourceContainerLogQueryGroup)
 
 	for _, resourceName := range resourceNames {
-		var container googlecloud.ResourceContainer
-		switch {
-		case strings.HasPrefix(resourceName, "projects/"):
-			projectID := resourceName[len("projects/"):]
-			slashIndex := strings.Index(projectID, "/")
-			if slashIndex != -1 {
-				projectID = projectID[:slashIndex]
-			}
-			container = googlecloud.Project(projectID)
-		default:
-			// TODO: Add support for other resource containers like organizations, folders, and billingAccounts.
-			// Unsupported resource container types.
-		}
-		if container == nil {
-			return nil, fmt.Errorf("unsupported resource name %q : %w", resourceName, khierrors.ErrInvalidInput)
+		container, err := resourceContainerOfResourceName(resourceName)
+		if err != nil {
+			return nil, err
 		}
 		containerIdentifier := container.Identifier()
 		if _, ok := groups[containerIdentifier]; !ok {
@@ -321,6 +553,25 @@ func groupResourceNamesByContainer(resourceNames []string) ([]*resourceContainer
 	return result, nil
 }
 
+// resourceContainerOfResourceName returns the Google Cloud resource container owning the given resource name.
+// e.g. the project `foo` for `projects/foo/locations/global/buckets/bar/views/baz`.
+func resourceContainerOfResourceName(resourceName string) (googlecloud.ResourceContainer, error) {
+	segments := strings.SplitN(resourceName, "/", 3)
+	if len(segments) >= 2 && segments[1] != "" {
+		switch segments[0] {
+		case "projects":
+			return googlecloud.Project(segments[1]), nil
+		case "organizations":
+			return googlecloud.Organization(segments[1]), nil
+		case "folders":
+			return googlecloud.Folder(segments[1]), nil
+		case "billingAccounts":
+			return googlecloud.BillingAccount(segments[1]), nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported resource name %q : %w", resourceName, khierrors.ErrInvalidInput)
+}
+
 // divideGroupByMaximumResourceName divides resourceContainerLogQueryGroup instances into smaller groups if their resourceNames slice exceeds maxResourceNamePerGroup.
 func divideGroupByMaximumResourceName(groups []*resourceContainerLogQueryGroup, maxResourceNamePerGroup int) []*resourceContainerLogQueryGroup {
 	var dividedGroups []*resourceContainerLogQueryGroup
@@ -31,10 +31,12 @@ import (
 	"github.com/kyasbal/khi/pkg/common/typedmap"
 	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
 	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
 	"github.com/kyasbal/khi/pkg/core/task/taskid"
 	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
 	"github.com/kyasbal/khi/pkg/model/enum"
 	"github.com/kyasbal/khi/pkg/model/log"
+	core_contract "github.com/kyasbal/khi/pkg/task/core/contract"
 	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -267,7 +269,10 @@ timestamp < "2025-01-01T01:01:00+0000"`, func(logSource chan<- *loggingpb.LogEnt
 				tasktest.NewTaskDependencyValuePair(InputStartTimeTaskID.Ref(), startTime),
 				tasktest.NewTaskDependencyValuePair(InputEndTimeTaskID.Ref(), endTime),
 				tasktest.NewTaskDependencyValuePair[LogFetcher](LoggingFetcherTaskID.Ref(), fetcher),
-				tasktest.NewTaskDependencyValuePair(InputLoggingFilterResourceNameTaskID.Ref(), resourceNamesInput))
+				tasktest.NewTaskDependencyValuePair(InputLoggingFilterResourceNameTaskID.Ref(), resourceNamesInput),
+				tasktest.NewTaskDependencyValuePair(InputAdditionalProjectIDsTaskID.Ref(), []string{}),
+				tasktest.NewTaskDependencyValuePair(InputLogViewResourceNamesTaskID.Ref(), []string{}),
+				tasktest.NewTaskDependencyValuePair(InputAdditionalLogFilterTaskID.Ref(), ""))
 			if err != nil {
 				t.Errorf("first NewCloudLoggingFilterTask dry run failed:%v", err)
 			}
@@ -283,6 +288,9 @@ timestamp < "2025-01-01T01:01:00+0000"`, func(logSource chan<- *loggingpb.LogEnt
 				tasktest.NewTaskDependencyValuePair(InputEndTimeTaskID.Ref(), endTime),
 				tasktest.NewTaskDependencyValuePair[LogFetcher](LoggingFetcherTaskID.Ref(), fetcher),
 				tasktest.NewTaskDependencyValuePair(InputLoggingFilterResourceNameTaskID.Ref(), resourceNamesInput),
+				tasktest.NewTaskDependencyValuePair(InputAdditionalProjectIDsTaskID.Ref(), []string{}),
+				tasktest.NewTaskDependencyValuePair(InputLogViewResourceNamesTaskID.Ref(), []string{}),
+				tasktest.NewTaskDependencyValuePair(InputAdditionalLogFilterTaskID.Ref(), ""),
 			)
 			if tt.wantError != nil {
 				if !errors.Is(err, tt.wantError) {
@@ -314,6 +322,158 @@ timestamp < "2025-01-01T01:01:00+0000"`, func(logSource chan<- *loggingpb.LogEnt
 	}
 }
 
+// resumingLogFetcher sends the entries prepared for each call and returns the error prepared for the call after sending them.
+type resumingLogFetcher struct {
+	entries      [][]*loggingpb.LogEntry
+	errs         []error
+	queriedNames [][]string
+}
+
+// FetchLogs implements LogFetcher.
+func (f *resumingLogFetcher) FetchLogs(dest chan<- *loggingpb.LogEntry, ctx context.Context, filter string, container googlecloud.ResourceContainer, resourceContainers []string) error {
+	call := len(f.queriedNames)
+	f.queriedNames = append(f.queriedNames, resourceContainers)
+	for _, entry := range f.entries[call] {
+		dest <- entry
+	}
+	if f.errs[call] != nil {
+		return f.errs[call]
+	}
+	close(dest)
+	return nil
+}
+
+var _ LogFetcher = (*resumingLogFetcher)(nil)
+
+func TestNewListLogEntriesTaskResumesRetriedAttempt(t *testing.T) {
+	startTime := time.Date(2025, time.January, 1, 1, 0, 0, 0, time.UTC)
+	endTime := time.Date(2025, time.January, 1, 1, 1, 0, 0, time.UTC)
+	transientErr := status.Error(codes.Unavailable, "service unavailable")
+	fetcher := &resumingLogFetcher{
+		entries: [][]*loggingpb.LogEntry{
+			{newFollowedTestEntry("a", startTime.Add(10*time.Second))},
+			{},
+			{newFollowedTestEntry("b", startTime.Add(30*time.Second))},
+		},
+		errs: []error{nil, transientErr, nil},
+	}
+	task := NewListLogEntriesTask(&mockListLogEntriesTaskSetting{
+		logFilters:         []string{"foo"},
+		resourceNames:      []string{"projects/bar", "projects/baz"},
+		timePartitionCount: 1,
+		description:        &ListLogEntriesTaskDescription{QueryName: "query-foo", DefaultLogType: enum.LogTypeContainer},
+	})
+	resourceNamesInput := NewResourceNamesInput()
+	run := func(ctx context.Context, mode inspectioncore_contract.InspectionTaskModeType) ([]*log.Log, error) {
+		logs, _, err := inspectiontest.RunInspectionTask(ctx, task, mode, map[string]any{},
+			tasktest.NewTaskDependencyValuePair(InputStartTimeTaskID.Ref(), startTime),
+			tasktest.NewTaskDependencyValuePair(InputEndTimeTaskID.Ref(), endTime),
+			tasktest.NewTaskDependencyValuePair[LogFetcher](LoggingFetcherTaskID.Ref(), fetcher),
+			tasktest.NewTaskDependencyValuePair(InputLoggingFilterResourceNameTaskID.Ref(), resourceNamesInput),
+			tasktest.NewTaskDependencyValuePair(InputAdditionalProjectIDsTaskID.Ref(), []string{}),
+			tasktest.NewTaskDependencyValuePair(InputLogViewResourceNamesTaskID.Ref(), []string{}),
+			tasktest.NewTaskDependencyValuePair(InputAdditionalLogFilterTaskID.Ref(), ""))
+		return logs, err
+	}
+	firstCtx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+	if _, err := run(firstCtx, inspectioncore_contract.TaskModeDryRun); err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+
+	isFinal := false
+	ctx := khictx.WithValue(inspectiontest.NextRunTaskContext(t.Context(), firstCtx), core_contract.TaskAttemptContextKey, &core_contract.TaskAttempt{
+		IsFinal: func(err error) bool { return isFinal },
+		State:   typedmap.NewTypedMap(),
+	})
+	if _, err := run(ctx, inspectioncore_contract.TaskModeRun); !errors.Is(err, transientErr) {
+		t.Fatalf("the first attempt returned %v, want %v", err, transientErr)
+	}
+	metadata := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
+	errorMessageSet, _ := typedmap.Get(metadata, inspectionmetadata.ErrorMessageSetMetadataKey)
+	if len(errorMessageSet.ErrorMessages) != 0 {
+		t.Errorf("got error messages %v after the attempt to be retried, want none", errorMessageSet.ErrorMessages)
+	}
+
+	isFinal = true
+	logs, err := run(ctx, inspectioncore_contract.TaskModeRun)
+	if err != nil {
+		t.Fatalf("the second attempt returned an unexpected error %v", err)
+	}
+	if diff := cmp.Diff([]string{"a", "b"}, readInsertIDs(logs)); diff != "" {
+		t.Errorf("logs mismatch (-want +got):\n%s", diff)
+	}
+	// The group completed in the first attempt must not be queried again.
+	wantQueriedNames := [][]string{{"projects/bar"}, {"projects/baz"}, {"projects/baz"}}
+	if diff := cmp.Diff(wantQueriedNames, fetcher.queriedNames); diff != "" {
+		t.Errorf("queried resource names mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func readInsertIDs(logs []*log.Log) []string {
+	result := []string{}
+	for _, l := range logs {
+		result = append(result, l.ReadStringOrDefault("insertId", ""))
+	}
+	return result
+}
+
+func TestNewListLogEntriesTaskRetryPolicy(t *testing.T) {
+	task := NewListLogEntriesTask(&mockListLogEntriesTaskSetting{
+		description: &ListLogEntriesTaskDescription{DefaultLogType: enum.LogTypeContainer},
+	})
+	policy, found := typedmap.Get(task.Labels(), coretask.LabelKeyTaskRetryPolicy)
+	if !found || policy == nil {
+		t.Fatalf("retry policy label not found")
+	}
+	if policy.MaxAttempts <= 1 {
+		t.Errorf("MaxAttempts = %d, want greater than 1", policy.MaxAttempts)
+	}
+	if policy.Retryable == nil || !policy.Retryable(status.Error(codes.Unavailable, "service unavailable")) {
+		t.Errorf("the retry policy must retry Unavailable errors")
+	}
+}
+
+func TestIsTransientLoggingError(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "unavailable",
+			err:  status.Error(codes.Unavailable, "service unavailable"),
+			want: true,
+		},
+		{
+			name: "resource exhausted wrapped with another error",
+			err:  fmt.Errorf("failed to fetch logs: %w", status.Error(codes.ResourceExhausted, "quota exceeded")),
+			want: false,
+		},
+		{
+			name: "invalid argument",
+			err:  status.Error(codes.InvalidArgument, "invalid filter"),
+			want: false,
+		},
+		{
+			name: "circuit open",
+			err:  fmt.Errorf("%w: %w", ErrLoggingCircuitOpen, status.Error(codes.Unavailable, "service unavailable")),
+			want: false,
+		},
+		{
+			name: "non gRPC error",
+			err:  errors.New("test error"),
+			want: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isTransientLoggingError(tc.err); got != tc.want {
+				t.Errorf("isTransientLoggingError() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestSetQueryInfo(t *testing.T) {
 	t.Parallel()
 	taskID := "task-foo"
@@ -400,6 +560,11 @@ func TestSetErrorMetadataForFetchLogError(t *testing.T) {
 				Message: "invalid input",
 			},
 		},
+		{
+			desc:             "query stopped by the open circuit",
+			err:              fmt.Errorf("%w: %w", ErrLoggingCircuitOpen, status.Error(codes.Unavailable, "service unavailable")),
+			wantErrorMessage: inspectionmetadata.NewCircuitOpenErrorMessage("Cloud Logging", "cloud logging requests are stopped after repeated failures: rpc error: code = Unavailable desc = service unavailable"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
@@ -445,10 +610,39 @@ func TestGroupResourceNamesByContainer(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "log views in organization and folder log buckets",
+			resourceNames: []string{
+				"organizations/123/locations/global/buckets/central/views/gke",
+				"folders/456/locations/us-central1/buckets/central/views/_AllLogs",
+				"projects/project-1",
+			},
+			want: []*resourceContainerLogQueryGroup{
+				{
+					container:     googlecloud.Folder("456"),
+					resourceNames: []string{"folders/456/locations/us-central1/buckets/central/views/_AllLogs"},
+				},
+				{
+					container:     googlecloud.Organization("123"),
+					resourceNames: []string{"organizations/123/locations/global/buckets/central/views/gke"},
+				},
+				{
+					container:     googlecloud.Project("project-1"),
+					resourceNames: []string{"projects/project-1"},
+				},
+			},
+		},
 		{
 			name: "unsupported resource name format",
 			resourceNames: []string{
-				"folders/12345",
+				"clusters/12345",
+			},
+			wantErr: true,
+		},
+		{
+			name: "resource name without id",
+			resourceNames: []string{
+				"folders/",
 			},
 			wantErr: true,
 		},
@@ -546,3 +740,80 @@ func TestDivideGroupByMaximumResourceName(t *testing.T) {
 		})
 	}
 }
+
+func TestAppendAdditionalProjectResourceNames(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		resourceNames []string
+		projectIDs    []string
+		want          []string
+	}{
+		{
+			desc:          "without additional projects",
+			resourceNames: []string{"projects/foo"},
+			projectIDs:    []string{},
+			want:          []string{"projects/foo"},
+		},
+		{
+			desc:          "with additional projects",
+			resourceNames: []string{"projects/foo"},
+			projectIDs:    []string{"host", "service"},
+			want:          []string{"projects/foo", "projects/host", "projects/service"},
+		},
+		{
+			desc:          "project already included in the resource names",
+			resourceNames: []string{"projects/foo", "projects/foo/logs/bar"},
+			projectIDs:    []string{"foo", "host"},
+			want:          []string{"projects/foo", "projects/foo/logs/bar", "projects/host"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := appendAdditionalProjectResourceNames(tc.resourceNames, tc.projectIDs)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("appendAdditionalProjectResourceNames() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAppendMissingResourceNames(t *testing.T) {
+	got := appendMissingResourceNames([]string{"projects/foo"}, []string{"folders/123/locations/global/buckets/central/views/gke", "projects/foo"})
+	want := []string{"projects/foo", "folders/123/locations/global/buckets/central/views/gke"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("appendMissingResourceNames() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAppendAdditionalLogFilter(t *testing.T) {
+	testCases := []struct {
+		desc             string
+		filters          []string
+		additionalFilter string
+		want             []string
+	}{
+		{
+			desc:             "without additional filter",
+			filters:          []string{"foo", "bar"},
+			additionalFilter: " ",
+			want:             []string{"foo", "bar"},
+		},
+		{
+			desc:             "with additional filter",
+			filters:          []string{"foo", "bar"},
+			additionalFilter: `resource.labels.namespace_name!="noisy" OR severity>=ERROR`,
+			want: []string{
+				"foo\n(resource.labels.namespace_name!=\"noisy\" OR severity>=ERROR)",
+				"bar\n(resource.labels.namespace_name!=\"noisy\" OR severity>=ERROR)",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := appendAdditionalLogFilter(tc.filters, tc.additionalFilter)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("appendAdditionalLogFilter() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -25,17 +25,19 @@ import (
 
 type LocationFetcher interface {
 	FetchRegions(ctx context.Context, projectId string) ([]string, error)
+	FetchZones(ctx context.Context, projectId string) ([]string, error)
 }
 
 type locationFetcherImpl struct {
-	client             *compute.RegionsClient
+	regionsClient      *compute.RegionsClient
+	zonesClient        *compute.ZonesClient
 	callOptionInjector *googlecloud.CallOptionInjector
 }
 
 // FetchRegions implements LocationFetcher.
 func (l *locationFetcherImpl) FetchRegions(ctx context.Context, projectId string) ([]string, error) {
 	ctx = l.callOptionInjector.InjectToCallContext(ctx, googlecloud.Project(projectId))
-	iter := l.client.List(ctx, &computepb.ListRegionsRequest{
+	iter := l.regionsClient.List(ctx, &computepb.ListRegionsRequest{
 		Project: projectId,
 	})
 
@@ -55,9 +57,33 @@ func (l *locationFetcherImpl) FetchRegions(ctx context.Context, projectId string
 	return result, nil
 }
 
-func NewLocationFetcher(client *compute.RegionsClient, callOptionInjector *googlecloud.CallOptionInjector) LocationFetcher {
+// FetchZones implements LocationFetcher.
+func (l *locationFetcherImpl) FetchZones(ctx context.Context, projectId string) ([]string, error) {
+	ctx = l.callOptionInjector.InjectToCallContext(ctx, googlecloud.Project(projectId))
+	iter := l.zonesClient.List(ctx, &computepb.ListZonesRequest{
+		Project: projectId,
+	})
+
+	var result []string
+	for {
+		zone, err := iter.Next()
+		if err != nil {
+			if err == iterator.Done {
+				break
+			}
+			return nil, err
+		}
+		if zone != nil {
+			result = append(result, *zone.Name)
+		}
+	}
+	return result, nil
+}
+
+func NewLocationFetcher(regionsClient *compute.RegionsClient, zonesClient *compute.ZonesClient, callOptionInjector *googlecloud.CallOptionInjector) LocationFetcher {
 	return &locationFetcherImpl{
-		client:             client,
+		regionsClient:      regionsClient,
+		zonesClient:        zonesClient,
 		callOptionInjector: callOptionInjector,
 	}
 }
@@ -0,0 +1,128 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_contract
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	"google.golang.org/protobuf/encoding/protodelim"
+)
+
+// logEntryStoreWriter streams raw log entries returned from Cloud Logging to a StreamingTaskCacheBackend.
+// The query result cache and the checkpoints of ListLogEntries tasks share this storage. Entries are written one by one as size delimited messages not to hold all of them in memory.
+// Methods of a nil writer do nothing, thus callers don't need to check if the backend is available.
+type logEntryStoreWriter struct {
+	key    string
+	writer inspectioncore_contract.TaskCacheWriter
+	buffer *bufio.Writer
+	err    error
+}
+
+// openLogEntryStoreWriter returns the writer storing log entries with the key in the backend.
+// It returns nil when the backend is nil or the writer can't be opened.
+func openLogEntryStoreWriter(ctx context.Context, backend inspectioncore_contract.StreamingTaskCacheBackend, key string) *logEntryStoreWriter {
+	if backend == nil {
+		return nil
+	}
+	writer, err := backend.OpenWriter(ctx, key)
+	if err != nil {
+		slog.WarnContext(ctx, fmt.Sprintf("failed to open the log entry store %s\n%v", key, err))
+		return nil
+	}
+	return &logEntryStoreWriter{
+		key:    key,
+		writer: writer,
+		buffer: bufio.NewWriter(writer),
+	}
+}
+
+// Write appends the entry to the store. The written entries are discarded on Commit when any of writes failed.
+func (w *logEntryStoreWriter) Write(entry *loggingpb.LogEntry) {
+	if w == nil || w.err != nil {
+		return
+	}
+	if _, err := protodelim.MarshalTo(w.buffer, entry); err != nil {
+		w.err = err
+	}
+}
+
+// Abort discards the written entries.
+func (w *logEntryStoreWriter) Abort() {
+	if w == nil {
+		return
+	}
+	w.writer.Abort()
+}
+
+// Commit stores the written entries. Failures are only logged because the entries are stored only to reuse them later.
+func (w *logEntryStoreWriter) Commit(ctx context.Context) {
+	if w == nil {
+		return
+	}
+	if w.err == nil {
+		w.err = w.buffer.Flush()
+	}
+	if w.err != nil {
+		w.writer.Abort()
+		slog.WarnContext(ctx, fmt.Sprintf("failed to write the log entry store %s\n%v", w.key, w.err))
+		return
+	}
+	if err := w.writer.Commit(); err != nil {
+		slog.WarnContext(ctx, fmt.Sprintf("failed to commit the log entry store %s\n%v", w.key, err))
+	}
+}
+
+// loadLogsFromLogEntryStore reads the log entries stored with logEntryStoreWriter and converts them to logs with the log type.
+// Each read entry is also written to the tee writer. Failures on reading the store are only logged because the task can still query the logs again, and the tee writer is aborted then not to store partial entries.
+func loadLogsFromLogEntryStore(ctx context.Context, backend inspectioncore_contract.StreamingTaskCacheBackend, key string, logType enum.LogType, tee *logEntryStoreWriter) ([]*log.Log, bool) {
+	reader, found, err := backend.OpenReader(ctx, key)
+	if err != nil {
+		slog.WarnContext(ctx, fmt.Sprintf("failed to read the log entry store %s\n%v", key, err))
+		return nil, false
+	}
+	if !found {
+		return nil, false
+	}
+	defer reader.Close()
+	bufferedReader := bufio.NewReader(reader)
+	logs := []*log.Log{}
+	for {
+		entry := &loggingpb.LogEntry{}
+		err := protodelim.UnmarshalFrom(bufferedReader, entry)
+		if errors.Is(err, io.EOF) {
+			return logs, true
+		}
+		if err != nil {
+			slog.WarnContext(ctx, fmt.Sprintf("failed to decode the log entry store %s\n%v", key, err))
+			if tee != nil {
+				tee.err = err
+			}
+			return nil, false
+		}
+		tee.Write(entry)
+		if khiLog, ok := convertLogEntry(ctx, entry, logType); ok {
+			logs = append(logs, khiLog)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_contract
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+func TestLogEntryStoreRoundTrip(t *testing.T) {
+	backend, err := inspectioncore_contract.NewFileSystemTaskCacheBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	ctx := context.Background()
+
+	if _, found := loadLogsFromLogEntryStore(ctx, backend, "foo", enum.LogTypeNode, nil); found {
+		t.Fatal("expected no stored logs before storing them")
+	}
+
+	writer := openLogEntryStoreWriter(ctx, backend, "foo")
+	writer.Write(&loggingpb.LogEntry{InsertId: "foo", Payload: &loggingpb.LogEntry_TextPayload{TextPayload: "hello"}})
+	writer.Write(&loggingpb.LogEntry{InsertId: "bar", LogName: "projects/foo/logs/bar"})
+	if _, found := loadLogsFromLogEntryStore(ctx, backend, "foo", enum.LogTypeNode, nil); found {
+		t.Fatal("expected the logs not to be visible before committing them")
+	}
+	writer.Commit(ctx)
+
+	tee := openLogEntryStoreWriter(ctx, backend, "tee")
+	restored, found := loadLogsFromLogEntryStore(ctx, backend, "foo", enum.LogTypeNode, tee)
+	if !found {
+		t.Fatal("expected the stored logs to be found")
+	}
+	tee.Commit(ctx)
+	teeRestored, found := loadLogsFromLogEntryStore(ctx, backend, "tee", enum.LogTypeNode, nil)
+	if !found {
+		t.Fatal("expected the logs written to the tee writer to be found")
+	}
+
+	if len(restored) != 2 {
+		t.Fatalf("expected 2 logs, got %d", len(restored))
+	}
+	if len(teeRestored) != 2 {
+		t.Errorf("expected 2 logs written to the tee writer, got %d", len(teeRestored))
+	}
+	for i, wantInsertID := range []string{"foo", "bar"} {
+		if restored[i].LogType != enum.LogTypeNode {
+			t.Errorf("log #%d: expected the log type %v, got %v", i, enum.LogTypeNode, restored[i].LogType)
+		}
+		got, err := restored[i].ReadString("insertId")
+		if err != nil || got != wantInsertID {
+			t.Errorf("log #%d: expected insertId %s, got %s (err: %v)", i, wantInsertID, got, err)
+		}
+	}
+	message, err := restored[0].ReadString("textPayload")
+	if err != nil || message != "hello" {
+		t.Errorf("expected the payload to be restored, got %s (err: %v)", message, err)
+	}
+}
+
+func TestLogEntryStoreWithEmptyResult(t *testing.T) {
+	backend, err := inspectioncore_contract.NewFileSystemTaskCacheBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	ctx := context.Background()
+	openLogEntryStoreWriter(ctx, backend, "empty").Commit(ctx)
+
+	restored, found := loadLogsFromLogEntryStore(ctx, backend, "empty", enum.LogTypeNode, nil)
+	if !found {
+		t.Fatal("expected the stored empty result to be found")
+	}
+	if len(restored) != 0 {
+		t.Errorf("expected no logs, got %d", len(restored))
+	}
+}
+
+func TestLogEntryStoreAbort(t *testing.T) {
+	backend, err := inspectioncore_contract.NewFileSystemTaskCacheBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	ctx := context.Background()
+	writer := openLogEntryStoreWriter(ctx, backend, "foo")
+	writer.Write(&loggingpb.LogEntry{InsertId: "foo"})
+	writer.Abort()
+
+	if _, found := loadLogsFromLogEntryStore(ctx, backend, "foo", enum.LogTypeNode, nil); found {
+		t.Error("expected the aborted logs not to be stored")
+	}
+	// A nil writer is returned without the backend and must be usable as a writer doing nothing.
+	nilWriter := openLogEntryStoreWriter(ctx, nil, "foo")
+	nilWriter.Write(&loggingpb.LogEntry{InsertId: "foo"})
+	nilWriter.Abort()
+	nilWriter.Commit(ctx)
+}
+
+func TestLogEntryStoreWithBrokenEntries(t *testing.T) {
+	backend, err := inspectioncore_contract.NewFileSystemTaskCacheBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	ctx := context.Background()
+	if err := backend.Set(ctx, "broken", []byte{0xff, 0xff, 0xff}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	tee := openLogEntryStoreWriter(ctx, backend, "tee")
+	if _, found := loadLogsFromLogEntryStore(ctx, backend, "broken", enum.LogTypeNode, tee); found {
+		t.Fatal("expected broken entries not to be loaded")
+	}
+	tee.Commit(ctx)
+	if _, found := loadLogsFromLogEntryStore(ctx, backend, "tee", enum.LogTypeNode, nil); found {
+		t.Error("expected the tee writer not to store partial entries")
+	}
+}
@@ -16,13 +16,18 @@ package googlecloudcommon_contract
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
+	"sync"
 	"time"
 
 	"cloud.google.com/go/logging/apiv2/loggingpb"
 	"github.com/googleapis/gax-go/v2"
 	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	"github.com/kyasbal/khi/pkg/core/inspection/gcpqueryutil"
 	"google.golang.org/api/iterator"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // LogFetcher is an interface for fetching logs from Cloud Logging with a given filter
@@ -32,25 +37,57 @@ type LogFetcher interface {
 	FetchLogs(dest chan<- *loggingpb.LogEntry, ctx context.Context, filter string, container googlecloud.ResourceContainer, resourceContainers []string) error
 }
 
+// minLogEntriesPageSize is the smallest page size used when Cloud Logging keeps throttling or timing out the list requests.
+const minLogEntriesPageSize int32 = 50
+
+// pageSizeGrowthSuccessCount is the count of pages fetched successfully in series to grow the page size again after shrinking it.
+const pageSizeGrowthSuccessCount = 3
+
+// maxRetryCountAtMinimumPageSize is the count of throttled or timed out requests allowed in series at the smallest page size.
+const maxRetryCountAtMinimumPageSize = 5
+
+// listLogEntriesPageFunc lists a page of log entries with the given page token and page size.
+// It returns the token of the next page, that is empty when the page is the last.
+type listLogEntriesPageFunc = func(ctx context.Context, pageToken string, pageSize int32) ([]*loggingpb.LogEntry, string, error)
+
 // logFetcherImpl is the implementation of LogFetcher actually accessing to the Cloud Logging API.
 type logFetcherImpl struct {
 	factory            *googlecloud.ClientFactory
 	callOptionInjector *googlecloud.CallOptionInjector
-	pageSize           int32
-	orderBy            string
+	// pageSize is the initial and the maximum page size. The actual page size shrinks when Cloud Logging throttles or times out the requests.
+	pageSize int32
+	orderBy  string
+	// rateLimiter is shared among the log queries in an inspection run to avoid exceeding the read quota of Cloud Logging.
+	rateLimiter *LoggingRateLimiter
+	// circuitBreaker is shared among the log queries in an inspection run to stop the remaining queries to a resource container after repeated failures.
+	circuitBreaker *LoggingCircuitBreaker
+
+	principalOnce sync.Once
+	principal     string
+	principalErr  error
 }
 
+var _ LogVolumeEstimator = (*logFetcherImpl)(nil)
+var _ LogFilterValidator = (*logFetcherImpl)(nil)
+var _ ResourceNameValidator = (*logFetcherImpl)(nil)
+var _ CredentialPrincipalResolver = (*logFetcherImpl)(nil)
+
 // NewLogFetcher returns the instance of LogFetcher initialized with the given *googlecloud.ClientFactory.
-func NewLogFetcher(clientFactory *googlecloud.ClientFactory, callOptionInjector *googlecloud.CallOptionInjector, pageSize int32) LogFetcher {
+// Every list request waits for the given rateLimiter before being sent. rateLimiter can be nil not to limit the requests.
+// The list requests of FetchLogs fail immediately once the circuitBreaker is opened for the resource container. circuitBreaker can be nil not to stop the requests.
+func NewLogFetcher(clientFactory *googlecloud.ClientFactory, callOptionInjector *googlecloud.CallOptionInjector, pageSize int32, rateLimiter *LoggingRateLimiter, circuitBreaker *LoggingCircuitBreaker) LogFetcher {
 	return &logFetcherImpl{
 		factory:            clientFactory,
 		pageSize:           pageSize,
 		orderBy:            "timestamp asc",
 		callOptionInjector: callOptionInjector,
+		rateLimiter:        rateLimiter,
+		circuitBreaker:     circuitBreaker,
 	}
 }
 
 // FetchLogs implements LogFetcher.
+// Logs are requested page by page and the entries of each page are sent to the destination as soon as the page is received.
 func (l *logFetcherImpl) FetchLogs(dest chan<- *loggingpb.LogEntry, ctx context.Context, filter string, container googlecloud.ResourceContainer, resourceContainers []string) error {
 	defer close(dest)
 	client, err := l.factory.LoggingClient(ctx, container)
@@ -60,44 +97,230 @@ func (l *logFetcherImpl) FetchLogs(dest chan<- *loggingpb.LogEntry, ctx context.
 	defer client.Close()
 
 	ctx = l.callOptionInjector.InjectToCallContext(ctx, container)
+	listPage := func(ctx context.Context, pageToken string, pageSize int32) ([]*loggingpb.LogEntry, string, error) {
+		if err := l.circuitBreaker.Allow(container); err != nil {
+			return nil, "", err
+		}
+		release, err := l.rateLimiter.Acquire(ctx)
+		if err != nil {
+			return nil, "", err
+		}
+		defer release()
+		iter := client.ListLogEntries(ctx, &loggingpb.ListLogEntriesRequest{
+			ResourceNames: resourceContainers,
+			Filter:        filter,
+			OrderBy:       l.orderBy,
+		}, gax.WithRetry(newCloudLoggingRetrier), googlecloud.NeverTimeout)
+		entries := []*loggingpb.LogEntry{}
+		nextPageToken, err := iterator.NewPager(iter, int(pageSize), pageToken).NextPage(&entries)
+		if ctx.Err() == nil {
+			l.circuitBreaker.Record(container, err)
+		}
+		return entries, nextPageToken, err
+	}
+	return fetchPagesAdaptively(ctx, dest, listPage, newAdaptivePageSize(l.pageSize), &gax.Backoff{
+		Initial:    time.Second,
+		Max:        30 * time.Second,
+		Multiplier: 2,
+	})
+}
+
+// CredentialPrincipal implements CredentialPrincipalResolver.
+// The principal is resolved once because the fetcher is instantiated for each inspection run.
+func (l *logFetcherImpl) CredentialPrincipal(ctx context.Context) (string, error) {
+	l.principalOnce.Do(func() {
+		l.principal, l.principalErr = resolveCredentialPrincipal(ctx, l.factory)
+	})
+	return l.principal, l.principalErr
+}
+
+// EstimateLogVolume implements LogVolumeEstimator.
+// It requests only the first page of the entries and extrapolates the count from the time range covered by the page.
+func (l *logFetcherImpl) EstimateLogVolume(ctx context.Context, filter string, container googlecloud.ResourceContainer, resourceContainers []string, startTime, endTime time.Time) (LogVolumeEstimate, error) {
+	client, err := l.factory.LoggingClient(ctx, container)
+	if err != nil {
+		return LogVolumeEstimate{}, err
+	}
+	defer client.Close()
+
+	ctx = l.callOptionInjector.InjectToCallContext(ctx, container)
+	release, err := l.rateLimiter.Acquire(ctx)
+	if err != nil {
+		return LogVolumeEstimate{}, err
+	}
+	defer release()
 	iter := client.ListLogEntries(ctx, &loggingpb.ListLogEntriesRequest{
 		ResourceNames: resourceContainers,
-		Filter:        filter,
+		Filter:        fmt.Sprintf("%s\n%s", filter, gcpqueryutil.TimeRangeQuerySection(startTime, endTime, false)),
 		OrderBy:       l.orderBy,
-		PageSize:      l.pageSize,
-	}, gax.WithRetry(newCloudLoggingRetrier), googlecloud.NeverTimeout)
+	}, gax.WithRetry(newCloudLoggingRetrier))
+	entries := []*loggingpb.LogEntry{}
+	nextPageToken, err := iterator.NewPager(iter, logVolumeEstimationSampleSize, "").NextPage(&entries)
+	if err != nil {
+		return LogVolumeEstimate{}, err
+	}
+	return estimateLogVolumeFromSample(entries, nextPageToken != "", startTime, endTime), nil
+}
 
-	for {
-		entry, err := iter.Next()
-		if err == iterator.Done {
-			break
-		}
+// ValidateLogFilter implements LogFilterValidator.
+// It requests a single entry in the last minute to let Cloud Logging parse the filter with the smallest cost.
+func (l *logFetcherImpl) ValidateLogFilter(ctx context.Context, filter string, container googlecloud.ResourceContainer) error {
+	client, err := l.factory.LoggingClient(ctx, container)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx = l.callOptionInjector.InjectToCallContext(ctx, container)
+	release, err := l.rateLimiter.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	endTime := time.Now()
+	iter := client.ListLogEntries(ctx, &loggingpb.ListLogEntriesRequest{
+		ResourceNames: []string{container.Identifier()},
+		Filter:        fmt.Sprintf("(%s)\n%s", filter, gcpqueryutil.TimeRangeQuerySection(endTime.Add(-time.Minute), endTime, false)),
+		OrderBy:       l.orderBy,
+	})
+	entries := []*loggingpb.LogEntry{}
+	_, err = iterator.NewPager(iter, 1, "").NextPage(&entries)
+	return err
+}
+
+// ValidateResourceName implements ResourceNameValidator.
+// It requests a single entry in the last minute from the resource name to let Cloud Logging check the resource with the smallest cost.
+func (l *logFetcherImpl) ValidateResourceName(ctx context.Context, resourceName string, container googlecloud.ResourceContainer) error {
+	client, err := l.factory.LoggingClient(ctx, container)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
 
-		select {
-		// Check the context cancel first
-		case <-ctx.Done():
+	ctx = l.callOptionInjector.InjectToCallContext(ctx, container)
+	release, err := l.rateLimiter.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	endTime := time.Now()
+	iter := client.ListLogEntries(ctx, &loggingpb.ListLogEntriesRequest{
+		ResourceNames: []string{resourceName},
+		Filter:        gcpqueryutil.TimeRangeQuerySection(endTime.Add(-time.Minute), endTime, false),
+		OrderBy:       l.orderBy,
+	})
+	entries := []*loggingpb.LogEntry{}
+	_, err = iterator.NewPager(iter, 1, "").NextPage(&entries)
+	return err
+}
+
+// fetchPagesAdaptively lists all pages with listPage and sends the entries to dest.
+// The page size is halved when a request is throttled or timed out, and the same page is requested again after the backoff.
+func fetchPagesAdaptively(ctx context.Context, dest chan<- *loggingpb.LogEntry, listPage listLogEntriesPageFunc, pageSize *adaptivePageSize, backoff *gax.Backoff) error {
+	pageToken := ""
+	retryCountAtMinimumPageSize := 0
+	for {
+		entries, nextPageToken, err := listPage(ctx, pageToken, pageSize.Current())
+		if ctx.Err() != nil {
 			return ctx.Err()
-		default:
 		}
-
 		if err != nil {
-			return err
+			if !isPageSizeRelatedError(err) {
+				return err
+			}
+			if !pageSize.Shrink() {
+				retryCountAtMinimumPageSize++
+				if retryCountAtMinimumPageSize > maxRetryCountAtMinimumPageSize {
+					return err
+				}
+			}
+			slog.WarnContext(ctx, fmt.Sprintf("listing log entries failed with a retryable error. Retrying with page size %d: %v", pageSize.Current(), err))
+			select {
+			case <-time.After(backoff.Pause()):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			continue
 		}
-		select {
-		case dest <- entry:
-		case <-ctx.Done():
-			return ctx.Err()
+		retryCountAtMinimumPageSize = 0
+		pageSize.Succeeded()
+
+		for _, entry := range entries {
+			select {
+			case dest <- entry:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
 		}
+		if nextPageToken == "" {
+			return nil
+		}
+		pageToken = nextPageToken
+	}
+}
+
+// isPageSizeRelatedError returns true when the error can be resolved by requesting smaller pages.
+func isPageSizeRelatedError(err error) bool {
+	code := status.Code(err)
+	return code == codes.ResourceExhausted || code == codes.DeadlineExceeded
+}
+
+// adaptivePageSize is the page size of list requests adapting to the throttling and timeout errors returned from Cloud Logging.
+type adaptivePageSize struct {
+	current      int32
+	max          int32
+	successCount int
+}
+
+func newAdaptivePageSize(maxPageSize int32) *adaptivePageSize {
+	return &adaptivePageSize{
+		current: maxPageSize,
+		max:     maxPageSize,
+	}
+}
+
+// Current returns the page size used for the next request.
+func (a *adaptivePageSize) Current() int32 {
+	return a.current
+}
+
+// Shrink halves the page size. It returns false when the page size is already the smallest.
+func (a *adaptivePageSize) Shrink() bool {
+	a.successCount = 0
+	minPageSize := min(minLogEntriesPageSize, a.max)
+	if a.current <= minPageSize {
+		return false
+	}
+	a.current = max(a.current/2, minPageSize)
+	return true
+}
 
+// Succeeded records a successful request and doubles the page size up to the maximum after pageSizeGrowthSuccessCount successes in series.
+func (a *adaptivePageSize) Succeeded() {
+	a.successCount++
+	if a.successCount < pageSizeGrowthSuccessCount || a.current >= a.max {
+		return
 	}
-	return nil
+	a.successCount = 0
+	a.current = min(a.current*2, a.max)
 }
 
 func newCloudLoggingRetrier() gax.Retryer {
 	// Cloud Logging may return PermissionError even when caller has sufficient permission especially when the project contains many log views.
 	// Allow up to 5 Permission errors in series.
+	// ResourceExhausted and DeadlineExceeded are not retried here but handled by fetchPagesAdaptively to retry with a smaller page size.
 	return googlecloud.NewRetryWithCountBudget([]codes.Code{
 		codes.PermissionDenied,
-	}, 100*time.Millisecond, 1.0, time.Second, 5, googlecloud.NewDefaultRetryer(),
+	}, 100*time.Millisecond, 1.0, time.Second, 5, gax.OnCodes([]codes.Code{
+		codes.Aborted,
+		codes.Canceled,
+		codes.Internal,
+		codes.Unknown,
+		codes.Unavailable,
+	}, gax.Backoff{
+		Initial:    100 * time.Millisecond,
+		Max:        5000 * time.Millisecond,
+		Multiplier: 1.30,
+	}),
 	)
 }
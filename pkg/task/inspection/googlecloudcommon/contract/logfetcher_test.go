@@ -22,8 +22,12 @@ import (
 	"time"
 
 	"cloud.google.com/go/logging/apiv2/loggingpb"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/gax-go/v2"
 	"github.com/kyasbal/khi/internal/testflags"
 	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestLogFetcherImpl_FetchLogs(t *testing.T) {
@@ -109,3 +113,137 @@ func TestLogFetcherImpl_FetchLogsIsCancellable(t *testing.T) {
 	}
 	close(fetchLogFinished)
 }
+
+type listPageCall struct {
+	pageToken string
+	pageSize  int32
+}
+
+type listPageResponse struct {
+	entryCount    int
+	nextPageToken string
+	err           error
+}
+
+func TestFetchPagesAdaptively(t *testing.T) {
+	throttled := status.Error(codes.ResourceExhausted, "quota exceeded")
+	testCases := []struct {
+		name           string
+		maxPageSize    int32
+		responses      []listPageResponse
+		wantCalls      []listPageCall
+		wantEntryCount int
+		wantErr        bool
+	}{
+		{
+			name:        "all pages fetched without errors",
+			maxPageSize: 1000,
+			responses: []listPageResponse{
+				{entryCount: 3, nextPageToken: "page-2"},
+				{entryCount: 2},
+			},
+			wantCalls: []listPageCall{
+				{pageToken: "", pageSize: 1000},
+				{pageToken: "page-2", pageSize: 1000},
+			},
+			wantEntryCount: 5,
+		},
+		{
+			name:        "page size shrinks on throttling and grows after successes",
+			maxPageSize: 1000,
+			responses: []listPageResponse{
+				{entryCount: 1, nextPageToken: "page-2"},
+				{err: throttled},
+				{err: status.Error(codes.DeadlineExceeded, "deadline exceeded")},
+				{entryCount: 1, nextPageToken: "page-3"},
+				{entryCount: 1, nextPageToken: "page-4"},
+				{entryCount: 1, nextPageToken: "page-5"},
+				{entryCount: 1},
+			},
+			wantCalls: []listPageCall{
+				{pageToken: "", pageSize: 1000},
+				{pageToken: "page-2", pageSize: 1000},
+				{pageToken: "page-2", pageSize: 500},
+				{pageToken: "page-2", pageSize: 250},
+				{pageToken: "page-3", pageSize: 250},
+				{pageToken: "page-4", pageSize: 250},
+				{pageToken: "page-5", pageSize: 500},
+			},
+			wantEntryCount: 5,
+		},
+		{
+			name:        "non retryable error",
+			maxPageSize: 1000,
+			responses: []listPageResponse{
+				{err: status.Error(codes.InvalidArgument, "invalid filter")},
+			},
+			wantCalls: []listPageCall{
+				{pageToken: "", pageSize: 1000},
+			},
+			wantErr: true,
+		},
+		{
+			name:        "throttled too many times at the minimum page size",
+			maxPageSize: 100,
+			responses: []listPageResponse{
+				{err: throttled},
+				{err: throttled},
+				{err: throttled},
+				{err: throttled},
+				{err: throttled},
+				{err: throttled},
+				{err: throttled},
+			},
+			wantCalls: []listPageCall{
+				{pageToken: "", pageSize: 100},
+				{pageToken: "", pageSize: 50},
+				{pageToken: "", pageSize: 50},
+				{pageToken: "", pageSize: 50},
+				{pageToken: "", pageSize: 50},
+				{pageToken: "", pageSize: 50},
+				{pageToken: "", pageSize: 50},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := []listPageCall{}
+			listPage := func(ctx context.Context, pageToken string, pageSize int32) ([]*loggingpb.LogEntry, string, error) {
+				calls = append(calls, listPageCall{pageToken: pageToken, pageSize: pageSize})
+				if len(calls) > len(tc.responses) {
+					t.Fatalf("listPage was called more than expected")
+				}
+				response := tc.responses[len(calls)-1]
+				entries := make([]*loggingpb.LogEntry, response.entryCount)
+				for i := range entries {
+					entries[i] = &loggingpb.LogEntry{}
+				}
+				return entries, response.nextPageToken, response.err
+			}
+			dest := make(chan *loggingpb.LogEntry)
+			entryCount := 0
+			receiverDone := make(chan struct{})
+			go func() {
+				defer close(receiverDone)
+				for range dest {
+					entryCount++
+				}
+			}()
+
+			err := fetchPagesAdaptively(t.Context(), dest, listPage, newAdaptivePageSize(tc.maxPageSize), &gax.Backoff{Initial: time.Millisecond, Max: time.Millisecond})
+			close(dest)
+			<-receiverDone
+
+			if tc.wantErr != (err != nil) {
+				t.Errorf("fetchPagesAdaptively() returned error %v, wantErr %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.wantCalls, calls, cmp.AllowUnexported(listPageCall{})); diff != "" {
+				t.Errorf("listPage calls mismatch (-want +got):\n%s", diff)
+			}
+			if entryCount != tc.wantEntryCount {
+				t.Errorf("received %d entries, want %d", entryCount, tc.wantEntryCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_contract
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// LogFilterValidator is implemented by LogFetcher implementations that can check a log filter is accepted by Cloud Logging without fetching the logs.
+type LogFilterValidator interface {
+	// ValidateLogFilter sends a small query with the given filter and returns the error returned from Cloud Logging.
+	ValidateLogFilter(ctx context.Context, filter string, container googlecloud.ResourceContainer) error
+}
+
+// ValidateLogFilterWithDryRunQuery checks the given filter with a small query against the container and returns the hint message for the form.
+// The message is empty when the filter is accepted, the fetcher can't validate filters or the validation failed for a reason other than the filter itself.
+// The results are cached in the GlobalSharedMap not to send the same query on every dry run.
+func ValidateLogFilterWithDryRunQuery(ctx context.Context, fetcher LogFetcher, filter string, container googlecloud.ResourceContainer) string {
+	validator, ok := fetcher.(LogFilterValidator)
+	if !ok {
+		return ""
+	}
+	sharedMap := khictx.MustGetValue(ctx, inspectioncore_contract.GlobalSharedMap)
+	cacheKey := typedmap.NewTypedKey[string](fmt.Sprintf("log-filter-validation-%s-%s", container.Identifier(), filter))
+	if cached, found := typedmap.Get(sharedMap, cacheKey); found {
+		return cached
+	}
+	err := validator.ValidateLogFilter(ctx, filter, container)
+	if err != nil && status.Code(err) != codes.InvalidArgument {
+		slog.WarnContext(ctx, fmt.Sprintf("skipping to validate the log filter: %v", err))
+		return ""
+	}
+	hint := ""
+	if err != nil {
+		hint = fmt.Sprintf("Cloud Logging rejected the filter: %s", status.Convert(err).Message())
+	}
+	typedmap.Set(sharedMap, cacheKey, hint)
+	return hint
+}
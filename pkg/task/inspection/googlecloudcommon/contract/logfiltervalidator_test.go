@@ -0,0 +1,100 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_contract
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type countingLogFilterValidator struct {
+	callCount int
+	err       error
+}
+
+// FetchLogs implements LogFetcher.
+func (c *countingLogFilterValidator) FetchLogs(dest chan<- *loggingpb.LogEntry, ctx context.Context, filter string, container googlecloud.ResourceContainer, resourceContainers []string) error {
+	close(dest)
+	return nil
+}
+
+// ValidateLogFilter implements LogFilterValidator.
+func (c *countingLogFilterValidator) ValidateLogFilter(ctx context.Context, filter string, container googlecloud.ResourceContainer) error {
+	c.callCount++
+	return c.err
+}
+
+func TestValidateLogFilterWithDryRunQuery(t *testing.T) {
+	testCases := []struct {
+		name          string
+		err           error
+		want          string
+		wantCallCount int
+	}{
+		{
+			name:          "valid filter",
+			want:          "",
+			wantCallCount: 1,
+		},
+		{
+			name:          "invalid filter",
+			err:           status.Error(codes.InvalidArgument, "unparseable filter"),
+			want:          "Cloud Logging rejected the filter: unparseable filter",
+			wantCallCount: 1,
+		},
+		{
+			name:          "failure unrelated to the filter is not cached",
+			err:           status.Error(codes.PermissionDenied, "permission denied"),
+			want:          "",
+			wantCallCount: 2,
+		},
+		{
+			name:          "failure without status is not cached",
+			err:           errors.New("connection refused"),
+			want:          "",
+			wantCallCount: 2,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+			validator := &countingLogFilterValidator{err: tc.err}
+			for i := 0; i < 2; i++ {
+				got := ValidateLogFilterWithDryRunQuery(ctx, validator, "foo", googlecloud.Project("bar"))
+				if got != tc.want {
+					t.Errorf("ValidateLogFilterWithDryRunQuery() = %q, want %q", got, tc.want)
+				}
+			}
+			if validator.callCount != tc.wantCallCount {
+				t.Errorf("ValidateLogFilter() was called %d times, want %d", validator.callCount, tc.wantCallCount)
+			}
+		})
+	}
+}
+
+func TestValidateLogFilterWithDryRunQueryWithoutValidator(t *testing.T) {
+	ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+	got := ValidateLogFilterWithDryRunQuery(ctx, &mockLogFetcher{}, "foo", googlecloud.Project("bar"))
+	if got != "" {
+		t.Errorf("ValidateLogFilterWithDryRunQuery() = %q, want empty", got)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_contract
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	"google.golang.org/protobuf/proto"
+)
+
+// logVolumeEstimationSampleSize is the count of log entries requested to estimate the volume of logs matching a filter.
+const logVolumeEstimationSampleSize = 100
+
+// logEntryMemoryOverheadRatio is the rough ratio of the memory used to hold a log entry in an inspection against its serialized size.
+const logEntryMemoryOverheadRatio = 8
+
+// largeLogVolumeWarningBytes is the projected memory usage of a query over which the estimate is shown as a warning.
+const largeLogVolumeWarningBytes = 1 << 30
+
+// LogVolumeEstimate is the estimated volume of logs matching a log filter.
+type LogVolumeEstimate struct {
+	// EntryCount is the estimated count of log entries.
+	EntryCount int
+	// Exact is true when EntryCount is the actual count of log entries because the sample contained all of them.
+	Exact bool
+	// MemoryBytes is the projected memory usage to hold the log entries in an inspection.
+	MemoryBytes int64
+}
+
+// LogVolumeEstimator estimates the volume of logs matching a filter by reading only a sample of them.
+// A LogFetcher can implement this interface to let the list log entries task show the estimate in the dry run.
+type LogVolumeEstimator interface {
+	// EstimateLogVolume estimates the volume of logs matching the filter in the time range. The filter must not contain the time range.
+	EstimateLogVolume(ctx context.Context, filter string, container googlecloud.ResourceContainer, resourceContainers []string, startTime, endTime time.Time) (LogVolumeEstimate, error)
+}
+
+// Add returns the sum of the 2 estimates.
+func (e LogVolumeEstimate) Add(other LogVolumeEstimate) LogVolumeEstimate {
+	return LogVolumeEstimate{
+		EntryCount:  e.EntryCount + other.EntryCount,
+		Exact:       e.Exact && other.Exact,
+		MemoryBytes: e.MemoryBytes + other.MemoryBytes,
+	}
+}
+
+// HintMessage returns the message shown in the form to tell the estimated volume of logs queried by the query.
+func (e LogVolumeEstimate) HintMessage(queryName string) string {
+	approximate := "~"
+	if e.Exact {
+		approximate = ""
+	}
+	return fmt.Sprintf("Estimated log volume of `%s`: %s%d logs (%s%s in memory)", queryName, approximate, e.EntryCount, approximate, formatBytes(e.MemoryBytes))
+}
+
+// estimateLogVolumeFromSample estimates the volume of logs in the time range from the first entries sorted by timestamp.
+// hasMore must be true when the sample didn't contain all the matching entries.
+func estimateLogVolumeFromSample(sample []*loggingpb.LogEntry, hasMore bool, startTime, endTime time.Time) LogVolumeEstimate {
+	if len(sample) == 0 {
+		return LogVolumeEstimate{Exact: !hasMore}
+	}
+	sampleBytes := 0
+	for _, entry := range sample {
+		sampleBytes += proto.Size(entry)
+	}
+	entryCount := len(sample)
+	if hasMore {
+		// Extrapolate the count with the ratio of the time range covered by the sample to the entire time range.
+		coveredDuration := sample[len(sample)-1].GetTimestamp().AsTime().Sub(startTime)
+		coveredDuration = max(coveredDuration, time.Second)
+		entryCount = max(entryCount, int(float64(entryCount)*float64(endTime.Sub(startTime))/float64(coveredDuration)))
+	}
+	return LogVolumeEstimate{
+		EntryCount:  entryCount,
+		Exact:       !hasMore,
+		MemoryBytes: int64(sampleBytes) * int64(entryCount) / int64(len(sample)) * logEntryMemoryOverheadRatio,
+	}
+}
+
+// estimateLogVolumeWithCache returns the estimate of the given query. The estimate is reused across dry runs in the same inspection while the query is not changed.
+func estimateLogVolumeWithCache(ctx context.Context, estimator LogVolumeEstimator, taskID string, filter string, container googlecloud.ResourceContainer, resourceNames []string, startTime, endTime time.Time) (LogVolumeEstimate, error) {
+	sharedMap := khictx.MustGetValue(ctx, inspectioncore_contract.GlobalSharedMap)
+	cacheKey := typedmap.NewTypedKey[LogVolumeEstimate](fmt.Sprintf("log-volume-estimate-%s-%s-%s-%s-%s", taskID, strings.Join(resourceNames, ","), startTime.Format(time.RFC3339), endTime.Format(time.RFC3339), filter))
+	if cached, found := typedmap.Get(sharedMap, cacheKey); found {
+		return cached, nil
+	}
+	estimate, err := estimator.EstimateLogVolume(ctx, filter, container, resourceNames, startTime, endTime)
+	if err != nil {
+		return LogVolumeEstimate{}, err
+	}
+	typedmap.Set(sharedMap, cacheKey, estimate)
+	return estimate, nil
+}
+
+// formatBytes formats the size in bytes with a binary prefix.
+func formatBytes(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,151 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_contract
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type countingLogVolumeEstimator struct {
+	callCount int
+	estimate  LogVolumeEstimate
+}
+
+// EstimateLogVolume implements LogVolumeEstimator.
+func (c *countingLogVolumeEstimator) EstimateLogVolume(ctx context.Context, filter string, container googlecloud.ResourceContainer, resourceContainers []string, startTime, endTime time.Time) (LogVolumeEstimate, error) {
+	c.callCount++
+	return c.estimate, nil
+}
+
+func TestEstimateLogVolumeFromSample(t *testing.T) {
+	startTime := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	endTime := startTime.Add(time.Hour)
+	entryAt := func(offset time.Duration) *loggingpb.LogEntry {
+		return &loggingpb.LogEntry{InsertId: "foo", Timestamp: timestamppb.New(startTime.Add(offset))}
+	}
+	entrySize := int64(proto.Size(entryAt(0)))
+	testCases := []struct {
+		name    string
+		sample  []*loggingpb.LogEntry
+		hasMore bool
+		want    LogVolumeEstimate
+	}{
+		{
+			name:   "empty sample",
+			sample: []*loggingpb.LogEntry{},
+			want:   LogVolumeEstimate{Exact: true},
+		},
+		{
+			name:   "sample containing all the entries",
+			sample: []*loggingpb.LogEntry{entryAt(time.Minute), entryAt(2 * time.Minute)},
+			want: LogVolumeEstimate{
+				EntryCount:  2,
+				Exact:       true,
+				MemoryBytes: 2 * entrySize * logEntryMemoryOverheadRatio,
+			},
+		},
+		{
+			name:    "sample covering a quarter of the time range",
+			sample:  []*loggingpb.LogEntry{entryAt(time.Minute), entryAt(15 * time.Minute)},
+			hasMore: true,
+			want: LogVolumeEstimate{
+				EntryCount:  8,
+				Exact:       false,
+				MemoryBytes: 8 * entrySize * logEntryMemoryOverheadRatio,
+			},
+		},
+		{
+			name:    "sample at the beginning of the time range",
+			sample:  []*loggingpb.LogEntry{entryAt(0), entryAt(0)},
+			hasMore: true,
+			want: LogVolumeEstimate{
+				EntryCount:  2 * 60 * 60,
+				Exact:       false,
+				MemoryBytes: 2 * 60 * 60 * entrySize * logEntryMemoryOverheadRatio,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := estimateLogVolumeFromSample(tc.sample, tc.hasMore, startTime, endTime)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("estimateLogVolumeFromSample() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLogVolumeEstimateHintMessage(t *testing.T) {
+	testCases := []struct {
+		name     string
+		estimate LogVolumeEstimate
+		want     string
+	}{
+		{
+			name:     "exact",
+			estimate: LogVolumeEstimate{EntryCount: 10, Exact: true, MemoryBytes: 512},
+			want:     "Estimated log volume of `foo`: 10 logs (512 B in memory)",
+		},
+		{
+			name:     "approximate",
+			estimate: LogVolumeEstimate{EntryCount: 12000, MemoryBytes: 3 * 1024 * 1024 / 2},
+			want:     "Estimated log volume of `foo`: ~12000 logs (~1.5 MiB in memory)",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.estimate.HintMessage("foo"); got != tc.want {
+				t.Errorf("HintMessage() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEstimateLogVolumeWithCache(t *testing.T) {
+	ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+	startTime := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	endTime := startTime.Add(time.Hour)
+	estimator := &countingLogVolumeEstimator{estimate: LogVolumeEstimate{EntryCount: 10, Exact: true}}
+
+	for i := 0; i < 2; i++ {
+		got, err := estimateLogVolumeWithCache(ctx, estimator, "foo", "filter", googlecloud.Project("bar"), []string{"projects/bar"}, startTime, endTime)
+		if err != nil {
+			t.Fatalf("estimateLogVolumeWithCache() returned an unexpected error: %v", err)
+		}
+		if diff := cmp.Diff(estimator.estimate, got); diff != "" {
+			t.Errorf("estimateLogVolumeWithCache() mismatch (-want +got):\n%s", diff)
+		}
+	}
+	if estimator.callCount != 1 {
+		t.Errorf("EstimateLogVolume() was called %d times, want 1", estimator.callCount)
+	}
+
+	_, err := estimateLogVolumeWithCache(ctx, estimator, "foo", "filter", googlecloud.Project("bar"), []string{"projects/bar"}, startTime, endTime.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("estimateLogVolumeWithCache() returned an unexpected error: %v", err)
+	}
+	if estimator.callCount != 2 {
+		t.Errorf("EstimateLogVolume() was called %d times after changing the time range, want 2", estimator.callCount)
+	}
+}
@@ -70,7 +70,9 @@ func (s *StandardProgressReportableLogFetcher) FetchLogsWithProgress(dest chan<-
 	filter := fmt.Sprintf("%s\n%s", filterWithoutTimeRange, gcpqueryutil.TimeRangeQuerySection(beginTime, endTime, false))
 
 	wg := sync.WaitGroup{}
-	wg.Add(2)
+	wg.Add(1)
+	consumerWg := sync.WaitGroup{}
+	consumerWg.Add(1)
 	logCount := atomic.Int32{}
 	latestLogTime := &beginTime
 	totalDurationInSeconds := endTime.Sub(beginTime).Seconds()
@@ -81,7 +83,7 @@ func (s *StandardProgressReportableLogFetcher) FetchLogsWithProgress(dest chan<-
 
 	// Consume logs from log fetcher and record count and the latest time for reporting progress
 	go func() {
-		defer wg.Done() // fetcher.FetchLogs is expected to run in sync. But make sure all the logs are consumed in this go routine.
+		defer consumerWg.Done() // fetcher.FetchLogs is expected to run in sync. But make sure all the logs are consumed in this go routine.
 		for {
 			select {
 			case <-subroutineCtx.Done():
@@ -133,10 +135,13 @@ func (s *StandardProgressReportableLogFetcher) FetchLogsWithProgress(dest chan<-
 	err := s.fetcher.FetchLogs(stubChan, ctx, filter, container, resourceContainers)
 	if err != nil {
 		cancelSubroutine()
+		consumerWg.Wait()
 		wg.Wait()
 		return err
 	}
 
+	// FetchLogs closes stubChan on its completion. Wait for the consumer to send the last received log before the cancellation not to drop it while dest is blocked.
+	consumerWg.Wait()
 	cancelSubroutine()
 	wg.Wait()
 
@@ -150,6 +155,13 @@ func (s *StandardProgressReportableLogFetcher) FetchLogsWithProgress(dest chan<-
 
 var _ ProgressReportableLogFetcher = (*StandardProgressReportableLogFetcher)(nil)
 
+// maxBufferedLogsPerPartition is the maximum count of logs buffered for a time partition waiting for the preceding partitions to be sent.
+// Fetching a partition pauses while its buffer is full, and this bounds the memory used by the partitions fetched ahead.
+var maxBufferedLogsPerPartition = 10000
+
+// TimePartitioningProgressReportableLogFetcher is a ProgressReportableLogFetcher splitting the time range into partitions fetched concurrently.
+// The logs are sent to the destination in the order of partitions. The logs of a partition are buffered until all the preceding partitions are sent.
+// Partitions are started in their order, so the first partition not sent yet is always being fetched and the stitching never waits for a paused partition.
 type TimePartitioningProgressReportableLogFetcher struct {
 	client         *StandardProgressReportableLogFetcher
 	partitionCount int
@@ -157,6 +169,7 @@ type TimePartitioningProgressReportableLogFetcher struct {
 	reportInterval time.Duration
 }
 
+// NewTimePartitioningProgressReportableLogFetcher returns a TimePartitioningProgressReportableLogFetcher fetching partitionCount partitions with maxParallelism partitions at most in parallel.
 func NewTimePartitioningProgressReportableLogFetcher(fetcher LogFetcher, interval time.Duration, partitionCount int, maxParallelism int) *TimePartitioningProgressReportableLogFetcher {
 	return &TimePartitioningProgressReportableLogFetcher{
 		client:         NewStandardProgressReportableLogFetcher(fetcher, interval),
@@ -207,6 +220,23 @@ func (t *TimePartitioningProgressReportableLogFetcher) FetchLogsWithProgress(log
 
 	times := t.getPartitionedTimes(beginTime, endTime)
 
+	buffers := make([]*partitionLogBuffer, t.partitionCount)
+	for i := range buffers {
+		buffers[i] = newPartitionLogBuffer(maxBufferedLogsPerPartition)
+	}
+	// Partitions paused on their full buffers must resume and stop when any partition fails.
+	stopClosingBuffers := context.AfterFunc(cancellableCtx, func() {
+		for _, buffer := range buffers {
+			buffer.Close()
+		}
+	})
+	defer stopClosingBuffers()
+	stitchDone := make(chan struct{})
+	go func() {
+		defer close(stitchDone)
+		stitchPartitionLogs(cancellableCtx, logChan, buffers)
+	}()
+
 	wg, groupCtx := errgroup.WithContext(cancellableCtx)
 	wg.SetLimit(t.maxParallelism)
 
@@ -227,7 +257,7 @@ func (t *TimePartitioningProgressReportableLogFetcher) FetchLogsWithProgress(log
 			subLogChan := make(chan *loggingpb.LogEntry)
 			subProgressChan := make(chan LogFetchProgress)
 
-			// Consume the subLogChan and route the log to the parent channel.
+			// Consume the subLogChan and buffer the log until the logs of the preceding partitions are sent.
 			go func() {
 				defer childWg.Done()
 				for {
@@ -238,11 +268,7 @@ func (t *TimePartitioningProgressReportableLogFetcher) FetchLogsWithProgress(log
 						if !ok {
 							return
 						}
-						select {
-						case logChan <- logEntry:
-						case <-groupCtx.Done():
-							return
-						}
+						buffers[subProgressIndex].Append(logEntry)
 					}
 				}
 			}()
@@ -269,11 +295,20 @@ func (t *TimePartitioningProgressReportableLogFetcher) FetchLogsWithProgress(log
 				return err
 			}
 			childWg.Wait()
+			buffers[subProgressIndex].Close()
 			return nil
 		})
 	}
 
 	err := wg.Wait()
+	if err != nil {
+		cancel()
+	}
+	// Buffers of the partitions not completed are closed to let the stitching goroutine finish.
+	for _, buffer := range buffers {
+		buffer.Close()
+	}
+	<-stitchDone
 	cancel()
 	rootGoroutineWaitGroup.Wait()
 	if err != nil {
@@ -298,6 +333,82 @@ func (t *TimePartitioningProgressReportableLogFetcher) getPartitionedTimes(begin
 	return divideTimeSegments(beginTime, endTime, t.partitionCount)
 }
 
+// stitchPartitionLogs sends the logs buffered for each partition to dest in the order of partitions.
+// The logs of a partition are sent as soon as they are buffered when all the preceding partitions are sent.
+func stitchPartitionLogs(ctx context.Context, dest chan<- *loggingpb.LogEntry, buffers []*partitionLogBuffer) {
+	for _, buffer := range buffers {
+		for {
+			entries, more := buffer.Take()
+			for _, entry := range entries {
+				// The buffers are closed after the cancellation when any partition fails. Stop here not to send the logs of the following partitions.
+				if ctx.Err() != nil {
+					return
+				}
+				select {
+				case dest <- entry:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if !more {
+				break
+			}
+		}
+	}
+}
+
+// partitionLogBuffer buffers the logs of a time partition until they are sent to the destination.
+type partitionLogBuffer struct {
+	lock     sync.Mutex
+	cond     *sync.Cond
+	entries  []*loggingpb.LogEntry
+	capacity int
+	closed   bool
+}
+
+// newPartitionLogBuffer returns a partitionLogBuffer holding capacity logs at most.
+func newPartitionLogBuffer(capacity int) *partitionLogBuffer {
+	buffer := &partitionLogBuffer{capacity: capacity}
+	buffer.cond = sync.NewCond(&buffer.lock)
+	return buffer
+}
+
+// Append adds a log to the buffer. It blocks while the buffer is full, and the log is dropped when the buffer is closed.
+func (b *partitionLogBuffer) Append(entry *loggingpb.LogEntry) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for len(b.entries) >= b.capacity && !b.closed {
+		b.cond.Wait()
+	}
+	if b.closed {
+		return
+	}
+	b.entries = append(b.entries, entry)
+	b.cond.Broadcast()
+}
+
+// Close marks no more logs are appended to the buffer. Calling Close more than once is allowed.
+func (b *partitionLogBuffer) Close() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.closed = true
+	b.cond.Broadcast()
+}
+
+// Take blocks until any log is buffered or the buffer is closed, then returns the buffered logs and removes them from the buffer.
+// The second returned value is false when the buffer is closed and no more logs will be returned.
+func (b *partitionLogBuffer) Take() ([]*loggingpb.LogEntry, bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for len(b.entries) == 0 && !b.closed {
+		b.cond.Wait()
+	}
+	entries := b.entries
+	b.entries = nil
+	b.cond.Broadcast()
+	return entries, !b.closed
+}
+
 var _ ProgressReportableLogFetcher = (*TimePartitioningProgressReportableLogFetcher)(nil)
 
 // divideTimeSegments divides a given time range into count of partitioned time segments.
@@ -455,13 +455,11 @@ timestamp < "2025-01-01T00:30:00+0000"`, func(logSource chan<- *loggingpb.LogEnt
 				{},
 				{LogCount: 2, Progress: 0.5},
 			},
+			// The log of the later partition is buffered until the preceding partition completes, thus it's not sent after the error.
 			wantLogs: []*loggingpb.LogEntry{
 				{
 					LogName: "bar", Timestamp: timestamppb.New(beginTime.Add(time.Minute * 15)),
 				},
-				{
-					LogName: "foo", Timestamp: timestamppb.New(beginTime.Add(time.Minute * 45)),
-				},
 			},
 		},
 		{
@@ -499,13 +497,11 @@ timestamp < "2025-01-01T00:20:00+0000"`, func(logSource chan<- *loggingpb.LogEnt
 				{},
 				{LogCount: 2, Progress: float32(1) / float32(3)},
 			},
+			// The log of the later partition is buffered until the preceding partition completes, thus it's not sent after the cancellation.
 			wantLogs: []*loggingpb.LogEntry{
 				{
 					LogName: "bar", Timestamp: timestamppb.New(beginTime.Add(time.Minute * 10)),
 				},
-				{
-					LogName: "foo", Timestamp: timestamppb.New(beginTime.Add(time.Minute * 30)),
-				},
 			},
 		},
 	}
@@ -569,3 +565,132 @@ timestamp < "2025-01-01T00:20:00+0000"`, func(logSource chan<- *loggingpb.LogEnt
 		})
 	}
 }
+
+func TestTimePartitioningProgressReportableLogFetcher_SendsLogsInPartitionOrder(t *testing.T) {
+	beginTime := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	tick := 50 * time.Millisecond
+	logAt := func(name string, minutes int) *loggingpb.LogEntry {
+		return &loggingpb.LogEntry{LogName: name, Timestamp: timestamppb.New(beginTime.Add(time.Duration(minutes) * time.Minute))}
+	}
+	// The later partitions return their logs earlier than the preceding partitions.
+	fetcher := getMockFetcherFromFakeLogUpstreamPairs(t, []fakeLogUpstreamPair{
+		newFakeLogUpstreamPair(`test filter
+timestamp >= "2025-01-01T00:00:00+0000"
+timestamp < "2025-01-01T00:20:00+0000"`, func(logSource chan<- *loggingpb.LogEntry, errSource chan<- error) {
+			<-time.After(3 * tick)
+			logSource <- logAt("p0-1", 5)
+			logSource <- logAt("p0-2", 10)
+		}),
+		newFakeLogUpstreamPair(`test filter
+timestamp >= "2025-01-01T00:20:00+0000"
+timestamp < "2025-01-01T00:40:00+0000"`, func(logSource chan<- *loggingpb.LogEntry, errSource chan<- error) {
+			<-time.After(2 * tick)
+			logSource <- logAt("p1-1", 25)
+		}),
+		newFakeLogUpstreamPair(`test filter
+timestamp >= "2025-01-01T00:40:00+0000"
+timestamp < "2025-01-01T01:00:00+0000"`, func(logSource chan<- *loggingpb.LogEntry, errSource chan<- error) {
+			logSource <- logAt("p2-1", 45)
+			logSource <- logAt("p2-2", 50)
+		}),
+	})
+
+	wg := sync.WaitGroup{}
+	var logs []*loggingpb.LogEntry
+	var progresses []LogFetchProgress
+	logReceiveChan := channelToArrayParallel(t.Context(), &wg, &logs)
+	progressReceiveChan := channelToArrayParallel(t.Context(), &wg, &progresses)
+
+	progressReportableFetcher := NewTimePartitioningProgressReportableLogFetcher(fetcher, tick, 3, 3)
+	err := progressReportableFetcher.FetchLogsWithProgress(logReceiveChan, progressReceiveChan, t.Context(), beginTime, beginTime.Add(time.Hour), "test filter", googlecloud.Project("foobar"), []string{})
+	if err != nil {
+		t.Fatalf("FetchLogsWithProgress() returned unexpected error: %v", err)
+	}
+	wg.Wait()
+
+	want := []*loggingpb.LogEntry{logAt("p0-1", 5), logAt("p0-2", 10), logAt("p1-1", 25), logAt("p2-1", 45), logAt("p2-2", 50)}
+	if diff := cmp.Diff(want, logs, protocmp.Transform()); diff != "" {
+		t.Errorf("FetchLogsWithProgress() sent logs in an unexpected order (-want, +got):\n%v", diff)
+	}
+}
+
+func TestTimePartitioningProgressReportableLogFetcher_BoundsPartitionBuffers(t *testing.T) {
+	originalMaxBufferedLogs := maxBufferedLogsPerPartition
+	maxBufferedLogsPerPartition = 1
+	defer func() { maxBufferedLogsPerPartition = originalMaxBufferedLogs }()
+
+	beginTime := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	tick := 50 * time.Millisecond
+	logAt := func(name string, minutes int) *loggingpb.LogEntry {
+		return &loggingpb.LogEntry{LogName: name, Timestamp: timestamppb.New(beginTime.Add(time.Duration(minutes) * time.Minute))}
+	}
+	// The later partition returns more logs than its buffer can hold before the first partition completes.
+	fetcher := getMockFetcherFromFakeLogUpstreamPairs(t, []fakeLogUpstreamPair{
+		newFakeLogUpstreamPair(`test filter
+timestamp >= "2025-01-01T00:00:00+0000"
+timestamp < "2025-01-01T00:30:00+0000"`, func(logSource chan<- *loggingpb.LogEntry, errSource chan<- error) {
+			<-time.After(2 * tick)
+			logSource <- logAt("p0-1", 5)
+		}),
+		newFakeLogUpstreamPair(`test filter
+timestamp >= "2025-01-01T00:30:00+0000"
+timestamp < "2025-01-01T01:00:00+0000"`, func(logSource chan<- *loggingpb.LogEntry, errSource chan<- error) {
+			logSource <- logAt("p1-1", 35)
+			logSource <- logAt("p1-2", 40)
+			logSource <- logAt("p1-3", 45)
+		}),
+	})
+
+	wg := sync.WaitGroup{}
+	var logs []*loggingpb.LogEntry
+	var progresses []LogFetchProgress
+	logReceiveChan := channelToArrayParallel(t.Context(), &wg, &logs)
+	progressReceiveChan := channelToArrayParallel(t.Context(), &wg, &progresses)
+
+	progressReportableFetcher := NewTimePartitioningProgressReportableLogFetcher(fetcher, tick, 2, 2)
+	err := progressReportableFetcher.FetchLogsWithProgress(logReceiveChan, progressReceiveChan, t.Context(), beginTime, beginTime.Add(time.Hour), "test filter", googlecloud.Project("foobar"), []string{})
+	if err != nil {
+		t.Fatalf("FetchLogsWithProgress() returned unexpected error: %v", err)
+	}
+	wg.Wait()
+
+	want := []*loggingpb.LogEntry{logAt("p0-1", 5), logAt("p1-1", 35), logAt("p1-2", 40), logAt("p1-3", 45)}
+	if diff := cmp.Diff(want, logs, protocmp.Transform()); diff != "" {
+		t.Errorf("FetchLogsWithProgress() sent logs in an unexpected order (-want, +got):\n%v", diff)
+	}
+}
+
+func TestPartitionLogBuffer(t *testing.T) {
+	buffer := newPartitionLogBuffer(1)
+	first := &loggingpb.LogEntry{InsertId: "first"}
+	second := &loggingpb.LogEntry{InsertId: "second"}
+	buffer.Append(first)
+
+	appended := make(chan struct{})
+	go func() {
+		defer close(appended)
+		buffer.Append(second)
+	}()
+	select {
+	case <-appended:
+		t.Fatal("Append() must block while the buffer is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	entries, more := buffer.Take()
+	if diff := cmp.Diff([]*loggingpb.LogEntry{first}, entries, protocmp.Transform()); diff != "" || !more {
+		t.Errorf("Take() returned unexpected logs (more: %v) (-want, +got):\n%v", more, diff)
+	}
+	<-appended
+	buffer.Close()
+	entries, more = buffer.Take()
+	if diff := cmp.Diff([]*loggingpb.LogEntry{second}, entries, protocmp.Transform()); diff != "" || more {
+		t.Errorf("Take() returned unexpected logs after closing (more: %v) (-want, +got):\n%v", more, diff)
+	}
+
+	// Logs appended after closing are dropped without blocking.
+	buffer.Append(first)
+	if entries, _ := buffer.Take(); len(entries) != 0 {
+		t.Errorf("Take() returned %d logs appended after closing, want 0", len(entries))
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_contract
+
+import (
+	"context"
+
+	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	cloudresourcemanager "google.golang.org/api/cloudresourcemanager/v3"
+)
+
+// ProjectFetcher fetches the list of project IDs visible to the active credentials.
+type ProjectFetcher interface {
+	FetchProjectIDs(ctx context.Context) ([]string, error)
+}
+
+type projectFetcherImpl struct {
+	service            *cloudresourcemanager.Service
+	callOptionInjector *googlecloud.CallOptionInjector
+}
+
+// FetchProjectIDs implements ProjectFetcher.
+func (p *projectFetcherImpl) FetchProjectIDs(ctx context.Context) ([]string, error) {
+	var result []string
+	var nextPageToken string
+	for {
+		req := p.service.Projects.Search().Query("state:ACTIVE").PageToken(nextPageToken).Context(ctx)
+		// Searching projects is not bound to a specific project.
+		p.callOptionInjector.InjectToCall(req, googlecloud.Project(""))
+		resp, err := req.Do()
+		if err != nil {
+			return nil, err
+		}
+		for _, project := range resp.Projects {
+			result = append(result, project.ProjectId)
+		}
+		nextPageToken = resp.NextPageToken
+		if nextPageToken == "" {
+			break
+		}
+	}
+	return result, nil
+}
+
+func NewProjectFetcher(service *cloudresourcemanager.Service, callOptionInjector *googlecloud.CallOptionInjector) ProjectFetcher {
+	return &projectFetcherImpl{
+		service:            service,
+		callOptionInjector: callOptionInjector,
+	}
+}
+
+var _ ProjectFetcher = (*projectFetcherImpl)(nil)
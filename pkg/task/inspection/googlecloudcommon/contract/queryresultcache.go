@@ -0,0 +1,40 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_contract
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/api/googlecloud"
+)
+
+// queryResultCacheMinimumAge is the minimum duration between the end of the queried time range and now to cache the query result.
+// Cloud Logging can still ingest logs in the recent time range, so caching the result of these queries would hide the logs ingested later.
+const queryResultCacheMinimumAge = 10 * time.Minute
+
+// queryResultCacheKey returns the key of the raw log entries queried by the principal with the filter against the resource names in the time range.
+// The principal is included not to return logs cached with a principal to another principal who may not have the permission to read them.
+func queryResultCacheKey(principal string, filter string, container googlecloud.ResourceContainer, resourceNames []string, startTime, endTime time.Time) string {
+	digest := sha256.Sum256([]byte(fmt.Sprintf("%s\n%s\n%s\n%s", principal, container.Identifier(), strings.Join(resourceNames, ","), filter)))
+	return fmt.Sprintf("query-result-%x-%d-%d", digest, startTime.UnixNano(), endTime.UnixNano())
+}
+
+// isQueryResultCacheable returns true when the logs in the time range ending at endTime are not expected to change anymore.
+func isQueryResultCacheable(endTime time.Time, now time.Time) bool {
+	return !endTime.After(now.Add(-queryResultCacheMinimumAge))
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_contract
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/api/googlecloud"
+)
+
+func TestQueryResultCacheKey(t *testing.T) {
+	startTime := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	endTime := startTime.Add(time.Hour)
+	base := queryResultCacheKey("foo@example.com", "foo", googlecloud.Project("bar"), []string{"projects/bar"}, startTime, endTime)
+
+	if got := queryResultCacheKey("foo@example.com", "foo", googlecloud.Project("bar"), []string{"projects/bar"}, startTime, endTime); got != base {
+		t.Errorf("queryResultCacheKey() returned different keys for the same query: %q and %q", base, got)
+	}
+	differentQueries := map[string]string{
+		"filter":         queryResultCacheKey("foo@example.com", "qux", googlecloud.Project("bar"), []string{"projects/bar"}, startTime, endTime),
+		"resource names": queryResultCacheKey("foo@example.com", "foo", googlecloud.Project("bar"), []string{"projects/bar", "projects/baz"}, startTime, endTime),
+		"start time":     queryResultCacheKey("foo@example.com", "foo", googlecloud.Project("bar"), []string{"projects/bar"}, startTime.Add(time.Second), endTime),
+		"end time":       queryResultCacheKey("foo@example.com", "foo", googlecloud.Project("bar"), []string{"projects/bar"}, startTime, endTime.Add(time.Second)),
+		"principal":      queryResultCacheKey("bar@example.com", "foo", googlecloud.Project("bar"), []string{"projects/bar"}, startTime, endTime),
+	}
+	for name, got := range differentQueries {
+		if got == base {
+			t.Errorf("queryResultCacheKey() returned the same key for the query with a different %s", name)
+		}
+	}
+}
+
+func TestIsQueryResultCacheable(t *testing.T) {
+	now := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name    string
+		endTime time.Time
+		want    bool
+	}{
+		{name: "old enough time range", endTime: now.Add(-time.Hour), want: true},
+		{name: "time range ending at the minimum age", endTime: now.Add(-queryResultCacheMinimumAge), want: true},
+		{name: "recent time range", endTime: now.Add(-time.Minute), want: false},
+		{name: "time range ending in the future", endTime: now.Add(time.Hour), want: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isQueryResultCacheable(tc.endTime, now); got != tc.want {
+				t.Errorf("isQueryResultCacheable() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_contract
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/sync/semaphore"
+	"golang.org/x/time/rate"
+)
+
+// LoggingRateLimiter limits the Cloud Logging API requests sent from all log queries in an inspection run.
+// The nil value and the value returned from NewLoggingRateLimiter with zero limits don't limit anything.
+type LoggingRateLimiter struct {
+	limiter   *rate.Limiter
+	semaphore *semaphore.Weighted
+}
+
+// NewLoggingRateLimiter returns a LoggingRateLimiter allowing requestsPerMinute requests per minute and maxConcurrentReads requests in flight at the same time.
+// A non positive value disables the corresponding limit.
+func NewLoggingRateLimiter(requestsPerMinute int, maxConcurrentReads int) *LoggingRateLimiter {
+	result := &LoggingRateLimiter{}
+	if requestsPerMinute > 0 {
+		result.limiter = rate.NewLimiter(rate.Every(time.Minute/time.Duration(requestsPerMinute)), 1)
+	}
+	if maxConcurrentReads > 0 {
+		result.semaphore = semaphore.NewWeighted(int64(maxConcurrentReads))
+	}
+	return result
+}
+
+// Acquire blocks until a request can be sent or the context is cancelled.
+// The caller must call the returned release function after receiving the response.
+func (l *LoggingRateLimiter) Acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	if l.semaphore != nil {
+		if err := l.semaphore.Acquire(ctx, 1); err != nil {
+			return nil, err
+		}
+	}
+	release := func() {
+		if l.semaphore != nil {
+			l.semaphore.Release(1)
+		}
+	}
+	if l.limiter != nil {
+		if err := l.limiter.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+	return release, nil
+}
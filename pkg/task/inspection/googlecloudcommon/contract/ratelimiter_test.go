@@ -0,0 +1,97 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_contract
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLoggingRateLimiter_Acquire(t *testing.T) {
+	t.Run("nil limiter does not block", func(t *testing.T) {
+		var limiter *LoggingRateLimiter
+		release, err := limiter.Acquire(t.Context())
+		if err != nil {
+			t.Fatalf("Acquire() returned an unexpected error: %v", err)
+		}
+		release()
+	})
+
+	t.Run("zero limits do not block", func(t *testing.T) {
+		limiter := NewLoggingRateLimiter(0, 0)
+		for i := 0; i < 100; i++ {
+			if _, err := limiter.Acquire(t.Context()); err != nil {
+				t.Fatalf("Acquire() returned an unexpected error: %v", err)
+			}
+		}
+	})
+
+	t.Run("concurrent reads are limited until released", func(t *testing.T) {
+		limiter := NewLoggingRateLimiter(0, 2)
+		release1, err := limiter.Acquire(t.Context())
+		if err != nil {
+			t.Fatalf("Acquire() returned an unexpected error: %v", err)
+		}
+		if _, err := limiter.Acquire(t.Context()); err != nil {
+			t.Fatalf("Acquire() returned an unexpected error: %v", err)
+		}
+
+		ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+		defer cancel()
+		if _, err := limiter.Acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Acquire() over the concurrency limit returned %v, want %v", err, context.DeadlineExceeded)
+		}
+
+		release1()
+		if _, err := limiter.Acquire(t.Context()); err != nil {
+			t.Errorf("Acquire() after release returned an unexpected error: %v", err)
+		}
+	})
+
+	t.Run("requests per minute are limited", func(t *testing.T) {
+		limiter := NewLoggingRateLimiter(1, 0)
+		release, err := limiter.Acquire(t.Context())
+		if err != nil {
+			t.Fatalf("Acquire() returned an unexpected error: %v", err)
+		}
+		release()
+
+		ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+		defer cancel()
+		if _, err := limiter.Acquire(ctx); err == nil {
+			t.Errorf("Acquire() over the rate limit returned no error, want an error")
+		}
+	})
+
+	t.Run("semaphore is released when the rate limiter wait fails", func(t *testing.T) {
+		limiter := NewLoggingRateLimiter(1, 1)
+		release, err := limiter.Acquire(t.Context())
+		if err != nil {
+			t.Fatalf("Acquire() returned an unexpected error: %v", err)
+		}
+		release()
+
+		ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+		defer cancel()
+		if _, err := limiter.Acquire(ctx); err == nil {
+			t.Fatalf("Acquire() over the rate limit returned no error, want an error")
+		}
+		if !limiter.semaphore.TryAcquire(1) {
+			t.Errorf("semaphore was not released after the failed Acquire()")
+		}
+	})
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_contract
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ResourceNameValidator is implemented by LogFetcher implementations that can check a resource name is readable on Cloud Logging without fetching the logs.
+type ResourceNameValidator interface {
+	// ValidateResourceName sends a small query to the given resource name and returns the error returned from Cloud Logging.
+	ValidateResourceName(ctx context.Context, resourceName string, container googlecloud.ResourceContainer) error
+}
+
+// ValidateResourceNameWithDryRunQuery checks the given resource name with a small query and returns the hint message for the form.
+// The message is empty when the resource name is readable, the fetcher can't validate resource names or the validation failed for a transient reason.
+// The results are cached in the GlobalSharedMap not to send the same query on every dry run.
+func ValidateResourceNameWithDryRunQuery(ctx context.Context, fetcher LogFetcher, resourceName string) string {
+	validator, ok := fetcher.(ResourceNameValidator)
+	if !ok {
+		return ""
+	}
+	container, err := resourceContainerOfResourceName(resourceName)
+	if err != nil {
+		return ""
+	}
+	sharedMap := khictx.MustGetValue(ctx, inspectioncore_contract.GlobalSharedMap)
+	cacheKey := typedmap.NewTypedKey[string](fmt.Sprintf("resource-name-validation-%s", resourceName))
+	if cached, found := typedmap.Get(sharedMap, cacheKey); found {
+		return cached
+	}
+	err = validator.ValidateResourceName(ctx, resourceName, container)
+	hint := ""
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.NotFound:
+		hint = fmt.Sprintf("`%s` was not found. Please confirm the log bucket or the log view exists: %s", resourceName, status.Convert(err).Message())
+	case codes.PermissionDenied:
+		hint = fmt.Sprintf("`%s` is not accessible. Please confirm you have the permission to read logs from it (e.g. roles/logging.viewAccessor for log views): %s", resourceName, status.Convert(err).Message())
+	case codes.InvalidArgument:
+		hint = fmt.Sprintf("Cloud Logging rejected `%s`: %s", resourceName, status.Convert(err).Message())
+	default:
+		slog.WarnContext(ctx, fmt.Sprintf("skipping to validate the resource name %s: %v", resourceName, err))
+		return ""
+	}
+	typedmap.Set(sharedMap, cacheKey, hint)
+	return hint
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_contract
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type countingResourceNameValidator struct {
+	callCount    int
+	gotContainer googlecloud.ResourceContainer
+	err          error
+}
+
+// FetchLogs implements LogFetcher.
+func (c *countingResourceNameValidator) FetchLogs(dest chan<- *loggingpb.LogEntry, ctx context.Context, filter string, container googlecloud.ResourceContainer, resourceContainers []string) error {
+	close(dest)
+	return nil
+}
+
+// ValidateResourceName implements ResourceNameValidator.
+func (c *countingResourceNameValidator) ValidateResourceName(ctx context.Context, resourceName string, container googlecloud.ResourceContainer) error {
+	c.callCount++
+	c.gotContainer = container
+	return c.err
+}
+
+func TestValidateResourceNameWithDryRunQuery(t *testing.T) {
+	resourceName := "projects/foo/locations/global/buckets/bar/views/baz"
+	testCases := []struct {
+		name          string
+		err           error
+		want          string
+		wantCallCount int
+	}{
+		{
+			name:          "readable resource name",
+			want:          "",
+			wantCallCount: 1,
+		},
+		{
+			name:          "log view not found",
+			err:           status.Error(codes.NotFound, "view not found"),
+			want:          "`projects/foo/locations/global/buckets/bar/views/baz` was not found. Please confirm the log bucket or the log view exists: view not found",
+			wantCallCount: 1,
+		},
+		{
+			name:          "log view not accessible",
+			err:           status.Error(codes.PermissionDenied, "permission denied"),
+			want:          "`projects/foo/locations/global/buckets/bar/views/baz` is not accessible. Please confirm you have the permission to read logs from it (e.g. roles/logging.viewAccessor for log views): permission denied",
+			wantCallCount: 1,
+		},
+		{
+			name:          "transient failure is not cached",
+			err:           status.Error(codes.Unavailable, "unavailable"),
+			want:          "",
+			wantCallCount: 2,
+		},
+		{
+			name:          "failure without status is not cached",
+			err:           errors.New("connection refused"),
+			want:          "",
+			wantCallCount: 2,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+			validator := &countingResourceNameValidator{err: tc.err}
+			for i := 0; i < 2; i++ {
+				got := ValidateResourceNameWithDryRunQuery(ctx, validator, resourceName)
+				if got != tc.want {
+					t.Errorf("ValidateResourceNameWithDryRunQuery() = %q, want %q", got, tc.want)
+				}
+			}
+			if validator.callCount != tc.wantCallCount {
+				t.Errorf("ValidateResourceName() was called %d times, want %d", validator.callCount, tc.wantCallCount)
+			}
+			if validator.gotContainer.Identifier() != "projects/foo" {
+				t.Errorf("ValidateResourceName() was called with the container %q, want %q", validator.gotContainer.Identifier(), "projects/foo")
+			}
+		})
+	}
+}
+
+func TestValidateResourceNameWithDryRunQueryWithoutValidator(t *testing.T) {
+	ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+	got := ValidateResourceNameWithDryRunQuery(ctx, &mockLogFetcher{}, "projects/foo")
+	if got != "" {
+		t.Errorf("ValidateResourceNameWithDryRunQuery() = %q, want empty", got)
+	}
+}
@@ -18,6 +18,8 @@ import (
 	"time"
 
 	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	"github.com/kyasbal/khi/pkg/api/googlecloud/credentialsource"
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
 	"github.com/kyasbal/khi/pkg/core/task/taskid"
 	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
 )
@@ -28,26 +30,50 @@ var GoogleCloudCommonTaskIDPrefix = "cloud.google.com/common/"
 // AutocompleteLocationTaskID is the task ID for the location autocomplete.
 var AutocompleteLocationTaskID taskid.TaskImplementationID[*inspectioncore_contract.AutocompleteResult[string]] = taskid.NewDefaultImplementationID[*inspectioncore_contract.AutocompleteResult[string]](GoogleCloudCommonTaskIDPrefix + "autocomplete-location")
 
+// AutocompleteProjectIDTaskID is the task ID for the project ID autocomplete.
+var AutocompleteProjectIDTaskID = taskid.NewDefaultImplementationID[*inspectioncore_contract.AutocompleteResult[string]](GoogleCloudCommonTaskIDPrefix + "autocomplete-project-id")
+
 // Common forms over Google Cloud related packages.
 
 // InputProjectIdTaskID is the task ID for the Google Cloud project ID.
 var InputProjectIdTaskID = taskid.NewDefaultImplementationID[string](GoogleCloudCommonTaskIDPrefix + "input-project-id")
 
+// InputAdditionalProjectIDsTaskID is the task ID for the project IDs queried in addition to the default resource names of each log query, e.g. the host project of a shared VPC.
+var InputAdditionalProjectIDsTaskID = taskid.NewDefaultImplementationID[[]string](GoogleCloudCommonTaskIDPrefix + "input-additional-project-ids")
+
+// InputLogViewResourceNamesTaskID is the task ID for the log view resource names queried in addition to the default resource names of each log query. This is used to query logs aggregated in log buckets of organizations or folders.
+var InputLogViewResourceNamesTaskID = taskid.NewDefaultImplementationID[[]string](GoogleCloudCommonTaskIDPrefix + "input-log-view-resource-names")
+
+// InputAdditionalLogFilterTaskID is the task ID for the Cloud Logging filter fragment appended to every generated query. The value is empty when no filter is appended.
+var InputAdditionalLogFilterTaskID = taskid.NewDefaultImplementationID[string](GoogleCloudCommonTaskIDPrefix + "input-additional-log-filter")
+
 // InputLoggingFilterResourceNameTaskID is the task ID to get log query target resource names.
 var InputLoggingFilterResourceNameTaskID = taskid.NewDefaultImplementationID[*ResourceNamesInput](GoogleCloudCommonTaskIDPrefix + "input-logging-filter-resource-name")
 
-// InputDurationTaskID is the task ID for the duration of the log query.
-var InputDurationTaskID = taskid.NewDefaultImplementationID[time.Duration](GoogleCloudCommonTaskIDPrefix + "input-duration")
+// InputTimeRangeTaskID is the task ID for the time range of the log query.
+var InputTimeRangeTaskID = taskid.NewDefaultImplementationID[formtask.TimeRange](GoogleCloudCommonTaskIDPrefix + "input-time-range")
 
-// InputEndTimeTaskID is the task ID for the end time of the log query.
+// InputEndTimeTaskID is the task ID for the end time of the log query. This is computed from InputTimeRangeTask.
 var InputEndTimeTaskID = taskid.NewDefaultImplementationID[time.Time](GoogleCloudCommonTaskIDPrefix + "input-end-time")
 
-// InputStartTimeTaskID is the task ID for the start time of the log query. This is computed from InputDurationTask and InputEndTimeTask.
+// InputStartTimeTaskID is the task ID for the start time of the log query. This is computed from InputTimeRangeTask.
 var InputStartTimeTaskID = taskid.NewDefaultImplementationID[time.Time](GoogleCloudCommonTaskIDPrefix + "input-start-time")
 
 // InputLocationsTaskID is the task ID for the locations of the target resource.
 var InputLocationsTaskID = taskid.NewDefaultImplementationID[string](GoogleCloudCommonTaskIDPrefix + "input-location")
 
+// InputImpersonateServiceAccountTaskID is the task ID for the email of the service account impersonated for the Google Cloud API calls. The value is empty when no service account is impersonated.
+var InputImpersonateServiceAccountTaskID = taskid.NewDefaultImplementationID[string](GoogleCloudCommonTaskIDPrefix + "input-impersonate-service-account")
+
+// InputQuotaProjectIDTaskID is the task ID for the project billed for the Google Cloud API calls with the `X-Goog-User-Project` header. The value is empty when the default quota project of the credentials is used.
+var InputQuotaProjectIDTaskID = taskid.NewDefaultImplementationID[string](GoogleCloudCommonTaskIDPrefix + "input-quota-project-id")
+
+// InputCredentialSourceTaskID is the task ID for the credential source selected for the Google Cloud API calls. The value is nil when the credentials configured on the server are used.
+var InputCredentialSourceTaskID = taskid.NewDefaultImplementationID[*credentialsource.Candidate](GoogleCloudCommonTaskIDPrefix + "input-credential-source")
+
+// CredentialSourceDetectorTaskID is the task ID to inject the instance of CredentialSourceDetector.
+var CredentialSourceDetectorTaskID = taskid.NewDefaultImplementationID[CredentialSourceDetector](GoogleCloudCommonTaskIDPrefix + "credential-source-detector")
+
 // APIClientFactoryTaskID is the task ID to generate the ClientFactory. This factory is instantiated with the options generated from the task with APIClientFactoryOptionsTaskID.
 var APIClientFactoryTaskID = taskid.NewDefaultImplementationID[*googlecloud.ClientFactory](GoogleCloudCommonTaskIDPrefix + "api-client-factory")
 
@@ -60,5 +86,14 @@ var APIClientCallOptionsInjectorTaskID = taskid.NewDefaultImplementationID[*goog
 // LocationFetcherTaskID is the task ID to inject the instance of LocationFetcher.
 var LocationFetcherTaskID = taskid.NewDefaultImplementationID[LocationFetcher](GoogleCloudCommonTaskIDPrefix + "location-fetcher")
 
+// ProjectFetcherTaskID is the task ID to inject the instance of ProjectFetcher.
+var ProjectFetcherTaskID = taskid.NewDefaultImplementationID[ProjectFetcher](GoogleCloudCommonTaskIDPrefix + "project-fetcher")
+
+// LoggingRateLimiterTaskID is the task ID to inject the LoggingRateLimiter shared among the log queries in an inspection run.
+var LoggingRateLimiterTaskID = taskid.NewDefaultImplementationID[*LoggingRateLimiter](GoogleCloudCommonTaskIDPrefix + "logging-rate-limiter")
+
+// LoggingCircuitBreakerTaskID is the task ID to inject the LoggingCircuitBreaker shared among the log queries in an inspection run.
+var LoggingCircuitBreakerTaskID = taskid.NewDefaultImplementationID[*LoggingCircuitBreaker](GoogleCloudCommonTaskIDPrefix + "logging-circuit-breaker")
+
 // LoggingFetcherTaskID is the task ID to inject the instance of LogFetcher.
 var LoggingFetcherTaskID = taskid.NewDefaultImplementationID[LogFetcher](GoogleCloudCommonTaskIDPrefix + "log-fetcher")
@@ -24,12 +24,21 @@ import (
 	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
 )
 
-// APIClientFactoryTask is a task to inject googlecloud.ClientFactory to the later tasks. The instance is singleton in an inspection and the instance is cached on inspection cache after the first generation.
+// APIClientFactoryTask is a task to inject googlecloud.ClientFactory to the later tasks. The instance is cached on inspection cache after the first generation and regenerated only when the credential source, the impersonated service account or the quota project is changed.
 var APIClientFactoryTask = inspectiontaskbase.NewCachedTask(googlecloudcommon_contract.APIClientFactoryTaskID, []taskid.UntypedTaskReference{
 	googlecloudcommon_contract.APIClientFactoryOptionsTaskID.Ref(),
+	googlecloudcommon_contract.InputCredentialSourceTaskID.Ref(),
+	googlecloudcommon_contract.InputImpersonateServiceAccountTaskID.Ref(),
+	googlecloudcommon_contract.InputQuotaProjectIDTaskID.Ref(),
 }, func(ctx context.Context, prevValue inspectiontaskbase.CacheableTaskResult[*googlecloud.ClientFactory]) (inspectiontaskbase.CacheableTaskResult[*googlecloud.ClientFactory], error) {
+	// The other options are not expected to be refreshed in an inspection.
+	credentialSource := serverDefaultCredentialSourceID
+	if candidate := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputCredentialSourceTaskID.Ref()); candidate != nil {
+		credentialSource = string(candidate.Source)
+	}
+	digest := "credential=" + credentialSource + ",impersonate=" + coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputImpersonateServiceAccountTaskID.Ref()) + ",quota=" + coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputQuotaProjectIDTaskID.Ref())
 	// Use cached client if it was set already.
-	if prevValue.DependencyDigest != "" {
+	if prevValue.DependencyDigest == digest {
 		return prevValue, nil
 	}
 	opts := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientFactoryOptionsTaskID.Ref())
@@ -39,7 +48,7 @@ var APIClientFactoryTask = inspectiontaskbase.NewCachedTask(googlecloudcommon_co
 		return inspectiontaskbase.CacheableTaskResult[*googlecloud.ClientFactory]{}, err
 	}
 	return inspectiontaskbase.CacheableTaskResult[*googlecloud.ClientFactory]{
-		DependencyDigest: "singleton", // the client don't need to recreate and it's singleton because the options are not expected to be refresh.
+		DependencyDigest: digest,
 		Value:            clientFactory,
 	}, nil
 })
@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	"github.com/kyasbal/khi/pkg/api/googlecloud/credentialsource"
 	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
 	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
 	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
@@ -62,7 +63,11 @@ func TestAPIClientFactoryTask(t *testing.T) {
 		t.Run(tc.desc, func(t *testing.T) {
 			mockOptionCalledCount = 0
 			ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
-			clientFactory, _, err := inspectiontest.RunInspectionTask(ctx, APIClientFactoryTask, inspectioncore_contract.TaskModeRun, map[string]any{}, tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.APIClientFactoryOptionsTaskID.Ref(), tc.options))
+			clientFactory, _, err := inspectiontest.RunInspectionTask(ctx, APIClientFactoryTask, inspectioncore_contract.TaskModeRun, map[string]any{},
+				tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.APIClientFactoryOptionsTaskID.Ref(), tc.options),
+				tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.InputCredentialSourceTaskID.Ref(), nil),
+				tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.InputImpersonateServiceAccountTaskID.Ref(), ""),
+				tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.InputQuotaProjectIDTaskID.Ref(), ""))
 			if !tc.wantErr && err != nil {
 				t.Errorf("APIClientFactoryTask failed: %v", err)
 			}
@@ -76,7 +81,11 @@ func TestAPIClientFactoryTask(t *testing.T) {
 				t.Errorf("APIClientFactoryTask returned nil")
 			}
 
-			clientFactory2, _, err := inspectiontest.RunInspectionTask(ctx, APIClientFactoryTask, inspectioncore_contract.TaskModeRun, map[string]any{}, tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.APIClientFactoryOptionsTaskID.Ref(), tc.options))
+			clientFactory2, _, err := inspectiontest.RunInspectionTask(ctx, APIClientFactoryTask, inspectioncore_contract.TaskModeRun, map[string]any{},
+				tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.APIClientFactoryOptionsTaskID.Ref(), tc.options),
+				tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.InputCredentialSourceTaskID.Ref(), nil),
+				tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.InputImpersonateServiceAccountTaskID.Ref(), ""),
+				tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.InputQuotaProjectIDTaskID.Ref(), ""))
 			if err != nil {
 				t.Errorf("APIClientFactoryTask failed on the second time: %v", err)
 			}
@@ -90,3 +99,78 @@ func TestAPIClientFactoryTask(t *testing.T) {
 	}
 
 }
+
+func TestAPIClientFactoryTaskRecreatesFactoryOnImpersonationChange(t *testing.T) {
+	ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+	runWithServiceAccount := func(serviceAccount string) *googlecloud.ClientFactory {
+		t.Helper()
+		clientFactory, _, err := inspectiontest.RunInspectionTask(ctx, APIClientFactoryTask, inspectioncore_contract.TaskModeRun, map[string]any{},
+			tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.APIClientFactoryOptionsTaskID.Ref(), []googlecloud.ClientFactoryOption{}),
+			tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.InputCredentialSourceTaskID.Ref(), nil),
+			tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.InputImpersonateServiceAccountTaskID.Ref(), serviceAccount),
+			tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.InputQuotaProjectIDTaskID.Ref(), ""))
+		if err != nil {
+			t.Fatalf("APIClientFactoryTask failed: %v", err)
+		}
+		return clientFactory
+	}
+
+	first := runWithServiceAccount("")
+	impersonated := runWithServiceAccount("log-reader@foo-project.iam.gserviceaccount.com")
+	if first == impersonated {
+		t.Errorf("APIClientFactoryTask returned the same instance after changing the impersonated service account")
+	}
+	if got := runWithServiceAccount("log-reader@foo-project.iam.gserviceaccount.com"); got != impersonated {
+		t.Errorf("APIClientFactoryTask returned different instances for the same impersonated service account")
+	}
+}
+
+func TestAPIClientFactoryTaskRecreatesFactoryOnCredentialSourceChange(t *testing.T) {
+	ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+	runWithCredentialSource := func(credentialSource *credentialsource.Candidate) *googlecloud.ClientFactory {
+		t.Helper()
+		clientFactory, _, err := inspectiontest.RunInspectionTask(ctx, APIClientFactoryTask, inspectioncore_contract.TaskModeRun, map[string]any{},
+			tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.APIClientFactoryOptionsTaskID.Ref(), []googlecloud.ClientFactoryOption{}),
+			tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.InputCredentialSourceTaskID.Ref(), credentialSource),
+			tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.InputImpersonateServiceAccountTaskID.Ref(), ""),
+			tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.InputQuotaProjectIDTaskID.Ref(), ""))
+		if err != nil {
+			t.Fatalf("APIClientFactoryTask failed: %v", err)
+		}
+		return clientFactory
+	}
+
+	serverDefault := runWithCredentialSource(nil)
+	gcloud := runWithCredentialSource(&credentialsource.Candidate{Source: credentialsource.SourceGcloud})
+	if serverDefault == gcloud {
+		t.Errorf("APIClientFactoryTask returned the same instance after changing the credential source")
+	}
+	if got := runWithCredentialSource(&credentialsource.Candidate{Source: credentialsource.SourceGcloud}); got != gcloud {
+		t.Errorf("APIClientFactoryTask returned different instances for the same credential source")
+	}
+}
+
+func TestAPIClientFactoryTaskRecreatesFactoryOnQuotaProjectChange(t *testing.T) {
+	ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+	runWithQuotaProject := func(quotaProjectID string) *googlecloud.ClientFactory {
+		t.Helper()
+		clientFactory, _, err := inspectiontest.RunInspectionTask(ctx, APIClientFactoryTask, inspectioncore_contract.TaskModeRun, map[string]any{},
+			tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.APIClientFactoryOptionsTaskID.Ref(), []googlecloud.ClientFactoryOption{}),
+			tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.InputCredentialSourceTaskID.Ref(), nil),
+			tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.InputImpersonateServiceAccountTaskID.Ref(), ""),
+			tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.InputQuotaProjectIDTaskID.Ref(), quotaProjectID))
+		if err != nil {
+			t.Fatalf("APIClientFactoryTask failed: %v", err)
+		}
+		return clientFactory
+	}
+
+	first := runWithQuotaProject("")
+	billed := runWithQuotaProject("billing-project")
+	if first == billed {
+		t.Errorf("APIClientFactoryTask returned the same instance after changing the quota project")
+	}
+	if got := runWithQuotaProject("billing-project"); got != billed {
+		t.Errorf("APIClientFactoryTask returned different instances for the same quota project")
+	}
+}
@@ -17,8 +17,10 @@ package googlecloudcommon_impl
 import (
 	"context"
 	"errors"
+	"slices"
 
 	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	"github.com/kyasbal/khi/pkg/api/googlecloud/options"
 	"github.com/kyasbal/khi/pkg/common/khictx"
 	"github.com/kyasbal/khi/pkg/common/khierrors"
 	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
@@ -32,17 +34,36 @@ import (
 // User can extend this behavior with defining new task for googlecloudcommon_contract.APIClientFactoryOptionsTaskID with higher selection priority.
 var APIClientFactoryOptionsTask = inspectiontaskbase.NewInspectionTask(
 	googlecloudcommon_contract.APIClientFactoryOptionsTaskID,
-	[]taskid.UntypedTaskReference{},
+	[]taskid.UntypedTaskReference{
+		googlecloudcommon_contract.InputCredentialSourceTaskID.Ref(),
+		googlecloudcommon_contract.InputImpersonateServiceAccountTaskID.Ref(),
+		googlecloudcommon_contract.InputQuotaProjectIDTaskID.Ref(),
+	},
 	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) ([]googlecloud.ClientFactoryOption, error) {
-		var options []googlecloud.ClientFactoryOption
+		var clientFactoryOptions []googlecloud.ClientFactoryOption
 		optionsFromContext, err := khictx.GetValue(ctx, googlecloudcommon_contract.APIClientFactoryOptionsContextKey)
 		if err != nil && !errors.Is(err, khierrors.ErrNotFound) {
 			return nil, err
 		}
 		if optionsFromContext != nil {
-			options = *optionsFromContext
+			clientFactoryOptions = slices.Clone(*optionsFromContext)
+		}
+		// The credential source selected on the form overrides the credentials configured on the server.
+		credentialSource := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputCredentialSourceTaskID.Ref())
+		if credentialSource != nil {
+			clientFactoryOptions = append(clientFactoryOptions, options.TokenSource(credentialSource.TokenSource))
+		}
+		// The quota project given on the form is sent as the `X-Goog-User-Project` header from every client.
+		quotaProjectID := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputQuotaProjectIDTaskID.Ref())
+		if quotaProjectID != "" {
+			clientFactoryOptions = append(clientFactoryOptions, options.QuotaProject(quotaProjectID))
+		}
+		// Impersonation must be the last to use the credentials given from the other options as the source credentials.
+		serviceAccount := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputImpersonateServiceAccountTaskID.Ref())
+		if serviceAccount != "" {
+			clientFactoryOptions = append(clientFactoryOptions, options.ImpersonateServiceAccount(serviceAccount))
 		}
-		return options, nil
+		return clientFactoryOptions, nil
 	},
 	coretask.WithSelectionPriority(googlecloudcommon_contract.DefaultAPIClientOptionTasksPriority),
 )
@@ -21,9 +21,12 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	"github.com/kyasbal/khi/pkg/api/googlecloud/credentialsource"
+	"github.com/kyasbal/khi/pkg/api/googlecloud/legacy"
 	"github.com/kyasbal/khi/pkg/api/googlecloud/options"
 	coreinspection "github.com/kyasbal/khi/pkg/core/inspection"
 	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
 	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
 	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
 )
@@ -47,9 +50,12 @@ func TestAPIClientFactoryOptionsTask(t *testing.T) {
 	option2 := options.QuotaProject("bar")
 
 	testCases := []struct {
-		desc           string
-		prepareContext func(ctx context.Context) context.Context
-		wantOptions    []googlecloud.ClientFactoryOption
+		desc             string
+		prepareContext   func(ctx context.Context) context.Context
+		serviceAccount   string
+		quotaProjectID   string
+		credentialSource *credentialsource.Candidate
+		wantOptions      []googlecloud.ClientFactoryOption
 	}{
 		{
 			desc: "without options in context",
@@ -69,12 +75,45 @@ func TestAPIClientFactoryOptionsTask(t *testing.T) {
 			},
 			wantOptions: []googlecloud.ClientFactoryOption{option1, option2},
 		},
+		{
+			desc: "with impersonated service account",
+			prepareContext: func(ctx context.Context) context.Context {
+				opt1 := coreinspection.RunContextOptionArrayElementFromValue(googlecloudcommon_contract.APIClientFactoryOptionsContextKey, option1)
+				ctx, _ = opt1(ctx, inspectioncore_contract.TaskModeRun)
+				return ctx
+			},
+			serviceAccount: "log-reader@foo-project.iam.gserviceaccount.com",
+			wantOptions:    []googlecloud.ClientFactoryOption{option1, options.ImpersonateServiceAccount("log-reader@foo-project.iam.gserviceaccount.com")},
+		},
+		{
+			desc: "with quota project and impersonated service account",
+			prepareContext: func(ctx context.Context) context.Context {
+				opt1 := coreinspection.RunContextOptionArrayElementFromValue(googlecloudcommon_contract.APIClientFactoryOptionsContextKey, option1)
+				ctx, _ = opt1(ctx, inspectioncore_contract.TaskModeRun)
+				return ctx
+			},
+			quotaProjectID: "billing-project",
+			serviceAccount: "log-reader@foo-project.iam.gserviceaccount.com",
+			wantOptions:    []googlecloud.ClientFactoryOption{option1, options.QuotaProject("billing-project"), options.ImpersonateServiceAccount("log-reader@foo-project.iam.gserviceaccount.com")},
+		},
+		{
+			desc: "with credential source and impersonated service account",
+			prepareContext: func(ctx context.Context) context.Context {
+				return ctx
+			},
+			credentialSource: &credentialsource.Candidate{Source: credentialsource.SourceProvidedToken, TokenSource: legacy.NewRawTokenTokenSource("foo")},
+			serviceAccount:   "log-reader@foo-project.iam.gserviceaccount.com",
+			wantOptions:      []googlecloud.ClientFactoryOption{options.TokenSource(legacy.NewRawTokenTokenSource("foo")), options.ImpersonateServiceAccount("log-reader@foo-project.iam.gserviceaccount.com")},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.desc, func(t *testing.T) {
 			ctx := tc.prepareContext(context.Background())
 			ctx = inspectiontest.WithDefaultTestInspectionTaskContext(ctx)
-			gotOptions, _, err := inspectiontest.RunInspectionTask(ctx, APIClientFactoryOptionsTask, inspectioncore_contract.TaskModeRun, map[string]any{})
+			gotOptions, _, err := inspectiontest.RunInspectionTask(ctx, APIClientFactoryOptionsTask, inspectioncore_contract.TaskModeRun, map[string]any{},
+				tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.InputCredentialSourceTaskID.Ref(), tc.credentialSource),
+				tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.InputImpersonateServiceAccountTaskID.Ref(), tc.serviceAccount),
+				tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.InputQuotaProjectIDTaskID.Ref(), tc.quotaProjectID))
 			if err != nil {
 				t.Fatalf("APIClientFactoryOptionsTask failed: %v", err)
 			}
@@ -17,6 +17,7 @@ package googlecloudcommon_impl
 import (
 	"context"
 	"fmt"
+	"time"
 
 	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
 	coretask "github.com/kyasbal/khi/pkg/core/task"
@@ -25,39 +26,48 @@ import (
 	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
 )
 
+// autocompleteLocationWaitTimeout is the duration to wait for the location list before returning the cached list to the form.
+const autocompleteLocationWaitTimeout = 500 * time.Millisecond
+
+// autocompleteLocationFetchTimeout is the deadline of fetching the location list in background.
+const autocompleteLocationFetchTimeout = 30 * time.Second
+
 // AutocompleteLocationTask is a task that provides a list of available locations for autocomplete.
-var AutocompleteLocationTask = inspectiontaskbase.NewCachedTask(googlecloudcommon_contract.AutocompleteLocationTaskID,
+// The list contains the regions followed by the zones available in the project.
+// The list is fetched in background not to block the dry run on slow networks.
+var AutocompleteLocationTask = inspectiontaskbase.NewAsyncAutocompleteTask(googlecloudcommon_contract.AutocompleteLocationTaskID,
 	[]taskid.UntypedTaskReference{
 		googlecloudcommon_contract.InputProjectIdTaskID.Ref(), // for API restriction
 		googlecloudcommon_contract.LocationFetcherTaskID.Ref(),
 	},
-	func(ctx context.Context, prevValue inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[string]]) (inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[string]], error) {
+	autocompleteLocationWaitTimeout,
+	autocompleteLocationFetchTimeout,
+	func(ctx context.Context) (string, error) {
 		projectID := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputProjectIdTaskID.Ref())
-		dependencyDigest := fmt.Sprintf("location-%s", projectID)
-
-		if prevValue.DependencyDigest == dependencyDigest {
-			return prevValue, nil
-		}
-
-		defaultResult := inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[string]]{
-			DependencyDigest: dependencyDigest,
-			Value: &inspectioncore_contract.AutocompleteResult[string]{
-				Values: []string{},
-				Error:  "",
-				Hint:   "",
-			},
+		return fmt.Sprintf("location-%s", projectID), nil
+	},
+	func(ctx context.Context) (*inspectioncore_contract.AutocompleteResult[string], error) {
+		projectID := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputProjectIdTaskID.Ref())
+		result := &inspectioncore_contract.AutocompleteResult[string]{
+			Values: []string{},
+			Error:  "",
+			Hint:   "",
 		}
-
 		if projectID == "" {
-			return defaultResult, nil
+			return result, nil
 		}
 
 		locationFetcher := coretask.GetTaskResult(ctx, googlecloudcommon_contract.LocationFetcherTaskID.Ref())
 		regions, err := locationFetcher.FetchRegions(ctx, projectID)
 		if err != nil {
-			return defaultResult, nil
+			return result, nil
+		}
+		result.Values = regions
+		zones, err := locationFetcher.FetchZones(ctx, projectID)
+		if err != nil {
+			// Regions are still useful for the suggestion even when zones are not available.
+			return result, nil
 		}
-		result := defaultResult
-		result.Value.Values = regions
+		result.Values = append(result.Values, zones...)
 		return result, nil
 	})
@@ -0,0 +1,66 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_impl
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/parameters"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// autocompleteProjectIDWaitTimeout is the duration to wait for the project list before returning the cached list to the form.
+const autocompleteProjectIDWaitTimeout = 500 * time.Millisecond
+
+// autocompleteProjectIDFetchTimeout is the deadline of fetching the project list in background.
+const autocompleteProjectIDFetchTimeout = 30 * time.Second
+
+// AutocompleteProjectIDTask is a task that provides a list of project IDs visible to the active credentials for autocomplete.
+// The list is fetched only once in an inspection because it doesn't depend on any form input.
+var AutocompleteProjectIDTask = inspectiontaskbase.NewAsyncAutocompleteTask(googlecloudcommon_contract.AutocompleteProjectIDTaskID,
+	[]taskid.UntypedTaskReference{
+		googlecloudcommon_contract.ProjectFetcherTaskID.Ref(),
+	},
+	autocompleteProjectIDWaitTimeout,
+	autocompleteProjectIDFetchTimeout,
+	func(ctx context.Context) (string, error) {
+		return "project-id", nil
+	},
+	func(ctx context.Context) (*inspectioncore_contract.AutocompleteResult[string], error) {
+		result := &inspectioncore_contract.AutocompleteResult[string]{
+			Values: []string{},
+			Error:  "",
+			Hint:   "",
+		}
+		// Users can't choose the other projects when the project ID is fixed.
+		if parameters.Auth.FixedProjectID != nil && *parameters.Auth.FixedProjectID != "" {
+			return result, nil
+		}
+
+		projectFetcher := coretask.GetTaskResult(ctx, googlecloudcommon_contract.ProjectFetcherTaskID.Ref())
+		projectIDs, err := projectFetcher.FetchProjectIDs(ctx)
+		if err != nil {
+			return result, nil
+		}
+		slices.Sort(projectIDs)
+		result.Values = projectIDs
+		return result, nil
+	})
@@ -16,10 +16,13 @@ package googlecloudcommon_impl
 
 import (
 	"context"
+	"sync"
 
 	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	"github.com/kyasbal/khi/pkg/api/googlecloud/credentialsource"
 	coretask "github.com/kyasbal/khi/pkg/core/task"
 	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/parameters"
 	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
 )
 
@@ -36,15 +39,74 @@ var LocationFetcherTask = coretask.NewTask(googlecloudcommon_contract.LocationFe
 	if err != nil {
 		return nil, err
 	}
-	return googlecloudcommon_contract.NewLocationFetcher(regionClient, callOptionInjector), nil
+	zoneClient, err := clientFactory.ZonesClient(ctx, googlecloud.Project(projectID))
+	if err != nil {
+		return nil, err
+	}
+	return googlecloudcommon_contract.NewLocationFetcher(regionClient, zoneClient, callOptionInjector), nil
+})
+
+// ProjectFetcherTask is the task to inject the reference to ProjectFetcher.
+var ProjectFetcherTask = coretask.NewTask(googlecloudcommon_contract.ProjectFetcherTaskID, []taskid.UntypedTaskReference{
+	googlecloudcommon_contract.APIClientFactoryTaskID.Ref(),
+	googlecloudcommon_contract.APIClientCallOptionsInjectorTaskID.Ref(),
+}, func(ctx context.Context) (googlecloudcommon_contract.ProjectFetcher, error) {
+	clientFactory := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientFactoryTaskID.Ref())
+	callOptionInjector := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientCallOptionsInjectorTaskID.Ref())
+	service, err := clientFactory.ResourceManagerService(ctx, googlecloud.Project(""))
+	if err != nil {
+		return nil, err
+	}
+	return googlecloudcommon_contract.NewProjectFetcher(service, callOptionInjector), nil
+})
+
+// credentialSourceDetector is shared among inspections to reuse the detection result cached in the detector.
+var credentialSourceDetector = sync.OnceValue(func() *credentialsource.Detector {
+	accessToken := ""
+	if parameters.Auth.AccessToken != nil {
+		accessToken = *parameters.Auth.AccessToken
+	}
+	return credentialsource.NewDetector(accessToken)
+})
+
+// CredentialSourceDetectorTask is the task to inject the reference to CredentialSourceDetector.
+var CredentialSourceDetectorTask = coretask.NewTask(googlecloudcommon_contract.CredentialSourceDetectorTaskID, []taskid.UntypedTaskReference{}, func(ctx context.Context) (googlecloudcommon_contract.CredentialSourceDetector, error) {
+	return credentialSourceDetector(), nil
+})
+
+// LoggingRateLimiterTask is a task to inject the LoggingRateLimiter configured with the server parameters.
+// This task runs once in an inspection run, thus the limit is shared among all the log queries in the run.
+var LoggingRateLimiterTask = coretask.NewTask(googlecloudcommon_contract.LoggingRateLimiterTaskID, []taskid.UntypedTaskReference{}, func(ctx context.Context) (*googlecloudcommon_contract.LoggingRateLimiter, error) {
+	requestsPerMinute := 0
+	if parameters.Common.CloudLoggingMaxRequestsPerMinute != nil {
+		requestsPerMinute = *parameters.Common.CloudLoggingMaxRequestsPerMinute
+	}
+	maxConcurrentReads := 0
+	if parameters.Common.CloudLoggingMaxConcurrentReads != nil {
+		maxConcurrentReads = *parameters.Common.CloudLoggingMaxConcurrentReads
+	}
+	return googlecloudcommon_contract.NewLoggingRateLimiter(requestsPerMinute, maxConcurrentReads), nil
+})
+
+// loggingCircuitBreakerFailureThreshold is the count of Cloud Logging failures in series for a resource container stopping the remaining log queries to the container in an inspection run.
+const loggingCircuitBreakerFailureThreshold = 3
+
+// LoggingCircuitBreakerTask is a task to inject the LoggingCircuitBreaker.
+// This task runs once in an inspection run, thus repeated failures in a log query stop the other log queries to the same resource container in the run.
+var LoggingCircuitBreakerTask = coretask.NewTask(googlecloudcommon_contract.LoggingCircuitBreakerTaskID, []taskid.UntypedTaskReference{}, func(ctx context.Context) (*googlecloudcommon_contract.LoggingCircuitBreaker, error) {
+	return googlecloudcommon_contract.NewLoggingCircuitBreaker(loggingCircuitBreakerFailureThreshold), nil
 })
 
 // LoggingFetcherTask is a task to inject the reference to LogFetcher.
 var LoggingFetcherTask = coretask.NewTask(googlecloudcommon_contract.LoggingFetcherTaskID, []taskid.UntypedTaskReference{
 	googlecloudcommon_contract.APIClientFactoryTaskID.Ref(),
 	googlecloudcommon_contract.APIClientCallOptionsInjectorTaskID.Ref(),
+	googlecloudcommon_contract.LoggingRateLimiterTaskID.Ref(),
+	googlecloudcommon_contract.LoggingCircuitBreakerTaskID.Ref(),
 }, func(ctx context.Context) (googlecloudcommon_contract.LogFetcher, error) {
 	clientFactory := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientFactoryTaskID.Ref())
 	callOptionInjector := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientCallOptionsInjectorTaskID.Ref())
-	return googlecloudcommon_contract.NewLogFetcher(clientFactory, callOptionInjector, 1000), nil
+	rateLimiter := coretask.GetTaskResult(ctx, googlecloudcommon_contract.LoggingRateLimiterTaskID.Ref())
+	circuitBreaker := coretask.GetTaskResult(ctx, googlecloudcommon_contract.LoggingCircuitBreakerTaskID.Ref())
+	return googlecloudcommon_contract.NewLogFetcher(clientFactory, callOptionInjector, 1000, rateLimiter, circuitBreaker), nil
 })
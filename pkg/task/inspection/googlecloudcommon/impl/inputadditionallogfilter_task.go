@@ -0,0 +1,55 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_impl
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+)
+
+// InputAdditionalLogFilterTask defines a form task for inputting a Cloud Logging filter fragment appended to every generated query.
+// The filter is validated with a small query against the project before the inspection runs, because a broken filter would make all the queries fail.
+var InputAdditionalLogFilterTask = formtask.NewTextFormTaskBuilder(googlecloudcommon_contract.InputAdditionalLogFilterTaskID, 0, "Additional log filter").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionAdvancedQuery}).
+	WithDependencies([]taskid.UntypedTaskReference{googlecloudcommon_contract.InputProjectIdTaskID.Ref(), googlecloudcommon_contract.LoggingFetcherTaskID.Ref()}).
+	WithPlaceholder(`e.g. -resource.labels.namespace_name="noisy-namespace"`).
+	WithDescription("A Cloud Logging filter fragment appended to every query with AND. Use this to exclude noisy logs or to narrow down the logs, e.g. to a node. Leave this empty not to change the queries.").
+	WithValidatingTiming(inspectionmetadata.Blur).
+	WithDefaultValueFunc(func(ctx context.Context, previousValues []string) (string, error) {
+		if len(previousValues) > 0 {
+			return previousValues[0], nil
+		}
+		return "", nil
+	}).
+	WithValidator(func(ctx context.Context, value string) (string, error) {
+		filter := strings.TrimSpace(value)
+		projectID := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputProjectIdTaskID.Ref())
+		if filter == "" || projectID == "" {
+			return "", nil
+		}
+		fetcher := coretask.GetTaskResult(ctx, googlecloudcommon_contract.LoggingFetcherTaskID.Ref())
+		return googlecloudcommon_contract.ValidateLogFilterWithDryRunQuery(ctx, fetcher, filter, googlecloud.Project(projectID)), nil
+	}).
+	WithConverter(func(ctx context.Context, value string) (string, error) {
+		return strings.TrimSpace(value), nil
+	}).
+	Build()
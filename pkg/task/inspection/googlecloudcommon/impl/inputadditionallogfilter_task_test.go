@@ -0,0 +1,108 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_impl
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	form_task_test "github.com/kyasbal/khi/pkg/core/inspection/formtask/test"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeLogFilterValidator is a LogFetcher rejecting the filters containing "invalid".
+type fakeLogFilterValidator struct{}
+
+// FetchLogs implements googlecloudcommon_contract.LogFetcher.
+func (f *fakeLogFilterValidator) FetchLogs(dest chan<- *loggingpb.LogEntry, ctx context.Context, filter string, container googlecloud.ResourceContainer, resourceContainers []string) error {
+	close(dest)
+	return nil
+}
+
+// ValidateLogFilter implements googlecloudcommon_contract.LogFilterValidator.
+func (f *fakeLogFilterValidator) ValidateLogFilter(ctx context.Context, filter string, container googlecloud.ResourceContainer) error {
+	if filter == "invalid" {
+		return status.Error(codes.InvalidArgument, "unparseable filter")
+	}
+	return nil
+}
+
+func TestInputAdditionalLogFilterTask(t *testing.T) {
+	wantDescription := "A Cloud Logging filter fragment appended to every query with AND. Use this to exclude noisy logs or to narrow down the logs, e.g. to a node. Leave this empty not to change the queries."
+	wantPlaceholder := `e.g. -resource.labels.namespace_name="noisy-namespace"`
+	projectIDTask := tasktest.StubTaskFromReferenceID(googlecloudcommon_contract.InputProjectIdTaskID.Ref(), "foo-project", nil)
+	fetcherTask := tasktest.StubTaskFromReferenceID[googlecloudcommon_contract.LogFetcher](googlecloudcommon_contract.LoggingFetcherTaskID.Ref(), &fakeLogFilterValidator{}, nil)
+	form_task_test.TestTextForms(t, "additional-log-filter", InputAdditionalLogFilterTask, []*form_task_test.TextFormTestCase{
+		{
+			Name:          "empty value",
+			Input:         "",
+			ExpectedValue: "",
+			Dependencies:  []coretask.UntypedTask{projectIDTask, fetcherTask},
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					ID:          googlecloudcommon_contract.InputAdditionalLogFilterTaskID.ReferenceIDString(),
+					Type:        inspectionmetadata.Text,
+					Label:       "Additional log filter",
+					Description: wantDescription,
+					HintType:    inspectionmetadata.None,
+				},
+				ValidationTiming: inspectionmetadata.Blur,
+				Placeholder:      wantPlaceholder,
+			},
+		},
+		{
+			Name:          "filter accepted by Cloud Logging",
+			Input:         ` -resource.labels.namespace_name="noisy" `,
+			ExpectedValue: `-resource.labels.namespace_name="noisy"`,
+			Dependencies:  []coretask.UntypedTask{projectIDTask, fetcherTask},
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					ID:          googlecloudcommon_contract.InputAdditionalLogFilterTaskID.ReferenceIDString(),
+					Type:        inspectionmetadata.Text,
+					Label:       "Additional log filter",
+					Description: wantDescription,
+					HintType:    inspectionmetadata.None,
+				},
+				ValidationTiming: inspectionmetadata.Blur,
+				Placeholder:      wantPlaceholder,
+			},
+		},
+		{
+			Name:          "filter rejected by Cloud Logging",
+			Input:         "invalid",
+			ExpectedValue: "",
+			Dependencies:  []coretask.UntypedTask{projectIDTask, fetcherTask},
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					ID:          googlecloudcommon_contract.InputAdditionalLogFilterTaskID.ReferenceIDString(),
+					Type:        inspectionmetadata.Text,
+					Label:       "Additional log filter",
+					Description: wantDescription,
+					HintType:    inspectionmetadata.Error,
+					Hint:        "Cloud Logging rejected the filter: unparseable filter",
+				},
+				ValidationTiming: inspectionmetadata.Blur,
+				Placeholder:      wantPlaceholder,
+			},
+		},
+	})
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_impl
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+)
+
+// InputAdditionalProjectIDsTask defines a form task for inputting the project IDs queried in addition to the main project.
+// This is needed for architectures storing related logs in different projects, e.g. network logs stored in the host project of a shared VPC.
+var InputAdditionalProjectIDsTask = formtask.NewTextFormTaskBuilder(googlecloudcommon_contract.InputAdditionalProjectIDsTaskID, 0, "Additional project IDs").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionResourceIdentifier, After: []string{googlecloudcommon_contract.InputProjectIdTaskID.ReferenceIDString()}}).
+	WithPlaceholder("e.g. shared-vpc-host-project other-project").
+	WithDescription("Space or comma separated project IDs queried in addition to the project above, e.g. the host project of a shared VPC. Leave this empty to query only the project above.").
+	WithValidatingTiming(inspectionmetadata.Blur).
+	WithDefaultValueFunc(func(ctx context.Context, previousValues []string) (string, error) {
+		if len(previousValues) > 0 {
+			return previousValues[0], nil
+		}
+		return "", nil
+	}).
+	WithValidator(func(ctx context.Context, value string) (string, error) {
+		for _, projectID := range splitProjectIDs(value) {
+			if !projectIdValidator.MatchString(projectID) {
+				return fmt.Sprintf("Project ID `%s` must match `^*[0-9a-z\\.:\\-]+$`", projectID), nil
+			}
+		}
+		return "", nil
+	}).
+	WithConverter(func(ctx context.Context, value string) ([]string, error) {
+		result := []string{}
+		for _, projectID := range splitProjectIDs(value) {
+			if !slices.Contains(result, projectID) {
+				result = append(result, projectID)
+			}
+		}
+		return result, nil
+	}).
+	Build()
+
+// splitProjectIDs splits the given space or comma separated project IDs.
+func splitProjectIDs(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n'
+	})
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_impl
+
+import (
+	"testing"
+
+	form_task_test "github.com/kyasbal/khi/pkg/core/inspection/formtask/test"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+)
+
+func TestInputAdditionalProjectIDsTask(t *testing.T) {
+	wantDescription := "Space or comma separated project IDs queried in addition to the project above, e.g. the host project of a shared VPC. Leave this empty to query only the project above."
+	wantPlaceholder := "e.g. shared-vpc-host-project other-project"
+	form_task_test.TestTextForms(t, "additional-project-ids", InputAdditionalProjectIDsTask, []*form_task_test.TextFormTestCase{
+		{
+			Name:          "empty value",
+			Input:         "",
+			ExpectedValue: []string{},
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					ID:          googlecloudcommon_contract.InputAdditionalProjectIDsTaskID.ReferenceIDString(),
+					Type:        inspectionmetadata.Text,
+					Label:       "Additional project IDs",
+					Description: wantDescription,
+					HintType:    inspectionmetadata.None,
+				},
+				ValidationTiming: inspectionmetadata.Blur,
+				Placeholder:      wantPlaceholder,
+			},
+		},
+		{
+			Name:          "space and comma separated project IDs",
+			Input:         " host-project, service-project  host-project",
+			ExpectedValue: []string{"host-project", "service-project"},
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					ID:          googlecloudcommon_contract.InputAdditionalProjectIDsTaskID.ReferenceIDString(),
+					Type:        inspectionmetadata.Text,
+					Label:       "Additional project IDs",
+					Description: wantDescription,
+					HintType:    inspectionmetadata.None,
+				},
+				ValidationTiming: inspectionmetadata.Blur,
+				Placeholder:      wantPlaceholder,
+			},
+		},
+		{
+			Name:          "invalid project ID",
+			Input:         "host-project Invalid_Project",
+			ExpectedValue: []string{},
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					ID:          googlecloudcommon_contract.InputAdditionalProjectIDsTaskID.ReferenceIDString(),
+					Type:        inspectionmetadata.Text,
+					Label:       "Additional project IDs",
+					Description: wantDescription,
+					HintType:    inspectionmetadata.Error,
+					Hint:        "Project ID `Invalid_Project` must match `^*[0-9a-z\\.:\\-]+$`",
+				},
+				ValidationTiming: inspectionmetadata.Blur,
+				Placeholder:      wantPlaceholder,
+			},
+		},
+	})
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_impl
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyasbal/khi/pkg/api/googlecloud/credentialsource"
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/parameters"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+)
+
+// serverDefaultCredentialSourceID is the option ID to use the credentials configured on the server without overriding them.
+const serverDefaultCredentialSourceID = "default"
+
+var credentialSourceLabels = map[credentialsource.Source]string{
+	credentialsource.SourceADC:            "Application Default Credentials",
+	credentialsource.SourceGcloud:         "gcloud CLI",
+	credentialsource.SourceMetadataServer: "Metadata server",
+	credentialsource.SourceProvidedToken:  "Access token given to KHI",
+}
+
+// InputCredentialSourceTask defines a form task to select the source of the credentials used for the Google Cloud API calls among the sources detected on the machine running KHI.
+var InputCredentialSourceTask = formtask.NewSelectFormTaskBuilder(googlecloudcommon_contract.InputCredentialSourceTaskID, 0, "Credential source").
+	WithDependencies([]taskid.UntypedTaskReference{googlecloudcommon_contract.CredentialSourceDetectorTaskID.Ref()}).
+	WithPosition(inspectionmetadata.FormPosition{
+		Section: googlecloudcommon_contract.FormSectionResourceIdentifier,
+		After:   []string{googlecloudcommon_contract.InputProjectIdTaskID.ReferenceIDString()},
+		Before:  []string{googlecloudcommon_contract.InputImpersonateServiceAccountTaskID.ReferenceIDString()},
+	}).
+	WithDescription("The source of the credentials used to call Google Cloud APIs. The sources available on the machine running KHI are listed with the identity used for queries.").
+	WithDefaultValueConstant(serverDefaultCredentialSourceID, true).
+	WithOptionsFunc(func(ctx context.Context, previousValues []string) ([]formtask.SelectFormOption[*credentialsource.Candidate], error) {
+		options := []formtask.SelectFormOption[*credentialsource.Candidate]{
+			{
+				ID:          serverDefaultCredentialSourceID,
+				Label:       "Server default",
+				Description: "Use the credentials configured on the KHI server",
+				Value:       nil,
+			},
+		}
+		// The credentials of the machine running KHI must not be exposed to the users signing in with their own accounts.
+		if parameters.Auth.OAuthEnabled() {
+			return options, nil
+		}
+		detector := coretask.GetTaskResult(ctx, googlecloudcommon_contract.CredentialSourceDetectorTaskID.Ref())
+		for _, candidate := range detector.Detect(ctx) {
+			description := "The principal couldn't be determined"
+			if candidate.Principal != "" {
+				description = candidate.Principal
+			}
+			options = append(options, formtask.SelectFormOption[*credentialsource.Candidate]{
+				ID:          string(candidate.Source),
+				Label:       credentialSourceLabels[candidate.Source],
+				Description: description,
+				Value:       &candidate,
+			})
+		}
+		return options, nil
+	}).
+	WithHintFunc(func(ctx context.Context, value string, convertedValue any) (string, inspectionmetadata.ParameterHintType, error) {
+		candidate, ok := convertedValue.(*credentialsource.Candidate)
+		if !ok || candidate == nil {
+			return "", inspectionmetadata.None, nil
+		}
+		if candidate.Principal == "" {
+			return "The identity of the selected credential source couldn't be determined. Queries may fail when it lacks the permissions.", inspectionmetadata.Warning, nil
+		}
+		return fmt.Sprintf("Queries will be sent as `%s`", candidate.Principal), inspectionmetadata.Info, nil
+	}).
+	Build()
@@ -0,0 +1,142 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_impl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/api/googlecloud/credentialsource"
+	"github.com/kyasbal/khi/pkg/api/googlecloud/legacy"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	"github.com/kyasbal/khi/pkg/parameters"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+type mockCredentialSourceDetector struct {
+	candidates []credentialsource.Candidate
+}
+
+// Detect implements googlecloudcommon_contract.CredentialSourceDetector.
+func (m *mockCredentialSourceDetector) Detect(ctx context.Context) []credentialsource.Candidate {
+	return m.candidates
+}
+
+var _ googlecloudcommon_contract.CredentialSourceDetector = (*mockCredentialSourceDetector)(nil)
+
+func TestInputCredentialSourceTask(t *testing.T) {
+	detector := &mockCredentialSourceDetector{
+		candidates: []credentialsource.Candidate{
+			{Source: credentialsource.SourceGcloud, Principal: "user@example.com", TokenSource: legacy.NewRawTokenTokenSource("foo")},
+			{Source: credentialsource.SourceMetadataServer, TokenSource: legacy.NewRawTokenTokenSource("bar")},
+		},
+	}
+	testCases := []struct {
+		desc          string
+		input         string
+		oauthEnabled  bool
+		wantSource    credentialsource.Source
+		wantOptionIDs []string
+		wantHint      string
+		wantHintType  inspectionmetadata.ParameterHintType
+	}{
+		{
+			desc:          "server default",
+			input:         "default",
+			wantOptionIDs: []string{"default", "gcloud", "metadata-server"},
+			wantHintType:  inspectionmetadata.None,
+		},
+		{
+			desc:          "detected source with the principal",
+			input:         "gcloud",
+			wantSource:    credentialsource.SourceGcloud,
+			wantOptionIDs: []string{"default", "gcloud", "metadata-server"},
+			wantHint:      "Queries will be sent as `user@example.com`",
+			wantHintType:  inspectionmetadata.Info,
+		},
+		{
+			desc:          "detected source without the principal",
+			input:         "metadata-server",
+			wantSource:    credentialsource.SourceMetadataServer,
+			wantOptionIDs: []string{"default", "gcloud", "metadata-server"},
+			wantHint:      "The identity of the selected credential source couldn't be determined. Queries may fail when it lacks the permissions.",
+			wantHintType:  inspectionmetadata.Warning,
+		},
+		{
+			desc:          "detected sources are hidden when OAuth is enabled",
+			input:         "default",
+			oauthEnabled:  true,
+			wantOptionIDs: []string{"default"},
+			wantHintType:  inspectionmetadata.None,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.oauthEnabled {
+				value := "foo"
+				path := "/oauth/callback"
+				parameters.Auth.OAuthClientID = &value
+				parameters.Auth.OAuthClientSecret = &value
+				parameters.Auth.OAuthRedirectURI = &value
+				parameters.Auth.OAuthRedirectTargetServingPath = &path
+				defer func() {
+					parameters.Auth.OAuthClientID = nil
+					parameters.Auth.OAuthClientSecret = nil
+					parameters.Auth.OAuthRedirectURI = nil
+					parameters.Auth.OAuthRedirectTargetServingPath = nil
+				}()
+			}
+			ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+			got, metadata, err := inspectiontest.RunInspectionTask(ctx, InputCredentialSourceTask, inspectioncore_contract.TaskModeDryRun, map[string]any{
+				googlecloudcommon_contract.InputCredentialSourceTaskID.ReferenceIDString(): tc.input,
+			}, tasktest.NewTaskDependencyValuePair[googlecloudcommon_contract.CredentialSourceDetector](googlecloudcommon_contract.CredentialSourceDetectorTaskID.Ref(), detector))
+			if err != nil {
+				t.Fatalf("RunInspectionTask() returned an unexpected error: %v", err)
+			}
+
+			var gotSource credentialsource.Source
+			if got != nil {
+				gotSource = got.Source
+			}
+			if gotSource != tc.wantSource {
+				t.Errorf("got source %q, want %q", gotSource, tc.wantSource)
+			}
+
+			formFields, found := typedmap.Get(metadata, inspectionmetadata.FormFieldSetMetadataKey)
+			if !found {
+				t.Fatalf("form field metadata not found")
+			}
+			field, ok := formFields.DangerouslyGetField(googlecloudcommon_contract.InputCredentialSourceTaskID.ReferenceIDString()).(inspectionmetadata.SelectParameterFormField)
+			if !ok {
+				t.Fatalf("the generated form is not a SelectParameterFormField")
+			}
+			gotOptionIDs := []string{}
+			for _, option := range field.Options {
+				gotOptionIDs = append(gotOptionIDs, option.ID)
+			}
+			if diff := cmp.Diff(tc.wantOptionIDs, gotOptionIDs); diff != "" {
+				t.Errorf("options mismatch (-want +got):\n%s", diff)
+			}
+			if field.Hint != tc.wantHint || field.HintType != tc.wantHintType {
+				t.Errorf("got hint %q(%s), want %q(%s)", field.Hint, field.HintType, tc.wantHint, tc.wantHintType)
+			}
+		})
+	}
+}
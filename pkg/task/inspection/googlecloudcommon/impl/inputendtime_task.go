@@ -16,55 +16,19 @@ package googlecloudcommon_impl
 
 import (
 	"context"
-	"fmt"
 	"time"
 
-	"github.com/kyasbal/khi/pkg/common"
-	"github.com/kyasbal/khi/pkg/common/khictx"
-	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
-	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
 	coretask "github.com/kyasbal/khi/pkg/core/task"
 	"github.com/kyasbal/khi/pkg/core/task/taskid"
 	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
 	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
 )
 
-// InputEndTimeTask defines a form task to input the end time for log queries.
-var InputEndTimeTask = formtask.NewTextFormTaskBuilder(googlecloudcommon_contract.InputEndTimeTaskID, googlecloudcommon_contract.PriorityForQueryTimeGroup+5000, "End time").
-	WithDependencies([]taskid.UntypedTaskReference{
-		inspectioncore_contract.TimeZoneShiftInputTaskID.Ref(),
-	}).
-	WithDescription(`The endtime of query. Please input it in the format of RFC3339
-(example: 2006-01-02T15:04:05-07:00)`).
-	WithSuggestionsFunc(func(ctx context.Context, value string, previousValues []string) ([]string, error) {
-		return previousValues, nil
-	}).
-	WithDefaultValueFunc(func(ctx context.Context, previousValues []string) (string, error) {
-		if len(previousValues) > 0 {
-			return previousValues[0], nil
-		}
-		creationTime := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionCreationTime)
-		timezoneShift := coretask.GetTaskResult(ctx, inspectioncore_contract.TimeZoneShiftInputTaskID.Ref())
-
-		return creationTime.In(timezoneShift).Format(time.RFC3339), nil
-	}).
-	WithHintFunc(func(ctx context.Context, value string, convertedValue any) (string, inspectionmetadata.ParameterHintType, error) {
-		creationTime := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionCreationTime)
-
-		specifiedTime := convertedValue.(time.Time)
-		if creationTime.Sub(specifiedTime) < 0 {
-			return fmt.Sprintf("Specified time `%s` is pointing the future. Please make sure if you specified the right value", value), inspectionmetadata.Warning, nil
-		}
-		return "", inspectionmetadata.Info, nil
-	}).
-	WithValidator(func(ctx context.Context, value string) (string, error) {
-		_, err := common.ParseTime(value)
-		if err != nil {
-			return "invalid time format. Please specify in the format of `2006-01-02T15:04:05-07:00`(RFC3339)", nil
-		}
-		return "", nil
-	}).
-	WithConverter(func(ctx context.Context, value string) (time.Time, error) {
-		return common.ParseTime(value)
-	}).
-	Build()
+// InputEndTimeTask returns the end time of the time range given from InputTimeRangeTask.
+var InputEndTimeTask = inspectiontaskbase.NewInspectionTask(googlecloudcommon_contract.InputEndTimeTaskID, []taskid.UntypedTaskReference{
+	googlecloudcommon_contract.InputTimeRangeTaskID.Ref(),
+}, func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (time.Time, error) {
+	timeRange := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputTimeRangeTaskID.Ref())
+	return timeRange.End, nil
+})
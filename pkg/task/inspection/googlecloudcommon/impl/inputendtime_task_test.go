@@ -15,79 +15,29 @@
 package googlecloudcommon_impl
 
 import (
+	"context"
 	"testing"
 	"time"
 
-	form_task_test "github.com/kyasbal/khi/pkg/core/inspection/formtask/test"
-	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
-	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
 	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
-	inspectioncore_impl "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/impl"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
 )
 
-func TestInputEndtime(t *testing.T) {
-	expectedDescription := "The endtime of query. Please input it in the format of RFC3339\n(example: 2006-01-02T15:04:05-07:00)"
-	expectedLabel := "End time"
-	expectedValue1, err := time.Parse(time.RFC3339, "2025-01-01T01:01:01Z")
-	if err != nil {
-		t.Errorf("unexpected error\n%s", err)
-	}
-	expectedValue2, err := time.Parse(time.RFC3339, "2020-01-02T00:00:00Z")
-	timezoneTaskUTC := tasktest.StubTask(inspectioncore_impl.TimeZoneShiftInputTask, time.UTC, nil)
-	timezoneTaskJST := tasktest.StubTask(inspectioncore_impl.TimeZoneShiftInputTask, time.FixedZone("", 9*3600), nil)
+func TestInputEndTime(t *testing.T) {
+	endTime := time.Date(2023, time.January, 2, 15, 45, 0, 0, time.UTC)
+	timeRange := formtask.TimeRange{Start: endTime.Add(-time.Hour), End: endTime}
 
+	ctx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
+	result, _, err := inspectiontest.RunInspectionTask(ctx, InputEndTimeTask, inspectioncore_contract.TaskModeDryRun, map[string]any{},
+		tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.InputTimeRangeTaskID.Ref(), timeRange),
+	)
 	if err != nil {
-		t.Errorf("unexpected error\n%s", err)
+		t.Errorf("unexpected error\n%v", err)
+	}
+	if !result.Equal(endTime) {
+		t.Errorf("returned time is not matching with the expected value\n%s", result)
 	}
-	form_task_test.TestTextForms(t, "endtime", InputEndTimeTask, []*form_task_test.TextFormTestCase{
-		{
-			Name:          "with empty",
-			Input:         "",
-			ExpectedValue: expectedValue1,
-			Dependencies:  []coretask.UntypedTask{timezoneTaskUTC},
-			ExpectedFormField: inspectionmetadata.TextParameterFormField{
-				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
-					Label:       expectedLabel,
-					Description: expectedDescription,
-					Hint:        "invalid time format. Please specify in the format of `2006-01-02T15:04:05-07:00`(RFC3339)",
-					HintType:    inspectionmetadata.Error,
-				},
-				Default:          "2025-01-01T01:01:01Z",
-				Suggestions:      []string{},
-				ValidationTiming: inspectionmetadata.Change,
-			},
-		},
-		{
-			Name:          "with valid timestamp and UTC timezone",
-			Input:         "2020-01-02T00:00:00Z",
-			ExpectedValue: expectedValue2,
-			Dependencies:  []coretask.UntypedTask{timezoneTaskUTC},
-			ExpectedFormField: inspectionmetadata.TextParameterFormField{
-				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
-					Label:       expectedLabel,
-					Description: expectedDescription,
-					HintType:    inspectionmetadata.None,
-				},
-				Suggestions:      []string{},
-				Default:          "2025-01-01T01:01:01Z",
-				ValidationTiming: inspectionmetadata.Change,
-			},
-		},
-		{
-			Name:          "with valid timestamp and non UTC timezone",
-			Input:         "2020-01-02T00:00:00Z",
-			ExpectedValue: expectedValue2,
-			Dependencies:  []coretask.UntypedTask{timezoneTaskJST},
-			ExpectedFormField: inspectionmetadata.TextParameterFormField{
-				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
-					Label:       expectedLabel,
-					Description: expectedDescription,
-					HintType:    inspectionmetadata.None,
-				},
-				Suggestions:      []string{},
-				Default:          "2025-01-01T10:01:01+09:00",
-				ValidationTiming: inspectionmetadata.Change,
-			},
-		},
-	})
 }
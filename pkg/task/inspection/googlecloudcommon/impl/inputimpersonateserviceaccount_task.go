@@ -0,0 +1,54 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_impl
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	"github.com/kyasbal/khi/pkg/parameters"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+)
+
+var serviceAccountEmailValidator = regexp.MustCompile(`^\s*[^\s@]+@[^\s@]+\.gserviceaccount\.com\s*$`)
+
+// InputImpersonateServiceAccountTask defines a form task for inputting the service account impersonated for the Google Cloud API calls.
+var InputImpersonateServiceAccountTask = formtask.NewTextFormTaskBuilder(googlecloudcommon_contract.InputImpersonateServiceAccountTaskID, 0, "Impersonate service account").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionResourceIdentifier, After: []string{googlecloudcommon_contract.InputProjectIdTaskID.ReferenceIDString()}}).
+	WithPlaceholder("e.g. log-reader@my-project.iam.gserviceaccount.com").
+	WithDescription("The email of the service account impersonated to call Google Cloud APIs. Leave this empty to use your own credentials. You need `roles/iam.serviceAccountTokenCreator` on the service account.").
+	WithValidatingTiming(inspectionmetadata.Blur).
+	WithDefaultValueFunc(func(ctx context.Context, previousValues []string) (string, error) {
+		if len(previousValues) > 0 {
+			return previousValues[0], nil
+		}
+		if parameters.Auth.ImpersonateServiceAccount != nil {
+			return *parameters.Auth.ImpersonateServiceAccount, nil
+		}
+		return "", nil
+	}).
+	WithValidator(func(ctx context.Context, value string) (string, error) {
+		if strings.TrimSpace(value) != "" && !serviceAccountEmailValidator.MatchString(value) {
+			return "Service account must be an email ending with `.gserviceaccount.com`", nil
+		}
+		return "", nil
+	}).
+	WithConverter(func(ctx context.Context, value string) (string, error) {
+		return strings.TrimSpace(value), nil
+	}).
+	Build()
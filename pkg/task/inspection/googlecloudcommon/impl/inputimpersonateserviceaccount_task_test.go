@@ -0,0 +1,104 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_impl
+
+import (
+	"testing"
+
+	form_task_test "github.com/kyasbal/khi/pkg/core/inspection/formtask/test"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	"github.com/kyasbal/khi/pkg/parameters"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+)
+
+func TestInputImpersonateServiceAccountTask(t *testing.T) {
+	wantDescription := "The email of the service account impersonated to call Google Cloud APIs. Leave this empty to use your own credentials. You need `roles/iam.serviceAccountTokenCreator` on the service account."
+	wantPlaceholder := "e.g. log-reader@my-project.iam.gserviceaccount.com"
+	form_task_test.TestTextForms(t, "impersonate-service-account", InputImpersonateServiceAccountTask, []*form_task_test.TextFormTestCase{
+		{
+			Name:          "empty value",
+			Input:         "",
+			ExpectedValue: "",
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					ID:          googlecloudcommon_contract.InputImpersonateServiceAccountTaskID.ReferenceIDString(),
+					Type:        inspectionmetadata.Text,
+					Label:       "Impersonate service account",
+					Description: wantDescription,
+					HintType:    inspectionmetadata.None,
+				},
+				ValidationTiming: inspectionmetadata.Blur,
+				Placeholder:      wantPlaceholder,
+			},
+		},
+		{
+			Name:          "valid service account with spaces",
+			Input:         "  log-reader@foo-project.iam.gserviceaccount.com ",
+			ExpectedValue: "log-reader@foo-project.iam.gserviceaccount.com",
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					ID:          googlecloudcommon_contract.InputImpersonateServiceAccountTaskID.ReferenceIDString(),
+					Type:        inspectionmetadata.Text,
+					Label:       "Impersonate service account",
+					Description: wantDescription,
+					HintType:    inspectionmetadata.None,
+				},
+				ValidationTiming: inspectionmetadata.Blur,
+				Placeholder:      wantPlaceholder,
+			},
+		},
+		{
+			Name:          "invalid service account",
+			Input:         "log-reader@example.com",
+			ExpectedValue: "",
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					ID:          googlecloudcommon_contract.InputImpersonateServiceAccountTaskID.ReferenceIDString(),
+					Type:        inspectionmetadata.Text,
+					Label:       "Impersonate service account",
+					Description: wantDescription,
+					HintType:    inspectionmetadata.Error,
+					Hint:        "Service account must be an email ending with `.gserviceaccount.com`",
+				},
+				ValidationTiming: inspectionmetadata.Blur,
+				Placeholder:      wantPlaceholder,
+			},
+		},
+		{
+			Name:          "default value from the parameter",
+			Input:         "",
+			ExpectedValue: "",
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					ID:          googlecloudcommon_contract.InputImpersonateServiceAccountTaskID.ReferenceIDString(),
+					Type:        inspectionmetadata.Text,
+					Label:       "Impersonate service account",
+					Description: wantDescription,
+					HintType:    inspectionmetadata.None,
+				},
+				Default:          "log-reader@foo-project.iam.gserviceaccount.com",
+				ValidationTiming: inspectionmetadata.Blur,
+				Placeholder:      wantPlaceholder,
+			},
+			Before: func() {
+				serviceAccount := "log-reader@foo-project.iam.gserviceaccount.com"
+				parameters.Auth.ImpersonateServiceAccount = &serviceAccount
+			},
+			After: func() {
+				parameters.Auth.ImpersonateServiceAccount = nil
+			},
+		},
+	})
+}
@@ -16,18 +16,22 @@ package googlecloudcommon_impl
 
 import (
 	"context"
+	"fmt"
 	"slices"
 
 	"github.com/kyasbal/khi/pkg/common"
 	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
 	coretask "github.com/kyasbal/khi/pkg/core/task"
 	"github.com/kyasbal/khi/pkg/core/task/taskid"
 	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
 )
 
 // InputLocationsTask defines a form task for inputting the resource location.
-var InputLocationsTask = formtask.NewTextFormTaskBuilder(googlecloudcommon_contract.InputLocationsTaskID, googlecloudcommon_contract.PriorityForResourceIdentifierGroup+3000, "Location").
+var InputLocationsTask = formtask.NewTextFormTaskBuilder(googlecloudcommon_contract.InputLocationsTaskID, 0, "Location").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionResourceIdentifier, After: []string{googlecloudcommon_contract.InputProjectIdTaskID.ReferenceIDString()}}).
 	WithDependencies([]taskid.UntypedTaskReference{googlecloudcommon_contract.AutocompleteLocationTaskID.Ref()}).
+	WithPlaceholder("e.g. us-central1").
 	WithDescription(
 		"The location(region) to specify the resource exist(s|ed)",
 	).
@@ -45,6 +49,18 @@ var InputLocationsTask = formtask.NewTextFormTaskBuilder(googlecloudcommon_contr
 		regions := coretask.GetTaskResult(ctx, googlecloudcommon_contract.AutocompleteLocationTaskID.Ref())
 		return common.SortForAutocomplete(value, regions.Values), nil
 	}).
+	WithSuggestionsLoadingFunc(func(ctx context.Context) (bool, error) {
+		regions := coretask.GetTaskResult(ctx, googlecloudcommon_contract.AutocompleteLocationTaskID.Ref())
+		return regions.Loading, nil
+	}).
+	WithHintFunc(func(ctx context.Context, value string, convertedValue any) (string, inspectionmetadata.ParameterHintType, error) {
+		locations := coretask.GetTaskResult(ctx, googlecloudcommon_contract.AutocompleteLocationTaskID.Ref())
+		// Skip the check until the list of locations is available.
+		if value == "" || locations.Loading || len(locations.Values) == 0 || slices.Contains(locations.Values, value) {
+			return "", inspectionmetadata.None, nil
+		}
+		return fmt.Sprintf("Location '%s' was not found in the available locations of the project. Make sure the location name is right.", value), inspectionmetadata.Warning, nil
+	}).
 	WithValidator(func(ctx context.Context, value string) (string, error) {
 		if value == "" {
 			return "location is required", nil
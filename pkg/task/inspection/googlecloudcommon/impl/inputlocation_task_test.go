@@ -32,6 +32,11 @@ func TestLocationInput(t *testing.T) {
 			Values: []string{"asia-northeast1", "us-central1"},
 		}, nil
 	})
+	mockAutocompleteLocationsWithZonesTask := coretask.NewTask(googlecloudcommon_contract.AutocompleteLocationTaskID, []taskid.UntypedTaskReference{}, func(ctx context.Context) (*inspectioncore_contract.AutocompleteResult[string], error) {
+		return &inspectioncore_contract.AutocompleteResult[string]{
+			Values: []string{"asia-northeast1", "us-central1", "asia-northeast1-a"},
+		}, nil
+	})
 	form_task_test.TestTextForms(t, "gcp-location", InputLocationsTask, []*form_task_test.TextFormTestCase{
 		{
 			Name:          "With valid location",
@@ -51,6 +56,7 @@ func TestLocationInput(t *testing.T) {
 				},
 				Readonly:         false,
 				ValidationTiming: inspectionmetadata.Change,
+				Placeholder:      "e.g. us-central1",
 				Default:          "asia-northeast1",
 			},
 		},
@@ -65,13 +71,60 @@ func TestLocationInput(t *testing.T) {
 					Type:        "Text",
 					Label:       "Location",
 					Description: "The location(region) to specify the resource exist(s|ed)",
-					HintType:    inspectionmetadata.None,
+					Hint:        "Location 'us' was not found in the available locations of the project. Make sure the location name is right.",
+					HintType:    inspectionmetadata.Warning,
 				},
 				Suggestions: []string{
 					"us-central1", "asia-northeast1",
 				},
 				Readonly:         false,
 				ValidationTiming: inspectionmetadata.Change,
+				Placeholder:      "e.g. us-central1",
+				Default:          "asia-northeast1",
+			},
+		},
+		{
+			Name:          "With location not found in the project",
+			Input:         "asia-northeast9",
+			ExpectedValue: "asia-northeast9",
+			Dependencies:  []coretask.UntypedTask{mockAutocompleteLocationsTask, InputProjectIdTask},
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					ID:          googlecloudcommon_contract.GoogleCloudCommonTaskIDPrefix + "input-location",
+					Type:        "Text",
+					Label:       "Location",
+					Description: "The location(region) to specify the resource exist(s|ed)",
+					Hint:        "Location 'asia-northeast9' was not found in the available locations of the project. Make sure the location name is right.",
+					HintType:    inspectionmetadata.Warning,
+				},
+				Suggestions: []string{
+					"asia-northeast1", "us-central1",
+				},
+				Readonly:         false,
+				ValidationTiming: inspectionmetadata.Change,
+				Placeholder:      "e.g. us-central1",
+				Default:          "asia-northeast1",
+			},
+		},
+		{
+			Name:          "With zone",
+			Input:         "asia-northeast1-a",
+			ExpectedValue: "asia-northeast1-a",
+			Dependencies:  []coretask.UntypedTask{mockAutocompleteLocationsWithZonesTask, InputProjectIdTask},
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					ID:          googlecloudcommon_contract.GoogleCloudCommonTaskIDPrefix + "input-location",
+					Type:        "Text",
+					Label:       "Location",
+					Description: "The location(region) to specify the resource exist(s|ed)",
+					HintType:    inspectionmetadata.None,
+				},
+				Suggestions: []string{
+					"asia-northeast1-a", "asia-northeast1", "us-central1",
+				},
+				Readonly:         false,
+				ValidationTiming: inspectionmetadata.Change,
+				Placeholder:      "e.g. us-central1",
 				Default:          "asia-northeast1",
 			},
 		},
@@ -35,7 +35,10 @@ var resourceNamesInputKey = typedmap.NewTypedKey[*googlecloudcommon_contract.Res
 
 // InputLoggingFilterResourceNameTask defines an inspection task that creates a form group
 // for overriding log filter resource names for advanced users.
-var InputLoggingFilterResourceNameTask = inspectiontaskbase.NewInspectionTask(googlecloudcommon_contract.InputLoggingFilterResourceNameTaskID, []taskid.UntypedTaskReference{}, func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (*googlecloudcommon_contract.ResourceNamesInput, error) {
+// On dry run, the resource names given by the user are also checked against Cloud Logging to show a hint when a log bucket or a log view doesn't exist or isn't accessible.
+var InputLoggingFilterResourceNameTask = inspectiontaskbase.NewInspectionTask(googlecloudcommon_contract.InputLoggingFilterResourceNameTaskID, []taskid.UntypedTaskReference{
+	googlecloudcommon_contract.LoggingFetcherTaskID.Ref(),
+}, func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (*googlecloudcommon_contract.ResourceNamesInput, error) {
 	// Tasks requiring active resource names can change, so we always retrieve current tasks that need resource names from the task graph.
 	taskRunner := khictx.MustGetValue(ctx, inspectioncore_contract.TaskRunner)
 	currentActiveResourceNameInputRequests := getCurrentActiveQueryIDsForResourceName(taskRunner)
@@ -50,6 +53,7 @@ var InputLoggingFilterResourceNameTask = inspectiontaskbase.NewInspectionTask(go
 	}
 
 	requestInput := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionTaskInput)
+	fetcher := coretask.GetTaskResult(ctx, googlecloudcommon_contract.LoggingFetcherTaskID.Ref())
 
 	queryForms := []inspectionmetadata.ParameterFormField{}
 	for _, request := range currentActiveResourceNameInputRequests {
@@ -77,6 +81,12 @@ var InputLoggingFilterResourceNameTask = inspectiontaskbase.NewInspectionTask(go
 					break
 				}
 			}
+			if formFieldBase.HintType == inspectionmetadata.None && taskMode == inspectioncore_contract.TaskModeDryRun {
+				if hint := validateResourceNamesWithLogging(ctx, fetcher, resourceNamesFromInput, queryInfo.DefaultResourceNames); hint != "" {
+					formFieldBase.HintType = inspectionmetadata.Warning
+					formFieldBase.Hint = hint
+				}
+			}
 		}
 		queryForms = append(queryForms, &inspectionmetadata.TextParameterFormField{
 			ParameterFormFieldBase: formFieldBase,
@@ -108,6 +118,21 @@ var InputLoggingFilterResourceNameTask = inspectiontaskbase.NewInspectionTask(go
 	return resourceNamesInput, nil
 })
 
+// validateResourceNamesWithLogging checks the resource names given by the user with small queries to Cloud Logging and returns the hint for the first problematic one.
+// The default resource names are not checked because these are generated from the other parameters.
+func validateResourceNamesWithLogging(ctx context.Context, fetcher googlecloudcommon_contract.LogFetcher, resourceNames []string, defaultResourceNames []string) string {
+	for _, resourceName := range resourceNames {
+		resourceName = strings.TrimSpace(resourceName)
+		if slices.Contains(defaultResourceNames, resourceName) {
+			continue
+		}
+		if hint := googlecloudcommon_contract.ValidateResourceNameWithDryRunQuery(ctx, fetcher, resourceName); hint != "" {
+			return hint
+		}
+	}
+	return ""
+}
+
 // getCurrentActiveQueryIDsForResourceName returns the query IDs that are currently active with retrieving them from the current task graph.
 func getCurrentActiveQueryIDsForResourceName(runner coretask.TaskRunner) []string {
 	tasks := runner.Tasks()
@@ -16,17 +16,23 @@ package googlecloudcommon_impl
 
 import (
 	"context"
+	"strings"
 	"testing"
 
+	"cloud.google.com/go/logging/apiv2/loggingpb"
 	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/api/googlecloud"
 	"github.com/kyasbal/khi/pkg/common/khictx"
 	"github.com/kyasbal/khi/pkg/common/typedmap"
 	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
 	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
 	coretask "github.com/kyasbal/khi/pkg/core/task"
 	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
 	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
 	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type mockTaskRunner struct {
@@ -49,7 +55,7 @@ func (m *mockTaskRunner) Run(ctx context.Context) error {
 }
 
 // Wait implements coretask.TaskRunner.
-func (m *mockTaskRunner) Wait() <-chan interface{} {
+func (m *mockTaskRunner) Wait() <-chan coretask.TaskRunnerTerminalState {
 	panic("unimplemented")
 }
 
@@ -59,6 +65,23 @@ func (m *mockTaskRunner) Tasks() []coretask.UntypedTask {
 
 var _ coretask.TaskRunner = (*mockTaskRunner)(nil)
 
+// fakeResourceNameValidator is a LogFetcher reporting the resource names containing "missing" as not found.
+type fakeResourceNameValidator struct{}
+
+// FetchLogs implements googlecloudcommon_contract.LogFetcher.
+func (f *fakeResourceNameValidator) FetchLogs(dest chan<- *loggingpb.LogEntry, ctx context.Context, filter string, container googlecloud.ResourceContainer, resourceContainers []string) error {
+	close(dest)
+	return nil
+}
+
+// ValidateResourceName implements googlecloudcommon_contract.ResourceNameValidator.
+func (f *fakeResourceNameValidator) ValidateResourceName(ctx context.Context, resourceName string, container googlecloud.ResourceContainer) error {
+	if strings.Contains(resourceName, "missing") {
+		return status.Error(codes.NotFound, "view not found")
+	}
+	return nil
+}
+
 func TestInputLoggingFilterResourceNameTask(t *testing.T) {
 	defaultNames := []string{"projects/foo"}
 	t1 := coretask.NewTask(taskid.NewDefaultImplementationID[struct{}]("t1"), nil, nil, coretask.WithLabelValue(
@@ -137,6 +160,71 @@ func TestInputLoggingFilterResourceNameTask(t *testing.T) {
 				CollapsedByDefault: true,
 			},
 		},
+		{
+			desc:       "log view not found",
+			taskMode:   inspectioncore_contract.TaskModeDryRun,
+			inputValue: "projects/foo projects/foo/locations/global/buckets/missing/views/_AllLogs",
+			tasks:      []coretask.UntypedTask{t1, nonRelatedTask},
+			wantForm: inspectionmetadata.GroupParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					Priority:    -1000000,
+					ID:          googlecloudcommon_contract.InputLoggingFilterResourceNameTaskID.ReferenceIDString(),
+					Type:        inspectionmetadata.Group,
+					Label:       "Logging filter resource names (advanced)",
+					Description: "Override these parameters when your logs are not on the same project of the cluster, or customize the log filter target resources.",
+					HintType:    inspectionmetadata.None,
+					Hint:        "",
+				},
+				Children: []inspectionmetadata.ParameterFormField{
+					&inspectionmetadata.TextParameterFormField{
+						ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+							ID:       "cloud.google.com/common/input-query-resource-names/test",
+							Type:     "text",
+							Label:    "test",
+							HintType: "warning",
+							Hint:     "`projects/foo/locations/global/buckets/missing/views/_AllLogs` was not found. Please confirm the log bucket or the log view exists: view not found",
+						},
+						Default:          "projects/foo",
+						Suggestions:      []string{"projects/foo"},
+						ValidationTiming: "change",
+					},
+				},
+				Collapsible:        true,
+				CollapsedByDefault: true,
+			},
+		},
+		{
+			desc:       "log view is not validated in run mode",
+			taskMode:   inspectioncore_contract.TaskModeRun,
+			inputValue: "projects/foo/locations/global/buckets/missing/views/_AllLogs",
+			tasks:      []coretask.UntypedTask{t1, nonRelatedTask},
+			wantForm: inspectionmetadata.GroupParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					Priority:    -1000000,
+					ID:          googlecloudcommon_contract.InputLoggingFilterResourceNameTaskID.ReferenceIDString(),
+					Type:        inspectionmetadata.Group,
+					Label:       "Logging filter resource names (advanced)",
+					Description: "Override these parameters when your logs are not on the same project of the cluster, or customize the log filter target resources.",
+					HintType:    inspectionmetadata.None,
+					Hint:        "",
+				},
+				Children: []inspectionmetadata.ParameterFormField{
+					&inspectionmetadata.TextParameterFormField{
+						ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+							ID:       "cloud.google.com/common/input-query-resource-names/test",
+							Type:     "text",
+							Label:    "test",
+							HintType: "none",
+						},
+						Default:          "projects/foo",
+						Suggestions:      []string{"projects/foo"},
+						ValidationTiming: "change",
+					},
+				},
+				Collapsible:        true,
+				CollapsedByDefault: true,
+			},
+		},
 		{
 			desc:       "basic input for run mode",
 			taskMode:   inspectioncore_contract.TaskModeRun,
@@ -190,11 +278,12 @@ func TestInputLoggingFilterResourceNameTask(t *testing.T) {
 			},
 		},
 	}
+	fetcher := tasktest.NewTaskDependencyValuePair[googlecloudcommon_contract.LogFetcher](googlecloudcommon_contract.LoggingFetcherTaskID.Ref(), &fakeResourceNameValidator{})
 	for _, tc := range testCases {
 		t.Run(tc.desc, func(t *testing.T) {
 			ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
 			ctx = khictx.WithValue[coretask.TaskRunner](ctx, inspectioncore_contract.TaskRunner, &mockTaskRunner{tasks: tc.tasks})
-			resourceNames, _, err := inspectiontest.RunInspectionTask(ctx, InputLoggingFilterResourceNameTask, inspectioncore_contract.TaskModeDryRun, map[string]any{})
+			resourceNames, _, err := inspectiontest.RunInspectionTask(ctx, InputLoggingFilterResourceNameTask, inspectioncore_contract.TaskModeDryRun, map[string]any{}, fetcher)
 			if err != nil {
 				t.Fatalf("Failed to call InputLoggingFilterResourceNameTask at 1st time:%v", err)
 			}
@@ -206,7 +295,7 @@ func TestInputLoggingFilterResourceNameTask(t *testing.T) {
 			resourceNames.UpdateDefaultResourceNamesForQuery("test", defaultNames)
 			_, metadata, err := inspectiontest.RunInspectionTask(newCtx, InputLoggingFilterResourceNameTask, tc.taskMode, map[string]any{
 				resourceName.GetInputID(): tc.inputValue,
-			})
+			}, fetcher)
 			if err != nil {
 				t.Fatalf("Failed to call InputLoggingFilterResourceNameTask at 2nd time:%v", err)
 			}
@@ -0,0 +1,70 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_impl
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+)
+
+// InputLogViewResourceNamesTask defines a form task for inputting the log views queried in addition to the default resource names.
+// Many organizations route their logs into a centralized log bucket of an organization or a folder, and the project scoped resource names can't find these logs.
+var InputLogViewResourceNamesTask = formtask.NewTextFormTaskBuilder(googlecloudcommon_contract.InputLogViewResourceNamesTaskID, 0, "Log views").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionResourceIdentifier, After: []string{googlecloudcommon_contract.InputAdditionalProjectIDsTaskID.ReferenceIDString()}}).
+	WithPlaceholder("e.g. folders/123456/locations/global/buckets/central-logs/views/_AllLogs").
+	WithDescription("Space separated log view resource names queried in addition to the projects above. Use this when the logs are routed to a centralized log bucket of an organization or a folder.").
+	WithValidatingTiming(inspectionmetadata.Blur).
+	WithDefaultValueFunc(func(ctx context.Context, previousValues []string) (string, error) {
+		if len(previousValues) > 0 {
+			return previousValues[0], nil
+		}
+		return "", nil
+	}).
+	WithValidator(func(ctx context.Context, value string) (string, error) {
+		for _, resourceName := range strings.Fields(value) {
+			if err := validateLogViewResourceName(resourceName); err != nil {
+				return fmt.Sprintf("%s: %s", resourceName, err.Error()), nil
+			}
+		}
+		return "", nil
+	}).
+	WithConverter(func(ctx context.Context, value string) ([]string, error) {
+		result := []string{}
+		for _, resourceName := range strings.Fields(value) {
+			if !slices.Contains(result, resourceName) {
+				result = append(result, resourceName)
+			}
+		}
+		return result, nil
+	}).
+	Build()
+
+// validateLogViewResourceName validates the given resource name is a log view usable as a resource name of entries.list.
+func validateLogViewResourceName(resourceName string) error {
+	if err := googlecloud.ValidateResourceNameOnLogEntriesList(resourceName); err != nil {
+		return err
+	}
+	if strings.Count(resourceName, "/") != 7 {
+		return fmt.Errorf("resource name must be a log view in the format of `**/**/locations/**/buckets/**/views/**`")
+	}
+	return nil
+}
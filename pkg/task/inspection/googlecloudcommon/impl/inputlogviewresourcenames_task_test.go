@@ -0,0 +1,96 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_impl
+
+import (
+	"testing"
+
+	form_task_test "github.com/kyasbal/khi/pkg/core/inspection/formtask/test"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+)
+
+func TestInputLogViewResourceNamesTask(t *testing.T) {
+	wantDescription := "Space separated log view resource names queried in addition to the projects above. Use this when the logs are routed to a centralized log bucket of an organization or a folder."
+	wantPlaceholder := "e.g. folders/123456/locations/global/buckets/central-logs/views/_AllLogs"
+	form_task_test.TestTextForms(t, "log-view-resource-names", InputLogViewResourceNamesTask, []*form_task_test.TextFormTestCase{
+		{
+			Name:          "empty value",
+			Input:         "",
+			ExpectedValue: []string{},
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					ID:          googlecloudcommon_contract.InputLogViewResourceNamesTaskID.ReferenceIDString(),
+					Type:        inspectionmetadata.Text,
+					Label:       "Log views",
+					Description: wantDescription,
+					HintType:    inspectionmetadata.None,
+				},
+				ValidationTiming: inspectionmetadata.Blur,
+				Placeholder:      wantPlaceholder,
+			},
+		},
+		{
+			Name:          "organization and folder log views",
+			Input:         " organizations/123/locations/global/buckets/central/views/gke  folders/456/locations/us-central1/buckets/central/views/_AllLogs",
+			ExpectedValue: []string{"organizations/123/locations/global/buckets/central/views/gke", "folders/456/locations/us-central1/buckets/central/views/_AllLogs"},
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					ID:          googlecloudcommon_contract.InputLogViewResourceNamesTaskID.ReferenceIDString(),
+					Type:        inspectionmetadata.Text,
+					Label:       "Log views",
+					Description: wantDescription,
+					HintType:    inspectionmetadata.None,
+				},
+				ValidationTiming: inspectionmetadata.Blur,
+				Placeholder:      wantPlaceholder,
+			},
+		},
+		{
+			Name:          "resource name not pointing a log view",
+			Input:         "folders/456",
+			ExpectedValue: []string{},
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					ID:          googlecloudcommon_contract.InputLogViewResourceNamesTaskID.ReferenceIDString(),
+					Type:        inspectionmetadata.Text,
+					Label:       "Log views",
+					Description: wantDescription,
+					HintType:    inspectionmetadata.Error,
+					Hint:        "folders/456: resource name must be a log view in the format of `**/**/locations/**/buckets/**/views/**`",
+				},
+				ValidationTiming: inspectionmetadata.Blur,
+				Placeholder:      wantPlaceholder,
+			},
+		},
+		{
+			Name:          "log view with misplaced segment",
+			Input:         "folders/456/locations/global/views/central/buckets/gke",
+			ExpectedValue: []string{},
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					ID:          googlecloudcommon_contract.InputLogViewResourceNamesTaskID.ReferenceIDString(),
+					Type:        inspectionmetadata.Text,
+					Label:       "Log views",
+					Description: wantDescription,
+					HintType:    inspectionmetadata.Error,
+					Hint:        "folders/456/locations/global/views/central/buckets/gke: resource name must be in the format of `**/**/locations/**/buckets/**/views/**` but `buckets` wasn't placed in the right place.",
+				},
+				ValidationTiming: inspectionmetadata.Blur,
+				Placeholder:      wantPlaceholder,
+			},
+		},
+	})
+}
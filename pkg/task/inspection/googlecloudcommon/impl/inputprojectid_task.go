@@ -19,8 +19,11 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/kyasbal/khi/pkg/common"
 	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
 	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
 	"github.com/kyasbal/khi/pkg/parameters"
 	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
 )
@@ -28,7 +31,10 @@ import (
 var projectIdValidator = regexp.MustCompile(`^\s*[0-9a-z\.:\-]+\s*$`)
 
 // InputProjectIdTask defines a form task for inputting the Google Cloud project ID.
-var InputProjectIdTask = formtask.NewTextFormTaskBuilder(googlecloudcommon_contract.InputProjectIdTaskID, googlecloudcommon_contract.PriorityForResourceIdentifierGroup+5000, "Project ID").
+var InputProjectIdTask = formtask.NewTextFormTaskBuilder(googlecloudcommon_contract.InputProjectIdTaskID, 0, "Project ID").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionResourceIdentifier}).
+	WithDependencies([]taskid.UntypedTaskReference{googlecloudcommon_contract.AutocompleteProjectIDTaskID.Ref()}).
+	WithPlaceholder("e.g. my-project-id").
 	WithDescription("The project ID containing logs of the cluster to query").
 	WithValidatingTiming(inspectionmetadata.Blur).
 	WithValidator(func(ctx context.Context, value string) (string, error) {
@@ -43,6 +49,9 @@ var InputProjectIdTask = formtask.NewTextFormTaskBuilder(googlecloudcommon_contr
 		}
 		return *parameters.Auth.FixedProjectID != "", nil
 	}).
+	WithReadonlyReasonFunc(func(ctx context.Context) (inspectionmetadata.ReadonlyReasonType, string, error) {
+		return inspectionmetadata.ReadonlyReasonServerConfiguration, "--fixed-project-id (KHI_FIXED_PROJECT_ID)", nil
+	}).
 	WithDefaultValueFunc(func(ctx context.Context, previousValues []string) (string, error) {
 		if parameters.Auth.FixedProjectID != nil && *parameters.Auth.FixedProjectID != "" {
 			return *parameters.Auth.FixedProjectID, nil
@@ -52,6 +61,14 @@ var InputProjectIdTask = formtask.NewTextFormTaskBuilder(googlecloudcommon_contr
 		}
 		return "", nil
 	}).
+	WithSuggestionsFunc(func(ctx context.Context, value string, previousValues []string) ([]string, error) {
+		projectIDs := coretask.GetTaskResult(ctx, googlecloudcommon_contract.AutocompleteProjectIDTaskID.Ref())
+		return common.SortForAutocomplete(value, projectIDs.Values), nil
+	}).
+	WithSuggestionsLoadingFunc(func(ctx context.Context) (bool, error) {
+		projectIDs := coretask.GetTaskResult(ctx, googlecloudcommon_contract.AutocompleteProjectIDTaskID.Ref())
+		return projectIDs.Loading, nil
+	}).
 	WithConverter(func(ctx context.Context, value string) (string, error) {
 		return strings.TrimSpace(value), nil
 	}).
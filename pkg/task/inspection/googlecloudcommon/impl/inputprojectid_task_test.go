@@ -15,22 +15,31 @@
 package googlecloudcommon_impl
 
 import (
+	"context"
 	"testing"
 
 	form_task_test "github.com/kyasbal/khi/pkg/core/inspection/formtask/test"
 	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
 	"github.com/kyasbal/khi/pkg/parameters"
 	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
 )
 
 func TestProjectIdInput(t *testing.T) {
 	wantDescription := "The project ID containing logs of the cluster to query"
+	mockAutocompleteProjectIDTask := coretask.NewTask(googlecloudcommon_contract.AutocompleteProjectIDTaskID, []taskid.UntypedTaskReference{}, func(ctx context.Context) (*inspectioncore_contract.AutocompleteResult[string], error) {
+		return &inspectioncore_contract.AutocompleteResult[string]{
+			Values: []string{"bar-project", "foo-project"},
+		}, nil
+	})
 	form_task_test.TestTextForms(t, "gcp-project-id", InputProjectIdTask, []*form_task_test.TextFormTestCase{
 		{
 			Name:          "With valid project ID",
 			Input:         "foo-project",
 			ExpectedValue: "foo-project",
-
+			Dependencies:  []coretask.UntypedTask{mockAutocompleteProjectIDTask},
 			ExpectedFormField: inspectionmetadata.TextParameterFormField{
 				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
 					ID:          googlecloudcommon_contract.GoogleCloudCommonTaskIDPrefix + "input/project-id",
@@ -39,14 +48,16 @@ func TestProjectIdInput(t *testing.T) {
 					Description: wantDescription,
 					HintType:    inspectionmetadata.None,
 				},
+				Suggestions:      []string{"foo-project", "bar-project"},
 				ValidationTiming: inspectionmetadata.Blur,
+				Placeholder:      "e.g. my-project-id",
 			},
 		},
 		{
 			Name:          "With fixed project ID from environment variable",
 			Input:         "foo-project",
 			ExpectedValue: "bar-project",
-
+			Dependencies:  []coretask.UntypedTask{mockAutocompleteProjectIDTask},
 			ExpectedFormField: inspectionmetadata.TextParameterFormField{
 				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
 					ID:          googlecloudcommon_contract.GoogleCloudCommonTaskIDPrefix + "input/project-id",
@@ -56,8 +67,12 @@ func TestProjectIdInput(t *testing.T) {
 					HintType:    inspectionmetadata.None,
 				},
 				Readonly:         true,
+				ReadonlyReason:   inspectionmetadata.ReadonlyReasonServerConfiguration,
+				ReadonlySource:   "--fixed-project-id (KHI_FIXED_PROJECT_ID)",
 				Default:          "bar-project",
+				Suggestions:      []string{"bar-project", "foo-project"},
 				ValidationTiming: inspectionmetadata.Blur,
+				Placeholder:      "e.g. my-project-id",
 			},
 			Before: func() {
 				expectedFixedProjectId := "bar-project"
@@ -71,7 +86,7 @@ func TestProjectIdInput(t *testing.T) {
 			Name:          "With invalid project ID",
 			Input:         "A invalid project ID",
 			ExpectedValue: "",
-
+			Dependencies:  []coretask.UntypedTask{mockAutocompleteProjectIDTask},
 			ExpectedFormField: inspectionmetadata.TextParameterFormField{
 				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
 					ID:          googlecloudcommon_contract.GoogleCloudCommonTaskIDPrefix + "input/project-id",
@@ -81,14 +96,16 @@ func TestProjectIdInput(t *testing.T) {
 					HintType:    inspectionmetadata.Error,
 					Hint:        "Project ID must match `^*[0-9a-z\\.:\\-]+$`",
 				},
+				Suggestions:      []string{"bar-project", "foo-project"},
 				ValidationTiming: inspectionmetadata.Blur,
+				Placeholder:      "e.g. my-project-id",
 			},
 		},
 		{
 			Name:          "Spaces around project ID must be trimmed",
 			Input:         "  project-foo   ",
 			ExpectedValue: "project-foo",
-
+			Dependencies:  []coretask.UntypedTask{mockAutocompleteProjectIDTask},
 			ExpectedFormField: inspectionmetadata.TextParameterFormField{
 				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
 					ID:          googlecloudcommon_contract.GoogleCloudCommonTaskIDPrefix + "input/project-id",
@@ -97,14 +114,16 @@ func TestProjectIdInput(t *testing.T) {
 					Description: wantDescription,
 					HintType:    inspectionmetadata.None,
 				},
+				Suggestions:      []string{"foo-project", "bar-project"},
 				ValidationTiming: inspectionmetadata.Blur,
+				Placeholder:      "e.g. my-project-id",
 			},
 		},
 		{
 			Name:          "With valid old style project ID",
 			Input:         "  deprecated.com:but-still-usable-project-id   ",
 			ExpectedValue: "deprecated.com:but-still-usable-project-id",
-
+			Dependencies:  []coretask.UntypedTask{mockAutocompleteProjectIDTask},
 			ExpectedFormField: inspectionmetadata.TextParameterFormField{
 				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
 					ID:          googlecloudcommon_contract.GoogleCloudCommonTaskIDPrefix + "input/project-id",
@@ -113,7 +132,9 @@ func TestProjectIdInput(t *testing.T) {
 					Label:       "Project ID",
 					HintType:    inspectionmetadata.None,
 				},
+				Suggestions:      []string{"bar-project", "foo-project"},
 				ValidationTiming: inspectionmetadata.Blur,
+				Placeholder:      "e.g. my-project-id",
 			},
 		},
 	})
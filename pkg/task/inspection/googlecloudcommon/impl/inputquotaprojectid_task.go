@@ -0,0 +1,52 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_impl
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	"github.com/kyasbal/khi/pkg/parameters"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+)
+
+// InputQuotaProjectIDTask defines a form task for inputting the project billed for the Google Cloud API calls.
+// This is needed when the user can read logs of the inspected project but the API usage must be billed to another project.
+var InputQuotaProjectIDTask = formtask.NewTextFormTaskBuilder(googlecloudcommon_contract.InputQuotaProjectIDTaskID, 0, "Quota project ID").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionResourceIdentifier, After: []string{googlecloudcommon_contract.InputImpersonateServiceAccountTaskID.ReferenceIDString()}}).
+	WithPlaceholder("e.g. billing-project").
+	WithDescription("The project billed for the API calls and whose quota is consumed (`X-Goog-User-Project`). Leave this empty to use the default quota project of the credentials. You need `serviceusage.services.use` permission on the project.").
+	WithValidatingTiming(inspectionmetadata.Blur).
+	WithDefaultValueFunc(func(ctx context.Context, previousValues []string) (string, error) {
+		if len(previousValues) > 0 {
+			return previousValues[0], nil
+		}
+		if parameters.Auth.QuotaProjectID != nil {
+			return *parameters.Auth.QuotaProjectID, nil
+		}
+		return "", nil
+	}).
+	WithValidator(func(ctx context.Context, value string) (string, error) {
+		if strings.TrimSpace(value) != "" && !projectIdValidator.MatchString(value) {
+			return "Project ID must match `^*[0-9a-z\\.:\\-]+$`", nil
+		}
+		return "", nil
+	}).
+	WithConverter(func(ctx context.Context, value string) (string, error) {
+		return strings.TrimSpace(value), nil
+	}).
+	Build()
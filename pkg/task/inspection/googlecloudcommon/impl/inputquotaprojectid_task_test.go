@@ -0,0 +1,104 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_impl
+
+import (
+	"testing"
+
+	form_task_test "github.com/kyasbal/khi/pkg/core/inspection/formtask/test"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	"github.com/kyasbal/khi/pkg/parameters"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+)
+
+func TestInputQuotaProjectIDTask(t *testing.T) {
+	wantDescription := "The project billed for the API calls and whose quota is consumed (`X-Goog-User-Project`). Leave this empty to use the default quota project of the credentials. You need `serviceusage.services.use` permission on the project."
+	wantPlaceholder := "e.g. billing-project"
+	form_task_test.TestTextForms(t, "quota-project-id", InputQuotaProjectIDTask, []*form_task_test.TextFormTestCase{
+		{
+			Name:          "empty value",
+			Input:         "",
+			ExpectedValue: "",
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					ID:          googlecloudcommon_contract.InputQuotaProjectIDTaskID.ReferenceIDString(),
+					Type:        inspectionmetadata.Text,
+					Label:       "Quota project ID",
+					Description: wantDescription,
+					HintType:    inspectionmetadata.None,
+				},
+				ValidationTiming: inspectionmetadata.Blur,
+				Placeholder:      wantPlaceholder,
+			},
+		},
+		{
+			Name:          "valid project ID with spaces",
+			Input:         "  billing-project ",
+			ExpectedValue: "billing-project",
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					ID:          googlecloudcommon_contract.InputQuotaProjectIDTaskID.ReferenceIDString(),
+					Type:        inspectionmetadata.Text,
+					Label:       "Quota project ID",
+					Description: wantDescription,
+					HintType:    inspectionmetadata.None,
+				},
+				ValidationTiming: inspectionmetadata.Blur,
+				Placeholder:      wantPlaceholder,
+			},
+		},
+		{
+			Name:          "invalid project ID",
+			Input:         "Billing_Project",
+			ExpectedValue: "",
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					ID:          googlecloudcommon_contract.InputQuotaProjectIDTaskID.ReferenceIDString(),
+					Type:        inspectionmetadata.Text,
+					Label:       "Quota project ID",
+					Description: wantDescription,
+					HintType:    inspectionmetadata.Error,
+					Hint:        "Project ID must match `^*[0-9a-z\\.:\\-]+$`",
+				},
+				ValidationTiming: inspectionmetadata.Blur,
+				Placeholder:      wantPlaceholder,
+			},
+		},
+		{
+			Name:          "default value from the parameter",
+			Input:         "",
+			ExpectedValue: "",
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					ID:          googlecloudcommon_contract.InputQuotaProjectIDTaskID.ReferenceIDString(),
+					Type:        inspectionmetadata.Text,
+					Label:       "Quota project ID",
+					Description: wantDescription,
+					HintType:    inspectionmetadata.None,
+				},
+				Default:          "billing-project",
+				ValidationTiming: inspectionmetadata.Blur,
+				Placeholder:      wantPlaceholder,
+			},
+			Before: func() {
+				quotaProjectID := "billing-project"
+				parameters.Auth.QuotaProjectID = &quotaProjectID
+			},
+			After: func() {
+				parameters.Auth.QuotaProjectID = nil
+			},
+		},
+	})
+}
@@ -29,15 +29,14 @@ import (
 	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
 )
 
-// InputStartTimeTask defines an inspection task that calculates the start time of a query
-// from the end time and duration.
+// InputStartTimeTask returns the start time of the time range given from InputTimeRangeTask.
+// This also records the time range on the header metadata.
 var InputStartTimeTask = inspectiontaskbase.NewInspectionTask(googlecloudcommon_contract.InputStartTimeTaskID, []taskid.UntypedTaskReference{
-	googlecloudcommon_contract.InputEndTimeTaskID.Ref(),
-	googlecloudcommon_contract.InputDurationTaskID.Ref(),
+	googlecloudcommon_contract.InputTimeRangeTaskID.Ref(),
 }, func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (time.Time, error) {
-	endTime := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputEndTimeTaskID.Ref())
-	duration := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputDurationTaskID.Ref())
-	startTime := endTime.Add(-duration)
+	timeRange := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputTimeRangeTaskID.Ref())
+	startTime := timeRange.Start
+	endTime := timeRange.End
 	// Add starttime and endtime on the header metadata
 	metadataSet := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
 
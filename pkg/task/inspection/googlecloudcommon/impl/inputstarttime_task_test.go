@@ -19,6 +19,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
 	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
 	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
 	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
@@ -26,20 +27,15 @@ import (
 )
 
 func TestInputStartTime(t *testing.T) {
-	duration, err := time.ParseDuration("1h30m")
-	if err != nil {
-		t.Fatal(err)
-	}
 	endTime, err := time.Parse(time.RFC3339, "2023-01-02T15:45:00Z")
 	if err != nil {
 		t.Fatal(err)
 	}
+	timeRange := formtask.TimeRange{Start: endTime.Add(-90 * time.Minute), End: endTime}
 
 	ctx := inspectiontest.WithDefaultTestInspectionTaskContext(context.Background())
 	startTime, _, err := inspectiontest.RunInspectionTask(ctx, InputStartTimeTask, inspectioncore_contract.TaskModeDryRun, map[string]any{},
-		tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.InputDurationTaskID.Ref(), duration),
-		tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.InputEndTimeTaskID.Ref(), endTime),
-		tasktest.NewTaskDependencyValuePair(inspectioncore_contract.TimeZoneShiftInputTaskID.Ref(), time.UTC),
+		tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.InputTimeRangeTaskID.Ref(), timeRange),
 	)
 	if err != nil {
 		t.Errorf("unexpected error\n%v", err)
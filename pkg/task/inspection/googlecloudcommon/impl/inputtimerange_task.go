@@ -0,0 +1,76 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_impl
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// InputTimeRangeTask defines a form task to input the time range for log queries with the end time and the duration.
+var InputTimeRangeTask = formtask.NewTimeRangeFormTaskBuilder(googlecloudcommon_contract.InputTimeRangeTaskID, 0, "Time range").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionQueryTime}).
+	WithDependencies([]taskid.UntypedTaskReference{
+		inspectioncore_contract.TimeZoneShiftInputTaskID.Ref(),
+	}).
+	WithDescription("The time range to gather logs. Specify the end time and the duration ending at it. Supported time units of the duration are `h`,`m` or `s`. (Example: `3h30m`)").
+	WithMarkdown().
+	WithTimezoneFunc(func(ctx context.Context) (*time.Location, error) {
+		return coretask.GetTaskResult(ctx, inspectioncore_contract.TimeZoneShiftInputTaskID.Ref()), nil
+	}).
+	WithPresets(time.Hour, time.Hour*3, time.Hour*12, time.Hour*24).
+	WithLookbackLimit(time.Hour*24*30, "Specified time range starts from over than 30 days ago, maybe some logs are missing and the generated result could be incomplete. See [the retention periods of Cloud Logging](https://cloud.google.com/logging/quotas#logs_retention_periods).").
+	WithHintFunc(func(ctx context.Context, value formtask.TimeRange) (string, inspectionmetadata.ParameterHintType, error) {
+		creationTime := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionCreationTime)
+		timezoneShift := coretask.GetTaskResult(ctx, inspectioncore_contract.TimeZoneShiftInputTaskID.Ref())
+
+		hintType := inspectionmetadata.Info
+		hintString := ""
+		if value.End.After(creationTime) {
+			hintString += fmt.Sprintf("- Specified end time `%s` is pointing the future. Please make sure if you specified the right value.\n", value.End.In(timezoneShift).Format(time.RFC3339))
+			hintType = inspectionmetadata.Warning
+		}
+		if value.Duration() > time.Hour*3 {
+			hintString += "- This duration can be too long for big clusters and lead OOM. Please retry with shorter duration when your machine crashed.\n"
+			hintType = inspectionmetadata.Warning
+		}
+		if hintString != "" {
+			hintString += "\n"
+		}
+		hintString += fmt.Sprintf("Query range:\n%s\n", toTimeDurationWithTimezone(value.Start, value.End, timezoneShift, true))
+		hintString += fmt.Sprintf("(UTC: %s)\n", toTimeDurationWithTimezone(value.Start, value.End, time.UTC, false))
+		hintString += fmt.Sprintf("(PDT: %s)", toTimeDurationWithTimezone(value.Start, value.End, time.FixedZone("PDT", -7*3600), false))
+		return hintString, hintType, nil
+	}).
+	Build()
+
+func toTimeDurationWithTimezone(startTime time.Time, endTime time.Time, timezone *time.Location, withTimezone bool) string {
+	timeFormat := "2006-01-02T15:04:05"
+	if withTimezone {
+		timeFormat = time.RFC3339
+	}
+	startTimeStr := startTime.In(timezone).Format(timeFormat)
+	endTimeStr := endTime.In(timezone).Format(timeFormat)
+	return fmt.Sprintf("%s ~ %s", startTimeStr, endTimeStr)
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudcommon_impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	form_task_test "github.com/kyasbal/khi/pkg/core/inspection/formtask/test"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	inspectioncore_impl "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/impl"
+)
+
+func TestInputTimeRange(t *testing.T) {
+	expectedDescription := "The time range to gather logs. Specify the end time and the duration ending at it. Supported time units of the duration are `h`,`m` or `s`. (Example: `3h30m`)"
+	expectedLabel := "Time range"
+	timezoneTaskUTC := tasktest.StubTask(inspectioncore_impl.TimeZoneShiftInputTask, time.UTC, nil)
+	timezoneTaskJST := tasktest.StubTask(inspectioncore_impl.TimeZoneShiftInputTask, time.FixedZone("", 9*3600), nil)
+	defaultEnd := time.Date(2025, time.January, 1, 1, 1, 1, 0, time.UTC)
+	presetsUTC := []inspectionmetadata.TimeRangeParameterFormFieldPreset{
+		{Label: "Last 1h", Value: inspectionmetadata.TimeRangeParameterValue{EndTime: "2025-01-01T01:01:01Z", Duration: "1h"}},
+		{Label: "Last 3h", Value: inspectionmetadata.TimeRangeParameterValue{EndTime: "2025-01-01T01:01:01Z", Duration: "3h"}},
+		{Label: "Last 12h", Value: inspectionmetadata.TimeRangeParameterValue{EndTime: "2025-01-01T01:01:01Z", Duration: "12h"}},
+		{Label: "Last 24h", Value: inspectionmetadata.TimeRangeParameterValue{EndTime: "2025-01-01T01:01:01Z", Duration: "24h"}},
+	}
+
+	form_task_test.TestTimeRangeForms(t, "time range", InputTimeRangeTask, []*form_task_test.TimeRangeFormTestCase{
+		{
+			Name:          "with the default value",
+			ExpectedValue: formtask.TimeRange{Start: defaultEnd.Add(-time.Hour), End: defaultEnd},
+			Dependencies:  []coretask.UntypedTask{timezoneTaskUTC},
+			ExpectedFormField: inspectionmetadata.TimeRangeParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					Label:       expectedLabel,
+					Description: expectedDescription,
+					Markdown:    true,
+					HintType:    inspectionmetadata.Info,
+					Hint: `Query range:
+2025-01-01T00:01:01Z ~ 2025-01-01T01:01:01Z
+(UTC: 2025-01-01T00:01:01 ~ 2025-01-01T01:01:01)
+(PDT: 2024-12-31T17:01:01 ~ 2024-12-31T18:01:01)`,
+				},
+				Default:      inspectionmetadata.TimeRangeParameterValue{EndTime: "2025-01-01T01:01:01Z", Duration: "1h"},
+				StartTimeUTC: "2025-01-01T00:01:01Z",
+				EndTimeUTC:   "2025-01-01T01:01:01Z",
+				Presets:      presetsUTC,
+			},
+		},
+		{
+			Name:          "with non UTC timezone",
+			Input:         map[string]any{"endTime": "2024-12-31T21:00:00+09:00", "duration": "10m"},
+			ExpectedValue: formtask.TimeRange{Start: time.Date(2024, time.December, 31, 11, 50, 0, 0, time.UTC), End: time.Date(2024, time.December, 31, 12, 0, 0, 0, time.UTC)},
+			Dependencies:  []coretask.UntypedTask{timezoneTaskJST},
+			ExpectedFormField: inspectionmetadata.TimeRangeParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					Label:       expectedLabel,
+					Description: expectedDescription,
+					Markdown:    true,
+					HintType:    inspectionmetadata.Info,
+					Hint: `Query range:
+2024-12-31T20:50:00+09:00 ~ 2024-12-31T21:00:00+09:00
+(UTC: 2024-12-31T11:50:00 ~ 2024-12-31T12:00:00)
+(PDT: 2024-12-31T04:50:00 ~ 2024-12-31T05:00:00)`,
+				},
+				Default:            inspectionmetadata.TimeRangeParameterValue{EndTime: "2025-01-01T10:01:01+09:00", Duration: "1h"},
+				StartTimeUTC:       "2024-12-31T11:50:00Z",
+				EndTimeUTC:         "2024-12-31T12:00:00Z",
+				TimezoneShiftHours: 9,
+				Presets: []inspectionmetadata.TimeRangeParameterFormFieldPreset{
+					{Label: "Last 1h", Value: inspectionmetadata.TimeRangeParameterValue{EndTime: "2025-01-01T10:01:01+09:00", Duration: "1h"}},
+					{Label: "Last 3h", Value: inspectionmetadata.TimeRangeParameterValue{EndTime: "2025-01-01T10:01:01+09:00", Duration: "3h"}},
+					{Label: "Last 12h", Value: inspectionmetadata.TimeRangeParameterValue{EndTime: "2025-01-01T10:01:01+09:00", Duration: "12h"}},
+					{Label: "Last 24h", Value: inspectionmetadata.TimeRangeParameterValue{EndTime: "2025-01-01T10:01:01+09:00", Duration: "24h"}},
+				},
+			},
+		},
+		{
+			Name:          "with longer duration starting before than 30 days",
+			Input:         map[string]any{"endTime": "2024-12-05T01:01:01Z", "duration": "72h"},
+			ExpectedValue: formtask.TimeRange{Start: time.Date(2024, time.December, 2, 1, 1, 1, 0, time.UTC), End: time.Date(2024, time.December, 5, 1, 1, 1, 0, time.UTC)},
+			Dependencies:  []coretask.UntypedTask{timezoneTaskUTC},
+			ExpectedFormField: inspectionmetadata.TimeRangeParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					Label:       expectedLabel,
+					Description: expectedDescription,
+					Markdown:    true,
+					HintType:    inspectionmetadata.Warning,
+					Hint: `Specified time range starts from over than 30 days ago, maybe some logs are missing and the generated result could be incomplete. See [the retention periods of Cloud Logging](https://cloud.google.com/logging/quotas#logs_retention_periods).
+
+- This duration can be too long for big clusters and lead OOM. Please retry with shorter duration when your machine crashed.
+
+Query range:
+2024-12-02T01:01:01Z ~ 2024-12-05T01:01:01Z
+(UTC: 2024-12-02T01:01:01 ~ 2024-12-05T01:01:01)
+(PDT: 2024-12-01T18:01:01 ~ 2024-12-04T18:01:01)`,
+				},
+				Default:      inspectionmetadata.TimeRangeParameterValue{EndTime: "2025-01-01T01:01:01Z", Duration: "1h"},
+				StartTimeUTC: "2024-12-02T01:01:01Z",
+				EndTimeUTC:   "2024-12-05T01:01:01Z",
+				Presets:      presetsUTC,
+			},
+		},
+		{
+			Name:          "with a future end time",
+			Input:         map[string]any{"endTime": "2030-01-01T00:00:00Z", "duration": "1h"},
+			ExpectedValue: formtask.TimeRange{Start: time.Date(2029, time.December, 31, 23, 0, 0, 0, time.UTC), End: time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)},
+			Dependencies:  []coretask.UntypedTask{timezoneTaskUTC},
+			ExpectedFormField: inspectionmetadata.TimeRangeParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					Label:       expectedLabel,
+					Description: expectedDescription,
+					Markdown:    true,
+					HintType:    inspectionmetadata.Warning,
+					Hint: "- Specified end time `2030-01-01T00:00:00Z` is pointing the future. Please make sure if you specified the right value.\n" + `
+Query range:
+2029-12-31T23:00:00Z ~ 2030-01-01T00:00:00Z
+(UTC: 2029-12-31T23:00:00 ~ 2030-01-01T00:00:00)
+(PDT: 2029-12-31T16:00:00 ~ 2029-12-31T17:00:00)`,
+				},
+				Default:      inspectionmetadata.TimeRangeParameterValue{EndTime: "2025-01-01T01:01:01Z", Duration: "1h"},
+				StartTimeUTC: "2029-12-31T23:00:00Z",
+				EndTimeUTC:   "2030-01-01T00:00:00Z",
+				Presets:      presetsUTC,
+			},
+		},
+	})
+}
@@ -23,16 +23,27 @@ import (
 func Register(registry coreinspection.InspectionTaskRegistry) error {
 	return coretask.RegisterTasks(registry,
 		AutocompleteLocationTask,
+		AutocompleteProjectIDTask,
 		InputProjectIdTask,
+		InputAdditionalProjectIDsTask,
+		InputLogViewResourceNamesTask,
+		InputAdditionalLogFilterTask,
 		InputLoggingFilterResourceNameTask,
-		InputDurationTask,
+		InputTimeRangeTask,
 		InputStartTimeTask,
 		InputEndTimeTask,
 		InputLocationsTask,
+		InputCredentialSourceTask,
+		InputImpersonateServiceAccountTask,
+		InputQuotaProjectIDTask,
 		APIClientFactoryTask,
 		APIClientFactoryOptionsTask,
 		APICallOptionsInjectorTask,
 		LocationFetcherTask,
+		ProjectFetcherTask,
+		CredentialSourceDetectorTask,
+		LoggingRateLimiterTask,
+		LoggingCircuitBreakerTask,
 		LoggingFetcherTask,
 	)
 }
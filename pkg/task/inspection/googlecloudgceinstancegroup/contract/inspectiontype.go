@@ -0,0 +1,34 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudgceinstancegroup_contract
+
+import (
+	"math"
+
+	coreinspection "github.com/kyasbal/khi/pkg/core/inspection"
+)
+
+// InspectionTypeId is the unique identifier for the GCE instance group inspection type.
+var InspectionTypeId = "gcp-gce-instance-group"
+
+// GCEInstanceGroupInspectionType defines the inspection type for managed instance groups of Compute Engine.
+var GCEInstanceGroupInspectionType = coreinspection.InspectionType{
+	Id:   InspectionTypeId,
+	Name: "GCE instance group",
+	Description: `Visualize logs generated from VMs in a managed instance group of Compute Engine (e.g. nodes of a self-managed Kubernetes cluster on GCE).
+Supporting serial port log, Compute API audit log and autoscaler log.`,
+	Icon:     "assets/icons/k8s.png",
+	Priority: math.MaxInt - 20,
+}
@@ -0,0 +1,31 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudgceinstancegroup_contract
+
+// GCEInstanceGroupIdentity is the tuple identifying a managed instance group on Compute Engine.
+type GCEInstanceGroupIdentity struct {
+	// ProjectID is the project ID of the instance group.
+	ProjectID string
+	// Location is the zone or the region of the instance group.
+	Location string
+	// InstanceGroupName is the name of the managed instance group.
+	InstanceGroupName string
+}
+
+// InstanceNamePrefix returns the prefix of the instance names in the instance group.
+// Instances created by a managed instance group are named `<base instance name>-<random suffix>` and the base instance name is the instance group name by default.
+func (g *GCEInstanceGroupIdentity) InstanceNamePrefix() string {
+	return g.InstanceGroupName + "-"
+}
@@ -0,0 +1,28 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudgceinstancegroup_contract
+
+import (
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+)
+
+// TaskIDPrefix is the prefix of task IDs for GCE instance group related tasks.
+var TaskIDPrefix = "cloud.google.com/gce-instance-group/"
+
+// InputInstanceGroupNameTaskID is the task ID for the name of the managed instance group.
+var InputInstanceGroupNameTaskID = taskid.NewDefaultImplementationID[string](TaskIDPrefix + "input-instance-group-name")
+
+// InstanceGroupIdentityTaskID is the task ID for getting the identity of the instance group. Fields are from form inputs.
+var InstanceGroupIdentityTaskID = taskid.NewDefaultImplementationID[GCEInstanceGroupIdentity](TaskIDPrefix + "instance-group-identity")
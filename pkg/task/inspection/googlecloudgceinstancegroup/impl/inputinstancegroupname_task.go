@@ -0,0 +1,48 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudgceinstancegroup_impl
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	googlecloudgceinstancegroup_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudgceinstancegroup/contract"
+)
+
+var instanceGroupNameValidator = regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`)
+
+// InputInstanceGroupNameTask is a form task receiving the name of the managed instance group from the user.
+var InputInstanceGroupNameTask = formtask.NewTextFormTaskBuilder(googlecloudgceinstancegroup_contract.InputInstanceGroupNameTaskID, 0, "Instance group name").
+	WithPosition(inspectionmetadata.FormPosition{
+		Section: googlecloudcommon_contract.FormSectionResourceIdentifier,
+		After:   []string{googlecloudcommon_contract.InputProjectIdTaskID.ReferenceIDString()},
+		Before:  []string{googlecloudcommon_contract.InputLocationsTaskID.ReferenceIDString()},
+	}).
+	WithPlaceholder("e.g. my-instance-group").
+	WithDescription("The name of the managed instance group to gather logs. Logs of the instances named with the instance group name as the prefix are gathered.").
+	WithValidator(func(ctx context.Context, value string) (string, error) {
+		if !instanceGroupNameValidator.MatchString(strings.TrimSpace(value)) {
+			return "Instance group name must match `^[a-z]([-a-z0-9]*[a-z0-9])?$`", nil
+		}
+		return "", nil
+	}).
+	WithConverter(func(ctx context.Context, value string) (string, error) {
+		return strings.TrimSpace(value), nil
+	}).
+	Build()
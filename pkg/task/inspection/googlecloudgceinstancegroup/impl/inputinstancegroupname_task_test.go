@@ -0,0 +1,100 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudgceinstancegroup_impl
+
+import (
+	"testing"
+
+	form_task_test "github.com/kyasbal/khi/pkg/core/inspection/formtask/test"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	googlecloudgceinstancegroup_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudgceinstancegroup/contract"
+)
+
+func TestInputInstanceGroupNameTask(t *testing.T) {
+	wantDescription := "The name of the managed instance group to gather logs. Logs of the instances named with the instance group name as the prefix are gathered."
+	form_task_test.TestTextForms(t, "instance group name", InputInstanceGroupNameTask, []*form_task_test.TextFormTestCase{
+		{
+			Name:          "with valid instance group name",
+			Input:         "my-instance-group",
+			ExpectedValue: "my-instance-group",
+			Dependencies:  []coretask.UntypedTask{},
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					ID:          googlecloudgceinstancegroup_contract.InputInstanceGroupNameTaskID.ReferenceIDString(),
+					Type:        "Text",
+					Label:       "Instance group name",
+					Description: wantDescription,
+					HintType:    inspectionmetadata.None,
+				},
+				ValidationTiming: inspectionmetadata.Change,
+				Placeholder:      "e.g. my-instance-group",
+			},
+		},
+		{
+			Name:          "spaces around instance group name must be trimmed",
+			Input:         "  my-instance-group  ",
+			ExpectedValue: "my-instance-group",
+			Dependencies:  []coretask.UntypedTask{},
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					ID:          googlecloudgceinstancegroup_contract.InputInstanceGroupNameTaskID.ReferenceIDString(),
+					Type:        "Text",
+					Label:       "Instance group name",
+					Description: wantDescription,
+					HintType:    inspectionmetadata.None,
+				},
+				ValidationTiming: inspectionmetadata.Change,
+				Placeholder:      "e.g. my-instance-group",
+			},
+		},
+		{
+			Name:          "invalid instance group name",
+			Input:         "My_Instance_Group",
+			ExpectedValue: "",
+			Dependencies:  []coretask.UntypedTask{},
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					ID:          googlecloudgceinstancegroup_contract.InputInstanceGroupNameTaskID.ReferenceIDString(),
+					Type:        "Text",
+					Label:       "Instance group name",
+					Description: wantDescription,
+					HintType:    inspectionmetadata.Error,
+					Hint:        "Instance group name must match `^[a-z]([-a-z0-9]*[a-z0-9])?$`",
+				},
+				ValidationTiming: inspectionmetadata.Change,
+				Placeholder:      "e.g. my-instance-group",
+			},
+		},
+		{
+			Name:          "instance group name ending with a hyphen",
+			Input:         "my-instance-group-",
+			ExpectedValue: "",
+			Dependencies:  []coretask.UntypedTask{},
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					ID:          googlecloudgceinstancegroup_contract.InputInstanceGroupNameTaskID.ReferenceIDString(),
+					Type:        "Text",
+					Label:       "Instance group name",
+					Description: wantDescription,
+					HintType:    inspectionmetadata.Error,
+					Hint:        "Instance group name must match `^[a-z]([-a-z0-9]*[a-z0-9])?$`",
+				},
+				ValidationTiming: inspectionmetadata.Change,
+				Placeholder:      "e.g. my-instance-group",
+			},
+		},
+	})
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudgceinstancegroup_impl
+
+import (
+	"context"
+
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	googlecloudgceinstancegroup_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudgceinstancegroup/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// InstanceGroupIdentityTask returns the identity of the instance group given from the form inputs.
+var InstanceGroupIdentityTask = inspectiontaskbase.NewInspectionTask(googlecloudgceinstancegroup_contract.InstanceGroupIdentityTaskID, []taskid.UntypedTaskReference{
+	googlecloudcommon_contract.InputProjectIdTaskID.Ref(),
+	googlecloudcommon_contract.InputLocationsTaskID.Ref(),
+	googlecloudgceinstancegroup_contract.InputInstanceGroupNameTaskID.Ref(),
+}, func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (googlecloudgceinstancegroup_contract.GCEInstanceGroupIdentity, error) {
+	return googlecloudgceinstancegroup_contract.GCEInstanceGroupIdentity{
+		ProjectID:         coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputProjectIdTaskID.Ref()),
+		Location:          coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputLocationsTaskID.Ref()),
+		InstanceGroupName: coretask.GetTaskResult(ctx, googlecloudgceinstancegroup_contract.InputInstanceGroupNameTaskID.Ref()),
+	}, nil
+})
@@ -0,0 +1,49 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudgceinstancegroup_impl
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	googlecloudgceinstancegroup_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudgceinstancegroup/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+func TestInstanceGroupIdentityTask(t *testing.T) {
+	ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+	got, _, err := inspectiontest.RunInspectionTask(ctx, InstanceGroupIdentityTask, inspectioncore_contract.TaskModeRun, map[string]any{},
+		tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.InputProjectIdTaskID.Ref(), "foo-project"),
+		tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.InputLocationsTaskID.Ref(), "us-central1-a"),
+		tasktest.NewTaskDependencyValuePair(googlecloudgceinstancegroup_contract.InputInstanceGroupNameTaskID.Ref(), "my-instance-group"),
+	)
+	if err != nil {
+		t.Fatalf("RunInspectionTask() returned an unexpected error: %v", err)
+	}
+	want := googlecloudgceinstancegroup_contract.GCEInstanceGroupIdentity{
+		ProjectID:         "foo-project",
+		Location:          "us-central1-a",
+		InstanceGroupName: "my-instance-group",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("InstanceGroupIdentityTask returned an unexpected result (-want +got):\n%s", diff)
+	}
+	if gotPrefix := got.InstanceNamePrefix(); gotPrefix != "my-instance-group-" {
+		t.Errorf("InstanceNamePrefix() = %q, want %q", gotPrefix, "my-instance-group-")
+	}
+}
@@ -17,15 +17,19 @@ package googlecloudinspectiontypegroup_contract
 import (
 	googlecloudclustercomposer_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudclustercomposer/contract"
 	googlecloudclustergdcbaremetal_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudclustergdcbaremetal/contract"
+	googlecloudclustergdcconnect_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudclustergdcconnect/contract"
 	googlecloudclustergdcvmware_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudclustergdcvmware/contract"
 	googlecloudclustergke_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudclustergke/contract"
 	googlecloudclustergkeonaws_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudclustergkeonaws/contract"
 	googlecloudclustergkeonazure_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudclustergkeonazure/contract"
+	googlecloudgceinstancegroup_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudgceinstancegroup/contract"
+	googlecloudlogarchive_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogarchive/contract"
+	googlecloudlogpubsub_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogpubsub/contract"
 )
 
 // GCPK8sClusterInspectionTypes is the list of inspection types of k8s clusters from Google Cloud.
 var GCPK8sClusterInspectionTypes = []string{
-	googlecloudclustergke_contract.InspectionTypeId, googlecloudclustercomposer_contract.InspectionTypeId, googlecloudclustergdcvmware_contract.InspectionTypeId, googlecloudclustergdcbaremetal_contract.InspectionTypeId, googlecloudclustergkeonaws_contract.InspectionTypeId, googlecloudclustergkeonazure_contract.InspectionTypeId,
+	googlecloudclustergke_contract.InspectionTypeId, googlecloudclustercomposer_contract.InspectionTypeId, googlecloudclustergdcvmware_contract.InspectionTypeId, googlecloudclustergdcbaremetal_contract.InspectionTypeId, googlecloudclustergdcconnect_contract.InspectionTypeId, googlecloudclustergkeonaws_contract.InspectionTypeId, googlecloudclustergkeonazure_contract.InspectionTypeId,
 }
 
 // GKEBasedClusterInspectionTypes is the list of inspection types of GKE.
@@ -40,10 +44,20 @@ var GKEMultiCloudClusterInspectionTypes = []string{
 
 // GDCClusterInspectionTypes is the list of inspection types of GDC clusters.
 var GDCClusterInspectionTypes = []string{
-	googlecloudclustergdcbaremetal_contract.InspectionTypeId, googlecloudclustergdcvmware_contract.InspectionTypeId,
+	googlecloudclustergdcbaremetal_contract.InspectionTypeId, googlecloudclustergdcvmware_contract.InspectionTypeId, googlecloudclustergdcconnect_contract.InspectionTypeId,
 }
 
 // CloudComposerInspectionTypes is the list of inspection types of Cloud Composer.
 var CloudComposerInspectionTypes = []string{
 	googlecloudclustercomposer_contract.InspectionTypeId,
 }
+
+// GCEInstanceGroupInspectionTypes is the list of inspection types of Compute Engine instance groups.
+var GCEInstanceGroupInspectionTypes = []string{
+	googlecloudgceinstancegroup_contract.InspectionTypeId,
+}
+
+// K8sLogSinkInspectionTypes is the list of inspection types reading Kubernetes logs exported by Cloud Logging sinks instead of querying Cloud Logging.
+var K8sLogSinkInspectionTypes = []string{
+	googlecloudlogarchive_contract.InspectionTypeId, googlecloudlogpubsub_contract.InspectionTypeId,
+}
@@ -0,0 +1,30 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudk8scommon_contract
+
+// GoogleCloudFleetMembership is a cluster registered as a membership of a fleet (GKE Hub).
+// Clusters in a fleet can belong to projects other than the fleet host project.
+type GoogleCloudFleetMembership struct {
+	// MembershipName is the resource name of the membership in the form of "projects/{fleetProjectID}/locations/{location}/memberships/{membershipID}".
+	MembershipName string
+	// FleetProjectID is the project ID of the fleet host project where the membership is registered.
+	FleetProjectID string
+	// Cluster is the identity of the registered cluster. The project ID is the one owning the cluster rather than the fleet host project.
+	Cluster GoogleCloudClusterIdentity
+	// Labels are the labels given to the membership.
+	Labels map[string]string
+	// State is the state code of the membership. e.g. "READY"
+	State string
+}
@@ -25,3 +25,6 @@ type NEGNameToResourceIdentityMap = map[string]commonlogk8sauditv2_contract.Reso
 var NEGNamesInventoryTaskID = taskid.NewDefaultImplementationID[NEGNameToResourceIdentityMap](GoogleCloudCommonK8STaskIDPrefix + "neg-names-inventory")
 
 var NEGNamesInventoryTaskBuilder = inspectiontaskbase.NewInventoryTaskBuilder(NEGNamesInventoryTaskID)
+
+// NEGNameToServiceMap is the map from NEG names to the identities of the Services the NEGs are created for.
+type NEGNameToServiceMap = map[string]commonlogk8sauditv2_contract.ResourceIdentity
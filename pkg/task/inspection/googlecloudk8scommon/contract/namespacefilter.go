@@ -0,0 +1,47 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudk8scommon_contract
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/gcpqueryutil"
+)
+
+// MatchNamespaceFilter evaluates the namespace filter given from InputNamespaceFilterTask with `#cluster-scoped` and `#namespaced` aliases on a log.
+// This is the client side equivalent of the namespace filters in Cloud Logging queries for the logs not queried from Cloud Logging.
+// namespaced must be true when the log is for a namespaced resource, and inNamespace must return true when the log is for a resource in the given namespace.
+func MatchNamespaceFilter(namespaced bool, inNamespace func(namespace string) bool, filter *gcpqueryutil.SetFilterParseResult) bool {
+	if filter.ValidationError != "" || filter.SubtractMode {
+		return true
+	}
+	hasClusterScope := slices.Contains(filter.Additives, "#cluster-scoped")
+	hasNamespacedScope := slices.Contains(filter.Additives, "#namespaced")
+	if hasClusterScope && hasNamespacedScope {
+		return true
+	}
+	if hasNamespacedScope {
+		return namespaced
+	}
+	namespaces := slices.DeleteFunc(slices.Clone(filter.Additives), func(additive string) bool { return strings.HasPrefix(additive, "#") })
+	if hasClusterScope {
+		return !namespaced || slices.ContainsFunc(namespaces, inNamespace)
+	}
+	if len(namespaces) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(namespaces, inNamespace)
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudk8scommon_contract
+
+import (
+	"testing"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/gcpqueryutil"
+)
+
+func TestMatchNamespaceFilter(t *testing.T) {
+	testCases := []struct {
+		name       string
+		namespaced bool
+		namespace  string
+		filter     *gcpqueryutil.SetFilterParseResult
+		want       bool
+	}{
+		{
+			name:       "namespace in additives",
+			namespaced: true,
+			namespace:  "default",
+			filter:     &gcpqueryutil.SetFilterParseResult{Additives: []string{"default"}},
+			want:       true,
+		},
+		{
+			name:       "namespace not in additives",
+			namespaced: true,
+			namespace:  "default",
+			filter:     &gcpqueryutil.SetFilterParseResult{Additives: []string{"kube-system"}},
+			want:       false,
+		},
+		{
+			name:   "cluster scoped resource with namespaces only",
+			filter: &gcpqueryutil.SetFilterParseResult{Additives: []string{"kube-system"}},
+			want:   false,
+		},
+		{
+			name:   "cluster scoped resource with #cluster-scoped",
+			filter: &gcpqueryutil.SetFilterParseResult{Additives: []string{"#cluster-scoped"}},
+			want:   true,
+		},
+		{
+			name:       "namespaced resource with #cluster-scoped",
+			namespaced: true,
+			namespace:  "default",
+			filter:     &gcpqueryutil.SetFilterParseResult{Additives: []string{"#cluster-scoped"}},
+			want:       false,
+		},
+		{
+			name:       "namespaced resource with #cluster-scoped and the namespace",
+			namespaced: true,
+			namespace:  "default",
+			filter:     &gcpqueryutil.SetFilterParseResult{Additives: []string{"#cluster-scoped", "default"}},
+			want:       true,
+		},
+		{
+			name:       "namespaced resource with #namespaced",
+			namespaced: true,
+			namespace:  "default",
+			filter:     &gcpqueryutil.SetFilterParseResult{Additives: []string{"#namespaced"}},
+			want:       true,
+		},
+		{
+			name:   "cluster scoped resource with #namespaced",
+			filter: &gcpqueryutil.SetFilterParseResult{Additives: []string{"#namespaced"}},
+			want:   false,
+		},
+		{
+			name:       "both aliases",
+			namespaced: true,
+			namespace:  "default",
+			filter:     &gcpqueryutil.SetFilterParseResult{Additives: []string{"#cluster-scoped", "#namespaced"}},
+			want:       true,
+		},
+		{
+			name:       "empty additives",
+			namespaced: true,
+			namespace:  "default",
+			filter:     &gcpqueryutil.SetFilterParseResult{},
+			want:       true,
+		},
+		{
+			name:       "subtract mode",
+			namespaced: true,
+			namespace:  "default",
+			filter:     &gcpqueryutil.SetFilterParseResult{Subtractives: []string{"default"}, SubtractMode: true},
+			want:       true,
+		},
+		{
+			name:       "filter with a validation error",
+			namespaced: true,
+			namespace:  "default",
+			filter:     &gcpqueryutil.SetFilterParseResult{Additives: []string{"kube-system"}, ValidationError: "invalid"},
+			want:       true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := MatchNamespaceFilter(tc.namespaced, func(namespace string) bool { return namespace == tc.namespace }, tc.filter)
+			if got != tc.want {
+				t.Errorf("MatchNamespaceFilter() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudk8scommon_contract
+
+import "strings"
+
+// GoogleCloudNodePool is a node pool of a GKE cluster.
+type GoogleCloudNodePool struct {
+	// Name is the name of the node pool.
+	Name string
+	// InstanceNamePrefixes is the list of prefixes of the instance names in the node pool. A node pool has an instance group for each zone and instance names in the group start with the same prefix.
+	InstanceNamePrefixes []string
+}
+
+// InstanceNamePrefixFromInstanceGroupURL returns the prefix of instance names in the instance group given by its URL.
+// Instance groups of a node pool are named like `gke-<cluster>-<node-pool>-<hash>-grp` and the instances in it are named like `gke-<cluster>-<node-pool>-<hash>-<suffix>`.
+func InstanceNamePrefixFromInstanceGroupURL(instanceGroupURL string) string {
+	name := instanceGroupURL[strings.LastIndex(instanceGroupURL, "/")+1:]
+	return strings.TrimSuffix(name, "-grp") + "-"
+}
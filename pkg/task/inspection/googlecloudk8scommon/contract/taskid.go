@@ -37,12 +37,23 @@ var AutocompleteLocationForClusterTaskID taskid.TaskImplementationID[*inspection
 // AutocompleteClusterIdentityTaskID is the task ID for returning cluster name candidates as AutocompleteClusterNameList.
 var AutocompleteClusterIdentityTaskID = taskid.NewDefaultImplementationID[*inspectioncore_contract.AutocompleteResult[GoogleCloudClusterIdentity]](GoogleCloudCommonK8STaskIDPrefix + "autocomplete/cluster-names")
 
+// AutocompleteFleetMembershipsTaskID is the task ID for returning the clusters registered to the fleet of the project as AutocompleteResult.
+// The default implementation returns no memberships, and cluster types supporting fleets override it.
+var AutocompleteFleetMembershipsTaskID = taskid.NewDefaultImplementationID[*inspectioncore_contract.AutocompleteResult[GoogleCloudFleetMembership]](GoogleCloudCommonK8STaskIDPrefix + "autocomplete/fleet-memberships")
+
+// AutocompleteLabeledClustersTaskID is the task ID for returning the clusters discovered by their resource labels across the selected projects as AutocompleteResult.
+// The default implementation returns no clusters, and cluster types supporting the discovery override it.
+var AutocompleteLabeledClustersTaskID = taskid.NewDefaultImplementationID[*inspectioncore_contract.AutocompleteResult[GoogleCloudClusterIdentity]](GoogleCloudCommonK8STaskIDPrefix + "autocomplete/labeled-clusters")
+
 // AutocompleteNamespacesTaskID is the task ID for returning namespace candidates as AutocompleteResult.
 var AutocompleteNamespacesTaskID = taskid.NewDefaultImplementationID[*inspectioncore_contract.AutocompleteResult[string]](GoogleCloudCommonK8STaskIDPrefix + "autocomplete/namespaces")
 
 // AutocompleteNodeNamesTaskID is the task ID for returning node name candidates as AutocompleteResult.
 var AutocompleteNodeNamesTaskID = taskid.NewDefaultImplementationID[*inspectioncore_contract.AutocompleteResult[string]](GoogleCloudCommonK8STaskIDPrefix + "autocomplete/node-names")
 
+// AutocompleteNodePoolsTaskID is the task ID for returning node pool candidates of the selected clusters as AutocompleteResult.
+var AutocompleteNodePoolsTaskID = taskid.NewDefaultImplementationID[*inspectioncore_contract.AutocompleteResult[GoogleCloudNodePool]](GoogleCloudCommonK8STaskIDPrefix + "autocomplete/node-pools")
+
 // AutocompletePodNamesTaskID is the task ID for returning pod name candidates as AutocompleteResult.
 var AutocompletePodNamesTaskID = taskid.NewDefaultImplementationID[*inspectioncore_contract.AutocompleteResult[string]](GoogleCloudCommonK8STaskIDPrefix + "autocomplete/pod-names")
 
@@ -64,6 +75,14 @@ var InputClusterNameTaskID = taskid.NewDefaultImplementationID[string](GoogleClo
 // ClusterIdentityTaskID is the task ID for getting the cluster identity. Fields are usually from form inputs.
 var ClusterIdentityTaskID = taskid.NewDefaultImplementationID[GoogleCloudClusterIdentity](GoogleCloudCommonK8STaskIDPrefix + "cluster-identity")
 
+// AutopilotClusterTaskID is the task ID for checking if any of the selected clusters is running in Autopilot mode.
+// The default implementation always returns false, and cluster types supporting Autopilot override it.
+var AutopilotClusterTaskID = taskid.NewDefaultImplementationID[bool](GoogleCloudCommonK8STaskIDPrefix + "autopilot-cluster")
+
+// ClusterIdentitiesTaskID is the task ID for getting the identities of all clusters selected in the inspection.
+// The cluster name input can contain multiple cluster names or glob patterns, and query tasks generate a log filter for each of the clusters.
+var ClusterIdentitiesTaskID = taskid.NewDefaultImplementationID[[]GoogleCloudClusterIdentity](GoogleCloudCommonK8STaskIDPrefix + "cluster-identities")
+
 // InputKindFilterTaskID is the task ID for the kind filter.
 var InputKindFilterTaskID = taskid.NewDefaultImplementationID[*queryutil.SetFilterParseResult](GoogleCloudCommonK8STaskIDPrefix + "input-kinds")
 
@@ -73,5 +92,15 @@ var InputNamespaceFilterTaskID = taskid.NewDefaultImplementationID[*queryutil.Se
 // InputNodeNameFilterTaskID receives space splitted node names to filter node specific logs.
 var InputNodeNameFilterTaskID = taskid.NewDefaultImplementationID[[]string](GoogleCloudCommonK8STaskIDPrefix + "input/node-name-filter")
 
+// InputNodePoolFilterTaskID receives node pool names to filter node specific logs.
+var InputNodePoolFilterTaskID = taskid.NewDefaultImplementationID[[]string](GoogleCloudCommonK8STaskIDPrefix + "input/node-pool-filter")
+
+// NodePoolNodeNameSubstringsTaskID is the task ID for the node name substrings matching the instances in the node pools given in the node pool filter.
+// This returns an empty list when no node pool is specified.
+var NodePoolNodeNameSubstringsTaskID = taskid.NewDefaultImplementationID[[]string](GoogleCloudCommonK8STaskIDPrefix + "node-pool-node-name-substrings")
+
 // NEGNamesDiscoveryTaskID is the task ID for extracting NEG names from audit logs.
 var NEGNamesDiscoveryTaskID = taskid.NewDefaultImplementationID[NEGNameToResourceIdentityMap](GoogleCloudCommonK8STaskIDPrefix + "neg-names-discovery")
+
+// NEGServicesTaskID is the task ID for resolving the Services owning NEGs from the ServiceNetworkEndpointGroup manifests in audit logs.
+var NEGServicesTaskID = taskid.NewDefaultImplementationID[NEGNameToServiceMap](GoogleCloudCommonK8STaskIDPrefix + "neg-services")
@@ -16,10 +16,14 @@ package googlecloudk8scommon_impl
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
+	"cloud.google.com/go/container/apiv1/containerpb"
 	"github.com/kyasbal/khi/pkg/api/googlecloud"
 	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
 	coretask "github.com/kyasbal/khi/pkg/core/task"
@@ -41,8 +45,27 @@ var AutocompleteMetricsK8sNodeTask = coretask.NewTask(googlecloudk8scommon_contr
 	return "kubernetes.io/anthos/up", nil
 })
 
-var AutocompleteClusterIdentityTask = inspectiontaskbase.NewCachedTask(googlecloudk8scommon_contract.AutocompleteClusterIdentityTaskID, []taskid.UntypedTaskReference{
+// AutocompleteFleetMembershipsTask is the default implementation of AutocompleteFleetMembershipsTaskID returning no fleet memberships.
+// This task is overriden for the cluster types registered to fleets.
+var AutocompleteFleetMembershipsTask = coretask.NewTask(googlecloudk8scommon_contract.AutocompleteFleetMembershipsTaskID, []taskid.UntypedTaskReference{}, func(ctx context.Context) (*inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudFleetMembership], error) {
+	return &inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudFleetMembership]{
+		Values: []googlecloudk8scommon_contract.GoogleCloudFleetMembership{},
+	}, nil
+})
+
+// AutocompleteLabeledClustersTask is the default implementation of AutocompleteLabeledClustersTaskID returning no clusters.
+// This task is overriden for the cluster types supporting the discovery by resource labels.
+var AutocompleteLabeledClustersTask = coretask.NewTask(googlecloudk8scommon_contract.AutocompleteLabeledClustersTaskID, []taskid.UntypedTaskReference{}, func(ctx context.Context) (*inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity], error) {
+	return &inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]{
+		Values: []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{},
+	}, nil
+})
+
+// AutocompleteClusterIdentityTask returns the clusters found in the metrics of the project and the clusters registered to the fleet of the project.
+var AutocompleteClusterIdentityTask = inspectiontaskbase.NewPersistentCachedTask(googlecloudk8scommon_contract.AutocompleteClusterIdentityTaskID, []taskid.UntypedTaskReference{
 	googlecloudk8scommon_contract.ClusterNamePrefixTaskRef,
+	googlecloudk8scommon_contract.AutocompleteFleetMembershipsTaskID.Ref(),
+	googlecloudk8scommon_contract.AutocompleteLabeledClustersTaskID.Ref(),
 	googlecloudcommon_contract.InputProjectIdTaskID.Ref(),
 	googlecloudcommon_contract.InputStartTimeTaskID.Ref(),
 	googlecloudcommon_contract.InputEndTimeTaskID.Ref(),
@@ -57,8 +80,10 @@ var AutocompleteClusterIdentityTask = inspectiontaskbase.NewCachedTask(googleclo
 	metricsType := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.AutocompleteMetricsK8sContainerTaskID.Ref())
 	cf := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientFactoryTaskID.Ref())
 	optionInjector := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientCallOptionsInjectorTaskID.Ref())
+	fleetMemberships := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.AutocompleteFleetMembershipsTaskID.Ref())
+	labeledClusters := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.AutocompleteLabeledClustersTaskID.Ref())
 
-	currentDigest := fmt.Sprintf("%s-%s-%d-%d", clusterNamePrefix, projectID, startTime.Unix(), endTime.Unix())
+	currentDigest := fmt.Sprintf("%s-%s-%d-%d-%d-%s", clusterNamePrefix, projectID, startTime.Unix(), endTime.Unix(), len(fleetMemberships.Values), clusterIdentitiesDigest(labeledClusters.Values))
 	if currentDigest == prevValue.DependencyDigest {
 		return prevValue, nil
 	}
@@ -92,7 +117,7 @@ var AutocompleteClusterIdentityTask = inspectiontaskbase.NewCachedTask(googleclo
 		errorString = err.Error()
 	}
 	metricsLabels = filterAndTrimPrefixFromClusterNames(metricsLabels, clusterNamePrefix)
-	if hintString == "" && errorString == "" && len(metricsLabels) == 0 {
+	if hintString == "" && errorString == "" && len(metricsLabels) == 0 && len(fleetMemberships.Values) == 0 && len(labeledClusters.Values) == 0 {
 		hintString = fmt.Sprintf("No cluster names found between %s and %s. It is highly likely that the time range is incorrect. Please verify the time range, or proceed by manually entering the cluster name.", startTime.Format(time.RFC3339), endTime.Format(time.RFC3339))
 	}
 
@@ -105,6 +130,8 @@ var AutocompleteClusterIdentityTask = inspectiontaskbase.NewCachedTask(googleclo
 			Location:          labels["location"],
 		}
 	}
+	identities = appendFleetMembershipClusters(identities, fleetMemberships.Values, clusterNamePrefix)
+	identities = appendClusters(identities, labeledClusters.Values, clusterNamePrefix)
 
 	return inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]]{
 		DependencyDigest: currentDigest,
@@ -116,6 +143,45 @@ var AutocompleteClusterIdentityTask = inspectiontaskbase.NewCachedTask(googleclo
 	}, nil
 })
 
+// appendFleetMembershipClusters appends the clusters registered to the fleet with the cluster name prefix to the identities found from metrics.
+// Clusters already included in the identities are not appended again.
+func appendFleetMembershipClusters(identities []googlecloudk8scommon_contract.GoogleCloudClusterIdentity, memberships []googlecloudk8scommon_contract.GoogleCloudFleetMembership, prefix string) []googlecloudk8scommon_contract.GoogleCloudClusterIdentity {
+	clusters := make([]googlecloudk8scommon_contract.GoogleCloudClusterIdentity, len(memberships))
+	for i, membership := range memberships {
+		clusters[i] = membership.Cluster
+	}
+	return appendClusters(identities, clusters, prefix)
+}
+
+// appendClusters appends the clusters with the given cluster type prefix not included in the identities yet.
+func appendClusters(identities []googlecloudk8scommon_contract.GoogleCloudClusterIdentity, clusters []googlecloudk8scommon_contract.GoogleCloudClusterIdentity, prefix string) []googlecloudk8scommon_contract.GoogleCloudClusterIdentity {
+	seen := map[string]struct{}{}
+	for _, identity := range identities {
+		seen[identity.ClusterName+"|"+identity.Location] = struct{}{}
+	}
+	for _, cluster := range clusters {
+		if cluster.ClusterTypePrefix != prefix {
+			continue
+		}
+		key := cluster.ClusterName + "|" + cluster.Location
+		if _, found := seen[key]; found {
+			continue
+		}
+		seen[key] = struct{}{}
+		identities = append(identities, cluster)
+	}
+	return identities
+}
+
+// clusterIdentitiesDigest returns a string identifying the given list of clusters.
+func clusterIdentitiesDigest(clusters []googlecloudk8scommon_contract.GoogleCloudClusterIdentity) string {
+	digests := make([]string, len(clusters))
+	for i, cluster := range clusters {
+		digests[i] = cluster.UniqueDigest()
+	}
+	return strings.Join(digests, ",")
+}
+
 // filterAndTrimPrefixFromClusterNames filters cluster names by prefix and trims the prefix from the filtered cluster names.
 func filterAndTrimPrefixFromClusterNames(metricsLabels []map[string]string, prefix string) []map[string]string {
 	filteredClusters := make([]map[string]string, 0, len(metricsLabels))
@@ -134,7 +200,7 @@ func filterAndTrimPrefixFromClusterNames(metricsLabels []map[string]string, pref
 }
 
 // AutocompleteLocationForClusterTask returns the location for the given cluster name.
-var AutocompleteLocationForClusterTask = inspectiontaskbase.NewCachedTask(googlecloudk8scommon_contract.AutocompleteLocationForClusterTaskID, []taskid.UntypedTaskReference{
+var AutocompleteLocationForClusterTask = inspectiontaskbase.NewPersistentCachedTask(googlecloudk8scommon_contract.AutocompleteLocationForClusterTaskID, []taskid.UntypedTaskReference{
 	googlecloudk8scommon_contract.InputClusterNameTaskID.Ref(), // This task must not depend on ClusterIdentity because this autocomplete will generate the source of it.
 	googlecloudcommon_contract.InputProjectIdTaskID.Ref(),
 	googlecloudcommon_contract.InputStartTimeTaskID.Ref(),
@@ -199,26 +265,28 @@ var AutocompleteLocationForClusterTask = inspectiontaskbase.NewCachedTask(google
 	}, nil
 }, coretask.WithSelectionPriority(500))
 
-var AutocompleteNamespacesTask = inspectiontaskbase.NewCachedTask(googlecloudk8scommon_contract.AutocompleteNamespacesTaskID, []taskid.UntypedTaskReference{
-	googlecloudk8scommon_contract.ClusterIdentityTaskID.Ref(),
+// AutocompleteNamespacesTask lists the namespaces of the selected clusters from the metrics.
+var AutocompleteNamespacesTask = inspectiontaskbase.NewPersistentCachedTask(googlecloudk8scommon_contract.AutocompleteNamespacesTaskID, []taskid.UntypedTaskReference{
+	googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref(),
 	googlecloudcommon_contract.InputStartTimeTaskID.Ref(),
 	googlecloudcommon_contract.InputEndTimeTaskID.Ref(),
 	googlecloudcommon_contract.APIClientFactoryTaskID.Ref(),
 	googlecloudcommon_contract.APIClientCallOptionsInjectorTaskID.Ref(),
 	googlecloudk8scommon_contract.AutocompleteMetricsK8sContainerTaskID.Ref(),
 }, func(ctx context.Context, prevValue inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[string]]) (inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[string]], error) {
-	cluster := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.ClusterIdentityTaskID.Ref())
+	clusters := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref())
 	startTime := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputStartTimeTaskID.Ref())
 	endTime := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputEndTimeTaskID.Ref())
 	metricsType := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.AutocompleteMetricsK8sContainerTaskID.Ref())
 	cf := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientFactoryTaskID.Ref())
 	optionInjector := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientCallOptionsInjectorTaskID.Ref())
 
-	currentDigest := fmt.Sprintf("%s-%d-%d", cluster.UniqueDigest(), startTime.Unix(), endTime.Unix())
+	clusters = clustersWithProject(clusters)
+	currentDigest := fmt.Sprintf("%s-%d-%d", clusterIdentitiesDigest(clusters), startTime.Unix(), endTime.Unix())
 	if currentDigest == prevValue.DependencyDigest {
 		return prevValue, nil
 	}
-	if cluster.ProjectID == "" {
+	if len(clusters) == 0 {
 		return inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[string]]{
 			Value: &inspectioncore_contract.AutocompleteResult[string]{
 				Values: []string{},
@@ -235,15 +303,7 @@ var AutocompleteNamespacesTask = inspectiontaskbase.NewCachedTask(googlecloudk8s
 		hintString = "The end time is more than 24 months ago. Suggested namespace names may not be complete."
 	}
 
-	client, err := cf.MonitoringMetricClient(ctx, googlecloud.Project(cluster.ProjectID))
-	if err != nil {
-		return prevValue, fmt.Errorf("failed to create monitoring metric client: %w", err)
-	}
-	defer client.Close()
-
-	ctx = optionInjector.InjectToCallContext(ctx, googlecloud.Project(cluster.ProjectID))
-	filter := fmt.Sprintf(`metric.type="%s" AND resource.type="k8s_container" AND resource.labels.cluster_name="%s" AND resource.labels.location="%s"`, metricsType, cluster.ClusterName, cluster.Location)
-	namespaces, err := googlecloud.QueryDistinctStringLabelValuesFromMetrics(ctx, client, cluster.ProjectID, filter, startTime, endTime, "resource.labels.namespace_name", "namespace_name")
+	namespaces, err := queryDistinctLabelValuesOfClusters(ctx, cf, optionInjector, clusters, metricsType, "k8s_container", startTime, endTime, "namespace_name")
 	if err != nil {
 		errorString = err.Error()
 	}
@@ -260,8 +320,9 @@ var AutocompleteNamespacesTask = inspectiontaskbase.NewCachedTask(googlecloudk8s
 	}, nil
 })
 
-var AutocompletePodNamesTask = inspectiontaskbase.NewCachedTask(googlecloudk8scommon_contract.AutocompletePodNamesTaskID, []taskid.UntypedTaskReference{
-	googlecloudk8scommon_contract.ClusterIdentityTaskID.Ref(),
+// AutocompletePodNamesTask lists the pod names of the selected clusters from the metrics.
+var AutocompletePodNamesTask = inspectiontaskbase.NewPersistentCachedTask(googlecloudk8scommon_contract.AutocompletePodNamesTaskID, []taskid.UntypedTaskReference{
+	googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref(),
 	googlecloudcommon_contract.InputStartTimeTaskID.Ref(),
 	googlecloudcommon_contract.InputEndTimeTaskID.Ref(),
 	googlecloudcommon_contract.APIClientFactoryTaskID.Ref(),
@@ -270,13 +331,14 @@ var AutocompletePodNamesTask = inspectiontaskbase.NewCachedTask(googlecloudk8sco
 }, func(ctx context.Context, prevValue inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[string]]) (inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[string]], error) {
 	startTime := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputStartTimeTaskID.Ref())
 	endTime := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputEndTimeTaskID.Ref())
-	cluster := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.ClusterIdentityTaskID.Ref())
+	clusters := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref())
 	metricsType := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.AutocompleteMetricsK8sContainerTaskID.Ref())
 	cf := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientFactoryTaskID.Ref())
 	optionInjector := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientCallOptionsInjectorTaskID.Ref())
 
-	currentDigest := fmt.Sprintf("%s-%d-%d", cluster.UniqueDigest(), startTime.Unix(), endTime.Unix())
-	if cluster.ProjectID != "" && currentDigest == prevValue.DependencyDigest {
+	clusters = clustersWithProject(clusters)
+	currentDigest := fmt.Sprintf("%s-%d-%d", clusterIdentitiesDigest(clusters), startTime.Unix(), endTime.Unix())
+	if len(clusters) > 0 && currentDigest == prevValue.DependencyDigest {
 		return prevValue, nil
 	}
 
@@ -286,15 +348,7 @@ var AutocompletePodNamesTask = inspectiontaskbase.NewCachedTask(googlecloudk8sco
 		hintString = "The end time is more than 24 months ago. Suggested pod names may not be complete."
 	}
 
-	client, err := cf.MonitoringMetricClient(ctx, googlecloud.Project(cluster.ProjectID))
-	if err != nil {
-		return prevValue, fmt.Errorf("failed to create monitoring metric client: %w", err)
-	}
-	defer client.Close()
-
-	ctx = optionInjector.InjectToCallContext(ctx, googlecloud.Project(cluster.ProjectID))
-	filter := fmt.Sprintf(`metric.type="%s" AND resource.type="k8s_container" AND resource.labels.cluster_name="%s" AND resource.labels.location="%s"`, metricsType, cluster.ClusterName, cluster.Location)
-	podNames, err := googlecloud.QueryDistinctStringLabelValuesFromMetrics(ctx, client, cluster.ProjectID, filter, startTime, endTime, "resource.labels.pod_name", "pod_name")
+	podNames, err := queryDistinctLabelValuesOfClusters(ctx, cf, optionInjector, clusters, metricsType, "k8s_container", startTime, endTime, "pod_name")
 	if err != nil {
 		errorString = err.Error()
 	}
@@ -311,8 +365,9 @@ var AutocompletePodNamesTask = inspectiontaskbase.NewCachedTask(googlecloudk8sco
 	}, nil
 })
 
-var AutocompleteNodeNamesTask = inspectiontaskbase.NewCachedTask(googlecloudk8scommon_contract.AutocompleteNodeNamesTaskID, []taskid.UntypedTaskReference{
-	googlecloudk8scommon_contract.ClusterIdentityTaskID.Ref(),
+// AutocompleteNodeNamesTask lists the node names of the selected clusters from the metrics.
+var AutocompleteNodeNamesTask = inspectiontaskbase.NewPersistentCachedTask(googlecloudk8scommon_contract.AutocompleteNodeNamesTaskID, []taskid.UntypedTaskReference{
+	googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref(),
 	googlecloudcommon_contract.InputStartTimeTaskID.Ref(),
 	googlecloudcommon_contract.InputEndTimeTaskID.Ref(),
 	googlecloudcommon_contract.APIClientFactoryTaskID.Ref(),
@@ -321,13 +376,14 @@ var AutocompleteNodeNamesTask = inspectiontaskbase.NewCachedTask(googlecloudk8sc
 }, func(ctx context.Context, prevValue inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[string]]) (inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[string]], error) {
 	startTime := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputStartTimeTaskID.Ref())
 	endTime := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputEndTimeTaskID.Ref())
-	cluster := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.ClusterIdentityTaskID.Ref())
+	clusters := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref())
 	metricsType := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.AutocompleteMetricsK8sNodeTaskID.Ref())
 	cf := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientFactoryTaskID.Ref())
 	optionInjector := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientCallOptionsInjectorTaskID.Ref())
 
-	currentDigest := fmt.Sprintf("%s-%d-%d", cluster.UniqueDigest(), startTime.Unix(), endTime.Unix())
-	if cluster.ProjectID != "" && currentDigest == prevValue.DependencyDigest {
+	clusters = clustersWithProject(clusters)
+	currentDigest := fmt.Sprintf("%s-%d-%d", clusterIdentitiesDigest(clusters), startTime.Unix(), endTime.Unix())
+	if len(clusters) > 0 && currentDigest == prevValue.DependencyDigest {
 		return prevValue, nil
 	}
 
@@ -337,15 +393,7 @@ var AutocompleteNodeNamesTask = inspectiontaskbase.NewCachedTask(googlecloudk8sc
 		hintString = "The end time is more than 24 months ago. Suggested namespace names may not be complete."
 	}
 
-	client, err := cf.MonitoringMetricClient(ctx, googlecloud.Project(cluster.ProjectID))
-	if err != nil {
-		return prevValue, fmt.Errorf("failed to create monitoring metric client: %w", err)
-	}
-	defer client.Close()
-
-	ctx = optionInjector.InjectToCallContext(ctx, googlecloud.Project(cluster.ProjectID))
-	filter := fmt.Sprintf(`metric.type="%s" AND resource.type="k8s_node" AND resource.labels.cluster_name="%s" AND resource.labels.location="%s"`, metricsType, cluster.ClusterName, cluster.Location)
-	nodes, err := googlecloud.QueryDistinctStringLabelValuesFromMetrics(ctx, client, cluster.ProjectID, filter, startTime, endTime, "resource.labels.node_name", "node_name")
+	nodes, err := queryDistinctLabelValuesOfClusters(ctx, cf, optionInjector, clusters, metricsType, "k8s_node", startTime, endTime, "node_name")
 	if err != nil {
 		errorString = err.Error()
 	}
@@ -361,3 +409,127 @@ var AutocompleteNodeNamesTask = inspectiontaskbase.NewCachedTask(googlecloudk8sc
 		},
 	}, nil
 })
+
+// clustersWithProject returns the clusters with their projects. The metrics of the clusters without projects can't be queried.
+func clustersWithProject(clusters []googlecloudk8scommon_contract.GoogleCloudClusterIdentity) []googlecloudk8scommon_contract.GoogleCloudClusterIdentity {
+	result := []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{}
+	for _, cluster := range clusters {
+		if cluster.ProjectID != "" {
+			result = append(result, cluster)
+		}
+	}
+	return result
+}
+
+// queryDistinctLabelValuesOfClusters returns the sorted distinct values of the resource label in the metrics of all the given clusters.
+// The values found in the other clusters are still returned when the query failed for some of the clusters, with the joined error.
+func queryDistinctLabelValuesOfClusters(ctx context.Context, cf *googlecloud.ClientFactory, optionInjector *googlecloud.CallOptionInjector, clusters []googlecloudk8scommon_contract.GoogleCloudClusterIdentity, metricsType string, resourceType string, startTime, endTime time.Time, labelKey string) ([]string, error) {
+	uniqueValues := map[string]struct{}{}
+	var errs []error
+	for _, cluster := range clusters {
+		values, err := queryDistinctLabelValuesOfCluster(ctx, cf, optionInjector, cluster, metricsType, resourceType, startTime, endTime, labelKey)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, value := range values {
+			uniqueValues[value] = struct{}{}
+		}
+	}
+	result := slices.Sorted(maps.Keys(uniqueValues))
+	return result, errors.Join(errs...)
+}
+
+// queryDistinctLabelValuesOfCluster returns the distinct values of the resource label in the metrics of the cluster.
+func queryDistinctLabelValuesOfCluster(ctx context.Context, cf *googlecloud.ClientFactory, optionInjector *googlecloud.CallOptionInjector, cluster googlecloudk8scommon_contract.GoogleCloudClusterIdentity, metricsType string, resourceType string, startTime, endTime time.Time, labelKey string) ([]string, error) {
+	client, err := cf.MonitoringMetricClient(ctx, googlecloud.Project(cluster.ProjectID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create monitoring metric client: %w", err)
+	}
+	defer client.Close()
+
+	ctx = optionInjector.InjectToCallContext(ctx, googlecloud.Project(cluster.ProjectID))
+	filter := fmt.Sprintf(`metric.type="%s" AND resource.type="%s" AND resource.labels.cluster_name="%s" AND resource.labels.location="%s"`, metricsType, resourceType, cluster.ClusterName, cluster.Location)
+	return googlecloud.QueryDistinctStringLabelValuesFromMetrics(ctx, client, cluster.ProjectID, filter, startTime, endTime, "resource.labels."+labelKey, labelKey)
+}
+
+// AutocompleteNodePoolsTask lists the node pools of the selected clusters from the GKE API.
+// Node pools with the same name in different clusters are merged into one candidate.
+var AutocompleteNodePoolsTask = inspectiontaskbase.NewPersistentCachedTask(googlecloudk8scommon_contract.AutocompleteNodePoolsTaskID, []taskid.UntypedTaskReference{
+	googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref(),
+	googlecloudcommon_contract.APIClientFactoryTaskID.Ref(),
+	googlecloudcommon_contract.APIClientCallOptionsInjectorTaskID.Ref(),
+}, func(ctx context.Context, prevValue inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudNodePool]]) (inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudNodePool]], error) {
+	clusters := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref())
+	cf := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientFactoryTaskID.Ref())
+	optionInjector := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientCallOptionsInjectorTaskID.Ref())
+
+	gkeClusters := []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{}
+	digests := []string{}
+	for _, cluster := range clusters {
+		// Node pools of GKE on AWS or Azure are not available from the GKE API.
+		if cluster.ProjectID == "" || cluster.ClusterTypePrefix != "" {
+			continue
+		}
+		gkeClusters = append(gkeClusters, cluster)
+		digests = append(digests, cluster.UniqueDigest())
+	}
+	currentDigest := strings.Join(digests, ",")
+	if currentDigest == prevValue.DependencyDigest && prevValue.Value != nil {
+		return prevValue, nil
+	}
+	if len(gkeClusters) == 0 {
+		return inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudNodePool]]{
+			Value: &inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudNodePool]{
+				Values: []googlecloudk8scommon_contract.GoogleCloudNodePool{},
+				Error:  "",
+				Hint:   "Node pool names are suggested only for GKE clusters after the project ID and the cluster name are provided.",
+			},
+			DependencyDigest: currentDigest,
+		}, nil
+	}
+
+	projectID := gkeClusters[0].ProjectID
+	client, err := cf.ContainerClusterManagerClient(ctx, googlecloud.Project(projectID))
+	if err != nil {
+		return prevValue, fmt.Errorf("failed to create cluster manager client: %w", err)
+	}
+	defer client.Close()
+
+	ctx = optionInjector.InjectToCallContext(ctx, googlecloud.Project(projectID))
+	errorString := ""
+	nodePools := []googlecloudk8scommon_contract.GoogleCloudNodePool{}
+	nodePoolIndices := map[string]int{}
+	for _, cluster := range gkeClusters {
+		resp, err := client.ListNodePools(ctx, &containerpb.ListNodePoolsRequest{
+			Parent: fmt.Sprintf("projects/%s/locations/%s/clusters/%s", cluster.ProjectID, cluster.Location, cluster.ClusterName),
+		})
+		if err != nil {
+			errorString = err.Error()
+			continue
+		}
+		for _, nodePool := range resp.GetNodePools() {
+			index, found := nodePoolIndices[nodePool.GetName()]
+			if !found {
+				index = len(nodePools)
+				nodePoolIndices[nodePool.GetName()] = index
+				nodePools = append(nodePools, googlecloudk8scommon_contract.GoogleCloudNodePool{Name: nodePool.GetName()})
+			}
+			for _, instanceGroupURL := range nodePool.GetInstanceGroupUrls() {
+				nodePools[index].InstanceNamePrefixes = append(nodePools[index].InstanceNamePrefixes, googlecloudk8scommon_contract.InstanceNamePrefixFromInstanceGroupURL(instanceGroupURL))
+			}
+		}
+	}
+	hintString := ""
+	if errorString == "" && len(nodePools) == 0 {
+		hintString = "No node pools found in the selected clusters. The clusters may be deleted already, or proceed by manually entering the node pool name."
+	}
+	return inspectiontaskbase.CacheableTaskResult[*inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudNodePool]]{
+		DependencyDigest: currentDigest,
+		Value: &inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudNodePool]{
+			Values: nodePools,
+			Error:  errorString,
+			Hint:   hintString,
+		},
+	}, nil
+})
@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
 )
 
 func TestFilterAndTrimPrefixFromClusterNames(t *testing.T) {
@@ -71,3 +72,36 @@ func TestFilterAndTrimPrefixFromClusterNames(t *testing.T) {
 		})
 	}
 }
+
+func TestAppendFleetMembershipClusters(t *testing.T) {
+	identities := []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{
+		{ProjectID: "fleet-project", ClusterName: "foo-cluster", Location: "us-central1"},
+	}
+	memberships := []googlecloudk8scommon_contract.GoogleCloudFleetMembership{
+		{
+			MembershipName: "projects/fleet-project/locations/us-central1/memberships/foo-cluster",
+			FleetProjectID: "fleet-project",
+			Cluster:        googlecloudk8scommon_contract.GoogleCloudClusterIdentity{ProjectID: "fleet-project", ClusterName: "foo-cluster", Location: "us-central1"},
+		},
+		{
+			MembershipName: "projects/fleet-project/locations/asia-northeast1/memberships/bar-cluster",
+			FleetProjectID: "fleet-project",
+			Cluster:        googlecloudk8scommon_contract.GoogleCloudClusterIdentity{ProjectID: "other-project", ClusterName: "bar-cluster", Location: "asia-northeast1"},
+		},
+		{
+			MembershipName: "projects/fleet-project/locations/global/memberships/aws-cluster",
+			FleetProjectID: "fleet-project",
+			Cluster:        googlecloudk8scommon_contract.GoogleCloudClusterIdentity{ProjectID: "fleet-project", ClusterTypePrefix: "awsClusters/", ClusterName: "aws-cluster", Location: "us-east-1"},
+		},
+	}
+	want := []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{
+		{ProjectID: "fleet-project", ClusterName: "foo-cluster", Location: "us-central1"},
+		{ProjectID: "other-project", ClusterName: "bar-cluster", Location: "asia-northeast1"},
+	}
+
+	got := appendFleetMembershipClusters(identities, memberships, "")
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("appendFleetMembershipClusters() mismatch (-want +got):\n%s", diff)
+	}
+}
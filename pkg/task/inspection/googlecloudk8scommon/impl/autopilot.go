@@ -0,0 +1,29 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudk8scommon_impl
+
+import (
+	"context"
+
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+)
+
+// AutopilotClusterTask is the default implementation of AutopilotClusterTaskID.
+// Autopilot is a mode of GKE, so clusters of the other cluster types are never Autopilot clusters.
+var AutopilotClusterTask = coretask.NewTask(googlecloudk8scommon_contract.AutopilotClusterTaskID, []taskid.UntypedTaskReference{}, func(ctx context.Context) (bool, error) {
+	return false, nil
+})
@@ -17,9 +17,11 @@ package googlecloudk8scommon_impl
 import (
 	"context"
 
+	"github.com/kyasbal/khi/pkg/common/khictx"
 	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
 	coretask "github.com/kyasbal/khi/pkg/core/task"
 	taskid "github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/log"
 	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
 	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
 	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
@@ -42,3 +44,84 @@ var ClusterIdentityTask = inspectiontaskbase.NewInspectionTask(googlecloudk8scom
 		Location:          location,
 	}, nil
 })
+
+// ClusterIdentitiesTask expands the cluster names and glob patterns given in the cluster name input to the list of cluster identities.
+// A glob pattern is expanded to the clusters matching it in the autocomplete list with their own projects and locations, and a plain cluster name is used as is with the location given in the form.
+// A plain cluster name only found in the other projects, e.g. discovered by resource labels, is resolved to the clusters in these projects.
+// When multiple clusters are selected, the resource paths written to the history are scoped to the cluster of each log not to mix the timelines of the resources with the same name in different clusters.
+var ClusterIdentitiesTask = inspectiontaskbase.NewInspectionTask(googlecloudk8scommon_contract.ClusterIdentitiesTaskID, []taskid.UntypedTaskReference{
+	googlecloudk8scommon_contract.ClusterIdentityTaskID.Ref(),
+	googlecloudk8scommon_contract.AutocompleteClusterIdentityTaskID.Ref(),
+}, func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) ([]googlecloudk8scommon_contract.GoogleCloudClusterIdentity, error) {
+	cluster := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.ClusterIdentityTaskID.Ref())
+	autocompleteClusters := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.AutocompleteClusterIdentityTaskID.Ref())
+
+	result := []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{}
+	seen := map[string]struct{}{}
+	add := func(identity googlecloudk8scommon_contract.GoogleCloudClusterIdentity) {
+		if _, found := seen[identity.UniqueDigest()]; found {
+			return
+		}
+		seen[identity.UniqueDigest()] = struct{}{}
+		result = append(result, identity)
+	}
+	for _, pattern := range splitClusterNamePatterns(cluster.ClusterName) {
+		if !isClusterNameGlobPattern(pattern) {
+			otherProjectClusters := clustersOnlyInOtherProjects(pattern, cluster.ProjectID, autocompleteClusters.Values)
+			if len(otherProjectClusters) == 0 {
+				identity := cluster
+				identity.ClusterName = pattern
+				add(identity)
+				continue
+			}
+			for _, candidate := range otherProjectClusters {
+				add(candidateToClusterIdentity(cluster, candidate))
+			}
+			continue
+		}
+		for _, candidate := range autocompleteClusters.Values {
+			if matchClusterNamePattern(pattern, candidate.NameWithClusterTypePrefix()) {
+				add(candidateToClusterIdentity(cluster, candidate))
+			}
+		}
+	}
+	if taskMode == inspectioncore_contract.TaskModeRun && len(result) > 1 {
+		builder := khictx.MustGetValue(ctx, inspectioncore_contract.CurrentHistoryBuilder)
+		builder.SetClusterScopeResolver(clusterNameOfLog)
+	}
+	return result, nil
+})
+
+// clusterNameOfLog returns the cluster name in the monitored resource labels of the log. It returns an empty string for logs not associated with a cluster.
+func clusterNameOfLog(l *log.Log) string {
+	return l.ReadStringOrDefault("resource.labels.cluster_name", "")
+}
+
+// candidateToClusterIdentity returns the identity of the cluster in the autocomplete list. The project given in the form is used when the cluster doesn't have its project.
+func candidateToClusterIdentity(cluster googlecloudk8scommon_contract.GoogleCloudClusterIdentity, candidate googlecloudk8scommon_contract.GoogleCloudClusterIdentity) googlecloudk8scommon_contract.GoogleCloudClusterIdentity {
+	projectID := candidate.ProjectID
+	if projectID == "" {
+		projectID = cluster.ProjectID
+	}
+	return googlecloudk8scommon_contract.GoogleCloudClusterIdentity{
+		ProjectID:         projectID,
+		ClusterTypePrefix: cluster.ClusterTypePrefix,
+		ClusterName:       candidate.NameWithClusterTypePrefix(),
+		Location:          candidate.Location,
+	}
+}
+
+// clustersOnlyInOtherProjects returns the clusters with the given name in the autocomplete list when none of them is in the given project.
+func clustersOnlyInOtherProjects(clusterName string, projectID string, candidates []googlecloudk8scommon_contract.GoogleCloudClusterIdentity) []googlecloudk8scommon_contract.GoogleCloudClusterIdentity {
+	result := []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{}
+	for _, candidate := range candidates {
+		if candidate.NameWithClusterTypePrefix() != clusterName {
+			continue
+		}
+		if candidate.ProjectID == "" || candidate.ProjectID == projectID {
+			return nil
+		}
+		result = append(result, candidate)
+	}
+	return result
+}
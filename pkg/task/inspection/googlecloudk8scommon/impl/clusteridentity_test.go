@@ -0,0 +1,128 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudk8scommon_impl
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+func TestClusterIdentitiesTask(t *testing.T) {
+	autocompleteClusters := &inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]{
+		Values: []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{
+			{ProjectID: "foo-project", ClusterTypePrefix: "awsClusters/", ClusterName: "prod-a", Location: "us-central1"},
+			{ProjectID: "foo-project", ClusterTypePrefix: "awsClusters/", ClusterName: "prod-b", Location: "asia-northeast1"},
+			{ProjectID: "foo-project", ClusterTypePrefix: "awsClusters/", ClusterName: "dev-a", Location: "us-central1"},
+			{ProjectID: "bar-project", ClusterTypePrefix: "awsClusters/", ClusterName: "payments-x", Location: "europe-west1"},
+		},
+	}
+	clusterLog, err := log.NewLogFromYAMLString("resource:\n  labels:\n    cluster_name: awsClusters/prod-a\n")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	testCases := []struct {
+		name        string
+		clusterName string
+		want        []googlecloudk8scommon_contract.GoogleCloudClusterIdentity
+	}{
+		{
+			name:        "single cluster",
+			clusterName: "awsClusters/dev-a",
+			want: []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{
+				{ProjectID: "foo-project", ClusterTypePrefix: "awsClusters/", ClusterName: "awsClusters/dev-a", Location: "us-west1"},
+			},
+		},
+		{
+			name:        "multiple clusters",
+			clusterName: "awsClusters/dev-a awsClusters/not-in-list",
+			want: []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{
+				{ProjectID: "foo-project", ClusterTypePrefix: "awsClusters/", ClusterName: "awsClusters/dev-a", Location: "us-west1"},
+				{ProjectID: "foo-project", ClusterTypePrefix: "awsClusters/", ClusterName: "awsClusters/not-in-list", Location: "us-west1"},
+			},
+		},
+		{
+			name:        "glob pattern expands to the clusters with their own locations",
+			clusterName: "awsClusters/prod-*",
+			want: []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{
+				{ProjectID: "foo-project", ClusterTypePrefix: "awsClusters/", ClusterName: "awsClusters/prod-a", Location: "us-central1"},
+				{ProjectID: "foo-project", ClusterTypePrefix: "awsClusters/", ClusterName: "awsClusters/prod-b", Location: "asia-northeast1"},
+			},
+		},
+		{
+			name:        "duplicated clusters are removed",
+			clusterName: "awsClusters/prod-? awsClusters/*-a",
+			want: []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{
+				{ProjectID: "foo-project", ClusterTypePrefix: "awsClusters/", ClusterName: "awsClusters/prod-a", Location: "us-central1"},
+				{ProjectID: "foo-project", ClusterTypePrefix: "awsClusters/", ClusterName: "awsClusters/prod-b", Location: "asia-northeast1"},
+				{ProjectID: "foo-project", ClusterTypePrefix: "awsClusters/", ClusterName: "awsClusters/dev-a", Location: "us-central1"},
+			},
+		},
+		{
+			name:        "glob pattern expands to the clusters in other projects",
+			clusterName: "awsClusters/payments-*",
+			want: []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{
+				{ProjectID: "bar-project", ClusterTypePrefix: "awsClusters/", ClusterName: "awsClusters/payments-x", Location: "europe-west1"},
+			},
+		},
+		{
+			name:        "cluster name only found in another project uses the project",
+			clusterName: "awsClusters/payments-x",
+			want: []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{
+				{ProjectID: "bar-project", ClusterTypePrefix: "awsClusters/", ClusterName: "awsClusters/payments-x", Location: "europe-west1"},
+			},
+		},
+		{
+			name:        "glob pattern not matching any cluster",
+			clusterName: "awsClusters/staging-*",
+			want:        []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+			got, _, err := inspectiontest.RunInspectionTask(ctx, ClusterIdentitiesTask, inspectioncore_contract.TaskModeRun, map[string]any{},
+				tasktest.NewTaskDependencyValuePair(googlecloudk8scommon_contract.ClusterIdentityTaskID.Ref(), googlecloudk8scommon_contract.GoogleCloudClusterIdentity{
+					ProjectID:         "foo-project",
+					ClusterTypePrefix: "awsClusters/",
+					ClusterName:       tc.clusterName,
+					Location:          "us-west1",
+				}),
+				tasktest.NewTaskDependencyValuePair(googlecloudk8scommon_contract.AutocompleteClusterIdentityTaskID.Ref(), autocompleteClusters),
+			)
+			if err != nil {
+				t.Fatalf("RunInspectionTask() returned an unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ClusterIdentitiesTask returned an unexpected result (-want +got):\n%s", diff)
+			}
+			// Resource paths are scoped to clusters only when multiple clusters are selected.
+			wantScope := ""
+			if len(tc.want) > 1 {
+				wantScope = "awsClusters/prod-a"
+			}
+			builder := khictx.MustGetValue(ctx, inspectioncore_contract.CurrentHistoryBuilder)
+			if gotScope := builder.ClusterScopeOf(clusterLog); gotScope != wantScope {
+				t.Errorf("ClusterScopeOf() = %q, want %q", gotScope, wantScope)
+			}
+		})
+	}
+}
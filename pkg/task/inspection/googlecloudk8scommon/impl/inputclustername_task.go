@@ -17,9 +17,12 @@ package googlecloudk8scommon_impl
 import (
 	"context"
 	"fmt"
+	"path"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
+	"unicode"
 
 	"github.com/kyasbal/khi/pkg/common"
 	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
@@ -30,28 +33,38 @@ import (
 	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
 )
 
-var clusterNameValidator = regexp.MustCompile(`^\s*[0-9a-z\-]+\s*$`)
+var clusterNameValidator = regexp.MustCompile(`^[0-9a-z\-\*\?]+$`)
 
 // InputClusterNameTask is a form task receving cluster name from the user.
 // This task return the cluster name with the prefixes defined from the cluster type. For example, a cluster named foo-cluster is `foo-cluster` in GKE but `awsCluster/foo-cluster` in GKE on AWS.
+// Multiple cluster names or glob patterns can be given separated by spaces or commas. These are returned joined with a space, and ClusterIdentitiesTask expands them to the list of clusters.
+// The tasks depending on the selected clusters must use ClusterIdentitiesTask instead of this value.
 // This input also supports autocomplete cluster names from some task having ID for googlecloudk8scommon_contract.AutocompleteClusterNamesTaskID.
-var InputClusterNameTask = formtask.NewTextFormTaskBuilder(googlecloudk8scommon_contract.InputClusterNameTaskID, googlecloudcommon_contract.PriorityForResourceIdentifierGroup+4000, "Cluster name").
-	WithDependencies([]taskid.UntypedTaskReference{googlecloudk8scommon_contract.AutocompleteClusterIdentityTaskID.Ref(), googlecloudk8scommon_contract.ClusterNamePrefixTaskRef}).
-	WithDescription("The cluster name to gather logs.").
+// The clusters discovered by their resource labels are suggested in a separate group listed first, and the first of them is used as the default value.
+var InputClusterNameTask = formtask.NewTextFormTaskBuilder(googlecloudk8scommon_contract.InputClusterNameTaskID, 0, "Cluster name").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionResourceIdentifier, After: []string{googlecloudcommon_contract.InputProjectIdTaskID.ReferenceIDString()}, Before: []string{googlecloudcommon_contract.InputLocationsTaskID.ReferenceIDString()}}).
+	WithDependencies([]taskid.UntypedTaskReference{googlecloudk8scommon_contract.AutocompleteClusterIdentityTaskID.Ref(), googlecloudk8scommon_contract.AutocompleteLabeledClustersTaskID.Ref(), googlecloudk8scommon_contract.ClusterNamePrefixTaskRef}).
+	WithPlaceholder("e.g. my-cluster").
+	WithDescription("The cluster name to gather logs. Multiple clusters can be specified by separating them with spaces or commas, and glob patterns (e.g. prod-*) select all the matching clusters.").
 	WithDefaultValueFunc(func(ctx context.Context, previousValues []string) (string, error) {
 		clusters := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.AutocompleteClusterIdentityTaskID.Ref())
+		labeledClusters := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.AutocompleteLabeledClustersTaskID.Ref())
 		// If the previous value is included in the list of cluster names, the name is used as the default value.
 		if len(previousValues) > 0 && hasClusterNameInAutocomplete(clusters.Values, previousValues[0]) {
 			return previousValues[0], nil
 		}
+		if len(labeledClusters.Values) > 0 {
+			return labeledClusters.Values[0].ClusterName, nil
+		}
 		if len(clusters.Values) == 0 {
 			return "", nil
 		}
 		return clusters.Values[0].ClusterName, nil
 	}).
-	WithSuggestionsFunc(func(ctx context.Context, value string, previousValues []string) ([]string, error) {
+	WithSuggestionItemsFunc(func(ctx context.Context, value string, previousValues []string) ([]inspectionmetadata.TextParameterFormFieldSuggestion, error) {
 		clusters := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.AutocompleteClusterIdentityTaskID.Ref())
-		return common.SortForAutocomplete(value, dedupeClusterName(clusters.Values)), nil
+		labeledClusters := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.AutocompleteLabeledClustersTaskID.Ref())
+		return groupLabeledClusterSuggestions(clusterNameSuggestions(value, clusters.Values), labeledClusters.Values), nil
 	}).
 	WithHintFunc(func(ctx context.Context, value string, convertedValue any) (string, inspectionmetadata.ParameterHintType, error) {
 		clusters := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.AutocompleteClusterIdentityTaskID.Ref())
@@ -62,36 +75,84 @@ var InputClusterNameTask = formtask.NewTextFormTaskBuilder(googlecloudk8scommon_
 		if clusters.Hint != "" {
 			return clusters.Hint, inspectionmetadata.Info, nil
 		}
-		for _, suggestedCluster := range clusters.Values {
-			if suggestedCluster.NameWithClusterTypePrefix() == convertedValue.(string) {
-				return "", inspectionmetadata.Info, nil
+		prefix := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.ClusterNamePrefixTaskRef)
+		patterns := splitClusterNamePatterns(value)
+		notFoundClusterNames := []string{}
+		for _, pattern := range patterns {
+			found := slices.ContainsFunc(clusters.Values, func(cluster googlecloudk8scommon_contract.GoogleCloudClusterIdentity) bool {
+				return matchClusterNamePattern(prefix+pattern, cluster.NameWithClusterTypePrefix())
+			})
+			if !found {
+				notFoundClusterNames = append(notFoundClusterNames, pattern)
+			}
+		}
+		if len(notFoundClusterNames) == 0 {
+			if len(patterns) > 1 || slices.ContainsFunc(patterns, isClusterNameGlobPattern) {
+				return "Logs are gathered from all the selected clusters. Timelines are separated by clusters with the cluster name prefixed to the namespace (e.g. my-cluster/default).", inspectionmetadata.Info, nil
 			}
+			return "", inspectionmetadata.Info, nil
 		}
 		availableClusterNameStr := ""
 		for _, cluster := range dedupeClusterName(clusters.Values) {
 			availableClusterNameStr += fmt.Sprintf("* %s\n", cluster)
 		}
-		return fmt.Sprintf("Cluster '%s' was not found in the specified project at this time. It works for the clusters existed in the past but make sure the cluster name is right if you believe the cluster should be there.\nAvailable cluster names:\n%s", value, availableClusterNameStr), inspectionmetadata.Warning, nil
+		return fmt.Sprintf("Cluster '%s' was not found in the specified project at this time. It works for the clusters existed in the past but make sure the cluster name is right if you believe the cluster should be there.\nAvailable cluster names:\n%s", strings.Join(notFoundClusterNames, "', '"), availableClusterNameStr), inspectionmetadata.Warning, nil
 	}).
 	WithValidator(func(ctx context.Context, value string) (string, error) {
-		if !clusterNameValidator.Match([]byte(value)) {
-			return "Cluster name must match `^[0-9a-z:\\-]+$`", nil
+		patterns := splitClusterNamePatterns(value)
+		if len(patterns) == 0 {
+			return "Cluster name is required", nil
+		}
+		for _, pattern := range patterns {
+			if !clusterNameValidator.MatchString(pattern) {
+				return "Cluster name must match `^[0-9a-z\\-]+$` or be a glob pattern using `*` and `?`", nil
+			}
 		}
 		return "", nil
 	}).
 	WithConverter(func(ctx context.Context, value string) (string, error) {
 		prefix := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.ClusterNamePrefixTaskRef)
-		return prefix + strings.TrimSpace(value), nil
+		patterns := splitClusterNamePatterns(value)
+		for i, pattern := range patterns {
+			patterns[i] = prefix + pattern
+		}
+		return strings.Join(patterns, " "), nil
 	}).
 	Build()
 
-func hasClusterNameInAutocomplete(autocmpleteList []googlecloudk8scommon_contract.GoogleCloudClusterIdentity, clusterName string) bool {
-	for _, cluster := range autocmpleteList {
-		if cluster.ClusterName == clusterName {
-			return true
+// hasClusterNameInAutocomplete returns true when every cluster name or glob pattern in the given value matches any of clusters in the autocomplete list.
+func hasClusterNameInAutocomplete(autocmpleteList []googlecloudk8scommon_contract.GoogleCloudClusterIdentity, value string) bool {
+	patterns := splitClusterNamePatterns(value)
+	if len(patterns) == 0 {
+		return false
+	}
+	for _, pattern := range patterns {
+		found := slices.ContainsFunc(autocmpleteList, func(cluster googlecloudk8scommon_contract.GoogleCloudClusterIdentity) bool {
+			return matchClusterNamePattern(pattern, cluster.ClusterName)
+		})
+		if !found {
+			return false
 		}
 	}
-	return false
+	return true
+}
+
+// splitClusterNamePatterns splits the cluster name input into cluster names or glob patterns separated by spaces or commas.
+func splitClusterNamePatterns(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+}
+
+// isClusterNameGlobPattern returns true when the given cluster name contains any glob meta characters.
+func isClusterNameGlobPattern(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?")
+}
+
+// matchClusterNamePattern returns true when the cluster name matches the given cluster name or glob pattern.
+func matchClusterNamePattern(pattern string, clusterName string) bool {
+	matched, err := path.Match(pattern, clusterName)
+	return err == nil && matched
 }
 
 func dedupeClusterName(clusters []googlecloudk8scommon_contract.GoogleCloudClusterIdentity) []string {
@@ -106,3 +167,48 @@ func dedupeClusterName(clusters []googlecloudk8scommon_contract.GoogleCloudClust
 	sort.Strings(result)
 	return result
 }
+
+// clusterNameSuggestions returns the deduped cluster names sorted for the autocomplete with the locations of the clusters in their descriptions.
+func clusterNameSuggestions(value string, clusters []googlecloudk8scommon_contract.GoogleCloudClusterIdentity) []inspectionmetadata.TextParameterFormFieldSuggestion {
+	locations := make(map[string][]string)
+	for _, cluster := range clusters {
+		if cluster.Location != "" && !slices.Contains(locations[cluster.ClusterName], cluster.Location) {
+			locations[cluster.ClusterName] = append(locations[cluster.ClusterName], cluster.Location)
+		}
+	}
+	clusterNames := common.SortForAutocomplete(value, dedupeClusterName(clusters))
+	result := make([]inspectionmetadata.TextParameterFormFieldSuggestion, len(clusterNames))
+	for i, clusterName := range clusterNames {
+		result[i] = inspectionmetadata.TextParameterFormFieldSuggestion{
+			Value: clusterName,
+		}
+		if clusterLocations := locations[clusterName]; len(clusterLocations) > 0 {
+			sort.Strings(clusterLocations)
+			result[i].Description = fmt.Sprintf("Location: %s", strings.Join(clusterLocations, ", "))
+		}
+	}
+	return result
+}
+
+// groupLabeledClusterSuggestions puts the suggestions of the clusters discovered by their resource labels in a group listed before the other clusters.
+// The suggestions are returned as is when no cluster is discovered by labels.
+func groupLabeledClusterSuggestions(suggestions []inspectionmetadata.TextParameterFormFieldSuggestion, labeledClusters []googlecloudk8scommon_contract.GoogleCloudClusterIdentity) []inspectionmetadata.TextParameterFormFieldSuggestion {
+	if len(labeledClusters) == 0 {
+		return suggestions
+	}
+	labeled := []inspectionmetadata.TextParameterFormFieldSuggestion{}
+	others := []inspectionmetadata.TextParameterFormFieldSuggestion{}
+	for _, suggestion := range suggestions {
+		isLabeled := slices.ContainsFunc(labeledClusters, func(cluster googlecloudk8scommon_contract.GoogleCloudClusterIdentity) bool {
+			return cluster.ClusterName == suggestion.Value
+		})
+		if isLabeled {
+			suggestion.Group = "Clusters matching the labels"
+			labeled = append(labeled, suggestion)
+		} else {
+			suggestion.Group = "Other clusters"
+			others = append(others, suggestion)
+		}
+	}
+	return append(labeled, others...)
+}
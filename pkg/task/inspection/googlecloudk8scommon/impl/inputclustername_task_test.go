@@ -17,7 +17,6 @@ package googlecloudk8scommon_impl
 import (
 	"testing"
 
-	"github.com/kyasbal/khi/pkg/common"
 	form_task_test "github.com/kyasbal/khi/pkg/core/inspection/formtask/test"
 	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
 	coretask "github.com/kyasbal/khi/pkg/core/task"
@@ -27,25 +26,45 @@ import (
 )
 
 func TestClusterNameInput(t *testing.T) {
-	wantDescription := "The cluster name to gather logs."
+	wantDescription := "The cluster name to gather logs. Multiple clusters can be specified by separating them with spaces or commas, and glob patterns (e.g. prod-*) select all the matching clusters."
 	testClusterNamePrefix := tasktest.StubTaskFromReferenceID(googlecloudk8scommon_contract.ClusterNamePrefixTaskRef, "", nil)
 	mockClusterNamesTask1 := tasktest.StubTaskFromReferenceID(googlecloudk8scommon_contract.AutocompleteClusterIdentityTaskID.Ref(), &inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]{
 		Values: []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{
 			{
 				ClusterName: "foo-cluster",
+				Location:    "us-central1",
 			},
 			{
 				ClusterName: "bar-cluster",
 			},
+			{
+				ClusterName: "foo-cluster",
+				Location:    "asia-northeast1",
+			},
 		},
 		Error: "",
 	}, nil)
+	noLabeledClustersTask := tasktest.StubTaskFromReferenceID(googlecloudk8scommon_contract.AutocompleteLabeledClustersTaskID.Ref(), &inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]{
+		Values: []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{},
+	}, nil)
+	labeledClustersTask := tasktest.StubTaskFromReferenceID(googlecloudk8scommon_contract.AutocompleteLabeledClustersTaskID.Ref(), &inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudClusterIdentity]{
+		Values: []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{
+			{
+				ProjectID:   "other-project",
+				ClusterName: "bar-cluster",
+			},
+		},
+	}, nil)
+	wantSuggestionItems := []inspectionmetadata.TextParameterFormFieldSuggestion{
+		{Value: "foo-cluster", Description: "Location: asia-northeast1, us-central1"},
+		{Value: "bar-cluster"},
+	}
 	form_task_test.TestTextForms(t, "cluster name", InputClusterNameTask, []*form_task_test.TextFormTestCase{
 		{
 			Name:          "with valid cluster name",
 			Input:         "foo-cluster",
 			ExpectedValue: "foo-cluster",
-			Dependencies:  []coretask.UntypedTask{mockClusterNamesTask1, testClusterNamePrefix},
+			Dependencies:  []coretask.UntypedTask{mockClusterNamesTask1, noLabeledClustersTask, testClusterNamePrefix},
 			ExpectedFormField: inspectionmetadata.TextParameterFormField{
 				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
 					ID:          googlecloudk8scommon_contract.GoogleCloudCommonK8STaskIDPrefix + "input-cluster-name",
@@ -55,15 +74,17 @@ func TestClusterNameInput(t *testing.T) {
 					Description: wantDescription,
 				},
 				Suggestions:      []string{"foo-cluster", "bar-cluster"},
+				SuggestionItems:  wantSuggestionItems,
 				Default:          "foo-cluster",
 				ValidationTiming: inspectionmetadata.Change,
+				Placeholder:      "e.g. my-cluster",
 			},
 		},
 		{
 			Name:          "spaces around cluster name must be trimmed",
 			Input:         "  foo-cluster   ",
 			ExpectedValue: "foo-cluster",
-			Dependencies:  []coretask.UntypedTask{mockClusterNamesTask1, testClusterNamePrefix},
+			Dependencies:  []coretask.UntypedTask{mockClusterNamesTask1, noLabeledClustersTask, testClusterNamePrefix},
 			ExpectedFormField: inspectionmetadata.TextParameterFormField{
 				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
 					ID:          googlecloudk8scommon_contract.GoogleCloudCommonK8STaskIDPrefix + "input-cluster-name",
@@ -73,15 +94,17 @@ func TestClusterNameInput(t *testing.T) {
 					HintType:    inspectionmetadata.None,
 				},
 				Suggestions:      []string{"foo-cluster", "bar-cluster"},
+				SuggestionItems:  wantSuggestionItems,
 				Default:          "foo-cluster",
 				ValidationTiming: inspectionmetadata.Change,
+				Placeholder:      "e.g. my-cluster",
 			},
 		},
 		{
 			Name:          "invalid cluster name",
 			Input:         "An invalid cluster name",
 			ExpectedValue: "foo-cluster",
-			Dependencies:  []coretask.UntypedTask{mockClusterNamesTask1, testClusterNamePrefix},
+			Dependencies:  []coretask.UntypedTask{mockClusterNamesTask1, noLabeledClustersTask, testClusterNamePrefix},
 			ExpectedFormField: inspectionmetadata.TextParameterFormField{
 				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
 					ID:          googlecloudk8scommon_contract.GoogleCloudCommonK8STaskIDPrefix + "input-cluster-name",
@@ -89,18 +112,20 @@ func TestClusterNameInput(t *testing.T) {
 					Label:       "Cluster name",
 					Description: wantDescription,
 					HintType:    inspectionmetadata.Error,
-					Hint:        "Cluster name must match `^[0-9a-z:\\-]+$`",
+					Hint:        "Cluster name must match `^[0-9a-z\\-]+$` or be a glob pattern using `*` and `?`",
 				},
-				Suggestions:      common.SortForAutocomplete("An invalid cluster name", []string{"foo-cluster", "bar-cluster"}),
+				Suggestions:      []string{"bar-cluster", "foo-cluster"},
+				SuggestionItems:  []inspectionmetadata.TextParameterFormFieldSuggestion{wantSuggestionItems[1], wantSuggestionItems[0]},
 				Default:          "foo-cluster",
 				ValidationTiming: inspectionmetadata.Change,
+				Placeholder:      "e.g. my-cluster",
 			},
 		},
 		{
 			Name:          "non existing cluster should show a hint",
 			Input:         "nonexisting-cluster",
 			ExpectedValue: "nonexisting-cluster",
-			Dependencies:  []coretask.UntypedTask{mockClusterNamesTask1, testClusterNamePrefix},
+			Dependencies:  []coretask.UntypedTask{mockClusterNamesTask1, noLabeledClustersTask, testClusterNamePrefix},
 			ExpectedFormField: inspectionmetadata.TextParameterFormField{
 				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
 					ID:          googlecloudk8scommon_contract.GoogleCloudCommonK8STaskIDPrefix + "input-cluster-name",
@@ -115,8 +140,79 @@ Available cluster names:
 					HintType: inspectionmetadata.Warning,
 				},
 				Suggestions:      []string{"foo-cluster", "bar-cluster"},
+				SuggestionItems:  wantSuggestionItems,
+				Default:          "foo-cluster",
+				ValidationTiming: inspectionmetadata.Change,
+				Placeholder:      "e.g. my-cluster",
+			},
+		},
+		{
+			Name:          "multiple cluster names separated by spaces or commas",
+			Input:         "foo-cluster, bar-cluster",
+			ExpectedValue: "foo-cluster bar-cluster",
+			Dependencies:  []coretask.UntypedTask{mockClusterNamesTask1, noLabeledClustersTask, testClusterNamePrefix},
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					ID:          googlecloudk8scommon_contract.GoogleCloudCommonK8STaskIDPrefix + "input-cluster-name",
+					Type:        "Text",
+					Label:       "Cluster name",
+					Description: wantDescription,
+					Hint:        "Logs are gathered from all the selected clusters. Timelines are separated by clusters with the cluster name prefixed to the namespace (e.g. my-cluster/default).",
+					HintType:    inspectionmetadata.Info,
+				},
+				Suggestions:      []string{"foo-cluster", "bar-cluster"},
+				SuggestionItems:  wantSuggestionItems,
+				Default:          "foo-cluster",
+				ValidationTiming: inspectionmetadata.Change,
+				Placeholder:      "e.g. my-cluster",
+			},
+		},
+		{
+			Name:          "glob pattern not matching any cluster should show a hint",
+			Input:         "foo-cluster baz-*",
+			ExpectedValue: "foo-cluster baz-*",
+			Dependencies:  []coretask.UntypedTask{mockClusterNamesTask1, noLabeledClustersTask, testClusterNamePrefix},
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					ID:          googlecloudk8scommon_contract.GoogleCloudCommonK8STaskIDPrefix + "input-cluster-name",
+					Type:        "Text",
+					Label:       "Cluster name",
+					Description: wantDescription,
+					Hint: `Cluster 'baz-*' was not found in the specified project at this time. It works for the clusters existed in the past but make sure the cluster name is right if you believe the cluster should be there.
+Available cluster names:
+* bar-cluster
+* foo-cluster
+`,
+					HintType: inspectionmetadata.Warning,
+				},
+				Suggestions:      []string{"foo-cluster", "bar-cluster"},
+				SuggestionItems:  wantSuggestionItems,
 				Default:          "foo-cluster",
 				ValidationTiming: inspectionmetadata.Change,
+				Placeholder:      "e.g. my-cluster",
+			},
+		},
+		{
+			Name:          "clusters matching the labels are suggested first",
+			Input:         "bar-cluster",
+			ExpectedValue: "bar-cluster",
+			Dependencies:  []coretask.UntypedTask{mockClusterNamesTask1, labeledClustersTask, testClusterNamePrefix},
+			ExpectedFormField: inspectionmetadata.TextParameterFormField{
+				ParameterFormFieldBase: inspectionmetadata.ParameterFormFieldBase{
+					ID:          googlecloudk8scommon_contract.GoogleCloudCommonK8STaskIDPrefix + "input-cluster-name",
+					Type:        "Text",
+					Label:       "Cluster name",
+					Description: wantDescription,
+					HintType:    inspectionmetadata.None,
+				},
+				Suggestions: []string{"bar-cluster", "foo-cluster"},
+				SuggestionItems: []inspectionmetadata.TextParameterFormFieldSuggestion{
+					{Value: "bar-cluster", Group: "Clusters matching the labels"},
+					{Value: "foo-cluster", Description: "Location: asia-northeast1, us-central1", Group: "Other clusters"},
+				},
+				Default:          "bar-cluster",
+				ValidationTiming: inspectionmetadata.Change,
+				Placeholder:      "e.g. my-cluster",
 			},
 		},
 	})
@@ -30,9 +30,11 @@ var inputKindNameAliasMap gcpqueryutil.SetFilterAliasToItemsMap = map[string][]s
 }
 
 // InputKindFilterTask is a form task for inputting the kind filter.
-var InputKindFilterTask = formtask.NewSetFormTaskBuilder(googlecloudk8scommon_contract.InputKindFilterTaskID, googlecloudcommon_contract.PriorityForK8sResourceFilterGroup+5000, "Kind").
+var InputKindFilterTask = formtask.NewSetFormTaskBuilder(googlecloudk8scommon_contract.InputKindFilterTaskID, 0, "Kind").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionK8sResourceFilter}).
 	WithDefaultValueConstant([]string{"@default"}, true).
 	WithDescription("The kinds of resources to gather logs. `@default` is a alias of set of kinds that frequently queried. Specify `@any` to query every kinds of resources").
+	WithDocURL(googlecloudcommon_contract.FormReferenceDocURL("kind")).
 	WithAllowAddAll(false).
 	WithAllowRemoveAll(false).
 	WithAllowCustomValue(true).
@@ -46,16 +48,14 @@ var InputKindFilterTask = formtask.NewSetFormTaskBuilder(googlecloudk8scommon_co
 		if len(value) == 0 {
 			return "kind filter can't be empty", nil
 		}
-		filterInStr := strings.Join(value, " ")
-		result, err := gcpqueryutil.ParseSetFilter(filterInStr, inputKindNameAliasMap, true, true, true)
+		result, err := gcpqueryutil.ParseSetFilterItems(value, inputKindNameAliasMap, true, true, true)
 		if err != nil {
 			return "", err
 		}
 		return result.ValidationError, nil
 	}).
 	WithConverter(func(ctx context.Context, value []string) (*gcpqueryutil.SetFilterParseResult, error) {
-		filterInStr := strings.Join(value, " ")
-		result, err := gcpqueryutil.ParseSetFilter(filterInStr, inputKindNameAliasMap, true, true, true)
+		result, err := gcpqueryutil.ParseSetFilterItems(value, inputKindNameAliasMap, true, true, true)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,56 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudk8scommon_impl
+
+import (
+	"testing"
+
+	form_task_test "github.com/kyasbal/khi/pkg/core/inspection/formtask/test"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+)
+
+func TestKindFilterInput(t *testing.T) {
+	wantBase := inspectionmetadata.ParameterFormFieldBase{
+		Label:       "Kind",
+		Description: "The kinds of resources to gather logs. `@default` is a alias of set of kinds that frequently queried. Specify `@any` to query every kinds of resources",
+		DocURL:      googlecloudcommon_contract.FormReferenceDocURL("kind"),
+		HintType:    inspectionmetadata.None,
+	}
+	wantErrorBase := wantBase
+	wantErrorBase.HintType = inspectionmetadata.Error
+	wantErrorBase.Hint = "kind filter can't be empty"
+
+	form_task_test.TestSetForms(t, "kind", InputKindFilterTask, []*form_task_test.SetFormTestCase{
+		{
+			Name:  "with valid kinds",
+			Input: []string{"pods", "deployments"},
+			ExpectedFormField: inspectionmetadata.SetParameterFormField{
+				ParameterFormFieldBase: wantBase,
+				AllowCustomValue:       true,
+				Default:                []string{"@default"},
+			},
+		},
+		{
+			Name:  "with empty kinds",
+			Input: []string{},
+			ExpectedFormField: inspectionmetadata.SetParameterFormField{
+				ParameterFormFieldBase: wantErrorBase,
+				AllowCustomValue:       true,
+				Default:                []string{"@default"},
+			},
+		},
+	})
+}
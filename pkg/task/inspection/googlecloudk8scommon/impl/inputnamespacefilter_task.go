@@ -17,7 +17,6 @@ package googlecloudk8scommon_impl
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
 	"github.com/kyasbal/khi/pkg/core/inspection/gcpqueryutil"
@@ -36,10 +35,12 @@ var inputNamespacesAliasMap gcpqueryutil.SetFilterAliasToItemsMap = map[string][
 }
 
 // InputNamespaceFilterTask is a form task for inputting the namespace filter.
-var InputNamespaceFilterTask = formtask.NewSetFormTaskBuilder(googlecloudk8scommon_contract.InputNamespaceFilterTaskID, googlecloudcommon_contract.PriorityForK8sResourceFilterGroup+4000, "Namespaces").
+var InputNamespaceFilterTask = formtask.NewSetFormTaskBuilder(googlecloudk8scommon_contract.InputNamespaceFilterTaskID, 0, "Namespaces").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionK8sResourceFilter, After: []string{googlecloudk8scommon_contract.InputKindFilterTaskID.ReferenceIDString()}}).
 	WithDependencies([]taskid.UntypedTaskReference{googlecloudk8scommon_contract.AutocompleteNamespacesTaskID.Ref()}).
 	WithDefaultValueConstant([]string{"@all_cluster_scoped", "@all_namespaced"}, true).
 	WithDescription("The namespace of resources to gather logs. Specify `@all_cluster_scoped` to gather logs for all non-namespaced resources. Specify `@all_namespaced` to gather logs for all namespaced resources.").
+	WithDocURL(googlecloudcommon_contract.FormReferenceDocURL("namespaces")).
 	WithAllowAddAll(false).
 	WithAllowRemoveAll(false).
 	WithAllowCustomValue(true).
@@ -68,16 +69,14 @@ var InputNamespaceFilterTask = formtask.NewSetFormTaskBuilder(googlecloudk8scomm
 		if len(value) == 0 {
 			return "namespace filter can't be empty", nil
 		}
-		namespaceFilterInStr := strings.Join(value, " ")
-		result, err := gcpqueryutil.ParseSetFilter(namespaceFilterInStr, inputNamespacesAliasMap, false, false, true)
+		result, err := gcpqueryutil.ParseSetFilterItems(value, inputNamespacesAliasMap, false, false, true)
 		if err != nil {
 			return "", err
 		}
 		return result.ValidationError, nil
 	}).
 	WithConverter(func(ctx context.Context, value []string) (*gcpqueryutil.SetFilterParseResult, error) {
-		namespaceFilterInStr := strings.Join(value, " ")
-		result, err := gcpqueryutil.ParseSetFilter(namespaceFilterInStr, inputNamespacesAliasMap, false, false, true)
+		result, err := gcpqueryutil.ParseSetFilterItems(value, inputNamespacesAliasMap, false, false, true)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,58 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudk8scommon_impl
+
+import (
+	"testing"
+
+	form_task_test "github.com/kyasbal/khi/pkg/core/inspection/formtask/test"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+func TestNamespaceFilterInput(t *testing.T) {
+	mockNamespacesTask := tasktest.StubTaskFromReferenceID(googlecloudk8scommon_contract.AutocompleteNamespacesTaskID.Ref(), &inspectioncore_contract.AutocompleteResult[string]{
+		Values: []string{"default", "kube-system"},
+	}, nil)
+	wantBase := inspectionmetadata.ParameterFormFieldBase{
+		Label:       "Namespaces",
+		Description: "The namespace of resources to gather logs. Specify `@all_cluster_scoped` to gather logs for all non-namespaced resources. Specify `@all_namespaced` to gather logs for all namespaced resources.",
+		DocURL:      googlecloudcommon_contract.FormReferenceDocURL("namespaces"),
+		HintType:    inspectionmetadata.None,
+	}
+
+	form_task_test.TestSetForms(t, "namespaces", InputNamespaceFilterTask, []*form_task_test.SetFormTestCase{
+		{
+			Name:         "with valid namespaces",
+			Input:        []string{"default", "@all_cluster_scoped"},
+			Dependencies: []coretask.UntypedTask{mockNamespacesTask},
+			ExpectedFormField: inspectionmetadata.SetParameterFormField{
+				ParameterFormFieldBase: wantBase,
+				AllowCustomValue:       true,
+				Options: []inspectionmetadata.SetParameterFormFieldOptionItem{
+					{ID: "@all_cluster_scoped", Description: "[Alias] An alias matches any of the cluster scoped resources"},
+					{ID: "@all_namespaced", Description: "[Alias] An alias matches any of the namespaced resources"},
+					{ID: "default"},
+					{ID: "kube-system"},
+				},
+				Default: []string{"@all_cluster_scoped", "@all_namespaced"},
+			},
+		},
+	})
+}
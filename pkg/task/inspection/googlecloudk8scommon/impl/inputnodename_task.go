@@ -32,9 +32,11 @@ const maxNodeNameFilterOptions = 500
 var nodeNameSubstringValidator = regexp.MustCompile("^[-a-z0-9]*$")
 
 // InputNodeNameFilterTask is a task to collect list of substrings of node names. This input value is used in querying k8s_node or serialport logs.
-var InputNodeNameFilterTask = formtask.NewSetFormTaskBuilder(googlecloudk8scommon_contract.InputNodeNameFilterTaskID, googlecloudcommon_contract.PriorityForK8sResourceFilterGroup+3000, "Node names").
-	WithDependencies([]taskid.UntypedTaskReference{googlecloudk8scommon_contract.AutocompleteNodeNamesTaskID.Ref()}).
+var InputNodeNameFilterTask = formtask.NewSetFormTaskBuilder(googlecloudk8scommon_contract.InputNodeNameFilterTaskID, 0, "Node names").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionK8sResourceFilter, After: []string{googlecloudk8scommon_contract.InputNamespaceFilterTaskID.ReferenceIDString()}}).
+	WithDependencies([]taskid.UntypedTaskReference{googlecloudk8scommon_contract.AutocompleteNodeNamesTaskID.Ref(), googlecloudk8scommon_contract.AutopilotClusterTaskID.Ref()}).
 	WithDefaultValueConstant([]string{}, true).
+	WithDocURL(googlecloudcommon_contract.FormReferenceDocURL("node-names")).
 	WithDescription("A space-separated list of node name substrings used to collect node-related logs. If left blank, KHI gathers logs from all nodes in the cluster.").
 	WithAllowAddAll(false).
 	WithAllowRemoveAll(false).
@@ -63,6 +65,10 @@ var InputNodeNameFilterTask = formtask.NewSetFormTaskBuilder(googlecloudk8scommo
 		if len(nodeNames.Values) > maxNodeNameFilterOptions {
 			return fmt.Sprintf("Some node names are not shown on the suggestion list because the number of node names is %d, which is more than %d.", len(nodeNames.Values), maxNodeNameFilterOptions), inspectionmetadata.Warning, nil
 		}
+		isAutopilot := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.AutopilotClusterTaskID.Ref())
+		if isAutopilot {
+			return "The cluster is running in Autopilot mode. Serial console logs are not available for Autopilot nodes, so the node names only filter the other node related logs.", inspectionmetadata.Info, nil
+		}
 		return "", inspectionmetadata.None, nil
 	}).
 	Build()
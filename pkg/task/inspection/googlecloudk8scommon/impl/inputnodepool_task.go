@@ -0,0 +1,103 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudk8scommon_impl
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+var nodePoolNameValidator = regexp.MustCompile("^[a-z]([-a-z0-9]*[a-z0-9])?$")
+
+// InputNodePoolFilterTask is a task to collect list of node pool names. This input value is used to restrict querying k8s_node or serialport logs to the instances in the node pools.
+var InputNodePoolFilterTask = formtask.NewSetFormTaskBuilder(googlecloudk8scommon_contract.InputNodePoolFilterTaskID, 0, "Node pools").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionK8sResourceFilter, After: []string{googlecloudk8scommon_contract.InputNodeNameFilterTaskID.ReferenceIDString()}}).
+	WithDependencies([]taskid.UntypedTaskReference{googlecloudk8scommon_contract.AutocompleteNodePoolsTaskID.Ref()}).
+	WithDefaultValueConstant([]string{}, true).
+	WithDescription("A list of node pool names used to collect node-related logs only from the nodes in them. This filter is applied together with the node name filter. If left blank, KHI gathers logs from all node pools in the cluster.").
+	WithAllowAddAll(true).
+	WithAllowRemoveAll(true).
+	WithAllowCustomValue(true).
+	WithValidator(func(ctx context.Context, value []string) (string, error) {
+		for _, v := range value {
+			if !nodePoolNameValidator.MatchString(v) {
+				return fmt.Sprintf("invalid node pool name: %s", v), nil
+			}
+		}
+		return "", nil
+	}).
+	WithOptionsFunc(func(ctx context.Context, prevValue []string) ([]inspectionmetadata.SetParameterFormFieldOptionItem, error) {
+		result := []inspectionmetadata.SetParameterFormFieldOptionItem{}
+		nodePools := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.AutocompleteNodePoolsTaskID.Ref())
+		for _, nodePool := range nodePools.Values {
+			result = append(result, inspectionmetadata.SetParameterFormFieldOptionItem{ID: nodePool.Name})
+		}
+		return result, nil
+	}).
+	WithHintFunc(func(ctx context.Context, value []string, convertedValue any) (string, inspectionmetadata.ParameterHintType, error) {
+		nodePools := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.AutocompleteNodePoolsTaskID.Ref())
+		if nodePools.Error != "" {
+			return fmt.Sprintf("Failed to list node pools: %s", nodePools.Error), inspectionmetadata.Warning, nil
+		}
+		notFoundNodePools := []string{}
+		for _, v := range value {
+			if !slices.ContainsFunc(nodePools.Values, func(nodePool googlecloudk8scommon_contract.GoogleCloudNodePool) bool { return nodePool.Name == v }) {
+				notFoundNodePools = append(notFoundNodePools, v)
+			}
+		}
+		if len(notFoundNodePools) > 0 {
+			return fmt.Sprintf("Node pool '%s' was not found in the selected clusters. Logs are filtered with node names containing `-<node pool name>-` for these node pools instead.", strings.Join(notFoundNodePools, "', '")), inspectionmetadata.Warning, nil
+		}
+		if nodePools.Hint != "" {
+			return nodePools.Hint, inspectionmetadata.Info, nil
+		}
+		return "", inspectionmetadata.None, nil
+	}).
+	Build()
+
+// NodePoolNodeNameSubstringsTask returns the node name substrings matching the instances in the node pools given in InputNodePoolFilterTask.
+// Node pools found in the autocomplete list are converted to the prefixes of instance names in them. Other node pools are converted to `-<node pool name>-` following the naming convention of GKE nodes.
+var NodePoolNodeNameSubstringsTask = inspectiontaskbase.NewInspectionTask(googlecloudk8scommon_contract.NodePoolNodeNameSubstringsTaskID, []taskid.UntypedTaskReference{
+	googlecloudk8scommon_contract.InputNodePoolFilterTaskID.Ref(),
+	googlecloudk8scommon_contract.AutocompleteNodePoolsTaskID.Ref(),
+}, func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) ([]string, error) {
+	nodePoolNames := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.InputNodePoolFilterTaskID.Ref())
+	nodePools := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.AutocompleteNodePoolsTaskID.Ref())
+
+	result := []string{}
+	for _, nodePoolName := range nodePoolNames {
+		index := slices.IndexFunc(nodePools.Values, func(nodePool googlecloudk8scommon_contract.GoogleCloudNodePool) bool {
+			return nodePool.Name == nodePoolName
+		})
+		if index == -1 || len(nodePools.Values[index].InstanceNamePrefixes) == 0 {
+			result = append(result, fmt.Sprintf("-%s-", nodePoolName))
+			continue
+		}
+		result = append(result, nodePools.Values[index].InstanceNamePrefixes...)
+	}
+	return result, nil
+})
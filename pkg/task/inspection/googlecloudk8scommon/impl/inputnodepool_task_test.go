@@ -0,0 +1,78 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudk8scommon_impl
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+func TestNodePoolNodeNameSubstringsTask(t *testing.T) {
+	nodePools := &inspectioncore_contract.AutocompleteResult[googlecloudk8scommon_contract.GoogleCloudNodePool]{
+		Values: []googlecloudk8scommon_contract.GoogleCloudNodePool{
+			{Name: "default-pool", InstanceNamePrefixes: []string{"gke-foo-default-pool-a1b2c3d4-", "gke-foo-default-pool-e5f6a7b8-"}},
+			{Name: "gpu-pool", InstanceNamePrefixes: []string{"gke-foo-gpu-pool-c9d0e1f2-"}},
+		},
+	}
+	testCases := []struct {
+		name          string
+		nodePoolNames []string
+		want          []string
+	}{
+		{
+			name:          "no node pools",
+			nodePoolNames: []string{},
+			want:          []string{},
+		},
+		{
+			name:          "node pools in the autocomplete list",
+			nodePoolNames: []string{"default-pool", "gpu-pool"},
+			want:          []string{"gke-foo-default-pool-a1b2c3d4-", "gke-foo-default-pool-e5f6a7b8-", "gke-foo-gpu-pool-c9d0e1f2-"},
+		},
+		{
+			name:          "node pool not in the autocomplete list",
+			nodePoolNames: []string{"deleted-pool"},
+			want:          []string{"-deleted-pool-"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+			got, _, err := inspectiontest.RunInspectionTask(ctx, NodePoolNodeNameSubstringsTask, inspectioncore_contract.TaskModeRun, map[string]any{},
+				tasktest.NewTaskDependencyValuePair(googlecloudk8scommon_contract.InputNodePoolFilterTaskID.Ref(), tc.nodePoolNames),
+				tasktest.NewTaskDependencyValuePair(googlecloudk8scommon_contract.AutocompleteNodePoolsTaskID.Ref(), nodePools),
+			)
+			if err != nil {
+				t.Fatalf("RunInspectionTask() returned an unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("NodePoolNodeNameSubstringsTask returned an unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInstanceNamePrefixFromInstanceGroupURL(t *testing.T) {
+	got := googlecloudk8scommon_contract.InstanceNamePrefixFromInstanceGroupURL("https://www.googleapis.com/compute/v1/projects/foo-project/zones/us-central1-a/instanceGroupManagers/gke-foo-default-pool-a1b2c3d4-grp")
+	want := "gke-foo-default-pool-a1b2c3d4-"
+	if got != want {
+		t.Errorf("InstanceNamePrefixFromInstanceGroupURL() = %q, want %q", got, want)
+	}
+}
@@ -16,14 +16,73 @@ package googlecloudk8scommon_impl
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"time"
 
 	coretask "github.com/kyasbal/khi/pkg/core/task"
 	"github.com/kyasbal/khi/pkg/core/task/taskid"
 	"github.com/kyasbal/khi/pkg/model/k8s"
+	"github.com/kyasbal/khi/pkg/parameters"
 	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
 )
 
+// schemaSourceFetchTimeout is the timeout to fetch a schema source given as an URL.
+const schemaSourceFetchTimeout = 30 * time.Second
+
 // DefaultK8sResourceMergeConfigTask return the default patch request merge config.
+// Schemas given with `--k8s-schema-sources` override the configurations of the built-in resources and add the ones of custom resources.
 var DefaultK8sResourceMergeConfigTask = coretask.NewTask(googlecloudk8scommon_contract.K8sResourceMergeConfigTaskID, []taskid.UntypedTaskReference{}, func(ctx context.Context) (*k8s.K8sManifestMergeConfigRegistry, error) {
-	return k8s.GenerateDefaultMergeConfig()
+	registry, err := k8s.GenerateDefaultMergeConfig()
+	if err != nil {
+		return nil, err
+	}
+	if parameters.Common.K8sSchemaSources == nil {
+		return registry, nil
+	}
+	for _, location := range strings.Split(*parameters.Common.K8sSchemaSources, ",") {
+		location = strings.TrimSpace(location)
+		if location == "" {
+			continue
+		}
+		// A schema source failing to load must not fail the inspection. The built-in schema is still usable for most of resources.
+		source, err := readSchemaSource(ctx, location)
+		if err != nil {
+			slog.WarnContext(ctx, fmt.Sprintf("failed to read the schema source %s: %v", location, err))
+			continue
+		}
+		count, err := registry.RegisterSchemaSource(source)
+		if err != nil {
+			slog.WarnContext(ctx, fmt.Sprintf("failed to load the schema source %s: %v", location, err))
+			continue
+		}
+		slog.DebugContext(ctx, fmt.Sprintf("loaded merge configurations of %d kinds from %s", count, location))
+	}
+	return registry, nil
 })
+
+// readSchemaSource reads the schema source from the URL or the file path.
+func readSchemaSource(ctx context.Context, location string) ([]byte, error) {
+	if !strings.HasPrefix(location, "http://") && !strings.HasPrefix(location, "https://") {
+		return os.ReadFile(location)
+	}
+	ctx, cancel := context.WithTimeout(ctx, schemaSourceFetchTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudk8scommon_impl
+
+import (
+	"context"
+
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	commonlogk8sauditv2_contract "github.com/kyasbal/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// negServiceNameLabelPath is the path of the label set on ServiceNetworkEndpointGroup by the NEG controller to point the Service.
+const negServiceNameLabelPath = "metadata.labels.networking\\.gke\\.io/service-name"
+
+// NEGServicesTask resolves the Service of each NEG from the labels of ServiceNetworkEndpointGroup resources.
+var NEGServicesTask = inspectiontaskbase.NewInspectionTask(googlecloudk8scommon_contract.NEGServicesTaskID, []taskid.UntypedTaskReference{
+	commonlogk8sauditv2_contract.ManifestGeneratorTaskID.Ref(),
+}, func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (googlecloudk8scommon_contract.NEGNameToServiceMap, error) {
+	if taskMode == inspectioncore_contract.TaskModeDryRun {
+		return nil, nil
+	}
+	result := googlecloudk8scommon_contract.NEGNameToServiceMap{}
+	resourceLogs := coretask.GetTaskResult(ctx, commonlogk8sauditv2_contract.ManifestGeneratorTaskID.Ref())
+	for _, group := range resourceLogs {
+		if group.Resource.Type() != commonlogk8sauditv2_contract.Resource {
+			continue
+		}
+		if group.Resource.APIVersion != "networking.gke.io/v1beta1" || group.Resource.Kind != "servicenetworkendpointgroup" {
+			continue
+		}
+		// Read the label from the latest manifest because the manifest of deletion logs can be empty.
+		for i := len(group.Logs) - 1; i >= 0; i-- {
+			if group.Logs[i].ResourceBodyReader == nil {
+				continue
+			}
+			serviceName := group.Logs[i].ResourceBodyReader.ReadStringOrDefault(negServiceNameLabelPath, "")
+			if serviceName == "" {
+				continue
+			}
+			result[group.Resource.Name] = commonlogk8sauditv2_contract.ResourceIdentity{
+				APIVersion: "core/v1",
+				Kind:       "service",
+				Namespace:  group.Resource.Namespace,
+				Name:       serviceName,
+			}
+			break
+		}
+	}
+	return result, nil
+})
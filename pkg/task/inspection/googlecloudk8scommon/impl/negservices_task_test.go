@@ -0,0 +1,125 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudk8scommon_impl
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/common/structured"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	commonlogk8sauditv2_contract "github.com/kyasbal/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+func TestNEGServicesTask(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		inputResource  *commonlogk8sauditv2_contract.ResourceIdentity
+		inputManifests []string
+		want           googlecloudk8scommon_contract.NEGNameToServiceMap
+	}{
+		{
+			desc: "ServiceNetworkEndpointGroup with the service name label",
+			inputResource: &commonlogk8sauditv2_contract.ResourceIdentity{
+				APIVersion: "networking.gke.io/v1beta1",
+				Kind:       "servicenetworkendpointgroup",
+				Namespace:  "default",
+				Name:       "k8s1-12345678-default-nginx-80-abcdef01",
+			},
+			inputManifests: []string{`metadata:
+  name: k8s1-12345678-default-nginx-80-abcdef01
+  namespace: default
+  labels:
+    networking.gke.io/managed-by: neg-controller
+    networking.gke.io/service-name: nginx
+    networking.gke.io/service-port: "80"`},
+			want: googlecloudk8scommon_contract.NEGNameToServiceMap{
+				"k8s1-12345678-default-nginx-80-abcdef01": {
+					APIVersion: "core/v1",
+					Kind:       "service",
+					Namespace:  "default",
+					Name:       "nginx",
+				},
+			},
+		},
+		{
+			desc: "the label is read from the latest manifest having it",
+			inputResource: &commonlogk8sauditv2_contract.ResourceIdentity{
+				APIVersion: "networking.gke.io/v1beta1",
+				Kind:       "servicenetworkendpointgroup",
+				Namespace:  "default",
+				Name:       "k8s1-12345678-default-nginx-80-abcdef01",
+			},
+			inputManifests: []string{`metadata:
+  labels:
+    networking.gke.io/service-name: nginx`, `metadata: {}`},
+			want: googlecloudk8scommon_contract.NEGNameToServiceMap{
+				"k8s1-12345678-default-nginx-80-abcdef01": {
+					APIVersion: "core/v1",
+					Kind:       "service",
+					Namespace:  "default",
+					Name:       "nginx",
+				},
+			},
+		},
+		{
+			desc: "resources other than ServiceNetworkEndpointGroup are ignored",
+			inputResource: &commonlogk8sauditv2_contract.ResourceIdentity{
+				APIVersion: "core/v1",
+				Kind:       "pod",
+				Namespace:  "default",
+				Name:       "nginx",
+			},
+			inputManifests: []string{`metadata:
+  labels:
+    networking.gke.io/service-name: nginx`},
+			want: googlecloudk8scommon_contract.NEGNameToServiceMap{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			logs := []*commonlogk8sauditv2_contract.ResourceManifestLog{}
+			for _, manifest := range tc.inputManifests {
+				node, err := structured.FromYAML(manifest)
+				if err != nil {
+					t.Fatal(err)
+				}
+				logs = append(logs, &commonlogk8sauditv2_contract.ResourceManifestLog{
+					ResourceBodyReader: structured.NewNodeReader(node),
+				})
+			}
+			input := commonlogk8sauditv2_contract.ResourceManifestLogGroupMap{
+				"test": {
+					Resource: tc.inputResource,
+					Logs:     logs,
+				},
+			}
+
+			ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+			got, _, err := inspectiontest.RunInspectionTask(ctx, NEGServicesTask, inspectioncore_contract.TaskModeRun, map[string]any{},
+				tasktest.NewTaskDependencyValuePair(commonlogk8sauditv2_contract.ManifestGeneratorTaskID.Ref(), input),
+			)
+			if err != nil {
+				t.Fatalf("RunInspectionTask() returned an unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("NEGServicesTask returned an unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
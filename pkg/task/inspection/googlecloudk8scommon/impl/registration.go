@@ -25,18 +25,26 @@ func Register(registry coreinspection.InspectionTaskRegistry) error {
 		HeaderSuggestedFileNameTask,
 		AutocompleteMetricsK8sContainerTask,
 		AutocompleteMetricsK8sNodeTask,
+		AutocompleteFleetMembershipsTask,
+		AutocompleteLabeledClustersTask,
 		AutocompleteClusterIdentityTask,
 		AutocompleteLocationForClusterTask,
 		AutocompleteNamespacesTask,
 		AutocompleteNodeNamesTask,
+		AutocompleteNodePoolsTask,
 		AutocompletePodNamesTask,
+		AutopilotClusterTask,
 		DefaultK8sResourceMergeConfigTask,
 		ClusterIdentityTask,
+		ClusterIdentitiesTask,
 		InputClusterNameTask,
 		InputKindFilterTask,
 		InputNamespaceFilterTask,
 		InputNodeNameFilterTask,
+		InputNodePoolFilterTask,
+		NodePoolNodeNameSubstringsTask,
 		NEGNamesInventoryTask,
 		NEGNamesDiscoveryTask,
+		NEGServicesTask,
 	)
 }
@@ -0,0 +1,66 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogarchive_contract
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LogArchivePath is the location in Cloud Storage where a Cloud Logging sink exports logs.
+type LogArchivePath struct {
+	Bucket string
+	// Prefix is the object name prefix where the sink writes the log directories. This is empty when the sink writes at the root of the bucket.
+	Prefix string
+}
+
+// ParseLogArchivePath parses the path in the form of `gs://<bucket>/<prefix>`.
+func ParseLogArchivePath(path string) (*LogArchivePath, error) {
+	path = strings.TrimSpace(path)
+	if !strings.HasPrefix(path, "gs://") {
+		return nil, fmt.Errorf("the log archive path must start with `gs://`")
+	}
+	bucket, prefix, _ := strings.Cut(strings.TrimPrefix(path, "gs://"), "/")
+	if bucket == "" {
+		return nil, fmt.Errorf("the log archive path must contain the bucket name")
+	}
+	return &LogArchivePath{
+		Bucket: bucket,
+		Prefix: strings.Trim(prefix, "/"),
+	}, nil
+}
+
+// String returns the path in the form of `gs://<bucket>/<prefix>`.
+func (p *LogArchivePath) String() string {
+	if p.Prefix == "" {
+		return fmt.Sprintf("gs://%s", p.Bucket)
+	}
+	return fmt.Sprintf("gs://%s/%s", p.Bucket, p.Prefix)
+}
+
+// HourlyObjectPrefixes returns the object name prefixes of the hourly objects containing logs of the given log ID in the time range.
+// Cloud Logging sinks write logs to objects named `<log ID>/YYYY/MM/DD/HH:00:00_HH:59:59_S<N>.json` in UTC.
+func (p *LogArchivePath) HourlyObjectPrefixes(logID string, startTime, endTime time.Time) []string {
+	base := logID
+	if p.Prefix != "" {
+		base = p.Prefix + "/" + logID
+	}
+	result := []string{}
+	for t := startTime.UTC().Truncate(time.Hour); !t.After(endTime); t = t.Add(time.Hour) {
+		result = append(result, fmt.Sprintf("%s/%s:", base, t.Format("2006/01/02/15")))
+	}
+	return result
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogarchive_contract
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseLogArchivePath(t *testing.T) {
+	testCases := []struct {
+		name    string
+		path    string
+		want    *LogArchivePath
+		wantErr bool
+	}{
+		{
+			name: "bucket only",
+			path: "gs://foo-bucket",
+			want: &LogArchivePath{Bucket: "foo-bucket"},
+		},
+		{
+			name: "bucket with trailing slash",
+			path: "gs://foo-bucket/",
+			want: &LogArchivePath{Bucket: "foo-bucket"},
+		},
+		{
+			name: "bucket with prefix",
+			path: " gs://foo-bucket/bar/baz/ ",
+			want: &LogArchivePath{Bucket: "foo-bucket", Prefix: "bar/baz"},
+		},
+		{
+			name:    "without scheme",
+			path:    "foo-bucket/bar",
+			wantErr: true,
+		},
+		{
+			name:    "empty bucket",
+			path:    "gs:///bar",
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseLogArchivePath(tc.path)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("ParseLogArchivePath(%q) returned no error, want error", tc.path)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseLogArchivePath(%q) returned an unexpected error: %v", tc.path, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ParseLogArchivePath(%q) mismatch (-want +got):\n%s", tc.path, diff)
+			}
+		})
+	}
+}
+
+func TestLogArchivePath_HourlyObjectPrefixes(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	testCases := []struct {
+		name      string
+		path      *LogArchivePath
+		startTime time.Time
+		endTime   time.Time
+		want      []string
+	}{
+		{
+			name:      "within an hour",
+			path:      &LogArchivePath{Bucket: "foo-bucket"},
+			startTime: time.Date(2024, 1, 2, 3, 10, 0, 0, time.UTC),
+			endTime:   time.Date(2024, 1, 2, 3, 50, 0, 0, time.UTC),
+			want:      []string{"events/2024/01/02/03:"},
+		},
+		{
+			name:      "across the day boundary with prefix",
+			path:      &LogArchivePath{Bucket: "foo-bucket", Prefix: "bar"},
+			startTime: time.Date(2024, 1, 1, 23, 30, 0, 0, time.UTC),
+			endTime:   time.Date(2024, 1, 2, 1, 0, 0, 0, time.UTC),
+			want:      []string{"bar/events/2024/01/01/23:", "bar/events/2024/01/02/00:", "bar/events/2024/01/02/01:"},
+		},
+		{
+			name:      "time range in other timezone",
+			path:      &LogArchivePath{Bucket: "foo-bucket"},
+			startTime: time.Date(2024, 1, 2, 9, 30, 0, 0, jst),
+			endTime:   time.Date(2024, 1, 2, 9, 40, 0, 0, jst),
+			want:      []string{"events/2024/01/02/00:"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.path.HourlyObjectPrefixes("events", tc.startTime, tc.endTime)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("HourlyObjectPrefixes() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
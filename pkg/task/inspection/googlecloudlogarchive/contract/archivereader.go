@@ -0,0 +1,87 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogarchive_contract
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+)
+
+// LogArchiveReader reads the objects exported by Cloud Logging sinks from Cloud Storage.
+type LogArchiveReader interface {
+	// ListObjects returns the names of objects in the bucket starting with the given prefix.
+	ListObjects(ctx context.Context, projectID string, bucket string, prefix string) ([]string, error)
+	// ReadObject returns the content of the object.
+	ReadObject(ctx context.Context, projectID string, bucket string, object string) ([]byte, error)
+}
+
+type LogArchiveReaderImpl struct{}
+
+// ListObjects implements LogArchiveReader.
+func (r *LogArchiveReaderImpl) ListObjects(ctx context.Context, projectID string, bucket string, prefix string) ([]string, error) {
+	cf := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientFactoryTaskID.Ref())
+	injector := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientCallOptionsInjectorTaskID.Ref())
+
+	storageClient, err := cf.StorageService(ctx, googlecloud.Project(projectID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the storage api client:%v", err)
+	}
+
+	result := []string{}
+	var nextPageToken string
+	for {
+		req := storageClient.Objects.List(bucket).Prefix(prefix).Fields("items/name", "nextPageToken").PageToken(nextPageToken).Context(ctx)
+		injector.InjectToCall(req, googlecloud.Project(projectID))
+		resp, err := req.Do()
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range resp.Items {
+			result = append(result, object.Name)
+		}
+		nextPageToken = resp.NextPageToken
+		if nextPageToken == "" {
+			break
+		}
+	}
+	return result, nil
+}
+
+// ReadObject implements LogArchiveReader.
+func (r *LogArchiveReaderImpl) ReadObject(ctx context.Context, projectID string, bucket string, object string) ([]byte, error) {
+	cf := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientFactoryTaskID.Ref())
+	injector := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientCallOptionsInjectorTaskID.Ref())
+
+	storageClient, err := cf.StorageService(ctx, googlecloud.Project(projectID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the storage api client:%v", err)
+	}
+
+	req := storageClient.Objects.Get(bucket, object).Context(ctx)
+	injector.InjectToCall(req, googlecloud.Project(projectID))
+	resp, err := req.Download()
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+var _ LogArchiveReader = (*LogArchiveReaderImpl)(nil)
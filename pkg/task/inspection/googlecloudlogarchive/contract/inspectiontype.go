@@ -0,0 +1,35 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogarchive_contract
+
+import (
+	"math"
+
+	coreinspection "github.com/kyasbal/khi/pkg/core/inspection"
+)
+
+// InspectionTypeId is the unique identifier for the inspection reading logs exported to Cloud Storage by Cloud Logging sinks.
+var InspectionTypeId = "gcp-log-archive-gcs"
+
+// LogArchiveInspectionType defines the inspection type for the Cloud Logging export archives in Cloud Storage.
+var LogArchiveInspectionType = coreinspection.InspectionType{
+	Id:   InspectionTypeId,
+	Name: "Kubernetes logs archived in Cloud Storage",
+	Description: `Visualize Kubernetes logs exported to a Cloud Storage bucket by a Cloud Logging sink.
+Use this to inspect logs older than the retention period of Cloud Logging without querying Cloud Logging.
+Supporting K8s audit log and k8s event log.`,
+	Icon:     "assets/icons/gke.png",
+	Priority: math.MaxInt - 6,
+}
@@ -0,0 +1,40 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogarchive_contract
+
+import (
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	googlecloudlogk8saudit_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8saudit/contract"
+	googlecloudlogk8sevent_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8sevent/contract"
+)
+
+// TaskIDPrefix is the prefix for all task IDs in the googlecloudlogarchive package.
+const TaskIDPrefix = "cloud.google.com/log-archive/"
+
+// InputLogArchivePathTaskID is the task ID for the Cloud Storage path where a Cloud Logging sink exports logs.
+var InputLogArchivePathTaskID = taskid.NewDefaultImplementationID[*LogArchivePath](TaskIDPrefix + "input-archive-path")
+
+// LogArchiveReaderTaskID is the task ID for injecting LogArchiveReader instance.
+var LogArchiveReaderTaskID = taskid.NewDefaultImplementationID[LogArchiveReader](TaskIDPrefix + "archive-reader")
+
+// AutocompleteNamespacesTaskID is the task ID for overriding the namespace autocomplete not available for the archived logs.
+var AutocompleteNamespacesTaskID = taskid.NewImplementationID(googlecloudk8scommon_contract.AutocompleteNamespacesTaskID.Ref(), "log-archive")
+
+// K8sAuditLogListLogEntriesTaskID is the task ID for reading k8s audit logs from the archive instead of Cloud Logging.
+var K8sAuditLogListLogEntriesTaskID = taskid.NewImplementationID(googlecloudlogk8saudit_contract.GCPK8sAuditLogListLogEntriesTaskID.Ref(), "log-archive")
+
+// K8sEventLogListLogEntriesTaskID is the task ID for reading k8s event logs from the archive instead of Cloud Logging.
+var K8sEventLogListLogEntriesTaskID = taskid.NewImplementationID(googlecloudlogk8sevent_contract.ListLogEntriesTaskID.Ref(), "log-archive")
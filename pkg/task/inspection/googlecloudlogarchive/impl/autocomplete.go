@@ -0,0 +1,32 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogarchive_impl
+
+import (
+	"context"
+
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	googlecloudlogarchive_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogarchive/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// AutocompleteNamespacesTask overrides the namespace autocomplete because the namespaces can't be listed from metrics without the cluster identity.
+var AutocompleteNamespacesTask = coretask.NewTask(googlecloudlogarchive_contract.AutocompleteNamespacesTaskID, []taskid.UntypedTaskReference{}, func(ctx context.Context) (*inspectioncore_contract.AutocompleteResult[string], error) {
+	return &inspectioncore_contract.AutocompleteResult[string]{
+		Values: []string{},
+		Hint:   "Namespace names are not suggested for the archived logs.",
+	}, nil
+}, coretask.WithSelectionPriority(1000), inspectioncore_contract.InspectionTypeLabel(googlecloudlogarchive_contract.InspectionTypeId))
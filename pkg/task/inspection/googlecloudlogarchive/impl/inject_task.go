@@ -0,0 +1,36 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogarchive_impl
+
+import (
+	"context"
+
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	googlecloudlogarchive_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogarchive/contract"
+)
+
+// LogArchiveReaderTask injects LogArchiveReader implementation.
+var LogArchiveReaderTask = coretask.NewTask(
+	googlecloudlogarchive_contract.LogArchiveReaderTaskID,
+	[]taskid.UntypedTaskReference{
+		googlecloudcommon_contract.APIClientFactoryTaskID.Ref(),
+		googlecloudcommon_contract.APIClientCallOptionsInjectorTaskID.Ref(),
+	},
+	func(ctx context.Context) (googlecloudlogarchive_contract.LogArchiveReader, error) {
+		return &googlecloudlogarchive_contract.LogArchiveReaderImpl{}, nil
+	},
+)
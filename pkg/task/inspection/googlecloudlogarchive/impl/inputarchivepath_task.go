@@ -0,0 +1,46 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogarchive_impl
+
+import (
+	"context"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	googlecloudlogarchive_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogarchive/contract"
+)
+
+// InputLogArchivePathTask is a form task for inputting the Cloud Storage path where a Cloud Logging sink exports logs.
+var InputLogArchivePathTask = formtask.NewTextFormTaskBuilder(googlecloudlogarchive_contract.InputLogArchivePathTaskID, 100, "Log archive path").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionResourceIdentifier}).
+	WithPlaceholder("e.g. gs://my-log-bucket/my-prefix").
+	WithDescription("The Cloud Storage path specified as the destination of the Cloud Logging sink. Logs are read from the hourly objects under `<path>/<log ID>/YYYY/MM/DD/`.").
+	WithValidatingTiming(inspectionmetadata.Blur).
+	WithValidator(func(ctx context.Context, value string) (string, error) {
+		if _, err := googlecloudlogarchive_contract.ParseLogArchivePath(value); err != nil {
+			return err.Error(), nil
+		}
+		return "", nil
+	}).
+	WithConverter(func(ctx context.Context, value string) (*googlecloudlogarchive_contract.LogArchivePath, error) {
+		path, err := googlecloudlogarchive_contract.ParseLogArchivePath(value)
+		if err != nil {
+			// The value is converted even when it is invalid in DryRun mode. Run mode never reaches here with an invalid value.
+			return &googlecloudlogarchive_contract.LogArchivePath{}, nil
+		}
+		return path, nil
+	}).
+	Build()
@@ -0,0 +1,140 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogarchive_impl
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/gcpqueryutil"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	"github.com/kyasbal/khi/pkg/core/inspection/progressutil"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	googlecloudlogarchive_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogarchive/contract"
+	googlecloudlogk8saudit_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8saudit/contract"
+	googlecloudlogk8sevent_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8sevent/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// K8sAuditLogListLogEntriesTask reads k8s audit logs from the log archive in place of querying Cloud Logging.
+var K8sAuditLogListLogEntriesTask = newLogArchiveListLogEntriesTask(
+	googlecloudlogarchive_contract.K8sAuditLogListLogEntriesTaskID,
+	"cloudaudit.googleapis.com/activity",
+	enum.LogTypeAudit,
+	[]taskid.UntypedTaskReference{
+		googlecloudk8scommon_contract.InputKindFilterTaskID.Ref(),
+		googlecloudk8scommon_contract.InputNamespaceFilterTaskID.Ref(),
+	},
+	func(ctx context.Context, l *log.Log) bool {
+		kindFilter := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.InputKindFilterTaskID.Ref())
+		namespaceFilter := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.InputNamespaceFilterTaskID.Ref())
+		return googlecloudlogk8saudit_contract.MatchK8sAuditLog(l, kindFilter, namespaceFilter)
+	},
+)
+
+// K8sEventLogListLogEntriesTask reads k8s event logs from the log archive in place of querying Cloud Logging.
+var K8sEventLogListLogEntriesTask = newLogArchiveListLogEntriesTask(
+	googlecloudlogarchive_contract.K8sEventLogListLogEntriesTaskID,
+	"events",
+	enum.LogTypeEvent,
+	[]taskid.UntypedTaskReference{
+		googlecloudk8scommon_contract.InputNamespaceFilterTaskID.Ref(),
+	},
+	func(ctx context.Context, l *log.Log) bool {
+		namespaceFilter := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.InputNamespaceFilterTaskID.Ref())
+		return googlecloudlogk8sevent_contract.MatchK8sEventLog(l, namespaceFilter)
+	},
+)
+
+// newLogArchiveListLogEntriesTask returns a task reading the logs of the given log ID from the hourly objects in the log archive.
+// Logs out of the query time range or not matching the logFilter are dropped.
+func newLogArchiveListLogEntriesTask(taskID taskid.TaskImplementationID[[]*log.Log], logID string, logType enum.LogType, dependencies []taskid.UntypedTaskReference, logFilter func(ctx context.Context, l *log.Log) bool) coretask.Task[[]*log.Log] {
+	return inspectiontaskbase.NewProgressReportableInspectionTask(taskID, append([]taskid.UntypedTaskReference{
+		googlecloudcommon_contract.InputProjectIdTaskID.Ref(),
+		googlecloudcommon_contract.InputStartTimeTaskID.Ref(),
+		googlecloudcommon_contract.InputEndTimeTaskID.Ref(),
+		googlecloudlogarchive_contract.InputLogArchivePathTaskID.Ref(),
+		googlecloudlogarchive_contract.LogArchiveReaderTaskID.Ref(),
+	}, dependencies...), func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, progress *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, error) {
+		if taskMode == inspectioncore_contract.TaskModeDryRun {
+			return []*log.Log{}, nil
+		}
+		projectID := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputProjectIdTaskID.Ref())
+		startTime := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputStartTimeTaskID.Ref())
+		endTime := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputEndTimeTaskID.Ref())
+		archivePath := coretask.GetTaskResult(ctx, googlecloudlogarchive_contract.InputLogArchivePathTaskID.Ref())
+		reader := coretask.GetTaskResult(ctx, googlecloudlogarchive_contract.LogArchiveReaderTaskID.Ref())
+
+		objects := []string{}
+		for _, prefix := range archivePath.HourlyObjectPrefixes(logID, startTime, endTime) {
+			names, err := reader.ListObjects(ctx, projectID, archivePath.Bucket, prefix)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list objects in gs://%s/%s: %w", archivePath.Bucket, prefix, err)
+			}
+			objects = append(objects, names...)
+		}
+
+		logs := []*log.Log{}
+		err := progressutil.ReportProgressFromArraySync(progress, objects, func(i int, object string) error {
+			data, err := reader.ReadObject(ctx, projectID, archivePath.Bucket, object)
+			if err != nil {
+				return fmt.Errorf("failed to read gs://%s/%s: %w", archivePath.Bucket, object, err)
+			}
+			for _, line := range strings.Split(string(data), "\n") {
+				if strings.TrimSpace(line) == "" {
+					continue
+				}
+				l, err := log.NewLogFromYAMLString(line)
+				if err != nil {
+					slog.WarnContext(ctx, fmt.Sprintf("failed to parse a log in gs://%s/%s: %v", archivePath.Bucket, object, err))
+					continue
+				}
+				// GCPCommonFieldSet is always required for any logs exported from Cloud Logging as well as the logs queried from Cloud Logging.
+				err = l.SetFieldSetReader(&gcpqueryutil.GCPCommonFieldSetReader{})
+				if err != nil {
+					slog.WarnContext(ctx, fmt.Sprintf("failed to read the common fields of a log in gs://%s/%s: %v", archivePath.Bucket, object, err))
+					continue
+				}
+				commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+				if commonFieldSet.Timestamp.Before(startTime) || commonFieldSet.Timestamp.After(endTime) {
+					continue
+				}
+				if !logFilter(ctx, l) {
+					continue
+				}
+				l.LogType = logType
+				logs = append(logs, l)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		slices.SortStableFunc(logs, func(a, b *log.Log) int {
+			return log.MustGetFieldSet(a, &log.CommonFieldSet{}).Timestamp.Compare(log.MustGetFieldSet(b, &log.CommonFieldSet{}).Timestamp)
+		})
+		return logs, nil
+	}, coretask.WithSelectionPriority(1000), inspectioncore_contract.InspectionTypeLabel(googlecloudlogarchive_contract.InspectionTypeId))
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogarchive_impl
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/core/inspection/gcpqueryutil"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	googlecloudlogarchive_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogarchive/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+type mockLogArchiveReader struct {
+	objects map[string]string
+}
+
+// ListObjects implements googlecloudlogarchive_contract.LogArchiveReader.
+func (m *mockLogArchiveReader) ListObjects(ctx context.Context, projectID string, bucket string, prefix string) ([]string, error) {
+	result := []string{}
+	for name := range m.objects {
+		if strings.HasPrefix(name, prefix) {
+			result = append(result, name)
+		}
+	}
+	return result, nil
+}
+
+// ReadObject implements googlecloudlogarchive_contract.LogArchiveReader.
+func (m *mockLogArchiveReader) ReadObject(ctx context.Context, projectID string, bucket string, object string) ([]byte, error) {
+	return []byte(m.objects[object]), nil
+}
+
+var _ googlecloudlogarchive_contract.LogArchiveReader = (*mockLogArchiveReader)(nil)
+
+func TestK8sEventLogListLogEntriesTask(t *testing.T) {
+	reader := &mockLogArchiveReader{
+		objects: map[string]string{
+			"archive/events/2024/01/02/03:00:00_03:59:59_S0.json": `{"insertId":"in-range-2","timestamp":"2024-01-02T03:40:00Z","jsonPayload":{"involvedObject":{"namespace":"default"}}}
+{"insertId":"out-of-range","timestamp":"2024-01-02T03:05:00Z","jsonPayload":{"involvedObject":{"namespace":"default"}}}
+{"insertId":"other-namespace","timestamp":"2024-01-02T03:30:00Z","jsonPayload":{"involvedObject":{"namespace":"kube-system"}}}
+`,
+			"archive/events/2024/01/02/04:00:00_04:59:59_S0.json": `{"insertId":"in-range-3","timestamp":"2024-01-02T04:10:00Z","jsonPayload":{"involvedObject":{"namespace":"default"}}}`,
+			"archive/events/2024/01/02/03:00:00_03:59:59_S1.json": `{"insertId":"in-range-1","timestamp":"2024-01-02T03:20:00Z","jsonPayload":{"involvedObject":{"namespace":"default"}}}`,
+			"archive/events/2024/01/02/05:00:00_05:59:59_S0.json": `{"insertId":"next-hour","timestamp":"2024-01-02T05:10:00Z","jsonPayload":{"involvedObject":{"namespace":"default"}}}`,
+		},
+	}
+	ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+	got, _, err := inspectiontest.RunInspectionTask(ctx, K8sEventLogListLogEntriesTask, inspectioncore_contract.TaskModeRun, map[string]any{},
+		tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.InputProjectIdTaskID.Ref(), "foo-project"),
+		tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.InputStartTimeTaskID.Ref(), time.Date(2024, 1, 2, 3, 10, 0, 0, time.UTC)),
+		tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.InputEndTimeTaskID.Ref(), time.Date(2024, 1, 2, 4, 30, 0, 0, time.UTC)),
+		tasktest.NewTaskDependencyValuePair(googlecloudlogarchive_contract.InputLogArchivePathTaskID.Ref(), &googlecloudlogarchive_contract.LogArchivePath{Bucket: "foo-bucket", Prefix: "archive"}),
+		tasktest.NewTaskDependencyValuePair[googlecloudlogarchive_contract.LogArchiveReader](googlecloudlogarchive_contract.LogArchiveReaderTaskID.Ref(), reader),
+		tasktest.NewTaskDependencyValuePair(googlecloudk8scommon_contract.InputNamespaceFilterTaskID.Ref(), &gcpqueryutil.SetFilterParseResult{Additives: []string{"default"}}),
+	)
+	if err != nil {
+		t.Fatalf("RunInspectionTask() returned an unexpected error: %v", err)
+	}
+
+	gotInsertIDs := []string{}
+	for _, l := range got {
+		if l.LogType != enum.LogTypeEvent {
+			t.Errorf("log %s has log type %v, want %v", l.ReadStringOrDefault("insertId", ""), l.LogType, enum.LogTypeEvent)
+		}
+		gotInsertIDs = append(gotInsertIDs, l.ReadStringOrDefault("insertId", ""))
+	}
+	wantInsertIDs := []string{"in-range-1", "in-range-2", "in-range-3"}
+	if diff := cmp.Diff(wantInsertIDs, gotInsertIDs); diff != "" {
+		t.Errorf("K8sEventLogListLogEntriesTask returned unexpected logs (-want +got):\n%s", diff)
+	}
+}
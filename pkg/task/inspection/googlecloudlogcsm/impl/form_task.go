@@ -28,11 +28,10 @@ import (
 	googlecloudlogcsm_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogcsm/contract"
 )
 
-const priorityForCSMGroup = googlecloudcommon_contract.FormBasePriority + 10000
-
 var inputCSMAliasMap gcpqueryutil.SetFilterAliasToItemsMap = map[string][]string{}
 
-var InputCSMResponseFlagsTask = formtask.NewSetFormTaskBuilder(googlecloudlogcsm_contract.InputCSMResponseFlagsTaskID, priorityForCSMGroup+1000, "Envoy response flags").
+var InputCSMResponseFlagsTask = formtask.NewSetFormTaskBuilder(googlecloudlogcsm_contract.InputCSMResponseFlagsTaskID, 0, "Envoy response flags").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionCSMLogFilter}).
 	WithDefaultValueConstant([]string{"@any", "-OK"}, true).
 	WithAllowAddAll(false).
 	WithAllowRemoveAll(false).
@@ -58,8 +57,7 @@ var InputCSMResponseFlagsTask = formtask.NewSetFormTaskBuilder(googlecloudlogcsm
 		return result, nil
 	}).
 	WithValidator(func(ctx context.Context, value []string) (string, error) {
-		strFilter := strings.Join(value, " ")
-		result, err := gcpqueryutil.ParseSetFilter(strFilter, inputCSMAliasMap, true, true, true)
+		result, err := gcpqueryutil.ParseSetFilterItems(value, inputCSMAliasMap, true, true, true)
 		if err != nil {
 			return "", err
 		}
@@ -76,8 +74,7 @@ var InputCSMResponseFlagsTask = formtask.NewSetFormTaskBuilder(googlecloudlogcsm
 		return result.ValidationError, nil
 	}).
 	WithConverter(func(ctx context.Context, value []string) (*gcpqueryutil.SetFilterParseResult, error) {
-		strFilter := strings.Join(value, " ")
-		result, err := gcpqueryutil.ParseSetFilter(strFilter, inputCSMAliasMap, true, true, true)
+		result, err := gcpqueryutil.ParseSetFilterItems(value, inputCSMAliasMap, true, true, true)
 		if err != nil {
 			return nil, err
 		}
@@ -94,6 +94,7 @@ func (c *CSMAccessLogListLogEntryTaskSetting) DefaultResourceNames(ctx context.C
 func (c *CSMAccessLogListLogEntryTaskSetting) Dependencies() []taskid.UntypedTaskReference {
 	return []taskid.UntypedTaskReference{
 		googlecloudlogcsm_contract.ClusterIdentityTaskID.Ref(),
+		googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref(),
 		googlecloudk8scommon_contract.InputNamespaceFilterTaskID.Ref(),
 		googlecloudlogcsm_contract.InputCSMResponseFlagsTaskID.Ref(),
 	}
@@ -114,10 +115,14 @@ func (c *CSMAccessLogListLogEntryTaskSetting) Description() *googlecloudcommon_c
 
 // LogFilters implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
 func (c *CSMAccessLogListLogEntryTaskSetting) LogFilters(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) ([]string, error) {
-	cluster := coretask.GetTaskResult(ctx, googlecloudlogcsm_contract.ClusterIdentityTaskID.Ref())
+	clusters := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref())
 	namespaceFilter := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.InputNamespaceFilterTaskID.Ref())
 	responseFlagsFilter := coretask.GetTaskResult(ctx, googlecloudlogcsm_contract.InputCSMResponseFlagsTaskID.Ref())
-	return []string{csmAccessLogsFilter(cluster, responseFlagsFilter, namespaceFilter)}, nil
+	filters := []string{}
+	for _, cluster := range clusters {
+		filters = append(filters, csmAccessLogsFilter(cluster, responseFlagsFilter, namespaceFilter))
+	}
+	return filters, nil
 }
 
 // TaskID implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
@@ -0,0 +1,139 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudloggceaudit_impl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/history"
+	"github.com/kyasbal/khi/pkg/model/history/resourcepath"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	googlecloudinspectiontypegroup_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudinspectiontypegroup/contract"
+	googlecloudloggceaudit_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudloggceaudit/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+var FieldSetReaderTask = inspectiontaskbase.NewFieldSetReadTask(googlecloudloggceaudit_contract.FieldSetReaderTaskID, googlecloudloggceaudit_contract.ListLogEntriesTaskID.Ref(), []log.FieldSetReader{
+	&googlecloudcommon_contract.GCPOperationAuditLogFieldSetReader{},
+})
+
+var LogIngesterTask = inspectiontaskbase.NewLogIngesterTask(googlecloudloggceaudit_contract.LogIngesterTaskID, googlecloudloggceaudit_contract.ListLogEntriesTaskID.Ref())
+
+var LogGrouperTask = inspectiontaskbase.NewLogGrouperTask(googlecloudloggceaudit_contract.LogGrouperTaskID, googlecloudloggceaudit_contract.FieldSetReaderTaskID.Ref(),
+	func(ctx context.Context, l *log.Log) string {
+		audit, err := log.GetFieldSet(l, &googlecloudcommon_contract.GCPAuditLogFieldSet{})
+		if err != nil {
+			return "unknown"
+		}
+		return resourcePathFromResourceName(audit.ResourceName).Path
+	})
+
+var LogToTimelineMapperTask = inspectiontaskbase.NewLogToTimelineMapperTask[struct{}](googlecloudloggceaudit_contract.LogToTimelineMapperTaskID, &gceAuditLogToTimelineMapperSetting{},
+	inspectioncore_contract.FeatureTaskLabel(`Compute API Logs`,
+		`Gather Compute API audit logs of the instance group and its instances to show the timings of the instance lifecycle(e.g creating/deleting/recreating instances, resizing the instance group...etc) on associated timelines.`,
+		enum.LogTypeComputeApi,
+		6000,
+		true,
+		googlecloudinspectiontypegroup_contract.GCEInstanceGroupInspectionTypes...),
+)
+
+type gceAuditLogToTimelineMapperSetting struct {
+}
+
+// Dependencies implements inspectiontaskbase.LogToTimelineMapper.
+func (g *gceAuditLogToTimelineMapperSetting) Dependencies() []taskid.UntypedTaskReference {
+	return []taskid.UntypedTaskReference{}
+}
+
+// GroupedLogTask implements inspectiontaskbase.LogToTimelineMapper.
+func (g *gceAuditLogToTimelineMapperSetting) GroupedLogTask() taskid.TaskReference[inspectiontaskbase.LogGroupMap] {
+	return googlecloudloggceaudit_contract.LogGrouperTaskID.Ref()
+}
+
+// LogIngesterTask implements inspectiontaskbase.LogToTimelineMapper.
+func (g *gceAuditLogToTimelineMapperSetting) LogIngesterTask() taskid.TaskReference[[]*log.Log] {
+	return googlecloudloggceaudit_contract.LogIngesterTaskID.Ref()
+}
+
+// ProcessLogByGroup implements inspectiontaskbase.LogToTimelineMapper.
+func (g *gceAuditLogToTimelineMapperSetting) ProcessLogByGroup(ctx context.Context, l *log.Log, cs *history.ChangeSet, builder *history.Builder, prevGroupData struct{}) (struct{}, error) {
+	commonLogFieldSet, err := log.GetFieldSet(l, &log.CommonFieldSet{})
+	if err != nil {
+		return struct{}{}, err
+	}
+	audit, err := log.GetFieldSet(l, &googlecloudcommon_contract.GCPAuditLogFieldSet{})
+	if err != nil {
+		return struct{}{}, err
+	}
+
+	resourcePath := audit.OperationPath(resourcePathFromResourceName(audit.ResourceName))
+
+	if audit.ImmediateOperation() {
+		cs.AddEvent(resourcePath)
+	} else {
+		state := enum.RevisionStateOperationStarted
+		verb := enum.RevisionVerbOperationStart
+		if audit.Ending() {
+			state = enum.RevisionStateOperationFinished
+			verb = enum.RevisionVerbOperationFinish
+		}
+		requestBody, _ := audit.RequestString()
+		cs.AddRevision(resourcePath, &history.StagingResourceRevision{
+			Body:       requestBody,
+			Verb:       verb,
+			State:      state,
+			Requestor:  audit.PrincipalEmail,
+			ChangeTime: commonLogFieldSet.Timestamp,
+			Partial:    false,
+		})
+	}
+
+	switch {
+	case audit.Starting():
+		cs.SetLogSummary(fmt.Sprintf("%s Started", audit.MethodName))
+	case audit.Ending():
+		cs.SetLogSummary(fmt.Sprintf("%s Finished", audit.MethodName))
+	default:
+		cs.SetLogSummary(audit.MethodName)
+	}
+
+	return struct{}{}, nil
+}
+
+var _ inspectiontaskbase.LogToTimelineMapper[struct{}] = (*gceAuditLogToTimelineMapperSetting)(nil)
+
+// resourcePathFromResourceName returns the timeline for the resource given in the audit log.
+// Instances are shown as nodes because they are usually nodes of a self-managed Kubernetes cluster, and instance groups are shown on their own timelines.
+// The resource name is in the format of `projects/<project>/zones/<zone>/instances/<name>` or `projects/<project>/regions/<region>/instanceGroupManagers/<name>`.
+func resourcePathFromResourceName(resourceName string) resourcepath.ResourcePath {
+	segments := strings.Split(resourceName, "/")
+	location := ""
+	for i := 0; i < len(segments)-1; i++ {
+		if segments[i] == "zones" || segments[i] == "regions" {
+			location = segments[i+1]
+		}
+	}
+	name := segments[len(segments)-1]
+	if len(segments) >= 2 && segments[len(segments)-2] == "instanceGroupManagers" {
+		return resourcepath.GCEInstanceGroupManager(location, name)
+	}
+	return resourcepath.Node(name)
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudloggceaudit_impl
+
+import (
+	"testing"
+)
+
+func TestResourcePathFromResourceName(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		resourceName string
+		want         string
+	}{
+		{
+			desc:         "instance",
+			resourceName: "projects/test-project/zones/us-central1-a/instances/my-mig-abcd",
+			want:         "core/v1#node#cluster-scope#my-mig-abcd",
+		},
+		{
+			desc:         "zonal instance group manager",
+			resourceName: "projects/test-project/zones/us-central1-a/instanceGroupManagers/my-mig",
+			want:         "compute.googleapis.com#instancegroupmanager#us-central1-a#my-mig",
+		},
+		{
+			desc:         "regional instance group manager",
+			resourceName: "projects/test-project/regions/us-central1/instanceGroupManagers/my-mig",
+			want:         "compute.googleapis.com#instancegroupmanager#us-central1#my-mig",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := resourcePathFromResourceName(tc.resourceName)
+			if got.Path != tc.want {
+				t.Errorf("resourcePathFromResourceName(%q).Path = %q, want %q", tc.resourceName, got.Path, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudloggceaudit_impl
+
+import (
+	"context"
+	"fmt"
+
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	googlecloudgceinstancegroup_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudgceinstancegroup/contract"
+	googlecloudloggceaudit_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudloggceaudit/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// GenerateGCEInstanceAuditQuery generates a query for Compute API audit logs of the instances in the given instance group.
+func GenerateGCEInstanceAuditQuery(instanceGroup googlecloudgceinstancegroup_contract.GCEInstanceGroupIdentity) string {
+	return fmt.Sprintf(`resource.type="gce_instance"
+-protoPayload.methodName:("list" OR "get" OR "watch")
+protoPayload.resourceName:"instances/%s"
+`, instanceGroup.InstanceNamePrefix())
+}
+
+// GenerateGCEInstanceGroupManagerAuditQuery generates a query for Compute API audit logs of the given instance group.
+func GenerateGCEInstanceGroupManagerAuditQuery(instanceGroup googlecloudgceinstancegroup_contract.GCEInstanceGroupIdentity) string {
+	return fmt.Sprintf(`resource.type="gce_instance_group_manager"
+-protoPayload.methodName:("list" OR "get" OR "watch")
+protoPayload.resourceName:"instanceGroupManagers/%s"
+`, instanceGroup.InstanceGroupName)
+}
+
+type gceAuditListLogEntriesTaskSetting struct {
+}
+
+// DefaultResourceNames implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
+func (g *gceAuditListLogEntriesTaskSetting) DefaultResourceNames(ctx context.Context) ([]string, error) {
+	instanceGroup := coretask.GetTaskResult(ctx, googlecloudloggceaudit_contract.InstanceGroupIdentityTaskID.Ref())
+	return []string{fmt.Sprintf("projects/%s", instanceGroup.ProjectID)}, nil
+}
+
+// Dependencies implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
+func (g *gceAuditListLogEntriesTaskSetting) Dependencies() []taskid.UntypedTaskReference {
+	return []taskid.UntypedTaskReference{
+		googlecloudloggceaudit_contract.InstanceGroupIdentityTaskID.Ref(),
+	}
+}
+
+// Description implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
+func (g *gceAuditListLogEntriesTaskSetting) Description() *googlecloudcommon_contract.ListLogEntriesTaskDescription {
+	return &googlecloudcommon_contract.ListLogEntriesTaskDescription{
+		DefaultLogType: enum.LogTypeComputeApi,
+		QueryName:      "Compute API Audit log",
+		ExampleQuery: GenerateGCEInstanceAuditQuery(googlecloudgceinstancegroup_contract.GCEInstanceGroupIdentity{
+			ProjectID:         "gcp-project-id",
+			Location:          "us-central1-a",
+			InstanceGroupName: "example-instance-group",
+		}),
+	}
+}
+
+// LogFilters implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
+func (g *gceAuditListLogEntriesTaskSetting) LogFilters(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) ([]string, error) {
+	instanceGroup := coretask.GetTaskResult(ctx, googlecloudloggceaudit_contract.InstanceGroupIdentityTaskID.Ref())
+	return []string{
+		GenerateGCEInstanceAuditQuery(instanceGroup),
+		GenerateGCEInstanceGroupManagerAuditQuery(instanceGroup),
+	}, nil
+}
+
+// TaskID implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
+func (g *gceAuditListLogEntriesTaskSetting) TaskID() taskid.TaskImplementationID[[]*log.Log] {
+	return googlecloudloggceaudit_contract.ListLogEntriesTaskID
+}
+
+// TimePartitionCount implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
+func (g *gceAuditListLogEntriesTaskSetting) TimePartitionCount(ctx context.Context) (int, error) {
+	return 1, nil
+}
+
+var _ googlecloudcommon_contract.ListLogEntriesTaskSetting = (*gceAuditListLogEntriesTaskSetting)(nil)
+
+var ListLogEntriesTask = googlecloudcommon_contract.NewListLogEntriesTask(&gceAuditListLogEntriesTaskSetting{})
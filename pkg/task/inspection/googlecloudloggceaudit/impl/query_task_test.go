@@ -0,0 +1,44 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudloggceaudit_impl
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	googlecloudgceinstancegroup_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudgceinstancegroup/contract"
+)
+
+func TestGenerateGCEAuditQueries(t *testing.T) {
+	instanceGroup := googlecloudgceinstancegroup_contract.GCEInstanceGroupIdentity{
+		ProjectID:         "test-project",
+		Location:          "us-central1-a",
+		InstanceGroupName: "my-mig",
+	}
+	wantInstanceQuery := `resource.type="gce_instance"
+-protoPayload.methodName:("list" OR "get" OR "watch")
+protoPayload.resourceName:"instances/my-mig-"
+`
+	if diff := cmp.Diff(wantInstanceQuery, GenerateGCEInstanceAuditQuery(instanceGroup)); diff != "" {
+		t.Errorf("GenerateGCEInstanceAuditQuery() mismatch (-want +got):\n%s", diff)
+	}
+	wantInstanceGroupManagerQuery := `resource.type="gce_instance_group_manager"
+-protoPayload.methodName:("list" OR "get" OR "watch")
+protoPayload.resourceName:"instanceGroupManagers/my-mig"
+`
+	if diff := cmp.Diff(wantInstanceGroupManagerQuery, GenerateGCEInstanceGroupManagerAuditQuery(instanceGroup)); diff != "" {
+		t.Errorf("GenerateGCEInstanceGroupManagerAuditQuery() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudloggceautoscaler_contract
+
+import (
+	"github.com/kyasbal/khi/pkg/common/structured"
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+// GCEAutoscalerLogFieldSet is the set of fields read from the autoscaler logs of managed instance groups.
+type GCEAutoscalerLogFieldSet struct {
+	// AutoscalerName is the name of the autoscaler emitting the log.
+	AutoscalerName string
+	// Message is the human readable message of the autoscaler decision or status.
+	Message string
+	// Status is the status of the autoscaler. This is an empty string when the log doesn't contain it.
+	Status string
+}
+
+// Kind implements log.FieldSet.
+func (g *GCEAutoscalerLogFieldSet) Kind() string {
+	return "gce_autoscaler"
+}
+
+var _ log.FieldSet = (*GCEAutoscalerLogFieldSet)(nil)
+
+// GCEAutoscalerLogFieldSetReader reads GCEAutoscalerLogFieldSet from autoscaler logs.
+type GCEAutoscalerLogFieldSetReader struct{}
+
+// FieldSetKind implements log.FieldSetReader.
+func (g *GCEAutoscalerLogFieldSetReader) FieldSetKind() string {
+	return (&GCEAutoscalerLogFieldSet{}).Kind()
+}
+
+// Read implements log.FieldSetReader.
+func (g *GCEAutoscalerLogFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	message := reader.ReadStringOrDefault("jsonPayload.message", "")
+	if message == "" {
+		message = reader.ReadStringOrDefault("textPayload", "")
+	}
+	return &GCEAutoscalerLogFieldSet{
+		AutoscalerName: reader.ReadStringOrDefault("resource.labels.autoscaler_name", ""),
+		Message:        message,
+		Status:         reader.ReadStringOrDefault("jsonPayload.status", ""),
+	}, nil
+}
+
+var _ log.FieldSetReader = (*GCEAutoscalerLogFieldSetReader)(nil)
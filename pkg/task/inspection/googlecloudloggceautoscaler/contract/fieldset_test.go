@@ -0,0 +1,67 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudloggceautoscaler_contract
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/model/log"
+	"github.com/kyasbal/khi/pkg/testutil/testlog"
+)
+
+func TestGCEAutoscalerLogFieldSetReader(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		input string
+		want  *GCEAutoscalerLogFieldSet
+	}{
+		{
+			desc: "json payload",
+			input: `logName: projects/test-project/logs/compute.googleapis.com%2Fautoscaler
+jsonPayload:
+  message: Scaling out from 2 to 4 instances because of the CPU utilization.
+  status: ACTIVE
+resource:
+  type: autoscaler
+  labels:
+    autoscaler_name: my-mig-autoscaler
+    location: us-central1-a
+    project_id: test-project`,
+			want: &GCEAutoscalerLogFieldSet{
+				AutoscalerName: "my-mig-autoscaler",
+				Message:        "Scaling out from 2 to 4 instances because of the CPU utilization.",
+				Status:         "ACTIVE",
+			},
+		},
+		{
+			desc: "text payload",
+			input: `logName: projects/test-project/logs/compute.googleapis.com%2Fautoscaler
+textPayload: Autoscaler is unable to scale because the maximum number of instances is reached.`,
+			want: &GCEAutoscalerLogFieldSet{
+				Message: "Autoscaler is unable to scale because the maximum number of instances is reached.",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l := testlog.MustLogFromYAML(tc.input, &GCEAutoscalerLogFieldSetReader{})
+			got := log.MustGetFieldSet(l, &GCEAutoscalerLogFieldSet{})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("MustGetFieldSet() got diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
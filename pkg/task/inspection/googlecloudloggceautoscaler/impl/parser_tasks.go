@@ -0,0 +1,88 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudloggceautoscaler_impl
+
+import (
+	"context"
+	"fmt"
+
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/history"
+	"github.com/kyasbal/khi/pkg/model/history/resourcepath"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudinspectiontypegroup_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudinspectiontypegroup/contract"
+	googlecloudloggceautoscaler_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudloggceautoscaler/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+var FieldSetReaderTask = inspectiontaskbase.NewFieldSetReadTask(googlecloudloggceautoscaler_contract.FieldSetReaderTaskID, googlecloudloggceautoscaler_contract.ListLogEntriesTaskID.Ref(), []log.FieldSetReader{
+	&googlecloudloggceautoscaler_contract.GCEAutoscalerLogFieldSetReader{},
+})
+
+var LogIngesterTask = inspectiontaskbase.NewLogIngesterTask(googlecloudloggceautoscaler_contract.LogIngesterTaskID, googlecloudloggceautoscaler_contract.ListLogEntriesTaskID.Ref())
+
+var LogGrouperTask = inspectiontaskbase.NewLogGrouperTask(googlecloudloggceautoscaler_contract.LogGrouperTaskID, googlecloudloggceautoscaler_contract.FieldSetReaderTaskID.Ref(),
+	func(ctx context.Context, l *log.Log) string {
+		return "" // No grouping
+	},
+)
+
+var LogToTimelineMapperTask = inspectiontaskbase.NewLogToTimelineMapperTask[struct{}](googlecloudloggceautoscaler_contract.LogToTimelineMapperTaskID, &gceAutoscalerLogToTimelineMapperSetting{},
+	inspectioncore_contract.FeatureTaskLabel(`GCE Autoscaler Logs`,
+		`Gather autoscaler logs of the instance group to show the autoscaler decisions on the autoscaler timeline under the instance group.`,
+		enum.LogTypeAutoscaler,
+		8000,
+		true,
+		googlecloudinspectiontypegroup_contract.GCEInstanceGroupInspectionTypes...),
+)
+
+type gceAutoscalerLogToTimelineMapperSetting struct{}
+
+// Dependencies implements inspectiontaskbase.LogToTimelineMapper.
+func (g *gceAutoscalerLogToTimelineMapperSetting) Dependencies() []taskid.UntypedTaskReference {
+	return []taskid.UntypedTaskReference{
+		googlecloudloggceautoscaler_contract.InstanceGroupIdentityTaskID.Ref(),
+	}
+}
+
+// GroupedLogTask implements inspectiontaskbase.LogToTimelineMapper.
+func (g *gceAutoscalerLogToTimelineMapperSetting) GroupedLogTask() taskid.TaskReference[inspectiontaskbase.LogGroupMap] {
+	return googlecloudloggceautoscaler_contract.LogGrouperTaskID.Ref()
+}
+
+// LogIngesterTask implements inspectiontaskbase.LogToTimelineMapper.
+func (g *gceAutoscalerLogToTimelineMapperSetting) LogIngesterTask() taskid.TaskReference[[]*log.Log] {
+	return googlecloudloggceautoscaler_contract.LogIngesterTaskID.Ref()
+}
+
+// ProcessLogByGroup implements inspectiontaskbase.LogToTimelineMapper.
+func (g *gceAutoscalerLogToTimelineMapperSetting) ProcessLogByGroup(ctx context.Context, l *log.Log, cs *history.ChangeSet, builder *history.Builder, prevGroupData struct{}) (struct{}, error) {
+	autoscalerFieldSet := log.MustGetFieldSet(l, &googlecloudloggceautoscaler_contract.GCEAutoscalerLogFieldSet{})
+	instanceGroup := coretask.GetTaskResult(ctx, googlecloudloggceautoscaler_contract.InstanceGroupIdentityTaskID.Ref())
+
+	cs.AddEvent(resourcepath.GCEInstanceGroupAutoscaler(instanceGroup.Location, instanceGroup.InstanceGroupName))
+	switch {
+	case autoscalerFieldSet.Message != "":
+		cs.SetLogSummary(autoscalerFieldSet.Message)
+	case autoscalerFieldSet.Status != "":
+		cs.SetLogSummary(fmt.Sprintf("Autoscaler status: %s", autoscalerFieldSet.Status))
+	}
+	return struct{}{}, nil
+}
+
+var _ inspectiontaskbase.LogToTimelineMapper[struct{}] = (*gceAutoscalerLogToTimelineMapperSetting)(nil)
@@ -0,0 +1,92 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudloggceautoscaler_impl
+
+import (
+	"testing"
+	"time"
+
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	"github.com/kyasbal/khi/pkg/model/history"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudgceinstancegroup_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudgceinstancegroup/contract"
+	googlecloudloggceautoscaler_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudloggceautoscaler/contract"
+	"github.com/kyasbal/khi/pkg/testutil/testchangeset"
+)
+
+func TestLogToTimelineMapperTask(t *testing.T) {
+	testTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		desc     string
+		input    *googlecloudloggceautoscaler_contract.GCEAutoscalerLogFieldSet
+		asserter []testchangeset.ChangeSetAsserter
+	}{
+		{
+			desc: "with message",
+			input: &googlecloudloggceautoscaler_contract.GCEAutoscalerLogFieldSet{
+				AutoscalerName: "my-mig-autoscaler",
+				Message:        "Autoscaler recommended size: 3",
+			},
+			asserter: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchResourcePathSet{
+					WantResourcePaths: []string{
+						"compute.googleapis.com#instancegroupmanager#us-central1-a#my-mig#autoscaler",
+					},
+				},
+				&testchangeset.HasLogSummary{
+					WantLogSummary: "Autoscaler recommended size: 3",
+				},
+			},
+		},
+		{
+			desc: "with status only",
+			input: &googlecloudloggceautoscaler_contract.GCEAutoscalerLogFieldSet{
+				AutoscalerName: "my-mig-autoscaler",
+				Status:         "ERROR",
+			},
+			asserter: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchResourcePathSet{
+					WantResourcePaths: []string{
+						"compute.googleapis.com#instancegroupmanager#us-central1-a#my-mig#autoscaler",
+					},
+				},
+				&testchangeset.HasLogSummary{
+					WantLogSummary: "Autoscaler status: ERROR",
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l := log.NewLogWithFieldSetsForTest(
+				&log.CommonFieldSet{Timestamp: testTime},
+				tc.input,
+			)
+			cs := history.NewChangeSet(l)
+			ctx := tasktest.WithTaskResult(t.Context(), googlecloudloggceautoscaler_contract.InstanceGroupIdentityTaskID.Ref(), googlecloudgceinstancegroup_contract.GCEInstanceGroupIdentity{
+				ProjectID:         "test-project",
+				Location:          "us-central1-a",
+				InstanceGroupName: "my-mig",
+			})
+			_, err := (&gceAutoscalerLogToTimelineMapperSetting{}).ProcessLogByGroup(ctx, l, cs, nil, struct{}{})
+			if err != nil {
+				t.Fatalf("ProcessLogByGroup() error = %v", err)
+			}
+			for _, asserter := range tc.asserter {
+				asserter.Assert(t, cs)
+			}
+		})
+	}
+}
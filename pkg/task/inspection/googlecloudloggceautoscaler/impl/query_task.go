@@ -0,0 +1,86 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudloggceautoscaler_impl
+
+import (
+	"context"
+	"fmt"
+
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	googlecloudgceinstancegroup_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudgceinstancegroup/contract"
+	googlecloudloggceautoscaler_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudloggceautoscaler/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// GenerateGCEAutoscalerQuery generates a query for autoscaler logs of the given instance group.
+// Autoscaler logs are filtered with the instance group name as a global text search because the autoscaler name can differ from the instance group name.
+func GenerateGCEAutoscalerQuery(instanceGroup googlecloudgceinstancegroup_contract.GCEInstanceGroupIdentity) string {
+	return fmt.Sprintf(`resource.type="autoscaler"
+"%s"
+`, instanceGroup.InstanceGroupName)
+}
+
+type gceAutoscalerListLogEntriesTaskSetting struct {
+}
+
+// DefaultResourceNames implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
+func (g *gceAutoscalerListLogEntriesTaskSetting) DefaultResourceNames(ctx context.Context) ([]string, error) {
+	instanceGroup := coretask.GetTaskResult(ctx, googlecloudloggceautoscaler_contract.InstanceGroupIdentityTaskID.Ref())
+	return []string{fmt.Sprintf("projects/%s", instanceGroup.ProjectID)}, nil
+}
+
+// Dependencies implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
+func (g *gceAutoscalerListLogEntriesTaskSetting) Dependencies() []taskid.UntypedTaskReference {
+	return []taskid.UntypedTaskReference{
+		googlecloudloggceautoscaler_contract.InstanceGroupIdentityTaskID.Ref(),
+	}
+}
+
+// Description implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
+func (g *gceAutoscalerListLogEntriesTaskSetting) Description() *googlecloudcommon_contract.ListLogEntriesTaskDescription {
+	return &googlecloudcommon_contract.ListLogEntriesTaskDescription{
+		DefaultLogType: enum.LogTypeAutoscaler,
+		QueryName:      "GCE autoscaler log",
+		ExampleQuery: GenerateGCEAutoscalerQuery(googlecloudgceinstancegroup_contract.GCEInstanceGroupIdentity{
+			ProjectID:         "gcp-project-id",
+			Location:          "us-central1-a",
+			InstanceGroupName: "example-instance-group",
+		}),
+	}
+}
+
+// LogFilters implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
+func (g *gceAutoscalerListLogEntriesTaskSetting) LogFilters(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) ([]string, error) {
+	instanceGroup := coretask.GetTaskResult(ctx, googlecloudloggceautoscaler_contract.InstanceGroupIdentityTaskID.Ref())
+	return []string{GenerateGCEAutoscalerQuery(instanceGroup)}, nil
+}
+
+// TaskID implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
+func (g *gceAutoscalerListLogEntriesTaskSetting) TaskID() taskid.TaskImplementationID[[]*log.Log] {
+	return googlecloudloggceautoscaler_contract.ListLogEntriesTaskID
+}
+
+// TimePartitionCount implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
+func (g *gceAutoscalerListLogEntriesTaskSetting) TimePartitionCount(ctx context.Context) (int, error) {
+	return 1, nil
+}
+
+var _ googlecloudcommon_contract.ListLogEntriesTaskSetting = (*gceAutoscalerListLogEntriesTaskSetting)(nil)
+
+var ListLogEntriesTask = googlecloudcommon_contract.NewListLogEntriesTask(&gceAutoscalerListLogEntriesTaskSetting{})
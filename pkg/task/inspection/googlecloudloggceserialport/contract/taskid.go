@@ -0,0 +1,46 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudloggceserialport_contract
+
+import (
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudgceinstancegroup_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudgceinstancegroup/contract"
+)
+
+// TaskIDPrefix is the prefix for the task IDs of the serial port log tasks of GCE instance groups.
+const TaskIDPrefix = "cloud.google.com/log/gce-serialport/"
+
+// InstanceGroupIdentityTaskID is the task id for aliasing the instance group identity.
+var InstanceGroupIdentityTaskID = taskid.NewDefaultImplementationID[googlecloudgceinstancegroup_contract.GCEInstanceGroupIdentity](TaskIDPrefix + "instance-group-identity")
+
+// ListLogEntriesTaskID is the task id for the task that queries serial port logs from the instances in the instance group.
+var ListLogEntriesTaskID = taskid.NewDefaultImplementationID[[]*log.Log](TaskIDPrefix + "query")
+
+// FieldSetReadTaskID is the task id for reading serial port specific fields(GCESerialPortLogFieldSet).
+var FieldSetReadTaskID = taskid.NewDefaultImplementationID[[]*log.Log](TaskIDPrefix + "field-set-read")
+
+// LogFilterTaskID is the task id for filtering empty messages included in the serial port logs.
+var LogFilterTaskID = taskid.NewDefaultImplementationID[[]*log.Log](TaskIDPrefix + "filter")
+
+// LogIngesterTaskID is the task id to serialize logs to history.
+var LogIngesterTaskID = taskid.NewDefaultImplementationID[[]*log.Log](TaskIDPrefix + "log-ingester")
+
+// LogGrouperTaskID is the task id to group logs by instance name and serial port number.
+var LogGrouperTaskID = taskid.NewDefaultImplementationID[inspectiontaskbase.LogGroupMap](TaskIDPrefix + "log-grouper")
+
+// LogToTimelineMapperTaskID is the task id to relate serialized logs to events on timeline.
+var LogToTimelineMapperTaskID = taskid.NewDefaultImplementationID[struct{}](TaskIDPrefix + "timeline-mapper")
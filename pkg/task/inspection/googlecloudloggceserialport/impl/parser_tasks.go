@@ -0,0 +1,97 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudloggceserialport_impl
+
+import (
+	"context"
+
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/history"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudinspectiontypegroup_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudinspectiontypegroup/contract"
+	googlecloudloggceserialport_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudloggceserialport/contract"
+	googlecloudlogserialport_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogserialport/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// FieldSetReadTask is the task to run GCESerialPortLogFieldSetReader on logs to parse serial port logs.
+var FieldSetReadTask = inspectiontaskbase.NewFieldSetReadTask(googlecloudloggceserialport_contract.FieldSetReadTaskID, googlecloudloggceserialport_contract.ListLogEntriesTaskID.Ref(), []log.FieldSetReader{
+	&googlecloudlogserialport_contract.GCESerialPortLogFieldSetReader{},
+})
+
+// LogFilterTask removes logs with empty message.
+var LogFilterTask = inspectiontaskbase.NewLogFilterTask(googlecloudloggceserialport_contract.LogFilterTaskID, googlecloudloggceserialport_contract.FieldSetReadTaskID.Ref(),
+	func(ctx context.Context, l *log.Log) bool {
+		return log.MustGetFieldSet(l, &googlecloudlogserialport_contract.GCESerialPortLogFieldSet{}).Message != ""
+	},
+)
+
+// LogIngesterTask is the log serializer task for serial port logs of GCE instance groups.
+var LogIngesterTask = inspectiontaskbase.NewLogIngesterTask(
+	googlecloudloggceserialport_contract.LogIngesterTaskID,
+	googlecloudloggceserialport_contract.LogFilterTaskID.Ref(),
+)
+
+// LogGrouperTask is the grouper task for serial port logs of GCE instance groups.
+// It groups logs by the instance name and port name
+var LogGrouperTask = inspectiontaskbase.NewLogGrouperTask(
+	googlecloudloggceserialport_contract.LogGrouperTaskID,
+	googlecloudloggceserialport_contract.LogFilterTaskID.Ref(),
+	func(ctx context.Context, l *log.Log) string {
+		return log.MustGetFieldSet(l, &googlecloudlogserialport_contract.GCESerialPortLogFieldSet{}).GetResourcePath().Path
+	},
+)
+
+// LogToTimelineMapperTask is the task to map serial port logs into events on the serial port timelines under instances.
+var LogToTimelineMapperTask = inspectiontaskbase.NewLogToTimelineMapperTask[struct{}](
+	googlecloudloggceserialport_contract.LogToTimelineMapperTaskID,
+	&gceSerialPortLogToTimelineMapper{},
+	inspectioncore_contract.FeatureTaskLabel(
+		"GCE Serialport log",
+		`Serialport logs from the instances in the instance group. This helps investigating VM bootstrapping issues and node components started by the startup script.`,
+		enum.LogTypeSerialPort,
+		10000, true, googlecloudinspectiontypegroup_contract.GCEInstanceGroupInspectionTypes...,
+	),
+)
+
+type gceSerialPortLogToTimelineMapper struct {
+}
+
+// Dependencies implements inspectiontaskbase.LogToTimelineMapper.
+func (g *gceSerialPortLogToTimelineMapper) Dependencies() []taskid.UntypedTaskReference {
+	return []taskid.UntypedTaskReference{}
+}
+
+// GroupedLogTask implements inspectiontaskbase.LogToTimelineMapper.
+func (g *gceSerialPortLogToTimelineMapper) GroupedLogTask() taskid.TaskReference[inspectiontaskbase.LogGroupMap] {
+	return googlecloudloggceserialport_contract.LogGrouperTaskID.Ref()
+}
+
+// LogIngesterTask implements inspectiontaskbase.LogToTimelineMapper.
+func (g *gceSerialPortLogToTimelineMapper) LogIngesterTask() taskid.TaskReference[[]*log.Log] {
+	return googlecloudloggceserialport_contract.LogIngesterTaskID.Ref()
+}
+
+// ProcessLogByGroup implements inspectiontaskbase.LogToTimelineMapper.
+func (g *gceSerialPortLogToTimelineMapper) ProcessLogByGroup(ctx context.Context, l *log.Log, cs *history.ChangeSet, builder *history.Builder, prevGroupData struct{}) (struct{}, error) {
+	serialportFieldSet := log.MustGetFieldSet(l, &googlecloudlogserialport_contract.GCESerialPortLogFieldSet{})
+	cs.AddEvent(serialportFieldSet.GetResourcePath())
+	cs.SetLogSummary(serialportFieldSet.Message)
+	return struct{}{}, nil
+}
+
+var _ inspectiontaskbase.LogToTimelineMapper[struct{}] = (*gceSerialPortLogToTimelineMapper)(nil)
@@ -0,0 +1,89 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudloggceserialport_impl
+
+import (
+	"context"
+	"fmt"
+
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	googlecloudgceinstancegroup_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudgceinstancegroup/contract"
+	googlecloudloggceserialport_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudloggceserialport/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// GenerateGCESerialPortQuery generates a query for serial port logs of the instances in the given instance group.
+func GenerateGCESerialPortQuery(instanceGroup googlecloudgceinstancegroup_contract.GCEInstanceGroupIdentity) string {
+	return fmt.Sprintf(`LOG_ID("serialconsole.googleapis.com%%2Fserial_port_1_output") OR
+LOG_ID("serialconsole.googleapis.com%%2Fserial_port_2_output") OR
+LOG_ID("serialconsole.googleapis.com%%2Fserial_port_3_output") OR
+LOG_ID("serialconsole.googleapis.com%%2Fserial_port_debug_output")
+
+resource.type="gce_instance"
+labels."compute.googleapis.com/resource_name":"%s"`, instanceGroup.InstanceNamePrefix())
+}
+
+type gceSerialPortListLogEntriesTaskSetting struct {
+}
+
+// DefaultResourceNames implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
+func (g *gceSerialPortListLogEntriesTaskSetting) DefaultResourceNames(ctx context.Context) ([]string, error) {
+	instanceGroup := coretask.GetTaskResult(ctx, googlecloudloggceserialport_contract.InstanceGroupIdentityTaskID.Ref())
+	return []string{fmt.Sprintf("projects/%s", instanceGroup.ProjectID)}, nil
+}
+
+// Dependencies implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
+func (g *gceSerialPortListLogEntriesTaskSetting) Dependencies() []taskid.UntypedTaskReference {
+	return []taskid.UntypedTaskReference{
+		googlecloudloggceserialport_contract.InstanceGroupIdentityTaskID.Ref(),
+	}
+}
+
+// Description implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
+func (g *gceSerialPortListLogEntriesTaskSetting) Description() *googlecloudcommon_contract.ListLogEntriesTaskDescription {
+	return &googlecloudcommon_contract.ListLogEntriesTaskDescription{
+		DefaultLogType: enum.LogTypeSerialPort,
+		QueryName:      "Serial port log",
+		ExampleQuery: GenerateGCESerialPortQuery(googlecloudgceinstancegroup_contract.GCEInstanceGroupIdentity{
+			ProjectID:         "gcp-project-id",
+			Location:          "us-central1-a",
+			InstanceGroupName: "example-instance-group",
+		}),
+	}
+}
+
+// LogFilters implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
+func (g *gceSerialPortListLogEntriesTaskSetting) LogFilters(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) ([]string, error) {
+	instanceGroup := coretask.GetTaskResult(ctx, googlecloudloggceserialport_contract.InstanceGroupIdentityTaskID.Ref())
+	return []string{GenerateGCESerialPortQuery(instanceGroup)}, nil
+}
+
+// TaskID implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
+func (g *gceSerialPortListLogEntriesTaskSetting) TaskID() taskid.TaskImplementationID[[]*log.Log] {
+	return googlecloudloggceserialport_contract.ListLogEntriesTaskID
+}
+
+// TimePartitionCount implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
+func (g *gceSerialPortListLogEntriesTaskSetting) TimePartitionCount(ctx context.Context) (int, error) {
+	return 10, nil
+}
+
+var _ googlecloudcommon_contract.ListLogEntriesTaskSetting = (*gceSerialPortListLogEntriesTaskSetting)(nil)
+
+var ListLogEntriesTask = googlecloudcommon_contract.NewListLogEntriesTask(&gceSerialPortListLogEntriesTaskSetting{})
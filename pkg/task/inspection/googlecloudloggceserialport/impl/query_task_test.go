@@ -0,0 +1,40 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudloggceserialport_impl
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	googlecloudgceinstancegroup_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudgceinstancegroup/contract"
+)
+
+func TestGenerateGCESerialPortQuery(t *testing.T) {
+	want := `LOG_ID("serialconsole.googleapis.com%2Fserial_port_1_output") OR
+LOG_ID("serialconsole.googleapis.com%2Fserial_port_2_output") OR
+LOG_ID("serialconsole.googleapis.com%2Fserial_port_3_output") OR
+LOG_ID("serialconsole.googleapis.com%2Fserial_port_debug_output")
+
+resource.type="gce_instance"
+labels."compute.googleapis.com/resource_name":"my-mig-"`
+	got := GenerateGCESerialPortQuery(googlecloudgceinstancegroup_contract.GCEInstanceGroupIdentity{
+		ProjectID:         "test-project",
+		Location:          "us-central1-a",
+		InstanceGroupName: "my-mig",
+	})
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GenerateGCESerialPortQuery() mismatch (-want +got):\n%s", diff)
+	}
+}
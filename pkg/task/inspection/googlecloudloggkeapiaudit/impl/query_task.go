@@ -51,6 +51,7 @@ func (g *gkeAPIListLogEntriesTaskSetting) DefaultResourceNames(ctx context.Conte
 func (g *gkeAPIListLogEntriesTaskSetting) Dependencies() []taskid.UntypedTaskReference {
 	return []taskid.UntypedTaskReference{
 		googlecloudloggkeapiaudit_contract.ClusterIdentityTaskID.Ref(),
+		googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref(),
 	}
 }
 
@@ -65,8 +66,12 @@ func (g *gkeAPIListLogEntriesTaskSetting) Description() *googlecloudcommon_contr
 
 // LogFilters implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
 func (g *gkeAPIListLogEntriesTaskSetting) LogFilters(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) ([]string, error) {
-	cluster := coretask.GetTaskResult(ctx, googlecloudloggkeapiaudit_contract.ClusterIdentityTaskID.Ref())
-	return []string{GenerateGKEAuditQuery(cluster)}, nil
+	clusters := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref())
+	filters := []string{}
+	for _, cluster := range clusters {
+		filters = append(filters, GenerateGKEAuditQuery(cluster))
+	}
+	return filters, nil
 }
 
 // TaskID implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
@@ -53,6 +53,7 @@ func (a *autoscalerListLogEntriesTaskSetting) DefaultResourceNames(ctx context.C
 func (a *autoscalerListLogEntriesTaskSetting) Dependencies() []taskid.UntypedTaskReference {
 	return []taskid.UntypedTaskReference{
 		googlecloudk8scommon_contract.ClusterIdentityTaskID.Ref(),
+		googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref(),
 	}
 }
 
@@ -71,8 +72,12 @@ func (a *autoscalerListLogEntriesTaskSetting) Description() *googlecloudcommon_c
 
 // LogFilters implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
 func (a *autoscalerListLogEntriesTaskSetting) LogFilters(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) ([]string, error) {
-	cluster := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.ClusterIdentityTaskID.Ref())
-	return []string{generateAutoscalerQuery(cluster, true)}, nil
+	clusters := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref())
+	filters := []string{}
+	for _, cluster := range clusters {
+		filters = append(filters, generateAutoscalerQuery(cluster, true))
+	}
+	return filters, nil
 }
 
 // TaskID implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
@@ -0,0 +1,80 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogk8saudit_contract
+
+import (
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/common/structured"
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+// wardenRejectionMessage is the message included in the status message when GKE Warden denies a request violating the Autopilot constraints.
+const wardenRejectionMessage = "GKE Warden rejected the request"
+
+// autoProvisionedNodePoolPrefix is the prefix of node pool names created by node auto-provisioning.
+const autoProvisionedNodePoolPrefix = "nap-"
+
+// GKEAutopilotAuditLogFieldSet is the field set for the fields only meaningful in k8s audit logs of GKE Autopilot clusters.
+type GKEAutopilotAuditLogFieldSet struct {
+	// ClusterName is the name of the cluster emitting the log.
+	ClusterName string
+	// ResourceAdjustment is the value of `autopilot.gke.io/resource-adjustment` annotation written when Autopilot modified the resource requests of the workload.
+	ResourceAdjustment string
+	// WardenRejected is true when GKE Warden denied the request because it violated the Autopilot constraints.
+	WardenRejected bool
+	// NodePoolName is the node pool name of the node resource in the request or the response.
+	NodePoolName string
+}
+
+// Kind implements log.FieldSet.
+func (g *GKEAutopilotAuditLogFieldSet) Kind() string {
+	return "gke_autopilot_audit_log"
+}
+
+// IsAutoProvisionedNodePool returns true if the node pool is created by node auto-provisioning.
+func (g *GKEAutopilotAuditLogFieldSet) IsAutoProvisionedNodePool() bool {
+	return strings.HasPrefix(g.NodePoolName, autoProvisionedNodePoolPrefix)
+}
+
+var _ log.FieldSet = (*GKEAutopilotAuditLogFieldSet)(nil)
+
+type GKEAutopilotAuditLogFieldSetReader struct{}
+
+// FieldSetKind implements log.FieldSetReader.
+func (g *GKEAutopilotAuditLogFieldSetReader) FieldSetKind() string {
+	return (&GKEAutopilotAuditLogFieldSet{}).Kind()
+}
+
+// Read implements log.FieldSetReader.
+func (g *GKEAutopilotAuditLogFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	var result GKEAutopilotAuditLogFieldSet
+	result.ClusterName = reader.ReadStringOrDefault("resource.labels.cluster_name", "")
+	result.ResourceAdjustment = readFromResponseOrRequest(reader, "metadata.annotations.autopilot\\.gke\\.io/resource-adjustment")
+	result.WardenRejected = strings.Contains(reader.ReadStringOrDefault("protoPayload.status.message", ""), wardenRejectionMessage)
+	result.NodePoolName = readFromResponseOrRequest(reader, "metadata.labels.cloud\\.google\\.com/gke-nodepool")
+	return &result, nil
+}
+
+var _ log.FieldSetReader = (*GKEAutopilotAuditLogFieldSetReader)(nil)
+
+// readFromResponseOrRequest reads the field from the response body, or from the request body when the response doesn't have it.
+// Mutating admission webhooks modify the manifest after the request, so the response is preferred.
+func readFromResponseOrRequest(reader *structured.NodeReader, fieldPath string) string {
+	if value := reader.ReadStringOrDefault("protoPayload.response."+fieldPath, ""); value != "" {
+		return value
+	}
+	return reader.ReadStringOrDefault("protoPayload.request."+fieldPath, "")
+}
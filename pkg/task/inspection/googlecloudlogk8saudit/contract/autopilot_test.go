@@ -0,0 +1,99 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogk8saudit_contract
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+func TestGKEAutopilotAuditLogFieldSetReader(t *testing.T) {
+	testCases := []struct {
+		desc string
+		log  string
+		want *GKEAutopilotAuditLogFieldSet
+	}{
+		{
+			desc: "resource adjustment in the response",
+			log: `resource:
+  labels:
+    cluster_name: foo-cluster
+protoPayload:
+  request:
+    metadata:
+      name: nginx
+  response:
+    metadata:
+      name: nginx
+      annotations:
+        autopilot.gke.io/resource-adjustment: '{"modified":true}'`,
+			want: &GKEAutopilotAuditLogFieldSet{
+				ClusterName:        "foo-cluster",
+				ResourceAdjustment: `{"modified":true}`,
+			},
+		},
+		{
+			desc: "rejected by GKE Warden",
+			log: `resource:
+  labels:
+    cluster_name: foo-cluster
+protoPayload:
+  status:
+    code: 7
+    message: 'admission webhook "warden-validating.common-webhooks.networking.gke.io" denied the request: GKE Warden rejected the request because it violates one or more constraints.'`,
+			want: &GKEAutopilotAuditLogFieldSet{
+				ClusterName:    "foo-cluster",
+				WardenRejected: true,
+			},
+		},
+		{
+			desc: "rejected by other admission webhooks",
+			log: `protoPayload:
+  status:
+    code: 7
+    message: 'admission webhook "validate.kyverno.svc" denied the request'`,
+			want: &GKEAutopilotAuditLogFieldSet{},
+		},
+		{
+			desc: "node pool name in the request",
+			log: `protoPayload:
+  request:
+    metadata:
+      labels:
+        cloud.google.com/gke-nodepool: nap-1a2b3c`,
+			want: &GKEAutopilotAuditLogFieldSet{
+				NodePoolName: "nap-1a2b3c",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l, err := log.NewLogFromYAMLString(tc.log)
+			if err != nil {
+				t.Fatalf("failed to parse the log: %v", err)
+			}
+			reader := &GKEAutopilotAuditLogFieldSetReader{}
+			got, err := reader.Read(l.NodeReader)
+			if err != nil {
+				t.Fatalf("Read() returned an unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Read() returned an unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -45,6 +45,9 @@ func (g *GCPK8sAuditLogFieldSetReader) Read(reader *structured.NodeReader) (log.
 	result.StatusCode = reader.ReadIntOrDefault("protoPayload.status.code", 0)
 	result.StatusMessage = reader.ReadStringOrDefault("protoPayload.status.message", "")
 	result.IsError = result.StatusCode != 0
+	// Audit annotations are written as labels of the log entry. The gRPC status code 7 is PERMISSION_DENIED.
+	decision := reader.ReadStringOrDefault("labels.authorization\\.k8s\\.io/decision", "")
+	result.IsPermissionDenied = decision == commonlogk8sauditv2_contract.AuthorizationDecisionForbid || (decision == "" && result.StatusCode == 7)
 	result.Request, _ = reader.GetReader("protoPayload.request")
 	result.Response, _ = reader.GetReader("protoPayload.response")
 	return &result, nil
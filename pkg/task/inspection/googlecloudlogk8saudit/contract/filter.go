@@ -0,0 +1,57 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogk8saudit_contract
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/gcpqueryutil"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+)
+
+var k8sAuditMutatingVerbs = []string{"create", "update", "patch", "delete"}
+
+// MatchK8sAuditLog returns true when the given k8s audit log matches the kind and namespace filters.
+// This is the client side equivalent of the Cloud Logging query for the logs not queried from Cloud Logging(e.g. logs exported by a log sink).
+func MatchK8sAuditLog(l *log.Log, kindFilter *gcpqueryutil.SetFilterParseResult, namespaceFilter *gcpqueryutil.SetFilterParseResult) bool {
+	if l.ReadStringOrDefault("resource.type", "") != "k8s_cluster" {
+		return false
+	}
+	methodName := l.ReadStringOrDefault("protoPayload.methodName", "")
+	if !slices.ContainsFunc(k8sAuditMutatingVerbs, func(verb string) bool { return strings.Contains(methodName, verb) }) {
+		return false
+	}
+	resourceName := l.ReadStringOrDefault("protoPayload.resourceName", "")
+	return matchK8sAuditKind(methodName, kindFilter) && googlecloudk8scommon_contract.MatchNamespaceFilter(strings.Contains(resourceName, "namespaces/"), func(namespace string) bool {
+		return strings.Contains(resourceName, "/namespaces/"+namespace)
+	}, namespaceFilter)
+}
+
+// matchK8sAuditKind returns true when the method name contains one of kinds selected in the filter.
+func matchK8sAuditKind(methodName string, filter *gcpqueryutil.SetFilterParseResult) bool {
+	if filter.ValidationError != "" {
+		return true
+	}
+	containsKind := func(kind string) bool { return strings.Contains(methodName, "."+kind+".") }
+	if filter.SubtractMode {
+		return !slices.ContainsFunc(filter.Subtractives, containsKind)
+	}
+	if len(filter.Additives) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(filter.Additives, containsKind)
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogk8saudit_contract
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/gcpqueryutil"
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+func mustAuditLog(t *testing.T, methodName string, resourceName string) *log.Log {
+	t.Helper()
+	l, err := log.NewLogFromYAMLString(fmt.Sprintf(`resource:
+  type: k8s_cluster
+protoPayload:
+  methodName: %s
+  resourceName: %s`, methodName, resourceName))
+	if err != nil {
+		t.Fatalf("failed to parse the test log: %v", err)
+	}
+	return l
+}
+
+func TestMatchK8sAuditLog(t *testing.T) {
+	anyKinds := &gcpqueryutil.SetFilterParseResult{SubtractMode: true}
+	allNamespaces := &gcpqueryutil.SetFilterParseResult{Additives: []string{"#cluster-scoped", "#namespaced"}}
+	testCases := []struct {
+		name            string
+		log             *log.Log
+		kindFilter      *gcpqueryutil.SetFilterParseResult
+		namespaceFilter *gcpqueryutil.SetFilterParseResult
+		want            bool
+	}{
+		{
+			name:            "mutating request without filters",
+			log:             mustAuditLog(t, "io.k8s.core.v1.pods.create", "core/v1/namespaces/default/pods/foo"),
+			kindFilter:      anyKinds,
+			namespaceFilter: allNamespaces,
+			want:            true,
+		},
+		{
+			name:            "read only request",
+			log:             mustAuditLog(t, "io.k8s.core.v1.pods.get", "core/v1/namespaces/default/pods/foo"),
+			kindFilter:      anyKinds,
+			namespaceFilter: allNamespaces,
+			want:            false,
+		},
+		{
+			name:            "kind in additives",
+			log:             mustAuditLog(t, "io.k8s.core.v1.pods.update", "core/v1/namespaces/default/pods/foo"),
+			kindFilter:      &gcpqueryutil.SetFilterParseResult{Additives: []string{"deployments", "pods"}},
+			namespaceFilter: allNamespaces,
+			want:            true,
+		},
+		{
+			name:            "kind not in additives",
+			log:             mustAuditLog(t, "io.k8s.core.v1.pods.update", "core/v1/namespaces/default/pods/foo"),
+			kindFilter:      &gcpqueryutil.SetFilterParseResult{Additives: []string{"deployments"}},
+			namespaceFilter: allNamespaces,
+			want:            false,
+		},
+		{
+			name:            "kind in subtractives",
+			log:             mustAuditLog(t, "io.k8s.core.v1.pods.update", "core/v1/namespaces/default/pods/foo"),
+			kindFilter:      &gcpqueryutil.SetFilterParseResult{SubtractMode: true, Subtractives: []string{"pods"}},
+			namespaceFilter: allNamespaces,
+			want:            false,
+		},
+		{
+			name:            "namespace in additives",
+			log:             mustAuditLog(t, "io.k8s.core.v1.pods.delete", "core/v1/namespaces/default/pods/foo"),
+			kindFilter:      anyKinds,
+			namespaceFilter: &gcpqueryutil.SetFilterParseResult{Additives: []string{"default"}},
+			want:            true,
+		},
+		{
+			name:            "namespace not in additives",
+			log:             mustAuditLog(t, "io.k8s.core.v1.pods.delete", "core/v1/namespaces/kube-system/pods/foo"),
+			kindFilter:      anyKinds,
+			namespaceFilter: &gcpqueryutil.SetFilterParseResult{Additives: []string{"default"}},
+			want:            false,
+		},
+		{
+			name:            "cluster scoped resource with #cluster-scoped",
+			log:             mustAuditLog(t, "io.k8s.core.v1.nodes.patch", "core/v1/nodes/foo"),
+			kindFilter:      anyKinds,
+			namespaceFilter: &gcpqueryutil.SetFilterParseResult{Additives: []string{"#cluster-scoped"}},
+			want:            true,
+		},
+		{
+			name:            "namespaced resource with #cluster-scoped",
+			log:             mustAuditLog(t, "io.k8s.core.v1.pods.patch", "core/v1/namespaces/default/pods/foo"),
+			kindFilter:      anyKinds,
+			namespaceFilter: &gcpqueryutil.SetFilterParseResult{Additives: []string{"#cluster-scoped"}},
+			want:            false,
+		},
+		{
+			name:            "namespaced resource with #cluster-scoped and the namespace",
+			log:             mustAuditLog(t, "io.k8s.core.v1.pods.patch", "core/v1/namespaces/default/pods/foo"),
+			kindFilter:      anyKinds,
+			namespaceFilter: &gcpqueryutil.SetFilterParseResult{Additives: []string{"#cluster-scoped", "default"}},
+			want:            true,
+		},
+		{
+			name:            "cluster scoped resource with #namespaced",
+			log:             mustAuditLog(t, "io.k8s.core.v1.nodes.patch", "core/v1/nodes/foo"),
+			kindFilter:      anyKinds,
+			namespaceFilter: &gcpqueryutil.SetFilterParseResult{Additives: []string{"#namespaced"}},
+			want:            false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := MatchK8sAuditLog(tc.log, tc.kindFilter, tc.namespaceFilter)
+			if got != tc.want {
+				t.Errorf("MatchK8sAuditLog() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
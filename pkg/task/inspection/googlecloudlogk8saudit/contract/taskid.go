@@ -28,3 +28,8 @@ var GCPK8sAuditLogListLogEntriesTaskID = taskid.NewDefaultImplementationID[[]*lo
 var GCPK8sAuditLogCommonFieldSetReaderTaskID = taskid.NewImplementationID(commonlogk8sauditv2_contract.K8sAuditLogProviderRef, "gcp")
 
 var GCPK8sAuditLogParserTailTaskID = taskid.NewImplementationID(commonlogk8sauditv2_contract.K8sAuditLogParserTailRef, "gcp")
+
+var GCPK8sAuditPermissionDeniedParserTailTaskID = taskid.NewImplementationID(commonlogk8sauditv2_contract.K8sAuditPermissionDeniedParserTailRef, "gcp")
+
+// GKEAutopilotLogToTimelineMapperTaskID is the task ID for the task to record events specific to GKE Autopilot clusters.
+var GKEAutopilotLogToTimelineMapperTaskID = taskid.NewDefaultImplementationID[struct{}](TaskIDPrefix + "autopilot-timeline-mapper")
@@ -0,0 +1,96 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogk8saudit_impl
+
+import (
+	"context"
+
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/history"
+	"github.com/kyasbal/khi/pkg/model/history/resourcepath"
+	"github.com/kyasbal/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/kyasbal/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	googlecloudlogk8saudit_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8saudit/contract"
+)
+
+// GKEAutopilotLogToTimelineMapperTask records events specific to GKE Autopilot clusters.
+// It records node auto-provisioning on the node pool timelines, workload separation violations denied by GKE Warden and resource adjustments on the workload timelines.
+// The task does nothing when none of the selected clusters is an Autopilot cluster.
+var GKEAutopilotLogToTimelineMapperTask = inspectiontaskbase.NewLogToTimelineMapperTask[struct{}](googlecloudlogk8saudit_contract.GKEAutopilotLogToTimelineMapperTaskID, &gkeAutopilotLogToTimelineMapperTaskSetting{})
+
+type gkeAutopilotLogToTimelineMapperTaskSetting struct{}
+
+// Dependencies implements inspectiontaskbase.LogToTimelineMapper.
+func (g *gkeAutopilotLogToTimelineMapperTaskSetting) Dependencies() []taskid.UntypedTaskReference {
+	return []taskid.UntypedTaskReference{
+		googlecloudk8scommon_contract.AutopilotClusterTaskID.Ref(),
+	}
+}
+
+// GroupedLogTask implements inspectiontaskbase.LogToTimelineMapper.
+func (g *gkeAutopilotLogToTimelineMapperTaskSetting) GroupedLogTask() taskid.TaskReference[inspectiontaskbase.LogGroupMap] {
+	return commonlogk8sauditv2_contract.LogSummaryGrouperTaskID.Ref()
+}
+
+// LogIngesterTask implements inspectiontaskbase.LogToTimelineMapper.
+func (g *gkeAutopilotLogToTimelineMapperTaskSetting) LogIngesterTask() taskid.TaskReference[[]*log.Log] {
+	return commonlogk8sauditv2_contract.K8sAuditLogIngesterTaskID.Ref()
+}
+
+// ProcessLogByGroup implements inspectiontaskbase.LogToTimelineMapper.
+func (g *gkeAutopilotLogToTimelineMapperTaskSetting) ProcessLogByGroup(ctx context.Context, l *log.Log, cs *history.ChangeSet, builder *history.Builder, prevGroupData struct{}) (struct{}, error) {
+	isAutopilot := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.AutopilotClusterTaskID.Ref())
+	if !isAutopilot {
+		return struct{}{}, nil
+	}
+	return struct{}{}, g.addEventsForLog(l, cs)
+}
+
+var _ inspectiontaskbase.LogToTimelineMapper[struct{}] = (*gkeAutopilotLogToTimelineMapperTaskSetting)(nil)
+
+// addEventsForLog adds events for the Autopilot specific operations found in the log.
+func (g *gkeAutopilotLogToTimelineMapperTaskSetting) addEventsForLog(l *log.Log, cs *history.ChangeSet) error {
+	commonFieldSet := log.MustGetFieldSet(l, &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{})
+	autopilotFieldSet, err := log.GetFieldSet(l, &googlecloudlogk8saudit_contract.GKEAutopilotAuditLogFieldSet{})
+	if err != nil {
+		return err
+	}
+	op := commonFieldSet.K8sOperation
+	if op == nil {
+		return nil
+	}
+
+	if autopilotFieldSet.WardenRejected {
+		cs.AddEvent(resourcepath.AutopilotWarden(autopilotFieldSet.ClusterName))
+		return nil
+	}
+	if commonFieldSet.IsError || op.Verb != enum.RevisionVerbCreate {
+		return nil
+	}
+	if op.PluralKind == "nodes" && autopilotFieldSet.IsAutoProvisionedNodePool() {
+		cs.AddEvent(resourcepath.Nodepool(autopilotFieldSet.ClusterName, autopilotFieldSet.NodePoolName))
+	}
+	if autopilotFieldSet.ResourceAdjustment != "" {
+		cs.AddEvent(resourcepath.ResourcePath{
+			Path:               op.ResourcePath(),
+			ParentRelationship: enum.RelationshipChild,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogk8saudit_impl
+
+import (
+	"testing"
+
+	"github.com/kyasbal/khi/pkg/model"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/history"
+	"github.com/kyasbal/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/kyasbal/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	googlecloudlogk8saudit_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8saudit/contract"
+	"github.com/kyasbal/khi/pkg/testutil/testchangeset"
+)
+
+func TestGKEAutopilotLogToTimelineMapperTaskSetting_AddEventsForLog(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		common    *commonlogk8sauditv2_contract.K8sAuditLogFieldSet
+		autopilot *googlecloudlogk8saudit_contract.GKEAutopilotAuditLogFieldSet
+		wantPath  []string
+	}{
+		{
+			desc: "request rejected by GKE Warden",
+			common: &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
+				K8sOperation: &model.KubernetesObjectOperation{APIVersion: "core/v1", PluralKind: "pods", Namespace: "default", Name: "privileged", Verb: enum.RevisionVerbCreate},
+				IsError:      true,
+			},
+			autopilot: &googlecloudlogk8saudit_contract.GKEAutopilotAuditLogFieldSet{ClusterName: "foo-cluster", WardenRejected: true},
+			wantPath: []string{
+				"@Cluster#controlplane#cluster-scope#foo-cluster#warden",
+			},
+		},
+		{
+			desc: "node created in an auto-provisioned node pool",
+			common: &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
+				K8sOperation: &model.KubernetesObjectOperation{APIVersion: "core/v1", PluralKind: "nodes", Namespace: "cluster-scope", Name: "gk3-foo-cluster-nap-1a2b3c-abcd", Verb: enum.RevisionVerbCreate},
+			},
+			autopilot: &googlecloudlogk8saudit_contract.GKEAutopilotAuditLogFieldSet{ClusterName: "foo-cluster", NodePoolName: "nap-1a2b3c"},
+			wantPath: []string{
+				"@Cluster#nodepool#foo-cluster#nap-1a2b3c",
+			},
+		},
+		{
+			desc: "node created in a node pool not auto-provisioned",
+			common: &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
+				K8sOperation: &model.KubernetesObjectOperation{APIVersion: "core/v1", PluralKind: "nodes", Namespace: "cluster-scope", Name: "gk3-foo-cluster-pool-1-abcd", Verb: enum.RevisionVerbCreate},
+			},
+			autopilot: &googlecloudlogk8saudit_contract.GKEAutopilotAuditLogFieldSet{ClusterName: "foo-cluster", NodePoolName: "pool-1"},
+			wantPath:  []string{},
+		},
+		{
+			desc: "pod created with resource adjustment",
+			common: &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
+				K8sOperation: &model.KubernetesObjectOperation{APIVersion: "core/v1", PluralKind: "pods", Namespace: "default", Name: "nginx", Verb: enum.RevisionVerbCreate},
+			},
+			autopilot: &googlecloudlogk8saudit_contract.GKEAutopilotAuditLogFieldSet{ClusterName: "foo-cluster", ResourceAdjustment: `{"modified":true}`},
+			wantPath: []string{
+				"core/v1#pod#default#nginx",
+			},
+		},
+		{
+			desc: "pod updated with resource adjustment annotation",
+			common: &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
+				K8sOperation: &model.KubernetesObjectOperation{APIVersion: "core/v1", PluralKind: "pods", Namespace: "default", Name: "nginx", Verb: enum.RevisionVerbPatch},
+			},
+			autopilot: &googlecloudlogk8saudit_contract.GKEAutopilotAuditLogFieldSet{ClusterName: "foo-cluster", ResourceAdjustment: `{"modified":true}`},
+			wantPath:  []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l := log.NewLogWithFieldSetsForTest(tc.common, tc.autopilot)
+			cs := history.NewChangeSet(l)
+
+			setting := &gkeAutopilotLogToTimelineMapperTaskSetting{}
+			err := setting.addEventsForLog(l, cs)
+			if err != nil {
+				t.Fatalf("failed to add events for log: %v", err)
+			}
+			asserter := testchangeset.MatchResourcePathSet{
+				WantResourcePaths: tc.wantPath,
+			}
+
+			asserter.Assert(t, cs)
+		})
+	}
+}
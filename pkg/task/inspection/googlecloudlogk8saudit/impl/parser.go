@@ -16,6 +16,7 @@ package googlecloudlogk8saudit_impl
 
 import (
 	"context"
+	"slices"
 
 	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
 	coretask "github.com/kyasbal/khi/pkg/core/task"
@@ -34,8 +35,9 @@ var GCPK8sAuditLogCommonFieldSetReaderTask = inspectiontaskbase.NewFieldSetReadT
 	googlecloudlogk8saudit_contract.GCPK8sAuditLogListLogEntriesTaskID.Ref(),
 	[]log.FieldSetReader{
 		&googlecloudlogk8saudit_contract.GCPK8sAuditLogFieldSetReader{},
+		&googlecloudlogk8saudit_contract.GKEAutopilotAuditLogFieldSetReader{},
 	},
-	inspectioncore_contract.InspectionTypeLabel(googlecloudinspectiontypegroup_contract.GCPK8sClusterInspectionTypes...),
+	inspectioncore_contract.InspectionTypeLabel(slices.Concat(googlecloudinspectiontypegroup_contract.GCPK8sClusterInspectionTypes, googlecloudinspectiontypegroup_contract.K8sLogSinkInspectionTypes)...),
 )
 
 var GCPK8sAuditLogParserTailTask = inspectiontaskbase.NewInspectionTask(
@@ -46,10 +48,12 @@ var GCPK8sAuditLogParserTailTask = inspectiontaskbase.NewInspectionTask(
 		commonlogk8sauditv2_contract.NamespaceRequestLogToTimelineMapperTaskID.Ref(),
 		commonlogk8sauditv2_contract.ResourceRevisionLogToTimelineMapperTaskID.Ref(),
 		commonlogk8sauditv2_contract.ConditionLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.VeleroOperationLogToTimelineMapperTaskID.Ref(),
 		commonlogk8sauditv2_contract.ResourceOwnerReferenceTimelineMapperTaskID.Ref(),
 		commonlogk8sauditv2_contract.PodPhaseLogToTimelineMapperTaskID.Ref(),
 		commonlogk8sauditv2_contract.EndpointResourceLogToTimelineMapperTaskID.Ref(),
 		commonlogk8sauditv2_contract.ContainerLogToTimelineMapperTaskID.Ref(),
+		googlecloudlogk8saudit_contract.GKEAutopilotLogToTimelineMapperTaskID.Ref(),
 
 		commonlogk8sauditv2_contract.NodeNameDiscoveryTaskID.Ref(),
 		commonlogk8sauditv2_contract.ResourceUIDDiscoveryTaskID.Ref(),
@@ -60,5 +64,16 @@ var GCPK8sAuditLogParserTailTask = inspectiontaskbase.NewInspectionTask(
 	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (struct{}, error) {
 		return struct{}{}, nil
 	},
-	inspectioncore_contract.FeatureTaskLabel("Kubernetes Audit Log(v3)", `Gather kubernetes audit logs and visualize resource modifications.`, enum.LogTypeAudit, 1001, true, googlecloudinspectiontypegroup_contract.GCPK8sClusterInspectionTypes...), coretask.NewSubsequentTaskRefsTaskLabel(inspectioncore_contract.SerializerTaskID.Ref()),
+	inspectioncore_contract.FeatureTaskLabel("Kubernetes Audit Log(v3)", `Gather kubernetes audit logs and visualize resource modifications.`, enum.LogTypeAudit, 1001, true, slices.Concat(googlecloudinspectiontypegroup_contract.GCPK8sClusterInspectionTypes, googlecloudinspectiontypegroup_contract.K8sLogSinkInspectionTypes)...), coretask.NewSubsequentTaskRefsTaskLabel(inspectioncore_contract.SerializerTaskID.Ref()),
+)
+
+var GCPK8sAuditPermissionDeniedParserTailTask = inspectiontaskbase.NewInspectionTask(
+	googlecloudlogk8saudit_contract.GCPK8sAuditPermissionDeniedParserTailTaskID,
+	[]taskid.UntypedTaskReference{
+		commonlogk8sauditv2_contract.PermissionDeniedLogToTimelineMapperTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (struct{}, error) {
+		return struct{}{}, nil
+	},
+	inspectioncore_contract.FeatureTaskLabel("Kubernetes Permission Denials", `Gather kubernetes audit logs of mutating requests denied by the authorizer and show them on timelines grouped by the principal and the resource. Useful to find authorization regressions like broken RBAC rules after an upgrade.`, enum.LogTypeAudit, 1002, false, slices.Concat(googlecloudinspectiontypegroup_contract.GCPK8sClusterInspectionTypes, googlecloudinspectiontypegroup_contract.K8sLogSinkInspectionTypes)...), coretask.NewSubsequentTaskRefsTaskLabel(inspectioncore_contract.SerializerTaskID.Ref()),
 )
@@ -46,6 +46,7 @@ func (k *GCPK8sAuditLogListLogEntriesTaskSetting) DefaultResourceNames(ctx conte
 func (k *GCPK8sAuditLogListLogEntriesTaskSetting) Dependencies() []taskid.UntypedTaskReference {
 	return []taskid.UntypedTaskReference{
 		googlecloudk8scommon_contract.ClusterIdentityTaskID.Ref(),
+		googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref(),
 		googlecloudk8scommon_contract.InputKindFilterTaskID.Ref(),
 		googlecloudk8scommon_contract.InputNamespaceFilterTaskID.Ref(),
 	}
@@ -74,11 +75,15 @@ func (k *GCPK8sAuditLogListLogEntriesTaskSetting) Description() *googlecloudcomm
 
 // LogFilters implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
 func (k *GCPK8sAuditLogListLogEntriesTaskSetting) LogFilters(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) ([]string, error) {
-	cluster := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.ClusterIdentityTaskID.Ref())
+	clusters := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref())
 	kindFilter := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.InputKindFilterTaskID.Ref())
 	namespaceFilter := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.InputNamespaceFilterTaskID.Ref())
 
-	return []string{GenerateK8sAuditQuery(cluster, kindFilter, namespaceFilter)}, nil
+	filters := []string{}
+	for _, cluster := range clusters {
+		filters = append(filters, GenerateK8sAuditQuery(cluster, kindFilter, namespaceFilter))
+	}
+	return filters, nil
 }
 
 // TaskID implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
@@ -25,5 +25,7 @@ func Register(registry coreinspection.InspectionTaskRegistry) error {
 		GCPK8sAuditLogListLogEntriesTask,
 		GCPK8sAuditLogCommonFieldSetReaderTask,
 		GCPK8sAuditLogParserTailTask,
+		GCPK8sAuditPermissionDeniedParserTailTask,
+		GKEAutopilotLogToTimelineMapperTask,
 	)
 }
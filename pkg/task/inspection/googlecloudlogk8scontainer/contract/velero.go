@@ -0,0 +1,102 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogk8scontainer_contract
+
+import (
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/logutil"
+	"github.com/kyasbal/khi/pkg/model"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/history"
+	"github.com/kyasbal/khi/pkg/model/history/resourcepath"
+)
+
+// VeleroServerContainerName is the name of the container running the Velero server.
+const VeleroServerContainerName = "velero"
+
+// veleroLogParser parses the logs of the Velero server written in logfmt.
+var veleroLogParser = logutil.NewLogfmtTextParser()
+
+// veleroOperationFieldKinds maps the fields in Velero server logs holding `<namespace>/<name>` of the processed operation to the kinds of the operation.
+var veleroOperationFieldKinds = map[string]string{
+	"backup":  "backup",
+	"restore": "restore",
+}
+
+// AddVeleroLogEvents associates a log of the Velero server with the operation timeline of the Backup or the Restore it mentions.
+// Warnings and errors mentioning an item are also associated with the timeline of the item to show the per-resource failures of the operation.
+// Logs from other containers are ignored.
+func AddVeleroLogEvents(cs *history.ChangeSet, fieldSet *K8sContainerLogFieldSet) {
+	if fieldSet.ContainerName != VeleroServerContainerName {
+		return
+	}
+	parsed := veleroLogParser.TryParse(fieldSet.Message)
+	if parsed == nil {
+		return
+	}
+	foundOperation := false
+	for field, kind := range veleroOperationFieldKinds {
+		operation, err := parsed.StringField(field)
+		if err != nil {
+			continue
+		}
+		namespace, name, found := strings.Cut(operation, "/")
+		if !found {
+			continue
+		}
+		cs.AddEvent(resourcepath.VeleroOperation(kind, namespace, name))
+		foundOperation = true
+	}
+	if !foundOperation {
+		return
+	}
+
+	severity, err := parsed.Severity()
+	if err != nil || (severity != enum.SeverityWarning && severity != enum.SeverityError && severity != enum.SeverityFatal) {
+		return
+	}
+	itemPath, found := veleroItemPath(parsed)
+	if found {
+		cs.AddEvent(itemPath)
+	}
+}
+
+// veleroItemPath returns the path of the item mentioned with `resource`, `namespace` and `name` fields in a Velero server log.
+// Velero writes the group and the plural name of the resource without the version, thus `v1` is assumed.
+func veleroItemPath(parsed *logutil.ParseStructuredLogResult) (resourcepath.ResourcePath, bool) {
+	groupResource, err := parsed.StringField("resource")
+	if err != nil || groupResource == "" {
+		return resourcepath.ResourcePath{}, false
+	}
+	name, err := parsed.StringField("name")
+	if err != nil || name == "" {
+		return resourcepath.ResourcePath{}, false
+	}
+	namespace, err := parsed.StringField("namespace")
+	if err != nil || namespace == "" {
+		namespace = "cluster-scope"
+	}
+	pluralKind, group, _ := strings.Cut(groupResource, ".")
+	if group == "" {
+		group = "core"
+	}
+	return resourcepath.FromK8sOperation(model.KubernetesObjectOperation{
+		APIVersion: group + "/v1",
+		PluralKind: pluralKind,
+		Namespace:  namespace,
+		Name:       name,
+	}), true
+}
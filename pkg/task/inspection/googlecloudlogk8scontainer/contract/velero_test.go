@@ -0,0 +1,93 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogk8scontainer_contract
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/model/history"
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+func TestAddVeleroLogEvents(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		containerName string
+		message       string
+		wantPaths     []string
+	}{
+		{
+			desc:          "info log of a restore",
+			containerName: "velero",
+			message:       `time="2025-01-01T00:00:00Z" level=info msg="Restoring resource 'pods' into namespace 'default'" logSource="pkg/restore/restore.go:100" restore=velero/daily-restore`,
+			wantPaths:     []string{"velero.io/v1#restore#velero#daily-restore#restore-daily-restore"},
+		},
+		{
+			desc:          "warning of a restored item",
+			containerName: "velero",
+			message:       `time="2025-01-01T00:00:00Z" level=warning msg="could not restore, Deployment \"nginx\" already exists" logSource="pkg/restore/restore.go:100" name=nginx namespace=default resource=deployments.apps restore=velero/daily-restore`,
+			wantPaths: []string{
+				"velero.io/v1#restore#velero#daily-restore#restore-daily-restore",
+				"apps/v1#deployment#default#nginx",
+			},
+		},
+		{
+			desc:          "error of a cluster scoped item in a backup",
+			containerName: "velero",
+			message:       `time="2025-01-01T00:00:00Z" level=error msg="Error backing up item" backup=velero/daily error="timeout" logSource="pkg/backup/backup.go:100" name=pv-1 resource=persistentvolumes`,
+			wantPaths: []string{
+				"velero.io/v1#backup#velero#daily#backup-daily",
+				"core/v1#persistentvolume#cluster-scope#pv-1",
+			},
+		},
+		{
+			desc:          "info log of a backed up item",
+			containerName: "velero",
+			message:       `time="2025-01-01T00:00:00Z" level=info msg="Backing up item" backup=velero/daily logSource="pkg/backup/item_backupper.go:100" name=nginx namespace=default resource=pods`,
+			wantPaths:     []string{"velero.io/v1#backup#velero#daily#backup-daily"},
+		},
+		{
+			desc:          "log without operations",
+			containerName: "velero",
+			message:       `time="2025-01-01T00:00:00Z" level=error msg="Error getting backup store" logSource="pkg/controller/backup_sync_controller.go:100"`,
+			wantPaths:     []string{},
+		},
+		{
+			desc:          "log from other containers",
+			containerName: "nginx",
+			message:       `level=error backup=velero/daily resource=pods namespace=default name=nginx`,
+			wantPaths:     []string{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cs := history.NewChangeSet(log.NewLogWithFieldSetsForTest(&log.CommonFieldSet{}))
+			AddVeleroLogEvents(cs, &K8sContainerLogFieldSet{
+				Namespace:     "velero",
+				PodName:       "velero-0",
+				ContainerName: tc.containerName,
+				Message:       tc.message,
+			})
+			gotPaths := cs.GetAllResourcePaths()
+			slices.Sort(gotPaths)
+			slices.Sort(tc.wantPaths)
+			if diff := cmp.Diff(tc.wantPaths, gotPaths); diff != "" {
+				t.Errorf("resource paths mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -17,7 +17,6 @@ package googlecloudlogk8scontainer_impl
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
 	"github.com/kyasbal/khi/pkg/core/inspection/gcpqueryutil"
@@ -29,8 +28,6 @@ import (
 	googlecloudlogk8scontainer_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8scontainer/contract"
 )
 
-const priorityForContainerGroup = googlecloudcommon_contract.FormBasePriority + 20000
-
 const maxNamespaceFilterOptions = 500
 const maxPodNameFilterOptions = 500
 
@@ -39,12 +36,14 @@ var inputNamespacesAliasMap gcpqueryutil.SetFilterAliasToItemsMap = map[string][
 }
 
 // InputContainerQueryNamespaceFilterTask is a form task that allows users to specify which namespaces to query for container logs.
-var InputContainerQueryNamespaceFilterTask = formtask.NewSetFormTaskBuilder(googlecloudlogk8scontainer_contract.InputContainerQueryNamespacesTaskID, priorityForContainerGroup+1000, "Namespaces(Container logs)").
+var InputContainerQueryNamespaceFilterTask = formtask.NewSetFormTaskBuilder(googlecloudlogk8scontainer_contract.InputContainerQueryNamespacesTaskID, 0, "Namespaces(Container logs)").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionContainerLogFilter, Before: []string{googlecloudlogk8scontainer_contract.InputContainerQueryPodNamesTaskID.ReferenceIDString()}}).
 	WithDependencies([]taskid.UntypedTaskReference{googlecloudk8scommon_contract.AutocompleteNamespacesTaskID.Ref()}).
 	WithDefaultValueConstant([]string{"@managed"}, true).
 	WithAllowAddAll(false).
 	WithAllowRemoveAll(false).
 	WithAllowCustomValue(true).
+	WithDocURL(googlecloudcommon_contract.FormReferenceDocURL("namespacescontainer-logs")).
 	WithDescription(`Container logs tend to be a lot and take very long time to query.
 Specify the space splitted namespace lists to query container logs only in the specific namespaces.`).
 	WithOptionsFunc(func(ctx context.Context, value []string) ([]inspectionmetadata.SetParameterFormFieldOptionItem, error) {
@@ -75,16 +74,14 @@ Specify the space splitted namespace lists to query container logs only in the s
 		return "", inspectionmetadata.None, nil
 	}).
 	WithValidator(func(ctx context.Context, value []string) (string, error) {
-		setFilterStr := strings.Join(value, " ")
-		result, err := gcpqueryutil.ParseSetFilter(setFilterStr, inputNamespacesAliasMap, true, true, true)
+		result, err := gcpqueryutil.ParseSetFilterItems(value, inputNamespacesAliasMap, true, true, true)
 		if err != nil {
 			return "", err
 		}
 		return result.ValidationError, nil
 	}).
 	WithConverter(func(ctx context.Context, value []string) (*gcpqueryutil.SetFilterParseResult, error) {
-		setFilterStr := strings.Join(value, " ")
-		result, err := gcpqueryutil.ParseSetFilter(setFilterStr, inputNamespacesAliasMap, true, true, true)
+		result, err := gcpqueryutil.ParseSetFilterItems(value, inputNamespacesAliasMap, true, true, true)
 		if err != nil {
 			return nil, err
 		}
@@ -95,12 +92,14 @@ Specify the space splitted namespace lists to query container logs only in the s
 var inputPodNamesAliasMap gcpqueryutil.SetFilterAliasToItemsMap = map[string][]string{}
 
 // InputContainerQueryPodNamesFilterMask is a form task that allows users to specify which pod names to query for container logs.
-var InputContainerQueryPodNamesFilterMask = formtask.NewSetFormTaskBuilder(googlecloudlogk8scontainer_contract.InputContainerQueryPodNamesTaskID, priorityForContainerGroup+2000, "Pod names(Container logs)").
+var InputContainerQueryPodNamesFilterMask = formtask.NewSetFormTaskBuilder(googlecloudlogk8scontainer_contract.InputContainerQueryPodNamesTaskID, 0, "Pod names(Container logs)").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionContainerLogFilter}).
 	WithDependencies([]taskid.UntypedTaskReference{googlecloudk8scommon_contract.AutocompletePodNamesTaskID.Ref()}).
 	WithDefaultValueConstant([]string{"@any"}, true).
 	WithAllowAddAll(false).
 	WithAllowRemoveAll(false).
 	WithAllowCustomValue(true).
+	WithDocURL(googlecloudcommon_contract.FormReferenceDocURL("pod-namescontainer-logs")).
 	WithDescription(`Container logs tend to be a lot and take very long time to query.
 	Specify the space splitted pod names lists to query container logs only in the specific pods.
 	This parameter is evaluated as the partial match not the perfect match. You can use the prefix of the pod names.`).
@@ -129,16 +128,14 @@ var InputContainerQueryPodNamesFilterMask = formtask.NewSetFormTaskBuilder(googl
 		return "", inspectionmetadata.None, nil
 	}).
 	WithValidator(func(ctx context.Context, value []string) (string, error) {
-		setFilterStr := strings.Join(value, " ")
-		result, err := gcpqueryutil.ParseSetFilter(setFilterStr, inputPodNamesAliasMap, true, true, true)
+		result, err := gcpqueryutil.ParseSetFilterItems(value, inputPodNamesAliasMap, true, true, true)
 		if err != nil {
 			return "", err
 		}
 		return result.ValidationError, nil
 	}).
 	WithConverter(func(ctx context.Context, value []string) (*gcpqueryutil.SetFilterParseResult, error) {
-		setFilterStr := strings.Join(value, " ")
-		result, err := gcpqueryutil.ParseSetFilter(setFilterStr, inputPodNamesAliasMap, true, true, true)
+		result, err := gcpqueryutil.ParseSetFilterItems(value, inputPodNamesAliasMap, true, true, true)
 		if err != nil {
 			return nil, err
 		}
@@ -79,6 +79,7 @@ func (c *containerLogLogToTimelineMapperSetting) ProcessLogByGroup(ctx context.C
 
 	cs.AddEvent(containerFields.ResourcePath())
 	cs.SetLogSummary(containerFields.Message)
+	googlecloudlogk8scontainer_contract.AddVeleroLogEvents(cs, containerFields)
 	return struct{}{}, nil
 }
 
@@ -105,6 +105,7 @@ func (c *containerListLogEntriesTaskSetting) DefaultResourceNames(ctx context.Co
 func (c *containerListLogEntriesTaskSetting) Dependencies() []taskid.UntypedTaskReference {
 	return []taskid.UntypedTaskReference{
 		googlecloudlogk8scontainer_contract.ClusterIdentityTaskID.Ref(),
+		googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref(),
 		googlecloudlogk8scontainer_contract.InputContainerQueryNamespacesTaskID.Ref(),
 		googlecloudlogk8scontainer_contract.InputContainerQueryPodNamesTaskID.Ref(),
 	}
@@ -133,11 +134,15 @@ func (c *containerListLogEntriesTaskSetting) Description() *googlecloudcommon_co
 
 // LogFilters implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
 func (c *containerListLogEntriesTaskSetting) LogFilters(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) ([]string, error) {
-	cluster := coretask.GetTaskResult(ctx, googlecloudlogk8scontainer_contract.ClusterIdentityTaskID.Ref())
+	clusters := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref())
 	namespacesFilter := coretask.GetTaskResult(ctx, googlecloudlogk8scontainer_contract.InputContainerQueryNamespacesTaskID.Ref())
 	podNamesFilter := coretask.GetTaskResult(ctx, googlecloudlogk8scontainer_contract.InputContainerQueryPodNamesTaskID.Ref())
 
-	return []string{GenerateK8sContainerQuery(cluster, namespacesFilter, podNamesFilter)}, nil
+	filters := []string{}
+	for _, cluster := range clusters {
+		filters = append(filters, GenerateK8sContainerQuery(cluster, namespacesFilter, podNamesFilter))
+	}
+	return filters, nil
 }
 
 // TaskID implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
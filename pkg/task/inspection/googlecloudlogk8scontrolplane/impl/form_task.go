@@ -16,7 +16,6 @@ package googlecloudlogk8scontrolplane_impl
 
 import (
 	"context"
-	"strings"
 
 	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
 	"github.com/kyasbal/khi/pkg/core/inspection/gcpqueryutil"
@@ -25,16 +24,15 @@ import (
 	googlecloudlogk8scontrolplane_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8scontrolplane/contract"
 )
 
-const priorityForControlPlaneGroup = googlecloudcommon_contract.FormBasePriority + 30000
-
 var inputControlPlaneComponentNameAliasMap map[string][]string = map[string][]string{}
 
 // InputControlPlaneComponentNameFilterTask is a form task for filtering control plane component names.
 var InputControlPlaneComponentNameFilterTask = formtask.NewSetFormTaskBuilder(
 	googlecloudlogk8scontrolplane_contract.InputControlPlaneComponentNameFilterTaskID,
-	priorityForControlPlaneGroup+1000,
+	0,
 	"Control plane component names",
 ).
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionControlPlaneLogFilter}).
 	WithDefaultValueConstant([]string{"@any", "-apiserver"}, true).
 	WithAllowAddAll(false).
 	WithAllowRemoveAll(false).
@@ -48,18 +46,17 @@ var InputControlPlaneComponentNameFilterTask = formtask.NewSetFormTaskBuilder(
 			{ID: "hpa-controller", Description: "Matches logs from horizontal pod autoscaler"},
 		}, nil
 	}).
+	WithDocURL(googlecloudcommon_contract.FormReferenceDocURL("control-plane-component-names")).
 	WithDescription("Control plane component names to query(e.g. apiserver, controller-manager...etc)").
 	WithValidator(func(ctx context.Context, value []string) (string, error) {
-		strFilter := strings.Join(value, " ")
-		result, err := gcpqueryutil.ParseSetFilter(strFilter, inputControlPlaneComponentNameAliasMap, true, true, true)
+		result, err := gcpqueryutil.ParseSetFilterItems(value, inputControlPlaneComponentNameAliasMap, true, true, true)
 		if err != nil {
 			return "", err
 		}
 		return result.ValidationError, nil
 	}).
 	WithConverter(func(ctx context.Context, value []string) (*gcpqueryutil.SetFilterParseResult, error) {
-		strFilter := strings.Join(value, " ")
-		result, err := gcpqueryutil.ParseSetFilter(strFilter, inputControlPlaneComponentNameAliasMap, true, true, true)
+		result, err := gcpqueryutil.ParseSetFilterItems(value, inputControlPlaneComponentNameAliasMap, true, true, true)
 		if err != nil {
 			return nil, err
 		}
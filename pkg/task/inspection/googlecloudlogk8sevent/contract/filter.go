@@ -0,0 +1,30 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogk8sevent_contract
+
+import (
+	"github.com/kyasbal/khi/pkg/core/inspection/gcpqueryutil"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+)
+
+// MatchK8sEventLog returns true when the given k8s event log matches the namespace filter.
+// This is the client side equivalent of the Cloud Logging query for the logs not queried from Cloud Logging(e.g. logs exported by a log sink).
+func MatchK8sEventLog(l *log.Log, namespaceFilter *gcpqueryutil.SetFilterParseResult) bool {
+	namespace := l.ReadStringOrDefault("jsonPayload.involvedObject.namespace", "")
+	return googlecloudk8scommon_contract.MatchNamespaceFilter(namespace != "", func(filterNamespace string) bool {
+		return namespace == filterNamespace
+	}, namespaceFilter)
+}
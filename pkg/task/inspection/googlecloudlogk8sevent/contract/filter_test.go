@@ -0,0 +1,76 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogk8sevent_contract
+
+import (
+	"testing"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/gcpqueryutil"
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+func TestMatchK8sEventLog(t *testing.T) {
+	namespacedEvent, err := log.NewLogFromYAMLString(`jsonPayload:
+  involvedObject:
+    namespace: default`)
+	if err != nil {
+		t.Fatalf("failed to parse the test log: %v", err)
+	}
+	clusterScopedEvent, err := log.NewLogFromYAMLString(`jsonPayload:
+  involvedObject:
+    kind: Node`)
+	if err != nil {
+		t.Fatalf("failed to parse the test log: %v", err)
+	}
+	testCases := []struct {
+		name            string
+		log             *log.Log
+		namespaceFilter *gcpqueryutil.SetFilterParseResult
+		want            bool
+	}{
+		{
+			name:            "namespace in additives",
+			log:             namespacedEvent,
+			namespaceFilter: &gcpqueryutil.SetFilterParseResult{Additives: []string{"default"}},
+			want:            true,
+		},
+		{
+			name:            "namespace not in additives",
+			log:             namespacedEvent,
+			namespaceFilter: &gcpqueryutil.SetFilterParseResult{Additives: []string{"kube-system"}},
+			want:            false,
+		},
+		{
+			name:            "cluster scoped event with #cluster-scoped",
+			log:             clusterScopedEvent,
+			namespaceFilter: &gcpqueryutil.SetFilterParseResult{Additives: []string{"#cluster-scoped"}},
+			want:            true,
+		},
+		{
+			name:            "cluster scoped event with #namespaced",
+			log:             clusterScopedEvent,
+			namespaceFilter: &gcpqueryutil.SetFilterParseResult{Additives: []string{"#namespaced"}},
+			want:            false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := MatchK8sEventLog(tc.log, tc.namespaceFilter)
+			if got != tc.want {
+				t.Errorf("MatchK8sEventLog() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
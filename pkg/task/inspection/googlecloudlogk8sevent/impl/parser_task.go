@@ -17,6 +17,7 @@ package googlecloudlogk8sevent_impl
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
 	"github.com/kyasbal/khi/pkg/core/task/taskid"
@@ -50,7 +51,7 @@ var LogToTimelineMapperTask = inspectiontaskbase.NewLogToTimelineMapperTask[stru
 	enum.LogTypeEvent,
 	2000,
 	true,
-	googlecloudinspectiontypegroup_contract.GCPK8sClusterInspectionTypes...,
+	slices.Concat(googlecloudinspectiontypegroup_contract.GCPK8sClusterInspectionTypes, googlecloudinspectiontypegroup_contract.K8sLogSinkInspectionTypes)...,
 ))
 
 type KubernetesEventLogToTimelineMapperSetting struct {
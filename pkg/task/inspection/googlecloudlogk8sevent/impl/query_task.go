@@ -88,6 +88,7 @@ func (k *K8sEventListLogEntriesTaskSetting) DefaultResourceNames(ctx context.Con
 func (k *K8sEventListLogEntriesTaskSetting) Dependencies() []taskid.UntypedTaskReference {
 	return []taskid.UntypedTaskReference{
 		googlecloudlogk8sevent_contract.ClusterIdentityTaskID.Ref(),
+		googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref(),
 		googlecloudk8scommon_contract.InputNamespaceFilterTaskID.Ref(),
 	}
 }
@@ -112,9 +113,13 @@ func (k *K8sEventListLogEntriesTaskSetting) Description() *googlecloudcommon_con
 
 // LogFilters implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
 func (k *K8sEventListLogEntriesTaskSetting) LogFilters(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) ([]string, error) {
-	cluster := coretask.GetTaskResult(ctx, googlecloudlogk8sevent_contract.ClusterIdentityTaskID.Ref())
+	clusters := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref())
 	namespaceFilter := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.InputNamespaceFilterTaskID.Ref())
-	return []string{GenerateK8sEventQuery(cluster, namespaceFilter)}, nil
+	filters := []string{}
+	for _, cluster := range clusters {
+		filters = append(filters, GenerateK8sEventQuery(cluster, namespaceFilter))
+	}
+	return filters, nil
 }
 
 // TaskID implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
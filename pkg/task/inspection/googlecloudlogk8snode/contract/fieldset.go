@@ -40,7 +40,8 @@ type K8sNodeLogCommonFieldSet struct {
 
 func (k *K8sNodeLogCommonFieldSet) ParserType() K8sNodeParserType {
 	switch k.Component {
-	case "containerd":
+	// CRI-O logs are handled in the same container runtime pipeline as containerd.
+	case "containerd", "crio":
 		return Containerd
 	case "kubelet":
 		return Kubelet
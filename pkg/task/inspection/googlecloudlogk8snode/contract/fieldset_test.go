@@ -124,6 +124,13 @@ func TestK8sNodeLogCommonFieldSet_ParserType(t *testing.T) {
 			},
 			want: Containerd,
 		},
+		{
+			desc: "crio uses containerd parser type",
+			fieldSet: &K8sNodeLogCommonFieldSet{
+				Component: "crio",
+			},
+			want: Containerd,
+		},
 		{
 			desc: "kubelet parser type",
 			fieldSet: &K8sNodeLogCommonFieldSet{
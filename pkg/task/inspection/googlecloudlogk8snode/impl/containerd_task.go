@@ -31,6 +31,7 @@ import (
 	coretask "github.com/kyasbal/khi/pkg/core/task"
 	"github.com/kyasbal/khi/pkg/core/task/taskid"
 	"github.com/kyasbal/khi/pkg/model/history"
+	"github.com/kyasbal/khi/pkg/model/history/resourcepath"
 	"github.com/kyasbal/khi/pkg/model/log"
 	commonlogk8sauditv2_contract "github.com/kyasbal/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
 	googlecloudlogk8snode_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8snode/contract"
@@ -303,6 +304,24 @@ func (c *containerdNodeLogLogToTimelineMapperSetting) ProcessLogByGroup(ctx cont
 		summaryReplaceMap[result.Value.ContainerID] = toReadableContainerName(pod.PodNamespace, pod.PodName, result.Value.ContainerName)
 	}
 
+	if lifecycleEvent := parseContainerLifecycleEvent(nodeLogFieldSet.Message); lifecycleEvent != nil {
+		if !lifecycleEvent.HasIdentity() {
+			resolveContainerLifecycleEventIdentity(lifecycleEvent, containerIDPatternFinder, podSandboxIDFinder)
+		}
+		if lifecycleEvent.HasIdentity() {
+			commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+			containerPath := resourcepath.Container(lifecycleEvent.PodNamespace, lifecycleEvent.PodName, lifecycleEvent.ContainerName)
+			cs.AddEvent(containerPath)
+			cs.AddRevision(containerPath, &history.StagingResourceRevision{
+				Verb:       lifecycleEvent.Verb,
+				State:      lifecycleEvent.State,
+				Requestor:  nodeLogFieldSet.Component,
+				ChangeTime: commonFieldSet.Timestamp,
+			})
+			summaryReplaceMap[lifecycleEvent.ContainerID] = toReadableContainerName(lifecycleEvent.PodNamespace, lifecycleEvent.PodName, lifecycleEvent.ContainerName)
+		}
+	}
+
 	severity, err := nodeLogFieldSet.Message.Severity()
 	if err == nil {
 		cs.SetLogSeverity(severity)
@@ -324,4 +343,21 @@ func (c *containerdNodeLogLogToTimelineMapperSetting) ProcessLogByGroup(ctx cont
 	return struct{}{}, nil
 }
 
+// resolveContainerLifecycleEventIdentity fills the pod and container names of the event from its container ID with the discovered container and pod sandbox IDs.
+func resolveContainerLifecycleEventIdentity(event *containerLifecycleEvent, containerIDFinder patternfinder.PatternFinder[*commonlogk8sauditv2_contract.ContainerIdentity], podSandboxIDFinder patternfinder.PatternFinder[*googlecloudlogk8snode_contract.PodSandboxIDInfo]) {
+	foundContainer := patternfinder.FindAllWithStarterRunes(event.ContainerID, containerIDFinder, true)
+	if len(foundContainer) == 0 {
+		return
+	}
+	container := foundContainer[0].Value
+	foundPod := patternfinder.FindAllWithStarterRunes(container.PodSandboxID, podSandboxIDFinder, true)
+	if len(foundPod) == 0 {
+		return
+	}
+	pod := foundPod[0].Value
+	event.PodNamespace = pod.PodNamespace
+	event.PodName = pod.PodName
+	event.ContainerName = container.ContainerName
+}
+
 var _ inspectiontaskbase.LogToTimelineMapper[struct{}] = (*containerdNodeLogLogToTimelineMapperSetting)(nil)
@@ -408,11 +408,81 @@ func TestContainerdLogToTimelineMapperTask(t *testing.T) {
 				&testchangeset.HasEvent{
 					ResourcePath: "core/v1#pod#kube-system#podname#fluentbit-gke-init",
 				},
+				&testchangeset.HasRevision{
+					ResourcePath: "core/v1#pod#kube-system#podname#fluentbit-gke-init",
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbCreate,
+						State:      enum.RevisionStateContainerWaiting,
+						Requestor:  "containerd",
+						ChangeTime: testTime,
+					},
+				},
 				&testchangeset.HasLogSummary{
 					WantLogSummary: `CreateContainer within sandbox "【podname (Namespace: kube-system)】" for &ContainerMetadata{Name:fluentbit-gke-init,Attempt:0,} returns container id "【fluentbit-gke-init (Pod: podname, Namespace: kube-system)】"`,
 				},
 			},
 		},
+		{
+			desc:         "containerd task exit log with non zero exit status",
+			inputMessage: `time="2025-09-29T06:34:07.973711745Z" level=info msg="TaskExit event in podsandbox handler container_id:\"fc3e6702e38e918ec02567358c4c889b38fc628838645222d9a08b0b68c90256\" id:\"fc3e6702e38e918ec02567358c4c889b38fc628838645222d9a08b0b68c90256\" pid:1234 exit_status:137"`,
+			inputNodeLogFieldSet: &googlecloudlogk8snode_contract.K8sNodeLogCommonFieldSet{
+				Component: "containerd",
+				NodeName:  "node-1",
+			},
+			inputPodIDInfo: map[string]*googlecloudlogk8snode_contract.PodSandboxIDInfo{
+				"6123c6aacf0c78dc38ec4f0ff72edd3cf04eb82ca0e3e7dddd3950ea9753bdf1": {
+					PodName:      "podname",
+					PodNamespace: "kube-system",
+					PodSandboxID: "6123c6aacf0c78dc38ec4f0ff72edd3cf04eb82ca0e3e7dddd3950ea9753bdf1",
+				},
+			},
+			inputContainerIDInfo: map[string]*commonlogk8sauditv2_contract.ContainerIdentity{
+				"fc3e6702e38e918ec02567358c4c889b38fc628838645222d9a08b0b68c90256": {
+					PodSandboxID:  "6123c6aacf0c78dc38ec4f0ff72edd3cf04eb82ca0e3e7dddd3950ea9753bdf1",
+					ContainerName: "fluentbit-gke-init",
+					ContainerID:   "fc3e6702e38e918ec02567358c4c889b38fc628838645222d9a08b0b68c90256",
+				},
+			},
+			asserter: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasRevision{
+					ResourcePath: "core/v1#pod#kube-system#podname#fluentbit-gke-init",
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbContainerError,
+						State:      enum.RevisionStateContainerTerminatedWithError,
+						Requestor:  "containerd",
+						ChangeTime: testTime,
+					},
+				},
+			},
+		},
+		{
+			desc:         "crio created container log",
+			inputMessage: `time="2025-09-29 06:34:07.973711745Z" level=info msg="Created container 1d2c3b4a: default/nginx-abcde/nginx" id=5f6e7d8c name=/runtime.v1.RuntimeService/CreateContainer`,
+			inputNodeLogFieldSet: &googlecloudlogk8snode_contract.K8sNodeLogCommonFieldSet{
+				Component: "crio",
+				NodeName:  "node-1",
+			},
+			asserter: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasEvent{
+					ResourcePath: "core/v1#node#cluster-scope#node-1#crio",
+				},
+				&testchangeset.HasEvent{
+					ResourcePath: "core/v1#pod#default#nginx-abcde#nginx",
+				},
+				&testchangeset.HasRevision{
+					ResourcePath: "core/v1#pod#default#nginx-abcde#nginx",
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbCreate,
+						State:      enum.RevisionStateContainerWaiting,
+						Requestor:  "crio",
+						ChangeTime: testTime,
+					},
+				},
+				&testchangeset.HasLogSummary{
+					WantLogSummary: "Created container 【nginx (Pod: nginx-abcde, Namespace: default)】: default/nginx-abcde/nginx",
+				},
+			},
+		},
 	}
 	for _, tc := range testCases {
 		logfmtParser := logutil.NewLogfmtTextParser()
@@ -0,0 +1,169 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogk8snode_impl
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/logutil"
+	"github.com/kyasbal/khi/pkg/model/enum"
+)
+
+// containerLifecycleEvent is a container lifecycle transition read from a container runtime (containerd or CRI-O) log.
+type containerLifecycleEvent struct {
+	// ContainerID is the runtime container ID the event is about.
+	ContainerID string
+	// PodNamespace, PodName and ContainerName are only filled when the log itself contains the container identity (CRI-O).
+	// Otherwise the identity needs to be resolved from ContainerID.
+	PodNamespace  string
+	PodName       string
+	ContainerName string
+	Verb          enum.RevisionVerb
+	State         enum.RevisionState
+}
+
+// HasIdentity returns true when the event contains the namespace, pod name and container name by itself.
+func (e *containerLifecycleEvent) HasIdentity() bool {
+	return e.PodNamespace != "" && e.PodName != "" && e.ContainerName != ""
+}
+
+// parseContainerLifecycleEvent reads a container lifecycle event from a containerd or CRI-O log message.
+// It returns nil when the message is not a container lifecycle event.
+func parseContainerLifecycleEvent(message *logutil.ParseStructuredLogResult) *containerLifecycleEvent {
+	msg, err := message.MainMessage()
+	if err != nil {
+		return nil
+	}
+	if event := parseContainerdLifecycleEvent(msg); event != nil {
+		return event
+	}
+	return parseCRIOLifecycleEvent(message, msg)
+}
+
+// parseContainerdLifecycleEvent reads a container lifecycle event from a containerd log message.
+func parseContainerdLifecycleEvent(msg string) *containerLifecycleEvent {
+	switch {
+	case strings.HasPrefix(msg, "CreateContainer"):
+		// CreateContainer within sandbox "<sandbox id>" for &ContainerMetadata{Name:nginx,Attempt:0,} returns container id "<container id>"
+		splitted := strings.Split(msg, "returns container id")
+		if len(splitted) < 2 {
+			return nil
+		}
+		return newContainerLifecycleEvent(readNextQuotedString(splitted[1]), enum.RevisionVerbCreate, enum.RevisionStateContainerWaiting)
+	case strings.HasPrefix(msg, "StartContainer for") && strings.HasSuffix(msg, "returns successfully"):
+		// StartContainer for "<container id>" returns successfully
+		return newContainerLifecycleEvent(readNextQuotedString(msg), enum.RevisionVerbUpdate, enum.RevisionStateContainerStarted)
+	case strings.HasPrefix(msg, "StopContainer for"), strings.HasPrefix(msg, "Kill container"):
+		// StopContainer for "<container id>" with timeout 30 (s)
+		// Kill container "<container id>"
+		return newContainerLifecycleEvent(readNextQuotedString(msg), enum.RevisionVerbDelete, enum.RevisionStateDeleting)
+	case strings.HasPrefix(msg, "TaskOOM event"):
+		// TaskOOM event container_id:"<container id>"
+		// TaskOOM event &TaskOOM{ContainerID:<container id>,XXX_unrecognized:[],}
+		return newContainerLifecycleEvent(readContainerdEventField(msg, "container_id", "ContainerID"), enum.RevisionVerbContainerError, enum.RevisionStateContainerTerminatedWithError)
+	case strings.HasPrefix(msg, "TaskExit event"):
+		// TaskExit event in podsandbox handler container_id:"<container id>" id:"<container id>" pid:1234 exit_status:137 exited_at:{...}
+		// TaskExit event &TaskExit{ContainerID:<container id>,ID:<container id>,Pid:1234,ExitStatus:0,ExitedAt:...,XXX_unrecognized:[],}
+		exitStatus, err := strconv.Atoi(readContainerdEventField(msg, "exit_status", "ExitStatus"))
+		if err != nil {
+			// exit_status is omitted when it is 0 in the protobuf text format.
+			exitStatus = 0
+		}
+		if exitStatus == 0 {
+			return newContainerLifecycleEvent(readContainerdEventField(msg, "container_id", "ContainerID"), enum.RevisionVerbContainerSuccess, enum.RevisionStateContainerTerminatedWithSuccess)
+		}
+		return newContainerLifecycleEvent(readContainerdEventField(msg, "container_id", "ContainerID"), enum.RevisionVerbContainerError, enum.RevisionStateContainerTerminatedWithError)
+	}
+	return nil
+}
+
+// parseCRIOLifecycleEvent reads a container lifecycle event from a CRI-O log message.
+func parseCRIOLifecycleEvent(message *logutil.ParseStructuredLogResult, msg string) *containerLifecycleEvent {
+	switch {
+	case strings.HasPrefix(msg, "Created container "):
+		// msg="Created container <container id>: <namespace>/<pod>/<container>"
+		return newCRIOLifecycleEventFromMessage(strings.TrimPrefix(msg, "Created container "), enum.RevisionVerbCreate, enum.RevisionStateContainerWaiting)
+	case msg == "Started container":
+		// msg="Started container" PodSandboxID=<sandbox id> containerID=<container id> description=<namespace>/<pod>/<container>
+		containerID, err := message.StringField("containerID")
+		if err != nil {
+			return nil
+		}
+		event := newContainerLifecycleEvent(containerID, enum.RevisionVerbUpdate, enum.RevisionStateContainerStarted)
+		if description, err := message.StringField("description"); err == nil {
+			event.setIdentityFromCRIODescription(description)
+		}
+		return event
+	case strings.HasPrefix(msg, "Stopping container: "):
+		// msg="Stopping container: <container id> (timeout: 30s)"
+		containerID, _, _ := strings.Cut(strings.TrimPrefix(msg, "Stopping container: "), " ")
+		return newContainerLifecycleEvent(containerID, enum.RevisionVerbDelete, enum.RevisionStateDeleting)
+	case strings.HasPrefix(msg, "Stopped container "):
+		// msg="Stopped container <container id>: <namespace>/<pod>/<container>"
+		return newCRIOLifecycleEventFromMessage(strings.TrimPrefix(msg, "Stopped container "), enum.RevisionVerbContainerSuccess, enum.RevisionStateContainerTerminatedWithSuccess)
+	}
+	return nil
+}
+
+// newCRIOLifecycleEventFromMessage builds an event from the `<container id>: <namespace>/<pod>/<container>` part of a CRI-O message.
+func newCRIOLifecycleEventFromMessage(idAndDescription string, verb enum.RevisionVerb, state enum.RevisionState) *containerLifecycleEvent {
+	containerID, description, _ := strings.Cut(idAndDescription, ":")
+	event := newContainerLifecycleEvent(containerID, verb, state)
+	if event != nil {
+		event.setIdentityFromCRIODescription(description)
+	}
+	return event
+}
+
+func newContainerLifecycleEvent(containerID string, verb enum.RevisionVerb, state enum.RevisionState) *containerLifecycleEvent {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return nil
+	}
+	return &containerLifecycleEvent{
+		ContainerID: containerID,
+		Verb:        verb,
+		State:       state,
+	}
+}
+
+// setIdentityFromCRIODescription reads the container identity from the `<namespace>/<pod>/<container>` description used in CRI-O logs.
+func (e *containerLifecycleEvent) setIdentityFromCRIODescription(description string) {
+	splitted := strings.Split(strings.TrimSpace(description), "/")
+	if len(splitted) != 3 {
+		return
+	}
+	e.PodNamespace = splitted[0]
+	e.PodName = splitted[1]
+	e.ContainerName = splitted[2]
+}
+
+// readContainerdEventField reads a field value from a containerd event either printed in protobuf text format (`snake_case:"value"`)
+// or as a Go struct (`CamelCase:value,`).
+func readContainerdEventField(msg string, textFieldName string, structFieldName string) string {
+	if _, after, found := strings.Cut(msg, textFieldName+":"); found {
+		if strings.HasPrefix(after, "\"") {
+			return readNextQuotedString(after)
+		}
+		value, _, _ := strings.Cut(after, " ")
+		return value
+	}
+	if _, after, found := strings.Cut(msg, structFieldName+":"); found {
+		value, _, _ := strings.Cut(after, ",")
+		return value
+	}
+	return ""
+}
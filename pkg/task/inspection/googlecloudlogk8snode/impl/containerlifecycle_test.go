@@ -0,0 +1,169 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogk8snode_impl
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/core/inspection/logutil"
+	"github.com/kyasbal/khi/pkg/model/enum"
+)
+
+func TestParseContainerLifecycleEvent(t *testing.T) {
+	testCases := []struct {
+		desc string
+		log  string
+		want *containerLifecycleEvent
+	}{
+		{
+			desc: "containerd create container",
+			log:  `time="2025-09-29T06:34:07.973711745Z" level=info msg="CreateContainer within sandbox \"sandbox123\" for &ContainerMetadata{Name:nginx,Attempt:0,} returns container id \"container123\""`,
+			want: &containerLifecycleEvent{
+				ContainerID: "container123",
+				Verb:        enum.RevisionVerbCreate,
+				State:       enum.RevisionStateContainerWaiting,
+			},
+		},
+		{
+			desc: "containerd start container",
+			log:  `time="2025-09-29T06:34:07.973711745Z" level=info msg="StartContainer for \"container123\" returns successfully"`,
+			want: &containerLifecycleEvent{
+				ContainerID: "container123",
+				Verb:        enum.RevisionVerbUpdate,
+				State:       enum.RevisionStateContainerStarted,
+			},
+		},
+		{
+			desc: "containerd start container request is ignored",
+			log:  `time="2025-09-29T06:34:07.973711745Z" level=info msg="StartContainer for \"container123\""`,
+			want: nil,
+		},
+		{
+			desc: "containerd stop container",
+			log:  `time="2025-09-29T06:34:07.973711745Z" level=info msg="StopContainer for \"container123\" with timeout 30 (s)"`,
+			want: &containerLifecycleEvent{
+				ContainerID: "container123",
+				Verb:        enum.RevisionVerbDelete,
+				State:       enum.RevisionStateDeleting,
+			},
+		},
+		{
+			desc: "containerd kill container",
+			log:  `time="2025-09-29T06:34:07.973711745Z" level=info msg="Kill container \"container123\""`,
+			want: &containerLifecycleEvent{
+				ContainerID: "container123",
+				Verb:        enum.RevisionVerbDelete,
+				State:       enum.RevisionStateDeleting,
+			},
+		},
+		{
+			desc: "containerd OOM event in protobuf text format",
+			log:  `time="2025-09-29T06:34:07.973711745Z" level=info msg="TaskOOM event container_id:\"container123\""`,
+			want: &containerLifecycleEvent{
+				ContainerID: "container123",
+				Verb:        enum.RevisionVerbContainerError,
+				State:       enum.RevisionStateContainerTerminatedWithError,
+			},
+		},
+		{
+			desc: "containerd OOM event in go struct format",
+			log:  `time="2025-09-29T06:34:07.973711745Z" level=info msg="TaskOOM event &TaskOOM{ContainerID:container123,XXX_unrecognized:[],}"`,
+			want: &containerLifecycleEvent{
+				ContainerID: "container123",
+				Verb:        enum.RevisionVerbContainerError,
+				State:       enum.RevisionStateContainerTerminatedWithError,
+			},
+		},
+		{
+			desc: "containerd exit event without exit status",
+			log:  `time="2025-09-29T06:34:07.973711745Z" level=info msg="TaskExit event in podsandbox handler container_id:\"container123\" id:\"container123\" pid:1234 exited_at:{seconds:1759127647}"`,
+			want: &containerLifecycleEvent{
+				ContainerID: "container123",
+				Verb:        enum.RevisionVerbContainerSuccess,
+				State:       enum.RevisionStateContainerTerminatedWithSuccess,
+			},
+		},
+		{
+			desc: "containerd exit event with non zero exit status in go struct format",
+			log:  `time="2025-09-29T06:34:07.973711745Z" level=info msg="TaskExit event &TaskExit{ContainerID:container123,ID:container123,Pid:1234,ExitStatus:1,XXX_unrecognized:[],}"`,
+			want: &containerLifecycleEvent{
+				ContainerID: "container123",
+				Verb:        enum.RevisionVerbContainerError,
+				State:       enum.RevisionStateContainerTerminatedWithError,
+			},
+		},
+		{
+			desc: "crio created container",
+			log:  `time="2025-09-29 06:34:07.973711745Z" level=info msg="Created container container123: default/nginx-abcde/nginx" id=request123 name=/runtime.v1.RuntimeService/CreateContainer`,
+			want: &containerLifecycleEvent{
+				ContainerID:   "container123",
+				PodNamespace:  "default",
+				PodName:       "nginx-abcde",
+				ContainerName: "nginx",
+				Verb:          enum.RevisionVerbCreate,
+				State:         enum.RevisionStateContainerWaiting,
+			},
+		},
+		{
+			desc: "crio started container",
+			log:  `time="2025-09-29 06:34:07.973711745Z" level=info msg="Started container" PodSandboxID=sandbox123 containerID=container123 description=default/nginx-abcde/nginx id=request123 name=/runtime.v1.RuntimeService/StartContainer`,
+			want: &containerLifecycleEvent{
+				ContainerID:   "container123",
+				PodNamespace:  "default",
+				PodName:       "nginx-abcde",
+				ContainerName: "nginx",
+				Verb:          enum.RevisionVerbUpdate,
+				State:         enum.RevisionStateContainerStarted,
+			},
+		},
+		{
+			desc: "crio stopping container",
+			log:  `time="2025-09-29 06:34:07.973711745Z" level=info msg="Stopping container: container123 (timeout: 30s)" id=request123 name=/runtime.v1.RuntimeService/StopContainer`,
+			want: &containerLifecycleEvent{
+				ContainerID: "container123",
+				Verb:        enum.RevisionVerbDelete,
+				State:       enum.RevisionStateDeleting,
+			},
+		},
+		{
+			desc: "crio stopped container",
+			log:  `time="2025-09-29 06:34:07.973711745Z" level=info msg="Stopped container container123: default/nginx-abcde/nginx" id=request123 name=/runtime.v1.RuntimeService/StopContainer`,
+			want: &containerLifecycleEvent{
+				ContainerID:   "container123",
+				PodNamespace:  "default",
+				PodName:       "nginx-abcde",
+				ContainerName: "nginx",
+				Verb:          enum.RevisionVerbContainerSuccess,
+				State:         enum.RevisionStateContainerTerminatedWithSuccess,
+			},
+		},
+		{
+			desc: "unrelated log",
+			log:  `time="2025-09-29T06:34:07.973711745Z" level=info msg="starting containerd"`,
+			want: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			message := logutil.NewLogfmtTextParser().TryParse(tc.log)
+			got := parseContainerLifecycleEvent(message)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("parseContainerLifecycleEvent() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
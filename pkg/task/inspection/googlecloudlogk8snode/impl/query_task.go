@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/kyasbal/khi/pkg/common/khictx"
 	"github.com/kyasbal/khi/pkg/core/inspection/gcpqueryutil"
 	coretask "github.com/kyasbal/khi/pkg/core/task"
 	"github.com/kyasbal/khi/pkg/core/task/taskid"
@@ -31,14 +32,16 @@ import (
 )
 
 // GenerateK8sNodeLogQuery generates a query for GKE node logs.
-func GenerateK8sNodeLogQuery(cluster googlecloudk8scommon_contract.GoogleCloudClusterIdentity, nodeNameSubstrings []string) string {
+// nodePoolNodeNameSubstrings restricts the logs to the nodes in the node pools in addition to the node name filter.
+func GenerateK8sNodeLogQuery(cluster googlecloudk8scommon_contract.GoogleCloudClusterIdentity, nodeNameSubstrings []string, nodePoolNodeNameSubstrings []string) string {
 	return fmt.Sprintf(`resource.type="k8s_node"
 resource.labels.project_id="%s"
 resource.labels.location="%s"
 resource.labels.cluster_name="%s"
 -log_id("events") -- ignore node related events because it's captured in k8s event log parsers
 %s
-`, cluster.ProjectID, cluster.Location, cluster.NameWithClusterTypePrefix(), generateNodeNameSubstringLogFilter(nodeNameSubstrings))
+%s
+`, cluster.ProjectID, cluster.Location, cluster.NameWithClusterTypePrefix(), generateNodeNameSubstringLogFilter(nodeNameSubstrings), generateNodePoolLogFilter(nodePoolNodeNameSubstrings))
 }
 
 func generateNodeNameSubstringLogFilter(nodeNameSubstrings []string) string {
@@ -49,6 +52,13 @@ func generateNodeNameSubstringLogFilter(nodeNameSubstrings []string) string {
 	}
 }
 
+func generateNodePoolLogFilter(nodePoolNodeNameSubstrings []string) string {
+	if len(nodePoolNodeNameSubstrings) == 0 {
+		return "-- No node pool filters are specified."
+	}
+	return fmt.Sprintf("resource.labels.node_name:(%s)", strings.Join(gcpqueryutil.WrapDoubleQuoteForStringArray(nodePoolNodeNameSubstrings), " OR "))
+}
+
 type k8snodeListLogEntriesTaskSetting struct{}
 
 // DefaultResourceNames implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
@@ -61,7 +71,9 @@ func (c *k8snodeListLogEntriesTaskSetting) DefaultResourceNames(ctx context.Cont
 func (c *k8snodeListLogEntriesTaskSetting) Dependencies() []taskid.UntypedTaskReference {
 	return []taskid.UntypedTaskReference{
 		googlecloudlogk8snode_contract.ClusterIdentityTaskID.Ref(),
+		googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref(),
 		googlecloudk8scommon_contract.InputNodeNameFilterTaskID.Ref(),
+		googlecloudk8scommon_contract.NodePoolNodeNameSubstringsTaskID.Ref(),
 	}
 }
 
@@ -74,15 +86,20 @@ func (c *k8snodeListLogEntriesTaskSetting) Description() *googlecloudcommon_cont
 			ProjectID:   "gcp-project-id",
 			Location:    "gcp-location",
 			ClusterName: "gcp-cluster-name",
-		}, []string{"gke-test-cluster-node-1", "gke-test-cluster-node-2"}),
+		}, []string{"gke-test-cluster-node-1", "gke-test-cluster-node-2"}, []string{}),
 	}
 }
 
 // LogFilters implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
 func (c *k8snodeListLogEntriesTaskSetting) LogFilters(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) ([]string, error) {
-	cluster := coretask.GetTaskResult(ctx, googlecloudlogk8snode_contract.ClusterIdentityTaskID.Ref())
+	clusters := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref())
 	nodeNameSubstrings := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.InputNodeNameFilterTaskID.Ref())
-	return []string{GenerateK8sNodeLogQuery(cluster, nodeNameSubstrings)}, nil
+	nodePoolNodeNameSubstrings := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.NodePoolNodeNameSubstringsTaskID.Ref())
+	filters := []string{}
+	for _, cluster := range clusters {
+		filters = append(filters, GenerateK8sNodeLogQuery(cluster, nodeNameSubstrings, nodePoolNodeNameSubstrings))
+	}
+	return filters, nil
 }
 
 // TaskID implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
@@ -97,4 +114,15 @@ func (k *k8snodeListLogEntriesTaskSetting) TimePartitionCount(ctx context.Contex
 
 var _ googlecloudcommon_contract.ListLogEntriesTaskSetting = (*k8snodeListLogEntriesTaskSetting)(nil)
 
-var ListLogEntriesTask = googlecloudcommon_contract.NewListLogEntriesTask(&k8snodeListLogEntriesTaskSetting{})
+// skipWhenNoLogFilterGenerated is a coretask.SkipPredicate skipping the node log query when no log filter is generated from the cluster and node filters.
+// It is decided with the generated filters not to skip any query the task would send.
+func skipWhenNoLogFilterGenerated(ctx context.Context) (bool, error) {
+	taskMode, _ := khictx.GetValue(ctx, inspectioncore_contract.InspectionTaskMode)
+	filters, err := (&k8snodeListLogEntriesTaskSetting{}).LogFilters(ctx, taskMode)
+	if err != nil {
+		return false, err
+	}
+	return len(filters) == 0, nil
+}
+
+var ListLogEntriesTask = googlecloudcommon_contract.NewListLogEntriesTask(&k8snodeListLogEntriesTaskSetting{}, coretask.WithSkipPredicate(skipWhenNoLogFilterGenerated))
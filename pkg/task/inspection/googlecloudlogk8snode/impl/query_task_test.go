@@ -15,18 +15,23 @@
 package googlecloudlogk8snode_impl
 
 import (
+	"context"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
 	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
 	gcp_test "github.com/kyasbal/khi/pkg/testutil/gcp"
 )
 
 func TestGenerateK8sNodeQueryIsValid(t *testing.T) {
 	testCases := []struct {
-		name               string
-		cluster            googlecloudk8scommon_contract.GoogleCloudClusterIdentity
-		nodeNameSubstrings []string
+		name                       string
+		cluster                    googlecloudk8scommon_contract.GoogleCloudClusterIdentity
+		nodeNameSubstrings         []string
+		nodePoolNodeNameSubstrings []string
 	}{
 		{
 			name: "Valid query with empty node name substring",
@@ -55,10 +60,20 @@ func TestGenerateK8sNodeQueryIsValid(t *testing.T) {
 			},
 			nodeNameSubstrings: []string{"node-1", "node-2", "node-3"},
 		},
+		{
+			name: "Valid query with node pool filter",
+			cluster: googlecloudk8scommon_contract.GoogleCloudClusterIdentity{
+				ProjectID:   "test-project",
+				Location:    "test-location",
+				ClusterName: "test-cluster",
+			},
+			nodeNameSubstrings:         []string{"node-1"},
+			nodePoolNodeNameSubstrings: []string{"gke-test-cluster-pool-1-a1b2c3d4-", "-pool-2-"},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			query := GenerateK8sNodeLogQuery(tc.cluster, tc.nodeNameSubstrings)
+			query := GenerateK8sNodeLogQuery(tc.cluster, tc.nodeNameSubstrings, tc.nodePoolNodeNameSubstrings)
 			err := gcp_test.IsValidLogQuery(t, query)
 			if err != nil {
 				t.Errorf("%s", err.Error())
@@ -98,3 +113,79 @@ func TestGenerateNodeNameSubstringLogFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateNodePoolLogFilter(t *testing.T) {
+	tests := []struct {
+		name                       string
+		nodePoolNodeNameSubstrings []string
+		want                       string
+	}{
+		{
+			name:                       "empty",
+			nodePoolNodeNameSubstrings: []string{},
+			want:                       "-- No node pool filters are specified.",
+		},
+		{
+			name:                       "multiple",
+			nodePoolNodeNameSubstrings: []string{"gke-cluster-pool-1-a1b2c3d4-", "-pool-2-"},
+			want:                       "resource.labels.node_name:(\"gke-cluster-pool-1-a1b2c3d4-\" OR \"-pool-2-\")",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := generateNodePoolLogFilter(tt.nodePoolNodeNameSubstrings)
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("generateNodePoolLogFilter() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestListLogEntriesTaskSkipPredicate(t *testing.T) {
+	testCases := []struct {
+		name               string
+		clusters           []googlecloudk8scommon_contract.GoogleCloudClusterIdentity
+		nodeNameSubstrings []string
+		wantSkip           bool
+	}{
+		{
+			name:     "cluster name filter resolved to no cluster",
+			clusters: []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{},
+			wantSkip: true,
+		},
+		{
+			name: "clusters are selected",
+			clusters: []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{
+				{ProjectID: "test-project", Location: "test-location", ClusterName: "test-cluster"},
+			},
+			wantSkip: false,
+		},
+		{
+			name: "clusters are selected with node name filters",
+			clusters: []googlecloudk8scommon_contract.GoogleCloudClusterIdentity{
+				{ProjectID: "test-project", Location: "test-location", ClusterName: "test-cluster"},
+			},
+			nodeNameSubstrings: []string{"node-1"},
+			wantSkip:           false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			predicate := typedmap.GetOrDefault[coretask.SkipPredicate](ListLogEntriesTask.Labels(), coretask.LabelKeyTaskSkipPredicate, nil)
+			if predicate == nil {
+				t.Fatalf("ListLogEntriesTask must have a skip predicate")
+			}
+			ctx := tasktest.WithTaskResult(context.Background(), googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref(), tc.clusters)
+			ctx = tasktest.WithTaskResult(ctx, googlecloudk8scommon_contract.InputNodeNameFilterTaskID.Ref(), tc.nodeNameSubstrings)
+			ctx = tasktest.WithTaskResult(ctx, googlecloudk8scommon_contract.NodePoolNodeNameSubstringsTaskID.Ref(), []string{})
+
+			gotSkip, err := predicate(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if gotSkip != tc.wantSkip {
+				t.Errorf("skip = %v, want %v", gotSkip, tc.wantSkip)
+			}
+		})
+	}
+}
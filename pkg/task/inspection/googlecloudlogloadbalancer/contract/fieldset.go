@@ -0,0 +1,101 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogloadbalancer_contract
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/common/khierrors"
+	"github.com/kyasbal/khi/pkg/common/structured"
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+type LoadBalancerLogType int
+
+const (
+	// LoadBalancerLogTypeRequest is the type of request logs from external or internal Application Load Balancers.
+	LoadBalancerLogTypeRequest LoadBalancerLogType = iota
+	// LoadBalancerLogTypeHealthCheck is the type of health check logs recording health state transitions of NEG endpoints.
+	LoadBalancerLogTypeHealthCheck
+)
+
+const (
+	HealthStateHealthy   = "HEALTHY"
+	HealthStateUnhealthy = "UNHEALTHY"
+)
+
+// LoadBalancerFieldSet is the field set for load balancer request logs and health check logs.
+type LoadBalancerFieldSet struct {
+	Type LoadBalancerLogType
+
+	// BackendServiceName is the name of the backend service handling the request. GKE Ingress uses the NEG name as the backend service name.
+	BackendServiceName string
+	// StatusDetails explains why the load balancer returned the status. e.g `failed_to_connect_to_backend`
+	StatusDetails string
+
+	// TargetIP and TargetPort are the endpoint probed by the health check.
+	TargetIP   string
+	TargetPort int
+	// HealthState is the health state after the transition. HEALTHY or UNHEALTHY.
+	HealthState         string
+	PreviousHealthState string
+	// DetailedHealthState is the reason of the health state. e.g `TIMEOUT`
+	DetailedHealthState string
+	ProbeResultText     string
+}
+
+// Kind implements log.FieldSet.
+func (l *LoadBalancerFieldSet) Kind() string {
+	return "load_balancer"
+}
+
+var _ log.FieldSet = (*LoadBalancerFieldSet)(nil)
+
+type LoadBalancerFieldSetReader struct{}
+
+// FieldSetKind implements log.FieldSetReader.
+func (l *LoadBalancerFieldSetReader) FieldSetKind() string {
+	return (&LoadBalancerFieldSet{}).Kind()
+}
+
+// Read implements log.FieldSetReader.
+func (l *LoadBalancerFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	var result LoadBalancerFieldSet
+	logName := reader.ReadStringOrDefault("logName", "")
+	resourceType := reader.ReadStringOrDefault("resource.type", "")
+	switch {
+	case resourceType == "http_load_balancer" || resourceType == "internal_http_lb_rule":
+		result.Type = LoadBalancerLogTypeRequest
+		result.BackendServiceName = reader.ReadStringOrDefault("resource.labels.backend_service_name", "")
+		result.StatusDetails = reader.ReadStringOrDefault("jsonPayload.statusDetails", "")
+	case strings.HasSuffix(logName, "healthchecks"):
+		result.Type = LoadBalancerLogTypeHealthCheck
+		result.TargetIP = reader.ReadStringOrDefault("jsonPayload.healthCheckProbeResult.targetIp", "")
+		if result.TargetIP == "" {
+			result.TargetIP = reader.ReadStringOrDefault("jsonPayload.healthCheckProbeResult.ipAddress", "")
+		}
+		result.TargetPort = reader.ReadIntOrDefault("jsonPayload.healthCheckProbeResult.targetPort", 0)
+		result.HealthState = reader.ReadStringOrDefault("jsonPayload.healthCheckProbeResult.healthState", "")
+		result.PreviousHealthState = reader.ReadStringOrDefault("jsonPayload.healthCheckProbeResult.previousHealthState", "")
+		result.DetailedHealthState = reader.ReadStringOrDefault("jsonPayload.healthCheckProbeResult.detailedHealthState", "")
+		result.ProbeResultText = reader.ReadStringOrDefault("jsonPayload.healthCheckProbeResult.probeResultText", "")
+	default:
+		return nil, fmt.Errorf("a log with unknown resource type %q and logName %q was given to LoadBalancerFieldSetReader:%w", resourceType, logName, khierrors.ErrInvalidInput)
+	}
+	return &result, nil
+}
+
+var _ log.FieldSetReader = (*LoadBalancerFieldSetReader)(nil)
@@ -0,0 +1,130 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogloadbalancer_contract
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/common/khierrors"
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+func TestLoadBalancerFieldSetReader(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		input string
+		want  *LoadBalancerFieldSet
+	}{
+		{
+			desc: "request log",
+			input: `
+logName: "projects/test-project/logs/requests"
+resource:
+  type: http_load_balancer
+  labels:
+    backend_service_name: k8s1-12345678-default-nginx-80-abcdef01
+jsonPayload:
+  statusDetails: failed_to_connect_to_backend
+httpRequest:
+  status: 502
+`,
+			want: &LoadBalancerFieldSet{
+				Type:               LoadBalancerLogTypeRequest,
+				BackendServiceName: "k8s1-12345678-default-nginx-80-abcdef01",
+				StatusDetails:      "failed_to_connect_to_backend",
+			},
+		},
+		{
+			desc: "health check log",
+			input: `
+logName: "projects/test-project/logs/compute.googleapis.com%2Fhealthchecks"
+resource:
+  type: gce_network_endpoint_group
+jsonPayload:
+  healthCheckProbeResult:
+    ipAddress: 10.0.0.1
+    targetIp: 10.0.0.1
+    targetPort: 8080
+    previousHealthState: HEALTHY
+    healthState: UNHEALTHY
+    detailedHealthState: TIMEOUT
+    probeResultText: "HTTP response: , Error: Timeout waiting for connect"
+`,
+			want: &LoadBalancerFieldSet{
+				Type:                LoadBalancerLogTypeHealthCheck,
+				TargetIP:            "10.0.0.1",
+				TargetPort:          8080,
+				PreviousHealthState: "HEALTHY",
+				HealthState:         "UNHEALTHY",
+				DetailedHealthState: "TIMEOUT",
+				ProbeResultText:     "HTTP response: , Error: Timeout waiting for connect",
+			},
+		},
+		{
+			desc: "health check log without targetIp",
+			input: `
+logName: "projects/test-project/logs/compute.googleapis.com%2Fhealthchecks"
+resource:
+  type: gce_network_endpoint_group
+jsonPayload:
+  healthCheckProbeResult:
+    ipAddress: 10.0.0.1
+    previousHealthState: UNHEALTHY
+    healthState: HEALTHY
+    detailedHealthState: HEALTHY
+`,
+			want: &LoadBalancerFieldSet{
+				Type:                LoadBalancerLogTypeHealthCheck,
+				TargetIP:            "10.0.0.1",
+				PreviousHealthState: "UNHEALTHY",
+				HealthState:         "HEALTHY",
+				DetailedHealthState: "HEALTHY",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l, err := log.NewLogFromYAMLString(tc.input)
+			if err != nil {
+				t.Fatalf("failed to parse YAML test input to log: %v", err)
+			}
+			err = l.SetFieldSetReader(&LoadBalancerFieldSetReader{})
+			if err != nil {
+				t.Fatalf("failed to run LoadBalancerFieldSetReader.Read(): %v", err)
+			}
+			got := log.MustGetFieldSet(l, &LoadBalancerFieldSet{})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("LoadBalancerFieldSet mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoadBalancerFieldSetReader_UnknownLog(t *testing.T) {
+	l, err := log.NewLogFromYAMLString(`
+logName: "projects/test-project/logs/cloudaudit.googleapis.com%2Factivity"
+resource:
+  type: gce_network
+`)
+	if err != nil {
+		t.Fatalf("failed to parse YAML test input to log: %v", err)
+	}
+	err = l.SetFieldSetReader(&LoadBalancerFieldSetReader{})
+	if !errors.Is(err, khierrors.ErrInvalidInput) {
+		t.Errorf("SetFieldSetReader() returned %v, want an error wrapping khierrors.ErrInvalidInput", err)
+	}
+}
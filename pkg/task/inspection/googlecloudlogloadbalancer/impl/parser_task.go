@@ -0,0 +1,164 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogloadbalancer_impl
+
+import (
+	"context"
+	"fmt"
+
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/history"
+	"github.com/kyasbal/khi/pkg/model/history/resourcepath"
+	"github.com/kyasbal/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/kyasbal/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	googlecloudinspectiontypegroup_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudinspectiontypegroup/contract"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	googlecloudlogloadbalancer_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogloadbalancer/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// healthCheckConditionName is the name of the pseudo condition timeline under Pods showing the health states reported by load balancer health checks.
+const healthCheckConditionName = "LoadBalancerHealthCheck"
+
+var FieldSetReaderTask = inspectiontaskbase.NewFieldSetReadTask(googlecloudlogloadbalancer_contract.FieldSetReaderTaskID, googlecloudlogloadbalancer_contract.ListLogEntriesTaskID.Ref(), []log.FieldSetReader{
+	&googlecloudcommon_contract.GCPAccessLogFieldSetReader{},
+	&googlecloudlogloadbalancer_contract.LoadBalancerFieldSetReader{},
+})
+
+var LogIngesterTask = inspectiontaskbase.NewLogIngesterTask(
+	googlecloudlogloadbalancer_contract.LogIngesterTaskID,
+	googlecloudlogloadbalancer_contract.ListLogEntriesTaskID.Ref(),
+)
+
+var LogGrouperTask = inspectiontaskbase.NewLogGrouperTask(googlecloudlogloadbalancer_contract.LogGrouperTaskID, googlecloudlogloadbalancer_contract.FieldSetReaderTaskID.Ref(),
+	func(ctx context.Context, l *log.Log) string {
+		lb := log.MustGetFieldSet(l, &googlecloudlogloadbalancer_contract.LoadBalancerFieldSet{})
+		if lb.Type == googlecloudlogloadbalancer_contract.LoadBalancerLogTypeHealthCheck {
+			return fmt.Sprintf("healthcheck-%s", lb.TargetIP)
+		}
+		return fmt.Sprintf("request-%s", lb.BackendServiceName)
+	},
+)
+
+var LogToTimelineMapperTask = inspectiontaskbase.NewLogToTimelineMapperTask[struct{}](googlecloudlogloadbalancer_contract.LogToTimelineMapperTaskID, &loadBalancerLogToTimelineMapperSetting{}, inspectioncore_contract.FeatureTaskLabel(
+	"Load Balancer & Health Check Logs",
+	"Gather failed requests of load balancers routed to NEGs and health state transitions of NEG endpoints, and show them on the timelines of Services, NEGs and Pods. Logging must be enabled on the backend services and the health checks.",
+	enum.LogTypeLoadBalancer,
+	7001,
+	false,
+	googlecloudinspectiontypegroup_contract.GKEBasedClusterInspectionTypes...,
+))
+
+type loadBalancerLogToTimelineMapperSetting struct{}
+
+// Dependencies implements inspectiontaskbase.LogToTimelineMapper.
+func (l *loadBalancerLogToTimelineMapperSetting) Dependencies() []taskid.UntypedTaskReference {
+	return []taskid.UntypedTaskReference{
+		googlecloudk8scommon_contract.NEGNamesInventoryTaskID.Ref(),
+		googlecloudk8scommon_contract.NEGServicesTaskID.Ref(),
+		commonlogk8sauditv2_contract.IPLeaseHistoryInventoryTaskID.Ref(),
+	}
+}
+
+// GroupedLogTask implements inspectiontaskbase.LogToTimelineMapper.
+func (l *loadBalancerLogToTimelineMapperSetting) GroupedLogTask() taskid.TaskReference[inspectiontaskbase.LogGroupMap] {
+	return googlecloudlogloadbalancer_contract.LogGrouperTaskID.Ref()
+}
+
+// LogIngesterTask implements inspectiontaskbase.LogToTimelineMapper.
+func (l *loadBalancerLogToTimelineMapperSetting) LogIngesterTask() taskid.TaskReference[[]*log.Log] {
+	return googlecloudlogloadbalancer_contract.LogIngesterTaskID.Ref()
+}
+
+// ProcessLogByGroup implements inspectiontaskbase.LogToTimelineMapper.
+func (l *loadBalancerLogToTimelineMapperSetting) ProcessLogByGroup(ctx context.Context, lg *log.Log, cs *history.ChangeSet, builder *history.Builder, prevGroupData struct{}) (struct{}, error) {
+	lb := log.MustGetFieldSet(lg, &googlecloudlogloadbalancer_contract.LoadBalancerFieldSet{})
+	switch lb.Type {
+	case googlecloudlogloadbalancer_contract.LoadBalancerLogTypeRequest:
+		negs := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.NEGNamesInventoryTaskID.Ref())
+		negServices := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.NEGServicesTaskID.Ref())
+		l.mapRequestLog(lg, cs, negs, negServices)
+	case googlecloudlogloadbalancer_contract.LoadBalancerLogTypeHealthCheck:
+		ipLeases := coretask.GetTaskResult(ctx, commonlogk8sauditv2_contract.IPLeaseHistoryInventoryTaskID.Ref())
+		l.mapHealthCheckLog(lg, cs, ipLeases)
+	}
+	return struct{}{}, nil
+}
+
+var _ inspectiontaskbase.LogToTimelineMapper[struct{}] = (*loadBalancerLogToTimelineMapperSetting)(nil)
+
+// mapRequestLog adds the failed request on the timelines of the NEG used as the backend service and the Service owning the NEG.
+func (l *loadBalancerLogToTimelineMapperSetting) mapRequestLog(lg *log.Log, cs *history.ChangeSet, negs googlecloudk8scommon_contract.NEGNameToResourceIdentityMap, negServices googlecloudk8scommon_contract.NEGNameToServiceMap) {
+	lb := log.MustGetFieldSet(lg, &googlecloudlogloadbalancer_contract.LoadBalancerFieldSet{})
+	accessLog := log.MustGetFieldSet(lg, &googlecloudcommon_contract.GCPAccessLogFieldSet{})
+
+	negNamespace := "unknown"
+	if neg, found := negs[lb.BackendServiceName]; found {
+		negNamespace = neg.Namespace
+	}
+	cs.AddEvent(resourcepath.NetworkEndpointGroup(negNamespace, lb.BackendServiceName))
+	if service, found := negServices[lb.BackendServiceName]; found {
+		cs.AddEvent(resourcepath.Service(service.Namespace, service.Name))
+	}
+
+	summary := fmt.Sprintf("%d %s %s", accessLog.Status, accessLog.Method, accessLog.RequestURL)
+	if lb.StatusDetails != "" {
+		summary = fmt.Sprintf("【%s】%s", lb.StatusDetails, summary)
+	}
+	if accessLog.Status >= 500 {
+		cs.SetLogSeverity(enum.SeverityError)
+	}
+	cs.SetLogSummary(summary)
+}
+
+// mapHealthCheckLog records the health state transition on the pseudo condition timeline of the Pod having the IP probed by the health check.
+func (l *loadBalancerLogToTimelineMapperSetting) mapHealthCheckLog(lg *log.Log, cs *history.ChangeSet, ipLeases commonlogk8sauditv2_contract.IPLeaseHistory) {
+	commonFieldSet := log.MustGetFieldSet(lg, &log.CommonFieldSet{})
+	lb := log.MustGetFieldSet(lg, &googlecloudlogloadbalancer_contract.LoadBalancerFieldSet{})
+
+	cs.SetLogSummary(fmt.Sprintf("Health check on %s:%d: %s → %s(%s)", lb.TargetIP, lb.TargetPort, lb.PreviousHealthState, lb.HealthState, lb.DetailedHealthState))
+	if lb.HealthState == googlecloudlogloadbalancer_contract.HealthStateUnhealthy {
+		cs.SetLogSeverity(enum.SeverityWarning)
+	}
+
+	if ipLeases == nil {
+		return
+	}
+	lease, err := ipLeases.GetResourceLeaseHolderAt(lb.TargetIP, commonFieldSet.Timestamp)
+	if err != nil || lease.Holder.Kind != "pod" {
+		return
+	}
+	verb := enum.RevisionVerbStatusUnknown
+	state := enum.RevisionStateConditionUnknown
+	switch lb.HealthState {
+	case googlecloudlogloadbalancer_contract.HealthStateHealthy:
+		verb = enum.RevisionVerbReady
+		state = enum.RevisionStateConditionTrue
+	case googlecloudlogloadbalancer_contract.HealthStateUnhealthy:
+		verb = enum.RevisionVerbNonReady
+		state = enum.RevisionStateConditionFalse
+	}
+	cs.AddRevision(resourcepath.Condition(resourcepath.Pod(lease.Holder.Namespace, lease.Holder.Name), healthCheckConditionName), &history.StagingResourceRevision{
+		Verb:       verb,
+		State:      state,
+		Body:       fmt.Sprintf("healthState: %s\ndetailedHealthState: %s\nprobeResultText: %q\n", lb.HealthState, lb.DetailedHealthState, lb.ProbeResultText),
+		Requestor:  "health-check",
+		ChangeTime: commonFieldSet.Timestamp,
+	})
+}
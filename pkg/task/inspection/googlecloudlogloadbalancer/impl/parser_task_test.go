@@ -0,0 +1,164 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogloadbalancer_impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/history"
+	"github.com/kyasbal/khi/pkg/model/history/resourceinfo/resourcelease"
+	"github.com/kyasbal/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/kyasbal/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	googlecloudlogloadbalancer_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogloadbalancer/contract"
+	"github.com/kyasbal/khi/pkg/testutil/testchangeset"
+)
+
+func TestMapRequestLog(t *testing.T) {
+	negName := "k8s1-12345678-default-nginx-80-abcdef01"
+	negs := googlecloudk8scommon_contract.NEGNameToResourceIdentityMap{
+		negName: {APIVersion: "networking.gke.io/v1beta1", Kind: "servicenetworkendpointgroup", Namespace: "default", Name: negName},
+	}
+	negServices := googlecloudk8scommon_contract.NEGNameToServiceMap{
+		negName: {APIVersion: "core/v1", Kind: "service", Namespace: "default", Name: "nginx"},
+	}
+	testCases := []struct {
+		desc      string
+		input     *googlecloudlogloadbalancer_contract.LoadBalancerFieldSet
+		asserters []testchangeset.ChangeSetAsserter
+	}{
+		{
+			desc: "request routed to a known NEG",
+			input: &googlecloudlogloadbalancer_contract.LoadBalancerFieldSet{
+				Type:               googlecloudlogloadbalancer_contract.LoadBalancerLogTypeRequest,
+				BackendServiceName: negName,
+				StatusDetails:      "failed_to_connect_to_backend",
+			},
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasLogSummary{WantLogSummary: "【failed_to_connect_to_backend】502 GET /index.html"},
+				&testchangeset.MatchResourcePathSet{
+					WantResourcePaths: []string{
+						"core/v1#service#default#nginx",
+						"networking.gke.io/v1beta1#servicenetworkendpointgroup#default#" + negName,
+					},
+				},
+			},
+		},
+		{
+			desc: "request routed to an unknown backend service",
+			input: &googlecloudlogloadbalancer_contract.LoadBalancerFieldSet{
+				Type:               googlecloudlogloadbalancer_contract.LoadBalancerLogTypeRequest,
+				BackendServiceName: "other-backend",
+			},
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasLogSummary{WantLogSummary: "502 GET /index.html"},
+				&testchangeset.MatchResourcePathSet{
+					WantResourcePaths: []string{
+						"networking.gke.io/v1beta1#servicenetworkendpointgroup#unknown#other-backend",
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l := log.NewLogWithFieldSetsForTest(&googlecloudcommon_contract.GCPAccessLogFieldSet{
+				Status:     502,
+				Method:     "GET",
+				RequestURL: "/index.html",
+			}, tc.input)
+			cs := history.NewChangeSet(l)
+
+			(&loadBalancerLogToTimelineMapperSetting{}).mapRequestLog(l, cs, negs, negServices)
+
+			for _, asserter := range tc.asserters {
+				asserter.Assert(t, cs)
+			}
+		})
+	}
+}
+
+func TestMapHealthCheckLog(t *testing.T) {
+	logTime := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	ipLeases := resourcelease.NewResourceLeaseHistory[*commonlogk8sauditv2_contract.ResourceIdentity]()
+	ipLeases.TouchResourceLease("10.0.0.1", logTime.Add(-time.Minute), &commonlogk8sauditv2_contract.ResourceIdentity{
+		APIVersion: "core/v1",
+		Kind:       "pod",
+		Namespace:  "default",
+		Name:       "nginx-1",
+	})
+	testCases := []struct {
+		desc      string
+		input     *googlecloudlogloadbalancer_contract.LoadBalancerFieldSet
+		asserters []testchangeset.ChangeSetAsserter
+	}{
+		{
+			desc: "endpoint becoming unhealthy",
+			input: &googlecloudlogloadbalancer_contract.LoadBalancerFieldSet{
+				Type:                googlecloudlogloadbalancer_contract.LoadBalancerLogTypeHealthCheck,
+				TargetIP:            "10.0.0.1",
+				TargetPort:          8080,
+				PreviousHealthState: "HEALTHY",
+				HealthState:         "UNHEALTHY",
+				DetailedHealthState: "TIMEOUT",
+			},
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasLogSummary{WantLogSummary: "Health check on 10.0.0.1:8080: HEALTHY → UNHEALTHY(TIMEOUT)"},
+				&testchangeset.HasRevision{
+					ResourcePath: "core/v1#pod#default#nginx-1#LoadBalancerHealthCheck",
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbNonReady,
+						State:      enum.RevisionStateConditionFalse,
+						Body:       "healthState: UNHEALTHY\ndetailedHealthState: TIMEOUT\nprobeResultText: \"\"\n",
+						Requestor:  "health-check",
+						ChangeTime: logTime,
+					},
+				},
+			},
+		},
+		{
+			desc: "endpoint without the Pod owning the IP",
+			input: &googlecloudlogloadbalancer_contract.LoadBalancerFieldSet{
+				Type:                googlecloudlogloadbalancer_contract.LoadBalancerLogTypeHealthCheck,
+				TargetIP:            "10.0.0.2",
+				TargetPort:          8080,
+				PreviousHealthState: "UNHEALTHY",
+				HealthState:         "HEALTHY",
+				DetailedHealthState: "HEALTHY",
+			},
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasLogSummary{WantLogSummary: "Health check on 10.0.0.2:8080: UNHEALTHY → HEALTHY(HEALTHY)"},
+				&testchangeset.MatchResourcePathSet{
+					WantResourcePaths: []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l := log.NewLogWithFieldSetsForTest(&log.CommonFieldSet{Timestamp: logTime}, tc.input)
+			cs := history.NewChangeSet(l)
+
+			(&loadBalancerLogToTimelineMapperSetting{}).mapHealthCheckLog(l, cs, ipLeases)
+
+			for _, asserter := range tc.asserters {
+				asserter.Assert(t, cs)
+			}
+		})
+	}
+}
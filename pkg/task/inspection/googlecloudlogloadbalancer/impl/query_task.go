@@ -0,0 +1,108 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogloadbalancer_impl
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/gcpqueryutil"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	googlecloudlogloadbalancer_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogloadbalancer/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// healthCheckLogsFilter returns the filter for health check logs of NEG endpoints.
+// Health check logs only record the transitions of health states, and the endpoints are associated with Pods by their IPs later.
+func healthCheckLogsFilter() string {
+	return `LOG_ID("compute.googleapis.com/healthchecks")
+resource.type="gce_network_endpoint_group"`
+}
+
+// generateLoadBalancerRequestLogsFilters returns the filters for failed requests routed to the backend services named with the given NEG names.
+// GKE Ingress and Gateway controllers name the backend services with the same name as the NEGs.
+func generateLoadBalancerRequestLogsFilters(taskMode inspectioncore_contract.InspectionTaskModeType, negNames []string) []string {
+	if taskMode == inspectioncore_contract.TaskModeDryRun {
+		return []string{loadBalancerRequestLogsFilterFromBackendServiceFilter("-- backend service name filters to be determined after audit log query")}
+	}
+	result := []string{}
+	for _, group := range gcpqueryutil.SplitToChildGroups(negNames, 10) {
+		result = append(result, loadBalancerRequestLogsFilterFromBackendServiceFilter(fmt.Sprintf(`resource.labels.backend_service_name:(%s)`, strings.Join(group, " OR "))))
+	}
+	return result
+}
+
+func loadBalancerRequestLogsFilterFromBackendServiceFilter(backendServiceFilter string) string {
+	return fmt.Sprintf(`resource.type=("http_load_balancer" OR "internal_http_lb_rule")
+httpRequest.status>=500
+%s`, backendServiceFilter)
+}
+
+type loadBalancerListLogEntriesTaskSetting struct{}
+
+// DefaultResourceNames implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
+func (l *loadBalancerListLogEntriesTaskSetting) DefaultResourceNames(ctx context.Context) ([]string, error) {
+	cluster := coretask.GetTaskResult(ctx, googlecloudlogloadbalancer_contract.ClusterIdentityTaskID.Ref())
+	return []string{fmt.Sprintf("projects/%s", cluster.ProjectID)}, nil
+}
+
+// Dependencies implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
+func (l *loadBalancerListLogEntriesTaskSetting) Dependencies() []taskid.UntypedTaskReference {
+	return []taskid.UntypedTaskReference{
+		googlecloudlogloadbalancer_contract.ClusterIdentityTaskID.Ref(),
+		googlecloudk8scommon_contract.NEGNamesInventoryTaskID.Ref(),
+	}
+}
+
+// Description implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
+func (l *loadBalancerListLogEntriesTaskSetting) Description() *googlecloudcommon_contract.ListLogEntriesTaskDescription {
+	return &googlecloudcommon_contract.ListLogEntriesTaskDescription{
+		DefaultLogType: enum.LogTypeLoadBalancer,
+		QueryName:      "Load balancer and health check logs",
+		ExampleQuery:   generateLoadBalancerRequestLogsFilters(inspectioncore_contract.TaskModeRun, []string{"neg-id-1", "neg-id-2"})[0],
+	}
+}
+
+// LogFilters implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
+func (l *loadBalancerListLogEntriesTaskSetting) LogFilters(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) ([]string, error) {
+	negs := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.NEGNamesInventoryTaskID.Ref())
+	negNames := []string{}
+	for negName := range negs {
+		negNames = append(negNames, negName)
+	}
+	slices.Sort(negNames)
+	return append([]string{healthCheckLogsFilter()}, generateLoadBalancerRequestLogsFilters(taskMode, negNames)...), nil
+}
+
+// TaskID implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
+func (l *loadBalancerListLogEntriesTaskSetting) TaskID() taskid.TaskImplementationID[[]*log.Log] {
+	return googlecloudlogloadbalancer_contract.ListLogEntriesTaskID
+}
+
+// TimePartitionCount implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
+func (l *loadBalancerListLogEntriesTaskSetting) TimePartitionCount(ctx context.Context) (int, error) {
+	return 1, nil
+}
+
+var _ googlecloudcommon_contract.ListLogEntriesTaskSetting = (*loadBalancerListLogEntriesTaskSetting)(nil)
+
+var ListLogEntriesTask = googlecloudcommon_contract.NewListLogEntriesTask(&loadBalancerListLogEntriesTaskSetting{})
@@ -0,0 +1,61 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogloadbalancer_impl
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+func TestGenerateLoadBalancerRequestLogsFilters(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		taskMode inspectioncore_contract.InspectionTaskModeType
+		negNames []string
+		want     []string
+	}{
+		{
+			desc:     "dry run",
+			taskMode: inspectioncore_contract.TaskModeDryRun,
+			want: []string{`resource.type=("http_load_balancer" OR "internal_http_lb_rule")
+httpRequest.status>=500
+-- backend service name filters to be determined after audit log query`},
+		},
+		{
+			desc:     "run with NEG names",
+			taskMode: inspectioncore_contract.TaskModeRun,
+			negNames: []string{"neg-1", "neg-2"},
+			want: []string{`resource.type=("http_load_balancer" OR "internal_http_lb_rule")
+httpRequest.status>=500
+resource.labels.backend_service_name:(neg-1 OR neg-2)`},
+		},
+		{
+			desc:     "run without NEG names",
+			taskMode: inspectioncore_contract.TaskModeRun,
+			negNames: []string{},
+			want:     []string{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := generateLoadBalancerRequestLogsFilters(tc.taskMode, tc.negNames)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("generateLoadBalancerRequestLogsFilters() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
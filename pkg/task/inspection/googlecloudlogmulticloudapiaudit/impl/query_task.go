@@ -53,6 +53,7 @@ func (g *multicloudAPIListLogEntriesTaskSetting) DefaultResourceNames(ctx contex
 func (g *multicloudAPIListLogEntriesTaskSetting) Dependencies() []taskid.UntypedTaskReference {
 	return []taskid.UntypedTaskReference{
 		googlecloudlogmulticloudapiaudit_contract.ClusterIdentityTaskID.Ref(),
+		googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref(),
 	}
 }
 
@@ -72,9 +73,13 @@ func (g *multicloudAPIListLogEntriesTaskSetting) Description() *googlecloudcommo
 
 // LogFilters implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
 func (g *multicloudAPIListLogEntriesTaskSetting) LogFilters(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) ([]string, error) {
-	clusterIdentity := coretask.GetTaskResult(ctx, googlecloudlogmulticloudapiaudit_contract.ClusterIdentityTaskID.Ref())
+	clusters := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref())
 
-	return []string{generateQuery(clusterIdentity)}, nil
+	filters := []string{}
+	for _, clusterIdentity := range clusters {
+		filters = append(filters, generateQuery(clusterIdentity))
+	}
+	return filters, nil
 }
 
 // TaskID implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
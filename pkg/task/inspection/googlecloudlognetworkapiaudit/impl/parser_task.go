@@ -82,6 +82,7 @@ type networkAPILogToTimelineMapperTaskSetting struct{}
 func (n *networkAPILogToTimelineMapperTaskSetting) Dependencies() []taskid.UntypedTaskReference {
 	return []taskid.UntypedTaskReference{
 		googlecloudk8scommon_contract.NEGNamesDiscoveryTaskID.Ref(),
+		googlecloudk8scommon_contract.NEGServicesTaskID.Ref(),
 		commonlogk8sauditv2_contract.IPLeaseHistoryInventoryTaskID.Ref(),
 	}
 }
@@ -170,6 +171,11 @@ func (n *networkAPILogToTimelineMapperTaskSetting) ProcessLogByGroup(ctx context
 		state = enum.RevisionStateConditionFalse
 	}
 	if negRequest != nil {
+		// Show the backend attach/detach on the Service timeline next to its Kubernetes events.
+		negServices := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.NEGServicesTaskID.Ref())
+		if service, found := negServices[negName]; found {
+			cs.AddEvent(resourcepath.Service(service.Namespace, service.Name))
+		}
 		for _, endpoint := range negRequest.NetworkEndpoints {
 			lease, err := ipLeases.GetResourceLeaseHolderAt(endpoint.IpAddress, commonFieldSet.Timestamp)
 			if err != nil {
@@ -0,0 +1,129 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlognetworkflow_contract
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/common/khierrors"
+	"github.com/kyasbal/khi/pkg/common/structured"
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+type NetworkFlowLogType int
+
+const (
+	// NetworkFlowLogTypeVPCFlow is the type of logs from VPC flow logs.
+	NetworkFlowLogTypeVPCFlow NetworkFlowLogType = iota
+	// NetworkFlowLogTypePolicyDenial is the type of logs from GKE Dataplane V2 network policy logging for denied connections.
+	NetworkFlowLogTypePolicyDenial
+)
+
+// NetworkFlowEndpoint is an endpoint of a connection.
+type NetworkFlowEndpoint struct {
+	IP   string
+	Port int
+	// PodNamespace and PodName are given when the log has the Pod information of the IP. They are empty otherwise.
+	PodNamespace string
+	PodName      string
+}
+
+// String returns the human readable representation of the endpoint.
+func (n *NetworkFlowEndpoint) String() string {
+	address := n.IP
+	if n.Port != 0 {
+		address = fmt.Sprintf("%s:%d", n.IP, n.Port)
+	}
+	if n.PodName == "" {
+		return address
+	}
+	return fmt.Sprintf("%s/%s(%s)", n.PodNamespace, n.PodName, address)
+}
+
+// NetworkFlowFieldSet is the field set for a connection in VPC flow logs or network policy logs.
+type NetworkFlowFieldSet struct {
+	Type        NetworkFlowLogType
+	Source      NetworkFlowEndpoint
+	Destination NetworkFlowEndpoint
+	Protocol    string
+	// Direction is the direction of the connection from the point of view of the Pod enforcing the network policy. This is only available for network policy logs.
+	Direction string
+}
+
+// Kind implements log.FieldSet.
+func (n *NetworkFlowFieldSet) Kind() string {
+	return "network_flow"
+}
+
+var _ log.FieldSet = (*NetworkFlowFieldSet)(nil)
+
+type NetworkFlowFieldSetReader struct{}
+
+// FieldSetKind implements log.FieldSetReader.
+func (n *NetworkFlowFieldSetReader) FieldSetKind() string {
+	return (&NetworkFlowFieldSet{}).Kind()
+}
+
+// Read implements log.FieldSetReader.
+func (n *NetworkFlowFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	var result NetworkFlowFieldSet
+	logName, err := reader.ReadString("logName")
+	if err != nil {
+		return nil, err
+	}
+	result.Source.IP = reader.ReadStringOrDefault("jsonPayload.connection.src_ip", "")
+	result.Source.Port = reader.ReadIntOrDefault("jsonPayload.connection.src_port", 0)
+	result.Destination.IP = reader.ReadStringOrDefault("jsonPayload.connection.dest_ip", "")
+	result.Destination.Port = reader.ReadIntOrDefault("jsonPayload.connection.dest_port", 0)
+	switch {
+	case strings.HasSuffix(logName, "vpc_flows"):
+		result.Type = NetworkFlowLogTypeVPCFlow
+		result.Protocol = protocolName(reader.ReadIntOrDefault("jsonPayload.connection.protocol", 0))
+		result.Source.PodNamespace = reader.ReadStringOrDefault("jsonPayload.src_gke_details.pod.pod_namespace", "")
+		result.Source.PodName = reader.ReadStringOrDefault("jsonPayload.src_gke_details.pod.pod_name", "")
+		result.Destination.PodNamespace = reader.ReadStringOrDefault("jsonPayload.dest_gke_details.pod.pod_namespace", "")
+		result.Destination.PodName = reader.ReadStringOrDefault("jsonPayload.dest_gke_details.pod.pod_name", "")
+	case strings.HasSuffix(logName, "policy-action"):
+		result.Type = NetworkFlowLogTypePolicyDenial
+		result.Protocol = reader.ReadStringOrDefault("jsonPayload.connection.protocol", "")
+		result.Direction = reader.ReadStringOrDefault("jsonPayload.connection.direction", "")
+		result.Source.PodNamespace = reader.ReadStringOrDefault("jsonPayload.src.pod_namespace", "")
+		result.Source.PodName = reader.ReadStringOrDefault("jsonPayload.src.pod_name", "")
+		result.Destination.PodNamespace = reader.ReadStringOrDefault("jsonPayload.dest.pod_namespace", "")
+		result.Destination.PodName = reader.ReadStringOrDefault("jsonPayload.dest.pod_name", "")
+	default:
+		return nil, fmt.Errorf("a log with unknown logName %q was given to NetworkFlowFieldSetReader:%w", logName, khierrors.ErrInvalidInput)
+	}
+	return &result, nil
+}
+
+var _ log.FieldSetReader = (*NetworkFlowFieldSetReader)(nil)
+
+// protocolName returns the name of the IANA protocol number used in VPC flow logs.
+func protocolName(protocol int) string {
+	switch protocol {
+	case 1:
+		return "icmp"
+	case 6:
+		return "tcp"
+	case 17:
+		return "udp"
+	case 58:
+		return "icmpv6"
+	default:
+		return fmt.Sprintf("protocol(%d)", protocol)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlognetworkflow_contract
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/common/khierrors"
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+func TestNetworkFlowFieldSetReader(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		input string
+		want  *NetworkFlowFieldSet
+	}{
+		{
+			desc: "VPC flow log with GKE details",
+			input: `
+logName: "projects/test-project/logs/compute.googleapis.com%2Fvpc_flows"
+jsonPayload:
+  connection:
+    src_ip: "10.0.0.1"
+    src_port: 43210
+    dest_ip: "10.0.1.2"
+    dest_port: 8080
+    protocol: 6
+  src_gke_details:
+    pod:
+      pod_namespace: "default"
+      pod_name: "frontend"
+  dest_gke_details:
+    pod:
+      pod_namespace: "backend"
+      pod_name: "api"
+`,
+			want: &NetworkFlowFieldSet{
+				Type:        NetworkFlowLogTypeVPCFlow,
+				Protocol:    "tcp",
+				Source:      NetworkFlowEndpoint{IP: "10.0.0.1", Port: 43210, PodNamespace: "default", PodName: "frontend"},
+				Destination: NetworkFlowEndpoint{IP: "10.0.1.2", Port: 8080, PodNamespace: "backend", PodName: "api"},
+			},
+		},
+		{
+			desc: "VPC flow log without GKE details",
+			input: `
+logName: "projects/test-project/logs/compute.googleapis.com%2Fvpc_flows"
+jsonPayload:
+  connection:
+    src_ip: "10.0.0.1"
+    src_port: 53
+    dest_ip: "8.8.8.8"
+    dest_port: 53
+    protocol: 17
+`,
+			want: &NetworkFlowFieldSet{
+				Type:        NetworkFlowLogTypeVPCFlow,
+				Protocol:    "udp",
+				Source:      NetworkFlowEndpoint{IP: "10.0.0.1", Port: 53},
+				Destination: NetworkFlowEndpoint{IP: "8.8.8.8", Port: 53},
+			},
+		},
+		{
+			desc: "network policy denial log",
+			input: `
+logName: "projects/test-project/logs/policy-action"
+jsonPayload:
+  connection:
+    src_ip: "10.0.0.1"
+    src_port: 43210
+    dest_ip: "10.0.1.2"
+    dest_port: 8080
+    protocol: "tcp"
+    direction: "ingress"
+  disposition: "deny"
+  src:
+    pod_namespace: "default"
+    pod_name: "frontend"
+  dest:
+    pod_namespace: "backend"
+    pod_name: "api"
+`,
+			want: &NetworkFlowFieldSet{
+				Type:        NetworkFlowLogTypePolicyDenial,
+				Protocol:    "tcp",
+				Direction:   "ingress",
+				Source:      NetworkFlowEndpoint{IP: "10.0.0.1", Port: 43210, PodNamespace: "default", PodName: "frontend"},
+				Destination: NetworkFlowEndpoint{IP: "10.0.1.2", Port: 8080, PodNamespace: "backend", PodName: "api"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l, err := log.NewLogFromYAMLString(tc.input)
+			if err != nil {
+				t.Fatalf("failed to parse YAML test input to log: %v", err)
+			}
+			err = l.SetFieldSetReader(&NetworkFlowFieldSetReader{})
+			if err != nil {
+				t.Fatalf("failed to run NetworkFlowFieldSetReader.Read(): %v", err)
+			}
+			got := log.MustGetFieldSet(l, &NetworkFlowFieldSet{})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("NetworkFlowFieldSet mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNetworkFlowFieldSetReader_UnknownLogName(t *testing.T) {
+	l, err := log.NewLogFromYAMLString(`logName: "projects/test-project/logs/cloudaudit.googleapis.com%2Factivity"`)
+	if err != nil {
+		t.Fatalf("failed to parse YAML test input to log: %v", err)
+	}
+	err = l.SetFieldSetReader(&NetworkFlowFieldSetReader{})
+	if !errors.Is(err, khierrors.ErrInvalidInput) {
+		t.Errorf("SetFieldSetReader() returned %v, want an error wrapping khierrors.ErrInvalidInput", err)
+	}
+}
+
+func TestNetworkFlowEndpoint_String(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		endpoint NetworkFlowEndpoint
+		want     string
+	}{
+		{
+			desc:     "with Pod",
+			endpoint: NetworkFlowEndpoint{IP: "10.0.0.1", Port: 80, PodNamespace: "default", PodName: "nginx"},
+			want:     "default/nginx(10.0.0.1:80)",
+		},
+		{
+			desc:     "without Pod",
+			endpoint: NetworkFlowEndpoint{IP: "10.0.0.1", Port: 80},
+			want:     "10.0.0.1:80",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.endpoint.String(); got != tc.want {
+				t.Errorf("String() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlognetworkflow_impl
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/history"
+	"github.com/kyasbal/khi/pkg/model/history/resourcepath"
+	"github.com/kyasbal/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/kyasbal/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	googlecloudinspectiontypegroup_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudinspectiontypegroup/contract"
+	googlecloudlognetworkflow_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlognetworkflow/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+var FieldSetReaderTask = inspectiontaskbase.NewFieldSetReadTask(googlecloudlognetworkflow_contract.FieldSetReaderTaskID, googlecloudlognetworkflow_contract.ListLogEntriesTaskID.Ref(), []log.FieldSetReader{
+	&googlecloudlognetworkflow_contract.NetworkFlowFieldSetReader{},
+})
+
+var LogIngesterTask = inspectiontaskbase.NewLogIngesterTask(
+	googlecloudlognetworkflow_contract.LogIngesterTaskID,
+	googlecloudlognetworkflow_contract.ListLogEntriesTaskID.Ref(),
+)
+
+var LogGrouperTask = inspectiontaskbase.NewLogGrouperTask(googlecloudlognetworkflow_contract.LogGrouperTaskID, googlecloudlognetworkflow_contract.FieldSetReaderTaskID.Ref(),
+	func(ctx context.Context, l *log.Log) string {
+		flow := log.MustGetFieldSet(l, &googlecloudlognetworkflow_contract.NetworkFlowFieldSet{})
+		return fmt.Sprintf("%s-%s", flow.Source.IP, flow.Destination.IP)
+	},
+)
+
+var LogToTimelineMapperTask = inspectiontaskbase.NewLogToTimelineMapperTask[struct{}](googlecloudlognetworkflow_contract.LogToTimelineMapperTaskID, &networkFlowLogToTimelineMapperSetting{}, inspectioncore_contract.FeatureTaskLabel(
+	"VPC Flow & Network Policy Logs",
+	"Gather VPC flow logs and connections denied by network policies from Cloud Logging and associate them with the Pods having the IPs on timelines. VPC flow logs with GKE annotations and network policy logging of GKE Dataplane V2 must be enabled.",
+	enum.LogTypeNetworkFlow,
+	10001,
+	false,
+	googlecloudinspectiontypegroup_contract.GKEBasedClusterInspectionTypes...,
+))
+
+type networkFlowLogToTimelineMapperSetting struct{}
+
+// Dependencies implements inspectiontaskbase.LogToTimelineMapper.
+func (n *networkFlowLogToTimelineMapperSetting) Dependencies() []taskid.UntypedTaskReference {
+	return []taskid.UntypedTaskReference{
+		commonlogk8sauditv2_contract.IPLeaseHistoryInventoryTaskID.Ref(),
+	}
+}
+
+// GroupedLogTask implements inspectiontaskbase.LogToTimelineMapper.
+func (n *networkFlowLogToTimelineMapperSetting) GroupedLogTask() taskid.TaskReference[inspectiontaskbase.LogGroupMap] {
+	return googlecloudlognetworkflow_contract.LogGrouperTaskID.Ref()
+}
+
+// LogIngesterTask implements inspectiontaskbase.LogToTimelineMapper.
+func (n *networkFlowLogToTimelineMapperSetting) LogIngesterTask() taskid.TaskReference[[]*log.Log] {
+	return googlecloudlognetworkflow_contract.LogIngesterTaskID.Ref()
+}
+
+// ProcessLogByGroup implements inspectiontaskbase.LogToTimelineMapper.
+func (n *networkFlowLogToTimelineMapperSetting) ProcessLogByGroup(ctx context.Context, l *log.Log, cs *history.ChangeSet, builder *history.Builder, prevGroupData struct{}) (struct{}, error) {
+	ipLeases := coretask.GetTaskResult(ctx, commonlogk8sauditv2_contract.IPLeaseHistoryInventoryTaskID.Ref())
+	n.mapNetworkFlow(l, cs, ipLeases)
+	return struct{}{}, nil
+}
+
+var _ inspectiontaskbase.LogToTimelineMapper[struct{}] = (*networkFlowLogToTimelineMapperSetting)(nil)
+
+// mapNetworkFlow adds events on the timelines of the Pods at the both ends of the connection and sets the log summary.
+func (n *networkFlowLogToTimelineMapperSetting) mapNetworkFlow(l *log.Log, cs *history.ChangeSet, ipLeases commonlogk8sauditv2_contract.IPLeaseHistory) {
+	commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+	flow := log.MustGetFieldSet(l, &googlecloudlognetworkflow_contract.NetworkFlowFieldSet{})
+
+	source := resolvePodOfEndpoint(flow.Source, commonFieldSet.Timestamp, ipLeases)
+	destination := resolvePodOfEndpoint(flow.Destination, commonFieldSet.Timestamp, ipLeases)
+	for _, endpoint := range []googlecloudlognetworkflow_contract.NetworkFlowEndpoint{source, destination} {
+		if endpoint.PodName == "" {
+			continue
+		}
+		cs.AddEvent(resourcepath.PodNetworkFlow(endpoint.PodNamespace, endpoint.PodName))
+	}
+
+	summary := fmt.Sprintf("%s %s → %s", flow.Protocol, source.String(), destination.String())
+	if flow.Type == googlecloudlognetworkflow_contract.NetworkFlowLogTypePolicyDenial {
+		summary = fmt.Sprintf("【Denied by network policy(%s)】%s", flow.Direction, summary)
+		cs.SetLogSeverity(enum.SeverityWarning)
+	}
+	cs.SetLogSummary(summary)
+}
+
+// resolvePodOfEndpoint fills the Pod of the endpoint from the history of the Pod IPs when the log doesn't have the Pod information.
+func resolvePodOfEndpoint(endpoint googlecloudlognetworkflow_contract.NetworkFlowEndpoint, t time.Time, ipLeases commonlogk8sauditv2_contract.IPLeaseHistory) googlecloudlognetworkflow_contract.NetworkFlowEndpoint {
+	if endpoint.PodName != "" || endpoint.IP == "" || ipLeases == nil {
+		return endpoint
+	}
+	lease, err := ipLeases.GetResourceLeaseHolderAt(endpoint.IP, t)
+	if err != nil || lease.Holder.Kind != "pod" {
+		return endpoint
+	}
+	endpoint.PodNamespace = lease.Holder.Namespace
+	endpoint.PodName = lease.Holder.Name
+	return endpoint
+}
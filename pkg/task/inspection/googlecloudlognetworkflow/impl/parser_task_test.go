@@ -0,0 +1,115 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlognetworkflow_impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/model/history"
+	"github.com/kyasbal/khi/pkg/model/history/resourceinfo/resourcelease"
+	"github.com/kyasbal/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/kyasbal/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	googlecloudlognetworkflow_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlognetworkflow/contract"
+	"github.com/kyasbal/khi/pkg/testutil/testchangeset"
+)
+
+func TestLogToTimelineMapper(t *testing.T) {
+	logTime := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	ipLeases := resourcelease.NewResourceLeaseHistory[*commonlogk8sauditv2_contract.ResourceIdentity]()
+	ipLeases.TouchResourceLease("10.0.1.2", logTime.Add(-time.Minute), &commonlogk8sauditv2_contract.ResourceIdentity{
+		APIVersion: "core/v1",
+		Kind:       "pod",
+		Namespace:  "backend",
+		Name:       "api",
+	})
+	ipLeases.TouchResourceLease("10.0.2.3", logTime.Add(-time.Minute), &commonlogk8sauditv2_contract.ResourceIdentity{
+		APIVersion: "core/v1",
+		Kind:       "node",
+		Name:       "node-1",
+	})
+
+	testCases := []struct {
+		desc      string
+		input     *googlecloudlognetworkflow_contract.NetworkFlowFieldSet
+		asserters []testchangeset.ChangeSetAsserter
+	}{
+		{
+			desc: "VPC flow log with Pods in the log",
+			input: &googlecloudlognetworkflow_contract.NetworkFlowFieldSet{
+				Type:        googlecloudlognetworkflow_contract.NetworkFlowLogTypeVPCFlow,
+				Protocol:    "tcp",
+				Source:      googlecloudlognetworkflow_contract.NetworkFlowEndpoint{IP: "10.0.0.1", Port: 43210, PodNamespace: "default", PodName: "frontend"},
+				Destination: googlecloudlognetworkflow_contract.NetworkFlowEndpoint{IP: "10.0.1.3", Port: 8080, PodNamespace: "backend", PodName: "api-2"},
+			},
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasLogSummary{WantLogSummary: "tcp default/frontend(10.0.0.1:43210) → backend/api-2(10.0.1.3:8080)"},
+				&testchangeset.MatchResourcePathSet{
+					WantResourcePaths: []string{
+						"core/v1#pod#backend#api-2#network-flow",
+						"core/v1#pod#default#frontend#network-flow",
+					},
+				},
+			},
+		},
+		{
+			desc: "network policy denial with the destination Pod resolved from its IP",
+			input: &googlecloudlognetworkflow_contract.NetworkFlowFieldSet{
+				Type:        googlecloudlognetworkflow_contract.NetworkFlowLogTypePolicyDenial,
+				Protocol:    "tcp",
+				Direction:   "ingress",
+				Source:      googlecloudlognetworkflow_contract.NetworkFlowEndpoint{IP: "10.0.0.1", Port: 43210, PodNamespace: "default", PodName: "frontend"},
+				Destination: googlecloudlognetworkflow_contract.NetworkFlowEndpoint{IP: "10.0.1.2", Port: 8080},
+			},
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasLogSummary{WantLogSummary: "【Denied by network policy(ingress)】tcp default/frontend(10.0.0.1:43210) → backend/api(10.0.1.2:8080)"},
+				&testchangeset.MatchResourcePathSet{
+					WantResourcePaths: []string{
+						"core/v1#pod#backend#api#network-flow",
+						"core/v1#pod#default#frontend#network-flow",
+					},
+				},
+			},
+		},
+		{
+			desc: "IPs not leased to Pods are ignored",
+			input: &googlecloudlognetworkflow_contract.NetworkFlowFieldSet{
+				Type:        googlecloudlognetworkflow_contract.NetworkFlowLogTypeVPCFlow,
+				Protocol:    "udp",
+				Source:      googlecloudlognetworkflow_contract.NetworkFlowEndpoint{IP: "10.0.2.3", Port: 53},
+				Destination: googlecloudlognetworkflow_contract.NetworkFlowEndpoint{IP: "8.8.8.8", Port: 53},
+			},
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasLogSummary{WantLogSummary: "udp 10.0.2.3:53 → 8.8.8.8:53"},
+				&testchangeset.MatchResourcePathSet{
+					WantResourcePaths: []string{},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l := log.NewLogWithFieldSetsForTest(&log.CommonFieldSet{Timestamp: logTime}, tc.input)
+			cs := history.NewChangeSet(l)
+
+			(&networkFlowLogToTimelineMapperSetting{}).mapNetworkFlow(l, cs, ipLeases)
+
+			for _, asserter := range tc.asserters {
+				asserter.Assert(t, cs)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlognetworkflow_impl
+
+import (
+	"context"
+	"fmt"
+
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	googlecloudlognetworkflow_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlognetworkflow/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// networkFlowLogsFilter returns the filter for VPC flow logs of the Pods in the cluster and connections denied by network policies in the cluster.
+// VPC flow logs are matched with the GKE annotations added to the flows, so only flows from or to the cluster are queried.
+func networkFlowLogsFilter(cluster googlecloudk8scommon_contract.GoogleCloudClusterIdentity) string {
+	clusterName := cluster.NameWithClusterTypePrefix()
+	return fmt.Sprintf(`(LOG_ID("compute.googleapis.com/vpc_flows") AND ((jsonPayload.src_gke_details.cluster.cluster_name="%s" AND jsonPayload.src_gke_details.cluster.cluster_location="%s") OR (jsonPayload.dest_gke_details.cluster.cluster_name="%s" AND jsonPayload.dest_gke_details.cluster.cluster_location="%s")))
+OR (LOG_ID("policy-action") AND resource.type="k8s_node" AND resource.labels.cluster_name="%s" AND resource.labels.location="%s" AND jsonPayload.disposition="deny")`, clusterName, cluster.Location, clusterName, cluster.Location, clusterName, cluster.Location)
+}
+
+type networkFlowListLogEntriesTaskSetting struct{}
+
+// DefaultResourceNames implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
+func (n *networkFlowListLogEntriesTaskSetting) DefaultResourceNames(ctx context.Context) ([]string, error) {
+	cluster := coretask.GetTaskResult(ctx, googlecloudlognetworkflow_contract.ClusterIdentityTaskID.Ref())
+	return []string{fmt.Sprintf("projects/%s", cluster.ProjectID)}, nil
+}
+
+// Dependencies implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
+func (n *networkFlowListLogEntriesTaskSetting) Dependencies() []taskid.UntypedTaskReference {
+	return []taskid.UntypedTaskReference{
+		googlecloudlognetworkflow_contract.ClusterIdentityTaskID.Ref(),
+		googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref(),
+	}
+}
+
+// Description implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
+func (n *networkFlowListLogEntriesTaskSetting) Description() *googlecloudcommon_contract.ListLogEntriesTaskDescription {
+	return &googlecloudcommon_contract.ListLogEntriesTaskDescription{
+		DefaultLogType: enum.LogTypeNetworkFlow,
+		QueryName:      "VPC flow logs and network policy logs",
+		ExampleQuery: networkFlowLogsFilter(googlecloudk8scommon_contract.GoogleCloudClusterIdentity{
+			ProjectID:   "test-project",
+			Location:    "test-location",
+			ClusterName: "test-cluster",
+		}),
+	}
+}
+
+// LogFilters implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
+func (n *networkFlowListLogEntriesTaskSetting) LogFilters(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) ([]string, error) {
+	clusters := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.ClusterIdentitiesTaskID.Ref())
+	filters := []string{}
+	for _, cluster := range clusters {
+		filters = append(filters, networkFlowLogsFilter(cluster))
+	}
+	return filters, nil
+}
+
+// TaskID implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
+func (n *networkFlowListLogEntriesTaskSetting) TaskID() taskid.TaskImplementationID[[]*log.Log] {
+	return googlecloudlognetworkflow_contract.ListLogEntriesTaskID
+}
+
+// TimePartitionCount implements googlecloudcommon_contract.ListLogEntriesTaskSetting.
+func (n *networkFlowListLogEntriesTaskSetting) TimePartitionCount(ctx context.Context) (int, error) {
+	return 10, nil
+}
+
+var _ googlecloudcommon_contract.ListLogEntriesTaskSetting = (*networkFlowListLogEntriesTaskSetting)(nil)
+
+var ListLogEntriesTask = googlecloudcommon_contract.NewListLogEntriesTask(&networkFlowListLogEntriesTaskSetting{})
@@ -0,0 +1,36 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlognetworkflow_impl
+
+import (
+	"testing"
+
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+)
+
+func TestNetworkFlowLogsFilter(t *testing.T) {
+	cluster := googlecloudk8scommon_contract.GoogleCloudClusterIdentity{
+		ProjectID:   "test-project",
+		Location:    "test-location",
+		ClusterName: "test-cluster",
+	}
+	want := `(LOG_ID("compute.googleapis.com/vpc_flows") AND ((jsonPayload.src_gke_details.cluster.cluster_name="test-cluster" AND jsonPayload.src_gke_details.cluster.cluster_location="test-location") OR (jsonPayload.dest_gke_details.cluster.cluster_name="test-cluster" AND jsonPayload.dest_gke_details.cluster.cluster_location="test-location")))
+OR (LOG_ID("policy-action") AND resource.type="k8s_node" AND resource.labels.cluster_name="test-cluster" AND resource.labels.location="test-location" AND jsonPayload.disposition="deny")`
+
+	got := networkFlowLogsFilter(cluster)
+	if got != want {
+		t.Errorf("networkFlowLogsFilter() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogpubsub_contract
+
+import (
+	"math"
+
+	coreinspection "github.com/kyasbal/khi/pkg/core/inspection"
+)
+
+// InspectionTypeId is the unique identifier for the inspection receiving logs from a Pub/Sub subscription of a Cloud Logging sink.
+var InspectionTypeId = "gcp-log-pubsub-stream"
+
+// LogPubSubStreamInspectionType defines the inspection type for the logs streamed through Pub/Sub by Cloud Logging sinks.
+var LogPubSubStreamInspectionType = coreinspection.InspectionType{
+	Id:   InspectionTypeId,
+	Name: "Kubernetes logs streamed from Pub/Sub",
+	Description: `Visualize Kubernetes logs received from a Pub/Sub subscription attached to the topic of a Cloud Logging sink.
+Logs are accumulated for the given streaming window to inspect an ongoing incident in near real time.
+Supporting K8s audit log and k8s event log.`,
+	Icon:     "assets/icons/gke.png",
+	Priority: math.MaxInt - 7,
+}
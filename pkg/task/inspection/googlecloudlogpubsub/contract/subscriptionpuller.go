@@ -0,0 +1,80 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogpubsub_contract
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	pubsub "google.golang.org/api/pubsub/v1"
+)
+
+// maxMessagesPerPull is the maximum number of messages received in a pull request.
+const maxMessagesPerPull = 1000
+
+// LogSubscriptionPuller receives the log entries published by Cloud Logging sinks from a Pub/Sub subscription.
+type LogSubscriptionPuller interface {
+	// Pull receives a batch of messages from the subscription and acknowledges them.
+	// It returns the message data, each of them is a JSON serialized LogEntry. It may return no message when nothing arrived before the server side timeout.
+	Pull(ctx context.Context, projectID string, subscriptionID string) ([][]byte, error)
+}
+
+type LogSubscriptionPullerImpl struct{}
+
+// Pull implements LogSubscriptionPuller.
+func (p *LogSubscriptionPullerImpl) Pull(ctx context.Context, projectID string, subscriptionID string) ([][]byte, error) {
+	cf := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientFactoryTaskID.Ref())
+	injector := coretask.GetTaskResult(ctx, googlecloudcommon_contract.APIClientCallOptionsInjectorTaskID.Ref())
+
+	pubsubClient, err := cf.PubSubService(ctx, googlecloud.Project(projectID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the pubsub api client:%v", err)
+	}
+	subscription := fmt.Sprintf("projects/%s/subscriptions/%s", projectID, subscriptionID)
+
+	pullReq := pubsubClient.Projects.Subscriptions.Pull(subscription, &pubsub.PullRequest{MaxMessages: maxMessagesPerPull}).Context(ctx)
+	injector.InjectToCall(pullReq, googlecloud.Project(projectID))
+	resp, err := pullReq.Do()
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.ReceivedMessages) == 0 {
+		return [][]byte{}, nil
+	}
+
+	result := make([][]byte, 0, len(resp.ReceivedMessages))
+	ackIDs := make([]string, 0, len(resp.ReceivedMessages))
+	for _, message := range resp.ReceivedMessages {
+		ackIDs = append(ackIDs, message.AckId)
+		data, err := base64.StdEncoding.DecodeString(message.Message.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode the message %s: %w", message.Message.MessageId, err)
+		}
+		result = append(result, data)
+	}
+
+	ackReq := pubsubClient.Projects.Subscriptions.Acknowledge(subscription, &pubsub.AcknowledgeRequest{AckIds: ackIDs}).Context(ctx)
+	injector.InjectToCall(ackReq, googlecloud.Project(projectID))
+	if _, err := ackReq.Do(); err != nil {
+		return nil, fmt.Errorf("failed to acknowledge the received messages: %w", err)
+	}
+	return result, nil
+}
+
+var _ LogSubscriptionPuller = (*LogSubscriptionPullerImpl)(nil)
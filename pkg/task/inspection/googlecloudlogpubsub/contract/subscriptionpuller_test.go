@@ -0,0 +1,129 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogpubsub_contract
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/api/googlecloud"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	"google.golang.org/api/option"
+)
+
+func TestLogSubscriptionPullerImplPull(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		messages   []map[string]any
+		pullStatus int
+		want       []string
+		wantAckIDs []string
+		wantErr    bool
+	}{
+		{
+			desc: "messages are decoded and acknowledged",
+			messages: []map[string]any{
+				{"ackId": "ack-1", "message": map[string]any{"messageId": "1", "data": base64.StdEncoding.EncodeToString([]byte(`{"insertId":"a"}`))}},
+				{"ackId": "ack-2", "message": map[string]any{"messageId": "2", "data": base64.StdEncoding.EncodeToString([]byte(`{"insertId":"b"}`))}},
+			},
+			want:       []string{`{"insertId":"a"}`, `{"insertId":"b"}`},
+			wantAckIDs: []string{"ack-1", "ack-2"},
+		},
+		{
+			desc: "no message arrived",
+			want: []string{},
+		},
+		{
+			desc: "message with invalid data isn't acknowledged",
+			messages: []map[string]any{
+				{"ackId": "ack-1", "message": map[string]any{"messageId": "1", "data": "!invalid base64!"}},
+			},
+			wantErr: true,
+		},
+		{
+			desc:       "pull request failed",
+			pullStatus: http.StatusForbidden,
+			wantErr:    true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var gotAckIDs []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/v1/projects/foo-project/subscriptions/foo-subscription:pull":
+					if tc.pullStatus != 0 {
+						w.WriteHeader(tc.pullStatus)
+						w.Write([]byte(`{"error":{"code":403,"message":"permission denied"}}`))
+						return
+					}
+					json.NewEncoder(w).Encode(map[string]any{"receivedMessages": tc.messages})
+				case "/v1/projects/foo-project/subscriptions/foo-subscription:acknowledge":
+					var req struct {
+						AckIDs []string `json:"ackIds"`
+					}
+					json.NewDecoder(r.Body).Decode(&req)
+					gotAckIDs = append(gotAckIDs, req.AckIDs...)
+					w.Write([]byte(`{}`))
+				default:
+					t.Errorf("unexpected request to %s", r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			factory, err := googlecloud.NewClientFactory(func(f *googlecloud.ClientFactory) error {
+				f.PubSubServiceOptions = append(f.PubSubServiceOptions, func(opts []option.ClientOption, _ googlecloud.ResourceContainer) ([]option.ClientOption, error) {
+					return append(opts, option.WithEndpoint(server.URL), option.WithoutAuthentication()), nil
+				})
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("failed to create client factory: %v", err)
+			}
+			ctx := tasktest.WithTaskResult(t.Context(), googlecloudcommon_contract.APIClientFactoryTaskID.Ref(), factory)
+			ctx = tasktest.WithTaskResult(ctx, googlecloudcommon_contract.APIClientCallOptionsInjectorTaskID.Ref(), googlecloud.NewCallOptionInjector())
+
+			got, err := (&LogSubscriptionPullerImpl{}).Pull(ctx, "foo-project", "foo-subscription")
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("Pull() returned no error, want an error")
+				}
+				if len(gotAckIDs) > 0 {
+					t.Errorf("Pull() acknowledged %v on an error, want no acknowledgement", gotAckIDs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Pull() returned an unexpected error: %v", err)
+			}
+			gotStrings := []string{}
+			for _, data := range got {
+				gotStrings = append(gotStrings, string(data))
+			}
+			if diff := cmp.Diff(tc.want, gotStrings); diff != "" {
+				t.Errorf("Pull() mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantAckIDs, gotAckIDs); diff != "" {
+				t.Errorf("acknowledged IDs mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogpubsub_contract
+
+import (
+	"time"
+
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	googlecloudlogk8saudit_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8saudit/contract"
+	googlecloudlogk8sevent_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8sevent/contract"
+)
+
+// TaskIDPrefix is the prefix for all task IDs in the googlecloudlogpubsub package.
+const TaskIDPrefix = "cloud.google.com/log-pubsub/"
+
+// InputSubscriptionIDTaskID is the task ID for the ID of the Pub/Sub subscription receiving logs from a Cloud Logging sink.
+var InputSubscriptionIDTaskID = taskid.NewDefaultImplementationID[string](TaskIDPrefix + "input-subscription-id")
+
+// InputStreamingWindowTaskID is the task ID for the duration to keep receiving logs from the subscription.
+var InputStreamingWindowTaskID = taskid.NewDefaultImplementationID[time.Duration](TaskIDPrefix + "input-streaming-window")
+
+// LogSubscriptionPullerTaskID is the task ID for injecting LogSubscriptionPuller instance.
+var LogSubscriptionPullerTaskID = taskid.NewDefaultImplementationID[LogSubscriptionPuller](TaskIDPrefix + "subscription-puller")
+
+// LogStreamTaskID is the task ID for the logs of any types received from the subscription in the streaming window.
+var LogStreamTaskID = taskid.NewDefaultImplementationID[[]*log.Log](TaskIDPrefix + "log-stream")
+
+// AutocompleteNamespacesTaskID is the task ID for overriding the namespace autocomplete not available for the streamed logs.
+var AutocompleteNamespacesTaskID = taskid.NewImplementationID(googlecloudk8scommon_contract.AutocompleteNamespacesTaskID.Ref(), "log-pubsub")
+
+// K8sAuditLogListLogEntriesTaskID is the task ID for picking k8s audit logs from the log stream instead of querying Cloud Logging.
+var K8sAuditLogListLogEntriesTaskID = taskid.NewImplementationID(googlecloudlogk8saudit_contract.GCPK8sAuditLogListLogEntriesTaskID.Ref(), "log-pubsub")
+
+// K8sEventLogListLogEntriesTaskID is the task ID for picking k8s event logs from the log stream instead of querying Cloud Logging.
+var K8sEventLogListLogEntriesTaskID = taskid.NewImplementationID(googlecloudlogk8sevent_contract.ListLogEntriesTaskID.Ref(), "log-pubsub")
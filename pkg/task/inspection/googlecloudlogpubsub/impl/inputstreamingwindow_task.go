@@ -0,0 +1,53 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogpubsub_impl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	googlecloudlogpubsub_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogpubsub/contract"
+)
+
+// maxStreamingWindow is the longest streaming window. KHI keeps the inspection running for the window.
+const maxStreamingWindow = time.Hour
+
+// InputStreamingWindowTask is a form task for inputting the duration to keep receiving logs from the subscription.
+var InputStreamingWindowTask = formtask.NewTextFormTaskBuilder(googlecloudlogpubsub_contract.InputStreamingWindowTaskID, 0, "Streaming window").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionQueryTime}).
+	WithDefaultValueConstant("5m", true).
+	WithDescription("The duration to keep receiving logs from the subscription after starting the inspection. The value must be parsable as Go's `time.Duration` like `10m` or `1h`.").
+	WithValidator(func(ctx context.Context, value string) (string, error) {
+		window, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return err.Error(), nil
+		}
+		if window <= 0 {
+			return "Streaming window must be positive", nil
+		}
+		if window > maxStreamingWindow {
+			return fmt.Sprintf("Streaming window must be %s or shorter", maxStreamingWindow), nil
+		}
+		return "", nil
+	}).
+	WithConverter(func(ctx context.Context, value string) (time.Duration, error) {
+		return time.ParseDuration(strings.TrimSpace(value))
+	}).
+	Build()
@@ -0,0 +1,37 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogpubsub_impl
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	googlecloudlogpubsub_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogpubsub/contract"
+)
+
+// InputSubscriptionIDTask is a form task for inputting the ID of the Pub/Sub subscription receiving logs from a Cloud Logging sink.
+var InputSubscriptionIDTask = formtask.NewTextFormTaskBuilder(googlecloudlogpubsub_contract.InputSubscriptionIDTaskID, 100, "Subscription ID").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionResourceIdentifier, After: []string{googlecloudcommon_contract.InputProjectIdTaskID.ReferenceIDString()}}).
+	WithPlaceholder("e.g. my-log-sink-subscription").
+	WithDescription("The ID of the Pub/Sub subscription in the project attached to the topic of the Cloud Logging sink. Received messages are acknowledged, use a subscription dedicated to KHI.").
+	WithRegexValidator(`[a-zA-Z][a-zA-Z0-9\-_.~+%]{2,254}`, "Subscription ID must start with a letter and consist of 3 to 255 letters, numbers or `-_.~+%`").
+	WithValidatingTiming(inspectionmetadata.Blur).
+	WithConverter(func(ctx context.Context, value string) (string, error) {
+		return strings.TrimSpace(value), nil
+	}).
+	Build()
@@ -0,0 +1,87 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogpubsub_impl
+
+import (
+	"context"
+	"strings"
+
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	googlecloudlogk8saudit_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8saudit/contract"
+	googlecloudlogk8sevent_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8sevent/contract"
+	googlecloudlogpubsub_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogpubsub/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// K8sAuditLogListLogEntriesTask picks k8s audit logs from the log stream in place of querying Cloud Logging.
+var K8sAuditLogListLogEntriesTask = newLogStreamListLogEntriesTask(
+	googlecloudlogpubsub_contract.K8sAuditLogListLogEntriesTaskID,
+	"cloudaudit.googleapis.com%2Factivity",
+	enum.LogTypeAudit,
+	[]taskid.UntypedTaskReference{
+		googlecloudk8scommon_contract.InputKindFilterTaskID.Ref(),
+		googlecloudk8scommon_contract.InputNamespaceFilterTaskID.Ref(),
+	},
+	func(ctx context.Context, l *log.Log) bool {
+		kindFilter := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.InputKindFilterTaskID.Ref())
+		namespaceFilter := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.InputNamespaceFilterTaskID.Ref())
+		return googlecloudlogk8saudit_contract.MatchK8sAuditLog(l, kindFilter, namespaceFilter)
+	},
+)
+
+// K8sEventLogListLogEntriesTask picks k8s event logs from the log stream in place of querying Cloud Logging.
+var K8sEventLogListLogEntriesTask = newLogStreamListLogEntriesTask(
+	googlecloudlogpubsub_contract.K8sEventLogListLogEntriesTaskID,
+	"events",
+	enum.LogTypeEvent,
+	[]taskid.UntypedTaskReference{
+		googlecloudk8scommon_contract.InputNamespaceFilterTaskID.Ref(),
+	},
+	func(ctx context.Context, l *log.Log) bool {
+		namespaceFilter := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.InputNamespaceFilterTaskID.Ref())
+		return googlecloudlogk8sevent_contract.MatchK8sEventLog(l, namespaceFilter)
+	},
+)
+
+// newLogStreamListLogEntriesTask returns a task picking the logs with the given URL encoded log ID from the log stream.
+// Logs not matching the logFilter are dropped.
+func newLogStreamListLogEntriesTask(taskID taskid.TaskImplementationID[[]*log.Log], logID string, logType enum.LogType, dependencies []taskid.UntypedTaskReference, logFilter func(ctx context.Context, l *log.Log) bool) coretask.Task[[]*log.Log] {
+	return inspectiontaskbase.NewInspectionTask(taskID, append([]taskid.UntypedTaskReference{
+		googlecloudlogpubsub_contract.LogStreamTaskID.Ref(),
+	}, dependencies...), func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) ([]*log.Log, error) {
+		if taskMode == inspectioncore_contract.TaskModeDryRun {
+			return []*log.Log{}, nil
+		}
+		stream := coretask.GetTaskResult(ctx, googlecloudlogpubsub_contract.LogStreamTaskID.Ref())
+
+		logs := []*log.Log{}
+		for _, l := range stream {
+			if !strings.HasSuffix(l.ReadStringOrDefault("logName", ""), "/logs/"+logID) {
+				continue
+			}
+			if !logFilter(ctx, l) {
+				continue
+			}
+			l.LogType = logType
+			logs = append(logs, l)
+		}
+		return logs, nil
+	}, coretask.WithSelectionPriority(1000), inspectioncore_contract.InspectionTypeLabel(googlecloudlogpubsub_contract.InspectionTypeId))
+}
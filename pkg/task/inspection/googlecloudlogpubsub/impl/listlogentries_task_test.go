@@ -0,0 +1,87 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogpubsub_impl
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/core/inspection/gcpqueryutil"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
+	googlecloudlogpubsub_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogpubsub/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+func TestK8sAuditLogListLogEntriesTask(t *testing.T) {
+	stream := []*log.Log{}
+	for _, yaml := range []string{
+		`insertId: pod-create
+logName: projects/foo-project/logs/cloudaudit.googleapis.com%2Factivity
+resource:
+  type: k8s_cluster
+protoPayload:
+  methodName: io.k8s.core.v1.pods.create
+  resourceName: core/v1/namespaces/default/pods/foo`,
+		`insertId: instance-insert
+logName: projects/foo-project/logs/cloudaudit.googleapis.com%2Factivity
+resource:
+  type: gce_instance
+protoPayload:
+  methodName: v1.compute.instances.insert`,
+		`insertId: event
+logName: projects/foo-project/logs/events
+jsonPayload:
+  involvedObject:
+    namespace: default`,
+		`insertId: other-namespace
+logName: projects/foo-project/logs/cloudaudit.googleapis.com%2Factivity
+resource:
+  type: k8s_cluster
+protoPayload:
+  methodName: io.k8s.core.v1.pods.delete
+  resourceName: core/v1/namespaces/kube-system/pods/bar`,
+	} {
+		l, err := log.NewLogFromYAMLString(yaml)
+		if err != nil {
+			t.Fatalf("failed to parse the test log: %v", err)
+		}
+		stream = append(stream, l)
+	}
+
+	ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+	got, _, err := inspectiontest.RunInspectionTask(ctx, K8sAuditLogListLogEntriesTask, inspectioncore_contract.TaskModeRun, map[string]any{},
+		tasktest.NewTaskDependencyValuePair(googlecloudlogpubsub_contract.LogStreamTaskID.Ref(), stream),
+		tasktest.NewTaskDependencyValuePair(googlecloudk8scommon_contract.InputKindFilterTaskID.Ref(), &gcpqueryutil.SetFilterParseResult{SubtractMode: true}),
+		tasktest.NewTaskDependencyValuePair(googlecloudk8scommon_contract.InputNamespaceFilterTaskID.Ref(), &gcpqueryutil.SetFilterParseResult{Additives: []string{"default"}}),
+	)
+	if err != nil {
+		t.Fatalf("RunInspectionTask() returned an unexpected error: %v", err)
+	}
+
+	gotInsertIDs := []string{}
+	for _, l := range got {
+		if l.LogType != enum.LogTypeAudit {
+			t.Errorf("log %s has log type %v, want %v", l.ReadStringOrDefault("insertId", ""), l.LogType, enum.LogTypeAudit)
+		}
+		gotInsertIDs = append(gotInsertIDs, l.ReadStringOrDefault("insertId", ""))
+	}
+	if diff := cmp.Diff([]string{"pod-create"}, gotInsertIDs); diff != "" {
+		t.Errorf("K8sAuditLogListLogEntriesTask returned unexpected logs (-want +got):\n%s", diff)
+	}
+}
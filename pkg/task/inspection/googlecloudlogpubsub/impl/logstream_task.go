@@ -0,0 +1,118 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogpubsub_impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	"github.com/kyasbal/khi/pkg/core/inspection/gcpqueryutil"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	googlecloudlogpubsub_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogpubsub/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// LogStreamTask keeps receiving logs from the subscription until the streaming window elapses.
+// The received logs are shared by the tasks picking logs of each log type because a message can be received only once from the subscription.
+var LogStreamTask = inspectiontaskbase.NewProgressReportableInspectionTask(googlecloudlogpubsub_contract.LogStreamTaskID, []taskid.UntypedTaskReference{
+	googlecloudcommon_contract.InputProjectIdTaskID.Ref(),
+	googlecloudlogpubsub_contract.InputSubscriptionIDTaskID.Ref(),
+	googlecloudlogpubsub_contract.InputStreamingWindowTaskID.Ref(),
+	googlecloudlogpubsub_contract.LogSubscriptionPullerTaskID.Ref(),
+}, func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, progress *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, error) {
+	if taskMode == inspectioncore_contract.TaskModeDryRun {
+		return []*log.Log{}, nil
+	}
+	projectID := coretask.GetTaskResult(ctx, googlecloudcommon_contract.InputProjectIdTaskID.Ref())
+	subscriptionID := coretask.GetTaskResult(ctx, googlecloudlogpubsub_contract.InputSubscriptionIDTaskID.Ref())
+	window := coretask.GetTaskResult(ctx, googlecloudlogpubsub_contract.InputStreamingWindowTaskID.Ref())
+	puller := coretask.GetTaskResult(ctx, googlecloudlogpubsub_contract.LogSubscriptionPullerTaskID.Ref())
+
+	startTime := time.Now()
+	endTime := startTime.Add(window)
+	streamCtx, cancel := context.WithDeadline(ctx, endTime)
+	defer cancel()
+
+	logs := []*log.Log{}
+	// Pub/Sub delivers a message at least once. Logs received twice are ignored with their insertId.
+	receivedInsertIDs := map[string]struct{}{}
+	for streamCtx.Err() == nil {
+		messages, err := puller.Pull(streamCtx, projectID, subscriptionID)
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+				break
+			}
+			return nil, fmt.Errorf("failed to receive logs from the subscription %s: %w", subscriptionID, err)
+		}
+		for _, message := range messages {
+			l, err := log.NewLogFromYAMLString(string(message))
+			if err != nil {
+				slog.WarnContext(ctx, fmt.Sprintf("failed to parse a log received from the subscription %s: %v", subscriptionID, err))
+				continue
+			}
+			// GCPCommonFieldSet is always required for any logs exported from Cloud Logging as well as the logs queried from Cloud Logging.
+			err = l.SetFieldSetReader(&gcpqueryutil.GCPCommonFieldSetReader{})
+			if err != nil {
+				slog.WarnContext(ctx, fmt.Sprintf("failed to read the common fields of a log received from the subscription %s: %v", subscriptionID, err))
+				continue
+			}
+			if log.MustGetFieldSet(l, &log.CommonFieldSet{}).Timestamp.IsZero() {
+				slog.WarnContext(ctx, fmt.Sprintf("ignoring a message without timestamp received from the subscription %s", subscriptionID))
+				continue
+			}
+			insertID := l.ReadStringOrDefault("insertId", "")
+			if insertID != "" {
+				if _, found := receivedInsertIDs[insertID]; found {
+					continue
+				}
+				receivedInsertIDs[insertID] = struct{}{}
+			}
+			logs = append(logs, l)
+		}
+		elapsed := time.Since(startTime)
+		progress.Update(min(float32(elapsed)/float32(window), 1), fmt.Sprintf("%d logs received (%s/%s)", len(logs), elapsed.Truncate(time.Second), window))
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	slices.SortStableFunc(logs, func(a, b *log.Log) int {
+		return log.MustGetFieldSet(a, &log.CommonFieldSet{}).Timestamp.Compare(log.MustGetFieldSet(b, &log.CommonFieldSet{}).Timestamp)
+	})
+
+	// Logs are delivered with a delay from their timestamps. The time range on the header covers both of the streaming window and the received logs.
+	if len(logs) > 0 {
+		firstLogTime := log.MustGetFieldSet(logs[0], &log.CommonFieldSet{}).Timestamp
+		if firstLogTime.Before(startTime) {
+			startTime = firstLogTime
+		}
+	}
+	metadataSet := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
+	header := typedmap.GetOrDefault(metadataSet, inspectionmetadata.HeaderMetadataKey, &inspectionmetadata.HeaderMetadata{})
+	header.SetStartTime(startTime)
+	header.SetEndTime(endTime)
+	return logs, nil
+})
@@ -0,0 +1,84 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudlogpubsub_impl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	googlecloudlogpubsub_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogpubsub/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// mockLogSubscriptionPuller returns the batches in order and blocks until the context is done after returning all of them.
+type mockLogSubscriptionPuller struct {
+	batches [][]string
+}
+
+// Pull implements googlecloudlogpubsub_contract.LogSubscriptionPuller.
+func (m *mockLogSubscriptionPuller) Pull(ctx context.Context, projectID string, subscriptionID string) ([][]byte, error) {
+	if len(m.batches) == 0 {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	batch := m.batches[0]
+	m.batches = m.batches[1:]
+	result := [][]byte{}
+	for _, message := range batch {
+		result = append(result, []byte(message))
+	}
+	return result, nil
+}
+
+var _ googlecloudlogpubsub_contract.LogSubscriptionPuller = (*mockLogSubscriptionPuller)(nil)
+
+func TestLogStreamTask(t *testing.T) {
+	puller := &mockLogSubscriptionPuller{
+		batches: [][]string{
+			{
+				`{"insertId":"second","timestamp":"2024-01-02T03:20:00Z","logName":"projects/foo-project/logs/events"}`,
+				`not a log`,
+			},
+			{
+				`{"insertId":"first","timestamp":"2024-01-02T03:10:00Z","logName":"projects/foo-project/logs/events"}`,
+				`{"insertId":"second","timestamp":"2024-01-02T03:20:00Z","logName":"projects/foo-project/logs/events"}`,
+			},
+		},
+	}
+	ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+	got, _, err := inspectiontest.RunInspectionTask(ctx, LogStreamTask, inspectioncore_contract.TaskModeRun, map[string]any{},
+		tasktest.NewTaskDependencyValuePair(googlecloudcommon_contract.InputProjectIdTaskID.Ref(), "foo-project"),
+		tasktest.NewTaskDependencyValuePair(googlecloudlogpubsub_contract.InputSubscriptionIDTaskID.Ref(), "foo-subscription"),
+		tasktest.NewTaskDependencyValuePair(googlecloudlogpubsub_contract.InputStreamingWindowTaskID.Ref(), 100*time.Millisecond),
+		tasktest.NewTaskDependencyValuePair[googlecloudlogpubsub_contract.LogSubscriptionPuller](googlecloudlogpubsub_contract.LogSubscriptionPullerTaskID.Ref(), puller),
+	)
+	if err != nil {
+		t.Fatalf("RunInspectionTask() returned an unexpected error: %v", err)
+	}
+
+	gotInsertIDs := []string{}
+	for _, l := range got {
+		gotInsertIDs = append(gotInsertIDs, l.ReadStringOrDefault("insertId", ""))
+	}
+	wantInsertIDs := []string{"first", "second"}
+	if diff := cmp.Diff(wantInsertIDs, gotInsertIDs); diff != "" {
+		t.Errorf("LogStreamTask returned unexpected logs (-want +got):\n%s", diff)
+	}
+}
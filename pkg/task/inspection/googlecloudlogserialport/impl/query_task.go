@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/kyasbal/khi/pkg/common/khictx"
 	"github.com/kyasbal/khi/pkg/core/inspection/gcpqueryutil"
 	coretask "github.com/kyasbal/khi/pkg/core/task"
 	"github.com/kyasbal/khi/pkg/core/task/taskid"
@@ -33,17 +34,17 @@ import (
 
 const MaxNodesPerQuery = 30
 
-func GenerateSerialPortQuery(taskMode inspectioncore_contract.InspectionTaskModeType, foundNodeNames []string, nodeNameSubstrings []string) []string {
+func GenerateSerialPortQuery(taskMode inspectioncore_contract.InspectionTaskModeType, foundNodeNames []string, nodeNameSubstrings []string, nodePoolNodeNameSubstrings []string) []string {
 	if taskMode == inspectioncore_contract.TaskModeDryRun {
 		return []string{
-			generateSerialPortQueryWithInstanceNameFilter("-- instance name filters to be determined after audit log query", generateNodeNameSubstringLogFilter(nodeNameSubstrings)),
+			generateSerialPortQueryWithInstanceNameFilter("-- instance name filters to be determined after audit log query", generateNodeNameSubstringLogFilter(nodeNameSubstrings), generateNodePoolLogFilter(nodePoolNodeNameSubstrings)),
 		}
 	} else {
 		result := []string{}
 		instanceNameGroups := gcpqueryutil.SplitToChildGroups(foundNodeNames, MaxNodesPerQuery)
 		for _, group := range instanceNameGroups {
 			instanceNameFilter := fmt.Sprintf(`labels."compute.googleapis.com/resource_name"=(%s)`, strings.Join(gcpqueryutil.WrapDoubleQuoteForStringArray(group), " OR "))
-			result = append(result, generateSerialPortQueryWithInstanceNameFilter(instanceNameFilter, generateNodeNameSubstringLogFilter(nodeNameSubstrings)))
+			result = append(result, generateSerialPortQueryWithInstanceNameFilter(instanceNameFilter, generateNodeNameSubstringLogFilter(nodeNameSubstrings), generateNodePoolLogFilter(nodePoolNodeNameSubstrings)))
 		}
 		return result
 	}
@@ -57,7 +58,14 @@ func generateNodeNameSubstringLogFilter(nodeNameSubstrings []string) string {
 	}
 }
 
-func generateSerialPortQueryWithInstanceNameFilter(instanceNameFilter string, nodeNameSubstringFilter string) string {
+func generateNodePoolLogFilter(nodePoolNodeNameSubstrings []string) string {
+	if len(nodePoolNodeNameSubstrings) == 0 {
+		return "-- No node pool filters are specified."
+	}
+	return fmt.Sprintf(`labels."compute.googleapis.com/resource_name":(%s)`, strings.Join(gcpqueryutil.WrapDoubleQuoteForStringArray(nodePoolNodeNameSubstrings), " OR "))
+}
+
+func generateSerialPortQueryWithInstanceNameFilter(instanceNameFilter string, nodeNameSubstringFilter string, nodePoolFilter string) string {
 	return fmt.Sprintf(`LOG_ID("serialconsole.googleapis.com%%2Fserial_port_1_output") OR
 LOG_ID("serialconsole.googleapis.com%%2Fserial_port_2_output") OR
 LOG_ID("serialconsole.googleapis.com%%2Fserial_port_3_output") OR
@@ -65,10 +73,45 @@ LOG_ID("serialconsole.googleapis.com%%2Fserial_port_debug_output")
 
 %s
 
-%s`, instanceNameFilter, nodeNameSubstringFilter)
+%s
+
+%s`, instanceNameFilter, nodeNameSubstringFilter, nodePoolFilter)
+}
+
+// skipWhenNoNodeMatched is a coretask.SkipPredicate skipping the serial port log query in the run mode when no node found in the audit logs matches the node name and node pool filters.
+// The query can't be skipped in the dry run mode because the node names are not known before querying audit logs.
+func skipWhenNoNodeMatched(ctx context.Context) (bool, error) {
+	taskMode, err := khictx.GetValue(ctx, inspectioncore_contract.InspectionTaskMode)
+	if err != nil || taskMode != inspectioncore_contract.TaskModeRun {
+		return false, nil
+	}
+	nodeNames := coretask.GetTaskResult(ctx, commonlogk8sauditv2_contract.NodeNameInventoryTaskID.Ref())
+	nodeNameSubstrings := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.InputNodeNameFilterTaskID.Ref())
+	nodePoolNodeNameSubstrings := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.NodePoolNodeNameSubstringsTaskID.Ref())
+	for _, nodeName := range nodeNames {
+		if matchesAnySubstring(nodeName, nodeNameSubstrings) && matchesAnySubstring(nodeName, nodePoolNodeNameSubstrings) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// matchesAnySubstring returns true when the value contains any of the substrings case insensitively like the `:` operator of Cloud Logging filters.
+// An empty substring list matches any value.
+func matchesAnySubstring(value string, substrings []string) bool {
+	if len(substrings) == 0 {
+		return true
+	}
+	value = strings.ToLower(value)
+	for _, substring := range substrings {
+		if strings.Contains(value, strings.ToLower(substring)) {
+			return true
+		}
+	}
+	return false
 }
 
-var LogQueryTask = googlecloudcommon_contract.NewListLogEntriesTask(&serialPortLoggingFilterTaskSetting{})
+var LogQueryTask = googlecloudcommon_contract.NewListLogEntriesTask(&serialPortLoggingFilterTaskSetting{}, coretask.WithSkipPredicate(skipWhenNoNodeMatched))
 
 type serialPortLoggingFilterTaskSetting struct {
 }
@@ -78,6 +121,7 @@ func (s *serialPortLoggingFilterTaskSetting) Dependencies() []taskid.UntypedTask
 	return []taskid.UntypedTaskReference{
 		googlecloudlogserialport_contract.ClusterIdentityTaskID.Ref(),
 		googlecloudk8scommon_contract.InputNodeNameFilterTaskID.Ref(),
+		googlecloudk8scommon_contract.NodePoolNodeNameSubstringsTaskID.Ref(),
 		commonlogk8sauditv2_contract.NodeNameInventoryTaskID.Ref(),
 	}
 }
@@ -90,7 +134,7 @@ func (s *serialPortLoggingFilterTaskSetting) Description() *googlecloudcommon_co
 		ExampleQuery: GenerateSerialPortQuery(inspectioncore_contract.TaskModeRun, []string{
 			"gke-test-cluster-node-1",
 			"gke-test-cluster-node-2",
-		}, []string{})[0],
+		}, []string{}, []string{})[0],
 	}
 }
 
@@ -98,7 +142,8 @@ func (s *serialPortLoggingFilterTaskSetting) Description() *googlecloudcommon_co
 func (s *serialPortLoggingFilterTaskSetting) LogFilters(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) ([]string, error) {
 	nodeNames := coretask.GetTaskResult(ctx, commonlogk8sauditv2_contract.NodeNameInventoryTaskID.Ref())
 	nodeNameSubstrings := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.InputNodeNameFilterTaskID.Ref())
-	return GenerateSerialPortQuery(taskMode, nodeNames, nodeNameSubstrings), nil
+	nodePoolNodeNameSubstrings := coretask.GetTaskResult(ctx, googlecloudk8scommon_contract.NodePoolNodeNameSubstringsTaskID.Ref())
+	return GenerateSerialPortQuery(taskMode, nodeNames, nodeNameSubstrings, nodePoolNodeNameSubstrings), nil
 }
 
 // DefaultResourceNames implements googlecloudcommon_contract.CloudLoggingFilterTaskSetting.
@@ -15,11 +15,18 @@
 package googlecloudlogserialport_impl
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/kyasbal/khi/pkg/common/idgenerator"
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	commonlogk8sauditv2_contract "github.com/kyasbal/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	googlecloudk8scommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudk8scommon/contract"
 	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
 	gcp_test "github.com/kyasbal/khi/pkg/testutil/gcp"
 )
@@ -30,6 +37,7 @@ func TestGenerateSerialPortQuery(t *testing.T) {
 		taskMode           inspectioncore_contract.InspectionTaskModeType
 		nodeNames          []string
 		nodeNameSubstrings []string
+		nodePoolFilters    []string
 		wantQuery          string
 	}{
 		{
@@ -44,7 +52,9 @@ LOG_ID("serialconsole.googleapis.com%2Fserial_port_debug_output")
 
 -- instance name filters to be determined after audit log query
 
--- No node name substring filters are specified.`,
+-- No node name substring filters are specified.
+
+-- No node pool filters are specified.`,
 		},
 		{
 			name:               "with single node",
@@ -58,7 +68,9 @@ LOG_ID("serialconsole.googleapis.com%2Fserial_port_debug_output")
 
 labels."compute.googleapis.com/resource_name"=("node-1")
 
--- No node name substring filters are specified.`,
+-- No node name substring filters are specified.
+
+-- No node pool filters are specified.`,
 		},
 		{
 			name:               "with multiple nodes",
@@ -72,7 +84,9 @@ LOG_ID("serialconsole.googleapis.com%2Fserial_port_debug_output")
 
 labels."compute.googleapis.com/resource_name"=("node-1" OR "node-2" OR "node-3")
 
--- No node name substring filters are specified.`,
+-- No node name substring filters are specified.
+
+-- No node pool filters are specified.`,
 		},
 		{
 			name:               "with node name substring",
@@ -86,13 +100,32 @@ LOG_ID("serialconsole.googleapis.com%2Fserial_port_debug_output")
 
 labels."compute.googleapis.com/resource_name"=("node-1" OR "node-2" OR "node-3")
 
-labels."compute.googleapis.com/resource_name":("node-1")`,
+labels."compute.googleapis.com/resource_name":("node-1")
+
+-- No node pool filters are specified.`,
+		},
+		{
+			name:               "with node pool filter",
+			taskMode:           inspectioncore_contract.TaskModeRun,
+			nodeNames:          []string{"node-1", "node-2", "node-3"},
+			nodeNameSubstrings: []string{},
+			nodePoolFilters:    []string{"gke-cluster-pool-1-a1b2c3d4-", "-pool-2-"},
+			wantQuery: `LOG_ID("serialconsole.googleapis.com%2Fserial_port_1_output") OR
+LOG_ID("serialconsole.googleapis.com%2Fserial_port_2_output") OR
+LOG_ID("serialconsole.googleapis.com%2Fserial_port_3_output") OR
+LOG_ID("serialconsole.googleapis.com%2Fserial_port_debug_output")
+
+labels."compute.googleapis.com/resource_name"=("node-1" OR "node-2" OR "node-3")
+
+-- No node name substring filters are specified.
+
+labels."compute.googleapis.com/resource_name":("gke-cluster-pool-1-a1b2c3d4-" OR "-pool-2-")`,
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			query := GenerateSerialPortQuery(tc.taskMode, tc.nodeNames, tc.nodeNameSubstrings)
+			query := GenerateSerialPortQuery(tc.taskMode, tc.nodeNames, tc.nodeNameSubstrings, tc.nodePoolFilters)
 			if diff := cmp.Diff(tc.wantQuery, query[0]); diff != "" {
 				t.Errorf("the generated query is not matching with the expected query\n%s", diff)
 			}
@@ -112,7 +145,7 @@ func TestMaximumNodeCountNotHittingQueryLengthLimit(t *testing.T) {
 	for i := 0; i < MaxNodesPerQuery*2+1; i++ { // This query must be splitted with 3 sub groups.
 		nodeNames = append(nodeNames, fmt.Sprintf(`gke-%s-%s-%s`, idg46.Generate(), idg8.Generate(), idg4.Generate()))
 	}
-	query := GenerateSerialPortQuery(inspectioncore_contract.TaskModeRun, nodeNames, []string{})
+	query := GenerateSerialPortQuery(inspectioncore_contract.TaskModeRun, nodeNames, []string{}, []string{})
 	if len(query) != 3 {
 		t.Errorf("len(GenerateSerialPortQuery())=%d, want %d", len(query), 3)
 	}
@@ -155,3 +188,76 @@ func Test_generateNodeNameSubstringLogFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestLogQueryTaskSkipPredicate(t *testing.T) {
+	testCases := []struct {
+		name                       string
+		taskMode                   inspectioncore_contract.InspectionTaskModeType
+		nodeNames                  []string
+		nodeNameSubstrings         []string
+		nodePoolNodeNameSubstrings []string
+		wantSkip                   bool
+	}{
+		{
+			name:      "dryrun is never skipped",
+			taskMode:  inspectioncore_contract.TaskModeDryRun,
+			nodeNames: []string{},
+			wantSkip:  false,
+		},
+		{
+			name:      "no node found in audit logs",
+			taskMode:  inspectioncore_contract.TaskModeRun,
+			nodeNames: []string{},
+			wantSkip:  true,
+		},
+		{
+			name:      "nodes found without filters",
+			taskMode:  inspectioncore_contract.TaskModeRun,
+			nodeNames: []string{"gke-cluster-pool-1-abcd-0001"},
+			wantSkip:  false,
+		},
+		{
+			name:               "node name filter matches no node",
+			taskMode:           inspectioncore_contract.TaskModeRun,
+			nodeNames:          []string{"gke-cluster-pool-1-abcd-0001"},
+			nodeNameSubstrings: []string{"pool-2"},
+			wantSkip:           true,
+		},
+		{
+			name:                       "node name filter and node pool filter match the same node",
+			taskMode:                   inspectioncore_contract.TaskModeRun,
+			nodeNames:                  []string{"gke-cluster-pool-1-abcd-0001", "gke-cluster-pool-2-efgh-0001"},
+			nodeNameSubstrings:         []string{"0001"},
+			nodePoolNodeNameSubstrings: []string{"-POOL-2-"},
+			wantSkip:                   false,
+		},
+		{
+			name:                       "node name filter and node pool filter match different nodes",
+			taskMode:                   inspectioncore_contract.TaskModeRun,
+			nodeNames:                  []string{"gke-cluster-pool-1-abcd-0001", "gke-cluster-pool-2-efgh-0002"},
+			nodeNameSubstrings:         []string{"0001"},
+			nodePoolNodeNameSubstrings: []string{"-pool-2-"},
+			wantSkip:                   true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			predicate := typedmap.GetOrDefault[coretask.SkipPredicate](LogQueryTask.Labels(), coretask.LabelKeyTaskSkipPredicate, nil)
+			if predicate == nil {
+				t.Fatalf("LogQueryTask must have a skip predicate")
+			}
+			ctx := khictx.WithValue(context.Background(), inspectioncore_contract.InspectionTaskMode, tc.taskMode)
+			ctx = tasktest.WithTaskResult(ctx, commonlogk8sauditv2_contract.NodeNameInventoryTaskID.Ref(), tc.nodeNames)
+			ctx = tasktest.WithTaskResult(ctx, googlecloudk8scommon_contract.InputNodeNameFilterTaskID.Ref(), tc.nodeNameSubstrings)
+			ctx = tasktest.WithTaskResult(ctx, googlecloudk8scommon_contract.NodePoolNodeNameSubstringsTaskID.Ref(), tc.nodePoolNodeNameSubstrings)
+
+			gotSkip, err := predicate(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if gotSkip != tc.wantSkip {
+				t.Errorf("skip = %v, want %v", gotSkip, tc.wantSkip)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafanalokik8s_contract
+
+import (
+	"encoding/json"
+
+	"github.com/kyasbal/khi/pkg/common/structured"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudlogk8scontainer_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8scontainer/contract"
+)
+
+// Label names are given in the order of priority. The first ones are attached by the Kubernetes service discovery of Promtail and Grafana Alloy,
+// and the others are attached by the OTLP ingestion of Loki.
+var (
+	namespaceLabelNames     = []string{"namespace", "k8s_namespace_name"}
+	podLabelNames           = []string{"pod", "k8s_pod_name"}
+	containerNameLabelNames = []string{"container", "k8s_container_name"}
+)
+
+// structuredContainerLogMessageFieldNames are the fields read as the message from container logs written in JSON.
+var structuredContainerLogMessageFieldNames = []string{
+	"message",
+	"msg",
+	"MESSAGE",
+	"log",
+}
+
+// LokiContainerLogFieldSetReader implements log.FieldSetReader for googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet{} from the stream labels of container logs.
+type LokiContainerLogFieldSetReader struct{}
+
+// FieldSetKind implements log.FieldSetReader.
+func (l *LokiContainerLogFieldSetReader) FieldSetKind() string {
+	return (&googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet{}).Kind()
+}
+
+// Read implements log.FieldSetReader.
+func (l *LokiContainerLogFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	var result googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet
+	result.Namespace = readFirstLabelOrDefault(reader, namespaceLabelNames, "unknown")
+	result.PodName = readFirstLabelOrDefault(reader, podLabelNames, "unknown")
+	result.ContainerName = readFirstLabelOrDefault(reader, containerNameLabelNames, "unknown")
+	for _, fieldName := range structuredContainerLogMessageFieldNames {
+		message, err := reader.ReadString(fieldName)
+		if err == nil {
+			result.Message = message
+			return &result, nil
+		}
+	}
+	// The line is a JSON object without any known message field. Show the whole object except the Loki metadata.
+	body := map[string]json.RawMessage{}
+	for key, child := range reader.Children() {
+		if key.Key == lokiEntryField {
+			continue
+		}
+		serializedChild, err := child.Serialize("", &structured.JSONNodeSerializer{})
+		if err != nil {
+			return nil, err
+		}
+		body[key.Key] = serializedChild
+	}
+	serialized, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	result.Message = string(serialized)
+	return &result, nil
+}
+
+var _ log.FieldSetReader = (*LokiContainerLogFieldSetReader)(nil)
+
+// readFirstLabelOrDefault returns the value of the first label found in the given label names.
+func readFirstLabelOrDefault(reader *structured.NodeReader, labelNames []string, defaultValue string) string {
+	for _, labelName := range labelNames {
+		if value := reader.ReadStringOrDefault(lokiEntryField+".labels."+labelName, ""); value != "" {
+			return value
+		}
+	}
+	return defaultValue
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafanalokik8s_contract
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/api/loki"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudlogk8scontainer_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8scontainer/contract"
+)
+
+func TestLokiContainerLogFieldSetReader(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		labels map[string]string
+		line   string
+		want   *googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet
+	}{
+		{
+			desc:   "labels from the kubernetes service discovery",
+			labels: map[string]string{"namespace": "default", "pod": "nginx", "container": "server"},
+			line:   "listening on :8080",
+			want: &googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet{
+				Namespace: "default", PodName: "nginx", ContainerName: "server", Message: "listening on :8080",
+			},
+		},
+		{
+			desc:   "labels from the OTLP ingestion",
+			labels: map[string]string{"k8s_namespace_name": "default", "k8s_pod_name": "nginx", "k8s_container_name": "server"},
+			line:   `{"level":"info","msg":"listening on :8080"}`,
+			want: &googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet{
+				Namespace: "default", PodName: "nginx", ContainerName: "server", Message: "listening on :8080",
+			},
+		},
+		{
+			desc:   "json line without a known message field and missing labels",
+			labels: map[string]string{"namespace": "default"},
+			line:   `{"level":"info","port":8080}`,
+			want: &googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet{
+				Namespace: "default", PodName: "unknown", ContainerName: "unknown", Message: `{"level":"info","port":8080}`,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l, err := NewLogFromStreamEntry(&StreamEntry{
+				Labels: tc.labels,
+				Entry:  &loki.Entry{Timestamp: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Line: tc.line},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = l.SetFieldSetReader(&LokiContainerLogFieldSetReader{})
+			if err != nil {
+				t.Fatalf("SetFieldSetReader() returned an unexpected error: %v", err)
+			}
+			got := log.MustGetFieldSet(l, &googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet{})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("K8sContainerLogFieldSet mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafanalokik8s_contract
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/api/loki"
+	"github.com/kyasbal/khi/pkg/common/structured"
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+// lokiEntryField is the field added on each log to hold the labels and the metadata of the Loki entry.
+const lokiEntryField = "loki"
+
+// QueryPageSize is the count of entries requested in a query_range request.
+// This is the default value of `max_entries_limit_per_query` of Loki.
+const QueryPageSize = 5000
+
+// StreamEntry is an entry with the labels of the stream containing it.
+type StreamEntry struct {
+	Labels map[string]string
+	Entry  *loki.Entry
+}
+
+// key returns the string identifying the entry used to remove duplicated entries returned across pages.
+func (s *StreamEntry) key() string {
+	var builder strings.Builder
+	for _, name := range slices.Sorted(maps.Keys(s.Labels)) {
+		fmt.Fprintf(&builder, "%s=%q,", name, s.Labels[name])
+	}
+	fmt.Fprintf(&builder, "%d,%s", s.Entry.Timestamp.UnixNano(), s.Entry.Line)
+	return builder.String()
+}
+
+// QueryAllEntries calls the query_range API in the forward direction until all the entries in the time range are read.
+// Each page begins at the last timestamp of the previous page, and entries already read at that timestamp are skipped.
+// onPage is called with the count of entries read so far after reading each page.
+func QueryAllEntries(ctx context.Context, api loki.LokiAPI, query string, startTime, endTime time.Time, onPage func(readCount int)) ([]*StreamEntry, error) {
+	var result []*StreamEntry
+	boundaryKeys := map[string]struct{}{}
+	pageStart := startTime
+	for {
+		output, err := api.QueryRange(ctx, &loki.QueryRangeInput{
+			Query:     query,
+			Start:     pageStart,
+			End:       endTime,
+			Limit:     QueryPageSize,
+			Direction: loki.DirectionForward,
+		})
+		if err != nil {
+			return nil, err
+		}
+		lastTimestamp := pageStart
+		var pageEntries []*StreamEntry
+		for _, stream := range output.Streams {
+			for _, entry := range stream.Entries {
+				streamEntry := &StreamEntry{Labels: stream.Labels, Entry: entry}
+				if _, found := boundaryKeys[streamEntry.key()]; found {
+					continue
+				}
+				pageEntries = append(pageEntries, streamEntry)
+				if entry.Timestamp.After(lastTimestamp) {
+					lastTimestamp = entry.Timestamp
+				}
+			}
+		}
+		result = append(result, pageEntries...)
+		if onPage != nil {
+			onPage(len(result))
+		}
+		if output.EntryCount() < QueryPageSize {
+			return result, nil
+		}
+
+		if lastTimestamp.Equal(pageStart) {
+			// All the entries in the page share the same timestamp. Skip the timestamp to avoid receiving the same page forever.
+			slog.WarnContext(ctx, "more entries than the page size were found at the same timestamp. Some entries may be missing", "query", query, "timestamp", pageStart)
+			pageStart = pageStart.Add(time.Nanosecond)
+			boundaryKeys = map[string]struct{}{}
+			continue
+		}
+		boundaryKeys = map[string]struct{}{}
+		for _, entry := range pageEntries {
+			if entry.Entry.Timestamp.Equal(lastTimestamp) {
+				boundaryKeys[entry.key()] = struct{}{}
+			}
+		}
+		pageStart = lastTimestamp
+	}
+}
+
+// NewLogFromStreamEntry converts an entry read from Loki to a log.
+// A line given in a JSON object (e.g. audit logs or structured container logs) is used as the log body. Other lines are stored in the `message` field.
+// The labels, the structured metadata and the timestamp of the entry are stored in the `loki` field.
+func NewLogFromStreamEntry(entry *StreamEntry) (*log.Log, error) {
+	body := map[string]any{}
+	if err := json.Unmarshal([]byte(entry.Entry.Line), &body); err != nil {
+		body = map[string]any{
+			"message": entry.Entry.Line,
+		}
+	}
+	hash := fnv.New64a()
+	hash.Write([]byte(entry.key()))
+	metadata := map[string]any{
+		"id":        fmt.Sprintf("%016x", hash.Sum64()),
+		"labels":    entry.Labels,
+		"timestamp": entry.Entry.Timestamp.UTC().Format(time.RFC3339Nano),
+	}
+	if entry.Entry.StructuredMetadata != nil {
+		metadata["structuredMetadata"] = entry.Entry.StructuredMetadata
+	}
+	body[lokiEntryField] = metadata
+	serialized, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize the entry at %s: %w", entry.Entry.Timestamp, err)
+	}
+	return log.NewLogFromYAMLString(string(serialized))
+}
+
+// LokiEntryCommonFieldSetReader implements log.FieldSetReader for log.CommonFieldSet{} from the metadata of the Loki entry.
+type LokiEntryCommonFieldSetReader struct{}
+
+// FieldSetKind implements log.FieldSetReader.
+func (l *LokiEntryCommonFieldSetReader) FieldSetKind() string {
+	return (&log.CommonFieldSet{}).Kind()
+}
+
+// Read implements log.FieldSetReader.
+func (l *LokiEntryCommonFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	result := &log.CommonFieldSet{}
+	result.DisplayID = reader.ReadStringOrDefault(lokiEntryField+".id", "unknown")
+	timestamp, err := reader.ReadTimestamp(lokiEntryField + ".timestamp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the timestamp of the Loki entry: %w", err)
+	}
+	result.Timestamp = timestamp
+	return result, nil
+}
+
+var _ log.FieldSetReader = (*LokiEntryCommonFieldSetReader)(nil)
@@ -0,0 +1,149 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafanalokik8s_contract
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/api/loki"
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+// fakeLokiAPI returns the entries in the queried time range in the forward direction up to the limit.
+type fakeLokiAPI struct {
+	labels  map[string]string
+	entries []*loki.Entry
+	queries []*loki.QueryRangeInput
+}
+
+// QueryRange implements loki.LokiAPI.
+func (f *fakeLokiAPI) QueryRange(ctx context.Context, input *loki.QueryRangeInput) (*loki.QueryRangeOutput, error) {
+	f.queries = append(f.queries, input)
+	stream := &loki.Stream{Labels: f.labels}
+	for _, entry := range f.entries {
+		if len(stream.Entries) >= input.Limit {
+			break
+		}
+		if !entry.Timestamp.Before(input.Start) && entry.Timestamp.Before(input.End) {
+			stream.Entries = append(stream.Entries, entry)
+		}
+	}
+	return &loki.QueryRangeOutput{Streams: []*loki.Stream{stream}}, nil
+}
+
+var _ loki.LokiAPI = (*fakeLokiAPI)(nil)
+
+func TestQueryAllEntries(t *testing.T) {
+	startTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	endTime := startTime.Add(24 * time.Hour)
+	entryCount := QueryPageSize + 1000
+	api := &fakeLokiAPI{labels: map[string]string{"job": "audit"}}
+	for i := 0; i < entryCount; i++ {
+		timestamp := startTime.Add(time.Duration(i) * time.Second)
+		// The last entry of the first page shares its timestamp with the next 2 entries.
+		if i > QueryPageSize-1 && i <= QueryPageSize+1 {
+			timestamp = startTime.Add(time.Duration(QueryPageSize-1) * time.Second)
+		}
+		api.entries = append(api.entries, &loki.Entry{Timestamp: timestamp, Line: fmt.Sprintf("line-%d", i)})
+	}
+
+	var pageCounts []int
+	got, err := QueryAllEntries(context.Background(), api, `{job="audit"}`, startTime, endTime, func(readCount int) {
+		pageCounts = append(pageCounts, readCount)
+	})
+	if err != nil {
+		t.Fatalf("QueryAllEntries() returned an unexpected error: %v", err)
+	}
+
+	if len(got) != entryCount {
+		t.Fatalf("QueryAllEntries() returned %d entries, want %d", len(got), entryCount)
+	}
+	for i, entry := range got {
+		if want := fmt.Sprintf("line-%d", i); entry.Entry.Line != want {
+			t.Fatalf("entry %d = %q, want %q", i, entry.Entry.Line, want)
+		}
+	}
+	if diff := cmp.Diff([]int{QueryPageSize, entryCount}, pageCounts); diff != "" {
+		t.Errorf("read counts reported on pages mismatch (-want +got):\n%s", diff)
+	}
+	if want := startTime.Add(time.Duration(QueryPageSize-1) * time.Second); !api.queries[1].Start.Equal(want) {
+		t.Errorf("the second page started at %v, want %v", api.queries[1].Start, want)
+	}
+}
+
+func TestNewLogFromStreamEntry(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		line       string
+		wantFields map[string]string
+	}{
+		{
+			desc: "json line",
+			line: `{"kind":"Event","stage":"ResponseComplete"}`,
+			wantFields: map[string]string{
+				"kind":                             "Event",
+				"stage":                            "ResponseComplete",
+				"loki.labels.job":                  "audit",
+				"loki.timestamp":                   "2025-01-01T00:00:00.000000001Z",
+				"loki.structuredMetadata.trace_id": "abc",
+			},
+		},
+		{
+			desc: "text line",
+			line: "I0101 00:00:00.000000       1 main.go:10] started",
+			wantFields: map[string]string{
+				"message":         "I0101 00:00:00.000000       1 main.go:10] started",
+				"loki.labels.job": "audit",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			entry := &StreamEntry{
+				Labels: map[string]string{"job": "audit"},
+				Entry: &loki.Entry{
+					Timestamp:          time.Date(2025, 1, 1, 0, 0, 0, 1, time.UTC),
+					Line:               tc.line,
+					StructuredMetadata: map[string]string{"trace_id": "abc"},
+				},
+			}
+			l, err := NewLogFromStreamEntry(entry)
+			if err != nil {
+				t.Fatalf("NewLogFromStreamEntry() returned an unexpected error: %v", err)
+			}
+			for fieldPath, want := range tc.wantFields {
+				if got := l.ReadStringOrDefault(fieldPath, ""); got != want {
+					t.Errorf("field %s = %q, want %q", fieldPath, got, want)
+				}
+			}
+
+			err = l.SetFieldSetReader(&LokiEntryCommonFieldSetReader{})
+			if err != nil {
+				t.Fatalf("SetFieldSetReader() returned an unexpected error: %v", err)
+			}
+			commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+			if !commonFieldSet.Timestamp.Equal(entry.Entry.Timestamp) {
+				t.Errorf("Timestamp = %v, want %v", commonFieldSet.Timestamp, entry.Entry.Timestamp)
+			}
+			if len(commonFieldSet.DisplayID) != 16 {
+				t.Errorf("DisplayID = %q, want a 16 characters hash", commonFieldSet.DisplayID)
+			}
+		})
+	}
+}
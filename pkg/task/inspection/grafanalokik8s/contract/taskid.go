@@ -0,0 +1,75 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafanalokik8s_contract
+
+import (
+	"github.com/kyasbal/khi/pkg/api/loki"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/kyasbal/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// LokiTaskPrefix is the prefixes of IDs used in Grafana Loki related tasks.
+const LokiTaskPrefix = "khi.google.com/grafana-loki/"
+
+// InputLokiURLTaskID is the task ID for the form to input the URL of the Loki HTTP API.
+var InputLokiURLTaskID = taskid.NewDefaultImplementationID[string](LokiTaskPrefix + "form/url")
+
+// InputTenantIDTaskID is the task ID for the form to input the tenant ID sent as the X-Scope-OrgID header.
+var InputTenantIDTaskID = taskid.NewDefaultImplementationID[string](LokiTaskPrefix + "form/tenant-id")
+
+// InputUsernameTaskID is the task ID for the form to input the username of the basic authentication.
+var InputUsernameTaskID = taskid.NewDefaultImplementationID[string](LokiTaskPrefix + "form/username")
+
+// InputPasswordTaskID is the task ID for the secret form to input the password of the basic authentication or the bearer token.
+var InputPasswordTaskID = taskid.NewDefaultImplementationID[string](LokiTaskPrefix + "form/password")
+
+// InputAuditLogSelectorTaskID is the task ID for the form to input the LogQL query selecting kube-apiserver audit logs.
+var InputAuditLogSelectorTaskID = taskid.NewDefaultImplementationID[string](LokiTaskPrefix + "form/audit-log-selector")
+
+// InputContainerLogSelectorTaskID is the task ID for the form to input the LogQL query selecting container logs.
+var InputContainerLogSelectorTaskID = taskid.NewDefaultImplementationID[string](LokiTaskPrefix + "form/container-log-selector")
+
+// LokiClientTaskID is the task ID to provide the client of the Loki HTTP API.
+var LokiClientTaskID = taskid.NewDefaultImplementationID[loki.LokiAPI](LokiTaskPrefix + "loki-client")
+
+// AuditLogQueryTaskID is the task ID to query kube-apiserver audit logs from Loki in place of reading uploaded audit log files.
+var AuditLogQueryTaskID = taskid.NewImplementationID(ossclusterk8s_contract.AuditLogFileReaderTaskID.Ref(), "loki")
+
+// LokiK8sAuditLogProviderTaskID is the task ID to provide the audit logs to the common k8s audit log parsers.
+var LokiK8sAuditLogProviderTaskID = taskid.NewImplementationID(commonlogk8sauditv2_contract.K8sAuditLogProviderRef, "loki")
+
+// LokiK8sAuditLogParserTailTaskID is the task ID of the feature task to parse audit logs.
+var LokiK8sAuditLogParserTailTaskID = taskid.NewImplementationID(commonlogk8sauditv2_contract.K8sAuditLogParserTailRef, "loki")
+
+// LokiK8sAuditPermissionDeniedParserTailTaskID is the task ID of the feature task to parse audit logs of denied requests.
+var LokiK8sAuditPermissionDeniedParserTailTaskID = taskid.NewImplementationID(commonlogk8sauditv2_contract.K8sAuditPermissionDeniedParserTailRef, "loki")
+
+// ContainerLogQueryTaskID is the task ID to query container logs from Loki.
+var ContainerLogQueryTaskID = taskid.NewDefaultImplementationID[[]*log.Log](LokiTaskPrefix + "container-log-query")
+
+// ContainerLogFieldSetReaderTaskID is the task ID to read the fieldset of container logs.
+var ContainerLogFieldSetReaderTaskID = taskid.NewDefaultImplementationID[[]*log.Log](LokiTaskPrefix + "container-log-fieldset-reader")
+
+// ContainerLogIngesterTaskID is the task ID to finalize the container logs to be included in the final output.
+var ContainerLogIngesterTaskID = taskid.NewDefaultImplementationID[[]*log.Log](LokiTaskPrefix + "container-log-ingester")
+
+// ContainerLogGrouperTaskID is the task ID to group container logs by the container.
+var ContainerLogGrouperTaskID = taskid.NewDefaultImplementationID[inspectiontaskbase.LogGroupMap](LokiTaskPrefix + "container-log-grouper")
+
+// ContainerLogToTimelineMapperTaskID is the task ID to add events of container logs on the container timelines.
+var ContainerLogToTimelineMapperTaskID = taskid.NewDefaultImplementationID[struct{}](LokiTaskPrefix + "container-log-timeline-mapper")
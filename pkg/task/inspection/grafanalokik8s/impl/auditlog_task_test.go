@@ -0,0 +1,96 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafanalokik8s_impl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/api/loki"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	"github.com/kyasbal/khi/pkg/model/log"
+	commontimerange_contract "github.com/kyasbal/khi/pkg/task/inspection/commontimerange/contract"
+	grafanalokik8s_contract "github.com/kyasbal/khi/pkg/task/inspection/grafanalokik8s/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+type fakeLokiAPI struct {
+	streams []*loki.Stream
+	queries []*loki.QueryRangeInput
+}
+
+// QueryRange implements loki.LokiAPI.
+func (f *fakeLokiAPI) QueryRange(ctx context.Context, input *loki.QueryRangeInput) (*loki.QueryRangeOutput, error) {
+	f.queries = append(f.queries, input)
+	return &loki.QueryRangeOutput{Streams: f.streams}, nil
+}
+
+var _ loki.LokiAPI = (*fakeLokiAPI)(nil)
+
+func TestAuditLogQueryTask(t *testing.T) {
+	startTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	endTime := startTime.Add(time.Hour)
+	api := &fakeLokiAPI{
+		streams: []*loki.Stream{
+			{
+				Labels: map[string]string{"job": "kube-apiserver-audit", "instance": "master-1"},
+				Entries: []*loki.Entry{
+					{Timestamp: startTime.Add(2 * time.Second), Line: `{"auditID":"audit-2","stage":"ResponseComplete","stageTimestamp":"2025-01-01T00:00:02Z"}`},
+					{Timestamp: startTime.Add(3 * time.Second), Line: `{"auditID":"audit-3","stage":"RequestReceived","stageTimestamp":"2025-01-01T00:00:03Z"}`},
+				},
+			},
+			{
+				Labels: map[string]string{"job": "kube-apiserver-audit", "instance": "master-2"},
+				Entries: []*loki.Entry{
+					{Timestamp: startTime.Add(time.Second), Line: `{"auditID":"audit-1","stage":"ResponseComplete","stageTimestamp":"2025-01-01T00:00:01Z"}`},
+				},
+			},
+		},
+	}
+
+	ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+	logs, _, err := inspectiontest.RunInspectionTask(ctx, AuditLogQueryTask, inspectioncore_contract.TaskModeRun, map[string]any{},
+		tasktest.NewTaskDependencyValuePair[loki.LokiAPI](grafanalokik8s_contract.LokiClientTaskID.Ref(), api),
+		tasktest.NewTaskDependencyValuePair(grafanalokik8s_contract.InputAuditLogSelectorTaskID.Ref(), `{job="kube-apiserver-audit"}`),
+		tasktest.NewTaskDependencyValuePair(commontimerange_contract.InputStartTimeTaskID.Ref(), startTime),
+		tasktest.NewTaskDependencyValuePair(commontimerange_contract.InputEndTimeTaskID.Ref(), endTime),
+	)
+	if err != nil {
+		t.Fatalf("AuditLogQueryTask returned an unexpected error: %v", err)
+	}
+
+	wantQueries := []*loki.QueryRangeInput{
+		{
+			Query:     `{job="kube-apiserver-audit"}`,
+			Start:     startTime,
+			End:       endTime,
+			Limit:     grafanalokik8s_contract.QueryPageSize,
+			Direction: loki.DirectionForward,
+		},
+	}
+	if diff := cmp.Diff(wantQueries, api.queries); diff != "" {
+		t.Errorf("queries mismatch (-want +got):\n%s", diff)
+	}
+	var gotAuditIDs []string
+	for _, l := range logs {
+		gotAuditIDs = append(gotAuditIDs, log.MustGetFieldSet(l, &log.CommonFieldSet{}).DisplayID)
+	}
+	if diff := cmp.Diff([]string{"audit-1", "audit-2"}, gotAuditIDs); diff != "" {
+		t.Errorf("audit logs mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafanalokik8s_impl
+
+import (
+	"context"
+
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/history"
+	"github.com/kyasbal/khi/pkg/model/log"
+	commontimerange_contract "github.com/kyasbal/khi/pkg/task/inspection/commontimerange/contract"
+	googlecloudlogk8scontainer_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8scontainer/contract"
+	grafanalokik8s_contract "github.com/kyasbal/khi/pkg/task/inspection/grafanalokik8s/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// ContainerLogQueryTask reads container stdout/stderr logs from Loki with the container log query.
+var ContainerLogQueryTask = inspectiontaskbase.NewProgressReportableInspectionTask(
+	grafanalokik8s_contract.ContainerLogQueryTaskID,
+	[]taskid.UntypedTaskReference{
+		grafanalokik8s_contract.LokiClientTaskID.Ref(),
+		grafanalokik8s_contract.InputContainerLogSelectorTaskID.Ref(),
+		commontimerange_contract.InputStartTimeTaskID.Ref(),
+		commontimerange_contract.InputEndTimeTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, tp *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, error) {
+		if taskMode == inspectioncore_contract.TaskModeDryRun {
+			return []*log.Log{}, nil
+		}
+		client := coretask.GetTaskResult(ctx, grafanalokik8s_contract.LokiClientTaskID.Ref())
+		query := coretask.GetTaskResult(ctx, grafanalokik8s_contract.InputContainerLogSelectorTaskID.Ref())
+		startTime := coretask.GetTaskResult(ctx, commontimerange_contract.InputStartTimeTaskID.Ref())
+		endTime := coretask.GetTaskResult(ctx, commontimerange_contract.InputEndTimeTaskID.Ref())
+
+		return queryLoki(ctx, client, query, startTime, endTime, tp, &grafanalokik8s_contract.LokiEntryCommonFieldSetReader{}, enum.LogTypeContainer)
+	},
+)
+
+var ContainerLogFieldSetReaderTask = inspectiontaskbase.NewFieldSetReadTask(
+	grafanalokik8s_contract.ContainerLogFieldSetReaderTaskID,
+	grafanalokik8s_contract.ContainerLogQueryTaskID.Ref(),
+	[]log.FieldSetReader{
+		&grafanalokik8s_contract.LokiContainerLogFieldSetReader{},
+	},
+)
+
+var ContainerLogIngesterTask = inspectiontaskbase.NewLogIngesterTask(grafanalokik8s_contract.ContainerLogIngesterTaskID, grafanalokik8s_contract.ContainerLogQueryTaskID.Ref())
+
+var ContainerLogGrouperTask = inspectiontaskbase.NewLogGrouperTask(
+	grafanalokik8s_contract.ContainerLogGrouperTaskID,
+	grafanalokik8s_contract.ContainerLogFieldSetReaderTaskID.Ref(),
+	func(ctx context.Context, l *log.Log) string {
+		// container log parser is stateless and it doesn't require grouping to work, but grouping them by the container for better performance to process them in parallel.
+		containerFields, err := log.GetFieldSet(l, &googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet{})
+		if err != nil {
+			return "unknown"
+		}
+		return containerFields.ResourcePath().Path
+	},
+)
+
+var ContainerLogToTimelineMapperTask = inspectiontaskbase.NewLogToTimelineMapperTask[struct{}](grafanalokik8s_contract.ContainerLogToTimelineMapperTaskID, &containerLogToTimelineMapperTaskSetting{},
+	inspectioncore_contract.FeatureTaskLabel(`Kubernetes container logs`,
+		`Gather stdout/stderr logs of containers shipped to Loki to visualize them on the timeline under an associated Pod. Log volume can be huge when the cluster has many Pods.`,
+		enum.LogTypeContainer,
+		4000,
+		false,
+		grafanalokik8s_contract.InspectionTypeID),
+)
+
+type containerLogToTimelineMapperTaskSetting struct {
+}
+
+// Dependencies implements inspectiontaskbase.LogToTimelineMapper.
+func (c *containerLogToTimelineMapperTaskSetting) Dependencies() []taskid.UntypedTaskReference {
+	return []taskid.UntypedTaskReference{}
+}
+
+// GroupedLogTask implements inspectiontaskbase.LogToTimelineMapper.
+func (c *containerLogToTimelineMapperTaskSetting) GroupedLogTask() taskid.TaskReference[inspectiontaskbase.LogGroupMap] {
+	return grafanalokik8s_contract.ContainerLogGrouperTaskID.Ref()
+}
+
+// LogIngesterTask implements inspectiontaskbase.LogToTimelineMapper.
+func (c *containerLogToTimelineMapperTaskSetting) LogIngesterTask() taskid.TaskReference[[]*log.Log] {
+	return grafanalokik8s_contract.ContainerLogIngesterTaskID.Ref()
+}
+
+// ProcessLogByGroup implements inspectiontaskbase.LogToTimelineMapper.
+func (c *containerLogToTimelineMapperTaskSetting) ProcessLogByGroup(ctx context.Context, l *log.Log, cs *history.ChangeSet, builder *history.Builder, prevGroupData struct{}) (struct{}, error) {
+	containerFields, err := log.GetFieldSet(l, &googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet{})
+	if err != nil {
+		return struct{}{}, nil
+	}
+
+	cs.AddEvent(containerFields.ResourcePath())
+	cs.SetLogSummary(containerFields.Message)
+	googlecloudlogk8scontainer_contract.AddVeleroLogEvents(cs, containerFields)
+	return struct{}{}, nil
+}
+
+var _ inspectiontaskbase.LogToTimelineMapper[struct{}] = (*containerLogToTimelineMapperTaskSetting)(nil)
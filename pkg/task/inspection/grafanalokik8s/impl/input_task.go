@@ -0,0 +1,134 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafanalokik8s_impl
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/api/loki"
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	grafanalokik8s_contract "github.com/kyasbal/khi/pkg/task/inspection/grafanalokik8s/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// previousValueOr returns the default value function using the last value given to the form or the given default value.
+func previousValueOr(defaultValue string) func(ctx context.Context, previousValues []string) (string, error) {
+	return func(ctx context.Context, previousValues []string) (string, error) {
+		if len(previousValues) > 0 {
+			return previousValues[0], nil
+		}
+		return defaultValue, nil
+	}
+}
+
+// trimSpace is the converter to remove spaces around the given value.
+func trimSpace(ctx context.Context, value string) (string, error) {
+	return strings.TrimSpace(value), nil
+}
+
+// validateStreamSelector returns the validation message when the value is not a LogQL log query beginning with a stream selector.
+func validateStreamSelector(ctx context.Context, value string) (string, error) {
+	if !strings.HasPrefix(strings.TrimSpace(value), "{") {
+		return "The query must be a LogQL log query beginning with a stream selector like `{namespace=\"default\"}`", nil
+	}
+	return "", nil
+}
+
+// InputLokiURLTask defines a form task to input the URL of the Loki HTTP API.
+var InputLokiURLTask = formtask.NewTextFormTaskBuilder(grafanalokik8s_contract.InputLokiURLTaskID, 0, "Loki URL").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionResourceIdentifier}).
+	WithPlaceholder("e.g. http://loki-gateway.monitoring.svc:3100").
+	WithDescription("The base URL of the Loki HTTP API. Logs are read with `/loki/api/v1/query_range` under this URL.").
+	WithMarkdown().
+	WithDefaultValueFunc(previousValueOr("")).
+	WithValidator(func(ctx context.Context, value string) (string, error) {
+		parsed, err := url.Parse(strings.TrimSpace(value))
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return "Loki URL must be an absolute URL beginning with `http://` or `https://`", nil
+		}
+		return "", nil
+	}).
+	WithConverter(trimSpace).
+	Build()
+
+// InputTenantIDTask defines a form task to input the tenant ID of Loki running in the multi tenant mode.
+var InputTenantIDTask = formtask.NewTextFormTaskBuilder(grafanalokik8s_contract.InputTenantIDTaskID, 0, "Tenant ID").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionResourceIdentifier, After: []string{grafanalokik8s_contract.InputLokiURLTaskID.ReferenceIDString()}}).
+	WithPlaceholder("e.g. my-tenant").
+	WithDescription("The tenant ID sent as the `X-Scope-OrgID` header. Leave this empty when Loki is not running in the multi tenant mode.").
+	WithMarkdown().
+	WithDefaultValueFunc(previousValueOr("")).
+	WithConverter(trimSpace).
+	Build()
+
+// InputUsernameTask defines a form task to input the username of the basic authentication.
+var InputUsernameTask = formtask.NewTextFormTaskBuilder(grafanalokik8s_contract.InputUsernameTaskID, 0, "Username").
+	WithPosition(inspectionmetadata.FormPosition{Section: grafanalokik8s_contract.FormSectionLokiAuthentication}).
+	WithDescription("The username of the basic authentication. Leave this empty to send the password as a bearer token, or to call Loki without authentication.").
+	WithDefaultValueFunc(previousValueOr("")).
+	WithConverter(trimSpace).
+	Build()
+
+// InputPasswordTask defines a secret form task to input the password of the basic authentication or the bearer token.
+var InputPasswordTask = formtask.NewSecretFormTaskBuilder(grafanalokik8s_contract.InputPasswordTaskID, 0, "Password or token").
+	WithPosition(inspectionmetadata.FormPosition{Section: grafanalokik8s_contract.FormSectionLokiAuthentication, After: []string{grafanalokik8s_contract.InputUsernameTaskID.ReferenceIDString()}}).
+	WithDescription("The password of the basic authentication, or the bearer token when the username is empty.").
+	WithConverter(trimSpace).
+	Build()
+
+// InputAuditLogSelectorTask defines a form task to input the LogQL query selecting kube-apiserver audit logs.
+var InputAuditLogSelectorTask = formtask.NewTextFormTaskBuilder(grafanalokik8s_contract.InputAuditLogSelectorTaskID, 0, "Audit log query").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionControlPlaneLogFilter}).
+	WithPlaceholder(`e.g. {job="kube-apiserver-audit"}`).
+	WithDescription("The LogQL log query selecting the kube-apiserver audit log lines written in JSON. Match the labels attached by the agent shipping the audit log file to Loki.").
+	WithDefaultValueFunc(previousValueOr(`{job="kube-apiserver-audit"}`)).
+	WithValidator(validateStreamSelector).
+	WithConverter(trimSpace).
+	Build()
+
+// InputContainerLogSelectorTask defines a form task to input the LogQL query selecting container logs.
+var InputContainerLogSelectorTask = formtask.NewTextFormTaskBuilder(grafanalokik8s_contract.InputContainerLogSelectorTaskID, 0, "Container log query").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionContainerLogFilter}).
+	WithPlaceholder(`e.g. {namespace="default"}`).
+	WithDescription("The LogQL log query selecting container logs. Each stream must have `namespace`, `pod` and `container` labels (or `k8s_namespace_name`, `k8s_pod_name` and `k8s_container_name` labels) to associate the logs with the containers.").
+	WithMarkdown().
+	WithDefaultValueFunc(previousValueOr(`{namespace=~".+", pod=~".+"}`)).
+	WithValidator(validateStreamSelector).
+	WithConverter(trimSpace).
+	Build()
+
+// LokiClientTask provides the client of the Loki HTTP API.
+var LokiClientTask = inspectiontaskbase.NewInspectionTask(grafanalokik8s_contract.LokiClientTaskID, []taskid.UntypedTaskReference{
+	grafanalokik8s_contract.InputLokiURLTaskID.Ref(),
+	grafanalokik8s_contract.InputTenantIDTaskID.Ref(),
+	grafanalokik8s_contract.InputUsernameTaskID.Ref(),
+	grafanalokik8s_contract.InputPasswordTaskID.Ref(),
+}, func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (loki.LokiAPI, error) {
+	endpoint := coretask.GetTaskResult(ctx, grafanalokik8s_contract.InputLokiURLTaskID.Ref())
+	auth := loki.Auth{
+		Username: coretask.GetTaskResult(ctx, grafanalokik8s_contract.InputUsernameTaskID.Ref()),
+		Password: coretask.GetTaskResult(ctx, grafanalokik8s_contract.InputPasswordTaskID.Ref()),
+		TenantID: coretask.GetTaskResult(ctx, grafanalokik8s_contract.InputTenantIDTaskID.Ref()),
+	}
+	return loki.NewClient(endpoint, auth, http.DefaultClient), nil
+})
@@ -0,0 +1,43 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafanalokik8s_impl
+
+import (
+	"context"
+	"testing"
+)
+
+func TestValidateStreamSelector(t *testing.T) {
+	testCases := []struct {
+		query     string
+		wantValid bool
+	}{
+		{query: `{namespace="default"}`, wantValid: true},
+		{query: ` {job="audit"} |= "pods"`, wantValid: true},
+		{query: `rate({job="audit"}[5m])`, wantValid: false},
+		{query: ``, wantValid: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			message, err := validateStreamSelector(context.Background(), tc.query)
+			if err != nil {
+				t.Fatalf("validateStreamSelector() returned an unexpected error: %v", err)
+			}
+			if (message == "") != tc.wantValid {
+				t.Errorf("validateStreamSelector(%q) = %q, want valid %v", tc.query, message, tc.wantValid)
+			}
+		})
+	}
+}
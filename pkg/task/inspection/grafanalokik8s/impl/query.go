@@ -0,0 +1,57 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafanalokik8s_impl
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/api/loki"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	grafanalokik8s_contract "github.com/kyasbal/khi/pkg/task/inspection/grafanalokik8s/contract"
+)
+
+// queryLoki reads all the entries matching the query and converts them to logs sorted by their timestamps.
+func queryLoki(ctx context.Context, api loki.LokiAPI, query string, startTime, endTime time.Time, tp *inspectionmetadata.TaskProgressMetadata, commonFieldSetReader log.FieldSetReader, logType enum.LogType) ([]*log.Log, error) {
+	tp.MarkIndeterminate()
+	entries, err := grafanalokik8s_contract.QueryAllEntries(ctx, api, query, startTime, endTime, func(readCount int) {
+		tp.Message = fmt.Sprintf("%d logs fetched", readCount)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read logs with the query %s: %w", query, err)
+	}
+
+	logs := make([]*log.Log, 0, len(entries))
+	for _, entry := range entries {
+		l, err := grafanalokik8s_contract.NewLogFromStreamEntry(entry)
+		if err != nil {
+			return nil, err
+		}
+		err = l.SetFieldSetReader(commonFieldSetReader)
+		if err != nil {
+			return nil, err
+		}
+		l.LogType = logType
+		logs = append(logs, l)
+	}
+	slices.SortStableFunc(logs, func(a, b *log.Log) int {
+		return log.MustGetFieldSet(a, &log.CommonFieldSet{}).Timestamp.Compare(log.MustGetFieldSet(b, &log.CommonFieldSet{}).Timestamp)
+	})
+	return logs, nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafanalokik8s_impl
+
+import (
+	coreinspection "github.com/kyasbal/khi/pkg/core/inspection"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	grafanalokik8s_contract "github.com/kyasbal/khi/pkg/task/inspection/grafanalokik8s/contract"
+)
+
+// Register registers all grafanalokik8s inspection tasks to the registry.
+func Register(registry coreinspection.InspectionTaskRegistry) error {
+	err := registry.AddInspectionType(grafanalokik8s_contract.LokiK8sInspectionType)
+	if err != nil {
+		return err
+	}
+
+	return coretask.RegisterTasks(registry,
+		InputLokiURLTask,
+		InputTenantIDTask,
+		InputUsernameTask,
+		InputPasswordTask,
+		InputAuditLogSelectorTask,
+		InputContainerLogSelectorTask,
+		LokiClientTask,
+		AuditLogQueryTask,
+		LokiK8sAuditLogFieldExtractorTask,
+		LokiK8sAuditLogParserTailTask,
+		LokiK8sAuditPermissionDeniedParserTailTask,
+		ContainerLogQueryTask,
+		ContainerLogFieldSetReaderTask,
+		ContainerLogIngesterTask,
+		ContainerLogGrouperTask,
+		ContainerLogToTimelineMapperTask,
+	)
+}
@@ -19,4 +19,7 @@ type AutocompleteResult[T any] struct {
 	Values []T
 	Error  string
 	Hint   string
+	// Loading is true when the candidates are still being fetched in background.
+	// Values contains the candidates fetched last time in that case.
+	Loading bool
 }
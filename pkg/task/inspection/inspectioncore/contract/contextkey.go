@@ -29,6 +29,10 @@ var InspectionTaskMode = typedmap.NewTypedKey[InspectionTaskModeType]("khi.googl
 // It contains a map of parameter names to their values.
 var InspectionTaskInput = typedmap.NewTypedKey[map[string]any]("khi.google.com/inspection/task-input")
 
+// InspectionSecretValues is the context key to access the secret values given to the current inspection run.
+// Logs written with a context containing this value are redacted with the registered secrets.
+var InspectionSecretValues = typedmap.NewTypedKey[*SecretValues]("khi.google.com/inspection/secret-values")
+
 // InspectionCreationTime is the context key to access the time when user created the inspection.
 var InspectionCreationTime = typedmap.NewTypedKey[time.Time]("khi.google.com/inspection/creation-time")
 
@@ -41,6 +45,25 @@ var InspectionSharedMap = typedmap.NewTypedKey[*typedmap.TypedMap]("khi.google.c
 // GlobalSharedMap is the context key to access a shared typed map across any inspection tasks.
 var GlobalSharedMap = typedmap.NewTypedKey[*typedmap.TypedMap]("khi.google.com/inspection/global-shared-map")
 
+// InspectionFollowSharedMap is the context key to access a shared typed map across the runs of a followed inspection.
+// Tasks store the data fetched in a run to fetch only the data added after it in the next run of the follower.
+// The value is only set in the runs started by the follower.
+var InspectionFollowSharedMap = typedmap.NewTypedKey[*typedmap.TypedMap]("khi.google.com/inspection/follow-shared-map")
+
+// TaskCacheBackendContextKey is the context key to access the TaskCacheBackend used by cached tasks to persist their results.
+// The value is not set when the persistent cache is disabled.
+var TaskCacheBackendContextKey = typedmap.NewTypedKey[TaskCacheBackend]("khi.google.com/inspection/task-cache-backend")
+
+// InspectionCheckpointBackendContextKey is the context key to access the StreamingTaskCacheBackend storing checkpoints of the logs queried in the current run.
+// Checkpoints are shared with the later run of the same inspection request to resume it after the server crashed in the middle of the run.
+// The value is only set in the run mode when checkpointing is enabled.
+var InspectionCheckpointBackendContextKey = typedmap.NewTypedKey[StreamingTaskCacheBackend]("khi.google.com/inspection/checkpoint-backend")
+
+// QueryResultCacheBackendContextKey is the context key to access the StreamingTaskCacheBackend storing raw log entries returned from Cloud Logging queries.
+// Unlike checkpoints, the cached query results are shared among any inspections querying the same logs with the same filter, time range and principal.
+// The value is not set when the query result cache is disabled.
+var QueryResultCacheBackendContextKey = typedmap.NewTypedKey[StreamingTaskCacheBackend]("khi.google.com/inspection/query-result-cache-backend")
+
 // InspectionTaskInspectionID is the context key to access the unique identifier for the current inspection.
 // This ID remains the same for all runs within a single inspection session.
 var InspectionTaskInspectionID = typedmap.NewTypedKey[string]("khi.google.com/inspection/inspection-id")
@@ -23,6 +23,8 @@ var (
 	TaskLabelKeyIsFormTask           = coretask.NewTaskLabelKey[bool](InspectionTaskPrefix + "is-form-task")
 	TaskLabelKeyFormFieldLabel       = coretask.NewTaskLabelKey[string](InspectionTaskPrefix + "form-field-label")
 	TaskLabelKeyFormFieldDescription = coretask.NewTaskLabelKey[string](InspectionTaskPrefix + "form-field-description")
+	// TaskLabelKeyIsSecretFormTask marks the form task receiving a secret value. Values of these fields must not be persisted.
+	TaskLabelKeyIsSecretFormTask = coretask.NewTaskLabelKey[bool](InspectionTaskPrefix + "is-secret-form-task")
 )
 
 type FormTaskLabelOpt struct {
@@ -35,6 +37,10 @@ func (f *FormTaskLabelOpt) Write(label *typedmap.TypedMap) {
 	typedmap.Set(label, TaskLabelKeyIsFormTask, true)
 	typedmap.Set(label, TaskLabelKeyFormFieldLabel, f.label)
 	typedmap.Set(label, TaskLabelKeyFormFieldDescription, f.description)
+	// Form tasks are cheap and their results are shown to users. Run them before heavier tasks unless the priority is given explicitly.
+	if _, found := typedmap.Get(label, coretask.LabelKeyTaskExecutionPriority); !found {
+		typedmap.Set(label, coretask.LabelKeyTaskExecutionPriority, coretask.TaskExecutionPriorityHigh)
+	}
 }
 
 // NewFormTaskLabelOpt constucts a new instance of task.LabelOpt for form related tasks.
@@ -36,6 +36,11 @@ func (q *QueryTaskLabelOpt) Write(label *typedmap.TypedMap) {
 	typedmap.Set(label, TaskLabelKeyIsQueryTask, true)
 	typedmap.Set(label, TaskLabelKeyQueryTaskTargetLogType, q.TargetLogType)
 	typedmap.Set(label, TaskLabelKeyQueryTaskSampleQuery, q.SampleQuery)
+	// Query tasks are heavy. Let the other tasks run first unless the priority is given explicitly.
+	if _, found := typedmap.Get(label, coretask.LabelKeyTaskExecutionPriority); !found {
+		typedmap.Set(label, coretask.LabelKeyTaskExecutionPriority, coretask.TaskExecutionPriorityLow)
+	}
+	typedmap.Set(label, coretask.LabelKeyTaskMemoryHeavy, true)
 }
 
 var _ (coretask.LabelOpt) = (*QueryTaskLabelOpt)(nil)
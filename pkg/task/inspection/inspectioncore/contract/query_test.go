@@ -49,4 +49,25 @@ func TestQueryTaskLabelOpt(t *testing.T) {
 	if sampleQuery != "sample query" {
 		t.Errorf("TaskLabel %s is expected to be sample query, but it is %v", TaskLabelKeyQueryTaskSampleQuery.Key(), sampleQuery)
 	}
+
+	priority, exists := typedmap.Get(label, coretask.LabelKeyTaskExecutionPriority)
+	if !exists {
+		t.Errorf("TaskLabel %s is expected to be set, but it is not", coretask.LabelKeyTaskExecutionPriority.Key())
+	}
+	if priority != coretask.TaskExecutionPriorityLow {
+		t.Errorf("TaskLabel %s is expected to be %d, but it is %d", coretask.LabelKeyTaskExecutionPriority.Key(), coretask.TaskExecutionPriorityLow, priority)
+	}
+
+	if !typedmap.GetOrDefault(label, coretask.LabelKeyTaskMemoryHeavy, false) {
+		t.Errorf("TaskLabel %s is expected to be true, but it is not", coretask.LabelKeyTaskMemoryHeavy.Key())
+	}
+}
+
+func TestQueryTaskLabelOpt_KeepsExplicitExecutionPriority(t *testing.T) {
+	label := coretask.NewLabelSet(coretask.WithExecutionPriority(coretask.TaskExecutionPriorityHigh), NewQueryTaskLabelOpt(enum.LogTypeComputeApi, "sample query"))
+
+	priority := typedmap.GetOrDefault(label, coretask.LabelKeyTaskExecutionPriority, 0)
+	if priority != coretask.TaskExecutionPriorityHigh {
+		t.Errorf("TaskLabel %s is expected to be %d, but it is %d", coretask.LabelKeyTaskExecutionPriority.Key(), coretask.TaskExecutionPriorityHigh, priority)
+	}
 }
@@ -0,0 +1,60 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inspectioncore_contract
+
+import (
+	"strings"
+	"sync"
+)
+
+// RedactedSecretValue is the string replacing secret values in logs.
+const RedactedSecretValue = "[REDACTED]"
+
+// SecretValues holds the secret values given to an inspection run, like credentials for custom log sources.
+// Logs written in the run are redacted with the registered values.
+type SecretValues struct {
+	mu     sync.RWMutex
+	values []string
+}
+
+// NewSecretValues returns an empty SecretValues.
+func NewSecretValues() *SecretValues {
+	return &SecretValues{}
+}
+
+// Add registers the given value as a secret. Empty values are ignored.
+func (s *SecretValues) Add(value string) {
+	if value == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, v := range s.values {
+		if v == value {
+			return
+		}
+	}
+	s.values = append(s.values, value)
+}
+
+// Redact returns the given text with every registered secret value replaced with RedactedSecretValue.
+func (s *SecretValues) Redact(text string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, value := range s.values {
+		text = strings.ReplaceAll(text, value, RedactedSecretValue)
+	}
+	return text
+}
@@ -0,0 +1,264 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inspectioncore_contract
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TaskCacheBackend persists serialized cached task results outside of the process memory.
+// The cached values survive server restarts with the implementation storing values in a persistent storage.
+type TaskCacheBackend interface {
+	// Get returns the value stored with the given key. It returns false when no value was found for the key.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores the value with the given key. The existing value is overwritten.
+	Set(ctx context.Context, key string, value []byte) error
+	// Delete removes the value stored with the given key. It does nothing when no value was found for the key.
+	Delete(ctx context.Context, key string) error
+}
+
+// StreamingTaskCacheBackend is a TaskCacheBackend reading and writing values as streams not to hold large values in memory at once.
+type StreamingTaskCacheBackend interface {
+	TaskCacheBackend
+	// OpenReader returns the reader of the value stored with the given key. It returns false when no value was found for the key.
+	OpenReader(ctx context.Context, key string) (io.ReadCloser, bool, error)
+	// OpenWriter returns the writer of the value stored with the given key.
+	// The written value is visible to readers only after the writer is committed, and the existing value is kept when the writer is aborted.
+	OpenWriter(ctx context.Context, key string) (TaskCacheWriter, error)
+}
+
+// TaskCacheWriter writes a value to a StreamingTaskCacheBackend.
+type TaskCacheWriter interface {
+	io.Writer
+	// Commit stores the written value with the key given to OpenWriter. The existing value is overwritten.
+	Commit() error
+	// Abort discards the written value. It does nothing after the writer is committed or aborted.
+	Abort()
+}
+
+// FileSystemTaskCacheBackend is an implementation of TaskCacheBackend storing each entry as a file in a folder.
+// The file name is the digest of the key.
+type FileSystemTaskCacheBackend struct {
+	folder string
+	// ttl is the duration to keep an entry not accessed. 0 means no expiration.
+	ttl time.Duration
+	// maxBytes is the total size of entries over which least recently used entries are removed. 0 means unlimited.
+	maxBytes int64
+	// evictionLock prevents concurrent evictions listing and removing the same entries.
+	evictionLock sync.Mutex
+}
+
+var _ StreamingTaskCacheBackend = (*FileSystemTaskCacheBackend)(nil)
+
+// NewFileSystemTaskCacheBackend returns a FileSystemTaskCacheBackend storing entries in the given folder.
+// The folder is created when it doesn't exist.
+func NewFileSystemTaskCacheBackend(folder string) (*FileSystemTaskCacheBackend, error) {
+	if err := os.MkdirAll(folder, 0755); err != nil {
+		return nil, err
+	}
+	return &FileSystemTaskCacheBackend{
+		folder: folder,
+	}, nil
+}
+
+// SetEvictionPolicy makes the backend remove entries not accessed within the ttl, and remove least recently used entries when the total size of entries exceeds maxBytes.
+// Entries are evicted when a new entry is stored. 0 disables each of the limits.
+func (f *FileSystemTaskCacheBackend) SetEvictionPolicy(ttl time.Duration, maxBytes int64) {
+	f.ttl = ttl
+	f.maxBytes = maxBytes
+}
+
+// Get implements TaskCacheBackend.
+func (f *FileSystemTaskCacheBackend) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reader, found, err := f.OpenReader(ctx, key)
+	if err != nil || !found {
+		return nil, found, err
+	}
+	defer reader.Close()
+	value, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// Set implements TaskCacheBackend.
+func (f *FileSystemTaskCacheBackend) Set(ctx context.Context, key string, value []byte) error {
+	writer, err := f.OpenWriter(ctx, key)
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(value); err != nil {
+		writer.Abort()
+		return err
+	}
+	return writer.Commit()
+}
+
+// Delete implements TaskCacheBackend.
+func (f *FileSystemTaskCacheBackend) Delete(ctx context.Context, key string) error {
+	err := os.Remove(f.entryPath(key))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// OpenReader implements StreamingTaskCacheBackend.
+func (f *FileSystemTaskCacheBackend) OpenReader(ctx context.Context, key string) (io.ReadCloser, bool, error) {
+	path := f.entryPath(key)
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	// The modification time is used as the last access time of the entry to evict least recently used entries.
+	now := time.Now()
+	if err := os.Chtimes(path, now, now); err != nil {
+		slog.WarnContext(ctx, "failed to update the access time of the cache entry", "path", path, "error", err)
+	}
+	return file, true, nil
+}
+
+// OpenWriter implements StreamingTaskCacheBackend.
+func (f *FileSystemTaskCacheBackend) OpenWriter(ctx context.Context, key string) (TaskCacheWriter, error) {
+	// Write to a temporary file first not to leave a broken entry when the process is terminated during writing it.
+	tmpFile, err := os.CreateTemp(f.folder, "tmp-*")
+	if err != nil {
+		return nil, err
+	}
+	return &fileSystemTaskCacheWriter{
+		backend: f,
+		file:    tmpFile,
+		path:    f.entryPath(key),
+	}, nil
+}
+
+// evict removes entries expired or exceeding the size limit.
+func (f *FileSystemTaskCacheBackend) evict(now time.Time) error {
+	if f.ttl <= 0 && f.maxBytes <= 0 {
+		return nil
+	}
+	f.evictionLock.Lock()
+	defer f.evictionLock.Unlock()
+	dirEntries, err := os.ReadDir(f.folder)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	entries := make([]os.FileInfo, 0, len(dirEntries))
+	totalBytes := int64(0)
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() || !strings.HasSuffix(dirEntry.Name(), ".cache") {
+			continue
+		}
+		info, err := dirEntry.Info()
+		if err != nil {
+			// The entry can be removed after listing it.
+			if !errors.Is(err, os.ErrNotExist) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		if f.ttl > 0 && now.Sub(info.ModTime()) > f.ttl {
+			if err := os.Remove(filepath.Join(f.folder, info.Name())); err != nil && !errors.Is(err, os.ErrNotExist) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		entries = append(entries, info)
+		totalBytes += info.Size()
+	}
+	if f.maxBytes <= 0 || totalBytes <= f.maxBytes {
+		return errors.Join(errs...)
+	}
+	slices.SortFunc(entries, func(a, b os.FileInfo) int {
+		return a.ModTime().Compare(b.ModTime())
+	})
+	for _, info := range entries {
+		if totalBytes <= f.maxBytes {
+			break
+		}
+		if err := os.Remove(filepath.Join(f.folder, info.Name())); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+			continue
+		}
+		totalBytes -= info.Size()
+	}
+	return errors.Join(errs...)
+}
+
+func (f *FileSystemTaskCacheBackend) entryPath(key string) string {
+	digest := sha256.Sum256([]byte(key))
+	return filepath.Join(f.folder, hex.EncodeToString(digest[:])+".cache")
+}
+
+// fileSystemTaskCacheWriter is the TaskCacheWriter of FileSystemTaskCacheBackend writing the value to a temporary file renamed on commit.
+type fileSystemTaskCacheWriter struct {
+	backend *FileSystemTaskCacheBackend
+	file    *os.File
+	path    string
+	done    bool
+}
+
+var _ TaskCacheWriter = (*fileSystemTaskCacheWriter)(nil)
+
+// Write implements TaskCacheWriter.
+func (w *fileSystemTaskCacheWriter) Write(p []byte) (int, error) {
+	return w.file.Write(p)
+}
+
+// Commit implements TaskCacheWriter.
+func (w *fileSystemTaskCacheWriter) Commit() error {
+	if w.done {
+		return errors.New("the cache writer is already committed or aborted")
+	}
+	w.done = true
+	defer os.Remove(w.file.Name())
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(w.file.Name(), w.path); err != nil {
+		return err
+	}
+	// Failing to evict other entries doesn't make the committed entry invalid.
+	if err := w.backend.evict(time.Now()); err != nil {
+		slog.Warn("failed to evict cache entries", "folder", w.backend.folder, "error", err)
+	}
+	return nil
+}
+
+// Abort implements TaskCacheWriter.
+func (w *fileSystemTaskCacheWriter) Abort() {
+	if w.done {
+		return
+	}
+	w.done = true
+	w.file.Close()
+	os.Remove(w.file.Name())
+}
@@ -0,0 +1,182 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inspectioncore_contract
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileSystemTaskCacheBackend(t *testing.T) {
+	ctx := context.Background()
+	folder := filepath.Join(t.TempDir(), "cache")
+	backend, err := NewFileSystemTaskCacheBackend(folder)
+	if err != nil {
+		t.Fatalf("NewFileSystemTaskCacheBackend() returned an unexpected error: %v", err)
+	}
+
+	_, found, err := backend.Get(ctx, "foo")
+	if err != nil {
+		t.Fatalf("Get() returned an unexpected error: %v", err)
+	}
+	if found {
+		t.Errorf("Get() returned found=true for a missing key")
+	}
+
+	if err := backend.Set(ctx, "foo", []byte("value1")); err != nil {
+		t.Fatalf("Set() returned an unexpected error: %v", err)
+	}
+	if err := backend.Set(ctx, "foo", []byte("value2")); err != nil {
+		t.Fatalf("Set() returned an unexpected error: %v", err)
+	}
+
+	// Another instance with the same folder must read the stored value.
+	reopened, err := NewFileSystemTaskCacheBackend(folder)
+	if err != nil {
+		t.Fatalf("NewFileSystemTaskCacheBackend() returned an unexpected error: %v", err)
+	}
+	value, found, err := reopened.Get(ctx, "foo")
+	if err != nil {
+		t.Fatalf("Get() returned an unexpected error: %v", err)
+	}
+	if !found {
+		t.Fatalf("Get() returned found=false for a stored key")
+	}
+	if string(value) != "value2" {
+		t.Errorf("Get() = %q, want %q", string(value), "value2")
+	}
+
+	if err := reopened.Delete(ctx, "foo"); err != nil {
+		t.Fatalf("Delete() returned an unexpected error: %v", err)
+	}
+	if err := reopened.Delete(ctx, "foo"); err != nil {
+		t.Errorf("Delete() on a missing key returned an unexpected error: %v", err)
+	}
+	if _, found, err := backend.Get(ctx, "foo"); err != nil || found {
+		t.Errorf("Get() after Delete() = (found=%v, err=%v), want (found=false, err=nil)", found, err)
+	}
+}
+
+func TestFileSystemTaskCacheBackendStreaming(t *testing.T) {
+	ctx := context.Background()
+	backend, err := NewFileSystemTaskCacheBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileSystemTaskCacheBackend() returned an unexpected error: %v", err)
+	}
+
+	writer, err := backend.OpenWriter(ctx, "foo")
+	if err != nil {
+		t.Fatalf("OpenWriter() returned an unexpected error: %v", err)
+	}
+	for _, chunk := range []string{"value", "1"} {
+		if _, err := writer.Write([]byte(chunk)); err != nil {
+			t.Fatalf("Write() returned an unexpected error: %v", err)
+		}
+	}
+	if _, found, _ := backend.Get(ctx, "foo"); found {
+		t.Errorf("Get() returned found=true before the writer is committed")
+	}
+	if err := writer.Commit(); err != nil {
+		t.Fatalf("Commit() returned an unexpected error: %v", err)
+	}
+
+	aborted, err := backend.OpenWriter(ctx, "foo")
+	if err != nil {
+		t.Fatalf("OpenWriter() returned an unexpected error: %v", err)
+	}
+	aborted.Write([]byte("value2"))
+	aborted.Abort()
+
+	reader, found, err := backend.OpenReader(ctx, "foo")
+	if err != nil || !found {
+		t.Fatalf("OpenReader() = (found=%v, err=%v), want (found=true, err=nil)", found, err)
+	}
+	defer reader.Close()
+	value, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("ReadAll() returned an unexpected error: %v", err)
+	}
+	if string(value) != "value1" {
+		t.Errorf("OpenReader() read %q, want %q", string(value), "value1")
+	}
+}
+
+func TestFileSystemTaskCacheBackendEviction(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	testCases := []struct {
+		name      string
+		ttl       time.Duration
+		maxBytes  int64
+		wantFound []bool
+	}{
+		{
+			name:      "no limits",
+			wantFound: []bool{true, true, true, true},
+		},
+		{
+			name:      "entries not accessed within ttl",
+			ttl:       time.Hour,
+			wantFound: []bool{false, false, true, true},
+		},
+		{
+			name:      "least recently used entries over the size",
+			maxBytes:  20,
+			wantFound: []bool{false, true, false, true},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			backend, err := NewFileSystemTaskCacheBackend(t.TempDir())
+			if err != nil {
+				t.Fatalf("NewFileSystemTaskCacheBackend() returned an unexpected error: %v", err)
+			}
+			backend.SetEvictionPolicy(tc.ttl, tc.maxBytes)
+			// Each entry has 10 bytes and is last accessed in the order of its index except key-1 accessed recently.
+			lastAccesses := []time.Time{now.Add(-3 * time.Hour), now.Add(-2 * time.Hour), now.Add(-time.Minute), now}
+			for i := 0; i < 3; i++ {
+				key := fmt.Sprintf("key-%d", i)
+				if err := backend.Set(ctx, key, []byte("0123456789")); err != nil {
+					t.Fatalf("Set() returned an unexpected error: %v", err)
+				}
+				if err := os.Chtimes(backend.entryPath(key), lastAccesses[i], lastAccesses[i]); err != nil {
+					t.Fatalf("failed to set the access time: %v", err)
+				}
+			}
+			if tc.maxBytes > 0 {
+				// Reading key-1 makes it the most recently used entry except the entry stored next.
+				if err := os.Chtimes(backend.entryPath("key-1"), now.Add(-time.Second), now.Add(-time.Second)); err != nil {
+					t.Fatalf("failed to set the access time: %v", err)
+				}
+			}
+			// Storing a new entry triggers the eviction.
+			if err := backend.Set(ctx, "key-3", []byte("0123456789")); err != nil {
+				t.Fatalf("Set() returned an unexpected error: %v", err)
+			}
+
+			for i, want := range tc.wantFound {
+				key := fmt.Sprintf("key-%d", i)
+				if _, err := os.Stat(backend.entryPath(key)); (err == nil) != want {
+					t.Errorf("%s: found=%v, want %v", key, err == nil, want)
+				}
+			}
+		})
+	}
+}
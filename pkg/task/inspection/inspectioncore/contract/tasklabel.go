@@ -52,6 +52,8 @@ type FeatureTaskLabelImpl struct {
 	featureOrder     int
 	isDefaultFeature bool
 	inspectionTypes  []string
+	// failureNotIsolated is true when a failure of this feature must abort the whole inspection.
+	failureNotIsolated bool
 }
 
 func (ftl *FeatureTaskLabelImpl) Write(label *typedmap.TypedMap) {
@@ -62,6 +64,8 @@ func (ftl *FeatureTaskLabelImpl) Write(label *typedmap.TypedMap) {
 	typedmap.Set(label, LabelKeyFeatureTaskOrder, ftl.featureOrder)
 	typedmap.Set(label, LabelKeyInspectionDefaultFeatureFlag, ftl.isDefaultFeature)
 	typedmap.Set(label, LabelKeyInspectionTypes, ftl.inspectionTypes)
+	// A failure in a feature is isolated not to abort other features in the same inspection unless the feature opted out.
+	typedmap.Set(label, coretask.LabelKeyTaskFailureIsolated, !ftl.failureNotIsolated)
 }
 
 func (ftl *FeatureTaskLabelImpl) WithDescription(description string) *FeatureTaskLabelImpl {
@@ -69,6 +73,13 @@ func (ftl *FeatureTaskLabelImpl) WithDescription(description string) *FeatureTas
 	return ftl
 }
 
+// WithoutFailureIsolation marks the feature to abort the whole inspection when it failed.
+// By default, a failure of a feature is reported as an error message of the inspection and only the timelines of the feature are missing in the result.
+func (ftl *FeatureTaskLabelImpl) WithoutFailureIsolation() *FeatureTaskLabelImpl {
+	ftl.failureNotIsolated = true
+	return ftl
+}
+
 var _ coretask.LabelOpt = (*FeatureTaskLabelImpl)(nil)
 
 func FeatureTaskLabel(title string, description string, logType enum.LogType, featureOrder int, isDefaultFeature bool, inspectionTypes ...string) *FeatureTaskLabelImpl {
@@ -0,0 +1,210 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8slivecapture_contract
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/api/kubernetes"
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+// CaptureRequestor is the principal recorded on the audit logs generated from the captured changes.
+// The actual requestor of the change is unknown from the watch API.
+const CaptureRequestor = "khi-live-capture"
+
+// reconnectInterval is the wait before watching the resource again after the watch stream ended unexpectedly.
+const reconnectInterval = time.Second
+
+// DefaultResources is the resources watched by default.
+var DefaultResources = []string{
+	"v1/pods",
+	"v1/nodes",
+	"v1/services",
+	"v1/events",
+	"apps/v1/deployments",
+	"apps/v1/replicasets",
+	"apps/v1/daemonsets",
+	"apps/v1/statefulsets",
+}
+
+// CapturedChange is a change of an object received from the watch API.
+type CapturedChange struct {
+	Resource     kubernetes.Resource
+	Type         kubernetes.WatchEventType
+	Object       json.RawMessage
+	ObservedTime time.Time
+}
+
+// watchedObjectMetadata is the subset of the object metadata used to continue watching and to build audit logs.
+type watchedObjectMetadata struct {
+	Metadata struct {
+		Name            string `json:"name"`
+		Namespace       string `json:"namespace"`
+		UID             string `json:"uid"`
+		ResourceVersion string `json:"resourceVersion"`
+	} `json:"metadata"`
+}
+
+// CaptureChanges watches the resources concurrently until the duration elapses or ctx is cancelled and returns the captured changes.
+// All existing objects are captured as ADDED changes at the beginning. The watch is continued from the last resourceVersion when the stream ends,
+// and restarted from the current state when the resourceVersion is too old.
+// A resource failing to watch (e.g. forbidden by RBAC) is skipped with a warning, and an error is returned only when every resource failed.
+// onChange is called with the count of changes captured so far.
+func CaptureChanges(ctx context.Context, api kubernetes.WatchAPI, resources []kubernetes.Resource, duration time.Duration, onChange func(count int)) ([]*CapturedChange, error) {
+	captureCtx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+
+	var mu sync.Mutex
+	var changes []*CapturedChange
+	var wg sync.WaitGroup
+	errs := make([]error, len(resources))
+	for i, resource := range resources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = watchResource(captureCtx, api, resource, func(change *CapturedChange) {
+				mu.Lock()
+				defer mu.Unlock()
+				changes = append(changes, change)
+				if onChange != nil {
+					onChange(len(changes))
+				}
+			})
+			if errs[i] != nil {
+				slog.WarnContext(ctx, "failed to watch a resource. Changes of the resource are not captured", "resource", resource.String(), "error", errs[i])
+			}
+		}()
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	failedCount := 0
+	for _, err := range errs {
+		if err != nil {
+			failedCount++
+		}
+	}
+	if len(resources) > 0 && failedCount == len(resources) {
+		return nil, fmt.Errorf("failed to watch any of the resources: %w", errors.Join(errs...))
+	}
+	return changes, nil
+}
+
+func watchResource(ctx context.Context, api kubernetes.WatchAPI, resource kubernetes.Resource, onChange func(change *CapturedChange)) error {
+	resourceVersion := ""
+	for ctx.Err() == nil {
+		err := api.Watch(ctx, resource, resourceVersion, func(event *kubernetes.WatchEvent) error {
+			var metadata watchedObjectMetadata
+			if err := json.Unmarshal(event.Object, &metadata); err == nil && metadata.Metadata.ResourceVersion != "" {
+				resourceVersion = metadata.Metadata.ResourceVersion
+			}
+			if event.Type == kubernetes.WatchEventBookmark {
+				return nil
+			}
+			onChange(&CapturedChange{
+				Resource:     resource,
+				Type:         event.Type,
+				Object:       event.Object,
+				ObservedTime: time.Now(),
+			})
+			return nil
+		})
+		if ctx.Err() != nil {
+			return nil
+		}
+		if kubernetes.IsGone(err) {
+			resourceVersion = ""
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(reconnectInterval):
+		}
+	}
+	return nil
+}
+
+// auditVerbs maps the types of watch events to the verbs of the generated audit logs.
+var auditVerbs = map[kubernetes.WatchEventType]string{
+	kubernetes.WatchEventAdded:    "create",
+	kubernetes.WatchEventModified: "update",
+	kubernetes.WatchEventDeleted:  "delete",
+}
+
+// NewAuditLogFromChange converts the captured change to a kube-apiserver audit log with the ResponseComplete stage,
+// thus the filters and the parsers for the audit logs of OSS clusters process it.
+// The object after the change is stored as the response object and the observed time is used as the stage timestamp.
+func NewAuditLogFromChange(change *CapturedChange, index int) (*log.Log, error) {
+	verb, found := auditVerbs[change.Type]
+	if !found {
+		return nil, fmt.Errorf("unsupported watch event type %s", change.Type)
+	}
+	var metadata watchedObjectMetadata
+	err := json.Unmarshal(change.Object, &metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the object of %s: %w", change.Resource, err)
+	}
+	var object map[string]any
+	err = json.Unmarshal(change.Object, &object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the object of %s: %w", change.Resource, err)
+	}
+	objectRef := map[string]any{
+		"resource":        change.Resource.Resource,
+		"apiVersion":      change.Resource.Version,
+		"name":            metadata.Metadata.Name,
+		"resourceVersion": metadata.Metadata.ResourceVersion,
+	}
+	if change.Resource.Group != "" {
+		objectRef["apiGroup"] = change.Resource.Group
+	}
+	if metadata.Metadata.Namespace != "" {
+		objectRef["namespace"] = metadata.Metadata.Namespace
+	}
+	if metadata.Metadata.UID != "" {
+		objectRef["uid"] = metadata.Metadata.UID
+	}
+	observedTime := change.ObservedTime.UTC().Format(time.RFC3339Nano)
+	auditLog := map[string]any{
+		"kind":                     "Event",
+		"apiVersion":               "audit.k8s.io/v1",
+		"level":                    "RequestResponse",
+		"auditID":                  fmt.Sprintf("live-capture-%d", index),
+		"stage":                    "ResponseComplete",
+		"verb":                     verb,
+		"user":                     map[string]any{"username": CaptureRequestor},
+		"objectRef":                objectRef,
+		"responseStatus":           map[string]any{"code": 200},
+		"responseObject":           object,
+		"requestReceivedTimestamp": observedTime,
+		"stageTimestamp":           observedTime,
+	}
+	serialized, err := json.Marshal(auditLog)
+	if err != nil {
+		return nil, err
+	}
+	return log.NewLogFromYAMLString(string(serialized))
+}
@@ -0,0 +1,164 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8slivecapture_contract
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/api/kubernetes"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/kyasbal/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// fakeWatchAPI returns the prepared responses for each call of Watch in order, and blocks until ctx is done after the last response.
+type fakeWatchAPI struct {
+	mu        sync.Mutex
+	responses map[string][]fakeWatchResponse
+	calls     map[string][]string
+}
+
+type fakeWatchResponse struct {
+	events []*kubernetes.WatchEvent
+	err    error
+}
+
+// Watch implements kubernetes.WatchAPI.
+func (f *fakeWatchAPI) Watch(ctx context.Context, resource kubernetes.Resource, resourceVersion string, handler func(event *kubernetes.WatchEvent) error) error {
+	f.mu.Lock()
+	f.calls[resource.String()] = append(f.calls[resource.String()], resourceVersion)
+	responses := f.responses[resource.String()]
+	if len(responses) == 0 {
+		f.mu.Unlock()
+		<-ctx.Done()
+		return nil
+	}
+	response := responses[0]
+	f.responses[resource.String()] = responses[1:]
+	f.mu.Unlock()
+	for _, event := range response.events {
+		if err := handler(event); err != nil {
+			return err
+		}
+	}
+	return response.err
+}
+
+var _ kubernetes.WatchAPI = (*fakeWatchAPI)(nil)
+
+func watchEvent(eventType kubernetes.WatchEventType, name string, resourceVersion string) *kubernetes.WatchEvent {
+	return &kubernetes.WatchEvent{
+		Type:   eventType,
+		Object: json.RawMessage(`{"metadata":{"name":"` + name + `","namespace":"default","resourceVersion":"` + resourceVersion + `"}}`),
+	}
+}
+
+func TestCaptureChanges(t *testing.T) {
+	pods := kubernetes.Resource{Version: "v1", Resource: "pods"}
+	deployments := kubernetes.Resource{Group: "apps", Version: "v1", Resource: "deployments"}
+	api := &fakeWatchAPI{
+		calls: map[string][]string{},
+		responses: map[string][]fakeWatchResponse{
+			pods.String(): {
+				// The stream ended after a bookmark, then the watch continues from the bookmark.
+				{events: []*kubernetes.WatchEvent{watchEvent(kubernetes.WatchEventAdded, "pod-a", "10"), watchEvent(kubernetes.WatchEventBookmark, "", "11")}},
+				// The resource version expired, then the watch restarts from the current state.
+				{err: &kubernetes.APIError{StatusCode: 410, Reason: "Expired"}},
+				{events: []*kubernetes.WatchEvent{watchEvent(kubernetes.WatchEventModified, "pod-a", "20")}},
+			},
+			deployments.String(): {
+				{err: &kubernetes.APIError{StatusCode: 403, Reason: "Forbidden"}},
+			},
+		},
+	}
+
+	changes, err := CaptureChanges(t.Context(), api, []kubernetes.Resource{pods, deployments}, 1500*time.Millisecond, nil)
+	if err != nil {
+		t.Fatalf("CaptureChanges() returned an unexpected error: %v", err)
+	}
+	var gotTypes []kubernetes.WatchEventType
+	for _, change := range changes {
+		gotTypes = append(gotTypes, change.Type)
+	}
+	if diff := cmp.Diff([]kubernetes.WatchEventType{kubernetes.WatchEventAdded, kubernetes.WatchEventModified}, gotTypes); diff != "" {
+		t.Errorf("captured change types mismatch (-want +got):\n%s", diff)
+	}
+	wantCalls := map[string][]string{
+		pods.String():        {"", "11", ""},
+		deployments.String(): {""},
+	}
+	if diff := cmp.Diff(wantCalls, api.calls); diff != "" {
+		t.Errorf("watch calls mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCaptureChangesFailsWhenAllResourcesFailed(t *testing.T) {
+	pods := kubernetes.Resource{Version: "v1", Resource: "pods"}
+	api := &fakeWatchAPI{
+		calls: map[string][]string{},
+		responses: map[string][]fakeWatchResponse{
+			pods.String(): {{err: &kubernetes.APIError{StatusCode: 403, Reason: "Forbidden"}}},
+		},
+	}
+	_, err := CaptureChanges(t.Context(), api, []kubernetes.Resource{pods}, time.Second, nil)
+	if err == nil {
+		t.Errorf("CaptureChanges() returned no error, want an error")
+	}
+}
+
+func TestNewAuditLogFromChange(t *testing.T) {
+	observedTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	l, err := NewAuditLogFromChange(&CapturedChange{
+		Resource:     kubernetes.Resource{Group: "apps", Version: "v1", Resource: "deployments"},
+		Type:         kubernetes.WatchEventDeleted,
+		Object:       json.RawMessage(`{"kind":"Deployment","metadata":{"name":"nginx","namespace":"default","uid":"uid-1","resourceVersion":"42"}}`),
+		ObservedTime: observedTime,
+	}, 3)
+	if err != nil {
+		t.Fatalf("NewAuditLogFromChange() returned an unexpected error: %v", err)
+	}
+	err = l.SetFieldSetReader(&ossclusterk8s_contract.OSSK8sAuditLogCommonFieldSetReader{})
+	if err != nil {
+		t.Fatalf("failed to read the common fieldset: %v", err)
+	}
+	err = l.SetFieldSetReader(&ossclusterk8s_contract.OSSK8sAuditLogFieldSetReader{})
+	if err != nil {
+		t.Fatalf("failed to read the audit fieldset: %v", err)
+	}
+
+	commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+	if diff := cmp.Diff(&log.CommonFieldSet{DisplayID: "live-capture-3", Timestamp: observedTime, Severity: enum.SeverityUnknown}, commonFieldSet); diff != "" {
+		t.Errorf("common fieldset mismatch (-want +got):\n%s", diff)
+	}
+	auditFieldSet := log.MustGetFieldSet(l, &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{})
+	if got, want := auditFieldSet.K8sOperation.ResourcePath(), "apps/v1#deployment#default#nginx"; got != want {
+		t.Errorf("resource path = %q, want %q", got, want)
+	}
+	if auditFieldSet.K8sOperation.Verb != enum.RevisionVerbDelete {
+		t.Errorf("verb = %v, want %v", auditFieldSet.K8sOperation.Verb, enum.RevisionVerbDelete)
+	}
+	if auditFieldSet.Principal != CaptureRequestor {
+		t.Errorf("principal = %q, want %q", auditFieldSet.Principal, CaptureRequestor)
+	}
+	if got := l.ReadStringOrDefault("responseObject.metadata.uid", ""); got != "uid-1" {
+		t.Errorf("responseObject.metadata.uid = %q, want %q", got, "uid-1")
+	}
+}
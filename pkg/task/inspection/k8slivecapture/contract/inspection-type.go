@@ -0,0 +1,31 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8slivecapture_contract
+
+import (
+	"math"
+
+	coreinspection "github.com/kyasbal/khi/pkg/core/inspection"
+)
+
+const InspectionTypeID = "kubernetes-live-capture"
+
+var K8sLiveCaptureInspectionType = coreinspection.InspectionType{
+	Id:          InspectionTypeID,
+	Name:        "Kubernetes (Live capture)",
+	Description: "Watch resources and events of a cluster through a kubeconfig for a bounded duration and visualize the captured changes. Useful for clusters without centralized logging",
+	Icon:        "assets/icons/k8s.png",
+	Priority:    math.MaxInt - 2004,
+}
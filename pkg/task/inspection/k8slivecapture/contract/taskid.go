@@ -0,0 +1,51 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8slivecapture_contract
+
+import (
+	"time"
+
+	"github.com/kyasbal/khi/pkg/api/kubernetes"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	commonlogk8sauditv2_contract "github.com/kyasbal/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// K8sLiveCaptureTaskPrefix is the prefixes of IDs used in live capture related tasks.
+const K8sLiveCaptureTaskPrefix = "khi.google.com/live-capture/"
+
+// InputKubeconfigPathTaskID is the task ID for the form to input the path of the kubeconfig file.
+var InputKubeconfigPathTaskID = taskid.NewDefaultImplementationID[string](K8sLiveCaptureTaskPrefix + "form/kubeconfig-path")
+
+// InputContextTaskID is the task ID for the form to input the kubeconfig context to use.
+var InputContextTaskID = taskid.NewDefaultImplementationID[string](K8sLiveCaptureTaskPrefix + "form/context")
+
+// InputResourcesTaskID is the task ID for the form to select the resources to watch.
+var InputResourcesTaskID = taskid.NewDefaultImplementationID[[]kubernetes.Resource](K8sLiveCaptureTaskPrefix + "form/resources")
+
+// InputCaptureDurationTaskID is the task ID for the form to input the duration to watch the resources.
+var InputCaptureDurationTaskID = taskid.NewDefaultImplementationID[time.Duration](K8sLiveCaptureTaskPrefix + "form/duration")
+
+// WatchClientTaskID is the task ID to provide the client of the Kubernetes API server.
+var WatchClientTaskID = taskid.NewDefaultImplementationID[kubernetes.WatchAPI](K8sLiveCaptureTaskPrefix + "watch-client")
+
+// AuditLogCaptureTaskID is the task ID to capture changes of resources as audit logs in place of reading uploaded audit log files.
+var AuditLogCaptureTaskID = taskid.NewImplementationID(ossclusterk8s_contract.AuditLogFileReaderTaskID.Ref(), "live-capture")
+
+// K8sLiveCaptureAuditLogProviderTaskID is the task ID to provide the captured changes to the common k8s audit log parsers.
+var K8sLiveCaptureAuditLogProviderTaskID = taskid.NewImplementationID(commonlogk8sauditv2_contract.K8sAuditLogProviderRef, "live-capture")
+
+// K8sLiveCaptureAuditLogParserTailTaskID is the task ID of the feature task to parse the captured changes.
+var K8sLiveCaptureAuditLogParserTailTaskID = taskid.NewImplementationID(commonlogk8sauditv2_contract.K8sAuditLogParserTailRef, "live-capture")
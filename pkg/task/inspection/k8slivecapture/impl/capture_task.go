@@ -0,0 +1,127 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8slivecapture_impl
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/kyasbal/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	k8slivecapture_contract "github.com/kyasbal/khi/pkg/task/inspection/k8slivecapture/contract"
+	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// progressUpdateInterval is the interval to update the progress while capturing changes.
+const progressUpdateInterval = time.Second
+
+// AuditLogCaptureTask watches the resources for the capture duration and converts the captured changes to audit logs in place of the uploaded audit log files.
+// The time range of the inspection is the capture window.
+var AuditLogCaptureTask = inspectiontaskbase.NewProgressReportableInspectionTask(
+	k8slivecapture_contract.AuditLogCaptureTaskID,
+	[]taskid.UntypedTaskReference{
+		k8slivecapture_contract.WatchClientTaskID.Ref(),
+		k8slivecapture_contract.InputResourcesTaskID.Ref(),
+		k8slivecapture_contract.InputCaptureDurationTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, tp *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, error) {
+		if taskMode == inspectioncore_contract.TaskModeDryRun {
+			return []*log.Log{}, nil
+		}
+		client := coretask.GetTaskResult(ctx, k8slivecapture_contract.WatchClientTaskID.Ref())
+		resources := coretask.GetTaskResult(ctx, k8slivecapture_contract.InputResourcesTaskID.Ref())
+		duration := coretask.GetTaskResult(ctx, k8slivecapture_contract.InputCaptureDurationTaskID.Ref())
+
+		startTime := time.Now()
+		var capturedCount atomic.Int64
+		progressCtx, cancelProgress := context.WithCancel(ctx)
+		go func() {
+			ticker := time.NewTicker(progressUpdateInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-progressCtx.Done():
+					return
+				case <-ticker.C:
+					elapsed := time.Since(startTime)
+					tp.Update(float32(elapsed)/float32(duration), fmt.Sprintf("%d changes captured (%s/%s)", capturedCount.Load(), elapsed.Truncate(time.Second), duration))
+				}
+			}
+		}()
+		changes, err := k8slivecapture_contract.CaptureChanges(ctx, client, resources, duration, func(count int) {
+			capturedCount.Store(int64(count))
+		})
+		cancelProgress()
+		if err != nil {
+			return nil, err
+		}
+		setHeaderTimeRange(ctx, startTime, time.Now())
+
+		logs := make([]*log.Log, 0, len(changes))
+		for i, change := range changes {
+			l, err := k8slivecapture_contract.NewAuditLogFromChange(change, i)
+			if err != nil {
+				return nil, err
+			}
+			err = l.SetFieldSetReader(&ossclusterk8s_contract.OSSK8sAuditLogCommonFieldSetReader{})
+			if err != nil {
+				return nil, err
+			}
+			l.LogType = enum.LogTypeAudit
+			logs = append(logs, l)
+		}
+		slices.SortStableFunc(logs, func(a, b *log.Log) int {
+			return log.MustGetFieldSet(a, &log.CommonFieldSet{}).Timestamp.Compare(log.MustGetFieldSet(b, &log.CommonFieldSet{}).Timestamp)
+		})
+		return logs, nil
+	},
+	coretask.WithSelectionPriority(1000),
+	inspectioncore_contract.InspectionTypeLabel(k8slivecapture_contract.InspectionTypeID),
+)
+
+// setHeaderTimeRange sets the time range in the header metadata to the capture window.
+func setHeaderTimeRange(ctx context.Context, startTime, endTime time.Time) {
+	metadataSet := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
+	header := typedmap.GetOrDefault(metadataSet, inspectionmetadata.HeaderMetadataKey, &inspectionmetadata.HeaderMetadata{})
+	header.StartTimeUnixSeconds = startTime.Unix()
+	header.EndTimeUnixSeconds = endTime.Unix()
+}
+
+var K8sLiveCaptureAuditLogFieldExtractorTask = inspectiontaskbase.NewFieldSetReadTask(
+	k8slivecapture_contract.K8sLiveCaptureAuditLogProviderTaskID,
+	ossclusterk8s_contract.NonEventAuditLogFilterTaskID.Ref(),
+	[]log.FieldSetReader{(&ossclusterk8s_contract.OSSK8sAuditLogFieldSetReader{})},
+	inspectioncore_contract.InspectionTypeLabel(k8slivecapture_contract.InspectionTypeID),
+)
+
+var K8sLiveCaptureAuditLogParserTailTask = inspectiontaskbase.NewInspectionTask(
+	k8slivecapture_contract.K8sLiveCaptureAuditLogParserTailTaskID,
+	commonlogk8sauditv2_contract.ParserTailTaskDependencies(),
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (struct{}, error) {
+		return struct{}{}, nil
+	},
+	inspectioncore_contract.FeatureTaskLabel("Kubernetes resource changes", `Watch the selected resources through the kubeconfig for the capture duration and visualize the changes of them.`, enum.LogTypeAudit, 1001, true, k8slivecapture_contract.InspectionTypeID), coretask.NewSubsequentTaskRefsTaskLabel(inspectioncore_contract.SerializerTaskID.Ref()),
+)
@@ -0,0 +1,179 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8slivecapture_impl
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/api/kubernetes"
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	googlecloudcommon_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudcommon/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	k8slivecapture_contract "github.com/kyasbal/khi/pkg/task/inspection/k8slivecapture/contract"
+)
+
+// minCaptureDuration and maxCaptureDuration are the bounds of the duration to watch resources.
+const (
+	minCaptureDuration = 10 * time.Second
+	maxCaptureDuration = time.Hour
+)
+
+// previousValueOr returns the default value function using the last value given to the form or the given default value.
+func previousValueOr(defaultValue string) func(ctx context.Context, previousValues []string) (string, error) {
+	return func(ctx context.Context, previousValues []string) (string, error) {
+		if len(previousValues) > 0 {
+			return previousValues[0], nil
+		}
+		return defaultValue, nil
+	}
+}
+
+// trimSpace is the converter to remove spaces around the given value.
+func trimSpace(ctx context.Context, value string) (string, error) {
+	return strings.TrimSpace(value), nil
+}
+
+// defaultKubeconfigPath returns the first path in the KUBECONFIG environment variable, or `~/.kube/config` when it's not set.
+func defaultKubeconfigPath() string {
+	if paths := filepath.SplitList(os.Getenv("KUBECONFIG")); len(paths) > 0 && paths[0] != "" {
+		return paths[0]
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".kube", "config")
+}
+
+// parseCaptureDuration parses the duration in the Go duration format like `5m` and checks it's in the allowed range.
+func parseCaptureDuration(value string) (time.Duration, error) {
+	duration, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("capture duration must be a duration like `5m` or `30s`")
+	}
+	if duration < minCaptureDuration || duration > maxCaptureDuration {
+		return 0, fmt.Errorf("capture duration must be between %s and %s", minCaptureDuration, maxCaptureDuration)
+	}
+	return duration, nil
+}
+
+// InputKubeconfigPathTask defines a form task to input the path of the kubeconfig file.
+var InputKubeconfigPathTask = formtask.NewTextFormTaskBuilder(k8slivecapture_contract.InputKubeconfigPathTaskID, 0, "Kubeconfig path").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionResourceIdentifier}).
+	WithPlaceholder("e.g. /home/user/.kube/config").
+	WithDescription("The path of the kubeconfig file on the machine running KHI. Static tokens, client certificates, basic authentication and credential plugins returning tokens (`exec`) are supported.").
+	WithMarkdown().
+	WithDefaultValueFunc(previousValueOr(defaultKubeconfigPath())).
+	WithValidator(func(ctx context.Context, value string) (string, error) {
+		info, err := os.Stat(strings.TrimSpace(value))
+		if err != nil || info.IsDir() {
+			return fmt.Sprintf("kubeconfig file `%s` was not found", strings.TrimSpace(value)), nil
+		}
+		return "", nil
+	}).
+	WithConverter(trimSpace).
+	Build()
+
+// InputContextTask defines a form task to input the kubeconfig context to use.
+var InputContextTask = formtask.NewTextFormTaskBuilder(k8slivecapture_contract.InputContextTaskID, 0, "Context").
+	WithPosition(inspectionmetadata.FormPosition{Section: googlecloudcommon_contract.FormSectionResourceIdentifier, After: []string{k8slivecapture_contract.InputKubeconfigPathTaskID.ReferenceIDString()}}).
+	WithDependencies([]taskid.UntypedTaskReference{k8slivecapture_contract.InputKubeconfigPathTaskID.Ref()}).
+	WithPlaceholder("e.g. kind-kind").
+	WithDescription("The context in the kubeconfig to connect. Leave this empty to use the current context.").
+	WithDefaultValueFunc(previousValueOr("")).
+	WithValidator(func(ctx context.Context, value string) (string, error) {
+		kubeconfigPath := coretask.GetTaskResult(ctx, k8slivecapture_contract.InputKubeconfigPathTaskID.Ref())
+		if _, err := kubernetes.LoadKubeconfigFile(kubeconfigPath, strings.TrimSpace(value)); err != nil {
+			return err.Error(), nil
+		}
+		return "", nil
+	}).
+	WithConverter(trimSpace).
+	Build()
+
+// InputResourcesTask defines a form task to select the resources to watch.
+var InputResourcesTask = formtask.NewSetFormTaskBuilder(k8slivecapture_contract.InputResourcesTaskID, 0, "Resources").
+	WithPosition(inspectionmetadata.FormPosition{Section: k8slivecapture_contract.FormSectionCapture}).
+	WithDefaultValueConstant(k8slivecapture_contract.DefaultResources, true).
+	WithDescription("The resources to watch in `<version>/<resource>` for the core API group or `<group>/<version>/<resource>` (e.g. `batch/v1/jobs`). Include `v1/events` to show Events on the timelines of the involved objects.").
+	WithMarkdown().
+	WithAllowAddAll(true).
+	WithAllowRemoveAll(false).
+	WithAllowCustomValue(true).
+	WithOptionsFunc(func(ctx context.Context, previousValues []string) ([]inspectionmetadata.SetParameterFormFieldOptionItem, error) {
+		result := []inspectionmetadata.SetParameterFormFieldOptionItem{}
+		for _, resource := range k8slivecapture_contract.DefaultResources {
+			result = append(result, inspectionmetadata.SetParameterFormFieldOptionItem{ID: resource})
+		}
+		return result, nil
+	}).
+	WithValidator(func(ctx context.Context, value []string) (string, error) {
+		if len(value) == 0 {
+			return "at least one resource must be selected", nil
+		}
+		for _, element := range value {
+			if _, err := kubernetes.ParseResource(element); err != nil {
+				return err.Error(), nil
+			}
+		}
+		return "", nil
+	}).
+	WithConverter(formtask.NewSetFormElementConverter(func(ctx context.Context, value string) (kubernetes.Resource, error) {
+		return kubernetes.ParseResource(value)
+	})).
+	Build()
+
+// InputCaptureDurationTask defines a form task to input the duration to watch the resources.
+var InputCaptureDurationTask = formtask.NewTextFormTaskBuilder(k8slivecapture_contract.InputCaptureDurationTaskID, 0, "Capture duration").
+	WithPosition(inspectionmetadata.FormPosition{Section: k8slivecapture_contract.FormSectionCapture, After: []string{k8slivecapture_contract.InputResourcesTaskID.ReferenceIDString()}}).
+	WithPlaceholder("e.g. 5m").
+	WithDescription(fmt.Sprintf("The duration to watch the resources after starting the inspection, between %s and %s. The inspection finishes after the duration.", minCaptureDuration, maxCaptureDuration)).
+	WithDefaultValueFunc(previousValueOr("5m")).
+	WithValidator(func(ctx context.Context, value string) (string, error) {
+		if _, err := parseCaptureDuration(value); err != nil {
+			return err.Error(), nil
+		}
+		return "", nil
+	}).
+	WithConverter(func(ctx context.Context, value string) (time.Duration, error) {
+		return parseCaptureDuration(value)
+	}).
+	Build()
+
+// WatchClientTask provides the client of the Kubernetes API server resolved from the kubeconfig.
+var WatchClientTask = inspectiontaskbase.NewInspectionTask(k8slivecapture_contract.WatchClientTaskID, []taskid.UntypedTaskReference{
+	k8slivecapture_contract.InputKubeconfigPathTaskID.Ref(),
+	k8slivecapture_contract.InputContextTaskID.Ref(),
+}, func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (kubernetes.WatchAPI, error) {
+	if taskMode == inspectioncore_contract.TaskModeDryRun {
+		return nil, nil
+	}
+	kubeconfigPath := coretask.GetTaskResult(ctx, k8slivecapture_contract.InputKubeconfigPathTaskID.Ref())
+	contextName := coretask.GetTaskResult(ctx, k8slivecapture_contract.InputContextTaskID.Ref())
+	config, err := kubernetes.LoadKubeconfigFile(kubeconfigPath, contextName)
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewClient(config)
+})
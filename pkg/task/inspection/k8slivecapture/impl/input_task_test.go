@@ -0,0 +1,52 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8slivecapture_impl
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCaptureDuration(t *testing.T) {
+	testCases := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "5m", want: 5 * time.Minute},
+		{value: " 30s ", want: 30 * time.Second},
+		{value: "1h", want: time.Hour},
+		{value: "5s", wantErr: true},
+		{value: "2h", wantErr: true},
+		{value: "five minutes", wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			got, err := parseCaptureDuration(tc.value)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("parseCaptureDuration(%q) returned no error, want an error", tc.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseCaptureDuration(%q) returned an unexpected error: %v", tc.value, err)
+			}
+			if got != tc.want {
+				t.Errorf("parseCaptureDuration(%q) = %v, want %v", tc.value, got, tc.want)
+			}
+		})
+	}
+}
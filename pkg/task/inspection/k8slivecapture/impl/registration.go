@@ -0,0 +1,40 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8slivecapture_impl
+
+import (
+	coreinspection "github.com/kyasbal/khi/pkg/core/inspection"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	k8slivecapture_contract "github.com/kyasbal/khi/pkg/task/inspection/k8slivecapture/contract"
+)
+
+// Register registers all k8slivecapture inspection tasks to the registry.
+func Register(registry coreinspection.InspectionTaskRegistry) error {
+	err := registry.AddInspectionType(k8slivecapture_contract.K8sLiveCaptureInspectionType)
+	if err != nil {
+		return err
+	}
+
+	return coretask.RegisterTasks(registry,
+		InputKubeconfigPathTask,
+		InputContextTask,
+		InputResourcesTask,
+		InputCaptureDurationTask,
+		WatchClientTask,
+		AuditLogCaptureTask,
+		K8sLiveCaptureAuditLogFieldExtractorTask,
+		K8sLiveCaptureAuditLogParserTailTask,
+	)
+}
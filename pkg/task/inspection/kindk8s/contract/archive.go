@@ -0,0 +1,131 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kindk8s_contract
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/server/upload"
+)
+
+// maxLineSizeInBytes is the maximum size of a line in log files in the archive.
+const maxLineSizeInBytes = 16 * 1024 * 1024
+
+var (
+	// nodeLogFilePattern matches `<node>/kubelet.log` and `<node>/containerd.log` written by journalctl for each node.
+	nodeLogFilePattern = regexp.MustCompile(`(?:^|/)([^/]+)/(kubelet|containerd)\.log$`)
+	// containerLogFilePattern matches `<node>/pods/<namespace>_<pod>_<uid>/<container>/<restart count>.log` copied from `/var/log/pods` of each node.
+	containerLogFilePattern = regexp.MustCompile(`(?:^|/)([^/]+)/pods/([^_/]+)_([^_/]+)_[^/]+/([^/]+)/\d+\.log(?:\.gz)?$`)
+)
+
+// NodeLogFile is the lines of a log file written by journalctl in the archive.
+type NodeLogFile struct {
+	Path     string
+	NodeName string
+	// Unit is the systemd unit name without `.service` the log was written from.
+	Unit string
+	// ModTime is the modification time of the file. It is used to infer the year missing in the timestamps of journalctl outputs.
+	ModTime time.Time
+	Lines   []string
+}
+
+// ContainerLogFile is the lines of a container log file written by the container runtime in the archive.
+type ContainerLogFile struct {
+	Path          string
+	NodeName      string
+	Namespace     string
+	PodName       string
+	ContainerName string
+	Lines         []string
+}
+
+// KindLogs is the logs read from the archive of `kind export logs`.
+type KindLogs struct {
+	NodeLogs      []*NodeLogFile
+	ContainerLogs []*ContainerLogFile
+	// SkippedFiles is the count of files not matching any known layout or failed to be read.
+	SkippedFiles int
+}
+
+// ReadKindLogs walks the archive of the directory written by `kind export logs` and reads the node and the container logs.
+// Files under `containers/` are ignored because they are the links to the files under `pods/`.
+func ReadKindLogs(reader io.Reader) (*KindLogs, error) {
+	result := &KindLogs{}
+	err := upload.WalkArchive(reader, func(file *upload.ArchiveFile) error {
+		filePath := strings.TrimPrefix(path.Clean("/"+file.Name), "/")
+		if match := nodeLogFilePattern.FindStringSubmatch(filePath); match != nil {
+			lines, err := readLines(file.Content, false)
+			if err != nil {
+				result.SkippedFiles++
+				return nil
+			}
+			result.NodeLogs = append(result.NodeLogs, &NodeLogFile{
+				Path:     filePath,
+				NodeName: match[1],
+				Unit:     match[2],
+				ModTime:  file.ModTime,
+				Lines:    lines,
+			})
+			return nil
+		}
+		if match := containerLogFilePattern.FindStringSubmatch(filePath); match != nil {
+			lines, err := readLines(file.Content, strings.HasSuffix(filePath, ".gz"))
+			if err != nil {
+				result.SkippedFiles++
+				return nil
+			}
+			result.ContainerLogs = append(result.ContainerLogs, &ContainerLogFile{
+				Path:          filePath,
+				NodeName:      match[1],
+				Namespace:     match[2],
+				PodName:       match[3],
+				ContainerName: match[4],
+				Lines:         lines,
+			})
+			return nil
+		}
+		result.SkippedFiles++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// readLines returns the lines of the content. The content is decompressed when gzipped is true.
+func readLines(content io.Reader, gzipped bool) ([]string, error) {
+	if gzipped {
+		gzipReader, err := gzip.NewReader(content)
+		if err != nil {
+			return nil, err
+		}
+		defer gzipReader.Close()
+		content = gzipReader
+	}
+	var lines []string
+	scanner := bufio.NewScanner(content)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSizeInBytes)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kindk8s_contract
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/testutil/testupload"
+)
+
+func TestReadKindLogs(t *testing.T) {
+	modTime := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	archive := testupload.Zip(t, []testupload.File{
+		{Name: "kind-logs/kind-version.txt", Content: "kind v0.27.0", ModTime: modTime},
+		{Name: "kind-logs/kind-control-plane/kubelet.log", Content: "Jan 01 00:00:00 kind-control-plane kubelet[100]: I0101 started\n", ModTime: modTime},
+		{Name: "kind-logs/kind-control-plane/journal.log", Content: "Jan 01 00:00:00 kind-control-plane systemd[1]: Started\n", ModTime: modTime},
+		{Name: "kind-logs/kind-worker/pods/kube-system_kindnet-abcde_0123-4567/kindnet-cni/0.log", Content: "2025-01-01T00:00:00Z stdout F started\n", ModTime: modTime},
+		{Name: "kind-logs/kind-worker/containers/kindnet-abcde_kube-system_kindnet-cni-0123.log", Content: "2025-01-01T00:00:00Z stdout F started\n", ModTime: modTime},
+	})
+
+	got, err := ReadKindLogs(strings.NewReader(archive))
+	if err != nil {
+		t.Fatalf("ReadKindLogs() returned an unexpected error: %v", err)
+	}
+	want := &KindLogs{
+		NodeLogs: []*NodeLogFile{
+			{
+				Path:     "kind-logs/kind-control-plane/kubelet.log",
+				NodeName: "kind-control-plane",
+				Unit:     "kubelet",
+				ModTime:  modTime,
+				Lines:    []string{"Jan 01 00:00:00 kind-control-plane kubelet[100]: I0101 started"},
+			},
+		},
+		ContainerLogs: []*ContainerLogFile{
+			{
+				Path:          "kind-logs/kind-worker/pods/kube-system_kindnet-abcde_0123-4567/kindnet-cni/0.log",
+				NodeName:      "kind-worker",
+				Namespace:     "kube-system",
+				PodName:       "kindnet-abcde",
+				ContainerName: "kindnet-cni",
+				Lines:         []string{"2025-01-01T00:00:00Z stdout F started"},
+			},
+		},
+		SkippedFiles: 3,
+	}
+	if diff := cmp.Diff(want, got, cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })); diff != "" {
+		t.Errorf("ReadKindLogs() mismatch (-want +got):\n%s", diff)
+	}
+}
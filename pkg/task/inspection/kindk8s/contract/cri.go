@@ -0,0 +1,138 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kindk8s_contract
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/common/structured"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudlogk8scontainer_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8scontainer/contract"
+)
+
+// containerLogFileField is the field added on each container log to hold the file and the container it was read from.
+const containerLogFileField = "kind"
+
+// CRILogEntry is a log entry written by the container runtime in the CRI logging format.
+type CRILogEntry struct {
+	// LineIndex is the index of the first line of the entry in the file.
+	LineIndex int
+	Timestamp time.Time
+	// Stream is `stdout` or `stderr`.
+	Stream  string
+	Message string
+}
+
+// ReadCRILogEntries parses the lines written in the CRI logging format like `2025-01-01T00:00:00.000000000Z stdout F message`.
+// Partial lines tagged with `P` are joined with the following lines up to the line tagged with `F`. Lines not in the format are ignored.
+func ReadCRILogEntries(lines []string) []*CRILogEntry {
+	var result []*CRILogEntry
+	var partial *CRILogEntry
+	for i, line := range lines {
+		parts := strings.SplitN(line, " ", 4)
+		if len(parts) < 3 {
+			continue
+		}
+		timestamp, err := time.Parse(time.RFC3339Nano, parts[0])
+		if err != nil {
+			continue
+		}
+		message := ""
+		if len(parts) == 4 {
+			message = parts[3]
+		}
+		if partial == nil {
+			partial = &CRILogEntry{LineIndex: i, Timestamp: timestamp, Stream: parts[1]}
+		}
+		partial.Message += message
+		if !strings.HasPrefix(parts[2], "P") {
+			result = append(result, partial)
+			partial = nil
+		}
+	}
+	if partial != nil {
+		result = append(result, partial)
+	}
+	return result
+}
+
+// NewLogFromCRILogEntry converts an entry of the container log file to a log.
+func NewLogFromCRILogEntry(file *ContainerLogFile, entry *CRILogEntry) (*log.Log, error) {
+	body := map[string]any{
+		"timestamp": entry.Timestamp.Format(time.RFC3339Nano),
+		"stream":    entry.Stream,
+		"message":   entry.Message,
+		containerLogFileField: map[string]any{
+			"path":      file.Path,
+			"line":      entry.LineIndex + 1,
+			"node":      file.NodeName,
+			"namespace": file.Namespace,
+			"pod":       file.PodName,
+			"container": file.ContainerName,
+		},
+	}
+	serialized, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	return log.NewLogFromYAMLString(string(serialized))
+}
+
+// KindContainerLogCommonFieldSetReader implements log.FieldSetReader for log.CommonFieldSet{} from container logs read from the archive.
+type KindContainerLogCommonFieldSetReader struct{}
+
+// FieldSetKind implements log.FieldSetReader.
+func (k *KindContainerLogCommonFieldSetReader) FieldSetKind() string {
+	return (&log.CommonFieldSet{}).Kind()
+}
+
+// Read implements log.FieldSetReader.
+func (k *KindContainerLogCommonFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	result := &log.CommonFieldSet{}
+	result.DisplayID = fmt.Sprintf("%s:%d", reader.ReadStringOrDefault(containerLogFileField+".path", "unknown"), reader.ReadIntOrDefault(containerLogFileField+".line", 0))
+	timestamp, err := reader.ReadTimestamp("timestamp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the timestamp of the container log: %w", err)
+	}
+	result.Timestamp = timestamp
+	result.Severity = enum.SeverityUnknown
+	return result, nil
+}
+
+var _ log.FieldSetReader = (*KindContainerLogCommonFieldSetReader)(nil)
+
+// KindContainerLogFieldSetReader implements log.FieldSetReader for googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet{} from container logs read from the archive.
+type KindContainerLogFieldSetReader struct{}
+
+// FieldSetKind implements log.FieldSetReader.
+func (k *KindContainerLogFieldSetReader) FieldSetKind() string {
+	return (&googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet{}).Kind()
+}
+
+// Read implements log.FieldSetReader.
+func (k *KindContainerLogFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	return &googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet{
+		Namespace:     reader.ReadStringOrDefault(containerLogFileField+".namespace", "unknown"),
+		PodName:       reader.ReadStringOrDefault(containerLogFileField+".pod", "unknown"),
+		ContainerName: reader.ReadStringOrDefault(containerLogFileField+".container", "unknown"),
+		Message:       reader.ReadStringOrDefault("message", ""),
+	}, nil
+}
+
+var _ log.FieldSetReader = (*KindContainerLogFieldSetReader)(nil)
@@ -0,0 +1,76 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kindk8s_contract
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudlogk8scontainer_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8scontainer/contract"
+)
+
+func TestReadCRILogEntries(t *testing.T) {
+	lines := []string{
+		"2025-01-01T00:00:00.000000001Z stdout F started",
+		"2025-01-01T00:00:01Z stderr P a long ",
+		"2025-01-01T00:00:01Z stderr P line split ",
+		"2025-01-01T00:00:01Z stderr F by the runtime",
+		"not a CRI log line",
+		"2025-01-01T00:00:02Z stdout F",
+	}
+	want := []*CRILogEntry{
+		{LineIndex: 0, Timestamp: time.Date(2025, 1, 1, 0, 0, 0, 1, time.UTC), Stream: "stdout", Message: "started"},
+		{LineIndex: 1, Timestamp: time.Date(2025, 1, 1, 0, 0, 1, 0, time.UTC), Stream: "stderr", Message: "a long line split by the runtime"},
+		{LineIndex: 5, Timestamp: time.Date(2025, 1, 1, 0, 0, 2, 0, time.UTC), Stream: "stdout", Message: ""},
+	}
+	got := ReadCRILogEntries(lines)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadCRILogEntries() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewLogFromCRILogEntry(t *testing.T) {
+	file := &ContainerLogFile{Path: "kind-worker/pods/default_nginx-0_0123/nginx/0.log", NodeName: "kind-worker", Namespace: "default", PodName: "nginx-0", ContainerName: "nginx"}
+	entry := &CRILogEntry{LineIndex: 9, Timestamp: time.Date(2025, 1, 1, 0, 0, 0, 1, time.UTC), Stream: "stdout", Message: "started"}
+	l, err := NewLogFromCRILogEntry(file, entry)
+	if err != nil {
+		t.Fatalf("NewLogFromCRILogEntry() returned an unexpected error: %v", err)
+	}
+	err = l.SetFieldSetReader(&KindContainerLogCommonFieldSetReader{})
+	if err != nil {
+		t.Fatalf("SetFieldSetReader() returned an unexpected error: %v", err)
+	}
+	err = l.SetFieldSetReader(&KindContainerLogFieldSetReader{})
+	if err != nil {
+		t.Fatalf("SetFieldSetReader() returned an unexpected error: %v", err)
+	}
+
+	commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+	if !commonFieldSet.Timestamp.Equal(entry.Timestamp) {
+		t.Errorf("Timestamp = %v, want %v", commonFieldSet.Timestamp, entry.Timestamp)
+	}
+	if want := file.Path + ":10"; commonFieldSet.DisplayID != want {
+		t.Errorf("DisplayID = %q, want %q", commonFieldSet.DisplayID, want)
+	}
+	containerFieldSet := log.MustGetFieldSet(l, &googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet{})
+	if got, want := containerFieldSet.ResourcePath().Path, "core/v1#pod#default#nginx-0#nginx"; got != want {
+		t.Errorf("ResourcePath() = %q, want %q", got, want)
+	}
+	if containerFieldSet.Message != "started" {
+		t.Errorf("Message = %q, want %q", containerFieldSet.Message, "started")
+	}
+}
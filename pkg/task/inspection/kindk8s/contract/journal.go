@@ -0,0 +1,85 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kindk8s_contract
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+// journalLinePattern matches a line written by journalctl in the default `short` output format like `Jan 02 15:04:05 kind-control-plane kubelet[123]: message`.
+var journalLinePattern = regexp.MustCompile(`^([A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2}(?:\.\d{1,6})?) (\S+) ([^\s\[:]+)(?:\[(\d+)\])?: (.*)$`)
+
+// JournalLine is a line parsed from outputs of journalctl.
+type JournalLine struct {
+	Timestamp  time.Time
+	Hostname   string
+	Identifier string
+	PID        string
+	Message    string
+}
+
+// ParseJournalLine parses a line written by journalctl in the default `short` output format.
+// The output omits the year, thus the year is inferred to place the timestamp before the reference time, which is usually the time the file was written.
+// It returns false for the lines not in the format like `-- Boot ... --` or the continued lines of multi line messages.
+func ParseJournalLine(line string, reference time.Time) (*JournalLine, bool) {
+	match := journalLinePattern.FindStringSubmatch(line)
+	if match == nil {
+		return nil, false
+	}
+	timestamp, err := time.Parse("2006 Jan _2 15:04:05", fmt.Sprintf("%d %s", reference.Year(), match[1]))
+	if err != nil {
+		return nil, false
+	}
+	// Allow the clock skew between the node and the host writing the archive.
+	if timestamp.After(reference.Add(24 * time.Hour)) {
+		timestamp = timestamp.AddDate(-1, 0, 0)
+	}
+	return &JournalLine{
+		Timestamp:  timestamp,
+		Hostname:   match[2],
+		Identifier: match[3],
+		PID:        match[4],
+		Message:    match[5],
+	}, true
+}
+
+// NewLogFromJournalLine converts a line of the node log file to a log in the shape of `journalctl -o json` outputs.
+// This lets the readers of journald logs for OSS clusters handle the node logs of kind clusters.
+func NewLogFromJournalLine(file *NodeLogFile, lineIndex int, line *JournalLine) (*log.Log, error) {
+	hostname := line.Hostname
+	if hostname == "" {
+		hostname = file.NodeName
+	}
+	body := map[string]any{
+		"__CURSOR":             fmt.Sprintf("%s:%d", file.Path, lineIndex+1),
+		"__REALTIME_TIMESTAMP": strconv.FormatInt(line.Timestamp.UnixMicro(), 10),
+		"_HOSTNAME":            hostname,
+		"_SYSTEMD_UNIT":        file.Unit + ".service",
+		"SYSLOG_IDENTIFIER":    line.Identifier,
+		"_PID":                 line.PID,
+		"MESSAGE":              line.Message,
+	}
+	serialized, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	return log.NewLogFromYAMLString(string(serialized))
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kindk8s_contract
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/model/log"
+	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+func TestParseJournalLine(t *testing.T) {
+	reference := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		desc   string
+		line   string
+		want   *JournalLine
+		wantOk bool
+	}{
+		{
+			desc: "kubelet log",
+			line: "Jan 01 12:34:56 kind-control-plane kubelet[123]: I0101 12:34:56.000000     123 kubelet.go:10] started",
+			want: &JournalLine{
+				Timestamp:  time.Date(2025, 1, 1, 12, 34, 56, 0, time.UTC),
+				Hostname:   "kind-control-plane",
+				Identifier: "kubelet",
+				PID:        "123",
+				Message:    "I0101 12:34:56.000000     123 kubelet.go:10] started",
+			},
+			wantOk: true,
+		},
+		{
+			desc: "log in the previous year",
+			line: "Dec 31 23:59:59 kind-worker containerd[45]: time=\"2024-12-31T23:59:59Z\" level=info msg=started",
+			want: &JournalLine{
+				Timestamp:  time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC),
+				Hostname:   "kind-worker",
+				Identifier: "containerd",
+				PID:        "45",
+				Message:    "time=\"2024-12-31T23:59:59Z\" level=info msg=started",
+			},
+			wantOk: true,
+		},
+		{
+			desc: "log without pid",
+			line: "Jan  1 00:00:00 kind-worker systemd: Started kubelet",
+			want: &JournalLine{
+				Timestamp:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				Hostname:   "kind-worker",
+				Identifier: "systemd",
+				Message:    "Started kubelet",
+			},
+			wantOk: true,
+		},
+		{
+			desc:   "boot separator",
+			line:   "-- Boot 0123456789abcdef --",
+			wantOk: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, ok := ParseJournalLine(tc.line, reference)
+			if ok != tc.wantOk {
+				t.Fatalf("ParseJournalLine() returned ok=%v, want %v", ok, tc.wantOk)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ParseJournalLine() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewLogFromJournalLine(t *testing.T) {
+	file := &NodeLogFile{Path: "kind-control-plane/kubelet.log", NodeName: "kind-control-plane", Unit: "kubelet"}
+	line := &JournalLine{
+		Timestamp:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Identifier: "kubelet",
+		PID:        "123",
+		Message:    "started",
+	}
+	l, err := NewLogFromJournalLine(file, 4, line)
+	if err != nil {
+		t.Fatalf("NewLogFromJournalLine() returned an unexpected error: %v", err)
+	}
+	err = l.SetFieldSetReader(&ossclusterk8s_contract.OSSJournaldCommonFieldSetReader{})
+	if err != nil {
+		t.Fatalf("SetFieldSetReader() returned an unexpected error: %v", err)
+	}
+
+	commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+	if !commonFieldSet.Timestamp.Equal(line.Timestamp) {
+		t.Errorf("Timestamp = %v, want %v", commonFieldSet.Timestamp, line.Timestamp)
+	}
+	if want := "kind-control-plane/kubelet.log:5"; commonFieldSet.DisplayID != want {
+		t.Errorf("DisplayID = %q, want %q", commonFieldSet.DisplayID, want)
+	}
+	if got := l.ReadStringOrDefault("_HOSTNAME", ""); got != "kind-control-plane" {
+		t.Errorf("_HOSTNAME = %q, want the node name as the fallback", got)
+	}
+}
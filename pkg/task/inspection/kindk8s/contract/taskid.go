@@ -0,0 +1,56 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kindk8s_contract
+
+import (
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/log"
+	"github.com/kyasbal/khi/pkg/server/upload"
+	googlecloudlogk8snode_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8snode/contract"
+)
+
+// KindTaskPrefix is the prefixes of IDs used in kind related tasks.
+const KindTaskPrefix = "khi.google.com/kind/"
+
+// InputLogArchiveTaskID is the task ID for the form to upload the archive of the logs exported with `kind export logs`.
+var InputLogArchiveTaskID = taskid.NewDefaultImplementationID[upload.UploadResult](KindTaskPrefix + "form/log-archive")
+
+// LogArchiveReaderTaskID is the task ID to walk the uploaded archive and collect the node and container logs.
+var LogArchiveReaderTaskID = taskid.NewDefaultImplementationID[*KindLogs](KindTaskPrefix + "log-archive-reader")
+
+// NodeLogReaderTaskID is the task ID to read the node logs in the archive as the source of the node log parsers.
+var NodeLogReaderTaskID = taskid.NewImplementationID(googlecloudlogk8snode_contract.ListLogEntriesTaskID.Ref(), "kind")
+
+// NodeLogCommonFieldSetReaderTaskID is the task ID to read the fieldset used by the node log parsers from the node logs in the archive.
+var NodeLogCommonFieldSetReaderTaskID = taskid.NewImplementationID(googlecloudlogk8snode_contract.CommonFieldsetReaderTaskID.Ref(), "kind")
+
+// NodeLogParserTailTaskID is the task ID of the feature task to parse the node logs in the archive.
+var NodeLogParserTailTaskID = taskid.NewDefaultImplementationID[struct{}](KindTaskPrefix + "node-log-parser-tail")
+
+// ContainerLogReaderTaskID is the task ID to read the container logs in the archive.
+var ContainerLogReaderTaskID = taskid.NewDefaultImplementationID[[]*log.Log](KindTaskPrefix + "container-log-reader")
+
+// ContainerLogFieldSetReaderTaskID is the task ID to read the container log fieldset from the container logs.
+var ContainerLogFieldSetReaderTaskID = taskid.NewDefaultImplementationID[[]*log.Log](KindTaskPrefix + "container-log-fieldset-reader")
+
+// ContainerLogIngesterTaskID is the task ID to ingest the container logs to the history.
+var ContainerLogIngesterTaskID = taskid.NewDefaultImplementationID[[]*log.Log](KindTaskPrefix + "container-log-ingester")
+
+// ContainerLogGrouperTaskID is the task ID to group the container logs by the container.
+var ContainerLogGrouperTaskID = taskid.NewDefaultImplementationID[inspectiontaskbase.LogGroupMap](KindTaskPrefix + "container-log-grouper")
+
+// ContainerLogToTimelineMapperTaskID is the task ID of the feature task to map the container logs to the timelines of the containers.
+var ContainerLogToTimelineMapperTaskID = taskid.NewDefaultImplementationID[struct{}](KindTaskPrefix + "container-log-mapper")
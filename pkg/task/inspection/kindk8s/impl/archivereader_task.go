@@ -0,0 +1,63 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kindk8s_impl
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/server/upload"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	kindk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/kindk8s/contract"
+)
+
+// InputLogArchiveTask is a form task to upload the archive of the logs exported with `kind export logs`.
+var InputLogArchiveTask = formtask.NewFileFormTaskBuilder(kindk8s_contract.InputLogArchiveTaskID, 1000, "kind log archive", &upload.ArchiveUploadFileVerifier{}).
+	WithDescription("Upload the directory written by `kind export logs` archived in `.zip`, `.tar` or `.tar.gz` (e.g. `kind export logs ./kind-logs && tar czf kind-logs.tar.gz kind-logs`). `kubelet.log` and `containerd.log` of each node and the container logs under `pods/` are read. Archives of `/var/log/pods` copied from other local clusters like minikube are also read when they are placed under a directory named after the node.").
+	WithMarkdown().
+	Build()
+
+// LogArchiveReaderTask walks the uploaded archive and reads the node and the container logs.
+var LogArchiveReaderTask = inspectiontaskbase.NewProgressReportableInspectionTask(
+	kindk8s_contract.LogArchiveReaderTaskID,
+	[]taskid.UntypedTaskReference{
+		kindk8s_contract.InputLogArchiveTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, tp *inspectionmetadata.TaskProgressMetadata) (*kindk8s_contract.KindLogs, error) {
+		if taskMode == inspectioncore_contract.TaskModeDryRun {
+			return &kindk8s_contract.KindLogs{}, nil
+		}
+		result := coretask.GetTaskResult(ctx, kindk8s_contract.InputLogArchiveTaskID.Ref())
+		tp.MarkIndeterminate()
+		reader, err := result.GetReader()
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+
+		logs, err := kindk8s_contract.ReadKindLogs(reader)
+		if err != nil {
+			return nil, err
+		}
+		slog.InfoContext(ctx, fmt.Sprintf("read the kind log archive: %d node log files, %d container log files, %d files skipped", len(logs.NodeLogs), len(logs.ContainerLogs), logs.SkippedFiles))
+		return logs, nil
+	},
+)
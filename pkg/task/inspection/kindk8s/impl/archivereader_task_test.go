@@ -0,0 +1,119 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kindk8s_impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	"github.com/kyasbal/khi/pkg/server/upload"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	kindk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/kindk8s/contract"
+	"github.com/kyasbal/khi/pkg/testutil/testupload"
+)
+
+// fixtureArchiveModTime is the modification time of the files in the fixture archive used to infer the year of journal lines.
+var fixtureArchiveModTime = time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+// fixtureArchiveFiles are the files of a small archive written by `kind export logs`.
+var fixtureArchiveFiles = []testupload.File{
+	{Name: "kind-logs/kind-version.txt", Content: "kind v0.27.0", ModTime: fixtureArchiveModTime},
+	{Name: "kind-logs/kind-control-plane/kubelet.log", Content: `Jan 01 00:00:02 kind-control-plane kubelet[100]: I0101 00:00:02.000000     100 kubelet.go:100] second
+-- Boot 0123456789abcdef --
+Jan 01 00:00:01 kind-control-plane kubelet[100]: I0101 00:00:01.000000     100 kubelet.go:100] first
+`, ModTime: fixtureArchiveModTime},
+	{Name: "kind-logs/kind-control-plane/containerd.log", Content: "Jan 01 00:00:03 kind-control-plane containerd[50]: time=\"2025-01-01T00:00:03Z\" level=info msg=\"started\"\n", ModTime: fixtureArchiveModTime},
+	{Name: "kind-logs/kind-worker/pods/kube-system_kindnet-abcde_0123-4567/kindnet-cni/0.log", Content: "2025-01-01T00:00:00Z stdout F started\n", ModTime: fixtureArchiveModTime},
+}
+
+func TestLogArchiveReaderTask(t *testing.T) {
+	type readFile struct {
+		Path     string
+		NodeName string
+		Lines    int
+	}
+	testCases := []struct {
+		desc              string
+		uploadResult      func(t *testing.T) upload.UploadResult
+		wantNodeLogs      []readFile
+		wantContainerLogs []readFile
+		wantSkippedFiles  int
+		wantErr           bool
+	}{
+		{
+			desc: "archive of kind export logs",
+			uploadResult: func(t *testing.T) upload.UploadResult {
+				return testupload.UploadFile(t, testupload.TarGz(t, fixtureArchiveFiles))
+			},
+			wantNodeLogs: []readFile{
+				{Path: "kind-logs/kind-control-plane/containerd.log", NodeName: "kind-control-plane", Lines: 1},
+				{Path: "kind-logs/kind-control-plane/kubelet.log", NodeName: "kind-control-plane", Lines: 3},
+			},
+			wantContainerLogs: []readFile{
+				{Path: "kind-logs/kind-worker/pods/kube-system_kindnet-abcde_0123-4567/kindnet-cni/0.log", NodeName: "kind-worker", Lines: 1},
+			},
+			wantSkippedFiles: 1,
+		},
+		{
+			desc: "upload not completed",
+			uploadResult: func(t *testing.T) upload.UploadResult {
+				result := testupload.UploadFile(t, testupload.TarGz(t, fixtureArchiveFiles))
+				result.Status = upload.UploadStatusWaiting
+				return result
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+			got, _, err := inspectiontest.RunInspectionTask(ctx, LogArchiveReaderTask, inspectioncore_contract.TaskModeRun, map[string]any{},
+				tasktest.NewTaskDependencyValuePair(kindk8s_contract.InputLogArchiveTaskID.Ref(), tc.uploadResult(t)),
+			)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("LogArchiveReaderTask returned no error, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LogArchiveReaderTask returned an unexpected error: %v", err)
+			}
+
+			var gotNodeLogs []readFile
+			for _, file := range got.NodeLogs {
+				gotNodeLogs = append(gotNodeLogs, readFile{Path: file.Path, NodeName: file.NodeName, Lines: len(file.Lines)})
+			}
+			var gotContainerLogs []readFile
+			for _, file := range got.ContainerLogs {
+				gotContainerLogs = append(gotContainerLogs, readFile{Path: file.Path, NodeName: file.NodeName, Lines: len(file.Lines)})
+			}
+			sortByPath := cmpopts.SortSlices(func(a, b readFile) bool { return a.Path < b.Path })
+			if diff := cmp.Diff(tc.wantNodeLogs, gotNodeLogs, sortByPath); diff != "" {
+				t.Errorf("node logs mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantContainerLogs, gotContainerLogs, sortByPath); diff != "" {
+				t.Errorf("container logs mismatch (-want +got):\n%s", diff)
+			}
+			if got.SkippedFiles != tc.wantSkippedFiles {
+				t.Errorf("SkippedFiles = %d, want %d", got.SkippedFiles, tc.wantSkippedFiles)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kindk8s_impl
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/logutil"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudlogk8snode_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8snode/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	kindk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/kindk8s/contract"
+	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// NodeLogReaderTask reads the kubelet and containerd logs of each node in the archive in place of the node logs queried from Cloud Logging.
+// The lines are converted to the shape of journald logs, thus the node log parsers process them as the node logs of OSS clusters.
+var NodeLogReaderTask = inspectiontaskbase.NewProgressReportableInspectionTask(
+	kindk8s_contract.NodeLogReaderTaskID,
+	[]taskid.UntypedTaskReference{
+		kindk8s_contract.LogArchiveReaderTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, tp *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, error) {
+		if taskMode == inspectioncore_contract.TaskModeDryRun {
+			return []*log.Log{}, nil
+		}
+		kindLogs := coretask.GetTaskResult(ctx, kindk8s_contract.LogArchiveReaderTaskID.Ref())
+		tp.MarkIndeterminate()
+
+		var logs []*log.Log
+		for _, file := range kindLogs.NodeLogs {
+			reference := file.ModTime
+			if reference.IsZero() {
+				reference = time.Now()
+			}
+			skippedLines := 0
+			for i, line := range file.Lines {
+				journalLine, ok := kindk8s_contract.ParseJournalLine(line, reference)
+				if !ok {
+					skippedLines++
+					continue
+				}
+				l, err := kindk8s_contract.NewLogFromJournalLine(file, i, journalLine)
+				if err != nil {
+					return nil, err
+				}
+				err = l.SetFieldSetReader(&ossclusterk8s_contract.OSSJournaldCommonFieldSetReader{})
+				if err != nil {
+					return nil, err
+				}
+				l.LogType = enum.LogTypeNode
+				logs = append(logs, l)
+			}
+			if skippedLines > 0 {
+				slog.DebugContext(ctx, fmt.Sprintf("%d lines not in the journalctl output format were ignored in %s", skippedLines, file.Path))
+			}
+		}
+
+		sortLogsByTimestamp(logs)
+		extendHeaderTimeRange(ctx, logs)
+
+		return logs, nil
+	},
+	coretask.WithSelectionPriority(1000),
+	inspectioncore_contract.InspectionTypeLabel(kindk8s_contract.InspectionTypeID),
+)
+
+// NodeLogCommonFieldSetReaderTask reads the fieldset used by the node log parsers from the node logs in the archive instead of the logs from Cloud Logging.
+var NodeLogCommonFieldSetReaderTask = inspectiontaskbase.NewFieldSetReadTask(
+	kindk8s_contract.NodeLogCommonFieldSetReaderTaskID,
+	googlecloudlogk8snode_contract.ListLogEntriesTaskID.Ref(),
+	[]log.FieldSetReader{
+		&ossclusterk8s_contract.OSSJournaldNodeLogFieldSetReader{
+			StructuredLogParser: logutil.NewMultiTextLogParser(
+				logutil.NewJsonlTextParser(),
+				logutil.NewKLogTextParser(true),
+				logutil.NewLogfmtTextParser(),
+				&logutil.FallbackRawTextLogParser{},
+			),
+		},
+	},
+	coretask.WithSelectionPriority(1000),
+	inspectioncore_contract.InspectionTypeLabel(kindk8s_contract.InspectionTypeID),
+)
+
+// NodeLogParserTailTask is the feature task to generate node scoped timelines from the node logs in the archive.
+var NodeLogParserTailTask = inspectiontaskbase.NewInspectionTask(
+	kindk8s_contract.NodeLogParserTailTaskID,
+	[]taskid.UntypedTaskReference{
+		googlecloudlogk8snode_contract.ContainerdLogLogToTimelineMapperTaskID.Ref(),
+		googlecloudlogk8snode_contract.KubeletLogLogToTimelineMapperTaskID.Ref(),
+		googlecloudlogk8snode_contract.OtherLogLogToTimelineMapperTaskID.Ref(),
+
+		googlecloudlogk8snode_contract.ContainerIDDiscoveryTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (struct{}, error) {
+		return struct{}{}, nil
+	},
+	inspectioncore_contract.FeatureTaskLabel("Kubernetes Node Logs", `Gather kubelet and containerd logs of each node in the archive to visualize them on node and container timelines.`, enum.LogTypeNode, 1003, true, kindk8s_contract.InspectionTypeID), coretask.NewSubsequentTaskRefsTaskLabel(inspectioncore_contract.SerializerTaskID.Ref()),
+)
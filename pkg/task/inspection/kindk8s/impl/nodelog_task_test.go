@@ -0,0 +1,100 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kindk8s_impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	kindk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/kindk8s/contract"
+	"github.com/kyasbal/khi/pkg/testutil/testupload"
+)
+
+func TestNodeLogReaderTask(t *testing.T) {
+	type nodeLog struct {
+		DisplayID string
+		Timestamp time.Time
+		Unit      string
+		Message   string
+	}
+
+	ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+	kindLogs, _, err := inspectiontest.RunInspectionTask(ctx, LogArchiveReaderTask, inspectioncore_contract.TaskModeRun, map[string]any{},
+		tasktest.NewTaskDependencyValuePair(kindk8s_contract.InputLogArchiveTaskID.Ref(), testupload.UploadFile(t, testupload.TarGz(t, fixtureArchiveFiles))),
+	)
+	if err != nil {
+		t.Fatalf("LogArchiveReaderTask returned an unexpected error: %v", err)
+	}
+
+	ctx = inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+	logs, _, err := inspectiontest.RunInspectionTask(ctx, NodeLogReaderTask, inspectioncore_contract.TaskModeRun, map[string]any{},
+		tasktest.NewTaskDependencyValuePair(kindk8s_contract.LogArchiveReaderTaskID.Ref(), kindLogs),
+	)
+	if err != nil {
+		t.Fatalf("NodeLogReaderTask returned an unexpected error: %v", err)
+	}
+
+	want := []nodeLog{
+		{
+			DisplayID: "kind-logs/kind-control-plane/kubelet.log:3",
+			Timestamp: time.Date(2025, 1, 1, 0, 0, 1, 0, time.UTC),
+			Unit:      "kubelet.service",
+			Message:   "I0101 00:00:01.000000     100 kubelet.go:100] first",
+		},
+		{
+			DisplayID: "kind-logs/kind-control-plane/kubelet.log:1",
+			Timestamp: time.Date(2025, 1, 1, 0, 0, 2, 0, time.UTC),
+			Unit:      "kubelet.service",
+			Message:   "I0101 00:00:02.000000     100 kubelet.go:100] second",
+		},
+		{
+			DisplayID: "kind-logs/kind-control-plane/containerd.log:1",
+			Timestamp: time.Date(2025, 1, 1, 0, 0, 3, 0, time.UTC),
+			Unit:      "containerd.service",
+			Message:   `time="2025-01-01T00:00:03Z" level=info msg="started"`,
+		},
+	}
+	var got []nodeLog
+	for _, l := range logs {
+		if l.LogType != enum.LogTypeNode {
+			t.Errorf("log type of %s = %v, want %v", l.ID, l.LogType, enum.LogTypeNode)
+		}
+		commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+		got = append(got, nodeLog{
+			DisplayID: commonFieldSet.DisplayID,
+			Timestamp: commonFieldSet.Timestamp,
+			Unit:      l.ReadStringOrDefault("_SYSTEMD_UNIT", ""),
+			Message:   l.ReadStringOrDefault("MESSAGE", ""),
+		})
+	}
+	if diff := cmp.Diff(want, got, cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })); diff != "" {
+		t.Errorf("node logs mismatch (-want +got):\n%s", diff)
+	}
+
+	metadataSet := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
+	header := typedmap.GetOrDefault(metadataSet, inspectionmetadata.HeaderMetadataKey, &inspectionmetadata.HeaderMetadata{})
+	if header.StartTimeUnixSeconds != want[0].Timestamp.Unix() || header.EndTimeUnixSeconds != want[2].Timestamp.Unix() {
+		t.Errorf("header time range = %d ~ %d, want %d ~ %d", header.StartTimeUnixSeconds, header.EndTimeUnixSeconds, want[0].Timestamp.Unix(), want[2].Timestamp.Unix())
+	}
+}
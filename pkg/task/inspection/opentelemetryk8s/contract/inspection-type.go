@@ -0,0 +1,31 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetryk8s_contract
+
+import (
+	"math"
+
+	coreinspection "github.com/kyasbal/khi/pkg/core/inspection"
+)
+
+const InspectionTypeID = "opentelemetry-log-files"
+
+var OpenTelemetryLogsInspectionType = coreinspection.InspectionType{
+	Id:          InspectionTypeID,
+	Name:        "OpenTelemetry log files",
+	Description: "Visualize logs exported in OTLP/JSON with the file exporter of the OpenTelemetry Collector on the timelines of the Kubernetes resources",
+	Icon:        "assets/icons/k8s.png",
+	Priority:    math.MaxInt - 1003,
+}
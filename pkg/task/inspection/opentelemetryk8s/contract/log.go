@@ -0,0 +1,213 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetryk8s_contract
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/common/structured"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/history/resourcepath"
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+// bodyMessageFieldNames is the list of field names read as the message from structured bodies in the order of priority.
+var bodyMessageFieldNames = []string{"message", "msg", "log"}
+
+// NewLogFromOTLPLogRecord converts a log record read from OTLP/JSON to a log typed from the Kubernetes resource emitted it.
+// Attributes are kept in the original keys for the log view, and the Kubernetes resource identified with the `k8s.*` attributes is written under `kubernetes` for the fieldset readers because their keys contain dots.
+func NewLogFromOTLPLogRecord(record *OTLPLogRecord) (*log.Log, error) {
+	body := map[string]any{
+		"timestamp":          record.Timestamp.Format(time.RFC3339Nano),
+		"severityNumber":     record.SeverityNumber,
+		"severityText":       record.SeverityText,
+		"body":               record.Body,
+		"message":            messageFromBody(record.Body),
+		"attributes":         record.Attributes,
+		"resourceAttributes": record.ResourceAttributes,
+		"scope": map[string]any{
+			"name":    record.ScopeName,
+			"version": record.ScopeVersion,
+		},
+		"traceId": record.TraceID,
+		"spanId":  record.SpanID,
+		"kubernetes": map[string]any{
+			"namespace": record.attribute("k8s.namespace.name"),
+			"pod":       record.attribute("k8s.pod.name"),
+			"container": record.attribute("k8s.container.name"),
+			"node":      record.attribute("k8s.node.name"),
+		},
+	}
+	serialized, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	hash := fnv.New64a()
+	hash.Write(serialized)
+	body["id"] = fmt.Sprintf("%016x", hash.Sum64())
+	serialized, err = json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	l, err := log.NewLogFromYAMLString(string(serialized))
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case record.attribute("k8s.pod.name") != "":
+		l.LogType = enum.LogTypeContainer
+	case record.attribute("k8s.node.name") != "":
+		l.LogType = enum.LogTypeNode
+	default:
+		l.LogType = enum.LogTypeUnknown
+	}
+	return l, nil
+}
+
+// attribute returns the string attribute of the resource, or the log record when the resource doesn't have it.
+func (r *OTLPLogRecord) attribute(key string) string {
+	if value, ok := r.ResourceAttributes[key].(string); ok && value != "" {
+		return value
+	}
+	if value, ok := r.Attributes[key].(string); ok {
+		return value
+	}
+	return ""
+}
+
+// messageFromBody returns the text shown as the summary of the log from the body.
+func messageFromBody(body any) string {
+	switch typedBody := body.(type) {
+	case nil:
+		return ""
+	case string:
+		return typedBody
+	case map[string]any:
+		for _, fieldName := range bodyMessageFieldNames {
+			if message, ok := typedBody[fieldName].(string); ok {
+				return message
+			}
+		}
+	}
+	serialized, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Sprint(body)
+	}
+	return string(serialized)
+}
+
+// severityFromOTLP converts the severity number of OTLP to the severity. The severity text is used when the number is unspecified.
+func severityFromOTLP(severityNumber int, severityText string) enum.Severity {
+	switch {
+	case severityNumber >= 21:
+		return enum.SeverityFatal
+	case severityNumber >= 17:
+		return enum.SeverityError
+	case severityNumber >= 13:
+		return enum.SeverityWarning
+	case severityNumber >= 1:
+		return enum.SeverityInfo
+	}
+	switch strings.ToUpper(severityText) {
+	case "FATAL", "CRITICAL":
+		return enum.SeverityFatal
+	case "ERROR":
+		return enum.SeverityError
+	case "WARN", "WARNING":
+		return enum.SeverityWarning
+	case "INFO", "DEBUG", "TRACE":
+		return enum.SeverityInfo
+	default:
+		return enum.SeverityUnknown
+	}
+}
+
+// OTLPLogCommonFieldSetReader implements log.FieldSetReader for log.CommonFieldSet{} from logs converted from OTLP/JSON.
+type OTLPLogCommonFieldSetReader struct{}
+
+// FieldSetKind implements log.FieldSetReader.
+func (o *OTLPLogCommonFieldSetReader) FieldSetKind() string {
+	return (&log.CommonFieldSet{}).Kind()
+}
+
+// Read implements log.FieldSetReader.
+func (o *OTLPLogCommonFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	result := &log.CommonFieldSet{}
+	result.DisplayID = reader.ReadStringOrDefault("id", "unknown")
+	timestamp, err := reader.ReadTimestamp("timestamp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the timestamp of the OTLP log record: %w", err)
+	}
+	result.Timestamp = timestamp
+	result.Severity = severityFromOTLP(reader.ReadIntOrDefault("severityNumber", 0), reader.ReadStringOrDefault("severityText", ""))
+	return result, nil
+}
+
+var _ log.FieldSetReader = (*OTLPLogCommonFieldSetReader)(nil)
+
+// OTLPLogFieldSet is the fieldset of the Kubernetes resource emitted the log record.
+type OTLPLogFieldSet struct {
+	Namespace     string
+	PodName       string
+	ContainerName string
+	NodeName      string
+	Message       string
+}
+
+// Kind implements log.FieldSet.
+func (o *OTLPLogFieldSet) Kind() string {
+	return "otlp_log"
+}
+
+// ResourcePath returns the resource path of the most specific resource identified with the attributes. It returns false when the log record has no Kubernetes resource attribute.
+func (o *OTLPLogFieldSet) ResourcePath() (resourcepath.ResourcePath, bool) {
+	switch {
+	case o.PodName != "" && o.ContainerName != "":
+		return resourcepath.Container(o.Namespace, o.PodName, o.ContainerName), true
+	case o.PodName != "":
+		return resourcepath.Pod(o.Namespace, o.PodName), true
+	case o.NodeName != "":
+		return resourcepath.Node(o.NodeName), true
+	default:
+		return resourcepath.ResourcePath{}, false
+	}
+}
+
+var _ log.FieldSet = (*OTLPLogFieldSet)(nil)
+
+// OTLPLogFieldSetReader implements log.FieldSetReader for OTLPLogFieldSet.
+type OTLPLogFieldSetReader struct{}
+
+// FieldSetKind implements log.FieldSetReader.
+func (o *OTLPLogFieldSetReader) FieldSetKind() string {
+	return (&OTLPLogFieldSet{}).Kind()
+}
+
+// Read implements log.FieldSetReader.
+func (o *OTLPLogFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	return &OTLPLogFieldSet{
+		Namespace:     reader.ReadStringOrDefault("kubernetes.namespace", ""),
+		PodName:       reader.ReadStringOrDefault("kubernetes.pod", ""),
+		ContainerName: reader.ReadStringOrDefault("kubernetes.container", ""),
+		NodeName:      reader.ReadStringOrDefault("kubernetes.node", ""),
+		Message:       reader.ReadStringOrDefault("message", ""),
+	}, nil
+}
+
+var _ log.FieldSetReader = (*OTLPLogFieldSetReader)(nil)
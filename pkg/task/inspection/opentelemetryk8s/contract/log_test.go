@@ -0,0 +1,120 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetryk8s_contract
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+func TestNewLogFromOTLPLogRecord(t *testing.T) {
+	testCases := []struct {
+		desc             string
+		record           *OTLPLogRecord
+		wantSeverity     enum.Severity
+		wantLogType      enum.LogType
+		wantFieldSet     *OTLPLogFieldSet
+		wantResourcePath string
+	}{
+		{
+			desc: "container log",
+			record: &OTLPLogRecord{
+				Timestamp:          time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				SeverityNumber:     9,
+				Body:               map[string]any{"message": "started", "port": 80},
+				ResourceAttributes: map[string]any{"k8s.namespace.name": "default", "k8s.pod.name": "nginx", "k8s.container.name": "web", "k8s.node.name": "node-1"},
+			},
+			wantSeverity:     enum.SeverityInfo,
+			wantLogType:      enum.LogTypeContainer,
+			wantFieldSet:     &OTLPLogFieldSet{Namespace: "default", PodName: "nginx", ContainerName: "web", NodeName: "node-1", Message: "started"},
+			wantResourcePath: "core/v1#pod#default#nginx#web",
+		},
+		{
+			desc: "pod log with attributes on the record",
+			record: &OTLPLogRecord{
+				Timestamp:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				SeverityText: "Error",
+				Body:         "failed",
+				Attributes:   map[string]any{"k8s.namespace.name": "default", "k8s.pod.name": "nginx"},
+			},
+			wantSeverity:     enum.SeverityError,
+			wantLogType:      enum.LogTypeContainer,
+			wantFieldSet:     &OTLPLogFieldSet{Namespace: "default", PodName: "nginx", Message: "failed"},
+			wantResourcePath: "core/v1#pod#default#nginx",
+		},
+		{
+			desc: "node log",
+			record: &OTLPLogRecord{
+				Timestamp:          time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				SeverityNumber:     21,
+				Body:               []any{"a", "b"},
+				ResourceAttributes: map[string]any{"k8s.node.name": "node-1"},
+			},
+			wantSeverity:     enum.SeverityFatal,
+			wantLogType:      enum.LogTypeNode,
+			wantFieldSet:     &OTLPLogFieldSet{NodeName: "node-1", Message: `["a","b"]`},
+			wantResourcePath: "core/v1#node#cluster-scope#node-1",
+		},
+		{
+			desc: "log without kubernetes attributes",
+			record: &OTLPLogRecord{
+				Timestamp: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				Body:      "hello",
+			},
+			wantSeverity: enum.SeverityUnknown,
+			wantLogType:  enum.LogTypeUnknown,
+			wantFieldSet: &OTLPLogFieldSet{Message: "hello"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l, err := NewLogFromOTLPLogRecord(tc.record)
+			if err != nil {
+				t.Fatalf("NewLogFromOTLPLogRecord() returned an unexpected error: %v", err)
+			}
+			if l.LogType != tc.wantLogType {
+				t.Errorf("LogType = %v, want %v", l.LogType, tc.wantLogType)
+			}
+			if err := l.SetFieldSetReader(&OTLPLogCommonFieldSetReader{}); err != nil {
+				t.Fatalf("SetFieldSetReader() returned an unexpected error: %v", err)
+			}
+			if err := l.SetFieldSetReader(&OTLPLogFieldSetReader{}); err != nil {
+				t.Fatalf("SetFieldSetReader() returned an unexpected error: %v", err)
+			}
+			commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+			if !commonFieldSet.Timestamp.Equal(tc.record.Timestamp) {
+				t.Errorf("Timestamp = %v, want %v", commonFieldSet.Timestamp, tc.record.Timestamp)
+			}
+			if commonFieldSet.Severity != tc.wantSeverity {
+				t.Errorf("Severity = %v, want %v", commonFieldSet.Severity, tc.wantSeverity)
+			}
+			if len(commonFieldSet.DisplayID) != 16 {
+				t.Errorf("DisplayID = %q, want a 16 characters hash", commonFieldSet.DisplayID)
+			}
+			otlpFieldSet := log.MustGetFieldSet(l, &OTLPLogFieldSet{})
+			if diff := cmp.Diff(tc.wantFieldSet, otlpFieldSet); diff != "" {
+				t.Errorf("OTLPLogFieldSet mismatch (-want +got):\n%s", diff)
+			}
+			resourcePath, found := otlpFieldSet.ResourcePath()
+			if found != (tc.wantResourcePath != "") || resourcePath.Path != tc.wantResourcePath {
+				t.Errorf("ResourcePath() = %q, %v, want %q", resourcePath.Path, found, tc.wantResourcePath)
+			}
+		})
+	}
+}
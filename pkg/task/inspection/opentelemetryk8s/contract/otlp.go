@@ -0,0 +1,184 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetryk8s_contract
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// otlpLogsData is the root of OTLP/JSON log files. The file exporter of the OpenTelemetry Collector writes one of them per line.
+type otlpLogsData struct {
+	ResourceLogs []*otlpResourceLogs `json:"resourceLogs"`
+}
+
+type otlpResourceLogs struct {
+	Resource struct {
+		Attributes []*otlpKeyValue `json:"attributes"`
+	} `json:"resource"`
+	ScopeLogs []*otlpScopeLogs `json:"scopeLogs"`
+}
+
+type otlpScopeLogs struct {
+	Scope struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	} `json:"scope"`
+	LogRecords []*otlpLogRecord `json:"logRecords"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano         json.RawMessage `json:"timeUnixNano"`
+	ObservedTimeUnixNano json.RawMessage `json:"observedTimeUnixNano"`
+	SeverityNumber       int             `json:"severityNumber"`
+	SeverityText         string          `json:"severityText"`
+	Body                 *otlpAnyValue   `json:"body"`
+	Attributes           []*otlpKeyValue `json:"attributes"`
+	TraceID              string          `json:"traceId"`
+	SpanID               string          `json:"spanId"`
+}
+
+type otlpKeyValue struct {
+	Key   string        `json:"key"`
+	Value *otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string         `json:"stringValue"`
+	BoolValue   *bool           `json:"boolValue"`
+	IntValue    json.RawMessage `json:"intValue"`
+	DoubleValue *float64        `json:"doubleValue"`
+	BytesValue  *string         `json:"bytesValue"`
+	ArrayValue  *struct {
+		Values []*otlpAnyValue `json:"values"`
+	} `json:"arrayValue"`
+	KvlistValue *struct {
+		Values []*otlpKeyValue `json:"values"`
+	} `json:"kvlistValue"`
+}
+
+// toValue converts the AnyValue to the plain value.
+func (v *otlpAnyValue) toValue() any {
+	if v == nil {
+		return nil
+	}
+	switch {
+	case v.StringValue != nil:
+		return *v.StringValue
+	case v.BoolValue != nil:
+		return *v.BoolValue
+	case v.IntValue != nil:
+		// 64 bit integers are written in strings in OTLP/JSON, but some exporters write them in numbers.
+		value, err := strconv.ParseInt(strings.Trim(string(v.IntValue), `"`), 10, 64)
+		if err != nil {
+			return string(v.IntValue)
+		}
+		return value
+	case v.DoubleValue != nil:
+		return *v.DoubleValue
+	case v.BytesValue != nil:
+		return *v.BytesValue
+	case v.ArrayValue != nil:
+		values := make([]any, 0, len(v.ArrayValue.Values))
+		for _, value := range v.ArrayValue.Values {
+			values = append(values, value.toValue())
+		}
+		return values
+	case v.KvlistValue != nil:
+		return keyValuesToMap(v.KvlistValue.Values)
+	default:
+		return nil
+	}
+}
+
+func keyValuesToMap(keyValues []*otlpKeyValue) map[string]any {
+	result := make(map[string]any, len(keyValues))
+	for _, keyValue := range keyValues {
+		result[keyValue.Key] = keyValue.Value.toValue()
+	}
+	return result
+}
+
+// parseUnixNano parses the uint64 timestamp in nanoseconds written either in a string or a number.
+func parseUnixNano(raw json.RawMessage) (time.Time, bool) {
+	value, err := strconv.ParseInt(strings.Trim(string(raw), `"`), 10, 64)
+	if err != nil || value == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(0, value), true
+}
+
+// OTLPLogRecord is a log record read from OTLP/JSON log files with the attributes of the resource and the scope emitted it.
+type OTLPLogRecord struct {
+	Timestamp          time.Time
+	SeverityNumber     int
+	SeverityText       string
+	Body               any
+	Attributes         map[string]any
+	ResourceAttributes map[string]any
+	ScopeName          string
+	ScopeVersion       string
+	TraceID            string
+	SpanID             string
+}
+
+// ReadOTLPLogRecords reads all the log records in OTLP/JSON from the reader.
+// The reader can contain multiple LogsData messages written in JSON lines like the outputs of the file exporter.
+// Records without timeUnixNano use observedTimeUnixNano instead, and records having neither of them are skipped.
+func ReadOTLPLogRecords(reader io.Reader) ([]*OTLPLogRecord, error) {
+	var records []*OTLPLogRecord
+	decoder := json.NewDecoder(reader)
+	for messageIndex := 1; ; messageIndex++ {
+		var logsData otlpLogsData
+		err := decoder.Decode(&logsData)
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the OTLP/JSON logs data %d: %w", messageIndex, err)
+		}
+		for _, resourceLogs := range logsData.ResourceLogs {
+			resourceAttributes := keyValuesToMap(resourceLogs.Resource.Attributes)
+			for _, scopeLogs := range resourceLogs.ScopeLogs {
+				for _, logRecord := range scopeLogs.LogRecords {
+					timestamp, found := parseUnixNano(logRecord.TimeUnixNano)
+					if !found {
+						timestamp, found = parseUnixNano(logRecord.ObservedTimeUnixNano)
+					}
+					if !found {
+						continue
+					}
+					records = append(records, &OTLPLogRecord{
+						Timestamp:          timestamp,
+						SeverityNumber:     logRecord.SeverityNumber,
+						SeverityText:       logRecord.SeverityText,
+						Body:               logRecord.Body.toValue(),
+						Attributes:         keyValuesToMap(logRecord.Attributes),
+						ResourceAttributes: resourceAttributes,
+						ScopeName:          scopeLogs.Scope.Name,
+						ScopeVersion:       scopeLogs.Scope.Version,
+						TraceID:            logRecord.TraceID,
+						SpanID:             logRecord.SpanID,
+					})
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetryk8s_contract
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testOTLPLogsData = `{"resourceLogs":[{"resource":{"attributes":[{"key":"k8s.namespace.name","value":{"stringValue":"default"}},{"key":"k8s.pod.name","value":{"stringValue":"nginx"}},{"key":"k8s.container.name","value":{"stringValue":"web"}}]},"scopeLogs":[{"scope":{"name":"filelog","version":"1.0"},"logRecords":[{"timeUnixNano":"1735689600000000001","severityNumber":17,"severityText":"ERROR","body":{"stringValue":"failed"},"attributes":[{"key":"retries","value":{"intValue":"3"}},{"key":"ok","value":{"boolValue":false}}],"traceId":"abc","spanId":"def"},{"observedTimeUnixNano":1735689601000000000,"body":{"kvlistValue":{"values":[{"key":"msg","value":{"stringValue":"started"}},{"key":"ports","value":{"arrayValue":{"values":[{"intValue":80},{"doubleValue":1.5}]}}}]}}},{"body":{"stringValue":"without timestamp"}}]}]}]}
+{"resourceLogs":[{"resource":{"attributes":[{"key":"k8s.node.name","value":{"stringValue":"node-1"}}]},"scopeLogs":[{"logRecords":[{"timeUnixNano":"1735689602000000000","severityText":"warn","body":{"stringValue":"disk pressure"}}]}]}]}
+`
+
+func TestReadOTLPLogRecords(t *testing.T) {
+	got, err := ReadOTLPLogRecords(strings.NewReader(testOTLPLogsData))
+	if err != nil {
+		t.Fatalf("ReadOTLPLogRecords() returned an unexpected error: %v", err)
+	}
+	podAttributes := map[string]any{"k8s.namespace.name": "default", "k8s.pod.name": "nginx", "k8s.container.name": "web"}
+	want := []*OTLPLogRecord{
+		{
+			Timestamp:          time.Unix(1735689600, 1),
+			SeverityNumber:     17,
+			SeverityText:       "ERROR",
+			Body:               "failed",
+			Attributes:         map[string]any{"retries": int64(3), "ok": false},
+			ResourceAttributes: podAttributes,
+			ScopeName:          "filelog",
+			ScopeVersion:       "1.0",
+			TraceID:            "abc",
+			SpanID:             "def",
+		},
+		{
+			Timestamp:          time.Unix(1735689601, 0),
+			Body:               map[string]any{"msg": "started", "ports": []any{int64(80), 1.5}},
+			Attributes:         map[string]any{},
+			ResourceAttributes: podAttributes,
+			ScopeName:          "filelog",
+			ScopeVersion:       "1.0",
+		},
+		{
+			Timestamp:          time.Unix(1735689602, 0),
+			SeverityText:       "warn",
+			Body:               "disk pressure",
+			Attributes:         map[string]any{},
+			ResourceAttributes: map[string]any{"k8s.node.name": "node-1"},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadOTLPLogRecords() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadOTLPLogRecordsWithInvalidJSON(t *testing.T) {
+	_, err := ReadOTLPLogRecords(strings.NewReader(`{"resourceLogs":[]}` + "\n{invalid"))
+	if err == nil || !strings.Contains(err.Error(), "logs data 2") {
+		t.Errorf("ReadOTLPLogRecords() returned error %v, want an error on the logs data 2", err)
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetryk8s_contract
+
+import (
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/log"
+	"github.com/kyasbal/khi/pkg/server/upload"
+)
+
+// OpenTelemetryTaskPrefix is the prefixes of IDs used in OpenTelemetry related tasks.
+const OpenTelemetryTaskPrefix = "khi.google.com/opentelemetry/"
+
+// InputLogFilesTaskID is the task ID for the form to upload OTLP/JSON log files.
+var InputLogFilesTaskID = taskid.NewDefaultImplementationID[upload.UploadResultList](OpenTelemetryTaskPrefix + "form/log-files")
+
+// LogReaderTaskID is the task ID to read the log records in the uploaded OTLP/JSON log files.
+var LogReaderTaskID = taskid.NewDefaultImplementationID[[]*log.Log](OpenTelemetryTaskPrefix + "log-reader")
+
+// LogFieldSetReaderTaskID is the task ID to read the fieldset of the Kubernetes resources from the log records.
+var LogFieldSetReaderTaskID = taskid.NewDefaultImplementationID[[]*log.Log](OpenTelemetryTaskPrefix + "log-fieldset-reader")
+
+// LogIngesterTaskID is the task ID to ingest the log records to the history.
+var LogIngesterTaskID = taskid.NewDefaultImplementationID[[]*log.Log](OpenTelemetryTaskPrefix + "log-ingester")
+
+// LogGrouperTaskID is the task ID to group the log records by the resource emitted them.
+var LogGrouperTaskID = taskid.NewDefaultImplementationID[inspectiontaskbase.LogGroupMap](OpenTelemetryTaskPrefix + "log-grouper")
+
+// LogToTimelineMapperTaskID is the task ID of the feature task to map the log records to the timelines of the resources emitted them.
+var LogToTimelineMapperTaskID = taskid.NewDefaultImplementationID[struct{}](OpenTelemetryTaskPrefix + "log-mapper")
@@ -0,0 +1,107 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetryk8s_impl
+
+import (
+	"context"
+
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/history"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudlogk8scontainer_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8scontainer/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	opentelemetryk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/opentelemetryk8s/contract"
+)
+
+var LogFieldSetReaderTask = inspectiontaskbase.NewFieldSetReadTask(
+	opentelemetryk8s_contract.LogFieldSetReaderTaskID,
+	opentelemetryk8s_contract.LogReaderTaskID.Ref(),
+	[]log.FieldSetReader{
+		&opentelemetryk8s_contract.OTLPLogFieldSetReader{},
+	},
+)
+
+var LogIngesterTask = inspectiontaskbase.NewLogIngesterTask(opentelemetryk8s_contract.LogIngesterTaskID, opentelemetryk8s_contract.LogReaderTaskID.Ref())
+
+var LogGrouperTask = inspectiontaskbase.NewLogGrouperTask(
+	opentelemetryk8s_contract.LogGrouperTaskID,
+	opentelemetryk8s_contract.LogFieldSetReaderTaskID.Ref(),
+	func(ctx context.Context, l *log.Log) string {
+		// The mapper is stateless, but grouping logs by the resource lets them processed in parallel.
+		otlpFields, err := log.GetFieldSet(l, &opentelemetryk8s_contract.OTLPLogFieldSet{})
+		if err != nil {
+			return "unknown"
+		}
+		resourcePath, found := otlpFields.ResourcePath()
+		if !found {
+			return "unknown"
+		}
+		return resourcePath.Path
+	},
+)
+
+var LogToTimelineMapperTask = inspectiontaskbase.NewLogToTimelineMapperTask[struct{}](opentelemetryk8s_contract.LogToTimelineMapperTaskID, &logToTimelineMapperTaskSetting{},
+	inspectioncore_contract.FeatureTaskLabel(`OpenTelemetry logs`,
+		`Gather log records exported in OTLP/JSON to visualize them on the timelines of the containers, Pods or nodes identified with their resource attributes.`,
+		enum.LogTypeContainer,
+		4000,
+		true,
+		opentelemetryk8s_contract.InspectionTypeID),
+)
+
+type logToTimelineMapperTaskSetting struct {
+}
+
+// Dependencies implements inspectiontaskbase.LogToTimelineMapper.
+func (l *logToTimelineMapperTaskSetting) Dependencies() []taskid.UntypedTaskReference {
+	return []taskid.UntypedTaskReference{}
+}
+
+// GroupedLogTask implements inspectiontaskbase.LogToTimelineMapper.
+func (l *logToTimelineMapperTaskSetting) GroupedLogTask() taskid.TaskReference[inspectiontaskbase.LogGroupMap] {
+	return opentelemetryk8s_contract.LogGrouperTaskID.Ref()
+}
+
+// LogIngesterTask implements inspectiontaskbase.LogToTimelineMapper.
+func (l *logToTimelineMapperTaskSetting) LogIngesterTask() taskid.TaskReference[[]*log.Log] {
+	return opentelemetryk8s_contract.LogIngesterTaskID.Ref()
+}
+
+// ProcessLogByGroup implements inspectiontaskbase.LogToTimelineMapper.
+func (l *logToTimelineMapperTaskSetting) ProcessLogByGroup(ctx context.Context, lg *log.Log, cs *history.ChangeSet, builder *history.Builder, prevGroupData struct{}) (struct{}, error) {
+	otlpFields, err := log.GetFieldSet(lg, &opentelemetryk8s_contract.OTLPLogFieldSet{})
+	if err != nil {
+		return struct{}{}, nil
+	}
+	cs.SetLogSummary(otlpFields.Message)
+	resourcePath, found := otlpFields.ResourcePath()
+	if !found {
+		return struct{}{}, nil
+	}
+	cs.AddEvent(resourcePath)
+	if otlpFields.ContainerName != "" {
+		googlecloudlogk8scontainer_contract.AddVeleroLogEvents(cs, &googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet{
+			Namespace:     otlpFields.Namespace,
+			PodName:       otlpFields.PodName,
+			ContainerName: otlpFields.ContainerName,
+			Message:       otlpFields.Message,
+		})
+	}
+	return struct{}{}, nil
+}
+
+var _ inspectiontaskbase.LogToTimelineMapper[struct{}] = (*logToTimelineMapperTaskSetting)(nil)
@@ -0,0 +1,97 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetryk8s_impl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/model/history"
+	opentelemetryk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/opentelemetryk8s/contract"
+	"github.com/kyasbal/khi/pkg/testutil/testchangeset"
+)
+
+func TestLogToTimelineMapper(t *testing.T) {
+	testCases := []struct {
+		desc               string
+		resourceAttributes map[string]any
+		body               any
+		asserters          []testchangeset.ChangeSetAsserter
+	}{
+		{
+			desc:               "container log",
+			resourceAttributes: map[string]any{"k8s.namespace.name": "default", "k8s.pod.name": "nginx", "k8s.container.name": "web"},
+			body:               map[string]any{"msg": "listening on :8080"},
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasEvent{ResourcePath: "core/v1#pod#default#nginx#web"},
+				&testchangeset.HasLogSummary{WantLogSummary: "listening on :8080"},
+			},
+		},
+		{
+			desc:               "pod log without container name",
+			resourceAttributes: map[string]any{"k8s.namespace.name": "default", "k8s.pod.name": "nginx"},
+			body:               "sandbox created",
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasEvent{ResourcePath: "core/v1#pod#default#nginx"},
+				&testchangeset.HasLogSummary{WantLogSummary: "sandbox created"},
+			},
+		},
+		{
+			desc:               "node log",
+			resourceAttributes: map[string]any{"k8s.node.name": "node-1"},
+			body:               "disk pressure",
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasEvent{ResourcePath: "core/v1#node#cluster-scope#node-1"},
+				&testchangeset.HasLogSummary{WantLogSummary: "disk pressure"},
+			},
+		},
+		{
+			desc:               "log without Kubernetes resource attributes",
+			resourceAttributes: map[string]any{"service.name": "checkout"},
+			body:               "order placed",
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.MatchResourcePathSet{WantResourcePaths: []string{}},
+				&testchangeset.HasLogSummary{WantLogSummary: "order placed"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l, err := opentelemetryk8s_contract.NewLogFromOTLPLogRecord(&opentelemetryk8s_contract.OTLPLogRecord{
+				Timestamp:          time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				Body:               tc.body,
+				Attributes:         map[string]any{},
+				ResourceAttributes: tc.resourceAttributes,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = l.SetFieldSetReader(&opentelemetryk8s_contract.OTLPLogFieldSetReader{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cs := history.NewChangeSet(l)
+			_, err = (&logToTimelineMapperTaskSetting{}).ProcessLogByGroup(context.Background(), l, cs, nil, struct{}{})
+			if err != nil {
+				t.Fatalf("ProcessLogByGroup() returned an unexpected error: %v", err)
+			}
+			for _, asserter := range tc.asserters {
+				asserter.Assert(t, cs)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetryk8s_impl
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/log"
+	"github.com/kyasbal/khi/pkg/server/upload"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	opentelemetryk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/opentelemetryk8s/contract"
+)
+
+// InputLogFilesTask is a form task to upload OTLP/JSON log files.
+var InputLogFilesTask = formtask.NewMultiFileFormTaskBuilder(opentelemetryk8s_contract.InputLogFilesTaskID, 1000, "OTLP/JSON log files", &upload.JSONLineUploadFileVerifier{
+	MaxLineSizeInBytes: 1024 * 1024 * 1024,
+}).
+	WithDescription("Upload log files written in OTLP/JSON by the `file` exporter of the OpenTelemetry Collector. Logs are associated with containers, Pods or nodes with the `k8s.namespace.name`, `k8s.pod.name`, `k8s.container.name` and `k8s.node.name` attributes added by the `k8sattributes` processor or the `filelog` receiver.").
+	WithMarkdown().
+	Build()
+
+// LogReaderTask reads the log records in the uploaded OTLP/JSON log files.
+var LogReaderTask = inspectiontaskbase.NewProgressReportableInspectionTask(
+	opentelemetryk8s_contract.LogReaderTaskID,
+	[]taskid.UntypedTaskReference{
+		opentelemetryk8s_contract.InputLogFilesTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, tp *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, error) {
+		if taskMode == inspectioncore_contract.TaskModeDryRun {
+			return []*log.Log{}, nil
+		}
+		result := coretask.GetTaskResult(ctx, opentelemetryk8s_contract.InputLogFilesTaskID.Ref())
+		readers, err := result.GetReaders()
+		if err != nil {
+			return nil, err
+		}
+		tp.MarkIndeterminate()
+
+		var logs []*log.Log
+		for _, reader := range readers {
+			defer reader.Close()
+			records, err := opentelemetryk8s_contract.ReadOTLPLogRecords(reader)
+			if err != nil {
+				return nil, err
+			}
+			for _, record := range records {
+				l, err := opentelemetryk8s_contract.NewLogFromOTLPLogRecord(record)
+				if err != nil {
+					return nil, err
+				}
+				err = l.SetFieldSetReader(&opentelemetryk8s_contract.OTLPLogCommonFieldSetReader{})
+				if err != nil {
+					return nil, err
+				}
+				logs = append(logs, l)
+			}
+		}
+		slog.InfoContext(ctx, fmt.Sprintf("read %d log records from %d OTLP/JSON files", len(logs), len(readers)))
+
+		slices.SortStableFunc(logs, func(a, b *log.Log) int {
+			return log.MustGetFieldSet(a, &log.CommonFieldSet{}).Timestamp.Compare(log.MustGetFieldSet(b, &log.CommonFieldSet{}).Timestamp)
+		})
+		extendHeaderTimeRange(ctx, logs)
+		return logs, nil
+	},
+)
+
+// headerTimeRangeLock guards the time range in the header metadata.
+var headerTimeRangeLock sync.Mutex
+
+// extendHeaderTimeRange extends the time range in the header metadata to include the given logs sorted by their timestamps.
+func extendHeaderTimeRange(ctx context.Context, sortedLogs []*log.Log) {
+	if len(sortedLogs) == 0 {
+		return
+	}
+	metadataSet := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
+	header := typedmap.GetOrDefault(metadataSet, inspectionmetadata.HeaderMetadataKey, &inspectionmetadata.HeaderMetadata{})
+	startTime := log.MustGetFieldSet(sortedLogs[0], &log.CommonFieldSet{}).Timestamp.Unix()
+	endTime := log.MustGetFieldSet(sortedLogs[len(sortedLogs)-1], &log.CommonFieldSet{}).Timestamp.Unix()
+
+	headerTimeRangeLock.Lock()
+	defer headerTimeRangeLock.Unlock()
+	if header.StartTimeUnixSeconds == 0 || startTime < header.StartTimeUnixSeconds {
+		header.StartTimeUnixSeconds = startTime
+	}
+	if endTime > header.EndTimeUnixSeconds {
+		header.EndTimeUnixSeconds = endTime
+	}
+}
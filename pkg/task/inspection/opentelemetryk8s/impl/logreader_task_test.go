@@ -0,0 +1,99 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetryk8s_impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	opentelemetryk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/opentelemetryk8s/contract"
+	"github.com/kyasbal/khi/pkg/testutil/testupload"
+)
+
+func TestLogReaderTask(t *testing.T) {
+	type otlpLog struct {
+		Timestamp time.Time
+		Severity  enum.Severity
+		Message   string
+		LogType   enum.LogType
+	}
+	podLogs := `{"resourceLogs":[{"resource":{"attributes":[{"key":"k8s.namespace.name","value":{"stringValue":"default"}},{"key":"k8s.pod.name","value":{"stringValue":"nginx"}},{"key":"k8s.container.name","value":{"stringValue":"web"}}]},"scopeLogs":[{"logRecords":[{"timeUnixNano":"1735689602000000000","severityNumber":17,"body":{"stringValue":"failed"}},{"timeUnixNano":"1735689600000000000","severityNumber":9,"body":{"kvlistValue":{"values":[{"key":"msg","value":{"stringValue":"started"}}]}}}]}]}]}
+`
+	nodeLogs := `{"resourceLogs":[{"resource":{"attributes":[{"key":"k8s.node.name","value":{"stringValue":"node-1"}}]},"scopeLogs":[{"logRecords":[{"timeUnixNano":"1735689601000000000","severityText":"warn","body":{"stringValue":"disk pressure"}}]}]}]}
+`
+	testCases := []struct {
+		desc     string
+		taskMode inspectioncore_contract.InspectionTaskModeType
+		want     []otlpLog
+	}{
+		{
+			desc:     "records of all files sorted by their timestamps",
+			taskMode: inspectioncore_contract.TaskModeRun,
+			want: []otlpLog{
+				{Timestamp: time.Unix(1735689600, 0), Severity: enum.SeverityInfo, Message: "started", LogType: enum.LogTypeContainer},
+				{Timestamp: time.Unix(1735689601, 0), Severity: enum.SeverityWarning, Message: "disk pressure", LogType: enum.LogTypeNode},
+				{Timestamp: time.Unix(1735689602, 0), Severity: enum.SeverityError, Message: "failed", LogType: enum.LogTypeContainer},
+			},
+		},
+		{
+			desc:     "files aren't read in dry run",
+			taskMode: inspectioncore_contract.TaskModeDryRun,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+			logs, _, err := inspectiontest.RunInspectionTask(ctx, LogReaderTask, tc.taskMode, map[string]any{},
+				tasktest.NewTaskDependencyValuePair(opentelemetryk8s_contract.InputLogFilesTaskID.Ref(), testupload.UploadFiles(t, podLogs, nodeLogs)),
+			)
+			if err != nil {
+				t.Fatalf("LogReaderTask returned an unexpected error: %v", err)
+			}
+
+			var got []otlpLog
+			for _, l := range logs {
+				if err := l.SetFieldSetReader(&opentelemetryk8s_contract.OTLPLogFieldSetReader{}); err != nil {
+					t.Fatalf("failed to read the OTLP fieldset: %v", err)
+				}
+				commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+				got = append(got, otlpLog{
+					Timestamp: commonFieldSet.Timestamp,
+					Severity:  commonFieldSet.Severity,
+					Message:   log.MustGetFieldSet(l, &opentelemetryk8s_contract.OTLPLogFieldSet{}).Message,
+					LogType:   l.LogType,
+				})
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })); diff != "" {
+				t.Errorf("logs mismatch (-want +got):\n%s", diff)
+			}
+
+			if tc.taskMode == inspectioncore_contract.TaskModeRun {
+				header := typedmap.GetOrDefault(khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata), inspectionmetadata.HeaderMetadataKey, &inspectionmetadata.HeaderMetadata{})
+				if header.StartTimeUnixSeconds != 1735689600 || header.EndTimeUnixSeconds != 1735689602 {
+					t.Errorf("header time range = [%d, %d], want [1735689600, 1735689602]", header.StartTimeUnixSeconds, header.EndTimeUnixSeconds)
+				}
+			}
+		})
+	}
+}
@@ -65,6 +65,8 @@ func (o *OSSK8sAuditLogFieldSetReader) Read(reader *structured.NodeReader) (log.
 	result.StatusCode = reader.ReadIntOrDefault("responseStatus.code", 0)
 	result.StatusMessage = reader.ReadStringOrDefault("responseStatus.message", "")
 	result.IsError = result.StatusCode < 200 || result.StatusCode >= 300
+	decision := reader.ReadStringOrDefault("annotations.authorization\\.k8s\\.io/decision", "")
+	result.IsPermissionDenied = decision == commonlogk8sauditv2_contract.AuthorizationDecisionForbid || (decision == "" && result.StatusCode == 403)
 	result.Request, _ = reader.GetReader("requestObject")
 	result.Response, _ = reader.GetReader("responseObject")
 	return &result, nil
@@ -135,6 +135,43 @@ objectRef:
 				},
 			},
 		},
+		{
+			desc: "denied by the authorizer",
+			input: `
+auditID: "forbidden-audit-id"
+verb: "delete"
+user:
+  username: "system:serviceaccount:default:foo"
+annotations:
+  authorization.k8s.io/decision: "forbid"
+responseStatus:
+  code: 403
+  message: "Forbidden"
+objectRef:
+  resource: "pods"
+  namespace: "default"
+  name: "nginx"
+`,
+			want: &commonlogk8sauditv2_contract.K8sAuditLogFieldSet{
+				OperationID:        "forbidden-audit-id",
+				IsFirst:            true,
+				IsLast:             true,
+				Principal:          "system:serviceaccount:default:foo",
+				StatusCode:         403,
+				StatusMessage:      "Forbidden",
+				IsError:            true,
+				IsPermissionDenied: true,
+				RequestURI:         "",
+				K8sOperation: &model.KubernetesObjectOperation{
+					APIVersion:      "core/unknown",
+					PluralKind:      "pods",
+					Namespace:       "default",
+					Name:            "nginx",
+					SubResourceName: "",
+					Verb:            enum.RevisionVerbDelete,
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
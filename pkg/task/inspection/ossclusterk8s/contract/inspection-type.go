@@ -25,7 +25,7 @@ const InspectionTypeID = "oss-kubernetes-from-files"
 var OSSKubernetesLogFilesInspectionType = coreinspection.InspectionType{
 	Id:          InspectionTypeID,
 	Name:        "OSS Kubernetes Log Files",
-	Description: "Visualize OSS Kubernetes logs through the uploaded files",
+	Description: "Visualize OSS Kubernetes logs through the uploaded files, including the logs of k3s and RKE2 clusters",
 	Icon:        "assets/icons/k8s.png",
 	Priority:    math.MaxInt - 1000,
 }
@@ -0,0 +1,96 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_contract
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/common/structured"
+	"github.com/kyasbal/khi/pkg/core/inspection/logutil"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudlogk8snode_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8snode/contract"
+)
+
+// OSSJournaldCommonFieldSetReader implements log.FieldSetReader for log.CommonFieldSet{} from a journald log exported with `journalctl -o json`.
+type OSSJournaldCommonFieldSetReader struct{}
+
+// FieldSetKind implements log.FieldSetReader.
+func (o *OSSJournaldCommonFieldSetReader) FieldSetKind() string {
+	return (&log.CommonFieldSet{}).Kind()
+}
+
+// Read implements log.FieldSetReader.
+func (o *OSSJournaldCommonFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	result := &log.CommonFieldSet{}
+	result.DisplayID = reader.ReadStringOrDefault("__CURSOR", "unknown")
+	// __REALTIME_TIMESTAMP is the wallclock time in microseconds since the epoch.
+	realtimeTimestamp := reader.ReadStringOrDefault("__REALTIME_TIMESTAMP", "")
+	microseconds, err := strconv.ParseInt(realtimeTimestamp, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read __REALTIME_TIMESTAMP from given journald log: %w", err)
+	}
+	result.Timestamp = time.UnixMicro(microseconds)
+	result.Severity = journaldPriorityToSeverity(reader.ReadStringOrDefault("PRIORITY", ""))
+	return result, nil
+}
+
+var _ log.FieldSetReader = (*OSSJournaldCommonFieldSetReader)(nil)
+
+// OSSJournaldNodeLogFieldSetReader implements log.FieldSetReader for googlecloudlogk8snode_contract.K8sNodeLogCommonFieldSet{} from a journald log.
+// This lets the node log parsers used for GKE handle the node logs of OSS clusters.
+type OSSJournaldNodeLogFieldSetReader struct {
+	StructuredLogParser logutil.StructuredLogParser
+}
+
+// FieldSetKind implements log.FieldSetReader.
+func (o *OSSJournaldNodeLogFieldSetReader) FieldSetKind() string {
+	return (&googlecloudlogk8snode_contract.K8sNodeLogCommonFieldSet{}).Kind()
+}
+
+// Read implements log.FieldSetReader.
+func (o *OSSJournaldNodeLogFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	var result googlecloudlogk8snode_contract.K8sNodeLogCommonFieldSet
+	message := reader.ReadStringOrDefault("MESSAGE", "")
+	result.Message = o.StructuredLogParser.TryParse(message)
+	result.Component = reader.ReadStringOrDefault("SYSLOG_IDENTIFIER", "")
+	if result.Component == "" {
+		result.Component = strings.TrimSuffix(reader.ReadStringOrDefault("_SYSTEMD_UNIT", ""), ".service")
+	}
+	result.Component = RancherEmbeddedComponent(strings.Trim(result.Component, "()"), message)
+	result.NodeName = reader.ReadStringOrDefault("_HOSTNAME", "")
+	return &result, nil
+}
+
+var _ log.FieldSetReader = (*OSSJournaldNodeLogFieldSetReader)(nil)
+
+// journaldPriorityToSeverity converts the syslog priority level used in the PRIORITY field of journald logs to the severity.
+func journaldPriorityToSeverity(priority string) enum.Severity {
+	switch priority {
+	case "0", "1", "2":
+		return enum.SeverityFatal
+	case "3":
+		return enum.SeverityError
+	case "4":
+		return enum.SeverityWarning
+	case "5", "6", "7":
+		return enum.SeverityInfo
+	default:
+		return enum.SeverityUnknown
+	}
+}
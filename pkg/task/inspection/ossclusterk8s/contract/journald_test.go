@@ -0,0 +1,154 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_contract
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/core/inspection/logutil"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudlogk8snode_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8snode/contract"
+)
+
+func TestOSSJournaldCommonFieldSetReader(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		input   string
+		want    *log.CommonFieldSet
+		wantErr bool
+	}{
+		{
+			desc:  "kubelet log",
+			input: `{"__CURSOR":"s=abc;i=1","__REALTIME_TIMESTAMP":"1700000000123456","PRIORITY":"6","SYSLOG_IDENTIFIER":"kubelet","MESSAGE":"foo"}`,
+			want: &log.CommonFieldSet{
+				DisplayID: "s=abc;i=1",
+				Timestamp: time.UnixMicro(1700000000123456),
+				Severity:  enum.SeverityInfo,
+			},
+		},
+		{
+			desc:  "error priority",
+			input: `{"__CURSOR":"s=abc;i=2","__REALTIME_TIMESTAMP":"1700000000000000","PRIORITY":"3","MESSAGE":"foo"}`,
+			want: &log.CommonFieldSet{
+				DisplayID: "s=abc;i=2",
+				Timestamp: time.UnixMicro(1700000000000000),
+				Severity:  enum.SeverityError,
+			},
+		},
+		{
+			desc:  "without priority",
+			input: `{"__REALTIME_TIMESTAMP":"1700000000000000","MESSAGE":"foo"}`,
+			want: &log.CommonFieldSet{
+				DisplayID: "unknown",
+				Timestamp: time.UnixMicro(1700000000000000),
+				Severity:  enum.SeverityUnknown,
+			},
+		},
+		{
+			desc:    "without timestamp",
+			input:   `{"__CURSOR":"s=abc;i=3","MESSAGE":"foo"}`,
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l, err := log.NewLogFromYAMLString(tc.input)
+			if err != nil {
+				t.Fatalf("failed to parse test input to log: %v", err)
+			}
+			err = l.SetFieldSetReader(&OSSJournaldCommonFieldSetReader{})
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("OSSJournaldCommonFieldSetReader.Read() returned no error, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to run OSSJournaldCommonFieldSetReader.Read(): %v", err)
+			}
+			got := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("CommonFieldSet mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOSSJournaldNodeLogFieldSetReader(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		input         string
+		wantComponent string
+		wantNodeName  string
+		wantMessage   string
+	}{
+		{
+			desc:          "kubelet log",
+			input:         `{"_HOSTNAME":"node-1","SYSLOG_IDENTIFIER":"kubelet","_SYSTEMD_UNIT":"kubelet.service","MESSAGE":"I1115 10:00:00.000000    1234 kubelet.go:100] \"Started kubelet\""}`,
+			wantComponent: "kubelet",
+			wantNodeName:  "node-1",
+			wantMessage:   "Started kubelet",
+		},
+		{
+			desc:          "containerd log without SYSLOG_IDENTIFIER",
+			input:         `{"_HOSTNAME":"node-2","_SYSTEMD_UNIT":"containerd.service","MESSAGE":"time=\"2023-11-15T10:00:00Z\" level=info msg=\"starting containerd\""}`,
+			wantComponent: "containerd",
+			wantNodeName:  "node-2",
+			wantMessage:   "starting containerd",
+		},
+		{
+			desc:          "kubelet log embedded in k3s",
+			input:         `{"_HOSTNAME":"node-3","SYSLOG_IDENTIFIER":"k3s","_SYSTEMD_UNIT":"k3s.service","MESSAGE":"I1115 10:00:00.000000    1234 kubelet.go:100] \"Started kubelet\""}`,
+			wantComponent: "kubelet",
+			wantNodeName:  "node-3",
+			wantMessage:   "Started kubelet",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l, err := log.NewLogFromYAMLString(tc.input)
+			if err != nil {
+				t.Fatalf("failed to parse test input to log: %v", err)
+			}
+			err = l.SetFieldSetReader(&OSSJournaldNodeLogFieldSetReader{
+				StructuredLogParser: logutil.NewMultiTextLogParser(
+					logutil.NewKLogTextParser(true),
+					logutil.NewLogfmtTextParser(),
+					&logutil.FallbackRawTextLogParser{},
+				),
+			})
+			if err != nil {
+				t.Fatalf("failed to run OSSJournaldNodeLogFieldSetReader.Read(): %v", err)
+			}
+			got := log.MustGetFieldSet(l, &googlecloudlogk8snode_contract.K8sNodeLogCommonFieldSet{})
+			if got.Component != tc.wantComponent {
+				t.Errorf("Component = %q, want %q", got.Component, tc.wantComponent)
+			}
+			if got.NodeName != tc.wantNodeName {
+				t.Errorf("NodeName = %q, want %q", got.NodeName, tc.wantNodeName)
+			}
+			gotMessage, err := got.Message.MainMessage()
+			if err != nil {
+				t.Fatalf("failed to read the main message: %v", err)
+			}
+			if gotMessage != tc.wantMessage {
+				t.Errorf("MainMessage() = %q, want %q", gotMessage, tc.wantMessage)
+			}
+		})
+	}
+}
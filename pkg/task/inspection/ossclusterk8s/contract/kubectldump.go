@@ -0,0 +1,144 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_contract
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/common/structured"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/history/resourcepath"
+	"github.com/kyasbal/khi/pkg/model/log"
+	"gopkg.in/yaml.v3"
+)
+
+// KubectlDumpRequestor is the requestor recorded on the revisions read from kubectl outputs.
+const KubectlDumpRequestor = "kubectl"
+
+// eventTimestampFieldPaths are the fields of core/v1 or events.k8s.io/v1 Events holding the time of the last occurrence in the order of preference.
+var eventTimestampFieldPaths = []string{
+	"lastTimestamp",
+	"series.lastObservedTime",
+	"eventTime",
+	"deprecatedLastTimestamp",
+	"firstTimestamp",
+	"metadata.creationTimestamp",
+}
+
+// ReadKubectlDumpItems reads the objects from the output of `kubectl get -o json` or `kubectl get -o yaml`.
+// The output may contain multiple YAML documents, and the items of List objects are expanded.
+// Items of typed lists (e.g. PodList) may omit their kind and apiVersion, thus they are filled from the list.
+func ReadKubectlDumpItems(data []byte) ([]map[string]any, error) {
+	var result []map[string]any
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var document map[string]any
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			return result, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the kubectl output: %w", err)
+		}
+		if document == nil {
+			continue
+		}
+		kind, _ := document["kind"].(string)
+		items, isList := document["items"].([]any)
+		if !isList || !strings.HasSuffix(kind, "List") {
+			result = append(result, document)
+			continue
+		}
+		apiVersion, _ := document["apiVersion"].(string)
+		for _, item := range items {
+			object, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			if _, found := object["kind"]; !found && kind != "List" {
+				object["kind"] = strings.TrimSuffix(kind, "List")
+			}
+			if _, found := object["apiVersion"]; !found && apiVersion != "" {
+				object["apiVersion"] = apiVersion
+			}
+			result = append(result, object)
+		}
+	}
+}
+
+// NewLogFromKubectlDumpItem converts an object read from the kubectl output to a log.
+func NewLogFromKubectlDumpItem(item map[string]any) (*log.Log, error) {
+	serialized, err := json.Marshal(item)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize an object in the kubectl output: %w", err)
+	}
+	return log.NewLogFromYAMLString(string(serialized))
+}
+
+// IsKubectlDumpEvent returns true when the object read from the kubectl output is a core/v1 or events.k8s.io/v1 Event.
+func IsKubectlDumpEvent(reader *structured.NodeReader) bool {
+	apiVersion := reader.ReadStringOrDefault("apiVersion", "")
+	return reader.ReadStringOrDefault("kind", "") == "Event" && (apiVersion == "v1" || strings.HasPrefix(apiVersion, "events.k8s.io/"))
+}
+
+// KubectlDumpResourcePath returns the resource path of the object in the same format as the resource paths generated from audit logs.
+func KubectlDumpResourcePath(apiVersion, kind, namespace, name string) resourcepath.ResourcePath {
+	if !strings.Contains(apiVersion, "/") {
+		apiVersion = "core/" + apiVersion
+	}
+	if namespace == "" {
+		namespace = "cluster-scope"
+	}
+	return resourcepath.NameLayerGeneralItem(apiVersion, strings.ToLower(kind), namespace, name)
+}
+
+// OSSKubectlDumpCommonFieldSetReader implements log.FieldSetReader for log.CommonFieldSet{} read from objects in kubectl outputs.
+// The timestamp is the last occurrence for Events and the creation time for other resources.
+type OSSKubectlDumpCommonFieldSetReader struct{}
+
+// FieldSetKind implements log.FieldSetReader.
+func (o *OSSKubectlDumpCommonFieldSetReader) FieldSetKind() string {
+	return (&log.CommonFieldSet{}).Kind()
+}
+
+// Read implements log.FieldSetReader.
+func (o *OSSKubectlDumpCommonFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	result := &log.CommonFieldSet{}
+	result.DisplayID = reader.ReadStringOrDefault("metadata.uid", reader.ReadStringOrDefault("metadata.name", "unknown"))
+	result.Severity = enum.SeverityUnknown
+	timestampFieldPaths := []string{"metadata.creationTimestamp"}
+	if IsKubectlDumpEvent(reader) {
+		timestampFieldPaths = eventTimestampFieldPaths
+		result.Severity = enum.SeverityInfo
+		if reader.ReadStringOrDefault("type", "") == "Warning" {
+			result.Severity = enum.SeverityWarning
+		}
+	}
+	for _, fieldPath := range timestampFieldPaths {
+		timestamp, err := reader.ReadTimestamp(fieldPath)
+		if err == nil && !timestamp.IsZero() {
+			result.Timestamp = timestamp
+			return result, nil
+		}
+	}
+	return nil, fmt.Errorf("no timestamp found in the object %s", result.DisplayID)
+}
+
+var _ log.FieldSetReader = (*OSSKubectlDumpCommonFieldSetReader)(nil)
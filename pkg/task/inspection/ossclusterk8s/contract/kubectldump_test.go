@@ -0,0 +1,179 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_contract
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+func TestReadKubectlDumpItems(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		input   string
+		want    []string
+		wantErr bool
+	}{
+		{
+			desc: "kubectl get -o json",
+			input: `{"apiVersion":"v1","kind":"List","items":[
+				{"apiVersion":"v1","kind":"Event","metadata":{"name":"ev1"}},
+				{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"deploy1"}}
+			]}`,
+			want: []string{"v1/Event/ev1", "apps/v1/Deployment/deploy1"},
+		},
+		{
+			desc: "typed list without kinds on items",
+			input: `apiVersion: v1
+kind: PodList
+items:
+- metadata:
+    name: pod1
+`,
+			want: []string{"v1/Pod/pod1"},
+		},
+		{
+			desc: "multiple documents",
+			input: `apiVersion: v1
+kind: Pod
+metadata:
+  name: pod1
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: svc1
+`,
+			want: []string{"v1/Pod/pod1", "v1/Service/svc1"},
+		},
+		{
+			desc:    "invalid yaml",
+			input:   `kind: [Pod`,
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			items, err := ReadKubectlDumpItems([]byte(tc.input))
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("ReadKubectlDumpItems() returned no error, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadKubectlDumpItems() returned an unexpected error: %v", err)
+			}
+			var got []string
+			for _, item := range items {
+				metadata, _ := item["metadata"].(map[string]any)
+				got = append(got, item["apiVersion"].(string)+"/"+item["kind"].(string)+"/"+metadata["name"].(string))
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ReadKubectlDumpItems() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOSSKubectlDumpCommonFieldSetReader(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		input   string
+		want    *log.CommonFieldSet
+		wantErr bool
+	}{
+		{
+			desc:  "core/v1 warning event",
+			input: `{"apiVersion":"v1","kind":"Event","type":"Warning","metadata":{"uid":"uid-1","creationTimestamp":"2025-01-01T00:00:00Z"},"firstTimestamp":"2025-01-01T00:00:01Z","lastTimestamp":"2025-01-01T00:00:05Z"}`,
+			want: &log.CommonFieldSet{
+				DisplayID: "uid-1",
+				Timestamp: time.Date(2025, 1, 1, 0, 0, 5, 0, time.UTC),
+				Severity:  enum.SeverityWarning,
+			},
+		},
+		{
+			desc:  "events.k8s.io/v1 event without lastTimestamp",
+			input: `{"apiVersion":"events.k8s.io/v1","kind":"Event","type":"Normal","metadata":{"uid":"uid-2"},"eventTime":"2025-01-01T00:00:02.000001Z","deprecatedLastTimestamp":null}`,
+			want: &log.CommonFieldSet{
+				DisplayID: "uid-2",
+				Timestamp: time.Date(2025, 1, 1, 0, 0, 2, 1000, time.UTC),
+				Severity:  enum.SeverityInfo,
+			},
+		},
+		{
+			desc:  "resource",
+			input: `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"deploy1","creationTimestamp":"2025-01-01T00:00:03Z"}}`,
+			want: &log.CommonFieldSet{
+				DisplayID: "deploy1",
+				Timestamp: time.Date(2025, 1, 1, 0, 0, 3, 0, time.UTC),
+				Severity:  enum.SeverityUnknown,
+			},
+		},
+		{
+			desc:    "without timestamp",
+			input:   `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm1"}}`,
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l, err := log.NewLogFromYAMLString(tc.input)
+			if err != nil {
+				t.Fatalf("failed to parse test input to log: %v", err)
+			}
+			err = l.SetFieldSetReader(&OSSKubectlDumpCommonFieldSetReader{})
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("OSSKubectlDumpCommonFieldSetReader.Read() returned no error, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("OSSKubectlDumpCommonFieldSetReader.Read() returned an unexpected error: %v", err)
+			}
+			got := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("OSSKubectlDumpCommonFieldSetReader.Read() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestKubectlDumpResourcePath(t *testing.T) {
+	testCases := []struct {
+		apiVersion string
+		kind       string
+		namespace  string
+		name       string
+		want       string
+	}{
+		{apiVersion: "v1", kind: "Pod", namespace: "default", name: "pod1", want: "core/v1#pod#default#pod1"},
+		{apiVersion: "apps/v1", kind: "Deployment", namespace: "kube-system", name: "coredns", want: "apps/v1#deployment#kube-system#coredns"},
+		{apiVersion: "v1", kind: "Node", namespace: "", name: "node1", want: "core/v1#node#cluster-scope#node1"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.want, func(t *testing.T) {
+			got := KubectlDumpResourcePath(tc.apiVersion, tc.kind, tc.namespace, tc.name).Path
+			if got != tc.want {
+				t.Errorf("KubectlDumpResourcePath() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
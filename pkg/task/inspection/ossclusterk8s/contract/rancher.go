@@ -0,0 +1,279 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_contract
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/model/log"
+	"github.com/kyasbal/khi/pkg/server/upload"
+)
+
+// RancherLogFileKind is the kind of log files written by k3s or RKE2 under their data directories.
+type RancherLogFileKind string
+
+const (
+	// RancherContainerdLog is `agent/containerd/containerd.log` written by containerd launched by k3s or RKE2 in the logrus format.
+	RancherContainerdLog RancherLogFileKind = "containerd"
+	// RancherKubeletLog is `agent/logs/kubelet.log` written by kubelet launched by RKE2 in the klog format.
+	RancherKubeletLog RancherLogFileKind = "kubelet"
+	// RancherAuditLog is the kube-apiserver audit log under `server/logs/` written in JSON lines. RKE2 writes it by default, and k3s writes it when the audit log path is configured there.
+	RancherAuditLog RancherLogFileKind = "audit"
+)
+
+// rancherMaxLineSizeInBytes is the maximum size of a line in log files in the archive.
+const rancherMaxLineSizeInBytes = 16 * 1024 * 1024
+
+var (
+	// rancherLogFilePattern matches the log files under the data directory of k3s or RKE2 (`/var/lib/rancher/k3s` or `/var/lib/rancher/rke2`).
+	// The directory containing the data directory is used as the node name.
+	rancherLogFilePattern = regexp.MustCompile(`(?:^|/)(?:([^/]+)/)?(k3s|rke2)/(agent/containerd/containerd\.log|agent/logs/kubelet\.log|server/logs/audit[^/]*\.log)$`)
+	// klogHeaderPattern matches the header of klog lines like `I0102 15:04:05.000000    1234 kubelet.go:100] message`.
+	klogHeaderPattern = regexp.MustCompile(`^([IWEF])(\d{4} \d{2}:\d{2}:\d{2}\.\d{6})\s+(\d+) ([^:\]\s]+):\d+\] `)
+	// logrusTimePattern matches the time at the beginning of logrus lines like `time="2025-01-02T15:04:05.000000000Z" level=info msg="message"`.
+	logrusTimePattern = regexp.MustCompile(`^time="([^"]+)"`)
+	// logrusLevelPattern matches the level field in logrus lines.
+	logrusLevelPattern = regexp.MustCompile(`\slevel=(\w+)`)
+	// rancherKubeletSourceFilePattern matches the source files of kubelet written in the headers of klog lines.
+	rancherKubeletSourceFilePattern = regexp.MustCompile(`^(kubelet.*|kuberuntime_.*|pod_workers|pod_startup_latency_tracker|pod_container_deletor|prober.*|status_manager|volume_manager|reconciler.*|operation_generator|eviction_manager|generic|evented|cpu_manager|memory_manager|container_manager_linux|csi_plugin|plugin_watcher)\.go$`)
+	// rancherEtcdCallerPattern matches the callers in the logs of the etcd server embedded in k3s.
+	rancherEtcdCallerPattern = regexp.MustCompile(`^(etcdserver|embed|mvcc|wal|rafthttp|v3rpc|backend|membership|snap|raft|etcdmain|v3compactor)/`)
+)
+
+// rancherSyslogIdentifiers are the journald identifiers of the k3s and RKE2 processes running Kubernetes components in them.
+var rancherSyslogIdentifiers = map[string]struct{}{
+	"k3s":         {},
+	"k3s-agent":   {},
+	"rke2":        {},
+	"rke2-server": {},
+	"rke2-agent":  {},
+}
+
+// RancherLogFile is a log file read from the data directory of k3s or RKE2.
+type RancherLogFile struct {
+	Path     string
+	NodeName string
+	Kind     RancherLogFileKind
+	// ModTime is the modification time of the file. It is used to infer the year missing in the timestamps of klog lines.
+	ModTime time.Time
+	Lines   []string
+}
+
+// RancherLogs is the log files read from the archives of the data directories of k3s or RKE2.
+type RancherLogs struct {
+	Files []*RancherLogFile
+	// SkippedFiles is the count of files not matching any known layout or failed to be read.
+	SkippedFiles int
+}
+
+// FilesOfKind returns the files of the given kind.
+func (r *RancherLogs) FilesOfKind(kind RancherLogFileKind) []*RancherLogFile {
+	var result []*RancherLogFile
+	for _, file := range r.Files {
+		if file.Kind == kind {
+			result = append(result, file)
+		}
+	}
+	return result
+}
+
+// ReadRancherLogArchive walks the archive of the data directory of k3s or RKE2 and appends the known log files to the result.
+func ReadRancherLogArchive(reader io.Reader, result *RancherLogs) error {
+	return upload.WalkArchive(reader, func(file *upload.ArchiveFile) error {
+		filePath := strings.TrimPrefix(path.Clean("/"+file.Name), "/")
+		match := rancherLogFilePattern.FindStringSubmatch(filePath)
+		if match == nil {
+			result.SkippedFiles++
+			return nil
+		}
+		lines, err := readRancherLogLines(file.Content)
+		if err != nil {
+			result.SkippedFiles++
+			return nil
+		}
+		nodeName := match[1]
+		if nodeName == "rancher" {
+			// The archive of `/var/lib/rancher` doesn't have the directory of the node.
+			nodeName = ""
+		}
+		kind := RancherAuditLog
+		switch {
+		case strings.HasSuffix(match[3], "containerd.log"):
+			kind = RancherContainerdLog
+		case strings.HasSuffix(match[3], "kubelet.log"):
+			kind = RancherKubeletLog
+		}
+		result.Files = append(result.Files, &RancherLogFile{
+			Path:     filePath,
+			NodeName: nodeName,
+			Kind:     kind,
+			ModTime:  file.ModTime,
+			Lines:    lines,
+		})
+		return nil
+	})
+}
+
+func readRancherLogLines(content io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(content)
+	scanner.Buffer(make([]byte, 0, 64*1024), rancherMaxLineSizeInBytes)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
+
+// NewLogFromRancherNodeLogLine converts a line of the containerd or kubelet log file to a log in the shape of `journalctl -o json` outputs.
+// It returns false for the lines without the timestamp like the continued lines of multi line messages.
+func NewLogFromRancherNodeLogLine(file *RancherLogFile, lineIndex int) (*log.Log, bool, error) {
+	line := file.Lines[lineIndex]
+	var timestamp time.Time
+	var priority, pid string
+	switch file.Kind {
+	case RancherKubeletLog:
+		match := klogHeaderPattern.FindStringSubmatch(line)
+		if match == nil {
+			return nil, false, nil
+		}
+		reference := file.ModTime
+		if reference.IsZero() {
+			reference = time.Now()
+		}
+		parsed, ok := parseKlogTimestamp(match[2], reference)
+		if !ok {
+			return nil, false, nil
+		}
+		timestamp = parsed
+		priority = klogSeverityToPriority(match[1])
+		pid = match[3]
+	case RancherContainerdLog:
+		match := logrusTimePattern.FindStringSubmatch(line)
+		if match == nil {
+			return nil, false, nil
+		}
+		parsed, err := time.Parse(time.RFC3339Nano, match[1])
+		if err != nil {
+			return nil, false, nil
+		}
+		timestamp = parsed
+		if levelMatch := logrusLevelPattern.FindStringSubmatch(line); levelMatch != nil {
+			priority = logrusLevelToPriority(levelMatch[1])
+		}
+	default:
+		return nil, false, fmt.Errorf("%s is not a node log file", file.Path)
+	}
+	hostname := file.NodeName
+	if hostname == "" {
+		hostname = "unknown"
+	}
+	body := map[string]any{
+		"__CURSOR":             fmt.Sprintf("%s:%d", file.Path, lineIndex+1),
+		"__REALTIME_TIMESTAMP": strconv.FormatInt(timestamp.UnixMicro(), 10),
+		"_HOSTNAME":            hostname,
+		"SYSLOG_IDENTIFIER":    string(file.Kind),
+		"MESSAGE":              line,
+	}
+	if pid != "" {
+		body["_PID"] = pid
+	}
+	if priority != "" {
+		body["PRIORITY"] = priority
+	}
+	serialized, err := json.Marshal(body)
+	if err != nil {
+		return nil, false, err
+	}
+	l, err := log.NewLogFromYAMLString(string(serialized))
+	if err != nil {
+		return nil, false, err
+	}
+	return l, true, nil
+}
+
+// parseKlogTimestamp parses the timestamp in the klog header omitting the year.
+// The year is inferred to place the timestamp before the reference time, which is usually the time the file was written.
+func parseKlogTimestamp(value string, reference time.Time) (time.Time, bool) {
+	timestamp, err := time.Parse("2006 0102 15:04:05.000000", fmt.Sprintf("%d %s", reference.Year(), value))
+	if err != nil {
+		return time.Time{}, false
+	}
+	// Allow the clock skew between the node and the host writing the archive.
+	if timestamp.After(reference.Add(24 * time.Hour)) {
+		timestamp = timestamp.AddDate(-1, 0, 0)
+	}
+	return timestamp, true
+}
+
+// klogSeverityToPriority converts the severity character in klog headers to the syslog priority level.
+func klogSeverityToPriority(severity string) string {
+	switch severity {
+	case "F":
+		return "2"
+	case "E":
+		return "3"
+	case "W":
+		return "4"
+	default:
+		return "6"
+	}
+}
+
+// logrusLevelToPriority converts the logrus level to the syslog priority level.
+func logrusLevelToPriority(level string) string {
+	switch level {
+	case "panic", "fatal":
+		return "2"
+	case "error":
+		return "3"
+	case "warning", "warn":
+		return "4"
+	case "debug", "trace":
+		return "7"
+	default:
+		return "6"
+	}
+}
+
+// RancherEmbeddedComponent returns the name of the component written the message in the journald logs of k3s or RKE2.
+// k3s runs kubelet and etcd in its process, thus their logs are written with the identifier of k3s. This lets the node log parsers handle them as the logs of the component.
+// The identifier is returned as is when it's not k3s or RKE2 or the component is unknown.
+func RancherEmbeddedComponent(identifier string, message string) string {
+	if _, found := rancherSyslogIdentifiers[identifier]; !found {
+		return identifier
+	}
+	if strings.HasPrefix(message, "{") {
+		var etcdLog struct {
+			Logger string `json:"logger"`
+			Caller string `json:"caller"`
+		}
+		if err := json.Unmarshal([]byte(message), &etcdLog); err == nil && (etcdLog.Logger == "etcd" || etcdLog.Logger == "raft" || rancherEtcdCallerPattern.MatchString(etcdLog.Caller)) {
+			return "etcd"
+		}
+		return identifier
+	}
+	if match := klogHeaderPattern.FindStringSubmatch(message); match != nil && rancherKubeletSourceFilePattern.MatchString(match[4]) {
+		return "kubelet"
+	}
+	return identifier
+}
@@ -0,0 +1,155 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_contract
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/testutil/testupload"
+)
+
+func TestReadRancherLogArchive(t *testing.T) {
+	modTime := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	archive := testupload.Zip(t, []testupload.File{
+		{Name: "node-1/rke2/agent/logs/kubelet.log", Content: "I0101 00:00:00.000000    1234 kubelet.go:100] started\n", ModTime: modTime},
+		{Name: "node-1/rke2/agent/containerd/containerd.log", Content: "time=\"2025-01-01T00:00:00Z\" level=info msg=started\n", ModTime: modTime},
+		{Name: "node-1/rke2/server/logs/audit.log", Content: "{\"kind\":\"Event\"}\n", ModTime: modTime},
+		{Name: "rancher/k3s/server/logs/audit-2025-01-01T00-00-00.000.log", Content: "{\"kind\":\"Event\"}\n", ModTime: modTime},
+		{Name: "node-1/rke2/agent/images/rke2-runtime.tar", Content: "", ModTime: modTime},
+	})
+
+	got := &RancherLogs{}
+	err := ReadRancherLogArchive(strings.NewReader(archive), got)
+	if err != nil {
+		t.Fatalf("ReadRancherLogArchive() returned an unexpected error: %v", err)
+	}
+	want := &RancherLogs{
+		Files: []*RancherLogFile{
+			{Path: "node-1/rke2/agent/logs/kubelet.log", NodeName: "node-1", Kind: RancherKubeletLog, ModTime: modTime, Lines: []string{"I0101 00:00:00.000000    1234 kubelet.go:100] started"}},
+			{Path: "node-1/rke2/agent/containerd/containerd.log", NodeName: "node-1", Kind: RancherContainerdLog, ModTime: modTime, Lines: []string{"time=\"2025-01-01T00:00:00Z\" level=info msg=started"}},
+			{Path: "node-1/rke2/server/logs/audit.log", NodeName: "node-1", Kind: RancherAuditLog, ModTime: modTime, Lines: []string{"{\"kind\":\"Event\"}"}},
+			{Path: "rancher/k3s/server/logs/audit-2025-01-01T00-00-00.000.log", NodeName: "", Kind: RancherAuditLog, ModTime: modTime, Lines: []string{"{\"kind\":\"Event\"}"}},
+		},
+		SkippedFiles: 1,
+	}
+	if diff := cmp.Diff(want, got, cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })); diff != "" {
+		t.Errorf("ReadRancherLogArchive() mismatch (-want +got):\n%s", diff)
+	}
+	if got := len(got.FilesOfKind(RancherAuditLog)); got != 2 {
+		t.Errorf("FilesOfKind(RancherAuditLog) returned %d files, want 2", got)
+	}
+}
+
+func TestNewLogFromRancherNodeLogLine(t *testing.T) {
+	modTime := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		desc           string
+		file           *RancherLogFile
+		wantOk         bool
+		wantFields     map[string]string
+		wantTimestamp  time.Time
+		wantIdentifier string
+	}{
+		{
+			desc:   "kubelet log",
+			file:   &RancherLogFile{Path: "node-1/rke2/agent/logs/kubelet.log", NodeName: "node-1", Kind: RancherKubeletLog, ModTime: modTime, Lines: []string{`E0101 12:34:56.789012    1234 kubelet.go:100] "failed"`}},
+			wantOk: true,
+			wantFields: map[string]string{
+				"__CURSOR":          "node-1/rke2/agent/logs/kubelet.log:1",
+				"_HOSTNAME":         "node-1",
+				"SYSLOG_IDENTIFIER": "kubelet",
+				"_PID":              "1234",
+				"PRIORITY":          "3",
+			},
+			wantTimestamp: time.Date(2025, 1, 1, 12, 34, 56, 789012000, time.UTC),
+		},
+		{
+			desc:   "kubelet log written in the previous year",
+			file:   &RancherLogFile{Path: "kubelet.log", Kind: RancherKubeletLog, ModTime: modTime, Lines: []string{`I1231 23:59:59.000000    1234 kubelet.go:100] "started"`}},
+			wantOk: true,
+			wantFields: map[string]string{
+				"_HOSTNAME": "unknown",
+				"PRIORITY":  "6",
+			},
+			wantTimestamp: time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC),
+		},
+		{
+			desc:   "containerd log",
+			file:   &RancherLogFile{Path: "node-1/k3s/agent/containerd/containerd.log", NodeName: "node-1", Kind: RancherContainerdLog, ModTime: modTime, Lines: []string{`time="2025-01-01T00:00:00.123456Z" level=warning msg="cleanup warnings"`}},
+			wantOk: true,
+			wantFields: map[string]string{
+				"SYSLOG_IDENTIFIER": "containerd",
+				"PRIORITY":          "4",
+			},
+			wantTimestamp: time.Date(2025, 1, 1, 0, 0, 0, 123456000, time.UTC),
+		},
+		{
+			desc: "continued line",
+			file: &RancherLogFile{Path: "kubelet.log", Kind: RancherKubeletLog, ModTime: modTime, Lines: []string{"goroutine 1 [running]:"}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l, ok, err := NewLogFromRancherNodeLogLine(tc.file, 0)
+			if err != nil {
+				t.Fatalf("NewLogFromRancherNodeLogLine() returned an unexpected error: %v", err)
+			}
+			if ok != tc.wantOk {
+				t.Fatalf("NewLogFromRancherNodeLogLine() returned %v, want %v", ok, tc.wantOk)
+			}
+			if !ok {
+				return
+			}
+			for field, want := range tc.wantFields {
+				if got := l.ReadStringOrDefault(field, ""); got != want {
+					t.Errorf("%s = %q, want %q", field, got, want)
+				}
+			}
+			if got := l.ReadStringOrDefault("MESSAGE", ""); got != tc.file.Lines[0] {
+				t.Errorf("MESSAGE = %q, want %q", got, tc.file.Lines[0])
+			}
+			if got, want := l.ReadStringOrDefault("__REALTIME_TIMESTAMP", ""), tc.wantTimestamp.UnixMicro(); got != strconv.FormatInt(want, 10) {
+				t.Errorf("__REALTIME_TIMESTAMP = %s, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestRancherEmbeddedComponent(t *testing.T) {
+	testCases := []struct {
+		identifier string
+		message    string
+		want       string
+	}{
+		{identifier: "k3s", message: `I0101 00:00:00.000000    1234 kuberuntime_container.go:100] "Killing container"`, want: "kubelet"},
+		{identifier: "k3s-agent", message: `I0101 00:00:00.000000    1234 pod_workers.go:100] "Pod worker started"`, want: "kubelet"},
+		{identifier: "k3s", message: `I0101 00:00:00.000000    1234 controller.go:100] "Syncing"`, want: "k3s"},
+		{identifier: "k3s", message: `{"level":"info","ts":"2025-01-01T00:00:00Z","caller":"etcdserver/server.go:100","msg":"applied"}`, want: "etcd"},
+		{identifier: "k3s", message: `{"level":"info","logger":"raft","msg":"elected leader"}`, want: "etcd"},
+		{identifier: "rke2", message: `time="2025-01-01T00:00:00Z" level=info msg="Starting rke2"`, want: "rke2"},
+		{identifier: "kubelet", message: `{"caller":"etcdserver/server.go:100"}`, want: "kubelet"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.message, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, RancherEmbeddedComponent(tc.identifier, tc.message)); diff != "" {
+				t.Errorf("RancherEmbeddedComponent(%q, %q) mismatch (-want +got):\n%s", tc.identifier, tc.message, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_contract
+
+import (
+	"slices"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/gcpqueryutil"
+)
+
+const (
+	// NamespaceFilterClusterScoped is the namespace filter item matching any of the cluster scoped resources.
+	NamespaceFilterClusterScoped = "#cluster-scoped"
+	// NamespaceFilterNamespaced is the namespace filter item matching any of the namespaced resources.
+	NamespaceFilterNamespaced = "#namespaced"
+)
+
+// MatchesKindFilter returns true when the plural kind (e.g. `pods`) of the resource given in objectRef.resource of an audit log is selected by the kind filter.
+func MatchesKindFilter(filter *gcpqueryutil.SetFilterParseResult, pluralKind string) bool {
+	if filter.SubtractMode {
+		return !slices.Contains(filter.Subtractives, pluralKind)
+	}
+	return slices.Contains(filter.Additives, pluralKind)
+}
+
+// MatchesNamespaceFilter returns true when the resource given in objectRef of an audit log is selected by the namespace filter.
+// namespace is empty for cluster scoped resources. A Namespace resource is regarded as a resource in the namespace itself, same as the filter used in Cloud Logging.
+func MatchesNamespaceFilter(filter *gcpqueryutil.SetFilterParseResult, pluralKind string, namespace string, name string) bool {
+	if filter.SubtractMode {
+		return !slices.Contains(filter.Subtractives, namespace)
+	}
+	if pluralKind == "namespaces" && namespace == "" && slices.Contains(filter.Additives, name) {
+		return true
+	}
+	if namespace == "" {
+		return slices.Contains(filter.Additives, NamespaceFilterClusterScoped)
+	}
+	return slices.Contains(filter.Additives, NamespaceFilterNamespaced) || slices.Contains(filter.Additives, namespace)
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_contract
+
+import (
+	"testing"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/gcpqueryutil"
+)
+
+func TestMatchesKindFilter(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		filter     *gcpqueryutil.SetFilterParseResult
+		pluralKind string
+		want       bool
+	}{
+		{
+			desc:       "additive filter containing the kind",
+			filter:     &gcpqueryutil.SetFilterParseResult{Additives: []string{"pods", "deployments"}},
+			pluralKind: "pods",
+			want:       true,
+		},
+		{
+			desc:       "additive filter not containing the kind",
+			filter:     &gcpqueryutil.SetFilterParseResult{Additives: []string{"deployments"}},
+			pluralKind: "pods",
+			want:       false,
+		},
+		{
+			desc:       "subtract mode without subtractives",
+			filter:     &gcpqueryutil.SetFilterParseResult{SubtractMode: true},
+			pluralKind: "pods",
+			want:       true,
+		},
+		{
+			desc:       "subtract mode subtracting the kind",
+			filter:     &gcpqueryutil.SetFilterParseResult{SubtractMode: true, Subtractives: []string{"pods"}},
+			pluralKind: "pods",
+			want:       false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := MatchesKindFilter(tc.filter, tc.pluralKind)
+			if got != tc.want {
+				t.Errorf("MatchesKindFilter() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMatchesNamespaceFilter(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		filter     *gcpqueryutil.SetFilterParseResult
+		pluralKind string
+		namespace  string
+		name       string
+		want       bool
+	}{
+		{
+			desc:       "cluster scoped resource with #cluster-scoped",
+			filter:     &gcpqueryutil.SetFilterParseResult{Additives: []string{NamespaceFilterClusterScoped}},
+			pluralKind: "nodes",
+			name:       "node-1",
+			want:       true,
+		},
+		{
+			desc:       "cluster scoped resource without #cluster-scoped",
+			filter:     &gcpqueryutil.SetFilterParseResult{Additives: []string{NamespaceFilterNamespaced}},
+			pluralKind: "nodes",
+			name:       "node-1",
+			want:       false,
+		},
+		{
+			desc:       "namespaced resource with #namespaced",
+			filter:     &gcpqueryutil.SetFilterParseResult{Additives: []string{NamespaceFilterNamespaced}},
+			pluralKind: "pods",
+			namespace:  "kube-system",
+			name:       "pod-1",
+			want:       true,
+		},
+		{
+			desc:       "namespaced resource in the selected namespace",
+			filter:     &gcpqueryutil.SetFilterParseResult{Additives: []string{"default"}},
+			pluralKind: "pods",
+			namespace:  "default",
+			name:       "pod-1",
+			want:       true,
+		},
+		{
+			desc:       "namespaced resource in another namespace",
+			filter:     &gcpqueryutil.SetFilterParseResult{Additives: []string{NamespaceFilterClusterScoped, "default"}},
+			pluralKind: "pods",
+			namespace:  "kube-system",
+			name:       "pod-1",
+			want:       false,
+		},
+		{
+			desc:       "namespace resource of the selected namespace",
+			filter:     &gcpqueryutil.SetFilterParseResult{Additives: []string{"default"}},
+			pluralKind: "namespaces",
+			name:       "default",
+			want:       true,
+		},
+		{
+			desc:       "namespace resource of another namespace",
+			filter:     &gcpqueryutil.SetFilterParseResult{Additives: []string{"default"}},
+			pluralKind: "namespaces",
+			name:       "kube-system",
+			want:       false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := MatchesNamespaceFilter(tc.filter, tc.pluralKind, tc.namespace, tc.name)
+			if got != tc.want {
+				t.Errorf("MatchesNamespaceFilter() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
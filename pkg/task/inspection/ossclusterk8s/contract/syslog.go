@@ -0,0 +1,165 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_contract
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+// rfc5424Pattern matches a syslog message in RFC5424 like `<165>1 2003-10-11T22:14:15.003Z host app 1234 ID47 [exampleSDID@32473 iut="3"] message`.
+var rfc5424Pattern = regexp.MustCompile(`^<(\d{1,3})>1 (\S+) (\S+) (\S+) (\S+) (\S+) (-|(?:\[(?:[^\]\\]|\\.)*\])+)(?: (.*))?$`)
+
+// rfc3164Pattern matches a syslog message in RFC3164 like `<34>Oct 11 22:14:15 host su[123]: message`.
+// The PRI part is optional to accept the files written by syslog daemons like /var/log/syslog. The timestamp can be in RFC3339 as rsyslog writes it with the high precision timestamp format.
+var rfc3164Pattern = regexp.MustCompile(`^(?:<(\d{1,3})>)?([A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2}|\d{4}-\d{2}-\d{2}T\S+) (\S+) ([^\s\[:]+)(?:\[([^\]]*)\])?: ?(.*)$`)
+
+// SyslogMessage is a node log line parsed from the syslog format.
+type SyslogMessage struct {
+	Timestamp time.Time
+	Hostname  string
+	AppName   string
+	ProcID    string
+	// Facility is the facility decoded from the PRI part. It is -1 when the line has no PRI part.
+	Facility int
+	// Severity is the severity level decoded from the PRI part. It is -1 when the line has no PRI part.
+	Severity int
+	Message  string
+}
+
+// IsSyslogLine returns true when the line is a syslog message in RFC3164 or RFC5424.
+func IsSyslogLine(line []byte) bool {
+	lineStr := strings.TrimSpace(string(line))
+	return rfc5424Pattern.MatchString(lineStr) || rfc3164Pattern.MatchString(lineStr)
+}
+
+// ParseSyslogLine parses a syslog message in RFC5424 or RFC3164 detected from the line.
+// Timestamps in RFC3164 omit the year, thus the year is inferred to place the timestamp before the reference time.
+func ParseSyslogLine(line string, reference time.Time) (*SyslogMessage, error) {
+	line = strings.TrimSpace(line)
+	if match := rfc5424Pattern.FindStringSubmatch(line); match != nil {
+		return parseRFC5424Match(match)
+	}
+	if match := rfc3164Pattern.FindStringSubmatch(line); match != nil {
+		return parseRFC3164Match(match, reference)
+	}
+	return nil, fmt.Errorf("the line is neither in RFC5424 nor in RFC3164 syslog format")
+}
+
+func parseRFC5424Match(match []string) (*SyslogMessage, error) {
+	facility, severity, err := parseSyslogPriority(match[1])
+	if err != nil {
+		return nil, err
+	}
+	if match[2] == "-" {
+		return nil, fmt.Errorf("syslog messages without timestamp are not supported")
+	}
+	timestamp, err := time.Parse(time.RFC3339Nano, match[2])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the timestamp of the syslog message: %w", err)
+	}
+	return &SyslogMessage{
+		Timestamp: timestamp,
+		Hostname:  nilValueToEmpty(match[3]),
+		AppName:   nilValueToEmpty(match[4]),
+		ProcID:    nilValueToEmpty(match[5]),
+		Facility:  facility,
+		Severity:  severity,
+		Message:   strings.TrimPrefix(match[8], "\ufeff"),
+	}, nil
+}
+
+func parseRFC3164Match(match []string, reference time.Time) (*SyslogMessage, error) {
+	facility, severity := -1, -1
+	if match[1] != "" {
+		var err error
+		facility, severity, err = parseSyslogPriority(match[1])
+		if err != nil {
+			return nil, err
+		}
+	}
+	timestamp, err := time.Parse(time.RFC3339Nano, match[2])
+	if err != nil {
+		timestamp, err = time.ParseInLocation("2006 Jan _2 15:04:05", fmt.Sprintf("%d %s", reference.Year(), match[2]), reference.Location())
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the timestamp of the syslog message: %w", err)
+		}
+		// Allow the clock skew between the node and the reference.
+		if timestamp.After(reference.Add(24 * time.Hour)) {
+			timestamp = timestamp.AddDate(-1, 0, 0)
+		}
+	}
+	return &SyslogMessage{
+		Timestamp: timestamp,
+		Hostname:  match[3],
+		AppName:   match[4],
+		ProcID:    match[5],
+		Facility:  facility,
+		Severity:  severity,
+		Message:   match[6],
+	}, nil
+}
+
+// parseSyslogPriority decodes the facility and the severity level from the PRI part.
+func parseSyslogPriority(priority string) (int, int, error) {
+	pri, err := strconv.Atoi(priority)
+	if err != nil || pri > 191 {
+		return 0, 0, fmt.Errorf("invalid syslog priority %q", priority)
+	}
+	return pri / 8, pri % 8, nil
+}
+
+// nilValueToEmpty converts the NILVALUE `-` of RFC5424 headers to an empty string.
+func nilValueToEmpty(value string) string {
+	if value == "-" {
+		return ""
+	}
+	return value
+}
+
+// NewLogFromSyslogLine converts a syslog formatted node log line to a log in the shape of `journalctl -o json` outputs.
+// The severity level is written in the PRIORITY field as journald does, thus OSSJournaldCommonFieldSetReader maps it to the severity.
+func NewLogFromSyslogLine(line string, reference time.Time) (*log.Log, error) {
+	message, err := ParseSyslogLine(line, reference)
+	if err != nil {
+		return nil, err
+	}
+	hash := fnv.New64a()
+	hash.Write([]byte(line))
+	body := map[string]any{
+		"__CURSOR":             fmt.Sprintf("%016x", hash.Sum64()),
+		"__REALTIME_TIMESTAMP": strconv.FormatInt(message.Timestamp.UnixMicro(), 10),
+		"_HOSTNAME":            message.Hostname,
+		"SYSLOG_IDENTIFIER":    message.AppName,
+		"_PID":                 message.ProcID,
+		"MESSAGE":              message.Message,
+	}
+	if message.Severity >= 0 {
+		body["PRIORITY"] = strconv.Itoa(message.Severity)
+		body["SYSLOG_FACILITY"] = strconv.Itoa(message.Facility)
+	}
+	serialized, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	return log.NewLogFromYAMLString(string(serialized))
+}
@@ -0,0 +1,176 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_contract
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+func TestParseSyslogLine(t *testing.T) {
+	reference := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		desc    string
+		line    string
+		want    *SyslogMessage
+		wantErr bool
+	}{
+		{
+			desc: "RFC5424",
+			line: `<165>1 2025-01-02T03:04:05.123Z node-1 kubelet 1234 ID47 [exampleSDID@32473 iut="3" eventSource="App\]"] started`,
+			want: &SyslogMessage{
+				Timestamp: time.Date(2025, 1, 2, 3, 4, 5, 123000000, time.UTC),
+				Hostname:  "node-1",
+				AppName:   "kubelet",
+				ProcID:    "1234",
+				Facility:  20,
+				Severity:  5,
+				Message:   "started",
+			},
+		},
+		{
+			desc: "RFC5424 with nil values",
+			line: `<11>1 2025-01-02T03:04:05Z - containerd - - - failed`,
+			want: &SyslogMessage{
+				Timestamp: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+				AppName:   "containerd",
+				Facility:  1,
+				Severity:  3,
+				Message:   "failed",
+			},
+		},
+		{
+			desc: "RFC3164",
+			line: `<28>Jan  2 03:04:05 node-1 kubelet[123]: I0102 03:04:05.000000     123 kubelet.go:10] started`,
+			want: &SyslogMessage{
+				Timestamp: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+				Hostname:  "node-1",
+				AppName:   "kubelet",
+				ProcID:    "123",
+				Facility:  3,
+				Severity:  4,
+				Message:   "I0102 03:04:05.000000     123 kubelet.go:10] started",
+			},
+		},
+		{
+			desc: "RFC3164 without PRI from the previous year",
+			line: `Dec 31 23:59:59 node-1 containerd: stopped`,
+			want: &SyslogMessage{
+				Timestamp: time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC),
+				Hostname:  "node-1",
+				AppName:   "containerd",
+				Facility:  -1,
+				Severity:  -1,
+				Message:   "stopped",
+			},
+		},
+		{
+			desc: "rsyslog high precision timestamp",
+			line: `2025-01-02T03:04:05.123456+00:00 node-1 systemd[1]: Started kubelet.service.`,
+			want: &SyslogMessage{
+				Timestamp: time.Date(2025, 1, 2, 3, 4, 5, 123456000, time.UTC),
+				Hostname:  "node-1",
+				AppName:   "systemd",
+				ProcID:    "1",
+				Facility:  -1,
+				Severity:  -1,
+				Message:   "Started kubelet.service.",
+			},
+		},
+		{
+			desc:    "invalid priority",
+			line:    `<192>Jan  2 03:04:05 node-1 kubelet: started`,
+			wantErr: true,
+		},
+		{
+			desc:    "plain text",
+			line:    `started kubelet`,
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := ParseSyslogLine(tc.line, reference)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("ParseSyslogLine() returned no error, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSyslogLine() returned an unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ParseSyslogLine() mismatch (-want +got):\n%s", diff)
+			}
+			if !IsSyslogLine([]byte(tc.line)) {
+				t.Errorf("IsSyslogLine() = false, want true")
+			}
+		})
+	}
+}
+
+func TestNewLogFromSyslogLine(t *testing.T) {
+	reference := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		desc         string
+		line         string
+		wantSeverity enum.Severity
+	}{
+		{
+			desc:         "error severity",
+			line:         `<27>Jan  2 03:04:05 node-1 kubelet[123]: failed`,
+			wantSeverity: enum.SeverityError,
+		},
+		{
+			desc:         "info severity",
+			line:         `<30>1 2025-01-02T03:04:05Z node-1 kubelet 123 - - started`,
+			wantSeverity: enum.SeverityInfo,
+		},
+		{
+			desc:         "without PRI",
+			line:         `Jan  2 03:04:05 node-1 kubelet[123]: started`,
+			wantSeverity: enum.SeverityUnknown,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l, err := NewLogFromSyslogLine(tc.line, reference)
+			if err != nil {
+				t.Fatalf("NewLogFromSyslogLine() returned an unexpected error: %v", err)
+			}
+			if err := l.SetFieldSetReader(&OSSJournaldCommonFieldSetReader{}); err != nil {
+				t.Fatalf("SetFieldSetReader() returned an unexpected error: %v", err)
+			}
+			commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+			if commonFieldSet.Severity != tc.wantSeverity {
+				t.Errorf("Severity = %v, want %v", commonFieldSet.Severity, tc.wantSeverity)
+			}
+			if want := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC); !commonFieldSet.Timestamp.Equal(want) {
+				t.Errorf("Timestamp = %v, want %v", commonFieldSet.Timestamp, want)
+			}
+			if len(commonFieldSet.DisplayID) != 16 {
+				t.Errorf("DisplayID = %q, want a 16 characters hash", commonFieldSet.DisplayID)
+			}
+			if got := l.ReadStringOrDefault("SYSLOG_IDENTIFIER", ""); got != "kubelet" {
+				t.Errorf("SYSLOG_IDENTIFIER = %q, want %q", got, "kubelet")
+			}
+		})
+	}
+}
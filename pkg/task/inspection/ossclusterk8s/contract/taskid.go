@@ -15,16 +15,25 @@
 package ossclusterk8s_contract
 
 import (
+	"github.com/kyasbal/khi/pkg/core/inspection/gcpqueryutil"
 	"github.com/kyasbal/khi/pkg/core/task/taskid"
 	"github.com/kyasbal/khi/pkg/model/log"
 	"github.com/kyasbal/khi/pkg/server/upload"
 	commonlogk8sauditv2_contract "github.com/kyasbal/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	googlecloudlogk8snode_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8snode/contract"
 )
 
 // OSSTaskPrefix is the prefixes of IDs used in OSS related tasks.
 const OSSTaskPrefix = "khi.google.com/oss/"
 
-var InputAuditLogFilesFormTaskID = taskid.NewDefaultImplementationID[upload.UploadResult](OSSTaskPrefix + "form/kube-apiserver-audit-log-files")
+var InputAuditLogFilesFormTaskID = taskid.NewDefaultImplementationID[upload.UploadResultList](OSSTaskPrefix + "form/kube-apiserver-audit-log-files")
+
+// InputKindFilterTaskID is the task ID for the kind filter applied to the uploaded audit logs.
+var InputKindFilterTaskID = taskid.NewDefaultImplementationID[*gcpqueryutil.SetFilterParseResult](OSSTaskPrefix + "form/kinds")
+
+// InputNamespaceFilterTaskID is the task ID for the namespace filter applied to the uploaded audit logs.
+var InputNamespaceFilterTaskID = taskid.NewDefaultImplementationID[*gcpqueryutil.SetFilterParseResult](OSSTaskPrefix + "form/namespaces")
+
 var AuditLogFileReaderTaskID = taskid.NewDefaultImplementationID[[]*log.Log](OSSTaskPrefix + "audit-log-reader")
 var NonEventAuditLogFilterTaskID = taskid.NewDefaultImplementationID[[]*log.Log](OSSTaskPrefix + "audit-log-filter-non-event-audit")
 var EventAuditLogFilterTaskID = taskid.NewDefaultImplementationID[[]*log.Log](OSSTaskPrefix + "audit-log-filter-event-audit")
@@ -32,3 +41,32 @@ var OSSK8sEventLogParserTaskID = taskid.NewDefaultImplementationID[struct{}](OSS
 
 var OSSK8sAuditLogProviderTaskID = taskid.NewImplementationID(commonlogk8sauditv2_contract.K8sAuditLogProviderRef, "oss")
 var OSSK8sAuditLogParserTailTaskID = taskid.NewImplementationID(commonlogk8sauditv2_contract.K8sAuditLogParserTailRef, "oss")
+
+var OSSK8sAuditPermissionDeniedParserTailTaskID = taskid.NewImplementationID(commonlogk8sauditv2_contract.K8sAuditPermissionDeniedParserTailRef, "oss")
+
+// InputNodeLogFilesFormTaskID is the task ID for the form to upload journald logs exported from nodes.
+var InputNodeLogFilesFormTaskID = taskid.NewDefaultImplementationID[upload.UploadResultList](OSSTaskPrefix + "form/node-journald-log-files")
+
+// InputRancherLogArchivesFormTaskID is the task ID for the form to upload archives of the data directories of k3s or RKE2.
+var InputRancherLogArchivesFormTaskID = taskid.NewDefaultImplementationID[upload.UploadResultList](OSSTaskPrefix + "form/rancher-log-archives")
+
+// RancherLogArchiveReaderTaskID is the task ID to read the log files in the uploaded archives of the data directories of k3s or RKE2.
+var RancherLogArchiveReaderTaskID = taskid.NewDefaultImplementationID[*RancherLogs](OSSTaskPrefix + "rancher-log-archive-reader")
+
+// OSSNodeLogFileReaderTaskID is the task ID to read the uploaded journald logs as the source of the node log parsers.
+var OSSNodeLogFileReaderTaskID = taskid.NewImplementationID(googlecloudlogk8snode_contract.ListLogEntriesTaskID.Ref(), "oss")
+
+// OSSNodeLogCommonFieldSetReaderTaskID is the task ID to read the fieldset used by the node log parsers from journald logs.
+var OSSNodeLogCommonFieldSetReaderTaskID = taskid.NewImplementationID(googlecloudlogk8snode_contract.CommonFieldsetReaderTaskID.Ref(), "oss")
+
+// OSSNodeLogParserTailTaskID is the task ID of the feature task to parse the uploaded journald logs.
+var OSSNodeLogParserTailTaskID = taskid.NewDefaultImplementationID[struct{}](OSSTaskPrefix + "node-log-parser-tail")
+
+// InputKubectlDumpFilesFormTaskID is the task ID for the form to upload outputs of `kubectl get -o json` or `kubectl get -o yaml`.
+var InputKubectlDumpFilesFormTaskID = taskid.NewDefaultImplementationID[upload.UploadResultList](OSSTaskPrefix + "form/kubectl-output-files")
+
+// KubectlDumpReaderTaskID is the task ID to read the objects in the uploaded kubectl outputs as logs.
+var KubectlDumpReaderTaskID = taskid.NewDefaultImplementationID[[]*log.Log](OSSTaskPrefix + "kubectl-output-reader")
+
+// OSSKubectlDumpParserTaskID is the task ID of the feature task to generate revisions and events from the uploaded kubectl outputs.
+var OSSKubectlDumpParserTaskID = taskid.NewDefaultImplementationID[struct{}](OSSTaskPrefix + "kubectl-output-parser")
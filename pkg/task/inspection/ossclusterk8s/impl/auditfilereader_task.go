@@ -16,13 +16,12 @@ package ossclusterk8s_impl
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"io"
 	"slices"
 	"strings"
 
-	"github.com/kyasbal/khi/pkg/common/khictx"
-	"github.com/kyasbal/khi/pkg/common/typedmap"
+	"github.com/kyasbal/khi/pkg/common/structured"
 	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
 	"github.com/kyasbal/khi/pkg/core/inspection/progressutil"
 	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
@@ -37,6 +36,7 @@ var AuditLogFileReaderTask = inspectiontaskbase.NewProgressReportableInspectionT
 	ossclusterk8s_contract.AuditLogFileReaderTaskID,
 	[]taskid.UntypedTaskReference{
 		ossclusterk8s_contract.InputAuditLogFilesFormTaskID.Ref(),
+		ossclusterk8s_contract.RancherLogArchiveReaderTaskID.Ref(),
 	},
 	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, tp *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, error) {
 		if taskMode == inspectioncore_contract.TaskModeDryRun {
@@ -44,18 +44,14 @@ var AuditLogFileReaderTask = inspectiontaskbase.NewProgressReportableInspectionT
 		}
 		result := coretask.GetTaskResult(ctx, ossclusterk8s_contract.InputAuditLogFilesFormTaskID.Ref())
 
-		reader, err := result.GetReader()
+		logLines, err := readUploadedLogLines(result)
 		if err != nil {
 			return nil, err
 		}
-		defer reader.Close()
-
-		logData, err := io.ReadAll(reader)
-		if err != nil {
-			return nil, err
+		rancherLogs := coretask.GetTaskResult(ctx, ossclusterk8s_contract.RancherLogArchiveReaderTaskID.Ref())
+		for _, file := range rancherLogs.FilesOfKind(ossclusterk8s_contract.RancherAuditLog) {
+			logLines = append(logLines, file.Lines...)
 		}
-
-		logLines := strings.Split(string(logData), "\n")
 		var logs []*log.Log
 
 		progressutil.ReportProgressFromArraySync(tp, logLines, func(i int, line string) error {
@@ -63,7 +59,11 @@ var AuditLogFileReaderTask = inspectiontaskbase.NewProgressReportableInspectionT
 				return nil
 			}
 
-			l, err := log.NewLogFromYAMLString(line)
+			// Audit logs are usually JSON lines. Most of fields in their request or response body are not read, parse them lazily.
+			l, err := log.NewLogFromJSON([]byte(line))
+			if errors.Is(err, structured.ErrInvalidJSON) {
+				l, err = log.NewLogFromYAMLString(line)
+			}
 			if err != nil {
 				return fmt.Errorf("failed to read a log: %w", err)
 			}
@@ -87,16 +87,7 @@ var AuditLogFileReaderTask = inspectiontaskbase.NewProgressReportableInspectionT
 			logBCommonField := log.MustGetFieldSet(b, &log.CommonFieldSet{})
 			return int(logACommonField.Timestamp.UnixNano() - logBCommonField.Timestamp.UnixNano())
 		})
-		metadataSet := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
-		header := typedmap.GetOrDefault(metadataSet, inspectionmetadata.HeaderMetadataKey, &inspectionmetadata.HeaderMetadata{})
-
-		if len(logs) > 0 {
-			startLogCommonField := log.MustGetFieldSet(logs[0], &log.CommonFieldSet{})
-			lastLogCommonField := log.MustGetFieldSet(logs[len(logs)-1], &log.CommonFieldSet{})
-
-			header.StartTimeUnixSeconds = startLogCommonField.Timestamp.Unix()
-			header.EndTimeUnixSeconds = lastLogCommonField.Timestamp.Unix()
-		}
+		extendHeaderTimeRange(ctx, logs)
 
 		return logs, nil
 	},
@@ -42,6 +42,7 @@ var OSSK8sAuditLogParserTailTask = inspectiontaskbase.NewInspectionTask(
 		commonlogk8sauditv2_contract.NamespaceRequestLogToTimelineMapperTaskID.Ref(),
 		commonlogk8sauditv2_contract.ResourceRevisionLogToTimelineMapperTaskID.Ref(),
 		commonlogk8sauditv2_contract.ConditionLogToTimelineMapperTaskID.Ref(),
+		commonlogk8sauditv2_contract.VeleroOperationLogToTimelineMapperTaskID.Ref(),
 		commonlogk8sauditv2_contract.ResourceOwnerReferenceTimelineMapperTaskID.Ref(),
 		commonlogk8sauditv2_contract.PodPhaseLogToTimelineMapperTaskID.Ref(),
 		commonlogk8sauditv2_contract.EndpointResourceLogToTimelineMapperTaskID.Ref(),
@@ -57,3 +58,14 @@ var OSSK8sAuditLogParserTailTask = inspectiontaskbase.NewInspectionTask(
 	},
 	inspectioncore_contract.FeatureTaskLabel("Kubernetes Audit Log(v3)", `Gather kubernetes audit logs and visualize resource modifications.`, enum.LogTypeAudit, 1001, true, ossclusterk8s_contract.InspectionTypeID), coretask.NewSubsequentTaskRefsTaskLabel(inspectioncore_contract.SerializerTaskID.Ref()),
 )
+
+var OSSK8sAuditPermissionDeniedParserTailTask = inspectiontaskbase.NewInspectionTask(
+	ossclusterk8s_contract.OSSK8sAuditPermissionDeniedParserTailTaskID,
+	[]taskid.UntypedTaskReference{
+		commonlogk8sauditv2_contract.PermissionDeniedLogToTimelineMapperTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (struct{}, error) {
+		return struct{}{}, nil
+	},
+	inspectioncore_contract.FeatureTaskLabel("Kubernetes Permission Denials", `Gather kubernetes audit logs of requests denied by the authorizer and show them on timelines grouped by the principal and the resource. Useful to find authorization regressions like broken RBAC rules after an upgrade.`, enum.LogTypeAudit, 1002, false, ossclusterk8s_contract.InspectionTypeID), coretask.NewSubsequentTaskRefsTaskLabel(inspectioncore_contract.SerializerTaskID.Ref()),
+)
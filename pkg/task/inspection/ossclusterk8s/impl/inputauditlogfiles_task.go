@@ -20,8 +20,8 @@ import (
 	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
 )
 
-var InputAuditLogFilesTask = formtask.NewFileFormTaskBuilder(ossclusterk8s_contract.InputAuditLogFilesFormTaskID, 1000, "Audit Log Files", &upload.JSONLineUploadFileVerifier{
+var InputAuditLogFilesTask = formtask.NewMultiFileFormTaskBuilder(ossclusterk8s_contract.InputAuditLogFilesFormTaskID, 1000, "Audit Log Files", &upload.JSONLineUploadFileVerifier{
 	MaxLineSizeInBytes: 1024 * 1024 * 1024,
 }).
-	WithDescription(`Upload JSONLine format kube-apiserver audit log`).
+	WithDescription(`Upload JSONLine format kube-apiserver audit log files. Multiple files like rotated log shards can be uploaded at once.`).
 	Build()
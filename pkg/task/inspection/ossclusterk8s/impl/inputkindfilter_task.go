@@ -0,0 +1,50 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"context"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	"github.com/kyasbal/khi/pkg/core/inspection/gcpqueryutil"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// InputKindFilterTask is a form task for inputting the kinds of resources to parse from the audit logs.
+var InputKindFilterTask = formtask.NewSetFormTaskBuilder(ossclusterk8s_contract.InputKindFilterTaskID, 900, "Kind").
+	WithPosition(inspectionmetadata.FormPosition{After: []string{ossclusterk8s_contract.InputAuditLogFilesFormTaskID.ReferenceIDString()}}).
+	WithDefaultValueConstant([]string{"@any"}, true).
+	WithDescription("The plural kinds of resources to parse from the audit logs (e.g. `pods`). Specify `@any` to parse every kinds of resources. Prefix a kind with `-` to exclude it from `@any`.").
+	WithAllowAddAll(false).
+	WithAllowRemoveAll(false).
+	WithAllowCustomValue(true).
+	WithOptionsConstant([]inspectionmetadata.SetParameterFormFieldOptionItem{
+		{ID: "@any", Description: "[Alias] An alias matches any of the kinds"},
+	}).
+	WithValidator(func(ctx context.Context, value []string) (string, error) {
+		if len(value) == 0 {
+			return "kind filter can't be empty", nil
+		}
+		result, err := gcpqueryutil.ParseSetFilterItems(value, gcpqueryutil.SetFilterAliasToItemsMap{}, true, true, true)
+		if err != nil {
+			return "", err
+		}
+		return result.ValidationError, nil
+	}).
+	WithConverter(func(ctx context.Context, value []string) (*gcpqueryutil.SetFilterParseResult, error) {
+		return gcpqueryutil.ParseSetFilterItems(value, gcpqueryutil.SetFilterAliasToItemsMap{}, true, true, true)
+	}).
+	Build()
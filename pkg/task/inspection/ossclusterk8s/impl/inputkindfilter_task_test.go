@@ -0,0 +1,112 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	form_task_test "github.com/kyasbal/khi/pkg/core/inspection/formtask/test"
+	"github.com/kyasbal/khi/pkg/core/inspection/gcpqueryutil"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+func TestKindFilterInput(t *testing.T) {
+	wantBase := inspectionmetadata.ParameterFormFieldBase{
+		Label:       "Kind",
+		Description: "The plural kinds of resources to parse from the audit logs (e.g. `pods`). Specify `@any` to parse every kinds of resources. Prefix a kind with `-` to exclude it from `@any`.",
+		HintType:    inspectionmetadata.None,
+	}
+	wantErrorBase := wantBase
+	wantErrorBase.HintType = inspectionmetadata.Error
+	wantErrorBase.Hint = "kind filter can't be empty"
+
+	form_task_test.TestSetForms(t, "kind", InputKindFilterTask, []*form_task_test.SetFormTestCase{
+		{
+			Name:  "with valid kinds",
+			Input: []string{"pods", "deployments"},
+			ExpectedFormField: inspectionmetadata.SetParameterFormField{
+				ParameterFormFieldBase: wantBase,
+				AllowCustomValue:       true,
+				Options: []inspectionmetadata.SetParameterFormFieldOptionItem{
+					{ID: "@any", Description: "[Alias] An alias matches any of the kinds"},
+				},
+				Default: []string{"@any"},
+			},
+		},
+		{
+			Name:  "with empty kinds",
+			Input: []string{},
+			ExpectedFormField: inspectionmetadata.SetParameterFormField{
+				ParameterFormFieldBase: wantErrorBase,
+				AllowCustomValue:       true,
+				Default:                []string{"@any"},
+			},
+		},
+	})
+}
+
+func TestKindFilterInputValue(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		input       []string
+		want        *gcpqueryutil.SetFilterParseResult
+		wantMatches map[string]bool
+	}{
+		{
+			desc:        "default value",
+			input:       nil,
+			want:        &gcpqueryutil.SetFilterParseResult{SubtractMode: true},
+			wantMatches: map[string]bool{"pods": true, "deployments": true},
+		},
+		{
+			desc:        "specific kinds",
+			input:       []string{"pods", "deployments"},
+			want:        &gcpqueryutil.SetFilterParseResult{Additives: []string{"deployments", "pods"}},
+			wantMatches: map[string]bool{"pods": true, "deployments": true, "services": false},
+		},
+		{
+			desc:        "kinds excluded from @any",
+			input:       []string{"@any", "-events"},
+			want:        &gcpqueryutil.SetFilterParseResult{Subtractives: []string{"events"}, SubtractMode: true},
+			wantMatches: map[string]bool{"pods": true, "events": false},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			input := map[string]any{}
+			if tc.input != nil {
+				input[ossclusterk8s_contract.InputKindFilterTaskID.ReferenceIDString()] = tc.input
+			}
+			ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+			got, _, err := inspectiontest.RunInspectionTask(ctx, InputKindFilterTask, inspectioncore_contract.TaskModeRun, input)
+			if err != nil {
+				t.Fatalf("InputKindFilterTask returned an unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("InputKindFilterTask mismatch (-want +got):\n%s", diff)
+			}
+			for kind, want := range tc.wantMatches {
+				if matched := ossclusterk8s_contract.MatchesKindFilter(got, kind); matched != want {
+					t.Errorf("MatchesKindFilter(%q) = %v, want %v", kind, matched, want)
+				}
+			}
+		})
+	}
+}
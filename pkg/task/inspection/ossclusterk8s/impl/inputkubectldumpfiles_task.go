@@ -0,0 +1,29 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	"github.com/kyasbal/khi/pkg/server/upload"
+	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// kubectlDumpFileVerifier accepts outputs of kubectl in JSON or YAML.
+var kubectlDumpFileVerifier = &upload.YAMLUploadFileVerifier{}
+
+// InputKubectlDumpFilesTask is a form task to upload outputs of kubectl.
+var InputKubectlDumpFilesTask = formtask.NewMultiFileFormTaskBuilder(ossclusterk8s_contract.InputKubectlDumpFilesFormTaskID, 600, "kubectl Output Files", kubectlDumpFileVerifier).
+	WithDescription("Upload outputs of `kubectl get events -A -o json` and `kubectl get <kind> -A -o yaml`. Events are shown at their last occurrence on the timeline of the involved object, and other resources are shown from their creation time with the dumped manifest. Files for multiple kinds can be uploaded at once.").
+	Build()
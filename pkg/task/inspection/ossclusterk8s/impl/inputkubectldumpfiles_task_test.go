@@ -0,0 +1,71 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kyasbal/khi/pkg/server/upload"
+)
+
+func TestKubectlDumpFileVerifier(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		content string
+		wantErr bool
+	}{
+		{
+			desc:    "kubectl get -o json",
+			content: `{"apiVersion":"v1","kind":"List","items":[{"apiVersion":"v1","kind":"Event","metadata":{"name":"ev1"}}]}`,
+		},
+		{
+			desc: "kubectl get -o yaml with multiple documents",
+			content: `apiVersion: v1
+kind: Pod
+metadata:
+  name: pod1
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: svc1
+`,
+		},
+		{
+			desc:    "broken JSON",
+			content: `{"apiVersion":"v1","kind":"List","items":[`,
+			wantErr: true,
+		},
+		{
+			desc:    "broken YAML",
+			content: "apiVersion: v1\nkind: [Pod\n",
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			provider := upload.NewLocalUploadFileStoreProvider(t.TempDir())
+			token := &upload.DirectUploadToken{ID: "kubectl-output"}
+			if err := provider.Write(token, strings.NewReader(tc.content)); err != nil {
+				t.Fatalf("failed to write the fixture file: %v", err)
+			}
+			err := kubectlDumpFileVerifier.Verify(provider, token)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Verify() returned error %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"context"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	"github.com/kyasbal/khi/pkg/core/inspection/gcpqueryutil"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+var inputNamespacesAliasMap gcpqueryutil.SetFilterAliasToItemsMap = map[string][]string{
+	"all_cluster_scoped": {ossclusterk8s_contract.NamespaceFilterClusterScoped},
+	"all_namespaced":     {ossclusterk8s_contract.NamespaceFilterNamespaced},
+}
+
+// InputNamespaceFilterTask is a form task for inputting the namespaces of resources to parse from the audit logs.
+var InputNamespaceFilterTask = formtask.NewSetFormTaskBuilder(ossclusterk8s_contract.InputNamespaceFilterTaskID, 800, "Namespaces").
+	WithPosition(inspectionmetadata.FormPosition{After: []string{ossclusterk8s_contract.InputKindFilterTaskID.ReferenceIDString()}}).
+	WithDefaultValueConstant([]string{"@all_cluster_scoped", "@all_namespaced"}, true).
+	WithDescription("The namespace of resources to parse from the audit logs. Specify `@all_cluster_scoped` to parse logs for all non-namespaced resources. Specify `@all_namespaced` to parse logs for all namespaced resources.").
+	WithAllowAddAll(false).
+	WithAllowRemoveAll(false).
+	WithAllowCustomValue(true).
+	WithOptionsConstant([]inspectionmetadata.SetParameterFormFieldOptionItem{
+		{ID: "@all_cluster_scoped", Description: "[Alias] An alias matches any of the cluster scoped resources"},
+		{ID: "@all_namespaced", Description: "[Alias] An alias matches any of the namespaced resources"},
+	}).
+	WithValidator(func(ctx context.Context, value []string) (string, error) {
+		if len(value) == 0 {
+			return "namespace filter can't be empty", nil
+		}
+		result, err := gcpqueryutil.ParseSetFilterItems(value, inputNamespacesAliasMap, false, false, true)
+		if err != nil {
+			return "", err
+		}
+		return result.ValidationError, nil
+	}).
+	WithConverter(func(ctx context.Context, value []string) (*gcpqueryutil.SetFilterParseResult, error) {
+		return gcpqueryutil.ParseSetFilterItems(value, inputNamespacesAliasMap, false, false, true)
+	}).
+	Build()
@@ -0,0 +1,129 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	form_task_test "github.com/kyasbal/khi/pkg/core/inspection/formtask/test"
+	"github.com/kyasbal/khi/pkg/core/inspection/gcpqueryutil"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+func TestNamespaceFilterInput(t *testing.T) {
+	wantBase := inspectionmetadata.ParameterFormFieldBase{
+		Label:       "Namespaces",
+		Description: "The namespace of resources to parse from the audit logs. Specify `@all_cluster_scoped` to parse logs for all non-namespaced resources. Specify `@all_namespaced` to parse logs for all namespaced resources.",
+		HintType:    inspectionmetadata.None,
+	}
+	wantErrorBase := wantBase
+	wantErrorBase.HintType = inspectionmetadata.Error
+	wantErrorBase.Hint = "namespace filter can't be empty"
+
+	form_task_test.TestSetForms(t, "namespaces", InputNamespaceFilterTask, []*form_task_test.SetFormTestCase{
+		{
+			Name:  "with valid namespaces",
+			Input: []string{"default", "@all_cluster_scoped"},
+			ExpectedFormField: inspectionmetadata.SetParameterFormField{
+				ParameterFormFieldBase: wantBase,
+				AllowCustomValue:       true,
+				Options: []inspectionmetadata.SetParameterFormFieldOptionItem{
+					{ID: "@all_cluster_scoped", Description: "[Alias] An alias matches any of the cluster scoped resources"},
+					{ID: "@all_namespaced", Description: "[Alias] An alias matches any of the namespaced resources"},
+				},
+				Default: []string{"@all_cluster_scoped", "@all_namespaced"},
+			},
+		},
+		{
+			Name:  "with empty namespaces",
+			Input: []string{},
+			ExpectedFormField: inspectionmetadata.SetParameterFormField{
+				ParameterFormFieldBase: wantErrorBase,
+				AllowCustomValue:       true,
+				Default:                []string{"@all_cluster_scoped", "@all_namespaced"},
+			},
+		},
+	})
+}
+
+func TestNamespaceFilterInputValue(t *testing.T) {
+	type resource struct {
+		pluralKind string
+		namespace  string
+		name       string
+	}
+	testCases := []struct {
+		desc        string
+		input       []string
+		want        *gcpqueryutil.SetFilterParseResult
+		wantMatches map[resource]bool
+	}{
+		{
+			desc:  "default value",
+			input: nil,
+			want:  &gcpqueryutil.SetFilterParseResult{Additives: []string{ossclusterk8s_contract.NamespaceFilterClusterScoped, ossclusterk8s_contract.NamespaceFilterNamespaced}},
+			wantMatches: map[resource]bool{
+				{pluralKind: "nodes", name: "node-1"}:                   true,
+				{pluralKind: "pods", namespace: "default", name: "foo"}: true,
+			},
+		},
+		{
+			desc:  "specific namespace and cluster scoped resources",
+			input: []string{"kube-system", "@all_cluster_scoped"},
+			want:  &gcpqueryutil.SetFilterParseResult{Additives: []string{ossclusterk8s_contract.NamespaceFilterClusterScoped, "kube-system"}},
+			wantMatches: map[resource]bool{
+				{pluralKind: "nodes", name: "node-1"}:                       true,
+				{pluralKind: "pods", namespace: "kube-system", name: "foo"}: true,
+				{pluralKind: "pods", namespace: "default", name: "foo"}:     false,
+				{pluralKind: "namespaces", name: "kube-system"}:             true,
+			},
+		},
+		{
+			desc:  "specific namespace only",
+			input: []string{"default"},
+			want:  &gcpqueryutil.SetFilterParseResult{Additives: []string{"default"}},
+			wantMatches: map[resource]bool{
+				{pluralKind: "nodes", name: "node-1"}:                   false,
+				{pluralKind: "pods", namespace: "default", name: "foo"}: true,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			input := map[string]any{}
+			if tc.input != nil {
+				input[ossclusterk8s_contract.InputNamespaceFilterTaskID.ReferenceIDString()] = tc.input
+			}
+			ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+			got, _, err := inspectiontest.RunInspectionTask(ctx, InputNamespaceFilterTask, inspectioncore_contract.TaskModeRun, input)
+			if err != nil {
+				t.Fatalf("InputNamespaceFilterTask returned an unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("InputNamespaceFilterTask mismatch (-want +got):\n%s", diff)
+			}
+			for r, want := range tc.wantMatches {
+				if matched := ossclusterk8s_contract.MatchesNamespaceFilter(got, r.pluralKind, r.namespace, r.name); matched != want {
+					t.Errorf("MatchesNamespaceFilter(%q, %q, %q) = %v, want %v", r.pluralKind, r.namespace, r.name, matched, want)
+				}
+			}
+		})
+	}
+}
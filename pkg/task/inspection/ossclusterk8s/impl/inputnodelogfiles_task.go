@@ -0,0 +1,32 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	"github.com/kyasbal/khi/pkg/server/upload"
+	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// nodeLogFileVerifier accepts journald logs exported in JSON lines and syslog formatted lines.
+var nodeLogFileVerifier = &upload.JSONLineUploadFileVerifier{
+	MaxLineSizeInBytes: 1024 * 1024 * 1024,
+	AcceptNonJSONLine:  ossclusterk8s_contract.IsSyslogLine,
+}
+
+// InputNodeLogFilesTask is a form task to upload journald or syslog formatted logs exported from nodes.
+var InputNodeLogFilesTask = formtask.NewMultiFileFormTaskBuilder(ossclusterk8s_contract.InputNodeLogFilesFormTaskID, 700, "Node Log Files", nodeLogFileVerifier).
+	WithDescription("Upload journald logs exported from nodes with `journalctl -o json`. Export the logs of kubelet and the container runtime units like `journalctl -o json -u kubelet -u containerd` (or `-u crio` for CRI-O nodes) on each node. Syslog formatted files in RFC3164 or RFC5424 like /var/log/syslog are also accepted. Files from multiple nodes can be uploaded at once.").
+	Build()
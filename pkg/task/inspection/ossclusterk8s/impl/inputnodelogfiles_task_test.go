@@ -0,0 +1,65 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kyasbal/khi/pkg/server/upload"
+)
+
+func TestNodeLogFileVerifier(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		content string
+		wantErr bool
+	}{
+		{
+			desc: "journalctl -o json",
+			content: `{"__CURSOR":"s=1","__REALTIME_TIMESTAMP":"1735689600000000","SYSLOG_IDENTIFIER":"kubelet","MESSAGE":"started"}
+{"__CURSOR":"s=2","__REALTIME_TIMESTAMP":"1735689601000000","SYSLOG_IDENTIFIER":"containerd","MESSAGE":"pulled"}
+`,
+		},
+		{
+			desc: "syslog in RFC3164 and RFC5424",
+			content: `Jan  1 00:00:00 node-1 kubelet[1234]: started
+<30>1 2025-01-01T00:00:01Z node-1 containerd 123 - - pulled image
+`,
+		},
+		{
+			desc:    "journald logs mixed with syslog lines",
+			content: "{\"__CURSOR\":\"s=1\",\"MESSAGE\":\"started\"}\n\nJan  1 00:00:00 node-1 kubelet[1234]: started\n",
+		},
+		{
+			desc:    "plain text",
+			content: "this is not a node log\n",
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			provider := upload.NewLocalUploadFileStoreProvider(t.TempDir())
+			token := &upload.DirectUploadToken{ID: "node-log"}
+			if err := provider.Write(token, strings.NewReader(tc.content)); err != nil {
+				t.Fatalf("failed to write the fixture file: %v", err)
+			}
+			err := nodeLogFileVerifier.Verify(provider, token)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Verify() returned error %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -26,7 +26,9 @@ import (
 	"github.com/kyasbal/khi/pkg/model/history/grouper"
 	"github.com/kyasbal/khi/pkg/model/history/resourcepath"
 	"github.com/kyasbal/khi/pkg/model/log"
+	k8slivecapture_contract "github.com/kyasbal/khi/pkg/task/inspection/k8slivecapture/contract"
 	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+	supportbundlek8s_contract "github.com/kyasbal/khi/pkg/task/inspection/supportbundlek8s/contract"
 )
 
 type OSSK8sEventFromK8sAudit struct {
@@ -88,5 +90,7 @@ var OSSK8sEventLogParserTask = legacyparser.NewParserTaskFromParser(
 	ossclusterk8s_contract.OSSK8sEventLogParserTaskID,
 	&OSSK8sEventFromK8sAudit{}, 2000, true, []string{
 		ossclusterk8s_contract.InspectionTypeID,
+		k8slivecapture_contract.InspectionTypeID,
+		supportbundlek8s_contract.InspectionTypeID,
 	},
 )
@@ -0,0 +1,196 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/kyasbal/khi/pkg/common/structured"
+	"github.com/kyasbal/khi/pkg/core/inspection/legacyparser"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/history"
+	"github.com/kyasbal/khi/pkg/model/history/grouper"
+	"github.com/kyasbal/khi/pkg/model/log"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+	supportbundlek8s_contract "github.com/kyasbal/khi/pkg/task/inspection/supportbundlek8s/contract"
+)
+
+// KubectlDumpReaderTask reads the objects in the uploaded kubectl outputs as logs sorted by their timestamps.
+var KubectlDumpReaderTask = inspectiontaskbase.NewProgressReportableInspectionTask(
+	ossclusterk8s_contract.KubectlDumpReaderTaskID,
+	[]taskid.UntypedTaskReference{
+		ossclusterk8s_contract.InputKubectlDumpFilesFormTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, tp *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, error) {
+		if taskMode == inspectioncore_contract.TaskModeDryRun {
+			return []*log.Log{}, nil
+		}
+		result := coretask.GetTaskResult(ctx, ossclusterk8s_contract.InputKubectlDumpFilesFormTaskID.Ref())
+		tp.MarkIndeterminate()
+
+		readers, err := result.GetReaders()
+		if err != nil {
+			return nil, err
+		}
+		var logs []*log.Log
+		for _, reader := range readers {
+			defer reader.Close()
+			data, err := io.ReadAll(reader)
+			if err != nil {
+				return nil, err
+			}
+			items, err := ossclusterk8s_contract.ReadKubectlDumpItems(data)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range items {
+				l, err := ossclusterk8s_contract.NewLogFromKubectlDumpItem(item)
+				if err != nil {
+					return nil, err
+				}
+				err = l.SetFieldSetReader(&ossclusterk8s_contract.OSSKubectlDumpCommonFieldSetReader{})
+				if err != nil {
+					return nil, err
+				}
+				if ossclusterk8s_contract.IsKubectlDumpEvent(l.NodeReader) {
+					l.LogType = enum.LogTypeEvent
+				}
+				logs = append(logs, l)
+			}
+		}
+
+		slices.SortStableFunc(logs, func(a, b *log.Log) int {
+			return log.MustGetFieldSet(a, &log.CommonFieldSet{}).Timestamp.Compare(log.MustGetFieldSet(b, &log.CommonFieldSet{}).Timestamp)
+		})
+		extendHeaderTimeRange(ctx, logs)
+
+		return logs, nil
+	},
+	inspectioncore_contract.InspectionTypeLabel(ossclusterk8s_contract.InspectionTypeID),
+)
+
+// OSSK8sKubectlDumpParser generates revisions and events from the objects in kubectl outputs.
+type OSSK8sKubectlDumpParser struct {
+}
+
+// Dependencies implements legacyparser.Parser.
+func (o *OSSK8sKubectlDumpParser) Dependencies() []taskid.UntypedTaskReference {
+	return []taskid.UntypedTaskReference{}
+}
+
+// Description implements legacyparser.Parser.
+func (o *OSSK8sKubectlDumpParser) Description() string {
+	return `Gather Events and resource manifests from the uploaded outputs of kubectl. Useful for a minimal inspection when no log is available.`
+}
+
+// GetParserName implements legacyparser.Parser.
+func (o *OSSK8sKubectlDumpParser) GetParserName() string {
+	return "OSS Kubernetes kubectl outputs"
+}
+
+// Grouper implements legacyparser.Parser.
+func (o *OSSK8sKubectlDumpParser) Grouper() grouper.LogGrouper {
+	return grouper.AllDependentLogGrouper
+}
+
+// LogTask implements legacyparser.Parser.
+func (o *OSSK8sKubectlDumpParser) LogTask() taskid.TaskReference[[]*log.Log] {
+	return ossclusterk8s_contract.KubectlDumpReaderTaskID.Ref()
+}
+
+// Parse implements legacyparser.Parser.
+func (o *OSSK8sKubectlDumpParser) Parse(ctx context.Context, l *log.Log, cs *history.ChangeSet, builder *history.Builder) error {
+	if ossclusterk8s_contract.IsKubectlDumpEvent(l.NodeReader) {
+		return o.parseEvent(l, cs)
+	}
+	return o.parseResource(l, cs)
+}
+
+// parseEvent records the Event on the timeline of the involved object.
+// core/v1 Events refer the object with `involvedObject` and events.k8s.io/v1 Events refer it with `regarding`.
+func (o *OSSK8sKubectlDumpParser) parseEvent(l *log.Log, cs *history.ChangeSet) error {
+	objectFieldPath := "involvedObject"
+	message := l.ReadStringOrDefault("message", "")
+	if !l.Has(objectFieldPath) {
+		objectFieldPath = "regarding"
+		message = l.ReadStringOrDefault("note", message)
+	}
+	apiVersion := l.ReadStringOrDefault(objectFieldPath+".apiVersion", "v1")
+	kind := l.ReadStringOrDefault(objectFieldPath+".kind", "unknown")
+	namespace := l.ReadStringOrDefault(objectFieldPath+".namespace", "")
+	name := l.ReadStringOrDefault(objectFieldPath+".name", "unknown")
+	cs.AddEvent(ossclusterk8s_contract.KubectlDumpResourcePath(apiVersion, kind, namespace, name))
+
+	reason := l.ReadStringOrDefault("reason", "???")
+	cs.SetLogSummary(fmt.Sprintf("【%s】%s", reason, message))
+	return nil
+}
+
+// parseResource records the dumped manifest as the revision at the creation time of the resource.
+func (o *OSSK8sKubectlDumpParser) parseResource(l *log.Log, cs *history.ChangeSet) error {
+	apiVersion := l.ReadStringOrDefault("apiVersion", "")
+	kind := l.ReadStringOrDefault("kind", "")
+	name := l.ReadStringOrDefault("metadata.name", "")
+	if apiVersion == "" || kind == "" || name == "" {
+		return fmt.Errorf("the object has no apiVersion, kind or metadata.name")
+	}
+	namespace := l.ReadStringOrDefault("metadata.namespace", "")
+	body, err := l.Serialize("", &structured.YAMLNodeSerializer{})
+	if err != nil {
+		return err
+	}
+	state := enum.RevisionStateExisting
+	if l.Has("metadata.deletionTimestamp") {
+		state = enum.RevisionStateDeleting
+	}
+	commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+	cs.AddRevision(ossclusterk8s_contract.KubectlDumpResourcePath(apiVersion, kind, namespace, name), &history.StagingResourceRevision{
+		Verb:       enum.RevisionVerbCreate,
+		State:      state,
+		Requestor:  ossclusterk8s_contract.KubectlDumpRequestor,
+		ChangeTime: commonFieldSet.Timestamp,
+		Body:       string(body),
+	})
+	if namespace == "" {
+		cs.SetLogSummary(fmt.Sprintf("%s %s", kind, name))
+	} else {
+		cs.SetLogSummary(fmt.Sprintf("%s %s/%s", kind, namespace, name))
+	}
+	return nil
+}
+
+// TargetLogType implements legacyparser.Parser.
+func (o *OSSK8sKubectlDumpParser) TargetLogType() enum.LogType {
+	return enum.LogTypeEvent
+}
+
+var _ legacyparser.Parser = (*OSSK8sKubectlDumpParser)(nil)
+
+// OSSK8sKubectlDumpParserTask is the feature task to generate revisions and events from the uploaded kubectl outputs.
+var OSSK8sKubectlDumpParserTask = legacyparser.NewParserTaskFromParser(
+	ossclusterk8s_contract.OSSKubectlDumpParserTaskID,
+	&OSSK8sKubectlDumpParser{}, 1004, false, []string{
+		ossclusterk8s_contract.InspectionTypeID,
+		supportbundlek8s_contract.InspectionTypeID,
+	},
+)
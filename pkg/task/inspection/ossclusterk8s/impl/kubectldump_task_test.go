@@ -0,0 +1,251 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/history"
+	"github.com/kyasbal/khi/pkg/model/log"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+	"github.com/kyasbal/khi/pkg/testutil/testchangeset"
+	"github.com/kyasbal/khi/pkg/testutil/testupload"
+)
+
+func TestKubectlDumpReaderTask(t *testing.T) {
+	type dumpLog struct {
+		DisplayID string
+		Timestamp time.Time
+		Severity  enum.Severity
+		LogType   enum.LogType
+	}
+	events := `{"apiVersion":"v1","kind":"List","items":[
+		{"apiVersion":"v1","kind":"Event","type":"Warning","metadata":{"name":"ev1","uid":"ev1-uid"},"lastTimestamp":"2025-01-01T00:00:02Z","firstTimestamp":"2025-01-01T00:00:00Z"},
+		{"apiVersion":"events.k8s.io/v1","kind":"Event","type":"Normal","metadata":{"name":"ev2","uid":"ev2-uid"},"eventTime":"2025-01-01T00:00:01.000000Z"}
+	]}`
+	pods := `apiVersion: v1
+kind: PodList
+items:
+- metadata:
+    name: nginx
+    namespace: default
+    uid: pod-uid
+    creationTimestamp: "2025-01-01T00:00:00Z"
+`
+	testCases := []struct {
+		desc     string
+		files    []string
+		taskMode inspectioncore_contract.InspectionTaskModeType
+		want     []dumpLog
+		wantErr  bool
+	}{
+		{
+			desc:     "objects of all files sorted by their timestamps",
+			files:    []string{events, pods},
+			taskMode: inspectioncore_contract.TaskModeRun,
+			want: []dumpLog{
+				{DisplayID: "pod-uid", Timestamp: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Severity: enum.SeverityUnknown, LogType: enum.LogTypeUnknown},
+				{DisplayID: "ev2-uid", Timestamp: time.Date(2025, 1, 1, 0, 0, 1, 0, time.UTC), Severity: enum.SeverityInfo, LogType: enum.LogTypeEvent},
+				{DisplayID: "ev1-uid", Timestamp: time.Date(2025, 1, 1, 0, 0, 2, 0, time.UTC), Severity: enum.SeverityWarning, LogType: enum.LogTypeEvent},
+			},
+		},
+		{
+			desc:     "object without timestamp",
+			files:    []string{"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm1\n"},
+			taskMode: inspectioncore_contract.TaskModeRun,
+			wantErr:  true,
+		},
+		{
+			desc:     "files aren't read in dry run",
+			files:    []string{events, pods},
+			taskMode: inspectioncore_contract.TaskModeDryRun,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+			logs, _, err := inspectiontest.RunInspectionTask(ctx, KubectlDumpReaderTask, tc.taskMode, map[string]any{},
+				tasktest.NewTaskDependencyValuePair(ossclusterk8s_contract.InputKubectlDumpFilesFormTaskID.Ref(), testupload.UploadFiles(t, tc.files...)),
+			)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("KubectlDumpReaderTask returned no error, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("KubectlDumpReaderTask returned an unexpected error: %v", err)
+			}
+
+			var got []dumpLog
+			for _, l := range logs {
+				commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+				got = append(got, dumpLog{
+					DisplayID: commonFieldSet.DisplayID,
+					Timestamp: commonFieldSet.Timestamp,
+					Severity:  commonFieldSet.Severity,
+					LogType:   l.LogType,
+				})
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("logs mismatch (-want +got):\n%s", diff)
+			}
+
+			if tc.taskMode == inspectioncore_contract.TaskModeRun {
+				header := typedmap.GetOrDefault(khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata), inspectionmetadata.HeaderMetadataKey, &inspectionmetadata.HeaderMetadata{})
+				wantStart, wantEnd := tc.want[0].Timestamp.Unix(), tc.want[len(tc.want)-1].Timestamp.Unix()
+				if header.StartTimeUnixSeconds != wantStart || header.EndTimeUnixSeconds != wantEnd {
+					t.Errorf("header time range = [%d, %d], want [%d, %d]", header.StartTimeUnixSeconds, header.EndTimeUnixSeconds, wantStart, wantEnd)
+				}
+			}
+		})
+	}
+}
+
+func TestOSSK8sKubectlDumpParser(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		item      map[string]any
+		asserters []testchangeset.ChangeSetAsserter
+		wantErr   bool
+	}{
+		{
+			desc: "core/v1 Event",
+			item: map[string]any{
+				"apiVersion":     "v1",
+				"kind":           "Event",
+				"metadata":       map[string]any{"name": "ev1"},
+				"involvedObject": map[string]any{"apiVersion": "v1", "kind": "Pod", "namespace": "default", "name": "nginx"},
+				"reason":         "BackOff",
+				"message":        "Back-off restarting failed container",
+				"lastTimestamp":  "2025-01-01T00:00:00Z",
+			},
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasEvent{ResourcePath: "core/v1#pod#default#nginx"},
+				&testchangeset.HasLogSummary{WantLogSummary: "【BackOff】Back-off restarting failed container"},
+			},
+		},
+		{
+			desc: "events.k8s.io/v1 Event",
+			item: map[string]any{
+				"apiVersion": "events.k8s.io/v1",
+				"kind":       "Event",
+				"metadata":   map[string]any{"name": "ev2"},
+				"regarding":  map[string]any{"apiVersion": "apps/v1", "kind": "Deployment", "namespace": "default", "name": "nginx"},
+				"reason":     "ScalingReplicaSet",
+				"note":       "Scaled up replica set nginx-abc to 1",
+				"eventTime":  "2025-01-01T00:00:00.000000Z",
+			},
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasEvent{ResourcePath: "apps/v1#deployment#default#nginx"},
+				&testchangeset.HasLogSummary{WantLogSummary: "【ScalingReplicaSet】Scaled up replica set nginx-abc to 1"},
+			},
+		},
+		{
+			desc: "namespaced resource",
+			item: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Pod",
+				"metadata":   map[string]any{"name": "nginx", "namespace": "default", "creationTimestamp": "2025-01-01T00:00:00Z"},
+			},
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasRevision{
+					ResourcePath: "core/v1#pod#default#nginx",
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbCreate,
+						State:      enum.RevisionStateExisting,
+						Requestor:  ossclusterk8s_contract.KubectlDumpRequestor,
+						ChangeTime: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+						Body: `apiVersion: v1
+kind: Pod
+metadata:
+  creationTimestamp: "2025-01-01T00:00:00Z"
+  name: nginx
+  namespace: default
+`,
+					},
+				},
+				&testchangeset.HasLogSummary{WantLogSummary: "Pod default/nginx"},
+			},
+		},
+		{
+			desc: "cluster scoped resource being deleted",
+			item: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Node",
+				"metadata":   map[string]any{"name": "node-1", "creationTimestamp": "2025-01-01T00:00:00Z", "deletionTimestamp": "2025-01-01T00:01:00Z"},
+			},
+			asserters: []testchangeset.ChangeSetAsserter{
+				&testchangeset.HasRevision{
+					ResourcePath: "core/v1#node#cluster-scope#node-1",
+					WantRevision: history.StagingResourceRevision{
+						Verb:       enum.RevisionVerbCreate,
+						State:      enum.RevisionStateDeleting,
+						Requestor:  ossclusterk8s_contract.KubectlDumpRequestor,
+						ChangeTime: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+						Body: `apiVersion: v1
+kind: Node
+metadata:
+  creationTimestamp: "2025-01-01T00:00:00Z"
+  deletionTimestamp: "2025-01-01T00:01:00Z"
+  name: node-1
+`,
+					},
+				},
+				&testchangeset.HasLogSummary{WantLogSummary: "Node node-1"},
+			},
+		},
+		{
+			desc: "resource without name",
+			item: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Pod",
+				"metadata":   map[string]any{"creationTimestamp": "2025-01-01T00:00:00Z"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l, err := ossclusterk8s_contract.NewLogFromKubectlDumpItem(tc.item)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = l.SetFieldSetReader(&ossclusterk8s_contract.OSSKubectlDumpCommonFieldSetReader{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cs := history.NewChangeSet(l)
+			err = (&OSSK8sKubectlDumpParser{}).Parse(context.Background(), l, cs, nil)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Parse() returned error %v, wantErr %v", err, tc.wantErr)
+			}
+			for _, asserter := range tc.asserters {
+				asserter.Assert(t, cs)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	"github.com/kyasbal/khi/pkg/model/log"
+	"github.com/kyasbal/khi/pkg/server/upload"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// headerTimeRangeLock guards the time range in the header metadata updated from the readers of uploaded log files running concurrently.
+var headerTimeRangeLock sync.Mutex
+
+// readUploadedLogLines reads all the uploaded files and returns their lines.
+func readUploadedLogLines(uploadResult upload.UploadResultList) ([]string, error) {
+	readers, err := uploadResult.GetReaders()
+	if err != nil {
+		return nil, err
+	}
+	var logLines []string
+	for _, reader := range readers {
+		defer reader.Close()
+		logData, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, err
+		}
+		logLines = append(logLines, strings.Split(string(logData), "\n")...)
+	}
+	return logLines, nil
+}
+
+// extendHeaderTimeRange extends the time range in the header metadata to include the given logs sorted by their timestamps.
+func extendHeaderTimeRange(ctx context.Context, sortedLogs []*log.Log) {
+	if len(sortedLogs) == 0 {
+		return
+	}
+	metadataSet := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
+	header := typedmap.GetOrDefault(metadataSet, inspectionmetadata.HeaderMetadataKey, &inspectionmetadata.HeaderMetadata{})
+	startTime := log.MustGetFieldSet(sortedLogs[0], &log.CommonFieldSet{}).Timestamp.Unix()
+	endTime := log.MustGetFieldSet(sortedLogs[len(sortedLogs)-1], &log.CommonFieldSet{}).Timestamp.Unix()
+
+	headerTimeRangeLock.Lock()
+	defer headerTimeRangeLock.Unlock()
+	if header.StartTimeUnixSeconds == 0 || startTime < header.StartTimeUnixSeconds {
+		header.StartTimeUnixSeconds = startTime
+	}
+	if endTime > header.EndTimeUnixSeconds {
+		header.EndTimeUnixSeconds = endTime
+	}
+}
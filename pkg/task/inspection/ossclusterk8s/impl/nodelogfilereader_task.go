@@ -0,0 +1,152 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/logutil"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	"github.com/kyasbal/khi/pkg/core/inspection/progressutil"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudlogk8snode_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8snode/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// NodeLogFileReaderTask reads the uploaded journald or syslog formatted logs and the kubelet and containerd logs in the k3s/RKE2 log archives in place of the node logs queried from Cloud Logging.
+// The node log parsers for GKE process them to generate the node scoped timelines.
+var NodeLogFileReaderTask = inspectiontaskbase.NewProgressReportableInspectionTask(
+	ossclusterk8s_contract.OSSNodeLogFileReaderTaskID,
+	[]taskid.UntypedTaskReference{
+		ossclusterk8s_contract.InputNodeLogFilesFormTaskID.Ref(),
+		ossclusterk8s_contract.RancherLogArchiveReaderTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, tp *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, error) {
+		if taskMode == inspectioncore_contract.TaskModeDryRun {
+			return []*log.Log{}, nil
+		}
+		result := coretask.GetTaskResult(ctx, ossclusterk8s_contract.InputNodeLogFilesFormTaskID.Ref())
+
+		logLines, err := readUploadedLogLines(result)
+		if err != nil {
+			return nil, err
+		}
+		var logs []*log.Log
+		readTime := time.Now()
+
+		err = progressutil.ReportProgressFromArraySync(tp, logLines, func(i int, line string) error {
+			if strings.TrimSpace(line) == "" {
+				return nil
+			}
+
+			l, err := readNodeLogLine(line, readTime)
+			if err != nil {
+				return fmt.Errorf("failed to read a log: %w", err)
+			}
+
+			err = l.SetFieldSetReader(&ossclusterk8s_contract.OSSJournaldCommonFieldSetReader{})
+			if err != nil {
+				return err
+			}
+			l.LogType = enum.LogTypeNode
+
+			logs = append(logs, l)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		rancherLogs := coretask.GetTaskResult(ctx, ossclusterk8s_contract.RancherLogArchiveReaderTaskID.Ref())
+		for _, file := range append(rancherLogs.FilesOfKind(ossclusterk8s_contract.RancherKubeletLog), rancherLogs.FilesOfKind(ossclusterk8s_contract.RancherContainerdLog)...) {
+			for i := range file.Lines {
+				l, ok, err := ossclusterk8s_contract.NewLogFromRancherNodeLogLine(file, i)
+				if err != nil {
+					return nil, err
+				}
+				if !ok {
+					continue
+				}
+				err = l.SetFieldSetReader(&ossclusterk8s_contract.OSSJournaldCommonFieldSetReader{})
+				if err != nil {
+					return nil, err
+				}
+				l.LogType = enum.LogTypeNode
+				logs = append(logs, l)
+			}
+		}
+
+		slices.SortFunc(logs, func(a, b *log.Log) int {
+			return log.MustGetFieldSet(a, &log.CommonFieldSet{}).Timestamp.Compare(log.MustGetFieldSet(b, &log.CommonFieldSet{}).Timestamp)
+		})
+		extendHeaderTimeRange(ctx, logs)
+
+		return logs, nil
+	},
+	coretask.WithSelectionPriority(1000),
+	inspectioncore_contract.InspectionTypeLabel(ossclusterk8s_contract.InspectionTypeID),
+)
+
+// readNodeLogLine reads a line of the uploaded node logs either exported from journald in JSON or written in the syslog format.
+// The reference time is used to infer the year omitted in RFC3164 timestamps.
+func readNodeLogLine(line string, reference time.Time) (*log.Log, error) {
+	if strings.HasPrefix(strings.TrimSpace(line), "{") {
+		return log.NewLogFromYAMLString(line)
+	}
+	return ossclusterk8s_contract.NewLogFromSyslogLine(line, reference)
+}
+
+// NodeLogCommonFieldSetReaderTask reads the fieldset used by the node log parsers from the journald logs instead of the logs from Cloud Logging.
+var NodeLogCommonFieldSetReaderTask = inspectiontaskbase.NewFieldSetReadTask(
+	ossclusterk8s_contract.OSSNodeLogCommonFieldSetReaderTaskID,
+	googlecloudlogk8snode_contract.ListLogEntriesTaskID.Ref(),
+	[]log.FieldSetReader{
+		&ossclusterk8s_contract.OSSJournaldNodeLogFieldSetReader{
+			StructuredLogParser: logutil.NewMultiTextLogParser(
+				logutil.NewJsonlTextParser(),
+				logutil.NewKLogTextParser(true),
+				logutil.NewLogfmtTextParser(),
+				&logutil.FallbackRawTextLogParser{},
+			),
+		},
+	},
+	coretask.WithSelectionPriority(1000),
+	inspectioncore_contract.InspectionTypeLabel(ossclusterk8s_contract.InspectionTypeID),
+)
+
+// NodeLogParserTailTask is the feature task to generate node scoped timelines from the uploaded journald logs.
+var NodeLogParserTailTask = inspectiontaskbase.NewInspectionTask(
+	ossclusterk8s_contract.OSSNodeLogParserTailTaskID,
+	[]taskid.UntypedTaskReference{
+		googlecloudlogk8snode_contract.ContainerdLogLogToTimelineMapperTaskID.Ref(),
+		googlecloudlogk8snode_contract.KubeletLogLogToTimelineMapperTaskID.Ref(),
+		googlecloudlogk8snode_contract.OtherLogLogToTimelineMapperTaskID.Ref(),
+
+		googlecloudlogk8snode_contract.ContainerIDDiscoveryTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (struct{}, error) {
+		return struct{}{}, nil
+	},
+	inspectioncore_contract.FeatureTaskLabel("Kubernetes Node Logs", `Gather kubelet and container runtime logs from the uploaded journald logs exported from nodes.`, enum.LogTypeNode, 1003, false, ossclusterk8s_contract.InspectionTypeID), coretask.NewSubsequentTaskRefsTaskLabel(inspectioncore_contract.SerializerTaskID.Ref()),
+)
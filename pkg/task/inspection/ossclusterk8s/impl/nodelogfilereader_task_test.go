@@ -0,0 +1,191 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+	"github.com/kyasbal/khi/pkg/testutil/testupload"
+)
+
+// nodeLog is the fields of node logs compared in tests.
+type nodeLog struct {
+	Timestamp  time.Time
+	Severity   enum.Severity
+	Hostname   string
+	Identifier string
+	Message    string
+}
+
+func readNodeLogForTest(t *testing.T, l *log.Log) nodeLog {
+	t.Helper()
+	if err := l.SetFieldSetReader(&ossclusterk8s_contract.OSSJournaldCommonFieldSetReader{}); err != nil {
+		t.Fatalf("failed to read the common fieldset: %v", err)
+	}
+	commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+	return nodeLog{
+		Timestamp:  commonFieldSet.Timestamp,
+		Severity:   commonFieldSet.Severity,
+		Hostname:   l.ReadStringOrDefault("_HOSTNAME", ""),
+		Identifier: l.ReadStringOrDefault("SYSLOG_IDENTIFIER", ""),
+		Message:    l.ReadStringOrDefault("MESSAGE", ""),
+	}
+}
+
+func TestReadNodeLogLine(t *testing.T) {
+	reference := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		desc    string
+		line    string
+		want    nodeLog
+		wantErr bool
+	}{
+		{
+			desc: "journald JSON",
+			line: `{"__CURSOR":"s=1","__REALTIME_TIMESTAMP":"1735689600000000","PRIORITY":"3","_HOSTNAME":"node-1","SYSLOG_IDENTIFIER":"kubelet","MESSAGE":"failed"}`,
+			want: nodeLog{Timestamp: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Severity: enum.SeverityError, Hostname: "node-1", Identifier: "kubelet", Message: "failed"},
+		},
+		{
+			desc: "RFC3164 syslog with the year inferred from the reference time",
+			line: `Jan  1 00:00:00 node-1 kubelet[1234]: started`,
+			want: nodeLog{Timestamp: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Severity: enum.SeverityUnknown, Hostname: "node-1", Identifier: "kubelet", Message: "started"},
+		},
+		{
+			desc: "RFC5424 syslog",
+			line: `<28>1 2025-01-01T00:00:01Z node-1 containerd 123 - - slow pull`,
+			want: nodeLog{Timestamp: time.Date(2025, 1, 1, 0, 0, 1, 0, time.UTC), Severity: enum.SeverityWarning, Hostname: "node-1", Identifier: "containerd", Message: "slow pull"},
+		},
+		{
+			desc:    "plain text",
+			line:    "this is not a node log",
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l, err := readNodeLogLine(tc.line, reference)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("readNodeLogLine(%q) returned no error, want an error", tc.line)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readNodeLogLine(%q) returned an unexpected error: %v", tc.line, err)
+			}
+			if diff := cmp.Diff(tc.want, readNodeLogForTest(t, l), cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })); diff != "" {
+				t.Errorf("readNodeLogLine(%q) mismatch (-want +got):\n%s", tc.line, diff)
+			}
+		})
+	}
+}
+
+func TestNodeLogFileReaderTask(t *testing.T) {
+	journaldLogs := `{"__CURSOR":"s=1","__REALTIME_TIMESTAMP":"1735689602000000","PRIORITY":"6","_HOSTNAME":"node-1","SYSLOG_IDENTIFIER":"kubelet","MESSAGE":"started"}
+
+{"__CURSOR":"s=2","__REALTIME_TIMESTAMP":"1735689600000000","PRIORITY":"3","_HOSTNAME":"node-1","SYSLOG_IDENTIFIER":"containerd","MESSAGE":"failed"}
+`
+	syslogLogs := "<30>1 2025-01-01T00:00:01Z node-2 containerd 123 - - pulled image\n"
+	rancherLogs := &ossclusterk8s_contract.RancherLogs{
+		Files: []*ossclusterk8s_contract.RancherLogFile{
+			{
+				Path:     "node-3/rke2/agent/logs/kubelet.log",
+				NodeName: "node-3",
+				Kind:     ossclusterk8s_contract.RancherKubeletLog,
+				ModTime:  time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+				Lines: []string{
+					"W0101 00:00:03.000000    1234 kubelet.go:100] node not ready",
+					"  continued line without header",
+				},
+			},
+			{
+				Path:     "node-3/rke2/agent/containerd/containerd.log",
+				NodeName: "node-3",
+				Kind:     ossclusterk8s_contract.RancherContainerdLog,
+				Lines: []string{
+					`time="2025-01-01T00:00:04Z" level=error msg="failed to pull"`,
+				},
+			},
+			{
+				Path:     "node-3/rke2/server/logs/audit.log",
+				NodeName: "node-3",
+				Kind:     ossclusterk8s_contract.RancherAuditLog,
+				Lines:    []string{`{"kind":"Event"}`},
+			},
+		},
+	}
+	testCases := []struct {
+		desc     string
+		taskMode inspectioncore_contract.InspectionTaskModeType
+		want     []nodeLog
+	}{
+		{
+			desc:     "uploaded node logs and node logs in the k3s/RKE2 archives sorted by their timestamps",
+			taskMode: inspectioncore_contract.TaskModeRun,
+			want: []nodeLog{
+				{Timestamp: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Severity: enum.SeverityError, Hostname: "node-1", Identifier: "containerd", Message: "failed"},
+				{Timestamp: time.Date(2025, 1, 1, 0, 0, 1, 0, time.UTC), Severity: enum.SeverityInfo, Hostname: "node-2", Identifier: "containerd", Message: "pulled image"},
+				{Timestamp: time.Date(2025, 1, 1, 0, 0, 2, 0, time.UTC), Severity: enum.SeverityInfo, Hostname: "node-1", Identifier: "kubelet", Message: "started"},
+				{Timestamp: time.Date(2025, 1, 1, 0, 0, 3, 0, time.UTC), Severity: enum.SeverityWarning, Hostname: "node-3", Identifier: "kubelet", Message: "W0101 00:00:03.000000    1234 kubelet.go:100] node not ready"},
+				{Timestamp: time.Date(2025, 1, 1, 0, 0, 4, 0, time.UTC), Severity: enum.SeverityError, Hostname: "node-3", Identifier: "containerd", Message: `time="2025-01-01T00:00:04Z" level=error msg="failed to pull"`},
+			},
+		},
+		{
+			desc:     "files aren't read in dry run",
+			taskMode: inspectioncore_contract.TaskModeDryRun,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+			logs, _, err := inspectiontest.RunInspectionTask(ctx, NodeLogFileReaderTask, tc.taskMode, map[string]any{},
+				tasktest.NewTaskDependencyValuePair(ossclusterk8s_contract.InputNodeLogFilesFormTaskID.Ref(), testupload.UploadFiles(t, journaldLogs, syslogLogs)),
+				tasktest.NewTaskDependencyValuePair(ossclusterk8s_contract.RancherLogArchiveReaderTaskID.Ref(), rancherLogs),
+			)
+			if err != nil {
+				t.Fatalf("NodeLogFileReaderTask returned an unexpected error: %v", err)
+			}
+
+			var got []nodeLog
+			for _, l := range logs {
+				if l.LogType != enum.LogTypeNode {
+					t.Errorf("log type = %v, want %v", l.LogType, enum.LogTypeNode)
+				}
+				got = append(got, readNodeLogForTest(t, l))
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })); diff != "" {
+				t.Errorf("logs mismatch (-want +got):\n%s", diff)
+			}
+
+			if tc.taskMode == inspectioncore_contract.TaskModeRun {
+				header := typedmap.GetOrDefault(khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata), inspectionmetadata.HeaderMetadataKey, &inspectionmetadata.HeaderMetadata{})
+				if header.StartTimeUnixSeconds != 1735689600 || header.EndTimeUnixSeconds != 1735689604 {
+					t.Errorf("header time range = [%d, %d], want [1735689600, 1735689604]", header.StartTimeUnixSeconds, header.EndTimeUnixSeconds)
+				}
+			}
+		})
+	}
+}
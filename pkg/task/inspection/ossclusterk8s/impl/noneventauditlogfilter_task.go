@@ -31,12 +31,16 @@ var NonEventAuditLogFilterTask = inspectiontaskbase.NewProgressReportableInspect
 	ossclusterk8s_contract.NonEventAuditLogFilterTaskID,
 	[]taskid.UntypedTaskReference{
 		ossclusterk8s_contract.AuditLogFileReaderTaskID.Ref(),
+		ossclusterk8s_contract.InputKindFilterTaskID.Ref(),
+		ossclusterk8s_contract.InputNamespaceFilterTaskID.Ref(),
 	}, func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, progress *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, error) {
 		if taskMode == inspectioncore_contract.TaskModeDryRun {
 			return []*log.Log{}, nil
 		}
 
 		logs := coretask.GetTaskResult(ctx, ossclusterk8s_contract.AuditLogFileReaderTaskID.Ref())
+		kindFilter := coretask.GetTaskResult(ctx, ossclusterk8s_contract.InputKindFilterTaskID.Ref())
+		namespaceFilter := coretask.GetTaskResult(ctx, ossclusterk8s_contract.InputNamespaceFilterTaskID.Ref())
 
 		var auditLogs []*log.Log
 
@@ -46,6 +50,10 @@ var NonEventAuditLogFilterTask = inspectiontaskbase.NewProgressReportableInspect
 				if verb == "" || verb == "get" || verb == "watch" || verb == "list" {
 					continue
 				}
+				pluralKind := l.ReadStringOrDefault("objectRef.resource", "")
+				if !ossclusterk8s_contract.MatchesKindFilter(kindFilter, pluralKind) || !ossclusterk8s_contract.MatchesNamespaceFilter(namespaceFilter, pluralKind, l.ReadStringOrDefault("objectRef.namespace", ""), l.ReadStringOrDefault("objectRef.name", "")) {
+					continue
+				}
 				l.LogType = enum.LogTypeAudit
 				auditLogs = append(auditLogs, l)
 			}
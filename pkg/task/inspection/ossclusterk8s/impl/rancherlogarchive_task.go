@@ -0,0 +1,67 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/server/upload"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// InputRancherLogArchivesTask is a form task to upload archives of the data directories of k3s or RKE2.
+var InputRancherLogArchivesTask = formtask.NewMultiFileFormTaskBuilder(ossclusterk8s_contract.InputRancherLogArchivesFormTaskID, 650, "k3s/RKE2 Log Archives", &upload.ArchiveUploadFileVerifier{}).
+	WithDescription("Upload the log files under the data directory of k3s or RKE2 archived in `.zip`, `.tar` or `.tar.gz` for each node (e.g. `tar czf node-1.tar.gz --transform 's,^,node-1/,' -C /var/lib/rancher rke2/agent/logs rke2/agent/containerd rke2/server/logs`). `agent/containerd/containerd.log`, `agent/logs/kubelet.log` and the audit logs under `server/logs/` are read. The directory containing `k3s` or `rke2` is used as the node name. Logs of kubelet and etcd embedded in k3s are read from the journald logs of the `k3s` unit uploaded as the node log files.").
+	WithMarkdown().
+	Build()
+
+// RancherLogArchiveReaderTask walks the uploaded archives and reads the log files of k3s or RKE2.
+var RancherLogArchiveReaderTask = inspectiontaskbase.NewProgressReportableInspectionTask(
+	ossclusterk8s_contract.RancherLogArchiveReaderTaskID,
+	[]taskid.UntypedTaskReference{
+		ossclusterk8s_contract.InputRancherLogArchivesFormTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, tp *inspectionmetadata.TaskProgressMetadata) (*ossclusterk8s_contract.RancherLogs, error) {
+		if taskMode == inspectioncore_contract.TaskModeDryRun {
+			return &ossclusterk8s_contract.RancherLogs{}, nil
+		}
+		result := coretask.GetTaskResult(ctx, ossclusterk8s_contract.InputRancherLogArchivesFormTaskID.Ref())
+		tp.MarkIndeterminate()
+		readers, err := result.GetReaders()
+		if err != nil {
+			return nil, err
+		}
+		logs := &ossclusterk8s_contract.RancherLogs{}
+		for _, reader := range readers {
+			defer reader.Close()
+			err := ossclusterk8s_contract.ReadRancherLogArchive(reader, logs)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if len(readers) > 0 {
+			slog.InfoContext(ctx, fmt.Sprintf("read the k3s/RKE2 log archives: %d log files, %d files skipped", len(logs.Files), logs.SkippedFiles))
+		}
+		return logs, nil
+	},
+)
@@ -0,0 +1,101 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossclusterk8s_impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+	"github.com/kyasbal/khi/pkg/testutil/testupload"
+)
+
+var fixtureRancherArchiveModTime = time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+func TestRancherLogArchiveReaderTask(t *testing.T) {
+	rke2Archive := testupload.TarGz(t, []testupload.File{
+		{Name: "node-1/rke2/agent/logs/kubelet.log", Content: "I0101 00:00:00.000000    1234 kubelet.go:100] started\nI0101 00:00:01.000000    1234 kubelet.go:100] synced\n", ModTime: fixtureRancherArchiveModTime},
+		{Name: "node-1/rke2/agent/containerd/containerd.log", Content: "time=\"2025-01-01T00:00:00Z\" level=info msg=started\n", ModTime: fixtureRancherArchiveModTime},
+		{Name: "node-1/rke2/agent/etc/containerd/config.toml", Content: "version = 2\n", ModTime: fixtureRancherArchiveModTime},
+	})
+	k3sArchive := testupload.Zip(t, []testupload.File{
+		{Name: "node-2/k3s/server/logs/audit.log", Content: "{\"kind\":\"Event\"}\n", ModTime: fixtureRancherArchiveModTime},
+		{Name: "node-2/k3s/agent/containerd/containerd.log", Content: "time=\"2025-01-01T00:00:02Z\" level=warning msg=slow\n", ModTime: fixtureRancherArchiveModTime},
+	})
+	testCases := []struct {
+		desc     string
+		files    []string
+		taskMode inspectioncore_contract.InspectionTaskModeType
+		want     *ossclusterk8s_contract.RancherLogs
+		wantErr  bool
+	}{
+		{
+			desc:     "log files in all archives",
+			files:    []string{rke2Archive, k3sArchive},
+			taskMode: inspectioncore_contract.TaskModeRun,
+			want: &ossclusterk8s_contract.RancherLogs{
+				Files: []*ossclusterk8s_contract.RancherLogFile{
+					{Path: "node-1/rke2/agent/logs/kubelet.log", NodeName: "node-1", Kind: ossclusterk8s_contract.RancherKubeletLog, ModTime: fixtureRancherArchiveModTime, Lines: []string{"I0101 00:00:00.000000    1234 kubelet.go:100] started", "I0101 00:00:01.000000    1234 kubelet.go:100] synced"}},
+					{Path: "node-1/rke2/agent/containerd/containerd.log", NodeName: "node-1", Kind: ossclusterk8s_contract.RancherContainerdLog, ModTime: fixtureRancherArchiveModTime, Lines: []string{"time=\"2025-01-01T00:00:00Z\" level=info msg=started"}},
+					{Path: "node-2/k3s/server/logs/audit.log", NodeName: "node-2", Kind: ossclusterk8s_contract.RancherAuditLog, ModTime: fixtureRancherArchiveModTime, Lines: []string{"{\"kind\":\"Event\"}"}},
+					{Path: "node-2/k3s/agent/containerd/containerd.log", NodeName: "node-2", Kind: ossclusterk8s_contract.RancherContainerdLog, ModTime: fixtureRancherArchiveModTime, Lines: []string{"time=\"2025-01-01T00:00:02Z\" level=warning msg=slow"}},
+				},
+				SkippedFiles: 1,
+			},
+		},
+		{
+			desc:     "no archive uploaded",
+			files:    []string{},
+			taskMode: inspectioncore_contract.TaskModeRun,
+			want:     &ossclusterk8s_contract.RancherLogs{},
+		},
+		{
+			desc:     "file not an archive",
+			files:    []string{"this is not an archive"},
+			taskMode: inspectioncore_contract.TaskModeRun,
+			wantErr:  true,
+		},
+		{
+			desc:     "archives aren't read in dry run",
+			files:    []string{rke2Archive},
+			taskMode: inspectioncore_contract.TaskModeDryRun,
+			want:     &ossclusterk8s_contract.RancherLogs{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+			got, _, err := inspectiontest.RunInspectionTask(ctx, RancherLogArchiveReaderTask, tc.taskMode, map[string]any{},
+				tasktest.NewTaskDependencyValuePair(ossclusterk8s_contract.InputRancherLogArchivesFormTaskID.Ref(), testupload.UploadFiles(t, tc.files...)),
+			)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("RancherLogArchiveReaderTask returned no error, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RancherLogArchiveReaderTask returned an unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })); diff != "" {
+				t.Errorf("RancherLogArchiveReaderTask mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -29,11 +29,23 @@ func Register(registry coreinspection.InspectionTaskRegistry) error {
 
 	return coretask.RegisterTasks(registry,
 		InputAuditLogFilesTask,
+		InputKindFilterTask,
+		InputNamespaceFilterTask,
 		AuditLogFileReaderTask,
 		EventAuditLogFilterTask,
 		NonEventAuditLogFilterTask,
 		OSSK8sEventLogParserTask,
 		OSSK8sAuditLogFieldExtractorTask,
 		OSSK8sAuditLogParserTailTask,
+		OSSK8sAuditPermissionDeniedParserTailTask,
+		InputNodeLogFilesTask,
+		InputRancherLogArchivesTask,
+		RancherLogArchiveReaderTask,
+		NodeLogFileReaderTask,
+		NodeLogCommonFieldSetReaderTask,
+		NodeLogParserTailTask,
+		InputKubectlDumpFilesTask,
+		KubectlDumpReaderTask,
+		OSSK8sKubectlDumpParserTask,
 	)
 }
@@ -0,0 +1,219 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parserplugin_contract
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/common/structured"
+)
+
+// templateVariablePattern matches a variable like `${name}` in templates of parser definitions.
+var templateVariablePattern = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+const (
+	// ParserFormatRegex is the format of text logs parsed with the named capture groups of a regular expression.
+	ParserFormatRegex = "regex"
+	// ParserFormatJSON is the format of logs written in JSON lines.
+	ParserFormatJSON = "json"
+)
+
+// ParserDefinition describes how to read a log line in the uploaded files without writing a plugin process.
+// Fields other than Format, Pattern and TimestampLayout are templates expanded with `${name}` variables.
+// A variable refers to a named capture group of the pattern in the regex format, or a dot separated field path like `${metadata.name}` in the JSON format.
+type ParserDefinition struct {
+	// Format is either `regex` or `json`.
+	Format string `yaml:"format"`
+	// Pattern is the regular expression with named capture groups like `(?P<time>\S+)`. This is used only in the regex format.
+	Pattern string `yaml:"pattern"`
+	// SkipUnmatchedLines ignores lines not matching the pattern or not in JSON instead of rejecting the file.
+	SkipUnmatchedLines bool `yaml:"skipUnmatchedLines"`
+	// Timestamp is the template of the log timestamp.
+	Timestamp string `yaml:"timestamp"`
+	// TimestampLayout is the Go time layout to parse the timestamp, or one of `unix`, `unixmilli` and `unixmicro`. RFC3339 is used when it is empty.
+	TimestampLayout string `yaml:"timestampLayout"`
+	Severity        string `yaml:"severity"`
+	Summary         string `yaml:"summary"`
+	// Resource is the resource the log is shown on. The log is not associated with any resource when its kind or name is empty.
+	Resource PluginResourceTemplate `yaml:"resource"`
+	// Verb is the template of the operation changed the resource. The log changes the state of the resource when it is one of `create`, `update`, `patch` and `delete`, and it is shown as an event otherwise.
+	Verb string `yaml:"verb"`
+	// Body is the template of the manifest of the resource after the change.
+	Body      string `yaml:"body"`
+	Requestor string `yaml:"requestor"`
+
+	pattern *regexp.Regexp
+}
+
+// PluginResourceTemplate is the templates of the fields identifying a resource.
+type PluginResourceTemplate struct {
+	APIVersion  string `yaml:"apiVersion"`
+	Kind        string `yaml:"kind"`
+	Namespace   string `yaml:"namespace"`
+	Name        string `yaml:"name"`
+	Subresource string `yaml:"subresource"`
+}
+
+// Compile validates the definition and prepares the pattern to parse lines.
+func (d *ParserDefinition) Compile() error {
+	switch d.Format {
+	case ParserFormatRegex:
+		pattern, err := regexp.Compile(d.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+		d.pattern = pattern
+	case ParserFormatJSON:
+	default:
+		return fmt.Errorf("unsupported parser format %q. It must be either `regex` or `json`", d.Format)
+	}
+	if d.Timestamp == "" {
+		return fmt.Errorf("timestamp is required")
+	}
+	return nil
+}
+
+// MatchLine returns true when the line is in the format of the definition.
+func (d *ParserDefinition) MatchLine(line []byte) bool {
+	if d.Format == ParserFormatJSON {
+		return json.Valid(line)
+	}
+	return d.pattern.Match(line)
+}
+
+// ParseLine reads a log line with the definition. It returns nil without error when the line doesn't match the pattern and SkipUnmatchedLines is set.
+func (d *ParserDefinition) ParseLine(line string) (*PluginLogRecord, error) {
+	var lookup func(name string) string
+	var body map[string]any
+	switch d.Format {
+	case ParserFormatJSON:
+		node, err := structured.FromYAML(line)
+		if err == nil {
+			err = json.Unmarshal([]byte(line), &body)
+		}
+		if err != nil {
+			if d.SkipUnmatchedLines {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("the line is not in JSON: %w", err)
+		}
+		lookup = jsonFieldLookup(structured.NewNodeReader(node))
+	default:
+		match := d.pattern.FindStringSubmatch(line)
+		if match == nil {
+			if d.SkipUnmatchedLines {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("the line doesn't match the pattern")
+		}
+		captures := map[string]string{}
+		body = map[string]any{}
+		for i, name := range d.pattern.SubexpNames() {
+			if name != "" {
+				captures[name] = match[i]
+				body[name] = match[i]
+			}
+		}
+		lookup = func(name string) string { return captures[name] }
+	}
+
+	timestamp, err := parseTimestamp(expandTemplate(d.Timestamp, lookup), d.TimestampLayout)
+	if err != nil {
+		return nil, err
+	}
+	record := &PluginLogRecord{
+		Timestamp: timestamp,
+		Severity:  expandTemplate(d.Severity, lookup),
+		Summary:   expandTemplate(d.Summary, lookup),
+		Body:      body,
+	}
+	resource := &PluginResource{
+		APIVersion:  expandTemplate(d.Resource.APIVersion, lookup),
+		Kind:        expandTemplate(d.Resource.Kind, lookup),
+		Namespace:   expandTemplate(d.Resource.Namespace, lookup),
+		Name:        expandTemplate(d.Resource.Name, lookup),
+		Subresource: expandTemplate(d.Resource.Subresource, lookup),
+	}
+	if resource.Kind == "" || resource.Name == "" {
+		return record, nil
+	}
+	switch verb := strings.ToLower(expandTemplate(d.Verb, lookup)); verb {
+	case "create", "update", "patch", "delete":
+		record.Revisions = []*PluginRevision{{
+			Resource:  *resource,
+			Deleted:   verb == "delete",
+			Manifest:  expandTemplate(d.Body, lookup),
+			Requestor: expandTemplate(d.Requestor, lookup),
+		}}
+	default:
+		record.Events = []*PluginResource{resource}
+	}
+	return record, nil
+}
+
+// jsonFieldLookup returns the lookup function reading the field in the given path. Non scalar fields are serialized in YAML.
+func jsonFieldLookup(reader *structured.NodeReader) func(name string) string {
+	return func(name string) string {
+		if !reader.Has(name) {
+			return ""
+		}
+		if value, err := reader.ReadString(name); err == nil {
+			return value
+		}
+		serialized, err := reader.Serialize(name, &structured.YAMLNodeSerializer{})
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSuffix(string(serialized), "\n")
+	}
+}
+
+// expandTemplate replaces `${name}` variables in the template with the values returned from the lookup function.
+func expandTemplate(template string, lookup func(name string) string) string {
+	return templateVariablePattern.ReplaceAllStringFunc(template, func(variable string) string {
+		return lookup(templateVariablePattern.FindStringSubmatch(variable)[1])
+	})
+}
+
+// parseTimestamp parses the timestamp with the layout given in the parser definition.
+func parseTimestamp(value string, layout string) (time.Time, error) {
+	switch layout {
+	case "unix", "unixmilli", "unixmicro":
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to parse the timestamp %q as %s: %w", value, layout, err)
+		}
+		switch layout {
+		case "unix":
+			return time.UnixMicro(int64(number * 1e6)), nil
+		case "unixmilli":
+			return time.UnixMicro(int64(number * 1e3)), nil
+		default:
+			return time.UnixMicro(int64(number)), nil
+		}
+	case "":
+		layout = time.RFC3339Nano
+	}
+	timestamp, err := time.Parse(layout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse the timestamp %q: %w", value, err)
+	}
+	return timestamp, nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parserplugin_contract
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParserDefinitionParseLine(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		definition *ParserDefinition
+		line       string
+		want       *PluginLogRecord
+		wantErr    bool
+	}{
+		{
+			desc: "regex event",
+			definition: &ParserDefinition{
+				Format:    ParserFormatRegex,
+				Pattern:   `^(?P<time>\S+) (?P<level>\w+) (?P<namespace>[^/]+)/(?P<pod>\S+) (?P<message>.*)$`,
+				Timestamp: "${time}",
+				Severity:  "${level}",
+				Summary:   "${message}",
+				Resource:  PluginResourceTemplate{APIVersion: "v1", Kind: "pod", Namespace: "${namespace}", Name: "${pod}"},
+			},
+			line: "2025-01-01T00:00:00Z ERROR default/nginx probe failed",
+			want: &PluginLogRecord{
+				Timestamp: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				Severity:  "ERROR",
+				Summary:   "probe failed",
+				Body:      map[string]any{"time": "2025-01-01T00:00:00Z", "level": "ERROR", "namespace": "default", "pod": "nginx", "message": "probe failed"},
+				Events:    []*PluginResource{{APIVersion: "v1", Kind: "pod", Namespace: "default", Name: "nginx"}},
+			},
+		},
+		{
+			desc: "json revision",
+			definition: &ParserDefinition{
+				Format:          ParserFormatJSON,
+				Timestamp:       "${ts}",
+				TimestampLayout: "unixmilli",
+				Summary:         "${verb} ${object.metadata.name}",
+				Resource:        PluginResourceTemplate{APIVersion: "apps/v1", Kind: "deployment", Namespace: "${object.metadata.namespace}", Name: "${object.metadata.name}"},
+				Verb:            "${verb}",
+				Body:            "${object}",
+				Requestor:       "${user}",
+			},
+			line: `{"ts":1735689600000,"verb":"UPDATE","user":"admin","object":{"metadata":{"name":"nginx","namespace":"default"}}}`,
+			want: &PluginLogRecord{
+				Timestamp: time.UnixMilli(1735689600000),
+				Summary:   "UPDATE nginx",
+				Body: map[string]any{
+					"ts":     float64(1735689600000),
+					"verb":   "UPDATE",
+					"user":   "admin",
+					"object": map[string]any{"metadata": map[string]any{"name": "nginx", "namespace": "default"}},
+				},
+				Revisions: []*PluginRevision{{
+					Resource:  PluginResource{APIVersion: "apps/v1", Kind: "deployment", Namespace: "default", Name: "nginx"},
+					Manifest:  "metadata:\n  name: nginx\n  namespace: default",
+					Requestor: "admin",
+				}},
+			},
+		},
+		{
+			desc: "delete without resource name",
+			definition: &ParserDefinition{
+				Format:    ParserFormatJSON,
+				Timestamp: "${time}",
+				Resource:  PluginResourceTemplate{Kind: "pod", Name: "${name}"},
+				Verb:      "delete",
+			},
+			line: `{"time":"2025-01-01T00:00:00Z"}`,
+			want: &PluginLogRecord{
+				Timestamp: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				Body:      map[string]any{"time": "2025-01-01T00:00:00Z"},
+			},
+		},
+		{
+			desc:       "unmatched line",
+			definition: &ParserDefinition{Format: ParserFormatRegex, Pattern: `^(?P<time>\S+) `, Timestamp: "${time}"},
+			line:       "foo",
+			wantErr:    true,
+		},
+		{
+			desc:       "skipped unmatched line",
+			definition: &ParserDefinition{Format: ParserFormatJSON, Timestamp: "${time}", SkipUnmatchedLines: true},
+			line:       "foo",
+		},
+		{
+			desc:       "invalid timestamp",
+			definition: &ParserDefinition{Format: ParserFormatJSON, Timestamp: "${time}"},
+			line:       `{"time":"yesterday"}`,
+			wantErr:    true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if err := tc.definition.Compile(); err != nil {
+				t.Fatalf("Compile() returned an unexpected error: %v", err)
+			}
+			got, err := tc.definition.ParseLine(tc.line)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("ParseLine() returned no error, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseLine() returned an unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ParseLine() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParserDefinitionCompile(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		definition *ParserDefinition
+		wantErr    bool
+	}{
+		{desc: "valid regex", definition: &ParserDefinition{Format: ParserFormatRegex, Pattern: `(?P<time>\S+)`, Timestamp: "${time}"}},
+		{desc: "invalid pattern", definition: &ParserDefinition{Format: ParserFormatRegex, Pattern: `(`, Timestamp: "${time}"}, wantErr: true},
+		{desc: "unknown format", definition: &ParserDefinition{Format: "xml", Timestamp: "${time}"}, wantErr: true},
+		{desc: "missing timestamp", definition: &ParserDefinition{Format: ParserFormatJSON}, wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.definition.Compile()
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Compile() returned error %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
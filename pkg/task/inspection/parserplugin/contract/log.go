@@ -0,0 +1,138 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parserplugin_contract
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/common/structured"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+// pluginLogDocument is the structure of logs converted from the records written by plugins.
+type pluginLogDocument struct {
+	ID string `json:"id"`
+	*PluginLogRecord
+}
+
+// NewLogFromPluginRecord converts a record written by a plugin to a log.
+func NewLogFromPluginRecord(record *PluginLogRecord) (*log.Log, error) {
+	serializedRecord, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	hash := fnv.New64a()
+	hash.Write(serializedRecord)
+	serialized, err := json.Marshal(&pluginLogDocument{
+		ID:              fmt.Sprintf("%016x", hash.Sum64()),
+		PluginLogRecord: record,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return log.NewLogFromYAMLString(string(serialized))
+}
+
+// PluginLogCommonFieldSetReader implements log.FieldSetReader for log.CommonFieldSet{} from logs written by plugins.
+type PluginLogCommonFieldSetReader struct{}
+
+// FieldSetKind implements log.FieldSetReader.
+func (p *PluginLogCommonFieldSetReader) FieldSetKind() string {
+	return (&log.CommonFieldSet{}).Kind()
+}
+
+// Read implements log.FieldSetReader.
+func (p *PluginLogCommonFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	result := &log.CommonFieldSet{}
+	result.DisplayID = reader.ReadStringOrDefault("id", "unknown")
+	timestamp, err := reader.ReadTimestamp("timestamp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the timestamp of the plugin log: %w", err)
+	}
+	result.Timestamp = timestamp
+	result.Severity = severityFromLabel(reader.ReadStringOrDefault("severity", ""))
+	return result, nil
+}
+
+var _ log.FieldSetReader = (*PluginLogCommonFieldSetReader)(nil)
+
+// severityFromLabel returns the severity having the given label. `WARN` is accepted as an alias of `WARNING`.
+func severityFromLabel(label string) enum.Severity {
+	if strings.EqualFold(label, "WARN") {
+		return enum.SeverityWarning
+	}
+	for severity, metadata := range enum.Severities {
+		if strings.EqualFold(metadata.Label, label) {
+			return severity
+		}
+	}
+	return enum.SeverityUnknown
+}
+
+// PluginLogFieldSet is the fieldset of the timeline changes written in logs by plugins.
+type PluginLogFieldSet struct {
+	Summary   string
+	Events    []*PluginResource
+	Revisions []*PluginRevision
+}
+
+// Kind implements log.FieldSet.
+func (p *PluginLogFieldSet) Kind() string {
+	return "parser_plugin"
+}
+
+var _ log.FieldSet = (*PluginLogFieldSet)(nil)
+
+// PluginLogFieldSetReader implements log.FieldSetReader for PluginLogFieldSet.
+type PluginLogFieldSetReader struct{}
+
+// FieldSetKind implements log.FieldSetReader.
+func (p *PluginLogFieldSetReader) FieldSetKind() string {
+	return (&PluginLogFieldSet{}).Kind()
+}
+
+// Read implements log.FieldSetReader.
+func (p *PluginLogFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	result := &PluginLogFieldSet{}
+	result.Summary = reader.ReadStringOrDefault("summary", "")
+	if err := readJSONField(reader, "events", &result.Events); err != nil {
+		return nil, err
+	}
+	if err := readJSONField(reader, "revisions", &result.Revisions); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+var _ log.FieldSetReader = (*PluginLogFieldSetReader)(nil)
+
+// readJSONField decodes the field into the given value when the field exists.
+func readJSONField(reader *structured.NodeReader, fieldPath string, value any) error {
+	if !reader.Has(fieldPath) {
+		return nil
+	}
+	serialized, err := reader.Serialize(fieldPath, &structured.JSONNodeSerializer{})
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(serialized, value); err != nil {
+		return fmt.Errorf("failed to read %s of the plugin log: %w", fieldPath, err)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parserplugin_contract
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"gopkg.in/yaml.v3"
+)
+
+// pluginIDPattern restricts plugin and field IDs to the characters usable in task IDs.
+var pluginIDPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// PluginManifest is the definition of a parser plugin loaded from a YAML file in the plugin folder.
+type PluginManifest struct {
+	// ID is the unique ID of the plugin used in the task IDs.
+	ID string `yaml:"id"`
+	// Name is the feature name shown in the feature list.
+	Name string `yaml:"name"`
+	// Description is the feature description shown in the feature list.
+	Description string `yaml:"description"`
+	// Command is the command line to run the plugin process. A relative executable path is resolved from the folder containing the manifest.
+	Command []string `yaml:"command"`
+	// Parser is the declarative definition of the parser reading uploaded log files. This is used instead of Command for simple log formats.
+	Parser *ParserDefinition `yaml:"parser"`
+	// InspectionTypes is the list of inspection type IDs the plugin feature is available in.
+	InspectionTypes []string `yaml:"inspectionTypes"`
+	// LogType is the label of the log type given to the logs written by the plugin like `k8s_container`. `unknown` is used when it is empty.
+	LogType string `yaml:"logType"`
+	// DefaultEnabled makes the plugin feature selected by default.
+	DefaultEnabled bool `yaml:"defaultEnabled"`
+	// Forms is the list of form fields shown when the plugin feature is selected. The values are sent to the plugin process. Plugins with Parser can't have forms.
+	Forms []*PluginFormField `yaml:"forms"`
+}
+
+// PluginFormField is a text form field declared in the plugin manifest.
+type PluginFormField struct {
+	// ID is the key of the value in the request sent to the plugin process.
+	ID          string `yaml:"id"`
+	Label       string `yaml:"label"`
+	Description string `yaml:"description"`
+	Placeholder string `yaml:"placeholder"`
+	Default     string `yaml:"default"`
+	// Required makes the form reject empty values.
+	Required bool `yaml:"required"`
+}
+
+// LogTypeEnum returns the log type given to the logs written by the plugin.
+func (m *PluginManifest) LogTypeEnum() enum.LogType {
+	for logType, metadata := range enum.LogTypes {
+		if metadata.Label == m.LogType {
+			return logType
+		}
+	}
+	return enum.LogTypeUnknown
+}
+
+// Validate returns an error when the manifest misses required fields or contains invalid values.
+func (m *PluginManifest) Validate() error {
+	if !pluginIDPattern.MatchString(m.ID) {
+		return fmt.Errorf("plugin id %q must consist of lower case alphanumeric characters or '-'", m.ID)
+	}
+	if m.Name == "" {
+		return fmt.Errorf("plugin %s has no name", m.ID)
+	}
+	if len(m.Command) == 0 && m.Parser == nil {
+		return fmt.Errorf("plugin %s has neither command nor parser", m.ID)
+	}
+	if len(m.Command) > 0 && m.Parser != nil {
+		return fmt.Errorf("plugin %s can't have both of command and parser", m.ID)
+	}
+	if m.Parser != nil && len(m.Forms) > 0 {
+		return fmt.Errorf("plugin %s can't have forms with parser. Plugins with parser show the form to upload log files instead", m.ID)
+	}
+	if m.Parser != nil {
+		if err := m.Parser.Compile(); err != nil {
+			return fmt.Errorf("invalid parser of plugin %s: %w", m.ID, err)
+		}
+	}
+	if len(m.InspectionTypes) == 0 {
+		return fmt.Errorf("plugin %s has no inspection type", m.ID)
+	}
+	if m.LogType != "" && m.LogTypeEnum() == enum.LogTypeUnknown {
+		return fmt.Errorf("plugin %s has an unknown log type %q", m.ID, m.LogType)
+	}
+	fieldIDs := map[string]struct{}{}
+	for _, field := range m.Forms {
+		if !pluginIDPattern.MatchString(field.ID) {
+			return fmt.Errorf("form field id %q of plugin %s must consist of lower case alphanumeric characters or '-'", field.ID, m.ID)
+		}
+		if _, found := fieldIDs[field.ID]; found {
+			return fmt.Errorf("plugin %s has duplicated form field id %q", m.ID, field.ID)
+		}
+		fieldIDs[field.ID] = struct{}{}
+	}
+	return nil
+}
+
+// LoadPluginManifests reads all the plugin manifests written in `*.yaml` or `*.yml` files directly under the folder.
+func LoadPluginManifests(folder string) ([]*PluginManifest, error) {
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the parser plugin folder %s: %w", folder, err)
+	}
+	var manifests []*PluginManifest
+	pluginIDs := map[string]string{}
+	for _, entry := range entries {
+		extension := filepath.Ext(entry.Name())
+		if entry.IsDir() || (extension != ".yaml" && extension != ".yml") {
+			continue
+		}
+		manifestPath := filepath.Join(folder, entry.Name())
+		manifest, err := loadPluginManifest(manifestPath)
+		if err != nil {
+			return nil, err
+		}
+		if previousPath, found := pluginIDs[manifest.ID]; found {
+			return nil, fmt.Errorf("plugin id %s is declared in both of %s and %s", manifest.ID, previousPath, manifestPath)
+		}
+		pluginIDs[manifest.ID] = manifestPath
+		manifests = append(manifests, manifest)
+	}
+	slices.SortFunc(manifests, func(a, b *PluginManifest) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return manifests, nil
+}
+
+func loadPluginManifest(manifestPath string) (*PluginManifest, error) {
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the parser plugin manifest %s: %w", manifestPath, err)
+	}
+	var manifest PluginManifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse the parser plugin manifest %s: %w", manifestPath, err)
+	}
+	if err := manifest.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid parser plugin manifest %s: %w", manifestPath, err)
+	}
+	if len(manifest.Command) > 0 {
+		executable := manifest.Command[0]
+		if strings.Contains(executable, string(filepath.Separator)) && !filepath.IsAbs(executable) {
+			manifest.Command[0] = filepath.Join(filepath.Dir(manifestPath), executable)
+		}
+	}
+	return &manifest, nil
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parserplugin_contract
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/model/enum"
+)
+
+func TestLoadPluginManifests(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		files   map[string]string
+		want    []*PluginManifest
+		wantErr string
+	}{
+		{
+			desc: "valid manifests",
+			files: map[string]string{
+				"b.yaml": `
+id: b-parser
+name: B parser
+command: ["./bin/b-parser", "--json"]
+inspectionTypes: ["oss-kubernetes-logs"]
+logType: k8s_container
+forms:
+- id: log-path
+  label: Log path
+  required: true
+`,
+				"a.yml": `
+id: a-parser
+name: A parser
+command: ["a-parser"]
+inspectionTypes: ["oss-kubernetes-logs"]
+`,
+				"readme.txt": "not a manifest",
+			},
+			want: []*PluginManifest{
+				{
+					ID:              "a-parser",
+					Name:            "A parser",
+					Command:         []string{"a-parser"},
+					InspectionTypes: []string{"oss-kubernetes-logs"},
+				},
+				{
+					ID:              "b-parser",
+					Name:            "B parser",
+					Command:         []string{"{{dir}}/bin/b-parser", "--json"},
+					InspectionTypes: []string{"oss-kubernetes-logs"},
+					LogType:         "k8s_container",
+					Forms: []*PluginFormField{
+						{ID: "log-path", Label: "Log path", Required: true},
+					},
+				},
+			},
+		},
+		{
+			desc: "duplicated plugin ids",
+			files: map[string]string{
+				"a.yaml": "{id: a, name: A, command: [a], inspectionTypes: [t]}",
+				"b.yaml": "{id: a, name: A, command: [a], inspectionTypes: [t]}",
+			},
+			wantErr: "plugin id a is declared in both",
+		},
+		{
+			desc: "invalid plugin id",
+			files: map[string]string{
+				"a.yaml": "{id: A_parser, name: A, command: [a], inspectionTypes: [t]}",
+			},
+			wantErr: "must consist of lower case alphanumeric characters",
+		},
+		{
+			desc: "missing command",
+			files: map[string]string{
+				"a.yaml": "{id: a, name: A, inspectionTypes: [t]}",
+			},
+			wantErr: "plugin a has neither command nor parser",
+		},
+		{
+			desc: "both of command and parser",
+			files: map[string]string{
+				"a.yaml": "{id: a, name: A, command: [a], parser: {format: json, timestamp: '${time}'}, inspectionTypes: [t]}",
+			},
+			wantErr: "plugin a can't have both of command and parser",
+		},
+		{
+			desc: "invalid parser",
+			files: map[string]string{
+				"a.yaml": "{id: a, name: A, parser: {format: regex, pattern: '(', timestamp: '${time}'}, inspectionTypes: [t]}",
+			},
+			wantErr: "invalid parser of plugin a: invalid pattern",
+		},
+		{
+			desc: "unknown log type",
+			files: map[string]string{
+				"a.yaml": "{id: a, name: A, command: [a], inspectionTypes: [t], logType: foo}",
+			},
+			wantErr: `unknown log type "foo"`,
+		},
+		{
+			desc: "duplicated form field ids",
+			files: map[string]string{
+				"a.yaml": "{id: a, name: A, command: [a], inspectionTypes: [t], forms: [{id: f}, {id: f}]}",
+			},
+			wantErr: `duplicated form field id "f"`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tc.files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := LoadPluginManifests(dir)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("LoadPluginManifests() returned error %v, want an error containing %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadPluginManifests() returned an unexpected error: %v", err)
+			}
+			for _, manifest := range tc.want {
+				manifest.Command[0] = strings.ReplaceAll(manifest.Command[0], "{{dir}}", dir)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("LoadPluginManifests() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPluginManifestLogTypeEnum(t *testing.T) {
+	if got := (&PluginManifest{LogType: "k8s_node"}).LogTypeEnum(); got != enum.LogTypeNode {
+		t.Errorf("LogTypeEnum() = %v, want %v", got, enum.LogTypeNode)
+	}
+	if got := (&PluginManifest{}).LogTypeEnum(); got != enum.LogTypeUnknown {
+		t.Errorf("LogTypeEnum() = %v, want %v", got, enum.LogTypeUnknown)
+	}
+}
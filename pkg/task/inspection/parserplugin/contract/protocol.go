@@ -0,0 +1,130 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parserplugin_contract
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/model/history/resourcepath"
+)
+
+// PluginRequest is the JSON document written to the stdin of the plugin process.
+type PluginRequest struct {
+	// PluginID is the ID of the plugin in the manifest. This lets a single executable serve multiple manifests.
+	PluginID string `json:"pluginId"`
+	// Values is the map of the form field IDs declared in the manifest to the values given in the form.
+	Values map[string]string `json:"values"`
+}
+
+// PluginLogRecord is a log written by the plugin process to the stdout as a line of JSON.
+type PluginLogRecord struct {
+	Timestamp time.Time `json:"timestamp"`
+	// Severity is the label of the severity like `INFO` or `ERROR`. The severity is unknown when it is empty.
+	Severity string `json:"severity,omitempty"`
+	// Summary is the text shown in the log list.
+	Summary string `json:"summary"`
+	// Body is the original log shown in the log view.
+	Body map[string]any `json:"body,omitempty"`
+	// Events is the list of resources to show the log as an event on their timelines.
+	Events []*PluginResource `json:"events,omitempty"`
+	// Revisions is the list of resource states changed at the log.
+	Revisions []*PluginRevision `json:"revisions,omitempty"`
+}
+
+// PluginResource identifies a timeline of a Kubernetes resource.
+type PluginResource struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	// Namespace is the namespace of the resource. Cluster scoped resources use an empty string.
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name"`
+	Subresource string `json:"subresource,omitempty"`
+}
+
+// ResourcePath returns the resource path of the timeline.
+func (r *PluginResource) ResourcePath() resourcepath.ResourcePath {
+	namespace := r.Namespace
+	if namespace == "" {
+		namespace = "cluster-scope"
+	}
+	if r.Subresource != "" {
+		return resourcepath.SubresourceLayerGeneralItem(r.APIVersion, r.Kind, namespace, r.Name, r.Subresource)
+	}
+	return resourcepath.NameLayerGeneralItem(r.APIVersion, r.Kind, namespace, r.Name)
+}
+
+// PluginRevision is a state of a resource changed at the log.
+type PluginRevision struct {
+	Resource PluginResource `json:"resource"`
+	// Deleted marks the resource deleted at the log.
+	Deleted bool `json:"deleted,omitempty"`
+	// Manifest is the YAML or JSON manifest of the resource after the change.
+	Manifest string `json:"manifest,omitempty"`
+	// Requestor is the principal changed the resource.
+	Requestor string `json:"requestor,omitempty"`
+}
+
+// RunPlugin runs the plugin process with the request written to its stdin and reads the logs written to its stdout in JSON lines.
+// The plugin must exit with the status 0. Messages written to the stderr are included in the error otherwise.
+func RunPlugin(ctx context.Context, manifest *PluginManifest, request *PluginRequest) ([]*PluginLogRecord, error) {
+	serializedRequest, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.CommandContext(ctx, manifest.Command[0], manifest.Command[1:]...)
+	cmd.Stdin = bytes.NewReader(serializedRequest)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start the parser plugin %s: %w", manifest.ID, err)
+	}
+
+	var records []*PluginLogRecord
+	decoder := json.NewDecoder(stdout)
+	for {
+		var record PluginLogRecord
+		err := decoder.Decode(&record)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			// Drain the rest of the output not to block the plugin process on writing it.
+			_, _ = io.Copy(io.Discard, stdout)
+			_ = cmd.Wait()
+			return nil, fmt.Errorf("failed to parse the log %d written by the parser plugin %s: %w", len(records)+1, manifest.ID, err)
+		}
+		if record.Timestamp.IsZero() {
+			_, _ = io.Copy(io.Discard, stdout)
+			_ = cmd.Wait()
+			return nil, fmt.Errorf("the log %d written by the parser plugin %s has no timestamp", len(records)+1, manifest.ID)
+		}
+		records = append(records, &record)
+	}
+	if err := cmd.Wait(); err != nil {
+		return nil, fmt.Errorf("the parser plugin %s failed: %w: %s", manifest.ID, err, stderr.String())
+	}
+	return records, nil
+}
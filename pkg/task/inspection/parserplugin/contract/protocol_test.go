@@ -0,0 +1,138 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parserplugin_contract
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+)
+
+func TestRunPlugin(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		script  string
+		want    []*PluginLogRecord
+		wantErr string
+	}{
+		{
+			desc: "records in json lines",
+			// The plugin echoes the value given in the form as the summary.
+			script: `value=$(sed -e 's/.*"path":"\([^"]*\)".*/\1/')
+echo '{"timestamp":"2025-01-01T00:00:00Z","severity":"ERROR","summary":"'$value'","events":[{"apiVersion":"v1","kind":"pod","namespace":"default","name":"nginx"}]}'
+echo '{"timestamp":"2025-01-01T00:00:01Z","summary":"deleted","revisions":[{"resource":{"apiVersion":"v1","kind":"node","name":"node-1"},"deleted":true}]}'`,
+			want: []*PluginLogRecord{
+				{
+					Timestamp: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					Severity:  "ERROR",
+					Summary:   "/var/log/foo.log",
+					Events:    []*PluginResource{{APIVersion: "v1", Kind: "pod", Namespace: "default", Name: "nginx"}},
+				},
+				{
+					Timestamp: time.Date(2025, 1, 1, 0, 0, 1, 0, time.UTC),
+					Summary:   "deleted",
+					Revisions: []*PluginRevision{{Resource: PluginResource{APIVersion: "v1", Kind: "node", Name: "node-1"}, Deleted: true}},
+				},
+			},
+		},
+		{
+			desc:    "failing plugin",
+			script:  `echo "no such file" >&2; exit 1`,
+			wantErr: "no such file",
+		},
+		{
+			desc:    "invalid output",
+			script:  `echo 'not json'`,
+			wantErr: "failed to parse the log 1",
+		},
+		{
+			desc:    "record without timestamp",
+			script:  `echo '{"summary":"foo"}'`,
+			wantErr: "has no timestamp",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			manifest := &PluginManifest{ID: "test", Command: []string{"sh", "-c", tc.script}}
+			got, err := RunPlugin(context.Background(), manifest, &PluginRequest{PluginID: "test", Values: map[string]string{"path": "/var/log/foo.log"}})
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("RunPlugin() returned error %v, want an error containing %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RunPlugin() returned an unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("RunPlugin() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewLogFromPluginRecord(t *testing.T) {
+	record := &PluginLogRecord{
+		Timestamp: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Severity:  "warn",
+		Summary:   "scaled",
+		Body:      map[string]any{"replicas": 3},
+		Events:    []*PluginResource{{APIVersion: "apps/v1", Kind: "deployment", Namespace: "default", Name: "nginx"}},
+		Revisions: []*PluginRevision{{Resource: PluginResource{APIVersion: "v1", Kind: "pod", Namespace: "default", Name: "nginx-1", Subresource: "status"}, Manifest: "phase: Running"}},
+	}
+	l, err := NewLogFromPluginRecord(record)
+	if err != nil {
+		t.Fatalf("NewLogFromPluginRecord() returned an unexpected error: %v", err)
+	}
+	if err := l.SetFieldSetReader(&PluginLogCommonFieldSetReader{}); err != nil {
+		t.Fatalf("SetFieldSetReader() returned an unexpected error: %v", err)
+	}
+	if err := l.SetFieldSetReader(&PluginLogFieldSetReader{}); err != nil {
+		t.Fatalf("SetFieldSetReader() returned an unexpected error: %v", err)
+	}
+
+	commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+	if !commonFieldSet.Timestamp.Equal(record.Timestamp) {
+		t.Errorf("Timestamp = %v, want %v", commonFieldSet.Timestamp, record.Timestamp)
+	}
+	if commonFieldSet.Severity != enum.SeverityWarning {
+		t.Errorf("Severity = %v, want %v", commonFieldSet.Severity, enum.SeverityWarning)
+	}
+	if len(commonFieldSet.DisplayID) != 16 {
+		t.Errorf("DisplayID = %q, want a 16 characters hash", commonFieldSet.DisplayID)
+	}
+	wantFieldSet := &PluginLogFieldSet{
+		Summary:   record.Summary,
+		Events:    record.Events,
+		Revisions: record.Revisions,
+	}
+	if diff := cmp.Diff(wantFieldSet, log.MustGetFieldSet(l, &PluginLogFieldSet{})); diff != "" {
+		t.Errorf("PluginLogFieldSet mismatch (-want +got):\n%s", diff)
+	}
+	if got, want := record.Revisions[0].Resource.ResourcePath().Path, "core/v1#pod#default#nginx-1#status"; got != want {
+		t.Errorf("ResourcePath() = %q, want %q", got, want)
+	}
+	if got, want := (&PluginResource{APIVersion: "v1", Kind: "node", Name: "node-1"}).ResourcePath().Path, "core/v1#node#cluster-scope#node-1"; got != want {
+		t.Errorf("ResourcePath() = %q, want %q", got, want)
+	}
+	if got := l.ReadIntOrDefault("body.replicas", 0); got != 3 {
+		t.Errorf("body.replicas = %d, want 3", got)
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parserplugin_contract
+
+import (
+	"fmt"
+
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/log"
+	"github.com/kyasbal/khi/pkg/server/upload"
+)
+
+// ParserPluginTaskPrefix is the prefix of IDs used in tasks generated from parser plugins.
+const ParserPluginTaskPrefix = "khi.google.com/parser-plugin/"
+
+// InputFormTaskID returns the task ID of the form to input the value of the given field declared in the plugin manifest.
+func InputFormTaskID(pluginID string, fieldID string) taskid.TaskImplementationID[string] {
+	return taskid.NewDefaultImplementationID[string](fmt.Sprintf("%s%s/form/%s", ParserPluginTaskPrefix, pluginID, fieldID))
+}
+
+// InputLogFilesTaskID returns the task ID of the form to upload the log files read with the declarative parser of the plugin.
+func InputLogFilesTaskID(pluginID string) taskid.TaskImplementationID[upload.UploadResultList] {
+	return taskid.NewDefaultImplementationID[upload.UploadResultList](ParserPluginTaskPrefix + pluginID + "/form/log-files")
+}
+
+// PluginRunTaskID returns the task ID to run the plugin process or the declarative parser and read the logs written by it.
+func PluginRunTaskID(pluginID string) taskid.TaskImplementationID[[]*log.Log] {
+	return taskid.NewDefaultImplementationID[[]*log.Log](ParserPluginTaskPrefix + pluginID + "/run")
+}
+
+// FieldSetReaderTaskID returns the task ID to read the fieldset of the logs written by the plugin.
+func FieldSetReaderTaskID(pluginID string) taskid.TaskImplementationID[[]*log.Log] {
+	return taskid.NewDefaultImplementationID[[]*log.Log](ParserPluginTaskPrefix + pluginID + "/fieldset-reader")
+}
+
+// LogIngesterTaskID returns the task ID to finalize the logs written by the plugin to be included in the final output.
+func LogIngesterTaskID(pluginID string) taskid.TaskImplementationID[[]*log.Log] {
+	return taskid.NewDefaultImplementationID[[]*log.Log](ParserPluginTaskPrefix + pluginID + "/log-ingester")
+}
+
+// LogGrouperTaskID returns the task ID to group the logs written by the plugin.
+func LogGrouperTaskID(pluginID string) taskid.TaskImplementationID[inspectiontaskbase.LogGroupMap] {
+	return taskid.NewDefaultImplementationID[inspectiontaskbase.LogGroupMap](ParserPluginTaskPrefix + pluginID + "/log-grouper")
+}
+
+// LogToTimelineMapperTaskID returns the task ID of the feature task to map the logs written by the plugin to timelines.
+func LogToTimelineMapperTaskID(pluginID string) taskid.TaskImplementationID[struct{}] {
+	return taskid.NewDefaultImplementationID[struct{}](ParserPluginTaskPrefix + pluginID + "/timeline-mapper")
+}
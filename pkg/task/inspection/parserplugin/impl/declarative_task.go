@@ -0,0 +1,95 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parserplugin_impl
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/log"
+	"github.com/kyasbal/khi/pkg/server/upload"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	parserplugin_contract "github.com/kyasbal/khi/pkg/task/inspection/parserplugin/contract"
+)
+
+// maxLogLineSizeInBytes is the maximum size of a line in the log files read with declarative parsers.
+const maxLogLineSizeInBytes = 1024 * 1024 * 1024
+
+// newInputLogFilesTask returns the form task to upload the log files read with the declarative parser of the plugin.
+func newInputLogFilesTask(manifest *parserplugin_contract.PluginManifest) coretask.Task[upload.UploadResultList] {
+	verifier := &upload.JSONLineUploadFileVerifier{
+		MaxLineSizeInBytes: maxLogLineSizeInBytes,
+	}
+	switch {
+	case manifest.Parser.SkipUnmatchedLines:
+		verifier.AcceptNonJSONLine = func(line []byte) bool { return true }
+	case manifest.Parser.Format == parserplugin_contract.ParserFormatRegex:
+		verifier.AcceptNonJSONLine = manifest.Parser.MatchLine
+	}
+	return formtask.NewMultiFileFormTaskBuilder(parserplugin_contract.InputLogFilesTaskID(manifest.ID), 0, manifest.Name+" log files", verifier).
+		WithDescription(fmt.Sprintf("Upload the log files read with the parser defined in the plugin %s.", manifest.ID)).
+		Build()
+}
+
+// newDeclarativeParserRunTask returns the task to read the uploaded log files with the declarative parser of the plugin.
+func newDeclarativeParserRunTask(manifest *parserplugin_contract.PluginManifest) coretask.Task[[]*log.Log] {
+	return inspectiontaskbase.NewProgressReportableInspectionTask(
+		parserplugin_contract.PluginRunTaskID(manifest.ID),
+		[]taskid.UntypedTaskReference{
+			parserplugin_contract.InputLogFilesTaskID(manifest.ID).Ref(),
+		},
+		func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, tp *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, error) {
+			if taskMode == inspectioncore_contract.TaskModeDryRun {
+				return []*log.Log{}, nil
+			}
+			uploadResult := coretask.GetTaskResult(ctx, parserplugin_contract.InputLogFilesTaskID(manifest.ID).Ref())
+			readers, err := uploadResult.GetReaders()
+			if err != nil {
+				return nil, err
+			}
+			tp.MarkIndeterminate()
+
+			var records []*parserplugin_contract.PluginLogRecord
+			for fileIndex, reader := range readers {
+				defer reader.Close()
+				scanner := bufio.NewScanner(reader)
+				scanner.Buffer(make([]byte, 0, 64*1024), maxLogLineSizeInBytes)
+				for lineNumber := 1; scanner.Scan(); lineNumber++ {
+					line := scanner.Text()
+					if line == "" {
+						continue
+					}
+					record, err := manifest.Parser.ParseLine(line)
+					if err != nil {
+						return nil, fmt.Errorf("failed to parse the line %d in the file %d with the parser of plugin %s: %w", lineNumber, fileIndex+1, manifest.ID, err)
+					}
+					if record != nil {
+						records = append(records, record)
+					}
+				}
+				if err := scanner.Err(); err != nil {
+					return nil, err
+				}
+			}
+			return newLogsFromRecords(manifest, records)
+		},
+	)
+}
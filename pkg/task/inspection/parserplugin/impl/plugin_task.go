@@ -0,0 +1,199 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parserplugin_impl
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/history"
+	"github.com/kyasbal/khi/pkg/model/log"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	parserplugin_contract "github.com/kyasbal/khi/pkg/task/inspection/parserplugin/contract"
+)
+
+// pluginFeatureOrder is the order of features generated from plugins. They are listed after the builtin features.
+const pluginFeatureOrder = 100000
+
+// NewPluginTasks returns the tasks to show the form fields declared in the plugin manifest, run the plugin or its declarative parser and map the logs on timelines.
+func NewPluginTasks(manifest *parserplugin_contract.PluginManifest) []coretask.UntypedTask {
+	tasks := []coretask.UntypedTask{}
+	if manifest.Parser != nil {
+		tasks = append(tasks, newInputLogFilesTask(manifest), newDeclarativeParserRunTask(manifest))
+	} else {
+		formTaskRefs := []taskid.UntypedTaskReference{}
+		for _, field := range manifest.Forms {
+			formTask := newPluginFormTask(manifest, field)
+			tasks = append(tasks, formTask)
+			formTaskRefs = append(formTaskRefs, formTask.UntypedID().GetUntypedReference())
+		}
+		tasks = append(tasks, newPluginRunTask(manifest, formTaskRefs))
+	}
+	runTaskID := parserplugin_contract.PluginRunTaskID(manifest.ID)
+	fieldSetReaderTaskID := parserplugin_contract.FieldSetReaderTaskID(manifest.ID)
+	ingesterTaskID := parserplugin_contract.LogIngesterTaskID(manifest.ID)
+	grouperTaskID := parserplugin_contract.LogGrouperTaskID(manifest.ID)
+	return append(tasks,
+		inspectiontaskbase.NewFieldSetReadTask(fieldSetReaderTaskID, runTaskID.Ref(), []log.FieldSetReader{
+			&parserplugin_contract.PluginLogFieldSetReader{},
+		}),
+		inspectiontaskbase.NewLogIngesterTask(ingesterTaskID, runTaskID.Ref()),
+		// Plugin logs can change multiple resources at once, thus all of them are processed in a single group to keep the order of changes on each resource.
+		inspectiontaskbase.NewLogGrouperTask(grouperTaskID, fieldSetReaderTaskID.Ref(), func(ctx context.Context, l *log.Log) string {
+			return manifest.ID
+		}),
+		inspectiontaskbase.NewLogToTimelineMapperTask[struct{}](parserplugin_contract.LogToTimelineMapperTaskID(manifest.ID), &pluginLogToTimelineMapperTaskSetting{
+			ingesterTaskID: ingesterTaskID,
+			grouperTaskID:  grouperTaskID,
+		}, inspectioncore_contract.FeatureTaskLabel(manifest.Name, manifest.Description, manifest.LogTypeEnum(), pluginFeatureOrder, manifest.DefaultEnabled, manifest.InspectionTypes...)),
+	)
+}
+
+// newPluginFormTask returns the text form task for the form field declared in the plugin manifest.
+func newPluginFormTask(manifest *parserplugin_contract.PluginManifest, field *parserplugin_contract.PluginFormField) coretask.Task[string] {
+	label := field.Label
+	if label == "" {
+		label = field.ID
+	}
+	builder := formtask.NewTextFormTaskBuilder(parserplugin_contract.InputFormTaskID(manifest.ID, field.ID), 0, label).
+		WithDescription(field.Description).
+		WithPlaceholder(field.Placeholder).
+		WithDefaultValueFunc(func(ctx context.Context, previousValues []string) (string, error) {
+			if len(previousValues) > 0 {
+				return previousValues[0], nil
+			}
+			return field.Default, nil
+		}).
+		WithConverter(func(ctx context.Context, value string) (string, error) {
+			return strings.TrimSpace(value), nil
+		})
+	if field.Required {
+		builder = builder.WithValidator(func(ctx context.Context, value string) (string, error) {
+			if strings.TrimSpace(value) == "" {
+				return fmt.Sprintf("%s is required by the plugin %s", label, manifest.Name), nil
+			}
+			return "", nil
+		})
+	}
+	return builder.Build()
+}
+
+// newPluginRunTask returns the task to run the plugin process with the values given in the form fields.
+func newPluginRunTask(manifest *parserplugin_contract.PluginManifest, formTaskRefs []taskid.UntypedTaskReference) coretask.Task[[]*log.Log] {
+	return inspectiontaskbase.NewProgressReportableInspectionTask(
+		parserplugin_contract.PluginRunTaskID(manifest.ID),
+		formTaskRefs,
+		func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, tp *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, error) {
+			if taskMode == inspectioncore_contract.TaskModeDryRun {
+				return []*log.Log{}, nil
+			}
+			request := &parserplugin_contract.PluginRequest{
+				PluginID: manifest.ID,
+				Values:   map[string]string{},
+			}
+			for _, field := range manifest.Forms {
+				request.Values[field.ID] = coretask.GetTaskResult(ctx, parserplugin_contract.InputFormTaskID(manifest.ID, field.ID).Ref())
+			}
+
+			tp.MarkIndeterminate()
+			records, err := parserplugin_contract.RunPlugin(ctx, manifest, request)
+			if err != nil {
+				return nil, err
+			}
+
+			return newLogsFromRecords(manifest, records)
+		},
+	)
+}
+
+// newLogsFromRecords converts the records written by the plugin to logs sorted by their timestamps.
+func newLogsFromRecords(manifest *parserplugin_contract.PluginManifest, records []*parserplugin_contract.PluginLogRecord) ([]*log.Log, error) {
+	logs := make([]*log.Log, 0, len(records))
+	for _, record := range records {
+		l, err := parserplugin_contract.NewLogFromPluginRecord(record)
+		if err != nil {
+			return nil, err
+		}
+		err = l.SetFieldSetReader(&parserplugin_contract.PluginLogCommonFieldSetReader{})
+		if err != nil {
+			return nil, err
+		}
+		l.LogType = manifest.LogTypeEnum()
+		logs = append(logs, l)
+	}
+	slices.SortStableFunc(logs, func(a, b *log.Log) int {
+		return log.MustGetFieldSet(a, &log.CommonFieldSet{}).Timestamp.Compare(log.MustGetFieldSet(b, &log.CommonFieldSet{}).Timestamp)
+	})
+	return logs, nil
+}
+
+type pluginLogToTimelineMapperTaskSetting struct {
+	ingesterTaskID taskid.TaskImplementationID[[]*log.Log]
+	grouperTaskID  taskid.TaskImplementationID[inspectiontaskbase.LogGroupMap]
+}
+
+// Dependencies implements inspectiontaskbase.LogToTimelineMapper.
+func (p *pluginLogToTimelineMapperTaskSetting) Dependencies() []taskid.UntypedTaskReference {
+	return []taskid.UntypedTaskReference{}
+}
+
+// GroupedLogTask implements inspectiontaskbase.LogToTimelineMapper.
+func (p *pluginLogToTimelineMapperTaskSetting) GroupedLogTask() taskid.TaskReference[inspectiontaskbase.LogGroupMap] {
+	return p.grouperTaskID.Ref()
+}
+
+// LogIngesterTask implements inspectiontaskbase.LogToTimelineMapper.
+func (p *pluginLogToTimelineMapperTaskSetting) LogIngesterTask() taskid.TaskReference[[]*log.Log] {
+	return p.ingesterTaskID.Ref()
+}
+
+// ProcessLogByGroup implements inspectiontaskbase.LogToTimelineMapper.
+func (p *pluginLogToTimelineMapperTaskSetting) ProcessLogByGroup(ctx context.Context, l *log.Log, cs *history.ChangeSet, builder *history.Builder, prevGroupData struct{}) (struct{}, error) {
+	commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+	pluginFieldSet, err := log.GetFieldSet(l, &parserplugin_contract.PluginLogFieldSet{})
+	if err != nil {
+		return struct{}{}, err
+	}
+	if pluginFieldSet.Summary != "" {
+		cs.SetLogSummary(pluginFieldSet.Summary)
+	}
+	for _, resource := range pluginFieldSet.Events {
+		cs.AddEvent(resource.ResourcePath())
+	}
+	for _, revision := range pluginFieldSet.Revisions {
+		verb, state := enum.RevisionVerbUpdate, enum.RevisionStateExisting
+		if revision.Deleted {
+			verb, state = enum.RevisionVerbDelete, enum.RevisionStateDeleted
+		}
+		cs.AddRevision(revision.Resource.ResourcePath(), &history.StagingResourceRevision{
+			Verb:       verb,
+			State:      state,
+			Body:       revision.Manifest,
+			Requestor:  revision.Requestor,
+			ChangeTime: commonFieldSet.Timestamp,
+		})
+	}
+	return struct{}{}, nil
+}
+
+var _ inspectiontaskbase.LogToTimelineMapper[struct{}] = (*pluginLogToTimelineMapperTaskSetting)(nil)
@@ -0,0 +1,143 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parserplugin_impl
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	parserplugin_contract "github.com/kyasbal/khi/pkg/task/inspection/parserplugin/contract"
+)
+
+func TestNewPluginTasks(t *testing.T) {
+	manifest := &parserplugin_contract.PluginManifest{
+		ID:      "test",
+		Name:    "Test plugin",
+		Command: []string{"sh", "-c", "true"},
+		Forms: []*parserplugin_contract.PluginFormField{
+			{ID: "path"},
+			{ID: "namespace"},
+		},
+	}
+	var got []string
+	for _, task := range NewPluginTasks(manifest) {
+		got = append(got, task.UntypedID().ReferenceIDString())
+	}
+	want := []string{
+		parserplugin_contract.InputFormTaskID("test", "path").ReferenceIDString(),
+		parserplugin_contract.InputFormTaskID("test", "namespace").ReferenceIDString(),
+		parserplugin_contract.PluginRunTaskID("test").ReferenceIDString(),
+		parserplugin_contract.FieldSetReaderTaskID("test").ReferenceIDString(),
+		parserplugin_contract.LogIngesterTaskID("test").ReferenceIDString(),
+		parserplugin_contract.LogGrouperTaskID("test").ReferenceIDString(),
+		parserplugin_contract.LogToTimelineMapperTaskID("test").ReferenceIDString(),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("NewPluginTasks() task IDs mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPluginRunTask(t *testing.T) {
+	type pluginLog struct {
+		Timestamp time.Time
+		Severity  enum.Severity
+		Summary   string
+		LogType   enum.LogType
+	}
+	testCases := []struct {
+		desc     string
+		script   string
+		taskMode inspectioncore_contract.InspectionTaskModeType
+		want     []pluginLog
+		wantErr  string
+	}{
+		{
+			desc: "records sorted by their timestamps",
+			// The plugin echoes the value given in the form as the summary of the later record.
+			script: `value=$(sed -e 's/.*"path":"\([^"]*\)".*/\1/')
+echo '{"timestamp":"2025-01-01T00:00:01Z","summary":"'$value'"}'
+echo '{"timestamp":"2025-01-01T00:00:00Z","severity":"ERROR","summary":"first","events":[{"apiVersion":"v1","kind":"pod","namespace":"default","name":"nginx"}]}'`,
+			taskMode: inspectioncore_contract.TaskModeRun,
+			want: []pluginLog{
+				{Timestamp: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Severity: enum.SeverityError, Summary: "first", LogType: enum.LogTypeContainer},
+				{Timestamp: time.Date(2025, 1, 1, 0, 0, 1, 0, time.UTC), Severity: enum.SeverityUnknown, Summary: "/var/log/foo.log", LogType: enum.LogTypeContainer},
+			},
+		},
+		{
+			desc:     "failing plugin",
+			script:   `echo "no such file" >&2; exit 1`,
+			taskMode: inspectioncore_contract.TaskModeRun,
+			wantErr:  "no such file",
+		},
+		{
+			desc:     "plugin isn't run in dry run",
+			script:   `echo "must not be run" >&2; exit 1`,
+			taskMode: inspectioncore_contract.TaskModeDryRun,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			manifest := &parserplugin_contract.PluginManifest{
+				ID:      "test",
+				Name:    "Test plugin",
+				Command: []string{"sh", "-c", tc.script},
+				LogType: "k8s_container",
+				Forms:   []*parserplugin_contract.PluginFormField{{ID: "path"}},
+			}
+			formTaskRef := parserplugin_contract.InputFormTaskID(manifest.ID, "path").Ref()
+			runTask := newPluginRunTask(manifest, []taskid.UntypedTaskReference{formTaskRef})
+
+			ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+			logs, _, err := inspectiontest.RunInspectionTask(ctx, runTask, tc.taskMode, map[string]any{},
+				tasktest.NewTaskDependencyValuePair(formTaskRef, "/var/log/foo.log"),
+			)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("plugin run task returned error %v, want an error containing %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("plugin run task returned an unexpected error: %v", err)
+			}
+
+			var got []pluginLog
+			for _, l := range logs {
+				if err := l.SetFieldSetReader(&parserplugin_contract.PluginLogFieldSetReader{}); err != nil {
+					t.Fatalf("failed to read the plugin fieldset: %v", err)
+				}
+				commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+				pluginFieldSet := log.MustGetFieldSet(l, &parserplugin_contract.PluginLogFieldSet{})
+				got = append(got, pluginLog{
+					Timestamp: commonFieldSet.Timestamp,
+					Severity:  commonFieldSet.Severity,
+					Summary:   pluginFieldSet.Summary,
+					LogType:   l.LogType,
+				})
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })); diff != "" {
+				t.Errorf("logs mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parserplugin_impl
+
+import (
+	"log/slog"
+
+	coreinspection "github.com/kyasbal/khi/pkg/core/inspection"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/parameters"
+	parserplugin_contract "github.com/kyasbal/khi/pkg/task/inspection/parserplugin/contract"
+)
+
+// Register registers the tasks of the parser plugins found in the folder given with `--parser-plugin-folder`.
+func Register(registry coreinspection.InspectionTaskRegistry) error {
+	if parameters.Common.ParserPluginFolder == nil || *parameters.Common.ParserPluginFolder == "" {
+		return nil
+	}
+	manifests, err := parserplugin_contract.LoadPluginManifests(*parameters.Common.ParserPluginFolder)
+	if err != nil {
+		return err
+	}
+	for _, manifest := range manifests {
+		err := coretask.RegisterTasks(registry, NewPluginTasks(manifest)...)
+		if err != nil {
+			return err
+		}
+		slog.Info("Loaded the parser plugin", "id", manifest.ID, "command", manifest.Command)
+	}
+	return nil
+}
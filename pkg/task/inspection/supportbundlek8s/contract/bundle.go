@@ -0,0 +1,255 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package supportbundlek8s_contract
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/kyasbal/khi/pkg/server/upload"
+	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// maxLineSizeInBytes is the maximum size of a line in log files in the archive.
+const maxLineSizeInBytes = 16 * 1024 * 1024
+
+// BundleFileKind is the kind of data held in a file of the archive.
+type BundleFileKind int
+
+const (
+	BundleFileUnknown BundleFileKind = iota
+	// BundleFileAuditLog is a kube-apiserver audit log file in JSON lines, optionally compressed with gzip.
+	BundleFileAuditLog
+	// BundleFileResourceDump is a dump of resources in YAML or JSON.
+	BundleFileResourceDump
+	// BundleFileContainerLog is a log file of a container with the timestamp at the beginning of each line.
+	BundleFileContainerLog
+)
+
+// BundleFile is the classified file in the archive.
+type BundleFile struct {
+	Kind BundleFileKind
+	// Namespace, PodName and ContainerName are set for container log files.
+	Namespace     string
+	PodName       string
+	ContainerName string
+	// ResourceDirectory is the directory name given by the plural resource name for resource dumps in the support bundle layout (e.g. `pods`).
+	// It is used to infer the kind of the items written without their kinds.
+	ResourceDirectory string
+}
+
+var (
+	// auditLogFilePattern matches audit log files like `audit.log`, `audit-2025-01-01T00-00-00.000.log.gz` or `master-0-audit.log`.
+	auditLogFilePattern = regexp.MustCompile(`(?:^|-)audit(?:[-.][^/]*)?\.log(?:\.gz)?$`)
+	// mustGatherContainerLogPattern matches `namespaces/<namespace>/pods/<pod>/<container>/<container>/logs/current.log` in must-gather.
+	mustGatherContainerLogPattern = regexp.MustCompile(`(?:^|/)namespaces/([^/]+)/pods/([^/]+)/([^/]+)/[^/]+/logs/(?:current|previous)\.log$`)
+	// supportBundleContainerLogPattern matches `cluster-resources/pods/logs/<namespace>/<pod>/<container>.log` in troubleshoot.sh support bundles.
+	supportBundleContainerLogPattern = regexp.MustCompile(`(?:^|/)cluster-resources/pods/logs/([^/]+)/([^/]+)/([^/]+?)(?:-previous)?\.log$`)
+	// mustGatherResourcePattern matches resource dumps under `namespaces/` or `cluster-scoped-resources/` in must-gather.
+	mustGatherResourcePattern = regexp.MustCompile(`(?:^|/)(?:namespaces|cluster-scoped-resources)/.+\.ya?ml$`)
+	// supportBundleResourcePattern matches resource dumps like `cluster-resources/pods/<namespace>.json` in troubleshoot.sh support bundles.
+	supportBundleResourcePattern = regexp.MustCompile(`(?:^|/)cluster-resources/([^/]+)(?:/[^/]+)?\.json$`)
+)
+
+// ClassifyBundleFile returns the kind of the file from its path in the archive.
+// OpenShift must-gather and troubleshoot.sh support bundle layouts are recognized, and audit log files are recognized by the file name in any layout.
+func ClassifyBundleFile(filePath string) *BundleFile {
+	filePath = strings.TrimPrefix(path.Clean("/"+filePath), "/")
+	if strings.Contains("/"+filePath, "/audit_logs/") || auditLogFilePattern.MatchString(path.Base(filePath)) {
+		return &BundleFile{Kind: BundleFileAuditLog}
+	}
+	if match := mustGatherContainerLogPattern.FindStringSubmatch(filePath); match != nil {
+		return &BundleFile{Kind: BundleFileContainerLog, Namespace: match[1], PodName: match[2], ContainerName: match[3]}
+	}
+	if match := supportBundleContainerLogPattern.FindStringSubmatch(filePath); match != nil {
+		return &BundleFile{Kind: BundleFileContainerLog, Namespace: match[1], PodName: match[2], ContainerName: match[3]}
+	}
+	if mustGatherResourcePattern.MatchString(filePath) {
+		return &BundleFile{Kind: BundleFileResourceDump}
+	}
+	if match := supportBundleResourcePattern.FindStringSubmatch(filePath); match != nil {
+		return &BundleFile{Kind: BundleFileResourceDump, ResourceDirectory: match[1]}
+	}
+	return &BundleFile{Kind: BundleFileUnknown}
+}
+
+// ContainerLogFile is the lines of a container log file in the archive.
+type ContainerLogFile struct {
+	Path          string
+	Namespace     string
+	PodName       string
+	ContainerName string
+	Lines         []string
+}
+
+// BundleContents is the data read from the files in the known layouts of the archive.
+type BundleContents struct {
+	AuditLogLines []string
+	ResourceItems []map[string]any
+	ContainerLogs []*ContainerLogFile
+	// SkippedFiles is the count of files not matching any known layout or failed to be parsed.
+	SkippedFiles int
+}
+
+// ReadBundle walks the tar archive optionally compressed with gzip and reads the files in the known layouts.
+// Files compressed with gzip in the archive are decompressed. Files failing to be parsed are skipped rather than failing the whole archive.
+func ReadBundle(reader io.Reader) (*BundleContents, error) {
+	tarReader, err := upload.NewTarReader(reader)
+	if err != nil {
+		return nil, err
+	}
+	result := &BundleContents{}
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return result, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		file := ClassifyBundleFile(header.Name)
+		if file.Kind == BundleFileUnknown {
+			result.SkippedFiles++
+			continue
+		}
+		var content io.Reader = tarReader
+		if strings.HasSuffix(header.Name, ".gz") {
+			gzipReader, err := gzip.NewReader(tarReader)
+			if err != nil {
+				result.SkippedFiles++
+				continue
+			}
+			content = gzipReader
+		}
+		err = result.readFile(header.Name, file, content)
+		if err != nil {
+			result.SkippedFiles++
+		}
+	}
+}
+
+func (b *BundleContents) readFile(filePath string, file *BundleFile, content io.Reader) error {
+	switch file.Kind {
+	case BundleFileAuditLog:
+		lines, err := readLines(content)
+		if err != nil {
+			return err
+		}
+		b.AuditLogLines = append(b.AuditLogLines, lines...)
+	case BundleFileContainerLog:
+		lines, err := readLines(content)
+		if err != nil {
+			return err
+		}
+		b.ContainerLogs = append(b.ContainerLogs, &ContainerLogFile{
+			Path:          filePath,
+			Namespace:     file.Namespace,
+			PodName:       file.PodName,
+			ContainerName: file.ContainerName,
+			Lines:         lines,
+		})
+	case BundleFileResourceDump:
+		data, err := io.ReadAll(content)
+		if err != nil {
+			return err
+		}
+		items, err := readResourceItems(data, file.ResourceDirectory)
+		if err != nil {
+			return err
+		}
+		b.ResourceItems = append(b.ResourceItems, items...)
+	}
+	return nil
+}
+
+// readLines returns the non empty lines of the content.
+func readLines(content io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(content)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSizeInBytes)
+	for scanner.Scan() {
+		if line := scanner.Text(); strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// supportBundleResourceKinds maps the directory names of troubleshoot.sh support bundles to the apiVersion and the kind of the items.
+// Items in support bundles are written in JSON arrays without their kinds.
+var supportBundleResourceKinds = map[string][2]string{
+	"pods":                     {"v1", "Pod"},
+	"services":                 {"v1", "Service"},
+	"events":                   {"v1", "Event"},
+	"nodes":                    {"v1", "Node"},
+	"namespaces":               {"v1", "Namespace"},
+	"configmaps":               {"v1", "ConfigMap"},
+	"pvs":                      {"v1", "PersistentVolume"},
+	"pvcs":                     {"v1", "PersistentVolumeClaim"},
+	"serviceaccounts":          {"v1", "ServiceAccount"},
+	"endpoints":                {"v1", "Endpoints"},
+	"deployments":              {"apps/v1", "Deployment"},
+	"replicasets":              {"apps/v1", "ReplicaSet"},
+	"statefulsets":             {"apps/v1", "StatefulSet"},
+	"daemonsets":               {"apps/v1", "DaemonSet"},
+	"jobs":                     {"batch/v1", "Job"},
+	"cronjobs":                 {"batch/v1", "CronJob"},
+	"ingress":                  {"networking.k8s.io/v1", "Ingress"},
+	"storage-classes":          {"storage.k8s.io/v1", "StorageClass"},
+	"poddisruptionbudgets":     {"policy/v1", "PodDisruptionBudget"},
+	"horizontalpodautoscalers": {"autoscaling/v2", "HorizontalPodAutoscaler"},
+}
+
+// readResourceItems reads the objects from a resource dump in kubectl outputs or JSON arrays of objects.
+// Objects without their kinds get the kind inferred from the resource directory, and are dropped when it's unknown.
+func readResourceItems(data []byte, resourceDirectory string) ([]map[string]any, error) {
+	var items []map[string]any
+	if trimmed := strings.TrimSpace(string(data)); strings.HasPrefix(trimmed, "[") {
+		err := json.Unmarshal([]byte(trimmed), &items)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		items, err = ossclusterk8s_contract.ReadKubectlDumpItems(data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	kind, kindFound := supportBundleResourceKinds[resourceDirectory]
+	var result []map[string]any
+	for _, item := range items {
+		if _, found := item["kind"]; !found {
+			if !kindFound {
+				continue
+			}
+			item["apiVersion"] = kind[0]
+			item["kind"] = kind[1]
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package supportbundlek8s_contract
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClassifyBundleFile(t *testing.T) {
+	testCases := []struct {
+		path string
+		want *BundleFile
+	}{
+		{
+			path: "must-gather/audit_logs/kube-apiserver/master-0-audit.log.gz",
+			want: &BundleFile{Kind: BundleFileAuditLog},
+		},
+		{
+			path: "logs/audit-2025-01-01T00-00-00.000.log",
+			want: &BundleFile{Kind: BundleFileAuditLog},
+		},
+		{
+			path: "must-gather/namespaces/openshift-etcd/pods/etcd-0/etcd/etcd/logs/current.log",
+			want: &BundleFile{Kind: BundleFileContainerLog, Namespace: "openshift-etcd", PodName: "etcd-0", ContainerName: "etcd"},
+		},
+		{
+			path: "support-bundle/cluster-resources/pods/logs/default/nginx-0/nginx-previous.log",
+			want: &BundleFile{Kind: BundleFileContainerLog, Namespace: "default", PodName: "nginx-0", ContainerName: "nginx"},
+		},
+		{
+			path: "must-gather/namespaces/default/core/pods.yaml",
+			want: &BundleFile{Kind: BundleFileResourceDump},
+		},
+		{
+			path: "must-gather/cluster-scoped-resources/core/nodes/node-0.yaml",
+			want: &BundleFile{Kind: BundleFileResourceDump},
+		},
+		{
+			path: "support-bundle/cluster-resources/pods/default.json",
+			want: &BundleFile{Kind: BundleFileResourceDump, ResourceDirectory: "pods"},
+		},
+		{
+			path: "support-bundle/cluster-resources/nodes.json",
+			want: &BundleFile{Kind: BundleFileResourceDump, ResourceDirectory: "nodes"},
+		},
+		{
+			path: "must-gather/version",
+			want: &BundleFile{Kind: BundleFileUnknown},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			got := ClassifyBundleFile(tc.path)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ClassifyBundleFile() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReadBundle(t *testing.T) {
+	var compressedAuditLog bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressedAuditLog)
+	gzipWriter.Write([]byte("{\"stage\":\"ResponseComplete\"}\n\n{\"stage\":\"RequestReceived\"}\n"))
+	gzipWriter.Close()
+
+	var archive bytes.Buffer
+	tarWriter := tar.NewWriter(&archive)
+	for _, file := range []struct {
+		name    string
+		content []byte
+	}{
+		{name: "bundle/audit_logs/audit.log.gz", content: compressedAuditLog.Bytes()},
+		{name: "bundle/cluster-resources/pods/default.json", content: []byte(`[{"metadata":{"name":"nginx-0","namespace":"default"}}]`)},
+		{name: "bundle/cluster-resources/custom/default.json", content: []byte(`[{"metadata":{"name":"unknown"}}]`)},
+		{name: "bundle/cluster-resources/pods/logs/default/nginx-0/nginx.log", content: []byte("2025-01-01T00:00:00Z started\n")},
+		{name: "bundle/namespaces/default/core/events.yaml", content: []byte("not: [a valid yaml")},
+		{name: "bundle/version.txt", content: []byte("1.0")},
+	} {
+		tarWriter.WriteHeader(&tar.Header{Name: file.name, Mode: 0644, Size: int64(len(file.content)), Typeflag: tar.TypeReg})
+		tarWriter.Write(file.content)
+	}
+	tarWriter.Close()
+
+	got, err := ReadBundle(&archive)
+	if err != nil {
+		t.Fatalf("ReadBundle() returned an unexpected error: %v", err)
+	}
+	want := &BundleContents{
+		AuditLogLines: []string{`{"stage":"ResponseComplete"}`, `{"stage":"RequestReceived"}`},
+		ResourceItems: []map[string]any{
+			{"apiVersion": "v1", "kind": "Pod", "metadata": map[string]any{"name": "nginx-0", "namespace": "default"}},
+		},
+		ContainerLogs: []*ContainerLogFile{
+			{
+				Path:          "bundle/cluster-resources/pods/logs/default/nginx-0/nginx.log",
+				Namespace:     "default",
+				PodName:       "nginx-0",
+				ContainerName: "nginx",
+				Lines:         []string{"2025-01-01T00:00:00Z started"},
+			},
+		},
+		SkippedFiles: 2,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadBundle() mismatch (-want +got):\n%s", diff)
+	}
+}
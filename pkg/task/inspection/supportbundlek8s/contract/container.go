@@ -0,0 +1,104 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package supportbundlek8s_contract
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/common/structured"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudlogk8scontainer_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8scontainer/contract"
+)
+
+// bundleFileField is the field added on each container log to hold the file and the container it was read from.
+const bundleFileField = "supportBundle"
+
+// ParseTimestampedLine splits the line written by `kubectl logs --timestamps` into the timestamp and the message.
+// It returns false when the line doesn't begin with a RFC3339 timestamp.
+func ParseTimestampedLine(line string) (time.Time, string, bool) {
+	timestampPart, message, _ := strings.Cut(line, " ")
+	timestamp, err := time.Parse(time.RFC3339Nano, timestampPart)
+	if err != nil {
+		return time.Time{}, "", false
+	}
+	return timestamp, message, true
+}
+
+// NewLogFromContainerLogLine converts a line of the container log file to a log.
+func NewLogFromContainerLogLine(file *ContainerLogFile, lineIndex int, timestamp time.Time, message string) (*log.Log, error) {
+	body := map[string]any{
+		"timestamp": timestamp.Format(time.RFC3339Nano),
+		"message":   message,
+		bundleFileField: map[string]any{
+			"path":      file.Path,
+			"line":      lineIndex + 1,
+			"namespace": file.Namespace,
+			"pod":       file.PodName,
+			"container": file.ContainerName,
+		},
+	}
+	serialized, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	return log.NewLogFromYAMLString(string(serialized))
+}
+
+// SupportBundleContainerLogCommonFieldSetReader implements log.FieldSetReader for log.CommonFieldSet{} from container logs read from the archive.
+type SupportBundleContainerLogCommonFieldSetReader struct{}
+
+// FieldSetKind implements log.FieldSetReader.
+func (s *SupportBundleContainerLogCommonFieldSetReader) FieldSetKind() string {
+	return (&log.CommonFieldSet{}).Kind()
+}
+
+// Read implements log.FieldSetReader.
+func (s *SupportBundleContainerLogCommonFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	result := &log.CommonFieldSet{}
+	result.DisplayID = fmt.Sprintf("%s:%d", reader.ReadStringOrDefault(bundleFileField+".path", "unknown"), reader.ReadIntOrDefault(bundleFileField+".line", 0))
+	timestamp, err := reader.ReadTimestamp("timestamp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the timestamp of the container log: %w", err)
+	}
+	result.Timestamp = timestamp
+	result.Severity = enum.SeverityUnknown
+	return result, nil
+}
+
+var _ log.FieldSetReader = (*SupportBundleContainerLogCommonFieldSetReader)(nil)
+
+// SupportBundleContainerLogFieldSetReader implements log.FieldSetReader for googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet{} from container logs read from the archive.
+type SupportBundleContainerLogFieldSetReader struct{}
+
+// FieldSetKind implements log.FieldSetReader.
+func (s *SupportBundleContainerLogFieldSetReader) FieldSetKind() string {
+	return (&googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet{}).Kind()
+}
+
+// Read implements log.FieldSetReader.
+func (s *SupportBundleContainerLogFieldSetReader) Read(reader *structured.NodeReader) (log.FieldSet, error) {
+	return &googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet{
+		Namespace:     reader.ReadStringOrDefault(bundleFileField+".namespace", "unknown"),
+		PodName:       reader.ReadStringOrDefault(bundleFileField+".pod", "unknown"),
+		ContainerName: reader.ReadStringOrDefault(bundleFileField+".container", "unknown"),
+		Message:       reader.ReadStringOrDefault("message", ""),
+	}, nil
+}
+
+var _ log.FieldSetReader = (*SupportBundleContainerLogFieldSetReader)(nil)
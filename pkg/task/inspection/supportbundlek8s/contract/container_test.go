@@ -0,0 +1,89 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package supportbundlek8s_contract
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudlogk8scontainer_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8scontainer/contract"
+)
+
+func TestParseTimestampedLine(t *testing.T) {
+	testCases := []struct {
+		line          string
+		wantOk        bool
+		wantTimestamp time.Time
+		wantMessage   string
+	}{
+		{
+			line:          "2025-01-01T00:00:00.123456789Z I0101 started the server",
+			wantOk:        true,
+			wantTimestamp: time.Date(2025, 1, 1, 0, 0, 0, 123456789, time.UTC),
+			wantMessage:   "I0101 started the server",
+		},
+		{
+			line:   "I0101 00:00:00.000000       1 main.go:10] started",
+			wantOk: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.line, func(t *testing.T) {
+			timestamp, message, ok := ParseTimestampedLine(tc.line)
+			if ok != tc.wantOk {
+				t.Fatalf("ParseTimestampedLine() returned ok=%v, want %v", ok, tc.wantOk)
+			}
+			if !timestamp.Equal(tc.wantTimestamp) {
+				t.Errorf("timestamp = %v, want %v", timestamp, tc.wantTimestamp)
+			}
+			if message != tc.wantMessage {
+				t.Errorf("message = %q, want %q", message, tc.wantMessage)
+			}
+		})
+	}
+}
+
+func TestNewLogFromContainerLogLine(t *testing.T) {
+	file := &ContainerLogFile{Path: "namespaces/default/pods/nginx-0/nginx/nginx/logs/current.log", Namespace: "default", PodName: "nginx-0", ContainerName: "nginx"}
+	timestamp := time.Date(2025, 1, 1, 0, 0, 0, 1, time.UTC)
+	l, err := NewLogFromContainerLogLine(file, 9, timestamp, "started")
+	if err != nil {
+		t.Fatalf("NewLogFromContainerLogLine() returned an unexpected error: %v", err)
+	}
+	err = l.SetFieldSetReader(&SupportBundleContainerLogCommonFieldSetReader{})
+	if err != nil {
+		t.Fatalf("SetFieldSetReader() returned an unexpected error: %v", err)
+	}
+	err = l.SetFieldSetReader(&SupportBundleContainerLogFieldSetReader{})
+	if err != nil {
+		t.Fatalf("SetFieldSetReader() returned an unexpected error: %v", err)
+	}
+
+	commonFieldSet := log.MustGetFieldSet(l, &log.CommonFieldSet{})
+	if !commonFieldSet.Timestamp.Equal(timestamp) {
+		t.Errorf("Timestamp = %v, want %v", commonFieldSet.Timestamp, timestamp)
+	}
+	if want := file.Path + ":10"; commonFieldSet.DisplayID != want {
+		t.Errorf("DisplayID = %q, want %q", commonFieldSet.DisplayID, want)
+	}
+	containerFieldSet := log.MustGetFieldSet(l, &googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet{})
+	if got, want := containerFieldSet.ResourcePath().Path, "core/v1#pod#default#nginx-0#nginx"; got != want {
+		t.Errorf("ResourcePath() = %q, want %q", got, want)
+	}
+	if containerFieldSet.Message != "started" {
+		t.Errorf("Message = %q, want %q", containerFieldSet.Message, "started")
+	}
+}
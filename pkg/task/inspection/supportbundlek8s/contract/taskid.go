@@ -0,0 +1,63 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package supportbundlek8s_contract
+
+import (
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/log"
+	"github.com/kyasbal/khi/pkg/server/upload"
+	commonlogk8sauditv2_contract "github.com/kyasbal/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+)
+
+// SupportBundleTaskPrefix is the prefixes of IDs used in support bundle related tasks.
+const SupportBundleTaskPrefix = "khi.google.com/support-bundle/"
+
+// InputBundleFileTaskID is the task ID for the form to upload the support bundle archive.
+var InputBundleFileTaskID = taskid.NewDefaultImplementationID[upload.UploadResult](SupportBundleTaskPrefix + "form/bundle-file")
+
+// BundleReaderTaskID is the task ID to walk the uploaded archive and collect the files in the known layouts.
+var BundleReaderTaskID = taskid.NewDefaultImplementationID[*BundleContents](SupportBundleTaskPrefix + "bundle-reader")
+
+// AuditLogReaderTaskID is the task ID to read the audit logs in the archive in place of the uploaded audit log files.
+var AuditLogReaderTaskID = taskid.NewImplementationID(ossclusterk8s_contract.AuditLogFileReaderTaskID.Ref(), "support-bundle")
+
+// SupportBundleAuditLogProviderTaskID is the task ID to provide the audit logs to the common k8s audit log parsers.
+var SupportBundleAuditLogProviderTaskID = taskid.NewImplementationID(commonlogk8sauditv2_contract.K8sAuditLogProviderRef, "support-bundle")
+
+// SupportBundleAuditLogParserTailTaskID is the task ID of the feature task to parse audit logs.
+var SupportBundleAuditLogParserTailTaskID = taskid.NewImplementationID(commonlogk8sauditv2_contract.K8sAuditLogParserTailRef, "support-bundle")
+
+// SupportBundleAuditPermissionDeniedParserTailTaskID is the task ID of the feature task to parse audit logs of denied requests.
+var SupportBundleAuditPermissionDeniedParserTailTaskID = taskid.NewImplementationID(commonlogk8sauditv2_contract.K8sAuditPermissionDeniedParserTailRef, "support-bundle")
+
+// ResourceDumpReaderTaskID is the task ID to read the resource dumps in the archive in place of the uploaded kubectl outputs.
+var ResourceDumpReaderTaskID = taskid.NewImplementationID(ossclusterk8s_contract.KubectlDumpReaderTaskID.Ref(), "support-bundle")
+
+// ContainerLogReaderTaskID is the task ID to read the container logs in the archive.
+var ContainerLogReaderTaskID = taskid.NewDefaultImplementationID[[]*log.Log](SupportBundleTaskPrefix + "container-log-reader")
+
+// ContainerLogFieldSetReaderTaskID is the task ID to read the container log fieldset from the container logs.
+var ContainerLogFieldSetReaderTaskID = taskid.NewDefaultImplementationID[[]*log.Log](SupportBundleTaskPrefix + "container-log-fieldset-reader")
+
+// ContainerLogIngesterTaskID is the task ID to ingest the container logs to the history.
+var ContainerLogIngesterTaskID = taskid.NewDefaultImplementationID[[]*log.Log](SupportBundleTaskPrefix + "container-log-ingester")
+
+// ContainerLogGrouperTaskID is the task ID to group the container logs by the container.
+var ContainerLogGrouperTaskID = taskid.NewDefaultImplementationID[inspectiontaskbase.LogGroupMap](SupportBundleTaskPrefix + "container-log-grouper")
+
+// ContainerLogToTimelineMapperTaskID is the task ID of the feature task to map the container logs to the timelines of the containers.
+var ContainerLogToTimelineMapperTaskID = taskid.NewDefaultImplementationID[struct{}](SupportBundleTaskPrefix + "container-log-mapper")
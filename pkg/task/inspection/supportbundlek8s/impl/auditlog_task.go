@@ -0,0 +1,109 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package supportbundlek8s_impl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	"github.com/kyasbal/khi/pkg/core/inspection/progressutil"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	commonlogk8sauditv2_contract "github.com/kyasbal/khi/pkg/task/inspection/commonlogk8sauditv2/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+	supportbundlek8s_contract "github.com/kyasbal/khi/pkg/task/inspection/supportbundlek8s/contract"
+)
+
+// AuditLogReaderTask reads the kube-apiserver audit logs in the archive in place of the uploaded audit log files.
+// Each line holds the JSON object written by kube-apiserver, thus the filters and the parsers for OSS clusters process them.
+var AuditLogReaderTask = inspectiontaskbase.NewProgressReportableInspectionTask(
+	supportbundlek8s_contract.AuditLogReaderTaskID,
+	[]taskid.UntypedTaskReference{
+		supportbundlek8s_contract.BundleReaderTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, tp *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, error) {
+		if taskMode == inspectioncore_contract.TaskModeDryRun {
+			return []*log.Log{}, nil
+		}
+		contents := coretask.GetTaskResult(ctx, supportbundlek8s_contract.BundleReaderTaskID.Ref())
+
+		var logs []*log.Log
+		err := progressutil.ReportProgressFromArraySync(tp, contents.AuditLogLines, func(i int, line string) error {
+			if strings.TrimSpace(line) == "" {
+				return nil
+			}
+
+			l, err := log.NewLogFromYAMLString(line)
+			if err != nil {
+				return fmt.Errorf("failed to read a log: %w", err)
+			}
+
+			err = l.SetFieldSetReader(&ossclusterk8s_contract.OSSK8sAuditLogCommonFieldSetReader{})
+			if err != nil {
+				return err
+			}
+
+			if !commonlogk8sauditv2_contract.IsParsableAuditLogStage(l) {
+				return nil
+			}
+
+			logs = append(logs, l)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		sortLogsByTimestamp(logs)
+		extendHeaderTimeRange(ctx, logs)
+
+		return logs, nil
+	},
+	coretask.WithSelectionPriority(1000),
+	inspectioncore_contract.InspectionTypeLabel(supportbundlek8s_contract.InspectionTypeID),
+)
+
+var SupportBundleAuditLogFieldExtractorTask = inspectiontaskbase.NewFieldSetReadTask(
+	supportbundlek8s_contract.SupportBundleAuditLogProviderTaskID,
+	ossclusterk8s_contract.NonEventAuditLogFilterTaskID.Ref(),
+	[]log.FieldSetReader{(&ossclusterk8s_contract.OSSK8sAuditLogFieldSetReader{})},
+	inspectioncore_contract.InspectionTypeLabel(supportbundlek8s_contract.InspectionTypeID),
+)
+
+var SupportBundleAuditLogParserTailTask = inspectiontaskbase.NewInspectionTask(
+	supportbundlek8s_contract.SupportBundleAuditLogParserTailTaskID,
+	commonlogk8sauditv2_contract.ParserTailTaskDependencies(),
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (struct{}, error) {
+		return struct{}{}, nil
+	},
+	inspectioncore_contract.FeatureTaskLabel("Kubernetes Audit Log(v3)", `Gather kube-apiserver audit logs in the support bundle and visualize resource modifications.`, enum.LogTypeAudit, 1001, true, supportbundlek8s_contract.InspectionTypeID), coretask.NewSubsequentTaskRefsTaskLabel(inspectioncore_contract.SerializerTaskID.Ref()),
+)
+
+var SupportBundleAuditPermissionDeniedParserTailTask = inspectiontaskbase.NewInspectionTask(
+	supportbundlek8s_contract.SupportBundleAuditPermissionDeniedParserTailTaskID,
+	[]taskid.UntypedTaskReference{
+		commonlogk8sauditv2_contract.PermissionDeniedLogToTimelineMapperTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType) (struct{}, error) {
+		return struct{}{}, nil
+	},
+	inspectioncore_contract.FeatureTaskLabel("Kubernetes Permission Denials", `Gather kube-apiserver audit logs of requests denied by the authorizer and show them on timelines grouped by the principal and the resource.`, enum.LogTypeAudit, 1002, false, supportbundlek8s_contract.InspectionTypeID), coretask.NewSubsequentTaskRefsTaskLabel(inspectioncore_contract.SerializerTaskID.Ref()),
+)
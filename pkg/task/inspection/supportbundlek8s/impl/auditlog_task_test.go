@@ -0,0 +1,98 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package supportbundlek8s_impl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	"github.com/kyasbal/khi/pkg/model/log"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	supportbundlek8s_contract "github.com/kyasbal/khi/pkg/task/inspection/supportbundlek8s/contract"
+	"github.com/kyasbal/khi/pkg/testutil/testupload"
+)
+
+// readFixtureBundle builds a gzip compressed tar archive containing the given files and reads it as the support bundle.
+func readFixtureBundle(t *testing.T, files []testupload.File) *supportbundlek8s_contract.BundleContents {
+	t.Helper()
+	contents, err := supportbundlek8s_contract.ReadBundle(strings.NewReader(testupload.TarGz(t, files)))
+	if err != nil {
+		t.Fatalf("failed to read the fixture bundle: %v", err)
+	}
+	return contents
+}
+
+func TestAuditLogReaderTask(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		files         []testupload.File
+		wantAuditIDs  []string
+		wantStartTime int64
+		wantEndTime   int64
+	}{
+		{
+			desc: "must-gather audit logs",
+			files: []testupload.File{
+				{Name: "must-gather/audit_logs/kube-apiserver/master-0-audit.log", Content: `{"auditID":"audit-2","stage":"ResponseComplete","stageTimestamp":"2025-01-01T00:00:02Z"}
+{"auditID":"audit-3","stage":"RequestReceived","stageTimestamp":"2025-01-01T00:00:03Z"}
+
+{"auditID":"audit-1","stage":"ResponseComplete","stageTimestamp":"2025-01-01T00:00:01Z"}
+`},
+				{Name: "must-gather/version", Content: "4.15"},
+			},
+			wantAuditIDs:  []string{"audit-1", "audit-2"},
+			wantStartTime: 1735689601,
+			wantEndTime:   1735689602,
+		},
+		{
+			desc: "bundle without audit logs",
+			files: []testupload.File{
+				{Name: "must-gather/version", Content: "4.15"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			contents := readFixtureBundle(t, tc.files)
+			ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+			logs, _, err := inspectiontest.RunInspectionTask(ctx, AuditLogReaderTask, inspectioncore_contract.TaskModeRun, map[string]any{},
+				tasktest.NewTaskDependencyValuePair(supportbundlek8s_contract.BundleReaderTaskID.Ref(), contents),
+			)
+			if err != nil {
+				t.Fatalf("AuditLogReaderTask returned an unexpected error: %v", err)
+			}
+
+			var gotAuditIDs []string
+			for _, l := range logs {
+				gotAuditIDs = append(gotAuditIDs, log.MustGetFieldSet(l, &log.CommonFieldSet{}).DisplayID)
+			}
+			if diff := cmp.Diff(tc.wantAuditIDs, gotAuditIDs); diff != "" {
+				t.Errorf("audit logs mismatch (-want +got):\n%s", diff)
+			}
+
+			metadataSet := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
+			header := typedmap.GetOrDefault(metadataSet, inspectionmetadata.HeaderMetadataKey, &inspectionmetadata.HeaderMetadata{})
+			if header.StartTimeUnixSeconds != tc.wantStartTime || header.EndTimeUnixSeconds != tc.wantEndTime {
+				t.Errorf("header time range = %d ~ %d, want %d ~ %d", header.StartTimeUnixSeconds, header.EndTimeUnixSeconds, tc.wantStartTime, tc.wantEndTime)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package supportbundlek8s_impl
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/kyasbal/khi/pkg/core/inspection/formtask"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/server/upload"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	supportbundlek8s_contract "github.com/kyasbal/khi/pkg/task/inspection/supportbundlek8s/contract"
+)
+
+// InputBundleFileTask is a form task to upload the support bundle archive.
+var InputBundleFileTask = formtask.NewFileFormTaskBuilder(supportbundlek8s_contract.InputBundleFileTaskID, 1000, "Support bundle archive", &upload.TarArchiveUploadFileVerifier{}).
+	WithDescription("Upload an OpenShift must-gather or a troubleshoot.sh support bundle archived in `.tar` or `.tar.gz`. Audit logs (`audit_logs/` or `*audit*.log`), resource dumps and container logs with timestamps in the archive are read, and other files are ignored.").
+	WithMarkdown().
+	Build()
+
+// BundleReaderTask walks the uploaded archive and reads the files in the known layouts.
+var BundleReaderTask = inspectiontaskbase.NewProgressReportableInspectionTask(
+	supportbundlek8s_contract.BundleReaderTaskID,
+	[]taskid.UntypedTaskReference{
+		supportbundlek8s_contract.InputBundleFileTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, tp *inspectionmetadata.TaskProgressMetadata) (*supportbundlek8s_contract.BundleContents, error) {
+		if taskMode == inspectioncore_contract.TaskModeDryRun {
+			return &supportbundlek8s_contract.BundleContents{}, nil
+		}
+		result := coretask.GetTaskResult(ctx, supportbundlek8s_contract.InputBundleFileTaskID.Ref())
+		tp.MarkIndeterminate()
+		reader, err := result.GetReader()
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+
+		contents, err := supportbundlek8s_contract.ReadBundle(reader)
+		if err != nil {
+			return nil, err
+		}
+		slog.InfoContext(ctx, fmt.Sprintf("read the support bundle: %d audit log lines, %d resources, %d container log files, %d files skipped", len(contents.AuditLogLines), len(contents.ResourceItems), len(contents.ContainerLogs), contents.SkippedFiles))
+		return contents, nil
+	},
+)
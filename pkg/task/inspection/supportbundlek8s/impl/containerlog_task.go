@@ -0,0 +1,143 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package supportbundlek8s_impl
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/history"
+	"github.com/kyasbal/khi/pkg/model/log"
+	googlecloudlogk8scontainer_contract "github.com/kyasbal/khi/pkg/task/inspection/googlecloudlogk8scontainer/contract"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	supportbundlek8s_contract "github.com/kyasbal/khi/pkg/task/inspection/supportbundlek8s/contract"
+)
+
+// ContainerLogReaderTask reads the lines of the container log files in the archive as logs.
+// Lines without the timestamp prefix written by `kubectl logs --timestamps` can't be placed on the timeline and are ignored.
+var ContainerLogReaderTask = inspectiontaskbase.NewProgressReportableInspectionTask(
+	supportbundlek8s_contract.ContainerLogReaderTaskID,
+	[]taskid.UntypedTaskReference{
+		supportbundlek8s_contract.BundleReaderTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, tp *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, error) {
+		if taskMode == inspectioncore_contract.TaskModeDryRun {
+			return []*log.Log{}, nil
+		}
+		contents := coretask.GetTaskResult(ctx, supportbundlek8s_contract.BundleReaderTaskID.Ref())
+		tp.MarkIndeterminate()
+
+		var logs []*log.Log
+		for _, file := range contents.ContainerLogs {
+			skippedLines := 0
+			for i, line := range file.Lines {
+				timestamp, message, ok := supportbundlek8s_contract.ParseTimestampedLine(line)
+				if !ok {
+					skippedLines++
+					continue
+				}
+				l, err := supportbundlek8s_contract.NewLogFromContainerLogLine(file, i, timestamp, message)
+				if err != nil {
+					return nil, err
+				}
+				err = l.SetFieldSetReader(&supportbundlek8s_contract.SupportBundleContainerLogCommonFieldSetReader{})
+				if err != nil {
+					return nil, err
+				}
+				l.LogType = enum.LogTypeContainer
+				logs = append(logs, l)
+			}
+			if skippedLines > 0 {
+				slog.WarnContext(ctx, fmt.Sprintf("%d lines without timestamps were ignored in %s", skippedLines, file.Path))
+			}
+		}
+
+		sortLogsByTimestamp(logs)
+		extendHeaderTimeRange(ctx, logs)
+
+		return logs, nil
+	},
+)
+
+var ContainerLogFieldSetReaderTask = inspectiontaskbase.NewFieldSetReadTask(
+	supportbundlek8s_contract.ContainerLogFieldSetReaderTaskID,
+	supportbundlek8s_contract.ContainerLogReaderTaskID.Ref(),
+	[]log.FieldSetReader{
+		&supportbundlek8s_contract.SupportBundleContainerLogFieldSetReader{},
+	},
+)
+
+var ContainerLogIngesterTask = inspectiontaskbase.NewLogIngesterTask(supportbundlek8s_contract.ContainerLogIngesterTaskID, supportbundlek8s_contract.ContainerLogReaderTaskID.Ref())
+
+var ContainerLogGrouperTask = inspectiontaskbase.NewLogGrouperTask(
+	supportbundlek8s_contract.ContainerLogGrouperTaskID,
+	supportbundlek8s_contract.ContainerLogFieldSetReaderTaskID.Ref(),
+	func(ctx context.Context, l *log.Log) string {
+		// container log parser is stateless and it doesn't require grouping to work, but grouping them by the container for better performance to process them in parallel.
+		containerFields, err := log.GetFieldSet(l, &googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet{})
+		if err != nil {
+			return "unknown"
+		}
+		return containerFields.ResourcePath().Path
+	},
+)
+
+var ContainerLogToTimelineMapperTask = inspectiontaskbase.NewLogToTimelineMapperTask[struct{}](supportbundlek8s_contract.ContainerLogToTimelineMapperTaskID, &containerLogToTimelineMapperTaskSetting{},
+	inspectioncore_contract.FeatureTaskLabel(`Kubernetes container logs`,
+		`Gather stdout/stderr logs of containers collected in the support bundle to visualize them on the timeline under an associated Pod. Only the log files written with timestamps are read.`,
+		enum.LogTypeContainer,
+		4000,
+		false,
+		supportbundlek8s_contract.InspectionTypeID),
+)
+
+type containerLogToTimelineMapperTaskSetting struct {
+}
+
+// Dependencies implements inspectiontaskbase.LogToTimelineMapper.
+func (c *containerLogToTimelineMapperTaskSetting) Dependencies() []taskid.UntypedTaskReference {
+	return []taskid.UntypedTaskReference{}
+}
+
+// GroupedLogTask implements inspectiontaskbase.LogToTimelineMapper.
+func (c *containerLogToTimelineMapperTaskSetting) GroupedLogTask() taskid.TaskReference[inspectiontaskbase.LogGroupMap] {
+	return supportbundlek8s_contract.ContainerLogGrouperTaskID.Ref()
+}
+
+// LogIngesterTask implements inspectiontaskbase.LogToTimelineMapper.
+func (c *containerLogToTimelineMapperTaskSetting) LogIngesterTask() taskid.TaskReference[[]*log.Log] {
+	return supportbundlek8s_contract.ContainerLogIngesterTaskID.Ref()
+}
+
+// ProcessLogByGroup implements inspectiontaskbase.LogToTimelineMapper.
+func (c *containerLogToTimelineMapperTaskSetting) ProcessLogByGroup(ctx context.Context, l *log.Log, cs *history.ChangeSet, builder *history.Builder, prevGroupData struct{}) (struct{}, error) {
+	containerFields, err := log.GetFieldSet(l, &googlecloudlogk8scontainer_contract.K8sContainerLogFieldSet{})
+	if err != nil {
+		return struct{}{}, nil
+	}
+
+	cs.AddEvent(containerFields.ResourcePath())
+	cs.SetLogSummary(containerFields.Message)
+	googlecloudlogk8scontainer_contract.AddVeleroLogEvents(cs, containerFields)
+	return struct{}{}, nil
+}
+
+var _ inspectiontaskbase.LogToTimelineMapper[struct{}] = (*containerLogToTimelineMapperTaskSetting)(nil)
@@ -0,0 +1,57 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package supportbundlek8s_impl
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/kyasbal/khi/pkg/common/khictx"
+	"github.com/kyasbal/khi/pkg/common/typedmap"
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	"github.com/kyasbal/khi/pkg/model/log"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+)
+
+// headerTimeRangeLock guards the time range in the header metadata updated from the readers of the archive running concurrently.
+var headerTimeRangeLock sync.Mutex
+
+// extendHeaderTimeRange extends the time range in the header metadata to include the given logs sorted by their timestamps.
+func extendHeaderTimeRange(ctx context.Context, sortedLogs []*log.Log) {
+	if len(sortedLogs) == 0 {
+		return
+	}
+	metadataSet := khictx.MustGetValue(ctx, inspectioncore_contract.InspectionRunMetadata)
+	header := typedmap.GetOrDefault(metadataSet, inspectionmetadata.HeaderMetadataKey, &inspectionmetadata.HeaderMetadata{})
+	startTime := log.MustGetFieldSet(sortedLogs[0], &log.CommonFieldSet{}).Timestamp.Unix()
+	endTime := log.MustGetFieldSet(sortedLogs[len(sortedLogs)-1], &log.CommonFieldSet{}).Timestamp.Unix()
+
+	headerTimeRangeLock.Lock()
+	defer headerTimeRangeLock.Unlock()
+	if header.StartTimeUnixSeconds == 0 || startTime < header.StartTimeUnixSeconds {
+		header.StartTimeUnixSeconds = startTime
+	}
+	if endTime > header.EndTimeUnixSeconds {
+		header.EndTimeUnixSeconds = endTime
+	}
+}
+
+// sortLogsByTimestamp sorts the logs by the timestamp in the common fieldset.
+func sortLogsByTimestamp(logs []*log.Log) {
+	slices.SortStableFunc(logs, func(a, b *log.Log) int {
+		return log.MustGetFieldSet(a, &log.CommonFieldSet{}).Timestamp.Compare(log.MustGetFieldSet(b, &log.CommonFieldSet{}).Timestamp)
+	})
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package supportbundlek8s_impl
+
+import (
+	"context"
+
+	inspectionmetadata "github.com/kyasbal/khi/pkg/core/inspection/metadata"
+	inspectiontaskbase "github.com/kyasbal/khi/pkg/core/inspection/taskbase"
+	coretask "github.com/kyasbal/khi/pkg/core/task"
+	"github.com/kyasbal/khi/pkg/core/task/taskid"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	ossclusterk8s_contract "github.com/kyasbal/khi/pkg/task/inspection/ossclusterk8s/contract"
+	supportbundlek8s_contract "github.com/kyasbal/khi/pkg/task/inspection/supportbundlek8s/contract"
+)
+
+// ResourceDumpReaderTask reads the resource dumps in the archive in place of the uploaded kubectl outputs.
+// The objects are in the same shape as the outputs of kubectl, thus the parser for kubectl outputs processes them.
+var ResourceDumpReaderTask = inspectiontaskbase.NewProgressReportableInspectionTask(
+	supportbundlek8s_contract.ResourceDumpReaderTaskID,
+	[]taskid.UntypedTaskReference{
+		supportbundlek8s_contract.BundleReaderTaskID.Ref(),
+	},
+	func(ctx context.Context, taskMode inspectioncore_contract.InspectionTaskModeType, tp *inspectionmetadata.TaskProgressMetadata) ([]*log.Log, error) {
+		if taskMode == inspectioncore_contract.TaskModeDryRun {
+			return []*log.Log{}, nil
+		}
+		contents := coretask.GetTaskResult(ctx, supportbundlek8s_contract.BundleReaderTaskID.Ref())
+		tp.MarkIndeterminate()
+
+		var logs []*log.Log
+		for _, item := range contents.ResourceItems {
+			l, err := ossclusterk8s_contract.NewLogFromKubectlDumpItem(item)
+			if err != nil {
+				return nil, err
+			}
+			err = l.SetFieldSetReader(&ossclusterk8s_contract.OSSKubectlDumpCommonFieldSetReader{})
+			if err != nil {
+				return nil, err
+			}
+			if ossclusterk8s_contract.IsKubectlDumpEvent(l.NodeReader) {
+				l.LogType = enum.LogTypeEvent
+			}
+			logs = append(logs, l)
+		}
+
+		sortLogsByTimestamp(logs)
+		extendHeaderTimeRange(ctx, logs)
+
+		return logs, nil
+	},
+	coretask.WithSelectionPriority(1000),
+	inspectioncore_contract.InspectionTypeLabel(supportbundlek8s_contract.InspectionTypeID),
+)
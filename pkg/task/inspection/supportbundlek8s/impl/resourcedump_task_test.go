@@ -0,0 +1,110 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package supportbundlek8s_impl
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	inspectiontest "github.com/kyasbal/khi/pkg/core/inspection/test"
+	tasktest "github.com/kyasbal/khi/pkg/core/task/test"
+	"github.com/kyasbal/khi/pkg/model/enum"
+	"github.com/kyasbal/khi/pkg/model/log"
+	inspectioncore_contract "github.com/kyasbal/khi/pkg/task/inspection/inspectioncore/contract"
+	supportbundlek8s_contract "github.com/kyasbal/khi/pkg/task/inspection/supportbundlek8s/contract"
+	"github.com/kyasbal/khi/pkg/testutil/testupload"
+)
+
+func TestResourceDumpReaderTask(t *testing.T) {
+	type resourceLog struct {
+		DisplayID string
+		LogType   enum.LogType
+	}
+	testCases := []struct {
+		desc    string
+		files   []testupload.File
+		want    []resourceLog
+		wantErr bool
+	}{
+		{
+			desc: "troubleshoot.sh resources and events",
+			files: []testupload.File{
+				{Name: "support-bundle/cluster-resources/pods/default.json", Content: `[
+{"metadata":{"name":"nginx-1","namespace":"default","uid":"uid-nginx-1","creationTimestamp":"2025-01-01T00:00:02Z"}},
+{"metadata":{"name":"nginx-0","namespace":"default","uid":"uid-nginx-0","creationTimestamp":"2025-01-01T00:00:01Z"}}
+]`},
+				{Name: "support-bundle/cluster-resources/events/default.json", Content: `[
+{"metadata":{"name":"nginx-0.1","namespace":"default","uid":"uid-event"},"type":"Warning","reason":"BackOff","lastTimestamp":"2025-01-01T00:00:03Z"}
+]`},
+			},
+			want: []resourceLog{
+				{DisplayID: "uid-nginx-0", LogType: enum.LogTypeUnknown},
+				{DisplayID: "uid-nginx-1", LogType: enum.LogTypeUnknown},
+				{DisplayID: "uid-event", LogType: enum.LogTypeEvent},
+			},
+		},
+		{
+			desc: "must-gather resource lists",
+			files: []testupload.File{
+				{Name: "must-gather/namespaces/default/core/pods.yaml", Content: `apiVersion: v1
+kind: PodList
+items:
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: etcd-0
+    namespace: default
+    creationTimestamp: "2025-01-01T00:00:00Z"
+`},
+			},
+			want: []resourceLog{
+				{DisplayID: "etcd-0", LogType: enum.LogTypeUnknown},
+			},
+		},
+		{
+			desc: "resource without timestamp",
+			files: []testupload.File{
+				{Name: "support-bundle/cluster-resources/pods/default.json", Content: `[{"metadata":{"name":"nginx-0","namespace":"default"}}]`},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			contents := readFixtureBundle(t, tc.files)
+			ctx := inspectiontest.WithDefaultTestInspectionTaskContext(t.Context())
+			logs, _, err := inspectiontest.RunInspectionTask(ctx, ResourceDumpReaderTask, inspectioncore_contract.TaskModeRun, map[string]any{},
+				tasktest.NewTaskDependencyValuePair(supportbundlek8s_contract.BundleReaderTaskID.Ref(), contents),
+			)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("ResourceDumpReaderTask returned no error, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResourceDumpReaderTask returned an unexpected error: %v", err)
+			}
+
+			var got []resourceLog
+			for _, l := range logs {
+				got = append(got, resourceLog{DisplayID: log.MustGetFieldSet(l, &log.CommonFieldSet{}).DisplayID, LogType: l.LogType})
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("resource logs mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testupload
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyasbal/khi/pkg/server/upload"
+)
+
+// File is a file contained in a fixture archive.
+type File struct {
+	Name    string
+	Content string
+	ModTime time.Time
+}
+
+// UploadFiles writes each of the given contents to a local upload store and returns them as the result of a multi file form.
+func UploadFiles(t *testing.T, contents ...string) upload.UploadResultList {
+	t.Helper()
+	provider := upload.NewLocalUploadFileStoreProvider(t.TempDir())
+	results := upload.UploadResultList{}
+	for i, content := range contents {
+		token := &upload.DirectUploadToken{ID: fmt.Sprintf("file-%d", i)}
+		if err := provider.Write(token, strings.NewReader(content)); err != nil {
+			t.Fatalf("failed to write the fixture file: %v", err)
+		}
+		results = append(results, upload.UploadResult{Token: token, StoreProvider: provider, Status: upload.UploadStatusCompleted})
+	}
+	return results
+}
+
+// UploadFile writes the content to a local upload store and returns it as the result of a single file form.
+func UploadFile(t *testing.T, content string) upload.UploadResult {
+	t.Helper()
+	return UploadFiles(t, content)[0]
+}
+
+// TarGz returns a gzip compressed tar archive containing the given files.
+func TarGz(t *testing.T, files []File) string {
+	t.Helper()
+	var archive bytes.Buffer
+	gzipWriter := gzip.NewWriter(&archive)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, file := range files {
+		if err := tarWriter.WriteHeader(&tar.Header{Name: file.Name, Mode: 0644, Size: int64(len(file.Content)), ModTime: file.ModTime, Typeflag: tar.TypeReg}); err != nil {
+			t.Fatalf("failed to write the header of %s: %v", file.Name, err)
+		}
+		if _, err := tarWriter.Write([]byte(file.Content)); err != nil {
+			t.Fatalf("failed to write %s: %v", file.Name, err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatalf("failed to close the tar archive: %v", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatalf("failed to close the gzip stream: %v", err)
+	}
+	return archive.String()
+}
+
+// Zip returns a zip archive containing the given files.
+func Zip(t *testing.T, files []File) string {
+	t.Helper()
+	var archive bytes.Buffer
+	zipWriter := zip.NewWriter(&archive)
+	for _, file := range files {
+		writer, err := zipWriter.CreateHeader(&zip.FileHeader{Name: file.Name, Modified: file.ModTime, Method: zip.Deflate})
+		if err != nil {
+			t.Fatalf("failed to create %s in the zip archive: %v", file.Name, err)
+		}
+		if _, err := writer.Write([]byte(file.Content)); err != nil {
+			t.Fatalf("failed to write %s: %v", file.Name, err)
+		}
+	}
+	if err := zipWriter.Close(); err != nil {
+		t.Fatalf("failed to close the zip archive: %v", err)
+	}
+	return archive.String()
+}
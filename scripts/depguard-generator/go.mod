@@ -2,7 +2,6 @@ module github.com/kyasbal/khi/scripts/depguard-generator
 
 go 1.25.5
 
-require (
-	github.com/google/go-cmp v0.7.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
-)
+require gopkg.in/yaml.v3 v3.0.1
+
+require github.com/google/go-cmp v0.7.0 // indirect
@@ -171,6 +171,84 @@ export type InspectionDryRunRequest = InspectionArgument;
  */
 export type InspectionRunRequest = InspectionArgument;
 
+/**
+ * Format of the file exported from GET /api/v3/inspection/<inspection-id>/parameters .
+ */
+export type InspectionParametersFormat = 'yaml' | 'json';
+
+/**
+ * Response schema of POST /api/v3/inspection/<inspection-id>/parameters/import .
+ */
+export interface ImportInspectionParametersResponse {
+  /**
+   * Map of form field IDs and their values to set on the form.
+   */
+  values: InspectionArgument;
+}
+
+/**
+ * A named set of form parameter values saved for an inspection type.
+ */
+export interface InspectionPreset {
+  /**
+   * Unique name of the preset in the inspection type.
+   */
+  name: string;
+  /**
+   * ID of the inspection type the preset was saved for.
+   */
+  inspectionType: string;
+  /**
+   * Map of form field IDs and their values. Values of secret fields are never saved.
+   */
+  values: { [key: string]: unknown };
+  /**
+   * The time when the preset was saved last time in RFC3339 format.
+   */
+  updatedAt: string;
+}
+
+/**
+ * Response schema of GET /api/v3/inspection/types/<inspection-type>/presets .
+ */
+export interface GetInspectionPresetsResponse {
+  presets: InspectionPreset[];
+}
+
+/**
+ * Request schema of PUT /api/v3/inspection/<inspection-id>/presets/<preset-name> .
+ */
+export interface PutInspectionPresetRequest {
+  values: InspectionArgument;
+}
+
+/**
+ * Response schema of POST /api/v3/inspection/<inspection-id>/presets/<preset-name>/apply .
+ */
+export interface ApplyInspectionPresetResponse {
+  values: InspectionArgument;
+}
+
+/**
+ * A section of the inspection form with the IDs of its top level fields.
+ */
+export interface FormLayoutSection {
+  /**
+   * ID of the section. This is empty for the fields without any section.
+   */
+  id: string;
+
+  /**
+   * Title of the section. Fields are shown without a title when this is empty.
+   */
+  label: string;
+
+  /**
+   * IDs of the top level form fields in this section in the order to be shown.
+   */
+  fieldIds: string[];
+}
+
 /**
  * Set of metadata generated for a inspection.
  */
@@ -180,6 +258,11 @@ export type InspectionMetadataInDryrun = {
    */
   form: ParameterFormField[];
 
+  /**
+   * Sections of the top level form fields in the order to be shown.
+   */
+  formLayout: FormLayoutSection[];
+
   /**
    * List of queries to be run with this inspection.
    */
@@ -22,6 +22,10 @@ export enum ParameterInputType {
   Text = 'text',
   File = 'file',
   Set = 'set',
+  Select = 'select',
+  DateTime = 'datetime',
+  TimeRange = 'timerange',
+  Secret = 'secret',
 }
 
 /**
@@ -62,6 +66,14 @@ export interface ParameterFormFieldBase {
    * The hint message shown at the bottom of inputs.
    */
   hint: string;
+  /**
+   * True when the description and the hint are sanitized HTML rendered from Markdown on the server.
+   */
+  markdown?: boolean;
+  /**
+   * URL of the document describing the syntax or the usage of this parameter. Empty when the parameter has no document.
+   */
+  docUrl?: string;
 }
 
 /**
@@ -101,6 +113,42 @@ export enum ParameterFormValidationTiming {
   Blur = 'blur',
 }
 
+/**
+ * Machine readable reason why a text form field is readonly.
+ */
+export enum ParameterReadonlyReason {
+  /**
+   * The field is readonly but no specific reason is given.
+   */
+  Unspecified = 'unspecified',
+  /**
+   * The value is fixed by the server configuration like command line flags or environment variables.
+   */
+  ServerConfiguration = 'server-configuration',
+}
+
+/**
+ * A suggestion of text type parameter with the details shown in the autocomplete list.
+ */
+export interface TextParameterFormFieldSuggestion {
+  /**
+   * The value set to the field when the suggestion is selected.
+   */
+  value: string;
+  /**
+   * The human readable name of the suggestion. The value is shown when this is empty.
+   */
+  label: string;
+  /**
+   * The supplemental information of the suggestion like the location of the resource.
+   */
+  description: string;
+  /**
+   * The name of the group the suggestion is listed in. Empty when the suggestion is not grouped.
+   */
+  group: string;
+}
+
 /**
  * Text type parameter specific data.
  */
@@ -110,6 +158,14 @@ export interface TextParameterFormField extends ParameterFormFieldBase {
    * If this text form field is readonly or not.
    */
   readonly: boolean;
+  /**
+   * The reason why this text form field is readonly. Empty string when the field is not readonly.
+   */
+  readonlyReason: ParameterReadonlyReason | '';
+  /**
+   * The human readable name of the source fixing the value like the name of the flag. Empty when the source is unknown.
+   */
+  readonlySource: string;
   /**
    * The default value of this text form field.
    */
@@ -120,10 +176,43 @@ export interface TextParameterFormField extends ParameterFormFieldBase {
    */
   suggestions: string[];
 
+  /**
+   * The autocomplete list with the details of each suggestion.
+   * Null when the suggestions are given only with their values in `suggestions`.
+   */
+  suggestionItems: TextParameterFormFieldSuggestion[] | null;
+
+  /**
+   * True when the suggestions are still being fetched on the backend.
+   * The suggestions may contain stale values in that case.
+   */
+  suggestionsLoading: boolean;
+
   /**
    * Type of the validation timing of this field.
    */
   validationTiming: ParameterFormValidationTiming;
+
+  /**
+   * The regular expression the value must fully match. Empty when the value has no pattern constraint.
+   * The server validates the value with the same pattern.
+   */
+  pattern: string;
+
+  /**
+   * The error message shown when the value doesn't match `pattern`.
+   */
+  patternErrorMessage: string;
+
+  /**
+   * The maximum number of characters of the value. 0 means no limit.
+   */
+  maxLength: number;
+
+  /**
+   * The example input shown while the value is empty. This is never used as the value.
+   */
+  placeholder: string;
 }
 
 /**
@@ -155,8 +244,59 @@ export interface FileParameterFormField extends ParameterFormFieldBase {
 
   /**
    * The status of file reported from the server side.
+   * When the field accepts multiple files, this is the least progressed status among the files.
    */
   status: UploadStatus;
+
+  /**
+   * If this field accepts multiple files or not. Files are uploaded with the tokens in `files` instead of `token` when this is true.
+   */
+  multiple: boolean;
+
+  /**
+   * The list of files in the order given by the user. This is always empty when `multiple` is false.
+   */
+  files: FileParameterFormFieldFile[];
+}
+
+/**
+ * The status of a file given to a file type parameter accepting multiple files.
+ */
+export interface FileParameterFormFieldFile {
+  /**
+   * The unique token to be used for uploading this file.
+   */
+  token: UploadToken;
+
+  /**
+   * The status of the file reported from the server side.
+   */
+  status: UploadStatus;
+
+  /**
+   * The type of the hint message of this file.
+   */
+  hintType: ParameterHintType;
+
+  /**
+   * The hint message of this file.
+   */
+  hint: string;
+}
+
+/**
+ * The value set to a file type parameter accepting multiple files to request upload tokens for the files.
+ */
+export interface MultipleFileParameterValue {
+  /**
+   * The number of files the user selected.
+   */
+  fileCount: number;
+
+  /**
+   * The time when the value was set. This is used to request refreshing the form status from the backend.
+   */
+  refreshedAt: string;
 }
 /**
  * An option item of set type parameter.
@@ -203,8 +343,143 @@ export interface SetParameterFormField extends ParameterFormFieldBase {
   allowCustomValue: boolean;
 }
 
+/**
+ * An option item of select type parameter.
+ */
+export interface SelectParameterFormFieldOptionItem {
+  /**
+   * A unique value sent to the backend when the option is selected.
+   */
+  id: string;
+  /**
+   * The label of the option shown in the drop down.
+   */
+  label: string;
+  /**
+   * The description of the option.
+   */
+  description: string;
+}
+
+/**
+ * Select type parameter specific data.
+ */
+export interface SelectParameterFormField extends ParameterFormFieldBase {
+  type: ParameterInputType.Select;
+  /**
+   * List of available options.
+   */
+  options: SelectParameterFormFieldOptionItem[];
+
+  /**
+   * The id of the option selected by default.
+   */
+  default: string;
+}
+
+/**
+ * DateTime type parameter specific data.
+ */
+export interface DateTimeParameterFormField extends ParameterFormFieldBase {
+  type: ParameterInputType.DateTime;
+  /**
+   * The default value in RFC3339 format in the timezone shifted with `timezoneShiftHours`.
+   */
+  default: string;
+
+  /**
+   * The current value normalized in UTC in RFC3339 format.
+   */
+  valueUTC: string;
+
+  /**
+   * The offset from UTC in hours used to show the time on the picker.
+   */
+  timezoneShiftHours: number;
+
+  /**
+   * List of previously used values in RFC3339 format.
+   */
+  suggestions: string[];
+}
+
+/**
+ * The value of time range type parameter.
+ */
+export interface TimeRangeParameterValue {
+  /**
+   * The end of the time range in RFC3339 format.
+   */
+  endTime: string;
+  /**
+   * The length of the time range in the format of Go time.Duration. (e.g `1h30m`)
+   */
+  duration: string;
+}
+
+/**
+ * A time range selectable with a click on time range type parameter.
+ */
+export interface TimeRangeParameterFormFieldPreset {
+  /**
+   * The short human readable name of the preset. (e.g `Last 1h`)
+   */
+  label: string;
+  /**
+   * The value set to the field when the preset is selected.
+   */
+  value: TimeRangeParameterValue;
+}
+
+/**
+ * Time range type parameter specific data.
+ */
+export interface TimeRangeParameterFormField extends ParameterFormFieldBase {
+  type: ParameterInputType.TimeRange;
+  /**
+   * The default value of this field. The end time is in the timezone shifted with timezoneShiftHours.
+   */
+  default: TimeRangeParameterValue;
+
+  /**
+   * The start of the current time range normalized in UTC in RFC3339 format.
+   */
+  startTimeUTC: string;
+
+  /**
+   * The end of the current time range normalized in UTC in RFC3339 format.
+   */
+  endTimeUTC: string;
+
+  /**
+   * The offset from UTC in hours used to show the end time on the picker.
+   */
+  timezoneShiftHours: number;
+
+  /**
+   * List of time ranges selectable with a click.
+   */
+  presets: TimeRangeParameterFormFieldPreset[];
+}
+
+/**
+ * Secret type parameter specific data.
+ * The value given to this field is never sent back from the backend.
+ */
+export interface SecretParameterFormField extends ParameterFormFieldBase {
+  type: ParameterInputType.Secret;
+  /**
+   * If a non empty value was given to this field or not.
+   */
+  valueGiven: boolean;
+}
+
 export type ParameterFormField =
   | GroupParameterFormField
   | TextParameterFormField
   | FileParameterFormField
-  | SetParameterFormField;
+  | SetParameterFormField
+  | SelectParameterFormField
+  | DateTimeParameterFormField
+  | TimeRangeParameterFormField
+  | SecretParameterFormField;
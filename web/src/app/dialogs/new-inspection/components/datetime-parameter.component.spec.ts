@@ -0,0 +1,48 @@
+/**
+ * Copyright 2025 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+import {
+  formatTimezoneOffset,
+  fromDateTimeLocalValue,
+  toDateTimeLocalValue,
+} from './datetime-parameter.component';
+
+describe('DateTimeParameterComponent utilities', () => {
+  it('formats timezone offsets', () => {
+    expect(formatTimezoneOffset(0)).toBe('Z');
+    expect(formatTimezoneOffset(9)).toBe('+09:00');
+    expect(formatTimezoneOffset(-5.5)).toBe('-05:30');
+  });
+
+  it('converts RFC3339 values to datetime-local values in the shifted timezone', () => {
+    expect(toDateTimeLocalValue('2025-01-01T00:00:00Z', 9)).toBe(
+      '2025-01-01T09:00:00',
+    );
+    expect(toDateTimeLocalValue('2025-01-01T09:00:00+09:00', -5.5)).toBe(
+      '2024-12-31T18:30:00',
+    );
+    expect(toDateTimeLocalValue('invalid', 0)).toBe('');
+  });
+
+  it('converts datetime-local values to RFC3339 values with the offset', () => {
+    expect(fromDateTimeLocalValue('2025-01-01T09:00', 9)).toBe(
+      '2025-01-01T09:00:00+09:00',
+    );
+    expect(fromDateTimeLocalValue('2025-01-01T09:00:30', 0)).toBe(
+      '2025-01-01T09:00:30Z',
+    );
+  });
+});
@@ -0,0 +1,126 @@
+/**
+ * Copyright 2025 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+import { CommonModule } from '@angular/common';
+import { Component, computed, inject, input, OnInit } from '@angular/core';
+import { MatFormFieldModule } from '@angular/material/form-field';
+import { MatInputModule } from '@angular/material/input';
+import { map, Observable } from 'rxjs';
+import { DateTimeParameterFormField } from 'src/app/common/schema/form-types';
+import { ParameterHeaderComponent } from './parameter-header.component';
+import { ParameterHintComponent } from './parameter-hint.component';
+import { PARAMETER_STORE } from './service/parameter-store';
+
+/**
+ * Converts the RFC3339 time string to the value of `datetime-local` input in the timezone shifted with the given hours.
+ * Returns an empty string when the given value is not a valid time.
+ */
+export function toDateTimeLocalValue(
+  value: string,
+  timezoneShiftHours: number,
+): string {
+  const time = Date.parse(value);
+  if (isNaN(time)) {
+    return '';
+  }
+  const shifted = new Date(time + timezoneShiftHours * 60 * 60 * 1000);
+  return shifted.toISOString().substring(0, 19);
+}
+
+/**
+ * Converts the value of `datetime-local` input to the RFC3339 time string with the offset of the given hours.
+ */
+export function fromDateTimeLocalValue(
+  value: string,
+  timezoneShiftHours: number,
+): string {
+  const withSeconds = value.length === 16 ? `${value}:00` : value;
+  return `${withSeconds}${formatTimezoneOffset(timezoneShiftHours)}`;
+}
+
+/**
+ * Formats the offset hours in the format used in RFC3339. (e.g `+09:00`, `-05:30` or `Z`)
+ */
+export function formatTimezoneOffset(timezoneShiftHours: number): string {
+  if (timezoneShiftHours === 0) {
+    return 'Z';
+  }
+  const sign = timezoneShiftHours > 0 ? '+' : '-';
+  const totalMinutes = Math.round(Math.abs(timezoneShiftHours) * 60);
+  const hours = `${Math.floor(totalMinutes / 60)}`.padStart(2, '0');
+  const minutes = `${totalMinutes % 60}`.padStart(2, '0');
+  return `${sign}${hours}:${minutes}`;
+}
+
+/**
+ * A form field for datetime type parameter in the new-inspection dialog.
+ */
+@Component({
+  selector: 'khi-new-inspection-datetime-parameter',
+  templateUrl: './datetime-parameter.component.html',
+  styleUrls: ['./datetime-parameter.component.scss'],
+  imports: [
+    CommonModule,
+    ParameterHeaderComponent,
+    MatFormFieldModule,
+    MatInputModule,
+    ParameterHintComponent,
+  ],
+})
+export class DateTimeParameterComponent implements OnInit {
+  /**
+   * The spec of this datetime type parameter.
+   */
+  readonly parameter = input.required<DateTimeParameterFormField>();
+
+  private readonly store = inject(PARAMETER_STORE);
+
+  /**
+   * The offset of the timezone shown next to the picker.
+   */
+  readonly timezoneOffset = computed(() =>
+    formatTimezoneOffset(this.parameter().timezoneShiftHours),
+  );
+
+  /**
+   * Observable that emits the current value in the format of `datetime-local` input.
+   */
+  value!: Observable<string>;
+
+  ngOnInit(): void {
+    this.value = this.store
+      .watch<string>(this.parameter().id)
+      .pipe(
+        map((value) =>
+          toDateTimeLocalValue(value, this.parameter().timezoneShiftHours),
+        ),
+      );
+  }
+
+  /**
+   * Handles change events from the picker.
+   */
+  onChange(ev: Event): void {
+    const value = (ev.target as HTMLInputElement).value;
+    if (value === '') {
+      return;
+    }
+    this.store.set(
+      this.parameter().id,
+      fromDateTimeLocalValue(value, this.parameter().timezoneShiftHours),
+    );
+  }
+}
@@ -16,7 +16,10 @@
 
 import { provideZoneChangeDetection, NgModule } from '@angular/core';
 import { ComponentFixture, TestBed } from '@angular/core/testing';
-import { FileParameterComponent } from './file-parameter.component';
+import {
+  FileParameterComponent,
+  fileStatusLabel,
+} from './file-parameter.component';
 import { BrowserAnimationsModule } from '@angular/platform-browser/animations';
 import { MatIconModule, MatIconRegistry } from '@angular/material/icon';
 import { FILE_UPLOADER, MockFileUploader } from './service/file-uploader';
@@ -55,6 +58,27 @@ describe('FileParameterComponent', () => {
     id: 'test-id',
     token: fakeUploadToken,
     status: UploadStatus.Waiting,
+    multiple: false,
+    files: [],
+  } as FileParameterFormField;
+
+  const multipleFileParameterForm = {
+    ...defaultFileParameterForm,
+    multiple: true,
+    files: [
+      {
+        token: { id: 'foo-0' },
+        status: UploadStatus.Done,
+        hintType: ParameterHintType.None,
+        hint: '',
+      },
+      {
+        token: { id: 'foo-1' },
+        status: UploadStatus.Waiting,
+        hintType: ParameterHintType.Error,
+        hint: 'invalid file format',
+      },
+    ],
   } as FileParameterFormField;
 
   let fixture: ComponentFixture<FileParameterComponent>;
@@ -192,4 +216,66 @@ describe('FileParameterComponent', () => {
 
     expect(uploadButton.attributes['disabled']).toBeTruthy();
   });
+
+  it('uploads each selected file with the token issued for its index', async () => {
+    const uploadSpy = spyOn(mockFileUploader, 'upload').and.callThrough();
+    fixture.componentRef.setInput('parameter', {
+      ...multipleFileParameterForm,
+      files: [],
+    });
+    fixture.detectChanges();
+    fixture.componentInstance.processReceivedFileInfo([
+      new File([], 'shard-0.jsonl'),
+      new File([], 'shard-1.jsonl'),
+    ]);
+    fixture.componentInstance.onClickUploadButton();
+    fixture.detectChanges();
+    await fixture.whenStable();
+
+    expect(uploadSpy).not.toHaveBeenCalled();
+
+    fixture.componentRef.setInput('parameter', multipleFileParameterForm);
+    fixture.detectChanges();
+    await fixture.whenStable();
+
+    expect(uploadSpy.calls.allArgs().map((args) => args[0])).toEqual([
+      { id: 'foo-0' },
+      { id: 'foo-1' },
+    ]);
+    expect(fixture.componentInstance.isSelectedFileUploaded()).toBeTrue();
+  });
+
+  it('shows the status of each file when multiple files are accepted', () => {
+    fixture.componentRef.setInput('parameter', multipleFileParameterForm);
+    fixture.componentInstance.uploadedFileNames.set(['shard-0.jsonl']);
+    fixture.detectChanges();
+
+    const names = fixture.debugElement
+      .queryAll(By.css('.file-list-item-name'))
+      .map((element) => element.nativeElement.textContent.trim());
+    expect(names).toEqual(['shard-0.jsonl', 'File 2']);
+    const statuses = fixture.debugElement
+      .queryAll(By.css('.file-list-item-status'))
+      .map((element) => element.nativeElement.textContent.trim());
+    expect(statuses).toEqual(['Uploaded', 'invalid file format']);
+  });
+});
+
+describe('fileStatusLabel', () => {
+  it('should return the hint unless the file has no hint', () => {
+    const file = {
+      token: { id: 'foo' },
+      status: UploadStatus.Done,
+      hintType: ParameterHintType.None,
+      hint: '',
+    };
+    expect(fileStatusLabel(file)).toBe('Uploaded');
+    expect(
+      fileStatusLabel({
+        ...file,
+        hintType: ParameterHintType.Error,
+        hint: 'invalid file format',
+      }),
+    ).toBe('invalid file format');
+  });
 });
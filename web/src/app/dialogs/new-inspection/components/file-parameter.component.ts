@@ -24,6 +24,7 @@ import {
   signal,
   ViewChild,
 } from '@angular/core';
+import { toObservable } from '@angular/core/rxjs-interop';
 import { ReactiveFormsModule } from '@angular/forms';
 import { MatButtonModule } from '@angular/material/button';
 import { MatFormFieldModule } from '@angular/material/form-field';
@@ -33,13 +34,35 @@ import { FILE_UPLOADER } from './service/file-uploader';
 import { MatProgressSpinnerModule } from '@angular/material/progress-spinner';
 import {
   FileParameterFormField,
+  FileParameterFormFieldFile,
+  MultipleFileParameterValue,
+  ParameterHintType,
   UploadStatus,
 } from 'src/app/common/schema/form-types';
 import { ParameterHeaderComponent } from './parameter-header.component';
 import { ParameterHintComponent } from './parameter-hint.component';
 import { PARAMETER_STORE } from './service/parameter-store';
 import { MatTooltipModule } from '@angular/material/tooltip';
-import { interval, Subject, takeUntil, takeWhile } from 'rxjs';
+import {
+  concat,
+  first,
+  interval,
+  map,
+  Subject,
+  switchMap,
+  takeUntil,
+  takeWhile,
+} from 'rxjs';
+
+/**
+ * Returns the label shown for a file given to a file type parameter accepting multiple files.
+ */
+export function fileStatusLabel(file: FileParameterFormFieldFile): string {
+  if (file.hintType === ParameterHintType.None) {
+    return 'Uploaded';
+  }
+  return file.hint;
+}
 
 @Component({
   selector: 'khi-new-inspection-file-parameter',
@@ -66,6 +89,10 @@ export class FileParameterComponent implements OnDestroy {
   static readonly FORM_STATUS_POLLING_INTERVAL_MS = 500;
 
   readonly UploadStatus = UploadStatus;
+
+  readonly ParameterHintType = ParameterHintType;
+
+  readonly fileStatusLabel = fileStatusLabel;
   /**
    * The setting of this file type form field.
    */
@@ -102,6 +129,23 @@ export class FileParameterComponent implements OnDestroy {
 
   selectedFile: File | null = null;
 
+  /**
+   * The files selected on the form when this field accepts multiple files.
+   */
+  selectedFiles: File[] = [];
+
+  /**
+   * The names of files uploaded on this field in the order of the files. Used only when this field accepts multiple files.
+   */
+  uploadedFileNames = signal<string[]>([]);
+
+  /**
+   * The number of files requested to the backend to issue upload tokens.
+   */
+  private requestedFileCount = 0;
+
+  private readonly parameter$ = toObservable(this.parameter);
+
   private formStoreRefreshCancel = new Subject();
 
   private snackBar = inject(MatSnackBar);
@@ -162,6 +206,10 @@ export class FileParameterComponent implements OnDestroy {
    * Eventhandler for the upload button.
    */
   onClickUploadButton() {
+    if (this.parameter().multiple) {
+      this.uploadMultipleFiles();
+      return;
+    }
     if (this.selectedFile === null) {
       return;
     }
@@ -185,6 +233,15 @@ export class FileParameterComponent implements OnDestroy {
   }
 
   processReceivedFileInfo(files: File[]) {
+    if (files.length === 0) {
+      return;
+    }
+    if (this.parameter().multiple) {
+      this.filename.set(files.map((file) => file.name).join(', '));
+      this.isSelectedFileUploaded.set(false);
+      this.selectedFiles = files;
+      return;
+    }
     if (files.length > 1) {
       this.snackBar.open('2 or more files are specified at once.');
     }
@@ -194,12 +251,67 @@ export class FileParameterComponent implements OnDestroy {
     this.selectedFile = file;
   }
 
+  /**
+   * Uploads the selected files in their order.
+   * The number of files is set to the parameter store first, then each file is uploaded with the token issued for the file index.
+   */
+  private uploadMultipleFiles() {
+    const files = this.selectedFiles;
+    if (files.length === 0) {
+      return;
+    }
+    this.isSelectedFileUploading.set(true);
+    this.uploadRatio.set(0);
+    this.uploadedFileNames.set(files.map((file) => file.name));
+    this.requestedFileCount = files.length;
+    this.requestStoreRefresh();
+    this.parameter$
+      .pipe(
+        first((parameter) => parameter.files.length === files.length),
+        switchMap((parameter) =>
+          concat(
+            ...files.map((file, index) =>
+              this.uploader
+                .upload(parameter.files[index].token, file)
+                .pipe(map((status) => ({ index, status }))),
+            ),
+          ),
+        ),
+      )
+      .subscribe(({ index, status }) => {
+        if (status.completeRatioUnknown) {
+          this.uploadRatio.set(undefined);
+        } else {
+          this.uploadRatio.set((index + status.completeRatio) / files.length);
+        }
+        if (status.done) {
+          this.requestStoreRefresh();
+          if (index === files.length - 1) {
+            this.isSelectedFileUploading.set(false);
+            this.isSelectedFileUploaded.set(true);
+            this.monitorRefreshingFormStoreWhileVerification();
+          }
+        }
+      });
+  }
+
   /**
    * Request refreshing the store status forcibly.
    * File form doesn't store meaningful parameter into the parameter store because it uploads file to the destination specified from the backend.
    * Set a timestamp instead of the parameter on the store when file form needs to get the latest form status from backend side.
    */
   private requestStoreRefresh() {
+    if (this.parameter().multiple) {
+      const value: MultipleFileParameterValue = {
+        fileCount: this.requestedFileCount,
+        refreshedAt: new Date().toISOString(),
+      };
+      this.store.setDefaultValues({
+        [this.parameter().id]: { ...value, fileCount: 0 },
+      });
+      this.store.set(this.parameter().id, value);
+      return;
+    }
     this.store.setDefaultValues({
       [this.parameter().id]: '',
     });
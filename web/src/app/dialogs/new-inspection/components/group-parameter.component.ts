@@ -22,6 +22,10 @@ import {
 import { TextParameterComponent } from './text-parameter.component';
 import { FileParameterComponent } from './file-parameter.component';
 import { SetParameterComponent } from './set-parameter.component';
+import { SelectParameterComponent } from './select-parameter.component';
+import { DateTimeParameterComponent } from './datetime-parameter.component';
+import { TimeRangeParameterComponent } from './timerange-parameter.component';
+import { SecretParameterComponent } from './secret-parameter.component';
 import { ParameterHeaderComponent } from './parameter-header.component';
 import { ParameterHintComponent } from './parameter-hint.component';
 import { CommonModule } from '@angular/common';
@@ -49,6 +53,10 @@ import {
     TextParameterComponent,
     FileParameterComponent,
     SetParameterComponent,
+    SelectParameterComponent,
+    DateTimeParameterComponent,
+    TimeRangeParameterComponent,
+    SecretParameterComponent,
     ParameterHeaderComponent,
     ParameterHintComponent,
   ],
@@ -99,4 +99,26 @@ describe('ParameterHeaderComponent', () => {
     expect(matIcon.length).toBe(1);
     expect(await matIcon[0].getName()).toBe('error');
   });
+
+  it('should show the link to the document when docUrl is given', async () => {
+    fixture.componentRef.setInput('parameter', {
+      label: 'test-label',
+      description: '',
+      hintType: ParameterHintType.None,
+      docUrl: 'https://example.com/doc#kind',
+    });
+    fixture.detectChanges();
+
+    const link = fixture.debugElement.query(By.css('.doc-link'));
+    expect(link.nativeElement.getAttribute('href')).toBe(
+      'https://example.com/doc#kind',
+    );
+    expect(link.nativeElement.getAttribute('target')).toBe('_blank');
+  });
+
+  it('should not show the link to the document without docUrl', () => {
+    fixture.detectChanges();
+
+    expect(fixture.debugElement.query(By.css('.doc-link'))).toBeNull();
+  });
 });
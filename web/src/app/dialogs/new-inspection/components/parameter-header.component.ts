@@ -39,6 +39,8 @@ export class ParameterHeaderComponent {
 
   readonly dirtyIconTooltip =
     "This field modified once and won't follow the default value when KHI updated the default dynamatically.";
+  readonly docLinkTooltip = 'Open the document of this field';
+
   /**
    * The spec of this text type parameter.
    */
@@ -112,4 +112,18 @@ describe('ParameterHintComponent', () => {
     expect(matIcon.length).toBe(1);
     expect(await matIcon[0].getName()).toBe('info');
   });
+
+  it('shows the hint rendered from Markdown as HTML without breaklines', () => {
+    fixture.componentRef.setInput('parameter', {
+      hintType: ParameterHintType.Info,
+      hint: '<ul><li>foo</li><li>bar</li></ul>\n',
+      markdown: true,
+    });
+    fixture.detectChanges();
+
+    const hint = fixture.debugElement.query(By.css('.hint.markdown'));
+    expect(hint.nativeElement.tagName).toBe('DIV');
+    expect(hint.nativeElement.querySelectorAll('li').length).toBe(2);
+    expect(hint.nativeElement.innerHTML).not.toContain('<br>');
+  });
 });
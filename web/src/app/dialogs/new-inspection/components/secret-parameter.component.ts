@@ -0,0 +1,66 @@
+/**
+ * Copyright 2025 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+import { CommonModule } from '@angular/common';
+import { Component, inject, input, OnInit } from '@angular/core';
+import { MatFormFieldModule } from '@angular/material/form-field';
+import { MatInputModule } from '@angular/material/input';
+import { Observable } from 'rxjs';
+import { SecretParameterFormField } from 'src/app/common/schema/form-types';
+import { ParameterHeaderComponent } from './parameter-header.component';
+import { ParameterHintComponent } from './parameter-hint.component';
+import { PARAMETER_STORE } from './service/parameter-store';
+
+/**
+ * A form field for secret type parameter in the new-inspection dialog.
+ * The value is masked on the input and it's only kept in the parameter store of the dialog.
+ */
+@Component({
+  selector: 'khi-new-inspection-secret-parameter',
+  templateUrl: './secret-parameter.component.html',
+  styleUrls: ['./secret-parameter.component.scss'],
+  imports: [
+    CommonModule,
+    ParameterHeaderComponent,
+    MatFormFieldModule,
+    MatInputModule,
+    ParameterHintComponent,
+  ],
+})
+export class SecretParameterComponent implements OnInit {
+  /**
+   * The spec of this secret type parameter.
+   */
+  readonly parameter = input.required<SecretParameterFormField>();
+
+  private readonly store = inject(PARAMETER_STORE);
+
+  /**
+   * Observable that emits the current value typed on the input.
+   */
+  value!: Observable<string>;
+
+  ngOnInit(): void {
+    this.value = this.store.watch<string>(this.parameter().id);
+  }
+
+  /**
+   * Handles input events from the password field.
+   */
+  onInput(ev: Event): void {
+    this.store.set(this.parameter().id, (ev.target as HTMLInputElement).value);
+  }
+}
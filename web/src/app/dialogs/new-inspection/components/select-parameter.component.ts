@@ -0,0 +1,65 @@
+/**
+ * Copyright 2025 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+import { CommonModule } from '@angular/common';
+import { Component, inject, input, OnInit } from '@angular/core';
+import { MatFormFieldModule } from '@angular/material/form-field';
+import { MatSelectChange, MatSelectModule } from '@angular/material/select';
+import { Observable } from 'rxjs';
+import { SelectParameterFormField } from 'src/app/common/schema/form-types';
+import { ParameterHeaderComponent } from './parameter-header.component';
+import { ParameterHintComponent } from './parameter-hint.component';
+import { PARAMETER_STORE } from './service/parameter-store';
+
+/**
+ * A form field for select type parameter in the new-inspection dialog.
+ */
+@Component({
+  selector: 'khi-new-inspection-select-parameter',
+  templateUrl: './select-parameter.component.html',
+  styleUrls: ['./select-parameter.component.scss'],
+  imports: [
+    CommonModule,
+    ParameterHeaderComponent,
+    MatFormFieldModule,
+    MatSelectModule,
+    ParameterHintComponent,
+  ],
+})
+export class SelectParameterComponent implements OnInit {
+  /**
+   * The spec of this select type parameter.
+   */
+  readonly parameter = input.required<SelectParameterFormField>();
+
+  private readonly store = inject(PARAMETER_STORE);
+
+  /**
+   * Observable that emits the id of the currently selected option.
+   */
+  value!: Observable<string>;
+
+  ngOnInit(): void {
+    this.value = this.store.watch<string>(this.parameter().id);
+  }
+
+  /**
+   * Handles the selection change of the drop down.
+   */
+  onSelectionChange(ev: MatSelectChange): void {
+    this.store.set(this.parameter().id, ev.value);
+  }
+}
@@ -16,14 +16,21 @@
 
 import { provideZoneChangeDetection, NgModule } from '@angular/core';
 import { ComponentFixture, TestBed } from '@angular/core/testing';
-import { TextParameterComponent } from './text-parameter.component';
+import {
+  groupTextParameterSuggestions,
+  readonlyReasonMessage,
+  TextParameterComponent,
+  validateTextParameterConstraints,
+} from './text-parameter.component';
 import { BrowserAnimationsModule } from '@angular/platform-browser/animations';
 import { MatIconRegistry } from '@angular/material/icon';
 import {
   ParameterFormValidationTiming,
   ParameterHintType,
+  ParameterReadonlyReason,
   TextParameterFormField,
 } from 'src/app/common/schema/form-types';
+import { MatProgressSpinnerHarness } from '@angular/material/progress-spinner/testing';
 import { MatInputHarness } from '@angular/material/input/testing';
 import { HarnessLoader } from '@angular/cdk/testing';
 import { TestbedHarnessEnvironment } from '@angular/cdk/testing/testbed';
@@ -54,8 +61,16 @@ describe('TextParameterComponent', () => {
     hintType: ParameterHintType.Error,
     hint: 'parameter test validation failed',
     readonly: false,
+    readonlyReason: '',
+    readonlySource: '',
     suggestions: ['foo', 'bar', 'qux'],
+    suggestionItems: null,
+    suggestionsLoading: false,
     validationTiming: ParameterFormValidationTiming.Change,
+    pattern: '',
+    patternErrorMessage: '',
+    maxLength: 0,
+    placeholder: '',
   } as TextParameterFormField;
 
   beforeAll(() => {
@@ -102,6 +117,18 @@ describe('TextParameterComponent', () => {
     expect(await matInput.getPlaceholder()).toBe('test-default-value');
   });
 
+  it('should show the placeholder instead of the default value when given', async () => {
+    fixture.componentRef.setInput('parameter', {
+      ...defaultParameter,
+      placeholder: 'e.g. foo',
+    });
+    fixture.detectChanges();
+
+    const matInput = await harnessLoader.getHarness(MatInputHarness);
+
+    expect(await matInput.getPlaceholder()).toBe('e.g. foo');
+  });
+
   it('should set the value to store when input received when validatingTiming=onchange', async () => {
     fixture.detectChanges();
 
@@ -135,6 +162,22 @@ describe('TextParameterComponent', () => {
     });
   });
 
+  it('should show the loading indicator only while suggestions are loading', async () => {
+    fixture.detectChanges();
+    expect(
+      await harnessLoader.getAllHarnesses(MatProgressSpinnerHarness),
+    ).toHaveSize(0);
+
+    fixture.componentRef.setInput('parameter', {
+      ...defaultParameter,
+      suggestionsLoading: true,
+    });
+    fixture.detectChanges();
+    expect(
+      await harnessLoader.getAllHarnesses(MatProgressSpinnerHarness),
+    ).toHaveSize(1);
+  });
+
   it('should make its input disabled when parameter.readonly = true', async () => {
     fixture.componentRef.setInput('parameter', {
       ...defaultParameter,
@@ -145,4 +188,143 @@ describe('TextParameterComponent', () => {
 
     expect(await matInput.isDisabled()).toBeTrue();
   });
+
+  it('should show the reason when the parameter is locked by server configuration', async () => {
+    fixture.componentRef.setInput('parameter', {
+      ...defaultParameter,
+      readonly: true,
+      readonlyReason: ParameterReadonlyReason.ServerConfiguration,
+      readonlySource: '--fixed-project-id',
+    });
+    fixture.detectChanges();
+
+    const reason = fixture.nativeElement.querySelector('.readonly-reason');
+    expect(reason.textContent).toContain(
+      'Locked by server configuration (--fixed-project-id)',
+    );
+  });
+
+  it('should show the client side validation error as soon as the value violates the constraints', async () => {
+    fixture.componentRef.setInput('parameter', {
+      ...defaultParameter,
+      pattern: '[a-z]+',
+      patternErrorMessage: 'must be lower case letters',
+    });
+    fixture.detectChanges();
+    const matInput = await harnessLoader.getHarness(MatInputHarness);
+
+    await matInput.setValue('Foo');
+    fixture.detectChanges();
+    const error = fixture.nativeElement.querySelector(
+      '.client-validation-error',
+    );
+    expect(error.textContent).toContain('must be lower case letters');
+
+    await matInput.setValue('foo');
+    fixture.detectChanges();
+    expect(
+      fixture.nativeElement.querySelector('.client-validation-error'),
+    ).toBeNull();
+  });
+});
+
+describe('validateTextParameterConstraints', () => {
+  const parameter = {
+    id: 'test-parameter-id',
+    pattern: '',
+    patternErrorMessage: '',
+    maxLength: 0,
+    placeholder: '',
+  } as TextParameterFormField;
+
+  it('should return an empty string without constraints', () => {
+    expect(validateTextParameterConstraints(parameter, 'foo')).toBe('');
+  });
+
+  it('should require the value to fully match the pattern', () => {
+    const withPattern = {
+      ...parameter,
+      pattern: '[a-z]+',
+      patternErrorMessage: 'must be lower case letters',
+    };
+    expect(validateTextParameterConstraints(withPattern, 'foo')).toBe('');
+    expect(validateTextParameterConstraints(withPattern, 'foo1')).toBe(
+      'must be lower case letters',
+    );
+  });
+
+  it('should count characters in code points for the max length', () => {
+    const withMaxLength = { ...parameter, maxLength: 2 };
+    expect(validateTextParameterConstraints(withMaxLength, '😀😀')).toBe('');
+    expect(validateTextParameterConstraints(withMaxLength, '😀😀😀')).toBe(
+      'must be 2 characters or less',
+    );
+  });
+});
+
+describe('readonlyReasonMessage', () => {
+  const parameter = {
+    id: 'test-parameter-id',
+    readonly: true,
+    readonlyReason: ParameterReadonlyReason.ServerConfiguration,
+    readonlySource: '',
+  } as TextParameterFormField;
+
+  it('should return an empty string for editable parameters', () => {
+    expect(readonlyReasonMessage({ ...parameter, readonly: false })).toBe('');
+  });
+
+  it('should return an empty string without a specific reason', () => {
+    expect(
+      readonlyReasonMessage({
+        ...parameter,
+        readonlyReason: ParameterReadonlyReason.Unspecified,
+      }),
+    ).toBe('');
+  });
+
+  it('should include the source when it is given', () => {
+    expect(readonlyReasonMessage(parameter)).toBe(
+      'Locked by server configuration',
+    );
+    expect(
+      readonlyReasonMessage({ ...parameter, readonlySource: '--foo' }),
+    ).toBe('Locked by server configuration (--foo)');
+  });
+});
+
+describe('groupTextParameterSuggestions', () => {
+  const parameter = {
+    id: 'test-parameter-id',
+    suggestions: ['foo', 'bar'],
+    suggestionItems: null,
+  } as TextParameterFormField;
+
+  it('should return the plain suggestions in a group without label', () => {
+    expect(groupTextParameterSuggestions(parameter)).toEqual([
+      {
+        group: '',
+        items: [
+          { value: 'foo', label: '', description: '', group: '' },
+          { value: 'bar', label: '', description: '', group: '' },
+        ],
+      },
+    ]);
+  });
+
+  it('should group the suggestion items in the order of first appearance', () => {
+    const foo = { value: 'foo', label: 'Foo', description: '', group: 'a' };
+    const bar = { value: 'bar', label: '', description: 'bar', group: 'b' };
+    const baz = { value: 'baz', label: '', description: '', group: 'a' };
+
+    expect(
+      groupTextParameterSuggestions({
+        ...parameter,
+        suggestionItems: [foo, bar, baz],
+      }),
+    ).toEqual([
+      { group: 'a', items: [foo, baz] },
+      { group: 'b', items: [bar] },
+    ]);
+  });
 });
@@ -15,7 +15,14 @@
  */
 
 import { CommonModule } from '@angular/common';
-import { Component, inject, input, OnInit } from '@angular/core';
+import {
+  Component,
+  computed,
+  inject,
+  input,
+  OnInit,
+  signal,
+} from '@angular/core';
 import { ParameterHeaderComponent } from './parameter-header.component';
 import { MatFormFieldModule } from '@angular/material/form-field';
 import { ReactiveFormsModule } from '@angular/forms';
@@ -24,12 +31,15 @@ import { ParameterHintComponent } from './parameter-hint.component';
 import {
   ParameterFormValidationTiming,
   ParameterHintType,
+  ParameterReadonlyReason,
   TextParameterFormField,
+  TextParameterFormFieldSuggestion,
 } from 'src/app/common/schema/form-types';
 import {
   MatAutocompleteModule,
   MatAutocompleteSelectedEvent,
 } from '@angular/material/autocomplete';
+import { MatProgressSpinnerModule } from '@angular/material/progress-spinner';
 import { PARAMETER_STORE } from './service/parameter-store';
 import {
   distinctUntilChanged,
@@ -40,6 +50,90 @@ import {
   takeUntil,
 } from 'rxjs';
 
+/**
+ * Returns the error message when the value violates the constraints of the text parameter, otherwise returns an empty string.
+ * The messages and the rules must be same as the validation done in the server side.
+ */
+export function validateTextParameterConstraints(
+  parameter: TextParameterFormField,
+  value: string,
+): string {
+  // The server counts characters in code points.
+  if (
+    parameter.maxLength > 0 &&
+    Array.from(value).length > parameter.maxLength
+  ) {
+    return `must be ${parameter.maxLength} characters or less`;
+  }
+  if (
+    parameter.pattern &&
+    !new RegExp(`^(?:${parameter.pattern})$`).test(value)
+  ) {
+    return parameter.patternErrorMessage;
+  }
+  return '';
+}
+
+/**
+ * Returns the message explaining why the text parameter is readonly, otherwise returns an empty string.
+ */
+export function readonlyReasonMessage(
+  parameter: TextParameterFormField,
+): string {
+  if (!parameter.readonly) {
+    return '';
+  }
+  switch (parameter.readonlyReason) {
+    case ParameterReadonlyReason.ServerConfiguration:
+      return parameter.readonlySource
+        ? `Locked by server configuration (${parameter.readonlySource})`
+        : 'Locked by server configuration';
+    default:
+      return '';
+  }
+}
+
+/**
+ * A group of suggestions shown in the autocomplete list of a text parameter.
+ */
+export interface TextParameterSuggestionGroup {
+  /**
+   * The label of the group. Empty for the suggestions not grouped.
+   */
+  group: string;
+  /**
+   * The suggestions in the group.
+   */
+  items: TextParameterFormFieldSuggestion[];
+}
+
+/**
+ * Returns the suggestions of the text parameter grouped in the order of first appearance of the groups.
+ * Suggestions given only with their values are returned in a single group without label.
+ */
+export function groupTextParameterSuggestions(
+  parameter: TextParameterFormField,
+): TextParameterSuggestionGroup[] {
+  const items: TextParameterFormFieldSuggestion[] =
+    parameter.suggestionItems ??
+    (parameter.suggestions ?? []).map((value) => ({
+      value,
+      label: '',
+      description: '',
+      group: '',
+    }));
+  const groups: TextParameterSuggestionGroup[] = [];
+  for (const item of items) {
+    let group = groups.find((g) => g.group === item.group);
+    if (group === undefined) {
+      group = { group: item.group, items: [] };
+      groups.push(group);
+    }
+    group.items.push(item);
+  }
+  return groups;
+}
+
 /**
  * A form field of parameter in the new-inspection dialog.
  */
@@ -55,6 +149,7 @@ import {
     ReactiveFormsModule,
     ParameterHintComponent,
     MatAutocompleteModule,
+    MatProgressSpinnerModule,
   ],
 })
 export class TextParameterComponent implements OnInit {
@@ -86,6 +181,36 @@ export class TextParameterComponent implements OnInit {
    */
   private readonly stagingInput = new ReplaySubject<string>(1);
 
+  /**
+   * The latest value typed by the user. null until the user types anything.
+   */
+  private readonly typedValue = signal<string | null>(null);
+
+  /**
+   * The error message of the constraints validated in the client side before the server validates the value.
+   */
+  readonly clientValidationError = computed(() => {
+    const value = this.typedValue();
+    if (value === null) {
+      return '';
+    }
+    return validateTextParameterConstraints(this.parameter(), value);
+  });
+
+  /**
+   * The message explaining why the field is readonly.
+   */
+  readonly readonlyMessage = computed(() =>
+    readonlyReasonMessage(this.parameter()),
+  );
+
+  /**
+   * The suggestions shown in the autocomplete list.
+   */
+  readonly suggestionGroups = computed(() =>
+    groupTextParameterSuggestions(this.parameter()),
+  );
+
   /**
    * Initializes the component.
    * Subscribes to the parameter store and staging input to update the `value` observable.
@@ -102,6 +227,7 @@ export class TextParameterComponent implements OnInit {
    * Updates the parameter store immediately if validationTiming is 'ParameterFormValidationTiming.Change', otherwise stages the input.
    */
   onInput(ev: Event) {
+    this.typedValue.set((ev.target as HTMLInputElement).value);
     if (
       this.parameter().validationTiming === ParameterFormValidationTiming.Change
     ) {
@@ -133,6 +259,7 @@ export class TextParameterComponent implements OnInit {
    * Handles the selection of an option from the autocomplete dropdown.
    */
   onOptionSelected(ev: MatAutocompleteSelectedEvent) {
+    this.typedValue.set(ev.option.value);
     this.store.set(this.parameter().id, ev.option.value);
   }
 }
@@ -0,0 +1,49 @@
+/**
+ * Copyright 2025 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+import { isTimeRangePresetSelected } from './timerange-parameter.component';
+
+describe('isTimeRangePresetSelected', () => {
+  const preset = {
+    label: 'Last 1h',
+    value: { endTime: '2025-01-01T09:00:00+09:00', duration: '1h' },
+  };
+
+  it('returns false without a value', () => {
+    expect(isTimeRangePresetSelected(preset, null)).toBeFalse();
+  });
+
+  it('compares the end times as time instants', () => {
+    expect(
+      isTimeRangePresetSelected(preset, {
+        endTime: '2025-01-01T00:00:00Z',
+        duration: '1h',
+      }),
+    ).toBeTrue();
+    expect(
+      isTimeRangePresetSelected(preset, {
+        endTime: '2025-01-01T00:00:01Z',
+        duration: '1h',
+      }),
+    ).toBeFalse();
+  });
+
+  it('returns false when the duration is different', () => {
+    expect(
+      isTimeRangePresetSelected(preset, { ...preset.value, duration: '2h' }),
+    ).toBeFalse();
+  });
+});
@@ -0,0 +1,168 @@
+/**
+ * Copyright 2025 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+import { CommonModule } from '@angular/common';
+import {
+  Component,
+  computed,
+  inject,
+  input,
+  OnDestroy,
+  OnInit,
+  signal,
+} from '@angular/core';
+import { MatButtonModule } from '@angular/material/button';
+import { MatFormFieldModule } from '@angular/material/form-field';
+import { MatInputModule } from '@angular/material/input';
+import { Subject, takeUntil } from 'rxjs';
+import {
+  TimeRangeParameterFormField,
+  TimeRangeParameterFormFieldPreset,
+  TimeRangeParameterValue,
+} from 'src/app/common/schema/form-types';
+import {
+  formatTimezoneOffset,
+  fromDateTimeLocalValue,
+  toDateTimeLocalValue,
+} from './datetime-parameter.component';
+import { ParameterHeaderComponent } from './parameter-header.component';
+import { ParameterHintComponent } from './parameter-hint.component';
+import { PARAMETER_STORE } from './service/parameter-store';
+
+/**
+ * Returns true when the value is same as the value of the preset.
+ * The end times are compared as time instants because they can be written with different offsets.
+ */
+export function isTimeRangePresetSelected(
+  preset: TimeRangeParameterFormFieldPreset,
+  value: TimeRangeParameterValue | null,
+): boolean {
+  if (value === null) {
+    return false;
+  }
+  return (
+    preset.value.duration === value.duration &&
+    Date.parse(preset.value.endTime) === Date.parse(value.endTime)
+  );
+}
+
+/**
+ * A form field for time range type parameter in the new-inspection dialog.
+ * The time range is given with the end time and the duration ending at it, or selected from the presets.
+ */
+@Component({
+  selector: 'khi-new-inspection-timerange-parameter',
+  templateUrl: './timerange-parameter.component.html',
+  styleUrls: ['./timerange-parameter.component.scss'],
+  imports: [
+    CommonModule,
+    ParameterHeaderComponent,
+    MatButtonModule,
+    MatFormFieldModule,
+    MatInputModule,
+    ParameterHintComponent,
+  ],
+})
+export class TimeRangeParameterComponent implements OnInit, OnDestroy {
+  /**
+   * The spec of this time range type parameter.
+   */
+  readonly parameter = input.required<TimeRangeParameterFormField>();
+
+  private readonly store = inject(PARAMETER_STORE);
+  private readonly destroyed = new Subject<void>();
+
+  /**
+   * The current value of the parameter. null until the value is loaded from the store.
+   */
+  readonly value = signal<TimeRangeParameterValue | null>(null);
+
+  /**
+   * The offset of the timezone shown next to the end time picker.
+   */
+  readonly timezoneOffset = computed(() =>
+    formatTimezoneOffset(this.parameter().timezoneShiftHours),
+  );
+
+  /**
+   * The current end time in the format of `datetime-local` input.
+   */
+  readonly endTimeLocalValue = computed(() =>
+    toDateTimeLocalValue(
+      this.value()?.endTime ?? '',
+      this.parameter().timezoneShiftHours,
+    ),
+  );
+
+  /**
+   * Exposes isTimeRangePresetSelected to the template.
+   */
+  readonly isPresetSelected = isTimeRangePresetSelected;
+
+  ngOnInit(): void {
+    this.store
+      .watch<TimeRangeParameterValue>(this.parameter().id)
+      .pipe(takeUntil(this.destroyed))
+      .subscribe((value) => {
+        this.value.set(value ?? null);
+      });
+  }
+
+  ngOnDestroy(): void {
+    this.destroyed.next();
+    this.destroyed.complete();
+  }
+
+  /**
+   * Handles change events from the end time picker.
+   */
+  onEndTimeChange(ev: Event): void {
+    const value = (ev.target as HTMLInputElement).value;
+    if (value === '') {
+      return;
+    }
+    this.update({
+      endTime: fromDateTimeLocalValue(
+        value,
+        this.parameter().timezoneShiftHours,
+      ),
+    });
+  }
+
+  /**
+   * Handles change events from the duration input.
+   */
+  onDurationChange(ev: Event): void {
+    this.update({ duration: (ev.target as HTMLInputElement).value });
+  }
+
+  /**
+   * Handles click events on the preset buttons.
+   */
+  onPresetClick(preset: TimeRangeParameterFormFieldPreset): void {
+    this.store.set(this.parameter().id, { ...preset.value });
+  }
+
+  /**
+   * Sets the value with one of the end time or the duration replaced.
+   */
+  private update(patch: Partial<TimeRangeParameterValue>): void {
+    this.store.set(this.parameter().id, {
+      ...(this.value() ?? this.parameter().default),
+      ...patch,
+    });
+  }
+}
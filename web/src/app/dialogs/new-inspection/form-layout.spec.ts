@@ -0,0 +1,73 @@
+/**
+ * Copyright 2025 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+import {
+  GroupParameterFormField,
+  ParameterFormField,
+  ParameterHintType,
+  ParameterInputType,
+  TextParameterFormField,
+} from 'src/app/common/schema/form-types';
+import { FORM_SECTION_GROUP_ID_PREFIX, layoutFormFields } from './form-layout';
+
+function textField(id: string): TextParameterFormField {
+  return {
+    id,
+    type: ParameterInputType.Text,
+    label: id,
+    description: '',
+    hint: '',
+    hintType: ParameterHintType.None,
+  } as TextParameterFormField;
+}
+
+describe('layoutFormFields', () => {
+  it('returns the fields as is without layout', () => {
+    const fields = [textField('foo'), textField('bar')];
+
+    expect(layoutFormFields(fields, undefined)).toEqual(fields);
+    expect(layoutFormFields(fields, [])).toEqual(fields);
+  });
+
+  it('wraps fields in labeled sections with groups', () => {
+    const fields = [textField('foo'), textField('bar'), textField('baz')];
+
+    const result = layoutFormFields(fields, [
+      { id: 'time', label: 'Query time range', fieldIds: ['bar', 'foo'] },
+      { id: '', label: '', fieldIds: ['baz'] },
+    ]);
+
+    expect(result.length).toBe(2);
+    const group = result[0] as GroupParameterFormField;
+    expect(group.type).toBe(ParameterInputType.Group);
+    expect(group.id).toBe(FORM_SECTION_GROUP_ID_PREFIX + 'time');
+    expect(group.label).toBe('Query time range');
+    expect(group.collapsible).toBeFalse();
+    expect(group.children.map((f) => f.id)).toEqual(['bar', 'foo']);
+    expect(result[1].id).toBe('baz');
+  });
+
+  it('appends fields missing in the layout and skips unknown field IDs', () => {
+    const fields: ParameterFormField[] = [textField('foo'), textField('bar')];
+
+    const result = layoutFormFields(fields, [
+      { id: '', label: '', fieldIds: ['unknown', 'bar'] },
+      { id: 'empty', label: 'Empty', fieldIds: ['unknown'] },
+    ]);
+
+    expect(result.map((f) => f.id)).toEqual(['bar', 'foo']);
+  });
+});
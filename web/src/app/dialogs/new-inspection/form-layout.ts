@@ -0,0 +1,76 @@
+/**
+ * Copyright 2025 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+import { FormLayoutSection } from 'src/app/common/schema/api-types';
+import {
+  ParameterFormField,
+  ParameterHintType,
+  ParameterInputType,
+} from 'src/app/common/schema/form-types';
+
+/**
+ * Prefix of the IDs of groups generated for labeled form sections.
+ */
+export const FORM_SECTION_GROUP_ID_PREFIX = 'section/';
+
+/**
+ * Arranges the top level form fields with the sections given from the backend.
+ * Fields in a labeled section are wrapped in a group titled with the section label,
+ * and fields in a section without label are placed directly.
+ * Fields not found in the layout are appended at the end in the given order.
+ */
+export function layoutFormFields(
+  fields: ParameterFormField[],
+  layout: FormLayoutSection[] | undefined,
+): ParameterFormField[] {
+  if (!layout || layout.length === 0) {
+    return fields;
+  }
+  const fieldsById = new Map(fields.map((field) => [field.id, field]));
+  const placedIds = new Set<string>();
+  const result: ParameterFormField[] = [];
+  for (const section of layout) {
+    const sectionFields: ParameterFormField[] = [];
+    for (const fieldId of section.fieldIds) {
+      const field = fieldsById.get(fieldId);
+      if (field === undefined || placedIds.has(fieldId)) {
+        continue;
+      }
+      placedIds.add(fieldId);
+      sectionFields.push(field);
+    }
+    if (sectionFields.length === 0) {
+      continue;
+    }
+    if (section.label === '') {
+      result.push(...sectionFields);
+      continue;
+    }
+    result.push({
+      id: FORM_SECTION_GROUP_ID_PREFIX + section.id,
+      type: ParameterInputType.Group,
+      label: section.label,
+      description: '',
+      hint: '',
+      hintType: ParameterHintType.None,
+      children: sectionFields,
+      collapsible: false,
+      collapsedByDefault: false,
+    });
+  }
+  result.push(...fields.filter((field) => !placedIds.has(field.id)));
+  return result;
+}
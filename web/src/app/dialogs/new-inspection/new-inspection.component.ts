@@ -65,6 +65,7 @@ import {
   ParameterInputType,
 } from 'src/app/common/schema/form-types';
 import { GroupParameterComponent } from './components/group-parameter.component';
+import { layoutFormFields } from './form-layout';
 import {
   InspectionMetadataPlan,
   InspectionMetadataQuery,
@@ -257,7 +258,7 @@ export class NewInspectionDialogComponent implements OnDestroy {
         return {
           rootGroupForm: {
             type: ParameterInputType.Group,
-            children: metadata.form,
+            children: layoutFormFields(metadata.form, metadata.formLayout),
           },
           queries: metadata.query,
           plan: metadata.plan,
@@ -309,6 +310,15 @@ export class NewInspectionDialogComponent implements OnDestroy {
         case ParameterInputType.Set:
           result[parameter.id] = parameter.default;
           break;
+        case ParameterInputType.Select:
+          result[parameter.id] = parameter.default;
+          break;
+        case ParameterInputType.DateTime:
+          result[parameter.id] = parameter.default;
+          break;
+        case ParameterInputType.TimeRange:
+          result[parameter.id] = parameter.default;
+          break;
         case ParameterInputType.Group:
           result = {
             ...result,
@@ -16,14 +16,19 @@
 
 import { Observable } from 'rxjs';
 import {
+  ApplyInspectionPresetResponse,
   GetConfigResponse,
   GetInspectionFeatureResponse,
+  GetInspectionPresetsResponse,
   GetInspectionResponse,
   GetInspectionTypesResponse,
+  ImportInspectionParametersResponse,
   InspectionDryRunRequest,
   InspectionDryRunResponse,
   InspectionMetadataOfRunResult,
+  InspectionParametersFormat,
   InspectionPatchRequest,
+  InspectionPreset,
   InspectionRunRequest,
   PopupAnswerResponse,
   PopupAnswerValidationResult,
@@ -141,6 +146,75 @@ export interface BackendAPI {
     request: InspectionDryRunRequest,
   ): Observable<InspectionDryRunResponse>;
 
+  /**
+   * Export the form parameter values resolved in the run as a file.
+   * Expected called endpoint: GET /api/v3/inspection/<inspection-id>/parameters
+   *
+   * @param inspectionID inspection ID to export the parameters
+   * @param format format of the exported file
+   */
+  exportInspectionParameters(
+    inspectionID: string,
+    format: InspectionParametersFormat,
+  ): Observable<Blob>;
+
+  /**
+   * Parse the exported parameter file to pre-fill the form of the inspection.
+   * Expected called endpoint: POST /api/v3/inspection/<inspection-id>/parameters/import
+   *
+   * @param inspectionID inspection ID to pre-fill the form
+   * @param content content of the exported parameter file in YAML or JSON
+   */
+  importInspectionParameters(
+    inspectionID: string,
+    content: string,
+  ): Observable<ImportInspectionParametersResponse>;
+
+  /**
+   * List the presets saved for the inspection type.
+   * Expected called endpoint: GET /api/v3/inspection/types/<inspection-type>/presets
+   *
+   * @param inspectionTypeId the type of inspection to list the presets
+   */
+  getPresets(
+    inspectionTypeId: string,
+  ): Observable<GetInspectionPresetsResponse>;
+
+  /**
+   * Save the form parameter values as a preset for the inspection type of the inspection.
+   * Expected called endpoint: PUT /api/v3/inspection/<inspection-id>/presets/<preset-name>
+   *
+   * @param inspectionID inspection ID whose inspection type the preset is saved for
+   * @param presetName name of the preset. The existing preset with the same name is overwritten.
+   * @param values form parameter values to save
+   */
+  savePreset(
+    inspectionID: string,
+    presetName: string,
+    values: InspectionDryRunRequest,
+  ): Observable<InspectionPreset>;
+
+  /**
+   * Get the form parameter values of the preset to apply them on the inspection.
+   * Expected called endpoint: POST /api/v3/inspection/<inspection-id>/presets/<preset-name>/apply
+   *
+   * @param inspectionID inspection ID to apply the preset
+   * @param presetName name of the preset
+   */
+  applyPreset(
+    inspectionID: string,
+    presetName: string,
+  ): Observable<ApplyInspectionPresetResponse>;
+
+  /**
+   * Delete the preset saved for the inspection type.
+   * Expected called endpoint: DELETE /api/v3/inspection/types/<inspection-type>/presets/<preset-name>
+   *
+   * @param inspectionTypeId the type of inspection the preset was saved for
+   * @param presetName name of the preset
+   */
+  deletePreset(inspectionTypeId: string, presetName: string): Observable<void>;
+
   /**
    * Download the inspection data with specified inspectionID in parallel.
    * Expected called endpoint:
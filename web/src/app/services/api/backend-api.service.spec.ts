@@ -18,7 +18,7 @@ import {
   HttpClientTestingModule,
   HttpTestingController,
 } from '@angular/common/http/testing';
-import { TestBed } from '@angular/core/testing';
+import { TestBed, fakeAsync, tick } from '@angular/core/testing';
 import { BackendAPIImpl, InspectionClient } from './backend-api.service';
 import { ViewStateService } from '../view-state.service';
 import {
@@ -37,6 +37,13 @@ import {
 } from '../../common/schema/api-types';
 import { BackendAPI } from './backend-api-interface';
 import { of } from 'rxjs';
+import {
+  ParameterFormField,
+  ParameterFormValidationTiming,
+  ParameterHintType,
+  ParameterInputType,
+  TextParameterFormField,
+} from '../../common/schema/form-types';
 
 describe('BackendAPIImpl testing', () => {
   let api: BackendAPIImpl;
@@ -256,6 +263,7 @@ describe('BackendAPIImpl testing', () => {
     const testResponse: InspectionDryRunResponse = {
       metadata: {
         form: [],
+        formLayout: [],
         query: [],
         plan: {
           taskGraph: '',
@@ -385,6 +393,7 @@ describe('InspectionTaskClient testing', () => {
         metadata: {
           query: [],
           form: [],
+          formLayout: [],
           plan: {
             taskGraph: 'test',
           },
@@ -455,6 +464,7 @@ describe('InspectionTaskClient testing', () => {
       metadata: {
         query: [],
         form: [],
+        formLayout: [],
         plan: {
           taskGraph: 'test',
         },
@@ -480,6 +490,7 @@ describe('InspectionTaskClient testing', () => {
       metadata: {
         query: [],
         form: [],
+        formLayout: [],
         plan: {
           taskGraph: 'test',
         },
@@ -491,4 +502,115 @@ describe('InspectionTaskClient testing', () => {
     });
     taskClient.dryrun({ test: 'foo' });
   });
+
+  describe('polling while suggestions are loading', () => {
+    const textField = (suggestionsLoading: boolean) =>
+      ({
+        id: 'text',
+        type: ParameterInputType.Text,
+        label: 'text',
+        description: '',
+        hintType: ParameterHintType.None,
+        hint: '',
+        readonly: false,
+        readonlyReason: '',
+        readonlySource: '',
+        default: '',
+        suggestions: [],
+        suggestionItems: null,
+        suggestionsLoading,
+        validationTiming: ParameterFormValidationTiming.Change,
+        pattern: '',
+        patternErrorMessage: '',
+        maxLength: 0,
+        placeholder: '',
+      }) as TextParameterFormField;
+    const responseWithForm = (
+      form: ParameterFormField[],
+    ): InspectionDryRunResponse => ({
+      metadata: {
+        query: [],
+        form,
+        formLayout: [],
+        plan: {
+          taskGraph: 'test',
+        },
+      },
+    });
+
+    it('polls dryrun until suggestions are loaded', fakeAsync(() => {
+      backendAPISpy.dryRunInspection.and.returnValues(
+        of(responseWithForm([textField(true)])),
+        of(responseWithForm([textField(true)])),
+        of(responseWithForm([textField(false)])),
+      );
+      const responses: InspectionDryRunResponse[] = [];
+      const subscription = taskClient.dryRunResult.subscribe((response) =>
+        responses.push(response),
+      );
+
+      taskClient.dryrun({ test: 'foo' });
+      tick(100);
+      expect(backendAPISpy.dryRunInspection).toHaveBeenCalledTimes(1);
+
+      tick(1200);
+      expect(backendAPISpy.dryRunInspection).toHaveBeenCalledTimes(2);
+
+      tick(1200);
+      expect(backendAPISpy.dryRunInspection).toHaveBeenCalledTimes(3);
+
+      tick(5000);
+      expect(backendAPISpy.dryRunInspection).toHaveBeenCalledTimes(3);
+      expect(responses.length).toBe(3);
+      expect(responses[2]).toEqual(responseWithForm([textField(false)]));
+      subscription.unsubscribe();
+    }));
+
+    it('finds loading suggestions in group children', fakeAsync(() => {
+      backendAPISpy.dryRunInspection.and.returnValues(
+        of(
+          responseWithForm([
+            {
+              id: 'group',
+              type: ParameterInputType.Group,
+              label: 'group',
+              description: '',
+              hintType: ParameterHintType.None,
+              hint: '',
+              children: [textField(true)],
+              collapsible: false,
+              collapsedByDefault: false,
+            } as ParameterFormField,
+          ]),
+        ),
+        of(responseWithForm([])),
+      );
+      const subscription = taskClient.dryRunResult.subscribe();
+
+      taskClient.dryrun({ test: 'foo' });
+      tick(1300);
+      expect(backendAPISpy.dryRunInspection).toHaveBeenCalledTimes(2);
+      subscription.unsubscribe();
+    }));
+
+    it('stops polling for a replaced parameter', fakeAsync(() => {
+      backendAPISpy.dryRunInspection.and.returnValues(
+        of(responseWithForm([textField(true)])),
+        of(responseWithForm([textField(false)])),
+      );
+      const subscription = taskClient.dryRunResult.subscribe();
+
+      taskClient.dryrun({ test: 'foo' });
+      tick(100);
+      taskClient.dryrun({ test: 'bar' });
+      tick(5000);
+
+      const calls = backendAPISpy.dryRunInspection.calls;
+      expect(calls.count()).toBe(2);
+      expect(calls.mostRecent().args[1]).toEqual(
+        jasmine.objectContaining({ test: 'bar' }),
+      );
+      subscription.unsubscribe();
+    }));
+  });
 });
@@ -31,6 +31,12 @@ import {
   InspectionMetadataOfRunResult,
   GetConfigResponse,
   InspectionPatchRequest,
+  GetInspectionPresetsResponse,
+  InspectionPreset,
+  PutInspectionPresetRequest,
+  ApplyInspectionPresetResponse,
+  InspectionParametersFormat,
+  ImportInspectionParametersResponse,
 } from '../../common/schema/api-types';
 import { HttpClient, HttpEvent } from '@angular/common/http';
 import {
@@ -50,13 +56,19 @@ import {
   retry,
   shareReplay,
   switchMap,
+  tap,
+  timer,
   withLatestFrom,
 } from 'rxjs';
 import { ViewStateService } from '../view-state.service';
 import { BackendAPI, DownloadProgressReporter } from './backend-api-interface';
 import { ProgressDialogStatusUpdator } from '../progress/progress-interface';
 import { ProgressUtil } from '../progress/progress-util';
-import { UploadToken } from 'src/app/common/schema/form-types';
+import {
+  ParameterFormField,
+  ParameterInputType,
+  UploadToken,
+} from 'src/app/common/schema/form-types';
 
 /**
  * An implementation of BackendAPI interface.
@@ -188,6 +200,55 @@ export class BackendAPIImpl implements BackendAPI {
     return this.http.post<InspectionDryRunResponse>(url, request);
   }
 
+  public exportInspectionParameters(
+    inspectionID: string,
+    format: InspectionParametersFormat,
+  ) {
+    const url =
+      this.baseUrl + `/inspection/${inspectionID}/parameters?format=${format}`;
+    return this.http.get(url, { responseType: 'blob' });
+  }
+
+  public importInspectionParameters(inspectionID: string, content: string) {
+    const url = this.baseUrl + `/inspection/${inspectionID}/parameters/import`;
+    return this.http.post<ImportInspectionParametersResponse>(url, content, {
+      headers: { 'Content-Type': 'text/plain' },
+    });
+  }
+
+  public getPresets(inspectionTypeId: string) {
+    const url = this.baseUrl + `/inspection/types/${inspectionTypeId}/presets`;
+    return this.http.get<GetInspectionPresetsResponse>(url);
+  }
+
+  public savePreset(
+    inspectionID: string,
+    presetName: string,
+    values: InspectionDryRunRequest,
+  ) {
+    const url =
+      this.baseUrl +
+      `/inspection/${inspectionID}/presets/${encodeURIComponent(presetName)}`;
+    const request: PutInspectionPresetRequest = { values };
+    return this.http.put<InspectionPreset>(url, request);
+  }
+
+  public applyPreset(inspectionID: string, presetName: string) {
+    const url =
+      this.baseUrl +
+      `/inspection/${inspectionID}/presets/${encodeURIComponent(presetName)}/apply`;
+    return this.http.post<ApplyInspectionPresetResponse>(url, null);
+  }
+
+  public deletePreset(inspectionTypeId: string, presetName: string) {
+    const url =
+      this.baseUrl +
+      `/inspection/types/${inspectionTypeId}/presets/${encodeURIComponent(presetName)}`;
+    return this.http
+      .delete(url, { responseType: 'text' })
+      .pipe(map(() => void 0));
+  }
+
   public getInspectionData(
     inspectionID: string,
     reporter: DownloadProgressReporter,
@@ -287,10 +348,20 @@ export class BackendAPIImpl implements BackendAPI {
 export class InspectionClient {
   private static DRYRUN_DEBOUNCE_DURATION = 100;
 
+  /**
+   * The delay before calling the dryrun API again while any field reports its suggestions are still being fetched on the backend.
+   */
+  private static SUGGESTIONS_LOADING_POLL_INTERVAL = 1000;
+
   public features = new ReplaySubject<InspectionFeature[]>(1);
 
   private dryRunParameter = new Subject<InspectionDryRunRequest>();
 
+  /**
+   * The last parameter given to the dryrun method. Polling for loading suggestions stops when the parameter is changed.
+   */
+  private latestDryRunParameter: InspectionDryRunRequest | null = null;
+
   private nonFormParameters = concat(this.viewState.timezoneShift).pipe(
     map((tzShift) => ({
       timezoneShift: tzShift,
@@ -301,7 +372,10 @@ export class InspectionClient {
   public dryRunResult = this.dryRunParameter.pipe(
     debounceTime(InspectionClient.DRYRUN_DEBOUNCE_DURATION),
     exhaustMap((param) =>
-      this.dryrunDirect(param).pipe(catchError(() => EMPTY)),
+      this.dryrunDirect(param).pipe(
+        tap((response) => this.pollWhileSuggestionsLoading(param, response)),
+        catchError(() => EMPTY),
+      ),
     ), // This must be exhaustMap not to cancel a request sent before in slow network environment with switchMap.
     shareReplay(1),
   );
@@ -343,9 +417,28 @@ export class InspectionClient {
   }
 
   public dryrun(request: InspectionDryRunRequest) {
+    this.latestDryRunParameter = request;
     this.dryRunParameter.next(request);
   }
 
+  /**
+   * Calls the dryrun API again with the same parameter after a delay when any field in the response is still loading its suggestions.
+   * The dryrun API is otherwise called only when a parameter is changed, thus the suggestions fetched later would never be shown.
+   */
+  private pollWhileSuggestionsLoading(
+    request: InspectionDryRunRequest,
+    response: InspectionDryRunResponse,
+  ) {
+    if (!hasLoadingSuggestions(response.metadata.form)) {
+      return;
+    }
+    timer(InspectionClient.SUGGESTIONS_LOADING_POLL_INTERVAL).subscribe(() => {
+      if (this.latestDryRunParameter === request) {
+        this.dryRunParameter.next(request);
+      }
+    });
+  }
+
   /**
    * dryrunDirect calls the dryrun API directly without debouncing.
    * This method is public for testing purpose. Use dryrun method instead.
@@ -407,3 +500,19 @@ export class BackendAPIUtil {
       );
   }
 }
+
+/**
+ * Returns true when any of the given fields or their children is still loading its suggestions.
+ */
+function hasLoadingSuggestions(fields: ParameterFormField[]): boolean {
+  return fields.some((field) => {
+    switch (field.type) {
+      case ParameterInputType.Text:
+        return field.suggestionsLoading;
+      case ParameterInputType.Group:
+        return hasLoadingSuggestions(field.children);
+      default:
+        return false;
+    }
+  });
+}